@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+// operation describes one API call in both transports
+type operation struct {
+	// method and path address the HTTP API, relative to /api/v1
+	method string
+	path   string
+	// subject addresses the NATS API, relative to the subject prefix; empty when
+	// the operation is only available over HTTP
+	subject string
+}
+
+// client calls the notification service API and returns the response data
+type client interface {
+	call(ctx context.Context, op operation, payload interface{}) (json.RawMessage, error)
+	close()
+}
+
+// httpClient calls the HTTP API
+type httpClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func newHTTPClient(baseURL, apiKey string, timeout time.Duration) *httpClient {
+	return &httpClient{
+		baseURL: strings.TrimRight(baseURL, "/") + "/api/v1",
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *httpClient) call(ctx context.Context, op operation, payload interface{}) (json.RawMessage, error) {
+	target := c.baseURL + op.path
+
+	var body io.Reader
+	if op.method == http.MethodGet {
+		query, err := queryValues(payload)
+		if err != nil {
+			return nil, err
+		}
+		if encoded := query.Encode(); encoded != "" {
+			target += "?" + encoded
+		}
+	} else if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, op.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		var problem struct {
+			Code   string `json:"code"`
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		}
+		if json.Unmarshal(data, &problem) == nil && (problem.Detail != "" || problem.Title != "") {
+			return nil, fmt.Errorf("%s %s: %s (%s)", op.method, op.path, firstNonEmpty(problem.Detail, problem.Title), problem.Code)
+		}
+		return nil, fmt.Errorf("%s %s: %s", op.method, op.path, resp.Status)
+	}
+	return data, nil
+}
+
+func (c *httpClient) close() {}
+
+// queryValues encodes a flat payload map as query parameters
+func queryValues(payload interface{}) (url.Values, error) {
+	values := url.Values{}
+	if payload == nil {
+		return values, nil
+	}
+
+	fields, ok := payload.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unsupported query payload %T", payload)
+	}
+	for key, value := range fields {
+		switch v := value.(type) {
+		case []string:
+			for _, item := range v {
+				values.Add(key, item)
+			}
+		default:
+			values.Set(key, fmt.Sprint(v))
+		}
+	}
+	return values, nil
+}
+
+// natsClient calls the NATS request/reply API
+type natsClient struct {
+	conn          *nats.Conn
+	subjectPrefix string
+	apiKey        string
+	timeout       time.Duration
+}
+
+func newNATSClient(natsURL, subjectPrefix, apiKey string, timeout time.Duration) (*natsClient, error) {
+	conn, err := nats.Connect(natsURL, nats.Name("dntf-cli"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	return &natsClient{
+		conn:          conn,
+		subjectPrefix: subjectPrefix,
+		apiKey:        apiKey,
+		timeout:       timeout,
+	}, nil
+}
+
+func (c *natsClient) call(ctx context.Context, op operation, payload interface{}) (json.RawMessage, error) {
+	if op.subject == "" {
+		return nil, fmt.Errorf("%s %s is only available over HTTP, use --transport=http", op.method, op.path)
+	}
+
+	request := struct {
+		ReqSeqId  string      `json:"reqSeqId"`
+		Data      interface{} `json:"data"`
+		Timestamp int64       `json:"timestamp"`
+		Timeout   int64       `json:"timeout"`
+	}{
+		ReqSeqId:  uuid.NewString(),
+		Data:      payload,
+		Timestamp: time.Now().UnixMilli(),
+		Timeout:   c.timeout.Milliseconds(),
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	msg := nats.NewMsg(c.subjectPrefix + "." + op.subject)
+	msg.Data = data
+	if c.apiKey != "" {
+		msg.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	reply, err := c.conn.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", msg.Subject, err)
+	}
+
+	var response struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+		Error   *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Details string `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(reply.Data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !response.Success {
+		if response.Error == nil {
+			return nil, fmt.Errorf("%s: request failed", msg.Subject)
+		}
+		return nil, fmt.Errorf("%s: %s (%s)", msg.Subject, firstNonEmpty(response.Error.Details, response.Error.Message), response.Error.Code)
+	}
+	return response.Data, nil
+}
+
+func (c *natsClient) close() {
+	c.conn.Close()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+// Command dntf is a command line client for the notification service. It
+// talks to the HTTP or NATS API to manage channels and templates, send test
+// messages, follow their status and load plugins.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// options holds the global flags
+type options struct {
+	transport     string
+	server        string
+	natsURL       string
+	subjectPrefix string
+	apiKey        string
+	timeout       time.Duration
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+
+	root := &cobra.Command{
+		Use:           "dntf",
+		Short:         "Command line client for the notification service",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.transport, "transport", envOrDefault("DNTF_TRANSPORT", "http"), "API to talk to: http or nats")
+	flags.StringVar(&opts.server, "server", envOrDefault("DNTF_SERVER", "http://localhost:8080"), "HTTP API base URL")
+	flags.StringVar(&opts.natsURL, "nats-url", envOrDefault("DNTF_NATS_URL", "nats://localhost:4222"), "NATS server URL")
+	flags.StringVar(&opts.subjectPrefix, "subject-prefix", envOrDefault("DNTF_SUBJECT_PREFIX", "eco1j.infra.eventcenter"), "NATS subject prefix")
+	flags.StringVar(&opts.apiKey, "api-key", os.Getenv("DNTF_API_KEY"), "API key sent with every request")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "timeout of one request")
+
+	root.AddCommand(
+		newChannelsCommand(opts),
+		newTemplatesCommand(opts),
+		newMessagesCommand(opts),
+		newPluginsCommand(opts),
+	)
+	return root
+}
+
+// connect creates the client for the selected transport
+func (o *options) connect() (client, error) {
+	switch o.transport {
+	case "http":
+		return newHTTPClient(o.server, o.apiKey, o.timeout), nil
+	case "nats":
+		return newNATSClient(o.natsURL, o.subjectPrefix, o.apiKey, o.timeout)
+	default:
+		return nil, fmt.Errorf("unknown transport %q, use http or nats", o.transport)
+	}
+}
+
+// run connects, calls one operation and prints its response
+func (o *options) run(cmd *cobra.Command, op operation, payload interface{}) error {
+	c, err := o.connect()
+	if err != nil {
+		return err
+	}
+	defer c.close()
+
+	data, err := c.call(cmd.Context(), op, payload)
+	if err != nil {
+		return err
+	}
+	return printJSON(cmd.OutOrStdout(), data)
+}
+
+// readPayload reads a JSON request body from a file, or from stdin when path is "-"
+func readPayload(cmd *cobra.Command, path string) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse request %s: %w", path, err)
+	}
+	return payload, nil
+}
+
+// printJSON prints a JSON document indented
+func printJSON(w io.Writer, data json.RawMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	_, err := fmt.Fprintln(w, out.String())
+	return err
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// listFlags holds the paging and filter flags of the list commands
+type listFlags struct {
+	skip        int
+	max         int
+	channelType string
+	tags        []string
+}
+
+func (f *listFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.skip, "skip", 0, "number of items to skip")
+	cmd.Flags().IntVar(&f.max, "max", 20, "maximum number of items to return")
+	cmd.Flags().StringVar(&f.channelType, "type", "", "filter by channel type")
+	cmd.Flags().StringSliceVar(&f.tags, "tag", nil, "filter by tag, repeatable")
+}
+
+func (f *listFlags) payload() map[string]interface{} {
+	payload := map[string]interface{}{
+		"skipCount":      f.skip,
+		"maxResultCount": f.max,
+	}
+	if f.channelType != "" {
+		payload["channelType"] = f.channelType
+	}
+	if len(f.tags) > 0 {
+		payload["tags"] = f.tags
+	}
+	return payload
+}
+
+func newChannelsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{Use: "channels", Short: "Manage channels"}
+
+	var file string
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a channel from a JSON request",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			payload, err := readPayload(cmd, file)
+			if err != nil {
+				return err
+			}
+			return opts.run(cmd, operation{method: http.MethodPost, path: "/channels", subject: "channel.create"}, payload)
+		},
+	}
+	create.Flags().StringVarP(&file, "file", "f", "-", "JSON request file, - for stdin")
+
+	var list listFlags
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List channels",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, operation{method: http.MethodGet, path: "/channels", subject: "channel.list"}, list.payload())
+		},
+	}
+	list.register(listCmd)
+
+	get := &cobra.Command{
+		Use:   "get <channel-id>",
+		Short: "Show a channel",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, operation{method: http.MethodGet, path: "/channels/" + args[0], subject: "channel.get"},
+				map[string]interface{}{"channelId": args[0]})
+		},
+	}
+
+	cmd.AddCommand(create, listCmd, get)
+	return cmd
+}
+
+func newTemplatesCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{Use: "templates", Short: "Manage templates"}
+
+	var file string
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a template from a JSON request",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			payload, err := readPayload(cmd, file)
+			if err != nil {
+				return err
+			}
+			return opts.run(cmd, operation{method: http.MethodPost, path: "/templates", subject: "template.create"}, payload)
+		},
+	}
+	create.Flags().StringVarP(&file, "file", "f", "-", "JSON request file, - for stdin")
+
+	var list listFlags
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, operation{method: http.MethodGet, path: "/templates", subject: "template.list"}, list.payload())
+		},
+	}
+	list.register(listCmd)
+
+	get := &cobra.Command{
+		Use:   "get <template-id>",
+		Short: "Show a template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, operation{method: http.MethodGet, path: "/templates/" + args[0], subject: "template.get"},
+				map[string]interface{}{"templateId": args[0]})
+		},
+	}
+
+	cmd.AddCommand(create, listCmd, get)
+	return cmd
+}
+
+func newMessagesCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{Use: "messages", Short: "Send messages and follow their status"}
+
+	var (
+		file          string
+		channelIDs    []string
+		channelTags   []string
+		channelGroups []string
+		templateID    string
+		recipients    []string
+		variables     map[string]string
+		correlationID string
+		tenantID      string
+		strict        bool
+	)
+	send := &cobra.Command{
+		Use:   "send",
+		Short: "Send a message, from flags or from a JSON request",
+		Example: `  dntf messages send --channel ch-1 --template tpl-1 --to ops@example.com --var name=World
+  dntf messages send --channel-tag prod --channel-tag oncall --template tpl-1 --to ops@example.com
+  dntf messages send --channel-group group-1 --template tpl-1 --to ops@example.com
+  dntf messages send -f message.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			payload := map[string]interface{}{}
+			if file != "" {
+				var err error
+				if payload, err = readPayload(cmd, file); err != nil {
+					return err
+				}
+			}
+			if len(channelIDs) > 0 {
+				payload["channelIds"] = channelIDs
+			}
+			if len(channelTags) > 0 {
+				payload["channelTags"] = channelTags
+			}
+			if len(channelGroups) > 0 {
+				payload["channelGroupIds"] = channelGroups
+			}
+			if templateID != "" {
+				payload["templateId"] = templateID
+			}
+			if len(recipients) > 0 {
+				list := make([]map[string]interface{}, len(recipients))
+				for i, recipient := range recipients {
+					list[i] = map[string]interface{}{"target": recipient}
+				}
+				payload["recipients"] = list
+			}
+			if len(variables) > 0 {
+				payload["variables"] = variables
+			}
+			if correlationID != "" {
+				payload["correlationId"] = correlationID
+			}
+			if tenantID != "" {
+				payload["tenantId"] = tenantID
+			}
+			if strict {
+				payload["strict"] = true
+			}
+			return opts.run(cmd, operation{method: http.MethodPost, path: "/messages", subject: "message.send"}, payload)
+		},
+	}
+	send.Flags().StringVarP(&file, "file", "f", "", "JSON request file, - for stdin; flags override its fields")
+	send.Flags().StringSliceVar(&channelIDs, "channel", nil, "channel ID, repeatable")
+	send.Flags().StringSliceVar(&channelTags, "channel-tag", nil, "send to the enabled channels carrying every given tag, repeatable")
+	send.Flags().StringSliceVar(&channelGroups, "channel-group", nil, "send to the enabled channels of the channel group, repeatable")
+	send.Flags().StringVar(&templateID, "template", "", "template ID")
+	send.Flags().StringSliceVar(&recipients, "to", nil, "recipient target, repeatable")
+	send.Flags().StringToStringVar(&variables, "var", nil, "template variable as key=value, repeatable")
+	send.Flags().StringVar(&correlationID, "correlation-id", "", "ID tying the message to a business transaction")
+	send.Flags().StringVar(&tenantID, "tenant", "", "tenant whose send quota the message counts against")
+	send.Flags().BoolVar(&strict, "strict", false, "fail rendering when a template variable has no value")
+
+	get := &cobra.Command{
+		Use:   "get <message-id>",
+		Short: "Show a message",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, getMessageOperation(args[0]), map[string]interface{}{"messageId": args[0]})
+		},
+	}
+
+	var channelID, status string
+	var list listFlags
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List messages",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			payload := map[string]interface{}{"skipCount": list.skip, "maxResultCount": list.max}
+			if channelID != "" {
+				payload["channelId"] = channelID
+			}
+			if status != "" {
+				payload["status"] = status
+			}
+			return opts.run(cmd, operation{method: http.MethodGet, path: "/messages", subject: "message.list"}, payload)
+		},
+	}
+	listCmd.Flags().IntVar(&list.skip, "skip", 0, "number of items to skip")
+	listCmd.Flags().IntVar(&list.max, "max", 20, "maximum number of items to return")
+	listCmd.Flags().StringVar(&channelID, "channel", "", "filter by channel ID")
+	listCmd.Flags().StringVar(&status, "status", "", "filter by status")
+
+	var interval time.Duration
+	tail := &cobra.Command{
+		Use:   "tail <message-id>",
+		Short: "Follow the status of a message until it is no longer pending",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tailMessage(cmd, opts, args[0], interval)
+		},
+	}
+	tail.Flags().DurationVar(&interval, "interval", 2*time.Second, "polling interval")
+
+	cmd.AddCommand(send, get, listCmd, tail)
+	return cmd
+}
+
+func getMessageOperation(messageID string) operation {
+	return operation{method: http.MethodGet, path: "/messages/" + messageID, subject: "message.get"}
+}
+
+// tailMessage polls a message and prints every status change until the
+// message reaches a final status or the command is interrupted
+func tailMessage(cmd *cobra.Command, opts *options, messageID string, interval time.Duration) error {
+	c, err := opts.connect()
+	if err != nil {
+		return err
+	}
+	defer c.close()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastStatus := ""
+	for {
+		data, err := c.call(cmd.Context(), getMessageOperation(messageID), map[string]interface{}{"messageId": messageID})
+		if err != nil {
+			return err
+		}
+
+		var message struct {
+			Status  string `json:"status"`
+			Results []struct {
+				ChannelID  string `json:"channelId"`
+				Status     string `json:"status"`
+				Error      string `json:"error"`
+				Recipients []struct {
+					Target            string `json:"target"`
+					Status            string `json:"status"`
+					Error             string `json:"error"`
+					SuppressionReason string `json:"suppressionReason"`
+				} `json:"recipients"`
+			} `json:"results"`
+		}
+		if err := json.Unmarshal(data, &message); err != nil {
+			return fmt.Errorf("failed to decode message: %w", err)
+		}
+
+		if message.Status != lastStatus {
+			lastStatus = message.Status
+			fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", time.Now().Format(time.RFC3339), message.Status)
+			for _, result := range message.Results {
+				fmt.Fprintf(cmd.OutOrStdout(), "    %s  %s  %s\n", result.ChannelID, result.Status, result.Error)
+				for _, recipient := range result.Recipients {
+					detail := recipient.Error
+					if recipient.SuppressionReason != "" {
+						detail = recipient.SuppressionReason
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "        %s  %s  %s\n", recipient.Target, recipient.Status, detail)
+				}
+			}
+		}
+		// Held messages are still sent once their channels leave maintenance
+		if message.Status != "" && message.Status != "pending" && message.Status != "held" {
+			return nil
+		}
+
+		select {
+		case <-cmd.Context().Done():
+			return cmd.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func newPluginsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{Use: "plugins", Short: "Manage plugins (HTTP only)"}
+
+	var name string
+	load := &cobra.Command{
+		Use:   "load <source-file>",
+		Short: "Load a plugin from a Go source file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read plugin source: %w", err)
+			}
+			if name == "" {
+				name = strings.TrimSuffix(args[0][strings.LastIndex(args[0], "/")+1:], ".go")
+			}
+			return opts.run(cmd, operation{method: http.MethodPost, path: "/plugins/load"},
+				map[string]interface{}{"name": name, "source": string(source)})
+		},
+	}
+	load.Flags().StringVar(&name, "name", "", "plugin name, defaults to the file name")
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List loaded plugins",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, operation{method: http.MethodGet, path: "/plugins"}, nil)
+		},
+	}
+
+	cmd.AddCommand(load, list)
+	return cmd
+}
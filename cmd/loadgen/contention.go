@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// contention summarizes the database activity sampled during a run
+type contention struct {
+	Samples int
+	// LockWaiters are the sessions waiting for a lock
+	MaxLockWaiters int
+	AvgLockWaiters float64
+	// Active are the sessions running a query
+	MaxActive int
+	AvgActive float64
+	// Deadlocks were detected during the run
+	Deadlocks int64
+}
+
+// contentionSampler samples the sessions of the service database in
+// pg_stat_activity and its deadlocks in pg_stat_database
+type contentionSampler struct {
+	db *sql.DB
+
+	deadlocks int64
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	summary   contention
+}
+
+// newContentionSampler connects to the database
+func newContentionSampler(dsn string) (*contentionSampler, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return &contentionSampler{db: db}, nil
+}
+
+// start samples the activity every interval until stop is called
+func (s *contentionSampler) start(ctx context.Context, interval time.Duration) error {
+	deadlocks, err := s.countDeadlocks(ctx)
+	if err != nil {
+		return err
+	}
+	s.deadlocks = deadlocks
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.sample(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// stop ends the sampling and returns the summary
+func (s *contentionSampler) stop() contention {
+	s.cancel()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	summary := s.summary
+	if summary.Samples > 0 {
+		summary.AvgLockWaiters /= float64(summary.Samples)
+		summary.AvgActive /= float64(summary.Samples)
+	}
+	if deadlocks, err := s.countDeadlocks(context.Background()); err == nil {
+		summary.Deadlocks = deadlocks - s.deadlocks
+	}
+	return summary
+}
+
+// close disconnects from the database
+func (s *contentionSampler) close() {
+	s.db.Close()
+}
+
+// sample records the sessions waiting for a lock and the active sessions.
+// Failed samples are skipped.
+func (s *contentionSampler) sample(ctx context.Context) {
+	var lockWaiters, active int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT count(*) FILTER (WHERE wait_event_type = 'Lock'),
+		       count(*) FILTER (WHERE state = 'active')
+		FROM pg_stat_activity
+		WHERE datname = current_database() AND pid <> pg_backend_pid()`).Scan(&lockWaiters, &active)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary.Samples++
+	s.summary.AvgLockWaiters += float64(lockWaiters)
+	s.summary.AvgActive += float64(active)
+	if lockWaiters > s.summary.MaxLockWaiters {
+		s.summary.MaxLockWaiters = lockWaiters
+	}
+	if active > s.summary.MaxActive {
+		s.summary.MaxActive = active
+	}
+}
+
+// countDeadlocks returns the deadlocks detected in the database since the statistics were reset
+func (s *contentionSampler) countDeadlocks(ctx context.Context) (int64, error) {
+	var deadlocks int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT deadlocks FROM pg_stat_database WHERE datname = current_database()`).Scan(&deadlocks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read database statistics: %w", err)
+	}
+	return deadlocks, nil
+}
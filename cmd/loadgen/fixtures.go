@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"notification/pkg/client"
+)
+
+// fixtures are the template and the channels the load is sent through
+type fixtures struct {
+	templateID string
+	channelIDs []string
+}
+
+// createFixtures creates a Slack template and channels whose webhook is the
+// mock provider. Channels are not retried, so that every provider failure
+// is a failed send.
+func createFixtures(ctx context.Context, c *client.Client, webhookURL string, channels, recipients int) (*fixtures, error) {
+	name := fmt.Sprintf("loadgen-%d", time.Now().Unix())
+
+	tmpl, err := c.CreateTemplate(ctx, &client.CreateTemplateRequest{
+		Name:        name,
+		ChannelType: "slack",
+		Subject:     "Load test {run}",
+		Content:     "Message {sequence} of load test {run}",
+		Tags:        []string{"loadgen"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+	f := &fixtures{templateID: tmpl.ID}
+
+	for i := 0; i < channels; i++ {
+		targets := make([]client.Recipient, 0, recipients)
+		for j := 0; j < recipients; j++ {
+			targets = append(targets, client.Recipient{
+				Name:   fmt.Sprintf("loadgen-%d", j),
+				Target: fmt.Sprintf("#loadgen-%d", j),
+				Type:   "channel",
+			})
+		}
+
+		ch, err := c.CreateChannel(ctx, &client.CreateChannelRequest{
+			ChannelName:    fmt.Sprintf("%s-%d", name, i),
+			Description:    "Created by loadgen",
+			Enabled:        true,
+			ChannelType:    "slack",
+			TemplateID:     tmpl.ID,
+			CommonSettings: client.CommonSettings{Timeout: 30000},
+			Config: map[string]interface{}{
+				"webhook_url": webhookURL,
+				"token":       "xoxb-loadgen",
+				"workspace":   "loadgen",
+			},
+			Recipients: targets,
+			Tags:       []string{"loadgen"},
+		})
+		if err != nil {
+			f.delete(c)
+			return nil, fmt.Errorf("failed to create channel: %w", err)
+		}
+		f.channelIDs = append(f.channelIDs, ch.ChannelID)
+	}
+
+	return f, nil
+}
+
+// request returns the send request of the load, without its correlation ID
+func (f *fixtures) request() *client.SendMessageRequest {
+	return &client.SendMessageRequest{
+		ChannelIDs: f.channelIDs,
+		TemplateID: f.templateID,
+		Recipients: []client.Recipient{{Name: "loadgen", Target: "#loadgen", Type: "channel"}},
+		Variables:  map[string]interface{}{"run": time.Now().Format(time.RFC3339)},
+	}
+}
+
+// delete removes the channels and the template, reporting failures without stopping
+func (f *fixtures) delete(c *client.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, channelID := range f.channelIDs {
+		if err := c.DeleteChannel(ctx, channelID); err != nil {
+			fmt.Fprintf(os.Stderr, "loadgen: failed to delete channel %s: %v\n", channelID, err)
+		}
+	}
+	if err := c.DeleteTemplate(ctx, f.templateID, &client.DeleteTemplateOptions{Force: true}); err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: failed to delete template %s: %v\n", f.templateID, err)
+	}
+}
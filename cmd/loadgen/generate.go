@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"notification/pkg/client"
+)
+
+// result is the outcome of a load run
+type result struct {
+	elapsed time.Duration
+	// sent counts the requests, succeeded the ones the API accepted
+	sent      int
+	succeeded int
+	// errors counts the failed requests by error code
+	errors map[string]int
+	// statuses counts the accepted messages by status
+	statuses map[client.MessageStatus]int
+	// skipped counts the sends due while every sender was busy
+	skipped   int
+	latencies []time.Duration
+}
+
+// merge adds the outcome of one sender
+func (r *result) merge(other *result) {
+	r.sent += other.sent
+	r.succeeded += other.succeeded
+	for code, count := range other.errors {
+		r.errors[code] += count
+	}
+	for status, count := range other.statuses {
+		r.statuses[status] += count
+	}
+	r.latencies = append(r.latencies, other.latencies...)
+}
+
+// newResult creates an empty result
+func newResult() *result {
+	return &result{
+		errors:   make(map[string]int),
+		statuses: make(map[client.MessageStatus]int),
+	}
+}
+
+// generate sends the request from concurrency senders at the rate for the
+// duration and waits for the sends in flight. A rate of 0 sends as fast as
+// the senders allow; sends due while every sender is busy are skipped, so
+// that a slow service shows as a lower throughput instead of a growing queue.
+func generate(ctx context.Context, c *client.Client, request *client.SendMessageRequest, rate float64, duration time.Duration, concurrency int) *result {
+	jobs := make(chan int, concurrency)
+	total := newResult()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			own := newResult()
+			for sequence := range jobs {
+				send(ctx, c, request, sequence, own)
+			}
+			mu.Lock()
+			total.merge(own)
+			mu.Unlock()
+		}()
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	total.skipped = schedule(sendCtx, jobs, rate)
+	close(jobs)
+	wg.Wait()
+
+	total.elapsed = time.Since(start)
+	return total
+}
+
+// schedule queues sequence numbers at the rate until the context is done and
+// returns the number of sends skipped because the queue was full
+func schedule(ctx context.Context, jobs chan<- int, rate float64) int {
+	sequence, skipped := 0, 0
+
+	if rate <= 0 {
+		for {
+			select {
+			case jobs <- sequence:
+				sequence++
+			case <-ctx.Done():
+				return skipped
+			}
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			select {
+			case jobs <- sequence:
+				sequence++
+			default:
+				skipped++
+			}
+		case <-ctx.Done():
+			return skipped
+		}
+	}
+}
+
+// send sends one message and records its outcome
+func send(ctx context.Context, c *client.Client, request *client.SendMessageRequest, sequence int, r *result) {
+	req := *request
+	req.CorrelationID = fmt.Sprintf("loadgen-%d", sequence)
+	req.Variables = make(map[string]interface{}, len(request.Variables)+1)
+	for key, value := range request.Variables {
+		req.Variables[key] = value
+	}
+	req.Variables["sequence"] = sequence
+
+	start := time.Now()
+	msg, err := c.SendMessage(ctx, &req)
+	latency := time.Since(start)
+
+	r.sent++
+	if err != nil {
+		r.errors[errorCode(err)]++
+		return
+	}
+	r.succeeded++
+	r.statuses[msg.Status]++
+	r.latencies = append(r.latencies, latency)
+}
+
+// errorCode returns the API error code of a failed send
+func errorCode(err error) string {
+	var apiErr *client.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code != "":
+		return apiErr.Code
+	case errors.Is(err, context.DeadlineExceeded):
+		return "TIMEOUT"
+	case errors.Is(err, context.Canceled):
+		return "CANCELED"
+	default:
+		return "TRANSPORT_ERROR"
+	}
+}
@@ -0,0 +1,187 @@
+// Command loadgen drives the send path of a running notification service at a
+// configurable rate over the HTTP or NATS API. It creates Slack channels whose
+// webhook points at a mock provider it serves itself, sends messages through
+// them for the given duration and reports the throughput, the latency
+// percentiles, the errors and, given a database DSN, the lock contention in
+// PostgreSQL.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"notification/pkg/client"
+)
+
+// options holds the command line flags
+type options struct {
+	transport     string
+	server        string
+	natsURL       string
+	subjectPrefix string
+	apiKey        string
+	timeout       time.Duration
+
+	rate        float64
+	duration    time.Duration
+	concurrency int
+	channels    int
+	recipients  int
+
+	providerListen      string
+	providerURL         string
+	providerLatency     time.Duration
+	providerFailureRate float64
+
+	databaseDSN string
+	keep        bool
+}
+
+func main() {
+	opts := &options{}
+	flag.StringVar(&opts.transport, "transport", "http", "API to send through: http or nats")
+	flag.StringVar(&opts.server, "server", "http://localhost:8080", "HTTP API base URL")
+	flag.StringVar(&opts.natsURL, "nats-url", "nats://localhost:4222", "NATS server URL")
+	flag.StringVar(&opts.subjectPrefix, "subject-prefix", client.DefaultSubjectPrefix, "NATS subject prefix")
+	flag.StringVar(&opts.apiKey, "api-key", os.Getenv("DNTF_API_KEY"), "API key sent with every request")
+	flag.DurationVar(&opts.timeout, "timeout", 30*time.Second, "timeout of one request")
+	flag.Float64Var(&opts.rate, "rate", 50, "messages per second, 0 sends as fast as the workers allow")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "how long to send")
+	flag.IntVar(&opts.concurrency, "concurrency", 10, "number of concurrent senders")
+	flag.IntVar(&opts.channels, "channels", 1, "channels each message is sent through")
+	flag.IntVar(&opts.recipients, "recipients", 1, "recipients of each channel")
+	flag.StringVar(&opts.providerListen, "provider-listen", "127.0.0.1:0", "address the mock provider listens on")
+	flag.StringVar(&opts.providerURL, "provider-url", "", "URL the service reaches the mock provider at, defaults to the listen address")
+	flag.DurationVar(&opts.providerLatency, "provider-latency", 50*time.Millisecond, "latency of the mock provider")
+	flag.Float64Var(&opts.providerFailureRate, "provider-failure-rate", 0, "share of the provider calls answered with 503, from 0 to 1")
+	flag.StringVar(&opts.databaseDSN, "database-dsn", "", "PostgreSQL DSN of the service database, to sample its lock contention")
+	flag.BoolVar(&opts.keep, "keep", false, "keep the created template and channels")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, opts); err != nil {
+		fmt.Fprintln(os.Stderr, "loadgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run sets up the provider and the fixtures, sends the load and prints the report
+func run(ctx context.Context, opts *options) error {
+	if opts.concurrency <= 0 {
+		return fmt.Errorf("concurrency must be positive")
+	}
+	if opts.channels <= 0 || opts.recipients <= 0 {
+		return fmt.Errorf("channels and recipients must be positive")
+	}
+
+	c, closeClient, err := opts.connect()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	// 1. Serve the mock provider
+	listener, err := net.Listen("tcp", opts.providerListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen for the mock provider: %w", err)
+	}
+	provider := newProvider(opts.providerLatency, opts.providerFailureRate)
+	go provider.serve(listener)
+	defer provider.close()
+
+	webhookURL := opts.providerURL
+	if webhookURL == "" {
+		webhookURL = "http://" + listener.Addr().String() + "/webhook"
+	}
+
+	// 2. Create the template and the channels sending to the provider
+	fixtures, err := createFixtures(ctx, c, webhookURL, opts.channels, opts.recipients)
+	if err != nil {
+		return err
+	}
+	if !opts.keep {
+		defer fixtures.delete(c)
+	}
+
+	// 3. Sample the database contention while sending
+	var sampler *contentionSampler
+	if opts.databaseDSN != "" {
+		sampler, err = newContentionSampler(opts.databaseDSN)
+		if err != nil {
+			return err
+		}
+		defer sampler.close()
+		if err := sampler.start(ctx, time.Second); err != nil {
+			return err
+		}
+	}
+
+	// 4. Send at the configured rate
+	fmt.Printf("Sending %s over %s at %s for %s with %d senders, provider %s\n",
+		describeShape(opts.channels, opts.recipients), opts.transport, describeRate(opts.rate),
+		opts.duration, opts.concurrency, webhookURL)
+	result := generate(ctx, c, fixtures.request(), opts.rate, opts.duration, opts.concurrency)
+
+	// 5. Report
+	report := &report{result: result, providerCalls: provider.stats()}
+	if sampler != nil {
+		contention := sampler.stop()
+		report.contention = &contention
+	}
+	report.print(os.Stdout)
+	return nil
+}
+
+// connect creates the client of the selected transport. Calls are not
+// retried, so that every failure shows in the report.
+func (o *options) connect() (*client.Client, func(), error) {
+	config := client.Config{
+		APIKey:  o.apiKey,
+		Timeout: o.timeout,
+		Retry:   client.NoRetry(),
+	}
+
+	switch o.transport {
+	case "http":
+		config.BaseURL = o.server
+		c, err := client.New(config)
+		return c, func() {}, err
+	case "nats":
+		nc, err := nats.Connect(o.natsURL, nats.Name("dntf-loadgen"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to NATS at %s: %w", o.natsURL, err)
+		}
+		config.NATSConn = nc
+		config.SubjectPrefix = o.subjectPrefix
+		c, err := client.New(config)
+		if err != nil {
+			nc.Close()
+			return nil, nil, err
+		}
+		return c, nc.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown transport %q, use http or nats", o.transport)
+	}
+}
+
+// describeShape describes the channels and recipients of each message
+func describeShape(channels, recipients int) string {
+	return fmt.Sprintf("messages to %d channel(s) of %d recipient(s)", channels, recipients)
+}
+
+// describeRate describes the target rate
+func describeRate(rate float64) string {
+	if rate <= 0 {
+		return "full speed"
+	}
+	return fmt.Sprintf("%g msg/s", rate)
+}
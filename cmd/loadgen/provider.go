@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// provider is a mock Slack webhook. It answers every call after the latency
+// and fails the given share of the calls with 503 Service Unavailable.
+type provider struct {
+	latency     time.Duration
+	failureRate float64
+	server      *http.Server
+
+	calls    atomic.Int64
+	failures atomic.Int64
+}
+
+// providerStats counts the calls the provider received
+type providerStats struct {
+	Calls    int64
+	Failures int64
+}
+
+// newProvider creates a mock provider
+func newProvider(latency time.Duration, failureRate float64) *provider {
+	p := &provider{latency: latency, failureRate: failureRate}
+	p.server = &http.Server{Handler: http.HandlerFunc(p.handle), ReadHeaderTimeout: 10 * time.Second}
+	return p
+}
+
+// serve answers the calls on the listener until the provider is closed
+func (p *provider) serve(listener net.Listener) {
+	_ = p.server.Serve(listener)
+}
+
+// close stops the provider
+func (p *provider) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = p.server.Shutdown(ctx)
+}
+
+// stats returns the call counters
+func (p *provider) stats() providerStats {
+	return providerStats{Calls: p.calls.Load(), Failures: p.failures.Load()}
+}
+
+// handle answers one webhook call
+func (p *provider) handle(w http.ResponseWriter, r *http.Request) {
+	p.calls.Add(1)
+	_, _ = io.Copy(io.Discard, r.Body)
+
+	if p.latency > 0 {
+		select {
+		case <-time.After(p.latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if p.failureRate > 0 && rand.Float64() < p.failureRate {
+		p.failures.Add(1)
+		http.Error(w, "mock provider unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
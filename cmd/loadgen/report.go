@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"notification/pkg/client"
+)
+
+// report prints the outcome of a run
+type report struct {
+	result        *result
+	providerCalls providerStats
+	// contention is nil when the database was not sampled
+	contention *contention
+}
+
+// print writes the report
+func (r *report) print(out io.Writer) {
+	res := r.result
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	seconds := res.elapsed.Seconds()
+	fmt.Fprintln(w, "\nRequests")
+	fmt.Fprintf(w, "  sent\t%d\n", res.sent)
+	fmt.Fprintf(w, "  accepted\t%d\n", res.succeeded)
+	fmt.Fprintf(w, "  failed\t%d\n", res.sent-res.succeeded)
+	fmt.Fprintf(w, "  skipped (senders busy)\t%d\n", res.skipped)
+	fmt.Fprintf(w, "  elapsed\t%s\n", res.elapsed.Round(time.Millisecond))
+	if seconds > 0 {
+		fmt.Fprintf(w, "  throughput\t%.1f msg/s\n", float64(res.succeeded)/seconds)
+	}
+
+	if len(res.latencies) > 0 {
+		sort.Slice(res.latencies, func(i, j int) bool { return res.latencies[i] < res.latencies[j] })
+		fmt.Fprintln(w, "\nLatency of accepted requests")
+		for _, p := range []float64{50, 90, 99} {
+			fmt.Fprintf(w, "  p%g\t%s\n", p, percentile(res.latencies, p))
+		}
+		fmt.Fprintf(w, "  max\t%s\n", res.latencies[len(res.latencies)-1])
+	}
+
+	if len(res.statuses) > 0 {
+		fmt.Fprintln(w, "\nMessage status")
+		statuses := make([]string, 0, len(res.statuses))
+		for status := range res.statuses {
+			statuses = append(statuses, string(status))
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(w, "  %s\t%d\n", status, res.statuses[client.MessageStatus(status)])
+		}
+	}
+
+	if len(res.errors) > 0 {
+		fmt.Fprintln(w, "\nErrors")
+		codes := make([]string, 0, len(res.errors))
+		for code := range res.errors {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			fmt.Fprintf(w, "  %s\t%d\n", code, res.errors[code])
+		}
+	}
+
+	fmt.Fprintln(w, "\nProvider")
+	fmt.Fprintf(w, "  calls\t%d\n", r.providerCalls.Calls)
+	fmt.Fprintf(w, "  failed calls\t%d\n", r.providerCalls.Failures)
+
+	if c := r.contention; c != nil {
+		fmt.Fprintln(w, "\nDatabase")
+		fmt.Fprintf(w, "  samples\t%d\n", c.Samples)
+		fmt.Fprintf(w, "  lock waiters (avg/max)\t%.1f / %d\n", c.AvgLockWaiters, c.MaxLockWaiters)
+		fmt.Fprintf(w, "  active sessions (avg/max)\t%.1f / %d\n", c.AvgActive, c.MaxActive)
+		fmt.Fprintf(w, "  deadlocks\t%d\n", c.Deadlocks)
+	}
+}
+
+// percentile returns the latency below which p percent of the sorted latencies fall
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	index := int(p / 100 * float64(len(sorted)-1))
+	return sorted[index]
+}
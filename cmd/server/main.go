@@ -4,36 +4,69 @@ package main
 // @version        1.0
 // @description    This is the API documentation for the Notification service.
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in                         header
+// @name                       X-API-Key
+// @description                API key, also accepted as an Authorization Bearer token
+
+// @securityDefinitions.basic BasicAuth
+// @description               Admin credentials, required by the /api/v1/admin endpoints
+
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"go.uber.org/zap"
 
+	analyticsusecases "notification/internal/application/analytics/usecases"
+	blackoutusecases "notification/internal/application/blackout/usecases"
+	categoryusecases "notification/internal/application/category/usecases"
 	"notification/internal/application/channel/usecases"
+	channelgroupusecases "notification/internal/application/channelgroup/usecases"
 	"notification/internal/application/cqrs"
 	channelcqrs "notification/internal/application/cqrs/channel"
 	messagecqrs "notification/internal/application/cqrs/message"
 	templatecqrs "notification/internal/application/cqrs/template"
+	failedeventusecases "notification/internal/application/failedevent/usecases"
 	healthusecases "notification/internal/application/health/usecases"
+	identityusecases "notification/internal/application/identity/usecases"
+	inappusecases "notification/internal/application/inapp/usecases"
 	messageusecases "notification/internal/application/message/usecases"
+	otpusecases "notification/internal/application/otp/usecases"
+	preferenceusecases "notification/internal/application/preference/usecases"
+	privacyusecases "notification/internal/application/privacy/usecases"
+	provisioningusecases "notification/internal/application/provisioning/usecases"
+	retentionusecases "notification/internal/application/retention/usecases"
+	slousecases "notification/internal/application/slo/usecases"
+	tagusecases "notification/internal/application/tag/usecases"
 	templateusecases "notification/internal/application/template/usecases"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/quota"
+	"notification/internal/domain/retention"
 	"notification/internal/domain/services"
 	"notification/internal/domain/shared"
+	"notification/internal/domain/slo"
 	"notification/internal/infrastructure/external"
 	"notification/internal/infrastructure/messaging"
 	"notification/internal/infrastructure/plugins"
 	"notification/internal/infrastructure/repository"
 	"notification/internal/presentation"
+	"notification/internal/presentation/executor"
 	"notification/internal/presentation/http/handlers"
 	"notification/internal/presentation/http/middleware"
+	"notification/internal/presentation/nats/asyncapi"
 	natshandlers "notification/internal/presentation/nats/handlers"
 	"notification/pkg/config"
 	"notification/pkg/database"
+	"notification/pkg/lock"
 	"notification/pkg/logger"
 
 	// swagger related imports
@@ -41,8 +74,25 @@ import (
 )
 
 func main() {
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets masked, then exit")
+	rotatePIIKeys := flag.Bool("rotate-pii-keys", false, "encrypt the stored recipient targets with the active PII key, then exit")
+	mode := flag.String("mode", "", "run mode: api (HTTP and NATS APIs), worker (send workers) or all; overrides SERVER_MODE")
+	flag.Parse()
+
+	if *printConfig {
+		os.Exit(runPrintConfig(*mode))
+	}
+	if *rotatePIIKeys {
+		os.Exit(runRotatePIIKeys(*mode))
+	}
+
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig(*mode)
+	if err == nil {
+		if err = cfg.Validate(); err != nil {
+			err = fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
 	if err != nil {
 		fmt.Printf("Failed to load configuration: %v\n", err)
 		os.Exit(1)
@@ -57,6 +107,7 @@ func main() {
 
 	log.Info("Starting Notification server",
 		zap.String("version", "1.0.0"),
+		zap.String("mode", cfg.Server.Mode),
 		zap.String("server_address", cfg.GetServerAddress()))
 
 	// Initialize channel types registry
@@ -91,7 +142,8 @@ func main() {
 		zap.String("type", cfg.Database.Type),
 		zap.String("host", cfg.Database.Host),
 		zap.Int("port", cfg.Database.Port),
-		zap.String("database", cfg.Database.DBName))
+		zap.String("database", cfg.Database.DBName),
+		zap.Int("replicas", len(cfg.Database.ReplicaDSNs)))
 
 	// Run GORM migrations
 	if err := db.RunMigrations(); err != nil {
@@ -111,13 +163,206 @@ func main() {
 	// Build dependency container
 	container := buildContainer(db, natsClient, log, cfg)
 
-	// Initialize HTTP handlers (both traditional and CQRS)
+	// Check the channel credentials before taking traffic
+	if cfg.SelfTest.Enabled {
+		runSelfTest(container, cfg, log)
+	}
+
+	// Start the parts of the service the run mode selects
+	var server *presentation.Server
+	if cfg.Server.RunsAPI() {
+		// Only the API tier hands messages to separate workers; in "all" mode they are sent inline
+		if !cfg.Server.RunsWorkers() {
+			container.SendMessageUseCase.SetDispatcher(messaging.NewNATSMessageDispatcher(
+				natsClient,
+				time.Duration(cfg.NATS.RequestTimeout)*time.Second,
+			))
+		}
+
+		server = newAPIServer(container, natsClient, cfg)
+		if err := server.Start(context.Background()); err != nil {
+			log.Fatal("Failed to start presentation layer server", zap.Error(err))
+		}
+
+		// Compensate the provisioning sagas and rerun the privacy requests a
+		// previous run left unfinished; a standby leaves them to the primary
+		if !container.ReadOnly.Enabled() {
+			go func() {
+				if err := container.ProvisionChannelUseCase.Resume(context.Background()); err != nil {
+					log.Error("Failed to resume provisioning sagas", zap.Error(err))
+				}
+				if err := container.PrivacyRequestUseCase.Resume(context.Background()); err != nil {
+					log.Error("Failed to resume privacy requests", zap.Error(err))
+				}
+			}()
+		}
+	}
+
+	var sendWorker *messaging.SendWorker
+	if cfg.Server.RunsWorkers() {
+		sendWorker = messaging.NewSendWorker(
+			natsClient,
+			&container.MessageRepo,
+			container.MessageSender,
+			cfg.Server.WorkerConcurrency,
+			time.Duration(cfg.NATS.HandlerTimeout)*time.Second,
+			log,
+		)
+		// A read-only instance leaves the queue group until it accepts commands
+		if container.ReadOnly.Enabled() {
+			sendWorker.Pause()
+		}
+		container.ReadOnly.OnChange(func(readOnly bool) {
+			if readOnly {
+				sendWorker.Pause()
+				return
+			}
+			if err := sendWorker.Resume(); err != nil {
+				log.Error("Failed to resume send worker", zap.Error(err))
+			}
+		})
+		if err := sendWorker.Start(); err != nil {
+			log.Fatal("Failed to start send worker", zap.Error(err))
+		}
+	}
+
+	// Keep the provider connections open where messages are sent
+	var keepAlive *external.ProviderKeepAlive
+	if cfg.Server.RunsWorkers() && cfg.ProviderKeepAlive.Enabled {
+		keepAlive = external.NewProviderKeepAlive(
+			&container.ChannelRepo,
+			container.SenderFactory,
+			time.Duration(cfg.ProviderKeepAlive.Interval)*time.Second,
+			log,
+		)
+		keepAlive.Start()
+	}
+
+	// Report the failed and held sends to the operators
+	var digestJob *external.FailureDigestJob
+	if cfg.Server.RunsWorkers() && container.FailureDigest != nil {
+		digestJob = external.NewFailureDigestJob(
+			container.FailureDigest,
+			time.Duration(cfg.FailureDigest.Interval)*time.Second,
+			log,
+		)
+		digestJob.SetReadOnly(container.ReadOnly)
+		digestJob.Start()
+	}
+
+	// Send the scheduled messages whose time has come
+	var scheduledSendJob *external.ScheduledSendJob
+	if cfg.Server.RunsWorkers() {
+		scheduledSendJob = external.NewScheduledSendJob(
+			container.ScheduledSends,
+			time.Duration(cfg.ScheduledSend.PollInterval)*time.Second,
+			cfg.ScheduledSend.BatchSize,
+			log,
+		)
+		scheduledSendJob.SetReadOnly(container.ReadOnly)
+		scheduledSendJob.Start()
+	}
+
+	// Enforce the retention windows of the tenants
+	var retentionJob *external.RetentionJob
+	if cfg.Server.RunsWorkers() && container.Retention != nil {
+		retentionJob = external.NewRetentionJob(
+			container.Retention,
+			time.Duration(cfg.Retention.Interval)*time.Second,
+			log,
+		)
+		retentionJob.SetReadOnly(container.ReadOnly)
+		retentionJob.Start()
+	}
+
+	// Measure the delivery latency objectives for the metrics endpoint and
+	// alert on their burn rates
+	var sloJob *external.SLOJob
+	if cfg.Server.RunsAPI() && container.SLOTracker != nil {
+		sloJob = external.NewSLOJob(
+			container.SLOTracker,
+			time.Duration(cfg.SLO.Interval)*time.Second,
+			log,
+		)
+		sloJob.SetReadOnly(container.ReadOnly)
+		sloJob.Start()
+	}
+
+	// Post the channel and template changes to the webhooks
+	if container.ChangeWebhooks != nil {
+		container.ChangeWebhooks.Start()
+	}
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info("Shutting down server...")
+
+	// Graceful shutdown
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if server != nil {
+		if err := server.Stop(shutdownCtx); err != nil {
+			log.Error("Server forced to shutdown", zap.Error(err))
+		}
+		if err := container.SendMessageUseCase.Drain(shutdownCtx); err != nil {
+			log.Error("Background deliveries forced to shutdown", zap.Error(err))
+		}
+	}
+	if sendWorker != nil {
+		if err := sendWorker.Stop(shutdownCtx); err != nil {
+			log.Error("Send worker forced to shutdown", zap.Error(err))
+		}
+	}
+	if keepAlive != nil {
+		if err := keepAlive.Stop(shutdownCtx); err != nil {
+			log.Error("Provider keepalive forced to shutdown", zap.Error(err))
+		}
+	}
+	if digestJob != nil {
+		if err := digestJob.Stop(shutdownCtx); err != nil {
+			log.Error("Failure digest forced to shutdown", zap.Error(err))
+		}
+	}
+	if scheduledSendJob != nil {
+		if err := scheduledSendJob.Stop(shutdownCtx); err != nil {
+			log.Error("Scheduled sends forced to shutdown", zap.Error(err))
+		}
+	}
+	if retentionJob != nil {
+		if err := retentionJob.Stop(shutdownCtx); err != nil {
+			log.Error("Retention job forced to shutdown", zap.Error(err))
+		}
+	}
+	if sloJob != nil {
+		if err := sloJob.Stop(shutdownCtx); err != nil {
+			log.Error("SLO job forced to shutdown", zap.Error(err))
+		}
+	}
+	if container.ChangeWebhooks != nil {
+		if err := container.ChangeWebhooks.Stop(shutdownCtx); err != nil {
+			log.Error("Change webhooks forced to shutdown", zap.Error(err))
+		}
+	}
+	log.Info("Server shutdown completed")
+}
+
+// newAPIServer wires the HTTP and NATS handlers into the presentation layer server
+func newAPIServer(container *Container, natsClient *messaging.NATSClient, cfg *config.Config) *presentation.Server {
+	// Initialize HTTP handlers
 	channelHandler := handlers.NewChannelHandler(
 		container.CreateChannelUseCase,
 		container.GetChannelUseCase,
 		container.ListChannelsUseCase,
 		container.UpdateChannelUseCase,
 		container.DeleteChannelUseCase,
+		container.SetChannelEnabledUseCase,
+		container.BulkChannelUseCase,
+		container.ChannelMaintenanceUseCase,
+		container.CheckDeliverabilityUseCase,
 	)
 
 	// Initialize template HTTP handler
@@ -127,6 +372,9 @@ func main() {
 		container.ListTemplatesUseCase,
 		container.UpdateTemplateUseCase,
 		container.DeleteTemplateUseCase,
+		container.GetTemplateUsageUseCase,
+		container.LintTemplateUseCase,
+		container.ValidateTemplateUseCase,
 	)
 
 	// Initialize health HTTP handler
@@ -140,80 +388,275 @@ func main() {
 		container.SendMessageUseCase,
 		container.GetMessageUseCase,
 		container.ListMessagesUseCase,
+		container.GetQuotaUsageUseCase,
+		container.ExportMessagesUseCase,
 	)
 
-	// Initialize CQRS HTTP handlers
-	cqrsChannelHandler := handlers.NewCQRSChannelHandler(container.CQRSFacade)
-	cqrsTemplateHandler := handlers.NewCQRSTemplateHandler(container.CQRSFacade)
-	cqrsMessageHandler := handlers.NewCQRSMessageHandler(container.CQRSFacade)
+	// The v2 routes are served by the same handlers running on the CQRS executors
+	cqrsChannelHandler := channelHandler.WithExecutor(
+		executor.NewCQRSChannelExecutor(container.CQRSFacade, container.SetChannelEnabledUseCase),
+	)
+	cqrsTemplateHandler := templateHandler.WithExecutor(executor.NewCQRSTemplateExecutor(container.CQRSFacade))
+	cqrsMessageHandler := messageHandler.WithExecutor(executor.NewCQRSMessageExecutor(container.CQRSFacade))
 
 	// Initialize NATS handler manager (traditional)
 	natsHandlerConfig := &natshandlers.HandlerConfig{
-		NATSConn:              natsClient.GetConnection(),
-		CreateChannelUseCase:  container.CreateChannelUseCase,
-		GetChannelUseCase:     container.GetChannelUseCase,
-		ListChannelsUseCase:   container.ListChannelsUseCase,
-		UpdateChannelUseCase:  container.UpdateChannelUseCase,
-		DeleteChannelUseCase:  container.DeleteChannelUseCase,
-		CreateTemplateUseCase: container.CreateTemplateUseCase,
-		GetTemplateUseCase:    container.GetTemplateUseCase,
-		ListTemplatesUseCase:  container.ListTemplatesUseCase,
-		UpdateTemplateUseCase: container.UpdateTemplateUseCase,
-		DeleteTemplateUseCase: container.DeleteTemplateUseCase,
-		SendMessageUseCase:    container.SendMessageUseCase,
-		GetMessageUseCase:     container.GetMessageUseCase,
-		ListMessagesUseCase:   container.ListMessagesUseCase,
+		NATSConn:       natsClient.GetConnection(),
+		MessageTimeout: time.Duration(cfg.NATS.HandlerTimeout) * time.Second,
+		PayloadLimits: natshandlers.PayloadLimits{
+			MaxBytes:     cfg.Server.MaxBodyBytes,
+			MaxJSONDepth: cfg.Server.MaxJSONDepth,
+		},
+		MaxUploadBytes:           cfg.NATS.MaxUploadBytes,
+		UploadTTL:                time.Duration(cfg.NATS.UploadTTL) * time.Second,
+		APIKeys:                  cfg.NATS.APIKeys,
+		RequireVersion:           cfg.NATS.RequireVersion,
+		UseCQRS:                  cfg.NATS.UseCQRS,
+		CQRSFacade:               container.CQRSFacade,
+		CreateChannelUseCase:     container.CreateChannelUseCase,
+		GetChannelUseCase:        container.GetChannelUseCase,
+		ListChannelsUseCase:      container.ListChannelsUseCase,
+		UpdateChannelUseCase:     container.UpdateChannelUseCase,
+		DeleteChannelUseCase:     container.DeleteChannelUseCase,
+		SetChannelEnabledUseCase: container.SetChannelEnabledUseCase,
+		CreateTemplateUseCase:    container.CreateTemplateUseCase,
+		GetTemplateUseCase:       container.GetTemplateUseCase,
+		ListTemplatesUseCase:     container.ListTemplatesUseCase,
+		UpdateTemplateUseCase:    container.UpdateTemplateUseCase,
+		DeleteTemplateUseCase:    container.DeleteTemplateUseCase,
+		SendMessageUseCase:       container.SendMessageUseCase,
+		GetMessageUseCase:        container.GetMessageUseCase,
+		ListMessagesUseCase:      container.ListMessagesUseCase,
+	}
+	if cfg.NATS.InboxTTL > 0 {
+		natsHandlerConfig.Inbox = container.NATSInbox
+		natsHandlerConfig.InboxTTL = time.Duration(cfg.NATS.InboxTTL) * time.Second
 	}
+	natsHandlerConfig.ReadOnly = container.ReadOnly
 	natsManager := natshandlers.NewHandlerManager(natsHandlerConfig)
 
-	// Initialize CQRS NATS handler
-	cqrsNatsHandler := natshandlers.NewCQRSChannelNATSHandler(container.CQRSFacade, natsClient.GetConnection())
+	// Initialize AsyncAPI handler documenting the NATS API and the published events
+	asyncAPIEvents := []asyncapi.Event{
+		{
+			Subject: messaging.ChannelHealthSubject,
+			Summary: "Channel health status changed",
+			Payload: messaging.ChannelHealthEvent{},
+		},
+		{
+			Subject: messaging.MessageProgressSubject,
+			Summary: "Message delivered through a channel, or through all of them",
+			Payload: messaging.MessageProgressEvent{},
+		},
+		{
+			Subject: messaging.InAppNotificationSubject,
+			Summary: "In-app notification stored for a user",
+			Payload: messaging.InAppNotificationEvent{},
+		},
+	}
+	for _, changeType := range []string{
+		shared.ChangeChannelCreated, shared.ChangeChannelUpdated, shared.ChangeChannelDeleted,
+		shared.ChangeChannelEnabled, shared.ChangeChannelDisabled,
+		shared.ChangeTemplateCreated, shared.ChangeTemplateUpdated, shared.ChangeTemplateDeleted,
+	} {
+		asyncAPIEvents = append(asyncAPIEvents, asyncapi.Event{
+			Subject: messaging.ChangeSubjectPrefix + "." + changeType,
+			Summary: changeType + " change, with the old and new versions and the actor",
+			Payload: shared.Change{},
+		})
+	}
+	asyncAPIHandler := handlers.NewAsyncAPIHandler(cfg.NATS.SubjectPrefix, asyncAPIEvents)
+
+	// Push the in-app notifications stored by every instance to the clients
+	// connected to this one
+	inAppHub := handlers.NewInAppHub()
+	if _, err := messaging.SubscribeInAppNotifications(natsClient, container.Logger, func(event *messaging.InAppNotificationEvent) {
+		inAppHub.Push(event.UserID, event)
+	}); err != nil {
+		container.Logger.Fatal("Failed to subscribe to in-app notifications", zap.Error(err))
+	}
 
 	// Initialize middleware configuration based on environment
 	var middlewareConfig *middleware.MiddlewareConfig
 	// For now, use development config as default
 	// TODO: Add Environment field to config.Config
 	middlewareConfig = middleware.DevelopmentMiddlewareConfig()
+	middlewareConfig.BodyLimit = &middleware.BodyLimitConfig{
+		MaxBodyBytes:          int64(cfg.Server.MaxBodyBytes),
+		MaxJSONDepth:          cfg.Server.MaxJSONDepth,
+		DisallowUnknownFields: cfg.Server.StrictJSON,
+	}
 
 	// Initialize presentation layer server
 	serverConfig := &presentation.ServerConfig{
-		HTTPPort:            fmt.Sprintf("%d", cfg.Server.Port),
-		HTTPTimeout:         time.Duration(cfg.Server.ReadTimeout) * time.Second,
-		ChannelHandler:      channelHandler,
-		CQRSChannelHandler:  cqrsChannelHandler,
-		TemplateHandler:     templateHandler,
-		MessageHandler:      messageHandler,
+		HTTPPort:           fmt.Sprintf("%d", cfg.Server.Port),
+		HTTPTimeout:        time.Duration(cfg.Server.ReadTimeout) * time.Second,
+		ChannelHandler:     channelHandler,
+		CQRSChannelHandler: cqrsChannelHandler,
+		TemplateHandler:    templateHandler,
+		MessageHandler:     messageHandler,
+		AnalyticsHandler:   handlers.NewAnalyticsHandler(container.GetCostReportUseCase),
+		TagHandler:         handlers.NewTagHandler(container.ListTagsUseCase, container.MergeTagsUseCase),
+		ChannelGroupHandler: handlers.NewChannelGroupHandler(
+			container.CreateChannelGroupUseCase,
+			container.GetChannelGroupUseCase,
+			container.UpdateChannelGroupUseCase,
+			container.DeleteChannelGroupUseCase,
+		),
+		ProvisioningHandler: handlers.NewProvisioningHandler(
+			container.ProvisionChannelUseCase,
+			container.GetProvisioningSagaUseCase,
+		),
+		FailedEventHandler: handlers.NewFailedEventHandler(
+			container.GetFailedEventUseCase,
+			container.ReplayFailedEventUseCase,
+		),
 		CQRSTemplateHandler: cqrsTemplateHandler,
 		CQRSMessageHandler:  cqrsMessageHandler,
 		NATSManager:         natsManager,
-		CQRSNATSHandler:     cqrsNatsHandler,
 		MiddlewareConfig:    middlewareConfig,
 		HealthHandler:       healthHandler,
+		AsyncAPIHandler:     asyncAPIHandler,
+		VoiceHandler:        handlers.NewVoiceHandler(container.AcknowledgeCallUseCase),
+		InAppHandler: handlers.NewInAppHandler(
+			container.GetInAppNotificationUseCase,
+			container.UpdateInAppNotificationUseCase,
+			inAppHub,
+		),
+		PreferenceHandler: handlers.NewPreferenceHandler(
+			container.GetUserPreferenceUseCase,
+			container.UpdateUserPreferenceUseCase,
+		),
+		CategoryHandler: handlers.NewCategoryHandler(
+			container.GetCategoryUseCase,
+			container.UpdateCategoryUseCase,
+			container.GetSubscriptionUseCase,
+			container.UpdateSubscriptionUseCase,
+		),
+		IdentityHandler:         handlers.NewIdentityHandler(container.GetSenderIdentityUseCase, container.UpdateSenderIdentityUseCase),
+		ReadOnlyHandler:         handlers.NewReadOnlyHandler(container.ReadOnly),
+		LogLevelHandler:         handlers.NewLogLevelHandler(container.Logger.Levels()),
+		ChannelReadinessHandler: handlers.NewChannelReadinessHandler(container.CheckChannelReadinessUseCase),
+		CompatibilityHandler:    handlers.NewCompatibilityHandler(container.GetCompatibilityUseCase),
+		LinkHandler:             handlers.NewLinkHandler(container.FollowShortLinkUseCase, container.GetMessageLinksUseCase),
+		QuarantineHandler:       handlers.NewQuarantineHandler(container.GetMessageQuarantineUseCase),
+		ScheduleHandler:         handlers.NewScheduleHandler(container.ScheduleMessageUseCase),
+		BlackoutHandler:         handlers.NewBlackoutHandler(container.GetBlackoutUseCase, container.UpdateBlackoutUseCase),
+		AcknowledgmentHandler:   handlers.NewAcknowledgmentHandler(container.AcknowledgeMessageUseCase, container.GetAcknowledgmentsUseCase),
+		ReplyHandler:            handlers.NewReplyHandler(container.ReceiveReplyUseCase, container.GetRepliesUseCase),
+		OTPHandler:              handlers.NewOTPHandler(container.OTPUseCase),
+		PrivacyHandler:          handlers.NewPrivacyHandler(container.PrivacyRequestUseCase),
+		RetentionHandler:        handlers.NewRetentionHandler(container.ListRetentionReportsUseCase),
+		SLOHandler:              handlers.NewSLOHandler(container.GetSLOStatusUseCase),
+		ReadOnly:                container.ReadOnly,
 	}
-	server := presentation.NewServer(serverConfig)
+	return presentation.NewServer(serverConfig)
+}
 
-	// Start the presentation layer server
-	ctx := context.Background()
-	if err := server.Start(ctx); err != nil {
-		log.Fatal("Failed to start presentation layer server", zap.Error(err))
+// runSelfTest checks the credentials of the enabled channels and logs a
+// readiness summary, exiting when a channel failed and the configuration says so
+func runSelfTest(container *Container, cfg *config.Config, log *logger.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.SelfTest.Timeout)*time.Second)
+	defer cancel()
+
+	result, err := container.CheckChannelReadinessUseCase.Execute(ctx)
+	if err != nil {
+		if cfg.SelfTest.FailOnError {
+			log.Fatal("Channel self-test failed", zap.Error(err))
+		}
+		log.Error("Channel self-test failed", zap.Error(err))
+		return
 	}
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	for _, ch := range result.Channels {
+		if ch.Status == string(channel.ReadinessFailed) {
+			log.Warn("Channel is not ready",
+				zap.String("channel_id", ch.ChannelID),
+				zap.String("channel_name", ch.ChannelName),
+				zap.String("channel_type", ch.ChannelType),
+				zap.String("error", ch.Error))
+		}
+	}
+	log.Info("Channel self-test completed",
+		zap.Int("ready", result.Ready),
+		zap.Int("reachable", result.Reachable),
+		zap.Int("unverified", result.Unverified),
+		zap.Int("failed", result.Failed))
+
+	if cfg.SelfTest.FailOnError && result.Failed > 0 {
+		log.Fatal("Channel self-test found channels that are not ready", zap.Int("failed", result.Failed))
+	}
+}
 
-	log.Info("Shutting down server...")
+// loadConfig loads the configuration without validating it, applying the
+// run mode given on the command line
+func loadConfig(mode string) (*config.Config, error) {
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return nil, err
+	}
+	if mode != "" {
+		cfg.Server.Mode = mode
+	}
+	return cfg, nil
+}
 
-	// Graceful shutdown
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// runPrintConfig prints the effective configuration and its validation
+// problems, returning the process exit code
+func runPrintConfig(mode string) int {
+	cfg, err := loadConfig(mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	if err := cfg.Print(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to print configuration: %v\n", err)
+		return 1
+	}
 
-	if err := server.Stop(shutdownCtx); err != nil {
-		log.Error("Server forced to shutdown", zap.Error(err))
-	} else {
-		log.Info("Server shutdown completed")
+	var problems config.ValidationErrors
+	if err := cfg.Validate(); errors.As(err, &problems) {
+		fmt.Fprintf(os.Stderr, "Configuration has %d problem(s):\n", len(problems))
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", problem)
+		}
+		return 1
+	}
+	return 0
+}
+
+// runRotatePIIKeys encrypts with the active PII key the recipient targets
+// stored in plain text or with a former key, returning the process exit code
+func runRotatePIIKeys(mode string) int {
+	cfg, err := loadConfig(mode)
+	if err == nil {
+		err = cfg.Validate()
 	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	cipher, err := cfg.PII.Cipher()
+	if err != nil || cipher == nil {
+		fmt.Fprintln(os.Stderr, "PII_ENCRYPTION_KEYS and PII_ACTIVE_KEY must be set to rotate the PII keys")
+		return 1
+	}
+
+	db, err := database.NewGormDB(&cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	counts, err := repository.RotatePIIKeys(context.Background(), db.DB, cipher)
+	for _, table := range []string{"channels", "messages", "message_results"} {
+		fmt.Printf("%s: %d row(s) encrypted with key %q\n", table, counts[table], cipher.ActiveKeyID())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to rotate PII keys: %v\n", err)
+		return 1
+	}
+	return 0
 }
 
 // Container holds all application dependencies
@@ -222,31 +665,128 @@ type Container struct {
 	ChannelRepo  repository.ChannelRepositoryImpl
 	TemplateRepo repository.TemplateRepositoryImpl
 	MessageRepo  repository.MessageRepositoryImpl
+	NATSInbox    *repository.NATSInboxRepositoryImpl
 
 	// Services
 	MessageSender       *services.EnhancedMessageSender
 	ChannelValidator    *services.ChannelValidator
 	TemplateRenderer    *services.DefaultTemplateRenderer
 	NotificationService *external.DefaultNotificationService
+	SenderFactory       *external.DefaultMessageSenderFactory
+	FailureDigest       *services.FailureDigest
+	Retention           *services.RetentionEnforcer
+	SLOTracker          *services.SLOTracker
+	ScheduledSends      *services.ScheduledSendRunner
+	ChangeWebhooks      *external.WebhookChangeNotifier
+
+	// ReadOnly rejects commands and pauses the workers while the instance is a standby
+	ReadOnly *shared.ReadOnlyMode
 
 	// Use Cases - Channel
-	CreateChannelUseCase *usecases.CreateChannelUseCase
-	GetChannelUseCase    *usecases.GetChannelUseCase
-	ListChannelsUseCase  *usecases.ListChannelsUseCase
-	UpdateChannelUseCase *usecases.UpdateChannelUseCase
-	DeleteChannelUseCase *usecases.DeleteChannelUseCase
+	CreateChannelUseCase      *usecases.CreateChannelUseCase
+	GetChannelUseCase         *usecases.GetChannelUseCase
+	ListChannelsUseCase       *usecases.ListChannelsUseCase
+	UpdateChannelUseCase      *usecases.UpdateChannelUseCase
+	DeleteChannelUseCase      *usecases.DeleteChannelUseCase
+	SetChannelEnabledUseCase  *usecases.SetChannelEnabledUseCase
+	BulkChannelUseCase        *usecases.BulkChannelUseCase
+	ChannelMaintenanceUseCase *usecases.ChannelMaintenanceUseCase
+
+	CheckChannelReadinessUseCase *usecases.CheckChannelReadinessUseCase
+	CheckDeliverabilityUseCase   *usecases.CheckDeliverabilityUseCase
 
 	// Use Cases - Template
-	CreateTemplateUseCase *templateusecases.CreateTemplateUseCase
-	GetTemplateUseCase    *templateusecases.GetTemplateUseCase
-	ListTemplatesUseCase  *templateusecases.ListTemplatesUseCase
-	UpdateTemplateUseCase *templateusecases.UpdateTemplateUseCase
-	DeleteTemplateUseCase *templateusecases.DeleteTemplateUseCase
+	CreateTemplateUseCase   *templateusecases.CreateTemplateUseCase
+	GetTemplateUseCase      *templateusecases.GetTemplateUseCase
+	ListTemplatesUseCase    *templateusecases.ListTemplatesUseCase
+	UpdateTemplateUseCase   *templateusecases.UpdateTemplateUseCase
+	DeleteTemplateUseCase   *templateusecases.DeleteTemplateUseCase
+	GetTemplateUsageUseCase *templateusecases.GetTemplateUsageUseCase
+	LintTemplateUseCase     *templateusecases.LintTemplateUseCase
+	ValidateTemplateUseCase *templateusecases.ValidateTemplateUseCase
+	GetCompatibilityUseCase *templateusecases.GetCompatibilityUseCase
 
 	// Use Cases - Message
-	SendMessageUseCase  *messageusecases.SendMessageUseCase
-	GetMessageUseCase   *messageusecases.GetMessageUseCase
-	ListMessagesUseCase *messageusecases.ListMessagesUseCase
+	SendMessageUseCase    *messageusecases.SendMessageUseCase
+	GetMessageUseCase     *messageusecases.GetMessageUseCase
+	ListMessagesUseCase   *messageusecases.ListMessagesUseCase
+	GetQuotaUsageUseCase  *messageusecases.GetQuotaUsageUseCase
+	ExportMessagesUseCase *messageusecases.ExportMessagesUseCase
+	// AcknowledgeCallUseCase records the key presses of voice calls
+	AcknowledgeCallUseCase *messageusecases.AcknowledgeCallUseCase
+
+	// Short links of the messages and their clicks
+	FollowShortLinkUseCase *messageusecases.FollowShortLinkUseCase
+	GetMessageLinksUseCase *messageusecases.GetMessageLinksUseCase
+
+	// Acknowledgments of the messages by their recipients
+	AcknowledgeMessageUseCase *messageusecases.AcknowledgeMessageUseCase
+	GetAcknowledgmentsUseCase *messageusecases.GetAcknowledgmentsUseCase
+
+	// Replies of the recipients to the messages
+	ReceiveReplyUseCase *messageusecases.ReceiveReplyUseCase
+	GetRepliesUseCase   *messageusecases.GetRepliesUseCase
+
+	// Attachments quarantined by the virus scan
+	GetMessageQuarantineUseCase *messageusecases.GetMessageQuarantineUseCase
+
+	// Messages scheduled at an instant or at recipient-local times
+	ScheduleMessageUseCase *messageusecases.ScheduleMessageUseCase
+
+	// Use Cases - Analytics
+	GetCostReportUseCase *analyticsusecases.GetCostReportUseCase
+
+	// Use Cases - Tag
+	ListTagsUseCase  *tagusecases.ListTagsUseCase
+	MergeTagsUseCase *tagusecases.MergeTagsUseCase
+
+	// Use Cases - Channel Group
+	CreateChannelGroupUseCase *channelgroupusecases.CreateChannelGroupUseCase
+	GetChannelGroupUseCase    *channelgroupusecases.GetChannelGroupUseCase
+	UpdateChannelGroupUseCase *channelgroupusecases.UpdateChannelGroupUseCase
+	DeleteChannelGroupUseCase *channelgroupusecases.DeleteChannelGroupUseCase
+
+	// Use Cases - Provisioning
+	ProvisionChannelUseCase    *provisioningusecases.ProvisionChannelUseCase
+	GetProvisioningSagaUseCase *provisioningusecases.GetProvisioningSagaUseCase
+
+	// Use Cases - Failed Event
+	GetFailedEventUseCase    *failedeventusecases.GetFailedEventUseCase
+	ReplayFailedEventUseCase *failedeventusecases.ReplayFailedEventUseCase
+
+	// Use Cases - In-App Notification
+	GetInAppNotificationUseCase    *inappusecases.GetInAppNotificationUseCase
+	UpdateInAppNotificationUseCase *inappusecases.UpdateInAppNotificationUseCase
+
+	// Use Cases - User Preference
+	GetUserPreferenceUseCase    *preferenceusecases.GetUserPreferenceUseCase
+	UpdateUserPreferenceUseCase *preferenceusecases.UpdateUserPreferenceUseCase
+
+	// Use Cases - Category
+	GetCategoryUseCase        *categoryusecases.GetCategoryUseCase
+	UpdateCategoryUseCase     *categoryusecases.UpdateCategoryUseCase
+	GetSubscriptionUseCase    *categoryusecases.GetSubscriptionUseCase
+	UpdateSubscriptionUseCase *categoryusecases.UpdateSubscriptionUseCase
+
+	// Use Cases - Sender Identity
+	GetSenderIdentityUseCase    *identityusecases.GetSenderIdentityUseCase
+	UpdateSenderIdentityUseCase *identityusecases.UpdateSenderIdentityUseCase
+
+	// Use Cases - Blackout Calendar
+	GetBlackoutUseCase    *blackoutusecases.GetBlackoutUseCase
+	UpdateBlackoutUseCase *blackoutusecases.UpdateBlackoutUseCase
+
+	// Use Cases - One-Time Verification Codes
+	OTPUseCase *otpusecases.OTPUseCase
+
+	// Use Cases - Data Subject Requests
+	PrivacyRequestUseCase *privacyusecases.PrivacyRequestUseCase
+
+	// Use Cases - Retention
+	ListRetentionReportsUseCase *retentionusecases.ListRetentionReportsUseCase
+
+	// Use Cases - SLO
+	GetSLOStatusUseCase *slousecases.GetSLOStatusUseCase
 
 	// Use Cases - Health
 	GetSystemHealthUseCase *healthusecases.GetSystemHealthUseCase
@@ -269,11 +809,63 @@ func buildContainer(db *database.GormDB, natsClient *messaging.NATSClient, log *
 	channelRepo := repository.NewChannelRepositoryImpl(db.DB)
 	templateRepo := repository.NewTemplateRepositoryImpl(db.DB)
 	messageRepo := repository.NewMessageRepositoryImpl(db.DB)
+	channelGroupRepo := repository.NewChannelGroupRepositoryImpl(db.DB)
+	provisioningSagaRepo := repository.NewProvisioningSagaRepositoryImpl(db.DB)
+	failedEventRepo := repository.NewFailedEventRepositoryImpl(db.DB)
+	natsInboxRepo := repository.NewNATSInboxRepositoryImpl(db.DB)
+	callAckRepo := repository.NewCallAcknowledgmentRepositoryImpl(db.DB)
+	shortLinkRepo := repository.NewShortLinkRepositoryImpl(db.DB)
+	quarantineRepo := repository.NewQuarantineRepositoryImpl(db.DB)
+	inAppNotificationRepo := repository.NewInAppNotificationRepositoryImpl(db.DB)
+	userPreferenceRepo := repository.NewUserPreferenceRepositoryImpl(db.DB)
+	categoryRepo := repository.NewCategoryRepositoryImpl(db.DB)
+	categorySubscriptionRepo := repository.NewCategorySubscriptionRepositoryImpl(db.DB)
+	senderIdentityRepo := repository.NewSenderIdentityRepositoryImpl(db.DB)
+	scheduledSendRepo := repository.NewScheduledSendRepositoryImpl(db.DB)
+	blackoutRepo := repository.NewBlackoutRepositoryImpl(db.DB)
+	acknowledgmentRepo := repository.NewAcknowledgmentRepositoryImpl(db.DB)
+	replyRepo := repository.NewReplyRepositoryImpl(db.DB)
+	otpRepo := repository.NewOTPRepositoryImpl(db.DB)
+	privacyRequestRepo := repository.NewPrivacyRequestRepositoryImpl(db.DB)
+	privacyAuditLog := repository.NewPrivacyAuditLogImpl(db.DB)
+	subjectDataStore := repository.NewSubjectDataStoreImpl(db.DB)
+
+	// Encrypt the recipient targets at rest when PII keys are configured
+	piiCipher, err := cfg.PII.Cipher()
+	if err != nil {
+		log.Fatal("Invalid PII encryption keys", zap.Error(err))
+	}
+	if piiCipher != nil {
+		channelRepo.SetPIICipher(piiCipher)
+		messageRepo.SetPIICipher(piiCipher)
+		subjectDataStore.SetPIICipher(piiCipher)
+		log.Info("PII encryption enabled", zap.String("active_key", piiCipher.ActiveKeyID()))
+	}
 
 	// Initialize external services
 	messageSenderFactory := external.NewDefaultMessageSenderFactory(30 * time.Second)
 	notificationService := external.NewDefaultNotificationService(messageSenderFactory)
-	notificationServiceAdapter := external.NewNotificationServiceAdapter(notificationService)
+	voiceSender, err := messageSenderFactory.CreateSender(shared.ChannelTypeVoice.String())
+	if err != nil {
+		log.Fatal("Voice sender is not registered", zap.Error(err))
+	}
+	voiceService := voiceSender.(*external.VoiceService)
+	voiceService.SetAcknowledgments(callAckRepo)
+	smsSender, err := messageSenderFactory.CreateSender(shared.ChannelTypeSMS.String())
+	if err != nil {
+		log.Fatal("SMS sender is not registered", zap.Error(err))
+	}
+	smsService := smsSender.(*external.SMSService)
+	inAppSender, err := messageSenderFactory.CreateSender(shared.ChannelTypeInApp.String())
+	if err != nil {
+		log.Fatal("In-app sender is not registered", zap.Error(err))
+	}
+	inAppSender.(*external.InAppService).SetNotifications(inAppNotificationRepo, messaging.NewNATSInAppNotifier(natsClient, log))
+
+	// The deployment environment scopes the channel and template lists and the
+	// sends, every sender going through the guard keeping it away from prod
+	environment := shared.Environment(cfg.Server.Environment)
+	notificationServiceAdapter := services.NewEnvironmentGuard(external.NewNotificationServiceAdapter(notificationService), environment)
 
 	// Initialize domain services
 	templateRenderer := services.NewDefaultTemplateRenderer()
@@ -284,27 +876,289 @@ func buildContainer(db *database.GormDB, natsClient *messaging.NATSClient, log *
 		messageRepo,
 		templateRenderer,
 		notificationServiceAdapter,
-		log,
+		log.WithComponent("message_sender"),
 	)
 
+	messageSender.SetProgressNotifier(messaging.NewNATSMessageProgressNotifier(natsClient, log))
+
+	// Lock each message while it is delivered, across the replicas on PostgreSQL
+	if cfg.SendLock.Enabled {
+		var locker lock.Locker = lock.NewMemoryLocker()
+		if db.IsPostgreSQL() {
+			sqlDB, err := db.DB.DB()
+			if err != nil {
+				log.Fatal("Failed to get database connection for send locks", zap.Error(err))
+			}
+			locker = lock.NewPostgresLocker(sqlDB)
+		}
+		messageSender.SetLocker(locker, time.Duration(cfg.SendLock.TTL)*time.Second)
+	}
+
+	if cfg.ChannelHealth.Enabled {
+		messageSender.SetHealthMonitor(services.NewChannelHealthMonitor(
+			channelRepo,
+			messageRepo,
+			services.ChannelHealthPolicy{
+				Window:                 cfg.ChannelHealth.Window,
+				MinSamples:             cfg.ChannelHealth.MinSamples,
+				DegradedFailurePercent: cfg.ChannelHealth.DegradedFailurePercent,
+				DisableFailurePercent:  cfg.ChannelHealth.DisableFailurePercent,
+			},
+			messaging.NewNATSChannelHealthNotifier(natsClient, log),
+			log,
+		))
+	}
+
+	if len(cfg.Pricing.Prices) > 0 {
+		messageSender.SetPricing(&services.PricingPolicy{
+			Currency: cfg.Pricing.Currency,
+			Prices:   cfg.Pricing.Prices,
+		})
+	}
+
+	if cfg.Mirror.Enabled {
+		policy := services.TrafficMirrorPolicy{
+			Percent: cfg.Mirror.Percent,
+			Mode:    services.MirrorMode(cfg.Mirror.Mode),
+		}
+		if cfg.Mirror.ShadowChannelID != "" {
+			shadowChannelID, err := channel.NewChannelIDFromString(cfg.Mirror.ShadowChannelID)
+			if err != nil {
+				log.Fatal("Invalid shadow channel ID", zap.Error(err))
+			}
+			policy.ShadowChannelID = shadowChannelID
+		}
+		messageSender.SetMirror(services.NewTrafficMirror(channelRepo, notificationServiceAdapter, policy, log))
+	}
+
+	var failureDigest *services.FailureDigest
+	if cfg.FailureDigest.Enabled {
+		operationsChannelID, err := channel.NewChannelIDFromString(cfg.FailureDigest.ChannelID)
+		if err != nil {
+			log.Fatal("Invalid failure digest channel ID", zap.Error(err))
+		}
+		failureDigest = services.NewFailureDigest(messageRepo, channelRepo, notificationServiceAdapter, services.FailureDigestPolicy{
+			ChannelID: operationsChannelID,
+			MinFailed: cfg.FailureDigest.MinFailed,
+			MinHeld:   cfg.FailureDigest.MinHeld,
+		}, log)
+	}
+
+	// Purge and delete the messages past the retention windows of their tenants
+	retentionReportRepo := repository.NewRetentionReportRepositoryImpl(db.DB)
+	var retentionEnforcer *services.RetentionEnforcer
+	if cfg.Retention.Enabled {
+		retentionEnforcer = services.NewRetentionEnforcer(
+			repository.NewRetentionStoreImpl(db.DB),
+			retentionReportRepo,
+			retentionPolicy(cfg.Retention),
+			log,
+		)
+	}
+
+	// Measure the delivery latency objectives from the message timestamps
+	var sloTracker *services.SLOTracker
+	if cfg.SLO.Enabled {
+		policy := sloPolicy(cfg.SLO)
+		if cfg.SLO.AlertChannelID != "" {
+			alertChannelID, err := channel.NewChannelIDFromString(cfg.SLO.AlertChannelID)
+			if err != nil {
+				log.Fatal("Invalid SLO alert channel ID", zap.Error(err))
+			}
+			policy.ChannelID = alertChannelID
+		}
+		sloTracker = services.NewSLOTracker(messageRepo, channelRepo, notificationServiceAdapter, policy, log.WithComponent("slo"))
+	}
+	// Recipients without preferences receive every message
+	preferenceFilter := services.NewPreferenceFilter(userPreferenceRepo)
+	preferenceFilter.SetSubscriptions(categoryRepo, categorySubscriptionRepo)
+	messageSender.SetPreferences(preferenceFilter)
+
+	// Adapt the content of each send to its channel type, e.g. HTML to Slack mrkdwn
+	if cfg.ContentAdaptation.Enabled {
+		messageSender.SetContentAdaptation(services.NewContentAdaptation(shared.GetChannelTypeRegistry(), cfg.ContentAdaptation.LinkVariable))
+	}
+
+	// Shorten the links of SMS messages, counting their clicks
+	if cfg.LinkShortening.Enabled {
+		var shortener services.LinkShortener = services.NewInternalLinkShortener(shortLinkRepo, cfg.LinkShortening.BaseURL)
+		if cfg.LinkShortening.Provider == config.LinkShortenerBitly {
+			shortener = external.NewBitlyLinkShortener(shortener, cfg.LinkShortening.APIToken, cfg.LinkShortening.Domain,
+				time.Duration(cfg.LinkShortening.Timeout)*time.Second)
+		}
+		prefix := strings.TrimRight(cfg.LinkShortening.BaseURL, "/") + "/l/"
+		messageSender.SetLinkShortening(services.NewLinkShortening(shortener, prefix, log))
+	}
+
+	// Ask the recipients of the channels wanting it to acknowledge their messages
+	if cfg.Acknowledgment.Enabled {
+		messageSender.SetAcknowledgmentLinks(services.NewAcknowledgmentLinks(acknowledgmentRepo, cfg.Acknowledgment.BaseURL, log))
+	}
+
+	// Correlate the replies to the messages of the channels tracking them
+	if cfg.Replies.Enabled {
+		messageSender.SetReplyTracking(services.NewReplyTracking(replyRepo, cfg.Replies.InboundAddress, log))
+	}
+
+	// Run the pre-send policy hooks, recording their decisions
+	if cfg.SendPolicy.Enabled {
+		policy := services.NewSendPolicy(services.SendPolicyRules{
+			Hooks:    cfg.SendPolicy.Hooks,
+			Tenants:  cfg.SendPolicy.Tenants,
+			Channels: cfg.SendPolicy.Channels,
+		}, log)
+		policy.Register(services.NewPIIScanner(cfg.SendPolicy.PIIBlock))
+		policy.Register(services.NewProfanityFilter(cfg.SendPolicy.ProfanityWords, cfg.SendPolicy.ProfanityAnnotateOnly))
+		policy.Register(services.NewSizePolicy(cfg.SendPolicy.MaxBytes))
+		if cfg.SendPolicy.WebhookURL != "" {
+			policy.Register(external.NewWebhookPolicyHook(cfg.SendPolicy.WebhookURL, cfg.SendPolicy.WebhookSecret,
+				time.Duration(cfg.SendPolicy.WebhookTimeout)*time.Second))
+		}
+		messageSender.SetPolicy(policy)
+	}
+
+	// Scan the attachments for viruses before delivery
+	if cfg.AttachmentScan.Enabled {
+		scanner := external.NewClamAVScanner(cfg.AttachmentScan.Address, time.Duration(cfg.AttachmentScan.Timeout)*time.Second)
+		messageSender.SetAttachmentScanning(services.NewAttachmentScanning(scanner, quarantineRepo,
+			cfg.AttachmentScan.DefaultAction, cfg.AttachmentScan.FailOpen, log))
+	}
+
+	// Send email only from the verified identities of the tenants
+	if cfg.SenderIdentity.Enforced {
+		messageSender.SetSenderIdentityCheck(services.NewSenderIdentityCheck(senderIdentityRepo))
+	}
+
 	// Initialize channel use cases
 	createChannelUseCase := usecases.NewCreateChannelUseCase(channelRepo, templateRepo, channelValidator, cfg)
 	getChannelUseCase := usecases.NewGetChannelUseCase(channelRepo)
 	listChannelsUseCase := usecases.NewListChannelsUseCase(channelRepo)
+	listChannelsUseCase.SetDefaultEnvironment(environment)
 	updateChannelUseCase := usecases.NewUpdateChannelUseCase(channelRepo, templateRepo, channelValidator, cfg)
 	deleteChannelUseCase := usecases.NewDeleteChannelUseCase(channelRepo, channelValidator, cfg)
+	setChannelEnabledUseCase := usecases.NewSetChannelEnabledUseCase(channelRepo)
+	channelMaintenanceUseCase := usecases.NewChannelMaintenanceUseCase(channelRepo, messageSender)
+	checkDeliverabilityUseCase := usecases.NewCheckDeliverabilityUseCase(channelRepo, services.NewEmailDeliverability(net.DefaultResolver))
+	bulkChannelUseCase := usecases.NewBulkChannelUseCase(createChannelUseCase, getChannelUseCase, updateChannelUseCase, deleteChannelUseCase, setChannelEnabledUseCase)
+	connectionVerifier := external.NewChannelConnectionVerifier(messageSenderFactory)
+	checkChannelReadinessUseCase := usecases.NewCheckChannelReadinessUseCase(channelRepo, connectionVerifier)
 
 	// Initialize template use cases
 	createTemplateUseCase := templateusecases.NewCreateTemplateUseCase(templateRepo)
+	createTemplateUseCase.SetOwnershipRequired(cfg.Ownership.Required)
 	getTemplateUseCase := templateusecases.NewGetTemplateUseCase(templateRepo)
 	listTemplatesUseCase := templateusecases.NewListTemplatesUseCase(templateRepo)
+	listTemplatesUseCase.SetDefaultEnvironment(environment)
 	updateTemplateUseCase := templateusecases.NewUpdateTemplateUseCase(templateRepo, channelRepo, cfg)
-	deleteTemplateUseCase := templateusecases.NewDeleteTemplateUseCase(templateRepo, channelRepo, cfg)
+	templateIntegrity := services.NewTemplateIntegrityService(channelRepo)
+	deleteTemplateUseCase := templateusecases.NewDeleteTemplateUseCase(templateRepo, channelRepo, templateIntegrity, cfg)
+	getTemplateUsageUseCase := templateusecases.NewGetTemplateUsageUseCase(templateRepo, channelRepo, messageRepo)
+	lintTemplateUseCase := templateusecases.NewLintTemplateUseCase()
+	validateTemplateUseCase := templateusecases.NewValidateTemplateUseCase()
+	getCompatibilityUseCase := templateusecases.NewGetCompatibilityUseCase(shared.GetChannelTypeRegistry())
+
+	// Publish the channel and template changes for the services depending on them
+	var changeWebhooks *external.WebhookChangeNotifier
+	if cfg.ChangeEvents.Enabled {
+		changeNotifiers := shared.ChangeNotifiers{messaging.NewNATSChangeNotifier(natsClient, log)}
+		if len(cfg.ChangeEvents.WebhookURLs) > 0 {
+			changeWebhooks = external.NewWebhookChangeNotifier(
+				cfg.ChangeEvents.WebhookURLs,
+				cfg.ChangeEvents.WebhookSecret,
+				time.Duration(cfg.ChangeEvents.WebhookTimeout)*time.Second,
+				log,
+			)
+			changeNotifiers = append(changeNotifiers, changeWebhooks)
+		}
+		createChannelUseCase.SetChangeNotifier(changeNotifiers)
+		updateChannelUseCase.SetChangeNotifier(changeNotifiers)
+		deleteChannelUseCase.SetChangeNotifier(changeNotifiers)
+		setChannelEnabledUseCase.SetChangeNotifier(changeNotifiers)
+		createTemplateUseCase.SetChangeNotifier(changeNotifiers)
+		updateTemplateUseCase.SetChangeNotifier(changeNotifiers)
+		deleteTemplateUseCase.SetChangeNotifier(changeNotifiers)
+	}
 
 	// Initialize message use cases
 	sendMessageUseCase := messageusecases.NewSendMessageUseCase(messageRepo, channelRepo, templateRepo, messageSender, cfg)
 	getMessageUseCase := messageusecases.NewGetMessageUseCase(messageRepo)
 	listMessagesUseCase := messageusecases.NewListMessagesUseCase(messageRepo)
+	exportMessagesUseCase := messageusecases.NewExportMessagesUseCase(messageRepo)
+	acknowledgeCallUseCase := messageusecases.NewAcknowledgeCallUseCase(channelRepo, callAckRepo, voiceService)
+	followShortLinkUseCase := messageusecases.NewFollowShortLinkUseCase(shortLinkRepo)
+	getMessageLinksUseCase := messageusecases.NewGetMessageLinksUseCase(messageRepo, shortLinkRepo)
+	acknowledgeMessageUseCase := messageusecases.NewAcknowledgeMessageUseCase(acknowledgmentRepo)
+	getAcknowledgmentsUseCase := messageusecases.NewGetAcknowledgmentsUseCase(messageRepo, acknowledgmentRepo)
+	receiveReplyUseCase := messageusecases.NewReceiveReplyUseCase(channelRepo, replyRepo, smsService, cfg.Replies.InboundSecret)
+	receiveReplyUseCase.SetNotifier(messaging.NewNATSReplyNotifier(natsClient, log))
+	getRepliesUseCase := messageusecases.NewGetRepliesUseCase(messageRepo, replyRepo)
+	getMessageQuarantineUseCase := messageusecases.NewGetMessageQuarantineUseCase(messageRepo, quarantineRepo)
+	sendMessageUseCase.SetChannelGroupRepository(channelGroupRepo)
+	sendMessageUseCase.SetCategoryRepository(categoryRepo)
+	sendMessageUseCase.SetEnvironment(environment)
+	if cfg.Server.SendConcurrency > 0 {
+		sendMessageUseCase.SetIntake(services.NewSendIntake(
+			cfg.Server.SendConcurrency,
+			cfg.Server.SendQueueDepth,
+			time.Duration(cfg.Server.SendRetryAfter)*time.Second,
+		))
+	}
+
+	var quotaManager *services.QuotaManager
+	if cfg.Quota.Enabled {
+		quotaManager = services.NewQuotaManager(
+			repository.NewQuotaUsageRepositoryImpl(db.DB),
+			quotaPolicy(cfg.Quota),
+		)
+		sendMessageUseCase.SetQuotaManager(quotaManager)
+	}
+	getQuotaUsageUseCase := messageusecases.NewGetQuotaUsageUseCase(quotaManager)
+
+	// Schedule messages, at recipient-local times in the time zones of the
+	// recipients or of their preferences
+	scheduleMessageUseCase := messageusecases.NewScheduleMessageUseCase(
+		sendMessageUseCase,
+		scheduledSendRepo,
+		services.NewRecipientTimezones(userPreferenceRepo),
+	)
+	scheduledSendRunner := services.NewScheduledSendRunner(scheduledSendRepo, scheduleMessageUseCase, log)
+
+	// Defer the non-critical scheduled messages falling within a blackout
+	blackoutCalendar := services.NewBlackoutCalendar(blackoutRepo)
+	scheduleMessageUseCase.SetBlackoutCalendar(blackoutCalendar)
+	scheduledSendRunner.SetBlackoutCalendar(blackoutCalendar)
+	getBlackoutUseCase := blackoutusecases.NewGetBlackoutUseCase(blackoutRepo)
+	updateBlackoutUseCase := blackoutusecases.NewUpdateBlackoutUseCase(blackoutRepo)
+	otpUseCase := otpusecases.NewOTPUseCase(channelRepo, templateRepo, otpRepo, templateRenderer, notificationServiceAdapter, cfg.OTP)
+	otpUseCase.SetEnvironment(environment)
+	privacyRequestUseCase := privacyusecases.NewPrivacyRequestUseCase(privacyRequestRepo, privacyAuditLog, subjectDataStore)
+	listRetentionReportsUseCase := retentionusecases.NewListRetentionReportsUseCase(retentionReportRepo, cfg.Retention.Enabled)
+	getSLOStatusUseCase := slousecases.NewGetSLOStatusUseCase(sloTracker)
+
+	// Initialize analytics use cases
+	getCostReportUseCase := analyticsusecases.NewGetCostReportUseCase(messageRepo, cfg.Pricing.Currency)
+
+	// Initialize tag use cases
+	listTagsUseCase := tagusecases.NewListTagsUseCase(channelRepo, templateRepo)
+	mergeTagsUseCase := tagusecases.NewMergeTagsUseCase(channelRepo, templateRepo)
+
+	// Initialize channel group use cases
+	createChannelGroupUseCase := channelgroupusecases.NewCreateChannelGroupUseCase(channelGroupRepo, channelRepo)
+	getChannelGroupUseCase := channelgroupusecases.NewGetChannelGroupUseCase(channelGroupRepo)
+	updateChannelGroupUseCase := channelgroupusecases.NewUpdateChannelGroupUseCase(channelGroupRepo, channelRepo)
+	deleteChannelGroupUseCase := channelgroupusecases.NewDeleteChannelGroupUseCase(channelGroupRepo)
+
+	// Initialize provisioning use cases
+	provisionChannelUseCase := provisioningusecases.NewProvisionChannelUseCase(
+		provisioningSagaRepo,
+		createChannelUseCase,
+		deleteChannelUseCase,
+		setChannelEnabledUseCase,
+		channelRepo,
+		connectionVerifier,
+		notificationServiceAdapter,
+	)
+	getProvisioningSagaUseCase := provisioningusecases.NewGetProvisioningSagaUseCase(provisioningSagaRepo)
 
 	// Initialize health use cases
 	getSystemHealthUseCase := healthusecases.NewGetSystemHealthUseCase()
@@ -312,9 +1166,44 @@ func buildContainer(db *database.GormDB, natsClient *messaging.NATSClient, log *
 	getLegacyHealthUseCase := healthusecases.NewGetLegacyHealthUseCase()
 
 	// Initialize CQRS system
-	cqrsManager := cqrs.NewCQRSManager()
-	cqrsConfig := cqrs.DefaultCQRSConfig()
-	cqrsFacade := cqrs.NewCQRSFacade(cqrsManager, cqrsConfig)
+	eventBus := cqrs.NewDefaultEventBus()
+	eventBus.SetFailedEventRepository(failedEventRepo)
+	cqrsFacade := cqrs.NewCQRSFacadeBuilder(cqrs.DefaultCQRSConfig()).
+		WithEventBus(eventBus).
+		WithQueryRetry(3, 200*time.Millisecond).
+		Build()
+	cqrsManager := cqrsFacade.Manager()
+
+	// Initialize failed event use cases
+	getFailedEventUseCase := failedeventusecases.NewGetFailedEventUseCase(failedEventRepo)
+	replayFailedEventUseCase := failedeventusecases.NewReplayFailedEventUseCase(failedEventRepo, eventBus)
+
+	// Initialize in-app notification use cases
+	getInAppNotificationUseCase := inappusecases.NewGetInAppNotificationUseCase(inAppNotificationRepo)
+	updateInAppNotificationUseCase := inappusecases.NewUpdateInAppNotificationUseCase(inAppNotificationRepo)
+
+	// Initialize user preference use cases
+	getUserPreferenceUseCase := preferenceusecases.NewGetUserPreferenceUseCase(userPreferenceRepo)
+	updateUserPreferenceUseCase := preferenceusecases.NewUpdateUserPreferenceUseCase(userPreferenceRepo)
+
+	// Initialize category use cases
+	getCategoryUseCase := categoryusecases.NewGetCategoryUseCase(categoryRepo)
+	updateCategoryUseCase := categoryusecases.NewUpdateCategoryUseCase(categoryRepo)
+	getSubscriptionUseCase := categoryusecases.NewGetSubscriptionUseCase(categoryRepo, categorySubscriptionRepo)
+	updateSubscriptionUseCase := categoryusecases.NewUpdateSubscriptionUseCase(categoryRepo, categorySubscriptionRepo)
+
+	// Initialize sender identity use cases
+	var identityConfirmation *services.IdentityConfirmation
+	if cfg.SenderIdentity.ConfirmationChannelID != "" {
+		confirmationChannelID, err := channel.NewChannelIDFromString(cfg.SenderIdentity.ConfirmationChannelID)
+		if err != nil {
+			log.Fatal("Invalid sender identity confirmation channel ID", zap.Error(err))
+		}
+		identityConfirmation = services.NewIdentityConfirmation(channelRepo, notificationServiceAdapter,
+			confirmationChannelID, cfg.SenderIdentity.BaseURL)
+	}
+	getSenderIdentityUseCase := identityusecases.NewGetSenderIdentityUseCase(senderIdentityRepo)
+	updateSenderIdentityUseCase := identityusecases.NewUpdateSenderIdentityUseCase(senderIdentityRepo, net.DefaultResolver, identityConfirmation)
 
 	// Initialize CQRS handlers
 	channelCommandHandlers := channelcqrs.NewChannelCommandHandlers(
@@ -430,31 +1319,120 @@ func buildContainer(db *database.GormDB, natsClient *messaging.NATSClient, log *
 		ChannelRepo:  *channelRepo,
 		TemplateRepo: *templateRepo,
 		MessageRepo:  *messageRepo,
+		NATSInbox:    natsInboxRepo,
 
 		// Services
 		MessageSender:       messageSender,
 		ChannelValidator:    channelValidator,
 		TemplateRenderer:    templateRenderer,
 		NotificationService: notificationService,
+		SenderFactory:       messageSenderFactory,
+		FailureDigest:       failureDigest,
+		Retention:           retentionEnforcer,
+		SLOTracker:          sloTracker,
+		ScheduledSends:      scheduledSendRunner,
+		ChangeWebhooks:      changeWebhooks,
+
+		ReadOnly: shared.NewReadOnlyMode(cfg.Server.ReadOnly),
 
 		// Use Cases - Channel
-		CreateChannelUseCase: createChannelUseCase,
-		GetChannelUseCase:    getChannelUseCase,
-		ListChannelsUseCase:  listChannelsUseCase,
-		UpdateChannelUseCase: updateChannelUseCase,
-		DeleteChannelUseCase: deleteChannelUseCase,
+		CreateChannelUseCase:      createChannelUseCase,
+		GetChannelUseCase:         getChannelUseCase,
+		ListChannelsUseCase:       listChannelsUseCase,
+		UpdateChannelUseCase:      updateChannelUseCase,
+		DeleteChannelUseCase:      deleteChannelUseCase,
+		SetChannelEnabledUseCase:  setChannelEnabledUseCase,
+		BulkChannelUseCase:        bulkChannelUseCase,
+		ChannelMaintenanceUseCase: channelMaintenanceUseCase,
+
+		CheckChannelReadinessUseCase: checkChannelReadinessUseCase,
+		CheckDeliverabilityUseCase:   checkDeliverabilityUseCase,
 
 		// Use Cases - Template
-		CreateTemplateUseCase: createTemplateUseCase,
-		GetTemplateUseCase:    getTemplateUseCase,
-		ListTemplatesUseCase:  listTemplatesUseCase,
-		UpdateTemplateUseCase: updateTemplateUseCase,
-		DeleteTemplateUseCase: deleteTemplateUseCase,
+		CreateTemplateUseCase:   createTemplateUseCase,
+		GetTemplateUseCase:      getTemplateUseCase,
+		ListTemplatesUseCase:    listTemplatesUseCase,
+		UpdateTemplateUseCase:   updateTemplateUseCase,
+		DeleteTemplateUseCase:   deleteTemplateUseCase,
+		GetTemplateUsageUseCase: getTemplateUsageUseCase,
+		LintTemplateUseCase:     lintTemplateUseCase,
+		ValidateTemplateUseCase: validateTemplateUseCase,
+		GetCompatibilityUseCase: getCompatibilityUseCase,
 
 		// Use Cases - Message
-		SendMessageUseCase:  sendMessageUseCase,
-		GetMessageUseCase:   getMessageUseCase,
-		ListMessagesUseCase: listMessagesUseCase,
+		SendMessageUseCase:     sendMessageUseCase,
+		GetMessageUseCase:      getMessageUseCase,
+		ListMessagesUseCase:    listMessagesUseCase,
+		GetQuotaUsageUseCase:   getQuotaUsageUseCase,
+		ExportMessagesUseCase:  exportMessagesUseCase,
+		AcknowledgeCallUseCase: acknowledgeCallUseCase,
+		FollowShortLinkUseCase: followShortLinkUseCase,
+		GetMessageLinksUseCase: getMessageLinksUseCase,
+
+		AcknowledgeMessageUseCase: acknowledgeMessageUseCase,
+		GetAcknowledgmentsUseCase: getAcknowledgmentsUseCase,
+
+		ReceiveReplyUseCase: receiveReplyUseCase,
+		GetRepliesUseCase:   getRepliesUseCase,
+
+		GetMessageQuarantineUseCase: getMessageQuarantineUseCase,
+
+		ScheduleMessageUseCase: scheduleMessageUseCase,
+
+		// Use Cases - Analytics
+		GetCostReportUseCase: getCostReportUseCase,
+
+		// Use Cases - Tag
+		ListTagsUseCase:  listTagsUseCase,
+		MergeTagsUseCase: mergeTagsUseCase,
+
+		// Use Cases - Channel Group
+		CreateChannelGroupUseCase: createChannelGroupUseCase,
+		GetChannelGroupUseCase:    getChannelGroupUseCase,
+		UpdateChannelGroupUseCase: updateChannelGroupUseCase,
+		DeleteChannelGroupUseCase: deleteChannelGroupUseCase,
+
+		// Use Cases - Provisioning
+		ProvisionChannelUseCase:    provisionChannelUseCase,
+		GetProvisioningSagaUseCase: getProvisioningSagaUseCase,
+
+		// Use Cases - Failed Event
+		GetFailedEventUseCase:    getFailedEventUseCase,
+		ReplayFailedEventUseCase: replayFailedEventUseCase,
+
+		// Use Cases - In-App Notification
+		GetInAppNotificationUseCase:    getInAppNotificationUseCase,
+		UpdateInAppNotificationUseCase: updateInAppNotificationUseCase,
+
+		// Use Cases - User Preference
+		GetUserPreferenceUseCase:    getUserPreferenceUseCase,
+		UpdateUserPreferenceUseCase: updateUserPreferenceUseCase,
+
+		// Use Cases - Category
+		GetCategoryUseCase:        getCategoryUseCase,
+		UpdateCategoryUseCase:     updateCategoryUseCase,
+		GetSubscriptionUseCase:    getSubscriptionUseCase,
+		UpdateSubscriptionUseCase: updateSubscriptionUseCase,
+
+		// Use Cases - Sender Identity
+		GetSenderIdentityUseCase:    getSenderIdentityUseCase,
+		UpdateSenderIdentityUseCase: updateSenderIdentityUseCase,
+
+		// Use Cases - Blackout Calendar
+		GetBlackoutUseCase:    getBlackoutUseCase,
+		UpdateBlackoutUseCase: updateBlackoutUseCase,
+
+		// Use Cases - One-Time Verification Codes
+		OTPUseCase: otpUseCase,
+
+		// Use Cases - Data Subject Requests
+		PrivacyRequestUseCase: privacyRequestUseCase,
+
+		// Use Cases - Retention
+		ListRetentionReportsUseCase: listRetentionReportsUseCase,
+
+		// Use Cases - SLO
+		GetSLOStatusUseCase: getSLOStatusUseCase,
 
 		// Use Cases - Health
 		GetSystemHealthUseCase: getSystemHealthUseCase,
@@ -471,3 +1449,51 @@ func buildContainer(db *database.GormDB, natsClient *messaging.NATSClient, log *
 		Config:     cfg,
 	}
 }
+
+// quotaPolicy converts the quota configuration into the quota policy
+func quotaPolicy(cfg config.QuotaConfig) services.QuotaPolicy {
+	policy := services.QuotaPolicy{
+		Tenant:   quota.Limit{Daily: cfg.TenantDaily, Monthly: cfg.TenantMonthly},
+		Channel:  quota.Limit{Daily: cfg.ChannelDaily, Monthly: cfg.ChannelMonthly},
+		Tenants:  make(map[string]quota.Limit, len(cfg.Tenants)),
+		Channels: make(map[string]quota.Limit, len(cfg.Channels)),
+	}
+	for tenantID, limit := range cfg.Tenants {
+		policy.Tenants[tenantID] = quota.Limit{Daily: limit.Daily, Monthly: limit.Monthly}
+	}
+	for channelID, limit := range cfg.Channels {
+		policy.Channels[channelID] = quota.Limit{Daily: limit.Daily, Monthly: limit.Monthly}
+	}
+	return policy
+}
+
+// retentionPolicy converts the retention configuration into the retention policy
+func retentionPolicy(cfg config.RetentionConfig) retention.Policy {
+	days := func(n int) time.Duration { return time.Duration(n) * 24 * time.Hour }
+	policy := retention.Policy{
+		Default: retention.Window{Content: days(cfg.ContentDays), Metadata: days(cfg.MetadataDays)},
+		Tenants: make(map[string]retention.Window, len(cfg.Tenants)),
+	}
+	for tenantID, window := range cfg.Tenants {
+		policy.Tenants[tenantID] = retention.Window{Content: days(window.ContentDays), Metadata: days(window.MetadataDays)}
+	}
+	return policy
+}
+
+// sloPolicy converts the SLO configuration into the SLO policy, with the
+// default burn-rate alerts
+func sloPolicy(cfg config.SLOConfig) services.SLOPolicy {
+	policy := services.SLOPolicy{
+		Objectives: make([]slo.Objective, 0, len(cfg.Objectives)),
+		Alerts:     slo.DefaultBurnRateAlerts,
+	}
+	for _, objective := range cfg.Objectives {
+		policy.Objectives = append(policy.Objectives, slo.Objective{
+			Name:      objective.Name,
+			Threshold: time.Duration(objective.Threshold) * time.Second,
+			Target:    objective.Target / 100,
+			Window:    time.Duration(objective.WindowDays) * 24 * time.Hour,
+		})
+	}
+	return policy
+}
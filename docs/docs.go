@@ -15,162 +15,153 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/channels": {
+        "/ack/{token}": {
             "get": {
-                "description": "Retrieves a list of all channels, with optional filtering by channel type and tags, and pagination, using CQRS.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Record the acknowledgment of a message by the recipient its link was sent to, or by someone else when by is set; a message already acknowledged keeps its first acknowledgment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "messages"
                 ],
-                "summary": "List all channels (CQRS)",
+                "summary": "Acknowledge a message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by channel type (e.g., email, sms)",
-                        "name": "channelType",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by tags (comma-separated)",
-                        "name": "tags",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Number of records to skip for pagination",
-                        "name": "skipCount",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Maximum number of records to return per page (1-100)",
-                        "name": "maxResultCount",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by enabled status",
-                        "name": "enabled",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Field to sort by",
-                        "name": "sortField",
-                        "in": "query"
+                        "description": "Acknowledgment token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "default": "asc",
-                        "description": "Sort order (asc or desc)",
-                        "name": "sortOrder",
+                        "description": "Who acknowledges the message, the recipient by default",
+                        "name": "by",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with channels list",
+                        "description": "Success response with the acknowledgment",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid query parameters",
+                        "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Acknowledgment not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/acknowledgments/pending": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a new channel with the provided details.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the acknowledgments still pending for longer than a duration, oldest first, e.g. to escalate the messages unacknowledged after 15 minutes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "messages"
                 ],
-                "summary": "Create a new channel",
+                "summary": "List the unacknowledged messages",
                 "parameters": [
                     {
-                        "description": "Create Channel Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/notification_internal_application_channel_dtos.CreateChannelRequest"
-                        }
+                        "type": "string",
+                        "description": "Pending for at least this duration, such as 15m",
+                        "name": "olderThan",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "type": "integer",
+                        "description": "Number of acknowledgments listed, 100 by default",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Success response with channel data",
+                    "200": {
+                        "description": "Success response with the pending acknowledgments",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid input or validation error",
+                        "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
-                    "409": {
-                        "description": "Conflict - Channel with the same name already exists",
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/channels/readiness": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Check the credentials of every enabled channel at its provider without sending anything: SMTP authentication, SMS API keys, Slack tokens, and the reachability of webhooks and other providers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Check the channel credentials",
+                "responses": {
+                    "200": {
+                        "description": "Success response with the readiness of each channel and the count by status",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/channels/{id}": {
+        "/api/v1/admin/failed-events": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves a single channel's details using its unique identifier via CQRS.",
+                "description": "List the event handler executions that failed on every attempt, most recent first",
                 "consumes": [
                     "application/json"
                 ],
@@ -178,51 +169,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "channels-cqrs"
+                    "admin"
                 ],
-                "summary": "Get a channel by ID (CQRS)",
+                "summary": "List failed events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "failed or replayed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type",
+                        "name": "eventType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of failed events to skip",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of failed events to return",
+                        "name": "maxResultCount",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with channel data",
+                        "description": "Success response with the failed events",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid channel ID format",
+                        "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found - Channel with specified ID does not exist",
+                    "422": {
+                        "description": "Validation failed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing channel's details using its unique identifier via CQRS.",
+            }
+        },
+        "/api/v1/admin/failed-events/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Replay the failed events not replayed yet, oldest first, once the downstream recovered",
                 "consumes": [
                     "application/json"
                 ],
@@ -230,60 +242,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "admin"
                 ],
-                "summary": "Update an existing channel (CQRS)",
+                "summary": "Replay pending failed events",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Channel ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Channel Request",
+                        "description": "Replay failed events request",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/notification_internal_application_channel_dtos.UpdateChannelRequest"
+                            "$ref": "#/definitions/notification_internal_application_failedevent_dtos.ReplayFailedEventsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with updated channel data",
+                        "description": "Success response with the replay outcome",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid input or validation error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found - Channel with specified ID does not exist",
+                        "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a channel using its unique identifier via CQRS.",
+            }
+        },
+        "/api/v1/admin/failed-events/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Get a failed event handler execution with the event payload",
                 "consumes": [
                     "application/json"
                 ],
@@ -291,13 +293,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "admin"
                 ],
-                "summary": "Delete a channel by ID (CQRS)",
+                "summary": "Get a failed event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel ID",
+                        "description": "Failed event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -305,44 +307,35 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with deletion confirmation",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request - Invalid channel ID format",
+                        "description": "Success response with the failed event",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Not Found - Channel with specified ID does not exist",
+                        "description": "Failed event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v2/channels": {
+        "/api/v1/admin/failed-events/{id}/replay": {
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
                     }
                 ],
-                "description": "Creates a new channel with the provided details using CQRS pattern.",
+                "description": "Hand a failed event to the handler that failed on it again",
                 "consumes": [
                     "application/json"
                 ],
@@ -350,112 +343,85 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "channels-cqrs"
+                    "admin"
                 ],
-                "summary": "Create a new channel (CQRS)",
+                "summary": "Replay a failed event",
                 "parameters": [
                     {
-                        "description": "Create Channel Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/notification_internal_application_channel_dtos.CreateChannelRequest"
-                        }
+                        "type": "string",
+                        "description": "Failed event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Success response with channel data",
+                    "200": {
+                        "description": "Success response with the replayed event",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request - Invalid input or validation error",
+                    "404": {
+                        "description": "Failed event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already replayed or the handler is no longer subscribed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "The handler failed again",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v2/channels/{id}": {
+        "/api/v1/admin/log-levels": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves a single channel's details using its unique identifier via CQRS.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Report the default log level of the instance and the levels of the modules overriding it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "channels-cqrs"
-                ],
-                "summary": "Get a channel by ID (CQRS)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Channel ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Get the log levels",
                 "responses": {
                     "200": {
-                        "description": "Success response with channel data",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request - Invalid channel ID format",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found - Channel with specified ID does not exist",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Success response with the log levels",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/api/v2/messages": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
                     }
                 ],
-                "description": "Retrieve a list of messages with optional filtering via CQRS pattern",
+                "description": "Set the default log level of the instance, or the level of one module, e.g. send_worker, until the instance restarts",
                 "consumes": [
                     "application/json"
                 ],
@@ -463,40 +429,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages-cqrs"
+                    "admin"
                 ],
-                "summary": "List messages (CQRS)",
+                "summary": "Set a log level",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by channel ID",
-                        "name": "channelId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by message status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Number of items to skip",
-                        "name": "skipCount",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Maximum number of items to return",
-                        "name": "maxResultCount",
-                        "in": "query"
+                        "description": "Log level request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_presentation_http_handlers.SetLogLevelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with messages list",
+                        "description": "Success response with the log levels",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -505,66 +454,45 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "422": {
+                        "description": "Validation failed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v2/messages/send": {
-            "post": {
+        "/api/v1/admin/log-levels/{module}": {
+            "delete": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
                     }
                 ],
-                "description": "Send a message to multiple channels using a template via CQRS pattern",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Make a module log from the default level of the instance again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "messages-cqrs"
+                    "admin"
                 ],
-                "summary": "Send a message (CQRS)",
+                "summary": "Reset the log level of a module",
                 "parameters": [
                     {
-                        "description": "Send message request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/notification_internal_application_message_dtos.SendMessageRequest"
-                        }
+                        "type": "string",
+                        "description": "Module",
+                        "name": "module",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Success response with message data",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    "200": {
+                        "description": "Success response with the log levels",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -573,24 +501,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v2/messages/{id}": {
+        "/api/v1/admin/messages/{id}/quarantine": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
                     }
                 ],
-                "description": "Retrieve a specific message by its ID via CQRS pattern",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the infected attachments the virus scan kept back from the sends of a message, with the virus found in each",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "messages-cqrs"
+                    "admin"
                 ],
-                "summary": "Get a message by ID (CQRS)",
+                "summary": "List the quarantined attachments of a message",
                 "parameters": [
                     {
                         "type": "string",
@@ -602,37 +527,65 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with message data",
+                        "description": "Success response with the quarantined attachments",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
                     "404": {
                         "description": "Message not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v2/templates": {
-            "post": {
+        "/api/v1/admin/read-only": {
+            "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Report whether the instance serves queries only, rejecting commands and pausing its workers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the read-only mode",
+                "responses": {
+                    "200": {
+                        "description": "Success response with the read-only mode",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
                     }
                 ],
-                "description": "Create a new message template for a specific channel type via CQRS pattern",
+                "description": "Enter read-only mode to serve queries only, as a warm standby or during a maintenance window, or leave it to accept commands and resume the workers",
                 "consumes": [
                     "application/json"
                 ],
@@ -640,23 +593,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates-cqrs"
+                    "admin"
                 ],
-                "summary": "Create a new template (CQRS)",
+                "summary": "Switch the read-only mode",
                 "parameters": [
                     {
-                        "description": "Create template request",
+                        "description": "Read-only mode request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/notification_internal_application_template_dtos.CreateTemplateRequest"
+                            "$ref": "#/definitions/internal_presentation_http_handlers.SetReadOnlyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Template created successfully",
+                    "200": {
+                        "description": "Success response with the read-only mode",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -665,28 +618,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v2/templates/{id}": {
+        "/api/v1/analytics/costs": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a specific template by its ID via CQRS pattern",
+                "description": "Sum the estimated cost of the sends per channel, tag, tenant or message class, from the channel prices configured when they were sent",
                 "consumes": [
                     "application/json"
                 ],
@@ -694,99 +639,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates-cqrs"
+                    "analytics"
                 ],
-                "summary": "Get a template by ID (CQRS)",
+                "summary": "Get estimated send costs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "channel",
+                        "description": "Group by channel, tag, tenant or class",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Messages created at or after, Unix milliseconds",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Messages created before, Unix milliseconds",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with template data",
+                        "description": "Success response with the cost report",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Template not found",
+                    "422": {
+                        "description": "Invalid grouping or time range",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/messages": {
+        "/api/v1/blackouts": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of messages with optional filtering",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the blackouts of the organization calendar by start, only those not over yet with upcoming=true",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "blackouts"
                 ],
-                "summary": "List messages",
+                "summary": "List the blackouts",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by channel ID",
-                        "name": "channelId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by message status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Number of items to skip",
-                        "name": "skipCount",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Maximum number of items to return",
-                        "name": "maxResultCount",
+                        "type": "boolean",
+                        "description": "Only the blackouts not over yet",
+                        "name": "upcoming",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with messages list",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Success response with the blackouts",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -795,8 +720,7 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
@@ -807,7 +731,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Send a message to multiple channels using a template",
+                "description": "Add a period such as a change freeze or a holiday to the organization calendar; the non-critical scheduled messages falling within it are deferred to its end",
                 "consumes": [
                     "application/json"
                 ],
@@ -815,23 +739,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "blackouts"
                 ],
-                "summary": "Send a message",
+                "summary": "Create a blackout",
                 "parameters": [
                     {
-                        "description": "Send message request",
+                        "description": "Blackout request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/notification_internal_application_message_dtos.SendMessageRequest"
+                            "$ref": "#/definitions/notification_internal_application_blackout_dtos.BlackoutRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Success response with message data",
+                    "201": {
+                        "description": "Success response with the blackout",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -840,42 +764,43 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/messages/{id}": {
+        "/api/v1/blackouts/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a specific message by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a blackout of the organization calendar by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "blackouts"
                 ],
-                "summary": "Get a message by ID",
+                "summary": "Get a blackout",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Message ID",
+                        "description": "Blackout ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -883,37 +808,33 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with message data",
+                        "description": "Success response with the blackout",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Message not found",
+                        "description": "Blackout not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/plugins": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a list of all loaded plugins with their statuses",
+                "description": "Replace the name, description and period of a blackout; the scheduled messages are deferred by the new period when they come due",
                 "consumes": [
                     "application/json"
                 ],
@@ -921,35 +842,148 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "blackouts"
                 ],
-                "summary": "List all loaded plugins",
-                "responses": {
-                    "200": {
-                        "description": "Success response with plugins list",
-                        "schema": {
+                "summary": "Replace a blackout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blackout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Blackout request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_blackout_dtos.BlackoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the blackout",
+                        "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Blackout not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a blackout from the organization calendar; the messages it deferred go out at the time they were deferred to",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blackouts"
+                ],
+                "summary": "Delete a blackout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blackout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Blackout not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
                     }
                 }
             }
         },
-        "/plugins/load": {
+        "/api/v1/categories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List every category by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "List the categories",
+                "responses": {
+                    "200": {
+                        "description": "Success response with the categories",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Load a new plugin from Go source code using Yaegi interpreter",
+                "description": "Create a category messages can be sent in, received by its subscribers or, when subscribed by default, by every recipient not unsubscribing",
                 "consumes": [
                     "application/json"
                 ],
@@ -957,23 +991,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "categories"
                 ],
-                "summary": "Load a plugin from source code",
+                "summary": "Create a category",
                 "parameters": [
                     {
-                        "description": "Load plugin request",
+                        "description": "Create category request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_presentation_http_handlers.LoadPluginRequest"
+                            "$ref": "#/definitions/notification_internal_application_category_dtos.CreateCategoryRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Plugin loaded successfully",
+                    "201": {
+                        "description": "Success response with the category",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -982,28 +1016,38 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A category with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/plugins/load-file": {
-            "post": {
+        "/api/v1/categories/{name}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Load a plugin from a file path on the server",
+                "description": "Get a category by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -1011,56 +1055,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "categories"
                 ],
-                "summary": "Load a plugin from file path",
+                "summary": "Get a category",
                 "parameters": [
                     {
-                        "description": "Load plugin from file request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Category name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Plugin loaded successfully",
+                        "description": "Success response with the category",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/plugins/{name}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get the status and information of a specific plugin",
+                "description": "Update the description of a category and whether recipients are subscribed to it by default; the choices of the recipients are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -1068,38 +1103,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "categories"
                 ],
-                "summary": "Get plugin status by name",
+                "summary": "Update a category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Plugin name",
+                        "description": "Category name",
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update category request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_category_dtos.UpdateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with plugin status",
+                        "description": "Success response with the category",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
                     "404": {
-                        "description": "Plugin not found",
+                        "description": "Category not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
@@ -1110,7 +1164,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Unload a specific plugin by name",
+                "description": "Delete a category and the subscriptions to it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1118,13 +1172,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "plugins"
+                    "categories"
                 ],
-                "summary": "Unload a plugin",
+                "summary": "Delete a category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Plugin name",
+                        "description": "Category name",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -1132,37 +1186,35 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Plugin unloaded successfully",
+                        "description": "Success response",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Plugin not found",
+                        "description": "Category not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/templates": {
+        "/api/v1/channel-groups": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of templates with optional filtering",
+                "description": "List channel groups ordered by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -1170,44 +1222,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "channel-groups"
                 ],
-                "summary": "List templates",
+                "summary": "List channel groups",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by channel type",
-                        "name": "channelType",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by tags",
-                        "name": "tags",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "Number of records to skip for pagination",
+                        "description": "Number of groups to skip",
                         "name": "skipCount",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 20,
-                        "description": "Maximum number of records to return per page (1-100)",
+                        "description": "Maximum number of groups to return",
                         "name": "maxResultCount",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with templates list",
+                        "description": "Success response with the channel groups",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1216,15 +1250,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
@@ -1235,7 +1267,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new message template for a specific channel type",
+                "description": "Create a named group of channels that send requests can target with one ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1243,23 +1275,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "channel-groups"
                 ],
-                "summary": "Create a new template",
+                "summary": "Create a channel group",
                 "parameters": [
                     {
-                        "description": "Create template request",
+                        "description": "Create channel group request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/notification_internal_application_template_dtos.CreateTemplateRequest"
+                            "$ref": "#/definitions/notification_internal_application_channelgroup_dtos.CreateChannelGroupRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Template created successfully",
+                        "description": "Success response with the channel group",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1268,28 +1300,38 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A channel group with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
             }
         },
-        "/templates/{id}": {
+        "/api/v1/channel-groups/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a specific template by its ID",
+                "description": "Get a channel group and its member channel IDs",
                 "consumes": [
                     "application/json"
                 ],
@@ -1297,13 +1339,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "channel-groups"
                 ],
-                "summary": "Get a template by ID",
+                "summary": "Get a channel group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
+                        "description": "Channel group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1311,24 +1353,22 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with template data",
+                        "description": "Success response with the channel group",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Template not found",
+                        "description": "Channel group not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
@@ -1339,7 +1379,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing template by its ID",
+                "description": "Update the name and description of a channel group",
                 "consumes": [
                     "application/json"
                 ],
@@ -1347,30 +1387,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "channel-groups"
                 ],
-                "summary": "Update a template",
+                "summary": "Update a channel group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
+                        "description": "Channel group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update template request",
+                        "description": "Update channel group request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/notification_internal_application_template_dtos.UpdateTemplateRequest"
+                            "$ref": "#/definitions/notification_internal_application_channelgroup_dtos.UpdateChannelGroupRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Template updated successfully",
+                        "description": "Success response with the channel group",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1379,22 +1419,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "404": {
-                        "description": "Template not found",
+                        "description": "Channel group not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A channel group with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
                         }
                     }
                 }
@@ -1405,7 +1454,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing template by its ID",
+                "description": "Delete a channel group; its member channels are left untouched",
                 "consumes": [
                     "application/json"
                 ],
@@ -1413,13 +1462,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "channel-groups"
                 ],
-                "summary": "Delete a template",
+                "summary": "Delete a channel group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
+                        "description": "Channel group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1427,67 +1476,7303 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Template deleted successfully",
+                        "description": "Success response",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Template not found",
+                        "description": "Channel group not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channel-groups/{id}/members": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add channels to a channel group; channels already in the group are ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channel-groups"
+                ],
+                "summary": "Add channels to a channel group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channel IDs to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channelgroup_dtos.ChannelGroupMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the channel group",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Channel group not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channel-groups/{id}/members/remove": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove channels from a channel group; channels not in the group are ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channel-groups"
+                ],
+                "summary": "Remove channels from a channel group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channel IDs to remove",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channelgroup_dtos.ChannelGroupMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the channel group",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Channel group not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_presentation_http_handlers.LoadPluginRequest": {
-            "type": "object",
-            "required": [
-                "name",
-                "source"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
+        },
+        "/api/v1/channels": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of all channels, with optional filtering by channel type and tags, and pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "List all channels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by channel type (e.g., email, sms)",
+                        "name": "channelType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of records to skip for pagination",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of records to return per page (1-100)",
+                        "name": "maxResultCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by referenced template ID",
+                        "name": "templateId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by owner",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by team",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by environment (dev, staging or prod), including unscoped channels; all lists every environment. Defaults to the server environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only channels last used before this Unix millisecond timestamp, including never-used channels",
+                        "name": "lastUsedBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only channels last used at or after this Unix millisecond timestamp",
+                        "name": "lastUsedAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only channels not used within this many days",
+                        "name": "unusedForDays",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "channelName",
+                            "channelType",
+                            "enabled",
+                            "createdAt",
+                            "updatedAt",
+                            "lastUsed"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sortField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order (asc or desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. channelId,channelName,enabled",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with channels list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Unsupported sort field or order, or invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new channel with the provided details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Create a new channel",
+                "parameters": [
+                    {
+                        "description": "Create Channel Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.CreateChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success response with channel data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Channel with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - Legacy system unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates every channel in the array. Each item succeeds or fails on its own.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Create channels in bulk",
+                "parameters": [
+                    {
+                        "description": "Create channels in bulk Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.BulkCreateChannelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-item results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Empty or oversized batch",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/bulk/delete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes every listed channel.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Delete channels in bulk",
+                "parameters": [
+                    {
+                        "description": "Delete channels in bulk Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.BulkChannelIDsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-item results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Empty or oversized batch",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/bulk/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Disables every listed channel.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Disable channels in bulk",
+                "parameters": [
+                    {
+                        "description": "Disable channels in bulk Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.BulkChannelIDsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-item results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Empty or oversized batch",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/bulk/enable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enables every listed channel.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Enable channels in bulk",
+                "parameters": [
+                    {
+                        "description": "Enable channels in bulk Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.BulkChannelIDsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-item results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Empty or oversized batch",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/bulk/tag": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds and removes tags on every listed channel.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Tag channels in bulk",
+                "parameters": [
+                    {
+                        "description": "Tag channels in bulk Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.BulkTagChannelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-item results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Empty or oversized batch",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/by-name/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single channel's details using its unique name, e.g. to import existing resources.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Get a channel by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. channelId,channelName,enabled",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with channel data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified - Channel unchanged since the given entity tag"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel name",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified name does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single channel's details using its unique identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Get a channel by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. channelId,channelName,enabled",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with channel data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified - Channel unchanged since the given entity tag"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates an existing channel's details using its unique identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Update an existing channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Update Channel Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.UpdateChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Channel with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - Channel was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - Legacy system unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a channel using its unique identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Delete a channel by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - Channel was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - Legacy system unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates only the provided fields of a channel. Config keys are merged into the existing configuration; a key set to null removes it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Partially update a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Patch Channel Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.PatchChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Channel with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - Channel was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - Legacy system unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/{id}/deliverability": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Checks the SPF, DKIM and DMARC DNS records of the sender domain of an email channel and reports their problems. The DKIM keys are looked up at the given selectors, else at the dkim_selector of the channel config, else at the common ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Check the email deliverability of a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "DKIM selectors, comma separated",
+                        "name": "selector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the check of each record",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID or not an email channel",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/{id}/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Disables a channel without resubmitting its configuration.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Disable a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - Channel was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/{id}/enable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enables a channel without resubmitting its configuration.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Enable a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - Channel was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/{id}/maintenance": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Parks the messages for the channel with status held instead of sending them, until maintenance ends.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Put a channel in maintenance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ends maintenance and sends the messages parked for the channel, oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Take a channel out of maintenance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the number of released messages",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/channels/{id}/maintenance/release": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sends the messages still parked for a channel that is out of maintenance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Release held messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the number of released messages",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Channel is in maintenance",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/compatibility": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List, for each channel type, whether it sends HTML, attachments and subjects and the longest content and subject it accepts, so that templates are only paired with channels able to send them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List the template content each channel type supports",
+                "responses": {
+                    "200": {
+                        "description": "Success response with the content capabilities of each channel type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/identities": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the sender identities of a tenant, or of every tenant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "identities"
+                ],
+                "summary": "List the sender identities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the sender identities",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a pending sender identity of a tenant: an address, emailed a confirmation link, or a domain, verified by publishing the DNS TXT record of the response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "identities"
+                ],
+                "summary": "Create a sender identity",
+                "parameters": [
+                    {
+                        "description": "Create sender identity request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_identity_dtos.CreateSenderIdentityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success response with the sender identity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The tenant already has the sender identity",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/identities/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a sender identity by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "identities"
+                ],
+                "summary": "Get a sender identity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sender identity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the sender identity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Sender identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a sender identity; email channels sending from it are refused afterwards when identities are enforced",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "identities"
+                ],
+                "summary": "Delete a sender identity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sender identity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Sender identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/identities/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Verify a domain by looking up its DNS TXT record, or email the confirmation link of an address again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "identities"
+                ],
+                "summary": "Verify a sender identity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sender identity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the sender identity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Sender identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Verification record not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/messages": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of messages with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "List messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by channel ID",
+                        "name": "channelId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by message status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of items to return",
+                        "name": "maxResultCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,status,createdAt",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with messages list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send a message to multiple channels using a template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Send a message",
+                "parameters": [
+                    {
+                        "description": "Send message request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_message_dtos.SendMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with message data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Prod channel or template refused to a deployment below prod",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Send quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Send queue full, retry after the Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/messages/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the messages matching the filter, oldest first, as NDJSON (one message per line) or CSV (one row per message result). The export is sent in chunks while it is read, so it is not paginated. An export failing after the response started ends early with the error code in the X-Export-Error trailer.",
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Export messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "Export format: ndjson or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by channel ID",
+                        "name": "channelId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by message status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tenant ID",
+                        "name": "tenantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Earliest creation time in Unix milliseconds, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Latest creation time in Unix milliseconds, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported messages",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/messages/quota": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the daily and monthly send quota usage of a tenant and/or a channel; the default tenant when neither is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Get send quota usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with quota usage",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid tenant or channel ID",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/messages/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific message by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Get a message by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,status,createdAt",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with message data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/messages/{id}/acknowledgments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the acknowledgments of a message by its recipients, who acknowledged it and when",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "List the acknowledgments of a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the acknowledgments of the message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/messages/{id}/links": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the links of a message sent in short form, with the clicks counted on each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "List the short links of a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the short links and their clicks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/messages/{id}/replies": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the SMS and emails sent back by the recipients of a message",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replies"
+                ],
+                "summary": "List the replies to a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the replies",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/otp/send": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generate a one-time code and send it to the recipient, rendered by the template of the request or the configured one in its {code} variable. Only the hash of the code is stored; verify it with the ID of the response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "otp"
+                ],
+                "summary": "Send a verification code",
+                "parameters": [
+                    {
+                        "description": "Send OTP request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_otp_dtos.SendOTPRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success response with the code sent",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Channel or template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "A code was sent to the recipient too recently",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "The code could not be sent",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/otp/verify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Check a one-time code. A wrong code uses up one of its attempts; a code is verified once, before it expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "otp"
+                ],
+                "summary": "Verify a verification code",
+                "parameters": [
+                    {
+                        "description": "Verify OTP request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_otp_dtos.VerifyOTPRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the verified code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Verification code not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The code was already verified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "The code is wrong, expired or out of attempts",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/plugins": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of all loaded plugins with their statuses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "List all loaded plugins",
+                "responses": {
+                    "200": {
+                        "description": "Success response with plugins list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/plugins/load": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Load a new plugin from Go source code using Yaegi interpreter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "Load a plugin from source code",
+                "parameters": [
+                    {
+                        "description": "Load plugin request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_presentation_http_handlers.LoadPluginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plugin loaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/plugins/load-file": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Load a plugin from a file path on the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "Load a plugin from file path",
+                "parameters": [
+                    {
+                        "description": "Load plugin from file request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plugin loaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/plugins/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status and information of a specific plugin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "Get plugin status by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Plugin name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with plugin status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Plugin not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Unload a specific plugin by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plugins"
+                ],
+                "summary": "Unload a plugin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Plugin name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plugin unloaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Plugin not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/privacy/audit": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the audit entries of the privacy requests, newest first: who requested an export or erasure, and how it completed. Entries identify the recipients by the hash of their address.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "List the privacy audit trail",
+                "parameters": [
+                    {
+                        "maximum": 1000,
+                        "type": "integer",
+                        "description": "Number of entries listed, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the audit entries",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/privacy/erasures": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start deleting every row stored about a recipient address, in one transaction. The messages sent to the recipient alone are deleted with their results and engagement; the messages shared with other recipients keep their results, the address replaced by \"[erased]\". The exports made of the recipient are dropped, and the request and its audit trail keep the hash of the address only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Erase the data of a recipient",
+                "parameters": [
+                    {
+                        "description": "Privacy request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_privacy_dtos.PrivacyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Success response with the started request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/privacy/exports": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start collecting every row stored about a recipient address: the messages sent to it with its results, its acknowledgments, reply tokens, replies, verification codes, in-app notifications, scheduled sends, preferences and subscriptions. The request runs in the background; follow it at the Location header and get the data once it completed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Export the data of a recipient",
+                "parameters": [
+                    {
+                        "description": "Privacy request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_privacy_dtos.PrivacyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Success response with the started request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/privacy/requests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status of an export or erasure request and, once completed, its report counting the rows exported or erased per kind of data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Get a privacy request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Privacy request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Privacy request not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/privacy/requests/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the rows a completed export request collected about its recipient, per kind of data. The data is gone once the recipient is erased.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Get the data of a privacy export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Privacy request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the exported data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Privacy export not found or erased",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The export has not completed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/provisioning/channels": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start provisioning a channel in steps: create it disabled, verify the provider connection, optionally send a test message and enable it. Completed steps are undone when a later one fails. Follow the saga at the Location header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "provisioning"
+                ],
+                "summary": "Provision a channel",
+                "parameters": [
+                    {
+                        "description": "Provision channel request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_provisioning_dtos.ProvisionChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Success response with the started saga",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/provisioning/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status of a provisioning saga and each of its steps",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "provisioning"
+                ],
+                "summary": "Get a provisioning saga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the saga",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid saga ID",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Saga not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/email/inbound": {
+            "post": {
+                "description": "Record an email reply posted in raw RFC 5322 form by the mail provider, correlated to the message whose reply token tags the address it was sent to",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replies"
+                ],
+                "summary": "Receive an email reply",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inbound secret",
+                        "name": "X-Inbound-Secret",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Raw email",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success response with the reply",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid secret",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/info": {
+            "get": {
+                "description": "Get information about the API and available endpoints",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "API information",
+                "responses": {
+                    "200": {
+                        "description": "API information",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_models.InfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/sms/channels/{id}/inbound": {
+            "post": {
+                "description": "Record an SMS sent back to an SMS channel, correlated to the latest message the channel sent to its sender. Called by Twilio, which signs the request with the auth token of the channel.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "replies"
+                ],
+                "summary": "Receive an SMS reply",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Twilio request signature",
+                        "name": "X-Twilio-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone number of the sender",
+                        "name": "From",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text of the reply",
+                        "name": "Body",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message SID",
+                        "name": "MessageSid",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Empty TwiML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "SMS channel not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/voice/channels/{id}/ack": {
+            "post": {
+                "description": "Record the key a recipient pressed during a call of a voice channel. Called by Twilio, which signs the request with the auth token of the channel.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "voice"
+                ],
+                "summary": "Acknowledge a voice call",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Twilio request signature",
+                        "name": "X-Twilio-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Call SID",
+                        "name": "CallSid",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keys pressed",
+                        "name": "Digits",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TwiML thanking the recipient",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Voice channel not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/replies": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the replies received after a time, newest first, the uncorrelated ones included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replies"
+                ],
+                "summary": "List the replies received",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Received after, in Unix milliseconds",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "type": "integer",
+                        "description": "Number of replies listed, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the replies",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/retention/reports": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List what the retention job did per tenant, newest first: the number of messages whose content (variables, template overrides, reply bodies, quarantined attachments) was purged and of messages deleted with their delivery metadata, and the creation times before which it did so.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "retention"
+                ],
+                "summary": "List the retention reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID, every tenant by default",
+                        "name": "tenantId",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "type": "integer",
+                        "description": "Number of reports listed, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the reports",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/routing/simulate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Work out which channels a send request selects and why, and for each channel the template, recipients and rendered preview of the send or how it would end, without sending, saving or counting the message",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routing"
+                ],
+                "summary": "Simulate the routing of a message",
+                "parameters": [
+                    {
+                        "description": "Hypothetical send message request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_message_dtos.SendMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the simulated routing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Prod channel or template refused to a deployment below prod",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template or channel not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send a message later, at an instant (sendAt) or at a local time of each recipient (localTime). A local time fans out into one send per time zone of the recipients, read from the recipients, else their user preferences, else defaultTimezone. Non-critical sends falling within a blackout of the calendar are deferred to its end",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Schedule a message",
+                "parameters": [
+                    {
+                        "description": "Schedule message request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_message_dtos.ScheduleMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success response with the sends of the schedule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Prod channel or template refused to a deployment below prod",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template or channel not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the sends of a schedule with their time zone, time and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Get a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the sends of the schedule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel the sends of a schedule not sent yet, keeping those already sent",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Cancel a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the sends of the schedule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/slo": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Measure the delivery latency objectives over their windows from the creation and first successful send times of the messages: the share of the messages dispatched within the threshold, the error budget left, and the burn rates of the alerts. Percents are out of 100 and durations in seconds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slo"
+                ],
+                "summary": "List the SLOs",
+                "responses": {
+                    "200": {
+                        "description": "Success response with the objectives",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/slo/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Measure one delivery latency objective over its window.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slo"
+                ],
+                "summary": "Get an SLO",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Objective name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the objective",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Objective not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List every tag carried by a channel or template with its usage counts, most used first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "Success response with the tags",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the source tags with the target tag on every channel and template carrying one of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Merge tags",
+                "parameters": [
+                    {
+                        "description": "Merge tags request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_tag_dtos.MergeTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the number of channels and templates updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags/rename": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rename a tag on every channel and template carrying it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Rename a tag",
+                "parameters": [
+                    {
+                        "description": "Rename tag request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_tag_dtos.RenameTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the number of channels and templates updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of templates with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by channel type",
+                        "name": "channelType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Filter by tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by owner",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by team",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by environment (dev, staging or prod), including unscoped templates; all lists every environment. Defaults to the server environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of records to skip for pagination",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of records to return per page (1-100)",
+                        "name": "maxResultCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,channelType",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with templates list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new message template for a specific channel type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create a new template",
+                "parameters": [
+                    {
+                        "description": "Create template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_template_dtos.CreateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Template created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates/by-name/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific template by its unique name, e.g. to import existing resources",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get a template by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,channelType",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with template data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified - Template unchanged since the given entity tag"
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates/lint": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Report the variables used in a template's subject or content that are not declared in its variables",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Lint template variables",
+                "parameters": [
+                    {
+                        "description": "Lint template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_template_dtos.LintTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with lint results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates/validate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Check a template before saving it: placeholder syntax errors with line and column, undeclared variables and channel type constraints",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Validate a template",
+                "parameters": [
+                    {
+                        "description": "Validate template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_template_dtos.ValidateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with validation results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific template by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get a template by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,channelType",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with template data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified - Template unchanged since the given entity tag"
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fully replace an existing template by its ID. Omitted optional fields are cleared. Supports If-Match for optimistic concurrency.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Replace a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Replace template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_template_dtos.ReplaceTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template replaced successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed - template was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing template by its ID. Templates still referenced by channels are only deleted with force=true, which detaches the channels, or with reassignTo, which moves them to another template of the same channel type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Delete a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Detach referencing channels from the template and delete it",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Move referencing channels to this template before deleting",
+                        "name": "reassignTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template is still referenced by channels",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed - template was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update only the provided fields of an existing template. Supports If-Match for optimistic concurrency.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Partially update a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Update template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_template_dtos.UpdateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed - template was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates/{id}/usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the channels referencing a template with their message counts over a recent window",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get template usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Window for recent message counts in days (1-365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template usage",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the in-app notifications of a user, most recent first, with the unread count",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inapp"
+                ],
+                "summary": "List the notifications of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "List the unread notifications only",
+                        "name": "unreadOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category of the notifications",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of notifications to skip",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of notifications to return",
+                        "name": "maxResultCount",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the notifications",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/notifications/read": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mark the given notifications of a user read, or every unread one when the request names none",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inapp"
+                ],
+                "summary": "Mark notifications of a user read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notifications to mark read",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_inapp_dtos.MarkInAppNotificationsReadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the marked and unread counts",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/notifications/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upgrade to a WebSocket receiving every new in-app notification of the user with the unread count",
+                "tags": [
+                    "inapp"
+                ],
+                "summary": "Stream the new notifications of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching to the WebSocket protocol"
+                    },
+                    "400": {
+                        "description": "Not a WebSocket request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Count the in-app notifications a user has not read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inapp"
+                ],
+                "summary": "Count the unread notifications of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the unread count",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/notifications/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a notification of a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inapp"
+                ],
+                "summary": "Delete a notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the unread count left",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mark a notification of a user read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inapp"
+                ],
+                "summary": "Mark a notification read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the notification",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the preferred channels, quiet hours and category opt-outs of a user, identified by a user ID or the target address the channels send to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Get the notification preferences of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or target address",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the preferences",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User has no preferences",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the preferences of a user. Messages to the user through channel types it does not prefer, in categories it opted out of or during its quiet hours are suppressed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Set the notification preferences of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or target address",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Preferences of the user",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_preference_dtos.SetUserPreferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the preferences",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the preferences of a user, who receives every message again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Delete the notification preferences of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or target address",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User has no preferences",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List whether a user, identified by a user ID or the target address the channels send to, receives the messages of every category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "List the subscriptions of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or target address",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the subscriptions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{userId}/subscriptions/{category}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Make a user receive the messages of a category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Subscribe a user to a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or target address",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category name",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the subscription",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stop a user receiving the messages of a category, including a category subscribed by default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Unsubscribe a user from a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or target address",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category name",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the subscription",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/channels": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of all channels, with optional filtering by channel type and tags, and pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "List all channels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by channel type (e.g., email, sms)",
+                        "name": "channelType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of records to skip for pagination",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of records to return per page (1-100)",
+                        "name": "maxResultCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by referenced template ID",
+                        "name": "templateId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by owner",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by team",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by environment (dev, staging or prod), including unscoped channels; all lists every environment. Defaults to the server environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only channels last used before this Unix millisecond timestamp, including never-used channels",
+                        "name": "lastUsedBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only channels last used at or after this Unix millisecond timestamp",
+                        "name": "lastUsedAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only channels not used within this many days",
+                        "name": "unusedForDays",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "channelName",
+                            "channelType",
+                            "enabled",
+                            "createdAt",
+                            "updatedAt",
+                            "lastUsed"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sortField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order (asc or desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. channelId,channelName,enabled",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with channels list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Unsupported sort field or order, or invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new channel with the provided details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Create a new channel",
+                "parameters": [
+                    {
+                        "description": "Create Channel Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.CreateChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success response with channel data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Channel with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - Legacy system unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/channels/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single channel's details using its unique identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Get a channel by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. channelId,channelName,enabled",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with channel data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified - Channel unchanged since the given entity tag"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates an existing channel's details using its unique identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Update an existing channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Update Channel Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_channel_dtos.UpdateChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Channel with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - Channel was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - Legacy system unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a channel using its unique identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Delete a channel by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the channel as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid channel ID format",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Channel with specified ID does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - Channel was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - Legacy system unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/messages": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of messages with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "List messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by channel ID",
+                        "name": "channelId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by message status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of items to return",
+                        "name": "maxResultCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,status,createdAt",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with messages list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/messages/send": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send a message to multiple channels using a template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Send a message",
+                "parameters": [
+                    {
+                        "description": "Send message request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_message_dtos.SendMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with message data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Prod channel or template refused to a deployment below prod",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Send quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Send queue full, retry after the Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/messages/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific message by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Get a message by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,status,createdAt",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with message data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/templates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of templates with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by channel type",
+                        "name": "channelType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Filter by tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by owner",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by team",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by environment (dev, staging or prod), including unscoped templates; all lists every environment. Defaults to the server environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of records to skip for pagination",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of records to return per page (1-100)",
+                        "name": "maxResultCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,channelType",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with templates list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new message template for a specific channel type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create a new template",
+                "parameters": [
+                    {
+                        "description": "Create template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_template_dtos.CreateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Template created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific template by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get a template by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,channelType",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with template data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified - Template unchanged since the given entity tag"
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update only the provided fields of an existing template. Supports If-Match for optimistic concurrency.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Partially update a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Update template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_template_dtos.UpdateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed - template was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing template by its ID. Templates still referenced by channels are only deleted with force=true, which detaches the channels, or with reassignTo, which moves them to another template of the same channel type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Delete a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Detach referencing channels from the template and delete it",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Move referencing channels to this template before deleting",
+                        "name": "reassignTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the template as last seen by the client",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template is still referenced by channels",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed - template was modified",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Legacy health endpoint for backward compatibility",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Legacy health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_health_dtos.LegacyHealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health-status": {
+            "get": {
+                "description": "Provides comprehensive view of service health including dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Detailed health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_health_dtos.DetailedHealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_health_dtos.DetailedHealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Provides a lightweight, fast response used for liveness checks by Kubernetes, load balancers, or uptime monitoring tools",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Minimal liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_application_health_dtos.LivenessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/identities/{id}/confirm": {
+            "get": {
+                "description": "Verify an address identity through the link of its confirmation email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "identities"
+                ],
+                "summary": "Confirm a sender address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sender identity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success response with the sender identity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Sender identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/l/{code}": {
+            "get": {
+                "description": "Redirect to the target of a short link sent in a message, counting the click against the message",
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Follow a short link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short link code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the target of the link",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Short link not found",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/notification_internal_presentation_http_httputil.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "internal_presentation_http_handlers.LoadPluginRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "source"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_presentation_http_handlers.SetLogLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "module": {
+                    "description": "Module is the component whose level is set; the default level when empty",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "internal_presentation_http_handlers.SetReadOnlyRequest": {
+            "type": "object",
+            "required": [
+                "readOnly"
+            ],
+            "properties": {
+                "readOnly": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "notification_internal_application_blackout_dtos.BlackoutRequest": {
+            "type": "object",
+            "required": [
+                "endsAt",
+                "name",
+                "startsAt"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "endsAt": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "startsAt": {
+                    "description": "StartsAt and EndsAt bound the blackout in Unix milliseconds, StartsAt\ninclusive and EndsAt exclusive",
+                    "type": "integer"
+                }
+            }
+        },
+        "notification_internal_application_category_dtos.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "defaultSubscribed": {
+                    "description": "DefaultSubscribed makes every recipient receive the messages of the\ncategory until it unsubscribes; otherwise only subscribers receive them",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_category_dtos.UpdateCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "defaultSubscribed": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.BulkChannelIDsRequest": {
+            "type": "object",
+            "required": [
+                "channelIds"
+            ],
+            "properties": {
+                "channelIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.BulkCreateChannelsRequest": {
+            "type": "object",
+            "required": [
+                "channels"
+            ],
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_channel_dtos.CreateChannelRequest"
+                    }
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.BulkTagChannelsRequest": {
+            "type": "object",
+            "required": [
+                "channelIds"
+            ],
+            "properties": {
+                "addTags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "channelIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "removeTags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.CommonSettingsDTO": {
+            "type": "object",
+            "required": [
+                "timeout"
+            ],
+            "properties": {
+                "retryAttempts": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "retryDelay": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "timeout": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.CreateChannelRequest": {
+            "type": "object",
+            "required": [
+                "channelName",
+                "channelType",
+                "commonSettings",
+                "config"
+            ],
+            "properties": {
+                "channelName": {
+                    "type": "string"
+                },
+                "channelType": {
+                    "type": "string"
+                },
+                "commonSettings": {
+                    "$ref": "#/definitions/notification_internal_application_channel_dtos.CommonSettingsDTO"
+                },
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "environment": {
+                    "description": "Environment is dev, staging or prod; empty serves every environment",
+                    "type": "string"
+                },
+                "fallbackChannelId": {
+                    "description": "FallbackChannelID is sent through when a delivery fails after the retries",
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_channel_dtos.RecipientDTO"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "team": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "variableDefaults": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.PatchChannelRequest": {
+            "type": "object",
+            "properties": {
+                "channelName": {
+                    "type": "string"
+                },
+                "channelType": {
+                    "type": "string"
+                },
+                "commonSettings": {
+                    "$ref": "#/definitions/notification_internal_application_channel_dtos.CommonSettingsDTO"
+                },
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "environment": {
+                    "type": "string"
+                },
+                "fallbackChannelId": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_channel_dtos.RecipientDTO"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "team": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "variableDefaults": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.RecipientDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone sends scheduled in local time reach the recipient in",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_channel_dtos.UpdateChannelRequest": {
+            "type": "object",
+            "required": [
+                "channelName",
+                "channelType",
+                "commonSettings",
+                "config"
+            ],
+            "properties": {
+                "channelId": {
+                    "type": "string"
+                },
+                "channelName": {
+                    "type": "string"
+                },
+                "channelType": {
+                    "type": "string"
+                },
+                "commonSettings": {
+                    "$ref": "#/definitions/notification_internal_application_channel_dtos.CommonSettingsDTO"
+                },
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "environment": {
+                    "description": "Environment is dev, staging or prod; empty serves every environment",
+                    "type": "string"
+                },
+                "fallbackChannelId": {
+                    "description": "FallbackChannelID is sent through when a delivery fails after the retries",
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_channel_dtos.RecipientDTO"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "team": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "variableDefaults": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "notification_internal_application_channelgroup_dtos.ChannelGroupMembersRequest": {
+            "type": "object",
+            "required": [
+                "channelIds"
+            ],
+            "properties": {
+                "channelIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "notification_internal_application_channelgroup_dtos.CreateChannelGroupRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "channelIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_channelgroup_dtos.UpdateChannelGroupRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_failedevent_dtos.ReplayFailedEventsRequest": {
+            "type": "object",
+            "properties": {
+                "eventType": {
+                    "description": "EventType limits the replay to one event type, empty for every type",
+                    "type": "string"
+                },
+                "maxResultCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notification_internal_application_health_dtos.DependencyHealth": {
+            "type": "object",
+            "properties": {
+                "latency": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_health_dtos.DetailedHealthResponse": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_health_dtos.DependencyHealth"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "system_info": {
+                    "$ref": "#/definitions/notification_internal_application_health_dtos.SystemInfo"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_health_dtos.LegacyHealthResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_health_dtos.LivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_health_dtos.MemoryInfo": {
+            "type": "object",
+            "properties": {
+                "alloc": {
+                    "type": "integer"
+                },
+                "num_gc": {
+                    "type": "integer"
+                },
+                "sys": {
+                    "type": "integer"
+                },
+                "total_alloc": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notification_internal_application_health_dtos.SystemInfo": {
+            "type": "object",
+            "properties": {
+                "go_version": {
+                    "type": "string"
+                },
+                "memory": {
+                    "$ref": "#/definitions/notification_internal_application_health_dtos.MemoryInfo"
+                },
+                "uptime": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_identity_dtos.CreateSenderIdentityRequest": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "tenantId": {
+                    "description": "TenantID is the tenant sending from the identity, the default tenant when empty",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is the address, verified by a confirmation email, or the domain,\nverified by a DNS TXT record",
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_inapp_dtos.MarkInAppNotificationsReadRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "description": "IDs are the notifications to mark read, empty for every unread one",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "notification_internal_application_message_dtos.ScheduleMessageRequest": {
+            "type": "object",
+            "required": [
+                "recipients",
+                "templateId"
+            ],
+            "properties": {
+                "allowProd": {
+                    "description": "AllowProd lets a deployment below prod send through prod channels and\ntemplates, which it refuses otherwise.",
+                    "type": "boolean"
+                },
+                "async": {
+                    "description": "Async answers with the pending message as soon as it is accepted and\ndelivers it in the background; the delivery is followed on the\nmessage.progress events or by getting the message.",
+                    "type": "boolean"
+                },
+                "category": {
+                    "description": "Category is the topic of the message, such as billing or security. The\nmessage only reaches the recipients receiving the category and not\nopting out of it.",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "channelGroupIds": {
+                    "description": "ChannelGroupIDs targets the enabled members of the channel groups, which\nmay mix channel types as each channel renders its own template.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "channelIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "channelOverrides": {
+                    "$ref": "#/definitions/notification_internal_domain_message.ChannelOverrides"
+                },
+                "channelTags": {
+                    "description": "ChannelTags targets the enabled channels carrying every one of the tags\nand the template's channel type, resolved when the message is sent.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "correlationId": {
+                    "description": "CorrelationID ties the message to a business transaction. It is stored\nwith the message, logged and passed on to the providers.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "critical": {
+                    "description": "Critical sends the message even during the blackouts of the calendar,\nwhich defer the other scheduled messages until they end",
+                    "type": "boolean"
+                },
+                "defaultTimezone": {
+                    "description": "DefaultTimezone is the IANA time zone of the recipients with no time\nzone of their own or in their preferences, UTC when empty",
+                    "type": "string"
+                },
+                "localTime": {
+                    "description": "LocalTime is the time of day of each recipient the message is sent at,\nsuch as 2026-03-01T09:00: the recipients are grouped by their time zone\nand each group is sent when its clock shows that time.",
+                    "type": "string"
+                },
+                "messageClass": {
+                    "description": "MessageClass is transactional or marketing, marketing when empty.\nMarketing messages honor every opt-out, subscription and quiet hours\nof the recipients and are rejected over the send quotas; transactional\nones reach the recipients opting out or in their quiet hours, and are\ncounted against the quotas without being rejected.",
+                    "type": "string",
+                    "enum": [
+                        "transactional",
+                        "marketing"
+                    ]
+                },
+                "recipients": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
+                },
+                "sendAt": {
+                    "description": "SendAt is the instant the message is sent at, in Unix milliseconds",
+                    "type": "integer"
+                },
+                "settings": {
+                    "$ref": "#/definitions/notification_internal_domain_shared.CommonSettings"
+                },
+                "strict": {
+                    "description": "Strict fails rendering with RENDER_ERROR when a template variable has\nno value, instead of rendering it empty.",
+                    "type": "boolean"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "description": "TenantID names the tenant whose send quota the message counts against,\nthe default tenant when empty.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "notification_internal_application_message_dtos.SendMessageRequest": {
+            "type": "object",
+            "required": [
+                "recipients",
+                "templateId"
+            ],
+            "properties": {
+                "allowProd": {
+                    "description": "AllowProd lets a deployment below prod send through prod channels and\ntemplates, which it refuses otherwise.",
+                    "type": "boolean"
+                },
+                "async": {
+                    "description": "Async answers with the pending message as soon as it is accepted and\ndelivers it in the background; the delivery is followed on the\nmessage.progress events or by getting the message.",
+                    "type": "boolean"
+                },
+                "category": {
+                    "description": "Category is the topic of the message, such as billing or security. The\nmessage only reaches the recipients receiving the category and not\nopting out of it.",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "channelGroupIds": {
+                    "description": "ChannelGroupIDs targets the enabled members of the channel groups, which\nmay mix channel types as each channel renders its own template.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "channelIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "channelOverrides": {
+                    "$ref": "#/definitions/notification_internal_domain_message.ChannelOverrides"
+                },
+                "channelTags": {
+                    "description": "ChannelTags targets the enabled channels carrying every one of the tags\nand the template's channel type, resolved when the message is sent.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "correlationId": {
+                    "description": "CorrelationID ties the message to a business transaction. It is stored\nwith the message, logged and passed on to the providers.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "messageClass": {
+                    "description": "MessageClass is transactional or marketing, marketing when empty.\nMarketing messages honor every opt-out, subscription and quiet hours\nof the recipients and are rejected over the send quotas; transactional\nones reach the recipients opting out or in their quiet hours, and are\ncounted against the quotas without being rejected.",
+                    "type": "string",
+                    "enum": [
+                        "transactional",
+                        "marketing"
+                    ]
+                },
+                "recipients": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/notification_internal_domain_shared.CommonSettings"
+                },
+                "strict": {
+                    "description": "Strict fails rendering with RENDER_ERROR when a template variable has\nno value, instead of rendering it empty.",
+                    "type": "boolean"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "description": "TenantID names the tenant whose send quota the message counts against,\nthe default tenant when empty.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "notification_internal_application_otp_dtos.SendOTPRequest": {
+            "type": "object",
+            "required": [
+                "recipient"
+            ],
+            "properties": {
+                "allowProd": {
+                    "description": "AllowProd lets a deployment below prod send the code through a prod\nchannel and template",
+                    "type": "boolean"
+                },
+                "channelId": {
+                    "description": "ChannelID and TemplateID override the configured OTP channel and\ntemplate; the template gets the code in its {code} variable",
+                    "type": "string"
+                },
+                "purpose": {
+                    "description": "Purpose scopes the code, such as login or password_reset; verification\nwhen empty",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "recipient": {
+                    "description": "Recipient is the address or phone number the code is sent to",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "Variables are passed on to the template, which cannot override code,\npurpose and expiresInMinutes",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "notification_internal_application_otp_dtos.VerifyOTPRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "id"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "id": {
+                    "description": "ID is the ID of the code answered by the send",
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_preference_dtos.QuietHoursDTO": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "start": {
+                    "description": "Start and End are times of day formatted as HH:MM, a window ending\nbefore it starts spans midnight",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is an IANA time zone, UTC when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_preference_dtos.SetUserPreferenceRequest": {
+            "type": "object",
+            "properties": {
+                "optOutCategories": {
+                    "description": "OptOutCategories are the message categories the user does not want",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preferredChannels": {
+                    "description": "PreferredChannels are the channel types the user accepts messages from, empty for every type",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "source": {
+                "quietHours": {
+                    "description": "QuietHours is the daily do-not-disturb window, none when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/notification_internal_application_preference_dtos.QuietHoursDTO"
+                        }
+                    ]
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone sends scheduled in local time reach the user in",
                     "type": "string"
                 }
             }
         },
-        "notification_internal_application_channel_dtos.CommonSettingsDTO": {
+        "notification_internal_application_privacy_dtos.PrivacyRequest": {
             "type": "object",
             "required": [
-                "timeout"
+                "recipient"
             ],
             "properties": {
-                "retryAttempts": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "retryDelay": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "timeout": {
-                    "type": "integer",
-                    "minimum": 1
+                "recipient": {
+                    "description": "Recipient is the address, phone number or user ID the data is stored\nunder, matched ignoring case",
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "notification_internal_application_channel_dtos.CreateChannelRequest": {
+        "notification_internal_application_provisioning_dtos.ProvisionChannelRequest": {
             "type": "object",
             "required": [
                 "channelName",
@@ -1515,77 +8800,161 @@ const docTemplate = `{
                 "enabled": {
                     "type": "boolean"
                 },
+                "environment": {
+                    "description": "Environment is dev, staging or prod; empty serves every environment",
+                    "type": "string"
+                },
+                "fallbackChannelId": {
+                    "description": "FallbackChannelID is sent through when a delivery fails after the retries",
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
                 "recipients": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/notification_internal_application_channel_dtos.RecipientDTO"
                     }
                 },
+                "sendTestMessage": {
+                    "description": "SendTestMessage sends a test message to the recipients before the channel is enabled",
+                    "type": "boolean"
+                },
                 "tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "team": {
+                    "type": "string"
+                },
                 "templateId": {
                     "type": "string"
+                },
+                "testContent": {
+                    "type": "string"
+                },
+                "testSubject": {
+                    "type": "string"
+                },
+                "variableDefaults": {
+                    "type": "object",
+                    "additionalProperties": true
                 }
             }
         },
-        "notification_internal_application_channel_dtos.RecipientDTO": {
+        "notification_internal_application_tag_dtos.MergeTagsRequest": {
             "type": "object",
             "required": [
-                "name",
-                "type"
+                "sources",
+                "target"
             ],
             "properties": {
-                "name": {
-                    "type": "string"
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "target": {
                     "type": "string"
+                }
+            }
+        },
+        "notification_internal_application_tag_dtos.RenameTagRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string"
                 },
-                "type": {
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "notification_internal_application_channel_dtos.UpdateChannelRequest": {
+        "notification_internal_application_template_dtos.AttachmentDTO": {
             "type": "object",
             "required": [
-                "channelName",
-                "channelType",
-                "commonSettings",
-                "config"
+                "filename"
             ],
             "properties": {
-                "channelId": {
+                "content": {
+                    "description": "Content is the base64 encoded file",
+                    "type": "string",
+                    "format": "base64"
+                },
+                "contentId": {
                     "type": "string"
                 },
-                "channelName": {
+                "contentType": {
+                    "type": "string"
+                },
+                "filename": {
                     "type": "string"
                 },
+                "inline": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "notification_internal_application_template_dtos.CreateTemplateRequest": {
+            "type": "object",
+            "required": [
+                "channelType",
+                "content",
+                "name"
+            ],
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_template_dtos.AttachmentDTO"
+                    }
+                },
                 "channelType": {
+                    "$ref": "#/definitions/notification_internal_domain_shared.ChannelType"
+                },
+                "content": {
                     "type": "string"
                 },
-                "commonSettings": {
-                    "$ref": "#/definitions/notification_internal_application_channel_dtos.CommonSettingsDTO"
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "config": {
+                "environment": {
+                    "description": "Environment is dev, staging or prod; empty serves every environment",
+                    "type": "string"
+                },
+                "headers": {
+                    "description": "Headers are extra email headers, such as Reply-To or List-Unsubscribe,\ntheir values rendered with the variables",
                     "type": "object",
-                    "additionalProperties": true
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "description": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "owner": {
                     "type": "string"
                 },
-                "enabled": {
+                "settings": {
+                    "$ref": "#/definitions/notification_internal_domain_shared.CommonSettings"
+                },
+                "strict": {
                     "type": "boolean"
                 },
-                "recipients": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/notification_internal_application_channel_dtos.RecipientDTO"
-                    }
+                "subject": {
+                    "type": "string",
+                    "maxLength": 200
                 },
                 "tags": {
                     "type": "array",
@@ -1593,71 +8962,84 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "templateId": {
+                "team": {
                     "type": "string"
+                },
+                "variables": {
+                    "description": "Variables are declared besides those the subject and content use",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "notification_internal_application_message_dtos.SendMessageRequest": {
+        "notification_internal_application_template_dtos.LintTemplateRequest": {
             "type": "object",
             "required": [
-                "channelIds",
-                "recipients",
-                "templateId"
+                "content"
             ],
             "properties": {
-                "channelIds": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
+                "content": {
+                    "type": "string"
                 },
-                "channelOverrides": {
-                    "$ref": "#/definitions/notification_internal_domain_message.ChannelOverrides"
+                "subject": {
+                    "type": "string"
                 },
-                "recipients": {
+                "variables": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "type": "object",
-                        "additionalProperties": true
+                        "type": "string"
                     }
-                },
-                "settings": {
-                    "$ref": "#/definitions/notification_internal_domain_shared.CommonSettings"
-                },
-                "templateId": {
-                    "type": "string"
-                },
-                "variables": {
-                    "type": "object",
-                    "additionalProperties": true
                 }
             }
         },
-        "notification_internal_application_template_dtos.CreateTemplateRequest": {
+        "notification_internal_application_template_dtos.ReplaceTemplateRequest": {
             "type": "object",
             "required": [
-                "channelType",
                 "content",
                 "name"
             ],
             "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_template_dtos.AttachmentDTO"
+                    }
+                },
                 "channelType": {
                     "$ref": "#/definitions/notification_internal_domain_shared.ChannelType"
                 },
                 "content": {
                     "type": "string"
                 },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "environment": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 1
                 },
+                "owner": {
+                    "type": "string"
+                },
                 "settings": {
                     "$ref": "#/definitions/notification_internal_domain_shared.CommonSettings"
                 },
+                "strict": {
+                    "type": "boolean"
+                },
                 "subject": {
                     "type": "string",
                     "maxLength": 200
@@ -1668,6 +9050,9 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "team": {
+                    "type": "string"
+                },
                 "variables": {
                     "type": "array",
                     "items": {
@@ -1679,18 +9064,45 @@ const docTemplate = `{
         "notification_internal_application_template_dtos.UpdateTemplateRequest": {
             "type": "object",
             "properties": {
+                "attachments": {
+                    "description": "Attachments replace those of the template when set; an empty list removes them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_application_template_dtos.AttachmentDTO"
+                    }
+                },
                 "content": {
                     "type": "string",
                     "minLength": 1
                 },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "environment": {
+                    "type": "string"
+                },
+                "headers": {
+                    "description": "Headers replace those of the template when set; an empty object removes them",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 1
                 },
+                "owner": {
+                    "type": "string"
+                },
                 "settings": {
                     "$ref": "#/definitions/notification_internal_domain_shared.CommonSettings"
                 },
+                "strict": {
+                    "type": "boolean"
+                },
                 "subject": {
                     "type": "string",
                     "maxLength": 200
@@ -1701,6 +9113,33 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "team": {
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "Variables replace the declared ones when set; an empty list removes them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "notification_internal_application_template_dtos.ValidateTemplateRequest": {
+            "type": "object",
+            "required": [
+                "channelType"
+            ],
+            "properties": {
+                "channelType": {
+                    "$ref": "#/definitions/notification_internal_domain_shared.ChannelType"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
                 "variables": {
                     "type": "array",
                     "items": {
@@ -1731,6 +9170,84 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "notification_internal_presentation_http_httputil.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "channelName"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "channel name is required"
+                }
+            }
+        },
+        "notification_internal_presentation_http_httputil.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "CHANNEL_NOT_FOUND"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "channel not found"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_internal_presentation_http_httputil.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/channels/123"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:notification:problem:channel-not-found"
+                }
+            }
+        },
+        "notification_internal_presentation_http_models.InfoResponse": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "service": {
+                    "type": "string",
+                    "example": "notification-api"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key, also accepted as an Authorization Bearer token",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BasicAuth": {
+            "type": "basic"
         }
     }
 }`
@@ -1742,7 +9259,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "",
 	Schemes:          []string{},
 	Title:            "Notification API",
-	Description:      "This is the API documentation for the Notification service.",
+	Description:      "Admin credentials, required by the /api/v1/admin endpoints",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -0,0 +1,41 @@
+package dtos
+
+import (
+	"notification/internal/domain/message"
+)
+
+// GetCostReportRequest represents the request for the estimated send costs.
+type GetCostReportRequest struct {
+	// GroupBy is channel, tag, tenant or class, channel when empty
+	GroupBy string `form:"groupBy" json:"groupBy,omitempty"`
+	// From and To bound the message creation time in Unix milliseconds,
+	// From inclusive and To exclusive
+	From int64 `form:"from" json:"from,omitempty"`
+	To   int64 `form:"to" json:"to,omitempty"`
+}
+
+// CostReportResponse represents the estimated send costs per group.
+type CostReportResponse struct {
+	GroupBy  message.CostGroupBy `json:"groupBy"`
+	Currency string              `json:"currency"`
+	From     int64               `json:"from,omitempty"`
+	To       int64               `json:"to,omitempty"`
+	Items    []*CostItemResponse `json:"items"`
+}
+
+// CostItemResponse represents the estimated cost of the sends in one group.
+type CostItemResponse struct {
+	// Key is the channel ID, tag, tenant ID or message class of the group
+	Key   string  `json:"key"`
+	Sends int     `json:"sends"`
+	Cost  float64 `json:"cost"`
+}
+
+// ToCostItemResponse converts a cost total to a response DTO.
+func ToCostItemResponse(total *message.CostTotal) *CostItemResponse {
+	return &CostItemResponse{
+		Key:   total.Key,
+		Sends: total.Sends,
+		Cost:  total.Cost,
+	}
+}
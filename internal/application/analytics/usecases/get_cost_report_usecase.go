@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/analytics/dtos"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// GetCostReportUseCase handles reporting the estimated send costs.
+type GetCostReportUseCase struct {
+	messageRepo message.MessageRepository
+	currency    string
+}
+
+// NewGetCostReportUseCase creates a new GetCostReportUseCase. The currency is
+// the one the channel prices are configured in.
+func NewGetCostReportUseCase(messageRepo message.MessageRepository, currency string) *GetCostReportUseCase {
+	return &GetCostReportUseCase{
+		messageRepo: messageRepo,
+		currency:    currency,
+	}
+}
+
+// Execute sums the estimated cost of the sends per channel, tag, tenant or
+// message class.
+func (uc *GetCostReportUseCase) Execute(ctx context.Context, req *dtos.GetCostReportRequest) (*dtos.CostReportResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	// 1. Validate request
+	if req == nil {
+		req = &dtos.GetCostReportRequest{}
+	}
+
+	groupBy := message.CostGroupByChannel
+	if req.GroupBy != "" {
+		groupBy = message.CostGroupBy(req.GroupBy)
+	}
+	if !groupBy.IsValid() {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("groupBy must be one of channel, tag, tenant, class, got %q", req.GroupBy))
+	}
+	if req.From < 0 || req.To < 0 {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("from and to must be Unix millisecond timestamps"))
+	}
+	if req.To > 0 && req.From >= req.To {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("from must be before to"))
+	}
+
+	// 2. Sum the costs
+	totals, err := uc.messageRepo.SumCosts(ctx, &message.CostFilter{
+		GroupBy: groupBy,
+		From:    req.From,
+		To:      req.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Convert to response
+	response := &dtos.CostReportResponse{
+		GroupBy:  groupBy,
+		Currency: uc.currency,
+		From:     req.From,
+		To:       req.To,
+		Items:    make([]*dtos.CostItemResponse, 0, len(totals)),
+	}
+	for _, total := range totals {
+		response.Items = append(response.Items, dtos.ToCostItemResponse(total))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,44 @@
+package dtos
+
+import (
+	"notification/internal/domain/blackout"
+)
+
+// BlackoutRequest is the DTO for creating or replacing a blackout.
+type BlackoutRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// StartsAt and EndsAt bound the blackout in Unix milliseconds, StartsAt
+	// inclusive and EndsAt exclusive
+	StartsAt int64 `json:"startsAt" binding:"required"`
+	EndsAt   int64 `json:"endsAt" binding:"required"`
+}
+
+// BlackoutResponse is the DTO for a blackout response.
+type BlackoutResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	StartsAt    int64  `json:"startsAt"`
+	EndsAt      int64  `json:"endsAt"`
+	CreatedAt   int64  `json:"createdAt"`
+	UpdatedAt   int64  `json:"updatedAt"`
+}
+
+// ListBlackoutsResponse is the DTO for a blackout list response.
+type ListBlackoutsResponse struct {
+	Items []*BlackoutResponse `json:"items"`
+}
+
+// FromBlackout converts a blackout to a response DTO.
+func FromBlackout(b *blackout.Blackout) *BlackoutResponse {
+	return &BlackoutResponse{
+		ID:          b.ID,
+		Name:        b.Name,
+		Description: b.Description,
+		StartsAt:    b.StartsAt.UnixMilli(),
+		EndsAt:      b.EndsAt.UnixMilli(),
+		CreatedAt:   b.CreatedAt.UnixMilli(),
+		UpdatedAt:   b.UpdatedAt.UnixMilli(),
+	}
+}
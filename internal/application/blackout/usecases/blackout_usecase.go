@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"notification/internal/application/blackout/dtos"
+	"notification/internal/domain/blackout"
+	"notification/internal/domain/shared"
+)
+
+// GetBlackoutUseCase is the use case for querying the blackout calendar.
+type GetBlackoutUseCase struct {
+	blackoutRepo blackout.BlackoutRepository
+}
+
+// NewGetBlackoutUseCase creates a use case instance.
+func NewGetBlackoutUseCase(blackoutRepo blackout.BlackoutRepository) *GetBlackoutUseCase {
+	return &GetBlackoutUseCase{
+		blackoutRepo: blackoutRepo,
+	}
+}
+
+// Get gets a blackout by ID.
+func (uc *GetBlackoutUseCase) Get(ctx context.Context, id string) (*dtos.BlackoutResponse, error) {
+	b, err := uc.blackoutRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtos.FromBlackout(b), nil
+}
+
+// List lists the blackouts of the calendar, only those not over yet when
+// upcoming is set.
+func (uc *GetBlackoutUseCase) List(ctx context.Context, upcoming bool) (*dtos.ListBlackoutsResponse, error) {
+	var blackouts []*blackout.Blackout
+	var err error
+	if upcoming {
+		blackouts, err = uc.blackoutRepo.FindEndingAfter(ctx, time.Now())
+	} else {
+		blackouts, err = uc.blackoutRepo.List(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dtos.BlackoutResponse, 0, len(blackouts))
+	for _, b := range blackouts {
+		items = append(items, dtos.FromBlackout(b))
+	}
+
+	return &dtos.ListBlackoutsResponse{Items: items}, nil
+}
+
+// UpdateBlackoutUseCase is the use case for managing the blackout calendar.
+// The scheduled sends a change moves into or out of a blackout are deferred
+// or sent accordingly when they come due.
+type UpdateBlackoutUseCase struct {
+	blackoutRepo blackout.BlackoutRepository
+}
+
+// NewUpdateBlackoutUseCase creates a use case instance.
+func NewUpdateBlackoutUseCase(blackoutRepo blackout.BlackoutRepository) *UpdateBlackoutUseCase {
+	return &UpdateBlackoutUseCase{
+		blackoutRepo: blackoutRepo,
+	}
+}
+
+// Create adds a blackout to the calendar.
+func (uc *UpdateBlackoutUseCase) Create(ctx context.Context, request *dtos.BlackoutRequest) (*dtos.BlackoutResponse, error) {
+	b, err := blackout.NewBlackout(request.Name, request.Description,
+		time.UnixMilli(request.StartsAt), time.UnixMilli(request.EndsAt))
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	if err := uc.blackoutRepo.Save(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromBlackout(b), nil
+}
+
+// Update replaces the name, description and period of a blackout.
+func (uc *UpdateBlackoutUseCase) Update(ctx context.Context, id string, request *dtos.BlackoutRequest) (*dtos.BlackoutResponse, error) {
+	b, err := uc.blackoutRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Change(request.Name, request.Description,
+		time.UnixMilli(request.StartsAt), time.UnixMilli(request.EndsAt)); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if err := uc.blackoutRepo.Update(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromBlackout(b), nil
+}
+
+// Delete removes a blackout from the calendar; the sends it deferred go out
+// at the time they were deferred to.
+func (uc *UpdateBlackoutUseCase) Delete(ctx context.Context, id string) error {
+	return uc.blackoutRepo.Delete(ctx, id)
+}
@@ -0,0 +1,75 @@
+package dtos
+
+import (
+	"notification/internal/domain/category"
+)
+
+// CreateCategoryRequest is the DTO for creating a category.
+type CreateCategoryRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// DefaultSubscribed makes every recipient receive the messages of the
+	// category until it unsubscribes; otherwise only subscribers receive them
+	DefaultSubscribed bool `json:"defaultSubscribed"`
+}
+
+// UpdateCategoryRequest is the DTO for updating a category.
+type UpdateCategoryRequest struct {
+	Description       string `json:"description"`
+	DefaultSubscribed bool   `json:"defaultSubscribed"`
+}
+
+// CategoryResponse is the DTO for a category response.
+type CategoryResponse struct {
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	DefaultSubscribed bool   `json:"defaultSubscribed"`
+	CreatedAt         int64  `json:"createdAt"`
+	UpdatedAt         int64  `json:"updatedAt"`
+}
+
+// ListCategoriesResponse is the DTO for a category list response.
+type ListCategoriesResponse struct {
+	Items []*CategoryResponse `json:"items"`
+}
+
+// SubscriptionResponse is the DTO for the subscription of a user to a category.
+type SubscriptionResponse struct {
+	Category   string `json:"category"`
+	Subscribed bool   `json:"subscribed"`
+	// Default is true when the user made no choice and the default of the category applies
+	Default   bool   `json:"default"`
+	UpdatedAt *int64 `json:"updatedAt,omitempty"`
+}
+
+// ListSubscriptionsResponse is the DTO for the subscriptions of a user to every category.
+type ListSubscriptionsResponse struct {
+	UserID string                  `json:"userId"`
+	Items  []*SubscriptionResponse `json:"items"`
+}
+
+// FromCategory converts a category to its response DTO.
+func FromCategory(c *category.Category) *CategoryResponse {
+	return &CategoryResponse{
+		Name:              c.Name,
+		Description:       c.Description,
+		DefaultSubscribed: c.DefaultSubscribed,
+		CreatedAt:         c.CreatedAt.UnixMilli(),
+		UpdatedAt:         c.UpdatedAt.UnixMilli(),
+	}
+}
+
+// FromSubscription converts the subscription of a user to a category to its
+// response DTO, the default of the category when subscription is nil.
+func FromSubscription(c *category.Category, subscription *category.Subscription) *SubscriptionResponse {
+	response := &SubscriptionResponse{
+		Category:   c.Name,
+		Subscribed: c.Receives(subscription),
+		Default:    subscription == nil,
+	}
+	if subscription != nil {
+		updatedAt := subscription.UpdatedAt.UnixMilli()
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/category/dtos"
+	"notification/internal/domain/category"
+	"notification/internal/domain/shared"
+)
+
+// GetCategoryUseCase is the use case for querying the categories.
+type GetCategoryUseCase struct {
+	categoryRepo category.CategoryRepository
+}
+
+// NewGetCategoryUseCase creates a use case instance.
+func NewGetCategoryUseCase(categoryRepo category.CategoryRepository) *GetCategoryUseCase {
+	return &GetCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Get gets a category by name.
+func (uc *GetCategoryUseCase) Get(ctx context.Context, name string) (*dtos.CategoryResponse, error) {
+	name = category.NormalizeName(name)
+	if name == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("category name is required"))
+	}
+
+	c, err := uc.categoryRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtos.FromCategory(c), nil
+}
+
+// List lists every category by name.
+func (uc *GetCategoryUseCase) List(ctx context.Context) (*dtos.ListCategoriesResponse, error) {
+	categories, err := uc.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dtos.CategoryResponse, 0, len(categories))
+	for _, c := range categories {
+		items = append(items, dtos.FromCategory(c))
+	}
+
+	return &dtos.ListCategoriesResponse{Items: items}, nil
+}
+
+// UpdateCategoryUseCase is the use case for managing the categories.
+type UpdateCategoryUseCase struct {
+	categoryRepo category.CategoryRepository
+}
+
+// NewUpdateCategoryUseCase creates a use case instance.
+func NewUpdateCategoryUseCase(categoryRepo category.CategoryRepository) *UpdateCategoryUseCase {
+	return &UpdateCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Create creates a category.
+func (uc *UpdateCategoryUseCase) Create(ctx context.Context, request *dtos.CreateCategoryRequest) (*dtos.CategoryResponse, error) {
+	// 1. Validate input parameters
+	c, err := category.NewCategory(request.Name, request.Description, request.DefaultSubscribed)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 2. Check the name is free
+	if _, err := uc.categoryRepo.FindByName(ctx, c.Name); err == nil {
+		return nil, shared.NewConflictError("CATEGORY_NAME_CONFLICT", fmt.Sprintf("category '%s' already exists", c.Name))
+	} else if !shared.IsNotFound(err) {
+		return nil, err
+	}
+
+	// 3. Persist the category
+	if err := uc.categoryRepo.Save(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromCategory(c), nil
+}
+
+// Update updates the description of a category and whether recipients are
+// subscribed to it by default. The choices of the recipients are kept.
+func (uc *UpdateCategoryUseCase) Update(ctx context.Context, name string, request *dtos.UpdateCategoryRequest) (*dtos.CategoryResponse, error) {
+	// 1. Query the category
+	c, err := uc.categoryRepo.FindByName(ctx, category.NormalizeName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Apply the changes
+	if err := c.Update(request.Description, request.DefaultSubscribed); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 3. Persist them
+	if err := uc.categoryRepo.Update(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromCategory(c), nil
+}
+
+// Delete deletes a category and the subscriptions to it. Messages sent in the
+// category afterwards reach every recipient not opting out of it.
+func (uc *UpdateCategoryUseCase) Delete(ctx context.Context, name string) error {
+	name = category.NormalizeName(name)
+	if name == "" {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("category name is required"))
+	}
+
+	return uc.categoryRepo.Delete(ctx, name)
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/category/dtos"
+	"notification/internal/domain/category"
+	"notification/internal/domain/preference"
+	"notification/internal/domain/shared"
+)
+
+// GetSubscriptionUseCase is the use case for querying the subscriptions of a user.
+type GetSubscriptionUseCase struct {
+	categoryRepo     category.CategoryRepository
+	subscriptionRepo category.SubscriptionRepository
+}
+
+// NewGetSubscriptionUseCase creates a use case instance.
+func NewGetSubscriptionUseCase(categoryRepo category.CategoryRepository, subscriptionRepo category.SubscriptionRepository) *GetSubscriptionUseCase {
+	return &GetSubscriptionUseCase{
+		categoryRepo:     categoryRepo,
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// List lists whether a user receives the messages of every category.
+func (uc *GetSubscriptionUseCase) List(ctx context.Context, userID string) (*dtos.ListSubscriptionsResponse, error) {
+	// 1. Validate input parameters
+	userID = preference.NormalizeUserID(userID)
+	if userID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID is required"))
+	}
+
+	// 2. Query the categories and the choices of the user
+	categories, err := uc.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := uc.subscriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byCategory := make(map[string]*category.Subscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byCategory[subscription.Category] = subscription
+	}
+
+	// 3. Convert to response DTO
+	items := make([]*dtos.SubscriptionResponse, 0, len(categories))
+	for _, c := range categories {
+		items = append(items, dtos.FromSubscription(c, byCategory[c.Name]))
+	}
+
+	return &dtos.ListSubscriptionsResponse{
+		UserID: userID,
+		Items:  items,
+	}, nil
+}
+
+// UpdateSubscriptionUseCase is the use case for the users subscribing to and
+// unsubscribing from categories.
+type UpdateSubscriptionUseCase struct {
+	categoryRepo     category.CategoryRepository
+	subscriptionRepo category.SubscriptionRepository
+}
+
+// NewUpdateSubscriptionUseCase creates a use case instance.
+func NewUpdateSubscriptionUseCase(categoryRepo category.CategoryRepository, subscriptionRepo category.SubscriptionRepository) *UpdateSubscriptionUseCase {
+	return &UpdateSubscriptionUseCase{
+		categoryRepo:     categoryRepo,
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Subscribe makes a user receive the messages of a category.
+func (uc *UpdateSubscriptionUseCase) Subscribe(ctx context.Context, userID, categoryName string) (*dtos.SubscriptionResponse, error) {
+	return uc.save(ctx, userID, categoryName, true)
+}
+
+// Unsubscribe stops a user receiving the messages of a category.
+func (uc *UpdateSubscriptionUseCase) Unsubscribe(ctx context.Context, userID, categoryName string) (*dtos.SubscriptionResponse, error) {
+	return uc.save(ctx, userID, categoryName, false)
+}
+
+// save records the choice of a user for a category
+func (uc *UpdateSubscriptionUseCase) save(ctx context.Context, userID, categoryName string, subscribed bool) (*dtos.SubscriptionResponse, error) {
+	// 1. Validate input parameters
+	subscription, err := category.NewSubscription(userID, categoryName, subscribed)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 2. Query the category
+	c, err := uc.categoryRepo.FindByName(ctx, subscription.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Persist the choice
+	if err := uc.subscriptionRepo.Save(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromSubscription(c, subscription), nil
+}
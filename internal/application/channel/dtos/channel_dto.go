@@ -1,35 +1,154 @@
 package dtos
 
 import (
+	"fmt"
+
 	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
 	"notification/internal/domain/shared"
 )
 
 // CreateChannelRequest is the DTO for creating a channel.
 type CreateChannelRequest struct {
-	ChannelName    string                 `json:"channelName" binding:"required"`
-	Description    string                 `json:"description"`
-	Enabled        bool                   `json:"enabled"`
-	ChannelType    string                 `json:"channelType" binding:"required"`
-	TemplateID     string                 `json:"templateId"`
-	CommonSettings CommonSettingsDTO      `json:"commonSettings" binding:"required"`
-	Config         map[string]interface{} `json:"config" binding:"required"`
-	Recipients     []RecipientDTO         `json:"recipients"`
-	Tags           []string               `json:"tags"`
+	ChannelName      string                 `json:"channelName" binding:"required"`
+	Description      string                 `json:"description"`
+	Enabled          bool                   `json:"enabled"`
+	ChannelType      string                 `json:"channelType" binding:"required"`
+	TemplateID       string                 `json:"templateId"`
+	CommonSettings   CommonSettingsDTO      `json:"commonSettings" binding:"required"`
+	Config           map[string]interface{} `json:"config" binding:"required"`
+	Recipients       []RecipientDTO         `json:"recipients"`
+	Tags             []string               `json:"tags"`
+	VariableDefaults map[string]interface{} `json:"variableDefaults"`
+	Owner            string                 `json:"owner"`
+	Team             string                 `json:"team"`
+	// Environment is dev, staging or prod; empty serves every environment
+	Environment string `json:"environment"`
+	// FallbackChannelID is sent through when a delivery fails after the retries
+	FallbackChannelID string `json:"fallbackChannelId"`
 }
 
 // UpdateChannelRequest is the DTO for updating a channel.
 type UpdateChannelRequest struct {
-	ChannelID      string                 `json:"channelId,omitempty"`
-	ChannelName    string                 `json:"channelName" binding:"required"`
-	Description    string                 `json:"description"`
-	Enabled        bool                   `json:"enabled"`
-	ChannelType    string                 `json:"channelType" binding:"required"`
-	TemplateID     string                 `json:"templateId"`
-	CommonSettings CommonSettingsDTO      `json:"commonSettings" binding:"required"`
-	Config         map[string]interface{} `json:"config" binding:"required"`
-	Recipients     []RecipientDTO         `json:"recipients"`
-	Tags           []string               `json:"tags"`
+	ChannelID        string                 `json:"channelId,omitempty"`
+	ChannelName      string                 `json:"channelName" binding:"required"`
+	Description      string                 `json:"description"`
+	Enabled          bool                   `json:"enabled"`
+	ChannelType      string                 `json:"channelType" binding:"required"`
+	TemplateID       string                 `json:"templateId"`
+	CommonSettings   CommonSettingsDTO      `json:"commonSettings" binding:"required"`
+	Config           map[string]interface{} `json:"config" binding:"required"`
+	Recipients       []RecipientDTO         `json:"recipients"`
+	Tags             []string               `json:"tags"`
+	VariableDefaults map[string]interface{} `json:"variableDefaults"`
+	Owner            string                 `json:"owner"`
+	Team             string                 `json:"team"`
+	// Environment is dev, staging or prod; empty serves every environment
+	Environment string `json:"environment"`
+	// FallbackChannelID is sent through when a delivery fails after the retries
+	FallbackChannelID string `json:"fallbackChannelId"`
+}
+
+// PatchChannelRequest is the DTO for partially updating a channel.
+// Omitted fields keep their current value. Config keys are merged into the
+// existing configuration; a key set to null removes it. Variable defaults
+// are merged the same way. An empty fallback channel ID removes the fallback.
+type PatchChannelRequest struct {
+	ChannelName       *string                `json:"channelName,omitempty"`
+	Description       *string                `json:"description,omitempty"`
+	Enabled           *bool                  `json:"enabled,omitempty"`
+	ChannelType       *string                `json:"channelType,omitempty"`
+	TemplateID        *string                `json:"templateId,omitempty"`
+	CommonSettings    *CommonSettingsDTO     `json:"commonSettings,omitempty"`
+	Config            map[string]interface{} `json:"config,omitempty"`
+	Recipients        *[]RecipientDTO        `json:"recipients,omitempty"`
+	Tags              *[]string              `json:"tags,omitempty"`
+	VariableDefaults  map[string]interface{} `json:"variableDefaults,omitempty"`
+	Owner             *string                `json:"owner,omitempty"`
+	Team              *string                `json:"team,omitempty"`
+	Environment       *string                `json:"environment,omitempty"`
+	FallbackChannelID *string                `json:"fallbackChannelId,omitempty"`
+}
+
+// MergeInto applies the patch on top of the current channel state and
+// returns the resulting full update request.
+func (req *PatchChannelRequest) MergeInto(current *ChannelResponse) *UpdateChannelRequest {
+	merged := &UpdateChannelRequest{
+		ChannelID:         current.ChannelID,
+		ChannelName:       current.ChannelName,
+		Description:       current.Description,
+		Enabled:           current.Enabled,
+		ChannelType:       current.ChannelType,
+		TemplateID:        current.TemplateID,
+		CommonSettings:    current.CommonSettings,
+		Config:            make(map[string]interface{}, len(current.Config)),
+		Recipients:        current.Recipients,
+		Tags:              current.Tags,
+		VariableDefaults:  make(map[string]interface{}, len(current.VariableDefaults)),
+		Owner:             current.Owner,
+		Team:              current.Team,
+		Environment:       current.Environment,
+		FallbackChannelID: current.FallbackChannelID,
+	}
+	for k, v := range current.Config {
+		merged.Config[k] = v
+	}
+	for k, v := range current.VariableDefaults {
+		merged.VariableDefaults[k] = v
+	}
+
+	if req.ChannelName != nil {
+		merged.ChannelName = *req.ChannelName
+	}
+	if req.Description != nil {
+		merged.Description = *req.Description
+	}
+	if req.Enabled != nil {
+		merged.Enabled = *req.Enabled
+	}
+	if req.ChannelType != nil {
+		merged.ChannelType = *req.ChannelType
+	}
+	if req.TemplateID != nil {
+		merged.TemplateID = *req.TemplateID
+	}
+	if req.CommonSettings != nil {
+		merged.CommonSettings = *req.CommonSettings
+	}
+	for k, v := range req.Config {
+		if v == nil {
+			delete(merged.Config, k)
+			continue
+		}
+		merged.Config[k] = v
+	}
+	if req.Recipients != nil {
+		merged.Recipients = *req.Recipients
+	}
+	if req.Tags != nil {
+		merged.Tags = *req.Tags
+	}
+	for k, v := range req.VariableDefaults {
+		if v == nil {
+			delete(merged.VariableDefaults, k)
+			continue
+		}
+		merged.VariableDefaults[k] = v
+	}
+	if req.Owner != nil {
+		merged.Owner = *req.Owner
+	}
+	if req.Team != nil {
+		merged.Team = *req.Team
+	}
+	if req.Environment != nil {
+		merged.Environment = *req.Environment
+	}
+	if req.FallbackChannelID != nil {
+		merged.FallbackChannelID = *req.FallbackChannelID
+	}
+
+	return merged
 }
 
 // ListChannelsRequest is the DTO for listing channels.
@@ -38,34 +157,58 @@ type ListChannelsRequest struct {
 	Tags           []string `form:"tags" json:"tags"`
 	SkipCount      int      `form:"skipCount" json:"skipCount"`
 	MaxResultCount int      `form:"maxResultCount" json:"maxResultCount"`
+	TemplateID     string   `form:"templateId" json:"templateId"`
+	Owner          string   `form:"owner" json:"owner"`
+	Team           string   `form:"team" json:"team"`
+	Environment    string   `form:"environment" json:"environment"`
+	LastUsedBefore *int64   `form:"lastUsedBefore" json:"lastUsedBefore,omitempty"`
+	LastUsedAfter  *int64   `form:"lastUsedAfter" json:"lastUsedAfter,omitempty"`
+	UnusedForDays  int      `form:"unusedForDays" json:"unusedForDays,omitempty"`
+	SortField      string   `form:"sortField" json:"sortField"`
+	SortOrder      string   `form:"sortOrder" json:"sortOrder"`
 }
 
 // ChannelResponse is the DTO for a channel response.
 type ChannelResponse struct {
-	ChannelID      string                 `json:"channelId"`
-	ChannelName    string                 `json:"channelName"`
-	Description    string                 `json:"description"`
-	Enabled        bool                   `json:"enabled"`
-	ChannelType    string                 `json:"channelType"`
-	TemplateID     string                 `json:"templateId,omitempty"`
-	CommonSettings CommonSettingsDTO      `json:"commonSettings"`
-	Config         map[string]interface{} `json:"config"`
-	Recipients     []RecipientDTO         `json:"recipients"`
-	Tags           []string               `json:"tags"`
-	CreatedAt      int64                  `json:"createdAt"`
-	UpdatedAt      int64                  `json:"updatedAt"`
-	LastUsed       *int64                 `json:"lastUsed,omitempty"`
+	ChannelID         string                 `json:"channelId"`
+	ChannelName       string                 `json:"channelName"`
+	Description       string                 `json:"description"`
+	Enabled           bool                   `json:"enabled"`
+	Maintenance       bool                   `json:"maintenance"`
+	ChannelType       string                 `json:"channelType"`
+	TemplateID        string                 `json:"templateId,omitempty"`
+	CommonSettings    CommonSettingsDTO      `json:"commonSettings"`
+	Config            map[string]interface{} `json:"config"`
+	Recipients        []RecipientDTO         `json:"recipients"`
+	Tags              []string               `json:"tags"`
+	VariableDefaults  map[string]interface{} `json:"variableDefaults"`
+	Owner             string                 `json:"owner,omitempty"`
+	Team              string                 `json:"team,omitempty"`
+	Environment       string                 `json:"environment,omitempty"`
+	FallbackChannelID string                 `json:"fallbackChannelId,omitempty"`
+	Health            *ChannelHealthDTO      `json:"health,omitempty"`
+	CreatedAt         int64                  `json:"createdAt"`
+	UpdatedAt         int64                  `json:"updatedAt"`
+	LastUsed          *int64                 `json:"lastUsed,omitempty"`
+	Version           int64                  `json:"version"`
 }
 
 // ChannelSummaryResponse is the DTO for a channel summary response (for list queries).
 type ChannelSummaryResponse struct {
-	ChannelID   string   `json:"channelId"`
-	ChannelName string   `json:"channelName"`
-	ChannelType string   `json:"channelType"`
-	Tags        []string `json:"tags"`
-	Enabled     bool     `json:"enabled"`
-	CreatedAt   int64    `json:"createdAt"`
-	UpdatedAt   int64    `json:"updatedAt"`
+	ChannelID   string            `json:"channelId"`
+	ChannelName string            `json:"channelName"`
+	ChannelType string            `json:"channelType"`
+	TemplateID  string            `json:"templateId,omitempty"`
+	Tags        []string          `json:"tags"`
+	Enabled     bool              `json:"enabled"`
+	Maintenance bool              `json:"maintenance"`
+	Owner       string            `json:"owner,omitempty"`
+	Team        string            `json:"team,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Health      *ChannelHealthDTO `json:"health,omitempty"`
+	CreatedAt   int64             `json:"createdAt"`
+	UpdatedAt   int64             `json:"updatedAt"`
+	LastUsed    *int64            `json:"lastUsed,omitempty"`
 }
 
 // ListChannelsResponse is the DTO for a list of channels.
@@ -82,6 +225,50 @@ type DeleteChannelResponse struct {
 	ChannelID string `json:"channelId"`
 	Deleted   bool   `json:"deleted"`
 	DeletedAt int64  `json:"deletedAt"`
+	Version   int64  `json:"version"`
+}
+
+// MaxBulkItems is the maximum number of items accepted by a single bulk request.
+const MaxBulkItems = 500
+
+// BulkCreateChannelsRequest is the DTO for creating several channels at once.
+type BulkCreateChannelsRequest struct {
+	Channels []CreateChannelRequest `json:"channels" binding:"required"`
+}
+
+// BulkChannelIDsRequest is the DTO for bulk enable, disable and delete operations.
+type BulkChannelIDsRequest struct {
+	ChannelIDs []string `json:"channelIds" binding:"required"`
+}
+
+// BulkTagChannelsRequest is the DTO for adding and removing tags on several channels.
+type BulkTagChannelsRequest struct {
+	ChannelIDs []string `json:"channelIds" binding:"required"`
+	AddTags    []string `json:"addTags"`
+	RemoveTags []string `json:"removeTags"`
+}
+
+// BulkItemError is the DTO for the error of a single bulk item.
+type BulkItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BulkItemResult is the DTO for the outcome of a single bulk item.
+type BulkItemResult struct {
+	Index     int            `json:"index"`
+	ChannelID string         `json:"channelId,omitempty"`
+	Success   bool           `json:"success"`
+	Data      interface{}    `json:"data,omitempty"`
+	Error     *BulkItemError `json:"error,omitempty"`
+}
+
+// BulkOperationResponse is the DTO for a bulk operation response.
+type BulkOperationResponse struct {
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
 }
 
 // CommonSettingsDTO is the DTO for common settings.
@@ -105,29 +292,108 @@ func FromCommonSettings(settings *shared.CommonSettings) CommonSettingsDTO {
 	}
 }
 
+// ChannelMaintenanceResponse is the DTO for a maintenance mode change.
+type ChannelMaintenanceResponse struct {
+	ChannelID   string `json:"channelId"`
+	Maintenance bool   `json:"maintenance"`
+	// ReleasedCount is the number of parked messages sent when maintenance ended
+	ReleasedCount int `json:"releasedCount"`
+}
+
+// ChannelDeliverabilityResponse is the DTO for the check of the DNS records
+// of the sender domain of an email channel.
+type ChannelDeliverabilityResponse struct {
+	ChannelID     string `json:"channelId"`
+	SenderAddress string `json:"senderAddress"`
+	*services.DeliverabilityReport
+	CheckedAt int64 `json:"checkedAt"`
+}
+
+// ChannelReadinessDTO is the DTO for the check of a channel at its provider.
+type ChannelReadinessDTO struct {
+	ChannelID   string `json:"channelId"`
+	ChannelName string `json:"channelName"`
+	ChannelType string `json:"channelType"`
+	// Status is ready, reachable, unverified or failed
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ChannelReadinessResponse is the DTO for the readiness of the enabled channels.
+type ChannelReadinessResponse struct {
+	Channels   []*ChannelReadinessDTO `json:"channels"`
+	Ready      int                    `json:"ready"`
+	Reachable  int                    `json:"reachable"`
+	Unverified int                    `json:"unverified"`
+	Failed     int                    `json:"failed"`
+	CheckedAt  int64                  `json:"checkedAt"`
+}
+
+// ChannelHealthDTO is the DTO for the delivery health of a channel.
+type ChannelHealthDTO struct {
+	Status       string  `json:"status"`
+	SuccessCount int     `json:"successCount"`
+	FailureCount int     `json:"failureCount"`
+	FailureRate  float64 `json:"failureRate"`
+	CheckedAt    int64   `json:"checkedAt,omitempty"`
+}
+
+// FromChannelID converts an optional channel ID, empty when it is nil.
+func FromChannelID(id *channel.ChannelID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// FromChannelHealth creates a DTO from a domain object.
+func FromChannelHealth(health *channel.ChannelHealth) *ChannelHealthDTO {
+	return &ChannelHealthDTO{
+		Status:       string(health.Status),
+		SuccessCount: health.Successes,
+		FailureCount: health.Failures,
+		FailureRate:  health.FailureRate(),
+		CheckedAt:    health.CheckedAt,
+	}
+}
+
 // RecipientDTO is the DTO for a recipient.
 type RecipientDTO struct {
 	Name   string `json:"name" binding:"required"`
 	Target string `json:"target,omitempty"`
 	Type   string `json:"type" binding:"required"`
+	// Timezone is the IANA time zone sends scheduled in local time reach the recipient in
+	Timezone string `json:"timezone,omitempty"`
 }
 
 // ToRecipient converts to a domain object.
 func (dto RecipientDTO) ToRecipient() (*channel.Recipient, error) {
-	return channel.NewRecipient(dto.Name, dto.Target, dto.Type)
+	recipient, err := channel.NewRecipient(dto.Name, dto.Target, dto.Type)
+	if err != nil {
+		return nil, err
+	}
+	return recipient.WithTimezone(dto.Timezone)
 }
 
 // FromRecipient creates a DTO from a domain object.
 func FromRecipient(recipient *channel.Recipient) RecipientDTO {
 	return RecipientDTO{
-		Name:   recipient.Name,
-		Target: recipient.Target,
-		Type:   recipient.Type,
+		Name:     recipient.Name,
+		Target:   recipient.Target,
+		Type:     recipient.Type,
+		Timezone: recipient.Timezone,
 	}
 }
 
+// MaxRecipients is the maximum number of recipients a channel may have.
+const MaxRecipients = 1000
+
 // ToRecipientsSlice converts to a slice of recipients.
 func ToRecipientsSlice(dtos []RecipientDTO) ([]*channel.Recipient, error) {
+	if len(dtos) > MaxRecipients {
+		return nil, fmt.Errorf("a channel accepts at most %d recipients, got %d", MaxRecipients, len(dtos))
+	}
+
 	recipients := make([]*channel.Recipient, 0, len(dtos))
 	for _, dto := range dtos {
 		recipient, err := dto.ToRecipient()
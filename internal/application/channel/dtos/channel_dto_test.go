@@ -0,0 +1,64 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchChannelRequest_MergeInto(t *testing.T) {
+	current := &ChannelResponse{
+		ChannelID:      "ch-1",
+		ChannelName:    "alerts",
+		Description:    "ops alerts",
+		Enabled:        true,
+		ChannelType:    "email",
+		CommonSettings: CommonSettingsDTO{Timeout: 30, RetryAttempts: 3, RetryDelay: 5},
+		Config: map[string]interface{}{
+			"host":     "smtp.example.com",
+			"password": "secret",
+			"legacy":   "drop-me",
+		},
+		Recipients: []RecipientDTO{{Name: "Ops", Target: "ops@example.com", Type: "to"}},
+		Tags:       []string{"ops"},
+		VariableDefaults: map[string]interface{}{
+			"team":   "Ops",
+			"footer": "Sent by ops",
+		},
+	}
+
+	enabled := false
+	tags := []string{"ops", "critical"}
+	patch := &PatchChannelRequest{
+		Enabled: &enabled,
+		Tags:    &tags,
+		Config: map[string]interface{}{
+			"host":   "smtp2.example.com",
+			"legacy": nil,
+		},
+		VariableDefaults: map[string]interface{}{
+			"team":   "SRE",
+			"footer": nil,
+		},
+	}
+
+	merged := patch.MergeInto(current)
+
+	assert.Equal(t, "ch-1", merged.ChannelID)
+	assert.Equal(t, "alerts", merged.ChannelName)
+	assert.Equal(t, "ops alerts", merged.Description)
+	assert.False(t, merged.Enabled)
+	assert.Equal(t, "email", merged.ChannelType)
+	assert.Equal(t, current.CommonSettings, merged.CommonSettings)
+	assert.Equal(t, map[string]interface{}{
+		"host":     "smtp2.example.com",
+		"password": "secret",
+	}, merged.Config)
+	assert.Equal(t, current.Recipients, merged.Recipients)
+	assert.Equal(t, []string{"ops", "critical"}, merged.Tags)
+	assert.Equal(t, map[string]interface{}{"team": "SRE"}, merged.VariableDefaults)
+
+	// The current state must not be mutated by the merge
+	assert.Equal(t, "drop-me", current.Config["legacy"])
+	assert.Equal(t, "Sent by ops", current.VariableDefaults["footer"])
+}
@@ -0,0 +1,173 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/channel/dtos"
+	"notification/internal/domain/shared"
+)
+
+// BulkChannelUseCase is the use case for applying one operation to many channels.
+// Items are processed independently: a failing item is reported in its result
+// and does not stop the remaining items.
+type BulkChannelUseCase struct {
+	createUseCase *CreateChannelUseCase
+	getUseCase    *GetChannelUseCase
+	updateUseCase *UpdateChannelUseCase
+	deleteUseCase *DeleteChannelUseCase
+	enableUseCase *SetChannelEnabledUseCase
+}
+
+// NewBulkChannelUseCase creates a use case instance.
+func NewBulkChannelUseCase(
+	createUseCase *CreateChannelUseCase,
+	getUseCase *GetChannelUseCase,
+	updateUseCase *UpdateChannelUseCase,
+	deleteUseCase *DeleteChannelUseCase,
+	enableUseCase *SetChannelEnabledUseCase,
+) *BulkChannelUseCase {
+	return &BulkChannelUseCase{
+		createUseCase: createUseCase,
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		enableUseCase: enableUseCase,
+	}
+}
+
+// Create creates every channel in the request.
+func (uc *BulkChannelUseCase) Create(ctx context.Context, request *dtos.BulkCreateChannelsRequest) (*dtos.BulkOperationResponse, error) {
+	// 1. Validate input parameters
+	if err := validateBulkSize(len(request.Channels)); err != nil {
+		return nil, err
+	}
+
+	// 2. Create each channel
+	response := newBulkOperationResponse(len(request.Channels))
+	for i := range request.Channels {
+		created, err := uc.createUseCase.Execute(ctx, &request.Channels[i])
+		if err != nil {
+			response.addFailure(i, "", err, "CREATE_CHANNEL_FAILED")
+			continue
+		}
+		response.addSuccess(i, created.ChannelID, created)
+	}
+
+	return response.BulkOperationResponse, nil
+}
+
+// SetEnabled enables or disables every channel in the request.
+func (uc *BulkChannelUseCase) SetEnabled(ctx context.Context, request *dtos.BulkChannelIDsRequest, enabled bool) (*dtos.BulkOperationResponse, error) {
+	return uc.forEachChannel(request.ChannelIDs, "UPDATE_CHANNEL_FAILED", func(channelID string) (interface{}, error) {
+		return uc.enableUseCase.Execute(ctx, channelID, enabled)
+	})
+}
+
+// Delete deletes every channel in the request.
+func (uc *BulkChannelUseCase) Delete(ctx context.Context, request *dtos.BulkChannelIDsRequest) (*dtos.BulkOperationResponse, error) {
+	return uc.forEachChannel(request.ChannelIDs, "DELETE_CHANNEL_FAILED", func(channelID string) (interface{}, error) {
+		return uc.deleteUseCase.Execute(ctx, channelID)
+	})
+}
+
+// Tag adds and removes tags on every channel in the request.
+func (uc *BulkChannelUseCase) Tag(ctx context.Context, request *dtos.BulkTagChannelsRequest) (*dtos.BulkOperationResponse, error) {
+	if len(request.AddTags) == 0 && len(request.RemoveTags) == 0 {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("at least one of addTags or removeTags is required"))
+	}
+
+	return uc.forEachChannel(request.ChannelIDs, "UPDATE_CHANNEL_FAILED", func(channelID string) (interface{}, error) {
+		current, err := uc.getUseCase.Execute(ctx, channelID)
+		if err != nil {
+			return nil, err
+		}
+
+		tags := applyTagChanges(current.Tags, request.AddTags, request.RemoveTags)
+		return uc.updateUseCase.Patch(ctx, channelID, &dtos.PatchChannelRequest{Tags: &tags})
+	})
+}
+
+// forEachChannel runs the operation for each channel ID and collects the per-item results.
+func (uc *BulkChannelUseCase) forEachChannel(channelIDs []string, fallbackCode string, operation func(channelID string) (interface{}, error)) (*dtos.BulkOperationResponse, error) {
+	if err := validateBulkSize(len(channelIDs)); err != nil {
+		return nil, err
+	}
+
+	response := newBulkOperationResponse(len(channelIDs))
+	for i, channelID := range channelIDs {
+		data, err := operation(channelID)
+		if err != nil {
+			response.addFailure(i, channelID, err, fallbackCode)
+			continue
+		}
+		response.addSuccess(i, channelID, data)
+	}
+
+	return response.BulkOperationResponse, nil
+}
+
+// validateBulkSize checks the number of items in a bulk request.
+func validateBulkSize(count int) error {
+	if count == 0 {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("at least one item is required"))
+	}
+	if count > dtos.MaxBulkItems {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("a bulk request accepts at most %d items, got %d", dtos.MaxBulkItems, count))
+	}
+	return nil
+}
+
+// applyTagChanges returns the tags with additions appended and removals dropped, preserving order.
+func applyTagChanges(current, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, tag := range remove {
+		removed[tag] = true
+	}
+
+	seen := make(map[string]bool, len(current)+len(add))
+	tags := make([]string, 0, len(current)+len(add))
+	for _, tag := range append(append([]string{}, current...), add...) {
+		if removed[tag] || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// bulkOperationResponse accumulates per-item results.
+type bulkOperationResponse struct {
+	*dtos.BulkOperationResponse
+}
+
+func newBulkOperationResponse(total int) *bulkOperationResponse {
+	return &bulkOperationResponse{&dtos.BulkOperationResponse{
+		Total:   total,
+		Results: make([]dtos.BulkItemResult, 0, total),
+	}}
+}
+
+func (r *bulkOperationResponse) addSuccess(index int, channelID string, data interface{}) {
+	r.Succeeded++
+	r.Results = append(r.Results, dtos.BulkItemResult{
+		Index:     index,
+		ChannelID: channelID,
+		Success:   true,
+		Data:      data,
+	})
+}
+
+func (r *bulkOperationResponse) addFailure(index int, channelID string, err error, fallbackCode string) {
+	r.Failed++
+	r.Results = append(r.Results, dtos.BulkItemResult{
+		Index:     index,
+		ChannelID: channelID,
+		Success:   false,
+		Error: &dtos.BulkItemError{
+			Code:    shared.ErrorCodeOf(err, fallbackCode),
+			Message: err.Error(),
+		},
+	})
+}
@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/application/channel/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/pkg/config"
+)
+
+// memoryChannelRepository keeps the channels in memory, failing the updates
+// of the channels listed in updateErrs
+type memoryChannelRepository struct {
+	channel.ChannelRepository
+	channels   map[string]*channel.Channel
+	updateErrs map[string]error
+}
+
+func (r *memoryChannelRepository) Save(ctx context.Context, ch *channel.Channel) error {
+	r.channels[ch.ID().String()] = ch
+	return nil
+}
+
+func (r *memoryChannelRepository) FindByID(ctx context.Context, id *channel.ChannelID) (*channel.Channel, error) {
+	ch, ok := r.channels[id.String()]
+	if !ok {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")
+	}
+	return ch, nil
+}
+
+func (r *memoryChannelRepository) ExistsByName(ctx context.Context, name *channel.ChannelName) (bool, error) {
+	return false, nil
+}
+
+func (r *memoryChannelRepository) Update(ctx context.Context, ch *channel.Channel) error {
+	if err := r.updateErrs[ch.ID().String()]; err != nil {
+		return err
+	}
+	r.channels[ch.ID().String()] = ch
+	return nil
+}
+
+func (r *memoryChannelRepository) UpdateEnabled(ctx context.Context, ch *channel.Channel) error {
+	return r.Update(ctx, ch)
+}
+
+// legacySystem accepts every group change but the creation of the groups named taken
+func legacySystem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	var group LegacyChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&group); err != nil || group.Name == "taken" {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]string{"groupId": uuid.New().String()})
+}
+
+func emailConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"host": "smtp.example.com", "port": float64(587), "username": "ops", "password": "secret",
+		"senderEmail": "ops@example.com", "secure": true, "method": "tls",
+	}
+}
+
+func newBulkChannelUseCase(t *testing.T) (*BulkChannelUseCase, *memoryChannelRepository) {
+	t.Helper()
+	shared.InitializeChannelTypes()
+	server := httptest.NewServer(http.HandlerFunc(legacySystem))
+	t.Cleanup(server.Close)
+
+	repo := &memoryChannelRepository{channels: map[string]*channel.Channel{}, updateErrs: map[string]error{}}
+	validator := services.NewChannelValidator(repo, nil)
+	cfg := &config.Config{LegacySystem: config.LegacySystemConfig{URL: server.URL}}
+	return NewBulkChannelUseCase(
+		NewCreateChannelUseCase(repo, nil, validator, cfg),
+		NewGetChannelUseCase(repo),
+		NewUpdateChannelUseCase(repo, nil, validator, cfg),
+		NewDeleteChannelUseCase(repo, validator, cfg),
+		NewSetChannelEnabledUseCase(repo),
+	), repo
+}
+
+// addChannel stores an enabled email channel tagged with tags
+func addChannel(t *testing.T, repo *memoryChannelRepository, name string, tags ...string) string {
+	t.Helper()
+	channelName, err := channel.NewChannelName(name)
+	require.NoError(t, err)
+	recipient, err := channel.NewRecipient("ops", "ops@example.com", "to")
+	require.NoError(t, err)
+	ch, err := channel.NewChannel(channelName, nil, true, shared.ChannelTypeEmail, nil,
+		&shared.CommonSettings{Timeout: 10}, channel.NewChannelConfig(emailConfig()),
+		channel.NewRecipients([]*channel.Recipient{recipient}), channel.NewTags(tags))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), ch))
+	return ch.ID().String()
+}
+
+// itemErrorCodes returns the error code of each result, empty for the successes
+func itemErrorCodes(response *dtos.BulkOperationResponse) []string {
+	codes := make([]string, len(response.Results))
+	for i, result := range response.Results {
+		if result.Error != nil {
+			codes[i] = result.Error.Code
+		}
+	}
+	return codes
+}
+
+func TestBulkChannelUseCase_Create(t *testing.T) {
+	useCase, repo := newBulkChannelUseCase(t)
+	request := func(name string) dtos.CreateChannelRequest {
+		return dtos.CreateChannelRequest{
+			ChannelName:    name,
+			Enabled:        true,
+			ChannelType:    shared.ChannelTypeEmail.String(),
+			CommonSettings: dtos.CommonSettingsDTO{Timeout: 10},
+			Config:         emailConfig(),
+			Recipients:     []dtos.RecipientDTO{{Name: "ops", Target: "ops@example.com", Type: "to"}},
+		}
+	}
+
+	response, err := useCase.Create(context.Background(), &dtos.BulkCreateChannelsRequest{
+		Channels: []dtos.CreateChannelRequest{request("alerts"), request(""), request("taken")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.Total)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 2, response.Failed)
+	assert.Equal(t, []string{"", "INVALID_REQUEST", "LEGACY_CONFLICT"}, itemErrorCodes(response))
+	assert.True(t, response.Results[0].Success)
+	assert.Contains(t, repo.channels, response.Results[0].ChannelID)
+	assert.Len(t, repo.channels, 1)
+}
+
+func TestBulkChannelUseCase_SetEnabled(t *testing.T) {
+	useCase, repo := newBulkChannelUseCase(t)
+	enabled := addChannel(t, repo, "alerts")
+	failing := addChannel(t, repo, "reports")
+	repo.updateErrs[failing] = errors.New("connection refused")
+
+	response, err := useCase.SetEnabled(context.Background(), &dtos.BulkChannelIDsRequest{
+		ChannelIDs: []string{enabled, uuid.New().String(), "", failing},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, response.Total)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 3, response.Failed)
+	assert.Equal(t, []string{"", "CHANNEL_NOT_FOUND", "INVALID_REQUEST", "UPDATE_CHANNEL_FAILED"}, itemErrorCodes(response))
+	for i, result := range response.Results {
+		assert.Equal(t, i, result.Index)
+	}
+	assert.False(t, repo.channels[enabled].IsEnabled())
+}
+
+func TestBulkChannelUseCase_Delete(t *testing.T) {
+	useCase, repo := newBulkChannelUseCase(t)
+	deleted := addChannel(t, repo, "alerts")
+	failing := addChannel(t, repo, "reports")
+	repo.updateErrs[failing] = errors.New("connection refused")
+
+	response, err := useCase.Delete(context.Background(), &dtos.BulkChannelIDsRequest{
+		ChannelIDs: []string{deleted, failing, deleted},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 2, response.Failed)
+	assert.Equal(t, []string{"", "DELETE_CHANNEL_FAILED", "CHANNEL_NOT_FOUND"}, itemErrorCodes(response))
+	assert.Equal(t, failing, response.Results[1].ChannelID)
+	assert.True(t, repo.channels[deleted].IsDeleted())
+}
+
+func TestBulkChannelUseCase_Tag(t *testing.T) {
+	useCase, repo := newBulkChannelUseCase(t)
+	tagged := addChannel(t, repo, "alerts", "ops", "legacy")
+	missing := uuid.New().String()
+
+	response, err := useCase.Tag(context.Background(), &dtos.BulkTagChannelsRequest{
+		ChannelIDs: []string{tagged, missing},
+		AddTags:    []string{"critical", "ops"},
+		RemoveTags: []string{"legacy"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, []string{"", "CHANNEL_NOT_FOUND"}, itemErrorCodes(response))
+	assert.Equal(t, []string{"ops", "critical"}, repo.channels[tagged].Tags().ToSlice())
+
+	_, err = useCase.Tag(context.Background(), &dtos.BulkTagChannelsRequest{ChannelIDs: []string{tagged}})
+	assert.Equal(t, shared.ErrorKindValidation, shared.ErrorKindOf(err))
+}
+
+func TestBulkChannelUseCase_RejectsTheRequestSize(t *testing.T) {
+	useCase, _ := newBulkChannelUseCase(t)
+
+	_, err := useCase.Delete(context.Background(), &dtos.BulkChannelIDsRequest{})
+	assert.Equal(t, shared.ErrorKindValidation, shared.ErrorKindOf(err))
+
+	_, err = useCase.Delete(context.Background(), &dtos.BulkChannelIDsRequest{
+		ChannelIDs: make([]string, dtos.MaxBulkItems+1),
+	})
+	assert.Equal(t, shared.ErrorKindValidation, shared.ErrorKindOf(err))
+}
+
+func TestApplyTagChanges(t *testing.T) {
+	assert.Equal(t, []string{"a", "c", "d"}, applyTagChanges([]string{"a", "b", "c"}, []string{"c", "d"}, []string{"b"}))
+	assert.Equal(t, []string{}, applyTagChanges(nil, nil, nil))
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+
+	"notification/internal/application/channel/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// maskedConfigValue replaces the values of secret config keys in published changes
+const maskedConfigValue = "****"
+
+// notifyChannelChange reports a change of a channel to the notifier, if any
+func notifyChannelChange(ctx context.Context, notifier shared.ChangeNotifier, changeType, channelID string, before, after *dtos.ChannelResponse) {
+	if notifier == nil {
+		return
+	}
+
+	var beforeState, afterState interface{}
+	if before != nil {
+		beforeState = maskChannelSecrets(before)
+	}
+	if after != nil {
+		afterState = maskChannelSecrets(after)
+	}
+	notifier.Changed(ctx, shared.NewChange(ctx, changeType, channelID, beforeState, afterState))
+}
+
+// maskChannelSecrets returns a copy of a channel whose secret config values
+// are masked, so that it can be published
+func maskChannelSecrets(response *dtos.ChannelResponse) *dtos.ChannelResponse {
+	masked := *response
+	masked.Config = make(map[string]interface{}, len(response.Config))
+	for key, value := range response.Config {
+		if channel.IsSecretConfigKey(key) {
+			value = maskedConfigValue
+		}
+		masked.Config[key] = value
+	}
+	return &masked
+}
+
+// channelSnapshot returns the state of a channel reported in its changes
+func channelSnapshot(ch *channel.Channel) *dtos.ChannelResponse {
+	var templateID string
+	if ch.TemplateID() != nil {
+		templateID = ch.TemplateID().String()
+	}
+
+	return &dtos.ChannelResponse{
+		ChannelID:         ch.ID().String(),
+		ChannelName:       ch.Name().String(),
+		Description:       ch.Description().String(),
+		Enabled:           ch.IsEnabled(),
+		Maintenance:       ch.InMaintenance(),
+		ChannelType:       ch.ChannelType().String(),
+		TemplateID:        templateID,
+		CommonSettings:    dtos.FromCommonSettings(ch.CommonSettings()),
+		Config:            ch.Config().ToMap(),
+		Recipients:        dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
+		Tags:              ch.Tags().ToSlice(),
+		VariableDefaults:  ch.VariableDefaults().ToMap(),
+		Owner:             ch.Ownership().Owner,
+		Team:              ch.Ownership().Team,
+		Environment:       ch.Environment().String(),
+		FallbackChannelID: dtos.FromChannelID(ch.FallbackChannelID()),
+		Health:            dtos.FromChannelHealth(ch.Health()),
+		CreatedAt:         ch.Timestamps().CreatedAt,
+		UpdatedAt:         ch.Timestamps().UpdatedAt,
+		LastUsed:          ch.LastUsed(),
+		Version:           ch.Version(),
+	}
+}
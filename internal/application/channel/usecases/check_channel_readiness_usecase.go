@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"notification/internal/application/channel/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// readinessConcurrency is the number of channels checked at a time
+const readinessConcurrency = 8
+
+// ReadinessChecker checks a channel at its provider, e.g. that the SMTP
+// server of an email channel accepts its credentials
+type ReadinessChecker interface {
+	CheckReadiness(ctx context.Context, ch *channel.Channel) (channel.ReadinessStatus, error)
+}
+
+// CheckChannelReadinessUseCase checks the credentials of the enabled
+// channels at their providers, so that bad credentials are found before a
+// send fails on them.
+type CheckChannelReadinessUseCase struct {
+	channelRepo channel.ChannelRepository
+	checker     ReadinessChecker
+}
+
+// NewCheckChannelReadinessUseCase creates a use case instance.
+func NewCheckChannelReadinessUseCase(channelRepo channel.ChannelRepository, checker ReadinessChecker) *CheckChannelReadinessUseCase {
+	return &CheckChannelReadinessUseCase{
+		channelRepo: channelRepo,
+		checker:     checker,
+	}
+}
+
+// Execute checks every enabled channel and summarizes the outcome by status.
+func (uc *CheckChannelReadinessUseCase) Execute(ctx context.Context) (*dtos.ChannelReadinessResponse, error) {
+	channels, err := uc.enabledChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*dtos.ChannelReadinessDTO, len(channels))
+	slots := make(chan struct{}, readinessConcurrency)
+	var wg sync.WaitGroup
+	for i, ch := range channels {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[i] = uc.check(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	response := &dtos.ChannelReadinessResponse{
+		Channels:  results,
+		CheckedAt: time.Now().UnixMilli(),
+	}
+	for _, result := range results {
+		switch channel.ReadinessStatus(result.Status) {
+		case channel.ReadinessReady:
+			response.Ready++
+		case channel.ReadinessReachable:
+			response.Reachable++
+		case channel.ReadinessUnverified:
+			response.Unverified++
+		default:
+			response.Failed++
+		}
+	}
+	return response, nil
+}
+
+// check checks one channel
+func (uc *CheckChannelReadinessUseCase) check(ctx context.Context, ch *channel.Channel) *dtos.ChannelReadinessDTO {
+	result := &dtos.ChannelReadinessDTO{
+		ChannelID:   ch.ID().String(),
+		ChannelName: ch.Name().String(),
+		ChannelType: ch.ChannelType().String(),
+	}
+
+	status, err := uc.checker.CheckReadiness(ctx, ch)
+	if err != nil {
+		status = channel.ReadinessFailed
+		result.Error = err.Error()
+	}
+	result.Status = string(status)
+	return result
+}
+
+// enabledChannels loads the enabled channels page by page.
+func (uc *CheckChannelReadinessUseCase) enabledChannels(ctx context.Context) ([]*channel.Channel, error) {
+	filter := channel.NewChannelFilter().WithEnabled(true)
+	var channels []*channel.Channel
+	for skipCount := 0; ; {
+		pagination, err := shared.NewPagination(skipCount, 100)
+		if err != nil {
+			return nil, err
+		}
+		page, err := uc.channelRepo.FindAll(ctx, filter, pagination)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list channels: %w", err)
+		}
+		channels = append(channels, page.Items...)
+
+		if !page.HasMore {
+			return channels, nil
+		}
+		skipCount += len(page.Items)
+	}
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"notification/internal/application/channel/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/identity"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// CheckDeliverabilityUseCase checks the SPF, DKIM and DMARC records of the
+// sender domain of an email channel, as misconfigured domains get their
+// email silently dropped.
+type CheckDeliverabilityUseCase struct {
+	channelRepo    channel.ChannelRepository
+	deliverability *services.EmailDeliverability
+}
+
+// NewCheckDeliverabilityUseCase creates a use case instance.
+func NewCheckDeliverabilityUseCase(channelRepo channel.ChannelRepository, deliverability *services.EmailDeliverability) *CheckDeliverabilityUseCase {
+	return &CheckDeliverabilityUseCase{
+		channelRepo:    channelRepo,
+		deliverability: deliverability,
+	}
+}
+
+// Execute checks the sender domain of an email channel, with the DKIM keys
+// of the given selectors, else of those of the channel config, else of the
+// common ones.
+func (uc *CheckDeliverabilityUseCase) Execute(ctx context.Context, channelID string, selectors []string) (*dtos.ChannelDeliverabilityResponse, error) {
+	id, err := channel.NewChannelIDFromString(channelID)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
+	}
+	ch, err := uc.channelRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("channel not found: %w", err)
+	}
+	if ch.IsDeleted() {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel has been deleted")
+	}
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return nil, shared.NewValidationError("INVALID_REQUEST",
+			fmt.Errorf("deliverability is checked for email channels, not %s", ch.ChannelType()))
+	}
+
+	sender, err := services.EmailSenderAddress(ch)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if len(selectors) == 0 {
+		if value, ok := ch.Config().Get(services.DKIMSelectorConfigKey); ok {
+			selectors = splitList(fmt.Sprintf("%v", value))
+		}
+	}
+
+	return &dtos.ChannelDeliverabilityResponse{
+		ChannelID:            ch.ID().String(),
+		SenderAddress:        sender,
+		DeliverabilityReport: uc.deliverability.Check(ctx, identity.DomainOf(sender), selectors),
+		CheckedAt:            time.Now().UnixMilli(),
+	}, nil
+}
+
+// splitList splits a comma separated list, dropping the empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
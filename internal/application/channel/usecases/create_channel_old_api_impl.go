@@ -2,10 +2,10 @@ package usecases
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"notification/internal/application/channel/dtos"
+	"notification/internal/domain/shared"
 	"notification/internal/infrastructure/external"
 )
 
@@ -83,23 +83,29 @@ func (uc *CreateChannelOldAPIUseCase) Execute(ctx context.Context, request *dtos
 	// --- Call the old system's API ---
 	oldAPIResp, err := uc.oldSystemClient.CreateGroup(oldAPIReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create group in old system: %w", err)
+		return nil, shared.WrapLegacyError("failed to create group in old system", err)
 	}
 
 	// --- Mapping from old system's API response to new system's ChannelResponse ---
 	// Note: The old system's response is very limited compared to ChannelResponse.
 	// Many fields in ChannelResponse will be empty or default values.
 	response := &dtos.ChannelResponse{
-		ChannelID:   oldAPIResp.ID, // Populate ChannelID from old system's ID
-		ChannelName: request.ChannelName,
-		Description: request.Description,
-		Enabled:     request.Enabled,
-		ChannelType: request.ChannelType,
-		Config:      request.Config,     // Use the original config from request
-		Recipients:  request.Recipients, // Use the original recipients from request
-		Tags:        request.Tags,
-		CreatedAt:   time.Now().Unix(), // Set current time as creation time
-		UpdatedAt:   time.Now().Unix(), // Set current time as update time
+		ChannelID:         oldAPIResp.ID, // Populate ChannelID from old system's ID
+		ChannelName:       request.ChannelName,
+		Description:       request.Description,
+		Enabled:           request.Enabled,
+		ChannelType:       request.ChannelType,
+		Config:            request.Config,     // Use the original config from request
+		Recipients:        request.Recipients, // Use the original recipients from request
+		Tags:              request.Tags,
+		VariableDefaults:  request.VariableDefaults,
+		Owner:             request.Owner,
+		Team:              request.Team,
+		Environment:       request.Environment,
+		FallbackChannelID: request.FallbackChannelID,
+		CreatedAt:         time.Now().Unix(), // Set current time as creation time
+		UpdatedAt:         time.Now().Unix(), // Set current time as update time
+		Version:           1,
 		// LastUsed will be nil as old system doesn't provide it
 	}
 
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
+
+	"go.uber.org/zap"
 
 	"notification/internal/application/channel/dtos"
 	"notification/internal/domain/channel"
@@ -15,14 +19,21 @@ import (
 	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 	"notification/pkg/config"
+	"notification/pkg/logger"
 )
 
+// legacyCompensationTimeout bounds the calls that undo or reconcile a legacy
+// group creation. They run detached from the request context so that they
+// still happen when the request itself timed out or was cancelled.
+const legacyCompensationTimeout = 10 * time.Second
+
 // CreateChannelUseCase is the use case for creating a channel.
 type CreateChannelUseCase struct {
 	channelRepo  channel.ChannelRepository
 	templateRepo template.TemplateRepository
 	validator    *services.ChannelValidator
 	config       *config.Config
+	changes      shared.ChangeNotifier
 }
 
 // NewCreateChannelUseCase creates a use case instance.
@@ -40,17 +51,22 @@ func NewCreateChannelUseCase(
 	}
 }
 
+// SetChangeNotifier makes the use case report the channels it creates.
+func (uc *CreateChannelUseCase) SetChangeNotifier(notifier shared.ChangeNotifier) {
+	uc.changes = notifier
+}
+
 // Execute executes the create channel operation.
 func (uc *CreateChannelUseCase) Execute(ctx context.Context, request *dtos.CreateChannelRequest) (*dtos.ChannelResponse, error) {
 	// 1. Validate input parameters
 	if err := uc.validateRequest(request); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid request: %w", err))
 	}
 
 	// 2. Convert to domain objects
 	domainObjects, err := uc.convertToDomainObjects(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert to domain objects: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to convert to domain objects: %w", err))
 	}
 
 	// 3. Business validation
@@ -60,19 +76,23 @@ func (uc *CreateChannelUseCase) Execute(ctx context.Context, request *dtos.Creat
 		domainObjects.ChannelType,
 		domainObjects.TemplateID,
 		domainObjects.Config,
+		domainObjects.VariableDefaults,
 	); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if err := uc.validator.ValidateFallbackChannel(ctx, nil, domainObjects.FallbackChannelID); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
 
 	// 4. Forward to legacy system to get the channel ID
 	groupID, err := uc.forwardToLegacySystem(ctx, domainObjects, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to forward to legacy system: %w", err)
+		return nil, shared.WrapLegacyError("failed to forward to legacy system", err)
 	}
 
 	channelID, err := channel.NewChannelIDFromString(groupID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create channel ID from group ID: %w", err)
+		return nil, uc.compensateLegacyCreate(ctx, groupID, fmt.Errorf("failed to create channel ID from group ID: %w", err))
 	}
 
 	// 5. Create a channel entity with the ID from the legacy system
@@ -89,16 +109,23 @@ func (uc *CreateChannelUseCase) Execute(ctx context.Context, request *dtos.Creat
 		domainObjects.Tags,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create channel: %w", err)
+		return nil, uc.compensateLegacyCreate(ctx, groupID, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to create channel: %w", err)))
+	}
+	ch.SetVariableDefaults(domainObjects.VariableDefaults)
+	ch.SetOwnership(domainObjects.Ownership)
+	ch.SetEnvironment(domainObjects.Environment)
+	if err := ch.SetFallbackChannel(domainObjects.FallbackChannelID); err != nil {
+		return nil, uc.compensateLegacyCreate(ctx, groupID, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to create channel: %w", err)))
 	}
 
-	// 6. Persist
-	if err := uc.channelRepo.Save(ctx, ch); err != nil {
-		return nil, fmt.Errorf("failed to save channel: %w", err)
+	// 6. Persist, undoing the legacy group creation if the channel cannot be stored
+	if err := uc.persistCreatedChannel(ctx, ch, groupID); err != nil {
+		return nil, err
 	}
 
 	// 7. Convert to response DTO
 	response := uc.convertToResponse(ch)
+	notifyChannelChange(ctx, uc.changes, shared.ChangeChannelCreated, response.ChannelID, nil, response)
 	return response, nil
 }
 
@@ -116,19 +143,29 @@ func (uc *CreateChannelUseCase) validateRequest(request *dtos.CreateChannelReque
 		return fmt.Errorf("channel type is required")
 	}
 
+	if uc.config != nil && uc.config.Ownership.Required &&
+		strings.TrimSpace(request.Owner) == "" && strings.TrimSpace(request.Team) == "" {
+		return fmt.Errorf("an owner or a team is required")
+	}
+
 	return nil
 }
 
 // DomainObjects are the converted domain objects.
 type DomainObjects struct {
-	Name           *channel.ChannelName
-	Description    *channel.Description
-	ChannelType    shared.ChannelType
-	TemplateID     *template.TemplateID
-	CommonSettings *shared.CommonSettings
-	Config         *channel.ChannelConfig
-	Recipients     *channel.Recipients
-	Tags           *channel.Tags
+	Name             *channel.ChannelName
+	Description      *channel.Description
+	ChannelType      shared.ChannelType
+	TemplateID       *template.TemplateID
+	CommonSettings   *shared.CommonSettings
+	Config           *channel.ChannelConfig
+	Recipients       *channel.Recipients
+	Tags             *channel.Tags
+	VariableDefaults *channel.VariableDefaults
+	Ownership        *shared.Ownership
+	Environment      shared.Environment
+	// FallbackChannelID is nil when the channel has no fallback
+	FallbackChannelID *channel.ChannelID
 }
 
 // LegacyChannelRequest defines the request payload for the legacy system.
@@ -210,15 +247,43 @@ func (uc *CreateChannelUseCase) convertToDomainObjects(request *dtos.CreateChann
 	// Tags
 	tags := channel.NewTags(request.Tags)
 
+	// Template variable defaults
+	variableDefaults := channel.NewVariableDefaults(request.VariableDefaults)
+
+	// Owner and team
+	ownership, err := shared.NewOwnership(request.Owner, request.Team)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ownership: %w", err)
+	}
+
+	// Deployment environment
+	environment, err := shared.NewEnvironment(request.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fallback channel
+	var fallbackChannelID *channel.ChannelID
+	if request.FallbackChannelID != "" {
+		fallbackChannelID, err = channel.NewChannelIDFromString(request.FallbackChannelID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback channel ID: %w", err)
+		}
+	}
+
 	return &DomainObjects{
-		Name:           name,
-		Description:    description,
-		ChannelType:    channelType,
-		TemplateID:     templateID,
-		CommonSettings: commonSettings,
-		Config:         config,
-		Recipients:     recipients,
-		Tags:           tags,
+		Name:              name,
+		Description:       description,
+		ChannelType:       channelType,
+		TemplateID:        templateID,
+		CommonSettings:    commonSettings,
+		Config:            config,
+		Recipients:        recipients,
+		Tags:              tags,
+		VariableDefaults:  variableDefaults,
+		Ownership:         ownership,
+		Environment:       environment,
+		FallbackChannelID: fallbackChannelID,
 	}, nil
 }
 
@@ -230,22 +295,119 @@ func (uc *CreateChannelUseCase) convertToResponse(ch *channel.Channel) *dtos.Cha
 	}
 
 	return &dtos.ChannelResponse{
-		ChannelID:      ch.ID().String(),
-		ChannelName:    ch.Name().String(),
-		Description:    ch.Description().String(),
-		Enabled:        ch.IsEnabled(),
-		ChannelType:    ch.ChannelType().String(),
-		TemplateID:     templateID,
-		CommonSettings: dtos.FromCommonSettings(ch.CommonSettings()),
-		Config:         ch.Config().ToMap(),
-		Recipients:     dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
-		Tags:           ch.Tags().ToSlice(),
-		CreatedAt:      ch.Timestamps().CreatedAt,
-		UpdatedAt:      ch.Timestamps().UpdatedAt,
-		LastUsed:       ch.LastUsed(),
+		ChannelID:         ch.ID().String(),
+		ChannelName:       ch.Name().String(),
+		Description:       ch.Description().String(),
+		Enabled:           ch.IsEnabled(),
+		Maintenance:       ch.InMaintenance(),
+		ChannelType:       ch.ChannelType().String(),
+		TemplateID:        templateID,
+		CommonSettings:    dtos.FromCommonSettings(ch.CommonSettings()),
+		Config:            ch.Config().ToMap(),
+		Recipients:        dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
+		Tags:              ch.Tags().ToSlice(),
+		VariableDefaults:  ch.VariableDefaults().ToMap(),
+		Owner:             ch.Ownership().Owner,
+		Team:              ch.Ownership().Team,
+		Environment:       ch.Environment().String(),
+		FallbackChannelID: dtos.FromChannelID(ch.FallbackChannelID()),
+		Health:            dtos.FromChannelHealth(ch.Health()),
+		CreatedAt:         ch.Timestamps().CreatedAt,
+		UpdatedAt:         ch.Timestamps().UpdatedAt,
+		LastUsed:          ch.LastUsed(),
+		Version:           ch.Version(),
 	}
 }
 
+// persistCreatedChannel saves a channel whose group already exists in the legacy
+// system. When the save fails the legacy group is deleted again. When the save
+// was cut short by the request deadline the row may still have been committed,
+// so the local store is checked before deciding to compensate.
+func (uc *CreateChannelUseCase) persistCreatedChannel(ctx context.Context, ch *channel.Channel, groupID string) error {
+	saveErr := uc.channelRepo.Save(ctx, ch)
+	if saveErr == nil {
+		return nil
+	}
+	cause := fmt.Errorf("failed to save channel: %w", saveErr)
+
+	if ctx.Err() != nil || errors.Is(saveErr, context.DeadlineExceeded) || errors.Is(saveErr, context.Canceled) {
+		saved, err := uc.channelSaved(ctx, ch.ID())
+		if err != nil {
+			// The outcome is unknown; deleting the legacy group could orphan the local row instead.
+			logger.FromContext(ctx).Warn("Could not confirm whether the channel was saved, legacy group kept",
+				zap.String("channel_id", ch.ID().String()),
+				zap.String("group_id", groupID),
+				zap.Error(err))
+			return cause
+		}
+		if saved {
+			return nil
+		}
+	}
+
+	return uc.compensateLegacyCreate(ctx, groupID, cause)
+}
+
+// channelSaved reports whether the channel was persisted, using a context detached from the request.
+func (uc *CreateChannelUseCase) channelSaved(ctx context.Context, id *channel.ChannelID) (bool, error) {
+	existsCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), legacyCompensationTimeout)
+	defer cancel()
+
+	return uc.channelRepo.Exists(existsCtx, id)
+}
+
+// compensateLegacyCreate deletes the legacy group created for a channel that
+// could not be stored locally and returns the original error. A failed rollback
+// is logged and reported alongside it so the orphaned group can be cleaned up.
+func (uc *CreateChannelUseCase) compensateLegacyCreate(ctx context.Context, groupID string, cause error) error {
+	deleteCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), legacyCompensationTimeout)
+	defer cancel()
+
+	if err := uc.deleteLegacyGroup(deleteCtx, groupID); err != nil {
+		logger.FromContext(ctx).Warn("Failed to roll back the legacy group after channel creation failed",
+			zap.String("group_id", groupID),
+			zap.Error(err))
+		return fmt.Errorf("%w (rollback of legacy group %s failed: %v)", cause, groupID, err)
+	}
+
+	return cause
+}
+
+// deleteLegacyGroup deletes a group in the legacy system.
+func (uc *CreateChannelUseCase) deleteLegacyGroup(ctx context.Context, groupID string) error {
+	legacyURL := uc.config.LegacySystem.URL + "/Groups"
+	bearerToken := uc.config.LegacySystem.Token
+
+	// 1. Marshal the request body (array of group IDs)
+	jsonBody, err := json.Marshal([]string{groupID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal legacy request body: %w", err)
+	}
+
+	// 2. Create and send the HTTP DELETE request
+	req, err := http.NewRequestWithContext(ctx, "DELETE", legacyURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create legacy http request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+bearerToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to legacy system: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 3. Check response status
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return shared.NewLegacyStatusError(resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 func (uc *CreateChannelUseCase) forwardToLegacySystem(ctx context.Context, domainObjects *DomainObjects, request *dtos.CreateChannelRequest) (string, error) {
 	legacyURL := uc.config.LegacySystem.URL + "/Groups"
 	bearerToken := uc.config.LegacySystem.Token
@@ -353,7 +515,7 @@ func (uc *CreateChannelUseCase) forwardToLegacySystem(ctx context.Context, domai
 	// 6. Check response status
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("legacy system returned error status %d: %s", resp.StatusCode, string(body))
+		return "", shared.NewLegacyStatusError(resp.StatusCode, string(body))
 	}
 
 	// 7. Parse the response
@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+	"notification/pkg/config"
+)
+
+// savingChannelRepository fails to save channels and answers whether they exist
+type savingChannelRepository struct {
+	channel.ChannelRepository
+	saveErr   error
+	exists    bool
+	existsErr error
+}
+
+func (r *savingChannelRepository) Save(ctx context.Context, ch *channel.Channel) error {
+	return r.saveErr
+}
+
+func (r *savingChannelRepository) Exists(ctx context.Context, id *channel.ChannelID) (bool, error) {
+	return r.exists, r.existsErr
+}
+
+// legacyGroups is a legacy system recording the groups it is asked to delete
+type legacyGroups struct {
+	mu      sync.Mutex
+	deleted []string
+	status  int
+}
+
+func (l *legacyGroups) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete || r.URL.Path != "/Groups" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var ids []string
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	l.mu.Lock()
+	l.deleted = append(l.deleted, ids...)
+	l.mu.Unlock()
+	w.WriteHeader(l.status)
+}
+
+func newCreatedChannel(t *testing.T) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+	name, err := channel.NewChannelName("alerts")
+	require.NoError(t, err)
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeEmail, nil,
+		&shared.CommonSettings{Timeout: 10}, channel.NewChannelConfig(map[string]interface{}{}), nil, nil)
+	require.NoError(t, err)
+	return ch
+}
+
+func TestCreateChannelUseCase_CompensatesTheLegacyGroup(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		repo        *savingChannelRepository
+		legacy      int
+		wantErr     string
+		wantDeleted []string
+	}{
+		{
+			name:        "save fails",
+			ctx:         context.Background(),
+			repo:        &savingChannelRepository{saveErr: errors.New("connection refused")},
+			legacy:      http.StatusOK,
+			wantErr:     "failed to save channel: connection refused",
+			wantDeleted: []string{"grp-1"},
+		},
+		{
+			name:   "deadline hit with the row saved",
+			ctx:    cancelled,
+			repo:   &savingChannelRepository{saveErr: context.Canceled, exists: true},
+			legacy: http.StatusOK,
+		},
+		{
+			name:        "deadline hit with the row missing",
+			ctx:         cancelled,
+			repo:        &savingChannelRepository{saveErr: context.Canceled},
+			legacy:      http.StatusOK,
+			wantErr:     "failed to save channel: context canceled",
+			wantDeleted: []string{"grp-1"},
+		},
+		{
+			name:    "deadline hit with the outcome unknown",
+			ctx:     context.Background(),
+			repo:    &savingChannelRepository{saveErr: context.DeadlineExceeded, existsErr: errors.New("connection refused")},
+			legacy:  http.StatusOK,
+			wantErr: "failed to save channel: context deadline exceeded",
+		},
+		{
+			name:        "rollback fails",
+			ctx:         context.Background(),
+			repo:        &savingChannelRepository{saveErr: errors.New("connection refused")},
+			legacy:      http.StatusInternalServerError,
+			wantErr:     "failed to save channel: connection refused (rollback of legacy group grp-1 failed: legacy system returned error status 500: )",
+			wantDeleted: []string{"grp-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy := &legacyGroups{status: tt.legacy}
+			server := httptest.NewServer(legacy)
+			defer server.Close()
+
+			useCase := NewCreateChannelUseCase(tt.repo, nil, nil, &config.Config{
+				LegacySystem: config.LegacySystemConfig{URL: server.URL},
+			})
+			err := useCase.persistCreatedChannel(tt.ctx, newCreatedChannel(t), "grp-1")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantDeleted, legacy.deleted)
+		})
+	}
+}
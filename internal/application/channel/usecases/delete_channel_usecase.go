@@ -11,6 +11,7 @@ import (
 	"notification/internal/application/channel/dtos"
 	"notification/internal/domain/channel"
 	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
 	"notification/pkg/config"
 )
 
@@ -19,6 +20,7 @@ type DeleteChannelUseCase struct {
 	channelRepo channel.ChannelRepository
 	validator   *services.ChannelValidator
 	config      *config.Config
+	changes     shared.ChangeNotifier
 }
 
 // NewDeleteChannelUseCase creates a use case instance.
@@ -34,17 +36,22 @@ func NewDeleteChannelUseCase(
 	}
 }
 
+// SetChangeNotifier makes the use case report the channels it deletes.
+func (uc *DeleteChannelUseCase) SetChangeNotifier(notifier shared.ChangeNotifier) {
+	uc.changes = notifier
+}
+
 // Execute executes the delete channel operation.
 func (uc *DeleteChannelUseCase) Execute(ctx context.Context, channelID string) (*dtos.DeleteChannelResponse, error) {
 	// 1. Validate input parameters
 	if channelID == "" {
-		return nil, fmt.Errorf("channel ID is required")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel ID is required"))
 	}
 
 	// 2. Convert to domain object
 	id, err := channel.NewChannelIDFromString(channelID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid channel ID: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
 	}
 
 	// 3. Business validation
@@ -60,10 +67,11 @@ func (uc *DeleteChannelUseCase) Execute(ctx context.Context, channelID string) (
 
 	// 5. Forward to legacy system
 	if err := uc.forwardDeleteToLegacySystem(ctx, ch.ID().String()); err != nil {
-		return nil, fmt.Errorf("failed to forward delete to legacy system: %w", err)
+		return nil, shared.WrapLegacyError("failed to forward delete to legacy system", err)
 	}
 
 	// 6. Perform soft deletion
+	previous := channelSnapshot(ch)
 	if err := ch.Delete(); err != nil {
 		return nil, fmt.Errorf("failed to delete channel: %w", err)
 	}
@@ -78,7 +86,9 @@ func (uc *DeleteChannelUseCase) Execute(ctx context.Context, channelID string) (
 		ChannelID: ch.ID().String(),
 		Deleted:   true,
 		DeletedAt: *ch.Timestamps().DeletedAt,
+		Version:   ch.Version(),
 	}
+	notifyChannelChange(ctx, uc.changes, shared.ChangeChannelDeleted, response.ChannelID, previous, nil)
 
 	return response, nil
 }
@@ -115,7 +125,7 @@ func (uc *DeleteChannelUseCase) forwardDeleteToLegacySystem(ctx context.Context,
 	// 4. Check response status
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("legacy system returned error status %d: %s", resp.StatusCode, string(body))
+		return shared.NewLegacyStatusError(resp.StatusCode, string(body))
 	}
 
 	return nil
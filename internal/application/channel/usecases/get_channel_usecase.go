@@ -6,6 +6,7 @@ import (
 
 	"notification/internal/application/channel/dtos"
 	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
 )
 
 // GetChannelUseCase is the use case for getting a single channel.
@@ -22,15 +23,17 @@ func NewGetChannelUseCase(channelRepo channel.ChannelRepository) *GetChannelUseC
 
 // Execute executes the get channel operation.
 func (uc *GetChannelUseCase) Execute(ctx context.Context, channelID string) (*dtos.ChannelResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
 	// 1. Validate input parameters
 	if channelID == "" {
-		return nil, fmt.Errorf("channel ID is required")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel ID is required"))
 	}
 
 	// 2. Convert to domain object
 	id, err := channel.NewChannelIDFromString(channelID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid channel ID: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
 	}
 
 	// 3. Query the channel
@@ -41,7 +44,7 @@ func (uc *GetChannelUseCase) Execute(ctx context.Context, channelID string) (*dt
 
 	// 4. Check if the channel is deleted
 	if ch.IsDeleted() {
-		return nil, fmt.Errorf("channel has been deleted")
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel has been deleted")
 	}
 
 	// 5. Convert to response DTO
@@ -49,6 +52,36 @@ func (uc *GetChannelUseCase) Execute(ctx context.Context, channelID string) (*dt
 	return response, nil
 }
 
+// ExecuteByName looks up a channel by its unique name.
+func (uc *GetChannelUseCase) ExecuteByName(ctx context.Context, channelName string) (*dtos.ChannelResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	// 1. Validate input parameters
+	if channelName == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel name is required"))
+	}
+
+	// 2. Convert to domain object
+	name, err := channel.NewChannelName(channelName)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel name: %w", err))
+	}
+
+	// 3. Query the channel
+	ch, err := uc.channelRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("channel not found: %w", err)
+	}
+
+	// 4. Check if the channel is deleted
+	if ch.IsDeleted() {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel has been deleted")
+	}
+
+	// 5. Convert to response DTO
+	return uc.convertToResponse(ch), nil
+}
+
 // convertToResponse converts to a response DTO.
 func (uc *GetChannelUseCase) convertToResponse(ch *channel.Channel) *dtos.ChannelResponse {
 	var templateID string
@@ -57,18 +90,26 @@ func (uc *GetChannelUseCase) convertToResponse(ch *channel.Channel) *dtos.Channe
 	}
 
 	return &dtos.ChannelResponse{
-		ChannelID:      ch.ID().String(),
-		ChannelName:    ch.Name().String(),
-		Description:    ch.Description().String(),
-		Enabled:        ch.IsEnabled(),
-		ChannelType:    ch.ChannelType().String(),
-		TemplateID:     templateID,
-		CommonSettings: dtos.FromCommonSettings(ch.CommonSettings()),
-		Config:         ch.Config().ToMap(),
-		Recipients:     dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
-		Tags:           ch.Tags().ToSlice(),
-		CreatedAt:      ch.Timestamps().CreatedAt,
-		UpdatedAt:      ch.Timestamps().UpdatedAt,
-		LastUsed:       ch.LastUsed(),
+		ChannelID:         ch.ID().String(),
+		ChannelName:       ch.Name().String(),
+		Description:       ch.Description().String(),
+		Enabled:           ch.IsEnabled(),
+		Maintenance:       ch.InMaintenance(),
+		ChannelType:       ch.ChannelType().String(),
+		TemplateID:        templateID,
+		CommonSettings:    dtos.FromCommonSettings(ch.CommonSettings()),
+		Config:            ch.Config().ToMap(),
+		Recipients:        dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
+		Tags:              ch.Tags().ToSlice(),
+		VariableDefaults:  ch.VariableDefaults().ToMap(),
+		Owner:             ch.Ownership().Owner,
+		Team:              ch.Ownership().Team,
+		Environment:       ch.Environment().String(),
+		FallbackChannelID: dtos.FromChannelID(ch.FallbackChannelID()),
+		Health:            dtos.FromChannelHealth(ch.Health()),
+		CreatedAt:         ch.Timestamps().CreatedAt,
+		UpdatedAt:         ch.Timestamps().UpdatedAt,
+		LastUsed:          ch.LastUsed(),
+		Version:           ch.Version(),
 	}
 }
\ No newline at end of file
@@ -3,15 +3,20 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"notification/internal/application/channel/dtos"
 	"notification/internal/domain/channel"
 	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
 )
 
 // ListChannelsUseCase is the use case for listing channels.
 type ListChannelsUseCase struct {
 	channelRepo channel.ChannelRepository
+	// defaultEnvironment filters the requests naming no environment
+	defaultEnvironment shared.Environment
 }
 
 // NewListChannelsUseCase creates a use case instance.
@@ -21,16 +26,27 @@ func NewListChannelsUseCase(channelRepo channel.ChannelRepository) *ListChannels
 	}
 }
 
+// SetDefaultEnvironment lists the channels of the environment, and the unscoped
+// ones, when a request names no environment.
+func (uc *ListChannelsUseCase) SetDefaultEnvironment(environment shared.Environment) {
+	uc.defaultEnvironment = environment
+}
+
 // Execute executes the channel list query.
 func (uc *ListChannelsUseCase) Execute(ctx context.Context, request *dtos.ListChannelsRequest) (*dtos.ListChannelsResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
 	// 1. Create pagination parameters
 	pagination, err := uc.createPagination(request)
 	if err != nil {
-		return nil, fmt.Errorf("invalid pagination: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid pagination: %w", err))
 	}
 
 	// 2. Create filter conditions
-	filter := uc.createFilter(request)
+	filter, err := uc.createFilter(request)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
 
 	// 3. Query data
 	result, err := uc.channelRepo.FindAll(ctx, filter, pagination)
@@ -63,7 +79,7 @@ func (uc *ListChannelsUseCase) createPagination(request *dtos.ListChannelsReques
 }
 
 // createFilter creates filter conditions.
-func (uc *ListChannelsUseCase) createFilter(request *dtos.ListChannelsRequest) *channel.ChannelFilter {
+func (uc *ListChannelsUseCase) createFilter(request *dtos.ListChannelsRequest) (*channel.ChannelFilter, error) {
 	filter := channel.NewChannelFilter()
 
 	// Channel type filter
@@ -79,7 +95,68 @@ func (uc *ListChannelsUseCase) createFilter(request *dtos.ListChannelsRequest) *
 		filter.WithTags(request.Tags)
 	}
 
-	return filter
+	// Template filter
+	if request.TemplateID != "" {
+		templateID, err := template.NewTemplateIDFromString(request.TemplateID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template ID: %w", err)
+		}
+		filter.WithTemplateID(templateID)
+	}
+
+	// Ownership filter
+	if owner := strings.TrimSpace(request.Owner); owner != "" {
+		filter.WithOwner(owner)
+	}
+	if team := strings.TrimSpace(request.Team); team != "" {
+		filter.WithTeam(team)
+	}
+
+	// Environment filter, the default environment unless the request names one or all
+	environment, err := shared.NewEnvironmentFilter(request.Environment, uc.defaultEnvironment)
+	if err != nil {
+		return nil, err
+	}
+	if environment != "" {
+		filter.WithEnvironment(environment)
+	}
+
+	// Last-used range filter; unusedForDays is shorthand for an upper bound relative to now
+	if request.UnusedForDays < 0 {
+		return nil, fmt.Errorf("unusedForDays cannot be negative")
+	}
+	if request.UnusedForDays > 0 && request.LastUsedBefore == nil {
+		before := time.Now().AddDate(0, 0, -request.UnusedForDays).UnixMilli()
+		request.LastUsedBefore = &before
+	}
+	if request.LastUsedBefore != nil {
+		filter.WithLastUsedBefore(*request.LastUsedBefore)
+	}
+	if request.LastUsedAfter != nil {
+		filter.WithLastUsedAfter(*request.LastUsedAfter)
+	}
+	if request.LastUsedBefore != nil && request.LastUsedAfter != nil && *request.LastUsedAfter > *request.LastUsedBefore {
+		return nil, fmt.Errorf("lastUsedAfter must not be later than lastUsedBefore")
+	}
+
+	// Sorting
+	if request.SortField != "" {
+		if !channel.IsSortableField(request.SortField) {
+			return nil, fmt.Errorf("cannot sort by field: %s", request.SortField)
+		}
+
+		sortOrder := strings.ToLower(request.SortOrder)
+		if sortOrder == "" {
+			sortOrder = channel.SortOrderAsc
+		}
+		if sortOrder != channel.SortOrderAsc && sortOrder != channel.SortOrderDesc {
+			return nil, fmt.Errorf("sort order must be 'asc' or 'desc'")
+		}
+
+		filter.WithSorting(request.SortField, sortOrder)
+	}
+
+	return filter, nil
 }
 
 // convertToResponse converts to a response DTO.
@@ -87,14 +164,26 @@ func (uc *ListChannelsUseCase) convertToResponse(result *shared.PaginatedResult[
 	items := make([]dtos.ChannelSummaryResponse, 0, len(result.Items))
 
 	for _, ch := range result.Items {
+		var templateID string
+		if ch.TemplateID() != nil {
+			templateID = ch.TemplateID().String()
+		}
+
 		items = append(items, dtos.ChannelSummaryResponse{
 			ChannelID:   ch.ID().String(),
 			ChannelName: ch.Name().String(),
 			ChannelType: ch.ChannelType().String(),
+			TemplateID:  templateID,
 			Tags:        ch.Tags().ToSlice(),
 			Enabled:     ch.IsEnabled(),
+			Maintenance: ch.InMaintenance(),
+			Owner:       ch.Ownership().Owner,
+			Team:        ch.Ownership().Team,
+			Environment: ch.Environment().String(),
+			Health:      dtos.FromChannelHealth(ch.Health()),
 			CreatedAt:   ch.Timestamps().CreatedAt,
 			UpdatedAt:   ch.Timestamps().UpdatedAt,
+			LastUsed:    ch.LastUsed(),
 		})
 	}
 
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/channel/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// SetChannelEnabledUseCase is the use case for enabling or disabling a channel.
+// Only the enabled flag is changed, so the full update validation and the
+// legacy config push are not involved.
+type SetChannelEnabledUseCase struct {
+	channelRepo channel.ChannelRepository
+	changes     shared.ChangeNotifier
+}
+
+// NewSetChannelEnabledUseCase creates a use case instance.
+func NewSetChannelEnabledUseCase(channelRepo channel.ChannelRepository) *SetChannelEnabledUseCase {
+	return &SetChannelEnabledUseCase{
+		channelRepo: channelRepo,
+	}
+}
+
+// SetChangeNotifier makes the use case report the channels it enables or disables.
+func (uc *SetChannelEnabledUseCase) SetChangeNotifier(notifier shared.ChangeNotifier) {
+	uc.changes = notifier
+}
+
+// Enable enables the channel.
+func (uc *SetChannelEnabledUseCase) Enable(ctx context.Context, channelID string) (*dtos.ChannelResponse, error) {
+	return uc.Execute(ctx, channelID, true)
+}
+
+// Disable disables the channel.
+func (uc *SetChannelEnabledUseCase) Disable(ctx context.Context, channelID string) (*dtos.ChannelResponse, error) {
+	return uc.Execute(ctx, channelID, false)
+}
+
+// Execute sets the enabled flag of the channel.
+func (uc *SetChannelEnabledUseCase) Execute(ctx context.Context, channelID string, enabled bool) (*dtos.ChannelResponse, error) {
+	// 1. Validate input parameters
+	if channelID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel ID is required"))
+	}
+
+	// 2. Convert to domain object
+	id, err := channel.NewChannelIDFromString(channelID)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
+	}
+
+	// 3. Query the channel
+	ch, err := uc.channelRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("channel not found: %w", err)
+	}
+
+	// 4. Check if the channel is deleted
+	if ch.IsDeleted() {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel has been deleted")
+	}
+
+	// 5. Nothing to do if the channel is already in the requested state
+	if ch.IsEnabled() == enabled {
+		return uc.convertToResponse(ch), nil
+	}
+
+	// 6. Flip the flag, re-enabled channels start over with a healthy window
+	previous := channelSnapshot(ch)
+	if enabled {
+		ch.Enable()
+		ch.ResetHealth()
+	} else {
+		ch.Disable()
+	}
+
+	// 7. Persist only the enabled flag and health
+	if err := uc.channelRepo.UpdateEnabled(ctx, ch); err != nil {
+		return nil, fmt.Errorf("failed to save channel: %w", err)
+	}
+
+	// 8. Convert to response DTO
+	response := uc.convertToResponse(ch)
+	changeType := shared.ChangeChannelDisabled
+	if enabled {
+		changeType = shared.ChangeChannelEnabled
+	}
+	notifyChannelChange(ctx, uc.changes, changeType, response.ChannelID, previous, response)
+	return response, nil
+}
+
+// convertToResponse converts to a response DTO.
+func (uc *SetChannelEnabledUseCase) convertToResponse(ch *channel.Channel) *dtos.ChannelResponse {
+	var templateID string
+	if ch.TemplateID() != nil {
+		templateID = ch.TemplateID().String()
+	}
+
+	return &dtos.ChannelResponse{
+		ChannelID:         ch.ID().String(),
+		ChannelName:       ch.Name().String(),
+		Description:       ch.Description().String(),
+		Enabled:           ch.IsEnabled(),
+		Maintenance:       ch.InMaintenance(),
+		ChannelType:       ch.ChannelType().String(),
+		TemplateID:        templateID,
+		CommonSettings:    dtos.FromCommonSettings(ch.CommonSettings()),
+		Config:            ch.Config().ToMap(),
+		Recipients:        dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
+		Tags:              ch.Tags().ToSlice(),
+		VariableDefaults:  ch.VariableDefaults().ToMap(),
+		Owner:             ch.Ownership().Owner,
+		Team:              ch.Ownership().Team,
+		Environment:       ch.Environment().String(),
+		FallbackChannelID: dtos.FromChannelID(ch.FallbackChannelID()),
+		Health:            dtos.FromChannelHealth(ch.Health()),
+		CreatedAt:         ch.Timestamps().CreatedAt,
+		UpdatedAt:         ch.Timestamps().UpdatedAt,
+		LastUsed:          ch.LastUsed(),
+		Version:           ch.Version(),
+	}
+}
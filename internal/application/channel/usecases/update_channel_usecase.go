@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"notification/internal/application/channel/dtos"
@@ -23,6 +24,7 @@ type UpdateChannelUseCase struct {
 	templateRepo template.TemplateRepository
 	validator    *services.ChannelValidator
 	config       *config.Config
+	changes      shared.ChangeNotifier
 }
 
 // NewUpdateChannelUseCase creates a use case instance.
@@ -40,22 +42,34 @@ func NewUpdateChannelUseCase(
 	}
 }
 
+// SetChangeNotifier makes the use case report the channels it changes.
+func (uc *UpdateChannelUseCase) SetChangeNotifier(notifier shared.ChangeNotifier) {
+	uc.changes = notifier
+}
+
 // Execute executes the channel update.
 func (uc *UpdateChannelUseCase) Execute(ctx context.Context, channelID string, request *dtos.UpdateChannelRequest) (*dtos.ChannelResponse, error) {
+	_, response, err := uc.ExecuteWithPrevious(ctx, channelID, request)
+	return response, err
+}
+
+// ExecuteWithPrevious executes the channel update and also returns the
+// channel as it was before, so callers can tell what changed.
+func (uc *UpdateChannelUseCase) ExecuteWithPrevious(ctx context.Context, channelID string, request *dtos.UpdateChannelRequest) (previous, response *dtos.ChannelResponse, err error) {
 	// 1. Validate input parameters
 	if err := uc.validateRequest(channelID, request); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid request: %w", err))
 	}
 
 	// 2. Convert to domain objects
 	id, err := channel.NewChannelIDFromString(channelID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid channel ID: %w", err)
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
 	}
 
 	domainObjects, err := uc.convertToDomainObjects(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert to domain objects: %w", err)
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to convert to domain objects: %w", err))
 	}
 
 	// 3. Business validation
@@ -66,27 +80,37 @@ func (uc *UpdateChannelUseCase) Execute(ctx context.Context, channelID string, r
 		domainObjects.ChannelType,
 		domainObjects.TemplateID,
 		domainObjects.Config,
+		domainObjects.VariableDefaults,
 	); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := uc.validator.ValidateFallbackChannel(ctx, id, domainObjects.FallbackChannelID); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
 	}
 
 	// 4. Query existing channel
 	ch, err := uc.channelRepo.FindByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("channel not found: %w", err)
+		return nil, nil, fmt.Errorf("channel not found: %w", err)
 	}
 
 	// 5. Check if the channel is deleted
 	if ch.IsDeleted() {
-		return nil, fmt.Errorf("cannot update deleted channel")
+		return nil, nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "cannot update deleted channel")
+	}
+	previous = uc.convertToResponse(ch)
+
+	// 6. Skip the update entirely when nothing changed, so repeated PUTs are idempotent
+	if uc.isUnchanged(ch, domainObjects, request.Enabled) {
+		return previous, previous, nil
 	}
 
-	// 6. Forward to legacy system
+	// 7. Forward to legacy system
 	if err := uc.forwardUpdateToLegacySystem(ctx, ch.ID().String(), domainObjects, request); err != nil {
-		return nil, fmt.Errorf("failed to forward update to legacy system: %w", err)
+		return nil, nil, shared.WrapLegacyError("failed to forward update to legacy system", err)
 	}
 
-	// 7. Update the channel
+	// 8. Update the channel
 	if err := ch.Update(
 		domainObjects.Name,
 		domainObjects.Description,
@@ -98,17 +122,24 @@ func (uc *UpdateChannelUseCase) Execute(ctx context.Context, channelID string, r
 		domainObjects.Recipients,
 		domainObjects.Tags,
 	); err != nil {
-		return nil, fmt.Errorf("failed to update channel: %w", err)
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to update channel: %w", err))
+	}
+	ch.SetVariableDefaults(domainObjects.VariableDefaults)
+	ch.SetOwnership(domainObjects.Ownership)
+	ch.SetEnvironment(domainObjects.Environment)
+	if err := ch.SetFallbackChannel(domainObjects.FallbackChannelID); err != nil {
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to update channel: %w", err))
 	}
 
-	// 8. Persist
+	// 9. Persist
 	if err := uc.channelRepo.Update(ctx, ch); err != nil {
-		return nil, fmt.Errorf("failed to save channel: %w", err)
+		return nil, nil, fmt.Errorf("failed to save channel: %w", err)
 	}
 
-	// 9. Convert to response DTO
-	response := uc.convertToResponse(ch)
-	return response, nil
+	// 10. Convert to response DTO
+	response = uc.convertToResponse(ch)
+	notifyChannelChange(ctx, uc.changes, shared.ChangeChannelUpdated, response.ChannelID, previous, response)
+	return previous, response, nil
 }
 
 // validateRequest validates the request parameters.
@@ -180,18 +211,123 @@ func (uc *UpdateChannelUseCase) convertToDomainObjects(request *dtos.UpdateChann
 	// Tags
 	tags := channel.NewTags(request.Tags)
 
+	// Template variable defaults
+	variableDefaults := channel.NewVariableDefaults(request.VariableDefaults)
+
+	// Owner and team
+	ownership, err := shared.NewOwnership(request.Owner, request.Team)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ownership: %w", err)
+	}
+
+	// Deployment environment
+	environment, err := shared.NewEnvironment(request.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fallback channel
+	var fallbackChannelID *channel.ChannelID
+	if request.FallbackChannelID != "" {
+		fallbackChannelID, err = channel.NewChannelIDFromString(request.FallbackChannelID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback channel ID: %w", err)
+		}
+	}
+
 	return &DomainObjects{
-		Name:           name,
-		Description:    description,
-		ChannelType:    channelType,
-		TemplateID:     templateID,
-		CommonSettings: commonSettings,
-		Config:         config,
-		Recipients:     recipients,
-		Tags:           tags,
+		Name:              name,
+		Description:       description,
+		ChannelType:       channelType,
+		TemplateID:        templateID,
+		CommonSettings:    commonSettings,
+		Config:            config,
+		Recipients:        recipients,
+		Tags:              tags,
+		VariableDefaults:  variableDefaults,
+		Ownership:         ownership,
+		Environment:       environment,
+		FallbackChannelID: fallbackChannelID,
 	}, nil
 }
 
+// Patch applies a partial update: omitted fields keep their current values.
+func (uc *UpdateChannelUseCase) Patch(ctx context.Context, channelID string, request *dtos.PatchChannelRequest) (*dtos.ChannelResponse, error) {
+	// 1. Validate input parameters
+	if channelID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel ID is required"))
+	}
+	if request == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+
+	id, err := channel.NewChannelIDFromString(channelID)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
+	}
+
+	// 2. Query existing channel
+	ch, err := uc.channelRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("channel not found: %w", err)
+	}
+	if ch.IsDeleted() {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "cannot update deleted channel")
+	}
+
+	// 3. Merge the patch into the current state and run the regular update
+	return uc.Execute(ctx, channelID, request.MergeInto(uc.convertToResponse(ch)))
+}
+
+// isUnchanged reports whether applying the requested state would leave the channel as it is.
+func (uc *UpdateChannelUseCase) isUnchanged(ch *channel.Channel, domainObjects *DomainObjects, enabled bool) bool {
+	if ch.Name().String() != domainObjects.Name.String() ||
+		ch.Description().String() != domainObjects.Description.String() ||
+		ch.IsEnabled() != enabled ||
+		ch.ChannelType() != domainObjects.ChannelType {
+		return false
+	}
+
+	switch {
+	case ch.TemplateID() == nil && domainObjects.TemplateID != nil,
+		ch.TemplateID() != nil && !ch.TemplateID().Equals(domainObjects.TemplateID):
+		return false
+	}
+
+	if dtos.FromCommonSettings(ch.CommonSettings()) != dtos.FromCommonSettings(domainObjects.CommonSettings) {
+		return false
+	}
+
+	if !reflect.DeepEqual(ch.Config().ToMap(), domainObjects.Config.ToMap()) {
+		return false
+	}
+
+	if !reflect.DeepEqual(
+		dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
+		dtos.FromRecipientsSlice(domainObjects.Recipients.ToSlice()),
+	) {
+		return false
+	}
+
+	if !reflect.DeepEqual(ch.VariableDefaults().ToMap(), domainObjects.VariableDefaults.ToMap()) {
+		return false
+	}
+
+	if *ch.Ownership() != *domainObjects.Ownership {
+		return false
+	}
+
+	if ch.Environment() != domainObjects.Environment {
+		return false
+	}
+
+	if dtos.FromChannelID(ch.FallbackChannelID()) != dtos.FromChannelID(domainObjects.FallbackChannelID) {
+		return false
+	}
+
+	return reflect.DeepEqual(ch.Tags().ToSlice(), domainObjects.Tags.ToSlice())
+}
+
 // convertToResponse converts to a response DTO.
 func (uc *UpdateChannelUseCase) convertToResponse(ch *channel.Channel) *dtos.ChannelResponse {
 	var templateID string
@@ -200,19 +336,27 @@ func (uc *UpdateChannelUseCase) convertToResponse(ch *channel.Channel) *dtos.Cha
 	}
 
 	return &dtos.ChannelResponse{
-		ChannelID:      ch.ID().String(),
-		ChannelName:    ch.Name().String(),
-		Description:    ch.Description().String(),
-		Enabled:        ch.IsEnabled(),
-		ChannelType:    ch.ChannelType().String(),
-		TemplateID:     templateID,
-		CommonSettings: dtos.FromCommonSettings(ch.CommonSettings()),
-		Config:         ch.Config().ToMap(),
-		Recipients:     dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
-		Tags:           ch.Tags().ToSlice(),
-		CreatedAt:      ch.Timestamps().CreatedAt,
-		UpdatedAt:      ch.Timestamps().UpdatedAt,
-		LastUsed:       ch.LastUsed(),
+		ChannelID:         ch.ID().String(),
+		ChannelName:       ch.Name().String(),
+		Description:       ch.Description().String(),
+		Enabled:           ch.IsEnabled(),
+		Maintenance:       ch.InMaintenance(),
+		ChannelType:       ch.ChannelType().String(),
+		TemplateID:        templateID,
+		CommonSettings:    dtos.FromCommonSettings(ch.CommonSettings()),
+		Config:            ch.Config().ToMap(),
+		Recipients:        dtos.FromRecipientsSlice(ch.Recipients().ToSlice()),
+		Tags:              ch.Tags().ToSlice(),
+		VariableDefaults:  ch.VariableDefaults().ToMap(),
+		Owner:             ch.Ownership().Owner,
+		Team:              ch.Ownership().Team,
+		Environment:       ch.Environment().String(),
+		FallbackChannelID: dtos.FromChannelID(ch.FallbackChannelID()),
+		Health:            dtos.FromChannelHealth(ch.Health()),
+		CreatedAt:         ch.Timestamps().CreatedAt,
+		UpdatedAt:         ch.Timestamps().UpdatedAt,
+		LastUsed:          ch.LastUsed(),
+		Version:           ch.Version(),
 	}
 }
 
@@ -324,7 +468,7 @@ func (uc *UpdateChannelUseCase) forwardUpdateToLegacySystem(ctx context.Context,
 	// 6. Check response status
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("legacy system returned error status %d: %s", resp.StatusCode, string(body))
+		return shared.NewLegacyStatusError(resp.StatusCode, string(body))
 	}
 
 	return nil
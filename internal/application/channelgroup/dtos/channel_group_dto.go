@@ -0,0 +1,67 @@
+package dtos
+
+import (
+	"notification/internal/domain/channelgroup"
+)
+
+// CreateChannelGroupRequest is the DTO for creating a channel group.
+type CreateChannelGroupRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	ChannelIDs  []string `json:"channelIds"`
+}
+
+// UpdateChannelGroupRequest is the DTO for updating the name and description of a channel group.
+type UpdateChannelGroupRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// ChannelGroupMembersRequest is the DTO for adding channels to or removing channels from a group.
+type ChannelGroupMembersRequest struct {
+	ChannelIDs []string `json:"channelIds" binding:"required"`
+}
+
+// ListChannelGroupsRequest is the DTO for listing channel groups.
+type ListChannelGroupsRequest struct {
+	SkipCount      int `form:"skipCount" json:"skipCount"`
+	MaxResultCount int `form:"maxResultCount" json:"maxResultCount"`
+}
+
+// ChannelGroupResponse is the DTO for a channel group response.
+type ChannelGroupResponse struct {
+	GroupID     string   `json:"groupId"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	ChannelIDs  []string `json:"channelIds"`
+	CreatedAt   int64    `json:"createdAt"`
+	UpdatedAt   int64    `json:"updatedAt"`
+}
+
+// ListChannelGroupsResponse is the DTO for a list of channel groups.
+type ListChannelGroupsResponse struct {
+	Items          []*ChannelGroupResponse `json:"items"`
+	SkipCount      int                     `json:"skipCount"`
+	MaxResultCount int                     `json:"maxResultCount"`
+	TotalCount     int                     `json:"totalCount"`
+	HasMore        bool                    `json:"hasMore"`
+}
+
+// DeleteChannelGroupResponse is the DTO for a delete channel group response.
+type DeleteChannelGroupResponse struct {
+	GroupID   string `json:"groupId"`
+	Deleted   bool   `json:"deleted"`
+	DeletedAt int64  `json:"deletedAt"`
+}
+
+// FromChannelGroup creates a DTO from a domain object.
+func FromChannelGroup(group *channelgroup.ChannelGroup) *ChannelGroupResponse {
+	return &ChannelGroupResponse{
+		GroupID:     group.ID().String(),
+		Name:        group.Name().String(),
+		Description: group.Description().String(),
+		ChannelIDs:  group.Members().Strings(),
+		CreatedAt:   group.Timestamps().CreatedAt,
+		UpdatedAt:   group.Timestamps().UpdatedAt,
+	}
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/channelgroup/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/channelgroup"
+	"notification/internal/domain/shared"
+)
+
+// CreateChannelGroupUseCase is the use case for creating a channel group.
+type CreateChannelGroupUseCase struct {
+	groupRepo   channelgroup.ChannelGroupRepository
+	channelRepo channel.ChannelRepository
+}
+
+// NewCreateChannelGroupUseCase creates a use case instance.
+func NewCreateChannelGroupUseCase(groupRepo channelgroup.ChannelGroupRepository, channelRepo channel.ChannelRepository) *CreateChannelGroupUseCase {
+	return &CreateChannelGroupUseCase{
+		groupRepo:   groupRepo,
+		channelRepo: channelRepo,
+	}
+}
+
+// Execute creates a channel group.
+func (uc *CreateChannelGroupUseCase) Execute(ctx context.Context, request *dtos.CreateChannelGroupRequest) (*dtos.ChannelGroupResponse, error) {
+	// 1. Validate input parameters
+	if request == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+
+	name, err := channelgroup.NewGroupName(request.Name)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid group name: %w", err))
+	}
+	description, err := channel.NewDescription(request.Description)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid description: %w", err))
+	}
+
+	// 2. Check the name is free
+	exists, err := uc.groupRepo.ExistsByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, shared.NewConflictError("CHANNEL_GROUP_NAME_CONFLICT", fmt.Sprintf("channel group with name '%s' already exists", name.String()))
+	}
+
+	// 3. Resolve the member channels
+	channelIDs, err := findMemberChannels(ctx, uc.channelRepo, request.ChannelIDs)
+	if err != nil {
+		return nil, err
+	}
+	members, err := channelgroup.NewMembers(channelIDs)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 4. Create and persist the group
+	group, err := channelgroup.NewChannelGroup(name, description, members)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to create channel group: %w", err))
+	}
+	if err := uc.groupRepo.Save(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromChannelGroup(group), nil
+}
+
+// findMemberChannels converts the channel IDs of a request and checks every
+// channel exists.
+func findMemberChannels(ctx context.Context, channelRepo channel.ChannelRepository, values []string) ([]*channel.ChannelID, error) {
+	channelIDs := make([]*channel.ChannelID, 0, len(values))
+	for _, value := range values {
+		channelID, err := channel.NewChannelIDFromString(value)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID '%s': %w", value, err))
+		}
+		if _, err := channelRepo.FindByID(ctx, channelID); err != nil {
+			if shared.IsNotFound(err) {
+				return nil, shared.NewValidationError("CHANNEL_NOT_FOUND", fmt.Errorf("channel '%s' does not exist", value))
+			}
+			return nil, err
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+	return channelIDs, nil
+}
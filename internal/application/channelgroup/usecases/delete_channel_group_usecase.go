@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/channelgroup/dtos"
+	"notification/internal/domain/channelgroup"
+)
+
+// DeleteChannelGroupUseCase is the use case for deleting a channel group.
+// The member channels are left as they are.
+type DeleteChannelGroupUseCase struct {
+	groupRepo channelgroup.ChannelGroupRepository
+}
+
+// NewDeleteChannelGroupUseCase creates a use case instance.
+func NewDeleteChannelGroupUseCase(groupRepo channelgroup.ChannelGroupRepository) *DeleteChannelGroupUseCase {
+	return &DeleteChannelGroupUseCase{
+		groupRepo: groupRepo,
+	}
+}
+
+// Execute soft deletes a channel group.
+func (uc *DeleteChannelGroupUseCase) Execute(ctx context.Context, groupID string) (*dtos.DeleteChannelGroupResponse, error) {
+	// 1. Query the group
+	group, err := findChannelGroup(ctx, uc.groupRepo, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Perform soft deletion
+	if err := group.Delete(); err != nil {
+		return nil, fmt.Errorf("failed to delete channel group: %w", err)
+	}
+
+	// 3. Persist
+	if err := uc.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return &dtos.DeleteChannelGroupResponse{
+		GroupID:   group.ID().String(),
+		Deleted:   true,
+		DeletedAt: *group.Timestamps().DeletedAt,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/channelgroup/dtos"
+	"notification/internal/domain/channelgroup"
+	"notification/internal/domain/shared"
+)
+
+// GetChannelGroupUseCase is the use case for reading channel groups.
+type GetChannelGroupUseCase struct {
+	groupRepo channelgroup.ChannelGroupRepository
+}
+
+// NewGetChannelGroupUseCase creates a use case instance.
+func NewGetChannelGroupUseCase(groupRepo channelgroup.ChannelGroupRepository) *GetChannelGroupUseCase {
+	return &GetChannelGroupUseCase{
+		groupRepo: groupRepo,
+	}
+}
+
+// Execute gets a channel group by ID.
+func (uc *GetChannelGroupUseCase) Execute(ctx context.Context, groupID string) (*dtos.ChannelGroupResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	group, err := findChannelGroup(ctx, uc.groupRepo, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtos.FromChannelGroup(group), nil
+}
+
+// List lists the channel groups by name.
+func (uc *GetChannelGroupUseCase) List(ctx context.Context, request *dtos.ListChannelGroupsRequest) (*dtos.ListChannelGroupsResponse, error) {
+	// 1. Create pagination parameters
+	maxResultCount := request.MaxResultCount
+	if maxResultCount <= 0 {
+		maxResultCount = 20
+	}
+	pagination, err := shared.NewPagination(request.SkipCount, maxResultCount)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid pagination: %w", err))
+	}
+
+	// 2. Query the groups
+	result, err := uc.groupRepo.FindAll(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Convert to response DTO
+	items := make([]*dtos.ChannelGroupResponse, 0, len(result.Items))
+	for _, group := range result.Items {
+		items = append(items, dtos.FromChannelGroup(group))
+	}
+
+	return &dtos.ListChannelGroupsResponse{
+		Items:          items,
+		SkipCount:      result.SkipCount,
+		MaxResultCount: result.MaxResultCount,
+		TotalCount:     result.TotalCount,
+		HasMore:        result.HasMore,
+	}, nil
+}
+
+// findChannelGroup converts the group ID and finds the group.
+func findChannelGroup(ctx context.Context, groupRepo channelgroup.ChannelGroupRepository, groupID string) (*channelgroup.ChannelGroup, error) {
+	id, err := channelgroup.NewChannelGroupIDFromString(groupID)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel group ID: %w", err))
+	}
+
+	return groupRepo.FindByID(ctx, id)
+}
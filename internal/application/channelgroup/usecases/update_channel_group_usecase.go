@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/channelgroup/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/channelgroup"
+	"notification/internal/domain/shared"
+)
+
+// UpdateChannelGroupUseCase is the use case for changing a channel group and its members.
+type UpdateChannelGroupUseCase struct {
+	groupRepo   channelgroup.ChannelGroupRepository
+	channelRepo channel.ChannelRepository
+}
+
+// NewUpdateChannelGroupUseCase creates a use case instance.
+func NewUpdateChannelGroupUseCase(groupRepo channelgroup.ChannelGroupRepository, channelRepo channel.ChannelRepository) *UpdateChannelGroupUseCase {
+	return &UpdateChannelGroupUseCase{
+		groupRepo:   groupRepo,
+		channelRepo: channelRepo,
+	}
+}
+
+// Execute updates the name and description of a channel group.
+func (uc *UpdateChannelGroupUseCase) Execute(ctx context.Context, groupID string, request *dtos.UpdateChannelGroupRequest) (*dtos.ChannelGroupResponse, error) {
+	// 1. Validate input parameters
+	if request == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+
+	name, err := channelgroup.NewGroupName(request.Name)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid group name: %w", err))
+	}
+	description, err := channel.NewDescription(request.Description)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid description: %w", err))
+	}
+
+	// 2. Query the group
+	group, err := findChannelGroup(ctx, uc.groupRepo, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Check a new name is free
+	if group.Name().String() != name.String() {
+		exists, err := uc.groupRepo.ExistsByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, shared.NewConflictError("CHANNEL_GROUP_NAME_CONFLICT", fmt.Sprintf("channel group with name '%s' already exists", name.String()))
+		}
+	}
+
+	// 4. Update and persist
+	if err := group.Update(name, description); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to update channel group: %w", err))
+	}
+	if err := uc.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromChannelGroup(group), nil
+}
+
+// AddMembers adds channels to a channel group. Channels already in the group are skipped.
+func (uc *UpdateChannelGroupUseCase) AddMembers(ctx context.Context, groupID string, request *dtos.ChannelGroupMembersRequest) (*dtos.ChannelGroupResponse, error) {
+	// 1. Validate input parameters
+	if request == nil || len(request.ChannelIDs) == 0 {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("at least one channel ID is required"))
+	}
+
+	// 2. Query the group
+	group, err := findChannelGroup(ctx, uc.groupRepo, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Resolve the channels and add them
+	channelIDs, err := findMemberChannels(ctx, uc.channelRepo, request.ChannelIDs)
+	if err != nil {
+		return nil, err
+	}
+	added, err := group.AddMembers(channelIDs)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 4. Persist
+	if added > 0 {
+		if err := uc.groupRepo.Update(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+
+	return dtos.FromChannelGroup(group), nil
+}
+
+// RemoveMembers removes channels from a channel group. Channels not in the group are ignored.
+func (uc *UpdateChannelGroupUseCase) RemoveMembers(ctx context.Context, groupID string, request *dtos.ChannelGroupMembersRequest) (*dtos.ChannelGroupResponse, error) {
+	// 1. Validate input parameters
+	if request == nil || len(request.ChannelIDs) == 0 {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("at least one channel ID is required"))
+	}
+
+	channelIDs := make([]*channel.ChannelID, 0, len(request.ChannelIDs))
+	for _, value := range request.ChannelIDs {
+		channelID, err := channel.NewChannelIDFromString(value)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID '%s': %w", value, err))
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+
+	// 2. Query the group
+	group, err := findChannelGroup(ctx, uc.groupRepo, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Remove the channels and persist
+	if group.RemoveMembers(channelIDs) > 0 {
+		if err := uc.groupRepo.Update(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+
+	return dtos.FromChannelGroup(group), nil
+}
@@ -11,82 +11,57 @@ import (
 	"notification/pkg/logger"
 )
 
-// DefaultCommandBus is the default implementation of CommandBus
+// DefaultCommandBus is the default implementation of CommandBus. Commands
+// pass through its middlewares before reaching their handler.
 type DefaultCommandBus struct {
 	handlers map[string]CommandHandler
+	execute  CommandHandlerFunc
 	mutex    sync.RWMutex
 }
 
-// NewDefaultCommandBus creates a new default command bus
+// NewDefaultCommandBus creates a new default command bus that logs and
+// validates commands
 func NewDefaultCommandBus() *DefaultCommandBus {
-	return &DefaultCommandBus{
+	return NewCommandBusWithMiddlewares(DefaultCommandMiddlewares()...)
+}
+
+// NewCommandBusWithMiddlewares creates a command bus running the given
+// middlewares, the first one outermost
+func NewCommandBusWithMiddlewares(middlewares ...CommandMiddleware) *DefaultCommandBus {
+	bus := &DefaultCommandBus{
 		handlers: make(map[string]CommandHandler),
 	}
+	bus.execute = ChainCommand(middlewares...)(bus.dispatch)
+	return bus
 }
 
 // Execute executes a command
 func (bus *DefaultCommandBus) Execute(ctx context.Context, command Command) (*CommandResult, error) {
 	startTime := time.Now()
-	
-	logger.Info("Executing command",
-		zap.String("command_id", command.GetCommandID()),
-		zap.String("command_type", command.GetCommandType()))
-
-	// Validate command
-	if err := command.Validate(); err != nil {
-		logger.Error("Command validation failed",
-			zap.String("command_id", command.GetCommandID()),
-			zap.Error(err))
-		return &CommandResult{
-			CommandID:  command.GetCommandID(),
-			Success:    false,
-			Error:      fmt.Errorf("command validation failed: %w", err),
-			ExecutedAt: time.Now(),
-			Duration:   time.Since(startTime),
-		}, err
+
+	result, err := bus.execute(ctx, command)
+	if result == nil {
+		result = &CommandResult{CommandID: command.GetCommandID(), Success: err == nil, Error: err}
 	}
 
-	// Get handler
+	// Update result with timing information
+	result.Duration = time.Since(startTime)
+	result.ExecutedAt = time.Now()
+
+	return result, err
+}
+
+// dispatch hands a command to its registered handler
+func (bus *DefaultCommandBus) dispatch(ctx context.Context, command Command) (*CommandResult, error) {
 	handler, err := bus.GetHandler(command.GetCommandType())
 	if err != nil {
-		logger.Error("No handler found for command",
-			zap.String("command_id", command.GetCommandID()),
-			zap.String("command_type", command.GetCommandType()),
-			zap.Error(err))
-		return &CommandResult{
-			CommandID:  command.GetCommandID(),
-			Success:    false,
-			Error:      err,
-			ExecutedAt: time.Now(),
-			Duration:   time.Since(startTime),
-		}, err
+		return failedCommand(command, err)
 	}
 
-	// Execute command
 	result, err := handler.Handle(ctx, command)
 	if err != nil {
-		logger.Error("Command execution failed",
-			zap.String("command_id", command.GetCommandID()),
-			zap.String("command_type", command.GetCommandType()),
-			zap.Error(err))
-		return &CommandResult{
-			CommandID:  command.GetCommandID(),
-			Success:    false,
-			Error:      err,
-			ExecutedAt: time.Now(),
-			Duration:   time.Since(startTime),
-		}, err
+		return failedCommand(command, err)
 	}
-
-	// Update result with timing information
-	result.Duration = time.Since(startTime)
-	result.ExecutedAt = time.Now()
-
-	logger.Info("Command executed successfully",
-		zap.String("command_id", command.GetCommandID()),
-		zap.String("command_type", command.GetCommandType()),
-		zap.Duration("duration", result.Duration))
-
 	return result, nil
 }
 
@@ -120,83 +95,57 @@ func (bus *DefaultCommandBus) GetHandler(commandType string) (CommandHandler, er
 	return handler, nil
 }
 
-// DefaultQueryBus is the default implementation of QueryBus
+// DefaultQueryBus is the default implementation of QueryBus. Queries pass
+// through its middlewares before reaching their handler.
 type DefaultQueryBus struct {
 	handlers map[string]QueryHandler
+	execute  QueryHandlerFunc
 	mutex    sync.RWMutex
 }
 
-// NewDefaultQueryBus creates a new default query bus
+// NewDefaultQueryBus creates a new default query bus that logs and
+// validates queries
 func NewDefaultQueryBus() *DefaultQueryBus {
-	return &DefaultQueryBus{
+	return NewQueryBusWithMiddlewares(DefaultQueryMiddlewares()...)
+}
+
+// NewQueryBusWithMiddlewares creates a query bus running the given
+// middlewares, the first one outermost
+func NewQueryBusWithMiddlewares(middlewares ...QueryMiddleware) *DefaultQueryBus {
+	bus := &DefaultQueryBus{
 		handlers: make(map[string]QueryHandler),
 	}
+	bus.execute = ChainQuery(middlewares...)(bus.dispatch)
+	return bus
 }
 
 // Execute executes a query
 func (bus *DefaultQueryBus) Execute(ctx context.Context, query Query) (*QueryResult, error) {
 	startTime := time.Now()
-	
-	logger.Debug("Executing query",
-		zap.String("query_id", query.GetQueryID()),
-		zap.String("query_type", query.GetQueryType()))
-
-	// Validate query
-	if err := query.Validate(); err != nil {
-		logger.Error("Query validation failed",
-			zap.String("query_id", query.GetQueryID()),
-			zap.Error(err))
-		return &QueryResult{
-			QueryID:    query.GetQueryID(),
-			Success:    false,
-			Error:      fmt.Errorf("query validation failed: %w", err),
-			ExecutedAt: time.Now(),
-			Duration:   time.Since(startTime),
-		}, err
+
+	result, err := bus.execute(ctx, query)
+	if result == nil {
+		result = &QueryResult{QueryID: query.GetQueryID(), Success: err == nil, Error: err}
 	}
 
-	// Get handler
+	// Update result with timing information
+	result.Duration = time.Since(startTime)
+	result.ExecutedAt = time.Now()
+
+	return result, err
+}
+
+// dispatch hands a query to its registered handler
+func (bus *DefaultQueryBus) dispatch(ctx context.Context, query Query) (*QueryResult, error) {
 	handler, err := bus.GetHandler(query.GetQueryType())
 	if err != nil {
-		logger.Error("No handler found for query",
-			zap.String("query_id", query.GetQueryID()),
-			zap.String("query_type", query.GetQueryType()),
-			zap.Error(err))
-		return &QueryResult{
-			QueryID:    query.GetQueryID(),
-			Success:    false,
-			Error:      err,
-			ExecutedAt: time.Now(),
-			Duration:   time.Since(startTime),
-		}, err
+		return failedQuery(query, err)
 	}
 
-	// Execute query
 	result, err := handler.Handle(ctx, query)
 	if err != nil {
-		logger.Error("Query execution failed",
-			zap.String("query_id", query.GetQueryID()),
-			zap.String("query_type", query.GetQueryType()),
-			zap.Error(err))
-		return &QueryResult{
-			QueryID:    query.GetQueryID(),
-			Success:    false,
-			Error:      err,
-			ExecutedAt: time.Now(),
-			Duration:   time.Since(startTime),
-		}, err
+		return failedQuery(query, err)
 	}
-
-	// Update result with timing information
-	result.Duration = time.Since(startTime)
-	result.ExecutedAt = time.Now()
-
-	logger.Debug("Query executed successfully",
-		zap.String("query_id", query.GetQueryID()),
-		zap.String("query_type", query.GetQueryType()),
-		zap.Duration("duration", result.Duration),
-		zap.Bool("cache_hit", result.CacheHit))
-
 	return result, nil
 }
 
@@ -229,96 +178,3 @@ func (bus *DefaultQueryBus) GetHandler(queryType string) (QueryHandler, error) {
 
 	return handler, nil
 }
-
-// DefaultEventBus is the default implementation of EventBus
-type DefaultEventBus struct {
-	handlers map[string][]EventHandler
-	mutex    sync.RWMutex
-}
-
-// NewDefaultEventBus creates a new default event bus
-func NewDefaultEventBus() *DefaultEventBus {
-	return &DefaultEventBus{
-		handlers: make(map[string][]EventHandler),
-	}
-}
-
-// Publish publishes an event
-func (bus *DefaultEventBus) Publish(ctx context.Context, event Event) error {
-	logger.Info("Publishing event",
-		zap.String("event_id", event.GetEventID()),
-		zap.String("event_type", event.GetEventType()),
-		zap.String("aggregate_id", event.GetAggregateID()))
-
-	bus.mutex.RLock()
-	handlers, exists := bus.handlers[event.GetEventType()]
-	bus.mutex.RUnlock()
-
-	if !exists {
-		logger.Debug("No handlers registered for event type",
-			zap.String("event_type", event.GetEventType()))
-		return nil
-	}
-
-	// Handle event with all registered handlers
-	for _, handler := range handlers {
-		if err := handler.Handle(ctx, event); err != nil {
-			logger.Error("Event handler failed",
-				zap.String("event_id", event.GetEventID()),
-				zap.String("event_type", event.GetEventType()),
-				zap.Error(err))
-			// Continue with other handlers even if one fails
-		}
-	}
-
-	return nil
-}
-
-// PublishBatch publishes multiple events
-func (bus *DefaultEventBus) PublishBatch(ctx context.Context, events []Event) error {
-	for _, event := range events {
-		if err := bus.Publish(ctx, event); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// Subscribe subscribes to events of a specific type
-func (bus *DefaultEventBus) Subscribe(eventType string, handler EventHandler) error {
-	bus.mutex.Lock()
-	defer bus.mutex.Unlock()
-
-	if bus.handlers[eventType] == nil {
-		bus.handlers[eventType] = make([]EventHandler, 0)
-	}
-
-	bus.handlers[eventType] = append(bus.handlers[eventType], handler)
-	logger.Info("Event handler subscribed",
-		zap.String("event_type", eventType))
-
-	return nil
-}
-
-// Unsubscribe unsubscribes from events of a specific type
-func (bus *DefaultEventBus) Unsubscribe(eventType string, handler EventHandler) error {
-	bus.mutex.Lock()
-	defer bus.mutex.Unlock()
-
-	handlers, exists := bus.handlers[eventType]
-	if !exists {
-		return fmt.Errorf("no handlers registered for event type: %s", eventType)
-	}
-
-	// Remove the handler from the slice
-	for i, h := range handlers {
-		if h == handler {
-			bus.handlers[eventType] = append(handlers[:i], handlers[i+1:]...)
-			logger.Info("Event handler unsubscribed",
-				zap.String("event_type", eventType))
-			return nil
-		}
-	}
-
-	return fmt.Errorf("handler not found for event type: %s", eventType)
-}
\ No newline at end of file
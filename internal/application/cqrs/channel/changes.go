@@ -0,0 +1,94 @@
+package channel
+
+import (
+	"reflect"
+
+	"notification/internal/application/channel/dtos"
+	domainchannel "notification/internal/domain/channel"
+)
+
+// maskedConfigValue replaces the values of secret config keys in change sets
+const maskedConfigValue = "****"
+
+// FieldChange records the value of a channel field before and after an update.
+// A nil value means the field or config key was not set.
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// diffChannel returns the fields that differ between two states of a channel.
+// Config keys are compared one by one under "config.<key>", and the values of
+// secret keys are masked so that the change set can be published.
+func diffChannel(before, after *dtos.ChannelResponse) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	if before == nil || after == nil {
+		return changes
+	}
+
+	fields := []struct {
+		name          string
+		before, after interface{}
+	}{
+		{"channelName", before.ChannelName, after.ChannelName},
+		{"description", before.Description, after.Description},
+		{"enabled", before.Enabled, after.Enabled},
+		{"channelType", before.ChannelType, after.ChannelType},
+		{"templateId", before.TemplateID, after.TemplateID},
+		{"commonSettings", before.CommonSettings, after.CommonSettings},
+		{"recipients", before.Recipients, after.Recipients},
+		{"tags", before.Tags, after.Tags},
+		{"variableDefaults", before.VariableDefaults, after.VariableDefaults},
+		{"owner", before.Owner, after.Owner},
+		{"team", before.Team, after.Team},
+		{"environment", before.Environment, after.Environment},
+		{"fallbackChannelId", before.FallbackChannelID, after.FallbackChannelID},
+	}
+	for _, field := range fields {
+		if !reflect.DeepEqual(field.before, field.after) {
+			changes[field.name] = FieldChange{Before: field.before, After: field.after}
+		}
+	}
+
+	for key, change := range diffConfig(before.Config, after.Config) {
+		changes["config."+key] = change
+	}
+	return changes
+}
+
+// diffConfig returns the config keys that were added, removed or changed
+func diffConfig(before, after map[string]interface{}) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if ok && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		change := FieldChange{Before: oldValue}
+		if ok {
+			change.After = newValue
+		}
+		changes[key] = maskConfigChange(key, change)
+	}
+	for key, newValue := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = maskConfigChange(key, FieldChange{After: newValue})
+		}
+	}
+	return changes
+}
+
+// maskConfigChange hides the values of a secret config key, keeping whether
+// it was set before and after
+func maskConfigChange(key string, change FieldChange) FieldChange {
+	if !domainchannel.IsSecretConfigKey(key) {
+		return change
+	}
+	if change.Before != nil {
+		change.Before = maskedConfigValue
+	}
+	if change.After != nil {
+		change.After = maskedConfigValue
+	}
+	return change
+}
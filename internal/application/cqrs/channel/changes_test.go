@@ -0,0 +1,48 @@
+package channel
+
+import (
+	"reflect"
+	"testing"
+
+	"notification/internal/application/channel/dtos"
+)
+
+func TestDiffChannel(t *testing.T) {
+	before := &dtos.ChannelResponse{
+		ChannelName: "alerts",
+		Enabled:     true,
+		Tags:        []string{"ops"},
+		Config: map[string]interface{}{
+			"host":     "smtp.example.com",
+			"password": "old-secret",
+			"port":     float64(25),
+		},
+	}
+	after := &dtos.ChannelResponse{
+		ChannelName: "alerts",
+		Enabled:     false,
+		Tags:        []string{"ops"},
+		Config: map[string]interface{}{
+			"host":     "smtp.example.com",
+			"password": "new-secret",
+			"apiKey":   "key",
+		},
+	}
+
+	want := map[string]FieldChange{
+		"enabled":         {Before: true, After: false},
+		"config.password": {Before: maskedConfigValue, After: maskedConfigValue},
+		"config.apiKey":   {After: maskedConfigValue},
+		"config.port":     {Before: float64(25)},
+	}
+	if got := diffChannel(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffChannel() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffChannelUnchanged(t *testing.T) {
+	ch := &dtos.ChannelResponse{ChannelName: "alerts", Config: map[string]interface{}{"token": "x"}}
+	if got := diffChannel(ch, ch); len(got) != 0 {
+		t.Errorf("diffChannel() = %v, want no changes", got)
+	}
+}
@@ -66,7 +66,7 @@ type ChannelUpdatedEventData struct {
 	Tags           []string               `json:"tags"`
 	Enabled        bool                   `json:"enabled"`
 	UpdatedAt      int64                  `json:"updatedAt"`
-	Changes        map[string]interface{} `json:"changes"` // What fields were changed
+	Changes        map[string]FieldChange `json:"changes"` // What fields were changed
 }
 
 // NewChannelUpdatedEvent creates a new channel updated event
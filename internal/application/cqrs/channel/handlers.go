@@ -80,7 +80,7 @@ func (h *CreateChannelCommandHandler) Handle(ctx context.Context, command cqrs.C
 		CreatedAt:   response.CreatedAt,
 	}
 
-	event := NewChannelCreatedEvent(response.ChannelID, 1, eventData)
+	event := NewChannelCreatedEvent(response.ChannelID, response.Version, eventData)
 	events := []cqrs.Event{event}
 
 	// Publish event
@@ -124,7 +124,7 @@ func (h *UpdateChannelCommandHandler) Handle(ctx context.Context, command cqrs.C
 		zap.String("channel_id", cmd.ChannelID))
 
 	// Execute the use case
-	response, err := h.handlers.updateUseCase.Execute(ctx, cmd.ChannelID, cmd.Request)
+	previous, response, err := h.handlers.updateUseCase.ExecuteWithPrevious(ctx, cmd.ChannelID, cmd.Request)
 	if err != nil {
 		return &cqrs.CommandResult{
 			CommandID: cmd.GetCommandID(),
@@ -145,10 +145,10 @@ func (h *UpdateChannelCommandHandler) Handle(ctx context.Context, command cqrs.C
 		Tags:        response.Tags,
 		Enabled:     response.Enabled,
 		UpdatedAt:   response.UpdatedAt,
-		Changes:     make(map[string]interface{}), // TODO: Track actual changes
+		Changes:     diffChannel(previous, response),
 	}
 
-	event := NewChannelUpdatedEvent(response.ChannelID, 2, eventData) // TODO: Get actual version
+	event := NewChannelUpdatedEvent(response.ChannelID, response.Version, eventData)
 	events := []cqrs.Event{event}
 
 	// Publish event
@@ -207,7 +207,7 @@ func (h *DeleteChannelCommandHandler) Handle(ctx context.Context, command cqrs.C
 		DeletedAt:   response.DeletedAt,
 	}
 
-	event := NewChannelDeletedEvent(response.ChannelID, 3, eventData) // TODO: Get actual version
+	event := NewChannelDeletedEvent(response.ChannelID, response.Version, eventData)
 	events := []cqrs.Event{event}
 
 	// Publish event
@@ -311,8 +311,15 @@ func (h *ListChannelsQueryHandler) Handle(ctx context.Context, query cqrs.Query)
 
 	// Convert CQRS query to DTO
 	request := &dtos.ListChannelsRequest{
-		ChannelType: q.ChannelType,
-		Tags:        q.Tags,
+		ChannelType:    q.ChannelType,
+		Tags:           q.Tags,
+		TemplateID:     q.TemplateID,
+		Owner:          q.Owner,
+		Team:           q.Team,
+		Environment:    q.Environment,
+		LastUsedBefore: q.LastUsedBefore,
+		LastUsedAfter:  q.LastUsedAfter,
+		UnusedForDays:  q.UnusedForDays,
 	}
 
 	if q.Options != nil && q.Options.Pagination != nil {
@@ -320,6 +327,11 @@ func (h *ListChannelsQueryHandler) Handle(ctx context.Context, query cqrs.Query)
 		request.MaxResultCount = q.Options.Pagination.Limit
 	}
 
+	if q.Options != nil && len(q.Options.Sorting) > 0 {
+		request.SortField = q.Options.Sorting[0].Field
+		request.SortOrder = q.Options.Sorting[0].Order
+	}
+
 	// Execute the use case
 	response, err := h.handlers.listUseCase.Execute(ctx, request)
 	if err != nil {
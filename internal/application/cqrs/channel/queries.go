@@ -41,7 +41,16 @@ type ListChannelsQuery struct {
 	ChannelType string             `json:"channelType,omitempty"`
 	Tags        []string           `json:"tags,omitempty"`
 	Enabled     *bool              `json:"enabled,omitempty"`
-	Options     *cqrs.QueryOptions `json:"options,omitempty"`
+	TemplateID  string             `json:"templateId,omitempty"`
+	Owner       string             `json:"owner,omitempty"`
+	Team        string             `json:"team,omitempty"`
+	Environment string             `json:"environment,omitempty"`
+	// LastUsedBefore and LastUsedAfter bound the last-used time in Unix milliseconds
+	LastUsedBefore *int64 `json:"lastUsedBefore,omitempty"`
+	LastUsedAfter  *int64 `json:"lastUsedAfter,omitempty"`
+	// UnusedForDays matches channels not used within the given number of days
+	UnusedForDays int                `json:"unusedForDays,omitempty"`
+	Options       *cqrs.QueryOptions `json:"options,omitempty"`
 }
 
 // NewListChannelsQuery creates a new list channels query
@@ -70,6 +79,33 @@ func (q *ListChannelsQuery) WithEnabled(enabled bool) *ListChannelsQuery {
 	return q
 }
 
+// WithTemplateID sets the template filter
+func (q *ListChannelsQuery) WithTemplateID(templateID string) *ListChannelsQuery {
+	q.TemplateID = templateID
+	return q
+}
+
+// WithOwnership sets the owner, team and environment filters
+func (q *ListChannelsQuery) WithOwnership(owner, team, environment string) *ListChannelsQuery {
+	q.Owner = owner
+	q.Team = team
+	q.Environment = environment
+	return q
+}
+
+// WithLastUsedRange sets the last-used time range filter; nil bounds are open
+func (q *ListChannelsQuery) WithLastUsedRange(after, before *int64) *ListChannelsQuery {
+	q.LastUsedAfter = after
+	q.LastUsedBefore = before
+	return q
+}
+
+// WithUnusedForDays filters channels not used within the given number of days
+func (q *ListChannelsQuery) WithUnusedForDays(days int) *ListChannelsQuery {
+	q.UnusedForDays = days
+	return q
+}
+
 // WithPagination sets pagination options
 func (q *ListChannelsQuery) WithPagination(offset, limit int) *ListChannelsQuery {
 	q.Options.Pagination = &cqrs.Pagination{
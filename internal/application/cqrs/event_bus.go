@@ -0,0 +1,460 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"notification/internal/domain/failedevent"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// EventBusConfig holds the dispatch settings of a DefaultEventBus
+type EventBusConfig struct {
+	// Concurrency bounds the handlers running at the same time per publish
+	Concurrency int
+	// RetryAttempts is the number of times a failing handler is invoked
+	RetryAttempts int
+	// RetryDelay is the wait before the first retry, doubled for every further one
+	RetryDelay time.Duration
+	// OrderByAggregate dispatches the events of one aggregate one at a time,
+	// in publish order, while events of different aggregates run in parallel
+	OrderByAggregate bool
+	// PoisonEventLimit is the number of most recent poison events kept
+	PoisonEventLimit int
+}
+
+// DefaultEventBusConfig returns the default event bus settings
+func DefaultEventBusConfig() EventBusConfig {
+	return EventBusConfig{
+		Concurrency:      4,
+		RetryAttempts:    3,
+		RetryDelay:       100 * time.Millisecond,
+		OrderByAggregate: true,
+		PoisonEventLimit: 100,
+	}
+}
+
+// PoisonEvent is an event a handler kept failing on after all its retries
+type PoisonEvent struct {
+	EventID     string    `json:"eventId"`
+	EventType   string    `json:"eventType"`
+	AggregateID string    `json:"aggregateId"`
+	Handler     string    `json:"handler"`
+	Attempts    int       `json:"attempts"`
+	Error       string    `json:"error"`
+	FailedAt    time.Time `json:"failedAt"`
+}
+
+// EventHandlerMetrics holds the counters of one handler of one event type
+type EventHandlerMetrics struct {
+	Handled      int64         `json:"handled"`
+	Failed       int64         `json:"failed"`
+	Retries      int64         `json:"retries"`
+	Poisoned     int64         `json:"poisoned"`
+	TotalLatency time.Duration `json:"totalLatency"`
+	MaxLatency   time.Duration `json:"maxLatency"`
+}
+
+// DefaultEventBus is the default implementation of EventBus. The handlers of
+// an event run concurrently, each retried on failure; an event a handler
+// still fails on is recorded as a poison event and reported by Publish.
+type DefaultEventBus struct {
+	config   EventBusConfig
+	handlers map[string][]EventHandler
+	mutex    sync.RWMutex
+
+	aggregateMu    sync.Mutex
+	aggregateLocks map[string]*aggregateLock
+
+	statsMu sync.Mutex
+	metrics map[string]*EventHandlerMetrics
+	poison  []PoisonEvent
+
+	// failedEvents persists the poison events for replay, nil to keep them in memory only
+	failedEvents failedevent.FailedEventRepository
+}
+
+// failedEventSaveTimeout bounds persisting a poison event. The save runs
+// detached from the publish context, which may be what made the handler fail.
+const failedEventSaveTimeout = 5 * time.Second
+
+// dispatchingAggregateKey is the context key of the aggregate whose events
+// are being dispatched
+type dispatchingAggregateKey struct{}
+
+// aggregateLock serializes the dispatch of the events of one aggregate
+type aggregateLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewDefaultEventBus creates a new default event bus
+func NewDefaultEventBus() *DefaultEventBus {
+	return NewEventBusWithConfig(DefaultEventBusConfig())
+}
+
+// NewEventBusWithConfig creates an event bus with the given settings
+func NewEventBusWithConfig(config EventBusConfig) *DefaultEventBus {
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	if config.RetryAttempts < 1 {
+		config.RetryAttempts = 1
+	}
+	return &DefaultEventBus{
+		config:         config,
+		handlers:       make(map[string][]EventHandler),
+		aggregateLocks: make(map[string]*aggregateLock),
+		metrics:        make(map[string]*EventHandlerMetrics),
+	}
+}
+
+// SetFailedEventRepository persists the events handlers give up on so that they
+// can be replayed later.
+func (bus *DefaultEventBus) SetFailedEventRepository(repo failedevent.FailedEventRepository) {
+	bus.failedEvents = repo
+}
+
+// Publish publishes an event to its handlers and waits for them. It returns
+// the errors of the handlers that failed on every attempt.
+func (bus *DefaultEventBus) Publish(ctx context.Context, event Event) error {
+	logger.FromContext(ctx).Info("Publishing event",
+		zap.String("event_id", event.GetEventID()),
+		zap.String("event_type", event.GetEventType()),
+		zap.String("aggregate_id", event.GetAggregateID()))
+
+	bus.mutex.RLock()
+	handlers := append([]EventHandler(nil), bus.handlers[event.GetEventType()]...)
+	bus.mutex.RUnlock()
+
+	if len(handlers) == 0 {
+		logger.Debug("No handlers registered for event type",
+			zap.String("event_type", event.GetEventType()))
+		return nil
+	}
+
+	// A handler publishing for the aggregate it handles already holds its lock
+	if aggregateID := event.GetAggregateID(); bus.config.OrderByAggregate && aggregateID != "" &&
+		ctx.Value(dispatchingAggregateKey{}) != aggregateID {
+		unlock := bus.lockAggregate(aggregateID)
+		defer unlock()
+		ctx = context.WithValue(ctx, dispatchingAggregateKey{}, aggregateID)
+	}
+
+	// Handle event with all registered handlers, a bounded number at a time
+	errs := make([]error, len(handlers))
+	slots := make(chan struct{}, bus.config.Concurrency)
+	var wg sync.WaitGroup
+	for i, handler := range handlers {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int, handler EventHandler) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			errs[i] = bus.dispatch(ctx, event, handler)
+		}(i, handler)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// PublishBatch publishes multiple events. The events of one aggregate are
+// published in order, those of different aggregates concurrently.
+func (bus *DefaultEventBus) PublishBatch(ctx context.Context, events []Event) error {
+	var order []string
+	byAggregate := make(map[string][]Event)
+	for _, event := range events {
+		id := event.GetAggregateID()
+		if _, ok := byAggregate[id]; !ok {
+			order = append(order, id)
+		}
+		byAggregate[id] = append(byAggregate[id], event)
+	}
+
+	errs := make([]error, len(order))
+	slots := make(chan struct{}, bus.config.Concurrency)
+	var wg sync.WaitGroup
+	for i, id := range order {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int, events []Event) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			for _, event := range events {
+				errs[i] = errors.Join(errs[i], bus.Publish(ctx, event))
+			}
+		}(i, byAggregate[id])
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// dispatch invokes a handler until it succeeds, the attempts run out or the
+// error cannot be fixed by retrying, and records the outcome
+func (bus *DefaultEventBus) dispatch(ctx context.Context, event Event, handler EventHandler) error {
+	name := fmt.Sprintf("%T", handler)
+	delay := bus.config.RetryDelay
+
+	var err error
+	attempts := 0
+	for attempts < bus.config.RetryAttempts {
+		if attempts > 0 {
+			select {
+			case <-ctx.Done():
+				err = errors.Join(err, ctx.Err())
+				bus.poisoned(ctx, event, name, attempts, err)
+				return err
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		attempts++
+
+		start := time.Now()
+		err = bus.invoke(ctx, event, handler)
+		bus.record(event.GetEventType(), name, time.Since(start), err != nil, attempts > 1)
+		if err == nil {
+			return nil
+		}
+
+		logger.FromContext(ctx).Warn("Event handler failed",
+			zap.String("event_id", event.GetEventID()),
+			zap.String("event_type", event.GetEventType()),
+			zap.String("handler", name),
+			zap.Int("attempt", attempts),
+			zap.Error(err))
+
+		// A rejected event fails the same way on every attempt
+		if shared.ErrorKindOf(err) == shared.ErrorKindValidation {
+			break
+		}
+	}
+
+	bus.poisoned(ctx, event, name, attempts, err)
+	return err
+}
+
+// invoke runs a handler once, turning a panic into an error
+func (bus *DefaultEventBus) invoke(ctx context.Context, event Event, handler EventHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("event handler panicked: %v", recovered)
+		}
+	}()
+	return handler.Handle(ctx, event)
+}
+
+// poisoned records an event a handler gave up on
+func (bus *DefaultEventBus) poisoned(ctx context.Context, event Event, handler string, attempts int, err error) {
+	logger.FromContext(ctx).Error("Poison event, handler gave up",
+		zap.String("event_id", event.GetEventID()),
+		zap.String("event_type", event.GetEventType()),
+		zap.String("aggregate_id", event.GetAggregateID()),
+		zap.String("handler", handler),
+		zap.Int("attempts", attempts),
+		zap.Error(err))
+
+	failedAt := time.Now()
+	bus.statsMu.Lock()
+	bus.handlerMetrics(event.GetEventType(), handler).Poisoned++
+	bus.poison = append(bus.poison, PoisonEvent{
+		EventID:     event.GetEventID(),
+		EventType:   event.GetEventType(),
+		AggregateID: event.GetAggregateID(),
+		Handler:     handler,
+		Attempts:    attempts,
+		Error:       err.Error(),
+		FailedAt:    failedAt,
+	})
+	if limit := bus.config.PoisonEventLimit; limit > 0 && len(bus.poison) > limit {
+		bus.poison = bus.poison[len(bus.poison)-limit:]
+	}
+	bus.statsMu.Unlock()
+
+	if bus.failedEvents != nil {
+		bus.saveFailedEvent(ctx, event, handler, attempts, err, failedAt)
+	}
+}
+
+// saveFailedEvent persists a poison event with its payload
+func (bus *DefaultEventBus) saveFailedEvent(ctx context.Context, event Event, handler string, attempts int, cause error, failedAt time.Time) {
+	payload, err := json.Marshal(event.GetData())
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to encode poison event payload",
+			zap.String("event_id", event.GetEventID()),
+			zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), failedEventSaveTimeout)
+	defer cancel()
+
+	err = bus.failedEvents.Save(ctx, &failedevent.FailedEvent{
+		ID:            "fevt_" + uuid.New().String(),
+		EventID:       event.GetEventID(),
+		EventType:     event.GetEventType(),
+		AggregateID:   event.GetAggregateID(),
+		AggregateType: event.GetAggregateType(),
+		Version:       event.GetVersion(),
+		Payload:       payload,
+		Handler:       handler,
+		Attempts:      attempts,
+		Error:         cause.Error(),
+		Status:        failedevent.StatusFailed,
+		FailedAt:      failedAt,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to persist poison event",
+			zap.String("event_id", event.GetEventID()),
+			zap.String("handler", handler),
+			zap.Error(err))
+	}
+}
+
+// Replay hands a failed event to the handler that failed on it, once. The
+// handler must still be subscribed to the event type.
+func (bus *DefaultEventBus) Replay(ctx context.Context, failed *failedevent.FailedEvent) error {
+	bus.mutex.RLock()
+	var handler EventHandler
+	for _, h := range bus.handlers[failed.EventType] {
+		if fmt.Sprintf("%T", h) == failed.Handler {
+			handler = h
+			break
+		}
+	}
+	bus.mutex.RUnlock()
+
+	if handler == nil {
+		return shared.NewConflictError("HANDLER_NOT_SUBSCRIBED",
+			fmt.Sprintf("handler %s is no longer subscribed to %s", failed.Handler, failed.EventType))
+	}
+
+	event := replayEvent(failed)
+	start := time.Now()
+	err := bus.invoke(ctx, event, handler)
+	bus.record(event.GetEventType(), failed.Handler, time.Since(start), err != nil, true)
+	return err
+}
+
+// record updates the metrics of a handler invocation
+func (bus *DefaultEventBus) record(eventType, handler string, latency time.Duration, failed, retry bool) {
+	bus.statsMu.Lock()
+	defer bus.statsMu.Unlock()
+
+	metrics := bus.handlerMetrics(eventType, handler)
+	metrics.Handled++
+	metrics.TotalLatency += latency
+	if latency > metrics.MaxLatency {
+		metrics.MaxLatency = latency
+	}
+	if failed {
+		metrics.Failed++
+	}
+	if retry {
+		metrics.Retries++
+	}
+}
+
+// handlerMetrics returns the metrics of a handler, statsMu must be held
+func (bus *DefaultEventBus) handlerMetrics(eventType, handler string) *EventHandlerMetrics {
+	key := eventType + "/" + handler
+	metrics, ok := bus.metrics[key]
+	if !ok {
+		metrics = &EventHandlerMetrics{}
+		bus.metrics[key] = metrics
+	}
+	return metrics
+}
+
+// Metrics returns a copy of the handler metrics, keyed by event type and handler
+func (bus *DefaultEventBus) Metrics() map[string]EventHandlerMetrics {
+	bus.statsMu.Lock()
+	defer bus.statsMu.Unlock()
+
+	result := make(map[string]EventHandlerMetrics, len(bus.metrics))
+	for key, metrics := range bus.metrics {
+		result[key] = *metrics
+	}
+	return result
+}
+
+// PoisonEvents returns the most recent poison events, oldest first
+func (bus *DefaultEventBus) PoisonEvents() []PoisonEvent {
+	bus.statsMu.Lock()
+	defer bus.statsMu.Unlock()
+
+	return append([]PoisonEvent(nil), bus.poison...)
+}
+
+// lockAggregate serializes dispatch for an aggregate and returns the unlock
+func (bus *DefaultEventBus) lockAggregate(aggregateID string) func() {
+	bus.aggregateMu.Lock()
+	lock, ok := bus.aggregateLocks[aggregateID]
+	if !ok {
+		lock = &aggregateLock{}
+		bus.aggregateLocks[aggregateID] = lock
+	}
+	lock.refs++
+	bus.aggregateMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		bus.aggregateMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(bus.aggregateLocks, aggregateID)
+		}
+		bus.aggregateMu.Unlock()
+	}
+}
+
+// Subscribe subscribes to events of a specific type
+func (bus *DefaultEventBus) Subscribe(eventType string, handler EventHandler) error {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.handlers[eventType] = append(bus.handlers[eventType], handler)
+	logger.Info("Event handler subscribed",
+		zap.String("event_type", eventType))
+
+	return nil
+}
+
+// Unsubscribe unsubscribes from events of a specific type
+func (bus *DefaultEventBus) Unsubscribe(eventType string, handler EventHandler) error {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	handlers, exists := bus.handlers[eventType]
+	if !exists {
+		return fmt.Errorf("no handlers registered for event type: %s", eventType)
+	}
+
+	// Remove the handler from the slice
+	for i, h := range handlers {
+		if h == handler {
+			bus.handlers[eventType] = append(handlers[:i:i], handlers[i+1:]...)
+			logger.Info("Event handler unsubscribed",
+				zap.String("event_type", eventType))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("handler not found for event type: %s", eventType)
+}
@@ -0,0 +1,137 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/failedevent"
+	"notification/internal/domain/shared"
+)
+
+type recordingEventHandler struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	versions []int64
+}
+
+func (h *recordingEventHandler) GetEventType() string {
+	return "test.event"
+}
+
+func (h *recordingEventHandler) Handle(ctx context.Context, event Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.calls++
+	if h.calls <= h.failures {
+		return errors.New("handler failed")
+	}
+	h.versions = append(h.versions, event.GetVersion())
+	return nil
+}
+
+func testEventBus() *DefaultEventBus {
+	config := DefaultEventBusConfig()
+	config.RetryDelay = time.Millisecond
+	return NewEventBusWithConfig(config)
+}
+
+func TestDefaultEventBus_RetriesAndDetectsPoisonEvents(t *testing.T) {
+	bus := testEventBus()
+	flaky := &recordingEventHandler{failures: 2}
+	broken := &recordingEventHandler{failures: 10}
+	require.NoError(t, bus.Subscribe("test.event", flaky))
+	require.NoError(t, bus.Subscribe("other.event", broken))
+
+	// The flaky handler succeeds on its third attempt
+	require.NoError(t, bus.Publish(context.Background(), NewBaseEvent("test.event", "agg-1", "test", 1, nil)))
+	assert.Equal(t, 3, flaky.calls)
+
+	// The broken handler gives up and the event is reported as poison
+	err := bus.Publish(context.Background(), NewBaseEvent("other.event", "agg-1", "test", 2, nil))
+	assert.Error(t, err)
+	assert.Equal(t, 3, broken.calls)
+
+	poison := bus.PoisonEvents()
+	require.Len(t, poison, 1)
+	assert.Equal(t, "other.event", poison[0].EventType)
+	assert.Equal(t, 3, poison[0].Attempts)
+
+	metrics := bus.Metrics()["test.event/*cqrs.recordingEventHandler"]
+	assert.Equal(t, int64(3), metrics.Handled)
+	assert.Equal(t, int64(2), metrics.Failed)
+	assert.Equal(t, int64(2), metrics.Retries)
+}
+
+func TestDefaultEventBus_PublishBatchKeepsAggregateOrder(t *testing.T) {
+	bus := testEventBus()
+	handler := &recordingEventHandler{}
+	require.NoError(t, bus.Subscribe("test.event", handler))
+
+	var events []Event
+	for version := int64(1); version <= 20; version++ {
+		events = append(events, NewBaseEvent("test.event", "agg-1", "test", version, nil))
+	}
+	require.NoError(t, bus.PublishBatch(context.Background(), events))
+
+	require.Len(t, handler.versions, 20)
+	for i, version := range handler.versions {
+		assert.Equal(t, int64(i+1), version)
+	}
+}
+
+type memoryFailedEventRepository struct {
+	mu     sync.Mutex
+	events []*failedevent.FailedEvent
+}
+
+func (r *memoryFailedEventRepository) Save(ctx context.Context, failed *failedevent.FailedEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, failed)
+	return nil
+}
+
+func (r *memoryFailedEventRepository) Update(ctx context.Context, failed *failedevent.FailedEvent) error {
+	return nil
+}
+
+func (r *memoryFailedEventRepository) FindByID(ctx context.Context, id string) (*failedevent.FailedEvent, error) {
+	return nil, shared.NewNotFoundError("FAILED_EVENT_NOT_FOUND", "failed event not found")
+}
+
+func (r *memoryFailedEventRepository) FindAll(ctx context.Context, filter failedevent.Filter, pagination *shared.Pagination) (*shared.PaginatedResult[*failedevent.FailedEvent], error) {
+	return &shared.PaginatedResult[*failedevent.FailedEvent]{}, nil
+}
+
+func TestDefaultEventBus_PersistsAndReplaysPoisonEvents(t *testing.T) {
+	bus := testEventBus()
+	repo := &memoryFailedEventRepository{}
+	bus.SetFailedEventRepository(repo)
+	handler := &recordingEventHandler{failures: 3}
+	require.NoError(t, bus.Subscribe("test.event", handler))
+
+	event := NewBaseEvent("test.event", "agg-1", "test", 7, map[string]string{"channelId": "ch-1"})
+	assert.Error(t, bus.Publish(context.Background(), event))
+
+	require.Len(t, repo.events, 1)
+	failed := repo.events[0]
+	assert.Equal(t, event.ID, failed.EventID)
+	assert.Equal(t, failedevent.StatusFailed, failed.Status)
+	assert.JSONEq(t, `{"channelId":"ch-1"}`, string(failed.Payload))
+
+	// The downstream recovered, the replay reaches the handler that failed
+	require.NoError(t, bus.Replay(context.Background(), failed))
+	assert.Equal(t, []int64{7}, handler.versions)
+
+	// Handlers that are no longer subscribed cannot replay their events
+	require.NoError(t, bus.Unsubscribe("test.event", handler))
+	assert.True(t, shared.IsConflict(bus.Replay(context.Background(), failed)))
+}
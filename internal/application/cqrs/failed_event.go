@@ -0,0 +1,26 @@
+package cqrs
+
+import (
+	"encoding/json"
+
+	"notification/internal/domain/failedevent"
+)
+
+// replayEvent rebuilds the event a handler failed on. Its data is the
+// decoded JSON payload rather than the original typed data.
+func replayEvent(failed *failedevent.FailedEvent) Event {
+	var data interface{}
+	if len(failed.Payload) > 0 {
+		_ = json.Unmarshal(failed.Payload, &data)
+	}
+	return &BaseEvent{
+		ID:            failed.EventID,
+		Type:          failed.EventType,
+		AggregateID:   failed.AggregateID,
+		AggregateType: failed.AggregateType,
+		Timestamp:     failed.FailedAt,
+		Version:       failed.Version,
+		Data:          data,
+		Metadata:      map[string]interface{}{"replayOf": failed.ID},
+	}
+}
@@ -3,6 +3,7 @@ package cqrs
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -106,6 +107,8 @@ func DefaultCQRSConfig() *CQRSConfig {
 type CQRSFacade struct {
 	manager *CQRSManager
 	config  *CQRSConfig
+	// metrics is nil unless the facade was built with metrics enabled
+	metrics *BusMetrics
 }
 
 // NewCQRSFacade creates a new CQRS facade
@@ -113,66 +116,27 @@ func NewCQRSFacade(manager *CQRSManager, config *CQRSConfig) *CQRSFacade {
 	if config == nil {
 		config = DefaultCQRSConfig()
 	}
-	
+
 	return &CQRSFacade{
 		manager: manager,
 		config:  config,
 	}
 }
 
-// Send executes a command
+// Manager returns the manager whose buses the facade uses, to register
+// handlers on
+func (f *CQRSFacade) Manager() *CQRSManager {
+	return f.manager
+}
+
+// Send executes a command through the command bus middlewares
 func (f *CQRSFacade) Send(ctx context.Context, command Command) (*CommandResult, error) {
-	if f.config.EnableCommandLogging {
-		logger.Info("Sending command",
-			zap.String("command_id", command.GetCommandID()),
-			zap.String("command_type", command.GetCommandType()))
-	}
-	
-	result, err := f.manager.ExecuteCommand(ctx, command)
-	
-	if f.config.EnableCommandLogging {
-		if err != nil {
-			logger.Error("Command failed",
-				zap.String("command_id", command.GetCommandID()),
-				zap.String("command_type", command.GetCommandType()),
-				zap.Error(err))
-		} else {
-			logger.Info("Command completed",
-				zap.String("command_id", command.GetCommandID()),
-				zap.String("command_type", command.GetCommandType()),
-				zap.Bool("success", result.Success))
-		}
-	}
-	
-	return result, err
+	return f.manager.ExecuteCommand(ctx, command)
 }
 
-// Query executes a query
+// Query executes a query through the query bus middlewares
 func (f *CQRSFacade) Query(ctx context.Context, query Query) (*QueryResult, error) {
-	if f.config.EnableQueryLogging {
-		logger.Debug("Executing query",
-			zap.String("query_id", query.GetQueryID()),
-			zap.String("query_type", query.GetQueryType()))
-	}
-	
-	result, err := f.manager.ExecuteQuery(ctx, query)
-	
-	if f.config.EnableQueryLogging {
-		if err != nil {
-			logger.Error("Query failed",
-				zap.String("query_id", query.GetQueryID()),
-				zap.String("query_type", query.GetQueryType()),
-				zap.Error(err))
-		} else {
-			logger.Debug("Query completed",
-				zap.String("query_id", query.GetQueryID()),
-				zap.String("query_type", query.GetQueryType()),
-				zap.Bool("success", result.Success),
-				zap.Bool("cache_hit", result.CacheHit))
-		}
-	}
-	
-	return result, err
+	return f.manager.ExecuteQuery(ctx, query)
 }
 
 // Publish publishes an event
@@ -224,11 +188,138 @@ func (f *CQRSFacade) HealthCheck(ctx context.Context) error {
 
 // GetMetrics returns CQRS metrics
 func (f *CQRSFacade) GetMetrics() map[string]interface{} {
-	// TODO: Implement metrics collection
-	// This could include command/query execution times, success rates, event publishing rates, etc.
-	return map[string]interface{}{
-		"commands_executed": 0,
-		"queries_executed":  0,
-		"events_published":  0,
-	}
-}
\ No newline at end of file
+	if f.metrics == nil {
+		return map[string]interface{}{
+			"commands_executed": 0,
+			"queries_executed":  0,
+		}
+	}
+
+	commands := f.metrics.Commands()
+	queries := f.metrics.Queries()
+	metrics := map[string]interface{}{
+		"commands_executed": totalExecuted(commands),
+		"queries_executed":  totalExecuted(queries),
+		"commands":          commands,
+		"queries":           queries,
+	}
+	if eventBus, ok := f.manager.GetEventBus().(*DefaultEventBus); ok {
+		metrics["events"] = eventBus.Metrics()
+		metrics["poison_events"] = eventBus.PoisonEvents()
+	}
+	return metrics
+}
+
+func totalExecuted(metrics map[string]TypeMetrics) int64 {
+	var total int64
+	for _, m := range metrics {
+		total += m.Executed
+	}
+	return total
+}
+
+// CQRSFacadeBuilder builds a facade whose command and query buses compose
+// the cross-cutting middlewares centrally, in this order from the outermost:
+// logging, metrics, authorization, validation, the custom middlewares, retry
+// (queries only) and transaction (commands only).
+type CQRSFacadeBuilder struct {
+	config             *CQRSConfig
+	eventBus           EventBus
+	authorizer         Authorizer
+	transactions       TransactionRunner
+	retryAttempts      int
+	retryDelay         time.Duration
+	commandMiddlewares []CommandMiddleware
+	queryMiddlewares   []QueryMiddleware
+}
+
+// NewCQRSFacadeBuilder creates a builder; a nil config uses DefaultCQRSConfig
+func NewCQRSFacadeBuilder(config *CQRSConfig) *CQRSFacadeBuilder {
+	if config == nil {
+		config = DefaultCQRSConfig()
+	}
+	return &CQRSFacadeBuilder{
+		config:   config,
+		eventBus: NewDefaultEventBus(),
+	}
+}
+
+// WithEventBus replaces the default event bus
+func (b *CQRSFacadeBuilder) WithEventBus(eventBus EventBus) *CQRSFacadeBuilder {
+	b.eventBus = eventBus
+	return b
+}
+
+// WithAuthorizer authorizes every command and query before validation
+func (b *CQRSFacadeBuilder) WithAuthorizer(authorizer Authorizer) *CQRSFacadeBuilder {
+	b.authorizer = authorizer
+	return b
+}
+
+// WithTransactions runs every command handler in a transaction
+func (b *CQRSFacadeBuilder) WithTransactions(runner TransactionRunner) *CQRSFacadeBuilder {
+	b.transactions = runner
+	return b
+}
+
+// WithQueryRetry retries queries failing on an unavailable dependency.
+// Commands are not retried since their handlers are not idempotent.
+func (b *CQRSFacadeBuilder) WithQueryRetry(attempts int, delay time.Duration) *CQRSFacadeBuilder {
+	b.retryAttempts = attempts
+	b.retryDelay = delay
+	return b
+}
+
+// UseCommandMiddleware adds middlewares that run after validation
+func (b *CQRSFacadeBuilder) UseCommandMiddleware(middlewares ...CommandMiddleware) *CQRSFacadeBuilder {
+	b.commandMiddlewares = append(b.commandMiddlewares, middlewares...)
+	return b
+}
+
+// UseQueryMiddleware adds middlewares that run after validation
+func (b *CQRSFacadeBuilder) UseQueryMiddleware(middlewares ...QueryMiddleware) *CQRSFacadeBuilder {
+	b.queryMiddlewares = append(b.queryMiddlewares, middlewares...)
+	return b
+}
+
+// Build creates the buses, their manager and the facade
+func (b *CQRSFacadeBuilder) Build() *CQRSFacade {
+	var metrics *BusMetrics
+	var commands []CommandMiddleware
+	var queries []QueryMiddleware
+
+	if b.config.EnableCommandLogging {
+		commands = append(commands, CommandLoggingMiddleware())
+	}
+	if b.config.EnableQueryLogging {
+		queries = append(queries, QueryLoggingMiddleware())
+	}
+	if b.config.EnableMetrics {
+		metrics = NewBusMetrics()
+		commands = append(commands, CommandMetricsMiddleware(metrics))
+		queries = append(queries, QueryMetricsMiddleware(metrics))
+	}
+	if b.authorizer != nil {
+		commands = append(commands, CommandAuthorizationMiddleware(b.authorizer))
+		queries = append(queries, QueryAuthorizationMiddleware(b.authorizer))
+	}
+	commands = append(commands, CommandValidationMiddleware())
+	queries = append(queries, QueryValidationMiddleware())
+	commands = append(commands, b.commandMiddlewares...)
+	queries = append(queries, b.queryMiddlewares...)
+	if b.retryAttempts > 1 {
+		queries = append(queries, QueryRetryMiddleware(b.retryAttempts, b.retryDelay))
+	}
+	if b.transactions != nil {
+		commands = append(commands, CommandTransactionMiddleware(b.transactions))
+	}
+
+	manager := NewCQRSManagerWithBuses(
+		NewCommandBusWithMiddlewares(commands...),
+		NewQueryBusWithMiddlewares(queries...),
+		b.eventBus,
+	)
+	facade := NewCQRSFacade(manager, b.config)
+	facade.metrics = metrics
+	return facade
+}
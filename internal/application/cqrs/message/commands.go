@@ -32,8 +32,8 @@ func (c *SendMessageCommand) Validate() error {
 		return fmt.Errorf("request cannot be nil")
 	}
 	
-	if len(c.Request.ChannelIDs) == 0 {
-		return fmt.Errorf("at least one channel ID is required")
+	if len(c.Request.ChannelIDs) == 0 && len(c.Request.ChannelTags) == 0 && len(c.Request.ChannelGroupIDs) == 0 {
+		return fmt.Errorf("at least one channel ID, channel tag or channel group is required")
 	}
 	
 	// Validate channel IDs are not empty
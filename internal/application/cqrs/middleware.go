@@ -0,0 +1,343 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// CommandHandlerFunc executes a command once the bus resolved its handler
+type CommandHandlerFunc func(ctx context.Context, command Command) (*CommandResult, error)
+
+// CommandMiddleware wraps a CommandHandlerFunc with cross-cutting behaviour
+type CommandMiddleware func(next CommandHandlerFunc) CommandHandlerFunc
+
+// QueryHandlerFunc executes a query once the bus resolved its handler
+type QueryHandlerFunc func(ctx context.Context, query Query) (*QueryResult, error)
+
+// QueryMiddleware wraps a QueryHandlerFunc with cross-cutting behaviour
+type QueryMiddleware func(next QueryHandlerFunc) QueryHandlerFunc
+
+// ChainCommand composes command middlewares so that the first one runs outermost
+func ChainCommand(middlewares ...CommandMiddleware) CommandMiddleware {
+	return func(next CommandHandlerFunc) CommandHandlerFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// ChainQuery composes query middlewares so that the first one runs outermost
+func ChainQuery(middlewares ...QueryMiddleware) QueryMiddleware {
+	return func(next QueryHandlerFunc) QueryHandlerFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// DefaultCommandMiddlewares returns the middlewares of a default command bus
+func DefaultCommandMiddlewares() []CommandMiddleware {
+	return []CommandMiddleware{CommandLoggingMiddleware(), CommandValidationMiddleware()}
+}
+
+// DefaultQueryMiddlewares returns the middlewares of a default query bus
+func DefaultQueryMiddlewares() []QueryMiddleware {
+	return []QueryMiddleware{QueryLoggingMiddleware(), QueryValidationMiddleware()}
+}
+
+// failedCommand returns the result of a command that failed with err
+func failedCommand(command Command, err error) (*CommandResult, error) {
+	return &CommandResult{
+		CommandID:  command.GetCommandID(),
+		Success:    false,
+		Error:      err,
+		ExecutedAt: time.Now(),
+	}, err
+}
+
+// failedQuery returns the result of a query that failed with err
+func failedQuery(query Query, err error) (*QueryResult, error) {
+	return &QueryResult{
+		QueryID:    query.GetQueryID(),
+		Success:    false,
+		Error:      err,
+		ExecutedAt: time.Now(),
+	}, err
+}
+
+// CommandValidationMiddleware rejects invalid commands before they reach
+// their handler
+func CommandValidationMiddleware() CommandMiddleware {
+	return func(next CommandHandlerFunc) CommandHandlerFunc {
+		return func(ctx context.Context, command Command) (*CommandResult, error) {
+			if err := command.Validate(); err != nil {
+				return failedCommand(command, shared.NewValidationError("INVALID_COMMAND", fmt.Errorf("command validation failed: %w", err)))
+			}
+			return next(ctx, command)
+		}
+	}
+}
+
+// QueryValidationMiddleware rejects invalid queries before they reach their
+// handler
+func QueryValidationMiddleware() QueryMiddleware {
+	return func(next QueryHandlerFunc) QueryHandlerFunc {
+		return func(ctx context.Context, query Query) (*QueryResult, error) {
+			if err := query.Validate(); err != nil {
+				return failedQuery(query, shared.NewValidationError("INVALID_QUERY", fmt.Errorf("query validation failed: %w", err)))
+			}
+			return next(ctx, query)
+		}
+	}
+}
+
+// CommandLoggingMiddleware logs every command with its outcome and latency
+func CommandLoggingMiddleware() CommandMiddleware {
+	return func(next CommandHandlerFunc) CommandHandlerFunc {
+		return func(ctx context.Context, command Command) (*CommandResult, error) {
+			start := time.Now()
+			log := logger.FromContext(ctx)
+			log.Info("Executing command",
+				zap.String("command_id", command.GetCommandID()),
+				zap.String("command_type", command.GetCommandType()))
+
+			result, err := next(ctx, command)
+			if err != nil {
+				log.Error("Command execution failed",
+					zap.String("command_id", command.GetCommandID()),
+					zap.String("command_type", command.GetCommandType()),
+					zap.Error(err))
+				return result, err
+			}
+
+			log.Info("Command executed successfully",
+				zap.String("command_id", command.GetCommandID()),
+				zap.String("command_type", command.GetCommandType()),
+				zap.Duration("duration", time.Since(start)))
+			return result, nil
+		}
+	}
+}
+
+// QueryLoggingMiddleware logs every query with its outcome and latency.
+// Queries are frequent, so successes are logged at debug level.
+func QueryLoggingMiddleware() QueryMiddleware {
+	return func(next QueryHandlerFunc) QueryHandlerFunc {
+		return func(ctx context.Context, query Query) (*QueryResult, error) {
+			start := time.Now()
+			log := logger.FromContext(ctx)
+			log.Debug("Executing query",
+				zap.String("query_id", query.GetQueryID()),
+				zap.String("query_type", query.GetQueryType()))
+
+			result, err := next(ctx, query)
+			if err != nil {
+				log.Error("Query execution failed",
+					zap.String("query_id", query.GetQueryID()),
+					zap.String("query_type", query.GetQueryType()),
+					zap.Error(err))
+				return result, err
+			}
+
+			log.Debug("Query executed successfully",
+				zap.String("query_id", query.GetQueryID()),
+				zap.String("query_type", query.GetQueryType()),
+				zap.Duration("duration", time.Since(start)),
+				zap.Bool("cache_hit", result != nil && result.CacheHit))
+			return result, nil
+		}
+	}
+}
+
+// TypeMetrics holds the counters of one command or query type
+type TypeMetrics struct {
+	Executed      int64         `json:"executed"`
+	Failed        int64         `json:"failed"`
+	TotalDuration time.Duration `json:"totalDuration"`
+}
+
+// BusMetrics collects per-type counters of the commands and queries executed
+// through the buses
+type BusMetrics struct {
+	mu       sync.Mutex
+	commands map[string]*TypeMetrics
+	queries  map[string]*TypeMetrics
+}
+
+// NewBusMetrics creates empty bus metrics
+func NewBusMetrics() *BusMetrics {
+	return &BusMetrics{
+		commands: make(map[string]*TypeMetrics),
+		queries:  make(map[string]*TypeMetrics),
+	}
+}
+
+// Commands returns a copy of the counters per command type
+func (m *BusMetrics) Commands() map[string]TypeMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return snapshot(m.commands)
+}
+
+// Queries returns a copy of the counters per query type
+func (m *BusMetrics) Queries() map[string]TypeMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return snapshot(m.queries)
+}
+
+func snapshot(counters map[string]*TypeMetrics) map[string]TypeMetrics {
+	result := make(map[string]TypeMetrics, len(counters))
+	for messageType, metrics := range counters {
+		result[messageType] = *metrics
+	}
+	return result
+}
+
+func (m *BusMetrics) record(counters map[string]*TypeMetrics, messageType string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics, ok := counters[messageType]
+	if !ok {
+		metrics = &TypeMetrics{}
+		counters[messageType] = metrics
+	}
+	metrics.Executed++
+	metrics.TotalDuration += duration
+	if failed {
+		metrics.Failed++
+	}
+}
+
+// CommandMetricsMiddleware records the executions of every command type
+func CommandMetricsMiddleware(metrics *BusMetrics) CommandMiddleware {
+	return func(next CommandHandlerFunc) CommandHandlerFunc {
+		return func(ctx context.Context, command Command) (*CommandResult, error) {
+			start := time.Now()
+			result, err := next(ctx, command)
+			metrics.record(metrics.commands, command.GetCommandType(), time.Since(start), err != nil)
+			return result, err
+		}
+	}
+}
+
+// QueryMetricsMiddleware records the executions of every query type
+func QueryMetricsMiddleware(metrics *BusMetrics) QueryMiddleware {
+	return func(next QueryHandlerFunc) QueryHandlerFunc {
+		return func(ctx context.Context, query Query) (*QueryResult, error) {
+			start := time.Now()
+			result, err := next(ctx, query)
+			metrics.record(metrics.queries, query.GetQueryType(), time.Since(start), err != nil)
+			return result, err
+		}
+	}
+}
+
+// Authorizer decides whether the caller in ctx may execute a command or query
+// of the given type. It returns a forbidden error to deny it.
+type Authorizer func(ctx context.Context, messageType string) error
+
+// CommandAuthorizationMiddleware rejects the commands the authorizer denies
+func CommandAuthorizationMiddleware(authorize Authorizer) CommandMiddleware {
+	return func(next CommandHandlerFunc) CommandHandlerFunc {
+		return func(ctx context.Context, command Command) (*CommandResult, error) {
+			if err := authorize(ctx, command.GetCommandType()); err != nil {
+				return failedCommand(command, err)
+			}
+			return next(ctx, command)
+		}
+	}
+}
+
+// QueryAuthorizationMiddleware rejects the queries the authorizer denies
+func QueryAuthorizationMiddleware(authorize Authorizer) QueryMiddleware {
+	return func(next QueryHandlerFunc) QueryHandlerFunc {
+		return func(ctx context.Context, query Query) (*QueryResult, error) {
+			if err := authorize(ctx, query.GetQueryType()); err != nil {
+				return failedQuery(query, err)
+			}
+			return next(ctx, query)
+		}
+	}
+}
+
+// retry runs attempt up to attempts times, waiting delay between attempts,
+// as long as it fails because a dependency is unavailable
+func retry(ctx context.Context, attempts int, delay time.Duration, attempt func() error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+		}
+		if err = attempt(); shared.ErrorKindOf(err) != shared.ErrorKindUnavailable {
+			return err
+		}
+	}
+	return err
+}
+
+// CommandRetryMiddleware retries commands that failed because a dependency
+// was unavailable. Only use it for buses whose command handlers are
+// idempotent, since a failed attempt may have partly taken effect.
+func CommandRetryMiddleware(attempts int, delay time.Duration) CommandMiddleware {
+	return func(next CommandHandlerFunc) CommandHandlerFunc {
+		return func(ctx context.Context, command Command) (result *CommandResult, err error) {
+			err = retry(ctx, attempts, delay, func() error {
+				result, err = next(ctx, command)
+				return err
+			})
+			return result, err
+		}
+	}
+}
+
+// QueryRetryMiddleware retries queries that failed because a dependency was
+// unavailable
+func QueryRetryMiddleware(attempts int, delay time.Duration) QueryMiddleware {
+	return func(next QueryHandlerFunc) QueryHandlerFunc {
+		return func(ctx context.Context, query Query) (result *QueryResult, err error) {
+			err = retry(ctx, attempts, delay, func() error {
+				result, err = next(ctx, query)
+				return err
+			})
+			return result, err
+		}
+	}
+}
+
+// TransactionRunner runs fn in a transaction that repositories join through
+// the context it passes to fn, committing when fn succeeds and rolling back
+// otherwise
+type TransactionRunner interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// CommandTransactionMiddleware runs every command handler in a transaction
+func CommandTransactionMiddleware(runner TransactionRunner) CommandMiddleware {
+	return func(next CommandHandlerFunc) CommandHandlerFunc {
+		return func(ctx context.Context, command Command) (result *CommandResult, err error) {
+			txErr := runner.InTransaction(ctx, func(ctx context.Context) error {
+				result, err = next(ctx, command)
+				return err
+			})
+			if err == nil && txErr != nil {
+				return failedCommand(command, txErr)
+			}
+			return result, err
+		}
+	}
+}
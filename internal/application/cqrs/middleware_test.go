@@ -0,0 +1,82 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/shared"
+)
+
+type testQuery struct {
+	*BaseQuery
+	valid bool
+}
+
+func (q *testQuery) Validate() error {
+	if !q.valid {
+		return errors.New("invalid")
+	}
+	return nil
+}
+
+type testCommand struct {
+	*BaseCommand
+}
+
+func (c *testCommand) Validate() error {
+	return nil
+}
+
+type flakyQueryHandler struct {
+	failures int
+	calls    int
+}
+
+func (h *flakyQueryHandler) GetQueryType() string {
+	return "test.query"
+}
+
+func (h *flakyQueryHandler) Handle(ctx context.Context, query Query) (*QueryResult, error) {
+	h.calls++
+	if h.calls <= h.failures {
+		return nil, shared.NewUnavailableError("DEPENDENCY_UNAVAILABLE", "database unavailable", nil)
+	}
+	return &QueryResult{QueryID: query.GetQueryID(), Success: true, Data: "ok"}, nil
+}
+
+func TestCQRSFacadeBuilder_ComposesQueryMiddlewares(t *testing.T) {
+	facade := NewCQRSFacadeBuilder(nil).WithQueryRetry(3, time.Millisecond).Build()
+	handler := &flakyQueryHandler{failures: 2}
+	require.NoError(t, facade.Manager().RegisterQueryHandler(handler))
+
+	// Unavailable dependencies are retried
+	result, err := facade.Query(context.Background(), &testQuery{BaseQuery: NewBaseQuery("test.query"), valid: true})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.Data)
+	assert.Equal(t, 3, handler.calls)
+
+	// Invalid queries never reach the handler
+	_, err = facade.Query(context.Background(), &testQuery{BaseQuery: NewBaseQuery("test.query")})
+	assert.Equal(t, shared.ErrorKindValidation, shared.ErrorKindOf(err))
+	assert.Equal(t, 3, handler.calls)
+
+	queries := facade.GetMetrics()["queries"].(map[string]TypeMetrics)
+	assert.Equal(t, int64(2), queries["test.query"].Executed)
+	assert.Equal(t, int64(1), queries["test.query"].Failed)
+}
+
+func TestCQRSFacadeBuilder_AuthorizesCommands(t *testing.T) {
+	denied := shared.NewForbiddenError("FORBIDDEN", "not allowed")
+	facade := NewCQRSFacadeBuilder(nil).
+		WithAuthorizer(func(ctx context.Context, messageType string) error { return denied }).
+		Build()
+
+	result, err := facade.Send(context.Background(), &testCommand{BaseCommand: NewBaseCommand("test.command")})
+	assert.ErrorIs(t, err, denied)
+	assert.False(t, result.Success)
+}
@@ -0,0 +1,101 @@
+package cqrs
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"notification/pkg/logger"
+)
+
+// VersionedProjection applies the events of each aggregate to a projection in
+// version order. An event whose version is not above the last one applied for
+// its aggregate arrived out of order or twice; it is rejected without reaching
+// the projection. Versions may skip, since not every change is published, and
+// events with version 0 are unversioned and always applied.
+type VersionedProjection struct {
+	EventProjection
+
+	mu       sync.Mutex
+	versions map[string]int64
+}
+
+// NewVersionedProjection wraps a projection with version ordering
+func NewVersionedProjection(projection EventProjection) *VersionedProjection {
+	return &VersionedProjection{
+		EventProjection: projection,
+		versions:        make(map[string]int64),
+	}
+}
+
+// Handle applies the event unless it is older than the aggregate's state in
+// the projection. Events are applied one at a time.
+func (p *VersionedProjection) Handle(ctx context.Context, event Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	version := event.GetVersion()
+	if version == 0 {
+		return p.EventProjection.Handle(ctx, event)
+	}
+
+	key := event.GetAggregateType() + ":" + event.GetAggregateID()
+	if applied := p.versions[key]; version <= applied {
+		logger.FromContext(ctx).Warn("Rejected out-of-order event",
+			zap.String("projection", p.GetProjectionName()),
+			zap.String("event_id", event.GetEventID()),
+			zap.String("event_type", event.GetEventType()),
+			zap.String("aggregate_id", event.GetAggregateID()),
+			zap.Int64("version", version),
+			zap.Int64("applied_version", applied))
+		return nil
+	}
+
+	if err := p.EventProjection.Handle(ctx, event); err != nil {
+		return err
+	}
+	p.versions[key] = version
+	return nil
+}
+
+// AppliedVersion returns the version of the last event applied for an
+// aggregate, or 0 when none was
+func (p *VersionedProjection) AppliedVersion(aggregateType, aggregateID string) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.versions[aggregateType+":"+aggregateID]
+}
+
+// Reset resets the projection and forgets the applied versions
+func (p *VersionedProjection) Reset(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.EventProjection.Reset(ctx); err != nil {
+		return err
+	}
+	p.versions = make(map[string]int64)
+	return nil
+}
+
+// Handler returns the handler subscribing the projection to an event type
+func (p *VersionedProjection) Handler(eventType string) EventHandler {
+	return &projectionEventHandler{projection: p, eventType: eventType}
+}
+
+// projectionEventHandler feeds the events of one type to a projection
+type projectionEventHandler struct {
+	projection *VersionedProjection
+	eventType  string
+}
+
+// Handle applies the event to the projection
+func (h *projectionEventHandler) Handle(ctx context.Context, event Event) error {
+	return h.projection.Handle(ctx, event)
+}
+
+// GetEventType returns the event type the handler is subscribed to
+func (h *projectionEventHandler) GetEventType() string {
+	return h.eventType
+}
@@ -0,0 +1,56 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingProjection struct {
+	recordingEventHandler
+}
+
+func (p *recordingProjection) GetProjectionName() string {
+	return "test"
+}
+
+func (p *recordingProjection) Reset(ctx context.Context) error {
+	p.versions = nil
+	return nil
+}
+
+func (p *recordingProjection) GetLastProcessedVersion(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (p *recordingProjection) SetLastProcessedVersion(ctx context.Context, version int64) error {
+	return nil
+}
+
+func TestVersionedProjection_RejectsOutOfOrderEvents(t *testing.T) {
+	inner := &recordingProjection{}
+	projection := NewVersionedProjection(inner)
+	bus := testEventBus()
+	require.NoError(t, bus.Subscribe("test.event", projection.Handler("test.event")))
+
+	for _, version := range []int64{1, 3, 2, 3, 5} {
+		require.NoError(t, bus.Publish(context.Background(), NewBaseEvent("test.event", "agg-1", "test", version, nil)))
+	}
+	require.NoError(t, bus.Publish(context.Background(), NewBaseEvent("test.event", "agg-2", "test", 1, nil)))
+
+	assert.Equal(t, []int64{1, 3, 5, 1}, inner.versions)
+	assert.Equal(t, int64(5), projection.AppliedVersion("test", "agg-1"))
+}
+
+func TestVersionedProjection_RetriesFailedEvent(t *testing.T) {
+	inner := &recordingProjection{recordingEventHandler{failures: 1}}
+	projection := NewVersionedProjection(inner)
+
+	event := NewBaseEvent("test.event", "agg-1", "test", 1, nil)
+	require.Error(t, projection.Handle(context.Background(), event))
+	require.NoError(t, projection.Handle(context.Background(), event))
+
+	assert.Equal(t, []int64{1}, inner.versions)
+}
@@ -88,6 +88,8 @@ func (c *UpdateTemplateCommand) Validate() error {
 type DeleteTemplateCommand struct {
 	*cqrs.BaseCommand
 	TemplateID string `json:"templateId"`
+	Force      bool   `json:"force,omitempty"`
+	ReassignTo string `json:"reassignTo,omitempty"`
 }
 
 // NewDeleteTemplateCommand creates a new delete template command
@@ -98,6 +100,18 @@ func NewDeleteTemplateCommand(templateID string) *DeleteTemplateCommand {
 	}
 }
 
+// WithForce allows deleting a template that is still referenced by channels
+func (c *DeleteTemplateCommand) WithForce(force bool) *DeleteTemplateCommand {
+	c.Force = force
+	return c
+}
+
+// WithReassignTo moves channels referencing the template to a replacement template
+func (c *DeleteTemplateCommand) WithReassignTo(templateID string) *DeleteTemplateCommand {
+	c.ReassignTo = templateID
+	return c
+}
+
 // Validate validates the delete template command
 func (c *DeleteTemplateCommand) Validate() error {
 	if c.TemplateID == "" {
@@ -61,12 +61,12 @@ type TemplateDeletedEvent struct {
 }
 
 // NewTemplateDeletedEvent creates a new template deleted event
-func NewTemplateDeletedEvent(templateID string) *TemplateDeletedEvent {
+func NewTemplateDeletedEvent(templateID string, version int64) *TemplateDeletedEvent {
 	baseEvent := cqrs.NewBaseEvent(
 		TemplateDeletedEventType,
 		templateID,
 		"template",
-		0, // Version is not critical for a deletion event
+		version,
 		struct{ TemplateID string }{templateID},
 	)
 	return &TemplateDeletedEvent{
@@ -7,6 +7,7 @@ import (
 	"notification/internal/application/cqrs"
 	"notification/internal/application/template/dtos"
 	"notification/internal/application/template/usecases"
+	"notification/internal/domain/shared"
 )
 
 // TemplateCommandHandlers handles template commands
@@ -72,13 +73,16 @@ func (h *TemplateCommandHandlers) HandleUpdateTemplate(ctx context.Context, cmd
 // HandleDeleteTemplate handles delete template command
 func (h *TemplateCommandHandlers) HandleDeleteTemplate(ctx context.Context, cmd *DeleteTemplateCommand) error {
 	// Execute use case
-	err := h.deleteTemplateUC.Execute(ctx, cmd.TemplateID)
+	version, err := h.deleteTemplateUC.ExecuteWithVersion(ctx, cmd.TemplateID, &dtos.DeleteTemplateRequest{
+		Force:      cmd.Force,
+		ReassignTo: cmd.ReassignTo,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete template: %w", err)
 	}
 
 	// Publish event
-	event := NewTemplateDeletedEvent(cmd.TemplateID)
+	event := NewTemplateDeletedEvent(cmd.TemplateID, version)
 	if err := h.eventBus.Publish(ctx, event); err != nil {
 		// Log error but don't fail the command
 		fmt.Printf("Failed to publish template deleted event: %v\n", err)
@@ -117,22 +121,24 @@ func (h *TemplateQueryHandlers) HandleGetTemplate(ctx context.Context, query *Ge
 // HandleListTemplates handles list templates query
 func (h *TemplateQueryHandlers) HandleListTemplates(ctx context.Context, query *ListTemplatesQuery) (*dtos.ListTemplatesResponse, error) {
 	// Convert CQRS query to use case request
-	request := &dtos.ListTemplatesRequest{}
-	
-	if query.ChannelType != "" {
-		// Note: You might need to convert string to ChannelType enum
-		// request.ChannelType = &query.ChannelType
+	request := &dtos.ListTemplatesRequest{
+		Tags:        query.Tags,
+		Owner:       query.Owner,
+		Team:        query.Team,
+		Environment: query.Environment,
 	}
-	
-	if len(query.Tags) > 0 {
-		request.Tags = query.Tags
+
+	if query.ChannelType != "" {
+		channelType, err := shared.NewChannelType(query.ChannelType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list templates: %w", err)
+		}
+		request.ChannelType = &channelType
 	}
-	
+
 	// Handle pagination
 	if query.Options != nil && query.Options.Pagination != nil {
-		// Convert offset/limit to page/size
-		page := (query.Options.Pagination.Offset / query.Options.Pagination.Limit) + 1
-		request.SkipCount = page
+		request.SkipCount = query.Options.Pagination.Offset
 		request.MaxResultCount = query.Options.Pagination.Limit
 	}
 
@@ -40,6 +40,9 @@ type ListTemplatesQuery struct {
 	*cqrs.BaseQuery
 	ChannelType string             `json:"channelType,omitempty"`
 	Tags        []string           `json:"tags,omitempty"`
+	Owner       string             `json:"owner,omitempty"`
+	Team        string             `json:"team,omitempty"`
+	Environment string             `json:"environment,omitempty"`
 	Options     *cqrs.QueryOptions `json:"options,omitempty"`
 }
 
@@ -63,6 +66,14 @@ func (q *ListTemplatesQuery) WithTags(tags []string) *ListTemplatesQuery {
 	return q
 }
 
+// WithOwnership sets the owner, team and environment filters
+func (q *ListTemplatesQuery) WithOwnership(owner, team, environment string) *ListTemplatesQuery {
+	q.Owner = owner
+	q.Team = team
+	q.Environment = environment
+	return q
+}
+
 // WithPagination sets pagination options
 func (q *ListTemplatesQuery) WithPagination(offset, limit int) *ListTemplatesQuery {
 	q.Options.Pagination = &cqrs.Pagination{
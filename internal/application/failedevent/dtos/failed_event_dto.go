@@ -0,0 +1,81 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"notification/internal/domain/failedevent"
+)
+
+// ListFailedEventsRequest is the DTO for listing failed events.
+type ListFailedEventsRequest struct {
+	// Status is failed or replayed, empty for both
+	Status         string `form:"status" json:"status"`
+	EventType      string `form:"eventType" json:"eventType"`
+	SkipCount      int    `form:"skipCount" json:"skipCount"`
+	MaxResultCount int    `form:"maxResultCount" json:"maxResultCount"`
+}
+
+// ReplayFailedEventsRequest is the DTO for replaying the pending failed events.
+type ReplayFailedEventsRequest struct {
+	// EventType limits the replay to one event type, empty for every type
+	EventType      string `json:"eventType"`
+	MaxResultCount int    `json:"maxResultCount"`
+}
+
+// FailedEventResponse is the DTO for a failed event response.
+type FailedEventResponse struct {
+	ID            string          `json:"id"`
+	EventID       string          `json:"eventId"`
+	EventType     string          `json:"eventType"`
+	AggregateID   string          `json:"aggregateId"`
+	AggregateType string          `json:"aggregateType"`
+	Version       int64           `json:"version"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Handler       string          `json:"handler"`
+	Attempts      int             `json:"attempts"`
+	Error         string          `json:"error"`
+	Status        string          `json:"status"`
+	ReplayCount   int             `json:"replayCount"`
+	FailedAt      int64           `json:"failedAt"`
+	ReplayedAt    *int64          `json:"replayedAt,omitempty"`
+}
+
+// ListFailedEventsResponse is the DTO for a failed event list response.
+type ListFailedEventsResponse struct {
+	Items          []*FailedEventResponse `json:"items"`
+	SkipCount      int                    `json:"skipCount"`
+	MaxResultCount int                    `json:"maxResultCount"`
+	TotalCount     int                    `json:"totalCount"`
+	HasMore        bool                   `json:"hasMore"`
+}
+
+// ReplayFailedEventsResponse is the DTO for the outcome of a bulk replay.
+type ReplayFailedEventsResponse struct {
+	Replayed int                    `json:"replayed"`
+	Failed   int                    `json:"failed"`
+	Items    []*FailedEventResponse `json:"items"`
+}
+
+// FromFailedEvent converts a failed event to its response DTO.
+func FromFailedEvent(failed *failedevent.FailedEvent) *FailedEventResponse {
+	response := &FailedEventResponse{
+		ID:            failed.ID,
+		EventID:       failed.EventID,
+		EventType:     failed.EventType,
+		AggregateID:   failed.AggregateID,
+		AggregateType: failed.AggregateType,
+		Version:       failed.Version,
+		Payload:       failed.Payload,
+		Handler:       failed.Handler,
+		Attempts:      failed.Attempts,
+		Error:         failed.Error,
+		Status:        string(failed.Status),
+		ReplayCount:   failed.ReplayCount,
+		FailedAt:      failed.FailedAt.UnixMilli(),
+	}
+	if failed.ReplayedAt != nil {
+		replayedAt := failed.ReplayedAt.UnixMilli()
+		response.ReplayedAt = &replayedAt
+	}
+	return response
+}
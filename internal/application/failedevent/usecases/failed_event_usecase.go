@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"notification/internal/application/failedevent/dtos"
+	"notification/internal/domain/failedevent"
+	"notification/internal/domain/shared"
+)
+
+// EventReplayer hands a failed event to the handler that failed on it.
+type EventReplayer interface {
+	Replay(ctx context.Context, failed *failedevent.FailedEvent) error
+}
+
+// GetFailedEventUseCase is the use case for querying failed events.
+type GetFailedEventUseCase struct {
+	failedEventRepo failedevent.FailedEventRepository
+}
+
+// NewGetFailedEventUseCase creates a use case instance.
+func NewGetFailedEventUseCase(failedEventRepo failedevent.FailedEventRepository) *GetFailedEventUseCase {
+	return &GetFailedEventUseCase{
+		failedEventRepo: failedEventRepo,
+	}
+}
+
+// Execute gets a failed event.
+func (uc *GetFailedEventUseCase) Execute(ctx context.Context, id string) (*dtos.FailedEventResponse, error) {
+	if id == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed event ID is required"))
+	}
+
+	failed, err := uc.failedEventRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtos.FromFailedEvent(failed), nil
+}
+
+// List lists failed events, most recent first.
+func (uc *GetFailedEventUseCase) List(ctx context.Context, request *dtos.ListFailedEventsRequest) (*dtos.ListFailedEventsResponse, error) {
+	// 1. Validate the filter
+	status := failedevent.Status(request.Status)
+	switch status {
+	case "", failedevent.StatusFailed, failedevent.StatusReplayed:
+	default:
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid status: %s", request.Status))
+	}
+
+	// 2. Create pagination parameters
+	maxResultCount := request.MaxResultCount
+	if maxResultCount <= 0 {
+		maxResultCount = 20
+	}
+	pagination, err := shared.NewPagination(request.SkipCount, maxResultCount)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid pagination: %w", err))
+	}
+
+	// 3. Query the failed events
+	result, err := uc.failedEventRepo.FindAll(ctx, failedevent.Filter{Status: status, EventType: request.EventType}, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Convert to response DTO
+	items := make([]*dtos.FailedEventResponse, 0, len(result.Items))
+	for _, failed := range result.Items {
+		items = append(items, dtos.FromFailedEvent(failed))
+	}
+
+	return &dtos.ListFailedEventsResponse{
+		Items:          items,
+		SkipCount:      result.SkipCount,
+		MaxResultCount: result.MaxResultCount,
+		TotalCount:     result.TotalCount,
+		HasMore:        result.HasMore,
+	}, nil
+}
+
+// ReplayFailedEventUseCase is the use case for replaying failed events once
+// the downstream their handler depends on recovered.
+type ReplayFailedEventUseCase struct {
+	failedEventRepo failedevent.FailedEventRepository
+	replayer        EventReplayer
+}
+
+// NewReplayFailedEventUseCase creates a use case instance.
+func NewReplayFailedEventUseCase(failedEventRepo failedevent.FailedEventRepository, replayer EventReplayer) *ReplayFailedEventUseCase {
+	return &ReplayFailedEventUseCase{
+		failedEventRepo: failedEventRepo,
+		replayer:        replayer,
+	}
+}
+
+// Execute replays one failed event. A replay that fails again is recorded on
+// the event and reported as an unavailable error.
+func (uc *ReplayFailedEventUseCase) Execute(ctx context.Context, id string) (*dtos.FailedEventResponse, error) {
+	// 1. Validate input parameters
+	if id == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed event ID is required"))
+	}
+
+	// 2. Query the failed event
+	failed, err := uc.failedEventRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Replayed events are not handled twice
+	if failed.Status == failedevent.StatusReplayed {
+		return nil, shared.NewConflictError("EVENT_ALREADY_REPLAYED", "failed event was already replayed")
+	}
+
+	// 4. Replay and record the outcome
+	if err := uc.replay(ctx, failed); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromFailedEvent(failed), nil
+}
+
+// ReplayPending replays the pending failed events, oldest first, and reports
+// how many succeeded.
+func (uc *ReplayFailedEventUseCase) ReplayPending(ctx context.Context, request *dtos.ReplayFailedEventsRequest) (*dtos.ReplayFailedEventsResponse, error) {
+	// 1. Create pagination parameters
+	maxResultCount := request.MaxResultCount
+	if maxResultCount <= 0 {
+		maxResultCount = 100
+	}
+	pagination, err := shared.NewPagination(0, maxResultCount)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid pagination: %w", err))
+	}
+
+	// 2. Query the pending failed events
+	result, err := uc.failedEventRepo.FindAll(ctx, failedevent.Filter{Status: failedevent.StatusFailed, EventType: request.EventType}, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Replay them in the order they failed
+	response := &dtos.ReplayFailedEventsResponse{Items: make([]*dtos.FailedEventResponse, 0, len(result.Items))}
+	for i := len(result.Items) - 1; i >= 0; i-- {
+		failed := result.Items[i]
+		if err := uc.replay(ctx, failed); err != nil {
+			// Unclassified errors mean the outcome could not be stored
+			if shared.ErrorKindOf(err) == shared.ErrorKindInternal {
+				return nil, err
+			}
+			response.Failed++
+		} else {
+			response.Replayed++
+		}
+		response.Items = append(response.Items, dtos.FromFailedEvent(failed))
+	}
+
+	return response, nil
+}
+
+// replay hands the event to its handler and stores the outcome.
+func (uc *ReplayFailedEventUseCase) replay(ctx context.Context, failed *failedevent.FailedEvent) error {
+	replayErr := uc.replayer.Replay(ctx, failed)
+	if shared.IsConflict(replayErr) {
+		return replayErr
+	}
+
+	failed.ReplayCount++
+	if replayErr == nil {
+		now := time.Now()
+		failed.Status = failedevent.StatusReplayed
+		failed.ReplayedAt = &now
+	} else {
+		failed.Error = replayErr.Error()
+	}
+
+	if err := uc.failedEventRepo.Update(ctx, failed); err != nil {
+		return fmt.Errorf("failed to save replay outcome: %w", err)
+	}
+
+	if replayErr != nil {
+		return shared.NewUnavailableError("REPLAY_FAILED", "event handler failed again", replayErr)
+	}
+	return nil
+}
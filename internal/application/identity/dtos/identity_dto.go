@@ -0,0 +1,60 @@
+package dtos
+
+import (
+	"notification/internal/domain/identity"
+)
+
+// CreateSenderIdentityRequest is the DTO for creating a sender identity.
+type CreateSenderIdentityRequest struct {
+	// TenantID is the tenant sending from the identity, the default tenant when empty
+	TenantID string `json:"tenantId"`
+	// Value is the address, verified by a confirmation email, or the domain,
+	// verified by a DNS TXT record
+	Value string `json:"value" binding:"required"`
+}
+
+// VerificationRecordResponse is the DNS TXT record verifying a domain identity.
+type VerificationRecordResponse struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SenderIdentityResponse is the DTO for a sender identity response.
+type SenderIdentityResponse struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenantId"`
+	Kind     string `json:"kind"`
+	Value    string `json:"value"`
+	Status   string `json:"status"`
+	// VerificationRecord is the record to publish to verify a domain identity
+	VerificationRecord *VerificationRecordResponse `json:"verificationRecord,omitempty"`
+	CreatedAt          int64                       `json:"createdAt"`
+	VerifiedAt         *int64                      `json:"verifiedAt,omitempty"`
+}
+
+// ListSenderIdentitiesResponse is the DTO for a sender identity list response.
+type ListSenderIdentitiesResponse struct {
+	Items []*SenderIdentityResponse `json:"items"`
+}
+
+// FromSenderIdentity converts a sender identity to its response DTO.
+func FromSenderIdentity(i *identity.SenderIdentity) *SenderIdentityResponse {
+	response := &SenderIdentityResponse{
+		ID:        i.ID,
+		TenantID:  i.TenantID,
+		Kind:      string(i.Kind),
+		Value:     i.Value,
+		Status:    string(i.Status),
+		CreatedAt: i.CreatedAt.UnixMilli(),
+	}
+	if i.Kind == identity.KindDomain {
+		name, value := i.VerificationRecord()
+		response.VerificationRecord = &VerificationRecordResponse{Type: "TXT", Name: name, Value: value}
+	}
+	if i.VerifiedAt != nil {
+		verifiedAt := i.VerifiedAt.UnixMilli()
+		response.VerifiedAt = &verifiedAt
+	}
+	return response
+}
@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"notification/internal/application/identity/dtos"
+	"notification/internal/domain/identity"
+	"notification/internal/domain/quota"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// GetSenderIdentityUseCase is the use case for querying the sender identities.
+type GetSenderIdentityUseCase struct {
+	identityRepo identity.SenderIdentityRepository
+}
+
+// NewGetSenderIdentityUseCase creates a use case instance.
+func NewGetSenderIdentityUseCase(identityRepo identity.SenderIdentityRepository) *GetSenderIdentityUseCase {
+	return &GetSenderIdentityUseCase{
+		identityRepo: identityRepo,
+	}
+}
+
+// Get gets a sender identity by ID.
+func (uc *GetSenderIdentityUseCase) Get(ctx context.Context, id string) (*dtos.SenderIdentityResponse, error) {
+	i, err := uc.identityRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtos.FromSenderIdentity(i), nil
+}
+
+// List lists the sender identities of a tenant, of every tenant when tenantID is empty.
+func (uc *GetSenderIdentityUseCase) List(ctx context.Context, tenantID string) (*dtos.ListSenderIdentitiesResponse, error) {
+	identities, err := uc.identityRepo.FindByTenant(ctx, strings.TrimSpace(tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dtos.SenderIdentityResponse, 0, len(identities))
+	for _, i := range identities {
+		items = append(items, dtos.FromSenderIdentity(i))
+	}
+
+	return &dtos.ListSenderIdentitiesResponse{Items: items}, nil
+}
+
+// UpdateSenderIdentityUseCase is the use case for managing and verifying the
+// sender identities.
+type UpdateSenderIdentityUseCase struct {
+	identityRepo identity.SenderIdentityRepository
+	resolver     services.TXTResolver
+	// confirmation emails the confirmation links of the addresses; without
+	// one, addresses are verified through the domain holding them
+	confirmation *services.IdentityConfirmation
+}
+
+// NewUpdateSenderIdentityUseCase creates a use case instance.
+func NewUpdateSenderIdentityUseCase(
+	identityRepo identity.SenderIdentityRepository,
+	resolver services.TXTResolver,
+	confirmation *services.IdentityConfirmation,
+) *UpdateSenderIdentityUseCase {
+	return &UpdateSenderIdentityUseCase{
+		identityRepo: identityRepo,
+		resolver:     resolver,
+		confirmation: confirmation,
+	}
+}
+
+// Create creates a pending sender identity, emailing the confirmation link of
+// an address.
+func (uc *UpdateSenderIdentityUseCase) Create(ctx context.Context, request *dtos.CreateSenderIdentityRequest) (*dtos.SenderIdentityResponse, error) {
+	// 1. Validate input parameters
+	tenantID := request.TenantID
+	if strings.TrimSpace(tenantID) == "" {
+		tenantID = quota.DefaultTenantID
+	}
+	i, err := identity.NewSenderIdentity(tenantID, request.Value)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 2. Check the tenant has no such identity yet
+	identities, err := uc.identityRepo.FindByTenant(ctx, i.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range identities {
+		if existing.Value == i.Value {
+			return nil, shared.NewConflictError("SENDER_IDENTITY_CONFLICT",
+				fmt.Sprintf("tenant '%s' already has sender identity '%s'", i.TenantID, i.Value))
+		}
+	}
+
+	// 3. Persist the identity
+	if err := uc.identityRepo.Save(ctx, i); err != nil {
+		return nil, err
+	}
+
+	// 4. Email the confirmation link of an address
+	if i.Kind == identity.KindAddress && uc.confirmation != nil {
+		if err := uc.confirmation.Send(ctx, i); err != nil {
+			return nil, err
+		}
+	}
+
+	return dtos.FromSenderIdentity(i), nil
+}
+
+// Verify verifies a pending sender identity: a domain by looking up its
+// verification record, an address by emailing its confirmation link again or,
+// without confirmation emails, by looking up the record of its domain.
+func (uc *UpdateSenderIdentityUseCase) Verify(ctx context.Context, id string) (*dtos.SenderIdentityResponse, error) {
+	// 1. Query the identity
+	i, err := uc.identityRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if i.IsVerified() {
+		return dtos.FromSenderIdentity(i), nil
+	}
+
+	// 2. Email the confirmation link of an address again
+	if i.Kind == identity.KindAddress && uc.confirmation != nil {
+		if err := uc.confirmation.Send(ctx, i); err != nil {
+			return nil, err
+		}
+		return dtos.FromSenderIdentity(i), nil
+	}
+
+	// 3. Look up the verification record
+	name, value := i.VerificationRecord()
+	if i.Kind == identity.KindAddress {
+		name = identity.VerificationRecordPrefix + "." + identity.DomainOf(i.Value)
+	}
+	records, err := uc.resolver.LookupTXT(ctx, name)
+	var dnsErr *net.DNSError
+	if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		return nil, fmt.Errorf("failed to look up verification record %s: %w", name, err)
+	}
+	found := false
+	for _, record := range records {
+		if strings.TrimSpace(record) == value {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, shared.NewValidationError("SENDER_IDENTITY_NOT_VERIFIED",
+			fmt.Errorf("TXT record %s does not hold %s", name, value))
+	}
+
+	// 4. Persist the verification
+	i.MarkVerified()
+	if err := uc.identityRepo.Update(ctx, i); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromSenderIdentity(i), nil
+}
+
+// Confirm verifies an address identity with the token of its confirmation email.
+func (uc *UpdateSenderIdentityUseCase) Confirm(ctx context.Context, id, token string) (*dtos.SenderIdentityResponse, error) {
+	// 1. Query the identity
+	i, err := uc.identityRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Check the token
+	if err := i.Confirm(token); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 3. Persist the verification
+	if err := uc.identityRepo.Update(ctx, i); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromSenderIdentity(i), nil
+}
+
+// Delete deletes a sender identity; channels sending from it are refused
+// afterwards when the identities are enforced.
+func (uc *UpdateSenderIdentityUseCase) Delete(ctx context.Context, id string) error {
+	return uc.identityRepo.Delete(ctx, id)
+}
@@ -0,0 +1,75 @@
+package dtos
+
+import (
+	"notification/internal/domain/inapp"
+)
+
+// ListInAppNotificationsRequest is the DTO for listing the notifications of a user.
+type ListInAppNotificationsRequest struct {
+	UnreadOnly     bool   `form:"unreadOnly" json:"unreadOnly"`
+	Category       string `form:"category" json:"category"`
+	SkipCount      int    `form:"skipCount" json:"skipCount"`
+	MaxResultCount int    `form:"maxResultCount" json:"maxResultCount"`
+}
+
+// MarkInAppNotificationsReadRequest is the DTO for marking notifications read.
+type MarkInAppNotificationsReadRequest struct {
+	// IDs are the notifications to mark read, empty for every unread one
+	IDs []string `json:"ids"`
+}
+
+// InAppNotificationResponse is the DTO for an in-app notification response.
+type InAppNotificationResponse struct {
+	ID            string `json:"id"`
+	UserID        string `json:"userId"`
+	ChannelID     string `json:"channelId"`
+	Category      string `json:"category,omitempty"`
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	CorrelationID string `json:"correlationId,omitempty"`
+	Read          bool   `json:"read"`
+	CreatedAt     int64  `json:"createdAt"`
+	ReadAt        *int64 `json:"readAt,omitempty"`
+}
+
+// ListInAppNotificationsResponse is the DTO for an in-app notification list response.
+type ListInAppNotificationsResponse struct {
+	Items          []*InAppNotificationResponse `json:"items"`
+	SkipCount      int                          `json:"skipCount"`
+	MaxResultCount int                          `json:"maxResultCount"`
+	TotalCount     int                          `json:"totalCount"`
+	HasMore        bool                         `json:"hasMore"`
+	UnreadCount    int                          `json:"unreadCount"`
+}
+
+// UnreadCountResponse is the DTO for the unread count of a user.
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unreadCount"`
+}
+
+// MarkInAppNotificationsReadResponse is the DTO for the outcome of marking notifications read.
+type MarkInAppNotificationsReadResponse struct {
+	// Marked is the number of notifications that were unread
+	Marked      int `json:"marked"`
+	UnreadCount int `json:"unreadCount"`
+}
+
+// FromInAppNotification converts an in-app notification to its response DTO.
+func FromInAppNotification(notification *inapp.Notification) *InAppNotificationResponse {
+	response := &InAppNotificationResponse{
+		ID:            notification.ID,
+		UserID:        notification.UserID,
+		ChannelID:     notification.ChannelID,
+		Category:      notification.Category,
+		Title:         notification.Title,
+		Content:       notification.Content,
+		CorrelationID: notification.CorrelationID,
+		Read:          notification.IsRead(),
+		CreatedAt:     notification.CreatedAt.UnixMilli(),
+	}
+	if notification.ReadAt != nil {
+		readAt := notification.ReadAt.UnixMilli()
+		response.ReadAt = &readAt
+	}
+	return response
+}
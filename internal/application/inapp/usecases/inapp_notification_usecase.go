@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"notification/internal/application/inapp/dtos"
+	"notification/internal/domain/inapp"
+	"notification/internal/domain/shared"
+)
+
+// GetInAppNotificationUseCase is the use case for querying the notification
+// center of a user.
+type GetInAppNotificationUseCase struct {
+	notificationRepo inapp.NotificationRepository
+}
+
+// NewGetInAppNotificationUseCase creates a use case instance.
+func NewGetInAppNotificationUseCase(notificationRepo inapp.NotificationRepository) *GetInAppNotificationUseCase {
+	return &GetInAppNotificationUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// List lists the notifications of a user, most recent first, with the unread
+// count of the user.
+func (uc *GetInAppNotificationUseCase) List(ctx context.Context, userID string, request *dtos.ListInAppNotificationsRequest) (*dtos.ListInAppNotificationsResponse, error) {
+	// 1. Validate input parameters
+	if userID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID is required"))
+	}
+
+	// 2. Create pagination parameters
+	maxResultCount := request.MaxResultCount
+	if maxResultCount <= 0 {
+		maxResultCount = 20
+	}
+	pagination, err := shared.NewPagination(request.SkipCount, maxResultCount)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid pagination: %w", err))
+	}
+
+	// 3. Query the notifications
+	filter := inapp.Filter{UserID: userID, UnreadOnly: request.UnreadOnly, Category: request.Category}
+	result, err := uc.notificationRepo.FindAll(ctx, filter, pagination)
+	if err != nil {
+		return nil, err
+	}
+	unreadCount, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Convert to response DTO
+	items := make([]*dtos.InAppNotificationResponse, 0, len(result.Items))
+	for _, notification := range result.Items {
+		items = append(items, dtos.FromInAppNotification(notification))
+	}
+
+	return &dtos.ListInAppNotificationsResponse{
+		Items:          items,
+		SkipCount:      result.SkipCount,
+		MaxResultCount: result.MaxResultCount,
+		TotalCount:     result.TotalCount,
+		HasMore:        result.HasMore,
+		UnreadCount:    unreadCount,
+	}, nil
+}
+
+// CountUnread counts the unread notifications of a user.
+func (uc *GetInAppNotificationUseCase) CountUnread(ctx context.Context, userID string) (*dtos.UnreadCountResponse, error) {
+	if userID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID is required"))
+	}
+
+	unreadCount, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.UnreadCountResponse{UnreadCount: unreadCount}, nil
+}
+
+// UpdateInAppNotificationUseCase is the use case for the users reading and
+// deleting their notifications.
+type UpdateInAppNotificationUseCase struct {
+	notificationRepo inapp.NotificationRepository
+}
+
+// NewUpdateInAppNotificationUseCase creates a use case instance.
+func NewUpdateInAppNotificationUseCase(notificationRepo inapp.NotificationRepository) *UpdateInAppNotificationUseCase {
+	return &UpdateInAppNotificationUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// MarkRead marks a notification of a user read. A notification read before
+// keeps the time it was first read.
+func (uc *UpdateInAppNotificationUseCase) MarkRead(ctx context.Context, userID, id string) (*dtos.InAppNotificationResponse, error) {
+	// 1. Validate input parameters
+	if userID == "" || id == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID and notification ID are required"))
+	}
+
+	// 2. Query the notification of the user
+	notification, err := uc.notificationRepo.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Mark it read
+	if !notification.IsRead() {
+		now := time.Now()
+		if _, err := uc.notificationRepo.MarkRead(ctx, userID, []string{id}, now); err != nil {
+			return nil, err
+		}
+		notification.ReadAt = &now
+	}
+
+	return dtos.FromInAppNotification(notification), nil
+}
+
+// MarkAllRead marks the given notifications of a user read, or every unread
+// one when the request names none.
+func (uc *UpdateInAppNotificationUseCase) MarkAllRead(ctx context.Context, userID string, request *dtos.MarkInAppNotificationsReadRequest) (*dtos.MarkInAppNotificationsReadResponse, error) {
+	if userID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID is required"))
+	}
+
+	marked, err := uc.notificationRepo.MarkRead(ctx, userID, request.IDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	unreadCount, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.MarkInAppNotificationsReadResponse{
+		Marked:      marked,
+		UnreadCount: unreadCount,
+	}, nil
+}
+
+// Delete deletes a notification of a user and returns the unread count left.
+func (uc *UpdateInAppNotificationUseCase) Delete(ctx context.Context, userID, id string) (*dtos.UnreadCountResponse, error) {
+	if userID == "" || id == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID and notification ID are required"))
+	}
+
+	if err := uc.notificationRepo.Delete(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	unreadCount, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.UnreadCountResponse{UnreadCount: unreadCount}, nil
+}
@@ -5,22 +5,66 @@ import (
 	"notification/internal/domain/shared"
 )
 
-// SendMessageRequest represents the request to send a message.
+// MaxRecipients is the maximum number of recipients of a single message.
+const MaxRecipients = 1000
+
+// MaxCorrelationIDLength is the maximum length of a message correlation ID.
+const MaxCorrelationIDLength = 255
+
+// MaxTenantIDLength is the maximum length of a message tenant ID.
+const MaxTenantIDLength = 255
+
+// SendMessageRequest represents the request to send a message. The channels
+// are the ones listed in ChannelIDs together with the ones matching
+// ChannelTags and the members of ChannelGroupIDs; at least one of the three
+// is required.
 type SendMessageRequest struct {
-	ChannelIDs       []string                  `json:"channelIds" validate:"required,min=1"`
+	ChannelIDs []string `json:"channelIds,omitempty"`
+	// ChannelTags targets the enabled channels carrying every one of the tags
+	// and the template's channel type, resolved when the message is sent.
+	ChannelTags []string `json:"channelTags,omitempty"`
+	// ChannelGroupIDs targets the enabled members of the channel groups, which
+	// may mix channel types as each channel renders its own template.
+	ChannelGroupIDs  []string                  `json:"channelGroupIds,omitempty"`
 	TemplateID       string                    `json:"templateId" validate:"required"`
-	Recipients       []map[string]interface{}  `json:"recipients" validate:"required,min=1"`
+	Recipients       []map[string]interface{}  `json:"recipients" validate:"required,min=1,max=1000"`
 	Variables        map[string]interface{}    `json:"variables,omitempty"`
 	ChannelOverrides *message.ChannelOverrides `json:"channelOverrides,omitempty"`
 	Settings         *shared.CommonSettings    `json:"settings,omitempty"`
+	// CorrelationID ties the message to a business transaction. It is stored
+	// with the message, logged and passed on to the providers.
+	CorrelationID string `json:"correlationId,omitempty" validate:"omitempty,max=255"`
+	// TenantID names the tenant whose send quota the message counts against,
+	// the default tenant when empty.
+	TenantID string `json:"tenantId,omitempty" validate:"omitempty,max=255"`
+	// Category is the topic of the message, such as billing or security. The
+	// message only reaches the recipients receiving the category and not
+	// opting out of it.
+	Category string `json:"category,omitempty" validate:"omitempty,max=100"`
+	// MessageClass is transactional or marketing, marketing when empty.
+	// Marketing messages honor every opt-out, subscription and quiet hours
+	// of the recipients and are rejected over the send quotas; transactional
+	// ones reach the recipients opting out or in their quiet hours, and are
+	// counted against the quotas without being rejected.
+	MessageClass string `json:"messageClass,omitempty" validate:"omitempty,oneof=transactional marketing"`
+	// Strict fails rendering with RENDER_ERROR when a template variable has
+	// no value, instead of rendering it empty.
+	Strict bool `json:"strict,omitempty"`
+	// Async answers with the pending message as soon as it is accepted and
+	// delivers it in the background; the delivery is followed on the
+	// message.progress events or by getting the message.
+	Async bool `json:"async,omitempty"`
+	// AllowProd lets a deployment below prod send through prod channels and
+	// templates, which it refuses otherwise.
+	AllowProd bool `json:"allowProd,omitempty"`
 }
 
 // ListMessagesRequest represents the request to list messages.
 type ListMessagesRequest struct {
-	ChannelID      string `json:"channelId,omitempty"`
-	Status         string `json:"status,omitempty"`
-	SkipCount      int    `json:"skipCount,omitempty"`
-	MaxResultCount int    `json:"maxResultCount,omitempty"`
+	ChannelID      string `form:"channelId" json:"channelId,omitempty"`
+	Status         string `form:"status" json:"status,omitempty"`
+	SkipCount      int    `form:"skipCount" json:"skipCount,omitempty"`
+	MaxResultCount int    `form:"maxResultCount" json:"maxResultCount,omitempty"`
 }
 
 // ListMessagesResponse represents the response for listing messages.
@@ -32,6 +76,19 @@ type ListMessagesResponse struct {
 	HasMore        bool               `json:"hasMore"`
 }
 
+// ExportMessagesRequest represents the request to export messages. Empty
+// fields match every message.
+type ExportMessagesRequest struct {
+	// ChannelID matches the messages with a result for the channel
+	ChannelID string `form:"channelId" json:"channelId,omitempty"`
+	Status    string `form:"status" json:"status,omitempty"`
+	TenantID  string `form:"tenantId" json:"tenantId,omitempty"`
+	// From and To bound the message creation time in Unix milliseconds,
+	// From inclusive and To exclusive
+	From int64 `form:"from" json:"from,omitempty"`
+	To   int64 `form:"to" json:"to,omitempty"`
+}
+
 // MessageResponse represents the response for a message.
 type MessageResponse struct {
 	ID               string                    `json:"id"`
@@ -40,6 +97,9 @@ type MessageResponse struct {
 	Recipients       []map[string]interface{}  `json:"recipients"`
 	Variables        map[string]interface{}    `json:"variables,omitempty"`
 	ChannelOverrides *message.ChannelOverrides `json:"channelOverrides,omitempty"`
+	CorrelationID    string                    `json:"correlationId,omitempty"`
+	TenantID         string                    `json:"tenantId,omitempty"`
+	MessageClass     message.MessageClass      `json:"messageClass,omitempty"`
 	Status           message.MessageStatus     `json:"status"`
 	Results          []*MessageResultResponse  `json:"results,omitempty"`
 	Settings         *shared.CommonSettings    `json:"settings,omitempty"`
@@ -49,10 +109,174 @@ type MessageResponse struct {
 
 // MessageResultResponse represents the response for a message result.
 type MessageResultResponse struct {
-	Recipient string                      `json:"recipient"`
-	Status    message.MessageResultStatus `json:"status"`
-	Error     string                      `json:"error,omitempty"`
-	SentAt    *int64                      `json:"sentAt,omitempty"`
+	ChannelID     string                      `json:"channelId"`
+	Recipient     string                      `json:"recipient"`
+	Status        message.MessageResultStatus `json:"status"`
+	Error         string                      `json:"error,omitempty"`
+	ErrorCategory message.ErrorCategory       `json:"errorCategory,omitempty"`
+	SentAt        *int64                      `json:"sentAt,omitempty"`
+	Cost          float64                     `json:"cost,omitempty"`
+	Recipients    []*RecipientResultResponse  `json:"recipients,omitempty"`
+	// FallbackFrom is the channel whose failed delivery led to this send
+	FallbackFrom string `json:"fallbackFrom,omitempty"`
+	// PolicyDecisions are the decisions of the pre-send policy hooks on the send
+	PolicyDecisions []*message.PolicyDecision `json:"policyDecisions,omitempty"`
+	// AttachmentScans are the virus scans of the attachments of the send
+	AttachmentScans []*message.AttachmentScan `json:"attachmentScans,omitempty"`
+}
+
+// RecipientResultResponse represents the response for the result of one recipient.
+type RecipientResultResponse struct {
+	Target            string                      `json:"target"`
+	Status            message.MessageResultStatus `json:"status"`
+	ProviderMessageID string                      `json:"providerMessageId,omitempty"`
+	Error             string                      `json:"error,omitempty"`
+	ErrorCategory     message.ErrorCategory       `json:"errorCategory,omitempty"`
+	SentAt            *int64                      `json:"sentAt,omitempty"`
+	// Acknowledgment holds the keys the recipient pressed to acknowledge a voice call
+	Acknowledgment string `json:"acknowledgment,omitempty"`
+	AcknowledgedAt *int64 `json:"acknowledgedAt,omitempty"`
+	// Details describe what the send created at the provider, e.g. the key of a Jira issue
+	Details map[string]string `json:"details,omitempty"`
+	// SuppressionReason tells which preference of a suppressed recipient stopped the send
+	SuppressionReason string `json:"suppressionReason,omitempty"`
+}
+
+// MessageLinksResponse represents the short links of a message and their clicks.
+type MessageLinksResponse struct {
+	MessageID   string               `json:"messageId"`
+	TotalClicks int64                `json:"totalClicks"`
+	Links       []*ShortLinkResponse `json:"links"`
+}
+
+// ShortLinkResponse represents a short link of a message.
+type ShortLinkResponse struct {
+	Code          string `json:"code"`
+	TargetURL     string `json:"targetUrl"`
+	ChannelID     string `json:"channelId,omitempty"`
+	Clicks        int64  `json:"clicks"`
+	CreatedAt     int64  `json:"createdAt"`
+	LastClickedAt *int64 `json:"lastClickedAt,omitempty"`
+}
+
+// MessageAcknowledgmentsResponse represents the acknowledgments of a message
+// by its recipients.
+type MessageAcknowledgmentsResponse struct {
+	MessageID       string                    `json:"messageId"`
+	Acknowledged    int                       `json:"acknowledged"`
+	Pending         int                       `json:"pending"`
+	Acknowledgments []*AcknowledgmentResponse `json:"acknowledgments"`
+}
+
+// ListUnacknowledgedRequest represents the query of the acknowledgments
+// pending for longer than a duration.
+type ListUnacknowledgedRequest struct {
+	// OlderThan is a duration such as 15m, 0 when empty
+	OlderThan string `form:"olderThan"`
+	// Limit is the number of acknowledgments listed, 100 when 0
+	Limit int `form:"limit"`
+}
+
+// UnacknowledgedResponse represents the acknowledgments pending for longer
+// than a duration, oldest first.
+type UnacknowledgedResponse struct {
+	// OlderThan is the duration they are pending for at least, in seconds
+	OlderThan       int64                     `json:"olderThan"`
+	Acknowledgments []*AcknowledgmentResponse `json:"acknowledgments"`
+}
+
+// AcknowledgmentResponse represents the acknowledgment of a message by a
+// recipient.
+type AcknowledgmentResponse struct {
+	MessageID      string `json:"messageId"`
+	ChannelID      string `json:"channelId,omitempty"`
+	Recipient      string `json:"recipient"`
+	Acknowledged   bool   `json:"acknowledged"`
+	AcknowledgedAt *int64 `json:"acknowledgedAt,omitempty"`
+	AcknowledgedBy string `json:"acknowledgedBy,omitempty"`
+	CreatedAt      int64  `json:"createdAt"`
+}
+
+// ReplyResponse represents a reply received from a recipient.
+type ReplyResponse struct {
+	ID string `json:"id"`
+	// MessageID is the message answered, empty when no reply token matched
+	MessageID   string `json:"messageId,omitempty"`
+	ChannelID   string `json:"channelId,omitempty"`
+	ChannelType string `json:"channelType"`
+	From        string `json:"from"`
+	Subject     string `json:"subject,omitempty"`
+	Body        string `json:"body"`
+	ProviderID  string `json:"providerId,omitempty"`
+	ReceivedAt  int64  `json:"receivedAt"`
+}
+
+// MessageRepliesResponse represents the replies to a message.
+type MessageRepliesResponse struct {
+	MessageID string           `json:"messageId"`
+	Replies   []*ReplyResponse `json:"replies"`
+}
+
+// ListRepliesRequest represents the query of the replies received lately.
+type ListRepliesRequest struct {
+	// Since lists the replies received after it, in Unix milliseconds
+	Since int64 `form:"since"`
+	// Limit is the number of replies listed, 100 when 0
+	Limit int `form:"limit"`
+}
+
+// ListRepliesResponse represents the replies received lately, newest first.
+type ListRepliesResponse struct {
+	Replies []*ReplyResponse `json:"replies"`
+}
+
+// FromReply converts a reply to a response DTO.
+func FromReply(reply *message.Reply) *ReplyResponse {
+	return &ReplyResponse{
+		ID:          reply.ID,
+		MessageID:   reply.MessageID,
+		ChannelID:   reply.ChannelID,
+		ChannelType: reply.ChannelType,
+		From:        reply.From,
+		Subject:     reply.Subject,
+		Body:        reply.Body,
+		ProviderID:  reply.ProviderID,
+		ReceivedAt:  reply.ReceivedAt,
+	}
+}
+
+// FromAcknowledgment converts an acknowledgment to a response DTO.
+func FromAcknowledgment(ack *message.Acknowledgment) *AcknowledgmentResponse {
+	response := &AcknowledgmentResponse{
+		MessageID:      ack.MessageID,
+		ChannelID:      ack.ChannelID,
+		Recipient:      ack.Recipient,
+		Acknowledged:   ack.IsAcknowledged(),
+		AcknowledgedBy: ack.AcknowledgedBy,
+		CreatedAt:      ack.CreatedAt,
+	}
+	if ack.IsAcknowledged() {
+		acknowledgedAt := ack.AcknowledgedAt
+		response.AcknowledgedAt = &acknowledgedAt
+	}
+	return response
+}
+
+// MessageQuarantineResponse represents the infected attachments kept back from
+// the sends of a message.
+type MessageQuarantineResponse struct {
+	MessageID   string                           `json:"messageId"`
+	Attachments []*QuarantinedAttachmentResponse `json:"attachments"`
+}
+
+// QuarantinedAttachmentResponse represents a quarantined attachment.
+type QuarantinedAttachmentResponse struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channelId"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType,omitempty"`
+	Signature   string `json:"signature"`
+	CreatedAt   int64  `json:"createdAt"`
 }
 
 // ToMessageResponse converts a message entity to a response DTO.
@@ -64,10 +288,13 @@ func ToMessageResponse(m *message.Message) *MessageResponse {
 	// Note: The current message entity structure doesn't match our DTO exactly
 	// We'll need to adapt based on what's available
 	response := &MessageResponse{
-		ID:         m.ID().String(),
-		Status:     m.Status(),
-		CreatedAt:  m.CreatedAt(),
-		Recipients: []map[string]interface{}{}, // Initialize empty recipients
+		ID:            m.ID().String(),
+		CorrelationID: m.CorrelationID(),
+		TenantID:      m.TenantID(),
+		MessageClass:  m.Class(),
+		Status:        m.Status(),
+		CreatedAt:     m.CreatedAt(),
+		Recipients:    []map[string]interface{}{}, // Initialize empty recipients
 	}
 
 	// Get the first channel ID if available
@@ -91,16 +318,43 @@ func ToMessageResponse(m *message.Message) *MessageResponse {
 		response.Results = make([]*MessageResultResponse, len(m.Results()))
 		for i, result := range m.Results() {
 			response.Results[i] = &MessageResultResponse{
-				Status: result.Status(),
+				ChannelID: result.ChannelID().String(),
+				Status:    result.Status(),
+				Cost:      result.Cost(),
 			}
 
 			if result.Error() != nil {
 				response.Results[i].Error = result.Error().Details
+				response.Results[i].ErrorCategory = result.Error().Category
 			}
 
 			if result.SentAt() != nil {
 				response.Results[i].SentAt = result.SentAt()
 			}
+
+			if result.FallbackFrom() != nil {
+				response.Results[i].FallbackFrom = result.FallbackFrom().String()
+			}
+			response.Results[i].PolicyDecisions = result.PolicyDecisions()
+			response.Results[i].AttachmentScans = result.AttachmentScans()
+
+			for _, recipient := range result.Recipients() {
+				recipientResponse := &RecipientResultResponse{
+					Target:            recipient.Target,
+					Status:            recipient.Status,
+					ProviderMessageID: recipient.ProviderMessageID,
+					SentAt:            recipient.SentAt,
+					Acknowledgment:    recipient.Acknowledgment,
+					AcknowledgedAt:    recipient.AcknowledgedAt,
+					Details:           recipient.Details,
+					SuppressionReason: recipient.SuppressionReason,
+				}
+				if recipient.Error != nil {
+					recipientResponse.Error = recipient.Error.Details
+					recipientResponse.ErrorCategory = recipient.Error.Category
+				}
+				response.Results[i].Recipients = append(response.Results[i].Recipients, recipientResponse)
+			}
 		}
 	}
 
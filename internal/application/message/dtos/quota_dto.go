@@ -0,0 +1,53 @@
+package dtos
+
+import (
+	"notification/internal/domain/quota"
+)
+
+// GetQuotaUsageRequest represents the request to get the send quota usage of
+// a tenant, a channel or both.
+type GetQuotaUsageRequest struct {
+	TenantID  string `form:"tenantId" json:"tenantId,omitempty"`
+	ChannelID string `form:"channelId" json:"channelId,omitempty"`
+}
+
+// QuotaUsageListResponse represents the response for the send quota usage.
+type QuotaUsageListResponse struct {
+	// Enabled is false when quotas are not enforced and no usage is counted
+	Enabled bool                  `json:"enabled"`
+	Items   []*QuotaUsageResponse `json:"items"`
+}
+
+// QuotaUsageResponse represents the usage of one quota.
+type QuotaUsageResponse struct {
+	Scope     quota.Scope  `json:"scope"`
+	SubjectID string       `json:"subjectId"`
+	Period    quota.Period `json:"period"`
+	PeriodKey string       `json:"periodKey"`
+	Used      int          `json:"used"`
+	// Limit and Remaining are omitted when the quota is unlimited
+	Limit     *int  `json:"limit,omitempty"`
+	Remaining *int  `json:"remaining,omitempty"`
+	ResetsAt  int64 `json:"resetsAt"`
+}
+
+// ToQuotaUsageResponse converts a quota usage to a response DTO.
+func ToQuotaUsageResponse(usage *quota.Usage) *QuotaUsageResponse {
+	response := &QuotaUsageResponse{
+		Scope:     usage.Scope,
+		SubjectID: usage.SubjectID,
+		Period:    usage.Period,
+		PeriodKey: usage.PeriodKey,
+		Used:      usage.Used,
+		ResetsAt:  usage.ResetsAt,
+	}
+
+	if !usage.IsUnlimited() {
+		limit := usage.Limit
+		remaining := usage.Remaining()
+		response.Limit = &limit
+		response.Remaining = &remaining
+	}
+
+	return response
+}
@@ -0,0 +1,111 @@
+package dtos
+
+import (
+	"notification/internal/domain/services"
+)
+
+// Outcomes of a simulated send through a channel
+const (
+	// SimulationOutcomeSend means the provider would be sent the message
+	SimulationOutcomeSend = "send"
+	// SimulationOutcomeHeld means the message would be parked while the channel is in maintenance
+	SimulationOutcomeHeld = "held"
+	// SimulationOutcomeSuppressed means every recipient would be left out by their preferences
+	SimulationOutcomeSuppressed = "suppressed"
+	// SimulationOutcomeFailed means the send would fail before reaching the provider
+	SimulationOutcomeFailed = "failed"
+)
+
+// RoutingSimulationResponse describes where a send request would go.
+type RoutingSimulationResponse struct {
+	TemplateID string `json:"templateId"`
+	TenantID   string `json:"tenantId"`
+	Category   string `json:"category,omitempty"`
+	// MessageClass is the class the message would be sent as
+	MessageClass string `json:"messageClass"`
+	// Channels lists the channels the request selects, in the order of the send
+	Channels []*SimulatedChannelResponse `json:"channels"`
+}
+
+// SimulatedChannelResponse describes the send of a simulated message through
+// one channel.
+type SimulatedChannelResponse struct {
+	ChannelID   string `json:"channelId"`
+	ChannelName string `json:"channelName,omitempty"`
+	ChannelType string `json:"channelType,omitempty"`
+	// MatchedBy lists what in the request selected the channel: channelId,
+	// channelTags or channelGroup:<group ID>
+	MatchedBy []string `json:"matchedBy"`
+	// Outcome is send, held, suppressed or failed
+	Outcome string `json:"outcome"`
+	// TemplateID and TemplateName are the template of the channel the
+	// message is rendered with, empty when it has none
+	TemplateID   string `json:"templateId,omitempty"`
+	TemplateName string `json:"templateName,omitempty"`
+	// Subject and Content are the rendered preview of a send
+	Subject string `json:"subject,omitempty"`
+	Content string `json:"content,omitempty"`
+	// Recipients are the targets the provider would be sent to
+	Recipients []string `json:"recipients,omitempty"`
+	// Suppressed lists the recipients left out by their preferences
+	Suppressed []*RecipientResultResponse `json:"suppressed,omitempty"`
+	// ErrorCode and Error tell why a send would fail
+	ErrorCode string `json:"errorCode,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// FallbackChannelID is the channel the message would go to next if the
+	// provider failed the send
+	FallbackChannelID string `json:"fallbackChannelId,omitempty"`
+}
+
+// ToSimulatedChannelResponse converts the preview of a send to a response DTO.
+func ToSimulatedChannelResponse(preview *services.SendPreview, matchedBy []string) *SimulatedChannelResponse {
+	response := &SimulatedChannelResponse{
+		ChannelID: preview.ChannelID.String(),
+		MatchedBy: matchedBy,
+		Outcome:   SimulationOutcomeSend,
+	}
+
+	if ch := preview.Channel; ch != nil {
+		response.ChannelName = ch.Name().String()
+		response.ChannelType = ch.ChannelType().String()
+		if ch.FallbackChannelID() != nil {
+			response.FallbackChannelID = ch.FallbackChannelID().String()
+		}
+	}
+	if tmpl := preview.Template; tmpl != nil {
+		response.TemplateID = tmpl.ID().String()
+		response.TemplateName = tmpl.Name().String()
+	}
+
+	if request := preview.Request; request != nil {
+		response.Subject = request.Content.Subject
+		response.Content = request.Content.Content
+		for _, recipient := range request.Channel.Recipients().ToSlice() {
+			response.Recipients = append(response.Recipients, recipient.Target)
+		}
+	}
+
+	for _, recipient := range preview.Suppressed {
+		response.Suppressed = append(response.Suppressed, &RecipientResultResponse{
+			Target:            recipient.Target,
+			Status:            recipient.Status,
+			SuppressionReason: recipient.SuppressionReason,
+		})
+	}
+
+	switch result := preview.Result; {
+	case result == nil:
+	case result.IsHeld():
+		response.Outcome = SimulationOutcomeHeld
+	case result.IsFailed():
+		response.Outcome = SimulationOutcomeFailed
+		if result.Error() != nil {
+			response.ErrorCode = result.Error().Code
+			response.Error = result.Error().Details
+		}
+	default:
+		response.Outcome = SimulationOutcomeSuppressed
+	}
+
+	return response
+}
@@ -0,0 +1,88 @@
+package dtos
+
+import (
+	"notification/internal/domain/schedule"
+)
+
+// ScheduleMessageRequest represents the request to send a message later,
+// either at an instant or at a local time of each recipient. Exactly one of
+// SendAt and LocalTime is required.
+type ScheduleMessageRequest struct {
+	SendMessageRequest
+	// SendAt is the instant the message is sent at, in Unix milliseconds
+	SendAt *int64 `json:"sendAt,omitempty"`
+	// LocalTime is the time of day of each recipient the message is sent at,
+	// such as 2026-03-01T09:00: the recipients are grouped by their time zone
+	// and each group is sent when its clock shows that time.
+	LocalTime string `json:"localTime,omitempty"`
+	// DefaultTimezone is the IANA time zone of the recipients with no time
+	// zone of their own or in their preferences, UTC when empty
+	DefaultTimezone string `json:"defaultTimezone,omitempty"`
+	// Critical sends the message even during the blackouts of the calendar,
+	// which defer the other scheduled messages until they end
+	Critical bool `json:"critical,omitempty"`
+}
+
+// ScheduleResponse represents the sends of a schedule, one per time zone of
+// the recipients when scheduled in local time.
+type ScheduleResponse struct {
+	BatchID string                   `json:"batchId"`
+	Sends   []*ScheduledSendResponse `json:"sends"`
+}
+
+// ScheduledSendResponse represents one send of a schedule.
+type ScheduledSendResponse struct {
+	ID string `json:"id"`
+	// Timezone is the time zone of the recipients of the send, empty for an
+	// instant
+	Timezone string `json:"timezone,omitempty"`
+	SendAt   int64  `json:"sendAt"`
+	Critical bool   `json:"critical,omitempty"`
+	// Status is deferred while a blackout holds the send back to SendAt
+	Status schedule.Status `json:"status"`
+	// DeferredFrom is the time the send was scheduled at before a blackout
+	// deferred it, and DeferredBy the ID of that blackout
+	DeferredFrom *int64 `json:"deferredFrom,omitempty"`
+	DeferredBy   string `json:"deferredBy,omitempty"`
+	// ChannelIDs are the channels the send lists, besides those its channel
+	// tags and groups select when it is sent
+	ChannelIDs []string `json:"channelIds,omitempty"`
+	// MessageID is the message the send created, once sent
+	MessageID string `json:"messageId,omitempty"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt int64  `json:"createdAt"`
+	SentAt    *int64 `json:"sentAt,omitempty"`
+}
+
+// ToScheduleResponse converts the sends of a batch to a response DTO, the
+// channels of each send read from its request.
+func ToScheduleResponse(batchID string, sends []*schedule.ScheduledSend, channelIDs map[string][]string) *ScheduleResponse {
+	response := &ScheduleResponse{
+		BatchID: batchID,
+		Sends:   make([]*ScheduledSendResponse, 0, len(sends)),
+	}
+	for _, send := range sends {
+		item := &ScheduledSendResponse{
+			ID:         send.ID,
+			Timezone:   send.Timezone,
+			SendAt:     send.SendAt.UnixMilli(),
+			Critical:   send.Critical,
+			Status:     send.Status,
+			DeferredBy: send.DeferredBy,
+			ChannelIDs: channelIDs[send.ID],
+			MessageID:  send.MessageID,
+			Error:      send.Error,
+			CreatedAt:  send.CreatedAt.UnixMilli(),
+		}
+		if send.DeferredFrom != nil {
+			deferredFrom := send.DeferredFrom.UnixMilli()
+			item.DeferredFrom = &deferredFrom
+		}
+		if send.SentAt != nil {
+			sentAt := send.SentAt.UnixMilli()
+			item.SentAt = &sentAt
+		}
+		response.Sends = append(response.Sends, item)
+	}
+	return response
+}
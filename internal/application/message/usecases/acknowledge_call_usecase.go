@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// CallbackVerifier checks that a callback of a voice channel comes from its provider.
+type CallbackVerifier interface {
+	VerifyCallback(ch *channel.Channel, params url.Values, signature string) error
+}
+
+// AcknowledgeCallUseCase records the keys a recipient pressed during a voice call.
+type AcknowledgeCallUseCase struct {
+	channelRepo channel.ChannelRepository
+	ackRepo     message.CallAcknowledgmentRepository
+	verifier    CallbackVerifier
+}
+
+// NewAcknowledgeCallUseCase creates a new AcknowledgeCallUseCase.
+func NewAcknowledgeCallUseCase(
+	channelRepo channel.ChannelRepository,
+	ackRepo message.CallAcknowledgmentRepository,
+	verifier CallbackVerifier,
+) *AcknowledgeCallUseCase {
+	return &AcknowledgeCallUseCase{
+		channelRepo: channelRepo,
+		ackRepo:     ackRepo,
+		verifier:    verifier,
+	}
+}
+
+// Execute verifies the signature of the callback and records the acknowledgment
+// of the call it reports. The parameters are the posted form of the provider.
+func (uc *AcknowledgeCallUseCase) Execute(ctx context.Context, channelID string, params url.Values, signature string) error {
+	// 1. Validate input parameters
+	if channelID == "" {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel ID is required"))
+	}
+	id, err := channel.NewChannelIDFromString(channelID)
+	if err != nil {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
+	}
+
+	// 2. Find the voice channel
+	ch, err := uc.channelRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("channel not found: %w", err)
+	}
+	if ch.IsDeleted() || !ch.ChannelType().Equals(shared.ChannelTypeVoice) {
+		return shared.NewNotFoundError("CHANNEL_NOT_FOUND", "voice channel not found")
+	}
+
+	// 3. Verify the callback comes from the provider
+	if err := uc.verifier.VerifyCallback(ch, params, signature); err != nil {
+		return shared.NewForbiddenError("INVALID_SIGNATURE", err.Error())
+	}
+
+	// 4. Record the acknowledgment
+	ack, err := message.NewCallAcknowledgment(params.Get("CallSid"), ch.ID().String(), params.Get("Digits"))
+	if err != nil {
+		return shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if err := uc.ackRepo.Save(ctx, ack); err != nil {
+		return fmt.Errorf("failed to save call acknowledgment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// maxAcknowledgedByLength is the longest name of who acknowledged a message
+const maxAcknowledgedByLength = 255
+
+// Limits of the unacknowledged messages listed at once
+const (
+	defaultUnacknowledgedLimit = 100
+	maxUnacknowledgedLimit     = 1000
+)
+
+// AcknowledgeMessageUseCase records the acknowledgments of the messages by
+// their recipients, through the links sent to them.
+type AcknowledgeMessageUseCase struct {
+	ackRepo message.AcknowledgmentRepository
+}
+
+// NewAcknowledgeMessageUseCase creates a new AcknowledgeMessageUseCase.
+func NewAcknowledgeMessageUseCase(ackRepo message.AcknowledgmentRepository) *AcknowledgeMessageUseCase {
+	return &AcknowledgeMessageUseCase{
+		ackRepo: ackRepo,
+	}
+}
+
+// Execute acknowledges a message for the recipient of a link, by someone
+// else when by is set, e.g. the member of a Slack channel who clicked. A
+// message already acknowledged keeps its first acknowledgment.
+func (uc *AcknowledgeMessageUseCase) Execute(ctx context.Context, token, by string) (*dtos.AcknowledgmentResponse, error) {
+	if token == "" || len(token) > message.AcknowledgmentTokenLength {
+		return nil, shared.NewNotFoundError("ACKNOWLEDGMENT_NOT_FOUND", "acknowledgment not found")
+	}
+	by = strings.TrimSpace(by)
+	if len(by) > maxAcknowledgedByLength {
+		return nil, shared.NewValidationError("INVALID_REQUEST",
+			fmt.Errorf("by must be at most %d characters", maxAcknowledgedByLength))
+	}
+
+	ack, err := uc.ackRepo.FindByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find acknowledgment: %w", err)
+	}
+	if ack == nil {
+		return nil, shared.NewNotFoundError("ACKNOWLEDGMENT_NOT_FOUND", "acknowledgment not found")
+	}
+	if ack.IsAcknowledged() {
+		return dtos.FromAcknowledgment(ack), nil
+	}
+
+	if by == "" {
+		by = ack.Recipient
+	}
+	acknowledgedAt := time.Now().UnixMilli()
+	acknowledged, err := uc.ackRepo.Acknowledge(ctx, token, by, acknowledgedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !acknowledged {
+		// Acknowledged concurrently, the first one is kept
+		if ack, err = uc.ackRepo.FindByToken(ctx, token); err != nil || ack == nil {
+			return nil, errors.Join(errors.New("failed to read acknowledgment back"), err)
+		}
+		return dtos.FromAcknowledgment(ack), nil
+	}
+
+	ack.AcknowledgedAt = acknowledgedAt
+	ack.AcknowledgedBy = by
+	return dtos.FromAcknowledgment(ack), nil
+}
+
+// GetAcknowledgmentsUseCase lists the acknowledgments of the messages, per
+// message or pending for too long, e.g. to escalate the messages nobody
+// acknowledged within 15 minutes.
+type GetAcknowledgmentsUseCase struct {
+	messageRepo message.MessageRepository
+	ackRepo     message.AcknowledgmentRepository
+}
+
+// NewGetAcknowledgmentsUseCase creates a new GetAcknowledgmentsUseCase.
+func NewGetAcknowledgmentsUseCase(messageRepo message.MessageRepository, ackRepo message.AcknowledgmentRepository) *GetAcknowledgmentsUseCase {
+	return &GetAcknowledgmentsUseCase{
+		messageRepo: messageRepo,
+		ackRepo:     ackRepo,
+	}
+}
+
+// ForMessage lists the acknowledgments of a message by its recipients.
+func (uc *GetAcknowledgmentsUseCase) ForMessage(ctx context.Context, id string) (*dtos.MessageAcknowledgmentsResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	messageID, err := message.NewMessageIDFromString(id)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid message ID: %w", err))
+	}
+	if _, err := uc.messageRepo.FindByID(ctx, messageID); err != nil {
+		return nil, fmt.Errorf("failed to find message: %w", err)
+	}
+
+	acks, err := uc.ackRepo.FindByMessageID(ctx, messageID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.MessageAcknowledgmentsResponse{
+		MessageID:       messageID.String(),
+		Acknowledgments: make([]*dtos.AcknowledgmentResponse, 0, len(acks)),
+	}
+	for _, ack := range acks {
+		if ack.IsAcknowledged() {
+			response.Acknowledged++
+		} else {
+			response.Pending++
+		}
+		response.Acknowledgments = append(response.Acknowledgments, dtos.FromAcknowledgment(ack))
+	}
+	return response, nil
+}
+
+// Unacknowledged lists the acknowledgments pending for longer than a
+// duration, oldest first.
+func (uc *GetAcknowledgmentsUseCase) Unacknowledged(ctx context.Context, req *dtos.ListUnacknowledgedRequest) (*dtos.UnacknowledgedResponse, error) {
+	var olderThan time.Duration
+	if req.OlderThan != "" {
+		var err error
+		if olderThan, err = time.ParseDuration(req.OlderThan); err != nil || olderThan < 0 {
+			return nil, shared.NewValidationError("INVALID_REQUEST",
+				fmt.Errorf("olderThan must be a duration such as 15m, got %q", req.OlderThan))
+		}
+	}
+	limit := req.Limit
+	if limit < 0 || limit > maxUnacknowledgedLimit {
+		return nil, shared.NewValidationError("INVALID_REQUEST",
+			fmt.Errorf("limit must be between 0 and %d", maxUnacknowledgedLimit))
+	}
+	if limit == 0 {
+		limit = defaultUnacknowledgedLimit
+	}
+
+	acks, err := uc.ackRepo.FindUnacknowledged(ctx, time.Now().Add(-olderThan).UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.UnacknowledgedResponse{
+		OlderThan:       int64(olderThan / time.Second),
+		Acknowledgments: make([]*dtos.AcknowledgmentResponse, 0, len(acks)),
+	}
+	for _, ack := range acks {
+		response.Acknowledgments = append(response.Acknowledgments, dtos.FromAcknowledgment(ack))
+	}
+	return response, nil
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// exportPageSize is the number of messages an export reads from the database at a time
+const exportPageSize = 500
+
+// ExportMessagesUseCase is the use case for exporting messages.
+type ExportMessagesUseCase struct {
+	messageRepo message.MessageRepository
+}
+
+// NewExportMessagesUseCase creates a use case instance.
+func NewExportMessagesUseCase(messageRepo message.MessageRepository) *ExportMessagesUseCase {
+	return &ExportMessagesUseCase{
+		messageRepo: messageRepo,
+	}
+}
+
+// Execute passes the messages matching the request to write one at a time,
+// oldest first. Messages are read a page at a time, so the size of an export
+// does not bound memory. An invalid request fails before write is called;
+// the export stops at the first error of write.
+func (uc *ExportMessagesUseCase) Execute(ctx context.Context, req *dtos.ExportMessagesRequest, write func(*dtos.MessageResponse) error) error {
+	ctx = shared.WithStaleReads(ctx)
+
+	// 1. Validate request
+	filter, err := uc.createFilter(req)
+	if err != nil {
+		return shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 2. Walk the messages a page at a time, resuming after the last one
+	var after *message.ExportCursor
+	for {
+		messages, err := uc.messageRepo.FindForExport(ctx, filter, after, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			if err := write(dtos.ToMessageResponse(msg)); err != nil {
+				return err
+			}
+		}
+
+		if len(messages) < exportPageSize {
+			return nil
+		}
+		last := messages[len(messages)-1]
+		after = &message.ExportCursor{CreatedAt: last.CreatedAt(), ID: last.ID().String()}
+	}
+}
+
+// createFilter validates the request and converts it to an export filter.
+func (uc *ExportMessagesUseCase) createFilter(req *dtos.ExportMessagesRequest) (*message.ExportFilter, error) {
+	if req == nil {
+		req = &dtos.ExportMessagesRequest{}
+	}
+
+	status := message.MessageStatus(req.Status)
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("invalid message status: %s", req.Status)
+	}
+	if req.From < 0 || req.To < 0 {
+		return nil, fmt.Errorf("from and to must be Unix millisecond timestamps")
+	}
+	if req.To > 0 && req.From >= req.To {
+		return nil, fmt.Errorf("from must be before to")
+	}
+
+	return &message.ExportFilter{
+		ChannelID: req.ChannelID,
+		Status:    status,
+		TenantID:  req.TenantID,
+		From:      req.From,
+		To:        req.To,
+	}, nil
+}
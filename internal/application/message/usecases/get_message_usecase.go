@@ -6,6 +6,7 @@ import (
 
 	"notification/internal/application/message/dtos"
 	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
 )
 
 // GetMessageUseCase handles getting a single message.
@@ -22,15 +23,17 @@ func NewGetMessageUseCase(messageRepo message.MessageRepository) *GetMessageUseC
 
 // Execute gets a message by ID.
 func (uc *GetMessageUseCase) Execute(ctx context.Context, id string) (*dtos.MessageResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
 	// Validate input
 	if id == "" {
-		return nil, fmt.Errorf("message ID cannot be empty")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("message ID cannot be empty"))
 	}
 
 	// Create message ID
 	messageID, err := message.NewMessageIDFromString(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid message ID: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid message ID: %w", err))
 	}
 
 	// Find message
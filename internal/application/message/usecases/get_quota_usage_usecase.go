@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/quota"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// GetQuotaUsageUseCase handles getting the send quota usage.
+type GetQuotaUsageUseCase struct {
+	quotaManager *services.QuotaManager
+}
+
+// NewGetQuotaUsageUseCase creates a new GetQuotaUsageUseCase. The quota
+// manager is nil when quotas are disabled.
+func NewGetQuotaUsageUseCase(quotaManager *services.QuotaManager) *GetQuotaUsageUseCase {
+	return &GetQuotaUsageUseCase{
+		quotaManager: quotaManager,
+	}
+}
+
+// Execute gets the daily and monthly usage of a tenant and of a channel. The
+// default tenant is reported when neither is given.
+func (uc *GetQuotaUsageUseCase) Execute(ctx context.Context, req *dtos.GetQuotaUsageRequest) (*dtos.QuotaUsageListResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	// 1. Validate request
+	if req == nil {
+		req = &dtos.GetQuotaUsageRequest{}
+	}
+	if len(req.TenantID) > dtos.MaxTenantIDLength {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("tenantId must be at most %d characters", dtos.MaxTenantIDLength))
+	}
+	if req.ChannelID != "" {
+		if _, err := channel.NewChannelIDFromString(req.ChannelID); err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
+		}
+	}
+
+	response := &dtos.QuotaUsageListResponse{
+		Enabled: uc.quotaManager != nil,
+		Items:   []*dtos.QuotaUsageResponse{},
+	}
+	if uc.quotaManager == nil {
+		return response, nil
+	}
+
+	// 2. Collect the subjects
+	type subject struct {
+		scope quota.Scope
+		id    string
+	}
+	var subjects []subject
+	if req.TenantID != "" || req.ChannelID == "" {
+		tenantID := req.TenantID
+		if tenantID == "" {
+			tenantID = quota.DefaultTenantID
+		}
+		subjects = append(subjects, subject{quota.ScopeTenant, tenantID})
+	}
+	if req.ChannelID != "" {
+		subjects = append(subjects, subject{quota.ScopeChannel, req.ChannelID})
+	}
+
+	// 3. Get the usage
+	for _, s := range subjects {
+		usages, err := uc.quotaManager.Usage(ctx, s.scope, s.id)
+		if err != nil {
+			return nil, err
+		}
+		for _, usage := range usages {
+			response.Items = append(response.Items, dtos.ToQuotaUsageResponse(usage))
+		}
+	}
+
+	return response, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"notification/internal/application/message/dtos"
 	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
 )
 
 // ListMessagesUseCase is the use case for listing messages.
@@ -24,9 +25,11 @@ func NewListMessagesUseCase(
 
 // Execute executes the list messages operation.
 func (uc *ListMessagesUseCase) Execute(ctx context.Context, request *dtos.ListMessagesRequest) (*dtos.ListMessagesResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
 	// 1. Validate input parameters
 	if err := uc.validateRequest(request); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid request: %w", err))
 	}
 
 	// 2. For now, return empty result since repository doesn't support listing
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// GetMessageQuarantineUseCase lists the infected attachments kept back from
+// the sends of a message.
+type GetMessageQuarantineUseCase struct {
+	messageRepo    message.MessageRepository
+	quarantineRepo message.QuarantineRepository
+}
+
+// NewGetMessageQuarantineUseCase creates a new GetMessageQuarantineUseCase.
+func NewGetMessageQuarantineUseCase(messageRepo message.MessageRepository, quarantineRepo message.QuarantineRepository) *GetMessageQuarantineUseCase {
+	return &GetMessageQuarantineUseCase{
+		messageRepo:    messageRepo,
+		quarantineRepo: quarantineRepo,
+	}
+}
+
+// Execute lists the quarantined attachments of a message.
+func (uc *GetMessageQuarantineUseCase) Execute(ctx context.Context, id string) (*dtos.MessageQuarantineResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	messageID, err := message.NewMessageIDFromString(id)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid message ID: %w", err))
+	}
+	if _, err := uc.messageRepo.FindByID(ctx, messageID); err != nil {
+		return nil, fmt.Errorf("failed to find message: %w", err)
+	}
+
+	attachments, err := uc.quarantineRepo.FindByMessageID(ctx, messageID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.MessageQuarantineResponse{
+		MessageID:   messageID.String(),
+		Attachments: make([]*dtos.QuarantinedAttachmentResponse, 0, len(attachments)),
+	}
+	for _, attachment := range attachments {
+		response.Attachments = append(response.Attachments, &dtos.QuarantinedAttachmentResponse{
+			ID:          attachment.ID,
+			ChannelID:   attachment.ChannelID,
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Signature:   attachment.Signature,
+			CreatedAt:   attachment.CreatedAt,
+		})
+	}
+	return response, nil
+}
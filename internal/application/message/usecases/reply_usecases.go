@@ -0,0 +1,289 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// Limits of the replies listed at once
+const (
+	defaultRepliesLimit = 100
+	maxRepliesLimit     = 1000
+)
+
+// replyAddressHeaders are the headers of an inbound email holding the
+// address it was sent to, where the reply token is
+var replyAddressHeaders = []string{"Delivered-To", "X-Original-To", "To", "Cc"}
+
+// ReceiveReplyUseCase records the replies received back from the recipients,
+// correlated to the messages they answer by their reply tokens.
+type ReceiveReplyUseCase struct {
+	channelRepo channel.ChannelRepository
+	replyRepo   message.ReplyRepository
+	// smsVerifier checks that the SMS replies come from the provider
+	smsVerifier CallbackVerifier
+	// inboundSecret authenticates the provider posting the email replies,
+	// which are refused without it
+	inboundSecret string
+	notifier      message.ReplyNotifier
+}
+
+// NewReceiveReplyUseCase creates a new ReceiveReplyUseCase.
+func NewReceiveReplyUseCase(
+	channelRepo channel.ChannelRepository,
+	replyRepo message.ReplyRepository,
+	smsVerifier CallbackVerifier,
+	inboundSecret string,
+) *ReceiveReplyUseCase {
+	return &ReceiveReplyUseCase{
+		channelRepo:   channelRepo,
+		replyRepo:     replyRepo,
+		smsVerifier:   smsVerifier,
+		inboundSecret: inboundSecret,
+	}
+}
+
+// SetNotifier publishes each reply received. Without a notifier the replies
+// are only stored.
+func (uc *ReceiveReplyUseCase) SetNotifier(notifier message.ReplyNotifier) {
+	uc.notifier = notifier
+}
+
+// ReceiveSMS verifies the signature of an SMS reply posted by Twilio to the
+// callback URL of a channel and records it, correlated to the latest message
+// the channel sent to its sender. The parameters are the posted form.
+func (uc *ReceiveReplyUseCase) ReceiveSMS(ctx context.Context, channelID string, params url.Values, signature string) (*dtos.ReplyResponse, error) {
+	id, err := channel.NewChannelIDFromString(channelID)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
+	}
+	ch, err := uc.channelRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("channel not found: %w", err)
+	}
+	if ch.IsDeleted() || !ch.ChannelType().Equals(shared.ChannelTypeSMS) {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "SMS channel not found")
+	}
+	if err := uc.smsVerifier.VerifyCallback(ch, params, signature); err != nil {
+		return nil, shared.NewForbiddenError("INVALID_SIGNATURE", err.Error())
+	}
+
+	reply, err := message.NewReply(shared.ChannelTypeSMS.String(), params.Get("From"), "", params.Get("Body"), params.Get("MessageSid"))
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	token, err := uc.replyRepo.FindLatestToken(ctx, ch.ID().String(), message.NormalizePhoneNumber(reply.From))
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		reply.Correlate(token)
+	} else {
+		reply.ChannelID = ch.ID().String()
+	}
+	return uc.save(ctx, reply)
+}
+
+// ReceiveEmail records an email reply posted in raw form by the mail
+// provider with the inbound secret, correlated to the message whose reply
+// token tags the address it was sent to.
+func (uc *ReceiveReplyUseCase) ReceiveEmail(ctx context.Context, raw []byte, secret string) (*dtos.ReplyResponse, error) {
+	if uc.inboundSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(uc.inboundSecret)) != 1 {
+		return nil, shared.NewForbiddenError("INVALID_SECRET", "invalid inbound secret")
+	}
+
+	email, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid email: %w", err))
+	}
+	from, err := mail.ParseAddress(email.Header.Get("From"))
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid sender: %w", err))
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(email.Header.Get("Subject"))
+	if err != nil {
+		subject = email.Header.Get("Subject")
+	}
+	body, err := emailText(email.Header.Get("Content-Type"), email.Header.Get("Content-Transfer-Encoding"), email.Body)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid email body: %w", err))
+	}
+
+	reply, err := message.NewReply(shared.ChannelTypeEmail.String(), from.Address, subject, body, email.Header.Get("Message-Id"))
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if value := replyToken(email.Header); value != "" {
+		token, err := uc.replyRepo.FindToken(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if token != nil {
+			reply.Correlate(token)
+		}
+	}
+	return uc.save(ctx, reply)
+}
+
+// save records a reply and publishes it
+func (uc *ReceiveReplyUseCase) save(ctx context.Context, reply *message.Reply) (*dtos.ReplyResponse, error) {
+	if err := uc.replyRepo.Save(ctx, reply); err != nil {
+		return nil, err
+	}
+	if uc.notifier != nil {
+		uc.notifier.ReplyReceived(ctx, reply)
+	}
+	return dtos.FromReply(reply), nil
+}
+
+// replyToken returns the reply token of the first address an email was sent
+// to carrying one
+func replyToken(header mail.Header) string {
+	for _, name := range replyAddressHeaders {
+		addresses, err := header.AddressList(name)
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			if token := message.ReplyTokenFromAddress(address.Address); token != "" {
+				return token
+			}
+		}
+	}
+	return ""
+}
+
+// emailText returns the text of an email body: the plain text part of a
+// multipart body, else its HTML part, decoded from its transfer encoding
+func emailText(contentType, encoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		var html string
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				return html, nil
+			}
+			if err != nil {
+				return "", err
+			}
+			partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			if partType != "" && partType != "text/plain" && partType != "text/html" && !strings.HasPrefix(partType, "multipart/") {
+				// Attachments are left out
+				continue
+			}
+			text, err := emailText(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return "", err
+			}
+			switch {
+			case partType == "text/html":
+				if html == "" {
+					html = text
+				}
+			case text != "":
+				return text, nil
+			}
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	text, err := io.ReadAll(io.LimitReader(body, message.MaxReplyBodyLength))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(text)), nil
+}
+
+// GetRepliesUseCase lists the replies received, per message or lately.
+type GetRepliesUseCase struct {
+	messageRepo message.MessageRepository
+	replyRepo   message.ReplyRepository
+}
+
+// NewGetRepliesUseCase creates a new GetRepliesUseCase.
+func NewGetRepliesUseCase(messageRepo message.MessageRepository, replyRepo message.ReplyRepository) *GetRepliesUseCase {
+	return &GetRepliesUseCase{
+		messageRepo: messageRepo,
+		replyRepo:   replyRepo,
+	}
+}
+
+// ForMessage lists the replies to a message.
+func (uc *GetRepliesUseCase) ForMessage(ctx context.Context, id string) (*dtos.MessageRepliesResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	messageID, err := message.NewMessageIDFromString(id)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid message ID: %w", err))
+	}
+	if _, err := uc.messageRepo.FindByID(ctx, messageID); err != nil {
+		return nil, fmt.Errorf("failed to find message: %w", err)
+	}
+
+	replies, err := uc.replyRepo.FindByMessageID(ctx, messageID.String())
+	if err != nil {
+		return nil, err
+	}
+	response := &dtos.MessageRepliesResponse{
+		MessageID: messageID.String(),
+		Replies:   make([]*dtos.ReplyResponse, 0, len(replies)),
+	}
+	for _, reply := range replies {
+		response.Replies = append(response.Replies, dtos.FromReply(reply))
+	}
+	return response, nil
+}
+
+// List lists the replies received after a time, newest first.
+func (uc *GetRepliesUseCase) List(ctx context.Context, req *dtos.ListRepliesRequest) (*dtos.ListRepliesResponse, error) {
+	if req.Since < 0 {
+		return nil, shared.NewValidationError("INVALID_REQUEST", errors.New("since must not be negative"))
+	}
+	limit := req.Limit
+	if limit < 0 || limit > maxRepliesLimit {
+		return nil, shared.NewValidationError("INVALID_REQUEST",
+			fmt.Errorf("limit must be between 0 and %d", maxRepliesLimit))
+	}
+	if limit == 0 {
+		limit = defaultRepliesLimit
+	}
+
+	replies, err := uc.replyRepo.List(shared.WithStaleReads(ctx), req.Since, limit)
+	if err != nil {
+		return nil, err
+	}
+	response := &dtos.ListRepliesResponse{
+		Replies: make([]*dtos.ReplyResponse, 0, len(replies)),
+	}
+	for _, reply := range replies {
+		response.Replies = append(response.Replies, dtos.FromReply(reply))
+	}
+	return response, nil
+}
@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/schedule"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// ScheduleMessageUseCase holds send requests until their time, fanning the
+// sends scheduled in recipient-local time out per time zone of the
+// recipients.
+type ScheduleMessageUseCase struct {
+	sendUseCase  *SendMessageUseCase
+	scheduleRepo schedule.ScheduledSendRepository
+	timezones    *services.RecipientTimezones
+	calendar     *services.BlackoutCalendar
+}
+
+// NewScheduleMessageUseCase creates a new ScheduleMessageUseCase.
+func NewScheduleMessageUseCase(
+	sendUseCase *SendMessageUseCase,
+	scheduleRepo schedule.ScheduledSendRepository,
+	timezones *services.RecipientTimezones,
+) *ScheduleMessageUseCase {
+	return &ScheduleMessageUseCase{
+		sendUseCase:  sendUseCase,
+		scheduleRepo: scheduleRepo,
+		timezones:    timezones,
+	}
+}
+
+// SetBlackoutCalendar defers the non-critical sends scheduled into a blackout
+// to its end, so that the schedule shows them deferred right away.
+func (uc *ScheduleMessageUseCase) SetBlackoutCalendar(calendar *services.BlackoutCalendar) {
+	uc.calendar = calendar
+}
+
+// Execute schedules a message. The request is checked like a send now, so
+// that a schedule that could not be sent is refused up front. A send whose
+// time has already passed, such as a time zone where the local time is
+// over, is sent by the next run of the scheduled sends.
+func (uc *ScheduleMessageUseCase) Execute(ctx context.Context, req *dtos.ScheduleMessageRequest) (*dtos.ScheduleResponse, error) {
+	if req == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+	if (req.SendAt == nil) == (req.LocalTime == "") {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("exactly one of sendAt and localTime is required"))
+	}
+	if req.Async {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("scheduled messages cannot be async"))
+	}
+	if err := validateSendRequest(&req.SendMessageRequest); err != nil {
+		return nil, err
+	}
+	if _, err := schedule.LoadLocation(req.DefaultTimezone); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	resolved, err := uc.sendUseCase.resolve(ctx, &req.SendMessageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	batchID := schedule.NewBatchID()
+	var sends []*schedule.ScheduledSend
+	channelIDs := make(map[string][]string)
+	if req.SendAt != nil {
+		send, err := newScheduledSend(batchID, resolved.tenantID, "", time.UnixMilli(*req.SendAt), &req.SendMessageRequest, req.Critical)
+		if err != nil {
+			return nil, err
+		}
+		sends = append(sends, send)
+		channelIDs[send.ID] = req.ChannelIDs
+	} else {
+		sends, channelIDs, err = uc.fanOut(ctx, batchID, req, resolved)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Defer the non-critical sends falling within a blackout
+	if uc.calendar != nil {
+		now := time.Now()
+		for _, send := range sends {
+			if _, err := uc.calendar.Defer(ctx, send, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := uc.scheduleRepo.Save(ctx, sends); err != nil {
+		return nil, fmt.Errorf("failed to save scheduled sends: %w", err)
+	}
+	return dtos.ToScheduleResponse(batchID, sends, channelIDs), nil
+}
+
+// fanOut groups the recipients of each channel of a resolved request by time
+// zone and schedules one send per time zone at the local time of the
+// request. Each send lists the channels reaching the time zone, their
+// recipients narrowed to those living in it; channels without recipients
+// send in the default time zone.
+func (uc *ScheduleMessageUseCase) fanOut(ctx context.Context, batchID string, req *dtos.ScheduleMessageRequest, resolved *resolvedSend) ([]*schedule.ScheduledSend, map[string][]string, error) {
+	templateType := resolved.template.ChannelType()
+	overrides := resolved.channelOverrides.ToMap()
+
+	zoneChannels := make(map[string][]string)
+	zoneOverrides := make(map[string]map[string]*message.ChannelOverride)
+	for _, channelID := range resolved.channelIDs.ToSlice() {
+		ch := resolved.channels[channelID.String()]
+		if !ch.ChannelType().Equals(templateType) {
+			return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf(
+				"sends in local time need channels of the template channel type %s, channel %s is %s",
+				templateType, channelID, ch.ChannelType()))
+		}
+
+		recipients := ch.Recipients().ToSlice()
+		override, hasOverride := overrides[channelID.String()]
+		if hasOverride && override.HasRecipientsOverride() {
+			recipients = override.Recipients.ToSlice()
+		}
+		if len(recipients) == 0 {
+			zoneChannels[req.DefaultTimezone] = append(zoneChannels[req.DefaultTimezone], channelID.String())
+			if hasOverride {
+				zoneOverride(zoneOverrides, req.DefaultTimezone)[channelID.String()] = override
+			}
+			continue
+		}
+
+		groups, err := uc.timezones.Group(ctx, recipients, req.DefaultTimezone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find recipient time zones: %w", err)
+		}
+		for timezone, zoneRecipients := range groups {
+			narrowed := message.NewChannelOverride()
+			if hasOverride {
+				copied := *override
+				narrowed = &copied
+			}
+			narrowed.WithRecipients(channel.NewRecipients(zoneRecipients))
+			zoneChannels[timezone] = append(zoneChannels[timezone], channelID.String())
+			zoneOverride(zoneOverrides, timezone)[channelID.String()] = narrowed
+		}
+	}
+
+	timezones := make([]string, 0, len(zoneChannels))
+	for timezone := range zoneChannels {
+		timezones = append(timezones, timezone)
+	}
+	sort.Strings(timezones)
+
+	sends := make([]*schedule.ScheduledSend, 0, len(timezones))
+	channelIDs := make(map[string][]string, len(timezones))
+	for _, timezone := range timezones {
+		sendAt, err := schedule.ParseLocalTime(req.LocalTime, timezone)
+		if err != nil {
+			return nil, nil, shared.NewValidationError("INVALID_REQUEST", err)
+		}
+
+		zoneRequest := req.SendMessageRequest
+		zoneRequest.ChannelIDs = zoneChannels[timezone]
+		zoneRequest.ChannelTags = nil
+		zoneRequest.ChannelGroupIDs = nil
+		zoneRequest.ChannelOverrides = message.NewChannelOverrides(zoneOverrides[timezone])
+
+		send, err := newScheduledSend(batchID, resolved.tenantID, timezone, sendAt, &zoneRequest, req.Critical)
+		if err != nil {
+			return nil, nil, err
+		}
+		sends = append(sends, send)
+		channelIDs[send.ID] = zoneRequest.ChannelIDs
+	}
+	return sends, channelIDs, nil
+}
+
+// zoneOverride returns the channel overrides of a time zone, creating them
+func zoneOverride(overrides map[string]map[string]*message.ChannelOverride, timezone string) map[string]*message.ChannelOverride {
+	if overrides[timezone] == nil {
+		overrides[timezone] = make(map[string]*message.ChannelOverride)
+	}
+	return overrides[timezone]
+}
+
+// newScheduledSend creates a scheduled send holding a send request
+func newScheduledSend(batchID, tenantID, timezone string, sendAt time.Time, req *dtos.SendMessageRequest, critical bool) (*schedule.ScheduledSend, error) {
+	request, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode send request: %w", err)
+	}
+	send, err := schedule.NewScheduledSend(batchID, tenantID, timezone, sendAt, string(request), critical)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	return send, nil
+}
+
+// SendScheduled sends the request of a scheduled send, implementing
+// services.ScheduledSender.
+func (uc *ScheduleMessageUseCase) SendScheduled(ctx context.Context, send *schedule.ScheduledSend) (string, error) {
+	var req dtos.SendMessageRequest
+	if err := json.Unmarshal([]byte(send.Request), &req); err != nil {
+		return "", fmt.Errorf("failed to decode send request: %w", err)
+	}
+	response, err := uc.sendUseCase.Execute(ctx, &req)
+	if err != nil {
+		return "", err
+	}
+	return response.ID, nil
+}
+
+// Get returns the sends of a schedule.
+func (uc *ScheduleMessageUseCase) Get(ctx context.Context, batchID string) (*dtos.ScheduleResponse, error) {
+	sends, err := uc.scheduleRepo.FindByBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	channelIDs := make(map[string][]string, len(sends))
+	for _, send := range sends {
+		var req dtos.SendMessageRequest
+		if err := json.Unmarshal([]byte(send.Request), &req); err == nil {
+			channelIDs[send.ID] = req.ChannelIDs
+		}
+	}
+	return dtos.ToScheduleResponse(batchID, sends, channelIDs), nil
+}
+
+// Cancel cancels the sends of a schedule not sent yet and returns the
+// schedule; the sends already sent or sending are kept.
+func (uc *ScheduleMessageUseCase) Cancel(ctx context.Context, batchID string) (*dtos.ScheduleResponse, error) {
+	if _, err := uc.scheduleRepo.FindByBatch(ctx, batchID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.scheduleRepo.CancelBatch(ctx, batchID); err != nil {
+		return nil, err
+	}
+	return uc.Get(ctx, batchID)
+}
@@ -8,14 +8,21 @@ import (
 	"io"
 	"net/http"
 	"notification/internal/application/message/dtos"
+	"notification/internal/domain/category"
 	"notification/internal/domain/channel"
+	"notification/internal/domain/channelgroup"
 	"notification/internal/domain/message"
+	"notification/internal/domain/quota"
 	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 	"notification/pkg/config"
+	"notification/pkg/logger"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // LegacyMessageRequest defines the request payload for the legacy system.
@@ -29,6 +36,8 @@ type LegacyMessageRequest struct {
 	Variables   map[string]interface{} `json:"variables"`
 	SendList    []LegacySendListItem   `json:"sendList,omitempty"`
 	Attachments []LegacyAttachment     `json:"attachments"`
+	// CorrelationID is also sent as the X-Correlation-ID header
+	CorrelationID string `json:"correlationId,omitempty"`
 }
 
 // LegacySendListItem defines a recipient for the legacy system.
@@ -62,7 +71,16 @@ type SendMessageUseCase struct {
 	channelRepo   channel.ChannelRepository
 	templateRepo  template.TemplateRepository
 	messageSender *services.EnhancedMessageSender
-	config        *config.Config
+	dispatcher    services.MessageDispatcher
+	quotaManager  *services.QuotaManager
+	groupRepo     channelgroup.ChannelGroupRepository
+	categoryRepo  category.CategoryRepository
+	intake        *services.SendIntake
+	// environment is the deployment stage the messages are sent from
+	environment shared.Environment
+	config      *config.Config
+	// deliveries tracks the messages delivered in the background
+	deliveries sync.WaitGroup
 }
 
 // NewSendMessageUseCase creates a new SendMessageUseCase.
@@ -82,61 +100,328 @@ func NewSendMessageUseCase(
 	}
 }
 
+// SetDispatcher makes Execute hand accepted messages to the send workers
+// instead of sending them inline. The returned message is then pending.
+func (uc *SendMessageUseCase) SetDispatcher(dispatcher services.MessageDispatcher) {
+	uc.dispatcher = dispatcher
+}
+
+// SetQuotaManager makes Execute count messages against the send quotas and
+// reject the ones that would exceed them.
+func (uc *SendMessageUseCase) SetQuotaManager(quotaManager *services.QuotaManager) {
+	uc.quotaManager = quotaManager
+}
+
+// SetChannelGroupRepository lets requests target the members of channel groups.
+func (uc *SendMessageUseCase) SetChannelGroupRepository(groupRepo channelgroup.ChannelGroupRepository) {
+	uc.groupRepo = groupRepo
+}
+
+// SetCategoryRepository makes Execute reject the messages sent in a category
+// that is not registered.
+func (uc *SendMessageUseCase) SetCategoryRepository(categoryRepo category.CategoryRepository) {
+	uc.categoryRepo = categoryRepo
+}
+
+// SetIntake makes Execute wait for its turn in the send intake, and reject
+// the messages arriving while the intake is full.
+func (uc *SendMessageUseCase) SetIntake(intake *services.SendIntake) {
+	uc.intake = intake
+}
+
+// SetEnvironment scopes the sends to the deployment environment: tags only
+// resolve to the channels of the environment, and a deployment below prod
+// refuses prod channels and templates unless a request allows them.
+func (uc *SendMessageUseCase) SetEnvironment(environment shared.Environment) {
+	uc.environment = environment
+}
+
 // Execute sends a message.
 func (uc *SendMessageUseCase) Execute(ctx context.Context, req *dtos.SendMessageRequest) (*dtos.MessageResponse, error) {
 	// Validate request
-	if req == nil {
-		return nil, fmt.Errorf("request cannot be nil")
+	if err := validateSendRequest(req); err != nil {
+		return nil, err
+	}
+	if req.CorrelationID != "" {
+		ctx = logger.ContextWithCorrelationID(ctx, req.CorrelationID)
 	}
 
-	if len(req.ChannelIDs) == 0 {
-		return nil, fmt.Errorf("at least one channel ID is required")
+	// Wait for a turn in the send intake; a background delivery keeps it
+	// until the message is delivered
+	leave := func() {}
+	if uc.intake != nil {
+		intakeLeave, err := uc.intake.Enter(ctx)
+		if err != nil {
+			return nil, err
+		}
+		leave = intakeLeave
 	}
+	defer func() { leave() }()
 
-	// Create channel IDs from string slice
-	var channelIDEntities []*channel.ChannelID
-	for _, channelIDStr := range req.ChannelIDs {
-		channelID, err := channel.NewChannelIDFromString(channelIDStr)
+	// Resolve the template, channels, category and variables of the message
+	resolved, err := uc.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	channelIDs, variables, channelOverrides := resolved.channelIDs, resolved.variables, resolved.channelOverrides
+	tenantID := resolved.tenantID
+
+	// Count the message against the send quotas, which only reject marketing
+	// messages
+	if uc.quotaManager != nil {
+		consume := uc.quotaManager.Consume
+		if resolved.class.IsTransactional() {
+			consume = uc.quotaManager.Count
+		}
+		if err := consume(ctx, tenantID, channelIDs.ToSlice()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Hand the message to the send workers when a dispatcher is set
+	if uc.dispatcher != nil {
+		messageEntity, err := uc.messageSender.Accept(ctx, channelIDs, variables, channelOverrides, req.CorrelationID, tenantID, req.Strict)
 		if err != nil {
-			return nil, fmt.Errorf("invalid channel ID '%s': %w", channelIDStr, err)
+			return nil, fmt.Errorf("failed to accept message: %w", err)
 		}
-		channelIDEntities = append(channelIDEntities, channelID)
+		if err := uc.dispatcher.Dispatch(ctx, messageEntity); err != nil {
+			return nil, shared.NewUnavailableError("DISPATCH_FAILED", "failed to queue message for sending", err)
+		}
+		return dtos.ToMessageResponseWithRecipients(messageEntity, req.Recipients), nil
+	}
+
+	// Deliver in the background when asked, answering with the pending message
+	if req.Async {
+		messageEntity, err := uc.messageSender.Accept(ctx, channelIDs, variables, channelOverrides, req.CorrelationID, tenantID, req.Strict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to accept message: %w", err)
+		}
+		response := dtos.ToMessageResponseWithRecipients(messageEntity, req.Recipients)
+
+		deliverCtx := context.WithoutCancel(ctx)
+		deliveryLeave := leave
+		leave = func() {}
+		uc.deliveries.Add(1)
+		go func() {
+			defer uc.deliveries.Done()
+			defer deliveryLeave()
+			if err := uc.messageSender.Deliver(deliverCtx, messageEntity); err != nil {
+				logger.FromContext(deliverCtx).Error("Failed to deliver message in the background",
+					zap.String("message_id", messageEntity.ID().String()),
+					zap.Error(err))
+			}
+		}()
+		return response, nil
+	}
+
+	// Send message using domain service
+	messageEntity, err := uc.messageSender.SendMessage(
+		ctx,
+		channelIDs,
+		variables,
+		channelOverrides,
+		req.CorrelationID,
+		tenantID,
+		req.Strict,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
+	// Convert to response
+	return dtos.ToMessageResponseWithRecipients(messageEntity, req.Recipients), nil
+}
+
+// Drain waits for the messages delivered in the background, up to the
+// context deadline. The servers are stopped first, so that no send starts
+// meanwhile.
+func (uc *SendMessageUseCase) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		uc.deliveries.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background deliveries still running: %w", ctx.Err())
+	}
+}
+
+// Simulate works out where a send request would go without sending it: the
+// channels it selects with what selected them, and for each channel the
+// template, recipients and rendered content of the send, or how the send
+// would end before reaching the provider. Nothing is saved and no quota is
+// consumed.
+func (uc *SendMessageUseCase) Simulate(ctx context.Context, req *dtos.SendMessageRequest) (*dtos.RoutingSimulationResponse, error) {
+	// 1. Validate the request like a send
+	if err := validateSendRequest(req); err != nil {
+		return nil, err
+	}
+	if req.CorrelationID != "" {
+		ctx = logger.ContextWithCorrelationID(ctx, req.CorrelationID)
+	}
+
+	// 2. Resolve the template, channels, category and variables
+	resolved, err := uc.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Preview the send through each channel
+	response := &dtos.RoutingSimulationResponse{
+		TemplateID:   resolved.template.ID().String(),
+		TenantID:     resolved.tenantID,
+		Category:     resolved.category,
+		MessageClass: string(resolved.class),
+		Channels:     make([]*dtos.SimulatedChannelResponse, 0, resolved.channelIDs.Count()),
+	}
+	for _, channelID := range resolved.channelIDs.ToSlice() {
+		preview := uc.messageSender.Preview(ctx, channelID, resolved.variables, resolved.channelOverrides, req.Strict)
+		response.Channels = append(response.Channels, dtos.ToSimulatedChannelResponse(preview, resolved.matches[channelID.String()]))
+	}
+
+	return response, nil
+}
+
+// validateSendRequest checks the limits of a send request
+func validateSendRequest(req *dtos.SendMessageRequest) error {
+	if req == nil {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+
+	if len(req.ChannelIDs) == 0 && len(req.ChannelTags) == 0 && len(req.ChannelGroupIDs) == 0 {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("at least one channel ID, channel tag or channel group is required"))
+	}
+
+	if len(req.Recipients) > dtos.MaxRecipients {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("a message accepts at most %d recipients, got %d", dtos.MaxRecipients, len(req.Recipients)))
+	}
+
+	if len(req.CorrelationID) > dtos.MaxCorrelationIDLength {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("correlationId must be at most %d characters", dtos.MaxCorrelationIDLength))
+	}
+	if len(req.TenantID) > dtos.MaxTenantIDLength {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("tenantId must be at most %d characters", dtos.MaxTenantIDLength))
+	}
+	return nil
+}
+
+// Selectors of a send request recorded as what matched a channel
+const (
+	matchedByChannelID = "channelId"
+	matchedByTags      = "channelTags"
+	// matchedByGroup is followed by the ID of the channel group
+	matchedByGroup = "channelGroup:"
+)
+
+// channelMatches records, per channel ID, what in a send request selected the channel
+type channelMatches map[string][]string
+
+// add records a selector of a channel
+func (m channelMatches) add(channelID, matchedBy string) {
+	m[channelID] = append(m[channelID], matchedBy)
+}
+
+// resolvedSend is a send request resolved to what the message is sent with
+type resolvedSend struct {
+	template         *template.Template
+	channelIDs       *message.ChannelIDs
+	matches          channelMatches
+	channels         map[string]*channel.Channel
+	tenantID         string
+	category         string
+	class            message.MessageClass
+	variables        *message.Variables
+	channelOverrides *message.ChannelOverrides
+}
+
+// resolve finds the template and the channels of a send request, checks they
+// fit together, and resolves the tenant, category and variables of the message
+func (uc *SendMessageUseCase) resolve(ctx context.Context, req *dtos.SendMessageRequest) (*resolvedSend, error) {
 	// Create template ID
 	templateID, err := template.NewTemplateIDFromString(req.TemplateID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid template ID: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
+	}
+
+	// Validate template exists
+	templateEntity, err := uc.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find template: %w", err)
+	}
+	if err := services.CheckSendEnvironment(uc.environment, req.AllowProd, "template", templateEntity.ID().String(), templateEntity.Environment()); err != nil {
+		return nil, err
+	}
+
+	// Resolve the channels targeted by tag and by channel group
+	matches := make(channelMatches)
+	channelIDStrs, err := uc.resolveChannelIDs(ctx, req, templateEntity.ChannelType(), matches)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create channel IDs from string slice
+	var channelIDEntities []*channel.ChannelID
+	for _, channelIDStr := range channelIDStrs {
+		channelID, err := channel.NewChannelIDFromString(channelIDStr)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID '%s': %w", channelIDStr, err))
+		}
+		channelIDEntities = append(channelIDEntities, channelID)
 	}
 
 	// Validate all channels exist and get the first one for template validation
 	var firstChannelEntity *channel.Channel
+	channelEntities := make(map[string]*channel.Channel, len(channelIDEntities))
 	for i, channelID := range channelIDEntities {
 		channelEntity, err := uc.channelRepo.FindByID(ctx, channelID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to find channel '%s': %w", req.ChannelIDs[i], err)
+			return nil, fmt.Errorf("failed to find channel '%s': %w", channelIDStrs[i], err)
+		}
+		if err := services.CheckChannelEnvironment(ctx, uc.channelRepo, uc.environment, req.AllowProd, channelEntity); err != nil {
+			return nil, err
 		}
 		if i == 0 {
 			firstChannelEntity = channelEntity
 		}
+		channelEntities[channelID.String()] = channelEntity
 	}
 
-	// Validate template exists
-	templateEntity, err := uc.templateRepo.FindByID(ctx, templateID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find template: %w", err)
+	// Validate channel type matches template channel type (using first listed channel);
+	// tags only resolve to that type and groups may mix types
+	if len(req.ChannelIDs) > 0 && firstChannelEntity.ChannelType() != templateEntity.ChannelType() {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel type '%s' does not match template channel type '%s'",
+			firstChannelEntity.ChannelType(), templateEntity.ChannelType()))
 	}
 
-	// Validate channel type matches template channel type (using first channel)
-	if firstChannelEntity.ChannelType() != templateEntity.ChannelType() {
-		return nil, fmt.Errorf("channel type '%s' does not match template channel type '%s'",
-			firstChannelEntity.ChannelType(), templateEntity.ChannelType())
+	// Validate the overrides against the types of the channels they apply to
+	if err := uc.validateChannelOverrides(ctx, req.ChannelOverrides, channelEntities); err != nil {
+		return nil, err
 	}
 
 	// Create channel IDs
 	channelIDs, err := message.NewChannelIDs(channelIDEntities)
 	if err != nil {
-		return nil, fmt.Errorf("invalid channel IDs: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel IDs: %w", err))
+	}
+
+	// Messages without a tenant are sent for the default tenant
+	tenantID := req.TenantID
+	if tenantID == "" {
+		tenantID = quota.DefaultTenantID
+	}
+
+	// Resolve the category and the class of the message
+	categoryName, err := uc.resolveCategory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	class, err := resolveMessageClass(req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create variables if provided
@@ -146,6 +431,23 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *dtos.SendMessage
 	} else {
 		variables = message.NewVariables(nil)
 	}
+	if categoryName != "" {
+		variables = message.NewVariables(variables.ToMap())
+		variables.Set(services.NotificationCategoryVariable, categoryName)
+	}
+	if req.MessageClass != "" {
+		variables = message.NewVariables(variables.ToMap())
+		variables.Set(message.MessageClassVariable, string(class))
+	}
+	// Only the request decides whether the deliveries may go through prod channels
+	if _, exists := variables.Get(message.AllowProdVariable); exists || req.AllowProd {
+		allowProd := variables.ToMap()
+		delete(allowProd, message.AllowProdVariable)
+		if req.AllowProd {
+			allowProd[message.AllowProdVariable] = true
+		}
+		variables = message.NewVariables(allowProd)
+	}
 
 	// Create channel overrides if provided
 	var channelOverrides *message.ChannelOverrides
@@ -155,19 +457,213 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *dtos.SendMessage
 		channelOverrides = message.NewChannelOverrides(nil)
 	}
 
-	// Send message using domain service
-	messageEntity, err := uc.messageSender.SendMessage(
-		ctx,
-		channelIDs,
-		variables,
-		channelOverrides,
-	)
+	return &resolvedSend{
+		template:         templateEntity,
+		channelIDs:       channelIDs,
+		matches:          matches,
+		channels:         channelEntities,
+		tenantID:         tenantID,
+		category:         categoryName,
+		class:            class,
+		variables:        variables,
+		channelOverrides: channelOverrides,
+	}, nil
+}
+
+// resolveMessageClass returns the class of a request, marketing when it has
+// none
+func resolveMessageClass(req *dtos.SendMessageRequest) (message.MessageClass, error) {
+	class, err := message.ParseMessageClass(req.MessageClass)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send message: %w", err)
+		return "", shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if value, exists := req.Variables[message.MessageClassVariable]; exists {
+		if variableClass, err := message.ParseMessageClass(fmt.Sprintf("%v", value)); err != nil || variableClass != class {
+			return "", shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("message class '%s' differs from the %s variable", class, message.MessageClassVariable))
+		}
 	}
+	return class, nil
+}
 
-	// Convert to response
-	return dtos.ToMessageResponseWithRecipients(messageEntity, req.Recipients), nil
+// resolveCategory returns the normalized category of a request, empty when it
+// has none. With a category repository the category must be registered.
+func (uc *SendMessageUseCase) resolveCategory(ctx context.Context, req *dtos.SendMessageRequest) (string, error) {
+	name := category.NormalizeName(req.Category)
+	if name == "" {
+		return "", nil
+	}
+	if len(name) > category.MaxNameLength {
+		return "", shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("category must be at most %d characters", category.MaxNameLength))
+	}
+	if value, exists := req.Variables[services.NotificationCategoryVariable]; exists && category.NormalizeName(fmt.Sprintf("%v", value)) != name {
+		return "", shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("category '%s' differs from the %s variable", name, services.NotificationCategoryVariable))
+	}
+
+	if uc.categoryRepo != nil {
+		if _, err := uc.categoryRepo.FindByName(ctx, name); err != nil {
+			if shared.IsNotFound(err) {
+				return "", shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("category '%s' does not exist", name))
+			}
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+// resolveChannelIDs returns the channel IDs of the request followed by the IDs
+// of the enabled channels of the channel type carrying every channel tag and
+// the IDs of the enabled members of the channel groups, without duplicates,
+// recording what selected each channel in matches. Resolving the tags or a
+// group to no channel is a validation error.
+func (uc *SendMessageUseCase) resolveChannelIDs(ctx context.Context, req *dtos.SendMessageRequest, channelType shared.ChannelType, matches channelMatches) ([]string, error) {
+	for _, channelID := range req.ChannelIDs {
+		if len(matches[channelID]) == 0 {
+			matches.add(channelID, matchedByChannelID)
+		}
+	}
+	if len(req.ChannelTags) == 0 && len(req.ChannelGroupIDs) == 0 {
+		return req.ChannelIDs, nil
+	}
+
+	seen := make(map[string]bool, len(req.ChannelIDs))
+	channelIDs := make([]string, 0, len(req.ChannelIDs))
+	for _, channelID := range req.ChannelIDs {
+		if !seen[channelID] {
+			seen[channelID] = true
+			channelIDs = append(channelIDs, channelID)
+		}
+	}
+
+	if len(req.ChannelTags) == 0 {
+		return uc.appendGroupMembers(ctx, req.ChannelGroupIDs, channelIDs, seen, matches)
+	}
+
+	filter := channel.NewChannelFilter().
+		WithAllTags(req.ChannelTags).
+		WithChannelType(channelType).
+		WithEnabled(true)
+	if uc.environment != "" {
+		filter.WithEnvironment(uc.environment)
+	}
+
+	tagged := 0
+	for skipCount := 0; ; {
+		pagination, err := shared.NewPagination(skipCount, 100)
+		if err != nil {
+			return nil, err
+		}
+		page, err := uc.channelRepo.FindAll(ctx, filter, pagination)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find channels tagged %v: %w", req.ChannelTags, err)
+		}
+
+		for _, ch := range page.Items {
+			// The LIKE fallback of non-PostgreSQL stores also matches tags containing the wanted ones
+			if !hasAllTags(ch, req.ChannelTags) {
+				continue
+			}
+			tagged++
+			matches.add(ch.ID().String(), matchedByTags)
+			if !seen[ch.ID().String()] {
+				seen[ch.ID().String()] = true
+				channelIDs = append(channelIDs, ch.ID().String())
+			}
+		}
+
+		if !page.HasMore {
+			break
+		}
+		skipCount += len(page.Items)
+	}
+
+	if tagged == 0 {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("no enabled %s channel is tagged %v", channelType, req.ChannelTags))
+	}
+
+	return uc.appendGroupMembers(ctx, req.ChannelGroupIDs, channelIDs, seen, matches)
+}
+
+// appendGroupMembers appends the enabled members of the channel groups to the
+// channel IDs. Members deleted since they joined a group are skipped.
+func (uc *SendMessageUseCase) appendGroupMembers(ctx context.Context, groupIDs []string, channelIDs []string, seen map[string]bool, matches channelMatches) ([]string, error) {
+	if len(groupIDs) > 0 && uc.groupRepo == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel groups are not supported"))
+	}
+
+	for _, groupIDStr := range groupIDs {
+		groupID, err := channelgroup.NewChannelGroupIDFromString(groupIDStr)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel group ID '%s': %w", groupIDStr, err))
+		}
+		group, err := uc.groupRepo.FindByID(ctx, groupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find channel group '%s': %w", groupIDStr, err)
+		}
+
+		members := 0
+		for _, channelID := range group.Members().ToSlice() {
+			ch, err := uc.channelRepo.FindByID(ctx, channelID)
+			if err != nil {
+				if shared.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to find channel '%s': %w", channelID.String(), err)
+			}
+			if ch.IsDeleted() || !ch.IsEnabled() {
+				continue
+			}
+			members++
+			matches.add(channelID.String(), matchedByGroup+groupIDStr)
+			if !seen[channelID.String()] {
+				seen[channelID.String()] = true
+				channelIDs = append(channelIDs, channelID.String())
+			}
+		}
+
+		if members == 0 {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel group '%s' has no enabled channel", groupIDStr))
+		}
+	}
+
+	return channelIDs, nil
+}
+
+// validateChannelOverrides checks each override against the channel it applies
+// to, so that a message cannot override what the channel type keeps fixed.
+// Overrides may also target channels the message only reaches as a fallback.
+func (uc *SendMessageUseCase) validateChannelOverrides(ctx context.Context, overrides *message.ChannelOverrides, channels map[string]*channel.Channel) error {
+	if overrides == nil {
+		return nil
+	}
+
+	for channelIDStr, override := range overrides.ToMap() {
+		ch, ok := channels[channelIDStr]
+		if !ok {
+			channelID, err := channel.NewChannelIDFromString(channelIDStr)
+			if err != nil {
+				return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID '%s' in channel overrides: %w", channelIDStr, err))
+			}
+			if ch, err = uc.channelRepo.FindByID(ctx, channelID); err != nil {
+				return fmt.Errorf("failed to find channel '%s' of channel overrides: %w", channelIDStr, err)
+			}
+		}
+
+		if err := override.Validate(ch.ChannelType()); err != nil {
+			return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid override for channel '%s': %w", channelIDStr, err))
+		}
+	}
+
+	return nil
+}
+
+// hasAllTags checks if the channel carries every one of the tags
+func hasAllTags(ch *channel.Channel, tags []string) bool {
+	for _, tag := range tags {
+		if !ch.HasTag(tag) {
+			return false
+		}
+	}
+	return true
 }
 
 // Forward sends a message via the legacy system.
@@ -177,11 +673,22 @@ func (uc *SendMessageUseCase) Forward(ctx context.Context, req *dtos.SendMessage
 
 	// Validate request
 	if req == nil {
-		return nil, fmt.Errorf("request cannot be nil")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
 	}
 
 	if len(req.ChannelIDs) == 0 {
-		return nil, fmt.Errorf("at least one channel ID is required")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("at least one channel ID is required"))
+	}
+
+	if len(req.Recipients) > dtos.MaxRecipients {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("a message accepts at most %d recipients, got %d", dtos.MaxRecipients, len(req.Recipients)))
+	}
+
+	if len(req.CorrelationID) > dtos.MaxCorrelationIDLength {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("correlationId must be at most %d characters", dtos.MaxCorrelationIDLength))
+	}
+	if req.CorrelationID != "" {
+		ctx = logger.ContextWithCorrelationID(ctx, req.CorrelationID)
 	}
 
 	// 1. Get Template info
@@ -189,7 +696,7 @@ func (uc *SendMessageUseCase) Forward(ctx context.Context, req *dtos.SendMessage
 	if req.TemplateID != "" {
 		templateID, err := template.NewTemplateIDFromString(req.TemplateID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid template ID: %w", err)
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
 		}
 		templateEntity, err = uc.templateRepo.FindByID(ctx, templateID)
 		if err != nil {
@@ -210,9 +717,9 @@ func (uc *SendMessageUseCase) Forward(ctx context.Context, req *dtos.SendMessage
 		// Get Channel info
 		channelID, err := channel.NewChannelIDFromString(channelIDStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid channel ID '%s': %w", channelIDStr, err)
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID '%s': %w", channelIDStr, err))
 		}
-		_, err = uc.channelRepo.FindByID(ctx, channelID)
+		channelEntity, err := uc.channelRepo.FindByID(ctx, channelID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find channel '%s': %w", channelIDStr, err)
 		}
@@ -235,11 +742,13 @@ func (uc *SendMessageUseCase) Forward(ctx context.Context, req *dtos.SendMessage
 			Header:      "", // Assuming no header from SendMessageRequest
 			Footer:      "", // Assuming no footer from SendMessageRequest
 			UseTemplate: true,
-			Variables:   req.Variables,
+			Variables:   channelEntity.VariableDefaults().MergeUnder(req.Variables),
 			SendList:    sendList,
 			Attachments: []LegacyAttachment{}, // Assuming no attachments from SendMessageRequest
 			Subject:     "test",
 			Message:     "test",
+
+			CorrelationID: req.CorrelationID,
 		}
 
 		if templateEntity != nil {
@@ -264,20 +773,23 @@ func (uc *SendMessageUseCase) Forward(ctx context.Context, req *dtos.SendMessage
 	}
 	httpReq.Header.Set("Authorization", "Bearer "+bearerToken)
 	httpReq.Header.Set("Content-Type", "application/json")
+	if req.CorrelationID != "" {
+		httpReq.Header.Set("X-Correlation-ID", req.CorrelationID)
+	}
 
 	client := &http.Client{
 		Timeout: 30 * time.Second, // Set reasonable timeout
 	}
 	resp, err := client.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request to legacy system: %w", err)
+		return nil, shared.NewUnavailableError("LEGACY_SYSTEM_UNAVAILABLE", "failed to send request to legacy system", err)
 	}
 	defer resp.Body.Close()
 
 	// 5. Check response status
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("legacy system returned error status %d: %s", resp.StatusCode, string(body))
+		return nil, shared.NewLegacyStatusError(resp.StatusCode, string(body))
 	}
 
 	// 6. Parse the response and convert to a MessageResponse DTO
@@ -331,15 +843,16 @@ func (uc *SendMessageUseCase) Forward(ctx context.Context, req *dtos.SendMessage
 		}
 
 		messageResponse := &dtos.MessageResponse{
-			ID:         uuid.New().String(),
-			ChannelID:  result.GroupID,
-			TemplateID: req.TemplateID,
-			Recipients: req.Recipients,
-			Variables:  req.Variables,
-			Status:     channelStatus,
-			Results:    channelResults,
-			CreatedAt:  currentTime,
-			SentAt:     currentTime,
+			ID:            uuid.New().String(),
+			ChannelID:     result.GroupID,
+			TemplateID:    req.TemplateID,
+			Recipients:    req.Recipients,
+			Variables:     req.Variables,
+			CorrelationID: req.CorrelationID,
+			Status:        channelStatus,
+			Results:       channelResults,
+			CreatedAt:     currentTime,
+			SentAt:        currentTime,
 		}
 
 		messageResponses = append(messageResponses, messageResponse)
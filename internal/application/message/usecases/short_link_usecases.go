@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// FollowShortLinkUseCase resolves the short links of the messages, counting
+// each click against its message.
+type FollowShortLinkUseCase struct {
+	linkRepo message.ShortLinkRepository
+}
+
+// NewFollowShortLinkUseCase creates a new FollowShortLinkUseCase.
+func NewFollowShortLinkUseCase(linkRepo message.ShortLinkRepository) *FollowShortLinkUseCase {
+	return &FollowShortLinkUseCase{
+		linkRepo: linkRepo,
+	}
+}
+
+// Execute records a click on a short link and returns the URL it redirects to.
+// A click that cannot be counted still redirects.
+func (uc *FollowShortLinkUseCase) Execute(ctx context.Context, code string) (string, error) {
+	if code == "" || len(code) > message.ShortLinkCodeLength {
+		return "", shared.NewNotFoundError("LINK_NOT_FOUND", "short link not found")
+	}
+
+	link, err := uc.linkRepo.FindByCode(ctx, code)
+	if err != nil {
+		return "", fmt.Errorf("failed to find short link: %w", err)
+	}
+	if link == nil {
+		return "", shared.NewNotFoundError("LINK_NOT_FOUND", "short link not found")
+	}
+
+	// Counting the click must not keep the recipient from the page
+	if err := uc.linkRepo.RecordClick(context.WithoutCancel(ctx), link.Code, time.Now().UnixMilli()); err != nil {
+		return link.TargetURL, err
+	}
+	return link.TargetURL, nil
+}
+
+// GetMessageLinksUseCase lists the short links of a message and their clicks.
+type GetMessageLinksUseCase struct {
+	messageRepo message.MessageRepository
+	linkRepo    message.ShortLinkRepository
+}
+
+// NewGetMessageLinksUseCase creates a new GetMessageLinksUseCase.
+func NewGetMessageLinksUseCase(messageRepo message.MessageRepository, linkRepo message.ShortLinkRepository) *GetMessageLinksUseCase {
+	return &GetMessageLinksUseCase{
+		messageRepo: messageRepo,
+		linkRepo:    linkRepo,
+	}
+}
+
+// Execute lists the short links of a message.
+func (uc *GetMessageLinksUseCase) Execute(ctx context.Context, id string) (*dtos.MessageLinksResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	messageID, err := message.NewMessageIDFromString(id)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid message ID: %w", err))
+	}
+	if _, err := uc.messageRepo.FindByID(ctx, messageID); err != nil {
+		return nil, fmt.Errorf("failed to find message: %w", err)
+	}
+
+	links, err := uc.linkRepo.FindByMessageID(ctx, messageID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.MessageLinksResponse{
+		MessageID: messageID.String(),
+		Links:     make([]*dtos.ShortLinkResponse, 0, len(links)),
+	}
+	for _, link := range links {
+		item := &dtos.ShortLinkResponse{
+			Code:      link.Code,
+			TargetURL: link.TargetURL,
+			ChannelID: link.ChannelID,
+			Clicks:    link.Clicks,
+			CreatedAt: link.CreatedAt,
+		}
+		if link.LastClickedAt > 0 {
+			lastClickedAt := link.LastClickedAt
+			item.LastClickedAt = &lastClickedAt
+		}
+		response.TotalClicks += link.Clicks
+		response.Links = append(response.Links, item)
+	}
+	return response, nil
+}
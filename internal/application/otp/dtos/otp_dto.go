@@ -0,0 +1,64 @@
+package dtos
+
+import (
+	"notification/internal/domain/otp"
+)
+
+// SendOTPRequest is the DTO for sending a one-time verification code.
+type SendOTPRequest struct {
+	// Recipient is the address or phone number the code is sent to
+	Recipient string `json:"recipient" binding:"required,max=255"`
+	// Purpose scopes the code, such as login or password_reset; verification
+	// when empty
+	Purpose string `json:"purpose,omitempty" binding:"max=100"`
+	// ChannelID and TemplateID override the configured OTP channel and
+	// template; the template gets the code in its {code} variable
+	ChannelID  string `json:"channelId,omitempty"`
+	TemplateID string `json:"templateId,omitempty"`
+	// Variables are passed on to the template, which cannot override code,
+	// purpose and expiresInMinutes
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	// AllowProd lets a deployment below prod send the code through a prod
+	// channel and template
+	AllowProd bool `json:"allowProd,omitempty"`
+}
+
+// VerifyOTPRequest is the DTO for verifying a one-time verification code.
+type VerifyOTPRequest struct {
+	// ID is the ID of the code answered by the send
+	ID   string `json:"id" binding:"required"`
+	Code string `json:"code" binding:"required,max=20"`
+}
+
+// OTPResponse is the DTO for a one-time verification code response, which
+// never holds the code itself.
+type OTPResponse struct {
+	ID           string `json:"id"`
+	Purpose      string `json:"purpose"`
+	Recipient    string `json:"recipient"`
+	ChannelID    string `json:"channelId"`
+	AttemptsLeft int    `json:"attemptsLeft"`
+	Verified     bool   `json:"verified"`
+	CreatedAt    int64  `json:"createdAt"`
+	ExpiresAt    int64  `json:"expiresAt"`
+	VerifiedAt   *int64 `json:"verifiedAt,omitempty"`
+}
+
+// FromCode converts a verification code to its response DTO.
+func FromCode(code *otp.Code) *OTPResponse {
+	response := &OTPResponse{
+		ID:           code.ID,
+		Purpose:      code.Purpose,
+		Recipient:    code.Recipient,
+		ChannelID:    code.ChannelID,
+		AttemptsLeft: code.AttemptsLeft(),
+		Verified:     code.IsVerified(),
+		CreatedAt:    code.CreatedAt.UnixMilli(),
+		ExpiresAt:    code.ExpiresAt.UnixMilli(),
+	}
+	if code.VerifiedAt != nil {
+		verifiedAt := code.VerifiedAt.UnixMilli()
+		response.VerifiedAt = &verifiedAt
+	}
+	return response
+}
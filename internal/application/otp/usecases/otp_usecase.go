@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/application/otp/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/otp"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+// Variables the OTP templates are rendered with
+const (
+	CodeVariable             = "code"
+	PurposeVariable          = "purpose"
+	ExpiresInMinutesVariable = "expiresInMinutes"
+)
+
+// OTPUseCase is the use case for sending and verifying the one-time
+// verification codes. A code is rendered by a template of the channel it goes
+// through and only its hash is stored.
+type OTPUseCase struct {
+	channelRepo         channel.ChannelRepository
+	templateRepo        template.TemplateRepository
+	codeRepo            otp.CodeRepository
+	renderer            services.TemplateRenderer
+	notificationService services.ExternalNotificationService
+	config              config.OTPConfig
+	// environment is the deployment stage the codes are sent from
+	environment shared.Environment
+}
+
+// NewOTPUseCase creates a use case instance, zero settings of the config
+// using the defaults of the codes.
+func NewOTPUseCase(
+	channelRepo channel.ChannelRepository,
+	templateRepo template.TemplateRepository,
+	codeRepo otp.CodeRepository,
+	renderer services.TemplateRenderer,
+	notificationService services.ExternalNotificationService,
+	cfg config.OTPConfig,
+) *OTPUseCase {
+	if cfg.CodeLength == 0 {
+		cfg.CodeLength = otp.DefaultCodeLength
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = int(otp.DefaultTTL / time.Second)
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = otp.DefaultMaxAttempts
+	}
+	return &OTPUseCase{
+		channelRepo:         channelRepo,
+		templateRepo:        templateRepo,
+		codeRepo:            codeRepo,
+		renderer:            renderer,
+		notificationService: notificationService,
+		config:              cfg,
+	}
+}
+
+// SetEnvironment keeps a deployment below prod from sending codes through the
+// prod channels and templates, unless the request allows it.
+func (uc *OTPUseCase) SetEnvironment(environment shared.Environment) {
+	uc.environment = environment
+}
+
+// Send generates a code for a recipient and sends it through the channel and
+// template of the request, else the configured ones.
+func (uc *OTPUseCase) Send(ctx context.Context, req *dtos.SendOTPRequest) (*dtos.OTPResponse, error) {
+	// 1. Resolve the channel and the template
+	ch, tmpl, err := uc.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Create the code, the recipient waiting between two codes for a purpose
+	code, value, err := otp.NewCode(req.Purpose, req.Recipient, ch.ID().String(),
+		uc.config.CodeLength, time.Duration(uc.config.TTL)*time.Second, uc.config.MaxAttempts)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if uc.config.ResendInterval > 0 {
+		latest, err := uc.codeRepo.FindLatest(ctx, code.Purpose, code.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil && !latest.IsVerified() {
+			wait := latest.CreatedAt.Add(time.Duration(uc.config.ResendInterval) * time.Second).Sub(code.CreatedAt)
+			if wait > 0 {
+				return nil, shared.NewDomainError(shared.ErrorKindQuotaExceeded, "OTP_RESEND_TOO_SOON",
+					fmt.Sprintf("a code was sent to the recipient for %s, wait %s before sending another",
+						code.Purpose, wait.Round(time.Second)), nil).WithRetryAfter(wait)
+			}
+		}
+	}
+
+	// 3. Render the code with the template
+	variables := make(map[string]interface{}, len(req.Variables)+3)
+	for name, v := range req.Variables {
+		variables[name] = v
+	}
+	variables[CodeVariable] = value
+	variables[PurposeVariable] = code.Purpose
+	variables[ExpiresInMinutesVariable] = (uc.config.TTL + 59) / 60
+	content, err := uc.renderer.Render(ctx, &services.RenderRequest{
+		Subject:   tmpl.Subject(),
+		Content:   tmpl.Content(),
+		Variables: message.NewVariables(ch.VariableDefaults().MergeUnder(variables)),
+		Strict:    tmpl.IsStrict(),
+		Headers:   tmpl.Headers(),
+	})
+	if err != nil {
+		return nil, shared.NewValidationError("RENDER_ERROR", err)
+	}
+
+	// 4. Persist the code, then send it. A code the provider failed to send is
+	// deleted so that it does not hold the recipient back from another one.
+	recipient, err := channel.NewRecipient(code.Recipient, code.Recipient, "to")
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if err := uc.codeRepo.Save(ctx, code); err != nil {
+		return nil, err
+	}
+	result := uc.notificationService.SendSingleNotification(ctx, &services.SendRequest{
+		Channel:   ch.WithOverrides(channel.NewRecipients([]*channel.Recipient{recipient}), nil),
+		Content:   content,
+		Variables: variables,
+		AllowProd: req.AllowProd,
+	})
+	if !result.Success {
+		uc.discard(ctx, code)
+		return nil, shared.NewUnavailableError("OTP_SEND_FAILED", "failed to send verification code", result.Error)
+	}
+
+	return dtos.FromCode(code), nil
+}
+
+// discard deletes a code that was not sent, even when the request was cancelled
+func (uc *OTPUseCase) discard(ctx context.Context, code *otp.Code) {
+	if err := uc.codeRepo.Delete(context.WithoutCancel(ctx), code.ID); err != nil {
+		logger.FromContext(ctx).Warn("Failed to delete the verification code that was not sent",
+			zap.String("otp_id", code.ID),
+			zap.Error(err))
+	}
+}
+
+// resolve finds the channel and the template a code is sent with and checks
+// they fit together
+func (uc *OTPUseCase) resolve(ctx context.Context, req *dtos.SendOTPRequest) (*channel.Channel, *template.Template, error) {
+	channelIDStr := strings.TrimSpace(req.ChannelID)
+	if channelIDStr == "" {
+		channelIDStr = uc.config.ChannelID
+	}
+	templateIDStr := strings.TrimSpace(req.TemplateID)
+	if templateIDStr == "" {
+		templateIDStr = uc.config.TemplateID
+	}
+	if channelIDStr == "" || templateIDStr == "" {
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST",
+			errors.New("channelId and templateId are required, no OTP channel and template are configured"))
+	}
+
+	channelID, err := channel.NewChannelIDFromString(channelIDStr)
+	if err != nil {
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel ID: %w", err))
+	}
+	ch, err := uc.channelRepo.FindByID(ctx, channelID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find channel: %w", err)
+	}
+	if err := ch.CanSendMessage(); err != nil {
+		return nil, nil, shared.NewValidationError("CHANNEL_CANNOT_SEND", err)
+	}
+	if err := services.CheckChannelEnvironment(ctx, uc.channelRepo, uc.environment, req.AllowProd, ch); err != nil {
+		return nil, nil, err
+	}
+
+	templateID, err := template.NewTemplateIDFromString(templateIDStr)
+	if err != nil {
+		return nil, nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
+	}
+	tmpl, err := uc.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find template: %w", err)
+	}
+	if tmpl.IsDeleted() {
+		return nil, nil, shared.NewNotFoundError("TEMPLATE_NOT_FOUND", "template not found")
+	}
+	if err := services.CheckSendEnvironment(uc.environment, req.AllowProd, "template", tmpl.ID().String(), tmpl.Environment()); err != nil {
+		return nil, nil, err
+	}
+	if !tmpl.MatchesType(ch.ChannelType()) {
+		return nil, nil, shared.NewValidationError("TEMPLATE_CHANNEL_MISMATCH",
+			fmt.Errorf("template is a %s template, channel is a %s channel", tmpl.ChannelType(), ch.ChannelType()))
+	}
+	return ch, tmpl, nil
+}
+
+// Verify checks a code, using up one of its attempts when it is wrong.
+func (uc *OTPUseCase) Verify(ctx context.Context, req *dtos.VerifyOTPRequest) (*dtos.OTPResponse, error) {
+	// 1. Query the code
+	code, err := uc.codeRepo.FindByID(ctx, strings.TrimSpace(req.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Check the value
+	attempts := code.Attempts
+	verifyErr := code.Verify(req.Code, time.Now())
+	switch {
+	case errors.Is(verifyErr, otp.ErrAlreadyVerified):
+		return nil, shared.NewConflictError("OTP_ALREADY_VERIFIED", verifyErr.Error())
+	case errors.Is(verifyErr, otp.ErrExpired):
+		return nil, shared.NewValidationError("OTP_EXPIRED", verifyErr)
+	case errors.Is(verifyErr, otp.ErrAttemptsExceeded):
+		return nil, shared.NewValidationError("OTP_ATTEMPTS_EXCEEDED", verifyErr)
+	}
+
+	// 3. Persist the attempt, unless a concurrent verification used it first
+	updated, err := uc.codeRepo.Update(ctx, code, attempts)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, shared.NewConflictError("OTP_CONFLICT", "verification code was tried concurrently, try again")
+	}
+	if verifyErr != nil {
+		return nil, shared.NewValidationError("OTP_INVALID",
+			fmt.Errorf("%w, %d attempt(s) left", verifyErr, code.AttemptsLeft()))
+	}
+
+	return dtos.FromCode(code), nil
+}
@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/application/otp/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/otp"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+	"notification/pkg/config"
+)
+
+// fakeCodeRepository keeps the codes in memory, its Update reporting a
+// concurrent verification when conflict is set
+type fakeCodeRepository struct {
+	codes    map[string]*otp.Code
+	latest   *otp.Code
+	conflict bool
+	deleted  []string
+}
+
+func (r *fakeCodeRepository) Save(ctx context.Context, code *otp.Code) error {
+	stored := *code
+	r.codes[code.ID] = &stored
+	r.latest = &stored
+	return nil
+}
+
+func (r *fakeCodeRepository) FindByID(ctx context.Context, id string) (*otp.Code, error) {
+	code, ok := r.codes[id]
+	if !ok {
+		return nil, shared.NewNotFoundError("OTP_NOT_FOUND", "verification code not found")
+	}
+	found := *code
+	return &found, nil
+}
+
+func (r *fakeCodeRepository) FindLatest(ctx context.Context, purpose, recipient string) (*otp.Code, error) {
+	return r.latest, nil
+}
+
+func (r *fakeCodeRepository) Update(ctx context.Context, code *otp.Code, attempts int) (bool, error) {
+	if r.conflict || r.codes[code.ID].Attempts != attempts {
+		return false, nil
+	}
+	stored := *code
+	r.codes[code.ID] = &stored
+	return true, nil
+}
+
+func (r *fakeCodeRepository) Delete(ctx context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	delete(r.codes, id)
+	if r.latest != nil && r.latest.ID == id {
+		r.latest = nil
+	}
+	return nil
+}
+
+// otpChannelRepository and otpTemplateRepository find the one channel and template
+type otpChannelRepository struct {
+	channel.ChannelRepository
+	ch *channel.Channel
+}
+
+func (r *otpChannelRepository) FindByID(ctx context.Context, id *channel.ChannelID) (*channel.Channel, error) {
+	return r.ch, nil
+}
+
+type otpTemplateRepository struct {
+	template.TemplateRepository
+	tmpl *template.Template
+}
+
+func (r *otpTemplateRepository) FindByID(ctx context.Context, id *template.TemplateID) (*template.Template, error) {
+	return r.tmpl, nil
+}
+
+// plainRenderer returns the content of the template as is
+type plainRenderer struct{}
+
+func (plainRenderer) Render(ctx context.Context, req *services.RenderRequest) (*services.RenderedContent, error) {
+	return &services.RenderedContent{Content: req.Content.String()}, nil
+}
+
+// recordingNotificationService keeps the codes it sends, failing when err is set
+type recordingNotificationService struct {
+	services.ExternalNotificationService
+	err  error
+	sent []string
+}
+
+func (s *recordingNotificationService) SendSingleNotification(ctx context.Context, req *services.SendRequest) *services.SendResult {
+	if s.err != nil {
+		return &services.SendResult{Error: s.err}
+	}
+	s.sent = append(s.sent, req.Variables[CodeVariable].(string))
+	return &services.SendResult{Success: true}
+}
+
+func newOTPUseCase(t *testing.T, resendInterval int) (*OTPUseCase, *fakeCodeRepository, *recordingNotificationService) {
+	t.Helper()
+	shared.InitializeChannelTypes()
+	name, err := channel.NewChannelName("otp")
+	require.NoError(t, err)
+	recipient, err := channel.NewRecipient("ops", "ops@example.com", "to")
+	require.NoError(t, err)
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeEmail, nil,
+		&shared.CommonSettings{Timeout: 10}, channel.NewChannelConfig(map[string]interface{}{}),
+		channel.NewRecipients([]*channel.Recipient{recipient}), nil)
+	require.NoError(t, err)
+
+	templateName, err := template.NewTemplateName("otp")
+	require.NoError(t, err)
+	content, err := template.NewTemplateContent("Your code is {code}")
+	require.NoError(t, err)
+	tmpl, err := template.NewTemplate(templateName, nil, shared.ChannelTypeEmail, nil, content, nil)
+	require.NoError(t, err)
+
+	codes := &fakeCodeRepository{codes: map[string]*otp.Code{}}
+	sender := &recordingNotificationService{}
+	useCase := NewOTPUseCase(&otpChannelRepository{ch: ch}, &otpTemplateRepository{tmpl: tmpl}, codes,
+		plainRenderer{}, sender, config.OTPConfig{
+			ChannelID:      ch.ID().String(),
+			TemplateID:     tmpl.ID().String(),
+			ResendInterval: resendInterval,
+		})
+	return useCase, codes, sender
+}
+
+func TestOTPUseCase_ThrottlesResends(t *testing.T) {
+	useCase, codes, _ := newOTPUseCase(t, 30)
+	req := &dtos.SendOTPRequest{Recipient: "user@example.com", Purpose: "login"}
+
+	_, err := useCase.Send(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = useCase.Send(context.Background(), req)
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, shared.ErrorKindQuotaExceeded, domainErr.Kind())
+	assert.Equal(t, "OTP_RESEND_TOO_SOON", domainErr.Code())
+	assert.Len(t, codes.codes, 1)
+
+	// A verified code does not hold back the next one
+	codes.latest.VerifiedAt = &codes.latest.CreatedAt
+	_, err = useCase.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, codes.codes, 2)
+}
+
+func TestOTPUseCase_DeletesTheCodeWhenTheSendFails(t *testing.T) {
+	useCase, codes, sender := newOTPUseCase(t, 30)
+	req := &dtos.SendOTPRequest{Recipient: "user@example.com"}
+
+	sender.err = errors.New("smtp: connection refused")
+	_, err := useCase.Send(context.Background(), req)
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "OTP_SEND_FAILED", domainErr.Code())
+	assert.Len(t, codes.deleted, 1)
+	assert.Empty(t, codes.codes)
+
+	// The failed code does not count against the resend interval
+	sender.err = nil
+	response, err := useCase.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Contains(t, codes.codes, response.ID)
+	assert.Len(t, sender.sent, 1)
+}
+
+func TestOTPUseCase_VerifyReportsConcurrentAttempts(t *testing.T) {
+	useCase, codes, sender := newOTPUseCase(t, 0)
+	response, err := useCase.Send(context.Background(), &dtos.SendOTPRequest{Recipient: "user@example.com"})
+	require.NoError(t, err)
+
+	codes.conflict = true
+	_, err = useCase.Verify(context.Background(), &dtos.VerifyOTPRequest{ID: response.ID, Code: sender.sent[0]})
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, shared.ErrorKindConflict, domainErr.Kind())
+	assert.Equal(t, "OTP_CONFLICT", domainErr.Code())
+	assert.Zero(t, codes.codes[response.ID].Attempts)
+
+	codes.conflict = false
+	verified, err := useCase.Verify(context.Background(), &dtos.VerifyOTPRequest{ID: response.ID, Code: sender.sent[0]})
+	require.NoError(t, err)
+	assert.True(t, verified.Verified)
+	assert.NotNil(t, verified.VerifiedAt)
+}
+
+func TestOTPUseCase_KeepsNonProdDeploymentsAwayFromProd(t *testing.T) {
+	useCase, codes, sender := newOTPUseCase(t, 0)
+	useCase.SetEnvironment(shared.EnvironmentStaging)
+	ch := useCase.channelRepo.(*otpChannelRepository).ch
+	tmpl := useCase.templateRepo.(*otpTemplateRepository).tmpl
+	req := &dtos.SendOTPRequest{Recipient: "user@example.com"}
+
+	ch.SetEnvironment(shared.EnvironmentProd)
+	_, err := useCase.Send(context.Background(), req)
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, shared.ErrorKindForbidden, domainErr.Kind())
+	assert.Equal(t, "PROD_SEND_BLOCKED", domainErr.Code())
+	assert.Contains(t, domainErr.Error(), "channel")
+
+	ch.SetEnvironment(shared.EnvironmentStaging)
+	tmpl.SetEnvironment(shared.EnvironmentProd)
+	_, err = useCase.Send(context.Background(), req)
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "PROD_SEND_BLOCKED", domainErr.Code())
+	assert.Contains(t, domainErr.Error(), "template")
+	assert.Empty(t, codes.codes)
+	assert.Empty(t, sender.sent)
+
+	ch.SetEnvironment(shared.EnvironmentProd)
+	req.AllowProd = true
+	_, err = useCase.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, sender.sent, 1)
+}
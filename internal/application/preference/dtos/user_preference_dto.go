@@ -0,0 +1,62 @@
+package dtos
+
+import (
+	"notification/internal/domain/preference"
+)
+
+// SetUserPreferenceRequest is the DTO for setting the preferences of a user.
+type SetUserPreferenceRequest struct {
+	// PreferredChannels are the channel types the user accepts messages from, empty for every type
+	PreferredChannels []string `json:"preferredChannels"`
+	// QuietHours is the daily do-not-disturb window, none when omitted
+	QuietHours *QuietHoursDTO `json:"quietHours"`
+	// OptOutCategories are the message categories the user does not want
+	OptOutCategories []string `json:"optOutCategories"`
+	// Timezone is the IANA time zone sends scheduled in local time reach the user in
+	Timezone string `json:"timezone"`
+}
+
+// QuietHoursDTO is the DTO for the quiet hours of a user.
+type QuietHoursDTO struct {
+	// Start and End are times of day formatted as HH:MM, a window ending
+	// before it starts spans midnight
+	Start string `json:"start" binding:"required"`
+	End   string `json:"end" binding:"required"`
+	// Timezone is an IANA time zone, UTC when empty
+	Timezone string `json:"timezone"`
+}
+
+// UserPreferenceResponse is the DTO for a user preference response.
+type UserPreferenceResponse struct {
+	UserID            string         `json:"userId"`
+	PreferredChannels []string       `json:"preferredChannels"`
+	QuietHours        *QuietHoursDTO `json:"quietHours,omitempty"`
+	OptOutCategories  []string       `json:"optOutCategories"`
+	Timezone          string         `json:"timezone,omitempty"`
+	UpdatedAt         int64          `json:"updatedAt"`
+}
+
+// FromUserPreference converts user preferences to their response DTO.
+func FromUserPreference(userPreference *preference.UserPreference) *UserPreferenceResponse {
+	response := &UserPreferenceResponse{
+		UserID:            userPreference.UserID,
+		PreferredChannels: userPreference.PreferredChannels,
+		OptOutCategories:  userPreference.OptOutCategories,
+		Timezone:          userPreference.Timezone,
+		UpdatedAt:         userPreference.UpdatedAt.UnixMilli(),
+	}
+	if response.PreferredChannels == nil {
+		response.PreferredChannels = []string{}
+	}
+	if response.OptOutCategories == nil {
+		response.OptOutCategories = []string{}
+	}
+	if quietHours := userPreference.QuietHours; quietHours != nil {
+		response.QuietHours = &QuietHoursDTO{
+			Start:    quietHours.Start,
+			End:      quietHours.End,
+			Timezone: quietHours.Timezone,
+		}
+	}
+	return response
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/preference/dtos"
+	"notification/internal/domain/preference"
+	"notification/internal/domain/shared"
+)
+
+// GetUserPreferenceUseCase is the use case for querying the preferences of a user.
+type GetUserPreferenceUseCase struct {
+	preferenceRepo preference.PreferenceRepository
+}
+
+// NewGetUserPreferenceUseCase creates a use case instance.
+func NewGetUserPreferenceUseCase(preferenceRepo preference.PreferenceRepository) *GetUserPreferenceUseCase {
+	return &GetUserPreferenceUseCase{
+		preferenceRepo: preferenceRepo,
+	}
+}
+
+// Execute gets the preferences of a user, identified by a user ID or the
+// target address the channels send to.
+func (uc *GetUserPreferenceUseCase) Execute(ctx context.Context, userID string) (*dtos.UserPreferenceResponse, error) {
+	userID = preference.NormalizeUserID(userID)
+	if userID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID is required"))
+	}
+
+	userPreference, err := uc.preferenceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtos.FromUserPreference(userPreference), nil
+}
+
+// UpdateUserPreferenceUseCase is the use case for the users setting and
+// clearing their preferences.
+type UpdateUserPreferenceUseCase struct {
+	preferenceRepo preference.PreferenceRepository
+}
+
+// NewUpdateUserPreferenceUseCase creates a use case instance.
+func NewUpdateUserPreferenceUseCase(preferenceRepo preference.PreferenceRepository) *UpdateUserPreferenceUseCase {
+	return &UpdateUserPreferenceUseCase{
+		preferenceRepo: preferenceRepo,
+	}
+}
+
+// Set replaces the preferences of a user. The messages sent afterwards to the
+// user skip the channel types it does not prefer, the categories it opted
+// out of and its quiet hours, and those scheduled in local time reach it in
+// its time zone.
+func (uc *UpdateUserPreferenceUseCase) Set(ctx context.Context, userID string, request *dtos.SetUserPreferenceRequest) (*dtos.UserPreferenceResponse, error) {
+	// 1. Validate the quiet hours
+	var quietHours *preference.QuietHours
+	if request.QuietHours != nil {
+		var err error
+		quietHours, err = preference.NewQuietHours(request.QuietHours.Start, request.QuietHours.End, request.QuietHours.Timezone)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", err)
+		}
+	}
+
+	// 2. Create the preferences
+	userPreference, err := preference.NewUserPreference(userID, request.PreferredChannels, quietHours, request.OptOutCategories)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if err := userPreference.SetTimezone(request.Timezone); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 3. Save them
+	if err := uc.preferenceRepo.Save(ctx, userPreference); err != nil {
+		return nil, err
+	}
+
+	return dtos.FromUserPreference(userPreference), nil
+}
+
+// Delete deletes the preferences of a user, who receives every message again.
+func (uc *UpdateUserPreferenceUseCase) Delete(ctx context.Context, userID string) error {
+	userID = preference.NormalizeUserID(userID)
+	if userID == "" {
+		return shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("user ID is required"))
+	}
+
+	return uc.preferenceRepo.Delete(ctx, userID)
+}
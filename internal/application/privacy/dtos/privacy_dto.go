@@ -0,0 +1,96 @@
+package dtos
+
+import (
+	"notification/internal/domain/privacy"
+)
+
+// PrivacyRequest is the DTO for exporting or erasing the data stored about a
+// recipient.
+type PrivacyRequest struct {
+	// Recipient is the address, phone number or user ID the data is stored
+	// under, matched ignoring case
+	Recipient string `json:"recipient" binding:"required,max=255"`
+}
+
+// PrivacyRequestResponse is the DTO for a privacy request response, which
+// holds the report of the rows exported or erased once it completed.
+type PrivacyRequestResponse struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// Recipient is empty once erased
+	Recipient   string         `json:"recipient,omitempty"`
+	SubjectHash string         `json:"subjectHash"`
+	Status      string         `json:"status"`
+	Report      privacy.Report `json:"report,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	RequestedBy string         `json:"requestedBy,omitempty"`
+	CreatedAt   int64          `json:"createdAt"`
+	CompletedAt *int64         `json:"completedAt,omitempty"`
+}
+
+// FromRequest converts a privacy request to its response DTO.
+func FromRequest(request *privacy.Request) *PrivacyRequestResponse {
+	response := &PrivacyRequestResponse{
+		ID:          request.ID,
+		Kind:        string(request.Kind),
+		Recipient:   request.Subject,
+		SubjectHash: request.SubjectHash,
+		Status:      string(request.Status),
+		Report:      request.Report,
+		Error:       request.Error,
+		RequestedBy: request.RequestedBy,
+		CreatedAt:   request.CreatedAt.UnixMilli(),
+	}
+	if request.CompletedAt != nil {
+		completedAt := request.CompletedAt.UnixMilli()
+		response.CompletedAt = &completedAt
+	}
+	return response
+}
+
+// PrivacyExportResponse is the DTO for the data exported by a privacy request.
+type PrivacyExportResponse struct {
+	RequestID string `json:"requestId"`
+	Recipient string `json:"recipient"`
+	// Data holds the rows stored about the recipient per kind of data
+	Data        privacy.Export `json:"data"`
+	CompletedAt int64          `json:"completedAt"`
+}
+
+// ListPrivacyAuditRequest is the DTO for listing the privacy audit trail.
+type ListPrivacyAuditRequest struct {
+	Limit int `form:"limit"`
+}
+
+// PrivacyAuditEntryResponse is the DTO for an entry of the privacy audit trail.
+type PrivacyAuditEntryResponse struct {
+	ID          string         `json:"id"`
+	RequestID   string         `json:"requestId"`
+	Kind        string         `json:"kind"`
+	Action      string         `json:"action"`
+	SubjectHash string         `json:"subjectHash"`
+	Actor       string         `json:"actor,omitempty"`
+	Report      privacy.Report `json:"report,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	OccurredAt  int64          `json:"occurredAt"`
+}
+
+// ListPrivacyAuditResponse is the DTO for the privacy audit trail, newest first.
+type ListPrivacyAuditResponse struct {
+	Entries []*PrivacyAuditEntryResponse `json:"entries"`
+}
+
+// FromAuditEntry converts an audit entry to its response DTO.
+func FromAuditEntry(entry *privacy.AuditEntry) *PrivacyAuditEntryResponse {
+	return &PrivacyAuditEntryResponse{
+		ID:          entry.ID,
+		RequestID:   entry.RequestID,
+		Kind:        string(entry.Kind),
+		Action:      string(entry.Action),
+		SubjectHash: entry.SubjectHash,
+		Actor:       entry.Actor,
+		Report:      entry.Report,
+		Error:       entry.Error,
+		OccurredAt:  entry.OccurredAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,202 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/application/privacy/dtos"
+	"notification/internal/domain/privacy"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// privacyRequestTimeout bounds a privacy request, which runs detached from
+// the HTTP request that made it
+const privacyRequestTimeout = 10 * time.Minute
+
+// Limits of the audit entries listed at once
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// PrivacyRequestUseCase is the use case for the data subject requests:
+// exporting or erasing every row stored about a recipient. A request runs in
+// the background; it is followed by its ID and every step of it is audited.
+type PrivacyRequestUseCase struct {
+	requestRepo privacy.RequestRepository
+	auditLog    privacy.AuditLog
+	store       privacy.SubjectDataStore
+}
+
+// NewPrivacyRequestUseCase creates a use case instance.
+func NewPrivacyRequestUseCase(
+	requestRepo privacy.RequestRepository,
+	auditLog privacy.AuditLog,
+	store privacy.SubjectDataStore,
+) *PrivacyRequestUseCase {
+	return &PrivacyRequestUseCase{
+		requestRepo: requestRepo,
+		auditLog:    auditLog,
+		store:       store,
+	}
+}
+
+// Export starts exporting the data stored about a recipient.
+func (uc *PrivacyRequestUseCase) Export(ctx context.Context, req *dtos.PrivacyRequest) (*dtos.PrivacyRequestResponse, error) {
+	return uc.start(ctx, privacy.RequestKindExport, req)
+}
+
+// Erase starts erasing the data stored about a recipient.
+func (uc *PrivacyRequestUseCase) Erase(ctx context.Context, req *dtos.PrivacyRequest) (*dtos.PrivacyRequestResponse, error) {
+	return uc.start(ctx, privacy.RequestKindErasure, req)
+}
+
+// start persists and audits a request, then runs it in the background
+func (uc *PrivacyRequestUseCase) start(ctx context.Context, kind privacy.RequestKind, req *dtos.PrivacyRequest) (*dtos.PrivacyRequestResponse, error) {
+	// 1. Create the request, made by the actor of the context
+	request, err := privacy.NewRequest(kind, req.Recipient, shared.ActorFromContext(ctx))
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 2. Persist and audit it before any data is touched
+	if err := uc.requestRepo.Save(ctx, request); err != nil {
+		return nil, err
+	}
+	if err := uc.auditLog.Append(ctx, privacy.NewAuditEntry(request, privacy.AuditActionRequested)); err != nil {
+		return nil, err
+	}
+	response := dtos.FromRequest(request)
+
+	// 3. Run it in the background, detached from the request
+	go func() {
+		runCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), privacyRequestTimeout)
+		defer cancel()
+		uc.run(runCtx, request)
+	}()
+
+	return response, nil
+}
+
+// run exports or erases the data of a request, then records and audits its
+// outcome. Failures to persist are logged, the data being already handled.
+func (uc *PrivacyRequestUseCase) run(ctx context.Context, request *privacy.Request) {
+	log := logger.FromContext(ctx).With(
+		zap.String("privacy_request_id", request.ID),
+		zap.String("kind", string(request.Kind)),
+	)
+	if err := request.Start(); err != nil {
+		log.Warn("Privacy request cannot run", zap.Error(err))
+		return
+	}
+	if err := uc.requestRepo.Update(ctx, request); err != nil {
+		log.Error("Failed to persist privacy request", zap.Error(err))
+	}
+
+	var err error
+	switch request.Kind {
+	case privacy.RequestKindExport:
+		var export privacy.Export
+		if export, err = uc.store.Export(ctx, request.Subject); err == nil {
+			request.Complete(export.Report(), export, time.Now())
+		}
+	default:
+		var report privacy.Report
+		if report, err = uc.store.Erase(ctx, request.Subject); err == nil {
+			request.Complete(report, nil, time.Now())
+		}
+	}
+	action := privacy.AuditActionCompleted
+	if err != nil {
+		log.Error("Privacy request failed", zap.Error(err))
+		request.Fail(err, time.Now())
+		action = privacy.AuditActionFailed
+	}
+
+	if err := uc.requestRepo.Update(ctx, request); err != nil {
+		log.Error("Failed to persist privacy request", zap.Error(err))
+	}
+	if err := uc.auditLog.Append(ctx, privacy.NewAuditEntry(request, action)); err != nil {
+		log.Error("Failed to audit privacy request", zap.Error(err))
+	}
+}
+
+// Resume runs again the requests a previous run left pending or running;
+// exporting and erasing being repeatable, they start over.
+func (uc *PrivacyRequestUseCase) Resume(ctx context.Context) error {
+	requests, err := uc.requestRepo.FindUnfinished(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		logger.FromContext(ctx).Info("Resuming privacy request", zap.String("privacy_request_id", request.ID))
+		runCtx, cancel := context.WithTimeout(ctx, privacyRequestTimeout)
+		uc.run(runCtx, request)
+		cancel()
+	}
+	return nil
+}
+
+// Get gets the status and report of a request.
+func (uc *PrivacyRequestUseCase) Get(ctx context.Context, id string) (*dtos.PrivacyRequestResponse, error) {
+	request, err := uc.requestRepo.FindByID(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return nil, err
+	}
+	return dtos.FromRequest(request), nil
+}
+
+// GetExport gets the data of a completed export request, unless the subject
+// was erased since.
+func (uc *PrivacyRequestUseCase) GetExport(ctx context.Context, id string) (*dtos.PrivacyExportResponse, error) {
+	request, err := uc.requestRepo.FindByID(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return nil, err
+	}
+	if request.Kind != privacy.RequestKindExport {
+		return nil, shared.NewNotFoundError("PRIVACY_EXPORT_NOT_FOUND", "privacy request is not an export")
+	}
+	if request.Status != privacy.RequestStatusCompleted {
+		return nil, shared.NewConflictError("PRIVACY_EXPORT_NOT_READY",
+			fmt.Sprintf("privacy export is %s", request.Status))
+	}
+	if request.Export == nil {
+		return nil, shared.NewNotFoundError("PRIVACY_EXPORT_ERASED", "privacy export was erased with its subject")
+	}
+	return &dtos.PrivacyExportResponse{
+		RequestID:   request.ID,
+		Recipient:   request.Subject,
+		Data:        request.Export,
+		CompletedAt: request.CompletedAt.UnixMilli(),
+	}, nil
+}
+
+// ListAudit lists the audit trail of the privacy requests, newest first.
+func (uc *PrivacyRequestUseCase) ListAudit(ctx context.Context, req *dtos.ListPrivacyAuditRequest) (*dtos.ListPrivacyAuditResponse, error) {
+	limit := req.Limit
+	if limit < 0 || limit > maxAuditLimit {
+		return nil, shared.NewValidationError("INVALID_REQUEST",
+			fmt.Errorf("limit must be between 0 and %d", maxAuditLimit))
+	}
+	if limit == 0 {
+		limit = defaultAuditLimit
+	}
+
+	entries, err := uc.auditLog.List(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	response := &dtos.ListPrivacyAuditResponse{
+		Entries: make([]*dtos.PrivacyAuditEntryResponse, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, dtos.FromAuditEntry(entry))
+	}
+	return response, nil
+}
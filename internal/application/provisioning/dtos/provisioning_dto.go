@@ -0,0 +1,64 @@
+package dtos
+
+import (
+	channeldtos "notification/internal/application/channel/dtos"
+	"notification/internal/domain/provisioning"
+)
+
+// ProvisionChannelRequest is the DTO for provisioning a channel in steps:
+// the channel is created disabled, its provider connection is verified, a
+// test message is sent when asked for, and it is enabled when enabled is set.
+type ProvisionChannelRequest struct {
+	channeldtos.CreateChannelRequest
+	// SendTestMessage sends a test message to the recipients before the channel is enabled
+	SendTestMessage bool   `json:"sendTestMessage"`
+	TestSubject     string `json:"testSubject"`
+	TestContent     string `json:"testContent"`
+}
+
+// SagaStepResponse is the DTO for the state of one saga step.
+type SagaStepResponse struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	StartedAt  int64  `json:"startedAt,omitempty"`
+	FinishedAt int64  `json:"finishedAt,omitempty"`
+}
+
+// SagaResponse is the DTO for a provisioning saga response.
+type SagaResponse struct {
+	SagaID string `json:"sagaId"`
+	Kind   string `json:"kind"`
+	Status string `json:"status"`
+	// ChannelID is set once the channel was created
+	ChannelID string             `json:"channelId,omitempty"`
+	Steps     []SagaStepResponse `json:"steps"`
+	Error     string             `json:"error,omitempty"`
+	CreatedAt int64              `json:"createdAt"`
+	UpdatedAt int64              `json:"updatedAt"`
+}
+
+// FromSaga converts a saga to its response DTO.
+func FromSaga(saga *provisioning.Saga) *SagaResponse {
+	steps := make([]SagaStepResponse, 0, len(saga.Steps()))
+	for _, step := range saga.Steps() {
+		steps = append(steps, SagaStepResponse{
+			Name:       step.Name,
+			Status:     string(step.Status),
+			Error:      step.Error,
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+		})
+	}
+
+	return &SagaResponse{
+		SagaID:    saga.ID().String(),
+		Kind:      saga.Kind(),
+		Status:    string(saga.Status()),
+		ChannelID: saga.Data()[provisioning.DataChannelID],
+		Steps:     steps,
+		Error:     saga.Error(),
+		CreatedAt: saga.Timestamps().CreatedAt,
+		UpdatedAt: saga.Timestamps().UpdatedAt,
+	}
+}
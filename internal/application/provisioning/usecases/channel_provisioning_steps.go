@@ -0,0 +1,215 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	channelusecases "notification/internal/application/channel/usecases"
+	"notification/internal/application/provisioning/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/provisioning"
+	"notification/internal/domain/services"
+)
+
+// Steps of the channel provisioning saga
+const (
+	StepCreateChannel    = "create_channel"
+	StepVerifyConnection = "verify_connection"
+	StepSendTestMessage  = "send_test_message"
+	StepEnableChannel    = "enable_channel"
+)
+
+// Defaults of the test message sent while provisioning a channel
+const (
+	defaultTestSubject = "Test message"
+	defaultTestContent = "This is a test message sent while provisioning the channel."
+)
+
+// ConnectionVerifier checks that the provider of a channel accepts
+// connections with its configuration, e.g. that the SMTP server of an email
+// channel accepts the credentials. verified is false for channel types that
+// have nothing to verify.
+type ConnectionVerifier interface {
+	Verify(ctx context.Context, ch *channel.Channel) (verified bool, err error)
+}
+
+// provisionRequest decodes the provisioning request from the saga payload.
+func provisionRequest(saga *provisioning.Saga) (*dtos.ProvisionChannelRequest, error) {
+	data, err := json.Marshal(saga.Payload())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode saga payload: %w", err)
+	}
+	var request dtos.ProvisionChannelRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, fmt.Errorf("failed to decode saga payload: %w", err)
+	}
+	return &request, nil
+}
+
+// provisionedChannel finds the channel the saga created.
+func provisionedChannel(ctx context.Context, channelRepo channel.ChannelRepository, saga *provisioning.Saga) (*channel.Channel, error) {
+	id, err := channel.NewChannelIDFromString(saga.Data()[provisioning.DataChannelID])
+	if err != nil {
+		return nil, fmt.Errorf("saga has no channel: %w", err)
+	}
+	return channelRepo.FindByID(ctx, id)
+}
+
+// createChannelStep creates the channel, disabled, in the legacy system and
+// locally. It is compensated by deleting the channel again.
+type createChannelStep struct {
+	createUseCase *channelusecases.CreateChannelUseCase
+	deleteUseCase *channelusecases.DeleteChannelUseCase
+}
+
+func (s *createChannelStep) Name() string {
+	return StepCreateChannel
+}
+
+func (s *createChannelStep) Execute(ctx context.Context, saga *provisioning.Saga) (bool, error) {
+	request, err := provisionRequest(saga)
+	if err != nil {
+		return false, err
+	}
+
+	create := request.CreateChannelRequest
+	create.Enabled = false
+	response, err := s.createUseCase.Execute(ctx, &create)
+	if err != nil {
+		return false, err
+	}
+
+	saga.Set(provisioning.DataChannelID, response.ChannelID)
+	return false, nil
+}
+
+func (s *createChannelStep) Compensate(ctx context.Context, saga *provisioning.Saga) error {
+	_, err := s.deleteUseCase.Execute(ctx, saga.Data()[provisioning.DataChannelID])
+	return err
+}
+
+// verifyConnectionStep checks the provider connection of the channel. It
+// changes nothing, so there is nothing to compensate.
+type verifyConnectionStep struct {
+	channelRepo channel.ChannelRepository
+	verifier    ConnectionVerifier
+}
+
+func (s *verifyConnectionStep) Name() string {
+	return StepVerifyConnection
+}
+
+func (s *verifyConnectionStep) Execute(ctx context.Context, saga *provisioning.Saga) (bool, error) {
+	ch, err := provisionedChannel(ctx, s.channelRepo, saga)
+	if err != nil {
+		return false, err
+	}
+
+	verified, err := s.verifier.Verify(ctx, ch)
+	if err != nil {
+		return false, fmt.Errorf("connection verification failed: %w", err)
+	}
+	return !verified, nil
+}
+
+func (s *verifyConnectionStep) Compensate(ctx context.Context, saga *provisioning.Saga) error {
+	return nil
+}
+
+// sendTestMessageStep sends a test message to the recipients of the channel
+// when the request asks for it. A sent message cannot be taken back, so there
+// is nothing to compensate.
+type sendTestMessageStep struct {
+	channelRepo channel.ChannelRepository
+	notifier    services.ExternalNotificationService
+}
+
+func (s *sendTestMessageStep) Name() string {
+	return StepSendTestMessage
+}
+
+func (s *sendTestMessageStep) Execute(ctx context.Context, saga *provisioning.Saga) (bool, error) {
+	request, err := provisionRequest(saga)
+	if err != nil {
+		return false, err
+	}
+	if !request.SendTestMessage {
+		return true, nil
+	}
+
+	ch, err := provisionedChannel(ctx, s.channelRepo, saga)
+	if err != nil {
+		return false, err
+	}
+	// The channel stays disabled until the last step; the test message is
+	// sent through an enabled copy that is not stored
+	ch.Enable()
+
+	content := &services.RenderedContent{Subject: request.TestSubject, Content: request.TestContent}
+	if content.Subject == "" {
+		content.Subject = defaultTestSubject
+	}
+	if content.Content == "" {
+		content.Content = defaultTestContent
+	}
+
+	result := s.notifier.SendSingleNotification(ctx, &services.SendRequest{
+		Channel:       ch,
+		Content:       content,
+		CorrelationID: saga.ID().String(),
+	})
+	if !result.Success {
+		if result.Error != nil {
+			return false, fmt.Errorf("test message failed: %w", result.Error)
+		}
+		return false, errors.New("test message failed: " + result.Message)
+	}
+	return false, nil
+}
+
+func (s *sendTestMessageStep) Compensate(ctx context.Context, saga *provisioning.Saga) error {
+	return nil
+}
+
+// enableChannelStep enables the channel when the request asks for an
+// enabled channel. It is compensated by disabling the channel again.
+type enableChannelStep struct {
+	setEnabledUseCase *channelusecases.SetChannelEnabledUseCase
+}
+
+func (s *enableChannelStep) Name() string {
+	return StepEnableChannel
+}
+
+func (s *enableChannelStep) Execute(ctx context.Context, saga *provisioning.Saga) (bool, error) {
+	request, err := provisionRequest(saga)
+	if err != nil {
+		return false, err
+	}
+	if !request.Enabled {
+		return true, nil
+	}
+
+	_, err = s.setEnabledUseCase.Enable(ctx, saga.Data()[provisioning.DataChannelID])
+	return false, err
+}
+
+func (s *enableChannelStep) Compensate(ctx context.Context, saga *provisioning.Saga) error {
+	_, err := s.setEnabledUseCase.Disable(ctx, saga.Data()[provisioning.DataChannelID])
+	return err
+}
+
+// toPayload converts the provisioning request to a saga payload.
+func toPayload(request *dtos.ProvisionChannelRequest) (map[string]interface{}, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
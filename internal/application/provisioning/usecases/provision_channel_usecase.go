@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	channelusecases "notification/internal/application/channel/usecases"
+	"notification/internal/application/provisioning/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/provisioning"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// provisioningTimeout bounds a provisioning saga, including its compensation.
+// The saga runs detached from the request that started it.
+const provisioningTimeout = 5 * time.Minute
+
+// ProvisionChannelUseCase is the use case for provisioning a channel through
+// a saga: create the channel, verify its connection, send a test message and
+// enable it, undoing the completed steps when one fails.
+type ProvisionChannelUseCase struct {
+	orchestrator *SagaOrchestrator
+}
+
+// NewProvisionChannelUseCase creates a use case instance.
+func NewProvisionChannelUseCase(
+	sagaRepo provisioning.SagaRepository,
+	createUseCase *channelusecases.CreateChannelUseCase,
+	deleteUseCase *channelusecases.DeleteChannelUseCase,
+	setEnabledUseCase *channelusecases.SetChannelEnabledUseCase,
+	channelRepo channel.ChannelRepository,
+	verifier ConnectionVerifier,
+	notifier services.ExternalNotificationService,
+) *ProvisionChannelUseCase {
+	return &ProvisionChannelUseCase{
+		orchestrator: NewSagaOrchestrator(provisioning.KindChannelProvisioning, sagaRepo,
+			&createChannelStep{createUseCase: createUseCase, deleteUseCase: deleteUseCase},
+			&verifyConnectionStep{channelRepo: channelRepo, verifier: verifier},
+			&sendTestMessageStep{channelRepo: channelRepo, notifier: notifier},
+			&enableChannelStep{setEnabledUseCase: setEnabledUseCase},
+		),
+	}
+}
+
+// Execute starts provisioning a channel and returns the running saga; its
+// progress is followed with GetProvisioningSagaUseCase.
+func (uc *ProvisionChannelUseCase) Execute(ctx context.Context, request *dtos.ProvisionChannelRequest) (*dtos.SagaResponse, error) {
+	// 1. Validate input parameters
+	if err := uc.validateRequest(request); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
+	// 2. Persist the saga with the request as its payload
+	payload, err := toPayload(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode provisioning request: %w", err)
+	}
+	saga, err := uc.orchestrator.Start(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start provisioning saga: %w", err)
+	}
+	response := dtos.FromSaga(saga)
+
+	// 3. Run the steps in the background, detached from the request
+	go func() {
+		runCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), provisioningTimeout)
+		defer cancel()
+		uc.orchestrator.Run(runCtx, saga)
+	}()
+
+	return response, nil
+}
+
+// Resume compensates the provisioning sagas interrupted by a restart.
+func (uc *ProvisionChannelUseCase) Resume(ctx context.Context) error {
+	return uc.orchestrator.Resume(ctx)
+}
+
+// validateRequest validates the request parameters.
+func (uc *ProvisionChannelUseCase) validateRequest(request *dtos.ProvisionChannelRequest) error {
+	if request == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+
+	if request.ChannelName == "" {
+		return fmt.Errorf("channel name is required")
+	}
+
+	if request.ChannelType == "" {
+		return fmt.Errorf("channel type is required")
+	}
+
+	return nil
+}
+
+// GetProvisioningSagaUseCase is the use case for following a provisioning saga.
+type GetProvisioningSagaUseCase struct {
+	sagaRepo provisioning.SagaRepository
+}
+
+// NewGetProvisioningSagaUseCase creates a use case instance.
+func NewGetProvisioningSagaUseCase(sagaRepo provisioning.SagaRepository) *GetProvisioningSagaUseCase {
+	return &GetProvisioningSagaUseCase{
+		sagaRepo: sagaRepo,
+	}
+}
+
+// Execute gets the state of a saga and its steps.
+func (uc *GetProvisioningSagaUseCase) Execute(ctx context.Context, sagaID string) (*dtos.SagaResponse, error) {
+	// 1. Validate input parameters
+	if sagaID == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("saga ID is required"))
+	}
+
+	// 2. Convert to domain object
+	id, err := provisioning.NewSagaIDFromString(sagaID)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid saga ID: %w", err))
+	}
+
+	// 3. Query the saga
+	saga, err := uc.sagaRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Convert to response DTO
+	return dtos.FromSaga(saga), nil
+}
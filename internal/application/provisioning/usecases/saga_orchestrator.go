@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/provisioning"
+	"notification/pkg/logger"
+)
+
+// SagaStep is one step of a saga. Steps read their input from the saga
+// payload and record what later steps and compensations need in its data.
+type SagaStep interface {
+	// Name identifies the step in the persisted saga
+	Name() string
+	// Execute runs the step; skipped reports that the step had nothing to do
+	Execute(ctx context.Context, saga *provisioning.Saga) (skipped bool, err error)
+	// Compensate undoes the completed step after a later step failed
+	Compensate(ctx context.Context, saga *provisioning.Saga) error
+}
+
+// SagaOrchestrator runs the steps of the sagas of one kind, persisting every
+// transition, and compensates the completed steps in reverse order when a
+// step fails.
+type SagaOrchestrator struct {
+	kind     string
+	sagaRepo provisioning.SagaRepository
+	steps    []SagaStep
+}
+
+// NewSagaOrchestrator creates an orchestrator running the given steps in order.
+func NewSagaOrchestrator(kind string, sagaRepo provisioning.SagaRepository, steps ...SagaStep) *SagaOrchestrator {
+	return &SagaOrchestrator{
+		kind:     kind,
+		sagaRepo: sagaRepo,
+		steps:    steps,
+	}
+}
+
+// Start creates and persists a saga with the given payload; Run executes it.
+func (o *SagaOrchestrator) Start(ctx context.Context, payload map[string]interface{}) (*provisioning.Saga, error) {
+	names := make([]string, 0, len(o.steps))
+	for _, step := range o.steps {
+		names = append(names, step.Name())
+	}
+
+	saga, err := provisioning.NewSaga(o.kind, names, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.sagaRepo.Save(ctx, saga); err != nil {
+		return nil, err
+	}
+	return saga, nil
+}
+
+// Run executes the pending steps of a saga and, when one fails, compensates
+// the completed ones. The saga is persisted after every transition.
+func (o *SagaOrchestrator) Run(ctx context.Context, saga *provisioning.Saga) {
+	for next := saga.NextStep(); next != nil; next = saga.NextStep() {
+		step, err := o.step(next.Name)
+		if err == nil {
+			err = saga.StartStep(next.Name)
+		}
+		if err != nil {
+			saga.Interrupt()
+			break
+		}
+		o.persist(ctx, saga)
+
+		skipped, err := step.Execute(ctx, saga)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Saga step failed",
+				zap.String("saga_id", saga.ID().String()),
+				zap.String("step", next.Name),
+				zap.Error(err),
+			)
+			_ = saga.FailStep(next.Name, err)
+		} else {
+			_ = saga.CompleteStep(next.Name, skipped)
+		}
+		o.persist(ctx, saga)
+	}
+
+	o.compensate(ctx, saga)
+}
+
+// Resume compensates the sagas left unfinished by an earlier run, e.g. when
+// the service stopped in the middle of a saga. Their steps are not resumed
+// since a step that was running may or may not have taken effect.
+func (o *SagaOrchestrator) Resume(ctx context.Context) error {
+	sagas, err := o.sagaRepo.FindUnfinished(ctx, o.kind)
+	if err != nil {
+		return err
+	}
+
+	for _, saga := range sagas {
+		logger.FromContext(ctx).Info("Compensating interrupted saga", zap.String("saga_id", saga.ID().String()))
+		saga.Interrupt()
+		o.persist(ctx, saga)
+		o.compensate(ctx, saga)
+	}
+	return nil
+}
+
+// compensate undoes the completed steps of a compensating saga in reverse order.
+func (o *SagaOrchestrator) compensate(ctx context.Context, saga *provisioning.Saga) {
+	for _, completed := range saga.StepsToCompensate() {
+		step, err := o.step(completed.Name)
+		if err == nil {
+			err = step.Compensate(ctx, saga)
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("Saga compensation failed",
+				zap.String("saga_id", saga.ID().String()),
+				zap.String("step", completed.Name),
+				zap.Error(err),
+			)
+		}
+		_ = saga.CompensateStep(completed.Name, err)
+		o.persist(ctx, saga)
+	}
+
+	if saga.Status() == provisioning.SagaStatusCompensating {
+		saga.FinishCompensation()
+		o.persist(ctx, saga)
+	}
+}
+
+// persist stores the state of a saga. A failure is logged rather than
+// stopping the saga, whose steps already took effect.
+func (o *SagaOrchestrator) persist(ctx context.Context, saga *provisioning.Saga) {
+	if err := o.sagaRepo.Update(ctx, saga); err != nil {
+		logger.FromContext(ctx).Error("Failed to persist saga",
+			zap.String("saga_id", saga.ID().String()),
+			zap.String("status", string(saga.Status())),
+			zap.Error(err),
+		)
+	}
+}
+
+// step returns the step of the orchestrator with the given name.
+func (o *SagaOrchestrator) step(name string) (SagaStep, error) {
+	for _, step := range o.steps {
+		if step.Name() == name {
+			return step, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown saga step %s", name)
+}
@@ -0,0 +1,61 @@
+package dtos
+
+import (
+	"notification/internal/domain/retention"
+)
+
+// ListRetentionReportsRequest is the DTO for listing the reports of the
+// retention job.
+type ListRetentionReportsRequest struct {
+	// TenantID lists the reports of one tenant; every tenant's when empty
+	TenantID string `form:"tenantId" binding:"max=255"`
+	Limit    int    `form:"limit"`
+}
+
+// RetentionReportResponse is the DTO for what one run of the retention job
+// did for one tenant.
+type RetentionReportResponse struct {
+	ID       string `json:"id"`
+	RunID    string `json:"runId"`
+	TenantID string `json:"tenantId"`
+	// ContentBefore and MetadataBefore are the creation times before which
+	// the content was purged and the messages deleted, absent when kept
+	ContentBefore   *int64 `json:"contentBefore,omitempty"`
+	MetadataBefore  *int64 `json:"metadataBefore,omitempty"`
+	ContentPurged   int    `json:"contentPurged"`
+	MessagesDeleted int    `json:"messagesDeleted"`
+	Error           string `json:"error,omitempty"`
+	StartedAt       int64  `json:"startedAt"`
+	CompletedAt     int64  `json:"completedAt"`
+}
+
+// ListRetentionReportsResponse is the DTO for the reports of the retention
+// job, newest first.
+type ListRetentionReportsResponse struct {
+	// Enabled tells whether the retention job runs
+	Enabled bool                       `json:"enabled"`
+	Reports []*RetentionReportResponse `json:"reports"`
+}
+
+// FromReport converts a report to its response DTO.
+func FromReport(report *retention.Report) *RetentionReportResponse {
+	response := &RetentionReportResponse{
+		ID:              report.ID,
+		RunID:           report.RunID,
+		TenantID:        report.TenantID,
+		ContentPurged:   report.ContentPurged,
+		MessagesDeleted: report.MessagesDeleted,
+		Error:           report.Error,
+		StartedAt:       report.StartedAt.UnixMilli(),
+		CompletedAt:     report.CompletedAt.UnixMilli(),
+	}
+	if report.ContentBefore != nil {
+		before := report.ContentBefore.UnixMilli()
+		response.ContentBefore = &before
+	}
+	if report.MetadataBefore != nil {
+		before := report.MetadataBefore.UnixMilli()
+		response.MetadataBefore = &before
+	}
+	return response
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"notification/internal/application/retention/dtos"
+	"notification/internal/domain/retention"
+	"notification/internal/domain/shared"
+)
+
+// Limits of the reports listed at once
+const (
+	defaultReportLimit = 100
+	maxReportLimit     = 1000
+)
+
+// ListRetentionReportsUseCase handles listing what the retention job purged
+// and deleted per tenant.
+type ListRetentionReportsUseCase struct {
+	reportRepo retention.ReportRepository
+	enabled    bool
+}
+
+// NewListRetentionReportsUseCase creates a new ListRetentionReportsUseCase;
+// enabled tells whether the retention job runs.
+func NewListRetentionReportsUseCase(reportRepo retention.ReportRepository, enabled bool) *ListRetentionReportsUseCase {
+	return &ListRetentionReportsUseCase{
+		reportRepo: reportRepo,
+		enabled:    enabled,
+	}
+}
+
+// Execute lists the latest reports, newest first.
+func (uc *ListRetentionReportsUseCase) Execute(ctx context.Context, req *dtos.ListRetentionReportsRequest) (*dtos.ListRetentionReportsResponse, error) {
+	limit := req.Limit
+	if limit < 0 || limit > maxReportLimit {
+		return nil, shared.NewValidationError("INVALID_REQUEST",
+			fmt.Errorf("limit must be between 0 and %d", maxReportLimit))
+	}
+	if limit == 0 {
+		limit = defaultReportLimit
+	}
+
+	reports, err := uc.reportRepo.List(shared.WithStaleReads(ctx), strings.TrimSpace(req.TenantID), limit)
+	if err != nil {
+		return nil, err
+	}
+	response := &dtos.ListRetentionReportsResponse{
+		Enabled: uc.enabled,
+		Reports: make([]*dtos.RetentionReportResponse, 0, len(reports)),
+	}
+	for _, report := range reports {
+		response.Reports = append(response.Reports, dtos.FromReport(report))
+	}
+	return response, nil
+}
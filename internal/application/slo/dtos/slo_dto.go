@@ -0,0 +1,75 @@
+package dtos
+
+import (
+	"notification/internal/domain/slo"
+)
+
+// BurnRateResponse is the DTO for the burn rate of an objective over the
+// windows of a burn-rate alert.
+type BurnRateResponse struct {
+	Alert string `json:"alert"`
+	// LongWindow and ShortWindow are in seconds
+	LongWindow  int64   `json:"longWindow"`
+	ShortWindow int64   `json:"shortWindow"`
+	Threshold   float64 `json:"threshold"`
+	Long        float64 `json:"long"`
+	Short       float64 `json:"short"`
+	Firing      bool    `json:"firing"`
+}
+
+// SLOStatusResponse is the DTO for how a delivery latency objective is met.
+type SLOStatusResponse struct {
+	Name string `json:"name"`
+	// Threshold is the delay, in seconds, from the creation of a message by
+	// which it is dispatched
+	Threshold int64 `json:"threshold"`
+	// Target and Compliance are percents of the messages dispatched within
+	// the threshold
+	Target float64 `json:"target"`
+	// Window is the rolling period, in seconds, the objective is measured over
+	Window               int64               `json:"window"`
+	Messages             int                 `json:"messages"`
+	DispatchedInTime     int                 `json:"dispatchedInTime"`
+	Compliance           float64             `json:"compliance"`
+	Met                  bool                `json:"met"`
+	ErrorBudgetRemaining float64             `json:"errorBudgetRemaining"` // percent, negative once exceeded
+	BurnRates            []*BurnRateResponse `json:"burnRates"`
+	EvaluatedAt          int64               `json:"evaluatedAt"`
+}
+
+// ListSLOsResponse is the DTO for the delivery latency objectives.
+type ListSLOsResponse struct {
+	// Enabled tells whether the objectives are tracked
+	Enabled    bool                 `json:"enabled"`
+	Objectives []*SLOStatusResponse `json:"objectives"`
+}
+
+// FromStatus converts the status of an objective to its response DTO.
+func FromStatus(status *slo.Status) *SLOStatusResponse {
+	objective := status.Objective
+	response := &SLOStatusResponse{
+		Name:                 objective.Name,
+		Threshold:            int64(objective.Threshold.Seconds()),
+		Target:               objective.Target * 100,
+		Window:               int64(objective.Window.Seconds()),
+		Messages:             status.Measurement.Total,
+		DispatchedInTime:     status.Measurement.Dispatched,
+		Compliance:           status.Compliance() * 100,
+		Met:                  status.Met(),
+		ErrorBudgetRemaining: status.BudgetRemaining() * 100,
+		BurnRates:            make([]*BurnRateResponse, 0, len(status.BurnRates)),
+		EvaluatedAt:          status.EvaluatedAt.UnixMilli(),
+	}
+	for _, burnRate := range status.BurnRates {
+		response.BurnRates = append(response.BurnRates, &BurnRateResponse{
+			Alert:       burnRate.Alert.Name,
+			LongWindow:  int64(burnRate.Alert.Long.Seconds()),
+			ShortWindow: int64(burnRate.Alert.Short.Seconds()),
+			Threshold:   burnRate.Alert.Threshold,
+			Long:        burnRate.Long,
+			Short:       burnRate.Short,
+			Firing:      burnRate.Firing(),
+		})
+	}
+	return response
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/slo/dtos"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// GetSLOStatusUseCase handles measuring the delivery latency objectives.
+type GetSLOStatusUseCase struct {
+	tracker *services.SLOTracker
+}
+
+// NewGetSLOStatusUseCase creates a new GetSLOStatusUseCase; a nil tracker
+// reports the objectives as not tracked.
+func NewGetSLOStatusUseCase(tracker *services.SLOTracker) *GetSLOStatusUseCase {
+	return &GetSLOStatusUseCase{
+		tracker: tracker,
+	}
+}
+
+// Execute measures every objective.
+func (uc *GetSLOStatusUseCase) Execute(ctx context.Context) (*dtos.ListSLOsResponse, error) {
+	response := &dtos.ListSLOsResponse{
+		Enabled:    uc.tracker != nil,
+		Objectives: []*dtos.SLOStatusResponse{},
+	}
+	if uc.tracker == nil {
+		return response, nil
+	}
+
+	statuses, err := uc.tracker.Status(shared.WithStaleReads(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		response.Objectives = append(response.Objectives, dtos.FromStatus(status))
+	}
+	return response, nil
+}
+
+// ExecuteObjective measures the objective of a name.
+func (uc *GetSLOStatusUseCase) ExecuteObjective(ctx context.Context, name string) (*dtos.SLOStatusResponse, error) {
+	if uc.tracker == nil {
+		return nil, shared.NewNotFoundError("SLO_NOT_FOUND", "SLOs are not tracked")
+	}
+	status, err := uc.tracker.StatusOf(shared.WithStaleReads(ctx), name)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, shared.NewNotFoundError("SLO_NOT_FOUND", fmt.Sprintf("SLO %s not found", name))
+	}
+	return dtos.FromStatus(status), nil
+}
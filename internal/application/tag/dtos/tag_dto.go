@@ -0,0 +1,34 @@
+package dtos
+
+// TagResponse represents a tag and the number of channels and templates carrying it.
+type TagResponse struct {
+	Tag           string `json:"tag"`
+	ChannelCount  int    `json:"channelCount"`
+	TemplateCount int    `json:"templateCount"`
+	TotalCount    int    `json:"totalCount"`
+}
+
+// ListTagsResponse represents the response for listing tags.
+type ListTagsResponse struct {
+	Items []*TagResponse `json:"items"`
+}
+
+// RenameTagRequest represents the request to rename a tag.
+type RenameTagRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+// MergeTagsRequest represents the request to merge tags into one.
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" binding:"required"`
+	Target  string   `json:"target" binding:"required"`
+}
+
+// TagChangeResponse represents the outcome of renaming or merging tags.
+type TagChangeResponse struct {
+	Sources          []string `json:"sources"`
+	Target           string   `json:"target"`
+	ChannelsUpdated  int      `json:"channelsUpdated"`
+	TemplatesUpdated int      `json:"templatesUpdated"`
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"notification/internal/application/tag/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+// ListTagsUseCase handles listing the tags in use.
+type ListTagsUseCase struct {
+	channelRepo  channel.ChannelRepository
+	templateRepo template.TemplateRepository
+}
+
+// NewListTagsUseCase creates a new ListTagsUseCase.
+func NewListTagsUseCase(channelRepo channel.ChannelRepository, templateRepo template.TemplateRepository) *ListTagsUseCase {
+	return &ListTagsUseCase{
+		channelRepo:  channelRepo,
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute lists every tag carried by a channel or template with its usage
+// counts, most used first.
+func (uc *ListTagsUseCase) Execute(ctx context.Context) (*dtos.ListTagsResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	// 1. Count the tags of channels and templates
+	channelCounts, err := uc.channelRepo.CountTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count channel tags: %w", err)
+	}
+	templateCounts, err := uc.templateRepo.CountTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count template tags: %w", err)
+	}
+
+	// 2. Combine the counts
+	tags := make(map[string]*dtos.TagResponse, len(channelCounts)+len(templateCounts))
+	tagFor := func(name string) *dtos.TagResponse {
+		tag, ok := tags[name]
+		if !ok {
+			tag = &dtos.TagResponse{Tag: name}
+			tags[name] = tag
+		}
+		return tag
+	}
+	for name, count := range channelCounts {
+		tag := tagFor(name)
+		tag.ChannelCount = count
+		tag.TotalCount += count
+	}
+	for name, count := range templateCounts {
+		tag := tagFor(name)
+		tag.TemplateCount = count
+		tag.TotalCount += count
+	}
+
+	// 3. Sort by usage, then by name
+	response := &dtos.ListTagsResponse{Items: make([]*dtos.TagResponse, 0, len(tags))}
+	for _, tag := range tags {
+		response.Items = append(response.Items, tag)
+	}
+	sort.Slice(response.Items, func(i, j int) bool {
+		a, b := response.Items[i], response.Items[j]
+		if a.TotalCount != b.TotalCount {
+			return a.TotalCount > b.TotalCount
+		}
+		return a.Tag < b.Tag
+	})
+
+	return response, nil
+}
@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"notification/internal/application/tag/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+// MergeTagsUseCase handles renaming tags and merging them into one.
+type MergeTagsUseCase struct {
+	channelRepo  channel.ChannelRepository
+	templateRepo template.TemplateRepository
+}
+
+// NewMergeTagsUseCase creates a new MergeTagsUseCase.
+func NewMergeTagsUseCase(channelRepo channel.ChannelRepository, templateRepo template.TemplateRepository) *MergeTagsUseCase {
+	return &MergeTagsUseCase{
+		channelRepo:  channelRepo,
+		templateRepo: templateRepo,
+	}
+}
+
+// Rename renames a tag on every channel and template carrying it.
+func (uc *MergeTagsUseCase) Rename(ctx context.Context, req *dtos.RenameTagRequest) (*dtos.TagChangeResponse, error) {
+	if req == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+
+	return uc.Merge(ctx, &dtos.MergeTagsRequest{
+		Sources: []string{req.From},
+		Target:  req.To,
+	})
+}
+
+// Merge replaces the source tags with the target tag on every channel and
+// template carrying one of them. Channels and templates are updated
+// separately, so a failure on templates leaves the channels merged; running
+// the merge again completes it.
+func (uc *MergeTagsUseCase) Merge(ctx context.Context, req *dtos.MergeTagsRequest) (*dtos.TagChangeResponse, error) {
+	// 1. Validate request
+	if req == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+
+	target := strings.TrimSpace(req.Target)
+	if target == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("target tag is required"))
+	}
+
+	var sources []string
+	for _, source := range req.Sources {
+		source = strings.TrimSpace(source)
+		if source != "" && source != target {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("at least one source tag different from the target is required"))
+	}
+
+	// 2. Replace the tags
+	channelsUpdated, err := uc.channelRepo.ReplaceTags(ctx, sources, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update channel tags: %w", err)
+	}
+	templatesUpdated, err := uc.templateRepo.ReplaceTags(ctx, sources, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update template tags: %w", err)
+	}
+
+	return &dtos.TagChangeResponse{
+		Sources:          sources,
+		Target:           target,
+		ChannelsUpdated:  channelsUpdated,
+		TemplatesUpdated: templatesUpdated,
+	}, nil
+}
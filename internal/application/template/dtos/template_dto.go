@@ -1,6 +1,7 @@
 package dtos
 
 import (
+	"strings"
 	"time"
 
 	"notification/internal/domain/shared"
@@ -10,33 +11,204 @@ import (
 // CreateTemplateRequest represents the request to create a template.
 type CreateTemplateRequest struct {
 	Name        string                `json:"name" validate:"required,min=1,max=100"`
+	Description string                `json:"description,omitempty" validate:"max=500"`
 	ChannelType shared.ChannelType    `json:"channelType" validate:"required"`
 	Subject     string                `json:"subject,omitempty" validate:"max=200"`
 	Content     string                `json:"content" validate:"required"`
+	// Variables are declared besides those the subject and content use
 	Variables   []string              `json:"variables,omitempty"`
 	Tags        []string              `json:"tags,omitempty"`
+	Strict      bool                  `json:"strict,omitempty"`
+	Attachments []*AttachmentDTO      `json:"attachments,omitempty"`
+	// Headers are extra email headers, such as Reply-To or List-Unsubscribe,
+	// their values rendered with the variables
+	Headers     map[string]string     `json:"headers,omitempty"`
+	Owner       string                `json:"owner,omitempty"`
+	Team        string                `json:"team,omitempty"`
+	// Environment is dev, staging or prod; empty serves every environment
+	Environment string                `json:"environment,omitempty"`
 	Settings    *shared.CommonSettings `json:"settings,omitempty"`
 }
 
+// AttachmentDTO is a file sent with the emails of a template. An inline
+// attachment is shown in the HTML content, which refers to it with
+// cid:<contentId>; the content ID defaults to the filename.
+type AttachmentDTO struct {
+	Filename    string `json:"filename" validate:"required"`
+	ContentType string `json:"contentType,omitempty"`
+	// Content is the base64 encoded file
+	Content   []byte `json:"content" swaggertype:"string" format:"base64"`
+	Inline    bool   `json:"inline,omitempty"`
+	ContentID string `json:"contentId,omitempty"`
+}
+
+// AttachmentResponse describes an attachment of a template, without its content.
+type AttachmentResponse struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int    `json:"size"`
+	Inline      bool   `json:"inline,omitempty"`
+	ContentID   string `json:"contentId,omitempty"`
+}
+
+// ToAttachments converts attachment DTOs to the attachments of a template.
+func ToAttachments(items []*AttachmentDTO) (*template.Attachments, error) {
+	attachments := make([]*template.Attachment, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		attachment, err := template.NewAttachment(item.Filename, item.ContentType, item.Content, item.Inline, item.ContentID)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return template.NewAttachments(attachments)
+}
+
 // UpdateTemplateRequest represents the request to update a template.
 type UpdateTemplateRequest struct {
 	Name        *string               `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string               `json:"description,omitempty" validate:"omitempty,max=500"`
 	Subject     *string               `json:"subject,omitempty" validate:"omitempty,max=200"`
 	Content     *string               `json:"content,omitempty" validate:"omitempty,min=1"`
+	// Variables replace the declared ones when set; an empty list removes them
 	Variables   []string              `json:"variables,omitempty"`
 	Tags        []string              `json:"tags,omitempty"`
+	Strict      *bool                 `json:"strict,omitempty"`
+	// Attachments replace those of the template when set; an empty list removes them
+	Attachments []*AttachmentDTO      `json:"attachments,omitempty"`
+	// Headers replace those of the template when set; an empty object removes them
+	Headers     map[string]string     `json:"headers,omitempty"`
+	Owner       *string               `json:"owner,omitempty"`
+	Team        *string               `json:"team,omitempty"`
+	Environment *string               `json:"environment,omitempty"`
 	Settings    *shared.CommonSettings `json:"settings,omitempty"`
 }
 
+// ReplaceTemplateRequest represents the request to fully replace a template.
+// Every mutable field is overwritten; omitted optional fields are cleared.
+type ReplaceTemplateRequest struct {
+	Name        string              `json:"name" validate:"required,min=1,max=100"`
+	Description string              `json:"description,omitempty" validate:"max=500"`
+	ChannelType *shared.ChannelType `json:"channelType,omitempty"`
+	Subject     string              `json:"subject,omitempty" validate:"max=200"`
+	Content     string              `json:"content" validate:"required"`
+	Variables   []string            `json:"variables,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Strict      bool                `json:"strict,omitempty"`
+	Attachments []*AttachmentDTO    `json:"attachments,omitempty"`
+	Headers     map[string]string   `json:"headers,omitempty"`
+	Owner       string              `json:"owner,omitempty"`
+	Team        string              `json:"team,omitempty"`
+	Environment string              `json:"environment,omitempty"`
+	Settings    *shared.CommonSettings `json:"settings,omitempty"`
+}
+
+// ToUpdateTemplateRequest converts a replace request into an update request
+// with every field set. Settings stay nil when omitted, so the caller has to
+// clear them itself.
+func (req *ReplaceTemplateRequest) ToUpdateTemplateRequest() *UpdateTemplateRequest {
+	variables := req.Variables
+	if variables == nil {
+		variables = []string{}
+	}
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	attachments := req.Attachments
+	if attachments == nil {
+		attachments = []*AttachmentDTO{}
+	}
+	headers := req.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+
+	return &UpdateTemplateRequest{
+		Name:        &req.Name,
+		Description: &req.Description,
+		Subject:     &req.Subject,
+		Content:     &req.Content,
+		Variables:   variables,
+		Tags:        tags,
+		Strict:      &req.Strict,
+		Attachments: attachments,
+		Headers:     headers,
+		Owner:       &req.Owner,
+		Team:        &req.Team,
+		Environment: &req.Environment,
+		Settings:    req.Settings,
+	}
+}
+
+// DeleteTemplateRequest controls what happens to channels that still reference a deleted template.
+// By default deletion is refused; Force detaches the channels from the template and
+// ReassignTo moves them to another template of the same channel type.
+type DeleteTemplateRequest struct {
+	Force      bool   `json:"force,omitempty"`
+	ReassignTo string `json:"reassignTo,omitempty"`
+}
+
+// LintTemplateRequest represents the request to lint a template's variables.
+type LintTemplateRequest struct {
+	Subject   string   `json:"subject,omitempty"`
+	Content   string   `json:"content" validate:"required"`
+	Variables []string `json:"variables"`
+}
+
+// LintTemplateResponse reports the variables a template uses and those it uses without declaring them.
+type LintTemplateResponse struct {
+	Used       []string `json:"used"`
+	Undeclared []string `json:"undeclared"`
+	Valid      bool     `json:"valid"`
+}
+
+// ValidateTemplateRequest represents the request to validate a template before saving it.
+// Variables are checked against the declared list only when one is given.
+type ValidateTemplateRequest struct {
+	ChannelType shared.ChannelType `json:"channelType" validate:"required"`
+	Subject     string             `json:"subject,omitempty"`
+	Content     string             `json:"content"`
+	Variables   []string           `json:"variables,omitempty"`
+}
+
+// TemplateIssue represents a single problem found while validating a template.
+type TemplateIssue struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// ValidateTemplateResponse represents the result of validating a template.
+type ValidateTemplateResponse struct {
+	Valid      bool             `json:"valid"`
+	Errors     []*TemplateIssue `json:"errors"`
+	Warnings   []*TemplateIssue `json:"warnings"`
+	Variables  []string         `json:"variables"`
+	Undeclared []string         `json:"undeclared,omitempty"`
+}
+
 // TemplateResponse represents the response for a template.
 type TemplateResponse struct {
 	ID          string                `json:"id"`
 	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
 	ChannelType shared.ChannelType    `json:"channelType"`
 	Subject     string                `json:"subject,omitempty"`
 	Content     string                `json:"content"`
 	Variables   []string              `json:"variables,omitempty"`
 	Tags        []string              `json:"tags,omitempty"`
+	Strict      bool                  `json:"strict"`
+	Attachments []*AttachmentResponse `json:"attachments,omitempty"`
+	Headers     map[string]string     `json:"headers,omitempty"`
+	Owner       string                `json:"owner,omitempty"`
+	Team        string                `json:"team,omitempty"`
+	Environment string                `json:"environment,omitempty"`
 	Version     int                   `json:"version"`
 	Settings    *shared.CommonSettings `json:"settings,omitempty"`
 	CreatedAt   time.Time             `json:"createdAt"`
@@ -47,6 +219,9 @@ type TemplateResponse struct {
 type ListTemplatesRequest struct {
 	ChannelType    *shared.ChannelType `json:"channelType,omitempty"`
 	Tags           []string            `json:"tags,omitempty"`
+	Owner          string              `json:"owner,omitempty"`
+	Team           string              `json:"team,omitempty"`
+	Environment    string              `json:"environment,omitempty"`
 	SkipCount      int                 `json:"skipCount,omitempty" validate:"omitempty,min=0"`
 	MaxResultCount int                 `json:"maxResultCount,omitempty" validate:"omitempty,min=1,max=100"`
 }
@@ -60,6 +235,38 @@ type ListTemplatesResponse struct {
 	HasMore        bool                `json:"hasMore"`
 }
 
+// TemplateUsageResponse represents which channels reference a template and how much they are used.
+type TemplateUsageResponse struct {
+	TemplateID         string                  `json:"templateId"`
+	TemplateName       string                  `json:"templateName"`
+	ChannelCount       int                     `json:"channelCount"`
+	WindowDays         int                     `json:"windowDays"`
+	RecentMessageCount int                     `json:"recentMessageCount"`
+	Channels           []*TemplateUsageChannel `json:"channels"`
+}
+
+// TemplateUsageChannel represents a channel that references a template.
+type TemplateUsageChannel struct {
+	ChannelID          string `json:"channelId"`
+	ChannelName        string `json:"channelName"`
+	ChannelType        string `json:"channelType"`
+	Enabled            bool   `json:"enabled"`
+	LastUsed           *int64 `json:"lastUsed,omitempty"`
+	RecentMessageCount int    `json:"recentMessageCount"`
+}
+
+// CompatibilityResponse lists the template content each channel type can send.
+type CompatibilityResponse struct {
+	ChannelTypes []*ChannelTypeCompatibility `json:"channelTypes"`
+}
+
+// ChannelTypeCompatibility represents the template content a channel type can send.
+type ChannelTypeCompatibility struct {
+	ChannelType string `json:"channelType"`
+	DisplayName string `json:"displayName"`
+	shared.ContentCapabilities
+}
+
 // ToTemplateResponse converts a template entity to a response DTO.
 func ToTemplateResponse(t *template.Template) *TemplateResponse {
 	if t == nil {
@@ -73,11 +280,17 @@ func ToTemplateResponse(t *template.Template) *TemplateResponse {
 	response := &TemplateResponse{
 		ID:          t.ID().String(),
 		Name:        t.Name().String(),
+		Description: t.Description().String(),
 		ChannelType: t.ChannelType(),
 		Content:     t.Content().String(),
-		Variables:   t.GetAllVariables(),
+		Variables:   templateVariables(t),
 		Tags:        t.Tags().ToSlice(),
+		Strict:      t.IsStrict(),
+		Owner:       t.Ownership().Owner,
+		Team:        t.Ownership().Team,
+		Environment: t.Environment().String(),
 		Version:     t.Version().Int(),
+		Settings:    t.Settings(),
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 	}
@@ -86,9 +299,39 @@ func ToTemplateResponse(t *template.Template) *TemplateResponse {
 		response.Subject = t.Subject().String()
 	}
 
+	if headers := t.Headers(); len(headers) > 0 {
+		response.Headers = headers
+	}
+
+	for _, attachment := range t.Attachments().ToSlice() {
+		response.Attachments = append(response.Attachments, &AttachmentResponse{
+			Filename:    attachment.Filename(),
+			ContentType: attachment.ContentType(),
+			Size:        len(attachment.Content()),
+			Inline:      attachment.IsInline(),
+			ContentID:   attachment.ContentID(),
+		})
+	}
+
 	return response
 }
 
+// templateVariables lists the declared variables of a template followed by
+// the undeclared ones its subject and content use.
+func templateVariables(t *template.Template) []string {
+	variables := t.DeclaredVariables()
+	declared := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		declared[variable] = true
+	}
+	for _, variable := range t.GetAllVariables() {
+		if !declared[variable] {
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
+
 // ToTemplateResponseList converts a list of template entities to response DTOs.
 func ToTemplateResponseList(templates []*template.Template) []*TemplateResponse {
 	responses := make([]*TemplateResponse, len(templates))
@@ -109,6 +352,14 @@ func (req *ListTemplatesRequest) ToTemplateFilter() *template.TemplateFilter {
 	if len(req.Tags) > 0 {
 		filter.WithTags(req.Tags)
 	}
+
+	if owner := strings.TrimSpace(req.Owner); owner != "" {
+		filter.WithOwner(owner)
+	}
+
+	if team := strings.TrimSpace(req.Team); team != "" {
+		filter.WithTeam(team)
+	}
 	
 	return filter
 }
@@ -5,12 +5,16 @@ import (
 	"fmt"
 
 	"notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 )
 
 // CreateTemplateUseCase handles the creation of templates.
 type CreateTemplateUseCase struct {
 	templateRepo template.TemplateRepository
+	// ownershipRequired rejects templates with neither an owner nor a team
+	ownershipRequired bool
+	changes           shared.ChangeNotifier
 }
 
 // NewCreateTemplateUseCase creates a new CreateTemplateUseCase.
@@ -20,17 +24,27 @@ func NewCreateTemplateUseCase(templateRepo template.TemplateRepository) *CreateT
 	}
 }
 
+// SetOwnershipRequired sets whether templates must be created with an owner or a team.
+func (uc *CreateTemplateUseCase) SetOwnershipRequired(required bool) {
+	uc.ownershipRequired = required
+}
+
+// SetChangeNotifier makes the use case report the templates it creates.
+func (uc *CreateTemplateUseCase) SetChangeNotifier(notifier shared.ChangeNotifier) {
+	uc.changes = notifier
+}
+
 // Execute creates a new template.
 func (uc *CreateTemplateUseCase) Execute(ctx context.Context, req *dtos.CreateTemplateRequest) (*dtos.TemplateResponse, error) {
 	// Validate request
 	if req == nil {
-		return nil, fmt.Errorf("request cannot be nil")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
 	}
 
 	// Create template name
 	templateName, err := template.NewTemplateName(req.Name)
 	if err != nil {
-		return nil, fmt.Errorf("invalid template name: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template name: %w", err))
 	}
 
 	// Check if template with same name already exists
@@ -39,13 +53,13 @@ func (uc *CreateTemplateUseCase) Execute(ctx context.Context, req *dtos.CreateTe
 		return nil, fmt.Errorf("failed to check template existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("template with name '%s' already exists", req.Name)
+		return nil, shared.NewConflictError("TEMPLATE_NAME_CONFLICT", fmt.Sprintf("template with name '%s' already exists", req.Name))
 	}
 
 	// Create template content
 	templateContent, err := template.NewTemplateContent(req.Content)
 	if err != nil {
-		return nil, fmt.Errorf("invalid template content: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template content: %w", err))
 	}
 
 	// Create subject if provided
@@ -53,19 +67,52 @@ func (uc *CreateTemplateUseCase) Execute(ctx context.Context, req *dtos.CreateTe
 	if req.Subject != "" {
 		subject, err = template.NewSubject(req.Subject)
 		if err != nil {
-			return nil, fmt.Errorf("invalid subject: %w", err)
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid subject: %w", err))
 		}
 	}
 
-	// Create description (empty for now)
-	description, err := template.NewDescription("")
+	// Create description
+	description, err := template.NewDescription(req.Description)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid description: %w", err))
+	}
+
+	// Check the delivery settings
+	settings, err := toTemplateSettings(req.Settings)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create description: %w", err)
+		return nil, err
 	}
 
 	// Create tags
 	tags := template.NewTags(req.Tags)
 
+	// Create attachments, which only emails carry
+	attachments, err := dtos.ToAttachments(req.Attachments)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid attachments: %w", err))
+	}
+	if !attachments.IsEmpty() && req.ChannelType != shared.ChannelTypeEmail {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("attachments are only supported by email templates"))
+	}
+	if len(req.Headers) > 0 && req.ChannelType != shared.ChannelTypeEmail {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("headers are only supported by email templates"))
+	}
+
+	// Create ownership
+	ownership, err := shared.NewOwnership(req.Owner, req.Team)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid ownership: %w", err))
+	}
+	if uc.ownershipRequired && ownership.IsEmpty() {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("an owner or a team is required"))
+	}
+
+	// Create environment
+	environment, err := shared.NewEnvironment(req.Environment)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+
 	// Create template entity
 	templateEntity, err := template.NewTemplate(
 		templateName,
@@ -76,8 +123,17 @@ func (uc *CreateTemplateUseCase) Execute(ctx context.Context, req *dtos.CreateTe
 		tags,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create template: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to create template: %w", err))
+	}
+	templateEntity.SetStrict(req.Strict)
+	templateEntity.SetAttachments(attachments)
+	if err := templateEntity.SetHeaders(req.Headers); err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid headers: %w", err))
 	}
+	templateEntity.SetOwnership(ownership)
+	templateEntity.SetEnvironment(environment)
+	templateEntity.SetDeclaredVariables(req.Variables)
+	templateEntity.SetSettings(settings)
 
 	// Save template
 	if err := uc.templateRepo.Save(ctx, templateEntity); err != nil {
@@ -85,5 +141,7 @@ func (uc *CreateTemplateUseCase) Execute(ctx context.Context, req *dtos.CreateTe
 	}
 
 	// Convert to response
-	return dtos.ToTemplateResponse(templateEntity), nil
+	response := dtos.ToTemplateResponse(templateEntity)
+	notifyTemplateChange(ctx, uc.changes, shared.ChangeTemplateCreated, response.ID, nil, response)
+	return response, nil
 }
\ No newline at end of file
@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"strings"
 
+	"notification/internal/application/template/dtos"
 	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
 	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 	"notification/pkg/config"
@@ -19,44 +21,97 @@ import (
 type DeleteTemplateUseCase struct {
 	templateRepo template.TemplateRepository
 	channelRepo  channel.ChannelRepository
+	integrity    *services.TemplateIntegrityService
 	config       *config.Config
+	changes      shared.ChangeNotifier
 }
 
 // NewDeleteTemplateUseCase creates a new DeleteTemplateUseCase.
 func NewDeleteTemplateUseCase(
 	templateRepo template.TemplateRepository,
 	channelRepo channel.ChannelRepository,
+	integrity *services.TemplateIntegrityService,
 	config *config.Config,
 ) *DeleteTemplateUseCase {
 	return &DeleteTemplateUseCase{
 		templateRepo: templateRepo,
 		channelRepo:  channelRepo,
+		integrity:    integrity,
 		config:       config,
 	}
 }
 
-// Execute deletes a template.
-func (uc *DeleteTemplateUseCase) Execute(ctx context.Context, id string) error {
+// SetChangeNotifier makes the use case report the templates it deletes.
+func (uc *DeleteTemplateUseCase) SetChangeNotifier(notifier shared.ChangeNotifier) {
+	uc.changes = notifier
+}
+
+// Execute deletes a template. A template still referenced by channels is only
+// deleted when the request forces it or names a replacement; otherwise a
+// TEMPLATE_IN_USE conflict is returned.
+func (uc *DeleteTemplateUseCase) Execute(ctx context.Context, id string, req *dtos.DeleteTemplateRequest) error {
+	_, err := uc.ExecuteWithVersion(ctx, id, req)
+	return err
+}
+
+// ExecuteWithVersion deletes the template and returns the version the
+// deletion gives it, one above its last.
+func (uc *DeleteTemplateUseCase) ExecuteWithVersion(ctx context.Context, id string, req *dtos.DeleteTemplateRequest) (int64, error) {
+	if req == nil {
+		req = &dtos.DeleteTemplateRequest{}
+	}
+
 	// Validate input
 	if id == "" {
-		return fmt.Errorf("template ID cannot be empty")
+		return 0, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("template ID cannot be empty"))
 	}
 
 	// Create template ID
 	templateID, err := template.NewTemplateIDFromString(id)
 	if err != nil {
-		return fmt.Errorf("invalid template ID: %w", err)
+		return 0, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
 	}
 
 	// Get template entity before deletion (needed for legacy channel updates)
 	templateEntity, err := uc.templateRepo.FindByID(ctx, templateID)
 	if err != nil {
-		return fmt.Errorf("template with ID '%s' not found: %w", id, err)
+		return 0, fmt.Errorf("template with ID '%s' not found: %w", id, err)
+	}
+
+	// Resolve the deletion policy
+	policy := services.TemplateDeletionRestrict
+	var replacement *template.Template
+	if req.ReassignTo != "" {
+		policy = services.TemplateDeletionReassign
+		replacementID, err := template.NewTemplateIDFromString(req.ReassignTo)
+		if err != nil {
+			return 0, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid replacement template ID: %w", err))
+		}
+		replacement, err = uc.templateRepo.FindByID(ctx, replacementID)
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return 0, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("replacement template '%s' not found", req.ReassignTo))
+			}
+			return 0, fmt.Errorf("failed to find replacement template: %w", err)
+		}
+	} else if req.Force {
+		policy = services.TemplateDeletionDetach
+	}
+
+	// Find channels that reference this template (for legacy sync after release)
+	channelsUsingTemplate, err := findChannelsByTemplate(ctx, uc.channelRepo, templateID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Release the channel references according to the policy
+	if _, err := uc.integrity.ReleaseTemplate(ctx, templateEntity, policy, replacement); err != nil {
+		return 0, err
 	}
 
-	// Update legacy channels that use this template before deletion
-	// Set template content to empty since template is being deleted
-	if err := uc.updateLegacyChannelsForTemplateDelete(ctx, templateEntity); err != nil {
+	// Update legacy channels that used this template before deletion:
+	// reassigned channels get the replacement content, detached channels fall back to defaults
+	if err := uc.updateLegacyChannelsForTemplateDelete(ctx, templateEntity, channelsUsingTemplate, replacement); err != nil {
 		// Log error but don't fail the operation
 		// The template deletion should proceed, legacy sync is best effort
 		fmt.Printf("Warning: failed to update legacy channels for template deletion %s: %v\n", templateEntity.ID().String(), err)
@@ -64,35 +119,18 @@ func (uc *DeleteTemplateUseCase) Execute(ctx context.Context, id string) error {
 
 	// Delete template
 	if err := uc.templateRepo.Delete(ctx, templateID); err != nil {
-		return fmt.Errorf("failed to delete template: %w", err)
+		return 0, fmt.Errorf("failed to delete template: %w", err)
 	}
+	notifyTemplateChange(ctx, uc.changes, shared.ChangeTemplateDeleted, templateEntity.ID().String(), dtos.ToTemplateResponse(templateEntity), nil)
 
-	return nil
+	return int64(templateEntity.Version().Int()) + 1, nil
 }
 
 // updateLegacyChannelsForTemplateDelete updates all legacy channels that use the template being deleted
-func (uc *DeleteTemplateUseCase) updateLegacyChannelsForTemplateDelete(ctx context.Context, templateEntity *template.Template) error {
-	// Find all channels that use this template
-	// Since we don't have FindByTemplateID, we'll get all channels and filter
-	filter := channel.NewChannelFilter()
-	pagination := &shared.Pagination{MaxResultCount: 100} // Get maximum allowed channels per query
-
-	result, err := uc.channelRepo.FindAll(ctx, filter, pagination)
-	if err != nil {
-		return fmt.Errorf("failed to find channels: %w", err)
-	}
-
-	// Filter channels that use this template
-	var channelsUsingTemplate []*channel.Channel
-	for _, ch := range result.Items {
-		if ch.TemplateID() != nil && ch.TemplateID().String() == templateEntity.ID().String() {
-			channelsUsingTemplate = append(channelsUsingTemplate, ch)
-		}
-	}
-
+func (uc *DeleteTemplateUseCase) updateLegacyChannelsForTemplateDelete(ctx context.Context, templateEntity *template.Template, channelsUsingTemplate []*channel.Channel, replacement *template.Template) error {
 	// Update each channel in the legacy system with empty template content
 	for _, ch := range channelsUsingTemplate {
-		if err := uc.updateLegacyChannelForTemplateDelete(ctx, ch); err != nil {
+		if err := uc.updateLegacyChannelForTemplateDelete(ctx, ch, replacement); err != nil {
 			// Log error but continue with other channels
 			fmt.Printf("Warning: failed to update legacy channel %s for template deletion: %v\n", ch.ID().String(), err)
 		}
@@ -101,8 +139,9 @@ func (uc *DeleteTemplateUseCase) updateLegacyChannelsForTemplateDelete(ctx conte
 	return nil
 }
 
-// updateLegacyChannelForTemplateDelete updates a single channel in the legacy system for template deletion
-func (uc *DeleteTemplateUseCase) updateLegacyChannelForTemplateDelete(ctx context.Context, ch *channel.Channel) error {
+// updateLegacyChannelForTemplateDelete updates a single channel in the legacy system for template deletion.
+// When the channel was reassigned, the replacement template content is pushed instead of the fallbacks.
+func (uc *DeleteTemplateUseCase) updateLegacyChannelForTemplateDelete(ctx context.Context, ch *channel.Channel, replacement *template.Template) error {
 	legacyURL := uc.config.LegacySystem.URL + "/Groups/" + ch.ID().String()
 	bearerToken := uc.config.LegacySystem.Token
 
@@ -141,16 +180,23 @@ func (uc *DeleteTemplateUseCase) updateLegacyChannelForTemplateDelete(ctx contex
 	}
 
 	// Set empty template content since template is being deleted
-	// Use fallback values from config or defaults
-	if emailSubject, ok := configMap["emailSubject"].(string); ok {
-		legacyReq.Config.EmailSubject = emailSubject
-	} else {
-		legacyReq.Config.EmailSubject = "Default Subject"
-	}
-	if templateContent, ok := configMap["template"].(string); ok {
-		legacyReq.Config.Template = templateContent
+	// Use the replacement template, or fallback values from config or defaults
+	if replacement != nil {
+		if replacement.Subject() != nil {
+			legacyReq.Config.EmailSubject = replacement.Subject().String()
+		}
+		legacyReq.Config.Template = replacement.Content().String()
 	} else {
-		legacyReq.Config.Template = "Default Template Content"
+		if emailSubject, ok := configMap["emailSubject"].(string); ok {
+			legacyReq.Config.EmailSubject = emailSubject
+		} else {
+			legacyReq.Config.EmailSubject = "Default Subject"
+		}
+		if templateContent, ok := configMap["template"].(string); ok {
+			legacyReq.Config.Template = templateContent
+		} else {
+			legacyReq.Config.Template = "Default Template Content"
+		}
 	}
 
 	// Populate SendList from channel recipients
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+
+	"notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
+)
+
+// GetCompatibilityUseCase lists the template content each registered channel
+// type can send, so that clients can keep templates off the channels unable
+// to send them, such as an HTML template on an SMS channel.
+type GetCompatibilityUseCase struct {
+	registry shared.ChannelTypeRegistry
+}
+
+// NewGetCompatibilityUseCase creates a new GetCompatibilityUseCase.
+func NewGetCompatibilityUseCase(registry shared.ChannelTypeRegistry) *GetCompatibilityUseCase {
+	return &GetCompatibilityUseCase{
+		registry: registry,
+	}
+}
+
+// Execute lists the channel types by name with the content they can send.
+func (uc *GetCompatibilityUseCase) Execute(ctx context.Context) (*dtos.CompatibilityResponse, error) {
+	channelTypes := uc.registry.GetAllChannelTypes()
+	sort.Slice(channelTypes, func(i, j int) bool {
+		return channelTypes[i].GetName() < channelTypes[j].GetName()
+	})
+
+	response := &dtos.CompatibilityResponse{
+		ChannelTypes: make([]*dtos.ChannelTypeCompatibility, 0, len(channelTypes)),
+	}
+	for _, channelType := range channelTypes {
+		response.ChannelTypes = append(response.ChannelTypes, &dtos.ChannelTypeCompatibility{
+			ChannelType:         channelType.GetName(),
+			DisplayName:         channelType.GetDisplayName(),
+			ContentCapabilities: shared.ContentCapabilitiesOf(channelType),
+		})
+	}
+	return response, nil
+}
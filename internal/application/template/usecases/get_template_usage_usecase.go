@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"notification/internal/application/template/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+const (
+	// DefaultUsageWindowDays is the default window for recent message counts.
+	DefaultUsageWindowDays = 30
+	// MaxUsageWindowDays is the largest accepted window for recent message counts.
+	MaxUsageWindowDays = 365
+)
+
+// GetTemplateUsageUseCase handles reporting which channels use a template.
+type GetTemplateUsageUseCase struct {
+	templateRepo template.TemplateRepository
+	channelRepo  channel.ChannelRepository
+	messageRepo  message.MessageRepository
+}
+
+// NewGetTemplateUsageUseCase creates a new GetTemplateUsageUseCase.
+func NewGetTemplateUsageUseCase(
+	templateRepo template.TemplateRepository,
+	channelRepo channel.ChannelRepository,
+	messageRepo message.MessageRepository,
+) *GetTemplateUsageUseCase {
+	return &GetTemplateUsageUseCase{
+		templateRepo: templateRepo,
+		channelRepo:  channelRepo,
+		messageRepo:  messageRepo,
+	}
+}
+
+// Execute reports the channels referencing a template and their message counts
+// over the last windowDays days. A non-positive window uses the default.
+func (uc *GetTemplateUsageUseCase) Execute(ctx context.Context, id string, windowDays int) (*dtos.TemplateUsageResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	// Validate input
+	if id == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("template ID cannot be empty"))
+	}
+	if windowDays <= 0 {
+		windowDays = DefaultUsageWindowDays
+	}
+	if windowDays > MaxUsageWindowDays {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("usage window cannot exceed %d days", MaxUsageWindowDays))
+	}
+
+	// Create template ID
+	templateID, err := template.NewTemplateIDFromString(id)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
+	}
+
+	// Find template
+	templateEntity, err := uc.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find template: %w", err)
+	}
+
+	// Find channels referencing the template
+	channels, err := findChannelsByTemplate(ctx, uc.channelRepo, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count recent messages per channel
+	channelIDs := make([]string, 0, len(channels))
+	for _, ch := range channels {
+		channelIDs = append(channelIDs, ch.ID().String())
+	}
+
+	since := time.Now().AddDate(0, 0, -windowDays).UnixMilli()
+	counts, err := uc.messageRepo.CountByChannelsSince(ctx, channelIDs, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent messages: %w", err)
+	}
+
+	// Build response
+	response := &dtos.TemplateUsageResponse{
+		TemplateID:   templateEntity.ID().String(),
+		TemplateName: templateEntity.Name().String(),
+		ChannelCount: len(channels),
+		WindowDays:   windowDays,
+		Channels:     make([]*dtos.TemplateUsageChannel, 0, len(channels)),
+	}
+	for _, ch := range channels {
+		count := counts[ch.ID().String()]
+		response.RecentMessageCount += count
+		response.Channels = append(response.Channels, &dtos.TemplateUsageChannel{
+			ChannelID:          ch.ID().String(),
+			ChannelName:        ch.Name().String(),
+			ChannelType:        ch.ChannelType().String(),
+			Enabled:            ch.IsEnabled(),
+			LastUsed:           ch.LastUsed(),
+			RecentMessageCount: count,
+		})
+	}
+
+	return response, nil
+}
+
+// findChannelsByTemplate returns every non-deleted channel that references the template.
+func findChannelsByTemplate(ctx context.Context, channelRepo channel.ChannelRepository, templateID *template.TemplateID) ([]*channel.Channel, error) {
+	filter := channel.NewChannelFilter().WithTemplateID(templateID)
+
+	var channels []*channel.Channel
+	for skip := 0; ; {
+		pagination, err := shared.NewPagination(skip, 100)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pagination: %w", err)
+		}
+
+		result, err := channelRepo.FindAll(ctx, filter, pagination)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find channels using template: %w", err)
+		}
+
+		channels = append(channels, result.Items...)
+		if !result.HasMore || len(result.Items) == 0 {
+			return channels, nil
+		}
+		skip += len(result.Items)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 )
 
@@ -22,15 +23,17 @@ func NewGetTemplateUseCase(templateRepo template.TemplateRepository) *GetTemplat
 
 // Execute gets a template by ID.
 func (uc *GetTemplateUseCase) Execute(ctx context.Context, id string) (*dtos.TemplateResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
 	// Validate input
 	if id == "" {
-		return nil, fmt.Errorf("template ID cannot be empty")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("template ID cannot be empty"))
 	}
 
 	// Create template ID
 	templateID, err := template.NewTemplateIDFromString(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid template ID: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
 	}
 
 	// Find template
@@ -41,4 +44,28 @@ func (uc *GetTemplateUseCase) Execute(ctx context.Context, id string) (*dtos.Tem
 
 	// Convert to response
 	return dtos.ToTemplateResponse(templateEntity), nil
-}
\ No newline at end of file
+}
+// ExecuteByName gets a template by its unique name.
+func (uc *GetTemplateUseCase) ExecuteByName(ctx context.Context, name string) (*dtos.TemplateResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
+	// Validate input
+	if name == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("template name cannot be empty"))
+	}
+
+	// Create template name
+	templateName, err := template.NewTemplateName(name)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template name: %w", err))
+	}
+
+	// Find template
+	templateEntity, err := uc.templateRepo.FindByName(ctx, templateName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find template: %w", err)
+	}
+
+	// Convert to response
+	return dtos.ToTemplateResponse(templateEntity), nil
+}
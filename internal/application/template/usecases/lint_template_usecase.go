@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+// LintTemplateUseCase reports template variables that are used but not declared.
+type LintTemplateUseCase struct{}
+
+// NewLintTemplateUseCase creates a new LintTemplateUseCase.
+func NewLintTemplateUseCase() *LintTemplateUseCase {
+	return &LintTemplateUseCase{}
+}
+
+// Execute lints the subject and content of a template against its declared variables.
+func (uc *LintTemplateUseCase) Execute(ctx context.Context, req *dtos.LintTemplateRequest) (*dtos.LintTemplateResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+
+	subject, err := template.NewSubject(req.Subject)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid subject: %w", err))
+	}
+	content, err := template.NewTemplateContent(req.Content)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template content: %w", err))
+	}
+
+	used, undeclared := lintVariables(subject, content, req.Variables)
+
+	return &dtos.LintTemplateResponse{
+		Used:       used,
+		Undeclared: undeclared,
+		Valid:      len(undeclared) == 0,
+	}, nil
+}
+
+// lintVariables returns the variables used by the subject and the content and
+// those of them missing from declared, both sorted by name.
+func lintVariables(subject *template.Subject, content *template.TemplateContent, declared []string) ([]string, []string) {
+	known := make(map[string]bool, len(declared))
+	for _, variable := range declared {
+		known[variable] = true
+	}
+
+	usedSet := make(map[string]bool)
+	for _, variable := range append(subject.ExtractVariables(), content.ExtractVariables()...) {
+		usedSet[variable] = true
+	}
+
+	used := make([]string, 0, len(usedSet))
+	undeclared := make([]string, 0)
+	for variable := range usedSet {
+		used = append(used, variable)
+		if !known[variable] {
+			undeclared = append(undeclared, variable)
+		}
+	}
+	sort.Strings(used)
+	sort.Strings(undeclared)
+
+	return used, undeclared
+}
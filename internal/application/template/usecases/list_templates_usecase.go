@@ -5,12 +5,15 @@ import (
 	"fmt"
 
 	"notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 )
 
 // ListTemplatesUseCase handles listing templates.
 type ListTemplatesUseCase struct {
 	templateRepo template.TemplateRepository
+	// defaultEnvironment filters the requests naming no environment
+	defaultEnvironment shared.Environment
 }
 
 // NewListTemplatesUseCase creates a new ListTemplatesUseCase.
@@ -20,8 +23,16 @@ func NewListTemplatesUseCase(templateRepo template.TemplateRepository) *ListTemp
 	}
 }
 
+// SetDefaultEnvironment lists the templates of the environment, and the
+// unscoped ones, when a request names no environment.
+func (uc *ListTemplatesUseCase) SetDefaultEnvironment(environment shared.Environment) {
+	uc.defaultEnvironment = environment
+}
+
 // Execute lists templates with filtering and pagination.
 func (uc *ListTemplatesUseCase) Execute(ctx context.Context, req *dtos.ListTemplatesRequest) (*dtos.ListTemplatesResponse, error) {
+	ctx = shared.WithStaleReads(ctx)
+
 	// Use default request if nil
 	if req == nil {
 		req = &dtos.ListTemplatesRequest{}
@@ -31,6 +42,15 @@ func (uc *ListTemplatesUseCase) Execute(ctx context.Context, req *dtos.ListTempl
 	filter := req.ToTemplateFilter()
 	pagination := req.ToPagination()
 
+	// Filter on the environment, the default one unless the request names one or all
+	environment, err := shared.NewEnvironmentFilter(req.Environment, uc.defaultEnvironment)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", err)
+	}
+	if environment != "" {
+		filter.WithEnvironment(environment)
+	}
+
 	// Find templates
 	result, err := uc.templateRepo.FindAll(ctx, filter, pagination)
 	if err != nil {
@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+
+	"notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
+)
+
+// notifyTemplateChange reports a change of a template to the notifier, if any
+func notifyTemplateChange(ctx context.Context, notifier shared.ChangeNotifier, changeType, templateID string, before, after *dtos.TemplateResponse) {
+	if notifier == nil {
+		return
+	}
+
+	var beforeState, afterState interface{}
+	if before != nil {
+		beforeState = before
+	}
+	if after != nil {
+		afterState = after
+	}
+	notifier.Changed(ctx, shared.NewChange(ctx, changeType, templateID, beforeState, afterState))
+}
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"reflect"
+	"slices"
 	"strings"
 
 	"notification/internal/application/template/dtos"
@@ -21,6 +24,7 @@ type UpdateTemplateUseCase struct {
 	templateRepo template.TemplateRepository
 	channelRepo  channel.ChannelRepository
 	config       *config.Config
+	changes      shared.ChangeNotifier
 }
 
 // NewUpdateTemplateUseCase creates a new UpdateTemplateUseCase.
@@ -36,20 +40,30 @@ func NewUpdateTemplateUseCase(
 	}
 }
 
+// SetChangeNotifier makes the use case report the templates it changes.
+func (uc *UpdateTemplateUseCase) SetChangeNotifier(notifier shared.ChangeNotifier) {
+	uc.changes = notifier
+}
+
 // Execute updates a template.
 func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dtos.UpdateTemplateRequest) (*dtos.TemplateResponse, error) {
+	return uc.update(ctx, id, req, false)
+}
+
+// update applies an update request; replace also clears the settings it omits.
+func (uc *UpdateTemplateUseCase) update(ctx context.Context, id string, req *dtos.UpdateTemplateRequest, replace bool) (*dtos.TemplateResponse, error) {
 	// Validate input
 	if id == "" {
-		return nil, fmt.Errorf("template ID cannot be empty")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("template ID cannot be empty"))
 	}
 	if req == nil {
-		return nil, fmt.Errorf("request cannot be nil")
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
 	}
 
 	// Create template ID
 	templateID, err := template.NewTemplateIDFromString(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid template ID: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
 	}
 
 	// Find existing template
@@ -57,13 +71,14 @@ func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dt
 	if err != nil {
 		return nil, fmt.Errorf("failed to find template: %w", err)
 	}
+	previous := dtos.ToTemplateResponse(templateEntity)
 
 	// Update name if provided
 	var updatedName *template.TemplateName
 	if req.Name != nil {
 		templateName, err := template.NewTemplateName(*req.Name)
 		if err != nil {
-			return nil, fmt.Errorf("invalid template name: %w", err)
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template name: %w", err))
 		}
 
 		// Check if another template with same name exists
@@ -73,7 +88,7 @@ func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dt
 				return nil, fmt.Errorf("failed to check template name existence: %w", err)
 			}
 			if exists {
-				return nil, fmt.Errorf("template with name '%s' already exists", *req.Name)
+				return nil, shared.NewConflictError("TEMPLATE_NAME_CONFLICT", fmt.Sprintf("template with name '%s' already exists", *req.Name))
 			}
 		}
 
@@ -90,7 +105,7 @@ func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dt
 		} else {
 			subject, err := template.NewSubject(*req.Subject)
 			if err != nil {
-				return nil, fmt.Errorf("invalid subject: %w", err)
+				return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid subject: %w", err))
 			}
 			updatedSubject = subject
 		}
@@ -103,7 +118,7 @@ func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dt
 	if req.Content != nil {
 		templateContent, err := template.NewTemplateContent(*req.Content)
 		if err != nil {
-			return nil, fmt.Errorf("invalid template content: %w", err)
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template content: %w", err))
 		}
 		updatedContent = templateContent
 	} else {
@@ -118,8 +133,93 @@ func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dt
 		updatedTags = templateEntity.Tags()
 	}
 
-	// Create description (keep existing or empty)
+	// Update strict rendering if provided
+	updatedStrict := templateEntity.IsStrict()
+	if req.Strict != nil {
+		updatedStrict = *req.Strict
+	}
+
+	// Update attachments if provided
+	var updatedAttachments *template.Attachments
+	if req.Attachments != nil {
+		attachments, err := dtos.ToAttachments(req.Attachments)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid attachments: %w", err))
+		}
+		if !attachments.IsEmpty() && templateEntity.ChannelType() != shared.ChannelTypeEmail {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("attachments are only supported by email templates"))
+		}
+		updatedAttachments = attachments
+	}
+
+	// Update headers if provided
+	var updatedHeaders map[string]string
+	if req.Headers != nil {
+		headers, err := template.NormalizeEmailHeaders(req.Headers)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid headers: %w", err))
+		}
+		if len(headers) > 0 && templateEntity.ChannelType() != shared.ChannelTypeEmail {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("headers are only supported by email templates"))
+		}
+		updatedHeaders = headers
+	}
+
+	// Update owner and team if provided
+	updatedOwnership := *templateEntity.Ownership()
+	if req.Owner != nil {
+		updatedOwnership.Owner = *req.Owner
+	}
+	if req.Team != nil {
+		updatedOwnership.Team = *req.Team
+	}
+	ownership, err := shared.NewOwnership(updatedOwnership.Owner, updatedOwnership.Team)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid ownership: %w", err))
+	}
+
+	// Update environment if provided
+	updatedEnvironment := templateEntity.Environment()
+	if req.Environment != nil {
+		if updatedEnvironment, err = shared.NewEnvironment(*req.Environment); err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", err)
+		}
+	}
+
+	// Update description if provided
 	description := templateEntity.Description()
+	if req.Description != nil {
+		if description, err = template.NewDescription(*req.Description); err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid description: %w", err))
+		}
+	}
+
+	// Update declared variables if provided
+	var updatedVariables []string
+	if req.Variables != nil {
+		updatedVariables = req.Variables
+	}
+
+	// Update settings if provided, or clear them on a replace
+	updatedSettings := templateEntity.Settings()
+	if req.Settings != nil || replace {
+		if updatedSettings, err = toTemplateSettings(req.Settings); err != nil {
+			return nil, err
+		}
+	}
+
+	// Nothing changed: keep version and timestamps as they are so repeated requests are idempotent
+	if isTemplateUnchanged(templateEntity, updatedName, updatedSubject, updatedContent, updatedTags) &&
+		templateEntity.IsStrict() == updatedStrict &&
+		(updatedAttachments == nil || (updatedAttachments.IsEmpty() && templateEntity.Attachments().IsEmpty())) &&
+		(updatedHeaders == nil || maps.Equal(updatedHeaders, templateEntity.Headers())) &&
+		*templateEntity.Ownership() == *ownership &&
+		templateEntity.Environment() == updatedEnvironment &&
+		templateEntity.Description().String() == description.String() &&
+		(updatedVariables == nil || slices.Equal(template.NormalizeVariables(updatedVariables), templateEntity.DeclaredVariables())) &&
+		reflect.DeepEqual(templateEntity.Settings(), updatedSettings) {
+		return dtos.ToTemplateResponse(templateEntity), nil
+	}
 
 	// Update the template using the Update method
 	if err := templateEntity.Update(
@@ -130,8 +230,23 @@ func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dt
 		updatedContent,
 		updatedTags,
 	); err != nil {
-		return nil, fmt.Errorf("failed to update template: %w", err)
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("failed to update template: %w", err))
+	}
+	templateEntity.SetStrict(updatedStrict)
+	if updatedAttachments != nil {
+		templateEntity.SetAttachments(updatedAttachments)
+	}
+	if updatedHeaders != nil {
+		if err := templateEntity.SetHeaders(updatedHeaders); err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid headers: %w", err))
+		}
 	}
+	templateEntity.SetOwnership(ownership)
+	templateEntity.SetEnvironment(updatedEnvironment)
+	if updatedVariables != nil {
+		templateEntity.SetDeclaredVariables(updatedVariables)
+	}
+	templateEntity.SetSettings(updatedSettings)
 
 	// Save updated template
 	if err := uc.templateRepo.Update(ctx, templateEntity); err != nil {
@@ -146,7 +261,75 @@ func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, id string, req *dt
 	}
 
 	// Convert to response
-	return dtos.ToTemplateResponse(templateEntity), nil
+	response := dtos.ToTemplateResponse(templateEntity)
+	notifyTemplateChange(ctx, uc.changes, shared.ChangeTemplateUpdated, response.ID, previous, response)
+	return response, nil
+}
+
+// Replace fully replaces the mutable fields of a template.
+func (uc *UpdateTemplateUseCase) Replace(ctx context.Context, id string, req *dtos.ReplaceTemplateRequest) (*dtos.TemplateResponse, error) {
+	// Validate input
+	if req == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+	if req.Name == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("template name is required"))
+	}
+	if req.Content == "" {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("template content is required"))
+	}
+
+	// The channel type is immutable; accept it only when it matches the stored value
+	if req.ChannelType != nil {
+		templateID, err := template.NewTemplateIDFromString(id)
+		if err != nil {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid template ID: %w", err))
+		}
+
+		templateEntity, err := uc.templateRepo.FindByID(ctx, templateID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find template: %w", err)
+		}
+
+		if !templateEntity.ChannelType().Equals(*req.ChannelType) {
+			return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("channel type cannot be changed from '%s' to '%s'", templateEntity.ChannelType().String(), req.ChannelType.String()))
+		}
+	}
+
+	return uc.update(ctx, id, req.ToUpdateTemplateRequest(), true)
+}
+
+// toTemplateSettings checks the delivery settings of a request; nil keeps none.
+func toTemplateSettings(settings *shared.CommonSettings) (*shared.CommonSettings, error) {
+	if settings == nil {
+		return nil, nil
+	}
+	checked, err := shared.NewCommonSettings(settings.Timeout, settings.RetryAttempts, settings.RetryDelay)
+	if err != nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid settings: %w", err))
+	}
+	return checked, nil
+}
+
+// isTemplateUnchanged reports whether the new values equal the template's current state.
+func isTemplateUnchanged(
+	templateEntity *template.Template,
+	name *template.TemplateName,
+	subject *template.Subject,
+	content *template.TemplateContent,
+	tags *template.Tags,
+) bool {
+	subjectValue := func(s *template.Subject) string {
+		if s == nil {
+			return ""
+		}
+		return s.String()
+	}
+
+	return templateEntity.Name().String() == name.String() &&
+		subjectValue(templateEntity.Subject()) == subjectValue(subject) &&
+		templateEntity.Content().String() == content.String() &&
+		reflect.DeepEqual(templateEntity.Tags().ToSlice(), tags.ToSlice())
 }
 
 // updateLegacyChannelsUsingTemplate updates all legacy channels that use the given template
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/application/template/dtos"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+	"notification/pkg/config"
+)
+
+// fakeTemplateRepository keeps a single template in memory
+type fakeTemplateRepository struct {
+	template.TemplateRepository
+	stored  *template.Template
+	updates int
+}
+
+func (r *fakeTemplateRepository) Save(ctx context.Context, tmpl *template.Template) error {
+	r.stored = tmpl
+	return nil
+}
+
+func (r *fakeTemplateRepository) FindByID(ctx context.Context, id *template.TemplateID) (*template.Template, error) {
+	return r.stored, nil
+}
+
+func (r *fakeTemplateRepository) ExistsByName(ctx context.Context, name *template.TemplateName) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeTemplateRepository) Update(ctx context.Context, tmpl *template.Template) error {
+	r.updates++
+	r.stored = tmpl
+	return nil
+}
+
+// fakeChannelRepository has no channels
+type fakeChannelRepository struct {
+	channel.ChannelRepository
+}
+
+func (r *fakeChannelRepository) FindAll(ctx context.Context, filter *channel.ChannelFilter, pagination *shared.Pagination) (*shared.PaginatedResult[*channel.Channel], error) {
+	return &shared.PaginatedResult[*channel.Channel]{}, nil
+}
+
+func newStoredTemplate(t *testing.T) (*fakeTemplateRepository, *UpdateTemplateUseCase) {
+	t.Helper()
+	shared.InitializeChannelTypes()
+	repo := &fakeTemplateRepository{}
+	createUseCase := NewCreateTemplateUseCase(repo)
+	response, err := createUseCase.Execute(context.Background(), &dtos.CreateTemplateRequest{
+		Name:        "welcome",
+		Description: "Sent on sign up",
+		ChannelType: shared.ChannelTypeEmail,
+		Subject:     "Hello {name}",
+		Content:     "Welcome {name}",
+		Variables:   []string{"name", "plan"},
+		Settings:    &shared.CommonSettings{Timeout: 5000, RetryAttempts: 2, RetryDelay: 100},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Sent on sign up", response.Description)
+	require.Equal(t, []string{"name", "plan"}, response.Variables)
+	require.NotNil(t, response.Settings)
+
+	return repo, NewUpdateTemplateUseCase(repo, &fakeChannelRepository{}, &config.Config{})
+}
+
+func TestUpdateTemplateUseCase_ReplaceClearsTheOmittedFields(t *testing.T) {
+	repo, useCase := newStoredTemplate(t)
+
+	response, err := useCase.Replace(context.Background(), repo.stored.ID().String(), &dtos.ReplaceTemplateRequest{
+		Name:    "welcome",
+		Subject: "Hello {name}",
+		Content: "Welcome {name}",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, response.Description)
+	assert.Equal(t, []string{"name"}, response.Variables)
+	assert.Nil(t, response.Settings)
+	assert.Empty(t, repo.stored.DeclaredVariables())
+	assert.Nil(t, repo.stored.Settings())
+}
+
+func TestUpdateTemplateUseCase_PatchKeepsTheOmittedFields(t *testing.T) {
+	repo, useCase := newStoredTemplate(t)
+	content := "Welcome back {name}"
+
+	response, err := useCase.Execute(context.Background(), repo.stored.ID().String(), &dtos.UpdateTemplateRequest{
+		Content: &content,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Sent on sign up", response.Description)
+	assert.Equal(t, []string{"name", "plan"}, response.Variables)
+	assert.Equal(t, &shared.CommonSettings{Timeout: 5000, RetryAttempts: 2, RetryDelay: 100}, response.Settings)
+}
+
+func TestUpdateTemplateUseCase_ReplaceWithTheSameFieldsIsANoOp(t *testing.T) {
+	repo, useCase := newStoredTemplate(t)
+	version := repo.stored.Version().Int()
+
+	_, err := useCase.Replace(context.Background(), repo.stored.ID().String(), &dtos.ReplaceTemplateRequest{
+		Name:        "welcome",
+		Description: "Sent on sign up",
+		Subject:     "Hello {name}",
+		Content:     "Welcome {name}",
+		Variables:   []string{"name", "plan"},
+		Settings:    &shared.CommonSettings{Timeout: 5000, RetryAttempts: 2, RetryDelay: 100},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, repo.updates)
+	assert.Equal(t, version, repo.stored.Version().Int())
+}
+
+func TestUpdateTemplateUseCase_RejectsInvalidSettings(t *testing.T) {
+	repo, useCase := newStoredTemplate(t)
+
+	_, err := useCase.Execute(context.Background(), repo.stored.ID().String(), &dtos.UpdateTemplateRequest{
+		Settings: &shared.CommonSettings{Timeout: 0},
+	})
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, shared.ErrorKindValidation, domainErr.Kind())
+}
@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+const (
+	// SMSSegmentLength is the number of characters sent in a single SMS.
+	SMSSegmentLength = 160
+	// MaxSMSLength is the longest SMS body the providers accept; longer bodies are truncated.
+	MaxSMSLength = 1600
+	// MaxVoiceLength is the longest text a voice call reads out.
+	MaxVoiceLength = 4000
+	// MaxWhatsAppLength is the longest text body of a WhatsApp session message.
+	MaxWhatsAppLength = 4096
+	// MaxDiscordLength is the longest content of a plain Discord message.
+	MaxDiscordLength = 2000
+	// MaxDiscordEmbedLength is the longest description of a Discord embed.
+	MaxDiscordEmbedLength = 4096
+	// MaxPagerDutySummaryLength is the longest summary of a PagerDuty incident.
+	MaxPagerDutySummaryLength = 1024
+	// MaxOpsgenieMessageLength is the longest message of an Opsgenie alert.
+	MaxOpsgenieMessageLength = 130
+	// MaxJiraSummaryLength is the longest summary of a Jira issue.
+	MaxJiraSummaryLength = 255
+	// MaxMQTTPayloadLength is the payload size of an MQTT message above
+	// which brokers configured with the usual limits may refuse it.
+	MaxMQTTPayloadLength = 256 * 1024
+	// MaxInAppTitleLength is the longest title of an in-app notification.
+	MaxInAppTitleLength = 255
+)
+
+// ValidateTemplateUseCase checks a template before it is saved. The template
+// syntax only knows {variable} placeholders, so there are no conditional
+// blocks that could be unreachable.
+type ValidateTemplateUseCase struct{}
+
+// NewValidateTemplateUseCase creates a new ValidateTemplateUseCase.
+func NewValidateTemplateUseCase() *ValidateTemplateUseCase {
+	return &ValidateTemplateUseCase{}
+}
+
+// Execute reports syntax errors, undeclared variables and channel type constraints.
+func (uc *ValidateTemplateUseCase) Execute(ctx context.Context, req *dtos.ValidateTemplateRequest) (*dtos.ValidateTemplateResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("request cannot be nil"))
+	}
+	if !req.ChannelType.IsValid() {
+		return nil, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("invalid channel type: %s", req.ChannelType))
+	}
+
+	response := &dtos.ValidateTemplateResponse{
+		Errors:    make([]*dtos.TemplateIssue, 0),
+		Warnings:  make([]*dtos.TemplateIssue, 0),
+		Variables: make([]string, 0),
+	}
+
+	// 1. Placeholder syntax
+	for _, field := range []struct{ name, text string }{{"subject", req.Subject}, {"content", req.Content}} {
+		for _, syntaxErr := range template.CheckSyntax(field.text) {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   field.name,
+				Code:    "SYNTAX_ERROR",
+				Message: syntaxErr.Message,
+				Line:    syntaxErr.Line,
+				Column:  syntaxErr.Column,
+			})
+		}
+	}
+
+	// 2. Field constraints shared by every channel type
+	subject, subjectErr := template.NewSubject(req.Subject)
+	if subjectErr != nil {
+		response.Errors = append(response.Errors, &dtos.TemplateIssue{Field: "subject", Code: "INVALID_SUBJECT", Message: subjectErr.Error()})
+	}
+	content, contentErr := template.NewTemplateContent(req.Content)
+	if contentErr != nil {
+		response.Errors = append(response.Errors, &dtos.TemplateIssue{Field: "content", Code: "INVALID_CONTENT", Message: contentErr.Error()})
+	}
+
+	// 3. Declared variables
+	if subjectErr == nil && contentErr == nil {
+		used, undeclared := lintVariables(subject, content, req.Variables)
+		response.Variables = used
+		if req.Variables != nil {
+			response.Undeclared = undeclared
+			for _, variable := range undeclared {
+				response.Errors = append(response.Errors, &dtos.TemplateIssue{
+					Field:   "variables",
+					Code:    "UNDECLARED_VARIABLE",
+					Message: fmt.Sprintf("variable '%s' is used but not declared", variable),
+				})
+			}
+		}
+	}
+
+	// 4. Channel type constraints
+	uc.checkChannelConstraints(req, response)
+
+	response.Valid = len(response.Errors) == 0
+	return response, nil
+}
+
+// checkChannelConstraints adds the issues specific to the template's channel type.
+func (uc *ValidateTemplateUseCase) checkChannelConstraints(req *dtos.ValidateTemplateRequest, response *dtos.ValidateTemplateResponse) {
+	switch req.ChannelType {
+	case shared.ChannelTypeEmail:
+		if strings.TrimSpace(req.Subject) == "" {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   "subject",
+				Code:    "SUBJECT_REQUIRED",
+				Message: "email templates require a subject",
+			})
+		}
+	case shared.ChannelTypeSMS:
+		// SMS providers receive the subject and the content as one body
+		body := req.Content
+		if strings.TrimSpace(req.Subject) != "" {
+			body = req.Subject + "\n\n" + req.Content
+		}
+		length := len([]rune(body))
+
+		if length > MaxSMSLength {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "SMS_TOO_LONG",
+				Message: fmt.Sprintf("SMS body is %d characters before rendering, the limit is %d", length, MaxSMSLength),
+			})
+		} else if length > SMSSegmentLength {
+			segments := (length + SMSSegmentLength - 1) / SMSSegmentLength
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "SMS_MULTIPART",
+				Message: fmt.Sprintf("SMS body is %d characters before rendering and will be sent as %d messages", length, segments),
+			})
+		}
+	case shared.ChannelTypeVoice:
+		// Voice calls read out the content, the subject is not spoken
+		if length := len([]rune(req.Content)); length > MaxVoiceLength {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "VOICE_TOO_LONG",
+				Message: fmt.Sprintf("voice text is %d characters before rendering, the limit is %d", length, MaxVoiceLength),
+			})
+		}
+	case shared.ChannelTypeWhatsApp:
+		// Mapped templates send the approved WhatsApp template instead, the
+		// content is the body of the session messages
+		if length := len([]rune(req.Content)); length > MaxWhatsAppLength {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "WHATSAPP_TOO_LONG",
+				Message: fmt.Sprintf("WhatsApp text is %d characters before rendering, the limit is %d", length, MaxWhatsAppLength),
+			})
+		}
+	case shared.ChannelTypeDiscord:
+		// Embeds carry the content as their description, plain messages are truncated
+		length := len([]rune(req.Content))
+		if length > MaxDiscordEmbedLength {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "DISCORD_TOO_LONG",
+				Message: fmt.Sprintf("Discord content is %d characters before rendering, the limit is %d", length, MaxDiscordEmbedLength),
+			})
+		} else if length > MaxDiscordLength {
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "DISCORD_EMBED_REQUIRED",
+				Message: fmt.Sprintf("Discord content is %d characters before rendering, channels without embeds truncate it to %d", length, MaxDiscordLength),
+			})
+		}
+	case shared.ChannelTypePagerDuty:
+		// The subject summarizes the incident unless the message sets incident_summary
+		if length := len([]rune(req.Subject)); length > MaxPagerDutySummaryLength {
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "subject",
+				Code:    "PAGERDUTY_SUMMARY_TRUNCATED",
+				Message: fmt.Sprintf("PagerDuty summary is %d characters before rendering and will be truncated to %d", length, MaxPagerDutySummaryLength),
+			})
+		}
+	case shared.ChannelTypeOpsgenie:
+		// The subject is the message of the alert unless the message sets alert_message
+		if length := len([]rune(req.Subject)); length > MaxOpsgenieMessageLength {
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "subject",
+				Code:    "OPSGENIE_MESSAGE_TRUNCATED",
+				Message: fmt.Sprintf("Opsgenie alert message is %d characters before rendering and will be truncated to %d", length, MaxOpsgenieMessageLength),
+			})
+		}
+	case shared.ChannelTypeJira:
+		// The subject is the summary of the issue, the content its description
+		if strings.TrimSpace(req.Subject) == "" {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   "subject",
+				Code:    "SUBJECT_REQUIRED",
+				Message: "jira templates require a subject, the summary of the issue",
+			})
+		} else if length := len([]rune(req.Subject)); length > MaxJiraSummaryLength {
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "subject",
+				Code:    "JIRA_SUMMARY_TRUNCATED",
+				Message: fmt.Sprintf("Jira summary is %d characters before rendering and will be truncated to %d", length, MaxJiraSummaryLength),
+			})
+		}
+	case shared.ChannelTypeMQTT:
+		// Embedded consumers often cap the size of the messages they buffer
+		if length := len(req.Subject) + len(req.Content); length > MaxMQTTPayloadLength {
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "MQTT_PAYLOAD_LARGE",
+				Message: fmt.Sprintf("MQTT payload is %d bytes before rendering, brokers and devices may refuse payloads above %d", length, MaxMQTTPayloadLength),
+			})
+		}
+	case shared.ChannelTypeStatuspage:
+		// The subject names the incident, the content is the update shown on the page
+		if strings.TrimSpace(req.Content) == "" {
+			response.Errors = append(response.Errors, &dtos.TemplateIssue{
+				Field:   "content",
+				Code:    "STATUSPAGE_BODY_REQUIRED",
+				Message: "statuspage templates require content, the update shown on the status page",
+			})
+		}
+		if strings.TrimSpace(req.Subject) == "" {
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "subject",
+				Code:    "STATUSPAGE_NAME_FROM_CONTENT",
+				Message: "new incidents are named by the first line of the content unless messages set incident_summary",
+			})
+		}
+	case shared.ChannelTypeInApp:
+		// The subject is the title listed in the notification center
+		if length := len([]rune(req.Subject)); length > MaxInAppTitleLength {
+			response.Warnings = append(response.Warnings, &dtos.TemplateIssue{
+				Field:   "subject",
+				Code:    "INAPP_TITLE_TRUNCATED",
+				Message: fmt.Sprintf("in-app title is %d characters before rendering and will be truncated to %d", length, MaxInAppTitleLength),
+			})
+		}
+	}
+}
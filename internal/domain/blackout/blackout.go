@@ -0,0 +1,108 @@
+package blackout
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxNameLength is the longest name of a blackout
+const MaxNameLength = 255
+
+// Blackout is a period of the organization calendar, such as a change freeze
+// or a holiday, during which the non-critical scheduled messages are held
+// back until it ends.
+type Blackout struct {
+	ID          string
+	Name        string
+	Description string
+	// StartsAt is inclusive and EndsAt exclusive
+	StartsAt  time.Time
+	EndsAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewBlackout creates a blackout of the calendar.
+func NewBlackout(name, description string, startsAt, endsAt time.Time) (*Blackout, error) {
+	now := time.Now()
+	b := &Blackout{
+		ID:        uuid.New().String(),
+		CreatedAt: now,
+	}
+	if err := b.Change(name, description, startsAt, endsAt); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Change replaces the name, description and period of the blackout.
+func (b *Blackout) Change(name, description string, startsAt, endsAt time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("blackout name is required")
+	}
+	if len(name) > MaxNameLength {
+		return errors.New("blackout name is too long")
+	}
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return errors.New("blackout start and end are required")
+	}
+	if !endsAt.After(startsAt) {
+		return errors.New("blackout must end after it starts")
+	}
+
+	b.Name = name
+	b.Description = strings.TrimSpace(description)
+	b.StartsAt = startsAt
+	b.EndsAt = endsAt
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Covers tells whether a time falls within the blackout.
+func (b *Blackout) Covers(at time.Time) bool {
+	return !at.Before(b.StartsAt) && at.Before(b.EndsAt)
+}
+
+// DeferredUntil returns when a message due at a time may be sent given the
+// blackouts of the calendar, and the blackout that first held it back; nil
+// when no blackout covers the time. Overlapping and back-to-back blackouts
+// hold the message until the last of them ends.
+func DeferredUntil(blackouts []*Blackout, at time.Time) (time.Time, *Blackout) {
+	var first *Blackout
+	for {
+		var covering *Blackout
+		for _, b := range blackouts {
+			if b.Covers(at) && (covering == nil || b.EndsAt.After(covering.EndsAt)) {
+				covering = b
+			}
+		}
+		if covering == nil {
+			return at, first
+		}
+		if first == nil {
+			first = covering
+		}
+		at = covering.EndsAt
+	}
+}
+
+// BlackoutRepository keeps the blackout calendar.
+type BlackoutRepository interface {
+	// Save stores a new blackout
+	Save(ctx context.Context, blackout *Blackout) error
+	// Update stores the changes of a blackout
+	Update(ctx context.Context, blackout *Blackout) error
+	// FindByID finds a blackout, returning a not found error when missing
+	FindByID(ctx context.Context, id string) (*Blackout, error)
+	// FindEndingAfter lists the blackouts ending after a time, by start
+	FindEndingAfter(ctx context.Context, at time.Time) ([]*Blackout, error)
+	// List lists the blackouts by start
+	List(ctx context.Context) ([]*Blackout, error)
+	// Delete deletes a blackout, returning a not found error when missing
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,33 @@
+package blackout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferredUntil(t *testing.T) {
+	day := time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+	freeze, err := NewBlackout("Change freeze", "", day.Add(-48*time.Hour), day)
+	require.NoError(t, err)
+	holiday, err := NewBlackout(" Christmas ", "Office closed", day, day.Add(48*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "Christmas", holiday.Name)
+	blackouts := []*Blackout{freeze, holiday}
+
+	until, held := DeferredUntil(blackouts, day.Add(-time.Hour))
+	assert.Equal(t, day.Add(48*time.Hour), until, "back-to-back blackouts hold until the last ends")
+	assert.Same(t, freeze, held)
+
+	at := day.Add(48 * time.Hour)
+	until, held = DeferredUntil(blackouts, at)
+	assert.Equal(t, at, until, "the end of a blackout is not part of it")
+	assert.Nil(t, held)
+
+	_, err = NewBlackout("Backwards", "", day, day)
+	assert.Error(t, err)
+	_, err = NewBlackout("", "", day, day.Add(time.Hour))
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxNameLength is the longest name of a category
+const MaxNameLength = 100
+
+// MaxDescriptionLength is the longest description of a category
+const MaxDescriptionLength = 500
+
+// namePattern matches the names of the categories, such as billing or
+// security.alerts
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Category is a topic messages are sent in, such as billing, security or
+// marketing, that the recipients subscribe to.
+type Category struct {
+	Name        string
+	Description string
+	// DefaultSubscribed makes every recipient receive the messages of the
+	// category until it unsubscribes; otherwise only the recipients that
+	// subscribed receive them
+	DefaultSubscribed bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// NormalizeName normalizes the name of a category as sent with a message
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NewCategory creates a category.
+func NewCategory(name, description string, defaultSubscribed bool) (*Category, error) {
+	name = NormalizeName(name)
+	if name == "" {
+		return nil, errors.New("category name is required")
+	}
+	if len(name) > MaxNameLength {
+		return nil, fmt.Errorf("category name must be at most %d characters", MaxNameLength)
+	}
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("category name %q may only hold lowercase letters, digits, dots, dashes and underscores", name)
+	}
+
+	now := time.Now()
+	c := &Category{
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := c.Update(description, defaultSubscribed); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update updates the description of the category and whether recipients are
+// subscribed to it by default.
+func (c *Category) Update(description string, defaultSubscribed bool) error {
+	description = strings.TrimSpace(description)
+	if len(description) > MaxDescriptionLength {
+		return fmt.Errorf("category description must be at most %d characters", MaxDescriptionLength)
+	}
+
+	c.Description = description
+	c.DefaultSubscribed = defaultSubscribed
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// Receives tells whether a recipient receives the messages of the category
+// given its subscription, nil when the recipient made no choice.
+func (c *Category) Receives(subscription *Subscription) bool {
+	if subscription == nil {
+		return c.DefaultSubscribed
+	}
+	return subscription.Subscribed
+}
+
+// CategoryRepository keeps the categories.
+type CategoryRepository interface {
+	// Save stores a new category
+	Save(ctx context.Context, category *Category) error
+	// Update stores the changes of a category
+	Update(ctx context.Context, category *Category) error
+	// FindByName finds a category, returning a not found error when missing
+	FindByName(ctx context.Context, name string) (*Category, error)
+	// FindAll lists the categories by name
+	FindAll(ctx context.Context) ([]*Category, error)
+	// Delete deletes a category with its subscriptions, returning a not found
+	// error when missing
+	Delete(ctx context.Context, name string) error
+}
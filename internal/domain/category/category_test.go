@@ -0,0 +1,49 @@
+package category
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategory(t *testing.T) {
+	c, err := NewCategory(" Security.Alerts ", " Sign-ins and password changes ", true)
+	require.NoError(t, err)
+	assert.Equal(t, "security.alerts", c.Name)
+	assert.Equal(t, "Sign-ins and password changes", c.Description)
+	assert.True(t, c.DefaultSubscribed)
+
+	_, err = NewCategory(" ", "", false)
+	assert.Error(t, err)
+	_, err = NewCategory("billing news", "", false)
+	assert.Error(t, err)
+	_, err = NewCategory("-billing", "", false)
+	assert.Error(t, err)
+	_, err = NewCategory(strings.Repeat("a", MaxNameLength+1), "", false)
+	assert.Error(t, err)
+	_, err = NewCategory("billing", strings.Repeat("a", MaxDescriptionLength+1), false)
+	assert.Error(t, err)
+}
+
+func TestCategoryReceives(t *testing.T) {
+	optIn, err := NewCategory("marketing", "", false)
+	require.NoError(t, err)
+	optOut, err := NewCategory("security", "", true)
+	require.NoError(t, err)
+
+	subscribed, err := NewSubscription(" Alice@Example.com ", "Marketing", true)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", subscribed.UserID)
+	assert.Equal(t, "marketing", subscribed.Category)
+	unsubscribed, err := NewSubscription("alice@example.com", "security", false)
+	require.NoError(t, err)
+
+	// Only the subscribers receive opt-in categories
+	assert.False(t, optIn.Receives(nil))
+	assert.True(t, optIn.Receives(subscribed))
+	// Everyone receives the other categories until they unsubscribe
+	assert.True(t, optOut.Receives(nil))
+	assert.False(t, optOut.Receives(unsubscribed))
+}
@@ -0,0 +1,50 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"notification/internal/domain/preference"
+)
+
+// Subscription is the choice of a recipient to receive the messages of a
+// category or not. The recipient is identified by the target the channels
+// send to or by the user ID of in-app channels, normalized as for its
+// preferences.
+type Subscription struct {
+	UserID     string
+	Category   string
+	Subscribed bool
+	UpdatedAt  time.Time
+}
+
+// NewSubscription records whether a user receives the messages of a category.
+func NewSubscription(userID, categoryName string, subscribed bool) (*Subscription, error) {
+	userID = preference.NormalizeUserID(userID)
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	categoryName = NormalizeName(categoryName)
+	if categoryName == "" {
+		return nil, errors.New("category name is required")
+	}
+
+	return &Subscription{
+		UserID:     userID,
+		Category:   categoryName,
+		Subscribed: subscribed,
+		UpdatedAt:  time.Now(),
+	}, nil
+}
+
+// SubscriptionRepository keeps the subscriptions of the recipients.
+type SubscriptionRepository interface {
+	// Save creates or replaces the subscription of a user to a category
+	Save(ctx context.Context, subscription *Subscription) error
+	// FindByUserID lists the subscriptions of a user
+	FindByUserID(ctx context.Context, userID string) ([]*Subscription, error)
+	// FindByCategory finds the subscriptions of the given users to a
+	// category, keyed by user ID
+	FindByCategory(ctx context.Context, categoryName string, userIDs []string) (map[string]*Subscription, error)
+}
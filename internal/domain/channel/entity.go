@@ -7,20 +7,35 @@ import (
 	"notification/internal/domain/template"
 )
 
+// MaxFallbackHops is the length of the longest fallback chain, counted from
+// the channel a message is sent to.
+const MaxFallbackHops = 3
+
 // Channel represents the channel aggregate root
 type Channel struct {
 	id             *ChannelID
 	name           *ChannelName
 	description    *Description
 	enabled        bool
+	maintenance    bool
 	channelType    shared.ChannelType
 	templateID     *template.TemplateID
 	commonSettings *shared.CommonSettings
 	config         *ChannelConfig
 	recipients     *Recipients
 	tags           *Tags
-	timestamps     *shared.Timestamps
-	lastUsed       *int64
+	// variableDefaults are merged under the message variables at render time
+	variableDefaults *VariableDefaults
+	ownership        *shared.Ownership
+	// environment is the deployment stage the channel is meant for
+	environment shared.Environment
+	// fallbackChannelID is sent through when a delivery fails after the retries
+	fallbackChannelID *ChannelID
+	health            *ChannelHealth
+	timestamps        *shared.Timestamps
+	lastUsed          *int64
+	// version counts the changes made to the channel by commands, starting at 1
+	version int64
 }
 
 // NewChannel creates a new channel
@@ -61,18 +76,22 @@ func NewChannel(
 	}
 
 	return &Channel{
-		id:             NewChannelID(),
-		name:           name,
-		description:    description,
-		enabled:        enabled,
-		channelType:    channelType,
-		templateID:     templateID,
-		commonSettings: commonSettings,
-		config:         config,
-		recipients:     recipients,
-		tags:           tags,
-		timestamps:     shared.NewTimestamps(),
-		lastUsed:       nil,
+		id:               NewChannelID(),
+		name:             name,
+		description:      description,
+		enabled:          enabled,
+		channelType:      channelType,
+		templateID:       templateID,
+		commonSettings:   commonSettings,
+		config:           config,
+		recipients:       recipients,
+		tags:             tags,
+		variableDefaults: NewVariableDefaults(nil),
+		ownership:        &shared.Ownership{},
+		health:           NewChannelHealth(),
+		timestamps:       shared.NewTimestamps(),
+		lastUsed:         nil,
+		version:          1,
 	}, nil
 }
 
@@ -118,18 +137,22 @@ func NewChannelWithID(
 	}
 
 	return &Channel{
-		id:             id,
-		name:           name,
-		description:    description,
-		enabled:        enabled,
-		channelType:    channelType,
-		templateID:     templateID,
-		commonSettings: commonSettings,
-		config:         config,
-		recipients:     recipients,
-		tags:           tags,
-		timestamps:     shared.NewTimestamps(),
-		lastUsed:       nil,
+		id:               id,
+		name:             name,
+		description:      description,
+		enabled:          enabled,
+		channelType:      channelType,
+		templateID:       templateID,
+		commonSettings:   commonSettings,
+		config:           config,
+		recipients:       recipients,
+		tags:             tags,
+		variableDefaults: NewVariableDefaults(nil),
+		ownership:        &shared.Ownership{},
+		health:           NewChannelHealth(),
+		timestamps:       shared.NewTimestamps(),
+		lastUsed:         nil,
+		version:          1,
 	}, nil
 }
 
@@ -139,28 +162,55 @@ func ReconstructChannel(
 	name *ChannelName,
 	description *Description,
 	enabled bool,
+	maintenance bool,
 	channelType shared.ChannelType,
 	templateID *template.TemplateID,
 	commonSettings *shared.CommonSettings,
 	config *ChannelConfig,
 	recipients *Recipients,
 	tags *Tags,
+	variableDefaults *VariableDefaults,
+	ownership *shared.Ownership,
+	environment shared.Environment,
+	fallbackChannelID *ChannelID,
+	health *ChannelHealth,
 	timestamps *shared.Timestamps,
 	lastUsed *int64,
+	version int64,
 ) *Channel {
+	if variableDefaults == nil {
+		variableDefaults = NewVariableDefaults(nil)
+	}
+	if ownership == nil {
+		ownership = &shared.Ownership{}
+	}
+	if health == nil {
+		health = NewChannelHealth()
+	}
+	if version < 1 {
+		version = 1
+	}
+
 	return &Channel{
-		id:             id,
-		name:           name,
-		description:    description,
-		enabled:        enabled,
-		channelType:    channelType,
-		templateID:     templateID,
-		commonSettings: commonSettings,
-		config:         config,
-		recipients:     recipients,
-		tags:           tags,
-		timestamps:     timestamps,
-		lastUsed:       lastUsed,
+		id:                id,
+		name:              name,
+		description:       description,
+		enabled:           enabled,
+		maintenance:       maintenance,
+		channelType:       channelType,
+		templateID:        templateID,
+		commonSettings:    commonSettings,
+		config:            config,
+		recipients:        recipients,
+		tags:              tags,
+		variableDefaults:  variableDefaults,
+		ownership:         ownership,
+		environment:       environment,
+		fallbackChannelID: fallbackChannelID,
+		health:            health,
+		timestamps:        timestamps,
+		lastUsed:          lastUsed,
+		version:           version,
 	}
 }
 
@@ -184,6 +234,11 @@ func (c *Channel) IsEnabled() bool {
 	return c.enabled
 }
 
+// InMaintenance checks if the messages for the channel are parked.
+func (c *Channel) InMaintenance() bool {
+	return c.maintenance
+}
+
 // ChannelType gets the channel type.
 func (c *Channel) ChannelType() shared.ChannelType {
 	return c.channelType
@@ -214,6 +269,32 @@ func (c *Channel) Tags() *Tags {
 	return c.tags
 }
 
+// VariableDefaults gets the template variable defaults.
+func (c *Channel) VariableDefaults() *VariableDefaults {
+	return c.variableDefaults
+}
+
+// Ownership gets the owner and team of the channel.
+func (c *Channel) Ownership() *shared.Ownership {
+	return c.ownership
+}
+
+// Environment gets the deployment stage of the channel, empty when unscoped.
+func (c *Channel) Environment() shared.Environment {
+	return c.environment
+}
+
+// FallbackChannelID gets the channel sent through when a delivery fails, nil
+// when the channel has no fallback.
+func (c *Channel) FallbackChannelID() *ChannelID {
+	return c.fallbackChannelID
+}
+
+// Health gets the delivery health.
+func (c *Channel) Health() *ChannelHealth {
+	return c.health
+}
+
 // Timestamps gets the timestamps.
 func (c *Channel) Timestamps() *shared.Timestamps {
 	return c.timestamps
@@ -224,6 +305,11 @@ func (c *Channel) LastUsed() *int64 {
 	return c.lastUsed
 }
 
+// Version gets the aggregate version, which the events about the channel carry.
+func (c *Channel) Version() int64 {
+	return c.version
+}
+
 // Update updates the channel.
 func (c *Channel) Update(
 	name *ChannelName,
@@ -272,20 +358,86 @@ func (c *Channel) Update(
 	c.recipients = recipients
 	c.tags = tags
 	c.timestamps.UpdateTimestamp()
+	c.version++
 
 	return nil
 }
 
+// SetVariableDefaults replaces the template variable defaults.
+func (c *Channel) SetVariableDefaults(defaults *VariableDefaults) {
+	if defaults == nil {
+		defaults = NewVariableDefaults(nil)
+	}
+	c.variableDefaults = defaults
+	c.timestamps.UpdateTimestamp()
+}
+
+// SetOwnership replaces the owner and team of the channel.
+func (c *Channel) SetOwnership(ownership *shared.Ownership) {
+	if ownership == nil {
+		ownership = &shared.Ownership{}
+	}
+	c.ownership = ownership
+	c.timestamps.UpdateTimestamp()
+}
+
+// SetEnvironment sets the deployment stage of the channel.
+func (c *Channel) SetEnvironment(environment shared.Environment) {
+	c.environment = environment
+	c.timestamps.UpdateTimestamp()
+}
+
+// SetFallbackChannel replaces the fallback channel; nil removes it.
+func (c *Channel) SetFallbackChannel(fallbackChannelID *ChannelID) error {
+	if fallbackChannelID != nil && fallbackChannelID.Equals(c.id) {
+		return errors.New("a channel cannot fall back to itself")
+	}
+	c.fallbackChannelID = fallbackChannelID
+	c.timestamps.UpdateTimestamp()
+	return nil
+}
+
+// UpdateHealth records the delivery health. The health is bookkeeping, so the
+// update timestamp is left alone.
+func (c *Channel) UpdateHealth(health *ChannelHealth) {
+	if health == nil {
+		health = NewChannelHealth()
+	}
+	c.health = health
+}
+
+// ResetHealth starts a new, healthy window, ignoring the sends before now.
+func (c *Channel) ResetHealth() {
+	c.health = NewChannelHealth()
+}
+
 // Enable enables the channel.
 func (c *Channel) Enable() {
 	c.enabled = true
 	c.timestamps.UpdateTimestamp()
+	c.version++
 }
 
 // Disable disables the channel.
 func (c *Channel) Disable() {
 	c.enabled = false
 	c.timestamps.UpdateTimestamp()
+	c.version++
+}
+
+// StartMaintenance parks the messages for the channel until maintenance ends.
+func (c *Channel) StartMaintenance() {
+	c.maintenance = true
+	c.timestamps.UpdateTimestamp()
+	c.version++
+}
+
+// EndMaintenance lets the channel send again. The parked messages are
+// released by the caller.
+func (c *Channel) EndMaintenance() {
+	c.maintenance = false
+	c.timestamps.UpdateTimestamp()
+	c.version++
 }
 
 // MarkAsUsed marks the channel as used.
@@ -315,6 +467,7 @@ func (c *Channel) Delete() error {
 		return errors.New("channel is already deleted")
 	}
 	c.timestamps.MarkDeleted()
+	c.version++
 	return nil
 }
 
@@ -333,6 +486,20 @@ func (c *Channel) HasAnyTag(tags []string) bool {
 	return c.tags.ContainsAny(tags)
 }
 
+// WithOverrides returns a copy of the channel for a single send, with the
+// recipients replaced and the config keys of the override set. Nil recipients
+// and an empty config keep the channel's own. The copy is not to be saved.
+func (c *Channel) WithOverrides(recipients *Recipients, config map[string]interface{}) *Channel {
+	copied := *c
+	if recipients != nil {
+		copied.recipients = recipients
+	}
+	if len(config) > 0 {
+		copied.config = c.config.WithOverride(config)
+	}
+	return &copied
+}
+
 // MatchesType checks if the channel type matches.
 func (c *Channel) MatchesType(channelType shared.ChannelType) bool {
 	return c.channelType == channelType
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
 )
 
 // ChannelRepository is the interface for the channel repository.
@@ -22,6 +23,20 @@ type ChannelRepository interface {
 	
 	// Update updates a channel.
 	Update(ctx context.Context, channel *Channel) error
+
+	// UpdateEnabled persists only the enabled flag, health and update timestamp of a channel.
+	UpdateEnabled(ctx context.Context, channel *Channel) error
+
+	// ReassignTemplate points every channel referencing one template, including
+	// soft-deleted channels, at another template. A nil target clears the reference.
+	ReassignTemplate(ctx context.Context, from *template.TemplateID, to *template.TemplateID) error
+
+	// CountTags returns the number of channels carrying each tag.
+	CountTags(ctx context.Context) (map[string]int, error)
+
+	// ReplaceTags replaces the given tags with another tag on every channel
+	// carrying one of them and returns the number of channels changed.
+	ReplaceTags(ctx context.Context, from []string, to string) (int, error)
 	
 	// Delete deletes a channel.
 	Delete(ctx context.Context, id *ChannelID) error
@@ -35,9 +50,45 @@ type ChannelRepository interface {
 
 // ChannelFilter is the filter for channels.
 type ChannelFilter struct {
-	ChannelType *shared.ChannelType `json:"channelType,omitempty"`
-	Tags        []string            `json:"tags,omitempty"`
-	Enabled     *bool               `json:"enabled,omitempty"`
+	ChannelType *shared.ChannelType  `json:"channelType,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	// AllTags matches channels carrying every one of the tags, where Tags
+	// matches channels carrying any of them.
+	AllTags []string `json:"allTags,omitempty"`
+	Enabled     *bool                `json:"enabled,omitempty"`
+	TemplateID  *template.TemplateID `json:"templateId,omitempty"`
+	Owner       string               `json:"owner,omitempty"`
+	Team        string               `json:"team,omitempty"`
+	// Environment matches the channels of the environment and the unscoped ones
+	Environment shared.Environment `json:"environment,omitempty"`
+	// LastUsedBefore matches channels last used before the given Unix millisecond
+	// timestamp, including channels that have never been used.
+	LastUsedBefore *int64 `json:"lastUsedBefore,omitempty"`
+	// LastUsedAfter matches channels last used at or after the given Unix millisecond timestamp.
+	LastUsedAfter *int64 `json:"lastUsedAfter,omitempty"`
+	SortField     string `json:"sortField,omitempty"`
+	SortOrder     string `json:"sortOrder,omitempty"`
+}
+
+// Sort orders accepted by ChannelFilter.WithSorting.
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// sortableFields lists the fields channels can be sorted by.
+var sortableFields = map[string]bool{
+	"channelName": true,
+	"channelType": true,
+	"enabled":     true,
+	"createdAt":   true,
+	"updatedAt":   true,
+	"lastUsed":    true,
+}
+
+// IsSortableField checks if channels can be sorted by the given field.
+func IsSortableField(field string) bool {
+	return sortableFields[field]
 }
 
 // NewChannelFilter creates a channel filter.
@@ -57,12 +108,61 @@ func (f *ChannelFilter) WithTags(tags []string) *ChannelFilter {
 	return f
 }
 
+// WithAllTags sets the filter on channels carrying every tag.
+func (f *ChannelFilter) WithAllTags(tags []string) *ChannelFilter {
+	f.AllTags = tags
+	return f
+}
+
 // WithEnabled sets the enabled status filter.
 func (f *ChannelFilter) WithEnabled(enabled bool) *ChannelFilter {
 	f.Enabled = &enabled
 	return f
 }
 
+// WithTemplateID sets the template filter.
+func (f *ChannelFilter) WithTemplateID(templateID *template.TemplateID) *ChannelFilter {
+	f.TemplateID = templateID
+	return f
+}
+
+// WithOwner sets the owner filter.
+func (f *ChannelFilter) WithOwner(owner string) *ChannelFilter {
+	f.Owner = owner
+	return f
+}
+
+// WithTeam sets the team filter.
+func (f *ChannelFilter) WithTeam(team string) *ChannelFilter {
+	f.Team = team
+	return f
+}
+
+// WithEnvironment sets the environment filter.
+func (f *ChannelFilter) WithEnvironment(environment shared.Environment) *ChannelFilter {
+	f.Environment = environment
+	return f
+}
+
+// WithLastUsedBefore sets the upper bound of the last-used filter.
+func (f *ChannelFilter) WithLastUsedBefore(timestamp int64) *ChannelFilter {
+	f.LastUsedBefore = &timestamp
+	return f
+}
+
+// WithLastUsedAfter sets the lower bound of the last-used filter.
+func (f *ChannelFilter) WithLastUsedAfter(timestamp int64) *ChannelFilter {
+	f.LastUsedAfter = &timestamp
+	return f
+}
+
+// WithSorting sets the sort field and order.
+func (f *ChannelFilter) WithSorting(field, order string) *ChannelFilter {
+	f.SortField = field
+	f.SortOrder = order
+	return f
+}
+
 // HasChannelTypeFilter checks if there is a channel type filter.
 func (f *ChannelFilter) HasChannelTypeFilter() bool {
 	return f.ChannelType != nil
@@ -73,7 +173,42 @@ func (f *ChannelFilter) HasTagsFilter() bool {
 	return len(f.Tags) > 0
 }
 
+// HasAllTagsFilter checks if there is a filter on channels carrying every tag.
+func (f *ChannelFilter) HasAllTagsFilter() bool {
+	return len(f.AllTags) > 0
+}
+
 // HasEnabledFilter checks if there is an enabled status filter.
 func (f *ChannelFilter) HasEnabledFilter() bool {
 	return f.Enabled != nil
-}
\ No newline at end of file
+}
+
+// HasTemplateFilter checks if there is a template filter.
+func (f *ChannelFilter) HasTemplateFilter() bool {
+	return f.TemplateID != nil
+}
+
+// HasOwnerFilter checks if there is an owner filter.
+func (f *ChannelFilter) HasOwnerFilter() bool {
+	return f.Owner != ""
+}
+
+// HasTeamFilter checks if there is a team filter.
+func (f *ChannelFilter) HasTeamFilter() bool {
+	return f.Team != ""
+}
+
+// HasEnvironmentFilter checks if there is an environment filter.
+func (f *ChannelFilter) HasEnvironmentFilter() bool {
+	return f.Environment != ""
+}
+
+// HasLastUsedFilter checks if there is a last-used time range filter.
+func (f *ChannelFilter) HasLastUsedFilter() bool {
+	return f.LastUsedBefore != nil || f.LastUsedAfter != nil
+}
+
+// HasSorting checks if a sort field is set.
+func (f *ChannelFilter) HasSorting() bool {
+	return f.SortField != ""
+}
@@ -1,11 +1,17 @@
 package channel
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+
+	"notification/internal/domain/shared"
 )
 
 // ChannelID represents a unique channel identifier
@@ -132,11 +138,80 @@ func (c *ChannelConfig) ToMap() map[string]interface{} {
 	return result
 }
 
+// WithOverride returns a copy of the configuration with the keys of the override set
+func (c *ChannelConfig) WithOverride(override map[string]interface{}) *ChannelConfig {
+	result := c.ToMap()
+	for k, v := range override {
+		result[k] = v
+	}
+	return NewChannelConfig(result)
+}
+
+// secretConfigKeyParts mark the config keys that hold credentials, matched
+// case-insensitively against the key
+var secretConfigKeyParts = []string{"password", "secret", "token", "apikey", "api_key", "credential", "privatekey", "private_key"}
+
+// IsSecretConfigKey reports whether the config key holds a credential whose
+// value must not leave the service
+func IsSecretConfigKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, part := range secretConfigKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// overridableConfigKeys are the config keys a message may override for a
+// single send, per channel type. Credentials and endpoints are left out so
+// that a send can neither redirect a channel nor replace its secrets.
+var overridableConfigKeys = map[shared.ChannelType][]string{
+	shared.ChannelTypeEmail: {"from", "senderEmail"},
+	shared.ChannelTypeSMS:   {"from"},
+}
+
+// OverridableConfigKeys returns the config keys a message may override for the channel type
+func OverridableConfigKeys(channelType shared.ChannelType) []string {
+	keys := make([]string, len(overridableConfigKeys[channelType]))
+	copy(keys, overridableConfigKeys[channelType])
+	return keys
+}
+
+// ValidateConfigOverride checks that a config override only sets keys the
+// channel type allows, each to a non-empty string
+func ValidateConfigOverride(channelType shared.ChannelType, override map[string]interface{}) error {
+	allowed := make(map[string]bool)
+	for _, key := range overridableConfigKeys[channelType] {
+		allowed[key] = true
+	}
+
+	var rejected []string
+	for key, value := range override {
+		if !allowed[key] {
+			rejected = append(rejected, key)
+			continue
+		}
+		if s, ok := value.(string); !ok || strings.TrimSpace(s) == "" {
+			return fmt.Errorf("config override '%s' must be a non-empty string", key)
+		}
+	}
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		return fmt.Errorf("config keys cannot be overridden for %s channels: %s", channelType, strings.Join(rejected, ", "))
+	}
+
+	return nil
+}
+
 // Recipient represents a message recipient
 type Recipient struct {
 	Name   string `json:"name"`
 	Target string `json:"target,omitempty"`
 	Type   string `json:"type"`
+	// Timezone is the IANA time zone of the recipient, which sends scheduled
+	// in local time are sent in; empty when unknown
+	Timezone string `json:"timezone,omitempty"`
 }
 
 // NewRecipient creates a new recipient
@@ -158,6 +233,20 @@ func NewRecipient(name, target, recipientType string) (*Recipient, error) {
 	}, nil
 }
 
+// WithTimezone returns a copy of the recipient in an IANA time zone, empty
+// for an unknown one.
+func (r *Recipient) WithTimezone(timezone string) (*Recipient, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("invalid recipient timezone %q", timezone)
+		}
+	}
+	recipient := *r
+	recipient.Timezone = timezone
+	return &recipient, nil
+}
+
 // Recipients represents a list of recipients
 type Recipients struct {
 	recipients []*Recipient
@@ -192,6 +281,35 @@ func (r *Recipients) Count() int {
 	return len(r.recipients)
 }
 
+// MarshalJSON encodes the recipients as a list
+func (r *Recipients) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.recipients)
+}
+
+// UnmarshalJSON decodes a list of recipients, validating each of them
+func (r *Recipients) UnmarshalJSON(data []byte) error {
+	var recipients []*Recipient
+	if err := json.Unmarshal(data, &recipients); err != nil {
+		return err
+	}
+
+	r.recipients = make([]*Recipient, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient == nil {
+			return errors.New("recipient cannot be null")
+		}
+		validated, err := NewRecipient(recipient.Name, recipient.Target, recipient.Type)
+		if err != nil {
+			return err
+		}
+		if validated, err = validated.WithTimezone(recipient.Timezone); err != nil {
+			return err
+		}
+		r.recipients = append(r.recipients, validated)
+	}
+	return nil
+}
+
 // Tags represents a collection of tags
 type Tags struct {
 	tags []string
@@ -271,3 +389,110 @@ func (t *Tags) ToSlice() []string {
 func (t *Tags) Count() int {
 	return len(t.tags)
 }
+
+// VariableDefaults holds the template variable values a channel falls back
+// to when a message does not set them
+type VariableDefaults struct {
+	data map[string]interface{}
+}
+
+// NewVariableDefaults creates variable defaults
+func NewVariableDefaults(defaults map[string]interface{}) *VariableDefaults {
+	if defaults == nil {
+		defaults = make(map[string]interface{})
+	}
+	return &VariableDefaults{data: defaults}
+}
+
+// Keys returns the names of the defaulted variables
+func (v *VariableDefaults) Keys() []string {
+	keys := make([]string, 0, len(v.data))
+	for k := range v.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsEmpty checks if no variable is defaulted
+func (v *VariableDefaults) IsEmpty() bool {
+	return len(v.data) == 0
+}
+
+// MergeUnder returns the defaults overridden by the given variables
+func (v *VariableDefaults) MergeUnder(variables map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(v.data)+len(variables))
+	for k, value := range v.data {
+		result[k] = value
+	}
+	for k, value := range variables {
+		result[k] = value
+	}
+	return result
+}
+
+// ToMap converts to map
+func (v *VariableDefaults) ToMap() map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, value := range v.data {
+		result[k] = value
+	}
+	return result
+}
+
+// HealthStatus represents the delivery health of a channel
+type HealthStatus string
+
+const (
+	// HealthStatusHealthy means the recent failure rate is below the degraded threshold
+	HealthStatusHealthy HealthStatus = "healthy"
+	// HealthStatusDegraded means the recent failure rate reached the degraded threshold
+	HealthStatusDegraded HealthStatus = "degraded"
+	// HealthStatusDisabled means the channel was disabled for its failure rate
+	HealthStatusDisabled HealthStatus = "disabled"
+)
+
+// ChannelHealth holds the outcome of the recent sends of a channel
+type ChannelHealth struct {
+	Status    HealthStatus
+	Successes int
+	Failures  int
+	// Since is the time the rolling window starts from, moved forward when the health is reset
+	Since     int64
+	CheckedAt int64
+}
+
+// NewChannelHealth creates a healthy channel health with an empty window
+func NewChannelHealth() *ChannelHealth {
+	return &ChannelHealth{
+		Status: HealthStatusHealthy,
+		Since:  time.Now().UnixMilli(),
+	}
+}
+
+// SampleSize returns the number of sends the health is based on
+func (h *ChannelHealth) SampleSize() int {
+	return h.Successes + h.Failures
+}
+
+// FailureRate returns the failure percentage of the recent sends
+func (h *ChannelHealth) FailureRate() float64 {
+	if h.SampleSize() == 0 {
+		return 0
+	}
+	return float64(h.Failures) * 100 / float64(h.SampleSize())
+}
+
+// ReadinessStatus tells what a check of a channel at its provider found
+type ReadinessStatus string
+
+const (
+	// ReadinessReady means the provider accepted the credentials of the channel
+	ReadinessReady ReadinessStatus = "ready"
+	// ReadinessReachable means the provider answered, but offers no way to check the credentials
+	ReadinessReachable ReadinessStatus = "reachable"
+	// ReadinessUnverified means the channel type has no provider to check
+	ReadinessUnverified ReadinessStatus = "unverified"
+	// ReadinessFailed means the provider could not be reached or rejected the credentials
+	ReadinessFailed ReadinessStatus = "failed"
+)
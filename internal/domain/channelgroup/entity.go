@@ -0,0 +1,152 @@
+package channelgroup
+
+import (
+	"errors"
+	"fmt"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// ChannelGroup is the aggregate root bundling several channels under one ID,
+// so that a message can be broadcast to all of them at once.
+type ChannelGroup struct {
+	id          *ChannelGroupID
+	name        *GroupName
+	description *channel.Description
+	members     *Members
+	timestamps  *shared.Timestamps
+}
+
+// NewChannelGroup creates a new channel group.
+func NewChannelGroup(name *GroupName, description *channel.Description, members *Members) (*ChannelGroup, error) {
+	if name == nil {
+		return nil, errors.New("group name is required")
+	}
+
+	// Set default values
+	if description == nil {
+		description, _ = channel.NewDescription("")
+	}
+	if members == nil {
+		members, _ = NewMembers(nil)
+	}
+
+	return &ChannelGroup{
+		id:          NewChannelGroupID(),
+		name:        name,
+		description: description,
+		members:     members,
+		timestamps:  shared.NewTimestamps(),
+	}, nil
+}
+
+// ReconstructChannelGroup reconstructs a channel group from persisted data.
+func ReconstructChannelGroup(
+	id *ChannelGroupID,
+	name *GroupName,
+	description *channel.Description,
+	members *Members,
+	timestamps *shared.Timestamps,
+) *ChannelGroup {
+	return &ChannelGroup{
+		id:          id,
+		name:        name,
+		description: description,
+		members:     members,
+		timestamps:  timestamps,
+	}
+}
+
+// ID gets the group ID.
+func (g *ChannelGroup) ID() *ChannelGroupID {
+	return g.id
+}
+
+// Name gets the group name.
+func (g *ChannelGroup) Name() *GroupName {
+	return g.name
+}
+
+// Description gets the description.
+func (g *ChannelGroup) Description() *channel.Description {
+	return g.description
+}
+
+// Members gets the member channels.
+func (g *ChannelGroup) Members() *Members {
+	return g.members
+}
+
+// Timestamps gets the timestamps.
+func (g *ChannelGroup) Timestamps() *shared.Timestamps {
+	return g.timestamps
+}
+
+// Update updates the name and description of the group.
+func (g *ChannelGroup) Update(name *GroupName, description *channel.Description) error {
+	if name == nil {
+		return errors.New("group name is required")
+	}
+	if description == nil {
+		description, _ = channel.NewDescription("")
+	}
+
+	g.name = name
+	g.description = description
+	g.timestamps.UpdateTimestamp()
+	return nil
+}
+
+// AddMembers adds channels to the group and returns the number added;
+// channels that already are members are skipped.
+func (g *ChannelGroup) AddMembers(channelIDs []*channel.ChannelID) (int, error) {
+	members := &Members{channelIDs: g.members.ToSlice()}
+	added := 0
+	for _, channelID := range channelIDs {
+		if members.add(channelID) {
+			added++
+		}
+	}
+	if members.Count() > MaxMembers {
+		return 0, fmt.Errorf("a channel group accepts at most %d channels, got %d", MaxMembers, members.Count())
+	}
+
+	if added > 0 {
+		g.members = members
+		g.timestamps.UpdateTimestamp()
+	}
+	return added, nil
+}
+
+// RemoveMembers removes channels from the group and returns the number removed.
+func (g *ChannelGroup) RemoveMembers(channelIDs []*channel.ChannelID) int {
+	removed := &Members{channelIDs: channelIDs}
+	kept := make([]*channel.ChannelID, 0, g.members.Count())
+	for _, member := range g.members.channelIDs {
+		if !removed.Contains(member) {
+			kept = append(kept, member)
+		}
+	}
+
+	count := g.members.Count() - len(kept)
+	if count > 0 {
+		g.members = &Members{channelIDs: kept}
+		g.timestamps.UpdateTimestamp()
+	}
+	return count
+}
+
+// Delete soft deletes the group.
+func (g *ChannelGroup) Delete() error {
+	if g.timestamps.IsDeleted() {
+		return errors.New("channel group is already deleted")
+	}
+	g.timestamps.MarkDeleted()
+	return nil
+}
+
+// IsDeleted checks if the group is deleted.
+func (g *ChannelGroup) IsDeleted() bool {
+	return g.timestamps.IsDeleted()
+}
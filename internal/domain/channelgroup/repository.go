@@ -0,0 +1,25 @@
+package channelgroup
+
+import (
+	"context"
+
+	"notification/internal/domain/shared"
+)
+
+// ChannelGroupRepository is the interface for the channel group repository.
+type ChannelGroupRepository interface {
+	// Save saves a channel group.
+	Save(ctx context.Context, group *ChannelGroup) error
+
+	// FindByID finds a channel group by ID.
+	FindByID(ctx context.Context, id *ChannelGroupID) (*ChannelGroup, error)
+
+	// FindAll finds all channel groups, by name.
+	FindAll(ctx context.Context, pagination *shared.Pagination) (*shared.PaginatedResult[*ChannelGroup], error)
+
+	// Update updates a channel group.
+	Update(ctx context.Context, group *ChannelGroup) error
+
+	// ExistsByName checks if a channel group with the specified name exists.
+	ExistsByName(ctx context.Context, name *GroupName) (bool, error)
+}
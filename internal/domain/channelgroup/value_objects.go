@@ -0,0 +1,133 @@
+package channelgroup
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"notification/internal/domain/channel"
+)
+
+// MaxMembers is the maximum number of channels a group may bundle.
+const MaxMembers = 100
+
+// groupNamePattern is the format of group names (letters, numbers, underscores, hyphens)
+var groupNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ChannelGroupID represents a unique channel group identifier
+type ChannelGroupID struct {
+	value string
+}
+
+// NewChannelGroupID creates a new channel group ID
+func NewChannelGroupID() *ChannelGroupID {
+	return &ChannelGroupID{
+		value: "group_" + uuid.New().String(),
+	}
+}
+
+// NewChannelGroupIDFromString creates a channel group ID from string
+func NewChannelGroupIDFromString(id string) (*ChannelGroupID, error) {
+	if id == "" {
+		return nil, errors.New("channel group ID cannot be empty")
+	}
+	return &ChannelGroupID{value: id}, nil
+}
+
+// String returns string representation
+func (g *ChannelGroupID) String() string {
+	return g.value
+}
+
+// Equals compares two channel group IDs for equality
+func (g *ChannelGroupID) Equals(other *ChannelGroupID) bool {
+	if other == nil {
+		return false
+	}
+	return g.value == other.value
+}
+
+// GroupName represents a channel group name
+type GroupName struct {
+	value string
+}
+
+// NewGroupName creates a new group name
+func NewGroupName(name string) (*GroupName, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("group name cannot be empty")
+	}
+	if len(name) > 100 {
+		return nil, errors.New("group name cannot exceed 100 characters")
+	}
+	if !groupNamePattern.MatchString(name) {
+		return nil, errors.New("group name can only contain letters, numbers, underscores, and hyphens")
+	}
+	return &GroupName{value: name}, nil
+}
+
+// String returns string representation
+func (n *GroupName) String() string {
+	return n.value
+}
+
+// Members represents the channels of a group, in the order they were added
+type Members struct {
+	channelIDs []*channel.ChannelID
+}
+
+// NewMembers creates a members collection, dropping duplicates
+func NewMembers(channelIDs []*channel.ChannelID) (*Members, error) {
+	members := &Members{channelIDs: make([]*channel.ChannelID, 0, len(channelIDs))}
+	for _, channelID := range channelIDs {
+		members.add(channelID)
+	}
+	if members.Count() > MaxMembers {
+		return nil, fmt.Errorf("a channel group accepts at most %d channels, got %d", MaxMembers, members.Count())
+	}
+	return members, nil
+}
+
+// add adds a channel unless it is already a member, reporting whether it was added
+func (m *Members) add(channelID *channel.ChannelID) bool {
+	if channelID == nil || m.Contains(channelID) {
+		return false
+	}
+	m.channelIDs = append(m.channelIDs, channelID)
+	return true
+}
+
+// Contains checks if the channel is a member
+func (m *Members) Contains(channelID *channel.ChannelID) bool {
+	for _, member := range m.channelIDs {
+		if member.Equals(channelID) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToSlice converts to slice
+func (m *Members) ToSlice() []*channel.ChannelID {
+	result := make([]*channel.ChannelID, len(m.channelIDs))
+	copy(result, m.channelIDs)
+	return result
+}
+
+// Strings returns the member channel IDs as strings
+func (m *Members) Strings() []string {
+	result := make([]string, len(m.channelIDs))
+	for i, channelID := range m.channelIDs {
+		result[i] = channelID.String()
+	}
+	return result
+}
+
+// Count returns the number of members
+func (m *Members) Count() int {
+	return len(m.channelIDs)
+}
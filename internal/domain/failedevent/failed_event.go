@@ -0,0 +1,59 @@
+package failedevent
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// Status is the state of a persisted failed handler execution
+type Status string
+
+const (
+	// StatusFailed means the handler has not processed the event yet
+	StatusFailed Status = "failed"
+	// StatusReplayed means a replay of the event succeeded
+	StatusReplayed Status = "replayed"
+)
+
+// FailedEvent is an event handler execution that failed on every attempt,
+// stored with the event payload so that it can be replayed once the
+// downstream the handler depends on recovers
+type FailedEvent struct {
+	ID            string
+	EventID       string
+	EventType     string
+	AggregateID   string
+	AggregateType string
+	Version       int64
+	// Payload is the JSON encoded event data
+	Payload     json.RawMessage
+	Handler     string
+	Attempts    int
+	Error       string
+	Status      Status
+	ReplayCount int
+	FailedAt    time.Time
+	ReplayedAt  *time.Time
+}
+
+// Filter filters the listed failed events
+type Filter struct {
+	// Status is empty to list every status
+	Status    Status
+	EventType string
+}
+
+// FailedEventRepository persists failed handler executions
+type FailedEventRepository interface {
+	// Save stores a new failed event
+	Save(ctx context.Context, failed *FailedEvent) error
+	// Update stores the outcome of a replay
+	Update(ctx context.Context, failed *FailedEvent) error
+	// FindByID finds a failed event, returning a not found error when missing
+	FindByID(ctx context.Context, id string) (*FailedEvent, error)
+	// FindAll lists failed events, most recent first
+	FindAll(ctx context.Context, filter Filter, pagination *shared.Pagination) (*shared.PaginatedResult[*FailedEvent], error)
+}
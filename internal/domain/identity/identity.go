@@ -0,0 +1,178 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind is what a sender identity allows sending from.
+type Kind string
+
+const (
+	// KindAddress allows sending from one address, verified by a confirmation email
+	KindAddress Kind = "address"
+	// KindDomain allows sending from any address of a domain, verified by a DNS record
+	KindDomain Kind = "domain"
+)
+
+// Status is the verification status of a sender identity.
+type Status string
+
+const (
+	// StatusPending is an identity waiting for its verification
+	StatusPending Status = "pending"
+	// StatusVerified is an identity the tenant proved it owns
+	StatusVerified Status = "verified"
+)
+
+// VerificationRecordPrefix is the label under a domain whose TXT record
+// verifies it
+const VerificationRecordPrefix = "_notification-verify"
+
+// VerificationValuePrefix starts the TXT record value holding the token
+const VerificationValuePrefix = "notification-verification="
+
+// domainPattern matches the domain names, with at least two labels
+var domainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// ErrInvalidToken is returned when confirming an identity with a wrong token
+var ErrInvalidToken = errors.New("verification token is invalid")
+
+// SenderIdentity is an address or a domain a tenant may send email from once
+// verified.
+type SenderIdentity struct {
+	ID       string
+	TenantID string
+	Kind     Kind
+	// Value is the address or the domain, lowercase
+	Value  string
+	Status Status
+	// Token is the secret the confirmation email links with, or the
+	// verification DNS record of a domain holds
+	Token      string
+	CreatedAt  time.Time
+	VerifiedAt *time.Time
+}
+
+// NewSenderIdentity creates a pending identity of a tenant, an address when
+// value holds an @, else a domain.
+func NewSenderIdentity(tenantID, value string) (*SenderIdentity, error) {
+	tenantID = strings.TrimSpace(tenantID)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return nil, errors.New("sender address or domain is required")
+	}
+
+	kind := KindDomain
+	if strings.Contains(value, "@") {
+		kind = KindAddress
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return nil, fmt.Errorf("sender address %q is invalid", value)
+		}
+		if !domainPattern.MatchString(DomainOf(value)) {
+			return nil, fmt.Errorf("sender address %q has an invalid domain", value)
+		}
+	} else if !domainPattern.MatchString(value) {
+		return nil, fmt.Errorf("sender domain %q is invalid", value)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	return &SenderIdentity{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Kind:      kind,
+		Value:     value,
+		Status:    StatusPending,
+		Token:     token,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// newToken returns a random verification token
+func newToken() (string, error) {
+	token := make([]byte, 20)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// DomainOf returns the domain of an address, lowercase.
+func DomainOf(address string) string {
+	return strings.ToLower(address[strings.LastIndex(address, "@")+1:])
+}
+
+// IsVerified tells whether the tenant proved it owns the identity.
+func (i *SenderIdentity) IsVerified() bool {
+	return i.Status == StatusVerified
+}
+
+// VerificationRecord returns the name and value of the TXT record verifying
+// a domain identity.
+func (i *SenderIdentity) VerificationRecord() (string, string) {
+	return VerificationRecordPrefix + "." + i.Value, VerificationValuePrefix + i.Token
+}
+
+// Confirm verifies an address identity with the token of its confirmation email.
+func (i *SenderIdentity) Confirm(token string) error {
+	if i.Kind != KindAddress {
+		return errors.New("domain identities are verified by their DNS record")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(i.Token)) != 1 {
+		return ErrInvalidToken
+	}
+	i.MarkVerified()
+	return nil
+}
+
+// MarkVerified records the verification of the identity; verifying it again
+// keeps the first verification time.
+func (i *SenderIdentity) MarkVerified() {
+	if i.IsVerified() {
+		return
+	}
+	now := time.Now()
+	i.Status = StatusVerified
+	i.VerifiedAt = &now
+}
+
+// Covers tells whether the identity allows sending from an address, once verified.
+func (i *SenderIdentity) Covers(address string) bool {
+	address = strings.ToLower(strings.TrimSpace(address))
+	if i.Kind == KindAddress {
+		return address == i.Value
+	}
+	return DomainOf(address) == i.Value
+}
+
+// SenderIdentityRepository keeps the sender identities of the tenants.
+type SenderIdentityRepository interface {
+	// Save stores a new identity
+	Save(ctx context.Context, identity *SenderIdentity) error
+	// Update stores the verification of an identity
+	Update(ctx context.Context, identity *SenderIdentity) error
+	// FindByID finds an identity, returning a not found error when missing
+	FindByID(ctx context.Context, id string) (*SenderIdentity, error)
+	// FindByTenant lists the identities of a tenant, by value; every tenant's
+	// when tenantID is empty
+	FindByTenant(ctx context.Context, tenantID string) ([]*SenderIdentity, error)
+	// Delete deletes an identity, returning a not found error when missing
+	Delete(ctx context.Context, id string) error
+}
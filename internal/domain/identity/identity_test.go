@@ -0,0 +1,49 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSenderIdentity(t *testing.T) {
+	address, err := NewSenderIdentity("acme", " Alerts@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, KindAddress, address.Kind)
+	assert.Equal(t, "alerts@example.com", address.Value)
+	assert.Equal(t, StatusPending, address.Status)
+	assert.Len(t, address.Token, 40)
+
+	domain, err := NewSenderIdentity("acme", "Mail.Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, KindDomain, domain.Kind)
+	name, value := domain.VerificationRecord()
+	assert.Equal(t, "_notification-verify.mail.example.com", name)
+	assert.Equal(t, "notification-verification="+domain.Token, value)
+
+	_, err = NewSenderIdentity("", "example.com")
+	assert.Error(t, err)
+	_, err = NewSenderIdentity("acme", "Alerts <alerts@example.com>")
+	assert.Error(t, err, "a display name is not part of the identity")
+	_, err = NewSenderIdentity("acme", "localhost")
+	assert.Error(t, err)
+}
+
+func TestSenderIdentityVerification(t *testing.T) {
+	address, err := NewSenderIdentity("acme", "alerts@example.com")
+	require.NoError(t, err)
+	assert.ErrorIs(t, address.Confirm("wrong"), ErrInvalidToken)
+	assert.False(t, address.IsVerified())
+	require.NoError(t, address.Confirm(address.Token))
+	assert.True(t, address.IsVerified())
+	assert.NotNil(t, address.VerifiedAt)
+	assert.True(t, address.Covers("Alerts@example.com"))
+	assert.False(t, address.Covers("billing@example.com"))
+
+	domain, err := NewSenderIdentity("acme", "example.com")
+	require.NoError(t, err)
+	assert.Error(t, domain.Confirm(domain.Token), "domains are verified by DNS")
+	assert.True(t, domain.Covers("billing@example.com"))
+	assert.False(t, domain.Covers("billing@mail.example.com"))
+}
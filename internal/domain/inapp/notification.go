@@ -0,0 +1,96 @@
+package inapp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"notification/internal/domain/shared"
+)
+
+// MaxTitleLength is the longest title of an in-app notification, longer
+// subjects are truncated.
+const MaxTitleLength = 255
+
+// Notification is an entry of the notification center of a user, stored by
+// the in-app channels for clients to list until the user deletes it
+type Notification struct {
+	ID        string
+	UserID    string
+	ChannelID string
+	// Category is set by the channel for clients to group or decorate entries
+	Category      string
+	Title         string
+	Content       string
+	CorrelationID string
+	CreatedAt     time.Time
+	// ReadAt is nil until the user reads the notification
+	ReadAt *time.Time
+}
+
+// NewNotification creates an unread notification of a user.
+func NewNotification(userID, channelID, title, content string) (*Notification, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if channelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+	title = strings.TrimSpace(title)
+	if title == "" && strings.TrimSpace(content) == "" {
+		return nil, errors.New("notification needs a title or content")
+	}
+	if runes := []rune(title); len(runes) > MaxTitleLength {
+		title = string(runes[:MaxTitleLength])
+	}
+	return &Notification{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		ChannelID: channelID,
+		Title:     title,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsRead reports whether the user read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// Filter filters the listed notifications of a user
+type Filter struct {
+	UserID     string
+	UnreadOnly bool
+	Category   string
+}
+
+// NotificationRepository keeps the notifications of the users.
+type NotificationRepository interface {
+	// Save stores a new notification
+	Save(ctx context.Context, notification *Notification) error
+	// FindByID finds a notification of a user, returning a not found error
+	// when missing or owned by another user
+	FindByID(ctx context.Context, userID, id string) (*Notification, error)
+	// FindAll lists the notifications of a user, most recent first
+	FindAll(ctx context.Context, filter Filter, pagination *shared.Pagination) (*shared.PaginatedResult[*Notification], error)
+	// CountUnread counts the unread notifications of a user
+	CountUnread(ctx context.Context, userID string) (int, error)
+	// MarkRead marks notifications of a user read, every unread one when ids
+	// is empty, and returns how many were unread
+	MarkRead(ctx context.Context, userID string, ids []string, readAt time.Time) (int, error)
+	// Delete deletes a notification of a user, returning a not found error
+	// when missing or owned by another user
+	Delete(ctx context.Context, userID, id string) error
+}
+
+// Notifier tells the clients of a user about a new notification.
+type Notifier interface {
+	// NotificationCreated is called once the notification is stored, with
+	// the unread count of its user
+	NotificationCreated(ctx context.Context, notification *Notification, unreadCount int)
+}
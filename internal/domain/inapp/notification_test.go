@@ -0,0 +1,32 @@
+package inapp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotification(t *testing.T) {
+	notification, err := NewNotification(" user_1 ", "channel_1", " Build failed ", "main is red")
+	require.NoError(t, err)
+	assert.NotEmpty(t, notification.ID)
+	assert.Equal(t, "user_1", notification.UserID)
+	assert.Equal(t, "channel_1", notification.ChannelID)
+	assert.Equal(t, "Build failed", notification.Title)
+	assert.Equal(t, "main is red", notification.Content)
+	assert.False(t, notification.IsRead())
+
+	// Long subjects are truncated to the title limit
+	notification, err = NewNotification("user_1", "channel_1", strings.Repeat("é", MaxTitleLength+10), "")
+	require.NoError(t, err)
+	assert.Len(t, []rune(notification.Title), MaxTitleLength)
+
+	_, err = NewNotification("", "channel_1", "title", "content")
+	assert.Error(t, err)
+	_, err = NewNotification("user_1", "", "title", "content")
+	assert.Error(t, err)
+	_, err = NewNotification("user_1", "channel_1", " ", "")
+	assert.Error(t, err)
+}
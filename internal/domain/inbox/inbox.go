@@ -0,0 +1,57 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Status is the state of a command request in the inbox
+type Status string
+
+const (
+	// StatusProcessing means the command is running, or its process stopped
+	// before the response was stored; the entry is leased until ExpiresAt
+	StatusProcessing Status = "processing"
+	// StatusCompleted means the command succeeded and its response is stored
+	StatusCompleted Status = "completed"
+)
+
+// Entry is a command request received over NATS, identified by the subject,
+// the client and the reqSeqId the client chose. A retried request finds the
+// entry of the first one and is answered with its stored response instead of
+// running the command again.
+type Entry struct {
+	Subject     string
+	ClientID    string
+	ReqSeqID    string
+	Status      Status
+	Response    json.RawMessage
+	ReceivedAt  time.Time
+	CompletedAt *time.Time
+	// ExpiresAt is when the entry is dropped and the reqSeqId may be reused:
+	// the end of the lease of a request in progress, or of the TTL of a
+	// completed response
+	ExpiresAt time.Time
+}
+
+// IsExpired reports whether the entry no longer guards its reqSeqId
+func (e *Entry) IsExpired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// InboxRepository stores the command requests received over NATS
+type InboxRepository interface {
+	// Reserve stores the entry as processing and returns nil, unless an
+	// entry of the same request that has not expired exists, which is
+	// returned instead
+	Reserve(ctx context.Context, entry *Entry) (*Entry, error)
+	// Complete stores the response and expiry of a reserved request, unless a
+	// retry took the entry over after its lease ran out
+	Complete(ctx context.Context, entry *Entry) error
+	// Release removes a reserved request whose command failed, so that a
+	// retry runs it again, unless a retry took the entry over
+	Release(ctx context.Context, entry *Entry) error
+	// DeleteExpired removes the entries expired at now and returns their number
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,89 @@
+package message
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// AcknowledgmentTokenLength is the number of characters of an acknowledgment
+// token
+const AcknowledgmentTokenLength = 32
+
+// Acknowledgment tracks whether a recipient acknowledged a message sent to
+// it through a channel. It is created unacknowledged with the link sent to
+// the recipient, and acknowledged once the link is followed.
+type Acknowledgment struct {
+	Token     string
+	MessageID string
+	ChannelID string
+	// Recipient is the target the link was sent to
+	Recipient string
+	CreatedAt int64
+	// AcknowledgedAt is 0 until acknowledged; AcknowledgedBy is who did,
+	// the recipient unless the link named someone else
+	AcknowledgedAt int64
+	AcknowledgedBy string
+}
+
+// NewAcknowledgment creates the unacknowledged acknowledgment of a message by
+// a recipient, with a random token.
+func NewAcknowledgment(messageID, channelID, recipient string) (*Acknowledgment, error) {
+	if messageID == "" {
+		return nil, errors.New("message ID is required")
+	}
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" {
+		return nil, errors.New("recipient is required")
+	}
+
+	token, err := newAcknowledgmentToken()
+	if err != nil {
+		return nil, err
+	}
+	return &Acknowledgment{
+		Token:     token,
+		MessageID: messageID,
+		ChannelID: channelID,
+		Recipient: recipient,
+		CreatedAt: time.Now().UnixMilli(),
+	}, nil
+}
+
+// IsAcknowledged tells whether the recipient acknowledged the message.
+func (a *Acknowledgment) IsAcknowledged() bool {
+	return a.AcknowledgedAt > 0
+}
+
+// newAcknowledgmentToken returns a random token, unguessable so that a
+// message cannot be acknowledged for its recipients by anyone else
+func newAcknowledgmentToken() (string, error) {
+	buf := make([]byte, AcknowledgmentTokenLength/2)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// AcknowledgmentRepository keeps the acknowledgments of the messages.
+type AcknowledgmentRepository interface {
+	// Save records the new acknowledgments of a send.
+	Save(ctx context.Context, acks []*Acknowledgment) error
+
+	// FindByToken finds an acknowledgment, nil when there is none.
+	FindByToken(ctx context.Context, token string) (*Acknowledgment, error)
+
+	// FindByMessageID lists the acknowledgments of a message, oldest first.
+	FindByMessageID(ctx context.Context, messageID string) ([]*Acknowledgment, error)
+
+	// FindUnacknowledged lists up to limit acknowledgments still pending that
+	// were created before createdBefore, oldest first.
+	FindUnacknowledged(ctx context.Context, createdBefore int64, limit int) ([]*Acknowledgment, error)
+
+	// Acknowledge records who acknowledged a message and when, telling
+	// whether it did; false when it was already acknowledged.
+	Acknowledge(ctx context.Context, token, by string, acknowledgedAt int64) (bool, error)
+}
@@ -0,0 +1,68 @@
+package message
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ScanVerdict is what the virus scan of an attachment found.
+type ScanVerdict string
+
+const (
+	// ScanVerdictClean is an attachment the scanner found nothing in
+	ScanVerdictClean ScanVerdict = "clean"
+	// ScanVerdictInfected is an attachment the scanner found a virus in
+	ScanVerdictInfected ScanVerdict = "infected"
+	// ScanVerdictError is an attachment the scanner could not scan
+	ScanVerdictError ScanVerdict = "error"
+)
+
+// AttachmentScan records the virus scan of an attachment sent with a message
+// through a channel.
+type AttachmentScan struct {
+	Filename string      `json:"filename"`
+	Verdict  ScanVerdict `json:"verdict"`
+	// Signature names the virus found, or the scan error
+	Signature string `json:"signature,omitempty"`
+	// Quarantined is set when the attachment was kept back from the send
+	Quarantined bool  `json:"quarantined,omitempty"`
+	ScannedAt   int64 `json:"scannedAt"`
+}
+
+// QuarantinedAttachment is an infected attachment kept back from a send, held
+// for review.
+type QuarantinedAttachment struct {
+	ID          string
+	MessageID   string
+	ChannelID   string
+	Filename    string
+	ContentType string
+	Content     []byte
+	Signature   string
+	CreatedAt   int64
+}
+
+// NewQuarantinedAttachment creates a quarantined attachment with a new ID.
+func NewQuarantinedAttachment(messageID, channelID, filename, contentType string, content []byte, signature string, createdAt int64) *QuarantinedAttachment {
+	return &QuarantinedAttachment{
+		ID:          uuid.New().String(),
+		MessageID:   messageID,
+		ChannelID:   channelID,
+		Filename:    filename,
+		ContentType: contentType,
+		Content:     content,
+		Signature:   signature,
+		CreatedAt:   createdAt,
+	}
+}
+
+// QuarantineRepository keeps the infected attachments kept back from sends.
+type QuarantineRepository interface {
+	// Save records a quarantined attachment.
+	Save(ctx context.Context, attachment *QuarantinedAttachment) error
+
+	// FindByMessageID lists the quarantined attachments of a message without
+	// their content, oldest first.
+	FindByMessageID(ctx context.Context, messageID string) ([]*QuarantinedAttachment, error)
+}
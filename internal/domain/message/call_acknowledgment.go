@@ -0,0 +1,50 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// CallAcknowledgment records the keys a recipient of a voice call pressed to
+// acknowledge it. The provider reports them while the call goes on, before
+// the result of the send is known, so they are kept by call until the sender
+// reads them back into the result of the recipient.
+type CallAcknowledgment struct {
+	CallSID   string
+	ChannelID string
+	// Digits are the DTMF keys pressed
+	Digits         string
+	AcknowledgedAt int64
+}
+
+// NewCallAcknowledgment creates the acknowledgment of a call.
+func NewCallAcknowledgment(callSID, channelID, digits string) (*CallAcknowledgment, error) {
+	callSID = strings.TrimSpace(callSID)
+	if callSID == "" {
+		return nil, errors.New("call SID is required")
+	}
+	if channelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+	digits = strings.TrimSpace(digits)
+	if digits == "" {
+		return nil, errors.New("digits are required")
+	}
+	return &CallAcknowledgment{
+		CallSID:        callSID,
+		ChannelID:      channelID,
+		Digits:         digits,
+		AcknowledgedAt: time.Now().UnixMilli(),
+	}, nil
+}
+
+// CallAcknowledgmentRepository keeps the acknowledgments of voice calls.
+type CallAcknowledgmentRepository interface {
+	// Save records an acknowledgment; a later one for the same call replaces it.
+	Save(ctx context.Context, ack *CallAcknowledgment) error
+
+	// FindByCallSID finds the acknowledgment of a call, nil when there is none.
+	FindByCallSID(ctx context.Context, callSID string) (*CallAcknowledgment, error)
+}
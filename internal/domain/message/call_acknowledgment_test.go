@@ -0,0 +1,25 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCallAcknowledgment(t *testing.T) {
+	ack, err := NewCallAcknowledgment(" CA123 ", "channel_1", " 1 ")
+	require.NoError(t, err)
+	assert.Equal(t, "CA123", ack.CallSID)
+	assert.Equal(t, "channel_1", ack.ChannelID)
+	assert.Equal(t, "1", ack.Digits)
+	assert.NotZero(t, ack.AcknowledgedAt)
+
+	_, err = NewCallAcknowledgment("", "channel_1", "1")
+	assert.Error(t, err)
+	_, err = NewCallAcknowledgment("CA123", "", "1")
+	assert.Error(t, err)
+	// A call that timed out waiting for a key posts no digits
+	_, err = NewCallAcknowledgment("CA123", "channel_1", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MessageClassVariable is the variable holding the class of a message, which
+// the preference filter and the reports read it from.
+const MessageClassVariable = "notification_class"
+
+// MessageClass tells what a message is sent for, which decides the rules it
+// is sent under.
+type MessageClass string
+
+const (
+	// MessageClassTransactional is a message the recipient needs, such as a
+	// receipt or a password reset. It reaches recipients opting out of its
+	// category or in their quiet hours, and counts against the send quotas
+	// without being rejected by them.
+	MessageClassTransactional MessageClass = "transactional"
+	// MessageClassMarketing is a message the recipient may refuse. It honors
+	// every opt-out, subscription and quiet hours of the recipients and is
+	// rejected over the send quotas. Messages sent without a class are
+	// marketing messages.
+	MessageClassMarketing MessageClass = "marketing"
+)
+
+// ParseMessageClass parses a message class, marketing when empty.
+func ParseMessageClass(value string) (MessageClass, error) {
+	class := MessageClass(strings.ToLower(strings.TrimSpace(value)))
+	switch class {
+	case "":
+		return MessageClassMarketing, nil
+	case MessageClassTransactional, MessageClassMarketing:
+		return class, nil
+	}
+	return "", fmt.Errorf("message class must be transactional or marketing, got %q", value)
+}
+
+// MessageClassOf returns the class of a message from its variables,
+// marketing when it has none or an unknown one.
+func MessageClassOf(variables map[string]interface{}) MessageClass {
+	value, ok := variables[MessageClassVariable].(string)
+	if !ok {
+		return MessageClassMarketing
+	}
+	class, err := ParseMessageClass(value)
+	if err != nil {
+		return MessageClassMarketing
+	}
+	return class
+}
+
+// IsTransactional checks if the class is transactional.
+func (c MessageClass) IsTransactional() bool {
+	return c == MessageClassTransactional
+}
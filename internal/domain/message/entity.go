@@ -13,16 +13,25 @@ type Message struct {
 	channelIDs       *ChannelIDs
 	variables        *Variables
 	channelOverrides *ChannelOverrides
+	correlationID    string
+	tenantID         string
+	strictRender     bool
 	status           MessageStatus
 	results          []*MessageResult
 	createdAt        int64
 }
 
-// NewMessage creates a new message.
+// NewMessage creates a new message. The correlation ID is an optional caller
+// supplied ID tying the message to a business transaction. The tenant ID names
+// the tenant the message is sent and billed for. With strictRender set,
+// variables without a value fail rendering instead of rendering empty.
 func NewMessage(
 	channelIDs *ChannelIDs,
 	variables *Variables,
 	channelOverrides *ChannelOverrides,
+	correlationID string,
+	tenantID string,
+	strictRender bool,
 ) (*Message, error) {
 	// Validate required fields
 	if channelIDs == nil {
@@ -42,6 +51,9 @@ func NewMessage(
 		channelIDs:       channelIDs,
 		variables:        variables,
 		channelOverrides: channelOverrides,
+		correlationID:    correlationID,
+		tenantID:         tenantID,
+		strictRender:     strictRender,
 		status:           MessageStatusPending,
 		results:          make([]*MessageResult, 0),
 		createdAt:        time.Now().UnixMilli(),
@@ -54,6 +66,9 @@ func ReconstructMessage(
 	channelIDs *ChannelIDs,
 	variables *Variables,
 	channelOverrides *ChannelOverrides,
+	correlationID string,
+	tenantID string,
+	strictRender bool,
 	status MessageStatus,
 	results []*MessageResult,
 	createdAt int64,
@@ -63,6 +78,9 @@ func ReconstructMessage(
 		channelIDs:       channelIDs,
 		variables:        variables,
 		channelOverrides: channelOverrides,
+		correlationID:    correlationID,
+		tenantID:         tenantID,
+		strictRender:     strictRender,
 		status:           status,
 		results:          results,
 		createdAt:        createdAt,
@@ -89,6 +107,29 @@ func (m *Message) ChannelOverrides() *ChannelOverrides {
 	return m.channelOverrides
 }
 
+// CorrelationID gets the caller supplied correlation ID, empty when none was given.
+func (m *Message) CorrelationID() string {
+	return m.correlationID
+}
+
+// TenantID gets the tenant the message is sent for.
+func (m *Message) TenantID() string {
+	return m.tenantID
+}
+
+// Class gets the class of the message, kept in its variables.
+func (m *Message) Class() MessageClass {
+	if m.variables == nil {
+		return MessageClassMarketing
+	}
+	return MessageClassOf(m.variables.variables)
+}
+
+// StrictRender checks if variables without a value fail rendering.
+func (m *Message) StrictRender() bool {
+	return m.strictRender
+}
+
 // Status gets the message status.
 func (m *Message) Status() MessageStatus {
 	return m.status
@@ -122,6 +163,26 @@ func (m *Message) AddResult(result *MessageResult) error {
 	return nil
 }
 
+// AddFallbackResult adds the result of sending through the fallback of a
+// channel whose delivery failed. A channel is sent through once per message,
+// so a fallback already holding a result is rejected.
+func (m *Message) AddFallbackResult(from *channel.ChannelID, result *MessageResult) error {
+	if result == nil {
+		return errors.New("message result cannot be nil")
+	}
+	if _, exists := m.GetResult(from); !exists {
+		return errors.New("result for the failed channel not found")
+	}
+	if _, exists := m.GetResult(result.ChannelID()); exists {
+		return errors.New("result for the fallback channel already exists")
+	}
+
+	result.fallbackFrom = from
+	m.results = append(m.results, result)
+	m.updateStatus()
+	return nil
+}
+
 // UpdateResult updates the message result for the specified channel.
 func (m *Message) UpdateResult(channelID *channel.ChannelID, result *MessageResult) error {
 	if channelID == nil {
@@ -133,6 +194,7 @@ func (m *Message) UpdateResult(channelID *channel.ChannelID, result *MessageResu
 	
 	for i, existingResult := range m.results {
 		if existingResult.ChannelID().Equals(channelID) {
+			result.fallbackFrom = existingResult.fallbackFrom
 			m.results[i] = result
 			m.updateStatus()
 			return nil
@@ -159,7 +221,7 @@ func (m *Message) GetResult(channelID *channel.ChannelID) (*MessageResult, bool)
 
 // IsCompleted checks if the message has been processed completely.
 func (m *Message) IsCompleted() bool {
-	return len(m.results) == m.channelIDs.Count()
+	return len(m.results)-len(m.getFallbackResults()) == m.channelIDs.Count()
 }
 
 // GetSuccessfulResults gets the successful results.
@@ -173,6 +235,17 @@ func (m *Message) GetSuccessfulResults() []*MessageResult {
 	return successful
 }
 
+// GetHeldResults gets the results parked for channels in maintenance.
+func (m *Message) GetHeldResults() []*MessageResult {
+	held := make([]*MessageResult, 0)
+	for _, result := range m.results {
+		if result.IsHeld() {
+			held = append(held, result)
+		}
+	}
+	return held
+}
+
 // GetFailedResults gets the failed results.
 func (m *Message) GetFailedResults() []*MessageResult {
 	failed := make([]*MessageResult, 0)
@@ -184,15 +257,60 @@ func (m *Message) GetFailedResults() []*MessageResult {
 	return failed
 }
 
-// updateStatus updates the message status based on the results.
+// getFallbackResults gets the results of the fallback channels.
+func (m *Message) getFallbackResults() []*MessageResult {
+	fallbacks := make([]*MessageResult, 0)
+	for _, result := range m.results {
+		if result.IsFallback() {
+			fallbacks = append(fallbacks, result)
+		}
+	}
+	return fallbacks
+}
+
+// isDelivered checks if the channel of a result or one of its fallbacks
+// sent the message.
+func (m *Message) isDelivered(result *MessageResult) bool {
+	for result != nil {
+		if result.IsSuccess() {
+			return true
+		}
+		next := result
+		result = nil
+		for _, candidate := range m.results {
+			if candidate.IsFallback() && candidate.FallbackFrom().Equals(next.ChannelID()) {
+				result = candidate
+				break
+			}
+		}
+	}
+	return false
+}
+
+// updateStatus updates the message status based on the results. A channel
+// counts as successful when one of its fallbacks sent the message.
 func (m *Message) updateStatus() {
 	if !m.IsCompleted() {
 		m.status = MessageStatusPending
 		return
 	}
 	
-	successCount := len(m.GetSuccessfulResults())
-	totalCount := len(m.results)
+	// Parked channels are still to be sent
+	if len(m.GetHeldResults()) > 0 {
+		m.status = MessageStatusHeld
+		return
+	}
+
+	successCount, totalCount := 0, 0
+	for _, result := range m.results {
+		if result.IsFallback() {
+			continue
+		}
+		totalCount++
+		if m.isDelivered(result) {
+			successCount++
+		}
+	}
 	
 	if successCount == totalCount {
 		m.status = MessageStatusSuccess
@@ -210,6 +328,18 @@ type MessageResult struct {
 	message   string
 	error     *MessageError
 	sentAt    *int64
+	// recipients holds the outcome per recipient when the provider reports it
+	recipients []*RecipientResult
+	// cost is the estimated cost of the send, 0 when the channel is not priced
+	cost float64
+	// fallbackFrom is the channel whose failed delivery led to this send, nil
+	// for the channels the message was sent to
+	fallbackFrom *channel.ChannelID
+	// policyDecisions are the decisions of the pre-send policy hooks, in the
+	// order the hooks ran
+	policyDecisions []*PolicyDecision
+	// attachmentScans are the virus scans of the attachments of the send
+	attachmentScans []*AttachmentScan
 }
 
 // MessageResultStatus is the status of a message result.
@@ -218,6 +348,10 @@ type MessageResultStatus string
 const (
 	MessageResultStatusSuccess MessageResultStatus = "success"
 	MessageResultStatusFailed  MessageResultStatus = "failed"
+	MessageResultStatusHeld    MessageResultStatus = "held"
+	// MessageResultStatusSuppressed is only set on recipients whose
+	// preferences stopped the send to them
+	MessageResultStatusSuppressed MessageResultStatus = "suppressed"
 )
 
 // NewSuccessfulMessageResult creates a successful message result.
@@ -260,6 +394,24 @@ func NewFailedMessageResult(channelID *channel.ChannelID, message string, err *M
 	}, nil
 }
 
+// NewHeldMessageResult creates a result parked until the channel leaves maintenance.
+func NewHeldMessageResult(channelID *channel.ChannelID, message string) (*MessageResult, error) {
+	if channelID == nil {
+		return nil, errors.New("channel ID is required")
+	}
+	if message == "" {
+		return nil, errors.New("message is required")
+	}
+
+	return &MessageResult{
+		channelID: channelID,
+		status:    MessageResultStatusHeld,
+		message:   message,
+		error:     nil,
+		sentAt:    nil,
+	}, nil
+}
+
 // ChannelID gets the channel ID.
 func (mr *MessageResult) ChannelID() *channel.ChannelID {
 	return mr.channelID
@@ -295,10 +447,109 @@ func (mr *MessageResult) IsFailed() bool {
 	return mr.status == MessageResultStatusFailed
 }
 
+// IsHeld checks if it is parked for a channel in maintenance.
+func (mr *MessageResult) IsHeld() bool {
+	return mr.status == MessageResultStatusHeld
+}
+
+// Recipients gets the outcome per recipient, empty when it is not known.
+func (mr *MessageResult) Recipients() []*RecipientResult {
+	return mr.recipients
+}
+
+// Cost gets the estimated cost of the send.
+func (mr *MessageResult) Cost() float64 {
+	return mr.cost
+}
+
+// SetCost sets the estimated cost of the send.
+func (mr *MessageResult) SetCost(cost float64) {
+	mr.cost = cost
+}
+
+// FallbackFrom gets the channel whose failed delivery led to this send.
+func (mr *MessageResult) FallbackFrom() *channel.ChannelID {
+	return mr.fallbackFrom
+}
+
+// IsFallback checks if the send went through the fallback of another channel.
+func (mr *MessageResult) IsFallback() bool {
+	return mr.fallbackFrom != nil
+}
+
+// SetFallbackFrom records the channel whose failed delivery led to this send.
+func (mr *MessageResult) SetFallbackFrom(channelID *channel.ChannelID) {
+	mr.fallbackFrom = channelID
+}
+
+// PolicyDecisions gets the decisions of the pre-send policy hooks.
+func (mr *MessageResult) PolicyDecisions() []*PolicyDecision {
+	return mr.policyDecisions
+}
+
+// SetPolicyDecisions records the decisions of the pre-send policy hooks.
+func (mr *MessageResult) SetPolicyDecisions(decisions []*PolicyDecision) {
+	mr.policyDecisions = decisions
+}
+
+// AttachmentScans gets the virus scans of the attachments.
+func (mr *MessageResult) AttachmentScans() []*AttachmentScan {
+	return mr.attachmentScans
+}
+
+// SetAttachmentScans records the virus scans of the attachments.
+func (mr *MessageResult) SetAttachmentScans(scans []*AttachmentScan) {
+	mr.attachmentScans = scans
+}
+
+// SetRecipients records the outcome per recipient.
+func (mr *MessageResult) SetRecipients(recipients []*RecipientResult) {
+	mr.recipients = recipients
+}
+
+// RecipientResult is the delivery outcome for one recipient of a channel.
+type RecipientResult struct {
+	Target            string              `json:"target"`
+	Status            MessageResultStatus `json:"status"`
+	ProviderMessageID string              `json:"providerMessageId,omitempty"`
+	Error             *MessageError       `json:"error,omitempty"`
+	SentAt            *int64              `json:"sentAt,omitempty"`
+	// Acknowledgment holds the keys a recipient pressed to acknowledge a voice call
+	Acknowledgment string `json:"acknowledgment,omitempty"`
+	AcknowledgedAt *int64 `json:"acknowledgedAt,omitempty"`
+	// Details describe what the send created at the provider, e.g. the key of a Jira issue
+	Details map[string]string `json:"details,omitempty"`
+	// SuppressionReason tells which preference of a suppressed recipient stopped the send
+	SuppressionReason string `json:"suppressionReason,omitempty"`
+}
+
+// IsSuccess checks if the recipient was sent to.
+func (rr *RecipientResult) IsSuccess() bool {
+	return rr.Status == MessageResultStatusSuccess
+}
+
 // MessageError is a message error.
 type MessageError struct {
 	Code    string `json:"code"`
 	Details string `json:"details"`
+	// Category classifies provider failures, empty for failures before the send
+	Category ErrorCategory `json:"category,omitempty"`
+}
+
+// ErrorCategory classifies why a provider failed to send.
+type ErrorCategory string
+
+const (
+	ErrorCategoryInvalidRecipient ErrorCategory = "invalid_recipient"
+	ErrorCategoryAuthFailure      ErrorCategory = "auth_failure"
+	ErrorCategoryRateLimited      ErrorCategory = "rate_limited"
+	ErrorCategoryTemporary        ErrorCategory = "temporary"
+	ErrorCategoryPermanent        ErrorCategory = "permanent"
+)
+
+// IsRetryable checks if sending again may succeed.
+func (c ErrorCategory) IsRetryable() bool {
+	return c == ErrorCategoryRateLimited || c == ErrorCategoryTemporary
 }
 
 // NewMessageError creates a message error.
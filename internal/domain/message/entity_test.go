@@ -0,0 +1,42 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+)
+
+func TestMessage_FallbackResults(t *testing.T) {
+	email, sms, slack := channel.NewChannelID(), channel.NewChannelID(), channel.NewChannelID()
+	channelIDs, err := NewChannelIDs([]*channel.ChannelID{email})
+	require.NoError(t, err)
+	msg, err := NewMessage(channelIDs, NewVariables(nil), NewChannelOverrides(nil), "", "", false)
+	require.NoError(t, err)
+
+	failed, err := NewFailedMessageResult(email, "Send failed", NewMessageError("SEND_ERROR", "timeout"))
+	require.NoError(t, err)
+	require.NoError(t, msg.AddResult(failed))
+	assert.Equal(t, MessageStatusFailed, msg.Status())
+
+	// The fallback fails too, the message stays failed
+	smsFailed, err := NewFailedMessageResult(sms, "Send failed", NewMessageError("SEND_ERROR", "timeout"))
+	require.NoError(t, err)
+	require.NoError(t, msg.AddFallbackResult(email, smsFailed))
+	assert.Equal(t, MessageStatusFailed, msg.Status())
+	assert.True(t, msg.IsCompleted())
+
+	// The fallback of the fallback delivers the message
+	slackSent, err := NewSuccessfulMessageResult(slack, "Sent")
+	require.NoError(t, err)
+	require.NoError(t, msg.AddFallbackResult(sms, slackSent))
+	assert.Equal(t, MessageStatusSuccess, msg.Status())
+	assert.True(t, slackSent.FallbackFrom().Equals(sms))
+
+	// A channel is sent through once per message
+	again, err := NewSuccessfulMessageResult(email, "Sent")
+	require.NoError(t, err)
+	assert.Error(t, msg.AddFallbackResult(slack, again))
+}
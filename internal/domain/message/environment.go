@@ -0,0 +1,12 @@
+package message
+
+// AllowProdVariable is the variable set on the messages whose request allowed
+// a deployment below prod to send them through prod channels, which the
+// deliveries and retries made after the request read it from.
+const AllowProdVariable = "notification_allow_prod"
+
+// AllowsProd checks if the variables of a message allow prod sends.
+func AllowsProd(variables map[string]interface{}) bool {
+	allowed, _ := variables[AllowProdVariable].(bool)
+	return allowed
+}
@@ -0,0 +1,27 @@
+package message
+
+// PolicyAction is what a pre-send policy hook decided for a send.
+type PolicyAction string
+
+const (
+	// PolicyActionAllow lets the send go on unchanged
+	PolicyActionAllow PolicyAction = "allow"
+	// PolicyActionModify lets the send go on with content the hook changed
+	PolicyActionModify PolicyAction = "modify"
+	// PolicyActionAnnotate lets the send go on, recording the hook annotations
+	PolicyActionAnnotate PolicyAction = "annotate"
+	// PolicyActionBlock stops the send
+	PolicyActionBlock PolicyAction = "block"
+	// PolicyActionError records a hook that failed; the send goes on
+	PolicyActionError PolicyAction = "error"
+)
+
+// PolicyDecision records the decision of a pre-send policy hook on the send
+// of a message through a channel, as the audit trail of the send.
+type PolicyDecision struct {
+	Hook        string            `json:"hook"`
+	Action      PolicyAction      `json:"action"`
+	Reason      string            `json:"reason,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	DecidedAt   int64             `json:"decidedAt"`
+}
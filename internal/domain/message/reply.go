@@ -0,0 +1,170 @@
+package message
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReplyTokenLength is the number of characters of a reply token
+const ReplyTokenLength = 24
+
+// MaxReplyBodyLength is the longest body of a reply kept, longer ones are cut
+const MaxReplyBodyLength = 64 * 1024
+
+// ReplyToken correlates the replies to a message sent through a channel.
+// Emails carry it in their reply address; SMS replies are matched to the
+// latest token of their sender on the channel, the recipient of the token.
+type ReplyToken struct {
+	Token     string
+	MessageID string
+	ChannelID string
+	// Recipient is the phone number an SMS was sent to, empty for an email,
+	// whose token covers all its recipients
+	Recipient string
+	CreatedAt int64
+}
+
+// NewReplyToken creates a reply token with a random value for a message sent
+// through a channel.
+func NewReplyToken(messageID, channelID, recipient string) (*ReplyToken, error) {
+	if messageID == "" {
+		return nil, errors.New("message ID is required")
+	}
+	if channelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	buf := make([]byte, ReplyTokenLength/2)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return &ReplyToken{
+		Token:     hex.EncodeToString(buf),
+		MessageID: messageID,
+		ChannelID: channelID,
+		Recipient: recipient,
+		CreatedAt: time.Now().UnixMilli(),
+	}, nil
+}
+
+// ReplyAddress returns the address replies carrying a token are sent to,
+// the inbound address with the token as its plus tag, e.g.
+// replies+<token>@example.com.
+func ReplyAddress(inboundAddress, token string) string {
+	local, domain, ok := strings.Cut(inboundAddress, "@")
+	if !ok {
+		return inboundAddress
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return local + "+" + token + "@" + domain
+}
+
+// ReplyTokenFromAddress returns the token of a reply address, empty when the
+// address has none.
+func ReplyTokenFromAddress(address string) string {
+	local, _, ok := strings.Cut(strings.TrimSpace(address), "@")
+	if !ok {
+		return ""
+	}
+	_, token, ok := strings.Cut(local, "+")
+	if !ok || len(token) != ReplyTokenLength {
+		return ""
+	}
+	return strings.ToLower(token)
+}
+
+// NormalizePhoneNumber returns a phone number as its digits, with the plus
+// sign of the international form, so that the numbers a reply comes from
+// match the ones the messages went to.
+func NormalizePhoneNumber(number string) string {
+	var normalized strings.Builder
+	for i, r := range strings.TrimSpace(number) {
+		if (r == '+' && i == 0) || (r >= '0' && r <= '9') {
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+// Reply is a message received back from a recipient, an SMS or an email,
+// correlated to the message it answers when a reply token matched.
+type Reply struct {
+	ID string
+	// MessageID and ChannelID are the message answered and the channel it
+	// went through; MessageID is empty for a reply no token matched
+	MessageID   string
+	ChannelID   string
+	ChannelType string
+	// From is the phone number or email address the reply came from
+	From    string
+	Subject string
+	Body    string
+	// ProviderID is the ID of the reply at the provider, the SID of an SMS or
+	// the Message-ID of an email
+	ProviderID string
+	ReceivedAt int64
+}
+
+// NewReply creates a reply received from a sender through a channel type.
+func NewReply(channelType, from, subject, body, providerID string) (*Reply, error) {
+	if channelType == "" {
+		return nil, errors.New("channel type is required")
+	}
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return nil, errors.New("sender is required")
+	}
+	if len(body) > MaxReplyBodyLength {
+		body = body[:MaxReplyBodyLength]
+	}
+	return &Reply{
+		ID:          uuid.New().String(),
+		ChannelType: channelType,
+		From:        from,
+		Subject:     strings.TrimSpace(subject),
+		Body:        body,
+		ProviderID:  strings.TrimSpace(providerID),
+		ReceivedAt:  time.Now().UnixMilli(),
+	}, nil
+}
+
+// Correlate ties the reply to the message of a reply token.
+func (r *Reply) Correlate(token *ReplyToken) {
+	r.MessageID = token.MessageID
+	r.ChannelID = token.ChannelID
+}
+
+// ReplyRepository keeps the reply tokens of the messages sent and the
+// replies received.
+type ReplyRepository interface {
+	// SaveTokens records the reply tokens of a send.
+	SaveTokens(ctx context.Context, tokens []*ReplyToken) error
+
+	// FindToken finds a reply token, nil when there is none.
+	FindToken(ctx context.Context, token string) (*ReplyToken, error)
+
+	// FindLatestToken finds the latest reply token of a recipient on a
+	// channel, nil when there is none.
+	FindLatestToken(ctx context.Context, channelID, recipient string) (*ReplyToken, error)
+
+	// Save records a reply.
+	Save(ctx context.Context, reply *Reply) error
+
+	// FindByMessageID lists the replies to a message, oldest first.
+	FindByMessageID(ctx context.Context, messageID string) ([]*Reply, error)
+
+	// List lists up to limit replies received after since, newest first.
+	List(ctx context.Context, since int64, limit int) ([]*Reply, error)
+}
+
+// ReplyNotifier is told of the replies received. Notifying must not fail the
+// reply, so implementations log their errors.
+type ReplyNotifier interface {
+	ReplyReceived(ctx context.Context, reply *Reply)
+}
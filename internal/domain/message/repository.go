@@ -17,4 +17,100 @@ type MessageRepository interface {
 	
 	// Exists checks if a message exists.
 	Exists(ctx context.Context, id *MessageID) (bool, error)
+
+	// CountByChannelsSince counts messages sent to each channel since the given
+	// Unix millisecond timestamp. Channels without messages are omitted.
+	CountByChannelsSince(ctx context.Context, channelIDs []string, since int64) (map[string]int, error)
+
+	// FindHeldByChannel finds the messages with a result parked for the
+	// channel, oldest first.
+	FindHeldByChannel(ctx context.Context, channelID string) ([]*Message, error)
+
+	// CountRecentResults counts the successful and failed results of the last
+	// limit sends to a channel, considering messages created since the given
+	// Unix millisecond timestamp.
+	CountRecentResults(ctx context.Context, channelID string, since int64, limit int) (successes, failures int, err error)
+
+	// SumCosts sums the estimated cost of the sends of the messages matching
+	// the filter, per group, most expensive first. Held sends are not counted.
+	SumCosts(ctx context.Context, filter *CostFilter) ([]*CostTotal, error)
+
+	// FindForExport finds up to limit messages matching the filter with their
+	// results, oldest first, starting after the cursor; a nil cursor starts at
+	// the oldest message.
+	FindForExport(ctx context.Context, filter *ExportFilter, after *ExportCursor, limit int) ([]*Message, error)
+
+	// CountFailures counts the failed and held sends of the messages created
+	// from the given Unix millisecond timestamp, inclusive, to the other,
+	// exclusive, per channel, status and error code, most frequent first.
+	CountFailures(ctx context.Context, from, to int64) ([]*FailureCount, error)
+
+	// CountDispatched counts the messages created from the given Unix
+	// millisecond timestamp, inclusive, to the other, exclusive, and those of
+	// them dispatched within the given milliseconds of their creation, i.e.
+	// with a successful send by then. Held messages are not counted.
+	CountDispatched(ctx context.Context, from, to, within int64) (total, dispatched int, err error)
+}
+
+// ExportFilter is the filter for message exports. Empty fields match every message.
+type ExportFilter struct {
+	// ChannelID matches the messages with a result for the channel
+	ChannelID string
+	Status    MessageStatus
+	TenantID  string
+	// From and To bound the message creation time in Unix milliseconds,
+	// From inclusive and To exclusive; 0 leaves the bound open
+	From int64
+	To   int64
+}
+
+// ExportCursor is the position of an export, which walks the messages in
+// the order of their creation time and ID.
+type ExportCursor struct {
+	CreatedAt int64
+	ID        string
+}
+
+// CostGroupBy is the dimension send costs are aggregated by.
+type CostGroupBy string
+
+const (
+	CostGroupByChannel CostGroupBy = "channel"
+	// CostGroupByTag counts a send once for each tag of its channel; sends to
+	// channels without tags are left out
+	CostGroupByTag    CostGroupBy = "tag"
+	CostGroupByTenant CostGroupBy = "tenant"
+	// CostGroupByClass segments the sends into transactional and marketing
+	CostGroupByClass CostGroupBy = "class"
+)
+
+// IsValid checks if the grouping is supported.
+func (g CostGroupBy) IsValid() bool {
+	return g == CostGroupByChannel || g == CostGroupByTag || g == CostGroupByTenant || g == CostGroupByClass
+}
+
+// CostFilter is the filter for cost reports.
+type CostFilter struct {
+	GroupBy CostGroupBy
+	// From and To bound the message creation time in Unix milliseconds,
+	// From inclusive and To exclusive; 0 leaves the bound open
+	From int64
+	To   int64
+}
+
+// FailureCount is the number of sends to a channel that failed with an error
+// code, or that are held.
+type FailureCount struct {
+	ChannelID string
+	Status    MessageResultStatus
+	// ErrorCode is empty for held sends
+	ErrorCode string
+	Count     int
+}
+
+// CostTotal is the estimated cost of the sends in one group.
+type CostTotal struct {
+	Key   string
+	Sends int
+	Cost  float64
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package message
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ShortLinkCodeLength is the number of characters of a short link code
+const ShortLinkCodeLength = 8
+
+// shortLinkAlphabet holds the characters of the short link codes, readable in an SMS
+const shortLinkAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ShortLink is a link of a message sent in short form, redirecting to its
+// target. Its clicks are counted against the message and channel it was
+// sent in.
+type ShortLink struct {
+	Code      string
+	TargetURL string
+	MessageID string
+	ChannelID string
+	// Clicks counts the redirects; LastClickedAt is 0 until the first one
+	Clicks        int64
+	CreatedAt     int64
+	LastClickedAt int64
+}
+
+// NewShortLink creates a short link with a random code for a link of a message.
+func NewShortLink(targetURL, messageID, channelID string) (*ShortLink, error) {
+	parsed, err := url.Parse(strings.TrimSpace(targetURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("target URL must be an absolute http or https URL")
+	}
+	if messageID == "" {
+		return nil, errors.New("message ID is required")
+	}
+
+	code, err := newShortLinkCode()
+	if err != nil {
+		return nil, err
+	}
+	return &ShortLink{
+		Code:      code,
+		TargetURL: parsed.String(),
+		MessageID: messageID,
+		ChannelID: channelID,
+		CreatedAt: time.Now().UnixMilli(),
+	}, nil
+}
+
+// newShortLinkCode returns a random code, unguessable so that the links of
+// other messages cannot be walked through
+func newShortLinkCode() (string, error) {
+	var code strings.Builder
+	max := big.NewInt(int64(len(shortLinkAlphabet)))
+	for i := 0; i < ShortLinkCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(shortLinkAlphabet[n.Int64()])
+	}
+	return code.String(), nil
+}
+
+// ShortLinkRepository keeps the short links of the messages and their clicks.
+type ShortLinkRepository interface {
+	// Save records a new short link.
+	Save(ctx context.Context, link *ShortLink) error
+
+	// FindByCode finds a short link, nil when there is none.
+	FindByCode(ctx context.Context, code string) (*ShortLink, error)
+
+	// FindByMessageID lists the short links of a message, oldest first.
+	FindByMessageID(ctx context.Context, messageID string) ([]*ShortLink, error)
+
+	// RecordClick counts a click on a short link made at clickedAt.
+	RecordClick(ctx context.Context, code string, clickedAt int64) error
+}
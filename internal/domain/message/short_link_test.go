@@ -0,0 +1,25 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShortLink(t *testing.T) {
+	link, err := NewShortLink("https://shop.example.com/orders/1042", "msg-1", "ch-1")
+	require.NoError(t, err)
+	assert.Len(t, link.Code, ShortLinkCodeLength)
+	assert.Equal(t, "https://shop.example.com/orders/1042", link.TargetURL)
+	assert.Zero(t, link.Clicks)
+
+	other, err := NewShortLink("https://shop.example.com/orders/1042", "msg-1", "ch-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, link.Code, other.Code)
+
+	_, err = NewShortLink("javascript:alert(1)", "msg-1", "ch-1")
+	assert.Error(t, err)
+	_, err = NewShortLink("https://shop.example.com", "", "ch-1")
+	assert.Error(t, err)
+}
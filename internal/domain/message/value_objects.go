@@ -1,6 +1,7 @@
 package message
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -54,12 +55,14 @@ const (
 	MessageStatusFailed         MessageStatus = "failed"
 	MessageStatusPartialSuccess MessageStatus = "partial_success"
 	MessageStatusPending        MessageStatus = "pending"
+	// MessageStatusHeld means the message is parked for channels in maintenance
+	MessageStatusHeld MessageStatus = "held"
 )
 
 // IsValid validates if the message status is valid.
 func (ms MessageStatus) IsValid() bool {
 	switch ms {
-	case MessageStatusSuccess, MessageStatusFailed, MessageStatusPartialSuccess, MessageStatusPending:
+	case MessageStatusSuccess, MessageStatusFailed, MessageStatusPartialSuccess, MessageStatusPending, MessageStatusHeld:
 		return true
 	default:
 		return false
@@ -119,6 +122,8 @@ type ChannelOverride struct {
 	Recipients       *channel.Recipients      `json:"recipients,omitempty"`
 	TemplateOverride *TemplateOverride        `json:"templateOverride,omitempty"`
 	SettingsOverride *shared.CommonSettings   `json:"settingsOverride,omitempty"`
+	// Config overrides the config keys the channel type allows, see channel.OverridableConfigKeys
+	Config map[string]interface{} `json:"config,omitempty"`
 }
 
 // NewChannelOverride creates a channel override setting.
@@ -144,6 +149,30 @@ func (c *ChannelOverride) WithSettingsOverride(settingsOverride *shared.CommonSe
 	return c
 }
 
+// WithConfig sets the config override.
+func (c *ChannelOverride) WithConfig(config map[string]interface{}) *ChannelOverride {
+	c.Config = config
+	return c
+}
+
+// HasConfigOverride checks if there is a config override.
+func (c *ChannelOverride) HasConfigOverride() bool {
+	return len(c.Config) > 0
+}
+
+// Validate checks the override against the type of the channel it applies to.
+func (c *ChannelOverride) Validate(channelType shared.ChannelType) error {
+	if c.HasRecipientsOverride() && c.Recipients.Count() == 0 {
+		return errors.New("recipient override cannot be empty")
+	}
+	if c.HasConfigOverride() {
+		if err := channel.ValidateConfigOverride(channelType, c.Config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // HasRecipientsOverride checks if there is a recipient override.
 func (c *ChannelOverride) HasRecipientsOverride() bool {
 	return c.Recipients != nil
@@ -192,6 +221,51 @@ func (t *TemplateOverride) HasTemplateOverride() bool {
 	return t.Template != nil
 }
 
+// templateOverrideJSON is the JSON form of a template override.
+type templateOverrideJSON struct {
+	Subject  *string `json:"subject,omitempty"`
+	Template *string `json:"template,omitempty"`
+}
+
+// MarshalJSON encodes the subject and template as strings.
+func (t *TemplateOverride) MarshalJSON() ([]byte, error) {
+	var data templateOverrideJSON
+	if t.Subject != nil {
+		subject := t.Subject.String()
+		data.Subject = &subject
+	}
+	if t.Template != nil {
+		content := t.Template.String()
+		data.Template = &content
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON decodes the subject and template, validating them.
+func (t *TemplateOverride) UnmarshalJSON(data []byte) error {
+	var decoded templateOverrideJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*t = TemplateOverride{}
+	if decoded.Subject != nil {
+		subject, err := template.NewSubject(*decoded.Subject)
+		if err != nil {
+			return fmt.Errorf("invalid subject override: %w", err)
+		}
+		t.Subject = subject
+	}
+	if decoded.Template != nil {
+		content, err := template.NewTemplateContent(*decoded.Template)
+		if err != nil {
+			return fmt.Errorf("invalid template override: %w", err)
+		}
+		t.Template = content
+	}
+	return nil
+}
+
 // ChannelOverrides is the channel override setting map.
 type ChannelOverrides struct {
 	overrides map[string]*ChannelOverride
@@ -231,6 +305,26 @@ func (c *ChannelOverrides) HasOverride(channelID string) bool {
 	return exists
 }
 
+// MarshalJSON encodes the overrides as an object keyed by channel ID.
+func (c *ChannelOverrides) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.overrides)
+}
+
+// UnmarshalJSON decodes an object of overrides keyed by channel ID.
+func (c *ChannelOverrides) UnmarshalJSON(data []byte) error {
+	overrides := make(map[string]*ChannelOverride)
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return err
+	}
+	for channelID, override := range overrides {
+		if override == nil {
+			delete(overrides, channelID)
+		}
+	}
+	c.overrides = overrides
+	return nil
+}
+
 // ChannelIDs is the list of channel IDs.
 type ChannelIDs struct {
 	channelIDs []*channel.ChannelID
@@ -0,0 +1,53 @@
+package message
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/shared"
+)
+
+func TestChannelOverrides_JSON(t *testing.T) {
+	data := []byte(`{
+		"ch-1": {
+			"recipients": [{"name": "Ops", "target": "#ops-escalation", "type": "channel"}],
+			"templateOverride": {"subject": "Escalated", "template": "Hello {name}"},
+			"config": {"from": "+15550100"}
+		}
+	}`)
+
+	var overrides ChannelOverrides
+	require.NoError(t, json.Unmarshal(data, &overrides))
+	override, ok := overrides.Get("ch-1")
+	require.True(t, ok)
+	assert.Equal(t, "#ops-escalation", override.Recipients.ToSlice()[0].Target)
+	assert.Equal(t, "Escalated", override.TemplateOverride.Subject.String())
+	assert.Equal(t, "Hello {name}", override.TemplateOverride.Template.String())
+
+	encoded, err := json.Marshal(&overrides)
+	require.NoError(t, err)
+	var decoded ChannelOverrides
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, overrides.ToMap(), decoded.ToMap())
+
+	// Invalid recipients are rejected when decoding
+	assert.Error(t, json.Unmarshal([]byte(`{"ch-1": {"recipients": [{"target": "#ops"}]}}`), &decoded))
+}
+
+func TestChannelOverride_Validate(t *testing.T) {
+	override := NewChannelOverride().WithConfig(map[string]interface{}{"from": "+15550100"})
+	assert.NoError(t, override.Validate(shared.ChannelTypeSMS))
+
+	// Credentials and endpoints cannot be overridden
+	override.WithConfig(map[string]interface{}{"from": "+15550100", "api_key": "stolen", "base_url": "https://example.com"})
+	err := override.Validate(shared.ChannelTypeSMS)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api_key, base_url")
+
+	// Slack channels are retargeted through the recipients, not the config
+	override.WithConfig(map[string]interface{}{"webhook_url": "https://example.com"})
+	assert.Error(t, override.Validate(shared.ChannelTypeSlack))
+}
@@ -0,0 +1,168 @@
+package otp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bounds of the number of digits of a code
+const (
+	MinCodeLength = 4
+	MaxCodeLength = 10
+)
+
+// Defaults of the codes
+const (
+	DefaultCodeLength  = 6
+	DefaultTTL         = 5 * time.Minute
+	DefaultMaxAttempts = 5
+	// DefaultPurpose is the purpose of the codes sent without one
+	DefaultPurpose = "verification"
+)
+
+var (
+	// ErrInvalidCode is returned when verifying a code with a wrong value
+	ErrInvalidCode = errors.New("verification code is invalid")
+	// ErrExpired is returned when verifying a code after its time to live
+	ErrExpired = errors.New("verification code has expired")
+	// ErrAttemptsExceeded is returned when verifying a code whose attempts are used up
+	ErrAttemptsExceeded = errors.New("verification code has no attempts left")
+	// ErrAlreadyVerified is returned when verifying a code a second time
+	ErrAlreadyVerified = errors.New("verification code was already used")
+)
+
+// Code is a one-time verification code sent to a recipient for a purpose,
+// such as a login or a phone number check. Only the hash of the code is kept.
+type Code struct {
+	ID string
+	// Purpose scopes the code, a code sent for a login does not verify a
+	// password reset
+	Purpose string
+	// Recipient is the address or phone number the code was sent to
+	Recipient string
+	ChannelID string
+	// CodeHash is the SHA-256 of the code salted with the ID
+	CodeHash    string
+	Attempts    int
+	MaxAttempts int
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	VerifiedAt  *time.Time
+}
+
+// NewCode creates a code of length digits sent to a recipient through a
+// channel, living for ttl and verified in up to maxAttempts attempts. It
+// returns the code with its value, which is not kept.
+func NewCode(purpose, recipient, channelID string, length int, ttl time.Duration, maxAttempts int) (*Code, string, error) {
+	purpose = strings.TrimSpace(purpose)
+	if purpose == "" {
+		purpose = DefaultPurpose
+	}
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" {
+		return nil, "", errors.New("recipient is required")
+	}
+	if length < MinCodeLength || length > MaxCodeLength {
+		return nil, "", fmt.Errorf("code length must be between %d and %d", MinCodeLength, MaxCodeLength)
+	}
+	if ttl <= 0 {
+		return nil, "", errors.New("code time to live must be positive")
+	}
+	if maxAttempts <= 0 {
+		return nil, "", errors.New("code attempts must be positive")
+	}
+
+	value, err := newValue(length)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	code := &Code{
+		ID:          uuid.New().String(),
+		Purpose:     purpose,
+		Recipient:   recipient,
+		ChannelID:   channelID,
+		MaxAttempts: maxAttempts,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+	code.CodeHash = code.hash(value)
+	return code, value, nil
+}
+
+// newValue returns random decimal digits
+func newValue(length int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%0*s", length, n.String()), nil
+}
+
+// hash returns the hash of a code value, salted with the ID of the code
+func (c *Code) hash(value string) string {
+	sum := sha256.Sum256([]byte(c.ID + ":" + value))
+	return hex.EncodeToString(sum[:])
+}
+
+// AttemptsLeft returns how many more times the code may be tried.
+func (c *Code) AttemptsLeft() int {
+	if c.Attempts >= c.MaxAttempts {
+		return 0
+	}
+	return c.MaxAttempts - c.Attempts
+}
+
+// IsVerified tells whether the code was verified.
+func (c *Code) IsVerified() bool {
+	return c.VerifiedAt != nil
+}
+
+// Verify checks a value against the code at a time, counting the attempt.
+// A code is verified once; a wrong value uses up one attempt.
+func (c *Code) Verify(value string, now time.Time) error {
+	if c.IsVerified() {
+		return ErrAlreadyVerified
+	}
+	if !now.Before(c.ExpiresAt) {
+		return ErrExpired
+	}
+	if c.AttemptsLeft() == 0 {
+		return ErrAttemptsExceeded
+	}
+
+	c.Attempts++
+	if subtle.ConstantTimeCompare([]byte(c.hash(strings.TrimSpace(value))), []byte(c.CodeHash)) != 1 {
+		return ErrInvalidCode
+	}
+	c.VerifiedAt = &now
+	return nil
+}
+
+// CodeRepository keeps the verification codes sent.
+type CodeRepository interface {
+	// Save stores a new code
+	Save(ctx context.Context, code *Code) error
+	// FindByID finds a code, returning a not found error when missing
+	FindByID(ctx context.Context, id string) (*Code, error)
+	// FindLatest finds the latest code sent to a recipient for a purpose, nil
+	// when there is none
+	FindLatest(ctx context.Context, purpose, recipient string) (*Code, error)
+	// Update stores the attempts and the verification of a code when it was
+	// still at attempts tries, returning false when a concurrent verification
+	// changed it first
+	Update(ctx context.Context, code *Code, attempts int) (bool, error)
+	// Delete removes a code, missing codes being no error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,50 @@
+package otp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCode(t *testing.T) {
+	code, value, err := NewCode("", " +15551234567 ", "ch-1", 6, 5*time.Minute, 3)
+	require.NoError(t, err)
+	assert.Len(t, value, 6)
+	assert.Equal(t, DefaultPurpose, code.Purpose)
+	assert.Equal(t, "+15551234567", code.Recipient)
+	assert.NotContains(t, code.CodeHash, value)
+	assert.Equal(t, 3, code.AttemptsLeft())
+
+	_, _, err = NewCode("login", "", "ch-1", 6, time.Minute, 3)
+	assert.Error(t, err)
+	_, _, err = NewCode("login", "alice@example.com", "ch-1", 3, time.Minute, 3)
+	assert.Error(t, err)
+}
+
+func TestCodeVerify(t *testing.T) {
+	code, value, err := NewCode("login", "alice@example.com", "ch-1", 6, 5*time.Minute, 2)
+	require.NoError(t, err)
+	now := time.Now()
+
+	wrong := "000000"
+	if value == wrong {
+		wrong = "111111"
+	}
+	assert.ErrorIs(t, code.Verify(wrong, now), ErrInvalidCode)
+	assert.Equal(t, 1, code.AttemptsLeft())
+	assert.ErrorIs(t, code.Verify(value, code.ExpiresAt), ErrExpired)
+	require.NoError(t, code.Verify(value, now))
+	assert.True(t, code.IsVerified())
+	assert.ErrorIs(t, code.Verify(value, now), ErrAlreadyVerified)
+
+	spent, value, err := NewCode("login", "alice@example.com", "ch-1", 6, 5*time.Minute, 1)
+	require.NoError(t, err)
+	wrong = "000000"
+	if value == wrong {
+		wrong = "111111"
+	}
+	assert.ErrorIs(t, spent.Verify(wrong, now), ErrInvalidCode)
+	assert.ErrorIs(t, spent.Verify(value, now), ErrAttemptsExceeded)
+}
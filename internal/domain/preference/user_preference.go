@@ -0,0 +1,223 @@
+package preference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// MaxCategoryLength is the longest category a user can opt out of
+const MaxCategoryLength = 100
+
+// SuppressionReason tells why the preferences of a user stopped a send to them.
+type SuppressionReason string
+
+const (
+	// SuppressionReasonCategoryOptOut is set when the user opted out of the category of the message
+	SuppressionReasonCategoryOptOut SuppressionReason = "category_opt_out"
+	// SuppressionReasonChannelNotPreferred is set when the user prefers other channel types
+	SuppressionReasonChannelNotPreferred SuppressionReason = "channel_not_preferred"
+	// SuppressionReasonQuietHours is set when the message arrives in the quiet hours of the user
+	SuppressionReasonQuietHours SuppressionReason = "quiet_hours"
+	// SuppressionReasonNotSubscribed is set when the user is not subscribed to the category of the message
+	SuppressionReasonNotSubscribed SuppressionReason = "not_subscribed"
+)
+
+// UserPreference holds how an end user wants to be notified. The user is
+// identified by the target the channels send to, such as an email address or
+// a phone number, or by the user ID of in-app channels.
+type UserPreference struct {
+	UserID string
+	// PreferredChannels are the channel types the user accepts messages
+	// from, empty for every type
+	PreferredChannels []string
+	// QuietHours is the daily do-not-disturb window, nil for none
+	QuietHours *QuietHours
+	// OptOutCategories are the message categories the user does not want
+	OptOutCategories []string
+	// Timezone is the IANA time zone of the user, which sends scheduled in
+	// local time reach the user in; empty when unknown
+	Timezone  string
+	UpdatedAt time.Time
+}
+
+// NormalizeUserID normalizes a user ID or target address so that the
+// preferences of a user match however the channels spell its targets.
+func NormalizeUserID(userID string) string {
+	return strings.ToLower(strings.TrimSpace(userID))
+}
+
+// NewUserPreference creates the preferences of a user.
+func NewUserPreference(userID string, preferredChannels []string, quietHours *QuietHours, optOutCategories []string) (*UserPreference, error) {
+	userID = NormalizeUserID(userID)
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	channels := normalizeList(preferredChannels)
+	for _, channelType := range channels {
+		if _, err := shared.NewChannelType(channelType); err != nil {
+			return nil, fmt.Errorf("preferred channel %q is not a channel type", channelType)
+		}
+	}
+	categories := normalizeList(optOutCategories)
+	for _, category := range categories {
+		if len(category) > MaxCategoryLength {
+			return nil, fmt.Errorf("category %q is longer than %d characters", category, MaxCategoryLength)
+		}
+	}
+
+	return &UserPreference{
+		UserID:            userID,
+		PreferredChannels: channels,
+		QuietHours:        quietHours,
+		OptOutCategories:  categories,
+		UpdatedAt:         time.Now(),
+	}, nil
+}
+
+// SetTimezone sets the IANA time zone of the user, empty for an unknown one.
+func (p *UserPreference) SetTimezone(timezone string) error {
+	timezone = strings.TrimSpace(timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q", timezone)
+		}
+	}
+	p.Timezone = timezone
+	return nil
+}
+
+// Evaluate tells whether a message of a category sent through a channel type
+// at a time reaches the user, returning an empty reason when it does.
+func (p *UserPreference) Evaluate(channelType, category string, at time.Time) SuppressionReason {
+	if category = strings.ToLower(strings.TrimSpace(category)); category != "" && contains(p.OptOutCategories, category) {
+		return SuppressionReasonCategoryOptOut
+	}
+	if len(p.PreferredChannels) > 0 && !contains(p.PreferredChannels, strings.ToLower(channelType)) {
+		return SuppressionReasonChannelNotPreferred
+	}
+	if p.QuietHours != nil && p.QuietHours.Contains(at) {
+		return SuppressionReasonQuietHours
+	}
+	return ""
+}
+
+// EvaluateTransactional tells whether a transactional message sent through a
+// channel type reaches the user, returning an empty reason when it does.
+// Transactional messages are needed by the user, so only the preferred
+// channels stop them, not the category opt-outs nor the quiet hours.
+func (p *UserPreference) EvaluateTransactional(channelType string) SuppressionReason {
+	if len(p.PreferredChannels) > 0 && !contains(p.PreferredChannels, strings.ToLower(channelType)) {
+		return SuppressionReasonChannelNotPreferred
+	}
+	return ""
+}
+
+// QuietHours is a daily window in the time zone of a user during which the
+// user is not disturbed. A window ending before it starts spans midnight.
+type QuietHours struct {
+	// Start and End are times of day formatted as HH:MM
+	Start    string
+	End      string
+	Timezone string
+
+	start    int
+	end      int
+	location *time.Location
+}
+
+// NewQuietHours creates quiet hours from HH:MM times of day and an IANA time
+// zone, UTC when empty.
+func NewQuietHours(start, end, timezone string) (*QuietHours, error) {
+	startMinute, err := parseTimeOfDay(start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours start: %w", err)
+	}
+	endMinute, err := parseTimeOfDay(end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours end: %w", err)
+	}
+	if startMinute == endMinute {
+		return nil, errors.New("quiet hours must end at another time than they start")
+	}
+
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours timezone %q", timezone)
+	}
+
+	return &QuietHours{
+		Start:    strings.TrimSpace(start),
+		End:      strings.TrimSpace(end),
+		Timezone: timezone,
+		start:    startMinute,
+		end:      endMinute,
+		location: location,
+	}, nil
+}
+
+// Contains reports whether a time falls in the quiet hours
+func (q *QuietHours) Contains(at time.Time) bool {
+	local := at.In(q.location)
+	minute := local.Hour()*60 + local.Minute()
+	if q.start < q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
+}
+
+// parseTimeOfDay parses an HH:MM time of day to minutes after midnight
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an HH:MM time", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// normalizeList trims, lowercases and deduplicates the entries of a list
+func normalizeList(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" || contains(normalized, value) {
+			continue
+		}
+		normalized = append(normalized, value)
+	}
+	return normalized
+}
+
+// contains checks if a list holds a value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// PreferenceRepository keeps the preferences of the users.
+type PreferenceRepository interface {
+	// Save creates or replaces the preferences of a user
+	Save(ctx context.Context, preference *UserPreference) error
+	// FindByUserID finds the preferences of a user, returning a not found
+	// error when the user has none
+	FindByUserID(ctx context.Context, userID string) (*UserPreference, error)
+	// FindByUserIDs finds the preferences of the users having some, keyed by
+	// user ID
+	FindByUserIDs(ctx context.Context, userIDs []string) (map[string]*UserPreference, error)
+	// Delete deletes the preferences of a user, returning a not found error
+	// when the user has none
+	Delete(ctx context.Context, userID string) error
+}
@@ -0,0 +1,91 @@
+package preference
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/shared"
+)
+
+func TestNewUserPreference(t *testing.T) {
+	shared.InitializeChannelTypes()
+
+	preference, err := NewUserPreference(" Alice@Example.com ", []string{"Email", "slack", "email", " "}, nil, []string{"Marketing", "marketing"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", preference.UserID)
+	assert.Equal(t, []string{"email", "slack"}, preference.PreferredChannels)
+	assert.Equal(t, []string{"marketing"}, preference.OptOutCategories)
+
+	_, err = NewUserPreference(" ", nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewUserPreference("alice", []string{"pigeon"}, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestNewQuietHours(t *testing.T) {
+	quietHours, err := NewQuietHours("22:00", "07:30", "")
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", quietHours.Timezone)
+
+	_, err = NewQuietHours("22:00", "22:00", "UTC")
+	assert.Error(t, err)
+	_, err = NewQuietHours("25:00", "07:00", "UTC")
+	assert.Error(t, err)
+	_, err = NewQuietHours("22:00", "7", "UTC")
+	assert.Error(t, err)
+	_, err = NewQuietHours("22:00", "07:00", "Mars/Olympus")
+	assert.Error(t, err)
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	overnight, err := NewQuietHours("22:00", "07:00", "Asia/Taipei")
+	require.NoError(t, err)
+	daytime, err := NewQuietHours("09:00", "17:00", "UTC")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		quietHours *QuietHours
+		at         time.Time
+		expected   bool
+	}{
+		// Taipei is UTC+8
+		{"before midnight", overnight, time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC), true},
+		{"after midnight", overnight, time.Date(2026, 3, 1, 22, 59, 0, 0, time.UTC), true},
+		{"at the end", overnight, time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC), false},
+		{"during the day", overnight, time.Date(2026, 3, 1, 4, 0, 0, 0, time.UTC), false},
+		{"at the start", daytime, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), true},
+		{"after the window", daytime, time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.quietHours.Contains(tt.at))
+		})
+	}
+}
+
+func TestUserPreferenceEvaluate(t *testing.T) {
+	shared.InitializeChannelTypes()
+
+	quietHours, err := NewQuietHours("22:00", "07:00", "UTC")
+	require.NoError(t, err)
+	preference, err := NewUserPreference("alice", []string{"email", "inapp"}, quietHours, []string{"marketing"})
+	require.NoError(t, err)
+
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, SuppressionReason(""), preference.Evaluate("email", "", day))
+	assert.Equal(t, SuppressionReason(""), preference.Evaluate("email", "billing", day))
+	assert.Equal(t, SuppressionReasonCategoryOptOut, preference.Evaluate("email", " Marketing ", day))
+	assert.Equal(t, SuppressionReasonChannelNotPreferred, preference.Evaluate("sms", "", day))
+	assert.Equal(t, SuppressionReasonQuietHours, preference.Evaluate("email", "", night))
+
+	// Without preferred channels every channel type is accepted
+	preference.PreferredChannels = nil
+	assert.Equal(t, SuppressionReason(""), preference.Evaluate("sms", "", day))
+}
@@ -0,0 +1,232 @@
+package privacy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestKind is what a privacy request does with the data of its subject
+type RequestKind string
+
+const (
+	// RequestKindExport collects the data stored about the subject
+	RequestKindExport RequestKind = "export"
+	// RequestKindErasure deletes the data stored about the subject
+	RequestKindErasure RequestKind = "erasure"
+)
+
+// RequestStatus is the status of a privacy request
+type RequestStatus string
+
+const (
+	RequestStatusPending   RequestStatus = "pending"
+	RequestStatusRunning   RequestStatus = "running"
+	RequestStatusCompleted RequestStatus = "completed"
+	RequestStatusFailed    RequestStatus = "failed"
+)
+
+// The kinds of data stored about a subject, keys of the reports and exports
+const (
+	// DataMessages are the messages sent to the subject alone, deleted with
+	// their results, acknowledgments, reply tokens, replies and short links
+	DataMessages = "messages"
+	// DataRedactedMessages are the messages also sent to other recipients,
+	// whose results and overrides keep the subject as ErasedTarget
+	DataRedactedMessages   = "redactedMessages"
+	DataAcknowledgments    = "acknowledgments"
+	DataReplyTokens        = "replyTokens"
+	DataReplies            = "replies"
+	DataOTPCodes           = "otpCodes"
+	DataInAppNotifications = "inAppNotifications"
+	// DataScheduledSends are the sends scheduled to the subject
+	DataScheduledSends = "scheduledSends"
+	// DataPreferences and DataSubscriptions are the suppression entries of the
+	// subject: its opt-outs, quiet hours and category subscriptions
+	DataPreferences   = "preferences"
+	DataSubscriptions = "subscriptions"
+	// DataExports are the exports of earlier requests about the subject,
+	// dropped by an erasure
+	DataExports = "exports"
+)
+
+// ErasedTarget replaces the address of an erased subject in the messages
+// shared with other recipients
+const ErasedTarget = "[erased]"
+
+// Report counts the rows a request exported or erased, per kind of data
+type Report map[string]int
+
+// Export holds the rows stored about a subject, per kind of data
+type Export map[string][]map[string]interface{}
+
+// Report counts the rows of an export.
+func (e Export) Report() Report {
+	report := make(Report, len(e))
+	for kind, rows := range e {
+		report[kind] = len(rows)
+	}
+	return report
+}
+
+// Request is a data subject request to export or erase every row stored about
+// a recipient address. It runs in the background; once completed it holds its
+// report, and the export for an export request.
+type Request struct {
+	ID   string
+	Kind RequestKind
+	// Subject is the recipient address the request is about, cleared once it
+	// is erased
+	Subject string
+	// SubjectHash identifies the subject after its erasure
+	SubjectHash string
+	Status      RequestStatus
+	Report      Report
+	Export      Export
+	Error       string
+	// RequestedBy is the identity the request was made with
+	RequestedBy string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// NormalizeSubject returns the form of an address the stored data is matched
+// against, which ignores case.
+func NormalizeSubject(subject string) string {
+	return strings.ToLower(strings.TrimSpace(subject))
+}
+
+// HashSubject returns the SHA-256 of a normalized address.
+func HashSubject(subject string) string {
+	sum := sha256.Sum256([]byte(NormalizeSubject(subject)))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewRequest creates a pending request about a subject.
+func NewRequest(kind RequestKind, subject, requestedBy string) (*Request, error) {
+	if kind != RequestKindExport && kind != RequestKindErasure {
+		return nil, fmt.Errorf("unknown privacy request kind %q", kind)
+	}
+	subject = NormalizeSubject(subject)
+	if subject == "" {
+		return nil, errors.New("recipient is required")
+	}
+	return &Request{
+		ID:          uuid.New().String(),
+		Kind:        kind,
+		Subject:     subject,
+		SubjectHash: HashSubject(subject),
+		Status:      RequestStatusPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// IsFinished tells whether the request completed or failed.
+func (r *Request) IsFinished() bool {
+	return r.Status == RequestStatusCompleted || r.Status == RequestStatusFailed
+}
+
+// Start marks the request running. A request interrupted while running is
+// started again, exporting and erasing being repeatable.
+func (r *Request) Start() error {
+	if r.IsFinished() {
+		return fmt.Errorf("privacy request is already %s", r.Status)
+	}
+	r.Status = RequestStatusRunning
+	return nil
+}
+
+// Complete records the report of a request and, for an export, the data
+// exported. An erasure forgets its subject, keeping its hash.
+func (r *Request) Complete(report Report, export Export, now time.Time) {
+	r.Status = RequestStatusCompleted
+	r.Report = report
+	r.Export = export
+	if r.Kind == RequestKindErasure {
+		r.Subject = ""
+	}
+	r.CompletedAt = &now
+}
+
+// Fail records why the request failed.
+func (r *Request) Fail(cause error, now time.Time) {
+	r.Status = RequestStatusFailed
+	r.Error = cause.Error()
+	r.CompletedAt = &now
+}
+
+// AuditAction is what an audit entry records of a privacy request
+type AuditAction string
+
+const (
+	AuditActionRequested AuditAction = "requested"
+	AuditActionCompleted AuditAction = "completed"
+	AuditActionFailed    AuditAction = "failed"
+)
+
+// AuditEntry records a step of a privacy request. Entries identify the
+// subject by its hash only, so they outlive its erasure.
+type AuditEntry struct {
+	ID          string
+	RequestID   string
+	Kind        RequestKind
+	Action      AuditAction
+	SubjectHash string
+	Actor       string
+	Report      Report
+	Error       string
+	OccurredAt  time.Time
+}
+
+// NewAuditEntry records an action on a request, by the identity that made it.
+func NewAuditEntry(request *Request, action AuditAction) *AuditEntry {
+	return &AuditEntry{
+		ID:          uuid.New().String(),
+		RequestID:   request.ID,
+		Kind:        request.Kind,
+		Action:      action,
+		SubjectHash: request.SubjectHash,
+		Actor:       request.RequestedBy,
+		Report:      request.Report,
+		Error:       request.Error,
+		OccurredAt:  time.Now(),
+	}
+}
+
+// RequestRepository keeps the privacy requests.
+type RequestRepository interface {
+	// Save stores a new request
+	Save(ctx context.Context, request *Request) error
+	// Update stores the status, report, export and subject of a request
+	Update(ctx context.Context, request *Request) error
+	// FindByID finds a request, returning a not found error when missing
+	FindByID(ctx context.Context, id string) (*Request, error)
+	// FindUnfinished lists the pending and running requests, oldest first
+	FindUnfinished(ctx context.Context) ([]*Request, error)
+}
+
+// AuditLog keeps the audit trail of the privacy requests; entries are never
+// changed or deleted.
+type AuditLog interface {
+	// Append records an entry
+	Append(ctx context.Context, entry *AuditEntry) error
+	// List lists up to limit entries, newest first
+	List(ctx context.Context, limit int) ([]*AuditEntry, error)
+}
+
+// SubjectDataStore finds and erases the rows stored about a subject across
+// the messages, their engagement and the suppression entries.
+type SubjectDataStore interface {
+	// Export collects the rows stored about a normalized subject
+	Export(ctx context.Context, subject string) (Export, error)
+	// Erase deletes the rows stored about a normalized subject in one
+	// transaction, along with the exports made of them, and reports them
+	Erase(ctx context.Context, subject string) (Report, error)
+}
@@ -0,0 +1,51 @@
+package privacy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRequest(t *testing.T) {
+	request, err := NewRequest(RequestKindErasure, " Alice@Example.com ", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", request.Subject)
+	assert.Equal(t, HashSubject("ALICE@example.com"), request.SubjectHash)
+	assert.Equal(t, RequestStatusPending, request.Status)
+
+	_, err = NewRequest(RequestKindExport, " ", "admin")
+	assert.Error(t, err)
+	_, err = NewRequest("rectification", "alice@example.com", "admin")
+	assert.Error(t, err)
+}
+
+func TestRequestLifecycle(t *testing.T) {
+	export, err := NewRequest(RequestKindExport, "alice@example.com", "admin")
+	require.NoError(t, err)
+	require.NoError(t, export.Start())
+	data := Export{DataReplies: {{"sender": "alice@example.com"}}, DataOTPCodes: {}}
+	export.Complete(data.Report(), data, time.Now())
+	assert.Equal(t, Report{DataReplies: 1, DataOTPCodes: 0}, export.Report)
+	assert.Equal(t, "alice@example.com", export.Subject)
+	assert.Error(t, export.Start())
+
+	erasure, err := NewRequest(RequestKindErasure, "alice@example.com", "admin")
+	require.NoError(t, err)
+	require.NoError(t, erasure.Start())
+	erasure.Complete(Report{DataMessages: 2}, nil, time.Now())
+	assert.Empty(t, erasure.Subject)
+	assert.NotEmpty(t, erasure.SubjectHash)
+
+	entry := NewAuditEntry(erasure, AuditActionCompleted)
+	assert.Equal(t, erasure.SubjectHash, entry.SubjectHash)
+	assert.Equal(t, "admin", entry.Actor)
+
+	failed, err := NewRequest(RequestKindErasure, "bob@example.com", "admin")
+	require.NoError(t, err)
+	failed.Fail(errors.New("database is down"), time.Now())
+	assert.True(t, failed.IsFinished())
+	assert.Equal(t, "database is down", failed.Error)
+}
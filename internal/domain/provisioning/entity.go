@@ -0,0 +1,271 @@
+package provisioning
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// KindChannelProvisioning is the kind of the sagas provisioning a channel
+const KindChannelProvisioning = "channel_provisioning"
+
+// DataChannelID is the data key of the channel a provisioning saga created
+const DataChannelID = "channelId"
+
+// Saga is the aggregate root of a multi-step operation. Its steps run in
+// order; when one fails, the completed ones are compensated in reverse order.
+// Every transition is persisted, so that the status of a saga can be
+// followed and an interrupted saga can be compensated after a restart.
+type Saga struct {
+	id         *SagaID
+	kind       string
+	status     SagaStatus
+	payload    map[string]interface{}
+	data       map[string]string
+	steps      []*Step
+	err        string
+	timestamps *shared.Timestamps
+}
+
+// NewSaga creates a running saga with the given steps, all pending. The
+// payload is the input of the steps.
+func NewSaga(kind string, stepNames []string, payload map[string]interface{}) (*Saga, error) {
+	if kind == "" {
+		return nil, errors.New("saga kind is required")
+	}
+	if len(stepNames) == 0 {
+		return nil, errors.New("a saga needs at least one step")
+	}
+
+	steps := make([]*Step, 0, len(stepNames))
+	for _, name := range stepNames {
+		steps = append(steps, &Step{Name: name, Status: StepStatusPending})
+	}
+
+	return &Saga{
+		id:         NewSagaID(),
+		kind:       kind,
+		status:     SagaStatusRunning,
+		payload:    payload,
+		data:       make(map[string]string),
+		steps:      steps,
+		timestamps: shared.NewTimestamps(),
+	}, nil
+}
+
+// ReconstructSaga reconstructs a saga from persisted data.
+func ReconstructSaga(
+	id *SagaID,
+	kind string,
+	status SagaStatus,
+	payload map[string]interface{},
+	data map[string]string,
+	steps []*Step,
+	err string,
+	timestamps *shared.Timestamps,
+) *Saga {
+	if data == nil {
+		data = make(map[string]string)
+	}
+	return &Saga{
+		id:         id,
+		kind:       kind,
+		status:     status,
+		payload:    payload,
+		data:       data,
+		steps:      steps,
+		err:        err,
+		timestamps: timestamps,
+	}
+}
+
+// ID gets the saga ID.
+func (s *Saga) ID() *SagaID {
+	return s.id
+}
+
+// Kind gets the kind of operation the saga runs.
+func (s *Saga) Kind() string {
+	return s.kind
+}
+
+// Status gets the saga status.
+func (s *Saga) Status() SagaStatus {
+	return s.status
+}
+
+// Payload gets the input of the steps.
+func (s *Saga) Payload() map[string]interface{} {
+	return s.payload
+}
+
+// Data gets the values the steps recorded for later steps and compensations.
+func (s *Saga) Data() map[string]string {
+	return s.data
+}
+
+// Steps gets the steps in execution order.
+func (s *Saga) Steps() []*Step {
+	return s.steps
+}
+
+// Error gets the error of the failed step, empty unless a step failed.
+func (s *Saga) Error() string {
+	return s.err
+}
+
+// Timestamps gets the timestamps.
+func (s *Saga) Timestamps() *shared.Timestamps {
+	return s.timestamps
+}
+
+// Set records a value for later steps and compensations.
+func (s *Saga) Set(key, value string) {
+	s.data[key] = value
+	s.timestamps.UpdateTimestamp()
+}
+
+// NextStep returns the first pending step, or nil when the saga is not
+// running or no step is pending.
+func (s *Saga) NextStep() *Step {
+	if s.status != SagaStatusRunning {
+		return nil
+	}
+	for _, step := range s.steps {
+		if step.Status == StepStatusPending {
+			return step
+		}
+	}
+	return nil
+}
+
+// StartStep marks a pending step as running.
+func (s *Saga) StartStep(name string) error {
+	step, err := s.step(name, StepStatusPending)
+	if err != nil {
+		return err
+	}
+	step.Status = StepStatusRunning
+	step.StartedAt = time.Now().UnixMilli()
+	s.timestamps.UpdateTimestamp()
+	return nil
+}
+
+// CompleteStep marks a running step as completed, or as skipped when it had
+// nothing to do. The saga completes with its last step.
+func (s *Saga) CompleteStep(name string, skipped bool) error {
+	step, err := s.step(name, StepStatusRunning)
+	if err != nil {
+		return err
+	}
+	step.Status = StepStatusCompleted
+	if skipped {
+		step.Status = StepStatusSkipped
+	}
+	step.FinishedAt = time.Now().UnixMilli()
+
+	if s.NextStep() == nil {
+		s.status = SagaStatusCompleted
+	}
+	s.timestamps.UpdateTimestamp()
+	return nil
+}
+
+// FailStep marks a running step as failed and starts the compensation of
+// the completed steps.
+func (s *Saga) FailStep(name string, cause error) error {
+	step, err := s.step(name, StepStatusRunning)
+	if err != nil {
+		return err
+	}
+	step.Status = StepStatusFailed
+	step.Error = cause.Error()
+	step.FinishedAt = time.Now().UnixMilli()
+
+	s.status = SagaStatusCompensating
+	s.err = fmt.Sprintf("step %s failed: %v", name, cause)
+	s.timestamps.UpdateTimestamp()
+	return nil
+}
+
+// Interrupt fails the step that was running when the saga was interrupted,
+// e.g. by a restart, and starts the compensation. A compensating saga is
+// left as it is, its remaining compensations still have to run.
+func (s *Saga) Interrupt() {
+	if s.status != SagaStatusRunning {
+		return
+	}
+	for _, step := range s.steps {
+		if step.Status == StepStatusRunning {
+			step.Status = StepStatusFailed
+			step.Error = "interrupted"
+			step.FinishedAt = time.Now().UnixMilli()
+		}
+	}
+	s.status = SagaStatusCompensating
+	if s.err == "" {
+		s.err = "saga was interrupted"
+	}
+	s.timestamps.UpdateTimestamp()
+}
+
+// StepsToCompensate returns the completed steps in reverse order, the order
+// they are undone in.
+func (s *Saga) StepsToCompensate() []*Step {
+	if s.status != SagaStatusCompensating {
+		return nil
+	}
+	var steps []*Step
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		if s.steps[i].Status == StepStatusCompleted {
+			steps = append(steps, s.steps[i])
+		}
+	}
+	return steps
+}
+
+// CompensateStep records the outcome of undoing a completed step.
+func (s *Saga) CompensateStep(name string, cause error) error {
+	step, err := s.step(name, StepStatusCompleted)
+	if err != nil {
+		return err
+	}
+	step.Status = StepStatusCompensated
+	if cause != nil {
+		step.Status = StepStatusCompensationFailed
+		step.Error = cause.Error()
+	}
+	s.timestamps.UpdateTimestamp()
+	return nil
+}
+
+// FinishCompensation ends a compensating saga, as compensated when every
+// completed step was undone and as failed otherwise.
+func (s *Saga) FinishCompensation() {
+	if s.status != SagaStatusCompensating {
+		return
+	}
+	s.status = SagaStatusCompensated
+	for _, step := range s.steps {
+		if step.Status == StepStatusCompensationFailed || step.Status == StepStatusCompleted {
+			s.status = SagaStatusFailed
+		}
+	}
+	s.timestamps.UpdateTimestamp()
+}
+
+// step returns the named step, which must be in the given status.
+func (s *Saga) step(name string, status StepStatus) (*Step, error) {
+	for _, step := range s.steps {
+		if step.Name != name {
+			continue
+		}
+		if step.Status != status {
+			return nil, fmt.Errorf("step %s is %s, expected %s", name, step.Status, status)
+		}
+		return step, nil
+	}
+	return nil, fmt.Errorf("saga %s has no step %s", s.id, name)
+}
@@ -0,0 +1,76 @@
+package provisioning
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaga_CompensatesCompletedStepsInReverseOrder(t *testing.T) {
+	saga, err := NewSaga(KindChannelProvisioning, []string{"create", "verify", "test", "enable"}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, saga.StartStep("create"))
+	require.NoError(t, saga.CompleteStep("create", false))
+	require.NoError(t, saga.StartStep("verify"))
+	require.NoError(t, saga.CompleteStep("verify", true))
+	assert.Equal(t, "test", saga.NextStep().Name)
+
+	// A step can only be started while it is pending
+	assert.Error(t, saga.StartStep("create"))
+
+	require.NoError(t, saga.StartStep("test"))
+	require.NoError(t, saga.FailStep("test", errors.New("smtp timeout")))
+	assert.Equal(t, SagaStatusCompensating, saga.Status())
+	assert.Nil(t, saga.NextStep())
+	assert.Contains(t, saga.Error(), "smtp timeout")
+
+	// Skipped steps did nothing, so only the created channel is undone
+	toCompensate := saga.StepsToCompensate()
+	require.Len(t, toCompensate, 1)
+	assert.Equal(t, "create", toCompensate[0].Name)
+
+	require.NoError(t, saga.CompensateStep("create", nil))
+	saga.FinishCompensation()
+	assert.Equal(t, SagaStatusCompensated, saga.Status())
+	assert.True(t, saga.Status().IsFinal())
+	assert.Equal(t, StepStatusPending, saga.Steps()[3].Status)
+}
+
+func TestSaga_FailsWhenCompensationFails(t *testing.T) {
+	saga, err := NewSaga(KindChannelProvisioning, []string{"create", "enable"}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, saga.StartStep("create"))
+	require.NoError(t, saga.CompleteStep("create", false))
+	require.NoError(t, saga.StartStep("enable"))
+
+	// A restart while a step runs fails that step
+	saga.Interrupt()
+	assert.Equal(t, SagaStatusCompensating, saga.Status())
+	assert.Equal(t, StepStatusFailed, saga.Steps()[1].Status)
+
+	require.NoError(t, saga.CompensateStep("create", errors.New("legacy system unavailable")))
+	saga.FinishCompensation()
+	assert.Equal(t, SagaStatusFailed, saga.Status())
+	assert.Equal(t, StepStatusCompensationFailed, saga.Steps()[0].Status)
+}
+
+func TestSaga_CompletesWithLastStep(t *testing.T) {
+	saga, err := NewSaga(KindChannelProvisioning, []string{"create", "enable"}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, saga.StartStep("create"))
+	require.NoError(t, saga.CompleteStep("create", false))
+	assert.Equal(t, SagaStatusRunning, saga.Status())
+	require.NoError(t, saga.StartStep("enable"))
+	require.NoError(t, saga.CompleteStep("enable", true))
+	assert.Equal(t, SagaStatusCompleted, saga.Status())
+
+	// A completed saga is not interrupted
+	saga.Interrupt()
+	assert.Equal(t, SagaStatusCompleted, saga.Status())
+	assert.Empty(t, saga.StepsToCompensate())
+}
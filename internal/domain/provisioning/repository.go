@@ -0,0 +1,20 @@
+package provisioning
+
+import (
+	"context"
+)
+
+// SagaRepository is the interface for the saga repository.
+type SagaRepository interface {
+	// Save saves a new saga.
+	Save(ctx context.Context, saga *Saga) error
+
+	// Update updates the status, data and steps of a saga.
+	Update(ctx context.Context, saga *Saga) error
+
+	// FindByID finds a saga by ID.
+	FindByID(ctx context.Context, id *SagaID) (*Saga, error)
+
+	// FindUnfinished finds the running and compensating sagas of a kind, oldest first.
+	FindUnfinished(ctx context.Context, kind string) ([]*Saga, error)
+}
@@ -0,0 +1,75 @@
+package provisioning
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// SagaID represents a unique saga identifier
+type SagaID struct {
+	value string
+}
+
+// NewSagaID creates a new saga ID
+func NewSagaID() *SagaID {
+	return &SagaID{
+		value: "saga_" + uuid.New().String(),
+	}
+}
+
+// NewSagaIDFromString creates a saga ID from string
+func NewSagaIDFromString(id string) (*SagaID, error) {
+	if id == "" {
+		return nil, errors.New("saga ID cannot be empty")
+	}
+	return &SagaID{value: id}, nil
+}
+
+// String returns string representation
+func (s *SagaID) String() string {
+	return s.value
+}
+
+// SagaStatus is the state of a saga
+type SagaStatus string
+
+const (
+	// SagaStatusRunning means the steps are being executed
+	SagaStatusRunning SagaStatus = "running"
+	// SagaStatusCompleted means every step succeeded
+	SagaStatusCompleted SagaStatus = "completed"
+	// SagaStatusCompensating means a step failed and the completed steps are being undone
+	SagaStatusCompensating SagaStatus = "compensating"
+	// SagaStatusCompensated means a step failed and every completed step was undone
+	SagaStatusCompensated SagaStatus = "compensated"
+	// SagaStatusFailed means a step failed and at least one completed step could not be undone
+	SagaStatusFailed SagaStatus = "failed"
+)
+
+// IsFinal reports whether the saga has finished
+func (s SagaStatus) IsFinal() bool {
+	return s == SagaStatusCompleted || s == SagaStatusCompensated || s == SagaStatusFailed
+}
+
+// StepStatus is the state of a saga step
+type StepStatus string
+
+const (
+	StepStatusPending            StepStatus = "pending"
+	StepStatusRunning            StepStatus = "running"
+	StepStatusCompleted          StepStatus = "completed"
+	StepStatusSkipped            StepStatus = "skipped"
+	StepStatusFailed             StepStatus = "failed"
+	StepStatusCompensated        StepStatus = "compensated"
+	StepStatusCompensationFailed StepStatus = "compensation_failed"
+)
+
+// Step is the persisted state of one saga step
+type Step struct {
+	Name       string
+	Status     StepStatus
+	Error      string
+	StartedAt  int64
+	FinishedAt int64
+}
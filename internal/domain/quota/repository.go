@@ -0,0 +1,15 @@
+package quota
+
+import (
+	"context"
+)
+
+// UsageRepository is the interface for the quota usage repository.
+type UsageRepository interface {
+	// Consume adds the charges to the usage of their periods, all of them or
+	// none. It returns an *ExceededError when a charge would exceed its limit.
+	Consume(ctx context.Context, charges []*Charge) error
+
+	// FindUsed returns the number of sends a subject used in a period.
+	FindUsed(ctx context.Context, scope Scope, subjectID string, period Period, periodKey string) (int, error)
+}
@@ -0,0 +1,130 @@
+package quota
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// DefaultTenantID is the tenant charged for messages sent without a tenant.
+const DefaultTenantID = "default"
+
+// Scope is what a quota limits.
+type Scope string
+
+const (
+	ScopeTenant  Scope = "tenant"
+	ScopeChannel Scope = "channel"
+)
+
+// Period is the time span a quota applies to. Periods follow the UTC calendar.
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"
+	PeriodMonthly Period = "monthly"
+)
+
+// Periods lists the quota periods.
+var Periods = []Period{PeriodDaily, PeriodMonthly}
+
+// Key returns the key of the period containing t, e.g. 2024-05-31 or 2024-05.
+func (p Period) Key(t time.Time) string {
+	if p == PeriodMonthly {
+		return t.UTC().Format("2006-01")
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// End returns the start of the period following the one containing t.
+func (p Period) End(t time.Time) time.Time {
+	t = t.UTC()
+	if p == PeriodMonthly {
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// Limit holds the number of sends allowed per period. A zero limit is unlimited.
+type Limit struct {
+	Daily   int
+	Monthly int
+}
+
+// For returns the limit of a period.
+func (l Limit) For(period Period) int {
+	if period == PeriodMonthly {
+		return l.Monthly
+	}
+	return l.Daily
+}
+
+// Charge is a number of sends counted against the quota of a subject.
+type Charge struct {
+	Scope     Scope
+	SubjectID string
+	Period    Period
+	PeriodKey string
+	Amount    int
+	// Limit is the quota of the period, 0 counts the sends without limiting them
+	Limit int
+}
+
+// SortCharges orders charges by scope, subject, period and period key, the
+// order their usage rows are locked in, so that concurrent messages charging
+// the same subjects cannot deadlock.
+func SortCharges(charges []*Charge) {
+	slices.SortFunc(charges, func(a, b *Charge) int {
+		return cmp.Or(
+			cmp.Compare(a.Scope, b.Scope),
+			cmp.Compare(a.SubjectID, b.SubjectID),
+			cmp.Compare(a.Period, b.Period),
+			cmp.Compare(a.PeriodKey, b.PeriodKey),
+		)
+	})
+}
+
+// Usage is the number of sends a subject used in a period.
+type Usage struct {
+	Scope     Scope
+	SubjectID string
+	Period    Period
+	PeriodKey string
+	Used      int
+	Limit     int
+	// ResetsAt is when the next period starts, in Unix milliseconds
+	ResetsAt int64
+}
+
+// IsUnlimited checks if the usage has no limit.
+func (u *Usage) IsUnlimited() bool {
+	return u.Limit <= 0
+}
+
+// Remaining returns the number of sends left in the period.
+func (u *Usage) Remaining() int {
+	if u.Used >= u.Limit {
+		return 0
+	}
+	return u.Limit - u.Used
+}
+
+// ExceededError is returned when a charge would exceed its quota.
+type ExceededError struct {
+	Charge *Charge
+	Used   int
+}
+
+// Error implements error.
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s quota of %s '%s' exceeded: %d of %d sends used, %d requested",
+		e.Charge.Period, e.Charge.Scope, e.Charge.SubjectID, e.Used, e.Charge.Limit, e.Charge.Amount)
+}
+
+// Kind implements shared.KindedError.
+func (e *ExceededError) Kind() shared.ErrorKind {
+	return shared.ErrorKindQuotaExceeded
+}
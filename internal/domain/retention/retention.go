@@ -0,0 +1,79 @@
+// Package retention holds how long the messages of the tenants are kept: the
+// content of a message is purged after a first window, the message and its
+// delivery metadata are deleted after a second one.
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+// Window holds how long a tenant keeps the content and the metadata of its
+// messages; a zero duration keeps them forever.
+type Window struct {
+	Content  time.Duration
+	Metadata time.Duration
+}
+
+// IsZero tells whether the window keeps the messages forever.
+func (w Window) IsZero() bool {
+	return w.Content <= 0 && w.Metadata <= 0
+}
+
+// Policy holds the retention windows of the tenants.
+type Policy struct {
+	// Default is the window of the tenants without their own
+	Default Window
+	// Tenants holds the windows per tenant ID
+	Tenants map[string]Window
+}
+
+// For returns the window of a tenant.
+func (p Policy) For(tenantID string) Window {
+	if window, ok := p.Tenants[tenantID]; ok {
+		return window
+	}
+	return p.Default
+}
+
+// Report is what one run of the retention job did for one tenant.
+type Report struct {
+	ID       string
+	RunID    string
+	TenantID string
+	// ContentBefore and MetadataBefore are the creation times before which
+	// the content was purged and the messages deleted, nil when kept
+	ContentBefore  *time.Time
+	MetadataBefore *time.Time
+	// ContentPurged and MessagesDeleted count the messages of the run
+	ContentPurged   int
+	MessagesDeleted int
+	// Error is why the run stopped early for the tenant, empty on success
+	Error       string
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Store purges and deletes the messages of the tenants.
+type Store interface {
+	// Tenants lists the IDs of the tenants having messages.
+	Tenants(ctx context.Context) ([]string, error)
+
+	// PurgeContent purges the content of the messages of a tenant created
+	// before a time, returning the number of messages purged.
+	PurgeContent(ctx context.Context, tenantID string, before time.Time) (int, error)
+
+	// DeleteMessages deletes the messages of a tenant created before a time
+	// with their results and related rows, returning the number deleted.
+	DeleteMessages(ctx context.Context, tenantID string, before time.Time) (int, error)
+}
+
+// ReportRepository keeps the reports of the retention job.
+type ReportRepository interface {
+	// Save saves the reports of a run.
+	Save(ctx context.Context, reports []*Report) error
+
+	// List lists the latest reports, newest first, of a tenant or of every
+	// tenant when tenantID is empty.
+	List(ctx context.Context, tenantID string, limit int) ([]*Report, error)
+}
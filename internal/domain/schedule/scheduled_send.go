@@ -0,0 +1,169 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the state of a scheduled send.
+type Status string
+
+const (
+	// StatusScheduled is a send waiting for its time
+	StatusScheduled Status = "scheduled"
+	// StatusDeferred is a send held back by a blackout of the calendar until
+	// it ends
+	StatusDeferred Status = "deferred"
+	// StatusSending is a send a worker claimed and is sending
+	StatusSending Status = "sending"
+	// StatusSent is a send whose message was sent
+	StatusSent Status = "sent"
+	// StatusFailed is a send whose message could not be sent
+	StatusFailed Status = "failed"
+	// StatusCancelled is a send cancelled before its time
+	StatusCancelled Status = "cancelled"
+)
+
+// LocalTimeLayout is the layout of the local times sends are scheduled at,
+// such as 2026-03-01T09:00
+const LocalTimeLayout = "2006-01-02T15:04"
+
+// ParseLocalTime returns the instant a local time falls on in a time zone,
+// UTC when empty.
+func ParseLocalTime(localTime, timezone string) (time.Time, error) {
+	location, err := LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sendAt, err := time.ParseInLocation(LocalTimeLayout, strings.TrimSpace(localTime), location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("local time %q is not of the form %s", localTime, LocalTimeLayout)
+	}
+	return sendAt, nil
+}
+
+// LoadLocation loads an IANA time zone, UTC when empty.
+func LoadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", timezone)
+	}
+	return location, nil
+}
+
+// ScheduledSend is a send request held until its time. A send scheduled in
+// recipient-local time fans out into one scheduled send per time zone of the
+// recipients, sharing a batch ID.
+type ScheduledSend struct {
+	ID      string
+	BatchID string
+	// TenantID is the tenant of the message, empty for the default one
+	TenantID string
+	// Timezone is the time zone of the recipients of the send, empty for an
+	// absolute schedule
+	Timezone string
+	SendAt   time.Time
+	// Request is the send request, as JSON
+	Request string
+	// Critical sends go out even during the blackouts of the calendar
+	Critical bool
+	Status   Status
+	// DeferredFrom is the time the send was scheduled at before a blackout
+	// deferred it, and DeferredBy that blackout
+	DeferredFrom *time.Time
+	DeferredBy   string
+	// MessageID is the message the send created, once sent
+	MessageID string
+	// Error is why the send failed
+	Error     string
+	CreatedAt time.Time
+	SentAt    *time.Time
+}
+
+// NewScheduledSend creates a send of a batch scheduled at a time.
+func NewScheduledSend(batchID, tenantID, timezone string, sendAt time.Time, request string, critical bool) (*ScheduledSend, error) {
+	if batchID == "" {
+		return nil, errors.New("batch ID is required")
+	}
+	if request == "" {
+		return nil, errors.New("send request is required")
+	}
+	return &ScheduledSend{
+		ID:        uuid.New().String(),
+		BatchID:   batchID,
+		TenantID:  tenantID,
+		Timezone:  timezone,
+		SendAt:    sendAt,
+		Request:   request,
+		Critical:  critical,
+		Status:    StatusScheduled,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NewBatchID returns the ID of a new batch of scheduled sends.
+func NewBatchID() string {
+	return "schedule_" + uuid.New().String()
+}
+
+// IsPending tells whether the send still waits for its time.
+func (s *ScheduledSend) IsPending() bool {
+	return s.Status == StatusScheduled || s.Status == StatusDeferred
+}
+
+// Defer holds the send back until a blackout ends, keeping the time it was
+// first scheduled at.
+func (s *ScheduledSend) Defer(until time.Time, blackoutID string) {
+	if s.DeferredFrom == nil {
+		scheduledAt := s.SendAt
+		s.DeferredFrom = &scheduledAt
+	}
+	s.Status = StatusDeferred
+	s.SendAt = until
+	s.DeferredBy = blackoutID
+}
+
+// MarkSent records the message the send created.
+func (s *ScheduledSend) MarkSent(messageID string) {
+	now := time.Now()
+	s.Status = StatusSent
+	s.MessageID = messageID
+	s.Error = ""
+	s.SentAt = &now
+}
+
+// MarkFailed records why the send failed.
+func (s *ScheduledSend) MarkFailed(err error) {
+	now := time.Now()
+	s.Status = StatusFailed
+	s.Error = err.Error()
+	s.SentAt = &now
+}
+
+// ScheduledSendRepository keeps the scheduled sends.
+type ScheduledSendRepository interface {
+	// Save stores the sends of a batch
+	Save(ctx context.Context, sends []*ScheduledSend) error
+	// FindByBatch lists the sends of a batch by send time, returning a not
+	// found error when the batch has none
+	FindByBatch(ctx context.Context, batchID string) ([]*ScheduledSend, error)
+	// FindDue lists up to limit pending sends whose time is not after now,
+	// the earliest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*ScheduledSend, error)
+	// Claim moves a pending send to sending, telling whether this caller
+	// claimed it; false when another worker did or it was cancelled
+	Claim(ctx context.Context, id string) (bool, error)
+	// Update stores the outcome or the deferral of a send
+	Update(ctx context.Context, send *ScheduledSend) error
+	// CancelBatch cancels the sends of a batch still pending, returning how
+	// many it cancelled
+	CancelBatch(ctx context.Context, batchID string) (int, error)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// AcknowledgmentLinksConfigKey is the email and Slack channel config flag
+// asking the recipients of its messages to acknowledge them
+const AcknowledgmentLinksConfigKey = "acknowledgment_links"
+
+// AcknowledgmentLinksRequested checks if a channel asks the recipients of its
+// messages to acknowledge them. Only email and Slack channels carry the links.
+func AcknowledgmentLinksRequested(ch *channel.Channel) bool {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) && !ch.ChannelType().Equals(shared.ChannelTypeSlack) {
+		return false
+	}
+	value, ok := ch.Config().Get(AcknowledgmentLinksConfigKey)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// AcknowledgmentLinks is the domain service adding an acknowledgment link per
+// recipient to the messages of the channels asking for them: a link at the
+// end of an email, a button under a Slack message. Following the link records
+// the acknowledgment of the recipient, so that the messages left
+// unacknowledged can be escalated. A send whose links cannot be recorded goes
+// out without them.
+type AcknowledgmentLinks struct {
+	repo message.AcknowledgmentRepository
+	// baseURL is the public address of the service the links point to
+	baseURL string
+	logger  *logger.Logger
+}
+
+// NewAcknowledgmentLinks creates the acknowledgment links to baseURL/ack/<token>.
+func NewAcknowledgmentLinks(repo message.AcknowledgmentRepository, baseURL string, logger *logger.Logger) *AcknowledgmentLinks {
+	return &AcknowledgmentLinks{
+		repo:    repo,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		logger:  logger,
+	}
+}
+
+// Add records an acknowledgment per recipient of a message sent through a
+// channel and sets their links on the content, in place. Channels not asking
+// for acknowledgments are left alone.
+func (a *AcknowledgmentLinks) Add(ctx context.Context, ch *channel.Channel, content *RenderedContent, messageID, channelID string) {
+	if content == nil || !AcknowledgmentLinksRequested(ch) {
+		return
+	}
+
+	var acks []*message.Acknowledgment
+	for _, recipient := range ch.Recipients().ToSlice() {
+		if recipient.Target == "" {
+			continue
+		}
+		ack, err := message.NewAcknowledgment(messageID, channelID, recipient.Target)
+		if err != nil {
+			a.logger.WithContext(ctx).Warn("Failed to create acknowledgment, sending without the links",
+				zap.String("message_id", messageID),
+				zap.String("channel_id", channelID),
+				zap.Error(err))
+			return
+		}
+		acks = append(acks, ack)
+	}
+	if len(acks) == 0 {
+		return
+	}
+
+	if err := a.repo.Save(ctx, acks); err != nil {
+		a.logger.WithContext(ctx).Warn("Failed to save acknowledgments, sending without the links",
+			zap.String("message_id", messageID),
+			zap.String("channel_id", channelID),
+			zap.Error(err))
+		return
+	}
+
+	content.AcknowledgmentLinks = make(map[string]string, len(acks))
+	for _, ack := range acks {
+		content.AcknowledgmentLinks[ack.Recipient] = a.baseURL + "/ack/" + ack.Token
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+// savedAcknowledgments keeps the acknowledgments saved, by token
+type savedAcknowledgments struct {
+	message.AcknowledgmentRepository
+	acks map[string]*message.Acknowledgment
+}
+
+func (r *savedAcknowledgments) Save(ctx context.Context, acks []*message.Acknowledgment) error {
+	for _, ack := range acks {
+		r.acks[ack.Token] = ack
+	}
+	return nil
+}
+
+func TestAcknowledgmentLinks(t *testing.T) {
+	log, err := logger.NewLogger(&config.LoggerConfig{Level: "error", Format: "json", OutputPath: os.DevNull})
+	require.NoError(t, err)
+	repo := &savedAcknowledgments{acks: map[string]*message.Acknowledgment{}}
+	links := NewAcknowledgmentLinks(repo, "https://notify.example.com/", log)
+
+	alice, err := channel.NewRecipient("Alice", "alice@example.com", "to")
+	require.NoError(t, err)
+	bob, err := channel.NewRecipient("Bob", "bob@example.com", "cc")
+	require.NoError(t, err)
+	recipients := channel.NewRecipients([]*channel.Recipient{alice, bob})
+
+	plain := &RenderedContent{Content: "<p>Disk full</p>"}
+	links.Add(context.Background(), newEmailChannel(t, nil).WithOverrides(recipients, nil), plain, "msg-1", "ch-1")
+	assert.Nil(t, plain.AcknowledgmentLinks, "the channel does not ask for acknowledgments")
+	assert.Empty(t, repo.acks)
+
+	ch := newEmailChannel(t, map[string]interface{}{AcknowledgmentLinksConfigKey: true}).WithOverrides(recipients, nil)
+	content := &RenderedContent{Content: "<p>Disk full</p>"}
+	links.Add(context.Background(), ch, content, "msg-1", "ch-1")
+	require.Len(t, content.AcknowledgmentLinks, 2)
+	require.Len(t, repo.acks, 2)
+	for token, ack := range repo.acks {
+		assert.Equal(t, "https://notify.example.com/ack/"+token, content.AcknowledgmentLinks[ack.Recipient])
+		assert.Equal(t, "msg-1", ack.MessageID)
+		assert.False(t, ack.IsAcknowledged())
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/pkg/logger"
+)
+
+// AttachmentScanner scans the content of an attachment for viruses, e.g.
+// with ClamAV.
+type AttachmentScanner interface {
+	// Scan returns the name of the virus found, empty when the content is clean
+	Scan(ctx context.Context, content []byte) (string, error)
+}
+
+// AttachmentScanConfigKey is the channel config key choosing what happens to
+// the infected attachments of its sends, one of the attachment scan actions
+const AttachmentScanConfigKey = "attachment_scan"
+
+// Attachment scan actions
+const (
+	// AttachmentScanOff sends the attachments unscanned
+	AttachmentScanOff = "off"
+	// AttachmentScanReject fails the sends with an infected attachment
+	AttachmentScanReject = "reject"
+	// AttachmentScanQuarantine keeps the infected attachments back for review
+	// and sends the message without them
+	AttachmentScanQuarantine = "quarantine"
+)
+
+var (
+	// ErrAttachmentInfected is returned for a send rejected for an infected attachment
+	ErrAttachmentInfected = errors.New("attachment is infected")
+	// ErrAttachmentScanFailed is returned for a send whose attachments could not be scanned
+	ErrAttachmentScanFailed = errors.New("attachment scan failed")
+)
+
+// AttachmentScanning is the domain service scanning the attachments of each
+// send for viruses before delivery, rejecting the send or quarantining the
+// infected attachments as its channel asks.
+type AttachmentScanning struct {
+	scanner    AttachmentScanner
+	quarantine message.QuarantineRepository
+	// defaultAction applies to the channels without an attachment_scan config
+	defaultAction string
+	// failOpen sends the attachments that could not be scanned rather than
+	// failing the send for a retry
+	failOpen bool
+	logger   *logger.Logger
+}
+
+// NewAttachmentScanning creates an attachment scanning.
+func NewAttachmentScanning(
+	scanner AttachmentScanner,
+	quarantine message.QuarantineRepository,
+	defaultAction string,
+	failOpen bool,
+	logger *logger.Logger,
+) *AttachmentScanning {
+	return &AttachmentScanning{
+		scanner:       scanner,
+		quarantine:    quarantine,
+		defaultAction: defaultAction,
+		failOpen:      failOpen,
+		logger:        logger,
+	}
+}
+
+// actionFor returns the attachment scan action of a channel. An unknown
+// action rejects, so that a typo does not let infected files through.
+func (a *AttachmentScanning) actionFor(ch *channel.Channel) string {
+	value, ok := ch.Config().Get(AttachmentScanConfigKey)
+	if !ok {
+		return a.defaultAction
+	}
+	action, _ := value.(string)
+	switch action {
+	case AttachmentScanOff, AttachmentScanReject, AttachmentScanQuarantine:
+		return action
+	default:
+		return AttachmentScanReject
+	}
+}
+
+// Scan scans the attachments of the content of a message sent through a
+// channel, removing the quarantined ones from the content. It returns the
+// scans, and an error wrapping ErrAttachmentInfected or
+// ErrAttachmentScanFailed when the send must not go on.
+func (a *AttachmentScanning) Scan(ctx context.Context, ch *channel.Channel, content *RenderedContent, messageID string) ([]*message.AttachmentScan, error) {
+	if content == nil || len(content.Attachments) == 0 {
+		return nil, nil
+	}
+	action := a.actionFor(ch)
+	if action == AttachmentScanOff {
+		return nil, nil
+	}
+
+	log := a.logger.WithContext(ctx).WithFields(
+		zap.String("message_id", messageID),
+		zap.String("channel_id", ch.ID().String()))
+
+	scans := make([]*message.AttachmentScan, 0, len(content.Attachments))
+	kept := make([]*RenderedAttachment, 0, len(content.Attachments))
+	for _, attachment := range content.Attachments {
+		signature, err := a.scanner.Scan(ctx, attachment.Content)
+		scan := &message.AttachmentScan{
+			Filename:  attachment.Filename,
+			Verdict:   message.ScanVerdictClean,
+			ScannedAt: time.Now().UnixMilli(),
+		}
+		scans = append(scans, scan)
+
+		switch {
+		case err != nil:
+			scan.Verdict = message.ScanVerdictError
+			scan.Signature = err.Error()
+			if !a.failOpen {
+				return scans, fmt.Errorf("%w: %s: %v", ErrAttachmentScanFailed, attachment.Filename, err)
+			}
+			log.Warn("Failed to scan attachment, sending it unscanned",
+				zap.String("filename", attachment.Filename),
+				zap.Error(err))
+		case signature != "":
+			scan.Verdict = message.ScanVerdictInfected
+			scan.Signature = signature
+			log.Warn("Infected attachment found",
+				zap.String("filename", attachment.Filename),
+				zap.String("signature", signature),
+				zap.String("action", action))
+			if action == AttachmentScanReject {
+				return scans, fmt.Errorf("%w: %s: %s", ErrAttachmentInfected, attachment.Filename, signature)
+			}
+
+			quarantined := message.NewQuarantinedAttachment(messageID, ch.ID().String(), attachment.Filename,
+				attachment.ContentType, attachment.Content, signature, scan.ScannedAt)
+			if err := a.quarantine.Save(ctx, quarantined); err != nil {
+				return scans, fmt.Errorf("%w: failed to quarantine %s: %v", ErrAttachmentScanFailed, attachment.Filename, err)
+			}
+			scan.Quarantined = true
+			continue
+		}
+		kept = append(kept, attachment)
+	}
+
+	content.Attachments = kept
+	return scans, nil
+}
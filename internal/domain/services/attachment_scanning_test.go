@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/message"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+// fakeScanner finds the EICAR test signature in the contents starting with "X5O",
+// and fails on the contents starting with "?"
+type fakeScanner struct{}
+
+func (fakeScanner) Scan(ctx context.Context, content []byte) (string, error) {
+	switch {
+	case len(content) > 0 && content[0] == '?':
+		return "", errors.New("clamd unavailable")
+	case len(content) >= 3 && string(content[:3]) == "X5O":
+		return "Eicar-Signature", nil
+	}
+	return "", nil
+}
+
+// memoryQuarantine keeps the quarantined attachments in memory
+type memoryQuarantine struct {
+	saved []*message.QuarantinedAttachment
+}
+
+func (q *memoryQuarantine) Save(ctx context.Context, attachment *message.QuarantinedAttachment) error {
+	q.saved = append(q.saved, attachment)
+	return nil
+}
+
+func (q *memoryQuarantine) FindByMessageID(ctx context.Context, messageID string) ([]*message.QuarantinedAttachment, error) {
+	return q.saved, nil
+}
+
+func TestAttachmentScanning(t *testing.T) {
+	log, err := logger.NewLogger(&config.LoggerConfig{Level: "error", Format: "json", OutputPath: os.DevNull})
+	require.NoError(t, err)
+	ch := newMQTTChannel(t, "alerts", nil)
+	newContent := func(contents ...string) *RenderedContent {
+		content := &RenderedContent{}
+		for i, data := range contents {
+			content.Attachments = append(content.Attachments, &RenderedAttachment{
+				Filename: string(rune('a'+i)) + ".pdf",
+				Content:  []byte(data),
+			})
+		}
+		return content
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		scanning := NewAttachmentScanning(fakeScanner{}, &memoryQuarantine{}, AttachmentScanReject, false, log)
+		scans, err := scanning.Scan(context.Background(), ch, newContent("report", "X5O!P%@AP"), "msg-1")
+		assert.ErrorIs(t, err, ErrAttachmentInfected)
+		require.Len(t, scans, 2)
+		assert.Equal(t, message.ScanVerdictClean, scans[0].Verdict)
+		assert.Equal(t, message.ScanVerdictInfected, scans[1].Verdict)
+		assert.Equal(t, "Eicar-Signature", scans[1].Signature)
+	})
+
+	t.Run("quarantine", func(t *testing.T) {
+		quarantine := &memoryQuarantine{}
+		scanning := NewAttachmentScanning(fakeScanner{}, quarantine, AttachmentScanQuarantine, false, log)
+		content := newContent("X5O!P%@AP", "report")
+		scans, err := scanning.Scan(context.Background(), ch, content, "msg-1")
+		require.NoError(t, err)
+		require.Len(t, scans, 2)
+		assert.True(t, scans[0].Quarantined)
+		require.Len(t, content.Attachments, 1, "the infected attachment is not sent")
+		assert.Equal(t, "b.pdf", content.Attachments[0].Filename)
+		require.Len(t, quarantine.saved, 1)
+		assert.Equal(t, "a.pdf", quarantine.saved[0].Filename)
+		assert.Equal(t, "msg-1", quarantine.saved[0].MessageID)
+	})
+
+	t.Run("scan failure", func(t *testing.T) {
+		scanning := NewAttachmentScanning(fakeScanner{}, &memoryQuarantine{}, AttachmentScanReject, false, log)
+		_, err := scanning.Scan(context.Background(), ch, newContent("?"), "msg-1")
+		assert.ErrorIs(t, err, ErrAttachmentScanFailed)
+
+		scanning = NewAttachmentScanning(fakeScanner{}, &memoryQuarantine{}, AttachmentScanReject, true, log)
+		content := newContent("?")
+		scans, err := scanning.Scan(context.Background(), ch, content, "msg-1")
+		require.NoError(t, err, "failing open sends the attachment unscanned")
+		assert.Equal(t, message.ScanVerdictError, scans[0].Verdict)
+		assert.Len(t, content.Attachments, 1)
+	})
+
+	t.Run("channel override", func(t *testing.T) {
+		ch.Config().Set(AttachmentScanConfigKey, AttachmentScanOff)
+		scanning := NewAttachmentScanning(fakeScanner{}, &memoryQuarantine{}, AttachmentScanReject, false, log)
+		scans, err := scanning.Scan(context.Background(), ch, newContent("X5O!P%@AP"), "msg-1")
+		require.NoError(t, err)
+		assert.Empty(t, scans)
+	})
+}
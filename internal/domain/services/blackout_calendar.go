@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"notification/internal/domain/blackout"
+	"notification/internal/domain/schedule"
+)
+
+// BlackoutCalendar is the domain service holding the non-critical scheduled
+// sends back while a blackout of the organization calendar, such as a change
+// freeze or a holiday, is on.
+type BlackoutCalendar struct {
+	repo blackout.BlackoutRepository
+}
+
+// NewBlackoutCalendar creates a blackout calendar.
+func NewBlackoutCalendar(repo blackout.BlackoutRepository) *BlackoutCalendar {
+	return &BlackoutCalendar{repo: repo}
+}
+
+// Defer defers a non-critical send falling within a blackout to the end of
+// the blackout, and tells whether it did. A send is looked at its time, or
+// at the given time when it is overdue.
+func (c *BlackoutCalendar) Defer(ctx context.Context, send *schedule.ScheduledSend, now time.Time) (bool, error) {
+	if send.Critical {
+		return false, nil
+	}
+	at := send.SendAt
+	if now.After(at) {
+		at = now
+	}
+
+	blackouts, err := c.repo.FindEndingAfter(ctx, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to find blackouts: %w", err)
+	}
+	until, held := blackout.DeferredUntil(blackouts, at)
+	if held == nil {
+		return false, nil
+	}
+	send.Defer(until, held.ID)
+	return true, nil
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// CalendarInviteConfigKey is the email channel config flag that sends a
+// calendar invite with the messages describing an event
+const CalendarInviteConfigKey = "calendar_invite"
+
+// Variables describing the event of a calendar invite. A message sets at
+// least the start and the end; the title defaults to the subject of the email
+// and the attendees to its recipients.
+const (
+	EventStartVariable       = "event_start"
+	EventEndVariable         = "event_end"
+	EventTitleVariable       = "event_title"
+	EventLocationVariable    = "event_location"
+	EventDescriptionVariable = "event_description"
+	EventAttendeesVariable   = "event_attendees"
+	// EventUIDVariable identifies the event, so that a later invite with a
+	// higher event_sequence updates it instead of adding another one
+	EventUIDVariable      = "event_uid"
+	EventSequenceVariable = "event_sequence"
+)
+
+// CalendarInvite is an event sent as a calendar invite with an email.
+type CalendarInvite struct {
+	UID         string
+	Sequence    int
+	Title       string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	// Attendees are email addresses, the recipients of the email when empty
+	Attendees []string
+}
+
+// CalendarInviteEnabled checks if a channel sends calendar invites.
+func CalendarInviteEnabled(ch *channel.Channel) bool {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return false
+	}
+	value, ok := ch.Config().Get(CalendarInviteConfigKey)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		enabled, _ := strconv.ParseBool(v)
+		return enabled
+	}
+	return false
+}
+
+// NewCalendarInvite builds the invite of the event described by the
+// variables of a message. It returns nil when the variables set no event
+// start, and an error when the event is incomplete or malformed.
+func NewCalendarInvite(variables map[string]interface{}, subject string) (*CalendarInvite, error) {
+	startValue, ok := eventVariable(variables, EventStartVariable)
+	if !ok {
+		return nil, nil
+	}
+	start, err := parseEventTime(EventStartVariable, startValue)
+	if err != nil {
+		return nil, err
+	}
+	endValue, ok := eventVariable(variables, EventEndVariable)
+	if !ok {
+		return nil, fmt.Errorf("%s is required with %s", EventEndVariable, EventStartVariable)
+	}
+	end, err := parseEventTime(EventEndVariable, endValue)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("%s must be after %s", EventEndVariable, EventStartVariable)
+	}
+
+	invite := &CalendarInvite{
+		UID:   uuid.New().String() + "@notification",
+		Title: subject,
+		Start: start,
+		End:   end,
+	}
+	if uid, ok := eventVariable(variables, EventUIDVariable); ok {
+		invite.UID = uid
+	}
+	if title, ok := eventVariable(variables, EventTitleVariable); ok {
+		invite.Title = title
+	}
+	if location, ok := eventVariable(variables, EventLocationVariable); ok {
+		invite.Location = location
+	}
+	if description, ok := eventVariable(variables, EventDescriptionVariable); ok {
+		invite.Description = description
+	}
+	if sequence, ok := eventVariable(variables, EventSequenceVariable); ok {
+		if invite.Sequence, err = strconv.Atoi(sequence); err != nil || invite.Sequence < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative integer", EventSequenceVariable)
+		}
+	}
+	invite.Attendees = eventAttendees(variables[EventAttendeesVariable])
+	return invite, nil
+}
+
+// eventVariable returns a non-empty variable as a string
+func eventVariable(variables map[string]interface{}, key string) (string, bool) {
+	value, ok := variables[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	s := strings.TrimSpace(fmt.Sprintf("%v", value))
+	return s, s != ""
+}
+
+// parseEventTime parses an RFC 3339 time or a Unix time in seconds
+func parseEventTime(key, value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%s must be an RFC 3339 time or a Unix time, got %q", key, value)
+}
+
+// eventAttendees reads the attendees from a list or a comma separated string
+func eventAttendees(value interface{}) []string {
+	var items []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			items = append(items, fmt.Sprintf("%v", item))
+		}
+	case []string:
+		items = v
+	case string:
+		items = strings.Split(v, ",")
+	}
+
+	attendees := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			attendees = append(attendees, item)
+		}
+	}
+	return attendees
+}
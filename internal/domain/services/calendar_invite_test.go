@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalendarInvite(t *testing.T) {
+	invite, err := NewCalendarInvite(map[string]interface{}{"name": "World"}, "Hello")
+	require.NoError(t, err)
+	assert.Nil(t, invite, "messages without an event send no invite")
+
+	invite, err = NewCalendarInvite(map[string]interface{}{
+		EventStartVariable:     "2026-10-20T10:00:00+08:00",
+		EventEndVariable:       "1792476000",
+		EventUIDVariable:       "maintenance-42",
+		EventSequenceVariable:  2,
+		EventAttendeesVariable: []interface{}{"ops@example.com", " ", "dba@example.com"},
+	}, "Database maintenance")
+	require.NoError(t, err)
+	assert.Equal(t, "maintenance-42", invite.UID)
+	assert.Equal(t, 2, invite.Sequence)
+	assert.Equal(t, "Database maintenance", invite.Title)
+	assert.Equal(t, time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC), invite.Start)
+	assert.Equal(t, time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC), invite.End)
+	assert.Equal(t, []string{"ops@example.com", "dba@example.com"}, invite.Attendees)
+
+	_, err = NewCalendarInvite(map[string]interface{}{EventStartVariable: "2026-10-20T10:00:00Z"}, "Hello")
+	assert.ErrorContains(t, err, EventEndVariable)
+
+	_, err = NewCalendarInvite(map[string]interface{}{
+		EventStartVariable: "2026-10-20T10:00:00Z",
+		EventEndVariable:   "2026-10-20T09:00:00Z",
+	}, "Hello")
+	assert.ErrorContains(t, err, "must be after")
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/pkg/logger"
+)
+
+// ChannelHealthPolicy holds the thresholds the channel health is judged by.
+type ChannelHealthPolicy struct {
+	// Window is the number of most recent sends the failure rate is computed over
+	Window int
+	// MinSamples is the number of sends needed before a channel can be degraded or disabled
+	MinSamples int
+	// DegradedFailurePercent is the failure rate that marks a channel degraded
+	DegradedFailurePercent int
+	// DisableFailurePercent is the failure rate that disables a channel, 0 never disables
+	DisableFailurePercent int
+}
+
+// Status returns the health status of a channel with the given send outcomes.
+func (p ChannelHealthPolicy) Status(health *channel.ChannelHealth) channel.HealthStatus {
+	if health.SampleSize() < p.MinSamples {
+		return channel.HealthStatusHealthy
+	}
+
+	rate := health.FailureRate()
+	switch {
+	case p.DisableFailurePercent > 0 && rate >= float64(p.DisableFailurePercent):
+		return channel.HealthStatusDisabled
+	case rate >= float64(p.DegradedFailurePercent):
+		return channel.HealthStatusDegraded
+	default:
+		return channel.HealthStatusHealthy
+	}
+}
+
+// ChannelHealthNotifier is told when the health status of a channel changes.
+type ChannelHealthNotifier interface {
+	ChannelHealthChanged(ctx context.Context, ch *channel.Channel, previous channel.HealthStatus)
+}
+
+// ChannelHealthMonitor is the domain service that tracks the rolling delivery
+// health of channels and disables the ones failing too often.
+type ChannelHealthMonitor struct {
+	channelRepo channel.ChannelRepository
+	messageRepo message.MessageRepository
+	policy      ChannelHealthPolicy
+	notifier    ChannelHealthNotifier
+	logger      *logger.Logger
+}
+
+// NewChannelHealthMonitor creates a channel health monitor. The notifier may be nil.
+func NewChannelHealthMonitor(
+	channelRepo channel.ChannelRepository,
+	messageRepo message.MessageRepository,
+	policy ChannelHealthPolicy,
+	notifier ChannelHealthNotifier,
+	logger *logger.Logger,
+) *ChannelHealthMonitor {
+	return &ChannelHealthMonitor{
+		channelRepo: channelRepo,
+		messageRepo: messageRepo,
+		policy:      policy,
+		notifier:    notifier,
+		logger:      logger,
+	}
+}
+
+// Check recomputes the health of a channel from its recent sends and saves it.
+// A channel reaching the disable threshold is disabled.
+func (m *ChannelHealthMonitor) Check(ctx context.Context, channelID *channel.ChannelID) error {
+	// 1. Get the channel
+	ch, err := m.channelRepo.FindByID(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	// 2. Count the outcomes of the recent sends
+	previous := ch.Health()
+	successes, failures, err := m.messageRepo.CountRecentResults(ctx, channelID.String(), previous.Since, m.policy.Window)
+	if err != nil {
+		return err
+	}
+
+	// 3. Judge the health
+	health := &channel.ChannelHealth{
+		Successes: successes,
+		Failures:  failures,
+		Since:     previous.Since,
+		CheckedAt: time.Now().UnixMilli(),
+	}
+	health.Status = m.policy.Status(health)
+	ch.UpdateHealth(health)
+
+	if health.Status == channel.HealthStatusDisabled && ch.IsEnabled() {
+		ch.Disable()
+	}
+
+	// 4. Save the channel
+	if err := m.channelRepo.Update(ctx, ch); err != nil {
+		return fmt.Errorf("failed to save channel health: %w", err)
+	}
+
+	// 5. Report status changes
+	if health.Status != previous.Status {
+		m.logger.WithContext(ctx).Warn("Channel health changed",
+			zap.String("channel_id", channelID.String()),
+			zap.String("owner", ch.Ownership().Owner),
+			zap.String("team", ch.Ownership().Team),
+			zap.String("previous_status", string(previous.Status)),
+			zap.String("status", string(health.Status)),
+			zap.Float64("failure_rate", health.FailureRate()),
+			zap.Int("sample_size", health.SampleSize()))
+
+		if m.notifier != nil {
+			m.notifier.ChannelHealthChanged(ctx, ch, previous.Status)
+		}
+	}
+
+	return nil
+}
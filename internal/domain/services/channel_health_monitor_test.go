@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"notification/internal/domain/channel"
+)
+
+func TestChannelHealthPolicy_Status(t *testing.T) {
+	policy := ChannelHealthPolicy{Window: 20, MinSamples: 10, DegradedFailurePercent: 50, DisableFailurePercent: 90}
+
+	tests := []struct {
+		name      string
+		successes int
+		failures  int
+		want      channel.HealthStatus
+	}{
+		{"too few samples", 0, 9, channel.HealthStatusHealthy},
+		{"below degraded", 6, 4, channel.HealthStatusHealthy},
+		{"degraded", 5, 5, channel.HealthStatusDegraded},
+		{"disabled", 1, 9, channel.HealthStatusDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := &channel.ChannelHealth{Successes: tt.successes, Failures: tt.failures}
+			assert.Equal(t, tt.want, policy.Status(health))
+		})
+	}
+
+	// A zero disable percentage never disables
+	policy.DisableFailurePercent = 0
+	assert.Equal(t, channel.HealthStatusDegraded, policy.Status(&channel.ChannelHealth{Failures: 20}))
+}
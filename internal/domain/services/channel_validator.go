@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"notification/internal/domain/channel"
 	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 )
 
+// discordColorPattern matches the hex RGB colors of Discord embeds
+var discordColorPattern = regexp.MustCompile(`^#?[0-9A-Fa-f]{6}$`)
+
 // ChannelValidator is the domain service for channel validation.
 type ChannelValidator struct {
 	channelRepo  channel.ChannelRepository
@@ -52,6 +57,11 @@ func (ves ValidationErrors) Error() string {
 	return fmt.Sprintf("multiple validation errors: %d errors", len(ves))
 }
 
+// Kind classifies validation errors for transport layers.
+func (ves ValidationErrors) Kind() shared.ErrorKind {
+	return shared.ErrorKindValidation
+}
+
 // HasErrors checks if there are any errors.
 func (ves ValidationErrors) HasErrors() bool {
 	return len(ves) > 0
@@ -72,11 +82,15 @@ func (cv *ChannelValidator) ValidateChannelForCreation(
 	channelType shared.ChannelType,
 	templateID *template.TemplateID,
 	config *channel.ChannelConfig,
+	variableDefaults *channel.VariableDefaults,
 ) error {
 	var errors ValidationErrors
 
 	// Validate channel name uniqueness
 	if err := cv.validateChannelNameUniqueness(ctx, name); err != nil {
+		if shared.IsConflict(err) {
+			return err
+		}
 		errors.Add("channelName", err.Error())
 	}
 
@@ -90,6 +104,11 @@ func (cv *ChannelValidator) ValidateChannelForCreation(
 		errors.Add("config", err.Error())
 	}
 
+	// Validate template variable defaults
+	if err := cv.validateVariableDefaults(ctx, templateID, variableDefaults); err != nil {
+		errors.Add("variableDefaults", err.Error())
+	}
+
 	if errors.HasErrors() {
 		return errors
 	}
@@ -105,12 +124,16 @@ func (cv *ChannelValidator) ValidateChannelForUpdate(
 	channelType shared.ChannelType,
 	templateID *template.TemplateID,
 	config *channel.ChannelConfig,
+	variableDefaults *channel.VariableDefaults,
 ) error {
 	var errors ValidationErrors
 
 	// Check if the channel exists
 	existingChannel, err := cv.channelRepo.FindByID(ctx, channelID)
 	if err != nil {
+		if shared.IsNotFound(err) {
+			return err
+		}
 		errors.Add("channelId", "channel not found")
 		return errors
 	}
@@ -118,6 +141,9 @@ func (cv *ChannelValidator) ValidateChannelForUpdate(
 	// Validate channel name uniqueness (excluding self)
 	if !existingChannel.Name().Equals(name) {
 		if err := cv.validateChannelNameUniqueness(ctx, name); err != nil {
+			if shared.IsConflict(err) {
+				return err
+			}
 			errors.Add("channelName", err.Error())
 		}
 	}
@@ -132,6 +158,11 @@ func (cv *ChannelValidator) ValidateChannelForUpdate(
 		errors.Add("config", err.Error())
 	}
 
+	// Validate template variable defaults
+	if err := cv.validateVariableDefaults(ctx, templateID, variableDefaults); err != nil {
+		errors.Add("variableDefaults", err.Error())
+	}
+
 	if errors.HasErrors() {
 		return errors
 	}
@@ -139,6 +170,56 @@ func (cv *ChannelValidator) ValidateChannelForUpdate(
 	return nil
 }
 
+// ValidateFallbackChannel validates the fallback of a channel: the fallback
+// must exist, must not lead back to the channel and its chain must not be
+// longer than channel.MaxFallbackHops. The channel ID is nil for a channel
+// that is still to be created.
+func (cv *ChannelValidator) ValidateFallbackChannel(
+	ctx context.Context,
+	channelID *channel.ChannelID,
+	fallbackChannelID *channel.ChannelID,
+) error {
+	if fallbackChannelID == nil {
+		return nil
+	}
+
+	var errors ValidationErrors
+	if err := cv.validateFallbackChain(ctx, channelID, fallbackChannelID); err != nil {
+		errors.Add("fallbackChannelId", err.Error())
+		return errors
+	}
+
+	return nil
+}
+
+// validateFallbackChain follows the fallback chain starting at the fallback channel.
+func (cv *ChannelValidator) validateFallbackChain(
+	ctx context.Context,
+	channelID *channel.ChannelID,
+	fallbackChannelID *channel.ChannelID,
+) error {
+	next := fallbackChannelID
+	for hops := 1; next != nil; hops++ {
+		if next.Equals(channelID) {
+			return errors.New("the fallback chain leads back to the channel")
+		}
+		if hops > channel.MaxFallbackHops {
+			return fmt.Errorf("the fallback chain is longer than %d channels", channel.MaxFallbackHops)
+		}
+
+		ch, err := cv.channelRepo.FindByID(ctx, next)
+		if err != nil {
+			return fmt.Errorf("fallback channel '%s' not found: %w", next, err)
+		}
+		if ch.IsDeleted() {
+			return fmt.Errorf("fallback channel '%s' is deleted", next)
+		}
+		next = ch.FallbackChannelID()
+	}
+
+	return nil
+}
+
 // validateChannelNameUniqueness validates channel name uniqueness.
 func (cv *ChannelValidator) validateChannelNameUniqueness(ctx context.Context, name *channel.ChannelName) error {
 	exists, err := cv.channelRepo.ExistsByName(ctx, name)
@@ -146,7 +227,7 @@ func (cv *ChannelValidator) validateChannelNameUniqueness(ctx context.Context, n
 		return fmt.Errorf("failed to check channel name uniqueness: %w", err)
 	}
 	if exists {
-		return errors.New("channel name already exists")
+		return shared.NewConflictError("CHANNEL_NAME_CONFLICT", "channel name already exists")
 	}
 	return nil
 }
@@ -176,6 +257,42 @@ func (cv *ChannelValidator) validateTemplateCompatibility(
 	return nil
 }
 
+// validateVariableDefaults checks that every defaulted variable is declared by the template.
+func (cv *ChannelValidator) validateVariableDefaults(
+	ctx context.Context,
+	templateID *template.TemplateID,
+	variableDefaults *channel.VariableDefaults,
+) error {
+	if variableDefaults == nil || variableDefaults.IsEmpty() {
+		return nil
+	}
+	if templateID == nil {
+		return errors.New("variable defaults require a template")
+	}
+
+	tmpl, err := cv.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("template not found: %w", err)
+	}
+
+	declared := make(map[string]bool)
+	for _, variable := range tmpl.GetAllVariables() {
+		declared[variable] = true
+	}
+
+	var unknown []string
+	for _, key := range variableDefaults.Keys() {
+		if !declared[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("variables not declared by the template: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
 // validateChannelConfig validates channel configuration.
 func (cv *ChannelValidator) validateChannelConfig(channelType shared.ChannelType, config *channel.ChannelConfig) error {
 	if config == nil {
@@ -189,6 +306,24 @@ func (cv *ChannelValidator) validateChannelConfig(channelType shared.ChannelType
 		return cv.validateSlackConfig(config)
 	case shared.ChannelTypeSMS:
 		return cv.validateSMSConfig(config)
+	case shared.ChannelTypeVoice:
+		return cv.validateVoiceConfig(config)
+	case shared.ChannelTypeWhatsApp:
+		return cv.validateWhatsAppConfig(config)
+	case shared.ChannelTypeDiscord:
+		return cv.validateDiscordConfig(config)
+	case shared.ChannelTypePagerDuty:
+		return cv.validatePagerDutyConfig(config)
+	case shared.ChannelTypeOpsgenie:
+		return cv.validateOpsgenieConfig(config)
+	case shared.ChannelTypeJira:
+		return cv.validateJiraConfig(config)
+	case shared.ChannelTypeMQTT:
+		return cv.validateMQTTConfig(config)
+	case shared.ChannelTypeStatuspage:
+		return cv.validateStatuspageConfig(config)
+	case shared.ChannelTypeInApp:
+		return cv.validateInAppConfig(config)
 	default:
 		return fmt.Errorf("unsupported channel type: %s", channelType)
 	}
@@ -220,6 +355,32 @@ func (cv *ChannelValidator) validateEmailConfig(config *channel.ChannelConfig) e
 		}
 	}
 
+	// Validate the calendar invite flag
+	if value, exists := config.Get(CalendarInviteConfigKey); exists {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("email config %s must be a boolean", CalendarInviteConfigKey)
+		}
+	}
+
+	// Validate the extra headers and the one-click unsubscribe flag
+	if _, err := ChannelEmailHeaders(config); err != nil {
+		return err
+	}
+	if value, exists := config.Get(ListUnsubscribeOneClickConfigKey); exists {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("email config %s must be a boolean", ListUnsubscribeOneClickConfigKey)
+		}
+	}
+
+	// Validate the acknowledgment links and reply tracking flags
+	for _, key := range []string{AcknowledgmentLinksConfigKey, ReplyTrackingConfigKey} {
+		if value, exists := config.Get(key); exists {
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("email config %s must be a boolean", key)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -233,6 +394,13 @@ func (cv *ChannelValidator) validateSlackConfig(config *channel.ChannelConfig) e
 		}
 	}
 
+	// Validate the acknowledgment links flag
+	if value, exists := config.Get(AcknowledgmentLinksConfigKey); exists {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("slack config %s must be a boolean", AcknowledgmentLinksConfigKey)
+		}
+	}
+
 	return nil
 }
 
@@ -246,6 +414,253 @@ func (cv *ChannelValidator) validateSMSConfig(config *channel.ChannelConfig) err
 		}
 	}
 
+	// Validate the reply tracking flag
+	if value, exists := config.Get(ReplyTrackingConfigKey); exists {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("sms config %s must be a boolean", ReplyTrackingConfigKey)
+		}
+	}
+
+	return nil
+}
+
+// validateVoiceConfig validates voice configuration.
+func (cv *ChannelValidator) validateVoiceConfig(config *channel.ChannelConfig) error {
+	requiredFields := []string{"provider", "account_sid", "auth_token", "from_number"}
+
+	for _, field := range requiredFields {
+		if value, exists := config.Get(field); !exists || value == "" {
+			return fmt.Errorf("voice config missing required field: %s", field)
+		}
+	}
+
+	// Validate the retry policy
+	for _, field := range []string{"max_attempts", "retry_interval", "ring_timeout"} {
+		value, exists := config.Get(field)
+		if !exists {
+			continue
+		}
+		switch v := value.(type) {
+		case float64:
+			if v < 1 {
+				return fmt.Errorf("voice config %s must be a positive number", field)
+			}
+		case int:
+			if v < 1 {
+				return fmt.Errorf("voice config %s must be a positive number", field)
+			}
+		default:
+			return fmt.Errorf("voice config %s must be a number", field)
+		}
+	}
+
+	return nil
+}
+
+// validateWhatsAppConfig validates WhatsApp configuration.
+func (cv *ChannelValidator) validateWhatsAppConfig(config *channel.ChannelConfig) error {
+	requiredFields := []string{"access_token", "phone_number_id"}
+
+	for _, field := range requiredFields {
+		if value, exists := config.Get(field); !exists || value == "" {
+			return fmt.Errorf("whatsapp config missing required field: %s", field)
+		}
+	}
+
+	// Validate the template mappings
+	if _, err := WhatsAppTemplateMappings(config); err != nil {
+		return fmt.Errorf("whatsapp config: %w", err)
+	}
+
+	// Validate the session fallback flag
+	if value, exists := config.Get(WhatsAppSessionFallbackConfigKey); exists {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("whatsapp config %s must be a boolean", WhatsAppSessionFallbackConfigKey)
+		}
+	}
+
+	return nil
+}
+
+// validateDiscordConfig validates Discord configuration.
+func (cv *ChannelValidator) validateDiscordConfig(config *channel.ChannelConfig) error {
+	mode, _ := config.Get("mode")
+	switch mode {
+	case "webhook":
+		if value, exists := config.Get("webhook_url"); !exists || value == "" {
+			return fmt.Errorf("discord config missing required field: webhook_url")
+		}
+	case "bot":
+		if value, exists := config.Get("bot_token"); !exists || value == "" {
+			return fmt.Errorf("discord config missing required field: bot_token")
+		}
+	default:
+		return fmt.Errorf("discord config mode must be webhook or bot")
+	}
+
+	// Validate the embed settings
+	if value, exists := config.Get("embed"); exists {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("discord config embed must be a boolean")
+		}
+	}
+	if value, exists := config.Get("embed_color"); exists {
+		color, ok := value.(string)
+		if !ok || !discordColorPattern.MatchString(color) {
+			return fmt.Errorf("discord config embed_color must be a hex RGB color like #5865F2")
+		}
+	}
+
+	return nil
+}
+
+// validatePagerDutyConfig validates PagerDuty configuration.
+func (cv *ChannelValidator) validatePagerDutyConfig(config *channel.ChannelConfig) error {
+	if value, exists := config.Get("routing_key"); !exists || value == "" {
+		return fmt.Errorf("pagerduty config missing required field: routing_key")
+	}
+
+	// Validate the default severity
+	if value, exists := config.Get(PagerDutyDefaultSeverityConfigKey); exists {
+		severity, ok := value.(string)
+		if !ok || !pagerDutySeverities[severity] {
+			return fmt.Errorf("pagerduty config %s must be critical, error, warning or info", PagerDutyDefaultSeverityConfigKey)
+		}
+	}
+
+	return nil
+}
+
+// validateOpsgenieConfig validates Opsgenie configuration.
+func (cv *ChannelValidator) validateOpsgenieConfig(config *channel.ChannelConfig) error {
+	if value, exists := config.Get("api_key"); !exists || value == "" {
+		return fmt.Errorf("opsgenie config missing required field: api_key")
+	}
+
+	// Validate the region
+	if value, exists := config.Get("region"); exists && value != "us" && value != "eu" {
+		return fmt.Errorf("opsgenie config region must be us or eu")
+	}
+
+	// Validate the routing and the priorities
+	if _, err := OpsgenieResponders(config); err != nil {
+		return fmt.Errorf("opsgenie config: %w", err)
+	}
+	if _, err := OpsgeniePriorityMapping(config); err != nil {
+		return fmt.Errorf("opsgenie config: %w", err)
+	}
+	if value, exists := config.Get(OpsgenieDefaultPriorityConfigKey); exists {
+		priority, ok := value.(string)
+		if !ok || !opsgeniePriorities[strings.ToUpper(priority)] {
+			return fmt.Errorf("opsgenie config %s must be one of P1 to P5", OpsgenieDefaultPriorityConfigKey)
+		}
+	}
+
+	return nil
+}
+
+// validateJiraConfig validates Jira configuration.
+func (cv *ChannelValidator) validateJiraConfig(config *channel.ChannelConfig) error {
+	requiredFields := []string{"base_url", "project_key"}
+
+	for _, field := range requiredFields {
+		if value, exists := config.Get(field); !exists || value == "" {
+			return fmt.Errorf("jira config missing required field: %s", field)
+		}
+	}
+
+	// Validate the credentials, an API token of an account or a personal access token
+	if value, exists := config.Get("access_token"); !exists || value == "" {
+		for _, field := range []string{"email", "api_token"} {
+			if value, exists := config.Get(field); !exists || value == "" {
+				return fmt.Errorf("jira config missing required field: %s (or access_token)", field)
+			}
+		}
+	}
+
+	// Validate the labels, which Jira refuses with spaces
+	if value, exists := config.Get("labels"); exists {
+		labels, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("jira config labels must be a list")
+		}
+		for _, label := range labels {
+			text, ok := label.(string)
+			if !ok || text == "" || strings.ContainsAny(text, " \t\n") {
+				return fmt.Errorf("jira config labels must be words without spaces, got %v", label)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMQTTConfig validates MQTT configuration.
+func (cv *ChannelValidator) validateMQTTConfig(config *channel.ChannelConfig) error {
+	if value, exists := config.Get("broker_url"); !exists || value == "" {
+		return fmt.Errorf("mqtt config missing required field: broker_url")
+	}
+
+	// Validate the topic template
+	topic, _ := config.Get(MQTTTopicConfigKey)
+	topicTemplate, _ := topic.(string)
+	if err := ValidateMQTTTopicTemplate(topicTemplate); err != nil {
+		return fmt.Errorf("mqtt config: %w", err)
+	}
+
+	// Validate the delivery settings
+	if value, exists := config.Get("qos"); exists {
+		switch value {
+		case 0, 1, 2, float64(0), float64(1), float64(2):
+		default:
+			return fmt.Errorf("mqtt config qos must be 0, 1 or 2")
+		}
+	}
+	if value, exists := config.Get("payload_format"); exists && value != "json" && value != "text" {
+		return fmt.Errorf("mqtt config payload_format must be json or text")
+	}
+
+	// Validate the TLS client certificate
+	clientCert, _ := config.Get("client_cert")
+	clientKey, _ := config.Get("client_key")
+	if (clientCert == nil || clientCert == "") != (clientKey == nil || clientKey == "") {
+		return fmt.Errorf("mqtt config client_cert and client_key must be set together")
+	}
+
+	return nil
+}
+
+// validateStatuspageConfig validates Statuspage configuration.
+func (cv *ChannelValidator) validateStatuspageConfig(config *channel.ChannelConfig) error {
+	requiredFields := []string{"api_key", "page_id"}
+
+	for _, field := range requiredFields {
+		if value, exists := config.Get(field); !exists || value == "" {
+			return fmt.Errorf("statuspage config missing required field: %s", field)
+		}
+	}
+
+	// Validate the components and the statuses they are set to
+	if _, err := StatuspageComponents(config); err != nil {
+		return fmt.Errorf("statuspage config: %w", err)
+	}
+	if _, err := StatuspageSeverityMapping(config); err != nil {
+		return fmt.Errorf("statuspage config: %w", err)
+	}
+
+	return nil
+}
+
+// validateInAppConfig validates in-app configuration.
+func (cv *ChannelValidator) validateInAppConfig(config *channel.ChannelConfig) error {
+	// The recipients are the users, the channel needs no credentials
+	if value, exists := config.Get("category"); exists {
+		category, ok := value.(string)
+		if !ok || len(category) > 100 {
+			return fmt.Errorf("inapp config category must be a string of at most 100 characters")
+		}
+	}
+
 	return nil
 }
 
@@ -259,7 +674,7 @@ func (cv *ChannelValidator) ValidateChannelDeletion(ctx context.Context, channel
 
 	// Check if the channel is already deleted
 	if ch.IsDeleted() {
-		return errors.New("channel is already deleted")
+		return shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel is already deleted")
 	}
 
 	// In a real project, you may need to check here:
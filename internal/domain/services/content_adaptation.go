@@ -0,0 +1,337 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"notification/internal/domain/shared"
+)
+
+// ContentAdapter rewrites rendered content into a form its channel type can
+// send. link points to the full content for the adapters truncating it,
+// empty when the message has none.
+type ContentAdapter interface {
+	Adapt(content *RenderedContent, capabilities shared.ContentCapabilities, link string)
+}
+
+// ContentAdapterFunc adapts a function to ContentAdapter.
+type ContentAdapterFunc func(content *RenderedContent, capabilities shared.ContentCapabilities, link string)
+
+// Adapt implements ContentAdapter.
+func (f ContentAdapterFunc) Adapt(content *RenderedContent, capabilities shared.ContentCapabilities, link string) {
+	f(content, capabilities, link)
+}
+
+// ContentAdaptation is the domain service adapting the rendered content of a
+// message to each channel it fans out to, e.g. stripping the HTML of an SMS,
+// so that one template reads well everywhere. Channel types without an
+// adapter, such as email, MQTT and plugins, send the content as rendered.
+type ContentAdaptation struct {
+	registry shared.ChannelTypeRegistry
+	adapters map[string]ContentAdapter
+	// linkVariable is the message variable holding the link to the full content
+	linkVariable string
+}
+
+// NewContentAdaptation creates a content adaptation with the adapters of the
+// built-in channel types, reading their capabilities from the registry.
+func NewContentAdaptation(registry shared.ChannelTypeRegistry, linkVariable string) *ContentAdaptation {
+	a := &ContentAdaptation{
+		registry:     registry,
+		adapters:     make(map[string]ContentAdapter),
+		linkVariable: linkVariable,
+	}
+
+	plainText := ContentAdapterFunc(adaptToPlainText)
+	for _, channelType := range []shared.ChannelType{
+		shared.ChannelTypeSMS,
+		shared.ChannelTypeVoice,
+		shared.ChannelTypeWhatsApp,
+		shared.ChannelTypeDiscord,
+		shared.ChannelTypePagerDuty,
+		shared.ChannelTypeOpsgenie,
+		shared.ChannelTypeJira,
+		shared.ChannelTypeStatuspage,
+	} {
+		a.Register(channelType, plainText)
+	}
+	a.Register(shared.ChannelTypeSMS, ContentAdapterFunc(adaptToSMS))
+	a.Register(shared.ChannelTypeSlack, ContentAdapterFunc(adaptToMrkdwn))
+	return a
+}
+
+// Register sets the adapter of a channel type, replacing its previous one.
+func (a *ContentAdaptation) Register(channelType shared.ChannelType, adapter ContentAdapter) {
+	a.adapters[channelType.String()] = adapter
+}
+
+// Adapt adapts rendered content to a channel type, in place.
+func (a *ContentAdaptation) Adapt(channelType shared.ChannelType, content *RenderedContent, variables map[string]interface{}) {
+	adapter, ok := a.adapters[channelType.String()]
+	if !ok || content == nil {
+		return
+	}
+
+	var capabilities shared.ContentCapabilities
+	if definition, err := a.registry.GetChannelType(channelType.String()); err == nil {
+		capabilities = shared.ContentCapabilitiesOf(definition)
+	}
+
+	var link string
+	if value, ok := variables[a.linkVariable]; ok && value != nil {
+		link = strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+	adapter.Adapt(content, capabilities, link)
+}
+
+// adaptToPlainText converts HTML content to text and truncates it to the
+// longest content of the channel type.
+func adaptToPlainText(content *RenderedContent, capabilities shared.ContentCapabilities, link string) {
+	if !capabilities.HTML && containsHTML(content.Content) {
+		content.Content = htmlToText(content.Content, false)
+	}
+	content.Content = truncateWithLink(content.Content, capabilities.MaxLength, link)
+}
+
+// adaptToSMS adapts content like adaptToPlainText, leaving room for the
+// subject, which SMS senders put in front of the content.
+func adaptToSMS(content *RenderedContent, capabilities shared.ContentCapabilities, link string) {
+	if capabilities.MaxLength > 0 && content.Subject != "" {
+		capabilities.MaxLength -= len([]rune(content.Subject)) + len("\n\n")
+		if capabilities.MaxLength < 1 {
+			capabilities.MaxLength = 1
+		}
+	}
+	adaptToPlainText(content, capabilities, link)
+}
+
+// adaptToMrkdwn converts HTML and Markdown content to the Slack mrkdwn syntax.
+func adaptToMrkdwn(content *RenderedContent, capabilities shared.ContentCapabilities, link string) {
+	if containsHTML(content.Content) {
+		content.Content = htmlToText(content.Content, true)
+	} else {
+		content.Content = markdownToMrkdwn(content.Content)
+	}
+	content.Content = truncateWithLink(content.Content, capabilities.MaxLength, link)
+}
+
+// truncateWithLink shortens a text to at most limit characters, ending it
+// with an ellipsis and the link to the full text when it is cut. A limit of
+// 0 keeps the text whole.
+func truncateWithLink(text string, limit int, link string) string {
+	runes := []rune(text)
+	if limit <= 0 || len(runes) <= limit {
+		return text
+	}
+
+	suffix := "…"
+	if link != "" && len([]rune(link))+2 < limit {
+		suffix = "… " + link
+	}
+	keep := limit - len([]rune(suffix))
+	return strings.TrimRight(string(runes[:keep]), " \t\n") + suffix
+}
+
+// htmlTagPattern matches the common HTML tags, and not the <url|text> links
+// and <@user> mentions of Slack.
+var htmlTagPattern = regexp.MustCompile(`(?i)</?(html|body|p|br|div|span|a|b|strong|i|em|u|s|del|strike|ul|ol|li|h[1-6]|table|tr|td|th|img|code|pre|blockquote|hr)(\s[^>]*)?/?>`)
+
+// containsHTML reports whether a content is written in HTML.
+func containsHTML(content string) bool {
+	return htmlTagPattern.MatchString(content)
+}
+
+// blankLinesPattern matches the runs of blank lines left by the HTML blocks.
+var blankLinesPattern = regexp.MustCompile(`\n[ \t]*(\n[ \t]*)+`)
+
+// htmlToText converts HTML to text, keeping the paragraphs, line breaks,
+// list items and link targets. With mrkdwn, emphasis, code and links are
+// written in the Slack mrkdwn syntax.
+func htmlToText(content string, mrkdwn bool) string {
+	var out strings.Builder
+	// links holds the target and text of the links being written
+	type pendingLink struct {
+		href string
+		text strings.Builder
+	}
+	var links []*pendingLink
+	write := func(s string) {
+		if len(links) > 0 {
+			links[len(links)-1].text.WriteString(s)
+			return
+		}
+		out.WriteString(s)
+	}
+	// atLineStart reports whether the next text starts a line, where its
+	// leading whitespace is dropped
+	atLineStart := func() bool {
+		current := out.String()
+		if len(links) > 0 {
+			current = links[len(links)-1].text.String()
+		}
+		return current == "" || strings.HasSuffix(current, "\n")
+	}
+	mark := func(plain, mrkdwnMark string) {
+		if mrkdwn {
+			write(mrkdwnMark)
+		} else {
+			write(plain)
+		}
+	}
+
+	skipping, preformatted := 0, 0
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+		start := tokenType == html.StartTagToken || tokenType == html.SelfClosingTagToken
+		end := tokenType == html.EndTagToken
+
+		switch tokenType {
+		case html.TextToken:
+			if skipping > 0 {
+				continue
+			}
+			text := token.Data
+			if preformatted == 0 {
+				// Runs of whitespace read as a single space, as in a browser
+				text = strings.Join(strings.Fields(text), " ")
+				if strings.TrimLeft(token.Data, " \t\r\n") != token.Data && !atLineStart() {
+					text = " " + text
+				}
+				if text != "" && text != " " && strings.TrimRight(token.Data, " \t\r\n") != token.Data {
+					text += " "
+				}
+				if text == "" {
+					continue
+				}
+			}
+			if mrkdwn {
+				text = escapeMrkdwn(text)
+			}
+			write(text)
+			continue
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+		default:
+			continue
+		}
+
+		switch token.Data {
+		case "script", "style", "head", "title":
+			if start && tokenType != html.SelfClosingTagToken {
+				skipping++
+			} else if end && skipping > 0 {
+				skipping--
+			}
+		case "br":
+			write("\n")
+		case "p", "div", "ul", "ol", "table", "blockquote":
+			write("\n\n")
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			// mrkdwn has no headings, they are written in bold
+			if start {
+				mark("\n\n", "\n\n*")
+			} else if end {
+				mark("\n\n", "*\n\n")
+			}
+		case "tr", "hr":
+			write("\n")
+		case "td", "th":
+			if end {
+				write(" ")
+			}
+		case "li":
+			if start {
+				mark("\n- ", "\n• ")
+			}
+		case "b", "strong":
+			mark("", "*")
+		case "i", "em":
+			mark("", "_")
+		case "s", "del", "strike":
+			mark("", "~")
+		case "code":
+			if preformatted == 0 {
+				mark("", "`")
+			}
+		case "pre":
+			if start {
+				preformatted++
+				mark("\n", "\n```\n")
+			} else if preformatted > 0 {
+				preformatted--
+				mark("\n", "\n```\n")
+			}
+		case "a":
+			if start {
+				href := ""
+				for _, attr := range token.Attr {
+					if attr.Key == "href" {
+						href = strings.TrimSpace(attr.Val)
+					}
+				}
+				links = append(links, &pendingLink{href: href})
+			} else if end && len(links) > 0 {
+				pending := links[len(links)-1]
+				links = links[:len(links)-1]
+				write(formatLink(pending.href, strings.TrimSpace(pending.text.String()), mrkdwn))
+			}
+		}
+	}
+	for len(links) > 0 {
+		pending := links[len(links)-1]
+		links = links[:len(links)-1]
+		write(formatLink(pending.href, strings.TrimSpace(pending.text.String()), mrkdwn))
+	}
+
+	lines := strings.Split(out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	text := blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// formatLink writes a link as its text followed by its target, or as a
+// mrkdwn <url|text> link.
+func formatLink(href, text string, mrkdwn bool) string {
+	switch {
+	case href == "" || strings.HasPrefix(href, "#"):
+		return text
+	case mrkdwn && text == "":
+		return "<" + href + ">"
+	case mrkdwn:
+		return "<" + href + "|" + text + ">"
+	case text == "" || text == href:
+		return href
+	default:
+		return text + " (" + href + ")"
+	}
+}
+
+// escapeMrkdwn escapes the characters mrkdwn reserves for its links and mentions.
+func escapeMrkdwn(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+var (
+	markdownLinkPattern    = regexp.MustCompile(`\[([^\]]+)\]\((\S+?)\)`)
+	markdownBoldPattern    = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	markdownStrikePattern  = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	markdownHeadingPattern = regexp.MustCompile(`(?m)^#{1,6}[ \t]+(.+?)[ \t]*#*[ \t]*$`)
+)
+
+// markdownToMrkdwn converts the Markdown links, bold, strikethrough and
+// headings of a content to mrkdwn. Text already in mrkdwn is left as is.
+func markdownToMrkdwn(content string) string {
+	content = markdownLinkPattern.ReplaceAllString(content, "<$2|$1>")
+	content = markdownBoldPattern.ReplaceAllString(content, "*$1$2*")
+	content = markdownStrikePattern.ReplaceAllString(content, "~$1~")
+	return markdownHeadingPattern.ReplaceAllString(content, "*$1*")
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"notification/internal/domain/shared"
+)
+
+const adaptationHTML = `<h1>Order shipped</h1>
+<p>Your order <b>#1042</b> is on its way.</p>
+<ul><li>Carrier: UPS</li><li>ETA: <em>Friday</em></li></ul>
+<p>Track it <a href="https://example.com/t/1042">here</a>.</p>`
+
+func TestContentAdaptation(t *testing.T) {
+	shared.InitializeChannelTypes()
+	adaptation := NewContentAdaptation(shared.GetChannelTypeRegistry(), "link")
+
+	t.Run("strips HTML for SMS", func(t *testing.T) {
+		content := &RenderedContent{Content: adaptationHTML}
+		adaptation.Adapt(shared.ChannelTypeSMS, content, nil)
+		assert.Equal(t, "Order shipped\n\nYour order #1042 is on its way.\n\n- Carrier: UPS\n- ETA: Friday\n\nTrack it here (https://example.com/t/1042).", content.Content)
+	})
+
+	t.Run("converts HTML to Slack mrkdwn", func(t *testing.T) {
+		content := &RenderedContent{Content: adaptationHTML}
+		adaptation.Adapt(shared.ChannelTypeSlack, content, nil)
+		assert.Equal(t, "*Order shipped*\n\nYour order *#1042* is on its way.\n\n• Carrier: UPS\n• ETA: _Friday_\n\nTrack it <https://example.com/t/1042|here>.", content.Content)
+	})
+
+	t.Run("converts Markdown to Slack mrkdwn", func(t *testing.T) {
+		content := &RenderedContent{Content: "## Deploy\n**done** in [CI](https://ci.example.com) ~~late~~ <@U123>"}
+		adaptation.Adapt(shared.ChannelTypeSlack, content, nil)
+		assert.Equal(t, "*Deploy*\n*done* in <https://ci.example.com|CI> ~late~ <@U123>", content.Content)
+	})
+
+	t.Run("truncates with the link", func(t *testing.T) {
+		content := &RenderedContent{Subject: "Report", Content: strings.Repeat("word ", 400)}
+		adaptation.Adapt(shared.ChannelTypeSMS, content, map[string]interface{}{"link": "https://example.com/r/7"})
+		assert.True(t, strings.HasSuffix(content.Content, "… https://example.com/r/7"))
+		assert.Equal(t, 1600-len("Report\n\n"), len([]rune(content.Content)))
+	})
+
+	t.Run("leaves email alone", func(t *testing.T) {
+		content := &RenderedContent{Content: adaptationHTML}
+		adaptation.Adapt(shared.ChannelTypeEmail, content, nil)
+		assert.Equal(t, adaptationHTML, content.Content)
+	})
+}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// TXTResolver looks up the TXT records of a DNS name; net.Resolver is one.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// DeliverabilityStatus is the outcome of a deliverability check.
+type DeliverabilityStatus string
+
+const (
+	// DeliverabilityPass is a record receivers will accept
+	DeliverabilityPass DeliverabilityStatus = "pass"
+	// DeliverabilityWarn is a record working today with weaknesses that get
+	// mail filtered as spam, or soon rejected
+	DeliverabilityWarn DeliverabilityStatus = "warn"
+	// DeliverabilityFail is a missing or broken record getting mail dropped
+	DeliverabilityFail DeliverabilityStatus = "fail"
+)
+
+// worse returns the worse of two statuses
+func (s DeliverabilityStatus) worse(other DeliverabilityStatus) DeliverabilityStatus {
+	rank := map[DeliverabilityStatus]int{DeliverabilityPass: 0, DeliverabilityWarn: 1, DeliverabilityFail: 2}
+	if rank[other] > rank[s] {
+		return other
+	}
+	return s
+}
+
+// RecordCheck is the check of the DNS record of a sender domain.
+type RecordCheck struct {
+	// Name is the DNS name looked up
+	Name   string               `json:"name"`
+	Status DeliverabilityStatus `json:"status"`
+	// Record is the record found, empty when there is none
+	Record   string   `json:"record,omitempty"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// problem records a problem of the record, worsening its status
+func (c *RecordCheck) problem(status DeliverabilityStatus, format string, args ...interface{}) {
+	c.Status = c.Status.worse(status)
+	c.Problems = append(c.Problems, fmt.Sprintf(format, args...))
+}
+
+// DeliverabilityReport is the check of the SPF, DKIM and DMARC records of a
+// sender domain. Status is the worst of them.
+type DeliverabilityReport struct {
+	Domain string               `json:"domain"`
+	Status DeliverabilityStatus `json:"status"`
+	SPF    *RecordCheck         `json:"spf"`
+	// DKIM holds a check per selector, or of the common selectors when none
+	// was given and no key was found at them
+	DKIM  []*RecordCheck `json:"dkim"`
+	DMARC *RecordCheck   `json:"dmarc"`
+}
+
+// DKIMSelectorConfigKey is the email channel config key naming the DKIM
+// selectors of its sender domain, comma separated
+const DKIMSelectorConfigKey = "dkim_selector"
+
+// CommonDKIMSelectors are the selectors tried when a channel names none, the
+// defaults of the common mail providers
+var CommonDKIMSelectors = []string{"default", "google", "selector1", "selector2", "k1", "s1", "s2", "mail", "dkim"}
+
+// spfLookupLimit is the number of DNS lookups an SPF evaluation may make,
+// past which receivers fail it (RFC 7208 section 4.6.4)
+const spfLookupLimit = 10
+
+// EmailDeliverability is the domain service checking the DNS records mail
+// receivers authenticate the email of a sender domain with. A domain with a
+// missing or broken SPF, DKIM or DMARC record gets its email silently
+// dropped or filtered as spam.
+type EmailDeliverability struct {
+	resolver TXTResolver
+}
+
+// NewEmailDeliverability creates an email deliverability check.
+func NewEmailDeliverability(resolver TXTResolver) *EmailDeliverability {
+	return &EmailDeliverability{resolver: resolver}
+}
+
+// Check checks the records of a sender domain, with the DKIM keys of the
+// given selectors, or of the common ones when none is given.
+func (d *EmailDeliverability) Check(ctx context.Context, domain string, selectors []string) *DeliverabilityReport {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	report := &DeliverabilityReport{
+		Domain: domain,
+		SPF:    d.checkSPF(ctx, domain),
+		DKIM:   d.checkDKIM(ctx, domain, selectors),
+		DMARC:  d.checkDMARC(ctx, domain),
+	}
+
+	report.Status = report.SPF.Status.worse(report.DMARC.Status)
+	for _, check := range report.DKIM {
+		report.Status = report.Status.worse(check.Status)
+	}
+	return report
+}
+
+// lookup returns the TXT records of a name starting with prefix, case
+// insensitive. A name that does not exist has none.
+func (d *EmailDeliverability) lookup(ctx context.Context, name, prefix string) ([]string, error) {
+	records, err := d.resolver.LookupTXT(ctx, name)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []string
+	for _, record := range records {
+		if strings.HasPrefix(strings.ToLower(record), strings.ToLower(prefix)) {
+			matching = append(matching, record)
+		}
+	}
+	return matching, nil
+}
+
+// checkSPF checks the SPF record of a domain
+func (d *EmailDeliverability) checkSPF(ctx context.Context, domain string) *RecordCheck {
+	check := &RecordCheck{Name: domain, Status: DeliverabilityPass}
+	records, err := d.lookup(ctx, domain, "v=spf1")
+	switch {
+	case err != nil:
+		check.problem(DeliverabilityFail, "SPF lookup failed: %v", err)
+		return check
+	case len(records) == 0:
+		check.problem(DeliverabilityFail, "no SPF record: receivers cannot tell the servers allowed to send for the domain")
+		return check
+	case len(records) > 1:
+		check.problem(DeliverabilityFail, "%d SPF records: receivers fail SPF when there is more than one", len(records))
+	}
+	check.Record = records[0]
+
+	terms := strings.Fields(strings.ToLower(check.Record))[1:]
+	all := ""
+	redirect := false
+	for _, term := range terms {
+		mechanism := strings.TrimLeft(term, "+-~?")
+		switch {
+		case mechanism == "all":
+			all = term
+		case strings.HasPrefix(term, "redirect="):
+			redirect = true
+		case mechanism == "ptr" || strings.HasPrefix(mechanism, "ptr:"):
+			check.problem(DeliverabilityWarn, "the ptr mechanism is deprecated and ignored by some receivers")
+		}
+	}
+	switch all {
+	case "all", "+all":
+		check.problem(DeliverabilityFail, "%q lets any server send for the domain", all)
+	case "?all":
+		check.problem(DeliverabilityWarn, "?all is neutral: mail from other servers is not rejected")
+	case "":
+		if !redirect {
+			check.problem(DeliverabilityWarn, "no all mechanism: mail from other servers is not rejected")
+		}
+	}
+
+	lookups := d.countSPFLookups(ctx, check.Record, 0)
+	if lookups > spfLookupLimit {
+		check.problem(DeliverabilityFail, "SPF needs over %d DNS lookups: receivers fail it", spfLookupLimit)
+	}
+	return check
+}
+
+// countSPFLookups counts the DNS lookups of an SPF record, following its
+// includes and redirect, and stopping once past the limit
+func (d *EmailDeliverability) countSPFLookups(ctx context.Context, record string, count int) int {
+	for _, term := range strings.Fields(strings.ToLower(record))[1:] {
+		if count > spfLookupLimit {
+			return count
+		}
+		mechanism := strings.TrimLeft(term, "+-~?")
+		target := ""
+		switch {
+		case strings.HasPrefix(mechanism, "include:"):
+			target = strings.TrimPrefix(mechanism, "include:")
+		case strings.HasPrefix(term, "redirect="):
+			target = strings.TrimPrefix(term, "redirect=")
+		case mechanism == "a" || mechanism == "mx" || mechanism == "ptr" ||
+			strings.HasPrefix(mechanism, "a:") || strings.HasPrefix(mechanism, "a/") ||
+			strings.HasPrefix(mechanism, "mx:") || strings.HasPrefix(mechanism, "mx/") ||
+			strings.HasPrefix(mechanism, "ptr:") || strings.HasPrefix(mechanism, "exists:"):
+			count++
+			continue
+		default:
+			continue
+		}
+
+		count++
+		records, err := d.lookup(ctx, target, "v=spf1")
+		if err == nil && len(records) == 1 {
+			count = d.countSPFLookups(ctx, records[0], count)
+		}
+	}
+	return count
+}
+
+// checkDKIM checks the DKIM keys of a domain at the given selectors, or at
+// the common ones
+func (d *EmailDeliverability) checkDKIM(ctx context.Context, domain string, selectors []string) []*RecordCheck {
+	if len(selectors) > 0 {
+		checks := make([]*RecordCheck, 0, len(selectors))
+		for _, selector := range selectors {
+			check := d.checkDKIMSelector(ctx, domain, selector)
+			if check.Record == "" && len(check.Problems) == 0 {
+				check.problem(DeliverabilityFail, "no DKIM key at selector %q", selector)
+			}
+			checks = append(checks, check)
+		}
+		return checks
+	}
+
+	var checks []*RecordCheck
+	for _, selector := range CommonDKIMSelectors {
+		if check := d.checkDKIMSelector(ctx, domain, selector); check.Record != "" {
+			checks = append(checks, check)
+		}
+	}
+	if len(checks) == 0 {
+		check := &RecordCheck{Name: "*._domainkey." + domain, Status: DeliverabilityPass}
+		check.problem(DeliverabilityWarn, "no DKIM key at the common selectors; set dkim_selector in the channel config to check yours")
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkDKIMSelector checks the DKIM key of a domain at a selector
+func (d *EmailDeliverability) checkDKIMSelector(ctx context.Context, domain, selector string) *RecordCheck {
+	check := &RecordCheck{Name: selector + "._domainkey." + domain, Status: DeliverabilityPass}
+	records, err := d.resolver.LookupTXT(ctx, check.Name)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return check
+	}
+	if err != nil {
+		check.problem(DeliverabilityFail, "DKIM lookup failed: %v", err)
+		return check
+	}
+	if len(records) == 0 {
+		return check
+	}
+	// A key longer than 255 characters may come back split in strings
+	check.Record = strings.Join(records, "")
+
+	tags := parseTags(check.Record)
+	if version, ok := tags["v"]; ok && version != "DKIM1" {
+		check.problem(DeliverabilityFail, "DKIM version %q is not DKIM1", version)
+	}
+	keyType := strings.ToLower(tags["k"])
+	if keyType == "" {
+		keyType = "rsa"
+	}
+	key, ok := tags["p"]
+	switch {
+	case !ok:
+		check.problem(DeliverabilityFail, "DKIM record has no public key (p=)")
+	case key == "":
+		check.problem(DeliverabilityFail, "DKIM key is revoked (empty p=)")
+	default:
+		checkDKIMKey(check, keyType, key)
+	}
+	return check
+}
+
+// checkDKIMKey checks the public key of a DKIM record
+func checkDKIMKey(check *RecordCheck, keyType, key string) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(key), ""))
+	if err != nil {
+		check.problem(DeliverabilityFail, "DKIM public key is not valid base64")
+		return
+	}
+
+	switch keyType {
+	case "ed25519":
+		if len(der) != ed25519.PublicKeySize {
+			check.problem(DeliverabilityFail, "DKIM ed25519 key is %d bytes, not %d", len(der), ed25519.PublicKeySize)
+		}
+	case "rsa":
+		parsed, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			parsed, err = x509.ParsePKCS1PublicKey(der)
+		}
+		rsaKey, ok := parsed.(*rsa.PublicKey)
+		if err != nil || !ok {
+			check.problem(DeliverabilityFail, "DKIM public key is not a valid RSA key")
+			return
+		}
+		switch bits := rsaKey.N.BitLen(); {
+		case bits < 1024:
+			check.problem(DeliverabilityFail, "DKIM key is %d bits: receivers ignore keys under 1024 bits", bits)
+		case bits < 2048:
+			check.problem(DeliverabilityWarn, "DKIM key is %d bits: 2048 bits are recommended", bits)
+		}
+	default:
+		check.problem(DeliverabilityFail, "DKIM key type %q is unknown", keyType)
+	}
+}
+
+// checkDMARC checks the DMARC record of a domain
+func (d *EmailDeliverability) checkDMARC(ctx context.Context, domain string) *RecordCheck {
+	check := &RecordCheck{Name: "_dmarc." + domain, Status: DeliverabilityPass}
+	records, err := d.lookup(ctx, check.Name, "v=DMARC1")
+	switch {
+	case err != nil:
+		check.problem(DeliverabilityFail, "DMARC lookup failed: %v", err)
+		return check
+	case len(records) == 0:
+		check.problem(DeliverabilityFail, "no DMARC record: the large mailbox providers reject or junk bulk mail without one")
+		return check
+	case len(records) > 1:
+		check.problem(DeliverabilityFail, "%d DMARC records: receivers ignore DMARC when there is more than one", len(records))
+	}
+	check.Record = records[0]
+
+	tags := parseTags(check.Record)
+	switch policy := strings.ToLower(tags["p"]); policy {
+	case "reject", "quarantine":
+	case "none":
+		check.problem(DeliverabilityWarn, "policy p=none only monitors: spoofed mail is still delivered")
+	case "":
+		check.problem(DeliverabilityFail, "DMARC record has no policy (p=)")
+	default:
+		check.problem(DeliverabilityFail, "DMARC policy %q is unknown", policy)
+	}
+	if pct, ok := tags["pct"]; ok {
+		if value, err := strconv.Atoi(pct); err != nil || value < 100 {
+			check.problem(DeliverabilityWarn, "pct=%s applies the policy to part of the mail only", pct)
+		}
+	}
+	if tags["rua"] == "" {
+		check.problem(DeliverabilityWarn, "no aggregate report address (rua=): failures go unnoticed")
+	}
+	return check
+}
+
+// parseTags parses the tag=value list of a DKIM or DMARC record
+func parseTags(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, part := range strings.Split(record, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+		tags[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return tags
+}
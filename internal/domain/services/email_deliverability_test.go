@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver answers TXT lookups from a map, the names missing from it not existing
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestEmailDeliverability(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	weakDER, err := x509.MarshalPKIXPublicKey(&weakKey.PublicKey)
+	require.NoError(t, err)
+
+	t.Run("well configured", func(t *testing.T) {
+		deliverability := NewEmailDeliverability(fakeResolver{
+			"example.com":               {"google-site-verification=abc", "v=spf1 include:_spf.example.net -all"},
+			"_spf.example.net":          {"v=spf1 ip4:192.0.2.0/24 ~all"},
+			"s1._domainkey.example.com": {"v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(publicKey)},
+			"_dmarc.example.com":        {"v=DMARC1; p=reject; rua=mailto:dmarc@example.com"},
+		})
+		report := deliverability.Check(context.Background(), "Example.com.", nil)
+		assert.Equal(t, "example.com", report.Domain)
+		assert.Equal(t, DeliverabilityPass, report.Status)
+		require.Len(t, report.DKIM, 1, "only the selectors with a key are reported")
+		assert.Equal(t, "s1._domainkey.example.com", report.DKIM[0].Name)
+	})
+
+	t.Run("misconfigured", func(t *testing.T) {
+		deliverability := NewEmailDeliverability(fakeResolver{
+			"example.com":                 {"v=spf1 a mx +all", "v=spf1 -all"},
+			"mail._domainkey.example.com": {"v=DKIM1; p=" + base64.StdEncoding.EncodeToString(weakDER)},
+			"_dmarc.example.com":          {"v=DMARC1; p=none; pct=50"},
+		})
+		report := deliverability.Check(context.Background(), "example.com", []string{"mail", "old"})
+		assert.Equal(t, DeliverabilityFail, report.Status)
+		assert.Equal(t, DeliverabilityFail, report.SPF.Status)
+		assert.Len(t, report.SPF.Problems, 2, "two records, and +all")
+
+		require.Len(t, report.DKIM, 2)
+		assert.Equal(t, DeliverabilityWarn, report.DKIM[0].Status)
+		assert.Contains(t, report.DKIM[0].Problems[0], "1024 bits")
+		assert.Equal(t, DeliverabilityFail, report.DKIM[1].Status, "a selector given has no key")
+
+		assert.Equal(t, DeliverabilityWarn, report.DMARC.Status)
+		assert.Len(t, report.DMARC.Problems, 3, "p=none, pct and no rua")
+	})
+
+	t.Run("missing records", func(t *testing.T) {
+		report := NewEmailDeliverability(fakeResolver{}).Check(context.Background(), "example.com", nil)
+		assert.Equal(t, DeliverabilityFail, report.SPF.Status)
+		assert.Equal(t, DeliverabilityFail, report.DMARC.Status)
+		require.Len(t, report.DKIM, 1)
+		assert.Equal(t, DeliverabilityWarn, report.DKIM[0].Status, "no key at the common selectors")
+	})
+
+	t.Run("SPF lookup limit", func(t *testing.T) {
+		resolver := fakeResolver{"example.com": {"v=spf1 include:a.example.net include:b.example.net -all"}}
+		resolver["a.example.net"] = []string{"v=spf1 a mx include:c.example.net -all"}
+		resolver["b.example.net"] = []string{"v=spf1 a mx a:x.example.net mx:y.example.net -all"}
+		resolver["c.example.net"] = []string{"v=spf1 a mx exists:z.example.net -all"}
+		report := NewEmailDeliverability(resolver).Check(context.Background(), "example.com", nil)
+		assert.Equal(t, DeliverabilityFail, report.SPF.Status)
+		assert.Contains(t, report.SPF.Problems[0], "DNS lookups")
+	})
+}
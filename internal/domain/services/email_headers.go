@@ -0,0 +1,119 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+// EmailHeadersConfigKey is the email channel config object of the extra
+// headers of its emails, by name, their values rendered with the variables.
+// They are added to those of the template, replacing the ones of the same name.
+const EmailHeadersConfigKey = "email_headers"
+
+// ListUnsubscribeOneClickConfigKey is the email channel config flag marking
+// the List-Unsubscribe URL of its emails as unsubscribing with a single POST
+// (RFC 8058), which mailbox providers then offer as an unsubscribe button
+const ListUnsubscribeOneClickConfigKey = "list_unsubscribe_one_click"
+
+// Headers of the unsubscribe links of an email (RFC 2369 and RFC 8058)
+const (
+	ListUnsubscribeHeader     = "List-Unsubscribe"
+	ListUnsubscribePostHeader = "List-Unsubscribe-Post"
+	listUnsubscribeOneClick   = "List-Unsubscribe=One-Click"
+)
+
+// ChannelEmailHeaders returns the extra email headers of a channel config by
+// canonical name.
+func ChannelEmailHeaders(config *channel.ChannelConfig) (map[string]string, error) {
+	value, ok := config.Get(EmailHeadersConfigKey)
+	if !ok || value == nil {
+		return nil, nil
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("email config %s must be an object of header values by name", EmailHeadersConfigKey)
+	}
+
+	headers := make(map[string]string, len(object))
+	for name, value := range object {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("email config %s: header %s must be a string", EmailHeadersConfigKey, name)
+		}
+		headers[name] = text
+	}
+	headers, err := template.NormalizeEmailHeaders(headers)
+	if err != nil {
+		return nil, fmt.Errorf("email config %s: %w", EmailHeadersConfigKey, err)
+	}
+	return headers, nil
+}
+
+// ListUnsubscribeOneClick checks if a channel sends one-click unsubscribe links.
+func ListUnsubscribeOneClick(ch *channel.Channel) bool {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return false
+	}
+	value, ok := ch.Config().Get(ListUnsubscribeOneClickConfigKey)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// EmailHeaders returns the extra headers of the emails a channel sends with a
+// template, nil for the other channel types. The headers of the channel
+// replace those of the template.
+func EmailHeaders(ch *channel.Channel, tmpl *template.Template) (map[string]string, error) {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return nil, nil
+	}
+	channelHeaders, err := ChannelEmailHeaders(ch.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	if tmpl != nil {
+		headers = tmpl.Headers()
+	}
+	for name, value := range channelHeaders {
+		headers[name] = value
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	return headers, nil
+}
+
+// FinalizeEmailHeaders checks the rendered headers of an email, which
+// variables must not have broken into several, and marks its unsubscribe
+// link as one-click when the channel asks for it.
+func FinalizeEmailHeaders(ch *channel.Channel, content *RenderedContent) error {
+	for name, value := range content.Headers {
+		if err := template.CheckEmailHeaderValue(value); err != nil {
+			return fmt.Errorf("header %s: %w", name, err)
+		}
+	}
+	if !ListUnsubscribeOneClick(ch) {
+		return nil
+	}
+
+	// One-click unsubscribing POSTs to the HTTPS URI of the header
+	if !strings.Contains(strings.ToLower(content.Headers[ListUnsubscribeHeader]), "<https://") {
+		return errors.New("one-click unsubscribe needs a List-Unsubscribe header with an https URI")
+	}
+	content.Headers[ListUnsubscribePostHeader] = listUnsubscribeOneClick
+	return nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+func newEmailChannel(t *testing.T, config map[string]interface{}) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+
+	name, err := channel.NewChannelName("newsletter")
+	require.NoError(t, err)
+	settings, err := shared.NewCommonSettings(30, 3, 5)
+	require.NoError(t, err)
+
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeEmail, nil, settings,
+		channel.NewChannelConfig(config), channel.NewRecipients(nil), channel.NewTags(nil))
+	require.NoError(t, err)
+	return ch
+}
+
+func TestEmailHeaders(t *testing.T) {
+	shared.InitializeChannelTypes()
+	name, err := template.NewTemplateName("digest")
+	require.NoError(t, err)
+	content, err := template.NewTemplateContent("<p>{body}</p>")
+	require.NoError(t, err)
+	tmpl, err := template.NewTemplate(name, nil, shared.ChannelTypeEmail, nil, content, nil)
+	require.NoError(t, err)
+	require.NoError(t, tmpl.SetHeaders(map[string]string{
+		"x-campaign":       "{campaign}",
+		"List-Unsubscribe": "<https://example.com/unsubscribe?u={user_id}>",
+	}))
+	assert.Error(t, tmpl.SetHeaders(map[string]string{"Subject": "Hi"}), "the email service writes the subject")
+
+	ch := newEmailChannel(t, map[string]interface{}{
+		EmailHeadersConfigKey:            map[string]interface{}{"Reply-To": "support@example.com", "X-Campaign": "weekly-{campaign}"},
+		ListUnsubscribeOneClickConfigKey: true,
+	})
+	headers, err := EmailHeaders(ch, tmpl)
+	require.NoError(t, err)
+	assert.Equal(t, "weekly-{campaign}", headers["X-Campaign"], "the channel replaces the headers of the template")
+	assert.Len(t, headers, 3)
+
+	rendered, err := NewDefaultTemplateRenderer().Render(context.Background(), &RenderRequest{
+		Subject:   tmpl.Subject(),
+		Content:   tmpl.Content(),
+		Variables: message.NewVariables(map[string]interface{}{"campaign": "42", "user_id": "u-7", "body": "News"}),
+		Headers:   headers,
+	})
+	require.NoError(t, err)
+	require.NoError(t, FinalizeEmailHeaders(ch, rendered))
+	assert.Equal(t, "weekly-42", rendered.Headers["X-Campaign"])
+	assert.Equal(t, "<https://example.com/unsubscribe?u=u-7>", rendered.Headers[ListUnsubscribeHeader])
+	assert.Equal(t, "List-Unsubscribe=One-Click", rendered.Headers[ListUnsubscribePostHeader])
+
+	rendered.Headers["X-Campaign"] = "42\r\nBcc: victim@example.com"
+	assert.Error(t, FinalizeEmailHeaders(ch, rendered), "a variable cannot add a header")
+
+	rendered.Headers = map[string]string{ListUnsubscribeHeader: "<mailto:unsubscribe@example.com>"}
+	assert.Error(t, FinalizeEmailHeaders(ch, rendered), "one-click needs an https URI")
+}
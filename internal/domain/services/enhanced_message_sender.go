@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,10 +10,15 @@ import (
 
 	"notification/internal/domain/channel"
 	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
+	"notification/pkg/lock"
 	"notification/pkg/logger"
 )
 
+// ErrDeliveryInProgress is returned when another sender holds the lock of the message
+var ErrDeliveryInProgress = errors.New("message is being delivered by another sender")
+
 // ExternalNotificationService defines the interface for external notification service
 type ExternalNotificationService interface {
 	// SendSingleNotification sends a notification through a single channel
@@ -27,6 +33,10 @@ type SendRequest struct {
 	Channel   *channel.Channel
 	Content   *RenderedContent
 	Variables map[string]interface{}
+	// CorrelationID is passed to the provider as call metadata when set
+	CorrelationID string
+	// AllowProd lets a deployment below prod send through a prod channel
+	AllowProd bool
 }
 
 // SendResult represents the result of a message sending operation
@@ -36,6 +46,27 @@ type SendResult struct {
 	Error     error
 	Details   map[string]interface{}
 	SentAt    int64
+	// Recipients holds the outcome per recipient, empty when the send failed before reaching any
+	Recipients []*RecipientSendResult
+	// ErrorCategory classifies the failure, empty on success
+	ErrorCategory message.ErrorCategory
+}
+
+// RecipientSendResult represents the outcome of a send to one recipient
+type RecipientSendResult struct {
+	Target            string
+	Success           bool
+	ProviderMessageID string
+	Error             error
+	ErrorCategory     message.ErrorCategory
+	SentAt            int64
+	// Acknowledgment holds the keys the recipient pressed to acknowledge a
+	// voice call, AcknowledgedAt is 0 when the call was not acknowledged
+	Acknowledgment string
+	AcknowledgedAt int64
+	// Details describe what the send created at the provider, e.g. the key
+	// of a Jira issue
+	Details map[string]string
 }
 
 // EnhancedMessageSender is an improved version of MessageSender with external service integration
@@ -45,6 +76,20 @@ type EnhancedMessageSender struct {
 	messageRepo           message.MessageRepository
 	renderer              TemplateRenderer
 	notificationService   ExternalNotificationService
+	healthMonitor         *ChannelHealthMonitor
+	pricing               *PricingPolicy
+	mirror                *TrafficMirror
+	preferences           *PreferenceFilter
+	adaptation            *ContentAdaptation
+	linkShortening        *LinkShortening
+	acknowledgmentLinks   *AcknowledgmentLinks
+	replyTracking         *ReplyTracking
+	policy                *SendPolicy
+	attachmentScanning    *AttachmentScanning
+	senderIdentityCheck   *SenderIdentityCheck
+	progress              MessageProgressNotifier
+	locker                lock.Locker
+	lockTTL               time.Duration
 	logger                *logger.Logger
 }
 
@@ -67,104 +112,643 @@ func NewEnhancedMessageSender(
 	}
 }
 
+// SetHealthMonitor makes the sender check the health of each channel after a
+// delivery. Without a monitor the channel health is not tracked.
+func (s *EnhancedMessageSender) SetHealthMonitor(monitor *ChannelHealthMonitor) {
+	s.healthMonitor = monitor
+}
+
+// SetPricing makes the sender record the estimated cost of each send. Without
+// pricing the cost is 0.
+func (s *EnhancedMessageSender) SetPricing(pricing *PricingPolicy) {
+	s.pricing = pricing
+}
+
+// SetMirror makes the sender copy a sample of its sends to a shadow channel or
+// the log. Without a mirror nothing is copied.
+func (s *EnhancedMessageSender) SetMirror(mirror *TrafficMirror) {
+	s.mirror = mirror
+}
+
+// SetPreferences makes the sender leave out the recipients whose preferences
+// stop a message, recording them as suppressed. Without preferences every
+// recipient is sent to.
+func (s *EnhancedMessageSender) SetPreferences(preferences *PreferenceFilter) {
+	s.preferences = preferences
+}
+
+// SetContentAdaptation makes the sender adapt the rendered content to the
+// type of each channel, e.g. converting HTML to Slack mrkdwn. Without it the
+// content is sent as rendered.
+func (s *EnhancedMessageSender) SetContentAdaptation(adaptation *ContentAdaptation) {
+	s.adaptation = adaptation
+}
+
+// SetLinkShortening makes the sender shorten the links of the messages sent
+// through SMS channels. Without it the links are sent as rendered.
+func (s *EnhancedMessageSender) SetLinkShortening(shortening *LinkShortening) {
+	s.linkShortening = shortening
+}
+
+// SetAcknowledgmentLinks makes the sender add an acknowledgment link per
+// recipient to the messages of the channels asking for them. Without it no
+// acknowledgment is tracked.
+func (s *EnhancedMessageSender) SetAcknowledgmentLinks(links *AcknowledgmentLinks) {
+	s.acknowledgmentLinks = links
+}
+
+// SetReplyTracking makes the sender record the reply tokens of the messages
+// of the channels tracking replies, so that the replies are correlated to
+// them. Without it replies are received uncorrelated.
+func (s *EnhancedMessageSender) SetReplyTracking(tracking *ReplyTracking) {
+	s.replyTracking = tracking
+}
+
+// SetPolicy makes the sender run the pre-send policy hooks on each send,
+// recording their decisions on its result. Without a policy nothing is checked.
+func (s *EnhancedMessageSender) SetPolicy(policy *SendPolicy) {
+	s.policy = policy
+}
+
+// SetAttachmentScanning makes the sender scan the attachments of each send
+// for viruses, recording the verdicts on its result. Without it attachments
+// are sent unscanned.
+func (s *EnhancedMessageSender) SetAttachmentScanning(scanning *AttachmentScanning) {
+	s.attachmentScanning = scanning
+}
+
+// SetSenderIdentityCheck makes the sender fail the email sends from an address
+// the tenant of the message has not verified. Without it any address is used.
+func (s *EnhancedMessageSender) SetSenderIdentityCheck(check *SenderIdentityCheck) {
+	s.senderIdentityCheck = check
+}
+
+// SetProgressNotifier makes the sender report each channel of a message as
+// its delivery completes, and the message once all of them did.
+func (s *EnhancedMessageSender) SetProgressNotifier(notifier MessageProgressNotifier) {
+	s.progress = notifier
+}
+
+// SetLocker makes the sender lock each message it delivers, and each channel
+// whose held messages it releases, for at most ttl, so that two replicas never
+// send the same message. Without a locker a message dispatched twice may be
+// sent twice.
+func (s *EnhancedMessageSender) SetLocker(locker lock.Locker, ttl time.Duration) {
+	s.locker = locker
+	s.lockTTL = ttl
+}
+
+// MessageProgressNotifier is told of the progress of a message delivery.
+type MessageProgressNotifier interface {
+	// ChannelDelivered is told the last result of a channel, fallbacks
+	// included, and how many of the channels of the message completed
+	ChannelDelivered(ctx context.Context, msg *message.Message, result *message.MessageResult, completed int)
+	// MessageDelivered is told once the results of the message are saved
+	MessageDelivered(ctx context.Context, msg *message.Message)
+}
+
+// MessageDispatcher hands an accepted message over to the send workers
+type MessageDispatcher interface {
+	// Dispatch queues a pending message for delivery
+	Dispatch(ctx context.Context, msg *message.Message) error
+}
+
 // SendMessage sends a message through multiple channels
 func (s *EnhancedMessageSender) SendMessage(
 	ctx context.Context,
 	channelIDs *message.ChannelIDs,
 	variables *message.Variables,
 	channelOverrides *message.ChannelOverrides,
+	correlationID string,
+	tenantID string,
+	strictRender bool,
 ) (*message.Message, error) {
-	startTime := time.Now()
-	
-	s.logger.Info("Starting message sending process",
+	msg, err := s.Accept(ctx, channelIDs, variables, channelOverrides, correlationID, tenantID, strictRender)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Deliver(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// Accept creates a pending message and saves it without sending it
+func (s *EnhancedMessageSender) Accept(
+	ctx context.Context,
+	channelIDs *message.ChannelIDs,
+	variables *message.Variables,
+	channelOverrides *message.ChannelOverrides,
+	correlationID string,
+	tenantID string,
+	strictRender bool,
+) (*message.Message, error) {
+	if correlationID != "" {
+		ctx = logger.ContextWithCorrelationID(ctx, correlationID)
+	}
+	log := s.logger.WithContext(ctx)
+
+	log.Info("Accepting message",
 		zap.Int("channel_count", channelIDs.Count()),
 		zap.Strings("variable_keys", variables.Keys()))
 
 	// Create message entity
-	msg, err := message.NewMessage(channelIDs, variables, channelOverrides)
+	msg, err := message.NewMessage(channelIDs, variables, channelOverrides, correlationID, tenantID, strictRender)
 	if err != nil {
-		s.logger.Error("Failed to create message entity", zap.Error(err))
+		log.Error("Failed to create message entity", zap.Error(err))
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
 
 	// Save initial message
 	if err := s.messageRepo.Save(ctx, msg); err != nil {
-		s.logger.Error("Failed to save initial message", zap.Error(err))
+		log.Error("Failed to save initial message", zap.Error(err))
 		return nil, fmt.Errorf("failed to save message: %w", err)
 	}
 
-	s.logger.Info("Message entity created and saved",
+	log.Info("Message entity created and saved",
 		zap.String("message_id", msg.ID().String()))
 
+	return msg, nil
+}
+
+// Deliver sends a pending message through each of its channels and saves the results
+func (s *EnhancedMessageSender) Deliver(ctx context.Context, msg *message.Message) error {
+	startTime := time.Now()
+	channelIDs := msg.ChannelIDs()
+
+	// Carry the correlation ID to every log line and provider call of this message
+	if msg.CorrelationID() != "" {
+		ctx = logger.ContextWithCorrelationID(ctx, msg.CorrelationID())
+	}
+	log := s.logger.WithContext(ctx)
+
+	// Only the sender holding the lock delivers the message, once
+	if s.locker != nil {
+		unlock, err := s.acquire(ctx, "message:"+msg.ID().String())
+		if errors.Is(err, lock.ErrNotAcquired) {
+			return ErrDeliveryInProgress
+		}
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		stored, err := s.messageRepo.FindByID(ctx, msg.ID())
+		if err != nil {
+			return fmt.Errorf("failed to load message: %w", err)
+		}
+		if stored.Status() != message.MessageStatusPending {
+			log.Info("Skipping message delivered by another sender",
+				zap.String("message_id", msg.ID().String()),
+				zap.String("status", string(stored.Status())))
+			return nil
+		}
+	}
+
+	log.Info("Starting message sending process",
+		zap.String("message_id", msg.ID().String()),
+		zap.Int("channel_count", channelIDs.Count()))
+
 	// Process each channel
 	successCount := 0
-	for _, channelID := range channelIDs.ToSlice() {
-		result := s.processSingleChannelEnhanced(ctx, channelID, variables, channelOverrides)
+	for i, channelID := range channelIDs.ToSlice() {
+		result := s.processSingleChannelEnhanced(ctx, msg, channelID)
 		
 		if err := msg.AddResult(result); err != nil {
-			s.logger.Error("Failed to add result to message",
+			log.Error("Failed to add result to message",
 				zap.String("channel_id", channelID.String()),
 				zap.Error(err))
 			continue
 		}
 
-		if result.IsSuccess() {
-			successCount++
-		}
-
-		s.logger.Info("Channel processing completed",
+		log.Info("Channel processing completed",
 			zap.String("channel_id", channelID.String()),
 			zap.String("status", string(result.Status())),
 			zap.String("message", result.Message()))
+
+		// Re-send through the fallback chain when the delivery failed
+		if result = s.sendFallbacks(ctx, msg, result); result.IsSuccess() {
+			successCount++
+		}
+
+		if s.progress != nil {
+			s.progress.ChannelDelivered(ctx, msg, result, i+1)
+		}
 	}
 
 	// Update message with results
 	if err := s.messageRepo.Update(ctx, msg); err != nil {
-		s.logger.Error("Failed to update message with results", zap.Error(err))
-		return nil, fmt.Errorf("failed to update message: %w", err)
+		log.Error("Failed to update message with results", zap.Error(err))
+		return fmt.Errorf("failed to update message: %w", err)
+	}
+
+	// Track the channel health now that the results are saved, fallbacks included
+	if s.healthMonitor != nil {
+		for _, result := range msg.Results() {
+			channelID := result.ChannelID()
+			if err := s.healthMonitor.Check(ctx, channelID); err != nil {
+				log.Warn("Failed to check channel health",
+					zap.String("channel_id", channelID.String()),
+					zap.Error(err))
+			}
+		}
+	}
+
+	if s.progress != nil {
+		s.progress.MessageDelivered(ctx, msg)
 	}
 
 	duration := time.Since(startTime)
-	s.logger.Info("Message sending process completed",
+	log.Info("Message sending process completed",
 		zap.String("message_id", msg.ID().String()),
 		zap.String("status", string(msg.Status())),
 		zap.Int("success_count", successCount),
 		zap.Int("total_count", channelIDs.Count()),
 		zap.Duration("duration", duration))
 
-	return msg, nil
+	return nil
 }
 
-// processSingleChannelEnhanced processes a single channel with enhanced error handling and logging
-func (s *EnhancedMessageSender) processSingleChannelEnhanced(
+// ReleaseHeld sends the messages parked for a channel, oldest first, and
+// returns how many were released. It stops when the channel is back in
+// maintenance.
+func (s *EnhancedMessageSender) ReleaseHeld(ctx context.Context, channelID *channel.ChannelID) (int, error) {
+	log := s.logger.WithContext(ctx).WithFields(zap.String("channel_id", channelID.String()))
+
+	if s.locker != nil {
+		unlock, err := s.acquire(ctx, "held:"+channelID.String())
+		if errors.Is(err, lock.ErrNotAcquired) {
+			return 0, shared.NewConflictError("RELEASE_IN_PROGRESS", "the held messages of the channel are being released by another sender")
+		}
+		if err != nil {
+			return 0, err
+		}
+		defer unlock()
+	}
+
+	messages, err := s.messageRepo.FindHeldByChannel(ctx, channelID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, msg := range messages {
+		msgCtx := ctx
+		if msg.CorrelationID() != "" {
+			msgCtx = logger.ContextWithCorrelationID(ctx, msg.CorrelationID())
+		}
+
+		result := s.processSingleChannelEnhanced(msgCtx, msg, channelID)
+		if result.IsHeld() {
+			break
+		}
+
+		if err := msg.UpdateResult(channelID, result); err != nil {
+			log.Error("Failed to update held result",
+				zap.String("message_id", msg.ID().String()),
+				zap.Error(err))
+			continue
+		}
+		s.sendFallbacks(msgCtx, msg, result)
+		if err := s.messageRepo.Update(msgCtx, msg); err != nil {
+			return released, fmt.Errorf("failed to update message: %w", err)
+		}
+		released++
+	}
+
+	if released > 0 && s.healthMonitor != nil {
+		if err := s.healthMonitor.Check(ctx, channelID); err != nil {
+			log.Warn("Failed to check channel health", zap.Error(err))
+		}
+	}
+
+	log.Info("Held messages released",
+		zap.Int("released_count", released),
+		zap.Int("held_count", len(messages)))
+
+	return released, nil
+}
+
+// acquire takes the lock named key and returns the function releasing it
+func (s *EnhancedMessageSender) acquire(ctx context.Context, key string) (func(), error) {
+	held, err := s.locker.TryAcquire(ctx, key, s.lockTTL)
+	if err != nil {
+		if errors.Is(err, lock.ErrNotAcquired) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to take lock %s: %w", key, err)
+	}
+
+	return func() {
+		if err := held.Release(context.WithoutCancel(ctx)); err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to release lock",
+				zap.String("key", key),
+				zap.Error(err))
+		}
+	}, nil
+}
+
+// SendPreview is what sending a message through a channel would do, worked
+// out without sending it.
+type SendPreview struct {
+	ChannelID *channel.ChannelID
+	// Channel and Template are nil when they could not be found, Template also
+	// when the channel has none
+	Channel  *channel.Channel
+	Template *template.Template
+	// Request is what the provider would be sent, nil when the send would end
+	// before reaching it
+	Request *SendRequest
+	// Result tells how the send would end before reaching the provider, held,
+	// suppressed or failed; nil when it would be sent
+	Result *message.MessageResult
+	// Suppressed holds the results of the recipients stopped by their preferences
+	Suppressed []*message.RecipientResult
+}
+
+// Preview works out the send of a message through a channel the way a
+// delivery does, up to the provider call, without sending or saving anything.
+// The fallback of the channel is not previewed: whether it is used depends on
+// how the provider answers.
+func (s *EnhancedMessageSender) Preview(
 	ctx context.Context,
 	channelID *channel.ChannelID,
 	variables *message.Variables,
 	channelOverrides *message.ChannelOverrides,
+	strictRender bool,
+) *SendPreview {
+	prepared, result := s.prepareSend(ctx, channelID, variables, channelOverrides, strictRender)
+	preview := &SendPreview{
+		ChannelID:  channelID,
+		Channel:    prepared.channel,
+		Template:   prepared.template,
+		Request:    prepared.request,
+		Result:     result,
+		Suppressed: prepared.suppressed,
+	}
+	if result != nil {
+		preview.Suppressed = nil
+		for _, recipient := range result.Recipients() {
+			if recipient.Status == message.MessageResultStatusSuppressed {
+				preview.Suppressed = append(preview.Suppressed, recipient)
+			}
+		}
+	}
+	return preview
+}
+
+// sendFallbacks sends a message through the fallback of a channel whose
+// delivery failed, re-rendered with the template of the fallback, and on
+// through the fallback of the fallback while the sends keep failing. Each hop
+// is recorded as a result of the message. A send that reached some recipients
+// is not sent again, and neither is a channel the message already went
+// through. It returns the last result of the chain.
+func (s *EnhancedMessageSender) sendFallbacks(ctx context.Context, msg *message.Message, result *message.MessageResult) *message.MessageResult {
+	for hop := 0; hop < channel.MaxFallbackHops && canFallBack(result); hop++ {
+		ch, err := s.channelRepo.FindByID(ctx, result.ChannelID())
+		if err != nil || ch.FallbackChannelID() == nil {
+			return result
+		}
+
+		log := s.logger.WithContext(ctx).WithFields(
+			zap.String("message_id", msg.ID().String()),
+			zap.String("channel_id", ch.ID().String()),
+			zap.String("fallback_channel_id", ch.FallbackChannelID().String()))
+
+		if _, exists := msg.GetResult(ch.FallbackChannelID()); exists {
+			log.Info("Fallback channel already used by the message, not sent again")
+			return result
+		}
+
+		log.Warn("Delivery failed, sending through the fallback channel")
+		fallbackResult := s.processSingleChannelEnhanced(ctx, msg, ch.FallbackChannelID())
+		if err := msg.AddFallbackResult(ch.ID(), fallbackResult); err != nil {
+			log.Error("Failed to add fallback result to message", zap.Error(err))
+			return result
+		}
+		result = fallbackResult
+	}
+
+	return result
+}
+
+// canFallBack checks if a result calls for sending through the fallback channel
+func canFallBack(result *message.MessageResult) bool {
+	if !result.IsFailed() {
+		return false
+	}
+	// Sending again would duplicate the message for the recipients that got
+	// it, and content a policy blocked is not sent elsewhere instead
+	return result.Error() == nil || (result.Error().Code != "PARTIAL_SEND_ERROR" && result.Error().Code != "POLICY_BLOCKED")
+}
+
+// preparedSend is the send of a message through a channel, filled in as far
+// as it was worked out
+type preparedSend struct {
+	// channel is the stored channel; the request carries it with the overrides
+	// of the message applied and the suppressed recipients left out
+	channel  *channel.Channel
+	template *template.Template
+	request  *SendRequest
+	// suppressed holds the results of the recipients stopped by their preferences
+	suppressed []*message.RecipientResult
+	logger     *logger.Logger
+}
+
+// processSingleChannelEnhanced processes a single channel of a message with enhanced error handling and logging
+func (s *EnhancedMessageSender) processSingleChannelEnhanced(
+	ctx context.Context,
+	msg *message.Message,
+	channelID *channel.ChannelID,
 ) *message.MessageResult {
-	channelLogger := s.logger.WithFields(zap.String("channel_id", channelID.String()))
+	prepared, result := s.prepareSend(ctx, channelID, msg.Variables(), msg.ChannelOverrides(), msg.StrictRender())
+	if result != nil {
+		return result
+	}
+	ch, sendChannel, sendRequest, channelLogger := prepared.channel, prepared.request.Channel, prepared.request, prepared.logger
+	suppressed := prepared.suppressed
+
+	// Send only from the verified identities of the tenant
+	if s.senderIdentityCheck != nil {
+		if err := s.senderIdentityCheck.Check(ctx, msg.TenantID(), sendChannel); err != nil {
+			channelLogger.Warn("Sender identity check stopped the send", zap.Error(err))
+			summary, code, category := "Sender identity check failed", "SENDER_IDENTITY_CHECK_FAILED", message.ErrorCategoryTemporary
+			if errors.Is(err, ErrSenderNotVerified) {
+				summary, code, category = "Sender is not verified", "SENDER_NOT_VERIFIED", message.ErrorCategoryPermanent
+			}
+			result := s.createFailedResult(channelID, summary, code, err.Error())
+			result.Error().Category = category
+			return result
+		}
+	}
+
+	// Scan the attachments for viruses, rejecting the send or keeping the
+	// infected ones back
+	var scans []*message.AttachmentScan
+	if s.attachmentScanning != nil {
+		var err error
+		scans, err = s.attachmentScanning.Scan(ctx, sendChannel, sendRequest.Content, msg.ID().String())
+		if err != nil {
+			channelLogger.Warn("Attachment scan stopped the send", zap.Error(err))
+			// A scanner that failed may succeed on a retry, an infected file will not
+			summary, code, category := "Attachment scan failed", "ATTACHMENT_SCAN_FAILED", message.ErrorCategoryTemporary
+			if errors.Is(err, ErrAttachmentInfected) {
+				summary, code, category = "Attachment is infected", "ATTACHMENT_INFECTED", message.ErrorCategoryPermanent
+			}
+			result := s.createFailedResult(channelID, summary, code, err.Error())
+			result.Error().Category = category
+			result.SetAttachmentScans(scans)
+			return result
+		}
+	}
+
+	// Run the pre-send policy hooks, which may change or block the send
+	var decisions []*message.PolicyDecision
+	if s.policy != nil {
+		var blocked *message.PolicyDecision
+		decisions, blocked = s.policy.Check(ctx, &PolicySend{
+			MessageID: msg.ID().String(),
+			TenantID:  msg.TenantID(),
+			Channel:   sendChannel,
+			Content:   sendRequest.Content,
+		})
+		if blocked != nil {
+			channelLogger.Warn("Send blocked by policy",
+				zap.String("hook", blocked.Hook),
+				zap.String("reason", blocked.Reason))
+			result := s.createFailedResult(channelID, "Send blocked by policy", "POLICY_BLOCKED",
+				fmt.Sprintf("%s: %s", blocked.Hook, blocked.Reason))
+			result.Error().Category = message.ErrorCategoryPermanent
+			result.SetPolicyDecisions(decisions)
+			result.SetAttachmentScans(scans)
+			return result
+		}
+	}
+
+	// Shorten the links of the message, counting their clicks against it
+	if s.linkShortening != nil {
+		s.linkShortening.Shorten(ctx, sendChannel.ChannelType(), sendRequest.Content, msg.ID().String(), channelID.String())
+	}
+
+	// Ask the recipients to acknowledge the message, tracking who does
+	if s.acknowledgmentLinks != nil {
+		s.acknowledgmentLinks.Add(ctx, sendChannel, sendRequest.Content, msg.ID().String(), channelID.String())
+	}
+
+	// Correlate the replies of the recipients to the message
+	if s.replyTracking != nil {
+		s.replyTracking.Add(ctx, sendChannel, sendRequest.Content, msg.ID().String(), channelID.String())
+	}
+
+	// Send message via external service
+	sendResult := s.sendWithRetry(ctx, sendChannel, sendRequest, channelLogger)
+	recipients := append(toRecipientResults(sendResult.Recipients), suppressed...)
+	if s.mirror != nil {
+		s.mirror.Mirror(ctx, sendRequest, sendResult)
+	}
+	
+	if !sendResult.Success {
+		channelLogger.Error("Message sending failed",
+			zap.Error(sendResult.Error),
+			zap.Any("details", sendResult.Details))
+		
+		errorCode := "SEND_ERROR"
+		errorDetails := "Failed to send message"
+		if sendResult.Error != nil {
+			errorDetails = sendResult.Error.Error()
+		}
+		// Some recipients got the message, the failed ones are listed per recipient
+		for _, recipient := range recipients {
+			if recipient.IsSuccess() {
+				errorCode = "PARTIAL_SEND_ERROR"
+				break
+			}
+		}
+		
+		result := s.createFailedResult(channelID, sendResult.Message, errorCode, errorDetails)
+		result.Error().Category = sendResult.ErrorCategory
+		result.SetRecipients(recipients)
+		result.SetPolicyDecisions(decisions)
+		result.SetAttachmentScans(scans)
+		s.estimateCost(sendChannel, sendResult, result)
+		return result
+	}
+
+	channelLogger.Info("Message sent successfully",
+		zap.String("result_message", sendResult.Message),
+		zap.Any("details", sendResult.Details))
+
+	// Mark channel as used
+	ch.MarkAsUsed()
+	if err := s.channelRepo.Update(ctx, ch); err != nil {
+		channelLogger.Warn("Failed to update channel last used time", zap.Error(err))
+		// This is not a critical error, so we don't fail the operation
+	}
+
+	// Create success result
+	result, err := message.NewSuccessfulMessageResult(channelID, sendResult.Message)
+	if err != nil {
+		channelLogger.Error("Failed to create success result", zap.Error(err))
+		return s.createFailedResult(channelID, "Failed to create result", "RESULT_ERROR", err.Error())
+	}
+	result.SetRecipients(recipients)
+	result.SetPolicyDecisions(decisions)
+	result.SetAttachmentScans(scans)
+	s.estimateCost(sendChannel, sendResult, result)
+
+	return result
+}
+
+// prepareSend works out the send of a message through a channel up to the
+// provider call: the channel with the overrides of the message, the template,
+// the recipients and the rendered content. It returns the result instead when
+// the send ends before reaching the provider, held, suppressed or failed.
+func (s *EnhancedMessageSender) prepareSend(
+	ctx context.Context,
+	channelID *channel.ChannelID,
+	variables *message.Variables,
+	channelOverrides *message.ChannelOverrides,
+	strictRender bool,
+) (*preparedSend, *message.MessageResult) {
+	prepared := &preparedSend{}
+	channelLogger := s.logger.WithContext(ctx).WithFields(zap.String("channel_id", channelID.String()))
 
 	// Get channel information
 	ch, err := s.channelRepo.FindByID(ctx, channelID)
 	if err != nil {
 		channelLogger.Error("Failed to retrieve channel", zap.Error(err))
-		return s.createFailedResult(channelID, "Failed to retrieve channel", "CHANNEL_NOT_FOUND", err.Error())
+		return prepared, s.createFailedResult(channelID, "Failed to retrieve channel", "CHANNEL_NOT_FOUND", err.Error())
 	}
 
+	prepared.channel = ch
 	channelLogger = channelLogger.WithFields(
 		zap.String("channel_name", ch.Name().String()),
 		zap.String("channel_type", ch.ChannelType().String()))
 
+	// Park the message while the channel is in maintenance
+	if ch.InMaintenance() && !ch.IsDeleted() {
+		channelLogger.Info("Channel in maintenance, message held")
+		return prepared, s.createHeldResult(channelID)
+	}
+
+	// Apply the recipient and config overrides of the message to this send only
+	sendChannel := ch
+	if override, exists := channelOverrides.Get(channelID.String()); exists {
+		sendChannel = ch.WithOverrides(override.Recipients, override.Config)
+	}
+
 	// Check if channel can send messages
-	if err := ch.CanSendMessage(); err != nil {
+	if err := sendChannel.CanSendMessage(); err != nil {
 		channelLogger.Warn("Channel cannot send message", zap.Error(err))
-		return s.createFailedResult(channelID, "Channel cannot send message", "CHANNEL_UNAVAILABLE", err.Error())
+		return prepared, s.createFailedResult(channelID, "Channel cannot send message", "CHANNEL_UNAVAILABLE", err.Error())
 	}
 
 	// Validate channel with external service
-	if err := s.notificationService.ValidateChannel(ch); err != nil {
+	if err := s.notificationService.ValidateChannel(sendChannel); err != nil {
 		channelLogger.Warn("Channel validation failed", zap.Error(err))
-		return s.createFailedResult(channelID, "Channel validation failed", "CHANNEL_INVALID", err.Error())
+		return prepared, s.createFailedResult(channelID, "Channel validation failed", "CHANNEL_INVALID", err.Error())
 	}
 
 	// Get template information if specified
@@ -173,15 +757,16 @@ func (s *EnhancedMessageSender) processSingleChannelEnhanced(
 		tmpl, err = s.templateRepo.FindByID(ctx, ch.TemplateID())
 		if err != nil {
 			channelLogger.Error("Failed to retrieve template", zap.Error(err))
-			return s.createFailedResult(channelID, "Failed to retrieve template", "TEMPLATE_NOT_FOUND", err.Error())
+			return prepared, s.createFailedResult(channelID, "Failed to retrieve template", "TEMPLATE_NOT_FOUND", err.Error())
 		}
+		prepared.template = tmpl
 
 		// Check template compatibility
 		if !tmpl.MatchesType(ch.ChannelType()) {
 			channelLogger.Error("Template type mismatch",
 				zap.String("template_type", tmpl.ChannelType().String()),
 				zap.String("channel_type", ch.ChannelType().String()))
-			return s.createFailedResult(channelID, "Template type mismatch", "TYPE_MISMATCH", 
+			return prepared, s.createFailedResult(channelID, "Template type mismatch", "TYPE_MISMATCH", 
 				fmt.Sprintf("Template type: %s, Channel type: %s", tmpl.ChannelType(), ch.ChannelType()))
 		}
 
@@ -190,14 +775,29 @@ func (s *EnhancedMessageSender) processSingleChannelEnhanced(
 			zap.String("template_name", tmpl.Name().String()))
 	}
 
-	// Prepare render request
+	// Prepare render request, strict when either the message or the template asks for it
 	renderRequest := s.prepareRenderRequestEnhanced(ch, tmpl, variables, channelOverrides)
+	renderRequest.Strict = strictRender || (tmpl != nil && tmpl.IsStrict())
+	if renderRequest.Headers, err = EmailHeaders(sendChannel, tmpl); err != nil {
+		channelLogger.Error("Email headers are invalid", zap.Error(err))
+		return prepared, s.createFailedResult(channelID, "Email headers are invalid", "INVALID_EMAIL_HEADERS", err.Error())
+	}
 
-	// Validate variables if template is used
-	if tmpl != nil {
-		if err := s.validateVariables(tmpl, renderRequest.Variables); err != nil {
-			channelLogger.Warn("Variable validation failed", zap.Error(err))
-			return s.createFailedResult(channelID, "Variable validation failed", "MISSING_VARIABLES", err.Error())
+	// Leave out the recipients whose preferences stop the message
+	var suppressed []*message.RecipientResult
+	if s.preferences != nil {
+		sendChannel, suppressed, err = s.preferences.Filter(ctx, sendChannel, renderRequest.Variables.ToMap())
+		if err != nil {
+			// Not knowing the preferences must not lose the message
+			channelLogger.Warn("Failed to check recipient preferences, sending to every recipient", zap.Error(err))
+		}
+		for _, recipient := range suppressed {
+			channelLogger.Info("Recipient suppressed by preferences",
+				zap.String("target", recipient.Target),
+				zap.String("reason", recipient.SuppressionReason))
+		}
+		if len(suppressed) > 0 && sendChannel.Recipients().Count() == 0 {
+			return prepared, s.createSuppressedResult(channelID, suppressed)
 		}
 	}
 
@@ -205,55 +805,197 @@ func (s *EnhancedMessageSender) processSingleChannelEnhanced(
 	renderedContent, err := s.renderer.Render(ctx, renderRequest)
 	if err != nil {
 		channelLogger.Error("Template rendering failed", zap.Error(err))
-		return s.createFailedResult(channelID, "Template rendering failed", "RENDER_ERROR", err.Error())
+		return prepared, s.createFailedResult(channelID, "Template rendering failed", "RENDER_ERROR", err.Error())
+	}
+
+	// Adapt the content to what the channel type can send
+	if s.adaptation != nil {
+		s.adaptation.Adapt(sendChannel.ChannelType(), renderedContent, renderRequest.Variables.ToMap())
+	}
+
+	// Check the rendered email headers, adding the one-click unsubscribe
+	if sendChannel.ChannelType().Equals(shared.ChannelTypeEmail) {
+		if err := FinalizeEmailHeaders(sendChannel, renderedContent); err != nil {
+			channelLogger.Error("Email headers are invalid", zap.Error(err))
+			return prepared, s.createFailedResult(channelID, "Email headers are invalid", "INVALID_EMAIL_HEADERS", err.Error())
+		}
+	}
+
+	// Describe the event of the message for channels sending calendar invites
+	if CalendarInviteEnabled(sendChannel) {
+		invite, err := NewCalendarInvite(renderRequest.Variables.ToMap(), renderedContent.Subject)
+		if err != nil {
+			channelLogger.Error("Calendar invite is invalid", zap.Error(err))
+			return prepared, s.createFailedResult(channelID, "Calendar invite is invalid", "INVALID_CALENDAR_INVITE", err.Error())
+		}
+		renderedContent.CalendarInvite = invite
+	}
+
+	// Send WhatsApp messages as the pre-approved template mapped to the local one
+	templateID := ""
+	if tmpl != nil {
+		templateID = tmpl.ID().String()
 	}
+	whatsAppTemplate, err := NewWhatsAppTemplate(sendChannel, templateID, renderRequest.Variables.ToMap())
+	if errors.Is(err, ErrWhatsAppTemplateUnmapped) {
+		channelLogger.Error("WhatsApp template is not mapped", zap.Error(err))
+		return prepared, s.createFailedResult(channelID, "WhatsApp template is not mapped", "WHATSAPP_TEMPLATE_UNMAPPED", err.Error())
+	}
+	if err != nil {
+		channelLogger.Error("WhatsApp template is invalid", zap.Error(err))
+		return prepared, s.createFailedResult(channelID, "WhatsApp template is invalid", "INVALID_WHATSAPP_TEMPLATE", err.Error())
+	}
+	renderedContent.WhatsAppTemplate = whatsAppTemplate
+
+	// Describe the incident event of PagerDuty messages
+	pagerDutyEvent, err := NewPagerDutyEvent(sendChannel, renderRequest.Variables.ToMap(), renderedContent)
+	if err != nil {
+		channelLogger.Error("PagerDuty event is invalid", zap.Error(err))
+		return prepared, s.createFailedResult(channelID, "PagerDuty event is invalid", "INVALID_PAGERDUTY_EVENT", err.Error())
+	}
+	renderedContent.PagerDutyEvent = pagerDutyEvent
+
+	// Describe the alert of Opsgenie messages
+	opsgenieAlert, err := NewOpsgenieAlert(sendChannel, renderRequest.Variables.ToMap(), renderedContent)
+	if err != nil {
+		channelLogger.Error("Opsgenie alert is invalid", zap.Error(err))
+		return prepared, s.createFailedResult(channelID, "Opsgenie alert is invalid", "INVALID_OPSGENIE_ALERT", err.Error())
+	}
+	renderedContent.OpsgenieAlert = opsgenieAlert
+
+	// Resolve the topics of MQTT messages
+	mqttPublications, err := NewMQTTPublications(sendChannel, renderRequest.Variables.ToMap())
+	if err != nil {
+		channelLogger.Error("MQTT topic cannot be resolved", zap.Error(err))
+		return prepared, s.createFailedResult(channelID, "MQTT topic cannot be resolved", "INVALID_MQTT_TOPIC", err.Error())
+	}
+	renderedContent.MQTTPublications = mqttPublications
+
+	// Describe the incident of Statuspage messages
+	statuspageIncident, err := NewStatuspageIncident(sendChannel, renderRequest.Variables.ToMap(), renderedContent)
+	if err != nil {
+		channelLogger.Error("Statuspage incident is invalid", zap.Error(err))
+		return prepared, s.createFailedResult(channelID, "Statuspage incident is invalid", "INVALID_STATUSPAGE_INCIDENT", err.Error())
+	}
+	renderedContent.StatuspageIncident = statuspageIncident
 
 	channelLogger.Debug("Template rendered successfully",
 		zap.Int("subject_length", len(renderedContent.Subject)),
 		zap.Int("content_length", len(renderedContent.Content)))
 
-	// Send message via external service
-	sendRequest := &SendRequest{
-		Channel:   ch,
-		Content:   renderedContent,
-		Variables: variables.ToMap(),
+	prepared.request = &SendRequest{
+		Channel:       sendChannel,
+		Content:       renderedContent,
+		Variables:     renderRequest.Variables.ToMap(),
+		CorrelationID: logger.CorrelationIDFromContext(ctx),
 	}
+	prepared.request.AllowProd = message.AllowsProd(prepared.request.Variables)
+	prepared.suppressed = suppressed
+	prepared.logger = channelLogger
+	return prepared, nil
+}
 
-	sendResult := s.notificationService.SendSingleNotification(ctx, sendRequest)
-	
-	if !sendResult.Success {
-		channelLogger.Error("Message sending failed",
-			zap.Error(sendResult.Error),
-			zap.Any("details", sendResult.Details))
-		
-		errorCode := "SEND_ERROR"
-		errorDetails := "Failed to send message"
-		if sendResult.Error != nil {
-			errorDetails = sendResult.Error.Error()
+
+// estimateCost records the estimated cost of a send on its result, counting
+// the recipients it reached
+func (s *EnhancedMessageSender) estimateCost(ch *channel.Channel, sendResult *SendResult, result *message.MessageResult) {
+	if s.pricing == nil {
+		return
+	}
+
+	delivered := 0
+	if len(sendResult.Recipients) > 0 {
+		for _, recipient := range sendResult.Recipients {
+			if recipient.Success {
+				delivered++
+			}
 		}
-		
-		return s.createFailedResult(channelID, sendResult.Message, errorCode, errorDetails)
+	} else if sendResult.Success {
+		delivered = 1
 	}
 
-	channelLogger.Info("Message sent successfully",
-		zap.String("result_message", sendResult.Message),
-		zap.Any("details", sendResult.Details))
+	result.SetCost(s.pricing.Cost(ch, delivered))
+}
 
-	// Mark channel as used
-	ch.MarkAsUsed()
-	if err := s.channelRepo.Update(ctx, ch); err != nil {
-		channelLogger.Warn("Failed to update channel last used time", zap.Error(err))
-		// This is not a critical error, so we don't fail the operation
+// sendWithRetry sends a message, retrying failures classified as retryable
+// up to the retry attempts of the channel. Permanent failures such as an
+// invalid recipient or rejected credentials are not retried, and neither is a
+// send that already reached some recipients.
+func (s *EnhancedMessageSender) sendWithRetry(
+	ctx context.Context,
+	ch *channel.Channel,
+	sendRequest *SendRequest,
+	channelLogger *logger.Logger,
+) *SendResult {
+	retryAttempts, retryDelay := 0, 0
+	if settings := ch.CommonSettings(); settings != nil {
+		retryAttempts, retryDelay = settings.RetryAttempts, settings.RetryDelay
 	}
 
-	// Create success result
-	result, err := message.NewSuccessfulMessageResult(channelID, sendResult.Message)
-	if err != nil {
-		channelLogger.Error("Failed to create success result", zap.Error(err))
-		return s.createFailedResult(channelID, "Failed to create result", "RESULT_ERROR", err.Error())
+	sendResult := s.notificationService.SendSingleNotification(ctx, sendRequest)
+	for attempt := 1; attempt <= retryAttempts && shouldRetry(sendResult); attempt++ {
+		channelLogger.Warn("Retrying message send",
+			zap.Int("attempt", attempt),
+			zap.Int("retry_attempts", retryAttempts),
+			zap.String("error_category", string(sendResult.ErrorCategory)),
+			zap.Error(sendResult.Error))
+
+		select {
+		case <-ctx.Done():
+			return sendResult
+		case <-time.After(time.Duration(retryDelay) * time.Millisecond):
+		}
+
+		sendResult = s.notificationService.SendSingleNotification(ctx, sendRequest)
 	}
 
-	return result
+	return sendResult
+}
+
+// shouldRetry checks if a failed send may succeed when sent again
+func shouldRetry(sendResult *SendResult) bool {
+	if sendResult.Success || !sendResult.ErrorCategory.IsRetryable() {
+		return false
+	}
+	// Sending again would duplicate the message for the recipients that got it
+	for _, recipient := range sendResult.Recipients {
+		if recipient.Success {
+			return false
+		}
+	}
+	return true
+}
+
+// toRecipientResults converts the per recipient outcomes of a send
+func toRecipientResults(sendResults []*RecipientSendResult) []*message.RecipientResult {
+	recipients := make([]*message.RecipientResult, 0, len(sendResults))
+	for _, sendResult := range sendResults {
+		recipient := &message.RecipientResult{
+			Target:            sendResult.Target,
+			Status:            message.MessageResultStatusSuccess,
+			ProviderMessageID: sendResult.ProviderMessageID,
+			Details:           sendResult.Details,
+		}
+		if sendResult.Success {
+			sentAt := sendResult.SentAt
+			recipient.SentAt = &sentAt
+		} else {
+			recipient.Status = message.MessageResultStatusFailed
+			details := "Failed to send message"
+			if sendResult.Error != nil {
+				details = sendResult.Error.Error()
+			}
+			recipient.Error = message.NewMessageError("SEND_ERROR", details)
+			recipient.Error.Category = sendResult.ErrorCategory
+		}
+		if sendResult.AcknowledgedAt > 0 {
+			acknowledgedAt := sendResult.AcknowledgedAt
+			recipient.Acknowledgment = sendResult.Acknowledgment
+			recipient.AcknowledgedAt = &acknowledgedAt
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients
 }
 
 // prepareRenderRequestEnhanced prepares render request with enhanced override handling
@@ -263,14 +1005,16 @@ func (s *EnhancedMessageSender) prepareRenderRequestEnhanced(
 	variables *message.Variables,
 	channelOverrides *message.ChannelOverrides,
 ) *RenderRequest {
+	// Channel variable defaults apply where the message sets no value
 	request := &RenderRequest{
-		Variables: variables,
+		Variables: message.NewVariables(ch.VariableDefaults().MergeUnder(variables.ToMap())),
 	}
 
 	// Set default subject and content
 	if tmpl != nil {
 		request.Subject = tmpl.Subject()
 		request.Content = tmpl.Content()
+		request.Attachments = tmpl.Attachments()
 	} else {
 		// Use empty subject and content if no template
 		defaultSubject, _ := template.NewSubject("")
@@ -295,18 +1039,24 @@ func (s *EnhancedMessageSender) prepareRenderRequestEnhanced(
 	return request
 }
 
-// validateVariables validates template variables
-func (s *EnhancedMessageSender) validateVariables(tmpl *template.Template, variables *message.Variables) error {
-	missingVariables := tmpl.ValidateVariables(variables.ToMap())
-	if len(missingVariables) > 0 {
-		return fmt.Errorf("missing required variables: %v", missingVariables)
-	}
-	return nil
-}
-
 // createFailedResult creates a failed message result
 func (s *EnhancedMessageSender) createFailedResult(channelID *channel.ChannelID, msg, code, details string) *message.MessageResult {
 	msgError := message.NewMessageError(code, details)
 	result, _ := message.NewFailedMessageResult(channelID, msg, msgError)
 	return result
+}
+
+// createSuppressedResult creates the result of a channel whose recipients all
+// suppressed the message. Nothing is sent, so nothing is retried or sent to a
+// fallback channel.
+func (s *EnhancedMessageSender) createSuppressedResult(channelID *channel.ChannelID, suppressed []*message.RecipientResult) *message.MessageResult {
+	result, _ := message.NewSuccessfulMessageResult(channelID, "Message suppressed by the preferences of every recipient")
+	result.SetRecipients(suppressed)
+	return result
+}
+
+// createHeldResult creates a result parked until the channel leaves maintenance
+func (s *EnhancedMessageSender) createHeldResult(channelID *channel.ChannelID) *message.MessageResult {
+	result, _ := message.NewHeldMessageResult(channelID, "Message held while channel is in maintenance")
+	return result
 }
\ No newline at end of file
@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"notification/internal/domain/message"
+	"notification/internal/sendbench"
+)
+
+// benchmarkConfigs are the pipelines the send benchmarks run against: the
+// bare sender, a sender waiting on the store and the provider like it does in
+// production, and one whose sends outnumber the store connections
+var benchmarkConfigs = []struct {
+	name   string
+	config sendbench.Config
+}{
+	{"instant", sendbench.Config{}},
+	{"3-channels", sendbench.Config{Channels: 3, Recipients: 5}},
+	{"latency", sendbench.Config{PoolSize: 10, QueryLatency: 200 * time.Microsecond, ProviderLatency: 2 * time.Millisecond}},
+	{"contended", sendbench.Config{PoolSize: 2, QueryLatency: 200 * time.Microsecond, ProviderLatency: 2 * time.Millisecond}},
+}
+
+func BenchmarkEnhancedMessageSender_SendMessage(b *testing.B) {
+	for _, bc := range benchmarkConfigs {
+		b.Run(bc.name, func(b *testing.B) {
+			p, err := sendbench.NewPipeline(bc.config)
+			if err != nil {
+				b.Fatal(err)
+			}
+			channelIDs := p.ChannelIDs()
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				msg, err := p.Sender.SendMessage(ctx, channelIDs, message.NewVariables(sendbench.Variables()),
+					message.NewChannelOverrides(nil), "", "", false)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if msg.Status() != message.MessageStatusSuccess {
+					b.Fatalf("message %s", msg.Status())
+				}
+				p.Latencies.Record(time.Since(start))
+			}
+			b.StopTimer()
+			p.ReportMetrics(b)
+		})
+	}
+}
+
+// BenchmarkEnhancedMessageSender_SendMessageParallel sends from 8 goroutines
+// per GOMAXPROCS, so that the store connections are contended
+func BenchmarkEnhancedMessageSender_SendMessageParallel(b *testing.B) {
+	for _, bc := range benchmarkConfigs {
+		b.Run(bc.name, func(b *testing.B) {
+			p, err := sendbench.NewPipeline(bc.config)
+			if err != nil {
+				b.Fatal(err)
+			}
+			channelIDs := p.ChannelIDs()
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.SetParallelism(8)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					start := time.Now()
+					_, err := p.Sender.SendMessage(ctx, channelIDs, message.NewVariables(sendbench.Variables()),
+						message.NewChannelOverrides(nil), "", "", false)
+					if err != nil {
+						b.Error(err)
+						return
+					}
+					p.Latencies.Record(time.Since(start))
+				}
+			})
+			b.StopTimer()
+			p.ReportMetrics(b)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/domain/message"
+	"notification/internal/domain/preference"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/internal/sendbench"
+	"notification/pkg/lock"
+)
+
+type recordingProgress struct {
+	mu        sync.Mutex
+	completed []int
+	delivered chan message.MessageStatus
+}
+
+func (r *recordingProgress) ChannelDelivered(ctx context.Context, msg *message.Message, result *message.MessageResult, completed int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.completed = append(r.completed, completed)
+}
+
+func (r *recordingProgress) MessageDelivered(ctx context.Context, msg *message.Message) {
+	r.delivered <- msg.Status()
+}
+
+func TestEnhancedMessageSender_ReportsProgressOfAsyncSends(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{Channels: 3, Recipients: 2})
+	require.NoError(t, err)
+	progress := &recordingProgress{delivered: make(chan message.MessageStatus, 1)}
+	p.Sender.SetProgressNotifier(progress)
+
+	request := p.Request()
+	request.Async = true
+	response, err := p.SendUseCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.ID)
+	assert.Equal(t, message.MessageStatusPending, response.Status, "the message is answered before it is delivered")
+
+	select {
+	case status := <-progress.delivered:
+		assert.Equal(t, message.MessageStatusSuccess, status)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the message was not delivered in the background")
+	}
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+	assert.Equal(t, []int{1, 2, 3}, progress.completed)
+}
+
+func TestSendMessageUseCase_AsyncSendsKeepTheirIntakeTurn(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{ProviderLatency: 100 * time.Millisecond})
+	require.NoError(t, err)
+	intake := services.NewSendIntake(1, 0, time.Second)
+	p.SendUseCase.SetIntake(intake)
+
+	request := p.Request()
+	request.Async = true
+	_, err = p.SendUseCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	running, _ := intake.Load()
+	assert.Equal(t, 1, running, "the background delivery keeps the turn")
+
+	_, err = p.SendUseCase.Execute(context.Background(), request)
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "RETRY_LATER", domainErr.Code())
+
+	require.NoError(t, p.SendUseCase.Drain(context.Background()))
+	running, _ = intake.Load()
+	assert.Zero(t, running)
+	calls, _ := p.Provider.Calls()
+	assert.EqualValues(t, 1, calls)
+}
+
+// preferenceStore is an in-memory preference repository
+type preferenceStore map[string]*preference.UserPreference
+
+func (s preferenceStore) Save(ctx context.Context, p *preference.UserPreference) error {
+	s[p.UserID] = p
+	return nil
+}
+
+func (s preferenceStore) FindByUserID(ctx context.Context, userID string) (*preference.UserPreference, error) {
+	return s[userID], nil
+}
+
+func (s preferenceStore) FindByUserIDs(ctx context.Context, userIDs []string) (map[string]*preference.UserPreference, error) {
+	found := make(map[string]*preference.UserPreference)
+	for _, userID := range userIDs {
+		if p, ok := s[userID]; ok {
+			found[userID] = p
+		}
+	}
+	return found, nil
+}
+
+func (s preferenceStore) Delete(ctx context.Context, userID string) error {
+	delete(s, userID)
+	return nil
+}
+
+func TestEnhancedMessageSender_SuppressesRecipientsByPreferences(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{Recipients: 2})
+	require.NoError(t, err)
+	optOut, err := preference.NewUserPreference("User-1@example.com", nil, nil, []string{"marketing"})
+	require.NoError(t, err)
+	preferences := preferenceStore{optOut.UserID: optOut}
+	p.Sender.SetPreferences(services.NewPreferenceFilter(preferences))
+
+	request := p.Request()
+	request.Variables[services.NotificationCategoryVariable] = "Marketing"
+	response, err := p.SendUseCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Results, 1)
+	recipients := response.Results[0].Recipients
+	require.Len(t, recipients, 2)
+	assert.Equal(t, "user-0@example.com", recipients[0].Target)
+	assert.Equal(t, message.MessageResultStatusSuccess, recipients[0].Status)
+	assert.Equal(t, "user-1@example.com", recipients[1].Target)
+	assert.Equal(t, message.MessageResultStatusSuppressed, recipients[1].Status)
+	assert.Equal(t, string(preference.SuppressionReasonCategoryOptOut), recipients[1].SuppressionReason)
+
+	// Nothing is sent when every recipient suppresses the message
+	everyone, err := preference.NewUserPreference("user-0@example.com", nil, nil, []string{"marketing"})
+	require.NoError(t, err)
+	preferences[everyone.UserID] = everyone
+	p.ResetStats()
+	response, err = p.SendUseCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, message.MessageStatusSuccess, response.Status)
+	assert.Len(t, response.Results[0].Recipients, 2)
+	calls, _ := p.Provider.Calls()
+	assert.Zero(t, calls)
+}
+
+func TestEnhancedMessageSender_SendsTransactionalMessagesDespiteOptOuts(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{Recipients: 2})
+	require.NoError(t, err)
+	quietHours, err := preference.NewQuietHours("00:00", "23:59", "UTC")
+	require.NoError(t, err)
+	optOut, err := preference.NewUserPreference("user-1@example.com", nil, quietHours, []string{"billing"})
+	require.NoError(t, err)
+	p.Sender.SetPreferences(services.NewPreferenceFilter(preferenceStore{optOut.UserID: optOut}))
+
+	request := p.Request()
+	request.Category = "billing"
+	request.MessageClass = "transactional"
+	response, err := p.SendUseCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, message.MessageClassTransactional, response.MessageClass)
+	require.Len(t, response.Results, 1)
+	for _, recipient := range response.Results[0].Recipients {
+		assert.Equal(t, message.MessageResultStatusSuccess, recipient.Status, recipient.Target)
+	}
+
+	request.MessageClass = "marketing"
+	response, err = p.SendUseCase.Execute(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, message.MessageResultStatusSuppressed, response.Results[0].Recipients[1].Status)
+}
+
+func TestSendMessageUseCase_SimulatesRoutingWithoutSending(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{Recipients: 2})
+	require.NoError(t, err)
+	optOut, err := preference.NewUserPreference("user-1@example.com", nil, nil, []string{"marketing"})
+	require.NoError(t, err)
+	p.Sender.SetPreferences(services.NewPreferenceFilter(preferenceStore{optOut.UserID: optOut}))
+
+	request := p.Request()
+	request.Variables[services.NotificationCategoryVariable] = "marketing"
+	simulation, err := p.SendUseCase.Simulate(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, simulation.Channels, 1)
+
+	simulated := simulation.Channels[0]
+	assert.Equal(t, []string{"channelId"}, simulated.MatchedBy)
+	assert.Equal(t, dtos.SimulationOutcomeSend, simulated.Outcome)
+	assert.Equal(t, "benchmark", simulated.TemplateName)
+	assert.Equal(t, "Build 1234 success", simulated.Subject)
+	assert.Equal(t, []string{"user-0@example.com"}, simulated.Recipients)
+	require.Len(t, simulated.Suppressed, 1)
+	assert.Equal(t, string(preference.SuppressionReasonCategoryOptOut), simulated.Suppressed[0].SuppressionReason)
+
+	calls, _ := p.Provider.Calls()
+	assert.Zero(t, calls)
+}
+
+func TestSendMessageUseCase_BlocksProdSendsFromStaging(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{Environment: shared.EnvironmentStaging})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ch, err := p.Store.ChannelRepository().FindByID(ctx, p.ChannelIDs().ToSlice()[0])
+	require.NoError(t, err)
+	ch.SetEnvironment(shared.EnvironmentProd)
+	require.NoError(t, p.Store.ChannelRepository().Update(ctx, ch))
+
+	_, err = p.SendUseCase.Execute(ctx, p.Request())
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "PROD_SEND_BLOCKED", domainErr.Code())
+	calls, _ := p.Provider.Calls()
+	assert.Zero(t, calls)
+
+	request := p.Request()
+	request.AllowProd = true
+	response, err := p.SendUseCase.Execute(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, message.MessageStatusSuccess, response.Status)
+}
+
+func TestEnhancedMessageSender_DeliversToProdOnlyWhenTheMessageAllowsIt(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{Environment: shared.EnvironmentStaging})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ch, err := p.Store.ChannelRepository().FindByID(ctx, p.ChannelIDs().ToSlice()[0])
+	require.NoError(t, err)
+	ch.SetEnvironment(shared.EnvironmentProd)
+	require.NoError(t, p.Store.ChannelRepository().Update(ctx, ch))
+
+	// A message sent without the use case, as a scheduled or queued delivery is
+	variables := p.Request().Variables
+	msg, err := p.Sender.SendMessage(ctx, p.ChannelIDs(), message.NewVariables(variables), message.NewChannelOverrides(nil), "", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, message.MessageStatusFailed, msg.Status())
+	calls, _ := p.Provider.Calls()
+	assert.Zero(t, calls)
+
+	variables[message.AllowProdVariable] = true
+	msg, err = p.Sender.SendMessage(ctx, p.ChannelIDs(), message.NewVariables(variables), message.NewChannelOverrides(nil), "", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, message.MessageStatusSuccess, msg.Status())
+}
+
+func TestEnhancedMessageSender_DeliversLockedMessagesOnce(t *testing.T) {
+	p, err := sendbench.NewPipeline(sendbench.Config{})
+	require.NoError(t, err)
+	locker := lock.NewMemoryLocker()
+	p.Sender.SetLocker(locker, time.Minute)
+
+	ctx := context.Background()
+	response, err := p.SendUseCase.Execute(ctx, p.Request())
+	require.NoError(t, err)
+	assert.Equal(t, message.MessageStatusSuccess, response.Status)
+	callsAfterSend, _ := p.Provider.Calls()
+
+	messageID, err := message.NewMessageIDFromString(response.ID)
+	require.NoError(t, err)
+	msg, err := p.Store.MessageRepository().FindByID(ctx, messageID)
+	require.NoError(t, err)
+
+	// A message delivered meanwhile is not sent again
+	require.NoError(t, p.Sender.Deliver(ctx, msg))
+
+	// Nor is a message another sender holds the lock of
+	held, err := locker.TryAcquire(ctx, "message:"+response.ID, time.Minute)
+	require.NoError(t, err)
+	assert.ErrorIs(t, p.Sender.Deliver(ctx, msg), services.ErrDeliveryInProgress)
+	require.NoError(t, held.Release(ctx))
+
+	calls, _ := p.Provider.Calls()
+	assert.Equal(t, callsAfterSend, calls)
+}
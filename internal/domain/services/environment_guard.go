@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// EnvironmentGuard is the notification service that refuses the sends
+// through a prod channel from a deployment below prod, unless the request
+// allows prod sends. Every sender goes through it, so that the digests,
+// alerts, confirmations and mirrored sends are kept away from prod as well as
+// the messages.
+type EnvironmentGuard struct {
+	next        ExternalNotificationService
+	environment shared.Environment
+}
+
+// NewEnvironmentGuard creates a guard sending through next from a deployment
+// of the environment.
+func NewEnvironmentGuard(next ExternalNotificationService, environment shared.Environment) *EnvironmentGuard {
+	return &EnvironmentGuard{
+		next:        next,
+		environment: environment,
+	}
+}
+
+// SendSingleNotification sends the request unless its channel is kept away
+// from the deployment, which fails the send permanently.
+func (g *EnvironmentGuard) SendSingleNotification(ctx context.Context, request *SendRequest) *SendResult {
+	if request.Channel != nil {
+		err := CheckSendEnvironment(g.environment, request.AllowProd, "channel", request.Channel.ID().String(), request.Channel.Environment())
+		if err != nil {
+			return &SendResult{
+				Message:       err.Error(),
+				Error:         err,
+				ErrorCategory: message.ErrorCategoryPermanent,
+			}
+		}
+	}
+	return g.next.SendSingleNotification(ctx, request)
+}
+
+// ValidateChannel validates if a channel can be used for sending
+func (g *EnvironmentGuard) ValidateChannel(ch *channel.Channel) error {
+	return g.next.ValidateChannel(ch)
+}
+
+// CheckSendEnvironment refuses a prod resource to a deployment below prod,
+// unless prod sends are allowed.
+func CheckSendEnvironment(deployment shared.Environment, allowProd bool, kind, id string, environment shared.Environment) error {
+	if allowProd || deployment.AllowsSendTo(environment) {
+		return nil
+	}
+	return shared.NewForbiddenError("PROD_SEND_BLOCKED",
+		fmt.Sprintf("%s '%s' is a %s %s and this is a %s deployment; set allowProd to send through it", kind, id, environment, kind, deployment))
+}
+
+// CheckChannelEnvironment refuses a prod channel to a deployment below prod,
+// unless prod sends are allowed. The fallbacks of the channel are checked
+// too, as a failed delivery is sent through them.
+func CheckChannelEnvironment(ctx context.Context, channelRepo channel.ChannelRepository, deployment shared.Environment, allowProd bool, ch *channel.Channel) error {
+	if allowProd || deployment.AllowsSendTo(shared.EnvironmentProd) {
+		return nil
+	}
+
+	for hop := 0; ch != nil; hop++ {
+		if err := CheckSendEnvironment(deployment, allowProd, "channel", ch.ID().String(), ch.Environment()); err != nil {
+			return err
+		}
+		if ch.FallbackChannelID() == nil || hop == channel.MaxFallbackHops {
+			return nil
+		}
+
+		fallback, err := channelRepo.FindByID(ctx, ch.FallbackChannelID())
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to find fallback channel '%s': %w", ch.FallbackChannelID().String(), err)
+		}
+		ch = fallback
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// countingNotificationService counts the sends that reach it
+type countingNotificationService struct {
+	ExternalNotificationService
+	sends int
+}
+
+func (s *countingNotificationService) SendSingleNotification(ctx context.Context, request *SendRequest) *SendResult {
+	s.sends++
+	return &SendResult{Success: true}
+}
+
+// fallbackChannelRepository finds the channels of a fallback chain
+type fallbackChannelRepository struct {
+	channel.ChannelRepository
+	channels map[string]*channel.Channel
+}
+
+func (r *fallbackChannelRepository) FindByID(ctx context.Context, id *channel.ChannelID) (*channel.Channel, error) {
+	ch, ok := r.channels[id.String()]
+	if !ok {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")
+	}
+	return ch, nil
+}
+
+func newEnvironmentChannel(t *testing.T, environment shared.Environment) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+	name, err := channel.NewChannelName("ops")
+	require.NoError(t, err)
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeEmail, nil,
+		&shared.CommonSettings{Timeout: 10}, channel.NewChannelConfig(map[string]interface{}{}), nil, nil)
+	require.NoError(t, err)
+	ch.SetEnvironment(environment)
+	return ch
+}
+
+func TestEnvironmentGuard(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployment shared.Environment
+		channel    shared.Environment
+		allowProd  bool
+		wantSent   bool
+	}{
+		{"prod channel from staging", shared.EnvironmentStaging, shared.EnvironmentProd, false, false},
+		{"prod channel from dev", shared.EnvironmentDev, shared.EnvironmentProd, false, false},
+		{"prod channel allowed from staging", shared.EnvironmentStaging, shared.EnvironmentProd, true, true},
+		{"prod channel from prod", shared.EnvironmentProd, shared.EnvironmentProd, false, true},
+		{"prod channel from an unscoped deployment", "", shared.EnvironmentProd, false, true},
+		{"staging channel from dev", shared.EnvironmentDev, shared.EnvironmentStaging, false, true},
+		{"unscoped channel from staging", shared.EnvironmentStaging, "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &countingNotificationService{}
+			guard := NewEnvironmentGuard(next, tt.deployment)
+
+			result := guard.SendSingleNotification(context.Background(), &SendRequest{
+				Channel:   newEnvironmentChannel(t, tt.channel),
+				AllowProd: tt.allowProd,
+			})
+			assert.Equal(t, tt.wantSent, result.Success)
+			if tt.wantSent {
+				assert.Equal(t, 1, next.sends)
+				return
+			}
+			assert.Zero(t, next.sends)
+			assert.Equal(t, message.ErrorCategoryPermanent, result.ErrorCategory)
+			assert.Equal(t, shared.ErrorKindForbidden, shared.ErrorKindOf(result.Error))
+		})
+	}
+}
+
+func TestCheckChannelEnvironment_ChecksTheFallbacks(t *testing.T) {
+	first := newEnvironmentChannel(t, shared.EnvironmentStaging)
+	prod := newEnvironmentChannel(t, shared.EnvironmentProd)
+	require.NoError(t, first.SetFallbackChannel(prod.ID()))
+	repo := &fallbackChannelRepository{channels: map[string]*channel.Channel{prod.ID().String(): prod}}
+
+	err := CheckChannelEnvironment(context.Background(), repo, shared.EnvironmentStaging, false, first)
+	assert.Equal(t, shared.ErrorKindForbidden, shared.ErrorKindOf(err))
+	assert.Contains(t, err.Error(), prod.ID().String())
+
+	assert.NoError(t, CheckChannelEnvironment(context.Background(), repo, shared.EnvironmentStaging, true, first))
+	assert.NoError(t, CheckChannelEnvironment(context.Background(), repo, shared.EnvironmentProd, false, first))
+
+	// A fallback that no longer exists is not sent through
+	delete(repo.channels, prod.ID().String())
+	assert.NoError(t, CheckChannelEnvironment(context.Background(), repo, shared.EnvironmentStaging, false, first))
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/pkg/logger"
+)
+
+// FailureDigestPolicy holds where the failure digests go and when a period
+// is worth one.
+type FailureDigestPolicy struct {
+	// ChannelID is the operations channel the digests are sent through
+	ChannelID *channel.ChannelID
+	// MinFailed and MinHeld are the numbers of failed and held sends from
+	// which a digest is sent; a zero threshold never sends one on its own
+	MinFailed int
+	MinHeld   int
+}
+
+// FailureDigest is the domain service that summarizes the failed and held
+// sends of a period and reports them to the operators through an operations
+// channel, so that failures do not go unnoticed.
+type FailureDigest struct {
+	messageRepo         message.MessageRepository
+	channelRepo         channel.ChannelRepository
+	notificationService ExternalNotificationService
+	policy              FailureDigestPolicy
+	logger              *logger.Logger
+}
+
+// NewFailureDigest creates a failure digest.
+func NewFailureDigest(
+	messageRepo message.MessageRepository,
+	channelRepo channel.ChannelRepository,
+	notificationService ExternalNotificationService,
+	policy FailureDigestPolicy,
+	logger *logger.Logger,
+) *FailureDigest {
+	return &FailureDigest{
+		messageRepo:         messageRepo,
+		channelRepo:         channelRepo,
+		notificationService: notificationService,
+		policy:              policy,
+		logger:              logger,
+	}
+}
+
+// channelFailures is the summary of the failed and held sends to a channel
+type channelFailures struct {
+	channelID string
+	failed    int
+	held      int
+	// errorCodes counts the failed sends per error code
+	errorCodes map[string]int
+}
+
+// Send summarizes the failed and held sends of the messages created from one
+// time, inclusive, to the other, exclusive, and sends the digest through the
+// operations channel when they reach a threshold. It reports whether a
+// digest was sent.
+func (d *FailureDigest) Send(ctx context.Context, from, to time.Time) (bool, error) {
+	counts, err := d.messageRepo.CountFailures(ctx, from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		return false, err
+	}
+
+	summaries, failed, held := summarizeFailures(counts)
+	if !d.exceeds(failed, held) {
+		return false, nil
+	}
+
+	operations, err := d.channelRepo.FindByID(ctx, d.policy.ChannelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get operations channel: %w", err)
+	}
+	if err := operations.CanSendMessage(); err != nil {
+		return false, fmt.Errorf("operations channel cannot send message: %w", err)
+	}
+
+	result := d.notificationService.SendSingleNotification(ctx, &SendRequest{
+		Channel: operations,
+		Content: d.content(ctx, summaries, failed, held, from, to),
+		Variables: map[string]interface{}{
+			"digest_failed": failed,
+			"digest_held":   held,
+			"digest_from":   from.UTC().Format(time.RFC3339),
+			"digest_to":     to.UTC().Format(time.RFC3339),
+		},
+	})
+	if !result.Success {
+		return false, fmt.Errorf("failed to send failure digest: %w", result.Error)
+	}
+
+	d.logger.WithContext(ctx).Info("Failure digest sent",
+		zap.String("operations_channel_id", operations.ID().String()),
+		zap.Int("failed", failed),
+		zap.Int("held", held))
+	return true, nil
+}
+
+// exceeds checks if the failed or held sends reach their threshold
+func (d *FailureDigest) exceeds(failed, held int) bool {
+	return (d.policy.MinFailed > 0 && failed >= d.policy.MinFailed) ||
+		(d.policy.MinHeld > 0 && held >= d.policy.MinHeld)
+}
+
+// content builds the digest, listing the channels with the most failed sends first
+func (d *FailureDigest) content(ctx context.Context, summaries []*channelFailures, failed, held int, from, to time.Time) *RenderedContent {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Period: %s to %s\nFailed sends: %d\nHeld sends: %d\n",
+		from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339), failed, held)
+
+	for _, summary := range summaries {
+		fmt.Fprintf(&body, "\n%s: %d failed, %d held", d.channelName(ctx, summary.channelID), summary.failed, summary.held)
+		codes := make([]string, 0, len(summary.errorCodes))
+		for code := range summary.errorCodes {
+			codes = append(codes, code)
+		}
+		sort.Slice(codes, func(i, j int) bool {
+			if summary.errorCodes[codes[i]] != summary.errorCodes[codes[j]] {
+				return summary.errorCodes[codes[i]] > summary.errorCodes[codes[j]]
+			}
+			return codes[i] < codes[j]
+		})
+		for _, code := range codes {
+			fmt.Fprintf(&body, "\n  %s: %d", code, summary.errorCodes[code])
+		}
+	}
+
+	return &RenderedContent{
+		Subject: fmt.Sprintf("[Failure digest] %d failed and %d held sends", failed, held),
+		Content: body.String(),
+	}
+}
+
+// channelName names a channel in the digest, by its ID when it is gone
+func (d *FailureDigest) channelName(ctx context.Context, channelID string) string {
+	id, err := channel.NewChannelIDFromString(channelID)
+	if err != nil {
+		return channelID
+	}
+	ch, err := d.channelRepo.FindByID(ctx, id)
+	if err != nil {
+		return channelID
+	}
+	return fmt.Sprintf("%s (%s)", ch.Name().String(), channelID)
+}
+
+// summarizeFailures groups the failure counts per channel, the channels with
+// the most failed sends first, and totals them
+func summarizeFailures(counts []*message.FailureCount) ([]*channelFailures, int, int) {
+	byChannel := make(map[string]*channelFailures)
+	var summaries []*channelFailures
+	failed, held := 0, 0
+	for _, count := range counts {
+		summary, ok := byChannel[count.ChannelID]
+		if !ok {
+			summary = &channelFailures{channelID: count.ChannelID, errorCodes: make(map[string]int)}
+			byChannel[count.ChannelID] = summary
+			summaries = append(summaries, summary)
+		}
+		switch count.Status {
+		case message.MessageResultStatusFailed:
+			summary.failed += count.Count
+			failed += count.Count
+			code := count.ErrorCode
+			if code == "" {
+				code = "UNKNOWN"
+			}
+			summary.errorCodes[code] += count.Count
+		case message.MessageResultStatusHeld:
+			summary.held += count.Count
+			held += count.Count
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].failed != summaries[j].failed {
+			return summaries[i].failed > summaries[j].failed
+		}
+		return summaries[i].held > summaries[j].held
+	})
+	return summaries, failed, held
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/message"
+)
+
+func TestSummarizeFailures(t *testing.T) {
+	summaries, failed, held := summarizeFailures([]*message.FailureCount{
+		{ChannelID: "slack", Status: message.MessageResultStatusHeld, Count: 4},
+		{ChannelID: "email", Status: message.MessageResultStatusFailed, ErrorCode: "SEND_FAILED", Count: 3},
+		{ChannelID: "slack", Status: message.MessageResultStatusFailed, ErrorCode: "TIMEOUT", Count: 1},
+		{ChannelID: "email", Status: message.MessageResultStatusFailed, Count: 2},
+	})
+
+	assert.Equal(t, 6, failed)
+	assert.Equal(t, 4, held)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "email", summaries[0].channelID)
+	assert.Equal(t, map[string]int{"SEND_FAILED": 3, "UNKNOWN": 2}, summaries[0].errorCodes)
+	assert.Equal(t, 1, summaries[1].failed)
+	assert.Equal(t, 4, summaries[1].held)
+}
+
+func TestFailureDigestExceeds(t *testing.T) {
+	digest := &FailureDigest{policy: FailureDigestPolicy{MinFailed: 5}}
+	assert.False(t, digest.exceeds(4, 100))
+	assert.True(t, digest.exceeds(5, 0))
+
+	digest.policy = FailureDigestPolicy{MinFailed: 0, MinHeld: 2}
+	assert.False(t, digest.exceeds(100, 1))
+	assert.True(t, digest.exceeds(0, 2))
+}
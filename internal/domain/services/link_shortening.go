@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// LinkShortener shortens a link sent in a message through a channel.
+type LinkShortener interface {
+	Shorten(ctx context.Context, targetURL, messageID, channelID string) (string, error)
+}
+
+// InternalLinkShortener shortens links to the redirect endpoint of the
+// service, which counts the clicks against the message before redirecting.
+type InternalLinkShortener struct {
+	repo message.ShortLinkRepository
+	// baseURL is the public address of the service the short links point to
+	baseURL string
+}
+
+// NewInternalLinkShortener creates a shortener of links to baseURL/l/<code>.
+func NewInternalLinkShortener(repo message.ShortLinkRepository, baseURL string) *InternalLinkShortener {
+	return &InternalLinkShortener{
+		repo:    repo,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Shorten implements LinkShortener.
+func (s *InternalLinkShortener) Shorten(ctx context.Context, targetURL, messageID, channelID string) (string, error) {
+	link, err := message.NewShortLink(targetURL, messageID, channelID)
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.Save(ctx, link); err != nil {
+		return "", fmt.Errorf("failed to save short link: %w", err)
+	}
+	return s.baseURL + "/l/" + link.Code, nil
+}
+
+// linkPattern matches the http and https links of a text, up to the
+// punctuation ending a sentence
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"']+[^\s<>"'.,;:!?)\]]`)
+
+// LinkShortening is the domain service replacing the links of the content
+// sent through SMS channels with short links, which take fewer of the
+// characters an SMS is billed by. A link that cannot be shortened is sent
+// as is.
+type LinkShortening struct {
+	shortener LinkShortener
+	// prefix is the start of the links already shortened, left alone
+	prefix string
+	logger *logger.Logger
+}
+
+// NewLinkShortening creates a link shortening whose short links start with
+// prefix, so that they are not shortened again.
+func NewLinkShortening(shortener LinkShortener, prefix string, logger *logger.Logger) *LinkShortening {
+	return &LinkShortening{
+		shortener: shortener,
+		prefix:    prefix,
+		logger:    logger,
+	}
+}
+
+// Shorten shortens the links of the content of a message sent through a
+// channel, in place. Channels other than SMS are left alone.
+func (l *LinkShortening) Shorten(ctx context.Context, channelType shared.ChannelType, content *RenderedContent, messageID, channelID string) {
+	if content == nil || !channelType.Equals(shared.ChannelTypeSMS) {
+		return
+	}
+
+	shortened := make(map[string]string)
+	content.Content = linkPattern.ReplaceAllStringFunc(content.Content, func(link string) string {
+		if l.prefix != "" && strings.HasPrefix(link, l.prefix) {
+			return link
+		}
+		if short, ok := shortened[link]; ok {
+			return short
+		}
+
+		short, err := l.shortener.Shorten(ctx, link, messageID, channelID)
+		if err != nil {
+			l.logger.WithContext(ctx).Warn("Failed to shorten link, sending it as is",
+				zap.String("message_id", messageID),
+				zap.String("channel_id", channelID),
+				zap.Error(err))
+			short = link
+		}
+		shortened[link] = short
+		return short
+	})
+}
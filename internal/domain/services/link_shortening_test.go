@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/shared"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+// countingShortener shortens links to numbered short links, failing for the failing link
+type countingShortener struct {
+	calls   int
+	failing string
+}
+
+func (s *countingShortener) Shorten(ctx context.Context, targetURL, messageID, channelID string) (string, error) {
+	if targetURL == s.failing {
+		return "", errors.New("provider unavailable")
+	}
+	s.calls++
+	return fmt.Sprintf("https://s.example.com/l/%d", s.calls), nil
+}
+
+func TestLinkShortening(t *testing.T) {
+	log, err := logger.NewLogger(&config.LoggerConfig{Level: "error", Format: "json", OutputPath: os.DevNull})
+	require.NoError(t, err)
+
+	shortener := &countingShortener{failing: "https://down.example.com/x"}
+	shortening := NewLinkShortening(shortener, "https://s.example.com/l/", log)
+
+	content := &RenderedContent{Content: "Track https://shop.example.com/orders/1042?ref=sms. Again: https://shop.example.com/orders/1042?ref=sms, " +
+		"help at https://down.example.com/x or https://s.example.com/l/abc"}
+	shortening.Shorten(context.Background(), shared.ChannelTypeSMS, content, "msg-1", "ch-1")
+	assert.Equal(t, "Track https://s.example.com/l/1. Again: https://s.example.com/l/1, "+
+		"help at https://down.example.com/x or https://s.example.com/l/abc", content.Content)
+	assert.Equal(t, 1, shortener.calls)
+
+	slack := &RenderedContent{Content: "See https://shop.example.com/orders/1042"}
+	shortening.Shorten(context.Background(), shared.ChannelTypeSlack, slack, "msg-1", "ch-2")
+	assert.Equal(t, "See https://shop.example.com/orders/1042", slack.Content)
+}
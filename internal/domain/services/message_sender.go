@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
 
 	"notification/internal/domain/channel"
 	"notification/internal/domain/message"
+	"notification/internal/domain/quota"
 	"notification/internal/domain/template"
 )
 
@@ -41,7 +47,7 @@ func (ms *MessageSender) SendMessage(
 	channelOverrides *message.ChannelOverrides,
 ) (*message.Message, error) {
 	// Create a message entity
-	msg, err := message.NewMessage(channelIDs, variables, channelOverrides)
+	msg, err := message.NewMessage(channelIDs, variables, channelOverrides, "", quota.DefaultTenantID, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
@@ -81,6 +87,12 @@ func (ms *MessageSender) processSingleChannel(
 		return ms.createFailedResult(channelID, "Failed to retrieve channel", "CHANNEL_NOT_FOUND", err.Error())
 	}
 
+	// Park the message while the channel is in maintenance
+	if ch.InMaintenance() && !ch.IsDeleted() {
+		result, _ := message.NewHeldMessageResult(channelID, "Message held while channel is in maintenance")
+		return result
+	}
+
 	// Check if the channel can send messages
 	if err := ch.CanSendMessage(); err != nil {
 		return ms.createFailedResult(channelID, "Channel cannot send message", "CHANNEL_UNAVAILABLE", err.Error())
@@ -101,11 +113,6 @@ func (ms *MessageSender) processSingleChannel(
 	// Prepare the rendering content
 	renderRequest := ms.prepareRenderRequest(ch, tmpl, variables, channelOverrides)
 
-	// Validate variables
-	if err := ms.validateVariables(tmpl, renderRequest.Variables); err != nil {
-		return ms.createFailedResult(channelID, "Variable validation failed", "MISSING_VARIABLES", err.Error())
-	}
-
 	// Render the template
 	renderedContent, err := ms.renderer.Render(ctx, renderRequest)
 	if err != nil {
@@ -138,10 +145,13 @@ func (ms *MessageSender) prepareRenderRequest(
 	variables *message.Variables,
 	channelOverrides *message.ChannelOverrides,
 ) *RenderRequest {
+	// Channel variable defaults apply where the message sets no value
 	request := &RenderRequest{
-		Subject:   tmpl.Subject(),
-		Content:   tmpl.Content(),
-		Variables: variables,
+		Subject:     tmpl.Subject(),
+		Content:     tmpl.Content(),
+		Variables:   message.NewVariables(ch.VariableDefaults().MergeUnder(variables.ToMap())),
+		Strict:      tmpl.IsStrict(),
+		Attachments: tmpl.Attachments(),
 	}
 
 	// Apply channel overrides
@@ -160,15 +170,6 @@ func (ms *MessageSender) prepareRenderRequest(
 	return request
 }
 
-// validateVariables validates variables.
-func (ms *MessageSender) validateVariables(tmpl *template.Template, variables *message.Variables) error {
-	missingVariables := tmpl.ValidateVariables(variables.ToMap())
-	if len(missingVariables) > 0 {
-		return fmt.Errorf("missing required variables: %v", missingVariables)
-	}
-	return nil
-}
-
 // createFailedResult creates a failed result.
 func (ms *MessageSender) createFailedResult(channelID *channel.ChannelID, msg, code, details string) *message.MessageResult {
 	msgError := message.NewMessageError(code, details)
@@ -186,12 +187,58 @@ type RenderRequest struct {
 	Subject   *template.Subject
 	Content   *template.TemplateContent
 	Variables *message.Variables
+	// Strict fails rendering when a variable has no value instead of rendering it empty
+	Strict bool
+	// Attachments are sent with the rendered content
+	Attachments *template.Attachments
+	// Headers are the extra email headers, their values rendered like the subject
+	Headers map[string]string
+}
+
+// MissingVariablesError is returned by a strict render when variables have no value.
+type MissingVariablesError struct {
+	Variables []string
+}
+
+// Error implements error.
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("missing variables: %s", strings.Join(e.Variables, ", "))
 }
 
 // RenderedContent is the rendering result.
 type RenderedContent struct {
-	Subject string
-	Content string
+	Subject     string
+	Content     string
+	Attachments []*RenderedAttachment
+	// Headers are the extra headers of an email by canonical name
+	Headers map[string]string
+	// CalendarInvite is sent with emails describing an event
+	CalendarInvite *CalendarInvite
+	// WhatsAppTemplate is the pre-approved template a WhatsApp message is sent
+	// as, nil for a session message carrying the content
+	WhatsAppTemplate *WhatsAppTemplate
+	// PagerDutyEvent is the event a PagerDuty message sends
+	PagerDutyEvent *PagerDutyEvent
+	// OpsgenieAlert is the alert an Opsgenie message creates or closes
+	OpsgenieAlert *OpsgenieAlert
+	// MQTTPublications are the topics an MQTT message is published to
+	MQTTPublications []*MQTTPublication
+	// StatuspageIncident is the incident a Statuspage message opens or updates
+	StatuspageIncident *StatuspageIncident
+	// AcknowledgmentLinks are the links acknowledging the message, by the
+	// target of each recipient
+	AcknowledgmentLinks map[string]string
+}
+
+// RenderedAttachment is an attachment of a rendered content. ContentID is
+// set for inline attachments, unique to the rendering, and the cid:
+// references of the content point to it.
+type RenderedAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	Inline      bool
+	ContentID   string
 }
 
 // DefaultTemplateRenderer is the default template renderer.
@@ -209,34 +256,95 @@ func (r *DefaultTemplateRenderer) Render(ctx context.Context, request *RenderReq
 	}
 
 	variableMap := request.Variables.ToMap()
+	missing := make(map[string]bool)
+
+	// Render the subject and the content
+	renderedSubject := r.renderTemplate(request.Subject.String(), variableMap, missing)
+	renderedContent := r.renderTemplate(request.Content.String(), variableMap, missing)
+	var renderedHeaders map[string]string
+	if len(request.Headers) > 0 {
+		renderedHeaders = make(map[string]string, len(request.Headers))
+		for name, value := range request.Headers {
+			renderedHeaders[name] = r.renderTemplate(value, variableMap, missing)
+		}
+	}
 
-	// Render the subject
-	renderedSubject, err := r.renderTemplate(request.Subject.String(), variableMap)
-	if err != nil {
-		return nil, fmt.Errorf("failed to render subject: %w", err)
+	if request.Strict && len(missing) > 0 {
+		keys := make([]string, 0, len(missing))
+		for key := range missing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return nil, &MissingVariablesError{Variables: keys}
 	}
 
-	// Render the content
-	renderedContent, err := r.renderTemplate(request.Content.String(), variableMap)
-	if err != nil {
-		return nil, fmt.Errorf("failed to render content: %w", err)
+	attachments, contentIDs := r.renderAttachments(request.Attachments)
+	if len(contentIDs) > 0 {
+		renderedContent = rewriteContentIDs(renderedContent, contentIDs)
 	}
 
 	return &RenderedContent{
-		Subject: renderedSubject,
-		Content: renderedContent,
+		Subject:     renderedSubject,
+		Content:     renderedContent,
+		Attachments: attachments,
+		Headers:     renderedHeaders,
 	}, nil
 }
 
-// renderTemplate renders a single template.
-func (r *DefaultTemplateRenderer) renderTemplate(template string, variables map[string]interface{}) (string, error) {
-	// Simple variable replacement implementation
-	// In a real project, you can use a more powerful template engine such as text/template or html/template
-	result := template
-	for key, value := range variables {
-		placeholder := fmt.Sprintf("{%s}", key)
-		replacement := fmt.Sprintf("%v", value)
-		result = fmt.Sprintf("%s", fmt.Sprintf(result, placeholder, replacement))
+// renderAttachments gives each inline attachment a Content-ID of its own, as
+// mail clients may mix up the images of different emails using the same one.
+// It returns the attachments and the Content-IDs by template content ID.
+func (r *DefaultTemplateRenderer) renderAttachments(attachments *template.Attachments) ([]*RenderedAttachment, map[string]string) {
+	if attachments.IsEmpty() {
+		return nil, nil
+	}
+
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
+	rendered := make([]*RenderedAttachment, 0, attachments.Count())
+	contentIDs := make(map[string]string)
+	for _, attachment := range attachments.ToSlice() {
+		item := &RenderedAttachment{
+			Filename:    attachment.Filename(),
+			ContentType: attachment.ContentType(),
+			Content:     attachment.Content(),
+			Inline:      attachment.IsInline(),
+		}
+		if attachment.IsInline() {
+			item.ContentID = fmt.Sprintf("%s.%s@notification", attachment.ContentID(), suffix)
+			contentIDs[attachment.ContentID()] = item.ContentID
+		}
+		rendered = append(rendered, item)
 	}
-	return result, nil
-}
\ No newline at end of file
+	return rendered, contentIDs
+}
+
+// cidPattern matches a cid: reference to an inline attachment.
+var cidPattern = regexp.MustCompile(`(?i)cid:([A-Za-z0-9._-]+)`)
+
+// rewriteContentIDs points the cid: references of a content to the
+// Content-IDs of its inline attachments. Unknown references are kept.
+func rewriteContentIDs(content string, contentIDs map[string]string) string {
+	return cidPattern.ReplaceAllStringFunc(content, func(reference string) string {
+		if contentID, ok := contentIDs[reference[len("cid:"):]]; ok {
+			return "cid:" + contentID
+		}
+		return reference
+	})
+}
+
+// placeholderPattern matches a {variable} placeholder.
+var placeholderPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// renderTemplate replaces the placeholders of a single template. Variables
+// without a value render empty and are recorded in missing.
+func (r *DefaultTemplateRenderer) renderTemplate(tmpl string, variables map[string]interface{}, missing map[string]bool) string {
+	return placeholderPattern.ReplaceAllStringFunc(tmpl, func(placeholder string) string {
+		key := strings.TrimSpace(placeholder[1 : len(placeholder)-1])
+		value, exists := variables[key]
+		if !exists || value == nil {
+			missing[key] = true
+			return ""
+		}
+		return fmt.Sprintf("%v", value)
+	})
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/message"
+	"notification/internal/domain/template"
+)
+
+func TestDefaultTemplateRenderer_Render(t *testing.T) {
+	subject, err := template.NewSubject("Alert for {team}")
+	require.NoError(t, err)
+	content, err := template.NewTemplateContent("Hello { name }, {footer}{ticket}")
+	require.NoError(t, err)
+
+	request := &RenderRequest{
+		Subject:   subject,
+		Content:   content,
+		Variables: message.NewVariables(map[string]interface{}{"name": "World", "ticket": 42}),
+	}
+	renderer := NewDefaultTemplateRenderer()
+
+	rendered, err := renderer.Render(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "Alert for ", rendered.Subject)
+	assert.Equal(t, "Hello World, 42", rendered.Content)
+
+	request.Strict = true
+	_, err = renderer.Render(context.Background(), request)
+	var missing *MissingVariablesError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{"footer", "team"}, missing.Variables)
+}
+
+func TestDefaultTemplateRenderer_RenderInlineAttachments(t *testing.T) {
+	subject, err := template.NewSubject("Welcome")
+	require.NoError(t, err)
+	content, err := template.NewTemplateContent(`<img src="cid:logo.png"><img src="cid:unknown">`)
+	require.NoError(t, err)
+	logo, err := template.NewAttachment("logo.png", "", []byte("png"), true, "")
+	require.NoError(t, err)
+	terms, err := template.NewAttachment("terms.pdf", "", []byte("pdf"), false, "")
+	require.NoError(t, err)
+	attachments, err := template.NewAttachments([]*template.Attachment{logo, terms})
+	require.NoError(t, err)
+
+	rendered, err := NewDefaultTemplateRenderer().Render(context.Background(), &RenderRequest{
+		Subject:     subject,
+		Content:     content,
+		Variables:   message.NewVariables(nil),
+		Attachments: attachments,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, rendered.Attachments, 2)
+	inline := rendered.Attachments[0]
+	assert.True(t, inline.Inline)
+	assert.Equal(t, "image/png", inline.ContentType)
+	assert.Regexp(t, `^logo\.png\.[0-9a-f]{12}@notification$`, inline.ContentID)
+	assert.Equal(t, `<img src="cid:`+inline.ContentID+`"><img src="cid:unknown">`, rendered.Content)
+	assert.Empty(t, rendered.Attachments[1].ContentID)
+}
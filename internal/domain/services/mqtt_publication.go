@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// MQTTTopicConfigKey is the MQTT channel config holding the topic template.
+// Its {variable} placeholders take the variables of the message, and the
+// {recipient} placeholder publishes once per recipient of the channel, e.g.
+// devices/{recipient}/notifications.
+const MQTTTopicConfigKey = "topic"
+
+// mqttRecipientPlaceholder is the topic template placeholder of the recipients
+const mqttRecipientPlaceholder = "recipient"
+
+// maxMQTTTopicLength is the longest topic of the MQTT protocol, in bytes
+const maxMQTTTopicLength = 65535
+
+// MQTTPublication is a topic an MQTT message is published to, with the
+// recipient it was resolved for.
+type MQTTPublication struct {
+	// Target is the recipient of the channel, the topic when the template
+	// has no {recipient} placeholder
+	Target string
+	Topic  string
+}
+
+// ValidateMQTTTopicTemplate checks the topic template of an MQTT channel. A
+// template may not subscribe with wildcards nor publish to the topics the
+// brokers reserve for themselves.
+func ValidateMQTTTopicTemplate(topic string) error {
+	if strings.TrimSpace(topic) == "" {
+		return fmt.Errorf("%s is required", MQTTTopicConfigKey)
+	}
+	if strings.ContainsAny(topic, "+#\x00") {
+		return fmt.Errorf("%s cannot contain the wildcards + and #", MQTTTopicConfigKey)
+	}
+	if strings.HasPrefix(topic, "$") {
+		return fmt.Errorf("%s cannot start with $, the topics of the broker", MQTTTopicConfigKey)
+	}
+	return nil
+}
+
+// NewMQTTPublications resolves the topics a message is published to through
+// an MQTT channel from the topic template of the channel and the variables of
+// the message. It returns nil for other channels, and an error when a
+// variable of the template has no value or would change the topic levels.
+func NewMQTTPublications(ch *channel.Channel, variables map[string]interface{}) ([]*MQTTPublication, error) {
+	if !ch.ChannelType().Equals(shared.ChannelTypeMQTT) {
+		return nil, nil
+	}
+
+	value, _ := ch.Config().Get(MQTTTopicConfigKey)
+	template, _ := value.(string)
+	if err := ValidateMQTTTopicTemplate(template); err != nil {
+		return nil, err
+	}
+
+	perRecipient := false
+	for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+		if strings.TrimSpace(match[1]) == mqttRecipientPlaceholder {
+			perRecipient = true
+		}
+	}
+	if !perRecipient {
+		topic, err := renderMQTTTopic(template, variables, "")
+		if err != nil {
+			return nil, err
+		}
+		return []*MQTTPublication{{Target: topic, Topic: topic}}, nil
+	}
+
+	recipients := ch.Recipients().ToSlice()
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("%s uses {%s} but the channel has no recipients", MQTTTopicConfigKey, mqttRecipientPlaceholder)
+	}
+	publications := make([]*MQTTPublication, 0, len(recipients))
+	for _, recipient := range recipients {
+		target := strings.TrimSpace(recipient.Target)
+		if target == "" {
+			target = recipient.Name
+		}
+		topic, err := renderMQTTTopic(template, variables, target)
+		if err != nil {
+			return nil, err
+		}
+		publications = append(publications, &MQTTPublication{Target: target, Topic: topic})
+	}
+	return publications, nil
+}
+
+// renderMQTTTopic replaces the placeholders of a topic template. Values are
+// single topic levels, so they may not contain a level separator or a
+// wildcard.
+func renderMQTTTopic(template string, variables map[string]interface{}, recipient string) (string, error) {
+	var err error
+	topic := placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		key := strings.TrimSpace(placeholder[1 : len(placeholder)-1])
+		value, ok := recipient, key == mqttRecipientPlaceholder
+		if !ok {
+			value, ok = eventVariable(variables, key)
+		}
+		switch {
+		case err != nil:
+		case !ok || value == "":
+			err = fmt.Errorf("topic variable %s has no value", key)
+		case strings.ContainsAny(value, "/+#\x00"):
+			err = fmt.Errorf("topic variable %s cannot contain /, + or #, got %q", key, value)
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(topic) > maxMQTTTopicLength {
+		return "", fmt.Errorf("topic cannot exceed %d bytes", maxMQTTTopicLength)
+	}
+	return topic, nil
+}
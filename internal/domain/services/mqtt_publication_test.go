@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+func newMQTTChannel(t *testing.T, topic string, recipients []*channel.Recipient) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+
+	name, err := channel.NewChannelName("devices")
+	require.NoError(t, err)
+	settings, err := shared.NewCommonSettings(30, 3, 5)
+	require.NoError(t, err)
+
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeMQTT, nil, settings,
+		channel.NewChannelConfig(map[string]interface{}{"broker_url": "tls://broker:8883", MQTTTopicConfigKey: topic}),
+		channel.NewRecipients(recipients), channel.NewTags(nil))
+	require.NoError(t, err)
+	return ch
+}
+
+func TestNewMQTTPublications(t *testing.T) {
+	ch := newMQTTChannel(t, "sites/{site}/alerts", nil)
+	publications, err := NewMQTTPublications(ch, map[string]interface{}{"site": "plant-7"})
+	require.NoError(t, err)
+	assert.Equal(t, []*MQTTPublication{{Target: "sites/plant-7/alerts", Topic: "sites/plant-7/alerts"}}, publications)
+
+	_, err = NewMQTTPublications(ch, map[string]interface{}{})
+	assert.ErrorContains(t, err, "site")
+	_, err = NewMQTTPublications(ch, map[string]interface{}{"site": "plant-7/#"})
+	assert.ErrorContains(t, err, "cannot contain", "a variable may not add levels or wildcards to the topic")
+
+	thermostat, err := channel.NewRecipient("thermostat", "dev-1", "device")
+	require.NoError(t, err)
+	gateway, err := channel.NewRecipient("gateway-2", "", "device")
+	require.NoError(t, err)
+	ch = newMQTTChannel(t, "devices/{recipient}/notifications", []*channel.Recipient{thermostat, gateway})
+	publications, err = NewMQTTPublications(ch, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*MQTTPublication{
+		{Target: "dev-1", Topic: "devices/dev-1/notifications"},
+		{Target: "gateway-2", Topic: "devices/gateway-2/notifications"},
+	}, publications)
+
+	_, err = NewMQTTPublications(newMQTTChannel(t, "devices/{recipient}", nil), nil)
+	assert.ErrorContains(t, err, "no recipients")
+}
+
+func TestValidateMQTTTopicTemplate(t *testing.T) {
+	assert.NoError(t, ValidateMQTTTopicTemplate("devices/{recipient}/notifications"))
+	assert.Error(t, ValidateMQTTTopicTemplate(""))
+	assert.Error(t, ValidateMQTTTopicTemplate("devices/+/notifications"))
+	assert.Error(t, ValidateMQTTTopicTemplate("devices/#"))
+	assert.Error(t, ValidateMQTTTopicTemplate("$SYS/notifications"))
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// Variables describing the Opsgenie alert of a message. Without them a
+// message creates an alert titled by its subject, or its content when it has
+// none, at the default priority of the channel.
+const (
+	AlertActionVariable   = "alert_action"
+	AlertAliasVariable    = "alert_alias"
+	AlertMessageVariable  = "alert_message"
+	AlertPriorityVariable = "alert_priority"
+	// AlertSeverityVariable is mapped to a priority by the priority mapping of the channel
+	AlertSeverityVariable = "alert_severity"
+	AlertTagsVariable     = "alert_tags"
+)
+
+// Opsgenie alert actions
+const (
+	OpsgenieActionCreate = "create"
+	// OpsgenieActionClose closes the open alert with the alias of the message
+	OpsgenieActionClose = "close"
+)
+
+// Config keys of Opsgenie channels
+const (
+	OpsgenieRespondersConfigKey      = "responders"
+	OpsgenieDefaultPriorityConfigKey = "default_priority"
+	// OpsgeniePriorityMappingConfigKey maps alert severities to priorities,
+	// overriding the default mapping
+	OpsgeniePriorityMappingConfigKey = "priority_mapping"
+)
+
+// Limits of an Opsgenie alert
+const (
+	maxOpsgenieMessageLength = 130
+	maxOpsgenieAliasLength   = 512
+)
+
+// defaultOpsgeniePriorityMapping maps the alert severities to priorities
+var defaultOpsgeniePriorityMapping = map[string]string{
+	"critical": "P1",
+	"error":    "P2",
+	"warning":  "P3",
+	"info":     "P5",
+}
+
+// opsgeniePriorities are the priorities of Opsgenie alerts
+var opsgeniePriorities = map[string]bool{"P1": true, "P2": true, "P3": true, "P4": true, "P5": true}
+
+// opsgenieResponderTypes are the kinds of Opsgenie responders
+var opsgenieResponderTypes = map[string]bool{"team": true, "user": true, "escalation": true, "schedule": true}
+
+// OpsgenieResponder is a team, user, escalation or schedule an alert is
+// routed to, identified by its ID or its name (the username of a user).
+type OpsgenieResponder struct {
+	Type string
+	ID   string
+	Name string
+}
+
+// OpsgenieAlert is an alert of the Opsgenie Alert API. Close alerts only
+// carry the alias of the alert they close.
+type OpsgenieAlert struct {
+	Action   string
+	Alias    string
+	Message  string
+	Priority string
+	Tags     []string
+	// Description is shown with the alert, the rendered content of the message
+	Description string
+}
+
+// OpsgenieResponders reads the responders of an Opsgenie channel.
+func OpsgenieResponders(config *channel.ChannelConfig) ([]OpsgenieResponder, error) {
+	value, ok := config.Get(OpsgenieRespondersConfigKey)
+	if !ok || value == nil {
+		return nil, nil
+	}
+	entries, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of responders", OpsgenieRespondersConfigKey)
+	}
+
+	responders := make([]OpsgenieResponder, 0, len(entries))
+	for i, entry := range entries {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("responder %d must be an object", i+1)
+		}
+		responder := OpsgenieResponder{}
+		responder.Type, _ = fields["type"].(string)
+		responder.ID, _ = fields["id"].(string)
+		responder.Name, _ = fields["name"].(string)
+		if responder.Name == "" {
+			responder.Name, _ = fields["username"].(string)
+		}
+		if !opsgenieResponderTypes[responder.Type] {
+			return nil, fmt.Errorf("responder %d must be of type team, user, escalation or schedule", i+1)
+		}
+		if responder.ID == "" && responder.Name == "" {
+			return nil, fmt.Errorf("responder %d needs an id or a name", i+1)
+		}
+		responders = append(responders, responder)
+	}
+	return responders, nil
+}
+
+// OpsgeniePriorityMapping reads the priority mapping of an Opsgenie channel,
+// the default mapping with the overrides of the channel.
+func OpsgeniePriorityMapping(config *channel.ChannelConfig) (map[string]string, error) {
+	mapping := make(map[string]string, len(defaultOpsgeniePriorityMapping))
+	for severity, priority := range defaultOpsgeniePriorityMapping {
+		mapping[severity] = priority
+	}
+
+	value, ok := config.Get(OpsgeniePriorityMappingConfigKey)
+	if !ok || value == nil {
+		return mapping, nil
+	}
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object mapping severities to priorities", OpsgeniePriorityMappingConfigKey)
+	}
+	for severity, entry := range entries {
+		priority, ok := entry.(string)
+		if !ok || !opsgeniePriorities[strings.ToUpper(priority)] {
+			return nil, fmt.Errorf("priority of severity %s must be one of P1 to P5", severity)
+		}
+		mapping[strings.ToLower(severity)] = strings.ToUpper(priority)
+	}
+	return mapping, nil
+}
+
+// NewOpsgenieAlert builds the alert a message sends through an Opsgenie
+// channel from its variables and rendered content. It returns nil for other
+// channels, and an error when the variables describe no valid alert.
+func NewOpsgenieAlert(ch *channel.Channel, variables map[string]interface{}, content *RenderedContent) (*OpsgenieAlert, error) {
+	if !ch.ChannelType().Equals(shared.ChannelTypeOpsgenie) {
+		return nil, nil
+	}
+
+	alert := &OpsgenieAlert{Action: OpsgenieActionCreate}
+	if action, ok := eventVariable(variables, AlertActionVariable); ok {
+		alert.Action = strings.ToLower(action)
+	}
+	alert.Alias, _ = eventVariable(variables, AlertAliasVariable)
+	if len(alert.Alias) > maxOpsgenieAliasLength {
+		return nil, fmt.Errorf("%s cannot exceed %d characters", AlertAliasVariable, maxOpsgenieAliasLength)
+	}
+
+	switch alert.Action {
+	case OpsgenieActionCreate:
+		// An alias of its own keeps the retries of the send from creating more alerts
+		if alert.Alias == "" {
+			alert.Alias = uuid.New().String()
+		}
+	case OpsgenieActionClose, "resolve":
+		alert.Action = OpsgenieActionClose
+		if alert.Alias == "" {
+			return nil, fmt.Errorf("%s is required to close an alert", AlertAliasVariable)
+		}
+		return alert, nil
+	default:
+		return nil, fmt.Errorf("%s must be create or close, got %q", AlertActionVariable, alert.Action)
+	}
+
+	alert.Message, _ = eventVariable(variables, AlertMessageVariable)
+	if alert.Message == "" {
+		alert.Message = strings.TrimSpace(content.Subject)
+	}
+	if alert.Message == "" {
+		alert.Message = strings.TrimSpace(content.Content)
+	}
+	if alert.Message == "" {
+		return nil, fmt.Errorf("an alert needs a message, set %s or render a subject", AlertMessageVariable)
+	}
+	if runes := []rune(alert.Message); len(runes) > maxOpsgenieMessageLength {
+		alert.Message = string(runes[:maxOpsgenieMessageLength])
+	}
+
+	priority, err := opsgeniePriority(ch.Config(), variables)
+	if err != nil {
+		return nil, err
+	}
+	alert.Priority = priority
+	alert.Tags = eventAttendees(variables[AlertTagsVariable])
+	alert.Description = content.Content
+	return alert, nil
+}
+
+// opsgeniePriority resolves the priority of an alert: the alert_priority of
+// the message, else its alert_severity through the priority mapping of the
+// channel, else the default priority of the channel, else P3
+func opsgeniePriority(config *channel.ChannelConfig, variables map[string]interface{}) (string, error) {
+	if priority, ok := eventVariable(variables, AlertPriorityVariable); ok {
+		priority = strings.ToUpper(priority)
+		if !opsgeniePriorities[priority] {
+			return "", fmt.Errorf("%s must be one of P1 to P5, got %q", AlertPriorityVariable, priority)
+		}
+		return priority, nil
+	}
+
+	if severity, ok := eventVariable(variables, AlertSeverityVariable); ok {
+		mapping, err := OpsgeniePriorityMapping(config)
+		if err != nil {
+			return "", err
+		}
+		priority, ok := mapping[strings.ToLower(severity)]
+		if !ok {
+			return "", fmt.Errorf("%s %q has no priority in the priority mapping of the channel", AlertSeverityVariable, severity)
+		}
+		return priority, nil
+	}
+
+	if value, ok := config.Get(OpsgenieDefaultPriorityConfigKey); ok {
+		if priority, ok := value.(string); ok && opsgeniePriorities[strings.ToUpper(priority)] {
+			return strings.ToUpper(priority), nil
+		}
+	}
+	return "P3", nil
+}
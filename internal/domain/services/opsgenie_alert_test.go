@@ -0,0 +1,91 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+func newOpsgenieChannel(t *testing.T, config map[string]interface{}) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+
+	name, err := channel.NewChannelName("on-call")
+	require.NoError(t, err)
+	settings, err := shared.NewCommonSettings(30, 3, 5)
+	require.NoError(t, err)
+
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeOpsgenie, nil, settings,
+		channel.NewChannelConfig(config), channel.NewRecipients(nil), channel.NewTags(nil))
+	require.NoError(t, err)
+	return ch
+}
+
+func TestNewOpsgenieAlert(t *testing.T) {
+	ch := newOpsgenieChannel(t, map[string]interface{}{
+		"api_key":                        "K3Y",
+		OpsgenieDefaultPriorityConfigKey: "p4",
+		OpsgeniePriorityMappingConfigKey: map[string]interface{}{"warning": "P2"},
+	})
+	content := &RenderedContent{Subject: "Disk full on db-1", Content: "/var is 98% full"}
+
+	alert, err := NewOpsgenieAlert(ch, map[string]interface{}{AlertTagsVariable: "db, disk"}, content)
+	require.NoError(t, err)
+	assert.Equal(t, OpsgenieActionCreate, alert.Action)
+	assert.Equal(t, "Disk full on db-1", alert.Message)
+	assert.Equal(t, "P4", alert.Priority, "the channel sets the default priority")
+	assert.Equal(t, []string{"db", "disk"}, alert.Tags)
+	assert.Equal(t, "/var is 98% full", alert.Description)
+	assert.NotEmpty(t, alert.Alias, "alerts get an alias so retries do not create more alerts")
+
+	alert, err = NewOpsgenieAlert(ch, map[string]interface{}{AlertSeverityVariable: "Warning", AlertAliasVariable: "db-1/disk"}, content)
+	require.NoError(t, err)
+	assert.Equal(t, "P2", alert.Priority, "the channel overrides the priority of warnings")
+	assert.Equal(t, "db-1/disk", alert.Alias)
+
+	alert, err = NewOpsgenieAlert(ch, map[string]interface{}{AlertSeverityVariable: "critical", AlertPriorityVariable: "p5"}, content)
+	require.NoError(t, err)
+	assert.Equal(t, "P5", alert.Priority, "alert_priority wins over alert_severity")
+
+	alert, err = NewOpsgenieAlert(ch, map[string]interface{}{AlertActionVariable: "resolve", AlertAliasVariable: "db-1/disk"}, content)
+	require.NoError(t, err)
+	assert.Equal(t, &OpsgenieAlert{Action: OpsgenieActionClose, Alias: "db-1/disk"}, alert)
+
+	_, err = NewOpsgenieAlert(ch, map[string]interface{}{AlertActionVariable: "close"}, content)
+	assert.ErrorContains(t, err, AlertAliasVariable)
+	_, err = NewOpsgenieAlert(ch, map[string]interface{}{AlertActionVariable: "snooze"}, content)
+	assert.ErrorContains(t, err, AlertActionVariable)
+	_, err = NewOpsgenieAlert(ch, map[string]interface{}{AlertSeverityVariable: "fatal"}, content)
+	assert.ErrorContains(t, err, AlertSeverityVariable)
+	_, err = NewOpsgenieAlert(ch, map[string]interface{}{AlertPriorityVariable: "P0"}, content)
+	assert.ErrorContains(t, err, AlertPriorityVariable)
+}
+
+func TestOpsgenieResponders(t *testing.T) {
+	responders, err := OpsgenieResponders(channel.NewChannelConfig(map[string]interface{}{
+		OpsgenieRespondersConfigKey: []interface{}{
+			map[string]interface{}{"type": "team", "name": "SRE"},
+			map[string]interface{}{"type": "user", "username": "oncall@example.com"},
+			map[string]interface{}{"type": "schedule", "id": "4513b7ea"},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []OpsgenieResponder{
+		{Type: "team", Name: "SRE"},
+		{Type: "user", Name: "oncall@example.com"},
+		{Type: "schedule", ID: "4513b7ea"},
+	}, responders)
+
+	_, err = OpsgenieResponders(channel.NewChannelConfig(map[string]interface{}{
+		OpsgenieRespondersConfigKey: []interface{}{map[string]interface{}{"type": "group", "name": "SRE"}},
+	}))
+	assert.Error(t, err)
+	_, err = OpsgenieResponders(channel.NewChannelConfig(map[string]interface{}{
+		OpsgenieRespondersConfigKey: []interface{}{map[string]interface{}{"type": "team"}},
+	}))
+	assert.Error(t, err)
+}
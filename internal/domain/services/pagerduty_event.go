@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// Variables describing the PagerDuty event of a message. Without them a
+// message triggers an incident summarized by its subject, or its content
+// when it has none, at the default severity of the channel.
+const (
+	IncidentActionVariable    = "incident_action"
+	IncidentSummaryVariable   = "incident_summary"
+	IncidentSeverityVariable  = "incident_severity"
+	IncidentDedupKeyVariable  = "incident_dedup_key"
+	IncidentComponentVariable = "incident_component"
+	IncidentGroupVariable     = "incident_group"
+	IncidentClassVariable     = "incident_class"
+)
+
+// PagerDuty event actions
+const (
+	PagerDutyActionTrigger     = "trigger"
+	PagerDutyActionAcknowledge = "acknowledge"
+	PagerDutyActionResolve     = "resolve"
+)
+
+// PagerDutyDefaultSeverityConfigKey is the PagerDuty channel config setting
+// the severity of the incidents whose messages set none
+const PagerDutyDefaultSeverityConfigKey = "default_severity"
+
+// maxPagerDutySummaryLength is the longest summary of a PagerDuty event
+const maxPagerDutySummaryLength = 1024
+
+// pagerDutySeverities are the severities of PagerDuty events
+var pagerDutySeverities = map[string]bool{"critical": true, "error": true, "warning": true, "info": true}
+
+// PagerDutyEvent is an event of the PagerDuty Events API v2. Acknowledge and
+// resolve events only carry the dedup key of the incident they update.
+type PagerDutyEvent struct {
+	Action    string
+	DedupKey  string
+	Summary   string
+	Severity  string
+	Component string
+	Group     string
+	Class     string
+	// Details are shown with the incident, the rendered content of the message
+	Details string
+}
+
+// NewPagerDutyEvent builds the event a message sends through a PagerDuty
+// channel from its variables and rendered content. It returns nil for other
+// channels, and an error when the variables describe no valid event.
+func NewPagerDutyEvent(ch *channel.Channel, variables map[string]interface{}, content *RenderedContent) (*PagerDutyEvent, error) {
+	if !ch.ChannelType().Equals(shared.ChannelTypePagerDuty) {
+		return nil, nil
+	}
+
+	event := &PagerDutyEvent{Action: PagerDutyActionTrigger}
+	if action, ok := eventVariable(variables, IncidentActionVariable); ok {
+		event.Action = strings.ToLower(action)
+	}
+	event.DedupKey, _ = eventVariable(variables, IncidentDedupKeyVariable)
+
+	switch event.Action {
+	case PagerDutyActionTrigger:
+		// A key of its own keeps the retries of the send from opening more incidents
+		if event.DedupKey == "" {
+			event.DedupKey = uuid.New().String()
+		}
+	case PagerDutyActionAcknowledge, PagerDutyActionResolve:
+		if event.DedupKey == "" {
+			return nil, fmt.Errorf("%s is required to %s an incident", IncidentDedupKeyVariable, event.Action)
+		}
+		return event, nil
+	default:
+		return nil, fmt.Errorf("%s must be trigger, acknowledge or resolve, got %q", IncidentActionVariable, event.Action)
+	}
+
+	event.Summary, _ = eventVariable(variables, IncidentSummaryVariable)
+	if event.Summary == "" {
+		event.Summary = strings.TrimSpace(content.Subject)
+	}
+	if event.Summary == "" {
+		event.Summary = strings.TrimSpace(content.Content)
+	}
+	if event.Summary == "" {
+		return nil, fmt.Errorf("an incident needs a summary, set %s or render a subject", IncidentSummaryVariable)
+	}
+	if runes := []rune(event.Summary); len(runes) > maxPagerDutySummaryLength {
+		event.Summary = string(runes[:maxPagerDutySummaryLength])
+	}
+
+	event.Severity = "error"
+	if severity, ok := ch.Config().Get(PagerDutyDefaultSeverityConfigKey); ok {
+		if s, ok := severity.(string); ok && s != "" {
+			event.Severity = strings.ToLower(s)
+		}
+	}
+	if severity, ok := eventVariable(variables, IncidentSeverityVariable); ok {
+		event.Severity = strings.ToLower(severity)
+	}
+	if !pagerDutySeverities[event.Severity] {
+		return nil, fmt.Errorf("%s must be critical, error, warning or info, got %q", IncidentSeverityVariable, event.Severity)
+	}
+
+	event.Component, _ = eventVariable(variables, IncidentComponentVariable)
+	event.Group, _ = eventVariable(variables, IncidentGroupVariable)
+	event.Class, _ = eventVariable(variables, IncidentClassVariable)
+	event.Details = content.Content
+	return event, nil
+}
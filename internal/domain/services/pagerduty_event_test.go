@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+func newPagerDutyChannel(t *testing.T, config map[string]interface{}) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+
+	name, err := channel.NewChannelName("on-call")
+	require.NoError(t, err)
+	settings, err := shared.NewCommonSettings(30, 3, 5)
+	require.NoError(t, err)
+
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypePagerDuty, nil, settings,
+		channel.NewChannelConfig(config), channel.NewRecipients(nil), channel.NewTags(nil))
+	require.NoError(t, err)
+	return ch
+}
+
+func TestNewPagerDutyEvent(t *testing.T) {
+	ch := newPagerDutyChannel(t, map[string]interface{}{"routing_key": "R0UT1NG", PagerDutyDefaultSeverityConfigKey: "warning"})
+	content := &RenderedContent{Subject: "Disk full on db-1", Content: "/var is 98% full"}
+
+	event, err := NewPagerDutyEvent(ch, map[string]interface{}{IncidentComponentVariable: "postgres"}, content)
+	require.NoError(t, err)
+	assert.Equal(t, PagerDutyActionTrigger, event.Action)
+	assert.Equal(t, "Disk full on db-1", event.Summary)
+	assert.Equal(t, "warning", event.Severity, "the channel sets the default severity")
+	assert.Equal(t, "postgres", event.Component)
+	assert.Equal(t, "/var is 98% full", event.Details)
+	assert.NotEmpty(t, event.DedupKey, "triggers get a dedup key so retries do not open more incidents")
+
+	event, err = NewPagerDutyEvent(ch, map[string]interface{}{
+		IncidentSummaryVariable:  "Database down",
+		IncidentSeverityVariable: "Critical",
+		IncidentDedupKeyVariable: "db-1/disk",
+	}, content)
+	require.NoError(t, err)
+	assert.Equal(t, "Database down", event.Summary)
+	assert.Equal(t, "critical", event.Severity)
+	assert.Equal(t, "db-1/disk", event.DedupKey)
+
+	event, err = NewPagerDutyEvent(ch, map[string]interface{}{IncidentActionVariable: "resolve", IncidentDedupKeyVariable: "db-1/disk"}, content)
+	require.NoError(t, err)
+	assert.Equal(t, &PagerDutyEvent{Action: PagerDutyActionResolve, DedupKey: "db-1/disk"}, event)
+
+	_, err = NewPagerDutyEvent(ch, map[string]interface{}{IncidentActionVariable: "acknowledge"}, content)
+	assert.ErrorContains(t, err, IncidentDedupKeyVariable)
+	_, err = NewPagerDutyEvent(ch, map[string]interface{}{IncidentActionVariable: "escalate"}, content)
+	assert.ErrorContains(t, err, IncidentActionVariable)
+	_, err = NewPagerDutyEvent(ch, map[string]interface{}{IncidentSeverityVariable: "high"}, content)
+	assert.ErrorContains(t, err, IncidentSeverityVariable)
+}
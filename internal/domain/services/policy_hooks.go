@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"notification/internal/domain/message"
+)
+
+// Names of the built-in policy hooks
+const (
+	PolicyHookPII       = "pii"
+	PolicyHookProfanity = "profanity"
+	PolicyHookSize      = "size"
+)
+
+// PIIScanner is the policy hook finding card numbers and US social security
+// numbers in the content of a send. It masks them, or blocks the send when
+// block is set.
+type PIIScanner struct {
+	block bool
+}
+
+// NewPIIScanner creates a PII scanner.
+func NewPIIScanner(block bool) *PIIScanner {
+	return &PIIScanner{block: block}
+}
+
+// Name implements PolicyHook.
+func (s *PIIScanner) Name() string {
+	return PolicyHookPII
+}
+
+var (
+	// cardNumberPattern matches 13 to 19 digits, grouped by spaces or dashes
+	cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// ssnPattern matches a US social security number written with dashes
+	ssnPattern = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
+)
+
+// Check implements PolicyHook.
+func (s *PIIScanner) Check(ctx context.Context, send *PolicySend) (*PolicyVerdict, error) {
+	cards, ssns := 0, 0
+	mask := func(text string) string {
+		text = cardNumberPattern.ReplaceAllStringFunc(text, func(match string) string {
+			digits := strings.NewReplacer(" ", "", "-", "").Replace(match)
+			if !luhnValid(digits) {
+				return match
+			}
+			cards++
+			return "**** " + digits[len(digits)-4:]
+		})
+		return ssnPattern.ReplaceAllStringFunc(text, func(match string) string {
+			ssns++
+			return "***-**-" + match[len(match)-4:]
+		})
+	}
+
+	subject := mask(send.Content.Subject)
+	content := mask(send.Content.Content)
+	if cards == 0 && ssns == 0 {
+		return nil, nil
+	}
+
+	annotations := map[string]string{
+		"cardNumbers": strconv.Itoa(cards),
+		"ssns":        strconv.Itoa(ssns),
+	}
+	if s.block {
+		return &PolicyVerdict{Action: message.PolicyActionBlock, Reason: "content contains personal data", Annotations: annotations}, nil
+	}
+	send.Content.Subject = subject
+	send.Content.Content = content
+	return &PolicyVerdict{Action: message.PolicyActionModify, Reason: "personal data masked", Annotations: annotations}, nil
+}
+
+// luhnValid checks the check digit of a card number
+func luhnValid(digits string) bool {
+	sum := 0
+	for i := range digits {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// ProfanityFilter is the policy hook finding words of a list in the content
+// of a send. It blocks the send, or only annotates it when annotateOnly is set.
+type ProfanityFilter struct {
+	pattern      *regexp.Regexp
+	annotateOnly bool
+}
+
+// NewProfanityFilter creates a filter of the given words, matched whole and
+// regardless of case.
+func NewProfanityFilter(words []string, annotateOnly bool) *ProfanityFilter {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+
+	filter := &ProfanityFilter{annotateOnly: annotateOnly}
+	if len(quoted) > 0 {
+		filter.pattern = regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return filter
+}
+
+// Name implements PolicyHook.
+func (f *ProfanityFilter) Name() string {
+	return PolicyHookProfanity
+}
+
+// Check implements PolicyHook.
+func (f *ProfanityFilter) Check(ctx context.Context, send *PolicySend) (*PolicyVerdict, error) {
+	if f.pattern == nil {
+		return nil, nil
+	}
+
+	found := make(map[string]bool)
+	for _, text := range []string{send.Content.Subject, send.Content.Content} {
+		for _, match := range f.pattern.FindAllString(text, -1) {
+			found[strings.ToLower(match)] = true
+		}
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	words := make([]string, 0, len(found))
+	for word := range found {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	verdict := &PolicyVerdict{
+		Action:      message.PolicyActionBlock,
+		Reason:      "content contains filtered words",
+		Annotations: map[string]string{"words": strings.Join(words, ",")},
+	}
+	if f.annotateOnly {
+		verdict.Action = message.PolicyActionAnnotate
+	}
+	return verdict, nil
+}
+
+// SizePolicy is the policy hook blocking the sends whose subject, content and
+// attachments together exceed a number of bytes.
+type SizePolicy struct {
+	maxBytes int
+}
+
+// NewSizePolicy creates a size policy.
+func NewSizePolicy(maxBytes int) *SizePolicy {
+	return &SizePolicy{maxBytes: maxBytes}
+}
+
+// Name implements PolicyHook.
+func (p *SizePolicy) Name() string {
+	return PolicyHookSize
+}
+
+// Check implements PolicyHook.
+func (p *SizePolicy) Check(ctx context.Context, send *PolicySend) (*PolicyVerdict, error) {
+	size := len(send.Content.Subject) + len(send.Content.Content)
+	for _, attachment := range send.Content.Attachments {
+		size += len(attachment.Content)
+	}
+	if size <= p.maxBytes {
+		return nil, nil
+	}
+	return &PolicyVerdict{
+		Action:      message.PolicyActionBlock,
+		Reason:      fmt.Sprintf("message is %d bytes, over the limit of %d", size, p.maxBytes),
+		Annotations: map[string]string{"bytes": strconv.Itoa(size)},
+	}, nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"notification/internal/domain/category"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/preference"
+	"notification/internal/domain/shared"
+)
+
+// NotificationCategoryVariable is the variable holding the category of a
+// message, matched against the subscriptions and the category opt-outs of the
+// recipients. Channels can set it for all their messages with a variable
+// default.
+const NotificationCategoryVariable = "notification_category"
+
+// PreferenceFilter is the domain service that leaves out of a send the
+// recipients whose preferences or subscriptions stop it.
+type PreferenceFilter struct {
+	preferences   preference.PreferenceRepository
+	categories    category.CategoryRepository
+	subscriptions category.SubscriptionRepository
+	now           func() time.Time
+}
+
+// NewPreferenceFilter creates a preference filter.
+func NewPreferenceFilter(preferences preference.PreferenceRepository) *PreferenceFilter {
+	return &PreferenceFilter{
+		preferences: preferences,
+		now:         time.Now,
+	}
+}
+
+// SetSubscriptions makes the filter deliver the messages of a category only
+// to the recipients subscribed to it. Without subscriptions, and for the
+// categories not registered, the category is only matched against opt-outs.
+func (f *PreferenceFilter) SetSubscriptions(categories category.CategoryRepository, subscriptions category.SubscriptionRepository) {
+	f.categories = categories
+	f.subscriptions = subscriptions
+}
+
+// Filter splits the recipients of a channel by their subscriptions and
+// preferences; transactional messages skip the subscriptions, the category
+// opt-outs and the quiet hours. It returns the channel sending to the recipients the message
+// reaches, and the results of the suppressed ones. Recipients without a
+// target are always sent to. On error the channel is returned unchanged.
+func (f *PreferenceFilter) Filter(ctx context.Context, ch *channel.Channel, variables map[string]interface{}) (*channel.Channel, []*message.RecipientResult, error) {
+	recipients := ch.Recipients().ToSlice()
+	userIDs := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if userID := preference.NormalizeUserID(recipient.Target); userID != "" {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return ch, nil, nil
+	}
+
+	transactional := message.MessageClassOf(variables).IsTransactional()
+	categoryName, _ := eventVariable(variables, NotificationCategoryVariable)
+	// Transactional messages reach the recipients whatever their subscriptions
+	subscriptionCategory := categoryName
+	if transactional {
+		subscriptionCategory = ""
+	}
+	messageCategory, subscriptions, err := f.findSubscriptions(ctx, subscriptionCategory, userIDs)
+	if err != nil {
+		return ch, nil, err
+	}
+	preferences, err := f.preferences.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return ch, nil, err
+	}
+
+	now := f.now()
+	kept := make([]*channel.Recipient, 0, len(recipients))
+	var suppressed []*message.RecipientResult
+	for _, recipient := range recipients {
+		userID := preference.NormalizeUserID(recipient.Target)
+		reason := preference.SuppressionReason("")
+		if userID != "" && messageCategory != nil && !messageCategory.Receives(subscriptions[userID]) {
+			reason = preference.SuppressionReasonNotSubscribed
+		} else if userPreference, ok := preferences[userID]; ok && transactional {
+			reason = userPreference.EvaluateTransactional(ch.ChannelType().String())
+		} else if ok {
+			reason = userPreference.Evaluate(ch.ChannelType().String(), categoryName, now)
+		}
+		if reason == "" {
+			kept = append(kept, recipient)
+			continue
+		}
+		suppressed = append(suppressed, &message.RecipientResult{
+			Target:            recipient.Target,
+			Status:            message.MessageResultStatusSuppressed,
+			SuppressionReason: string(reason),
+		})
+	}
+	if len(suppressed) == 0 {
+		return ch, nil, nil
+	}
+
+	return ch.WithOverrides(channel.NewRecipients(kept), nil), suppressed, nil
+}
+
+// findSubscriptions finds the registered category of a message and the
+// subscriptions of the recipients to it, a nil category when the message has
+// none or it is not registered
+func (f *PreferenceFilter) findSubscriptions(ctx context.Context, categoryName string, userIDs []string) (*category.Category, map[string]*category.Subscription, error) {
+	if categoryName == "" || f.categories == nil || f.subscriptions == nil {
+		return nil, nil, nil
+	}
+
+	messageCategory, err := f.categories.FindByName(ctx, category.NormalizeName(categoryName))
+	if shared.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscriptions, err := f.subscriptions.FindByCategory(ctx, messageCategory.Name, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return messageCategory, subscriptions, nil
+}
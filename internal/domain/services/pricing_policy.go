@@ -0,0 +1,36 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"notification/internal/domain/channel"
+)
+
+// PricingPolicy holds the estimated price of one send per channel type and
+// provider, used to attribute the notification spend.
+type PricingPolicy struct {
+	Currency string
+	// Prices is keyed by channel type, as sms, or by channel type and
+	// provider, as sms/twilio; the provider price wins
+	Prices map[string]float64
+}
+
+// Price returns the price of one send through a channel, 0 when it is not priced.
+func (p *PricingPolicy) Price(ch *channel.Channel) float64 {
+	channelType := ch.ChannelType().String()
+	if ch.Config() != nil {
+		if provider, ok := ch.Config().Get("provider"); ok {
+			key := channelType + "/" + strings.ToLower(fmt.Sprintf("%v", provider))
+			if price, ok := p.Prices[key]; ok {
+				return price
+			}
+		}
+	}
+	return p.Prices[channelType]
+}
+
+// Cost returns the estimated cost of a send that reached the given number of recipients.
+func (p *PricingPolicy) Cost(ch *channel.Channel, delivered int) float64 {
+	return p.Price(ch) * float64(delivered)
+}
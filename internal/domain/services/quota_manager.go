@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/quota"
+)
+
+// QuotaPolicy holds the send quotas of tenants and channels.
+type QuotaPolicy struct {
+	// Tenant and Channel are the limits of the subjects without their own
+	Tenant  quota.Limit
+	Channel quota.Limit
+	// Tenants and Channels hold the limits per tenant ID and channel ID
+	Tenants  map[string]quota.Limit
+	Channels map[string]quota.Limit
+}
+
+// Limit returns the limit of a subject.
+func (p QuotaPolicy) Limit(scope quota.Scope, subjectID string) quota.Limit {
+	if scope == quota.ScopeChannel {
+		if limit, ok := p.Channels[subjectID]; ok {
+			return limit
+		}
+		return p.Channel
+	}
+	if limit, ok := p.Tenants[subjectID]; ok {
+		return limit
+	}
+	return p.Tenant
+}
+
+// QuotaManager is the domain service that counts the sends of tenants and
+// channels and rejects the messages that would exceed their quotas.
+type QuotaManager struct {
+	usageRepo quota.UsageRepository
+	policy    QuotaPolicy
+	now       func() time.Time
+}
+
+// NewQuotaManager creates a quota manager.
+func NewQuotaManager(usageRepo quota.UsageRepository, policy QuotaPolicy) *QuotaManager {
+	return &QuotaManager{
+		usageRepo: usageRepo,
+		policy:    policy,
+		now:       time.Now,
+	}
+}
+
+// Consume counts a message to the channels against the daily and monthly
+// quotas of the tenant and of each channel. Every channel is one send. The
+// message is charged in full or not at all; an *quota.ExceededError is
+// returned when a quota would be exceeded.
+func (m *QuotaManager) Consume(ctx context.Context, tenantID string, channelIDs []*channel.ChannelID) error {
+	return m.consume(ctx, tenantID, channelIDs, true)
+}
+
+// Count counts a message to the channels against the quotas like Consume,
+// without rejecting it when a quota is exceeded. Transactional messages are
+// counted so that the usage reports them.
+func (m *QuotaManager) Count(ctx context.Context, tenantID string, channelIDs []*channel.ChannelID) error {
+	return m.consume(ctx, tenantID, channelIDs, false)
+}
+
+// consume charges a message to the quotas, limited by them or not
+func (m *QuotaManager) consume(ctx context.Context, tenantID string, channelIDs []*channel.ChannelID, limited bool) error {
+	if tenantID == "" {
+		tenantID = quota.DefaultTenantID
+	}
+
+	now := m.now()
+	var charges []*quota.Charge
+	for _, period := range quota.Periods {
+		charges = append(charges, m.charge(quota.ScopeTenant, tenantID, period, now, len(channelIDs)))
+		for _, channelID := range channelIDs {
+			charges = append(charges, m.charge(quota.ScopeChannel, channelID.String(), period, now, 1))
+		}
+	}
+	if !limited {
+		for _, charge := range charges {
+			charge.Limit = 0
+		}
+	}
+	quota.SortCharges(charges)
+
+	return m.usageRepo.Consume(ctx, charges)
+}
+
+// Usage returns the daily and monthly usage of a tenant or channel.
+func (m *QuotaManager) Usage(ctx context.Context, scope quota.Scope, subjectID string) ([]*quota.Usage, error) {
+	now := m.now()
+	limit := m.policy.Limit(scope, subjectID)
+
+	usages := make([]*quota.Usage, 0, len(quota.Periods))
+	for _, period := range quota.Periods {
+		key := period.Key(now)
+		used, err := m.usageRepo.FindUsed(ctx, scope, subjectID, period, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s quota usage: %w", period, err)
+		}
+		usages = append(usages, &quota.Usage{
+			Scope:     scope,
+			SubjectID: subjectID,
+			Period:    period,
+			PeriodKey: key,
+			Used:      used,
+			Limit:     limit.For(period),
+			ResetsAt:  period.End(now).UnixMilli(),
+		})
+	}
+
+	return usages, nil
+}
+
+// charge creates the charge of sends for a subject in the period containing now
+func (m *QuotaManager) charge(scope quota.Scope, subjectID string, period quota.Period, now time.Time, amount int) *quota.Charge {
+	return &quota.Charge{
+		Scope:     scope,
+		SubjectID: subjectID,
+		Period:    period,
+		PeriodKey: period.Key(now),
+		Amount:    amount,
+		Limit:     m.policy.Limit(scope, subjectID).For(period),
+	}
+}
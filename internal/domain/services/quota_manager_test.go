@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/quota"
+	"notification/internal/domain/shared"
+)
+
+// memoryUsageRepository is an in-memory quota.UsageRepository
+type memoryUsageRepository struct {
+	used map[string]int
+}
+
+func usageKey(scope quota.Scope, subjectID string, period quota.Period, periodKey string) string {
+	return string(scope) + "/" + subjectID + "/" + string(period) + "/" + periodKey
+}
+
+func (r *memoryUsageRepository) Consume(ctx context.Context, charges []*quota.Charge) error {
+	for _, charge := range charges {
+		used := r.used[usageKey(charge.Scope, charge.SubjectID, charge.Period, charge.PeriodKey)]
+		if charge.Limit > 0 && used+charge.Amount > charge.Limit {
+			return &quota.ExceededError{Charge: charge, Used: used}
+		}
+	}
+	for _, charge := range charges {
+		r.used[usageKey(charge.Scope, charge.SubjectID, charge.Period, charge.PeriodKey)] += charge.Amount
+	}
+	return nil
+}
+
+func (r *memoryUsageRepository) FindUsed(ctx context.Context, scope quota.Scope, subjectID string, period quota.Period, periodKey string) (int, error) {
+	return r.used[usageKey(scope, subjectID, period, periodKey)], nil
+}
+
+func TestQuotaManager_Consume(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryUsageRepository{used: map[string]int{}}
+	manager := NewQuotaManager(repo, QuotaPolicy{
+		Tenant:  quota.Limit{Daily: 3},
+		Tenants: map[string]quota.Limit{"acme": {Monthly: 10}},
+	})
+	manager.now = func() time.Time { return time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC) }
+
+	first, second := channel.NewChannelID(), channel.NewChannelID()
+
+	// Every channel is one send for the tenant
+	require.NoError(t, manager.Consume(ctx, "", []*channel.ChannelID{first, second}))
+
+	err := manager.Consume(ctx, "", []*channel.ChannelID{first, second})
+	var exceeded *quota.ExceededError
+	require.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, quota.DefaultTenantID, exceeded.Charge.SubjectID)
+	assert.Equal(t, shared.ErrorKindQuotaExceeded, shared.ErrorKindOf(err))
+
+	// A rejected message is not counted
+	usages, err := manager.Usage(ctx, quota.ScopeTenant, quota.DefaultTenantID)
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+	assert.Equal(t, quota.PeriodDaily, usages[0].Period)
+	assert.Equal(t, "2024-05-31", usages[0].PeriodKey)
+	assert.Equal(t, 2, usages[0].Used)
+	assert.Equal(t, 1, usages[0].Remaining())
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).UnixMilli(), usages[0].ResetsAt)
+	assert.True(t, usages[1].IsUnlimited())
+
+	// Tenant limits override the default
+	require.NoError(t, manager.Consume(ctx, "acme", []*channel.ChannelID{first, second}))
+	require.NoError(t, manager.Consume(ctx, "acme", []*channel.ChannelID{first, second}))
+}
+
+// recordingUsageRepository records the charges it is asked to consume
+type recordingUsageRepository struct {
+	memoryUsageRepository
+	charges []*quota.Charge
+}
+
+func (r *recordingUsageRepository) Consume(ctx context.Context, charges []*quota.Charge) error {
+	r.charges = charges
+	return nil
+}
+
+func TestQuotaManager_ConsumeSortsTheCharges(t *testing.T) {
+	repo := &recordingUsageRepository{}
+	manager := NewQuotaManager(repo, QuotaPolicy{})
+	manager.now = func() time.Time { return time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC) }
+
+	first, err := channel.NewChannelIDFromString("ch_b")
+	require.NoError(t, err)
+	second, err := channel.NewChannelIDFromString("ch_a")
+	require.NoError(t, err)
+	require.NoError(t, manager.Consume(context.Background(), "acme", []*channel.ChannelID{first, second}))
+
+	var order []string
+	for _, charge := range repo.charges {
+		order = append(order, usageKey(charge.Scope, charge.SubjectID, charge.Period, charge.PeriodKey))
+	}
+	assert.Equal(t, []string{
+		"channel/ch_a/daily/2024-05-31",
+		"channel/ch_a/monthly/2024-05",
+		"channel/ch_b/daily/2024-05-31",
+		"channel/ch_b/monthly/2024-05",
+		"tenant/acme/daily/2024-05-31",
+		"tenant/acme/monthly/2024-05",
+	}, order)
+}
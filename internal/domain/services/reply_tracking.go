@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// ReplyTrackingConfigKey is the email and SMS channel config flag correlating
+// the replies to its messages
+const ReplyTrackingConfigKey = "track_replies"
+
+// ReplyHeader is the email header replies are sent to
+const ReplyHeader = "Reply-To"
+
+// ReplyTrackingRequested checks if a channel correlates the replies to its
+// messages. Only email and SMS channels receive replies.
+func ReplyTrackingRequested(ch *channel.Channel) bool {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) && !ch.ChannelType().Equals(shared.ChannelTypeSMS) {
+		return false
+	}
+	value, ok := ch.Config().Get(ReplyTrackingConfigKey)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// ReplyTracking is the domain service recording the reply tokens of the
+// messages of the channels tracking replies. An email is answered to the
+// inbound address tagged with its token; an SMS reply is matched to the
+// token of its sender, one per recipient. A send whose tokens cannot be
+// recorded goes out untracked.
+type ReplyTracking struct {
+	repo message.ReplyRepository
+	// inboundAddress is the address the email replies are received at, email
+	// replies are not tracked without it
+	inboundAddress string
+	logger         *logger.Logger
+}
+
+// NewReplyTracking creates a reply tracking whose email replies go to
+// inboundAddress, tagged with their token.
+func NewReplyTracking(repo message.ReplyRepository, inboundAddress string, logger *logger.Logger) *ReplyTracking {
+	return &ReplyTracking{
+		repo:           repo,
+		inboundAddress: strings.TrimSpace(inboundAddress),
+		logger:         logger,
+	}
+}
+
+// Add records the reply tokens of a message sent through a channel and sets
+// the reply address of an email, in place. Channels not tracking replies are
+// left alone.
+func (t *ReplyTracking) Add(ctx context.Context, ch *channel.Channel, content *RenderedContent, messageID, channelID string) {
+	if content == nil || !ReplyTrackingRequested(ch) {
+		return
+	}
+	isEmail := ch.ChannelType().Equals(shared.ChannelTypeEmail)
+	if isEmail && t.inboundAddress == "" {
+		return
+	}
+
+	var recipients []string
+	if isEmail {
+		recipients = []string{""}
+	} else {
+		for _, recipient := range ch.Recipients().ToSlice() {
+			if number := message.NormalizePhoneNumber(recipient.Target); number != "" {
+				recipients = append(recipients, number)
+			}
+		}
+	}
+
+	tokens := make([]*message.ReplyToken, 0, len(recipients))
+	for _, recipient := range recipients {
+		token, err := message.NewReplyToken(messageID, channelID, recipient)
+		if err != nil {
+			t.warn(ctx, "Failed to create reply token, sending untracked", messageID, channelID, err)
+			return
+		}
+		tokens = append(tokens, token)
+	}
+	if len(tokens) == 0 {
+		return
+	}
+	if err := t.repo.SaveTokens(ctx, tokens); err != nil {
+		t.warn(ctx, "Failed to save reply tokens, sending untracked", messageID, channelID, err)
+		return
+	}
+
+	if isEmail {
+		if content.Headers == nil {
+			content.Headers = make(map[string]string)
+		}
+		content.Headers[ReplyHeader] = message.ReplyAddress(t.inboundAddress, tokens[0].Token)
+	}
+}
+
+// warn logs why a send goes out untracked
+func (t *ReplyTracking) warn(ctx context.Context, msg, messageID, channelID string, err error) {
+	t.logger.WithContext(ctx).Warn(msg,
+		zap.String("message_id", messageID),
+		zap.String("channel_id", channelID),
+		zap.Error(err))
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/message"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+// savedReplyTokens keeps the reply tokens saved
+type savedReplyTokens struct {
+	message.ReplyRepository
+	tokens []*message.ReplyToken
+}
+
+func (r *savedReplyTokens) SaveTokens(ctx context.Context, tokens []*message.ReplyToken) error {
+	r.tokens = append(r.tokens, tokens...)
+	return nil
+}
+
+func TestReplyTracking(t *testing.T) {
+	log, err := logger.NewLogger(&config.LoggerConfig{Level: "error", Format: "json", OutputPath: os.DevNull})
+	require.NoError(t, err)
+	repo := &savedReplyTokens{}
+	tracking := NewReplyTracking(repo, "replies@notify.example.com", log)
+
+	plain := &RenderedContent{Content: "<p>Disk full</p>"}
+	tracking.Add(context.Background(), newEmailChannel(t, nil), plain, "msg-1", "ch-1")
+	assert.Empty(t, plain.Headers, "the channel does not track replies")
+	assert.Empty(t, repo.tokens)
+
+	content := &RenderedContent{Content: "<p>Disk full</p>"}
+	ch := newEmailChannel(t, map[string]interface{}{ReplyTrackingConfigKey: "true"})
+	tracking.Add(context.Background(), ch, content, "msg-1", "ch-1")
+	require.Len(t, repo.tokens, 1)
+	token := repo.tokens[0]
+	assert.Equal(t, "msg-1", token.MessageID)
+	assert.Equal(t, "replies+"+token.Token+"@notify.example.com", content.Headers[ReplyHeader])
+	assert.Equal(t, token.Token, message.ReplyTokenFromAddress(content.Headers[ReplyHeader]))
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"notification/internal/domain/retention"
+	"notification/pkg/logger"
+)
+
+// RetentionEnforcer is the domain service that applies the retention windows
+// of the tenants: it purges the content of their older messages, deletes the
+// oldest ones and reports what it did per tenant.
+type RetentionEnforcer struct {
+	store   retention.Store
+	reports retention.ReportRepository
+	policy  retention.Policy
+	logger  *logger.Logger
+	now     func() time.Time
+}
+
+// NewRetentionEnforcer creates a retention enforcer.
+func NewRetentionEnforcer(
+	store retention.Store,
+	reports retention.ReportRepository,
+	policy retention.Policy,
+	logger *logger.Logger,
+) *RetentionEnforcer {
+	return &RetentionEnforcer{
+		store:   store,
+		reports: reports,
+		policy:  policy,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Enforce applies the windows to every tenant having messages and saves one
+// report per tenant with a window. A tenant that fails is reported with its
+// error and does not stop the others.
+func (e *RetentionEnforcer) Enforce(ctx context.Context) ([]*retention.Report, error) {
+	tenants, err := e.store.Tenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runID := uuid.NewString()
+	reports := make([]*retention.Report, 0, len(tenants))
+	for _, tenantID := range tenants {
+		window := e.policy.For(tenantID)
+		if window.IsZero() {
+			continue
+		}
+		report := e.enforce(ctx, tenantID, window)
+		report.RunID = runID
+		reports = append(reports, report)
+	}
+	if len(reports) == 0 {
+		return reports, nil
+	}
+
+	if err := e.reports.Save(ctx, reports); err != nil {
+		return reports, err
+	}
+	return reports, nil
+}
+
+// enforce applies a window to one tenant, the content first
+func (e *RetentionEnforcer) enforce(ctx context.Context, tenantID string, window retention.Window) *retention.Report {
+	now := e.now()
+	report := &retention.Report{
+		ID:        uuid.NewString(),
+		TenantID:  tenantID,
+		StartedAt: now,
+	}
+
+	err := func() error {
+		var err error
+		if window.Content > 0 {
+			before := now.Add(-window.Content)
+			report.ContentBefore = &before
+			if report.ContentPurged, err = e.store.PurgeContent(ctx, tenantID, before); err != nil {
+				return err
+			}
+		}
+		if window.Metadata > 0 {
+			before := now.Add(-window.Metadata)
+			report.MetadataBefore = &before
+			if report.MessagesDeleted, err = e.store.DeleteMessages(ctx, tenantID, before); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	report.CompletedAt = e.now()
+
+	log := e.logger.WithFields(
+		zap.String("tenant_id", tenantID),
+		zap.Int("content_purged", report.ContentPurged),
+		zap.Int("messages_deleted", report.MessagesDeleted),
+	)
+	if err != nil {
+		report.Error = err.Error()
+		log.Error("Failed to enforce retention", zap.Error(err))
+	} else {
+		log.Info("Retention enforced")
+	}
+	return report
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/retention"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+type fakeRetentionStore struct {
+	purged  map[string]time.Time
+	deleted map[string]time.Time
+}
+
+func (s *fakeRetentionStore) Tenants(context.Context) ([]string, error) {
+	return []string{"acme", "default", "forever"}, nil
+}
+
+func (s *fakeRetentionStore) PurgeContent(_ context.Context, tenantID string, before time.Time) (int, error) {
+	s.purged[tenantID] = before
+	return 3, nil
+}
+
+func (s *fakeRetentionStore) DeleteMessages(_ context.Context, tenantID string, before time.Time) (int, error) {
+	if tenantID == "default" {
+		return 0, errors.New("database is down")
+	}
+	s.deleted[tenantID] = before
+	return 2, nil
+}
+
+type fakeReportRepository struct {
+	saved []*retention.Report
+}
+
+func (r *fakeReportRepository) Save(_ context.Context, reports []*retention.Report) error {
+	r.saved = append(r.saved, reports...)
+	return nil
+}
+
+func (r *fakeReportRepository) List(context.Context, string, int) ([]*retention.Report, error) {
+	return r.saved, nil
+}
+
+func TestRetentionEnforcerAppliesTenantWindows(t *testing.T) {
+	log, err := logger.NewLogger(&config.LoggerConfig{Level: "error", Format: "json", OutputPath: os.DevNull})
+	require.NoError(t, err)
+	store := &fakeRetentionStore{purged: map[string]time.Time{}, deleted: map[string]time.Time{}}
+	reports := &fakeReportRepository{}
+	day := 24 * time.Hour
+	enforcer := NewRetentionEnforcer(store, reports, retention.Policy{
+		Default: retention.Window{Content: 7 * day, Metadata: 90 * day},
+		Tenants: map[string]retention.Window{
+			"acme":    {Content: 30 * day, Metadata: 365 * day},
+			"forever": {},
+		},
+	}, log)
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	enforcer.now = func() time.Time { return now }
+
+	result, err := enforcer.Enforce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result, 2, "tenants keeping their messages forever are not reported")
+	assert.Equal(t, result, reports.saved)
+	assert.Equal(t, result[0].RunID, result[1].RunID)
+
+	assert.Equal(t, now.Add(-30*day), store.purged["acme"])
+	assert.Equal(t, now.Add(-365*day), store.deleted["acme"])
+	assert.Equal(t, "acme", result[0].TenantID)
+	assert.Equal(t, 3, result[0].ContentPurged)
+	assert.Equal(t, 2, result[0].MessagesDeleted)
+	assert.Empty(t, result[0].Error)
+
+	assert.Equal(t, now.Add(-7*day), store.purged["default"])
+	assert.Equal(t, 3, result[1].ContentPurged)
+	assert.Equal(t, "database is down", result[1].Error)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/preference"
+	"notification/internal/domain/schedule"
+	"notification/pkg/logger"
+)
+
+// RecipientTimezones is the domain service working out the time zone each
+// recipient lives in, so that a send scheduled in local time reaches every
+// recipient at that time of its own day.
+type RecipientTimezones struct {
+	preferences preference.PreferenceRepository
+}
+
+// NewRecipientTimezones creates the recipient time zones, read from the user
+// preferences when the recipients do not carry one; preferences may be nil.
+func NewRecipientTimezones(preferences preference.PreferenceRepository) *RecipientTimezones {
+	return &RecipientTimezones{preferences: preferences}
+}
+
+// Group groups recipients by time zone: the one of the recipient, else the
+// one of the preferences of the user it targets, else the default one.
+func (t *RecipientTimezones) Group(ctx context.Context, recipients []*channel.Recipient, defaultTimezone string) (map[string][]*channel.Recipient, error) {
+	var userIDs []string
+	for _, recipient := range recipients {
+		if recipient.Timezone == "" {
+			if userID := preference.NormalizeUserID(recipient.Target); userID != "" {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+
+	preferences := map[string]*preference.UserPreference{}
+	if t.preferences != nil && len(userIDs) > 0 {
+		found, err := t.preferences.FindByUserIDs(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		preferences = found
+	}
+
+	groups := make(map[string][]*channel.Recipient)
+	for _, recipient := range recipients {
+		timezone := recipient.Timezone
+		if timezone == "" {
+			if userPreference, ok := preferences[preference.NormalizeUserID(recipient.Target)]; ok {
+				timezone = userPreference.Timezone
+			}
+		}
+		if timezone == "" {
+			timezone = defaultTimezone
+		}
+		groups[timezone] = append(groups[timezone], recipient)
+	}
+	return groups, nil
+}
+
+// ScheduledSender sends the request of a scheduled send, returning the ID of
+// the message it created.
+type ScheduledSender interface {
+	SendScheduled(ctx context.Context, send *schedule.ScheduledSend) (string, error)
+}
+
+// ScheduledSendRunner is the domain service sending the scheduled sends
+// whose time has come.
+type ScheduledSendRunner struct {
+	repo     schedule.ScheduledSendRepository
+	sender   ScheduledSender
+	calendar *BlackoutCalendar
+	logger   *logger.Logger
+}
+
+// NewScheduledSendRunner creates a scheduled send runner.
+func NewScheduledSendRunner(repo schedule.ScheduledSendRepository, sender ScheduledSender, logger *logger.Logger) *ScheduledSendRunner {
+	return &ScheduledSendRunner{
+		repo:   repo,
+		sender: sender,
+		logger: logger,
+	}
+}
+
+// SetBlackoutCalendar defers the non-critical sends due during a blackout
+// to its end instead of sending them.
+func (r *ScheduledSendRunner) SetBlackoutCalendar(calendar *BlackoutCalendar) {
+	r.calendar = calendar
+}
+
+// RunDue sends up to limit sends due at the given time and returns how many
+// it sent. A send is claimed first, so that it goes out once however many
+// workers run; a send that fails is not retried. A send a blackout holds
+// back is deferred again, to the end of the blackout.
+func (r *ScheduledSendRunner) RunDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	due, err := r.repo.FindDue(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, send := range due {
+		claimed, err := r.repo.Claim(ctx, send.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if r.calendar != nil {
+			deferred, err := r.calendar.Defer(ctx, send, now)
+			if err != nil || deferred {
+				// Put the send back pending, deferred or for the next run
+				if err != nil {
+					errs = append(errs, err)
+				}
+				if err := r.repo.Update(ctx, send); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+		}
+
+		messageID, err := r.sender.SendScheduled(ctx, send)
+		if err != nil {
+			r.logger.Warn("Failed to send scheduled send",
+				zap.String("scheduled_send_id", send.ID),
+				zap.String("batch_id", send.BatchID),
+				zap.Error(err))
+			send.MarkFailed(err)
+		} else {
+			send.MarkSent(messageID)
+			sent++
+		}
+		if err := r.repo.Update(ctx, send); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return sent, errors.Join(errs...)
+}
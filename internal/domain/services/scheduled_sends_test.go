@@ -0,0 +1,48 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/preference"
+	"notification/internal/domain/schedule"
+	"notification/internal/domain/services"
+)
+
+func TestRecipientTimezonesGroup(t *testing.T) {
+	userPreference, err := preference.NewUserPreference("bob@example.com", nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, userPreference.SetTimezone("Asia/Tokyo"))
+	require.Error(t, userPreference.SetTimezone("Mars/Olympus"))
+
+	alice, err := channel.NewRecipient("alice", "alice@example.com", "to")
+	require.NoError(t, err)
+	alice, err = alice.WithTimezone("America/New_York")
+	require.NoError(t, err)
+	bob, err := channel.NewRecipient("bob", "bob@example.com", "to")
+	require.NoError(t, err)
+	carol, err := channel.NewRecipient("carol", "carol@example.com", "to")
+	require.NoError(t, err)
+
+	timezones := services.NewRecipientTimezones(preferenceStore{"bob@example.com": userPreference})
+	groups, err := timezones.Group(context.Background(), []*channel.Recipient{alice, bob, carol}, "Europe/Paris")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]*channel.Recipient{
+		"America/New_York": {alice},
+		"Asia/Tokyo":       {bob},
+		"Europe/Paris":     {carol},
+	}, groups)
+
+	newYork, err := schedule.ParseLocalTime("2026-03-02T09:00", "America/New_York")
+	require.NoError(t, err)
+	tokyo, err := schedule.ParseLocalTime("2026-03-02T09:00", "Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-02T14:00:00Z", newYork.UTC().Format("2006-01-02T15:04:05Z07:00"))
+	assert.Equal(t, "2026-03-02T00:00:00Z", tokyo.UTC().Format("2006-01-02T15:04:05Z07:00"))
+	_, err = schedule.ParseLocalTime("9am", "")
+	assert.Error(t, err)
+}
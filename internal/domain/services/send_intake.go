@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// DefaultSendRetryAfter is how long a request turned away by the send intake
+// is told to wait when no delay is configured
+const DefaultSendRetryAfter = 5 * time.Second
+
+// SendIntake bounds the send requests handled at once, so that a spike does
+// not pile up on the database and the providers. At most concurrency
+// requests run, up to depth more wait for their turn in arrival order, and
+// the rest are turned away with a RETRY_LATER error.
+type SendIntake struct {
+	slots      chan struct{}
+	depth      int64
+	waiting    atomic.Int64
+	retryAfter time.Duration
+}
+
+// NewSendIntake creates a send intake. A zero retryAfter uses DefaultSendRetryAfter.
+func NewSendIntake(concurrency, depth int, retryAfter time.Duration) *SendIntake {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if depth < 0 {
+		depth = 0
+	}
+	if retryAfter <= 0 {
+		retryAfter = DefaultSendRetryAfter
+	}
+	return &SendIntake{
+		slots:      make(chan struct{}, concurrency),
+		depth:      int64(depth),
+		retryAfter: retryAfter,
+	}
+}
+
+// Enter waits for the turn of a request and returns the function ending it.
+// It fails at once when the queue is full, and when ctx ends before the turn
+// comes.
+func (i *SendIntake) Enter(ctx context.Context) (func(), error) {
+	select {
+	case i.slots <- struct{}{}:
+		return i.leave, nil
+	default:
+	}
+
+	if i.waiting.Add(1) > i.depth {
+		i.waiting.Add(-1)
+		return nil, shared.NewRetryLaterError(
+			fmt.Sprintf("the send queue is full with %d waiting requests", i.depth), i.retryAfter)
+	}
+	defer i.waiting.Add(-1)
+
+	select {
+	case i.slots <- struct{}{}:
+		return i.leave, nil
+	case <-ctx.Done():
+		return nil, shared.NewRetryLaterError("the request timed out waiting in the send queue", i.retryAfter)
+	}
+}
+
+// Load returns the number of requests running and waiting
+func (i *SendIntake) Load() (running, waiting int) {
+	return len(i.slots), int(i.waiting.Load())
+}
+
+// leave ends the turn of a request
+func (i *SendIntake) leave() {
+	<-i.slots
+}
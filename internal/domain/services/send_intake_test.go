@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/shared"
+)
+
+func TestSendIntake_TurnsAwayRequestsBeyondTheQueue(t *testing.T) {
+	intake := NewSendIntake(1, 1, 2*time.Second)
+
+	leave, err := intake.Enter(context.Background())
+	require.NoError(t, err)
+
+	entered := make(chan error, 1)
+	go func() {
+		leaveWaiting, err := intake.Enter(context.Background())
+		if err == nil {
+			leaveWaiting()
+		}
+		entered <- err
+	}()
+	require.Eventually(t, func() bool {
+		_, waiting := intake.Load()
+		return waiting == 1
+	}, time.Second, time.Millisecond)
+
+	_, err = intake.Enter(context.Background())
+	require.Error(t, err, "the queue is full")
+	assert.Equal(t, "RETRY_LATER", shared.ErrorCodeOf(err, ""))
+	assert.Equal(t, shared.ErrorKindUnavailable, shared.ErrorKindOf(err))
+	assert.Equal(t, 2*time.Second, shared.RetryAfterOf(err))
+
+	leave()
+	require.NoError(t, <-entered, "the waiting request gets its turn")
+	running, waiting := intake.Load()
+	assert.Equal(t, 0, running)
+	assert.Equal(t, 0, waiting)
+}
+
+func TestSendIntake_GivesUpWhenTheContextEnds(t *testing.T) {
+	intake := NewSendIntake(1, 1, 0)
+	leave, err := intake.Enter(context.Background())
+	require.NoError(t, err)
+	defer leave()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = intake.Enter(ctx)
+	require.Error(t, err)
+	assert.Equal(t, DefaultSendRetryAfter, shared.RetryAfterOf(err))
+}
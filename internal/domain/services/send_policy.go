@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/pkg/logger"
+)
+
+// PolicySend is the send of a message through a channel checked by the
+// pre-send policy hooks. Hooks modifying the send change Content in place.
+type PolicySend struct {
+	MessageID string
+	TenantID  string
+	Channel   *channel.Channel
+	Content   *RenderedContent
+}
+
+// PolicyVerdict is the decision of a policy hook on a send.
+type PolicyVerdict struct {
+	Action      message.PolicyAction
+	Reason      string
+	Annotations map[string]string
+}
+
+// PolicyHook checks a send before it reaches its provider, e.g. scanning it
+// for personal data. It may let it go on, change its content, annotate it or
+// block it.
+type PolicyHook interface {
+	// Name identifies the hook in the policy rules and the decisions
+	Name() string
+	Check(ctx context.Context, send *PolicySend) (*PolicyVerdict, error)
+}
+
+// SendPolicyRules selects the hooks run on each send by name: those of the
+// channel when it has an entry, else those of the tenant, else the default
+// ones. An empty entry runs no hook.
+type SendPolicyRules struct {
+	Hooks    []string
+	Tenants  map[string][]string
+	Channels map[string][]string
+}
+
+// hooksFor returns the names of the hooks run on a send of a tenant through a channel
+func (r SendPolicyRules) hooksFor(tenantID, channelID string) []string {
+	if hooks, ok := r.Channels[channelID]; ok {
+		return hooks
+	}
+	if hooks, ok := r.Tenants[tenantID]; ok {
+		return hooks
+	}
+	return r.Hooks
+}
+
+// SendPolicy is the domain service running the pre-send policy hooks of a
+// send and recording their decisions. A hook that fails is recorded and
+// skipped, so that an unavailable scanner does not stop the sends.
+type SendPolicy struct {
+	hooks  map[string]PolicyHook
+	rules  SendPolicyRules
+	logger *logger.Logger
+}
+
+// NewSendPolicy creates a send policy running hooks by the given rules.
+func NewSendPolicy(rules SendPolicyRules, logger *logger.Logger) *SendPolicy {
+	return &SendPolicy{
+		hooks:  make(map[string]PolicyHook),
+		rules:  rules,
+		logger: logger,
+	}
+}
+
+// Register makes a hook available to the rules under its name.
+func (p *SendPolicy) Register(hook PolicyHook) {
+	p.hooks[hook.Name()] = hook
+}
+
+// Check runs the hooks of a send in order until one blocks it. It returns
+// the decisions of the hooks that ran, and the blocking one, nil when the
+// send may go on.
+func (p *SendPolicy) Check(ctx context.Context, send *PolicySend) ([]*message.PolicyDecision, *message.PolicyDecision) {
+	log := p.logger.WithContext(ctx).WithFields(
+		zap.String("message_id", send.MessageID),
+		zap.String("channel_id", send.Channel.ID().String()))
+
+	var decisions []*message.PolicyDecision
+	for _, name := range p.rules.hooksFor(send.TenantID, send.Channel.ID().String()) {
+		hook, ok := p.hooks[name]
+		if !ok {
+			log.Warn("Unknown policy hook, skipped", zap.String("hook", name))
+			continue
+		}
+
+		decision := &message.PolicyDecision{Hook: name}
+		verdict, err := hook.Check(ctx, send)
+		decision.DecidedAt = time.Now().UnixMilli()
+		switch {
+		case err != nil:
+			log.Warn("Policy hook failed, send goes on", zap.String("hook", name), zap.Error(err))
+			decision.Action = message.PolicyActionError
+			decision.Reason = err.Error()
+		case verdict == nil:
+			decision.Action = message.PolicyActionAllow
+		default:
+			decision.Action = verdict.Action
+			decision.Reason = verdict.Reason
+			decision.Annotations = verdict.Annotations
+		}
+		decisions = append(decisions, decision)
+
+		if decision.Action == message.PolicyActionBlock {
+			return decisions, decision
+		}
+	}
+	return decisions, nil
+}
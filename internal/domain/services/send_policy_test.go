@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/message"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+// failingHook is a policy hook whose service is unavailable
+type failingHook struct{}
+
+func (failingHook) Name() string { return "webhook" }
+
+func (failingHook) Check(ctx context.Context, send *PolicySend) (*PolicyVerdict, error) {
+	return nil, errors.New("moderation service unavailable")
+}
+
+func TestSendPolicyRules(t *testing.T) {
+	rules := SendPolicyRules{
+		Hooks:    []string{PolicyHookPII},
+		Tenants:  map[string][]string{"acme": {PolicyHookProfanity}},
+		Channels: map[string][]string{"ch-1": {}},
+	}
+	assert.Equal(t, []string{}, rules.hooksFor("acme", "ch-1"), "the channel entry comes first, even empty")
+	assert.Equal(t, []string{PolicyHookProfanity}, rules.hooksFor("acme", "ch-2"))
+	assert.Equal(t, []string{PolicyHookPII}, rules.hooksFor("globex", "ch-2"))
+}
+
+func TestSendPolicyCheck(t *testing.T) {
+	log, err := logger.NewLogger(&config.LoggerConfig{Level: "error", Format: "json", OutputPath: os.DevNull})
+	require.NoError(t, err)
+	ch := newMQTTChannel(t, "alerts", nil)
+
+	policy := NewSendPolicy(SendPolicyRules{
+		Hooks: []string{"webhook", PolicyHookPII, PolicyHookProfanity, PolicyHookSize},
+	}, log)
+	policy.Register(failingHook{})
+	policy.Register(NewPIIScanner(false))
+	policy.Register(NewProfanityFilter([]string{"darn"}, false))
+	policy.Register(NewSizePolicy(1024))
+
+	send := &PolicySend{MessageID: "msg-1", TenantID: "acme", Channel: ch, Content: &RenderedContent{
+		Subject: "Receipt",
+		Content: "Paid with 4111 1111 1111 1111, not 1234 5678 9012 3456. SSN 123-45-6789.",
+	}}
+	decisions, blocked := policy.Check(context.Background(), send)
+	assert.Nil(t, blocked)
+	require.Len(t, decisions, 4)
+	assert.Equal(t, message.PolicyActionError, decisions[0].Action, "a failing hook does not stop the send")
+	assert.Equal(t, message.PolicyActionModify, decisions[1].Action)
+	assert.Equal(t, map[string]string{"cardNumbers": "1", "ssns": "1"}, decisions[1].Annotations)
+	assert.Equal(t, "Paid with **** 1111, not 1234 5678 9012 3456. SSN ***-**-6789.", send.Content.Content,
+		"only the numbers passing the Luhn check are masked")
+	assert.Equal(t, message.PolicyActionAllow, decisions[2].Action)
+	assert.Equal(t, message.PolicyActionAllow, decisions[3].Action)
+
+	send.Content.Content = "Darn, the build failed"
+	decisions, blocked = policy.Check(context.Background(), send)
+	require.NotNil(t, blocked)
+	assert.Equal(t, PolicyHookProfanity, blocked.Hook)
+	assert.Equal(t, map[string]string{"words": "darn"}, blocked.Annotations)
+	assert.Len(t, decisions, 3, "the hooks after a block do not run")
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/identity"
+	"notification/internal/domain/quota"
+	"notification/internal/domain/shared"
+)
+
+// ErrSenderNotVerified is returned for a send from an address no verified
+// identity of the tenant covers
+var ErrSenderNotVerified = errors.New("sender is not a verified identity of the tenant")
+
+// EmailSenderAddress returns the address an email channel sends from: its
+// from address, else its SMTP username as the email service does.
+func EmailSenderAddress(ch *channel.Channel) (string, error) {
+	sender := ""
+	for _, key := range []string{"from", "username"} {
+		if value, ok := ch.Config().Get(key); ok && value != nil {
+			if sender = strings.TrimSpace(fmt.Sprintf("%v", value)); sender != "" {
+				break
+			}
+		}
+	}
+	if sender == "" {
+		return "", errors.New("channel has no sender address (from)")
+	}
+
+	address, err := mail.ParseAddress(sender)
+	if err != nil {
+		return "", fmt.Errorf("sender address %q is invalid: %w", sender, err)
+	}
+	return address.Address, nil
+}
+
+// SenderIdentityCheck is the domain service keeping email channels from
+// sending from an address the tenant of the message has not verified.
+type SenderIdentityCheck struct {
+	repo identity.SenderIdentityRepository
+}
+
+// NewSenderIdentityCheck creates a sender identity check.
+func NewSenderIdentityCheck(repo identity.SenderIdentityRepository) *SenderIdentityCheck {
+	return &SenderIdentityCheck{repo: repo}
+}
+
+// Check checks that a channel sending for a tenant sends from one of its
+// verified identities, returning an error wrapping ErrSenderNotVerified when
+// not; messages without a tenant send for the default one. Channels other
+// than email are not checked.
+func (c *SenderIdentityCheck) Check(ctx context.Context, tenantID string, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return nil
+	}
+	if tenantID == "" {
+		tenantID = quota.DefaultTenantID
+	}
+	sender, err := EmailSenderAddress(ch)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSenderNotVerified, err)
+	}
+
+	identities, err := c.repo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to find sender identities: %w", err)
+	}
+	for _, senderIdentity := range identities {
+		if senderIdentity.IsVerified() && senderIdentity.Covers(sender) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s for tenant %s", ErrSenderNotVerified, sender, tenantID)
+}
+
+// IdentityConfirmation is the domain service emailing the confirmation link
+// of the address identities through an email channel of the operators.
+type IdentityConfirmation struct {
+	channelRepo         channel.ChannelRepository
+	notificationService ExternalNotificationService
+	channelID           *channel.ChannelID
+	// baseURL is the public address of the service the confirmation links point to
+	baseURL string
+}
+
+// NewIdentityConfirmation creates an identity confirmation sent through a channel.
+func NewIdentityConfirmation(
+	channelRepo channel.ChannelRepository,
+	notificationService ExternalNotificationService,
+	channelID *channel.ChannelID,
+	baseURL string,
+) *IdentityConfirmation {
+	return &IdentityConfirmation{
+		channelRepo:         channelRepo,
+		notificationService: notificationService,
+		channelID:           channelID,
+		baseURL:             strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Send emails the confirmation link of an address identity to the address.
+func (c *IdentityConfirmation) Send(ctx context.Context, senderIdentity *identity.SenderIdentity) error {
+	ch, err := c.channelRepo.FindByID(ctx, c.channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get confirmation channel: %w", err)
+	}
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return fmt.Errorf("confirmation channel is a %s channel, not email", ch.ChannelType())
+	}
+	if err := ch.CanSendMessage(); err != nil {
+		return fmt.Errorf("confirmation channel cannot send message: %w", err)
+	}
+
+	recipient, err := channel.NewRecipient(senderIdentity.Value, senderIdentity.Value, "to")
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("%s/identities/%s/confirm?token=%s", c.baseURL, senderIdentity.ID, url.QueryEscape(senderIdentity.Token))
+	result := c.notificationService.SendSingleNotification(ctx, &SendRequest{
+		Channel: ch.WithOverrides(channel.NewRecipients([]*channel.Recipient{recipient}), nil),
+		Content: &RenderedContent{
+			Subject: "Confirm your sender address",
+			Content: fmt.Sprintf("Tenant %s asked to send email from %s.\n\nOpen this link to confirm it:\n%s\n\n"+
+				"If you did not expect this email, ignore it.", senderIdentity.TenantID, senderIdentity.Value, link),
+		},
+	})
+	if !result.Success {
+		return fmt.Errorf("failed to send confirmation email: %w", result.Error)
+	}
+	return nil
+}
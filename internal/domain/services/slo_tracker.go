@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/slo"
+	"notification/pkg/logger"
+)
+
+// SLOPolicy holds the delivery latency objectives and where their burn-rate
+// alerts go.
+type SLOPolicy struct {
+	Objectives []slo.Objective
+	// Alerts are the burn-rate alerts of every objective, see
+	// slo.DefaultBurnRateAlerts
+	Alerts []slo.BurnRateAlert
+	// ChannelID is the operations channel the alerts are sent through; nil
+	// only logs them
+	ChannelID *channel.ChannelID
+}
+
+// SLOTracker is the domain service that measures the delivery latency
+// objectives from the creation and send times of the messages, and alerts the
+// operators through an operations channel when an error budget burns too fast.
+type SLOTracker struct {
+	messageRepo         message.MessageRepository
+	channelRepo         channel.ChannelRepository
+	notificationService ExternalNotificationService
+	policy              SLOPolicy
+	logger              *logger.Logger
+	now                 func() time.Time
+
+	mu sync.Mutex
+	// firing holds the alerts firing per objective and alert name
+	firing map[string]bool
+}
+
+// NewSLOTracker creates an SLO tracker.
+func NewSLOTracker(
+	messageRepo message.MessageRepository,
+	channelRepo channel.ChannelRepository,
+	notificationService ExternalNotificationService,
+	policy SLOPolicy,
+	logger *logger.Logger,
+) *SLOTracker {
+	return &SLOTracker{
+		messageRepo:         messageRepo,
+		channelRepo:         channelRepo,
+		notificationService: notificationService,
+		policy:              policy,
+		logger:              logger,
+		now:                 time.Now,
+		firing:              make(map[string]bool),
+	}
+}
+
+// Objectives returns the objectives tracked.
+func (t *SLOTracker) Objectives() []slo.Objective {
+	return t.policy.Objectives
+}
+
+// Status measures every objective now.
+func (t *SLOTracker) Status(ctx context.Context) ([]*slo.Status, error) {
+	now := t.now()
+	statuses := make([]*slo.Status, 0, len(t.policy.Objectives))
+	for _, objective := range t.policy.Objectives {
+		status, err := t.status(ctx, objective, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// StatusOf measures the objective of a name now, nil when none is tracked.
+func (t *SLOTracker) StatusOf(ctx context.Context, name string) (*slo.Status, error) {
+	for _, objective := range t.policy.Objectives {
+		if objective.Name == name {
+			return t.status(ctx, objective, t.now())
+		}
+	}
+	return nil, nil
+}
+
+// Evaluate measures every objective and sends an alert when one starts or
+// stops firing. An alert that could not be sent is retried on the next
+// evaluation.
+func (t *SLOTracker) Evaluate(ctx context.Context) ([]*slo.Status, error) {
+	statuses, err := t.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, status := range statuses {
+		for _, burnRate := range status.BurnRates {
+			key := status.Objective.Name + "/" + burnRate.Alert.Name
+			firing := burnRate.Firing()
+			if firing == t.firing[key] {
+				continue
+			}
+			if err := t.alert(ctx, status, burnRate); err != nil {
+				t.logger.WithContext(ctx).Error("Failed to send SLO alert",
+					zap.String("objective", status.Objective.Name),
+					zap.String("alert", burnRate.Alert.Name),
+					zap.Error(err))
+				continue
+			}
+			if firing {
+				t.firing[key] = true
+			} else {
+				delete(t.firing, key)
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// status measures an objective over its window and the windows of the alerts.
+// The periods end one threshold ago, the messages created since having yet to
+// miss it.
+func (t *SLOTracker) status(ctx context.Context, objective slo.Objective, now time.Time) (*slo.Status, error) {
+	end := now.Add(-objective.Threshold)
+	measurement, err := t.measure(ctx, objective, end.Add(-objective.Window), end)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &slo.Status{
+		Objective:   objective,
+		Measurement: measurement,
+		BurnRates:   make([]slo.BurnRate, 0, len(t.policy.Alerts)),
+		EvaluatedAt: now,
+	}
+	for _, alert := range t.policy.Alerts {
+		long, err := t.measure(ctx, objective, end.Add(-alert.Long), end)
+		if err != nil {
+			return nil, err
+		}
+		short, err := t.measure(ctx, objective, end.Add(-alert.Short), end)
+		if err != nil {
+			return nil, err
+		}
+		status.BurnRates = append(status.BurnRates, slo.BurnRate{
+			Alert: alert,
+			Long:  long.BurnRate(objective),
+			Short: short.BurnRate(objective),
+		})
+	}
+	return status, nil
+}
+
+// measure counts the messages of a period dispatched within the threshold
+func (t *SLOTracker) measure(ctx context.Context, objective slo.Objective, from, to time.Time) (slo.Measurement, error) {
+	total, dispatched, err := t.messageRepo.CountDispatched(ctx, from.UnixMilli(), to.UnixMilli(), objective.Threshold.Milliseconds())
+	if err != nil {
+		return slo.Measurement{}, fmt.Errorf("failed to measure objective %s: %w", objective.Name, err)
+	}
+	return slo.Measurement{Total: total, Dispatched: dispatched}, nil
+}
+
+// alert reports an alert starting or stopping to fire, through the operations
+// channel when there is one
+func (t *SLOTracker) alert(ctx context.Context, status *slo.Status, burnRate slo.BurnRate) error {
+	content := sloAlertContent(status, burnRate)
+	fields := []zap.Field{
+		zap.String("objective", status.Objective.Name),
+		zap.String("alert", burnRate.Alert.Name),
+		zap.Float64("burn_rate_long", burnRate.Long),
+		zap.Float64("burn_rate_short", burnRate.Short),
+		zap.Bool("firing", burnRate.Firing()),
+	}
+	if t.policy.ChannelID == nil {
+		t.logger.WithContext(ctx).Warn(content.Subject, fields...)
+		return nil
+	}
+
+	operations, err := t.channelRepo.FindByID(ctx, t.policy.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get operations channel: %w", err)
+	}
+	if err := operations.CanSendMessage(); err != nil {
+		return fmt.Errorf("operations channel cannot send message: %w", err)
+	}
+
+	result := t.notificationService.SendSingleNotification(ctx, &SendRequest{
+		Channel: operations,
+		Content: content,
+		Variables: map[string]interface{}{
+			"slo_objective":        status.Objective.Name,
+			"slo_alert":            burnRate.Alert.Name,
+			"slo_firing":           burnRate.Firing(),
+			"slo_burn_rate":        burnRate.Long,
+			"slo_budget_remaining": status.BudgetRemaining(),
+		},
+	})
+	if !result.Success {
+		return fmt.Errorf("failed to send SLO alert: %w", result.Error)
+	}
+
+	t.logger.WithContext(ctx).Info("SLO alert sent",
+		append(fields, zap.String("operations_channel_id", operations.ID().String()))...)
+	return nil
+}
+
+// sloAlertContent builds the alert of a burn rate
+func sloAlertContent(status *slo.Status, burnRate slo.BurnRate) *RenderedContent {
+	objective := status.Objective
+	subject := fmt.Sprintf("[SLO resolved] %s error budget no longer burning, %s alert", objective.Name, burnRate.Alert.Name)
+	if burnRate.Firing() {
+		subject = fmt.Sprintf("[SLO firing] %s error budget burning, %s alert", objective.Name, burnRate.Alert.Name)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Objective: %s, %.2f%% of the messages dispatched within %s over %s\n",
+		objective.Name, objective.Target*100, objective.Threshold, objective.Window)
+	fmt.Fprintf(&body, "Burn rate: %.1f over %s, %.1f over %s (threshold %.1f)\n",
+		burnRate.Long, burnRate.Alert.Long, burnRate.Short, burnRate.Alert.Short, burnRate.Alert.Threshold)
+	fmt.Fprintf(&body, "Compliance: %.3f%% of %d messages\nError budget remaining: %.1f%%",
+		status.Compliance()*100, status.Measurement.Total, status.BudgetRemaining()*100)
+
+	return &RenderedContent{
+		Subject: subject,
+		Content: body.String(),
+	}
+}
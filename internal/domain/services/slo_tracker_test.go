@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"notification/internal/domain/slo"
+)
+
+func TestSLOBurnRates(t *testing.T) {
+	objective := slo.Objective{Name: "dispatch", Threshold: 30 * time.Second, Target: 0.99, Window: 30 * 24 * time.Hour}
+
+	status := &slo.Status{Objective: objective, Measurement: slo.Measurement{Total: 1000, Dispatched: 995}}
+	assert.True(t, status.Met())
+	assert.InDelta(t, 0.5, status.BudgetRemaining(), 1e-9)
+	assert.Equal(t, 1.0, slo.Measurement{}.Compliance())
+
+	alert := slo.DefaultBurnRateAlerts[0]
+	burning := slo.BurnRate{
+		Alert: alert,
+		Long:  slo.Measurement{Total: 100, Dispatched: 80}.BurnRate(objective),
+		Short: slo.Measurement{Total: 10, Dispatched: 7}.BurnRate(objective),
+	}
+	assert.InDelta(t, 20, burning.Long, 1e-9)
+	assert.True(t, burning.Firing())
+
+	recovered := burning
+	recovered.Short = slo.Measurement{Total: 10, Dispatched: 10}.BurnRate(objective)
+	assert.False(t, recovered.Firing())
+	assert.Contains(t, sloAlertContent(status, recovered).Subject, "[SLO resolved] dispatch")
+}
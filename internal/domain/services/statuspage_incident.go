@@ -0,0 +1,178 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// Variables describing the Statuspage incident of a message, besides the
+// incident_summary and incident_severity it shares with PagerDuty messages so
+// that one notification updates both. Without them a message opens an
+// incident named by its subject and leaves its components as they are.
+const (
+	// IncidentStatusVariable is investigating, identified, monitoring or resolved
+	IncidentStatusVariable = "incident_status"
+	// StatuspageIncidentIDVariable is the incident a message updates, the
+	// provider message ID of the message that opened it
+	StatuspageIncidentIDVariable = "statuspage_incident_id"
+	// IncidentComponentsVariable lists the components of the incident,
+	// overriding the components of the channel
+	IncidentComponentsVariable = "incident_components"
+)
+
+// Config keys of Statuspage channels
+const (
+	StatuspageComponentsConfigKey = "components"
+	// StatuspageSeverityMappingConfigKey maps incident severities to component
+	// statuses, overriding the default mapping
+	StatuspageSeverityMappingConfigKey = "severity_mapping"
+)
+
+// Statuspage statuses
+const (
+	StatuspageStatusInvestigating = "investigating"
+	StatuspageStatusResolved      = "resolved"
+	// StatuspageComponentOperational is the status of the components of resolved incidents
+	StatuspageComponentOperational = "operational"
+)
+
+// statuspageIncidentStatuses are the statuses of Statuspage incidents
+var statuspageIncidentStatuses = map[string]bool{"investigating": true, "identified": true, "monitoring": true, "resolved": true}
+
+// statuspageComponentStatuses are the statuses of Statuspage components
+var statuspageComponentStatuses = map[string]bool{
+	"operational":          true,
+	"degraded_performance": true,
+	"partial_outage":       true,
+	"major_outage":         true,
+	"under_maintenance":    true,
+}
+
+// defaultStatuspageSeverityMapping maps the incident severities to component statuses
+var defaultStatuspageSeverityMapping = map[string]string{
+	"critical": "major_outage",
+	"error":    "partial_outage",
+	"warning":  "degraded_performance",
+	"info":     "operational",
+}
+
+// StatuspageIncident is an incident a message opens or updates on a
+// Statuspage page.
+type StatuspageIncident struct {
+	// ID is the incident to update, empty to open one
+	ID     string
+	Name   string
+	Status string
+	// Body is the update shown on the page, the rendered content of the message
+	Body         string
+	ComponentIDs []string
+	// ComponentStatus is the status the components are set to, empty to leave them as they are
+	ComponentStatus string
+}
+
+// StatuspageComponents reads the components of a Statuspage channel.
+func StatuspageComponents(config *channel.ChannelConfig) ([]string, error) {
+	value, ok := config.Get(StatuspageComponentsConfigKey)
+	if !ok || value == nil {
+		return nil, nil
+	}
+	entries, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of component IDs", StatuspageComponentsConfigKey)
+	}
+	components := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		id, ok := entry.(string)
+		if !ok || strings.TrimSpace(id) == "" {
+			return nil, fmt.Errorf("%s must be a list of component IDs", StatuspageComponentsConfigKey)
+		}
+		components = append(components, strings.TrimSpace(id))
+	}
+	return components, nil
+}
+
+// StatuspageSeverityMapping reads the severity mapping of a Statuspage
+// channel, the default mapping with the overrides of the channel.
+func StatuspageSeverityMapping(config *channel.ChannelConfig) (map[string]string, error) {
+	mapping := make(map[string]string, len(defaultStatuspageSeverityMapping))
+	for severity, status := range defaultStatuspageSeverityMapping {
+		mapping[severity] = status
+	}
+
+	value, ok := config.Get(StatuspageSeverityMappingConfigKey)
+	if !ok || value == nil {
+		return mapping, nil
+	}
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object mapping severities to component statuses", StatuspageSeverityMappingConfigKey)
+	}
+	for severity, entry := range entries {
+		status, ok := entry.(string)
+		if !ok || !statuspageComponentStatuses[strings.ToLower(status)] {
+			return nil, fmt.Errorf("component status of severity %s must be operational, degraded_performance, partial_outage, major_outage or under_maintenance", severity)
+		}
+		mapping[strings.ToLower(severity)] = strings.ToLower(status)
+	}
+	return mapping, nil
+}
+
+// NewStatuspageIncident builds the incident a message opens or updates
+// through a Statuspage channel from its variables and rendered content. It
+// returns nil for other channels, and an error when the variables describe no
+// valid incident.
+func NewStatuspageIncident(ch *channel.Channel, variables map[string]interface{}, content *RenderedContent) (*StatuspageIncident, error) {
+	if !ch.ChannelType().Equals(shared.ChannelTypeStatuspage) {
+		return nil, nil
+	}
+
+	incident := &StatuspageIncident{Status: StatuspageStatusInvestigating, Body: content.Content}
+	incident.ID, _ = eventVariable(variables, StatuspageIncidentIDVariable)
+	if status, ok := eventVariable(variables, IncidentStatusVariable); ok {
+		incident.Status = strings.ToLower(status)
+	}
+	if !statuspageIncidentStatuses[incident.Status] {
+		return nil, fmt.Errorf("%s must be investigating, identified, monitoring or resolved, got %q", IncidentStatusVariable, incident.Status)
+	}
+
+	// Updates keep the name of the incident unless the message renames it
+	incident.Name, _ = eventVariable(variables, IncidentSummaryVariable)
+	if incident.Name == "" && incident.ID == "" {
+		incident.Name = strings.TrimSpace(content.Subject)
+		if incident.Name == "" {
+			incident.Name, _, _ = strings.Cut(strings.TrimSpace(content.Content), "\n")
+		}
+		if incident.Name == "" {
+			return nil, fmt.Errorf("an incident needs a name, set %s or render a subject", IncidentSummaryVariable)
+		}
+	}
+
+	components, err := StatuspageComponents(ch.Config())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := variables[IncidentComponentsVariable]; ok {
+		components = eventAttendees(variables[IncidentComponentsVariable])
+	}
+	incident.ComponentIDs = components
+
+	switch severity, ok := eventVariable(variables, IncidentSeverityVariable); {
+	case len(incident.ComponentIDs) == 0:
+	case incident.Status == StatuspageStatusResolved:
+		incident.ComponentStatus = StatuspageComponentOperational
+	case ok:
+		mapping, err := StatuspageSeverityMapping(ch.Config())
+		if err != nil {
+			return nil, err
+		}
+		status, ok := mapping[strings.ToLower(severity)]
+		if !ok {
+			return nil, fmt.Errorf("%s %q has no component status in the severity mapping of the channel", IncidentSeverityVariable, severity)
+		}
+		incident.ComponentStatus = status
+	}
+	return incident, nil
+}
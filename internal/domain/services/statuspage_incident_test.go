@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+func newStatuspageChannel(t *testing.T, config map[string]interface{}) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+
+	name, err := channel.NewChannelName("status-page")
+	require.NoError(t, err)
+	settings, err := shared.NewCommonSettings(30, 3, 5)
+	require.NoError(t, err)
+
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeStatuspage, nil, settings,
+		channel.NewChannelConfig(config), channel.NewRecipients(nil), channel.NewTags(nil))
+	require.NoError(t, err)
+	return ch
+}
+
+func TestNewStatuspageIncident(t *testing.T) {
+	ch := newStatuspageChannel(t, map[string]interface{}{
+		"api_key":                          "K3Y",
+		"page_id":                          "p4g3",
+		StatuspageComponentsConfigKey:      []interface{}{"api", "dashboard"},
+		StatuspageSeverityMappingConfigKey: map[string]interface{}{"warning": "partial_outage"},
+	})
+	content := &RenderedContent{Subject: "Elevated API errors", Content: "We are looking into it."}
+
+	incident, err := NewStatuspageIncident(ch, map[string]interface{}{IncidentSeverityVariable: "critical"}, content)
+	require.NoError(t, err)
+	assert.Equal(t, &StatuspageIncident{
+		Name:            "Elevated API errors",
+		Status:          StatuspageStatusInvestigating,
+		Body:            "We are looking into it.",
+		ComponentIDs:    []string{"api", "dashboard"},
+		ComponentStatus: "major_outage",
+	}, incident)
+
+	incident, err = NewStatuspageIncident(ch, map[string]interface{}{
+		IncidentSeverityVariable:   "Warning",
+		IncidentComponentsVariable: "api",
+		IncidentStatusVariable:     "identified",
+	}, content)
+	require.NoError(t, err)
+	assert.Equal(t, "partial_outage", incident.ComponentStatus, "the channel overrides the status of warnings")
+	assert.Equal(t, []string{"api"}, incident.ComponentIDs)
+	assert.Equal(t, "identified", incident.Status)
+
+	incident, err = NewStatuspageIncident(ch, map[string]interface{}{
+		StatuspageIncidentIDVariable: "inc-1",
+		IncidentStatusVariable:       "resolved",
+		IncidentSeverityVariable:     "critical",
+	}, &RenderedContent{Content: "Fixed."})
+	require.NoError(t, err)
+	assert.Equal(t, "inc-1", incident.ID)
+	assert.Empty(t, incident.Name, "updates keep the name of the incident")
+	assert.Equal(t, StatuspageComponentOperational, incident.ComponentStatus, "resolving sets the components back to operational")
+
+	incident, err = NewStatuspageIncident(ch, nil, content)
+	require.NoError(t, err)
+	assert.Empty(t, incident.ComponentStatus, "without a severity the components are left as they are")
+
+	_, err = NewStatuspageIncident(ch, map[string]interface{}{IncidentStatusVariable: "closed"}, content)
+	assert.ErrorContains(t, err, IncidentStatusVariable)
+	_, err = NewStatuspageIncident(ch, map[string]interface{}{IncidentSeverityVariable: "fatal"}, content)
+	assert.ErrorContains(t, err, IncidentSeverityVariable)
+	_, err = NewStatuspageIncident(ch, nil, &RenderedContent{})
+	assert.ErrorContains(t, err, IncidentSummaryVariable)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+// TemplateDeletionPolicy decides what happens to channels that reference a template being deleted.
+type TemplateDeletionPolicy string
+
+const (
+	// TemplateDeletionRestrict refuses to delete a template that channels still reference.
+	TemplateDeletionRestrict TemplateDeletionPolicy = "restrict"
+	// TemplateDeletionDetach removes the template reference from the channels, leaving them without a template.
+	TemplateDeletionDetach TemplateDeletionPolicy = "detach"
+	// TemplateDeletionReassign moves the channels to a replacement template of the same channel type.
+	TemplateDeletionReassign TemplateDeletionPolicy = "reassign"
+)
+
+// TemplateIntegrityService is the domain service that keeps channel template references valid.
+type TemplateIntegrityService struct {
+	channelRepo channel.ChannelRepository
+}
+
+// NewTemplateIntegrityService creates a template integrity service.
+func NewTemplateIntegrityService(channelRepo channel.ChannelRepository) *TemplateIntegrityService {
+	return &TemplateIntegrityService{
+		channelRepo: channelRepo,
+	}
+}
+
+// CountReferences counts the channels that reference the template.
+func (s *TemplateIntegrityService) CountReferences(ctx context.Context, templateID *template.TemplateID) (int, error) {
+	filter := channel.NewChannelFilter().WithTemplateID(templateID)
+	pagination, err := shared.NewPagination(0, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.channelRepo.FindAll(ctx, filter, pagination)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count channels using template: %w", err)
+	}
+
+	return result.TotalCount, nil
+}
+
+// ReleaseTemplate applies the deletion policy to the channels referencing the
+// template so that it can be deleted. The replacement is only used by the
+// reassign policy. It returns the number of channels that still referenced the template.
+func (s *TemplateIntegrityService) ReleaseTemplate(
+	ctx context.Context,
+	tmpl *template.Template,
+	policy TemplateDeletionPolicy,
+	replacement *template.Template,
+) (int, error) {
+	references, err := s.CountReferences(ctx, tmpl.ID())
+	if err != nil {
+		return 0, err
+	}
+
+	switch policy {
+	case TemplateDeletionRestrict:
+		if references > 0 {
+			return references, shared.NewConflictError("TEMPLATE_IN_USE", fmt.Sprintf("template is referenced by %d channel(s); reassign them or delete with force", references))
+		}
+		return references, nil
+
+	case TemplateDeletionDetach:
+		if err := s.channelRepo.ReassignTemplate(ctx, tmpl.ID(), nil); err != nil {
+			return references, fmt.Errorf("failed to detach channels from template: %w", err)
+		}
+		return references, nil
+
+	case TemplateDeletionReassign:
+		if replacement == nil {
+			return references, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("a replacement template is required"))
+		}
+		if replacement.ID().Equals(tmpl.ID()) {
+			return references, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("replacement template must differ from the deleted template"))
+		}
+		if replacement.ChannelType() != tmpl.ChannelType() {
+			return references, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("replacement template channel type %s does not match %s", replacement.ChannelType(), tmpl.ChannelType()))
+		}
+		if err := s.channelRepo.ReassignTemplate(ctx, tmpl.ID(), replacement.ID()); err != nil {
+			return references, fmt.Errorf("failed to reassign channels to replacement template: %w", err)
+		}
+		return references, nil
+
+	default:
+		return references, shared.NewValidationError("INVALID_REQUEST", fmt.Errorf("unknown template deletion policy: %s", policy))
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/pkg/logger"
+)
+
+// MirrorMode selects what a mirrored send carries.
+type MirrorMode string
+
+const (
+	// MirrorModeMetadata mirrors a description of the send without its content
+	MirrorModeMetadata MirrorMode = "metadata"
+	// MirrorModeFull mirrors the rendered subject and content
+	MirrorModeFull MirrorMode = "full"
+)
+
+// TrafficMirrorPolicy holds which share of the sends is mirrored and where to.
+type TrafficMirrorPolicy struct {
+	// Percent is the share of the sends mirrored, from 0 to 100
+	Percent int
+	Mode    MirrorMode
+	// ShadowChannelID is the channel the mirrored sends go to, nil logs them instead
+	ShadowChannelID *channel.ChannelID
+}
+
+// TrafficMirror is the domain service that copies a sample of the production
+// sends to a shadow channel or the log, so that template changes can be
+// checked without reaching real recipients.
+type TrafficMirror struct {
+	channelRepo         channel.ChannelRepository
+	notificationService ExternalNotificationService
+	policy              TrafficMirrorPolicy
+	logger              *logger.Logger
+	// sample returns a number from 0 to 99 deciding whether a send is mirrored
+	sample func() int
+}
+
+// NewTrafficMirror creates a traffic mirror.
+func NewTrafficMirror(
+	channelRepo channel.ChannelRepository,
+	notificationService ExternalNotificationService,
+	policy TrafficMirrorPolicy,
+	logger *logger.Logger,
+) *TrafficMirror {
+	return &TrafficMirror{
+		channelRepo:         channelRepo,
+		notificationService: notificationService,
+		policy:              policy,
+		logger:              logger,
+		sample:              func() int { return rand.IntN(100) },
+	}
+}
+
+// Mirror copies a send when it falls in the sampled share. The copy is made in
+// the background; its failures are logged and never affect the send.
+func (m *TrafficMirror) Mirror(ctx context.Context, request *SendRequest, result *SendResult) {
+	if m.policy.ShadowChannelID != nil && request.Channel.ID().Equals(m.policy.ShadowChannelID) {
+		return
+	}
+	if m.sample() >= m.policy.Percent {
+		return
+	}
+
+	go m.mirror(context.WithoutCancel(ctx), request, result)
+}
+
+// mirror sends the copy of a send to the shadow channel, or logs it
+func (m *TrafficMirror) mirror(ctx context.Context, request *SendRequest, result *SendResult) {
+	log := m.logger.WithContext(ctx).WithFields(
+		zap.String("source_channel_id", request.Channel.ID().String()),
+		zap.String("source_channel_type", request.Channel.ChannelType().String()),
+		zap.String("mirror_mode", string(m.policy.Mode)),
+		zap.Bool("send_success", result.Success))
+	content := m.content(request, result)
+
+	if m.policy.ShadowChannelID == nil {
+		log.Info("Mirrored send",
+			zap.String("subject", content.Subject),
+			zap.String("content", content.Content))
+		return
+	}
+
+	shadow, err := m.channelRepo.FindByID(ctx, m.policy.ShadowChannelID)
+	if err != nil {
+		log.Warn("Failed to get shadow channel", zap.Error(err))
+		return
+	}
+	if err := shadow.CanSendMessage(); err != nil {
+		log.Warn("Shadow channel cannot send message", zap.Error(err))
+		return
+	}
+
+	mirrorRequest := &SendRequest{
+		Channel:       shadow,
+		Content:       content,
+		CorrelationID: request.CorrelationID,
+	}
+	if m.policy.Mode == MirrorModeFull {
+		mirrorRequest.Variables = request.Variables
+	}
+
+	mirrorResult := m.notificationService.SendSingleNotification(ctx, mirrorRequest)
+	if !mirrorResult.Success {
+		log.Warn("Failed to mirror send to shadow channel",
+			zap.String("shadow_channel_id", shadow.ID().String()),
+			zap.Error(mirrorResult.Error))
+		return
+	}
+
+	log.Debug("Send mirrored to shadow channel",
+		zap.String("shadow_channel_id", shadow.ID().String()))
+}
+
+// content builds the content of the copy of a send
+func (m *TrafficMirror) content(request *SendRequest, result *SendResult) *RenderedContent {
+	source := request.Channel
+	if m.policy.Mode == MirrorModeFull {
+		return &RenderedContent{
+			Subject:        fmt.Sprintf("[Mirror: %s] %s", source.Name().String(), request.Content.Subject),
+			Content:        request.Content.Content,
+			Attachments:    request.Content.Attachments,
+			CalendarInvite: request.Content.CalendarInvite,
+		}
+	}
+
+	outcome := "sent"
+	if !result.Success {
+		outcome = "failed"
+	}
+	return &RenderedContent{
+		Subject: fmt.Sprintf("[Mirror: %s] send %s", source.Name().String(), outcome),
+		Content: fmt.Sprintf("Channel: %s (%s)\nCorrelation ID: %s\nSubject length: %d\nContent length: %d\nOutcome: %s",
+			source.ID().String(), source.ChannelType().String(), request.CorrelationID,
+			len(request.Content.Subject), len(request.Content.Content), outcome),
+	}
+}
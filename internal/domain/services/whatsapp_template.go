@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+// Config keys of WhatsApp channels
+const (
+	// WhatsAppTemplateMappingsConfigKey maps the ID of a local template to the
+	// pre-approved WhatsApp template sent in its place
+	WhatsAppTemplateMappingsConfigKey = "template_mappings"
+	// WhatsAppSessionFallbackConfigKey sends the rendered content as a session
+	// message when the template of a message is not mapped
+	WhatsAppSessionFallbackConfigKey = "session_fallback"
+)
+
+// ErrWhatsAppTemplateUnmapped is returned when a message of a WhatsApp
+// channel has no pre-approved template and may not fall back to a session
+// message.
+var ErrWhatsAppTemplateUnmapped = errors.New("template is not mapped to a WhatsApp template and session_fallback is disabled")
+
+// WhatsAppTemplateMapping maps a local template to a pre-approved WhatsApp
+// template. Parameters names, in the order of the placeholders {{1}}, {{2}},
+// ... of the body of the WhatsApp template, the variables filling them.
+type WhatsAppTemplateMapping struct {
+	Name       string
+	Language   string
+	Parameters []string
+}
+
+// WhatsAppTemplate is a pre-approved WhatsApp template with the values of its
+// body parameters, in order.
+type WhatsAppTemplate struct {
+	Name       string
+	Language   string
+	Parameters []string
+}
+
+// WhatsAppTemplateMappings reads the template mappings of a WhatsApp channel,
+// keyed by local template ID.
+func WhatsAppTemplateMappings(config *channel.ChannelConfig) (map[string]*WhatsAppTemplateMapping, error) {
+	value, ok := config.Get(WhatsAppTemplateMappingsConfigKey)
+	if !ok || value == nil {
+		return nil, nil
+	}
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object keyed by template ID", WhatsAppTemplateMappingsConfigKey)
+	}
+
+	mappings := make(map[string]*WhatsAppTemplateMapping, len(entries))
+	for templateID, entry := range entries {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("mapping of template %s must be an object", templateID)
+		}
+		mapping := &WhatsAppTemplateMapping{}
+		mapping.Name, _ = fields["name"].(string)
+		if strings.TrimSpace(mapping.Name) == "" {
+			return nil, fmt.Errorf("mapping of template %s needs the name of a WhatsApp template", templateID)
+		}
+		mapping.Language, _ = fields["language"].(string)
+		if strings.TrimSpace(mapping.Language) == "" {
+			return nil, fmt.Errorf("mapping of template %s needs a language code", templateID)
+		}
+		if parameters, exists := fields["parameters"]; exists && parameters != nil {
+			names, ok := parameters.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("parameters of template %s must be a list of variable names", templateID)
+			}
+			for _, name := range names {
+				variable, ok := name.(string)
+				if !ok || strings.TrimSpace(variable) == "" {
+					return nil, fmt.Errorf("parameters of template %s must be a list of variable names", templateID)
+				}
+				mapping.Parameters = append(mapping.Parameters, variable)
+			}
+		}
+		mappings[templateID] = mapping
+	}
+	return mappings, nil
+}
+
+// WhatsAppSessionFallback checks if a WhatsApp channel sends session messages
+// for the messages without a pre-approved template.
+func WhatsAppSessionFallback(config *channel.ChannelConfig) bool {
+	value, ok := config.Get(WhatsAppSessionFallbackConfigKey)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		enabled, _ := strconv.ParseBool(v)
+		return enabled
+	}
+	return false
+}
+
+// NewWhatsAppTemplate resolves the WhatsApp template of a message sent
+// through a WhatsApp channel with the local template of the given ID, empty
+// when the channel has none. It returns nil when the message is sent as a
+// session message instead, which WhatsApp only delivers within 24 hours of
+// the last message of the recipient, and ErrWhatsAppTemplateUnmapped when
+// the channel does not allow it.
+func NewWhatsAppTemplate(ch *channel.Channel, templateID string, variables map[string]interface{}) (*WhatsAppTemplate, error) {
+	if !ch.ChannelType().Equals(shared.ChannelTypeWhatsApp) {
+		return nil, nil
+	}
+
+	mappings, err := WhatsAppTemplateMappings(ch.Config())
+	if err != nil {
+		return nil, err
+	}
+	mapping, ok := mappings[templateID]
+	if templateID == "" || !ok {
+		if WhatsAppSessionFallback(ch.Config()) {
+			return nil, nil
+		}
+		return nil, ErrWhatsAppTemplateUnmapped
+	}
+
+	whatsAppTemplate := &WhatsAppTemplate{
+		Name:       mapping.Name,
+		Language:   mapping.Language,
+		Parameters: make([]string, 0, len(mapping.Parameters)),
+	}
+	for i, name := range mapping.Parameters {
+		// WhatsApp rejects empty parameters, so a missing variable fails the send
+		value, ok := eventVariable(variables, name)
+		if !ok {
+			return nil, fmt.Errorf("variable %s of parameter {{%d}} of WhatsApp template %s has no value", name, i+1, mapping.Name)
+		}
+		whatsAppTemplate.Parameters = append(whatsAppTemplate.Parameters, value)
+	}
+	return whatsAppTemplate, nil
+}
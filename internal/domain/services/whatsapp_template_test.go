@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+)
+
+func newWhatsAppChannel(t *testing.T, config map[string]interface{}) *channel.Channel {
+	t.Helper()
+	shared.InitializeChannelTypes()
+
+	name, err := channel.NewChannelName("order-updates")
+	require.NoError(t, err)
+	settings, err := shared.NewCommonSettings(30, 3, 5)
+	require.NoError(t, err)
+	recipient, err := channel.NewRecipient("Customer", "+15550100", "phone")
+	require.NoError(t, err)
+
+	ch, err := channel.NewChannel(name, nil, true, shared.ChannelTypeWhatsApp, nil, settings,
+		channel.NewChannelConfig(config), channel.NewRecipients([]*channel.Recipient{recipient}), channel.NewTags(nil))
+	require.NoError(t, err)
+	return ch
+}
+
+func TestNewWhatsAppTemplate(t *testing.T) {
+	ch := newWhatsAppChannel(t, map[string]interface{}{
+		WhatsAppTemplateMappingsConfigKey: map[string]interface{}{
+			"template_shipped": map[string]interface{}{
+				"name":       "order_shipped",
+				"language":   "en_US",
+				"parameters": []interface{}{"customer", "order_id"},
+			},
+		},
+	})
+
+	whatsAppTemplate, err := NewWhatsAppTemplate(ch, "template_shipped", map[string]interface{}{"order_id": 42, "customer": "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "order_shipped", whatsAppTemplate.Name)
+	assert.Equal(t, "en_US", whatsAppTemplate.Language)
+	assert.Equal(t, []string{"Ada", "42"}, whatsAppTemplate.Parameters, "parameters follow the order of the mapping")
+
+	_, err = NewWhatsAppTemplate(ch, "template_shipped", map[string]interface{}{"customer": "Ada"})
+	assert.ErrorContains(t, err, "order_id")
+
+	// Unmapped templates are refused unless the channel falls back to session messages
+	_, err = NewWhatsAppTemplate(ch, "template_other", nil)
+	assert.ErrorIs(t, err, ErrWhatsAppTemplateUnmapped)
+	_, err = NewWhatsAppTemplate(ch, "", nil)
+	assert.ErrorIs(t, err, ErrWhatsAppTemplateUnmapped)
+
+	ch = newWhatsAppChannel(t, map[string]interface{}{WhatsAppSessionFallbackConfigKey: true})
+	whatsAppTemplate, err = NewWhatsAppTemplate(ch, "template_other", nil)
+	require.NoError(t, err)
+	assert.Nil(t, whatsAppTemplate, "the message is sent as a session message")
+}
+
+func TestWhatsAppTemplateMappings_Invalid(t *testing.T) {
+	for _, mappings := range []interface{}{
+		"order_shipped",
+		map[string]interface{}{"template_shipped": map[string]interface{}{"language": "en_US"}},
+		map[string]interface{}{"template_shipped": map[string]interface{}{"name": "order_shipped"}},
+		map[string]interface{}{"template_shipped": map[string]interface{}{"name": "order_shipped", "language": "en_US", "parameters": "order_id"}},
+	} {
+		_, err := WhatsAppTemplateMappings(channel.NewChannelConfig(map[string]interface{}{WhatsAppTemplateMappingsConfigKey: mappings}))
+		assert.Error(t, err, "%v", mappings)
+	}
+}
@@ -0,0 +1,85 @@
+package shared
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Change types, named after the CQRS events of the same changes
+const (
+	ChangeChannelCreated  = "channel.created"
+	ChangeChannelUpdated  = "channel.updated"
+	ChangeChannelDeleted  = "channel.deleted"
+	ChangeChannelEnabled  = "channel.enabled"
+	ChangeChannelDisabled = "channel.disabled"
+
+	ChangeTemplateCreated = "template.created"
+	ChangeTemplateUpdated = "template.updated"
+	ChangeTemplateDeleted = "template.deleted"
+)
+
+// Change describes a change to a channel or template for the services and
+// caches depending on it. Before is nil for a creation and After for a
+// deletion; both carry the version of the resource.
+type Change struct {
+	ID           string      `json:"id"`
+	Type         string      `json:"type"`
+	ResourceType string      `json:"resourceType"`
+	ResourceID   string      `json:"resourceId"`
+	Actor        string      `json:"actor,omitempty"`
+	Before       interface{} `json:"before,omitempty"`
+	After        interface{} `json:"after,omitempty"`
+	OccurredAt   int64       `json:"occurredAt"`
+}
+
+// NewChange creates a change of the given type made by the actor of the context
+func NewChange(ctx context.Context, changeType, resourceID string, before, after interface{}) *Change {
+	resourceType, _, _ := strings.Cut(changeType, ".")
+	return &Change{
+		ID:           uuid.NewString(),
+		Type:         changeType,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Actor:        ActorFromContext(ctx),
+		Before:       before,
+		After:        after,
+		OccurredAt:   time.Now().UnixMilli(),
+	}
+}
+
+// ChangeNotifier is told about the changes to the channels and templates.
+// Notifying must not fail the change, so implementations log their errors.
+type ChangeNotifier interface {
+	Changed(ctx context.Context, change *Change)
+}
+
+// ChangeNotifiers fans a change out to several notifiers
+type ChangeNotifiers []ChangeNotifier
+
+// Changed implements ChangeNotifier
+func (n ChangeNotifiers) Changed(ctx context.Context, change *Change) {
+	for _, notifier := range n {
+		notifier.Changed(ctx, change)
+	}
+}
+
+// actorKey carries the identity a request was authenticated as
+type actorKey struct{}
+
+// WithActor returns a context recording who makes the changes done with it
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor recorded in the context, or an empty
+// string for anonymous requests and background jobs
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
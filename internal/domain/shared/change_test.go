@@ -0,0 +1,28 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChange(t *testing.T) {
+	ctx := WithActor(context.Background(), "alice")
+	change := NewChange(ctx, ChangeTemplateDeleted, "tpl-1", map[string]string{"name": "welcome"}, nil)
+
+	assert.NotEmpty(t, change.ID)
+	assert.Equal(t, "template", change.ResourceType)
+	assert.Equal(t, "tpl-1", change.ResourceID)
+	assert.Equal(t, "alice", change.Actor)
+
+	// A deletion has no new version
+	payload, err := json.Marshal(change)
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `"before":{"name":"welcome"}`)
+	assert.NotContains(t, string(payload), `"after"`)
+
+	assert.Empty(t, ActorFromContext(context.Background()))
+}
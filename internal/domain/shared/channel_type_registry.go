@@ -27,6 +27,37 @@ type ChannelTypeDefinition interface {
 	CreateMessageSender(timeout time.Duration) (interface{}, error)
 }
 
+// ContentCapabilities describes the template content a channel type can send
+type ContentCapabilities struct {
+	// HTML is set when the content is sent as HTML rather than as text
+	HTML bool `json:"html"`
+	// Attachments is set when templates may carry attachments
+	Attachments bool `json:"attachments"`
+	// Subject is set when the subject is sent, SubjectRequired when templates must have one
+	Subject         bool `json:"subject"`
+	SubjectRequired bool `json:"subjectRequired"`
+	// MaxLength and MaxSubjectLength are the longest content and subject, in
+	// characters, before they are refused or truncated; 0 when unlimited
+	MaxLength        int `json:"maxLength"`
+	MaxSubjectLength int `json:"maxSubjectLength"`
+}
+
+// ContentDescriber is implemented by the channel type definitions that
+// describe the template content they can send
+type ContentDescriber interface {
+	GetContentCapabilities() ContentCapabilities
+}
+
+// ContentCapabilitiesOf returns the template content a channel type can send.
+// Channel types that do not describe it, such as plugins, are assumed to send
+// plain text content only.
+func ContentCapabilitiesOf(channelType ChannelTypeDefinition) ContentCapabilities {
+	if describer, ok := channelType.(ContentDescriber); ok {
+		return describer.GetContentCapabilities()
+	}
+	return ContentCapabilities{}
+}
+
 // ChannelTypeRegistry manages all registered channel types
 type ChannelTypeRegistry interface {
 	// RegisterChannelType registers a new channel type
@@ -0,0 +1,28 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// plainChannelType is a channel type that does not describe its content, like a plugin
+type plainChannelType struct {
+	ChannelTypeDefinition
+}
+
+func (plainChannelType) GetName() string { return "plain" }
+
+func TestContentCapabilitiesOf(t *testing.T) {
+	email := ContentCapabilitiesOf(newEmailChannelType())
+	assert.True(t, email.HTML)
+	assert.True(t, email.Attachments)
+	assert.True(t, email.SubjectRequired)
+
+	sms := ContentCapabilitiesOf(newSMSChannelType())
+	assert.False(t, sms.HTML)
+	assert.Equal(t, 1600, sms.MaxLength)
+
+	var plain ChannelTypeDefinition = plainChannelType{newEmailChannelType()}
+	assert.Equal(t, ContentCapabilities{}, ContentCapabilitiesOf(plain))
+}
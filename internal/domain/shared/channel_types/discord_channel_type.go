@@ -0,0 +1,117 @@
+package channel_types
+
+import (
+	"errors"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// DiscordChannelType implements ChannelTypeDefinition for Discord channels
+type DiscordChannelType struct{}
+
+// GetName returns the channel type name
+func (d *DiscordChannelType) GetName() string {
+	return "discord"
+}
+
+// GetDisplayName returns the display name
+func (d *DiscordChannelType) GetDisplayName() string {
+	return "Discord"
+}
+
+// GetDescription returns the description
+func (d *DiscordChannelType) GetDescription() string {
+	return "Send notifications to Discord channels and threads through a webhook or a bot"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (d *DiscordChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true, MaxLength: 4096, MaxSubjectLength: 256}
+}
+
+// ValidateConfig validates the Discord channel configuration
+func (d *DiscordChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("discord configuration cannot be nil")
+	}
+
+	// Validate mode
+	mode, ok := config["mode"].(string)
+	if !ok || mode == "" {
+		return errors.New("mode is required for discord channel")
+	}
+
+	switch mode {
+	case "webhook":
+		webhookURL, ok := config["webhook_url"].(string)
+		if !ok || webhookURL == "" {
+			return errors.New("webhook_url is required for Discord webhook mode")
+		}
+	case "bot":
+		botToken, ok := config["bot_token"].(string)
+		if !ok || botToken == "" {
+			return errors.New("bot_token is required for Discord bot mode")
+		}
+	default:
+		return errors.New("unsupported discord mode: " + mode)
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for Discord channels
+func (d *DiscordChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"mode": map[string]interface{}{
+				"type":        "string",
+				"description": "Post through a channel webhook, or as a bot to the channels and threads of the recipients",
+				"enum":        []string{"webhook", "bot"},
+				"example":     "webhook",
+			},
+			"webhook_url": map[string]interface{}{
+				"type":        "string",
+				"description": "Discord webhook URL, required in webhook mode",
+				"example":     "https://discord.com/api/webhooks/123/abc",
+			},
+			"bot_token": map[string]interface{}{
+				"type":        "string",
+				"description": "Discord bot token, required in bot mode",
+				"format":      "password",
+			},
+			"username": map[string]interface{}{
+				"type":        "string",
+				"description": "Name the webhook posts as",
+				"example":     "Notifications",
+			},
+			"avatar_url": map[string]interface{}{
+				"type":        "string",
+				"description": "Avatar the webhook posts with",
+			},
+			"embed": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Send the subject and the content as an embed instead of a plain message",
+				"default":     false,
+			},
+			"embed_color": map[string]interface{}{
+				"type":        "string",
+				"description": "Color of the embed as a hex RGB value",
+				"example":     "#5865F2",
+			},
+		},
+		"required": []string{"mode"},
+	}
+}
+
+// CreateMessageSender creates a Discord message sender
+func (d *DiscordChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "discord_service", nil
+}
+
+// NewDiscordChannelType creates a new Discord channel type definition
+func NewDiscordChannelType() shared.ChannelTypeDefinition {
+	return &DiscordChannelType{}
+}
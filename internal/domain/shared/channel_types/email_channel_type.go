@@ -27,6 +27,11 @@ func (e *EmailChannelType) GetDescription() string {
 	return "Send notifications via email using SMTP"
 }
 
+// GetContentCapabilities returns the template content the channel type can send
+func (e *EmailChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{HTML: true, Attachments: true, Subject: true, SubjectRequired: true}
+}
+
 // ValidateConfig validates the email channel configuration
 func (e *EmailChannelType) ValidateConfig(config map[string]interface{}) error {
 	if config == nil {
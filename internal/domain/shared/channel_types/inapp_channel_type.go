@@ -0,0 +1,72 @@
+package channel_types
+
+import (
+	"errors"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// InAppChannelType implements ChannelTypeDefinition for in-app channels
+type InAppChannelType struct{}
+
+// GetName returns the channel type name
+func (i *InAppChannelType) GetName() string {
+	return "inapp"
+}
+
+// GetDisplayName returns the display name
+func (i *InAppChannelType) GetDisplayName() string {
+	return "In-App"
+}
+
+// GetDescription returns the description
+func (i *InAppChannelType) GetDescription() string {
+	return "Store notifications in the notification center of the recipient users, pushed to their connected clients"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (i *InAppChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true, MaxSubjectLength: 255}
+}
+
+// ValidateConfig validates the in-app channel configuration
+func (i *InAppChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("inapp configuration cannot be nil")
+	}
+
+	// Validate category
+	if category, exists := config["category"]; exists {
+		if _, ok := category.(string); !ok {
+			return errors.New("category must be a string")
+		}
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for in-app channels
+func (i *InAppChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"category": map[string]interface{}{
+				"type":        "string",
+				"description": "Category of the notifications, for clients to group or decorate them",
+				"maxLength":   100,
+			},
+		},
+	}
+}
+
+// CreateMessageSender creates an in-app message sender
+func (i *InAppChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "inapp_service", nil
+}
+
+// NewInAppChannelType creates a new in-app channel type definition
+func NewInAppChannelType() shared.ChannelTypeDefinition {
+	return &InAppChannelType{}
+}
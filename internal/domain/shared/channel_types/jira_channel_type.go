@@ -0,0 +1,126 @@
+package channel_types
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// JiraChannelType implements ChannelTypeDefinition for Jira channels
+type JiraChannelType struct{}
+
+// GetName returns the channel type name
+func (j *JiraChannelType) GetName() string {
+	return "jira"
+}
+
+// GetDisplayName returns the display name
+func (j *JiraChannelType) GetDisplayName() string {
+	return "Jira"
+}
+
+// GetDescription returns the description
+func (j *JiraChannelType) GetDescription() string {
+	return "Create Jira issues summarized by the subject and described by the content of the messages"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (j *JiraChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true, SubjectRequired: true, MaxSubjectLength: 255}
+}
+
+// ValidateConfig validates the Jira channel configuration
+func (j *JiraChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("jira configuration cannot be nil")
+	}
+
+	// Validate site
+	baseURL, ok := config["base_url"].(string)
+	if !ok || baseURL == "" {
+		return errors.New("base_url is required for jira channel")
+	}
+	if !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+		return errors.New("base_url must be an http or https URL")
+	}
+
+	// Validate project
+	projectKey, ok := config["project_key"].(string)
+	if !ok || projectKey == "" {
+		return errors.New("project_key is required for jira channel")
+	}
+
+	// Validate credentials, an API token of an account or a personal access token
+	accessToken, _ := config["access_token"].(string)
+	apiToken, _ := config["api_token"].(string)
+	email, _ := config["email"].(string)
+	if accessToken == "" && (apiToken == "" || email == "") {
+		return errors.New("email and api_token, or access_token, are required for jira channel")
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for Jira channels
+func (j *JiraChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url": map[string]interface{}{
+				"type":        "string",
+				"description": "URL of the Jira site, e.g. https://example.atlassian.net",
+				"format":      "uri",
+			},
+			"email": map[string]interface{}{
+				"type":        "string",
+				"description": "Email of the Jira Cloud account creating the issues",
+				"format":      "email",
+			},
+			"api_token": map[string]interface{}{
+				"type":        "string",
+				"description": "API token of the Jira Cloud account",
+				"format":      "password",
+			},
+			"access_token": map[string]interface{}{
+				"type":        "string",
+				"description": "Personal access token of Jira Data Center, used instead of email and api_token",
+				"format":      "password",
+			},
+			"project_key": map[string]interface{}{
+				"type":        "string",
+				"description": "Key of the project the issues are created in",
+			},
+			"issue_type": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the type of the issues",
+				"default":     "Task",
+			},
+			"labels": map[string]interface{}{
+				"type":        "array",
+				"description": "Labels of the issues",
+				"items": map[string]interface{}{
+					"type":    "string",
+					"pattern": "^\\S+$",
+				},
+			},
+			"priority": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the priority of the issues, the default priority of the project when empty",
+			},
+		},
+		"required": []string{"base_url", "project_key"},
+	}
+}
+
+// CreateMessageSender creates a Jira message sender
+func (j *JiraChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "jira_service", nil
+}
+
+// NewJiraChannelType creates a new Jira channel type definition
+func NewJiraChannelType() shared.ChannelTypeDefinition {
+	return &JiraChannelType{}
+}
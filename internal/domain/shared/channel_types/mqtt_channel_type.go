@@ -0,0 +1,156 @@
+package channel_types
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// MQTTChannelType implements ChannelTypeDefinition for MQTT channels
+type MQTTChannelType struct{}
+
+// GetName returns the channel type name
+func (m *MQTTChannelType) GetName() string {
+	return "mqtt"
+}
+
+// GetDisplayName returns the display name
+func (m *MQTTChannelType) GetDisplayName() string {
+	return "MQTT"
+}
+
+// GetDescription returns the description
+func (m *MQTTChannelType) GetDescription() string {
+	return "Publish messages to the topics of an MQTT broker for IoT devices and embedded consumers"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (m *MQTTChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true, MaxLength: 256 * 1024}
+}
+
+// ValidateConfig validates the MQTT channel configuration
+func (m *MQTTChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("mqtt configuration cannot be nil")
+	}
+
+	// Validate broker
+	brokerURL, ok := config["broker_url"].(string)
+	if !ok || brokerURL == "" {
+		return errors.New("broker_url is required for mqtt channel")
+	}
+	scheme, _, found := strings.Cut(brokerURL, "://")
+	if !found {
+		return errors.New("broker_url must be a URL, e.g. tls://broker.example.com:8883")
+	}
+	switch scheme {
+	case "tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss":
+	default:
+		return errors.New("broker_url scheme must be tcp, mqtt, ssl, tls, mqtts, ws or wss")
+	}
+
+	// Validate topic
+	topic, ok := config["topic"].(string)
+	if !ok || topic == "" {
+		return errors.New("topic is required for mqtt channel")
+	}
+
+	// Validate QoS
+	if qos, exists := config["qos"]; exists {
+		switch qos {
+		case 0, 1, 2, float64(0), float64(1), float64(2):
+		default:
+			return errors.New("qos must be 0, 1 or 2")
+		}
+	}
+
+	// Validate TLS client certificate
+	clientCert, _ := config["client_cert"].(string)
+	clientKey, _ := config["client_key"].(string)
+	if (clientCert == "") != (clientKey == "") {
+		return errors.New("client_cert and client_key must be set together")
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for MQTT channels
+func (m *MQTTChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"broker_url": map[string]interface{}{
+				"type":        "string",
+				"description": "URL of the broker, e.g. tls://broker.example.com:8883",
+				"format":      "uri",
+			},
+			"topic": map[string]interface{}{
+				"type":        "string",
+				"description": "Topic template, {variable} placeholders take the message variables and {recipient} publishes once per recipient",
+			},
+			"qos": map[string]interface{}{
+				"type":        "integer",
+				"description": "Quality of service of the publications",
+				"enum":        []int{0, 1, 2},
+				"default":     1,
+			},
+			"retain": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether the broker keeps the last message of a topic for the devices subscribing later",
+				"default":     false,
+			},
+			"payload_format": map[string]interface{}{
+				"type":        "string",
+				"description": "Payload of the publications, a JSON object with the subject and the content, or the content as text",
+				"enum":        []string{"json", "text"},
+				"default":     "json",
+			},
+			"client_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Client identifier, generated when empty",
+			},
+			"username": map[string]interface{}{
+				"type":        "string",
+				"description": "Username of the broker account",
+			},
+			"password": map[string]interface{}{
+				"type":        "string",
+				"description": "Password of the broker account",
+				"format":      "password",
+			},
+			"ca_cert": map[string]interface{}{
+				"type":        "string",
+				"description": "PEM certificate of the authority of the broker certificate, the system authorities when empty",
+			},
+			"client_cert": map[string]interface{}{
+				"type":        "string",
+				"description": "PEM certificate of the client, for brokers authenticating clients by certificate",
+			},
+			"client_key": map[string]interface{}{
+				"type":        "string",
+				"description": "PEM private key of the client certificate",
+				"format":      "password",
+			},
+			"insecure_skip_verify": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Accept any broker certificate, for testing only",
+				"default":     false,
+			},
+		},
+		"required": []string{"broker_url", "topic"},
+	}
+}
+
+// CreateMessageSender creates an MQTT message sender
+func (m *MQTTChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "mqtt_service", nil
+}
+
+// NewMQTTChannelType creates a new MQTT channel type definition
+func NewMQTTChannelType() shared.ChannelTypeDefinition {
+	return &MQTTChannelType{}
+}
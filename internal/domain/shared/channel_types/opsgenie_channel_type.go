@@ -0,0 +1,129 @@
+package channel_types
+
+import (
+	"errors"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// OpsgenieChannelType implements ChannelTypeDefinition for Opsgenie channels
+type OpsgenieChannelType struct{}
+
+// GetName returns the channel type name
+func (o *OpsgenieChannelType) GetName() string {
+	return "opsgenie"
+}
+
+// GetDisplayName returns the display name
+func (o *OpsgenieChannelType) GetDisplayName() string {
+	return "Opsgenie"
+}
+
+// GetDescription returns the description
+func (o *OpsgenieChannelType) GetDescription() string {
+	return "Create Opsgenie alerts routed to responders, deduplicated by alias, and close them"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (o *OpsgenieChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true, MaxSubjectLength: 130}
+}
+
+// ValidateConfig validates the Opsgenie channel configuration
+func (o *OpsgenieChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("opsgenie configuration cannot be nil")
+	}
+
+	// Validate API key
+	apiKey, ok := config["api_key"].(string)
+	if !ok || apiKey == "" {
+		return errors.New("api_key is required for opsgenie channel")
+	}
+
+	// Validate region
+	if region, exists := config["region"]; exists {
+		switch region {
+		case "us", "eu":
+		default:
+			return errors.New("region must be us or eu")
+		}
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for Opsgenie channels
+func (o *OpsgenieChannelType) GetConfigSchema() map[string]interface{} {
+	priority := map[string]interface{}{
+		"type": "string",
+		"enum": []string{"P1", "P2", "P3", "P4", "P5"},
+	}
+
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"api_key": map[string]interface{}{
+				"type":        "string",
+				"description": "API key of an Opsgenie API integration",
+				"format":      "password",
+			},
+			"region": map[string]interface{}{
+				"type":        "string",
+				"description": "Region of the Opsgenie account",
+				"enum":        []string{"us", "eu"},
+				"default":     "us",
+			},
+			"responders": map[string]interface{}{
+				"type":        "array",
+				"description": "Teams, users, escalations and schedules the alerts are routed to",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"type": map[string]interface{}{
+							"type": "string",
+							"enum": []string{"team", "user", "escalation", "schedule"},
+						},
+						"id": map[string]interface{}{
+							"type": "string",
+						},
+						"name": map[string]interface{}{
+							"type":        "string",
+							"description": "Name of the responder, the username of a user",
+						},
+					},
+					"required": []string{"type"},
+				},
+			},
+			"default_priority": map[string]interface{}{
+				"type":        "string",
+				"description": "Priority of the alerts whose messages set neither alert_priority nor alert_severity",
+				"enum":        []string{"P1", "P2", "P3", "P4", "P5"},
+				"default":     "P3",
+			},
+			"priority_mapping": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Priority of each alert_severity, overriding critical=P1, error=P2, warning=P3 and info=P5",
+				"additionalProperties": priority,
+			},
+			"source": map[string]interface{}{
+				"type":        "string",
+				"description": "Source of the alerts",
+				"default":     "notification",
+			},
+		},
+		"required": []string{"api_key"},
+	}
+}
+
+// CreateMessageSender creates an Opsgenie message sender
+func (o *OpsgenieChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "opsgenie_service", nil
+}
+
+// NewOpsgenieChannelType creates a new Opsgenie channel type definition
+func NewOpsgenieChannelType() shared.ChannelTypeDefinition {
+	return &OpsgenieChannelType{}
+}
@@ -0,0 +1,92 @@
+package channel_types
+
+import (
+	"errors"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// PagerDutyChannelType implements ChannelTypeDefinition for PagerDuty channels
+type PagerDutyChannelType struct{}
+
+// GetName returns the channel type name
+func (p *PagerDutyChannelType) GetName() string {
+	return "pagerduty"
+}
+
+// GetDisplayName returns the display name
+func (p *PagerDutyChannelType) GetDisplayName() string {
+	return "PagerDuty"
+}
+
+// GetDescription returns the description
+func (p *PagerDutyChannelType) GetDescription() string {
+	return "Trigger, acknowledge and resolve PagerDuty incidents through the Events API v2"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (p *PagerDutyChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true, MaxSubjectLength: 1024}
+}
+
+// ValidateConfig validates the PagerDuty channel configuration
+func (p *PagerDutyChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("pagerduty configuration cannot be nil")
+	}
+
+	// Validate routing key
+	routingKey, ok := config["routing_key"].(string)
+	if !ok || routingKey == "" {
+		return errors.New("routing_key is required for pagerduty channel")
+	}
+
+	// Validate default severity
+	if severity, exists := config["default_severity"]; exists {
+		switch severity {
+		case "critical", "error", "warning", "info":
+		default:
+			return errors.New("default_severity must be critical, error, warning or info")
+		}
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for PagerDuty channels
+func (p *PagerDutyChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"routing_key": map[string]interface{}{
+				"type":        "string",
+				"description": "Integration key of the Events API v2 integration of a PagerDuty service",
+				"format":      "password",
+			},
+			"source": map[string]interface{}{
+				"type":        "string",
+				"description": "Source of the incidents",
+				"default":     "notification",
+			},
+			"default_severity": map[string]interface{}{
+				"type":        "string",
+				"description": "Severity of the incidents whose messages set no incident_severity",
+				"enum":        []string{"critical", "error", "warning", "info"},
+				"default":     "error",
+			},
+		},
+		"required": []string{"routing_key"},
+	}
+}
+
+// CreateMessageSender creates a PagerDuty message sender
+func (p *PagerDutyChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "pagerduty_service", nil
+}
+
+// NewPagerDutyChannelType creates a new PagerDuty channel type definition
+func NewPagerDutyChannelType() shared.ChannelTypeDefinition {
+	return &PagerDutyChannelType{}
+}
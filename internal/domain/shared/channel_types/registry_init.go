@@ -24,6 +24,51 @@ func RegisterDefaultChannelTypes() {
 	if err := registry.RegisterChannelType(NewSMSChannelType()); err != nil {
 		log.Printf("Warning: Failed to register sms channel type: %v", err)
 	}
+
+	// Register voice channel type
+	if err := registry.RegisterChannelType(NewVoiceChannelType()); err != nil {
+		log.Printf("Warning: Failed to register voice channel type: %v", err)
+	}
+
+	// Register WhatsApp channel type
+	if err := registry.RegisterChannelType(NewWhatsAppChannelType()); err != nil {
+		log.Printf("Warning: Failed to register whatsapp channel type: %v", err)
+	}
+
+	// Register Discord channel type
+	if err := registry.RegisterChannelType(NewDiscordChannelType()); err != nil {
+		log.Printf("Warning: Failed to register discord channel type: %v", err)
+	}
+
+	// Register PagerDuty channel type
+	if err := registry.RegisterChannelType(NewPagerDutyChannelType()); err != nil {
+		log.Printf("Warning: Failed to register pagerduty channel type: %v", err)
+	}
+
+	// Register Opsgenie channel type
+	if err := registry.RegisterChannelType(NewOpsgenieChannelType()); err != nil {
+		log.Printf("Warning: Failed to register opsgenie channel type: %v", err)
+	}
+
+	// Register Jira channel type
+	if err := registry.RegisterChannelType(NewJiraChannelType()); err != nil {
+		log.Printf("Warning: Failed to register jira channel type: %v", err)
+	}
+
+	// Register MQTT channel type
+	if err := registry.RegisterChannelType(NewMQTTChannelType()); err != nil {
+		log.Printf("Warning: Failed to register mqtt channel type: %v", err)
+	}
+
+	// Register Statuspage channel type
+	if err := registry.RegisterChannelType(NewStatuspageChannelType()); err != nil {
+		log.Printf("Warning: Failed to register statuspage channel type: %v", err)
+	}
+
+	// Register in-app channel type
+	if err := registry.RegisterChannelType(NewInAppChannelType()); err != nil {
+		log.Printf("Warning: Failed to register inapp channel type: %v", err)
+	}
 }
 
 // MustRegisterDefaultChannelTypes registers all default channel types and panics on error
@@ -44,4 +89,49 @@ func MustRegisterDefaultChannelTypes() {
 	if err := registry.RegisterChannelType(NewSMSChannelType()); err != nil {
 		panic("Failed to register sms channel type: " + err.Error())
 	}
+
+	// Register voice channel type
+	if err := registry.RegisterChannelType(NewVoiceChannelType()); err != nil {
+		panic("Failed to register voice channel type: " + err.Error())
+	}
+
+	// Register WhatsApp channel type
+	if err := registry.RegisterChannelType(NewWhatsAppChannelType()); err != nil {
+		panic("Failed to register whatsapp channel type: " + err.Error())
+	}
+
+	// Register Discord channel type
+	if err := registry.RegisterChannelType(NewDiscordChannelType()); err != nil {
+		panic("Failed to register discord channel type: " + err.Error())
+	}
+
+	// Register PagerDuty channel type
+	if err := registry.RegisterChannelType(NewPagerDutyChannelType()); err != nil {
+		panic("Failed to register pagerduty channel type: " + err.Error())
+	}
+
+	// Register Opsgenie channel type
+	if err := registry.RegisterChannelType(NewOpsgenieChannelType()); err != nil {
+		panic("Failed to register opsgenie channel type: " + err.Error())
+	}
+
+	// Register Jira channel type
+	if err := registry.RegisterChannelType(NewJiraChannelType()); err != nil {
+		panic("Failed to register jira channel type: " + err.Error())
+	}
+
+	// Register MQTT channel type
+	if err := registry.RegisterChannelType(NewMQTTChannelType()); err != nil {
+		panic("Failed to register mqtt channel type: " + err.Error())
+	}
+
+	// Register Statuspage channel type
+	if err := registry.RegisterChannelType(NewStatuspageChannelType()); err != nil {
+		panic("Failed to register statuspage channel type: " + err.Error())
+	}
+
+	// Register in-app channel type
+	if err := registry.RegisterChannelType(NewInAppChannelType()); err != nil {
+		panic("Failed to register inapp channel type: " + err.Error())
+	}
 }
\ No newline at end of file
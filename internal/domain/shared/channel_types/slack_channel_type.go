@@ -25,6 +25,11 @@ func (s *SlackChannelType) GetDescription() string {
 	return "Send notifications to Slack channels via webhook"
 }
 
+// GetContentCapabilities returns the template content the channel type can send
+func (s *SlackChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true}
+}
+
 // ValidateConfig validates the Slack channel configuration
 func (s *SlackChannelType) ValidateConfig(config map[string]interface{}) error {
 	if config == nil {
@@ -25,6 +25,11 @@ func (s *SMSChannelType) GetDescription() string {
 	return "Send notifications via SMS using Twilio or other SMS providers"
 }
 
+// GetContentCapabilities returns the template content the channel type can send
+func (s *SMSChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true, MaxLength: 1600}
+}
+
 // ValidateConfig validates the SMS channel configuration
 func (s *SMSChannelType) ValidateConfig(config map[string]interface{}) error {
 	if config == nil {
@@ -0,0 +1,117 @@
+package channel_types
+
+import (
+	"errors"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// StatuspageChannelType implements ChannelTypeDefinition for Statuspage channels
+type StatuspageChannelType struct{}
+
+// GetName returns the channel type name
+func (s *StatuspageChannelType) GetName() string {
+	return "statuspage"
+}
+
+// GetDisplayName returns the display name
+func (s *StatuspageChannelType) GetDisplayName() string {
+	return "Statuspage"
+}
+
+// GetDescription returns the description
+func (s *StatuspageChannelType) GetDescription() string {
+	return "Open and update the incidents of a Statuspage page, setting the status of its components"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (s *StatuspageChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{Subject: true}
+}
+
+// ValidateConfig validates the Statuspage channel configuration
+func (s *StatuspageChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("statuspage configuration cannot be nil")
+	}
+
+	// Validate API key
+	apiKey, ok := config["api_key"].(string)
+	if !ok || apiKey == "" {
+		return errors.New("api_key is required for statuspage channel")
+	}
+
+	// Validate page
+	pageID, ok := config["page_id"].(string)
+	if !ok || pageID == "" {
+		return errors.New("page_id is required for statuspage channel")
+	}
+
+	// Validate components
+	if components, exists := config["components"]; exists {
+		if _, ok := components.([]interface{}); !ok {
+			return errors.New("components must be a list of component IDs")
+		}
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for Statuspage channels
+func (s *StatuspageChannelType) GetConfigSchema() map[string]interface{} {
+	componentStatus := map[string]interface{}{
+		"type": "string",
+		"enum": []string{"operational", "degraded_performance", "partial_outage", "major_outage", "under_maintenance"},
+	}
+
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"api_key": map[string]interface{}{
+				"type":        "string",
+				"description": "API key of a Statuspage user",
+				"format":      "password",
+			},
+			"page_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the page the incidents are posted to",
+			},
+			"base_url": map[string]interface{}{
+				"type":        "string",
+				"description": "URL of a Statuspage compatible API",
+				"format":      "uri",
+				"default":     "https://api.statuspage.io/v1",
+			},
+			"components": map[string]interface{}{
+				"type":        "array",
+				"description": "IDs of the components affected by the incidents, unless messages set incident_components",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			},
+			"severity_mapping": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Component status of each incident_severity, overriding critical=major_outage, error=partial_outage, warning=degraded_performance and info=operational",
+				"additionalProperties": componentStatus,
+			},
+			"deliver_notifications": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether Statuspage notifies the subscribers of the page",
+				"default":     true,
+			},
+		},
+		"required": []string{"api_key", "page_id"},
+	}
+}
+
+// CreateMessageSender creates a Statuspage message sender
+func (s *StatuspageChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "statuspage_service", nil
+}
+
+// NewStatuspageChannelType creates a new Statuspage channel type definition
+func NewStatuspageChannelType() shared.ChannelTypeDefinition {
+	return &StatuspageChannelType{}
+}
@@ -0,0 +1,151 @@
+package channel_types
+
+import (
+	"errors"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// VoiceChannelType implements ChannelTypeDefinition for voice channels
+type VoiceChannelType struct{}
+
+// GetName returns the channel type name
+func (v *VoiceChannelType) GetName() string {
+	return "voice"
+}
+
+// GetDisplayName returns the display name
+func (v *VoiceChannelType) GetDisplayName() string {
+	return "Voice"
+}
+
+// GetDescription returns the description
+func (v *VoiceChannelType) GetDescription() string {
+	return "Send notifications as text-to-speech phone calls using Twilio Voice"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (v *VoiceChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{MaxLength: 4000}
+}
+
+// ValidateConfig validates the voice channel configuration
+func (v *VoiceChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("voice configuration cannot be nil")
+	}
+
+	// Validate provider
+	provider, ok := config["provider"].(string)
+	if !ok || provider == "" {
+		return errors.New("provider is required for voice channel")
+	}
+
+	switch provider {
+	case "twilio":
+		return v.validateTwilioConfig(config)
+	default:
+		return errors.New("unsupported voice provider: " + provider)
+	}
+}
+
+// validateTwilioConfig validates Twilio-specific configuration
+func (v *VoiceChannelType) validateTwilioConfig(config map[string]interface{}) error {
+	// Validate account SID
+	accountSID, ok := config["account_sid"].(string)
+	if !ok || accountSID == "" {
+		return errors.New("account_sid is required for Twilio Voice")
+	}
+
+	// Validate auth token
+	authToken, ok := config["auth_token"].(string)
+	if !ok || authToken == "" {
+		return errors.New("auth_token is required for Twilio Voice")
+	}
+
+	// Validate from number
+	fromNumber, ok := config["from_number"].(string)
+	if !ok || fromNumber == "" {
+		return errors.New("from_number is required for Twilio Voice")
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for voice channels
+func (v *VoiceChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"provider": map[string]interface{}{
+				"type":        "string",
+				"description": "Voice provider",
+				"enum":        []string{"twilio"},
+				"example":     "twilio",
+			},
+			"account_sid": map[string]interface{}{
+				"type":        "string",
+				"description": "Twilio Account SID",
+				"example":     "ACxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+			},
+			"auth_token": map[string]interface{}{
+				"type":        "string",
+				"description": "Twilio Auth Token",
+				"format":      "password",
+			},
+			"from_number": map[string]interface{}{
+				"type":        "string",
+				"description": "Caller phone number",
+				"example":     "+1234567890",
+			},
+			"callback_url": map[string]interface{}{
+				"type":        "string",
+				"description": "Public base URL of this service, where recipients acknowledge calls with a key press",
+				"example":     "https://notification.example.com",
+			},
+			"require_ack": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Call again when a call is not acknowledged",
+				"default":     false,
+			},
+			"max_attempts": map[string]interface{}{
+				"type":        "integer",
+				"description": "Calls per recipient before giving up on one not answering",
+				"default":     3,
+			},
+			"retry_interval": map[string]interface{}{
+				"type":        "integer",
+				"description": "Seconds between the calls to a recipient",
+				"default":     60,
+			},
+			"ring_timeout": map[string]interface{}{
+				"type":        "integer",
+				"description": "Seconds a call rings before it counts as not answered",
+				"default":     30,
+			},
+			"voice": map[string]interface{}{
+				"type":        "string",
+				"description": "Text-to-speech voice",
+				"example":     "alice",
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"description": "Language of the text-to-speech voice",
+				"example":     "en-US",
+			},
+		},
+		"required": []string{"provider", "account_sid", "auth_token", "from_number"},
+	}
+}
+
+// CreateMessageSender creates a voice message sender
+func (v *VoiceChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "voice_service", nil
+}
+
+// NewVoiceChannelType creates a new voice channel type definition
+func NewVoiceChannelType() shared.ChannelTypeDefinition {
+	return &VoiceChannelType{}
+}
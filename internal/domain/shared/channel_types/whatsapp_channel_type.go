@@ -0,0 +1,114 @@
+package channel_types
+
+import (
+	"errors"
+	"time"
+
+	"notification/internal/domain/shared"
+)
+
+// WhatsAppChannelType implements ChannelTypeDefinition for WhatsApp channels
+type WhatsAppChannelType struct{}
+
+// GetName returns the channel type name
+func (w *WhatsAppChannelType) GetName() string {
+	return "whatsapp"
+}
+
+// GetDisplayName returns the display name
+func (w *WhatsAppChannelType) GetDisplayName() string {
+	return "WhatsApp"
+}
+
+// GetDescription returns the description
+func (w *WhatsAppChannelType) GetDescription() string {
+	return "Send notifications via the WhatsApp Business Cloud API, as pre-approved templates or session messages"
+}
+
+// GetContentCapabilities returns the template content the channel type can send
+func (w *WhatsAppChannelType) GetContentCapabilities() shared.ContentCapabilities {
+	return shared.ContentCapabilities{MaxLength: 4096}
+}
+
+// ValidateConfig validates the WhatsApp channel configuration
+func (w *WhatsAppChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return errors.New("whatsapp configuration cannot be nil")
+	}
+
+	// Validate access token
+	accessToken, ok := config["access_token"].(string)
+	if !ok || accessToken == "" {
+		return errors.New("access_token is required for WhatsApp")
+	}
+
+	// Validate phone number ID
+	phoneNumberID, ok := config["phone_number_id"].(string)
+	if !ok || phoneNumberID == "" {
+		return errors.New("phone_number_id is required for WhatsApp")
+	}
+
+	return nil
+}
+
+// GetConfigSchema returns the configuration schema for WhatsApp channels
+func (w *WhatsAppChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"access_token": map[string]interface{}{
+				"type":        "string",
+				"description": "Access token of a system user of the WhatsApp Business account",
+				"format":      "password",
+			},
+			"phone_number_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the business phone number sending the messages",
+				"example":     "106540352242922",
+			},
+			"template_mappings": map[string]interface{}{
+				"type":        "object",
+				"description": "Pre-approved WhatsApp template sent for each local template ID, with the variables filling its body parameters in order",
+				"additionalProperties": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{
+							"type":        "string",
+							"description": "Name of the WhatsApp template",
+							"example":     "order_shipped",
+						},
+						"language": map[string]interface{}{
+							"type":        "string",
+							"description": "Language code of the WhatsApp template",
+							"example":     "en_US",
+						},
+						"parameters": map[string]interface{}{
+							"type":        "array",
+							"description": "Variables filling the parameters {{1}}, {{2}}, ... of the template body",
+							"items":       map[string]interface{}{"type": "string"},
+							"example":     []string{"customer_name", "order_id"},
+						},
+					},
+					"required": []string{"name", "language"},
+				},
+			},
+			"session_fallback": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Send messages without a mapped template as session messages, delivered only within 24 hours of the last message of the recipient",
+				"default":     false,
+			},
+		},
+		"required": []string{"access_token", "phone_number_id"},
+	}
+}
+
+// CreateMessageSender creates a WhatsApp message sender
+func (w *WhatsAppChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory identifier that infrastructure layer can use
+	return "whatsapp_service", nil
+}
+
+// NewWhatsAppChannelType creates a new WhatsApp channel type definition
+func NewWhatsAppChannelType() shared.ChannelTypeDefinition {
+	return &WhatsAppChannelType{}
+}
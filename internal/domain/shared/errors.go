@@ -0,0 +1,181 @@
+package shared
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrorKind classifies domain errors independently of any transport protocol
+type ErrorKind string
+
+const (
+	// ErrorKindInternal is used for errors that carry no classification
+	ErrorKindInternal ErrorKind = "INTERNAL"
+	// ErrorKindNotFound means the requested resource does not exist
+	ErrorKindNotFound ErrorKind = "NOT_FOUND"
+	// ErrorKindConflict means the request conflicts with the current state of a resource
+	ErrorKindConflict ErrorKind = "CONFLICT"
+	// ErrorKindValidation means the request is well-formed but semantically invalid
+	ErrorKindValidation ErrorKind = "VALIDATION_FAILED"
+	// ErrorKindUnavailable means a dependency required to serve the request is unavailable
+	ErrorKindUnavailable ErrorKind = "UNAVAILABLE"
+	// ErrorKindQuotaExceeded means the request would exceed a send quota
+	ErrorKindQuotaExceeded ErrorKind = "QUOTA_EXCEEDED"
+	// ErrorKindForbidden means the caller may not perform the operation
+	ErrorKindForbidden ErrorKind = "FORBIDDEN"
+	// ErrorKindPreconditionFailed means the resource changed since the version the caller expected
+	ErrorKindPreconditionFailed ErrorKind = "PRECONDITION_FAILED"
+)
+
+// KindedError is implemented by errors that know their own classification
+type KindedError interface {
+	error
+	Kind() ErrorKind
+}
+
+// DomainError is a classified error with a machine-readable code
+type DomainError struct {
+	kind    ErrorKind
+	code    string
+	message string
+	cause   error
+	// retryAfter is how long the caller should wait before retrying, zero when unknown
+	retryAfter time.Duration
+}
+
+// NewDomainError creates a new domain error
+func NewDomainError(kind ErrorKind, code, message string, cause error) *DomainError {
+	if code == "" {
+		code = string(kind)
+	}
+	return &DomainError{
+		kind:    kind,
+		code:    code,
+		message: message,
+		cause:   cause,
+	}
+}
+
+// NewNotFoundError creates an error for a missing resource
+func NewNotFoundError(code, message string) *DomainError {
+	return NewDomainError(ErrorKindNotFound, code, message, nil)
+}
+
+// NewConflictError creates an error for a conflicting resource state
+func NewConflictError(code, message string) *DomainError {
+	return NewDomainError(ErrorKindConflict, code, message, nil)
+}
+
+// NewValidationError creates an error for semantically invalid input
+func NewValidationError(code string, cause error) *DomainError {
+	message := "validation failed"
+	if cause != nil {
+		message = cause.Error()
+	}
+	return NewDomainError(ErrorKindValidation, code, message, cause)
+}
+
+// NewUnavailableError creates an error for an unavailable dependency
+func NewUnavailableError(code, message string, cause error) *DomainError {
+	return NewDomainError(ErrorKindUnavailable, code, message, cause)
+}
+
+// NewRetryLaterError creates an error for a request turned away while the
+// service is saturated, which the caller should retry after retryAfter
+func NewRetryLaterError(message string, retryAfter time.Duration) *DomainError {
+	err := NewDomainError(ErrorKindUnavailable, "RETRY_LATER", message, nil)
+	err.retryAfter = retryAfter
+	return err
+}
+
+// NewForbiddenError creates an error for an operation the caller may not perform
+func NewForbiddenError(code, message string) *DomainError {
+	return NewDomainError(ErrorKindForbidden, code, message, nil)
+}
+
+// NewPreconditionFailedError creates an error for a resource changed since
+// the version the caller expected
+func NewPreconditionFailedError(code, message string) *DomainError {
+	return NewDomainError(ErrorKindPreconditionFailed, code, message, nil)
+}
+
+// WithRetryAfter sets how long the caller should wait before retrying
+func (e *DomainError) WithRetryAfter(retryAfter time.Duration) *DomainError {
+	e.retryAfter = retryAfter
+	return e
+}
+
+// Error implements the error interface
+func (e *DomainError) Error() string {
+	if e.cause != nil && e.cause.Error() != e.message {
+		return e.message + ": " + e.cause.Error()
+	}
+	return e.message
+}
+
+// Unwrap returns the underlying cause
+func (e *DomainError) Unwrap() error {
+	return e.cause
+}
+
+// Kind returns the error classification
+func (e *DomainError) Kind() ErrorKind {
+	return e.kind
+}
+
+// Code returns the machine-readable error code
+func (e *DomainError) Code() string {
+	return e.code
+}
+
+// Message returns the error message without the cause
+func (e *DomainError) Message() string {
+	return e.message
+}
+
+// RetryAfter returns how long the caller should wait before retrying, zero when unknown
+func (e *DomainError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// ErrorKindOf returns the classification of the first classified error in the chain
+func ErrorKindOf(err error) ErrorKind {
+	var kinded KindedError
+	if errors.As(err, &kinded) {
+		return kinded.Kind()
+	}
+	return ErrorKindInternal
+}
+
+// ErrorCodeOf returns the machine-readable code of the first domain error in the chain.
+// Errors that only carry a kind report the kind itself; unclassified errors report fallback.
+func ErrorCodeOf(err error, fallback string) string {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code()
+	}
+	if kind := ErrorKindOf(err); kind != ErrorKindInternal {
+		return string(kind)
+	}
+	return fallback
+}
+
+// RetryAfterOf returns how long the caller should wait before retrying, as
+// told by the first domain error in the chain; zero when unknown
+func RetryAfterOf(err error) time.Duration {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.RetryAfter()
+	}
+	return 0
+}
+
+// IsNotFound checks whether the error is classified as not found
+func IsNotFound(err error) bool {
+	return ErrorKindOf(err) == ErrorKindNotFound
+}
+
+// IsConflict checks whether the error is classified as a conflict
+func IsConflict(err error) bool {
+	return ErrorKindOf(err) == ErrorKindConflict
+}
@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// kindOnlyError is classified without being a DomainError
+type kindOnlyError struct{}
+
+func (kindOnlyError) Error() string   { return "over quota" }
+func (kindOnlyError) Kind() ErrorKind { return ErrorKindQuotaExceeded }
+
+func TestDomainErrors(t *testing.T) {
+	cause := errors.New("connection refused")
+	tests := []struct {
+		name       string
+		err        error
+		wantKind   ErrorKind
+		wantCode   string
+		wantText   string
+		retryAfter time.Duration
+	}{
+		{"not found", NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found"), ErrorKindNotFound, "CHANNEL_NOT_FOUND", "channel not found", 0},
+		{"conflict", NewConflictError("NAME_CONFLICT", "name taken"), ErrorKindConflict, "NAME_CONFLICT", "name taken", 0},
+		{"validation", NewValidationError("INVALID_REQUEST", errors.New("name is required")), ErrorKindValidation, "INVALID_REQUEST", "name is required", 0},
+		{"validation without a cause", NewValidationError("INVALID_REQUEST", nil), ErrorKindValidation, "INVALID_REQUEST", "validation failed", 0},
+		{"unavailable with a cause", NewUnavailableError("LEGACY_SYSTEM_UNAVAILABLE", "legacy system down", cause), ErrorKindUnavailable, "LEGACY_SYSTEM_UNAVAILABLE", "legacy system down: connection refused", 0},
+		{"retry later", NewRetryLaterError("saturated", time.Second), ErrorKindUnavailable, "RETRY_LATER", "saturated", time.Second},
+		{"forbidden", NewForbiddenError("READ_ONLY", "read-only"), ErrorKindForbidden, "READ_ONLY", "read-only", 0},
+		{"precondition failed", NewPreconditionFailedError("PRECONDITION_FAILED", "modified"), ErrorKindPreconditionFailed, "PRECONDITION_FAILED", "modified", 0},
+		{"code defaults to the kind", NewDomainError(ErrorKindConflict, "", "taken", nil), ErrorKindConflict, "CONFLICT", "taken", 0},
+		{"with retry after", NewConflictError("BUSY", "busy").WithRetryAfter(time.Minute), ErrorKindConflict, "BUSY", "busy", time.Minute},
+		{"wrapped", fmt.Errorf("failed to update: %w", NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")), ErrorKindNotFound, "CHANNEL_NOT_FOUND", "failed to update: channel not found", 0},
+		{"kind only", kindOnlyError{}, ErrorKindQuotaExceeded, "QUOTA_EXCEEDED", "over quota", 0},
+		{"unclassified", cause, ErrorKindInternal, "FALLBACK", "connection refused", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantKind, ErrorKindOf(tt.err))
+			assert.Equal(t, tt.wantCode, ErrorCodeOf(tt.err, "FALLBACK"))
+			assert.Equal(t, tt.wantText, tt.err.Error())
+			assert.Equal(t, tt.retryAfter, RetryAfterOf(tt.err))
+			assert.Equal(t, tt.wantKind == ErrorKindNotFound, IsNotFound(tt.err))
+			assert.Equal(t, tt.wantKind == ErrorKindConflict, IsConflict(tt.err))
+		})
+	}
+}
+
+func TestDomainError_UnwrapsTheCause(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := NewUnavailableError("LEGACY_SYSTEM_UNAVAILABLE", "legacy system down", cause)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "legacy system down", err.Message())
+}
+
+func TestNewLegacyStatusError(t *testing.T) {
+	tests := []struct {
+		status   int
+		wantKind ErrorKind
+		wantCode string
+	}{
+		{400, ErrorKindValidation, "LEGACY_REQUEST_REJECTED"},
+		{401, ErrorKindUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+		{403, ErrorKindUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+		{404, ErrorKindNotFound, "LEGACY_RESOURCE_NOT_FOUND"},
+		{408, ErrorKindUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+		{409, ErrorKindConflict, "LEGACY_CONFLICT"},
+		{413, ErrorKindValidation, "LEGACY_REQUEST_REJECTED"},
+		{422, ErrorKindValidation, "LEGACY_REQUEST_REJECTED"},
+		{429, ErrorKindUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+		{500, ErrorKindUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+		{503, ErrorKindUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			err := NewLegacyStatusError(tt.status, "body")
+			assert.Equal(t, tt.wantKind, err.Kind())
+			assert.Equal(t, tt.wantCode, err.Code())
+			assert.Equal(t, fmt.Sprintf("legacy system returned error status %d: body", tt.status), err.Error())
+		})
+	}
+}
+
+func TestWrapLegacyError(t *testing.T) {
+	rejected := WrapLegacyError("failed to forward to legacy system", NewLegacyStatusError(400, "bad recipients"))
+	assert.Equal(t, ErrorKindValidation, ErrorKindOf(rejected))
+	assert.Equal(t, "LEGACY_REQUEST_REJECTED", ErrorCodeOf(rejected, ""))
+	assert.Equal(t, "failed to forward to legacy system: legacy system returned error status 400: bad recipients", rejected.Error())
+
+	transport := WrapLegacyError("failed to forward to legacy system", errors.New("connection refused"))
+	assert.Equal(t, ErrorKindUnavailable, ErrorKindOf(transport))
+	assert.Equal(t, "LEGACY_SYSTEM_UNAVAILABLE", ErrorCodeOf(transport, ""))
+}
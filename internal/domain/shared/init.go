@@ -43,6 +43,51 @@ func registerDefaultChannelTypes() {
 	if err := registry.RegisterChannelType(newSMSChannelType()); err != nil {
 		log.Printf("Warning: Failed to register sms channel type: %v", err)
 	}
+
+	// Register voice channel type
+	if err := registry.RegisterChannelType(newVoiceChannelType()); err != nil {
+		log.Printf("Warning: Failed to register voice channel type: %v", err)
+	}
+
+	// Register WhatsApp channel type
+	if err := registry.RegisterChannelType(newWhatsAppChannelType()); err != nil {
+		log.Printf("Warning: Failed to register whatsapp channel type: %v", err)
+	}
+
+	// Register Discord channel type
+	if err := registry.RegisterChannelType(newDiscordChannelType()); err != nil {
+		log.Printf("Warning: Failed to register discord channel type: %v", err)
+	}
+
+	// Register PagerDuty channel type
+	if err := registry.RegisterChannelType(newPagerDutyChannelType()); err != nil {
+		log.Printf("Warning: Failed to register pagerduty channel type: %v", err)
+	}
+
+	// Register Opsgenie channel type
+	if err := registry.RegisterChannelType(newOpsgenieChannelType()); err != nil {
+		log.Printf("Warning: Failed to register opsgenie channel type: %v", err)
+	}
+
+	// Register Jira channel type
+	if err := registry.RegisterChannelType(newJiraChannelType()); err != nil {
+		log.Printf("Warning: Failed to register jira channel type: %v", err)
+	}
+
+	// Register MQTT channel type
+	if err := registry.RegisterChannelType(newMQTTChannelType()); err != nil {
+		log.Printf("Warning: Failed to register mqtt channel type: %v", err)
+	}
+
+	// Register Statuspage channel type
+	if err := registry.RegisterChannelType(newStatuspageChannelType()); err != nil {
+		log.Printf("Warning: Failed to register statuspage channel type: %v", err)
+	}
+
+	// Register in-app channel type
+	if err := registry.RegisterChannelType(newInAppChannelType()); err != nil {
+		log.Printf("Warning: Failed to register inapp channel type: %v", err)
+	}
 }
 
 // mustRegisterDefaultChannelTypes registers all default channel types and panics on error
@@ -63,6 +108,51 @@ func mustRegisterDefaultChannelTypes() {
 	if err := registry.RegisterChannelType(newSMSChannelType()); err != nil {
 		panic("Failed to register sms channel type: " + err.Error())
 	}
+
+	// Register voice channel type
+	if err := registry.RegisterChannelType(newVoiceChannelType()); err != nil {
+		panic("Failed to register voice channel type: " + err.Error())
+	}
+
+	// Register WhatsApp channel type
+	if err := registry.RegisterChannelType(newWhatsAppChannelType()); err != nil {
+		panic("Failed to register whatsapp channel type: " + err.Error())
+	}
+
+	// Register Discord channel type
+	if err := registry.RegisterChannelType(newDiscordChannelType()); err != nil {
+		panic("Failed to register discord channel type: " + err.Error())
+	}
+
+	// Register PagerDuty channel type
+	if err := registry.RegisterChannelType(newPagerDutyChannelType()); err != nil {
+		panic("Failed to register pagerduty channel type: " + err.Error())
+	}
+
+	// Register Opsgenie channel type
+	if err := registry.RegisterChannelType(newOpsgenieChannelType()); err != nil {
+		panic("Failed to register opsgenie channel type: " + err.Error())
+	}
+
+	// Register Jira channel type
+	if err := registry.RegisterChannelType(newJiraChannelType()); err != nil {
+		panic("Failed to register jira channel type: " + err.Error())
+	}
+
+	// Register MQTT channel type
+	if err := registry.RegisterChannelType(newMQTTChannelType()); err != nil {
+		panic("Failed to register mqtt channel type: " + err.Error())
+	}
+
+	// Register Statuspage channel type
+	if err := registry.RegisterChannelType(newStatuspageChannelType()); err != nil {
+		panic("Failed to register statuspage channel type: " + err.Error())
+	}
+
+	// Register in-app channel type
+	if err := registry.RegisterChannelType(newInAppChannelType()); err != nil {
+		panic("Failed to register inapp channel type: " + err.Error())
+	}
 }
 
 // Built-in channel type implementations to avoid circular imports
@@ -73,6 +163,9 @@ type emailChannelType struct{}
 func (e *emailChannelType) GetName() string { return "email" }
 func (e *emailChannelType) GetDisplayName() string { return "Email" }
 func (e *emailChannelType) GetDescription() string { return "Send notifications via email using SMTP" }
+func (e *emailChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{HTML: true, Attachments: true, Subject: true, SubjectRequired: true}
+}
 
 func (e *emailChannelType) ValidateConfig(config map[string]interface{}) error {
 	// Basic validation - can be enhanced
@@ -114,6 +207,9 @@ type slackChannelType struct{}
 func (s *slackChannelType) GetName() string { return "slack" }
 func (s *slackChannelType) GetDisplayName() string { return "Slack" }
 func (s *slackChannelType) GetDescription() string { return "Send notifications to Slack channels via webhook" }
+func (s *slackChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true}
+}
 
 func (s *slackChannelType) ValidateConfig(config map[string]interface{}) error {
 	if config == nil {
@@ -150,6 +246,9 @@ type smsChannelType struct{}
 func (s *smsChannelType) GetName() string { return "sms" }
 func (s *smsChannelType) GetDisplayName() string { return "SMS" }
 func (s *smsChannelType) GetDescription() string { return "Send notifications via SMS" }
+func (s *smsChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true, MaxLength: 1600}
+}
 
 func (s *smsChannelType) ValidateConfig(config map[string]interface{}) error {
 	if config == nil {
@@ -178,4 +277,357 @@ func (s *smsChannelType) CreateMessageSender(timeout time.Duration) (interface{}
 
 func newSMSChannelType() ChannelTypeDefinition {
 	return &smsChannelType{}
-}
\ No newline at end of file
+}
+
+// voiceChannelType implements ChannelTypeDefinition for voice channels
+type voiceChannelType struct{}
+
+func (v *voiceChannelType) GetName() string { return "voice" }
+func (v *voiceChannelType) GetDisplayName() string { return "Voice" }
+func (v *voiceChannelType) GetDescription() string { return "Send notifications as text-to-speech phone calls" }
+func (v *voiceChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{MaxLength: 4000}
+}
+
+func (v *voiceChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("voice configuration cannot be nil")
+	}
+	return nil
+}
+
+func (v *voiceChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"provider": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"provider"},
+	}
+}
+
+func (v *voiceChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "voice_service_factory"
+	}, nil
+}
+
+func newVoiceChannelType() ChannelTypeDefinition {
+	return &voiceChannelType{}
+}
+
+// whatsAppChannelType implements ChannelTypeDefinition for WhatsApp channels
+type whatsAppChannelType struct{}
+
+func (w *whatsAppChannelType) GetName() string        { return "whatsapp" }
+func (w *whatsAppChannelType) GetDisplayName() string { return "WhatsApp" }
+func (w *whatsAppChannelType) GetDescription() string { return "Send notifications via WhatsApp" }
+func (w *whatsAppChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{MaxLength: 4096}
+}
+
+func (w *whatsAppChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("whatsapp configuration cannot be nil")
+	}
+	return nil
+}
+
+func (w *whatsAppChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"phone_number_id": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"phone_number_id"},
+	}
+}
+
+func (w *whatsAppChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "whatsapp_service_factory"
+	}, nil
+}
+
+func newWhatsAppChannelType() ChannelTypeDefinition {
+	return &whatsAppChannelType{}
+}
+
+// discordChannelType implements ChannelTypeDefinition for Discord channels
+type discordChannelType struct{}
+
+func (d *discordChannelType) GetName() string        { return "discord" }
+func (d *discordChannelType) GetDisplayName() string { return "Discord" }
+func (d *discordChannelType) GetDescription() string { return "Send notifications to Discord" }
+func (d *discordChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true, MaxLength: 4096, MaxSubjectLength: 256}
+}
+
+func (d *discordChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("discord configuration cannot be nil")
+	}
+	return nil
+}
+
+func (d *discordChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"mode": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"mode"},
+	}
+}
+
+func (d *discordChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "discord_service_factory"
+	}, nil
+}
+
+func newDiscordChannelType() ChannelTypeDefinition {
+	return &discordChannelType{}
+}
+
+// pagerDutyChannelType implements ChannelTypeDefinition for PagerDuty channels
+type pagerDutyChannelType struct{}
+
+func (p *pagerDutyChannelType) GetName() string        { return "pagerduty" }
+func (p *pagerDutyChannelType) GetDisplayName() string { return "PagerDuty" }
+func (p *pagerDutyChannelType) GetDescription() string { return "Open and update PagerDuty incidents" }
+func (p *pagerDutyChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true, MaxSubjectLength: 1024}
+}
+
+func (p *pagerDutyChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("pagerduty configuration cannot be nil")
+	}
+	return nil
+}
+
+func (p *pagerDutyChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"routing_key": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"routing_key"},
+	}
+}
+
+func (p *pagerDutyChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "pagerduty_service_factory"
+	}, nil
+}
+
+func newPagerDutyChannelType() ChannelTypeDefinition {
+	return &pagerDutyChannelType{}
+}
+
+// opsgenieChannelType implements ChannelTypeDefinition for Opsgenie channels
+type opsgenieChannelType struct{}
+
+func (o *opsgenieChannelType) GetName() string        { return "opsgenie" }
+func (o *opsgenieChannelType) GetDisplayName() string { return "Opsgenie" }
+func (o *opsgenieChannelType) GetDescription() string { return "Create and close Opsgenie alerts" }
+func (o *opsgenieChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true, MaxSubjectLength: 130}
+}
+
+func (o *opsgenieChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("opsgenie configuration cannot be nil")
+	}
+	return nil
+}
+
+func (o *opsgenieChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"api_key": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"api_key"},
+	}
+}
+
+func (o *opsgenieChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "opsgenie_service_factory"
+	}, nil
+}
+
+func newOpsgenieChannelType() ChannelTypeDefinition {
+	return &opsgenieChannelType{}
+}
+
+// jiraChannelType implements ChannelTypeDefinition for Jira channels
+type jiraChannelType struct{}
+
+func (j *jiraChannelType) GetName() string        { return "jira" }
+func (j *jiraChannelType) GetDisplayName() string { return "Jira" }
+func (j *jiraChannelType) GetDescription() string { return "Create Jira issues" }
+func (j *jiraChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true, SubjectRequired: true, MaxSubjectLength: 255}
+}
+
+func (j *jiraChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("jira configuration cannot be nil")
+	}
+	return nil
+}
+
+func (j *jiraChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":    map[string]interface{}{"type": "string"},
+			"project_key": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"base_url", "project_key"},
+	}
+}
+
+func (j *jiraChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "jira_service_factory"
+	}, nil
+}
+
+func newJiraChannelType() ChannelTypeDefinition {
+	return &jiraChannelType{}
+}
+
+// mqttChannelType implements ChannelTypeDefinition for MQTT channels
+type mqttChannelType struct{}
+
+func (m *mqttChannelType) GetName() string        { return "mqtt" }
+func (m *mqttChannelType) GetDisplayName() string { return "MQTT" }
+func (m *mqttChannelType) GetDescription() string { return "Publish messages to MQTT topics" }
+func (m *mqttChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true, MaxLength: 256 * 1024}
+}
+
+func (m *mqttChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("mqtt configuration cannot be nil")
+	}
+	return nil
+}
+
+func (m *mqttChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"broker_url": map[string]interface{}{"type": "string"},
+			"topic":      map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"broker_url", "topic"},
+	}
+}
+
+func (m *mqttChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "mqtt_service_factory"
+	}, nil
+}
+
+func newMQTTChannelType() ChannelTypeDefinition {
+	return &mqttChannelType{}
+}
+
+// statuspageChannelType implements ChannelTypeDefinition for Statuspage channels
+type statuspageChannelType struct{}
+
+func (s *statuspageChannelType) GetName() string        { return "statuspage" }
+func (s *statuspageChannelType) GetDisplayName() string { return "Statuspage" }
+func (s *statuspageChannelType) GetDescription() string { return "Open and update Statuspage incidents" }
+func (s *statuspageChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true}
+}
+
+func (s *statuspageChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("statuspage configuration cannot be nil")
+	}
+	return nil
+}
+
+func (s *statuspageChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"api_key": map[string]interface{}{"type": "string"},
+			"page_id": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"api_key", "page_id"},
+	}
+}
+
+func (s *statuspageChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "statuspage_service_factory"
+	}, nil
+}
+
+func newStatuspageChannelType() ChannelTypeDefinition {
+	return &statuspageChannelType{}
+}
+
+// inAppChannelType implements ChannelTypeDefinition for in-app channels
+type inAppChannelType struct{}
+
+func (i *inAppChannelType) GetName() string        { return "inapp" }
+func (i *inAppChannelType) GetDisplayName() string { return "In-App" }
+func (i *inAppChannelType) GetDescription() string { return "Store notifications in the notification center of users" }
+func (i *inAppChannelType) GetContentCapabilities() ContentCapabilities {
+	return ContentCapabilities{Subject: true, MaxSubjectLength: 255}
+}
+
+func (i *inAppChannelType) ValidateConfig(config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("inapp configuration cannot be nil")
+	}
+	return nil
+}
+
+func (i *inAppChannelType) GetConfigSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"category": map[string]interface{}{"type": "string"},
+		},
+	}
+}
+
+func (i *inAppChannelType) CreateMessageSender(timeout time.Duration) (interface{}, error) {
+	// Return a factory function that can be used by infrastructure layer
+	return func() interface{} {
+		// This will be handled by the infrastructure layer
+		return "inapp_service_factory"
+	}, nil
+}
+
+func newInAppChannelType() ChannelTypeDefinition {
+	return &inAppChannelType{}
+}
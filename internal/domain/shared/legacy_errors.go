@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// NewLegacyStatusError classifies an error status the legacy system answered
+// with. A 4xx rejects the request itself: 404 is reported as not found, 409
+// as a conflict and the others as invalid. The 5xx, and the 4xx that are not
+// the caller's doing (401, 403, 408 and 429), mean the legacy system cannot
+// serve the request and are reported as unavailable.
+func NewLegacyStatusError(status int, body string) *DomainError {
+	message := fmt.Sprintf("legacy system returned error status %d: %s", status, body)
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return NewUnavailableError("LEGACY_SYSTEM_UNAVAILABLE", message, nil)
+	case http.StatusNotFound:
+		return NewNotFoundError("LEGACY_RESOURCE_NOT_FOUND", message)
+	case http.StatusConflict:
+		return NewConflictError("LEGACY_CONFLICT", message)
+	}
+	if status >= 400 && status < 500 {
+		return NewDomainError(ErrorKindValidation, "LEGACY_REQUEST_REJECTED", message, nil)
+	}
+	return NewUnavailableError("LEGACY_SYSTEM_UNAVAILABLE", message, nil)
+}
+
+// WrapLegacyError reports a failed call to the legacy system. An error the
+// legacy system classified, such as a rejected request, keeps its kind; a
+// transport error is reported as the legacy system being unavailable.
+func WrapLegacyError(message string, err error) error {
+	if ErrorKindOf(err) != ErrorKindInternal {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	return NewUnavailableError("LEGACY_SYSTEM_UNAVAILABLE", message, err)
+}
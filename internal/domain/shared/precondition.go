@@ -0,0 +1,26 @@
+package shared
+
+import "context"
+
+// expectedVersionKey carries the version a conditional request found a
+// resource at
+type expectedVersionKey struct {
+	id string
+}
+
+// WithExpectedVersion returns a context whose writes of the resource only
+// apply while it is still at the given version, so that a change made after
+// the precondition was checked is not overwritten
+func WithExpectedVersion(ctx context.Context, id string, version int64) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{id: id}, version)
+}
+
+// ExpectedVersionFromContext returns the version the writes of the resource
+// done with the context expect, and false when they are unconditional
+func ExpectedVersionFromContext(ctx context.Context, id string) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	version, ok := ctx.Value(expectedVersionKey{id: id}).(int64)
+	return version, ok
+}
@@ -0,0 +1,22 @@
+package shared
+
+import "context"
+
+// staleReadsKey marks a context whose reads may be served by a read replica
+type staleReadsKey struct{}
+
+// WithStaleReads returns a context whose reads may be served by a read
+// replica, trading freshness for primary capacity. Queries backing list and
+// get endpoints use it; commands must not, as they read their own writes.
+func WithStaleReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleReadsKey{}, true)
+}
+
+// StaleReadsAllowed reports whether the context's reads may be served by a read replica
+func StaleReadsAllowed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	allowed, _ := ctx.Value(staleReadsKey{}).(bool)
+	return allowed
+}
@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ErrCodeReadOnly is the error code returned for commands rejected in read-only mode
+const ErrCodeReadOnly = "READ_ONLY"
+
+// ReadOnlyMode tracks whether the instance serves queries only. A read-only
+// instance rejects commands and pauses its workers, which suits standby
+// replicas during failover and maintenance windows. A nil ReadOnlyMode is
+// never read-only.
+type ReadOnlyMode struct {
+	enabled   atomic.Bool
+	mu        sync.Mutex
+	listeners []func(readOnly bool)
+}
+
+// NewReadOnlyMode creates a read-only mode in the given initial state
+func NewReadOnlyMode(enabled bool) *ReadOnlyMode {
+	mode := &ReadOnlyMode{}
+	mode.enabled.Store(enabled)
+	return mode
+}
+
+// Enabled reports whether the instance is read-only
+func (m *ReadOnlyMode) Enabled() bool {
+	return m != nil && m.enabled.Load()
+}
+
+// Set switches the mode and notifies the listeners, reporting whether the
+// mode changed. Listeners run synchronously in registration order.
+func (m *ReadOnlyMode) Set(enabled bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.enabled.Swap(enabled) == enabled {
+		return false
+	}
+	for _, listener := range m.listeners {
+		listener(enabled)
+	}
+	return true
+}
+
+// OnChange registers a listener called whenever the mode changes
+func (m *ReadOnlyMode) OnChange(listener func(readOnly bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// Check returns an unavailable error while the instance is read-only
+func (m *ReadOnlyMode) Check() error {
+	if !m.Enabled() {
+		return nil
+	}
+	return NewUnavailableError(ErrCodeReadOnly, "the instance is in read-only mode and does not accept commands", nil)
+}
@@ -0,0 +1,32 @@
+package shared
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	var unset *ReadOnlyMode
+	assert.False(t, unset.Enabled())
+	assert.NoError(t, unset.Check())
+
+	mode := NewReadOnlyMode(false)
+	var changes []bool
+	mode.OnChange(func(readOnly bool) { changes = append(changes, readOnly) })
+
+	assert.True(t, mode.Set(true))
+	assert.False(t, mode.Set(true))
+	assert.True(t, mode.Enabled())
+
+	var domainErr *DomainError
+	require.True(t, errors.As(mode.Check(), &domainErr))
+	assert.Equal(t, ErrCodeReadOnly, domainErr.Code())
+	assert.Equal(t, ErrorKindUnavailable, domainErr.Kind())
+
+	assert.True(t, mode.Set(false))
+	assert.NoError(t, mode.Check())
+	assert.Equal(t, []bool{true, false}, changes)
+}
@@ -3,6 +3,7 @@ package shared
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -13,9 +14,18 @@ type ChannelType struct {
 
 // Predefined channel types for backward compatibility
 var (
-	ChannelTypeEmail = MustNewChannelType("email")
-	ChannelTypeSlack = MustNewChannelType("slack")
-	ChannelTypeSMS   = MustNewChannelType("sms")
+	ChannelTypeEmail      = MustNewChannelType("email")
+	ChannelTypeSlack      = MustNewChannelType("slack")
+	ChannelTypeSMS        = MustNewChannelType("sms")
+	ChannelTypeVoice      = MustNewChannelType("voice")
+	ChannelTypeWhatsApp   = MustNewChannelType("whatsapp")
+	ChannelTypeDiscord    = MustNewChannelType("discord")
+	ChannelTypePagerDuty  = MustNewChannelType("pagerduty")
+	ChannelTypeOpsgenie   = MustNewChannelType("opsgenie")
+	ChannelTypeJira       = MustNewChannelType("jira")
+	ChannelTypeMQTT       = MustNewChannelType("mqtt")
+	ChannelTypeStatuspage = MustNewChannelType("statuspage")
+	ChannelTypeInApp      = MustNewChannelType("inapp")
 )
 
 // NewChannelType creates a new channel type
@@ -129,6 +139,94 @@ func NewCommonSettings(timeout, retryAttempts, retryDelay int) (*CommonSettings,
 	}, nil
 }
 
+// MaxOwnershipLength is the maximum length of an owner or team name.
+const MaxOwnershipLength = 255
+
+// Ownership records who is responsible for a resource, so that resources
+// nobody maintains any more can be traced back to a team
+type Ownership struct {
+	Owner string `json:"owner,omitempty"`
+	Team  string `json:"team,omitempty"`
+}
+
+// NewOwnership creates an ownership from an owner and a team, both optional
+func NewOwnership(owner, team string) (*Ownership, error) {
+	owner = strings.TrimSpace(owner)
+	team = strings.TrimSpace(team)
+	if len(owner) > MaxOwnershipLength {
+		return nil, fmt.Errorf("owner cannot exceed %d characters", MaxOwnershipLength)
+	}
+	if len(team) > MaxOwnershipLength {
+		return nil, fmt.Errorf("team cannot exceed %d characters", MaxOwnershipLength)
+	}
+
+	return &Ownership{Owner: owner, Team: team}, nil
+}
+
+// IsEmpty checks if neither the owner nor the team is set
+func (o *Ownership) IsEmpty() bool {
+	return o == nil || (o.Owner == "" && o.Team == "")
+}
+
+// Environment is the deployment stage a resource is meant for. The empty
+// environment leaves a resource unscoped, so that every deployment uses it.
+type Environment string
+
+// Deployment stages
+const (
+	EnvironmentDev     Environment = "dev"
+	EnvironmentStaging Environment = "staging"
+	EnvironmentProd    Environment = "prod"
+)
+
+// EnvironmentFilterAll is the environment filter that lists the resources of
+// every environment
+const EnvironmentFilterAll = "all"
+
+// NewEnvironment creates an environment from its name, empty for none
+func NewEnvironment(name string) (Environment, error) {
+	environment := Environment(strings.ToLower(strings.TrimSpace(name)))
+	if !environment.IsValid() {
+		return "", fmt.Errorf("invalid environment: %s (must be dev, staging or prod)", name)
+	}
+	return environment, nil
+}
+
+// NewEnvironmentFilter returns the environment a list is filtered on: the
+// named one, the default one when none is named, and none for all
+func NewEnvironmentFilter(name string, defaultEnvironment Environment) (Environment, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return defaultEnvironment, nil
+	}
+	if strings.EqualFold(name, EnvironmentFilterAll) {
+		return "", nil
+	}
+	return NewEnvironment(name)
+}
+
+// IsValid checks if the environment is a known stage or empty
+func (e Environment) IsValid() bool {
+	switch e {
+	case "", EnvironmentDev, EnvironmentStaging, EnvironmentProd:
+		return true
+	}
+	return false
+}
+
+// String returns the name of the environment
+func (e Environment) String() string {
+	return string(e)
+}
+
+// AllowsSendTo checks if a deployment of this environment may send through a
+// resource of the given environment without an explicit override. Only the
+// deployments of a stage below prod are kept away from prod resources, so
+// that testing cannot reach production recipients by mistake.
+func (e Environment) AllowsSendTo(resource Environment) bool {
+	return resource != EnvironmentProd || e == "" || e == EnvironmentProd
+}
+
 // Timestamps represents creation, update, and deletion timestamps
 type Timestamps struct {
 	CreatedAt int64  `json:"createdAt"` // Unix timestamp in milliseconds
@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnvironment(t *testing.T) {
+	environment, err := NewEnvironment(" Prod ")
+	require.NoError(t, err)
+	assert.Equal(t, EnvironmentProd, environment)
+
+	environment, err = NewEnvironment("")
+	require.NoError(t, err)
+	assert.Equal(t, Environment(""), environment)
+
+	_, err = NewEnvironment("production")
+	assert.Error(t, err)
+}
+
+func TestNewEnvironmentFilter(t *testing.T) {
+	environment, err := NewEnvironmentFilter("", EnvironmentStaging)
+	require.NoError(t, err)
+	assert.Equal(t, EnvironmentStaging, environment)
+
+	environment, err = NewEnvironmentFilter("ALL", EnvironmentStaging)
+	require.NoError(t, err)
+	assert.Equal(t, Environment(""), environment)
+
+	environment, err = NewEnvironmentFilter("dev", EnvironmentStaging)
+	require.NoError(t, err)
+	assert.Equal(t, EnvironmentDev, environment)
+}
+
+func TestEnvironment_AllowsSendTo(t *testing.T) {
+	assert.False(t, EnvironmentStaging.AllowsSendTo(EnvironmentProd))
+	assert.False(t, EnvironmentDev.AllowsSendTo(EnvironmentProd))
+	assert.True(t, EnvironmentStaging.AllowsSendTo(EnvironmentStaging))
+	assert.True(t, EnvironmentDev.AllowsSendTo(""))
+	assert.True(t, EnvironmentProd.AllowsSendTo(EnvironmentProd))
+	// An unscoped deployment keeps the behavior from before environments
+	assert.True(t, Environment("").AllowsSendTo(EnvironmentProd))
+}
@@ -0,0 +1,111 @@
+// Package slo holds the service level objectives of the delivery latency: the
+// share of the messages to be dispatched within a delay of their creation, and
+// the error budget the other messages consume.
+package slo
+
+import "time"
+
+// Objective is a delivery latency objective, e.g. 99% of the messages
+// dispatched within 30s over 30 days.
+type Objective struct {
+	Name string
+	// Threshold is the delay from the creation of a message by which it is
+	// dispatched, i.e. sent successfully to at least one channel
+	Threshold time.Duration
+	// Target is the share of the messages dispatched within the threshold,
+	// below 1
+	Target float64
+	// Window is the rolling period the objective is measured over
+	Window time.Duration
+}
+
+// ErrorBudget returns the share of the messages allowed to miss the threshold.
+func (o Objective) ErrorBudget() float64 {
+	return 1 - o.Target
+}
+
+// Measurement counts the messages of a period and those of them dispatched
+// within the threshold of an objective.
+type Measurement struct {
+	Total      int
+	Dispatched int
+}
+
+// Compliance returns the share of the messages dispatched within the
+// threshold, 1 when there is none.
+func (m Measurement) Compliance() float64 {
+	if m.Total == 0 {
+		return 1
+	}
+	return float64(m.Dispatched) / float64(m.Total)
+}
+
+// BurnRate returns how fast the period consumed the error budget of an
+// objective: 1 consumes it exactly over the window, higher exhausts it before.
+func (m Measurement) BurnRate(objective Objective) float64 {
+	budget := objective.ErrorBudget()
+	if budget <= 0 {
+		return 0
+	}
+	return (1 - m.Compliance()) / budget
+}
+
+// BudgetRemaining returns the share of the error budget of an objective left
+// after the period, negative once exceeded.
+func (m Measurement) BudgetRemaining(objective Objective) float64 {
+	return 1 - m.BurnRate(objective)
+}
+
+// BurnRateAlert fires when the error budget burns faster than a threshold over
+// both a long window, so that it is significant, and a short one, so that it
+// still burns.
+type BurnRateAlert struct {
+	Name      string
+	Long      time.Duration
+	Short     time.Duration
+	Threshold float64
+}
+
+// DefaultBurnRateAlerts page on a 30 days budget 2% consumed in an hour and
+// 5% consumed in 6 hours.
+var DefaultBurnRateAlerts = []BurnRateAlert{
+	{Name: "fast", Long: time.Hour, Short: 5 * time.Minute, Threshold: 14.4},
+	{Name: "slow", Long: 6 * time.Hour, Short: 30 * time.Minute, Threshold: 6},
+}
+
+// BurnRate is the burn rate of an objective over the windows of an alert.
+type BurnRate struct {
+	Alert BurnRateAlert
+	Long  float64
+	Short float64
+}
+
+// Firing tells whether the alert fires.
+func (b BurnRate) Firing() bool {
+	return b.Long > b.Alert.Threshold && b.Short > b.Alert.Threshold
+}
+
+// Status is how an objective is met at a time.
+type Status struct {
+	Objective Objective
+	// Measurement covers the window of the objective
+	Measurement Measurement
+	BurnRates   []BurnRate
+	EvaluatedAt time.Time
+}
+
+// Compliance returns the share of the messages of the window dispatched
+// within the threshold.
+func (s *Status) Compliance() float64 {
+	return s.Measurement.Compliance()
+}
+
+// Met tells whether the objective is met over its window.
+func (s *Status) Met() bool {
+	return s.Compliance() >= s.Objective.Target
+}
+
+// BudgetRemaining returns the share of the error budget left over the window.
+func (s *Status) BudgetRemaining() float64 {
+	return s.Measurement.BudgetRemaining(s.Objective)
+}
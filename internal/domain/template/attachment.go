@@ -0,0 +1,175 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxAttachmentSize is the largest content of a single attachment
+	MaxAttachmentSize = 1 << 20
+	// MaxAttachmentsSize is the largest content of all the attachments of a template
+	MaxAttachmentsSize = 5 << 20
+	// MaxAttachments is the largest number of attachments of a template
+	MaxAttachments = 10
+)
+
+// contentIDPattern restricts content IDs to characters that need no quoting
+// in a Content-ID header or a cid: URL
+var contentIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+
+// Attachment is a file sent with the messages of a template. An inline
+// attachment is shown in the HTML content, which refers to it with
+// cid:<contentID>, typically as the source of an image.
+type Attachment struct {
+	filename    string
+	contentType string
+	content     []byte
+	inline      bool
+	contentID   string
+}
+
+// NewAttachment creates an attachment. An empty content type is derived from
+// the filename, and the content ID of an inline attachment defaults to the
+// filename.
+func NewAttachment(filename, contentType string, content []byte, inline bool, contentID string) (*Attachment, error) {
+	filename = strings.TrimSpace(filename)
+	if filename == "" {
+		return nil, errors.New("attachment filename cannot be empty")
+	}
+	if len(filename) > 255 {
+		return nil, errors.New("attachment filename cannot exceed 255 characters")
+	}
+	if strings.ContainsAny(filename, "/\\\"\r\n") {
+		return nil, fmt.Errorf("attachment filename %q cannot contain path separators, quotes or line breaks", filename)
+	}
+
+	contentType = strings.TrimSpace(contentType)
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return nil, fmt.Errorf("attachment %s has an invalid content type: %w", filename, err)
+	}
+
+	if len(content) == 0 {
+		return nil, fmt.Errorf("attachment %s cannot be empty", filename)
+	}
+	if len(content) > MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment %s cannot exceed %d bytes", filename, MaxAttachmentSize)
+	}
+
+	contentID = strings.TrimSpace(contentID)
+	if inline {
+		if contentID == "" {
+			contentID = filename
+		}
+		if !contentIDPattern.MatchString(contentID) {
+			return nil, fmt.Errorf("attachment %s needs a content ID of letters, digits, '.', '_' and '-'", filename)
+		}
+	} else if contentID != "" {
+		return nil, fmt.Errorf("attachment %s has a content ID but is not inline", filename)
+	}
+
+	return &Attachment{
+		filename:    filename,
+		contentType: contentType,
+		content:     append([]byte(nil), content...),
+		inline:      inline,
+		contentID:   contentID,
+	}, nil
+}
+
+// Filename returns the filename.
+func (a *Attachment) Filename() string {
+	return a.filename
+}
+
+// ContentType returns the MIME type of the content.
+func (a *Attachment) ContentType() string {
+	return a.contentType
+}
+
+// Content returns the content.
+func (a *Attachment) Content() []byte {
+	return a.content
+}
+
+// IsInline checks if the attachment is shown in the content.
+func (a *Attachment) IsInline() bool {
+	return a.inline
+}
+
+// ContentID returns the name the content uses to refer to an inline
+// attachment, empty for other attachments.
+func (a *Attachment) ContentID() string {
+	return a.contentID
+}
+
+// Attachments are the attachments of a template.
+type Attachments struct {
+	items []*Attachment
+}
+
+// NewAttachments creates the attachments of a template. Filenames and the
+// content IDs of the inline attachments must be unique.
+func NewAttachments(items []*Attachment) (*Attachments, error) {
+	if len(items) > MaxAttachments {
+		return nil, fmt.Errorf("a template cannot have more than %d attachments", MaxAttachments)
+	}
+
+	filenames := make(map[string]bool)
+	contentIDs := make(map[string]bool)
+	total := 0
+	for _, item := range items {
+		if item == nil {
+			return nil, errors.New("attachment cannot be nil")
+		}
+		if filenames[item.filename] {
+			return nil, fmt.Errorf("duplicate attachment filename %s", item.filename)
+		}
+		filenames[item.filename] = true
+		if item.inline {
+			if contentIDs[item.contentID] {
+				return nil, fmt.Errorf("duplicate attachment content ID %s", item.contentID)
+			}
+			contentIDs[item.contentID] = true
+		}
+		total += len(item.content)
+	}
+	if total > MaxAttachmentsSize {
+		return nil, fmt.Errorf("the attachments of a template cannot exceed %d bytes", MaxAttachmentsSize)
+	}
+
+	return &Attachments{items: append([]*Attachment(nil), items...)}, nil
+}
+
+// ToSlice converts to a slice.
+func (a *Attachments) ToSlice() []*Attachment {
+	if a == nil {
+		return nil
+	}
+	result := make([]*Attachment, len(a.items))
+	copy(result, a.items)
+	return result
+}
+
+// Count gets the number of attachments.
+func (a *Attachments) Count() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.items)
+}
+
+// IsEmpty checks if there are no attachments.
+func (a *Attachments) IsEmpty() bool {
+	return a.Count() == 0
+}
@@ -0,0 +1,77 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+)
+
+// MaxEmailHeaders is the most extra headers an email may carry
+const MaxEmailHeaders = 20
+
+// reservedEmailHeaders are the headers the email service writes itself
+var reservedEmailHeaders = map[string]bool{
+	"From":                      true,
+	"To":                        true,
+	"Cc":                        true,
+	"Bcc":                       true,
+	"Subject":                   true,
+	"Date":                      true,
+	"Message-Id":                true,
+	"Mime-Version":              true,
+	"Content-Type":              true,
+	"Content-Transfer-Encoding": true,
+}
+
+// NormalizeEmailHeaders checks the extra headers of an email, their values
+// possibly holding {variable} placeholders, and returns them by canonical
+// name. Headers the email service writes itself cannot be set.
+func NormalizeEmailHeaders(headers map[string]string) (map[string]string, error) {
+	if len(headers) > MaxEmailHeaders {
+		return nil, fmt.Errorf("an email cannot carry more than %d extra headers", MaxEmailHeaders)
+	}
+
+	normalized := make(map[string]string, len(headers))
+	for name, value := range headers {
+		name = strings.TrimSpace(name)
+		if !isHeaderName(name) {
+			return nil, fmt.Errorf("header name %q is invalid", name)
+		}
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if reservedEmailHeaders[name] {
+			return nil, fmt.Errorf("header %s is set by the email service", name)
+		}
+		if _, ok := normalized[name]; ok {
+			return nil, fmt.Errorf("header %s is set twice", name)
+		}
+		if err := CheckEmailHeaderValue(value); err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		normalized[name] = strings.TrimSpace(value)
+	}
+	return normalized, nil
+}
+
+// CheckEmailHeaderValue checks that a header value cannot start another
+// header or the body of the email.
+func CheckEmailHeaderValue(value string) error {
+	if strings.ContainsAny(value, "\r\n") {
+		return errors.New("value cannot contain line breaks")
+	}
+	return nil
+}
+
+// isHeaderName tells whether name is a header field name (RFC 5322): printable
+// ASCII characters but the colon
+func isHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if c < 33 || c > 126 || c == ':' {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"errors"
+	"strings"
 
 	"notification/internal/domain/shared"
 )
@@ -15,8 +16,24 @@ type Template struct {
 	subject     *Subject
 	content     *TemplateContent
 	tags        *Tags
-	timestamps  *shared.Timestamps
-	version     *Version
+	// strict makes rendering fail when a variable has no value
+	strict bool
+	// attachments are sent with the messages of the template
+	attachments *Attachments
+	// headers are the extra headers of the emails of the template, by
+	// canonical name, their values rendered with the variables
+	headers   map[string]string
+	ownership *shared.Ownership
+	// environment is the deployment stage the template is meant for
+	environment shared.Environment
+	// variables are those declared by the author, besides the ones the
+	// subject and content use
+	variables []string
+	// settings are the delivery settings stored with the template, nil when
+	// it has none
+	settings   *shared.CommonSettings
+	timestamps *shared.Timestamps
+	version    *Version
 }
 
 // NewTemplate creates a new template.
@@ -58,6 +75,8 @@ func NewTemplate(
 		subject:     subject,
 		content:     content,
 		tags:        tags,
+		attachments: &Attachments{},
+		ownership:   &shared.Ownership{},
 		timestamps:  shared.NewTimestamps(),
 		version:     NewVersion(),
 	}, nil
@@ -72,9 +91,23 @@ func ReconstructTemplate(
 	subject *Subject,
 	content *TemplateContent,
 	tags *Tags,
+	strict bool,
+	attachments *Attachments,
+	headers map[string]string,
+	ownership *shared.Ownership,
+	environment shared.Environment,
+	variables []string,
+	settings *shared.CommonSettings,
 	timestamps *shared.Timestamps,
 	version *Version,
 ) *Template {
+	if ownership == nil {
+		ownership = &shared.Ownership{}
+	}
+	if attachments == nil {
+		attachments = &Attachments{}
+	}
+
 	return &Template{
 		id:          id,
 		name:        name,
@@ -83,6 +116,13 @@ func ReconstructTemplate(
 		subject:     subject,
 		content:     content,
 		tags:        tags,
+		strict:      strict,
+		attachments: attachments,
+		headers:     headers,
+		ownership:   ownership,
+		environment: environment,
+		variables:   variables,
+		settings:    settings,
 		timestamps:  timestamps,
 		version:     version,
 	}
@@ -128,6 +168,45 @@ func (t *Template) Timestamps() *shared.Timestamps {
 	return t.timestamps
 }
 
+// IsStrict checks if rendering fails on variables without a value.
+func (t *Template) IsStrict() bool {
+	return t.strict
+}
+
+// Attachments gets the attachments.
+func (t *Template) Attachments() *Attachments {
+	return t.attachments
+}
+
+// Headers gets the extra email headers by canonical name.
+func (t *Template) Headers() map[string]string {
+	headers := make(map[string]string, len(t.headers))
+	for name, value := range t.headers {
+		headers[name] = value
+	}
+	return headers
+}
+
+// Ownership gets the owner and team of the template.
+func (t *Template) Ownership() *shared.Ownership {
+	return t.ownership
+}
+
+// Environment gets the deployment stage of the template, empty when unscoped.
+func (t *Template) Environment() shared.Environment {
+	return t.environment
+}
+
+// DeclaredVariables gets the variables declared by the author.
+func (t *Template) DeclaredVariables() []string {
+	return append([]string{}, t.variables...)
+}
+
+// Settings gets the delivery settings, nil when the template has none.
+func (t *Template) Settings() *shared.CommonSettings {
+	return t.settings
+}
+
 // Version gets the version number.
 func (t *Template) Version() *Version {
 	return t.version
@@ -177,6 +256,77 @@ func (t *Template) Update(
 	return nil
 }
 
+// SetStrict sets whether rendering fails on variables without a value.
+func (t *Template) SetStrict(strict bool) {
+	if t.strict == strict {
+		return
+	}
+	t.strict = strict
+	t.timestamps.UpdateTimestamp()
+}
+
+// SetAttachments replaces the attachments.
+func (t *Template) SetAttachments(attachments *Attachments) {
+	if attachments == nil {
+		attachments = &Attachments{}
+	}
+	t.attachments = attachments
+	t.timestamps.UpdateTimestamp()
+}
+
+// SetHeaders replaces the extra email headers, checked by NormalizeEmailHeaders.
+func (t *Template) SetHeaders(headers map[string]string) error {
+	normalized, err := NormalizeEmailHeaders(headers)
+	if err != nil {
+		return err
+	}
+	t.headers = normalized
+	t.timestamps.UpdateTimestamp()
+	return nil
+}
+
+// SetOwnership replaces the owner and team of the template.
+func (t *Template) SetOwnership(ownership *shared.Ownership) {
+	if ownership == nil {
+		ownership = &shared.Ownership{}
+	}
+	t.ownership = ownership
+	t.timestamps.UpdateTimestamp()
+}
+
+// SetEnvironment sets the deployment stage of the template.
+func (t *Template) SetEnvironment(environment shared.Environment) {
+	t.environment = environment
+	t.timestamps.UpdateTimestamp()
+}
+
+// SetDeclaredVariables replaces the variables declared by the author.
+func (t *Template) SetDeclaredVariables(variables []string) {
+	t.variables = NormalizeVariables(variables)
+	t.timestamps.UpdateTimestamp()
+}
+
+// NormalizeVariables trims the declared variables, dropping blank and repeated ones.
+func NormalizeVariables(variables []string) []string {
+	declared := make([]string, 0, len(variables))
+	seen := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		variable = strings.TrimSpace(variable)
+		if variable == "" || seen[variable] {
+			continue
+		}
+		seen[variable] = true
+		declared = append(declared, variable)
+	}
+	return declared
+}
+
+// SetSettings replaces the delivery settings; nil removes them.
+func (t *Template) SetSettings(settings *shared.CommonSettings) {
+	t.settings = settings
+	t.timestamps.UpdateTimestamp()
+}
+
 // Delete soft deletes the template.
 func (t *Template) Delete() error {
 	if t.timestamps.IsDeleted() {
@@ -31,12 +31,23 @@ type TemplateRepository interface {
 	
 	// ExistsByName checks if a template with the specified name exists.
 	ExistsByName(ctx context.Context, name *TemplateName) (bool, error)
+
+	// CountTags returns the number of templates carrying each tag.
+	CountTags(ctx context.Context) (map[string]int, error)
+
+	// ReplaceTags replaces the given tags with another tag on every template
+	// carrying one of them and returns the number of templates changed.
+	ReplaceTags(ctx context.Context, from []string, to string) (int, error)
 }
 
 // TemplateFilter is the filter for templates.
 type TemplateFilter struct {
 	ChannelType *shared.ChannelType `json:"channelType,omitempty"`
 	Tags        []string            `json:"tags,omitempty"`
+	Owner       string              `json:"owner,omitempty"`
+	Team        string              `json:"team,omitempty"`
+	// Environment matches the templates of the environment and the unscoped ones
+	Environment shared.Environment `json:"environment,omitempty"`
 }
 
 // NewTemplateFilter creates a template filter.
@@ -56,6 +67,24 @@ func (f *TemplateFilter) WithTags(tags []string) *TemplateFilter {
 	return f
 }
 
+// WithOwner sets the owner filter.
+func (f *TemplateFilter) WithOwner(owner string) *TemplateFilter {
+	f.Owner = owner
+	return f
+}
+
+// WithTeam sets the team filter.
+func (f *TemplateFilter) WithTeam(team string) *TemplateFilter {
+	f.Team = team
+	return f
+}
+
+// WithEnvironment sets the environment filter.
+func (f *TemplateFilter) WithEnvironment(environment shared.Environment) *TemplateFilter {
+	f.Environment = environment
+	return f
+}
+
 // HasChannelTypeFilter checks if there is a channel type filter.
 func (f *TemplateFilter) HasChannelTypeFilter() bool {
 	return f.ChannelType != nil
@@ -64,4 +93,19 @@ func (f *TemplateFilter) HasChannelTypeFilter() bool {
 // HasTagsFilter checks if there is a tag filter.
 func (f *TemplateFilter) HasTagsFilter() bool {
 	return len(f.Tags) > 0
+}
+
+// HasOwnerFilter checks if there is an owner filter.
+func (f *TemplateFilter) HasOwnerFilter() bool {
+	return f.Owner != ""
+}
+
+// HasTeamFilter checks if there is a team filter.
+func (f *TemplateFilter) HasTeamFilter() bool {
+	return f.Team != ""
+}
+
+// HasEnvironmentFilter checks if there is an environment filter.
+func (f *TemplateFilter) HasEnvironmentFilter() bool {
+	return f.Environment != ""
 }
\ No newline at end of file
@@ -0,0 +1,68 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError is a placeholder syntax error at a 1-based line and column.
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error implements error.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// CheckSyntax reports the malformed {variable} placeholders of a template
+// text: unclosed, unmatched, nested, multi-line and empty placeholders.
+func CheckSyntax(text string) []*SyntaxError {
+	var errs []*SyntaxError
+
+	line, column := 1, 0
+	openLine, openColumn := 0, 0
+	var name strings.Builder
+	open := false
+
+	for _, r := range text {
+		column++
+
+		switch {
+		case r == '{':
+			if open {
+				errs = append(errs, &SyntaxError{Line: line, Column: column, Message: "nested '{' inside a placeholder"})
+				continue
+			}
+			open = true
+			openLine, openColumn = line, column
+			name.Reset()
+		case r == '}':
+			if !open {
+				errs = append(errs, &SyntaxError{Line: line, Column: column, Message: "unmatched '}'"})
+				continue
+			}
+			open = false
+			if strings.TrimSpace(name.String()) == "" {
+				errs = append(errs, &SyntaxError{Line: openLine, Column: openColumn, Message: "empty placeholder"})
+			}
+		case r == '\n':
+			if open {
+				errs = append(errs, &SyntaxError{Line: openLine, Column: openColumn, Message: "placeholder is not closed on its line"})
+				open = false
+			}
+			line++
+			column = 0
+		case open:
+			name.WriteRune(r)
+		}
+	}
+
+	if open {
+		errs = append(errs, &SyntaxError{Line: openLine, Column: openColumn, Message: "unclosed '{'"})
+	}
+
+	return errs
+}
@@ -0,0 +1,26 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSyntax(t *testing.T) {
+	assert.Empty(t, CheckSyntax("Hello {name},\nyour order { order_id } shipped"))
+
+	errs := CheckSyntax("Hello {name\n} and {}\n{a{b}} {tail")
+
+	got := make([]string, 0, len(errs))
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	assert.Equal(t, []string{
+		"line 1, column 7: placeholder is not closed on its line",
+		"line 2, column 1: unmatched '}'",
+		"line 2, column 7: empty placeholder",
+		"line 3, column 3: nested '{' inside a placeholder",
+		"line 3, column 6: unmatched '}'",
+		"line 3, column 8: unclosed '{'",
+	}, got)
+}
@@ -0,0 +1,94 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"notification/internal/domain/services"
+)
+
+// bitlyShortenURL is the endpoint of the Bitly API shortening a link
+const bitlyShortenURL = "https://api-ssl.bitly.com/v4/shorten"
+
+// BitlyLinkShortener shortens links with Bitly, e.g. to send them on a
+// branded domain. The link it shortens is the tracked short link of the
+// internal shortener, so that the clicks are still counted against the
+// message.
+type BitlyLinkShortener struct {
+	tracked    services.LinkShortener
+	httpClient *http.Client
+	token      string
+	// domain is the Bitly domain of the short links, empty for bit.ly
+	domain  string
+	baseURL string
+}
+
+// NewBitlyLinkShortener creates a Bitly shortener of the links shortened by tracked
+func NewBitlyLinkShortener(tracked services.LinkShortener, token, domain string, timeout time.Duration) *BitlyLinkShortener {
+	return &BitlyLinkShortener{
+		tracked: tracked,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		token:   token,
+		domain:  domain,
+		baseURL: bitlyShortenURL,
+	}
+}
+
+// bitlyShortenRequest represents the request shortening a link
+type bitlyShortenRequest struct {
+	LongURL string `json:"long_url"`
+	Domain  string `json:"domain,omitempty"`
+}
+
+// bitlyShortenResponse represents the short link Bitly answers with
+type bitlyShortenResponse struct {
+	Link string `json:"link"`
+}
+
+// Shorten implements services.LinkShortener
+func (s *BitlyLinkShortener) Shorten(ctx context.Context, targetURL, messageID, channelID string) (string, error) {
+	trackedURL, err := s.tracked.Shorten(ctx, targetURL, messageID, channelID)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(&bitlyShortenRequest{LongURL: trackedURL, Domain: s.domain})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Bitly request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create Bitly request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send Bitly request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Bitly answers 200 for a link it shortened before and 201 for a new one
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", &StatusError{Provider: "Bitly", StatusCode: resp.StatusCode}
+	}
+
+	var shortened bitlyShortenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&shortened); err != nil {
+		return "", fmt.Errorf("failed to decode Bitly response: %w", err)
+	}
+	if shortened.Link == "" {
+		return "", errors.New("Bitly response has no link")
+	}
+	return shortened.Link, nil
+}
@@ -0,0 +1,111 @@
+package external
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"notification/internal/domain/services"
+)
+
+// icsTimeFormat is the UTC date-time format of iCalendar (RFC 5545)
+const icsTimeFormat = "20060102T150405Z"
+
+// icsLineLength is the longest line of an iCalendar object in octets,
+// longer lines are folded
+const icsLineLength = 75
+
+// buildCalendarInvite builds the iCalendar object requesting the attendance
+// of an event, organized by the sender of the email. The attendees default
+// to the recipients of the email.
+func buildCalendarInvite(invite *services.CalendarInvite, from string, recipients []string, now time.Time) string {
+	attendees := invite.Attendees
+	if len(attendees) == 0 {
+		attendees = recipients
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//notification//calendar invite//EN",
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:REQUEST",
+		"BEGIN:VEVENT",
+		"UID:" + escapeICSText(invite.UID),
+		"SEQUENCE:" + fmt.Sprint(invite.Sequence),
+		"DTSTAMP:" + now.UTC().Format(icsTimeFormat),
+		"DTSTART:" + invite.Start.UTC().Format(icsTimeFormat),
+		"DTEND:" + invite.End.UTC().Format(icsTimeFormat),
+		"SUMMARY:" + escapeICSText(invite.Title),
+	}
+	if invite.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICSText(invite.Description))
+	}
+	if invite.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeICSText(invite.Location))
+	}
+	if organizer := icsAddress(from); organizer != "" {
+		lines = append(lines, "ORGANIZER:mailto:"+organizer)
+	}
+	for _, attendee := range attendees {
+		if address := icsAddress(attendee); address != "" {
+			lines = append(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:"+address)
+		}
+	}
+	lines = append(lines, "STATUS:CONFIRMED", "TRANSP:OPAQUE", "END:VEVENT", "END:VCALENDAR")
+
+	var ics strings.Builder
+	for _, line := range lines {
+		ics.WriteString(foldICSLine(line))
+		ics.WriteString("\r\n")
+	}
+	return ics.String()
+}
+
+// icsAddress returns the bare email address of a mailbox, empty when it is
+// not one
+func icsAddress(mailbox string) string {
+	address, err := mail.ParseAddress(mailbox)
+	if err != nil {
+		return ""
+	}
+	return address.Address
+}
+
+// escapeICSText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeICSText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(value)
+}
+
+// foldICSLine splits a content line longer than icsLineLength octets,
+// continuing it on lines starting with a space and never splitting a UTF-8
+// character
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLength {
+		return line
+	}
+
+	var folded strings.Builder
+	limit := icsLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		folded.WriteString(line[:cut])
+		folded.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the length of the next line
+		limit = icsLineLength - 1
+	}
+	folded.WriteString(line)
+	return folded.String()
+}
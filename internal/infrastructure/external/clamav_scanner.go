@@ -0,0 +1,99 @@
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamAVChunkSize is the size of the chunks the attachments are streamed in,
+// below the StreamMaxLength of any clamd
+const clamAVChunkSize = 64 << 10
+
+// ClamAVScanner scans attachments with a clamd daemon, streaming them with
+// the INSTREAM command.
+type ClamAVScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates a scanner of the clamd listening at address, a
+// host:port or the path of a unix socket.
+func NewClamAVScanner(address string, timeout time.Duration) *ClamAVScanner {
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+	return &ClamAVScanner{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}
+}
+
+// Scan implements services.AttachmentScanner
+func (s *ClamAVScanner) Scan(ctx context.Context, content []byte) (string, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return "", fmt.Errorf("failed to set clamd deadline: %w", err)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("failed to send clamd command: %w", err)
+	}
+	size := make([]byte, 4)
+	for start := 0; start < len(content); start += clamAVChunkSize {
+		chunk := content[start:min(start+clamAVChunkSize, len(content))]
+		binary.BigEndian.PutUint32(size, uint32(len(chunk)))
+		if _, err := conn.Write(size); err != nil {
+			return "", fmt.Errorf("failed to stream to clamd: %w", err)
+		}
+		if _, err := conn.Write(chunk); err != nil {
+			return "", fmt.Errorf("failed to stream to clamd: %w", err)
+		}
+	}
+	// A zero-length chunk ends the stream
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return "", fmt.Errorf("failed to stream to clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return "", fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamAVReply(reply)
+}
+
+// parseClamAVReply reads the verdict of a clamd reply: "stream: OK",
+// "stream: <signature> FOUND" or "<reason> ERROR"
+func parseClamAVReply(reply string) (string, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return "", nil
+	case strings.HasSuffix(result, " FOUND"):
+		return strings.TrimSuffix(result, " FOUND"), nil
+	case strings.HasSuffix(result, " ERROR"):
+		return "", fmt.Errorf("clamd error: %s", strings.TrimSuffix(result, " ERROR"))
+	default:
+		return "", errors.New("unexpected clamd reply: " + reply)
+	}
+}
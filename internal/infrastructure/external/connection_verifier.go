@@ -0,0 +1,98 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"notification/internal/domain/channel"
+)
+
+// ErrCredentialsRejected is returned when a provider refuses the credentials of a channel
+var ErrCredentialsRejected = errors.New("provider rejected the credentials")
+
+// CredentialVerifier is implemented by the message senders that can check
+// the credentials of a channel at its provider without sending anything
+type CredentialVerifier interface {
+	// VerifyCredentials checks the credentials of a channel. verified is
+	// false when its provider offers no way to check them.
+	VerifyCredentials(ctx context.Context, ch *channel.Channel) (verified bool, err error)
+}
+
+// ChannelConnectionVerifier checks that the provider of a channel accepts
+// connections with the channel configuration, with the senders of a factory.
+// Channel types whose sender cannot check credentials report that nothing was
+// verified.
+type ChannelConnectionVerifier struct {
+	factory MessageSenderFactory
+}
+
+// NewChannelConnectionVerifier creates a new connection verifier
+func NewChannelConnectionVerifier(factory MessageSenderFactory) *ChannelConnectionVerifier {
+	return &ChannelConnectionVerifier{factory: factory}
+}
+
+// Verify verifies the provider credentials of a channel
+func (v *ChannelConnectionVerifier) Verify(ctx context.Context, ch *channel.Channel) (bool, error) {
+	sender, err := v.factory.CreateSender(ch.ChannelType().String())
+	if err != nil {
+		return false, nil
+	}
+	verifier, ok := sender.(CredentialVerifier)
+	if !ok {
+		return false, nil
+	}
+	return verifier.VerifyCredentials(ctx, ch)
+}
+
+// CheckReadiness checks a channel at its provider: its credentials when the
+// sender can verify them, otherwise that the provider is reachable
+func (v *ChannelConnectionVerifier) CheckReadiness(ctx context.Context, ch *channel.Channel) (channel.ReadinessStatus, error) {
+	sender, err := v.factory.CreateSender(ch.ChannelType().String())
+	if err != nil {
+		return channel.ReadinessFailed, err
+	}
+	if err := sender.ValidateConfig(ch.Config()); err != nil {
+		return channel.ReadinessFailed, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if verifier, ok := sender.(CredentialVerifier); ok {
+		verified, err := verifier.VerifyCredentials(ctx, ch)
+		if err != nil {
+			return channel.ReadinessFailed, err
+		}
+		if verified {
+			return channel.ReadinessReady, nil
+		}
+	}
+
+	if warmer, ok := sender.(ProviderWarmer); ok {
+		if err := warmer.WarmUp(ctx, ch); err != nil {
+			return channel.ReadinessFailed, err
+		}
+		return channel.ReadinessReachable, nil
+	}
+	return channel.ReadinessUnverified, nil
+}
+
+// checkCredentials sends a request authenticated with the credentials of a
+// channel to a provider endpoint that changes nothing, failing with
+// ErrCredentialsRejected when the provider refuses them
+func checkCredentials(client *http.Client, req *http.Request, provider string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach provider: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrCredentialsRejected, &StatusError{Provider: provider, StatusCode: resp.StatusCode})
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return &StatusError{Provider: provider, StatusCode: resp.StatusCode}
+	}
+	return nil
+}
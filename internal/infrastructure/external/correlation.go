@@ -0,0 +1,18 @@
+package external
+
+import (
+	"context"
+	"net/http"
+
+	"notification/pkg/logger"
+)
+
+// CorrelationIDHeader carries the caller supplied correlation ID to providers and the legacy system
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// setCorrelationHeader copies the correlation ID carried by ctx onto an outgoing provider request
+func setCorrelationHeader(ctx context.Context, header http.Header) {
+	if correlationID := logger.CorrelationIDFromContext(ctx); correlationID != "" {
+		header.Set(CorrelationIDHeader, correlationID)
+	}
+}
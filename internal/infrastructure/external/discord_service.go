@@ -0,0 +1,422 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// discordAPIURL is the base URL of the Discord API the bot channels post to
+const discordAPIURL = "https://discord.com/api/v10"
+
+// Limits of a Discord message
+const (
+	discordContentLength          = 2000
+	discordEmbedTitleLength       = 256
+	discordEmbedDescriptionLength = 4096
+	discordDefaultEmbedColor      = 0x5865F2
+)
+
+// Modes of a Discord channel
+const (
+	discordModeWebhook = "webhook"
+	discordModeBot     = "bot"
+)
+
+// DiscordService implements MessageSender for Discord channels. Webhook
+// channels post to the channel of their webhook, to the threads of the
+// recipients of type "thread" and to the webhooks given by the recipients
+// of type "webhook". Bot channels post to the channels and threads whose ID
+// the recipients give.
+type DiscordService struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewDiscordService creates a new Discord service
+func NewDiscordService(timeout time.Duration) *DiscordService {
+	return &DiscordService{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		timeout: timeout,
+	}
+}
+
+// DiscordConfig holds Discord configuration
+type DiscordConfig struct {
+	Mode       string
+	WebhookURL string
+	BotToken   string
+	BaseURL    string
+	Username   string
+	AvatarURL  string
+	Embed      bool
+	EmbedColor int
+}
+
+// DiscordMessage represents a Discord message payload
+type DiscordMessage struct {
+	Content   string         `json:"content,omitempty"`
+	Username  string         `json:"username,omitempty"`
+	AvatarURL string         `json:"avatar_url,omitempty"`
+	Embeds    []DiscordEmbed `json:"embeds,omitempty"`
+}
+
+// DiscordEmbed represents an embed of a Discord message
+type DiscordEmbed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color,omitempty"`
+	Timestamp   string `json:"timestamp,omitempty"`
+}
+
+// discordRoute is where the message of a recipient is posted
+type discordRoute struct {
+	target     string
+	webhookURL string
+	threadID   string
+	channelID  string
+	err        error
+}
+
+// Send sends a message to Discord
+func (s *DiscordService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to send to Discord target %s: %w", result.Target, result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients posts the message to the route of every recipient and reports the outcome of each
+func (s *DiscordService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeDiscord) {
+		return nil, fmt.Errorf("invalid channel type for Discord service: %s", ch.ChannelType().String())
+	}
+
+	// Extract Discord configuration
+	config, err := s.extractDiscordConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Discord config: %w", err)
+	}
+
+	// Prepare routes
+	routes := s.prepareRoutes(config, ch.Recipients())
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no valid Discord targets found")
+	}
+
+	// Send to all routes
+	payload := s.buildMessage(config, content)
+	results := make([]*RecipientResult, 0, len(routes))
+	for _, route := range routes {
+		if route.err != nil {
+			results = append(results, &RecipientResult{
+				Target:        route.target,
+				Error:         route.err,
+				ErrorCategory: message.ErrorCategoryInvalidRecipient,
+			})
+			continue
+		}
+
+		messageID, err := s.sendToRoute(ctx, config, route, payload)
+		results = append(results, &RecipientResult{
+			Target:            route.target,
+			Success:           err == nil,
+			ProviderMessageID: messageID,
+			Error:             err,
+			SentAt:            time.Now().UnixMilli(),
+		})
+	}
+
+	return results, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the webhook,
+// or to the API for bot channels, in the idle pool of the client.
+func (s *DiscordService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeDiscord) {
+		return nil
+	}
+
+	config, err := s.extractDiscordConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract Discord config: %w", err)
+	}
+	url := config.BaseURL
+	if config.Mode == discordModeWebhook {
+		url = config.WebhookURL
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, url)
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *DiscordService) CloseIdle(before time.Time) int {
+	return 0
+}
+
+// GetChannelType returns the supported channel type
+func (s *DiscordService) GetChannelType() string {
+	return shared.ChannelTypeDiscord.String()
+}
+
+// ValidateConfig validates Discord channel configuration
+func (s *DiscordService) ValidateConfig(config *channel.ChannelConfig) error {
+	_, err := s.extractDiscordConfig(config)
+	return err
+}
+
+// extractDiscordConfig extracts Discord configuration from channel config
+func (s *DiscordService) extractDiscordConfig(config *channel.ChannelConfig) (*DiscordConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+
+	discordConfig := &DiscordConfig{
+		Mode:       strings.ToLower(str("mode")),
+		WebhookURL: str("webhook_url"),
+		BotToken:   str("bot_token"),
+		BaseURL:    str("base_url"),
+		Username:   str("username"),
+		AvatarURL:  str("avatar_url"),
+		EmbedColor: discordDefaultEmbedColor,
+	}
+	if discordConfig.BaseURL == "" {
+		discordConfig.BaseURL = discordAPIURL
+	}
+
+	switch discordConfig.Mode {
+	case discordModeWebhook:
+		if err := validateDiscordWebhookURL(discordConfig.WebhookURL); err != nil {
+			return nil, err
+		}
+	case discordModeBot:
+		if discordConfig.BotToken == "" {
+			return nil, errors.New("missing required field: bot_token (Discord bot token)")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Discord mode: %q. Supported modes: [webhook bot]", discordConfig.Mode)
+	}
+
+	if embed, ok := config.Get("embed"); ok {
+		switch v := embed.(type) {
+		case bool:
+			discordConfig.Embed = v
+		case string:
+			discordConfig.Embed, _ = strconv.ParseBool(v)
+		}
+	}
+	if color := str("embed_color"); color != "" {
+		value, err := strconv.ParseInt(strings.TrimPrefix(color, "#"), 16, 32)
+		if err != nil || value < 0 || value > 0xFFFFFF {
+			return nil, fmt.Errorf("invalid Discord embed color: %s", color)
+		}
+		discordConfig.EmbedColor = int(value)
+	}
+
+	return discordConfig, nil
+}
+
+// validateDiscordWebhookURL checks that a webhook URL is an HTTPS URL
+func validateDiscordWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return errors.New("missing required field: webhook_url (Discord webhook URL)")
+	}
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return errors.New("invalid Discord webhook URL: an HTTPS URL is required")
+	}
+	return nil
+}
+
+// prepareRoutes routes the channel recipients. A webhook channel without
+// recipients posts to the channel of its webhook.
+func (s *DiscordService) prepareRoutes(config *DiscordConfig, recipients *channel.Recipients) []*discordRoute {
+	routes := make([]*discordRoute, 0)
+
+	for _, recipient := range recipients.ToSlice() {
+		target := strings.TrimSpace(recipient.Target)
+		route := &discordRoute{target: target}
+
+		switch config.Mode {
+		case discordModeBot:
+			// Threads are channels of the bot API
+			if !isDiscordSnowflake(target) {
+				route.err = fmt.Errorf("Discord bot recipients need a channel or thread ID, got %q", target)
+			}
+			route.channelID = target
+		default:
+			switch strings.ToLower(recipient.Type) {
+			case "webhook":
+				route.target = discordWebhookTarget(target)
+				route.webhookURL = target
+				route.err = validateDiscordWebhookURL(target)
+			case "thread":
+				route.webhookURL = config.WebhookURL
+				route.threadID = target
+				if !isDiscordSnowflake(target) {
+					route.err = fmt.Errorf("Discord thread recipients need a thread ID, got %q", target)
+				}
+			default:
+				if target != "" {
+					route.err = fmt.Errorf("Discord webhook channels route to other channels through recipients of type webhook, got %q", target)
+				} else {
+					route.target = discordWebhookTarget(config.WebhookURL)
+				}
+				route.webhookURL = config.WebhookURL
+			}
+		}
+
+		routes = append(routes, route)
+	}
+
+	if len(routes) == 0 && config.Mode == discordModeWebhook {
+		routes = append(routes, &discordRoute{target: discordWebhookTarget(config.WebhookURL), webhookURL: config.WebhookURL})
+	}
+
+	return routes
+}
+
+// discordWebhookTarget names a webhook in the results by its ID, keeping its
+// token, the rest of its URL, out of the stored message
+func discordWebhookTarget(webhookURL string) string {
+	parsed, err := url.Parse(webhookURL)
+	if err == nil {
+		segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		for i, segment := range segments {
+			if segment == "webhooks" && i+1 < len(segments) {
+				return "webhook:" + segments[i+1]
+			}
+		}
+	}
+	return "webhook"
+}
+
+// isDiscordSnowflake checks if an ID is a Discord snowflake
+func isDiscordSnowflake(id string) bool {
+	if id == "" || len(id) > 20 {
+		return false
+	}
+	_, err := strconv.ParseUint(id, 10, 64)
+	return err == nil
+}
+
+// buildMessage builds the message posted to every route. Embeds carry the
+// subject as their title; plain messages start with it in bold.
+func (s *DiscordService) buildMessage(config *DiscordConfig, content *services.RenderedContent) *DiscordMessage {
+	msg := &DiscordMessage{}
+	if config.Mode == discordModeWebhook {
+		msg.Username = config.Username
+		msg.AvatarURL = config.AvatarURL
+	}
+
+	if config.Embed {
+		msg.Embeds = []DiscordEmbed{{
+			Title:       truncateRunes(content.Subject, discordEmbedTitleLength),
+			Description: truncateRunes(content.Content, discordEmbedDescriptionLength),
+			Color:       config.EmbedColor,
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		}}
+		return msg
+	}
+
+	text := content.Content
+	if content.Subject != "" {
+		text = "**" + content.Subject + "**\n" + text
+	}
+	msg.Content = truncateRunes(text, discordContentLength)
+	return msg
+}
+
+// truncateRunes shortens a text to at most limit characters, ending it with
+// an ellipsis when it is cut
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// sendToRoute posts a message to a route and returns the Discord message ID
+func (s *DiscordService) sendToRoute(ctx context.Context, config *DiscordConfig, route *discordRoute, msg *DiscordMessage) (string, error) {
+	var endpoint string
+	if route.channelID != "" {
+		endpoint = strings.TrimRight(config.BaseURL, "/") + "/channels/" + route.channelID + "/messages"
+	} else {
+		// wait=true makes the webhook answer with the message it created
+		webhookURL, err := url.Parse(route.webhookURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid Discord webhook URL: %w", err)
+		}
+		query := webhookURL.Query()
+		query.Set("wait", "true")
+		if route.threadID != "" {
+			query.Set("thread_id", route.threadID)
+		}
+		webhookURL.RawQuery = query.Encode()
+		endpoint = webhookURL.String()
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Discord message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	setCorrelationHeader(ctx, req.Header)
+	if route.channelID != "" {
+		req.Header.Set("Authorization", "Bot "+config.BotToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send Discord request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &StatusError{Provider: "Discord", StatusCode: resp.StatusCode}
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&created)
+	return created.ID, nil
+}
@@ -0,0 +1,161 @@
+package external
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"notification/internal/domain/services"
+)
+
+// base64LineLength is the longest line of a base64 encoded part (RFC 2045)
+const base64LineLength = 76
+
+// calendarInviteFilename is the name of the iCalendar file attached to
+// emails carrying a calendar invite
+const calendarInviteFilename = "invite.ics"
+
+// mimePart is a part of a multipart body
+type mimePart struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// writeEmailBody writes the Content-Type header and the body of an email.
+// Without attachments the body is the HTML content. Inline attachments are
+// sent with the HTML in a multipart/related part, so that the cid:
+// references of the content resolve to them. A calendar invite is an
+// alternative to the HTML, which calendar clients offer to accept, and is
+// attached as a file for the others. The other attachments follow in a
+// multipart/mixed message.
+func writeEmailBody(message *strings.Builder, content *services.RenderedContent, calendar string) error {
+	var inline, attached []*services.RenderedAttachment
+	for _, attachment := range content.Attachments {
+		if attachment.Inline {
+			inline = append(inline, attachment)
+		} else {
+			attached = append(attached, attachment)
+		}
+	}
+
+	body := mimePart{
+		header: textproto.MIMEHeader{"Content-Type": {"text/html; charset=UTF-8"}},
+		body:   []byte(content.Content),
+	}
+	var err error
+	if len(inline) > 0 {
+		parts := []mimePart{body}
+		for _, attachment := range inline {
+			parts = append(parts, attachmentPart(attachment))
+		}
+		if body, err = buildMultipart("multipart/related", map[string]string{"type": "text/html"}, parts); err != nil {
+			return err
+		}
+	}
+
+	if calendar != "" {
+		invite := mimePart{
+			header: textproto.MIMEHeader{"Content-Type": {"text/calendar; method=REQUEST; charset=UTF-8"}},
+			body:   []byte(calendar),
+		}
+		if body, err = buildMultipart("multipart/alternative", nil, []mimePart{body, invite}); err != nil {
+			return err
+		}
+		attached = append(attached, &services.RenderedAttachment{
+			Filename:    calendarInviteFilename,
+			ContentType: "application/ics",
+			Content:     []byte(calendar),
+		})
+	}
+
+	if len(attached) > 0 {
+		parts := []mimePart{body}
+		for _, attachment := range attached {
+			parts = append(parts, attachmentPart(attachment))
+		}
+		if body, err = buildMultipart("multipart/mixed", nil, parts); err != nil {
+			return err
+		}
+	}
+
+	message.WriteString(fmt.Sprintf("Content-Type: %s\r\n\r\n", body.header.Get("Content-Type")))
+	message.Write(body.body)
+	return nil
+}
+
+// writeExtraHeaders writes the extra headers of an email by name, encoding
+// the values that are not ASCII (RFC 2047)
+func writeExtraHeaders(message *strings.Builder, headers map[string]string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		message.WriteString(fmt.Sprintf("%s: %s\r\n", name, mime.QEncoding.Encode("UTF-8", headers[name])))
+	}
+}
+
+// buildMultipart builds a multipart part of the given media type
+func buildMultipart(mediaType string, params map[string]string, parts []mimePart) (mimePart, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, part := range parts {
+		pw, err := w.CreatePart(part.header)
+		if err != nil {
+			return mimePart{}, err
+		}
+		if _, err := pw.Write(part.body); err != nil {
+			return mimePart{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return mimePart{}, err
+	}
+
+	contentParams := map[string]string{"boundary": w.Boundary()}
+	for key, value := range params {
+		contentParams[key] = value
+	}
+	return mimePart{
+		header: textproto.MIMEHeader{"Content-Type": {mime.FormatMediaType(mediaType, contentParams)}},
+		body:   body.Bytes(),
+	}, nil
+}
+
+// attachmentPart builds the base64 encoded part of an attachment
+func attachmentPart(attachment *services.RenderedAttachment) mimePart {
+	mediaType, params, err := mime.ParseMediaType(attachment.ContentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+	params["name"] = attachment.Filename
+
+	disposition := "attachment"
+	if attachment.Inline {
+		disposition = "inline"
+	}
+	header := textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(mediaType, params)},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename})},
+	}
+	if attachment.Inline {
+		header.Set("Content-ID", "<"+attachment.ContentID+">")
+	}
+
+	var body bytes.Buffer
+	encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+	for len(encoded) > 0 {
+		n := min(base64LineLength, len(encoded))
+		body.WriteString(encoded[:n])
+		body.WriteString("\r\n")
+		encoded = encoded[n:]
+	}
+	return mimePart{header: header, body: body.Bytes()}
+}
@@ -2,8 +2,9 @@ package external
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/smtp"
+	"html"
 	"strconv"
 	"strings"
 	"time"
@@ -11,17 +12,20 @@ import (
 	"notification/internal/domain/channel"
 	"notification/internal/domain/services"
 	"notification/internal/domain/shared"
+	"notification/pkg/logger"
 )
 
 // EmailService implements MessageSender for email channel
 type EmailService struct {
 	timeout time.Duration
+	pool    *smtpPool
 }
 
 // NewEmailService creates a new email service
 func NewEmailService(timeout time.Duration) *EmailService {
 	return &EmailService{
 		timeout: timeout,
+		pool:    newSMTPPool(),
 	}
 }
 
@@ -44,8 +48,17 @@ func (s *EmailService) Send(ctx context.Context, ch *channel.Channel, content *s
 		return fmt.Errorf("no valid email recipients found")
 	}
 
+	// Emails asking for acknowledgment go to each recipient on its own, with
+	// the link of that recipient
+	if len(content.AcknowledgmentLinks) > 0 {
+		return s.sendWithAcknowledgmentLinks(ctx, config, recipients, content)
+	}
+
 	// Create email message
-	message := s.buildEmailMessage(config, recipients, content)
+	message, err := s.buildEmailMessage(config, recipients, content, logger.CorrelationIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to build email message: %w", err)
+	}
 
 	// Send email with timeout context
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
@@ -54,6 +67,41 @@ func (s *EmailService) Send(ctx context.Context, ch *channel.Channel, content *s
 	return s.sendSMTP(ctx, config, recipients.To, message)
 }
 
+// sendWithAcknowledgmentLinks sends one email per recipient, To, CC and BCC
+// alike, each with the acknowledgment link of its recipient at the end
+func (s *EmailService) sendWithAcknowledgmentLinks(ctx context.Context, config *SMTPConfig, recipients *EmailRecipients, content *services.RenderedContent) error {
+	var errs []error
+	for _, target := range append(append(append([]string{}, recipients.To...), recipients.CC...), recipients.BCC...) {
+		personal := *content
+		if link, ok := content.AcknowledgmentLinks[target]; ok {
+			personal.Content = withAcknowledgmentLink(content.Content, link)
+		}
+
+		message, err := s.buildEmailMessage(config, &EmailRecipients{To: []string{target}}, &personal, logger.CorrelationIDFromContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to build email message: %w", err)
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		err = s.sendSMTP(sendCtx, config, []string{target}, message)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send to %s: %w", target, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// withAcknowledgmentLink adds the acknowledgment link to the HTML content of
+// an email, at the end of its body
+func withAcknowledgmentLink(content, link string) string {
+	paragraph := fmt.Sprintf(`<p><a href="%s">Acknowledge this message</a></p>`, html.EscapeString(link))
+	if i := strings.LastIndex(strings.ToLower(content), "</body>"); i >= 0 {
+		return content[:i] + paragraph + content[i:]
+	}
+	return content + paragraph
+}
+
 // GetChannelType returns the supported channel type
 func (s *EmailService) GetChannelType() string {
 	return shared.ChannelTypeEmail.String()
@@ -101,6 +149,59 @@ func (s *EmailService) ValidateConfig(config *channel.ChannelConfig) error {
 	return nil
 }
 
+// VerifyConnection connects to the SMTP server of an email channel and
+// authenticates with its credentials without sending anything.
+func (s *EmailService) VerifyConnection(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return fmt.Errorf("invalid channel type for email service: %s", ch.ChannelType().String())
+	}
+
+	config, err := s.extractSMTPConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract SMTP config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	c, err := dialSMTP(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer c.client.Close()
+
+	return c.client.Quit()
+}
+
+// VerifyCredentials implements CredentialVerifier with VerifyConnection
+func (s *EmailService) VerifyCredentials(ctx context.Context, ch *channel.Channel) (bool, error) {
+	return true, s.VerifyConnection(ctx, ch)
+}
+
+// WarmUp implements ProviderWarmer. It keeps a connection to the SMTP server
+// of an email channel open and authenticated, checking it with a NOOP and
+// reconnecting when the server dropped it.
+func (s *EmailService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeEmail) {
+		return nil
+	}
+
+	config, err := s.extractSMTPConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract SMTP config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.pool.warm(ctx, config)
+}
+
+// CloseIdle implements ProviderWarmer
+func (s *EmailService) CloseIdle(before time.Time) int {
+	return s.pool.closeIdle(before)
+}
+
 // SMTPConfig holds SMTP configuration
 type SMTPConfig struct {
 	Host     string
@@ -200,8 +301,8 @@ func (s *EmailService) prepareRecipients(recipients *channel.Recipients) *EmailR
 	return emailRecipients
 }
 
-// buildEmailMessage builds the email message
-func (s *EmailService) buildEmailMessage(config *SMTPConfig, recipients *EmailRecipients, content *services.RenderedContent) string {
+// buildEmailMessage builds the email message, tagged with the correlation ID when one is set
+func (s *EmailService) buildEmailMessage(config *SMTPConfig, recipients *EmailRecipients, content *services.RenderedContent, correlationID string) (string, error) {
 	var message strings.Builder
 
 	// Headers
@@ -216,42 +317,32 @@ func (s *EmailService) buildEmailMessage(config *SMTPConfig, recipients *EmailRe
 	}
 
 	message.WriteString(fmt.Sprintf("Subject: %s\r\n", content.Subject))
+	if correlationID != "" {
+		message.WriteString(fmt.Sprintf("%s: %s\r\n", CorrelationIDHeader, correlationID))
+	}
+	writeExtraHeaders(&message, content.Headers)
 	message.WriteString("MIME-Version: 1.0\r\n")
-	message.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
-	message.WriteString("\r\n")
 
-	// Body
-	message.WriteString(content.Content)
+	// Calendar invite of the event the email describes
+	calendar := ""
+	if content.CalendarInvite != nil {
+		calendar = buildCalendarInvite(content.CalendarInvite, config.From, recipients.To, time.Now())
+	}
 
-	return message.String()
+	// Body, with the attachments of the content
+	if err := writeEmailBody(&message, content, calendar); err != nil {
+		return "", err
+	}
+
+	return message.String(), nil
 }
 
-// sendSMTP sends email via SMTP
+// sendSMTP sends email via SMTP, on the open connection to the server when
+// there is one
 func (s *EmailService) sendSMTP(ctx context.Context, config *SMTPConfig, recipients []string, message string) error {
-	// Create SMTP address
-	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
-
-	// Create auth
-	auth := smtp.PlainAuth("", config.Username, config.Password, config.Host)
-
 	// Combine all recipients (To + CC + BCC)
 	allRecipients := make([]string, 0, len(recipients))
 	allRecipients = append(allRecipients, recipients...)
 
-	// Send email with context cancellation support
-	done := make(chan error, 1)
-	go func() {
-		err := smtp.SendMail(addr, auth, config.From, allRecipients, []byte(message))
-		done <- err
-	}()
-
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("email sending cancelled: %w", ctx.Err())
-	case err := <-done:
-		if err != nil {
-			return fmt.Errorf("failed to send email: %w", err)
-		}
-		return nil
-	}
+	return s.pool.send(ctx, config, allRecipients, message)
 }
@@ -0,0 +1,164 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+
+	"notification/internal/domain/message"
+)
+
+// StatusError is returned when a provider answers with an unsuccessful HTTP status
+type StatusError struct {
+	// Provider names the request in the error message, e.g. SMS or webhook
+	Provider   string
+	StatusCode int
+}
+
+// Error implements error
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s request failed with status: %d", e.Provider, e.StatusCode)
+}
+
+// SlackAPIError is returned when the Slack Web API rejects a request
+type SlackAPIError struct {
+	Code string
+}
+
+// Error implements error
+func (e *SlackAPIError) Error() string {
+	return fmt.Sprintf("Slack API error: %s", e.Code)
+}
+
+// WhatsAppAPIError is returned when the WhatsApp Business Cloud API rejects a request
+type WhatsAppAPIError struct {
+	StatusCode int
+	Code       int
+	Message    string
+}
+
+// Error implements error
+func (e *WhatsAppAPIError) Error() string {
+	return fmt.Sprintf("WhatsApp API error %d: %s", e.Code, e.Message)
+}
+
+// ClassifyError maps a provider, SMTP or HTTP error to the category that
+// decides whether sending again may succeed. Errors that cannot be
+// classified are treated as temporary.
+func ClassifyError(err error) message.ErrorCategory {
+	if err == nil {
+		return ""
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return classifyHTTPStatus(statusErr.StatusCode)
+	}
+
+	var slackErr *SlackAPIError
+	if errors.As(err, &slackErr) {
+		return classifySlackError(slackErr.Code)
+	}
+
+	var whatsAppErr *WhatsAppAPIError
+	if errors.As(err, &whatsAppErr) {
+		return classifyWhatsAppError(whatsAppErr)
+	}
+
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		return classifySMTPCode(smtpErr.Code)
+	}
+
+	if category, ok := classifyMQTTRefusal(err); ok {
+		return category
+	}
+
+	// Network errors, timeouts and anything unclassified may pass on a later attempt
+	return message.ErrorCategoryTemporary
+}
+
+// classifyHTTPStatus classifies an unsuccessful HTTP status
+func classifyHTTPStatus(code int) message.ErrorCategory {
+	switch {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return message.ErrorCategoryAuthFailure
+	case code == http.StatusTooManyRequests:
+		return message.ErrorCategoryRateLimited
+	case code == http.StatusNotFound || code == http.StatusUnprocessableEntity:
+		return message.ErrorCategoryInvalidRecipient
+	case code == http.StatusRequestTimeout || code >= 500:
+		return message.ErrorCategoryTemporary
+	default:
+		return message.ErrorCategoryPermanent
+	}
+}
+
+// classifySlackError classifies a Slack Web API error code
+func classifySlackError(code string) message.ErrorCategory {
+	switch code {
+	case "channel_not_found", "user_not_found", "is_archived", "not_in_channel", "cannot_dm_bot":
+		return message.ErrorCategoryInvalidRecipient
+	case "invalid_auth", "not_authed", "token_revoked", "token_expired", "account_inactive", "missing_scope":
+		return message.ErrorCategoryAuthFailure
+	case "ratelimited", "rate_limited":
+		return message.ErrorCategoryRateLimited
+	case "internal_error", "fatal_error", "service_unavailable", "request_timeout":
+		return message.ErrorCategoryTemporary
+	default:
+		return message.ErrorCategoryPermanent
+	}
+}
+
+// classifyWhatsAppError classifies a WhatsApp Business Cloud API error by its
+// code, falling back to the HTTP status for the codes it does not know
+func classifyWhatsAppError(err *WhatsAppAPIError) message.ErrorCategory {
+	switch err.Code {
+	case whatsAppReengagementCode:
+		// A session message outside the 24 hour window, only a template reaches the recipient
+		return message.ErrorCategoryPermanent
+	case 131026:
+		return message.ErrorCategoryInvalidRecipient
+	case 0, 10, 190:
+		return message.ErrorCategoryAuthFailure
+	case 4, 80007, 130429, 131048, 131056:
+		return message.ErrorCategoryRateLimited
+	case 1, 2, 131000, 131016:
+		return message.ErrorCategoryTemporary
+	}
+	return classifyHTTPStatus(err.StatusCode)
+}
+
+// classifyMQTTRefusal classifies the refusal of a connection by an MQTT
+// broker, it reports false for the other errors
+func classifyMQTTRefusal(err error) (message.ErrorCategory, bool) {
+	switch {
+	case errors.Is(err, packets.ErrorRefusedBadUsernameOrPassword), errors.Is(err, packets.ErrorRefusedNotAuthorised):
+		return message.ErrorCategoryAuthFailure, true
+	case errors.Is(err, packets.ErrorRefusedBadProtocolVersion), errors.Is(err, packets.ErrorRefusedIDRejected):
+		// Connecting again with the same client ID and protocol cannot succeed
+		return message.ErrorCategoryPermanent, true
+	case errors.Is(err, packets.ErrorRefusedServerUnavailable):
+		return message.ErrorCategoryTemporary, true
+	}
+	return "", false
+}
+
+// classifySMTPCode classifies an SMTP reply code
+func classifySMTPCode(code int) message.ErrorCategory {
+	switch {
+	case code == 530 || code == 534 || code == 535:
+		return message.ErrorCategoryAuthFailure
+	case code == 550 || code == 551 || code == 553:
+		return message.ErrorCategoryInvalidRecipient
+	case code == 421 || code == 450 || code == 451 || code == 452:
+		return message.ErrorCategoryTemporary
+	case code >= 500:
+		return message.ErrorCategoryPermanent
+	default:
+		return message.ErrorCategoryTemporary
+	}
+}
@@ -0,0 +1,165 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/stretchr/testify/assert"
+
+	"notification/internal/domain/message"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want message.ErrorCategory
+	}{
+		{"nil", nil, ""},
+		{"status", &StatusError{Provider: "SMS", StatusCode: 401}, message.ErrorCategoryAuthFailure},
+		{"wrapped status", fmt.Errorf("send: %w", &StatusError{Provider: "webhook", StatusCode: 503}), message.ErrorCategoryTemporary},
+		{"slack", &SlackAPIError{Code: "channel_not_found"}, message.ErrorCategoryInvalidRecipient},
+		{"wrapped slack", fmt.Errorf("post: %w", &SlackAPIError{Code: "ratelimited"}), message.ErrorCategoryRateLimited},
+		{"whatsapp", &WhatsAppAPIError{StatusCode: 400, Code: 131026}, message.ErrorCategoryInvalidRecipient},
+		{"smtp", &textproto.Error{Code: 535, Msg: "authentication failed"}, message.ErrorCategoryAuthFailure},
+		{"wrapped smtp", fmt.Errorf("rcpt: %w", &textproto.Error{Code: 550, Msg: "no such user"}), message.ErrorCategoryInvalidRecipient},
+		{"mqtt refusal", fmt.Errorf("connect: %w", packets.ErrorRefusedNotAuthorised), message.ErrorCategoryAuthFailure},
+		{"timeout", context.DeadlineExceeded, message.ErrorCategoryTemporary},
+		{"unknown", errors.New("connection reset by peer"), message.ErrorCategoryTemporary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestClassifyHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code int
+		want message.ErrorCategory
+	}{
+		{401, message.ErrorCategoryAuthFailure},
+		{403, message.ErrorCategoryAuthFailure},
+		{429, message.ErrorCategoryRateLimited},
+		{404, message.ErrorCategoryInvalidRecipient},
+		{422, message.ErrorCategoryInvalidRecipient},
+		{408, message.ErrorCategoryTemporary},
+		{500, message.ErrorCategoryTemporary},
+		{503, message.ErrorCategoryTemporary},
+		{400, message.ErrorCategoryPermanent},
+		{413, message.ErrorCategoryPermanent},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.code), func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyHTTPStatus(tt.code))
+		})
+	}
+}
+
+func TestClassifySlackError(t *testing.T) {
+	tests := []struct {
+		code string
+		want message.ErrorCategory
+	}{
+		{"channel_not_found", message.ErrorCategoryInvalidRecipient},
+		{"user_not_found", message.ErrorCategoryInvalidRecipient},
+		{"is_archived", message.ErrorCategoryInvalidRecipient},
+		{"not_in_channel", message.ErrorCategoryInvalidRecipient},
+		{"cannot_dm_bot", message.ErrorCategoryInvalidRecipient},
+		{"invalid_auth", message.ErrorCategoryAuthFailure},
+		{"not_authed", message.ErrorCategoryAuthFailure},
+		{"token_revoked", message.ErrorCategoryAuthFailure},
+		{"token_expired", message.ErrorCategoryAuthFailure},
+		{"account_inactive", message.ErrorCategoryAuthFailure},
+		{"missing_scope", message.ErrorCategoryAuthFailure},
+		{"ratelimited", message.ErrorCategoryRateLimited},
+		{"rate_limited", message.ErrorCategoryRateLimited},
+		{"internal_error", message.ErrorCategoryTemporary},
+		{"fatal_error", message.ErrorCategoryTemporary},
+		{"service_unavailable", message.ErrorCategoryTemporary},
+		{"request_timeout", message.ErrorCategoryTemporary},
+		{"msg_too_long", message.ErrorCategoryPermanent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifySlackError(tt.code))
+		})
+	}
+}
+
+func TestClassifyWhatsAppError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *WhatsAppAPIError
+		want message.ErrorCategory
+	}{
+		{"re-engagement", &WhatsAppAPIError{StatusCode: 400, Code: whatsAppReengagementCode}, message.ErrorCategoryPermanent},
+		{"undeliverable", &WhatsAppAPIError{StatusCode: 400, Code: 131026}, message.ErrorCategoryInvalidRecipient},
+		{"token", &WhatsAppAPIError{StatusCode: 401, Code: 190}, message.ErrorCategoryAuthFailure},
+		{"permission", &WhatsAppAPIError{StatusCode: 403, Code: 10}, message.ErrorCategoryAuthFailure},
+		{"throughput", &WhatsAppAPIError{StatusCode: 400, Code: 130429}, message.ErrorCategoryRateLimited},
+		{"spam limit", &WhatsAppAPIError{StatusCode: 400, Code: 131048}, message.ErrorCategoryRateLimited},
+		{"service", &WhatsAppAPIError{StatusCode: 500, Code: 131000}, message.ErrorCategoryTemporary},
+		{"unknown code uses the status", &WhatsAppAPIError{StatusCode: 429, Code: 999999}, message.ErrorCategoryRateLimited},
+		{"unknown code and status", &WhatsAppAPIError{StatusCode: 400, Code: 100}, message.ErrorCategoryPermanent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyWhatsAppError(tt.err))
+		})
+	}
+}
+
+func TestClassifyMQTTRefusal(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want message.ErrorCategory
+		ok   bool
+	}{
+		{"bad credentials", packets.ErrorRefusedBadUsernameOrPassword, message.ErrorCategoryAuthFailure, true},
+		{"not authorised", packets.ErrorRefusedNotAuthorised, message.ErrorCategoryAuthFailure, true},
+		{"protocol version", packets.ErrorRefusedBadProtocolVersion, message.ErrorCategoryPermanent, true},
+		{"client ID", fmt.Errorf("connect: %w", packets.ErrorRefusedIDRejected), message.ErrorCategoryPermanent, true},
+		{"server unavailable", packets.ErrorRefusedServerUnavailable, message.ErrorCategoryTemporary, true},
+		{"other error", errors.New("network unreachable"), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, ok := classifyMQTTRefusal(tt.err)
+			assert.Equal(t, tt.want, category)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestClassifySMTPCode(t *testing.T) {
+	tests := []struct {
+		code int
+		want message.ErrorCategory
+	}{
+		{530, message.ErrorCategoryAuthFailure},
+		{534, message.ErrorCategoryAuthFailure},
+		{535, message.ErrorCategoryAuthFailure},
+		{550, message.ErrorCategoryInvalidRecipient},
+		{551, message.ErrorCategoryInvalidRecipient},
+		{553, message.ErrorCategoryInvalidRecipient},
+		{421, message.ErrorCategoryTemporary},
+		{450, message.ErrorCategoryTemporary},
+		{451, message.ErrorCategoryTemporary},
+		{452, message.ErrorCategoryTemporary},
+		{552, message.ErrorCategoryPermanent},
+		{554, message.ErrorCategoryPermanent},
+		{454, message.ErrorCategoryTemporary},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.code), func(t *testing.T) {
+			assert.Equal(t, tt.want, classifySMTPCode(tt.code))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// DefaultFailureDigestInterval is how often the failure digests are sent
+// when no interval is configured
+const DefaultFailureDigestInterval = time.Hour
+
+// FailureDigestJob sends the failure digest of the last period every interval
+// in the background. Each period starts where the previous one ended, so that
+// no failure is reported twice by the same process.
+type FailureDigestJob struct {
+	digest   *services.FailureDigest
+	interval time.Duration
+	logger   *logger.Logger
+	now      func() time.Time
+	readOnly *shared.ReadOnlyMode
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewFailureDigestJob creates a job sending a digest every interval; zero
+// uses DefaultFailureDigestInterval
+func NewFailureDigestJob(digest *services.FailureDigest, interval time.Duration, log *logger.Logger) *FailureDigestJob {
+	if interval <= 0 {
+		interval = DefaultFailureDigestInterval
+	}
+	return &FailureDigestJob{
+		digest:   digest,
+		interval: interval,
+		logger:   log.WithComponent("failure_digest"),
+		now:      time.Now,
+	}
+}
+
+// SetReadOnly skips the digests while the instance is read-only, leaving
+// them to the primary. The period restarts once the instance leaves it.
+func (j *FailureDigestJob) SetReadOnly(mode *shared.ReadOnlyMode) {
+	j.readOnly = mode
+}
+
+// Start sends the first digest after one interval and then every interval
+func (j *FailureDigestJob) Start() {
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		from := j.now()
+		for {
+			select {
+			case <-ticker.C:
+			case <-j.stop:
+				return
+			}
+			if j.readOnly.Enabled() {
+				from = j.now()
+				continue
+			}
+			from = j.Run(context.Background(), from)
+		}
+	}()
+
+	j.logger.Info("Failure digest started", zap.Duration("interval", j.interval))
+}
+
+// Stop ends the digests, waiting for the running one up to the context deadline
+func (j *FailureDigestJob) Stop(ctx context.Context) error {
+	if j.stop == nil {
+		return nil
+	}
+	close(j.stop)
+
+	select {
+	case <-j.done:
+		j.logger.Info("Failure digest stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failure digest stopped while sending: %w", ctx.Err())
+	}
+}
+
+// Run sends the digest of the period from the given time to now and returns
+// where the next period starts. A period whose digest could not be sent is
+// carried over to the next one.
+func (j *FailureDigestJob) Run(ctx context.Context, from time.Time) time.Time {
+	to := j.now()
+	if _, err := j.digest.Send(ctx, from, to); err != nil {
+		j.logger.Error("Failed to send failure digest",
+			zap.Time("from", from),
+			zap.Time("to", to),
+			zap.Error(err))
+		return from
+	}
+	return to
+}
@@ -0,0 +1,169 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/inapp"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// InAppService implements MessageSender for in-app channels. It stores a
+// notification in the notification center of every recipient, whose target
+// is the ID of the user, and tells the connected clients of the user about it.
+type InAppService struct {
+	notifications inapp.NotificationRepository
+	notifier      inapp.Notifier
+}
+
+// NewInAppService creates a new in-app service
+func NewInAppService() *InAppService {
+	return &InAppService{}
+}
+
+// SetNotifications sets where the notifications are stored and who is told
+// about them. Without it in-app messages fail; without a notifier the clients
+// only see the notifications when they list them.
+func (s *InAppService) SetNotifications(notifications inapp.NotificationRepository, notifier inapp.Notifier) {
+	s.notifications = notifications
+	s.notifier = notifier
+}
+
+// InAppConfig holds in-app configuration
+type InAppConfig struct {
+	Category string
+}
+
+// Send stores the notifications of a message
+func (s *InAppService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to store in-app notification for %s: %w", result.Target, result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients stores a notification for every user of the channel. The
+// ID of the notification is the provider message ID.
+func (s *InAppService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeInApp) {
+		return nil, fmt.Errorf("invalid channel type for in-app service: %s", ch.ChannelType().String())
+	}
+	if s.notifications == nil {
+		return nil, errors.New("in-app notifications are not stored by this instance")
+	}
+
+	// Extract in-app configuration
+	config, err := s.extractInAppConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract in-app config: %w", err)
+	}
+
+	userIDs := s.prepareUserIDs(ch.Recipients())
+	if len(userIDs) == 0 {
+		return nil, errors.New("no user IDs found in the recipients")
+	}
+
+	results := make([]*RecipientResult, 0, len(userIDs))
+	for _, userID := range userIDs {
+		result := &RecipientResult{Target: userID}
+		notification, err := s.store(ctx, ch, config, userID, content)
+		if err != nil {
+			result.Error = err
+		} else {
+			result.Success = true
+			result.ProviderMessageID = notification.ID
+			result.SentAt = notification.CreatedAt.UnixMilli()
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GetChannelType returns the supported channel type
+func (s *InAppService) GetChannelType() string {
+	return shared.ChannelTypeInApp.String()
+}
+
+// ValidateConfig validates in-app channel configuration
+func (s *InAppService) ValidateConfig(config *channel.ChannelConfig) error {
+	_, err := s.extractInAppConfig(config)
+	return err
+}
+
+// extractInAppConfig extracts in-app configuration from channel config
+func (s *InAppService) extractInAppConfig(config *channel.ChannelConfig) (*InAppConfig, error) {
+	inAppConfig := &InAppConfig{}
+	if value, ok := config.Get("category"); ok && value != nil {
+		category, ok := value.(string)
+		if !ok {
+			return nil, errors.New("category must be a string")
+		}
+		inAppConfig.Category = strings.TrimSpace(category)
+	}
+
+	return inAppConfig, nil
+}
+
+// prepareUserIDs collects the distinct user IDs of the recipients
+func (s *InAppService) prepareUserIDs(recipients *channel.Recipients) []string {
+	userIDs := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, recipient := range recipients.ToSlice() {
+		userID := strings.TrimSpace(recipient.Target)
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs
+}
+
+// store stores the notification of one user and tells the clients of the
+// user about it
+func (s *InAppService) store(ctx context.Context, ch *channel.Channel, config *InAppConfig, userID string, content *services.RenderedContent) (*inapp.Notification, error) {
+	notification, err := inapp.NewNotification(userID, ch.ID().String(), content.Subject, content.Content)
+	if err != nil {
+		return nil, err
+	}
+	notification.Category = config.Category
+	notification.CorrelationID = logger.CorrelationIDFromContext(ctx)
+
+	if err := s.notifications.Save(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	// The notification is stored, a client that misses the push sees it
+	// when it lists the notifications
+	if s.notifier != nil {
+		unreadCount, err := s.notifications.CountUnread(ctx, userID)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Failed to count unread in-app notifications",
+				zap.String("user_id", userID),
+				zap.Error(err))
+			return notification, nil
+		}
+		s.notifier.NotificationCreated(ctx, notification, unreadCount)
+	}
+
+	return notification, nil
+}
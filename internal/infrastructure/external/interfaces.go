@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
 	"notification/internal/domain/services"
 )
 
@@ -19,6 +20,34 @@ type MessageSender interface {
 	ValidateConfig(config *channel.ChannelConfig) error
 }
 
+// RecipientMessageSender is implemented by senders that deliver to each
+// recipient on its own. Rather than stopping at the first failure, it tries
+// every recipient and reports the outcome of each. The error is only returned
+// when no recipient could be tried, for instance for an invalid configuration.
+type RecipientMessageSender interface {
+	MessageSender
+
+	// SendToRecipients sends a message to every recipient of the channel
+	SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error)
+}
+
+// RecipientResult represents the outcome of a send to one recipient
+type RecipientResult struct {
+	Target            string
+	Success           bool
+	ProviderMessageID string
+	Error             error
+	ErrorCategory     message.ErrorCategory
+	SentAt            int64
+	// Acknowledgment holds the keys the recipient pressed to acknowledge a
+	// voice call, AcknowledgedAt is 0 when the call was not acknowledged
+	Acknowledgment string
+	AcknowledgedAt int64
+	// Details describe what the send created at the provider, e.g. the key
+	// of a Jira issue
+	Details map[string]string
+}
+
 // MessageSenderFactory creates message senders for different channel types
 type MessageSenderFactory interface {
 	// CreateSender creates a message sender for the given channel type
@@ -30,9 +59,10 @@ type MessageSenderFactory interface {
 
 // SendRequest represents a message sending request
 type SendRequest struct {
-	Channel   *channel.Channel
-	Content   *services.RenderedContent
-	Variables map[string]interface{}
+	Channel       *channel.Channel
+	Content       *services.RenderedContent
+	Variables     map[string]interface{}
+	CorrelationID string
 }
 
 // SendResult represents the result of a message sending operation
@@ -42,6 +72,10 @@ type SendResult struct {
 	Error     error
 	Details   map[string]interface{}
 	SentAt    int64
+	// ErrorCategory classifies the failure, it decides whether the send is retried
+	ErrorCategory message.ErrorCategory
+	// Recipients holds the outcome per recipient
+	Recipients []*RecipientResult
 }
 
 // NotificationService provides a high-level interface for sending notifications
@@ -0,0 +1,327 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// jiraIssuePath is the endpoint of the REST API creating issues. Version 2
+// takes plain text descriptions and is served by Jira Cloud and Data Center.
+const jiraIssuePath = "/rest/api/2/issue"
+
+// defaultJiraIssueType is the issue type of channels setting none
+const defaultJiraIssueType = "Task"
+
+// maxJiraSummaryLength is the longest summary of a Jira issue
+const maxJiraSummaryLength = 255
+
+// JiraService implements MessageSender for Jira channels. Every message
+// creates one issue in the project of the channel, summarized by the subject
+// and described by the content of the message.
+type JiraService struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewJiraService creates a new Jira service
+func NewJiraService(timeout time.Duration) *JiraService {
+	return &JiraService{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		timeout: timeout,
+	}
+}
+
+// JiraConfig holds Jira configuration
+type JiraConfig struct {
+	BaseURL     string
+	Email       string
+	APIToken    string
+	AccessToken string
+	ProjectKey  string
+	IssueType   string
+	Labels      []string
+	Priority    string
+}
+
+// JiraIssueRequest represents a request creating an issue
+type JiraIssueRequest struct {
+	Fields JiraIssueFields `json:"fields"`
+}
+
+// JiraIssueFields represents the fields of a created issue
+type JiraIssueFields struct {
+	Project     JiraKeyField   `json:"project"`
+	IssueType   JiraNameField  `json:"issuetype"`
+	Summary     string         `json:"summary"`
+	Description string         `json:"description,omitempty"`
+	Labels      []string       `json:"labels,omitempty"`
+	Priority    *JiraNameField `json:"priority,omitempty"`
+}
+
+// JiraKeyField references a Jira entity by key
+type JiraKeyField struct {
+	Key string `json:"key"`
+}
+
+// JiraNameField references a Jira entity by name
+type JiraNameField struct {
+	Name string `json:"name"`
+}
+
+// JiraIssueResponse represents the response of the REST API to a created issue
+type JiraIssueResponse struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+// JiraErrorResponse represents the errors of a refused request
+type JiraErrorResponse struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// Send sends an issue to Jira
+func (s *JiraService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to create Jira issue: %w", result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients creates the issue of the message and reports its outcome.
+// The key of the issue is the provider message ID, and the details of the
+// result hold the key, ID and URL of the issue.
+func (s *JiraService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeJira) {
+		return nil, fmt.Errorf("invalid channel type for Jira service: %s", ch.ChannelType().String())
+	}
+
+	// Extract Jira configuration
+	config, err := s.extractJiraConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Jira config: %w", err)
+	}
+
+	result := &RecipientResult{Target: config.ProjectKey}
+	summary := jiraSummary(content)
+	if summary == "" {
+		// Sending again cannot give the message a subject
+		result.Error = errors.New("message has no subject to summarize the Jira issue")
+		result.ErrorCategory = message.ErrorCategoryPermanent
+		return []*RecipientResult{result}, nil
+	}
+
+	issue, err := s.createIssue(ctx, config, summary, content.Content)
+	if err != nil {
+		result.Error = err
+		return []*RecipientResult{result}, nil
+	}
+
+	result.Success = true
+	result.ProviderMessageID = issue.Key
+	result.SentAt = time.Now().UnixMilli()
+	result.Details = map[string]string{
+		"issue_key": issue.Key,
+		"issue_id":  issue.ID,
+		"issue_url": config.BaseURL + "/browse/" + issue.Key,
+	}
+	return []*RecipientResult{result}, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the Jira site
+// in the idle pool of the client.
+func (s *JiraService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeJira) {
+		return nil
+	}
+
+	config, err := s.extractJiraConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract Jira config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, config.BaseURL)
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *JiraService) CloseIdle(before time.Time) int {
+	return 0
+}
+
+// GetChannelType returns the supported channel type
+func (s *JiraService) GetChannelType() string {
+	return shared.ChannelTypeJira.String()
+}
+
+// ValidateConfig validates Jira channel configuration
+func (s *JiraService) ValidateConfig(config *channel.ChannelConfig) error {
+	_, err := s.extractJiraConfig(config)
+	return err
+}
+
+// extractJiraConfig extracts Jira configuration from channel config
+func (s *JiraService) extractJiraConfig(config *channel.ChannelConfig) (*JiraConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+
+	jiraConfig := &JiraConfig{
+		BaseURL:     strings.TrimSuffix(str("base_url"), "/"),
+		Email:       str("email"),
+		APIToken:    str("api_token"),
+		AccessToken: str("access_token"),
+		ProjectKey:  str("project_key"),
+		IssueType:   str("issue_type"),
+		Priority:    str("priority"),
+	}
+	if jiraConfig.BaseURL == "" {
+		return nil, errors.New("missing required field: base_url")
+	}
+	if jiraConfig.ProjectKey == "" {
+		return nil, errors.New("missing required field: project_key")
+	}
+	if jiraConfig.AccessToken == "" && (jiraConfig.Email == "" || jiraConfig.APIToken == "") {
+		return nil, errors.New("missing required fields: email and api_token, or access_token")
+	}
+	if jiraConfig.IssueType == "" {
+		jiraConfig.IssueType = defaultJiraIssueType
+	}
+
+	if value, ok := config.Get("labels"); ok && value != nil {
+		labels, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.New("labels must be a list")
+		}
+		for _, label := range labels {
+			text := strings.TrimSpace(fmt.Sprintf("%v", label))
+			if text == "" || strings.ContainsAny(text, " \t\n") {
+				return nil, fmt.Errorf("invalid label %q, labels cannot contain spaces", text)
+			}
+			jiraConfig.Labels = append(jiraConfig.Labels, text)
+		}
+	}
+
+	return jiraConfig, nil
+}
+
+// createIssue creates an issue in the project of the channel
+func (s *JiraService) createIssue(ctx context.Context, config *JiraConfig, summary, description string) (*JiraIssueResponse, error) {
+	request := JiraIssueRequest{
+		Fields: JiraIssueFields{
+			Project:     JiraKeyField{Key: config.ProjectKey},
+			IssueType:   JiraNameField{Name: config.IssueType},
+			Summary:     summary,
+			Description: description,
+			Labels:      config.Labels,
+		},
+	}
+	if config.Priority != "" {
+		request.Fields.Priority = &JiraNameField{Name: config.Priority}
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Jira issue: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.BaseURL+jiraIssuePath, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if config.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+config.AccessToken)
+	} else {
+		req.SetBasicAuth(config.Email, config.APIToken)
+	}
+	setCorrelationHeader(ctx, req.Header)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Jira request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		statusErr := &StatusError{Provider: "Jira", StatusCode: resp.StatusCode}
+		var jiraErr JiraErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&jiraErr); err == nil {
+			if reasons := jiraErr.reasons(); reasons != "" {
+				return nil, fmt.Errorf("%s: %w", reasons, statusErr)
+			}
+		}
+		return nil, statusErr
+	}
+
+	var issue JiraIssueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode Jira response: %w", err)
+	}
+	if issue.Key == "" {
+		return nil, errors.New("response of Jira has no issue key")
+	}
+	return &issue, nil
+}
+
+// reasons joins the errors of a refused request, the field errors sorted by field
+func (r *JiraErrorResponse) reasons() string {
+	reasons := append([]string(nil), r.ErrorMessages...)
+	fields := make([]string, 0, len(r.Errors))
+	for field := range r.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		reasons = append(reasons, field+": "+r.Errors[field])
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// jiraSummary returns the summary of the issue of a message: its subject, or
+// the first line of its content when it has none, on one line and truncated
+// to the longest summary Jira accepts
+func jiraSummary(content *services.RenderedContent) string {
+	summary := strings.TrimSpace(content.Subject)
+	if summary == "" {
+		summary, _, _ = strings.Cut(strings.TrimSpace(content.Content), "\n")
+	}
+	summary = strings.Join(strings.Fields(summary), " ")
+	if runes := []rune(summary); len(runes) > maxJiraSummaryLength {
+		summary = string(runes[:maxJiraSummaryLength])
+	}
+	return summary
+}
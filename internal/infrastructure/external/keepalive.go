@@ -0,0 +1,199 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// DefaultKeepAliveInterval is how often the provider connections are warmed
+// up when no interval is configured. It stays below the idle timeouts of the
+// SMTP servers and of the HTTP client.
+const DefaultKeepAliveInterval = time.Minute
+
+// ProviderWarmer is implemented by the message senders whose provider
+// connections can be kept open between sends, so that the first send after a
+// quiet period does not wait for a new connection
+type ProviderWarmer interface {
+	// WarmUp opens the provider connection of a channel, or checks the open
+	// one and reconnects when the provider dropped it
+	WarmUp(ctx context.Context, ch *channel.Channel) error
+	// CloseIdle closes the connections neither used nor warmed up since
+	// before and returns their number
+	CloseIdle(before time.Time) int
+}
+
+// ProviderKeepAlive warms up the provider connections of the enabled
+// channels in the background
+type ProviderKeepAlive struct {
+	channelRepo channel.ChannelRepository
+	factory     MessageSenderFactory
+	interval    time.Duration
+	logger      *logger.Logger
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewProviderKeepAlive creates a keepalive warming up the connections of the
+// senders of the factory every interval; zero uses DefaultKeepAliveInterval
+func NewProviderKeepAlive(
+	channelRepo channel.ChannelRepository,
+	factory MessageSenderFactory,
+	interval time.Duration,
+	log *logger.Logger,
+) *ProviderKeepAlive {
+	if interval <= 0 {
+		interval = DefaultKeepAliveInterval
+	}
+	return &ProviderKeepAlive{
+		channelRepo: channelRepo,
+		factory:     factory,
+		interval:    interval,
+		logger:      log.WithComponent("provider_keepalive"),
+	}
+}
+
+// Start warms up the connections at once and then every interval
+func (k *ProviderKeepAlive) Start() {
+	k.stop = make(chan struct{})
+	k.done = make(chan struct{})
+
+	go func() {
+		defer close(k.done)
+		ticker := time.NewTicker(k.interval)
+		defer ticker.Stop()
+
+		for {
+			k.WarmUp(context.Background())
+			select {
+			case <-ticker.C:
+			case <-k.stop:
+				return
+			}
+		}
+	}()
+
+	k.logger.Info("Provider keepalive started", zap.Duration("interval", k.interval))
+}
+
+// Stop ends the warm ups, waiting for the running one up to the context deadline
+func (k *ProviderKeepAlive) Stop(ctx context.Context) error {
+	if k.stop == nil {
+		return nil
+	}
+	close(k.stop)
+
+	select {
+	case <-k.done:
+		k.logger.Info("Provider keepalive stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("provider keepalive stopped while warming up: %w", ctx.Err())
+	}
+}
+
+// WarmUp warms up the provider connections of every enabled channel once,
+// then closes the connections of the channels that are gone. It returns the
+// number of channels warmed up.
+func (k *ProviderKeepAlive) WarmUp(ctx context.Context) int {
+	started := time.Now()
+	warmers := k.warmers()
+	if len(warmers) == 0 {
+		return 0
+	}
+
+	filter := channel.NewChannelFilter().WithEnabled(true)
+	warmed := 0
+	for skipCount := 0; ; {
+		pagination, err := shared.NewPagination(skipCount, 100)
+		if err != nil {
+			k.logger.Error("Failed to page channels", zap.Error(err))
+			return warmed
+		}
+		page, err := k.channelRepo.FindAll(ctx, filter, pagination)
+		if err != nil {
+			k.logger.Warn("Failed to list channels to warm up", zap.Error(err))
+			return warmed
+		}
+
+		for _, ch := range page.Items {
+			warmer, ok := warmers[ch.ChannelType().String()]
+			if !ok {
+				continue
+			}
+			if err := warmer.WarmUp(ctx, ch); err != nil {
+				k.logger.Warn("Failed to warm up provider connection",
+					zap.String("channel_id", ch.ID().String()),
+					zap.String("channel_type", ch.ChannelType().String()),
+					zap.Error(err))
+				continue
+			}
+			warmed++
+		}
+
+		if !page.HasMore {
+			break
+		}
+		skipCount += len(page.Items)
+	}
+
+	for channelType, warmer := range warmers {
+		if closed := warmer.CloseIdle(started); closed > 0 {
+			k.logger.Debug("Closed idle provider connections",
+				zap.String("channel_type", channelType),
+				zap.Int("count", closed))
+		}
+	}
+	return warmed
+}
+
+// warmers returns the senders of the factory that keep connections, by channel type
+func (k *ProviderKeepAlive) warmers() map[string]ProviderWarmer {
+	warmers := make(map[string]ProviderWarmer)
+	for _, channelType := range k.factory.GetSupportedTypes() {
+		sender, err := k.factory.CreateSender(channelType)
+		if err != nil {
+			continue
+		}
+		if warmer, ok := sender.(ProviderWarmer); ok {
+			warmers[channelType] = warmer
+		}
+	}
+	return warmers
+}
+
+// warmHTTP sends a HEAD request to a provider, which leaves the connection
+// in the idle pool of the client whatever the status. A request failing on a
+// connection the provider dropped is sent again on a new one.
+func warmHTTP(ctx context.Context, client *http.Client, url string) error {
+	err := headRequest(ctx, client, url)
+	if err != nil {
+		client.CloseIdleConnections()
+		err = headRequest(ctx, client, url)
+	}
+	return err
+}
+
+// headRequest sends a HEAD request and drains the response
+func headRequest(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach provider: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
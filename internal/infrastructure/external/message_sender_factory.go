@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
 	"notification/internal/domain/services"
+	"notification/pkg/logger"
 )
 
 // DefaultMessageSenderFactory implements MessageSenderFactory
@@ -26,6 +28,15 @@ func NewDefaultMessageSenderFactory(timeout time.Duration) *DefaultMessageSender
 	factory.RegisterSender(NewEmailService(timeout))
 	factory.RegisterSender(NewSlackService(timeout))
 	factory.RegisterSender(NewSMSService(timeout))
+	factory.RegisterSender(NewVoiceService(timeout))
+	factory.RegisterSender(NewWhatsAppService(timeout))
+	factory.RegisterSender(NewDiscordService(timeout))
+	factory.RegisterSender(NewPagerDutyService(timeout))
+	factory.RegisterSender(NewOpsgenieService(timeout))
+	factory.RegisterSender(NewJiraService(timeout))
+	factory.RegisterSender(NewMQTTService(timeout))
+	factory.RegisterSender(NewStatuspageService(timeout))
+	factory.RegisterSender(NewInAppService())
 
 	return factory
 }
@@ -41,6 +52,15 @@ func NewExtensibleMessageSenderFactory(timeout time.Duration, registry interface
 	factory.RegisterSender(NewEmailService(timeout))
 	factory.RegisterSender(NewSlackService(timeout))
 	factory.RegisterSender(NewSMSService(timeout))
+	factory.RegisterSender(NewVoiceService(timeout))
+	factory.RegisterSender(NewWhatsAppService(timeout))
+	factory.RegisterSender(NewDiscordService(timeout))
+	factory.RegisterSender(NewPagerDutyService(timeout))
+	factory.RegisterSender(NewOpsgenieService(timeout))
+	factory.RegisterSender(NewJiraService(timeout))
+	factory.RegisterSender(NewMQTTService(timeout))
+	factory.RegisterSender(NewStatuspageService(timeout))
+	factory.RegisterSender(NewInAppService())
 
 	return factory
 }
@@ -103,8 +123,24 @@ func (s *DefaultNotificationService) SendNotification(ctx context.Context, reque
 	return results, nil
 }
 
-// SendSingleNotification sends a notification through a single channel
+// SendSingleNotification sends a notification through a single channel. The
+// correlation ID of the request is handed to the sender through ctx and
+// reported in the result details.
 func (s *DefaultNotificationService) SendSingleNotification(ctx context.Context, request *SendRequest) *SendResult {
+	if request == nil || request.CorrelationID == "" {
+		return s.sendSingle(ctx, request)
+	}
+
+	result := s.sendSingle(logger.ContextWithCorrelationID(ctx, request.CorrelationID), request)
+	if result.Details == nil {
+		result.Details = map[string]interface{}{}
+	}
+	result.Details["correlation_id"] = request.CorrelationID
+	return result
+}
+
+// sendSingle validates a request and sends it with the sender of its channel type
+func (s *DefaultNotificationService) sendSingle(ctx context.Context, request *SendRequest) *SendResult {
 	startTime := time.Now()
 
 	// Validate request
@@ -113,6 +149,8 @@ func (s *DefaultNotificationService) SendSingleNotification(ctx context.Context,
 			Success: false,
 			Message: "Request validation failed",
 			Error:   err,
+			// Sending again cannot fix the request or the channel
+			ErrorCategory: message.ErrorCategoryPermanent,
 			Details: map[string]interface{}{
 				"channel_id":   request.Channel.ID().String(),
 				"channel_type": request.Channel.ChannelType().String(),
@@ -127,6 +165,8 @@ func (s *DefaultNotificationService) SendSingleNotification(ctx context.Context,
 			Success: false,
 			Message: "Failed to create message sender",
 			Error:   err,
+			// Sending again cannot fix the request or the channel
+			ErrorCategory: message.ErrorCategoryPermanent,
 			Details: map[string]interface{}{
 				"channel_id":   request.Channel.ID().String(),
 				"channel_type": request.Channel.ChannelType().String(),
@@ -140,6 +180,8 @@ func (s *DefaultNotificationService) SendSingleNotification(ctx context.Context,
 			Success: false,
 			Message: "Channel configuration validation failed",
 			Error:   err,
+			// Sending again cannot fix the request or the channel
+			ErrorCategory: message.ErrorCategoryPermanent,
 			Details: map[string]interface{}{
 				"channel_id":   request.Channel.ID().String(),
 				"channel_type": request.Channel.ChannelType().String(),
@@ -148,16 +190,49 @@ func (s *DefaultNotificationService) SendSingleNotification(ctx context.Context,
 	}
 
 	// Send message
-	if err := sender.Send(ctx, request.Channel, request.Content); err != nil {
+	recipients, err := s.send(ctx, sender, request)
+	if err != nil {
 		return &SendResult{
-			Success: false,
-			Message: "Failed to send message",
-			Error:   err,
+			Success:       false,
+			Message:       "Failed to send message",
+			Error:         err,
+			ErrorCategory: ClassifyError(err),
+			Details: map[string]interface{}{
+				"channel_id":   request.Channel.ID().String(),
+				"channel_type": request.Channel.ChannelType().String(),
+				"duration_ms":  time.Since(startTime).Milliseconds(),
+			},
+		}
+	}
+
+	// The channel only succeeds when every recipient got the message
+	failed := 0
+	var firstErr error
+	var category message.ErrorCategory
+	for _, recipient := range recipients {
+		if !recipient.Success {
+			failed++
+			if recipient.ErrorCategory == "" {
+				recipient.ErrorCategory = ClassifyError(recipient.Error)
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to send to %s: %w", recipient.Target, recipient.Error)
+				category = recipient.ErrorCategory
+			}
+		}
+	}
+	if failed > 0 {
+		return &SendResult{
+			Success:       false,
+			Message:       fmt.Sprintf("Failed to send to %d of %d recipients", failed, len(recipients)),
+			Error:         firstErr,
+			ErrorCategory: category,
 			Details: map[string]interface{}{
 				"channel_id":   request.Channel.ID().String(),
 				"channel_type": request.Channel.ChannelType().String(),
 				"duration_ms":  time.Since(startTime).Milliseconds(),
 			},
+			Recipients: recipients,
 		}
 	}
 
@@ -170,8 +245,33 @@ func (s *DefaultNotificationService) SendSingleNotification(ctx context.Context,
 			"channel_type": request.Channel.ChannelType().String(),
 			"duration_ms":  time.Since(startTime).Milliseconds(),
 		},
-		SentAt: time.Now().UnixMilli(),
+		SentAt:     time.Now().UnixMilli(),
+		Recipients: recipients,
+	}
+}
+
+// send sends a request and returns the outcome per recipient. Senders that
+// cannot report per recipient send to all recipients at once, so every
+// recipient shares the outcome of the send.
+func (s *DefaultNotificationService) send(ctx context.Context, sender MessageSender, request *SendRequest) ([]*RecipientResult, error) {
+	if recipientSender, ok := sender.(RecipientMessageSender); ok {
+		return recipientSender.SendToRecipients(ctx, request.Channel, request.Content)
+	}
+
+	if err := sender.Send(ctx, request.Channel, request.Content); err != nil {
+		return nil, err
+	}
+
+	sentAt := time.Now().UnixMilli()
+	recipients := make([]*RecipientResult, 0, request.Channel.Recipients().Count())
+	for _, recipient := range request.Channel.Recipients().ToSlice() {
+		recipients = append(recipients, &RecipientResult{
+			Target:  recipient.Target,
+			Success: true,
+			SentAt:  sentAt,
+		})
 	}
+	return recipients, nil
 }
 
 // ValidateChannel validates if a channel can be used for sending
@@ -0,0 +1,395 @@
+package external
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// defaultMQTTQoS is the quality of service of channels setting none, at least
+// once, so that a publication lost on the way to the broker is sent again
+const defaultMQTTQoS = 1
+
+// MQTTService implements MessageSender for MQTT channels. Every message is
+// published to the topics resolved from the topic template of the channel,
+// on a broker connection kept open between messages.
+type MQTTService struct {
+	timeout time.Duration
+	pool    *mqttPool
+}
+
+// NewMQTTService creates a new MQTT service
+func NewMQTTService(timeout time.Duration) *MQTTService {
+	return &MQTTService{
+		timeout: timeout,
+		pool:    newMQTTPool(),
+	}
+}
+
+// MQTTConfig holds MQTT configuration
+type MQTTConfig struct {
+	BrokerURL     string
+	ClientID      string
+	Username      string
+	Password      string
+	QoS           byte
+	Retain        bool
+	PayloadFormat string
+	// TLS is the configuration of the TLS brokers, nil for the others
+	TLS *tls.Config
+	// key identifies the connections the configuration may share
+	key string
+}
+
+// MQTTPayload represents the JSON payload of a publication
+type MQTTPayload struct {
+	Subject       string `json:"subject,omitempty"`
+	Content       string `json:"content"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	SentAt        int64  `json:"sent_at"`
+}
+
+// Send publishes a message to MQTT
+func (s *MQTTService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to publish to %s: %w", result.Target, result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients publishes the message to each of its topics and reports
+// the outcome per topic
+func (s *MQTTService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeMQTT) {
+		return nil, fmt.Errorf("invalid channel type for MQTT service: %s", ch.ChannelType().String())
+	}
+
+	// Extract MQTT configuration
+	config, err := s.extractMQTTConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract MQTT config: %w", err)
+	}
+
+	if len(content.MQTTPublications) == 0 {
+		return nil, errors.New("message has no MQTT topic")
+	}
+
+	payload, err := s.buildPayload(ctx, config, content)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	client, err := s.pool.client(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*RecipientResult, 0, len(content.MQTTPublications))
+	for _, publication := range content.MQTTPublications {
+		result := &RecipientResult{Target: publication.Target}
+		if err := waitMQTT(ctx, client.Publish(publication.Topic, config.QoS, config.Retain, payload)); err != nil {
+			result.Error = fmt.Errorf("failed to publish to %s: %w", publication.Topic, err)
+		} else {
+			result.Success = true
+			result.SentAt = time.Now().UnixMilli()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// WarmUp implements ProviderWarmer. It connects to the broker of an MQTT
+// channel, or reconnects when the broker dropped the connection.
+func (s *MQTTService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeMQTT) {
+		return nil
+	}
+
+	config, err := s.extractMQTTConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract MQTT config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err = s.pool.client(ctx, config)
+	return err
+}
+
+// CloseIdle implements ProviderWarmer
+func (s *MQTTService) CloseIdle(before time.Time) int {
+	return s.pool.closeIdle(before)
+}
+
+// GetChannelType returns the supported channel type
+func (s *MQTTService) GetChannelType() string {
+	return shared.ChannelTypeMQTT.String()
+}
+
+// ValidateConfig validates MQTT channel configuration
+func (s *MQTTService) ValidateConfig(config *channel.ChannelConfig) error {
+	_, err := s.extractMQTTConfig(config)
+	return err
+}
+
+// extractMQTTConfig extracts MQTT configuration from channel config
+func (s *MQTTService) extractMQTTConfig(config *channel.ChannelConfig) (*MQTTConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+
+	mqttConfig := &MQTTConfig{
+		BrokerURL:     str("broker_url"),
+		ClientID:      str("client_id"),
+		Username:      str("username"),
+		Password:      str("password"),
+		QoS:           defaultMQTTQoS,
+		Retain:        str("retain") == "true",
+		PayloadFormat: str("payload_format"),
+	}
+	if mqttConfig.BrokerURL == "" {
+		return nil, errors.New("missing required field: broker_url")
+	}
+	broker, err := url.Parse(mqttConfig.BrokerURL)
+	if err != nil || broker.Host == "" {
+		return nil, fmt.Errorf("invalid broker_url: %s", mqttConfig.BrokerURL)
+	}
+
+	if qos := str("qos"); qos != "" {
+		value, err := strconv.Atoi(qos)
+		if err != nil || value < 0 || value > 2 {
+			return nil, fmt.Errorf("invalid qos: %s", qos)
+		}
+		mqttConfig.QoS = byte(value)
+	}
+
+	switch mqttConfig.PayloadFormat {
+	case "":
+		mqttConfig.PayloadFormat = "json"
+	case "json", "text":
+	default:
+		return nil, fmt.Errorf("unsupported payload_format: %s", mqttConfig.PayloadFormat)
+	}
+
+	// TLS brokers, and brokers authenticating the client by certificate
+	caCert, clientCert, clientKey := str("ca_cert"), str("client_cert"), str("client_key")
+	insecure := str("insecure_skip_verify") == "true"
+	switch broker.Scheme {
+	case "ssl", "tls", "mqtts", "wss":
+		mqttConfig.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	case "tcp", "mqtt", "ws":
+		if caCert != "" || clientCert != "" {
+			return nil, fmt.Errorf("broker_url scheme %s does not use TLS, use ssl, tls, mqtts or wss with certificates", broker.Scheme)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported broker_url scheme: %s", broker.Scheme)
+	}
+	if mqttConfig.TLS != nil {
+		mqttConfig.TLS.InsecureSkipVerify = insecure
+		if caCert != "" {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(caCert)) {
+				return nil, errors.New("ca_cert holds no PEM certificate")
+			}
+			mqttConfig.TLS.RootCAs = pool
+		}
+		if (clientCert == "") != (clientKey == "") {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+		if clientCert != "" {
+			certificate, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+			if err != nil {
+				return nil, fmt.Errorf("invalid client certificate: %w", err)
+			}
+			mqttConfig.TLS.Certificates = []tls.Certificate{certificate}
+		}
+	}
+
+	// Connections are only shared by channels with the same broker account
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		mqttConfig.BrokerURL, mqttConfig.ClientID, mqttConfig.Username, mqttConfig.Password,
+		caCert, clientCert, clientKey, strconv.FormatBool(insecure),
+	}, "\x00")))
+	mqttConfig.key = hex.EncodeToString(sum[:])
+
+	return mqttConfig, nil
+}
+
+// buildPayload builds the payload of the publications of a message
+func (s *MQTTService) buildPayload(ctx context.Context, config *MQTTConfig, content *services.RenderedContent) ([]byte, error) {
+	if config.PayloadFormat == "text" {
+		return []byte(content.Content), nil
+	}
+
+	payload, err := json.Marshal(MQTTPayload{
+		Subject:       content.Subject,
+		Content:       content.Content,
+		CorrelationID: logger.CorrelationIDFromContext(ctx),
+		SentAt:        time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal MQTT payload: %w", err)
+	}
+	return payload, nil
+}
+
+// mqttConn is a broker connection shared by the channels of a broker account
+type mqttConn struct {
+	client   mqtt.Client
+	lastUsed time.Time
+}
+
+// mqttPool keeps one broker connection per broker account, so that a
+// message does not pay for the connection, the TLS handshake and the
+// authentication of the one before it. Paho clients publish concurrently,
+// so a connection is used by every send at once.
+type mqttPool struct {
+	mu    sync.Mutex
+	conns map[string]*mqttConn
+	now   func() time.Time
+}
+
+// newMQTTPool creates an empty MQTT connection pool
+func newMQTTPool() *mqttPool {
+	return &mqttPool{
+		conns: make(map[string]*mqttConn),
+		now:   time.Now,
+	}
+}
+
+// client returns the open connection of a broker account, connecting when
+// there is none or the broker dropped it
+func (p *mqttPool) client(ctx context.Context, config *MQTTConfig) (mqtt.Client, error) {
+	p.mu.Lock()
+	c := p.conns[config.key]
+	if c != nil && c.client.IsConnectionOpen() {
+		c.lastUsed = p.now()
+		p.mu.Unlock()
+		return c.client, nil
+	}
+	delete(p.conns, config.key)
+	p.mu.Unlock()
+	if c != nil {
+		c.client.Disconnect(0)
+	}
+
+	client, err := dialMQTT(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Another send may have connected in the meantime
+	if other := p.conns[config.key]; other != nil && other.client.IsConnectionOpen() {
+		client.Disconnect(0)
+		other.lastUsed = p.now()
+		return other.client, nil
+	}
+	p.conns[config.key] = &mqttConn{client: client, lastUsed: p.now()}
+	return client, nil
+}
+
+// closeIdle disconnects the connections last used before the given time and
+// returns their number
+func (p *mqttPool) closeIdle(before time.Time) int {
+	p.mu.Lock()
+	idle := make([]*mqttConn, 0)
+	for key, c := range p.conns {
+		if c.lastUsed.Before(before) {
+			delete(p.conns, key)
+			idle = append(idle, c)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		// Give the publications in flight a moment to be acknowledged
+		c.client.Disconnect(250)
+	}
+	return len(idle)
+}
+
+// dialMQTT connects to the broker of a configuration
+func dialMQTT(ctx context.Context, config *MQTTConfig) (mqtt.Client, error) {
+	clientID := config.ClientID
+	if clientID == "" {
+		suffix := make([]byte, 6)
+		_, _ = rand.Read(suffix)
+		clientID = "notification-" + hex.EncodeToString(suffix)
+	}
+
+	connectTimeout := 30 * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		connectTimeout = time.Until(deadline)
+	}
+
+	options := mqtt.NewClientOptions().
+		AddBroker(config.BrokerURL).
+		SetClientID(clientID).
+		// MQTT 3.1.1, without falling back to 3.1 when the broker refuses the connection
+		SetProtocolVersion(4).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetCleanSession(true).
+		SetConnectTimeout(connectTimeout).
+		SetConnectRetry(false).
+		// The pool reconnects on the next send, retrying in the background
+		// would keep connections to brokers no channel uses anymore
+		SetAutoReconnect(false)
+	if config.TLS != nil {
+		options.SetTLSConfig(config.TLS)
+	}
+
+	client := mqtt.NewClient(options)
+	if err := waitMQTT(ctx, client.Connect()); err != nil {
+		return nil, fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	return client, nil
+}
+
+// waitMQTT waits for a connection or a publication to complete, at most
+// until the context is done
+func waitMQTT(ctx context.Context, token mqtt.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -23,21 +23,39 @@ func NewNotificationServiceAdapter(notificationService NotificationService) *Not
 func (a *NotificationServiceAdapter) SendSingleNotification(ctx context.Context, request *services.SendRequest) *services.SendResult {
 	// Convert services.SendRequest to external.SendRequest
 	externalRequest := &SendRequest{
-		Channel:   request.Channel,
-		Content:   request.Content,
-		Variables: request.Variables,
+		Channel:       request.Channel,
+		Content:       request.Content,
+		Variables:     request.Variables,
+		CorrelationID: request.CorrelationID,
 	}
 
 	// Call the external service
 	externalResult := a.notificationService.SendSingleNotification(ctx, externalRequest)
 
 	// Convert external.SendResult to services.SendResult
+	recipients := make([]*services.RecipientSendResult, 0, len(externalResult.Recipients))
+	for _, recipient := range externalResult.Recipients {
+		recipients = append(recipients, &services.RecipientSendResult{
+			Target:            recipient.Target,
+			Success:           recipient.Success,
+			ProviderMessageID: recipient.ProviderMessageID,
+			Error:             recipient.Error,
+			ErrorCategory:     recipient.ErrorCategory,
+			SentAt:            recipient.SentAt,
+			Acknowledgment:    recipient.Acknowledgment,
+			AcknowledgedAt:    recipient.AcknowledgedAt,
+			Details:           recipient.Details,
+		})
+	}
+
 	return &services.SendResult{
-		Success: externalResult.Success,
-		Message: externalResult.Message,
-		Error:   externalResult.Error,
-		Details: externalResult.Details,
-		SentAt:  externalResult.SentAt,
+		Success:       externalResult.Success,
+		Message:       externalResult.Message,
+		Error:         externalResult.Error,
+		Details:       externalResult.Details,
+		SentAt:        externalResult.SentAt,
+		Recipients:    recipients,
+		ErrorCategory: externalResult.ErrorCategory,
 	}
 }
 
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
+
+	"notification/internal/domain/shared"
 )
 
 // OldSystemClient defines the interface for interacting with the old system's API.
@@ -84,6 +87,10 @@ func (c *oldSystemClient) CreateGroup(req OldSystemCreateGroupRequest) (*OldSyst
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, shared.NewLegacyStatusError(resp.StatusCode, string(body))
+	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		return nil, fmt.Errorf("old system API returned non-success status: %d %s", resp.StatusCode, resp.Status)
 	}
@@ -0,0 +1,289 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// Endpoints of the Opsgenie Alert API of each region
+const (
+	opsgenieURL   = "https://api.opsgenie.com"
+	opsgenieEUURL = "https://api.eu.opsgenie.com"
+)
+
+// defaultOpsgenieSource is the source of the alerts of channels setting none
+const defaultOpsgenieSource = "notification"
+
+// opsgenieTarget names the Opsgenie account of a channel in the results, the
+// API key being a secret
+const opsgenieTarget = "opsgenie"
+
+// OpsgenieService implements MessageSender for Opsgenie channels. Every
+// message creates an alert routed to the responders of the channel, or closes
+// the alert with the alias of the message.
+type OpsgenieService struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewOpsgenieService creates a new Opsgenie service
+func NewOpsgenieService(timeout time.Duration) *OpsgenieService {
+	return &OpsgenieService{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		timeout: timeout,
+	}
+}
+
+// OpsgenieConfig holds Opsgenie configuration
+type OpsgenieConfig struct {
+	APIKey     string
+	BaseURL    string
+	Source     string
+	Responders []services.OpsgenieResponder
+}
+
+// OpsgenieCreateAlertRequest represents a request creating an alert
+type OpsgenieCreateAlertRequest struct {
+	Message     string                   `json:"message"`
+	Alias       string                   `json:"alias,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Responders  []OpsgenieResponderField `json:"responders,omitempty"`
+	Tags        []string                 `json:"tags,omitempty"`
+	Source      string                   `json:"source,omitempty"`
+	Priority    string                   `json:"priority,omitempty"`
+}
+
+// OpsgenieResponderField represents a responder of an alert
+type OpsgenieResponderField struct {
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// OpsgenieCloseAlertRequest represents a request closing an alert
+type OpsgenieCloseAlertRequest struct {
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// OpsgenieResponse represents the response of the Alert API, which processes
+// the requests asynchronously
+type OpsgenieResponse struct {
+	Result    string `json:"result"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
+
+// Send sends an alert to Opsgenie
+func (s *OpsgenieService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to send Opsgenie alert: %w", result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients creates or closes the alert of the message and reports
+// its outcome. The alias of the alert is the provider message ID, which later
+// messages set as alert_alias to close the alert.
+func (s *OpsgenieService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeOpsgenie) {
+		return nil, fmt.Errorf("invalid channel type for Opsgenie service: %s", ch.ChannelType().String())
+	}
+
+	// Extract Opsgenie configuration
+	config, err := s.extractOpsgenieConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Opsgenie config: %w", err)
+	}
+
+	alert := content.OpsgenieAlert
+	if alert == nil {
+		return nil, errors.New("message has no Opsgenie alert")
+	}
+
+	if alert.Action == services.OpsgenieActionClose {
+		err = s.closeAlert(ctx, config, alert)
+	} else {
+		err = s.createAlert(ctx, config, alert)
+	}
+	return []*RecipientResult{{
+		Target:            opsgenieTarget,
+		Success:           err == nil,
+		ProviderMessageID: alert.Alias,
+		Error:             err,
+		SentAt:            time.Now().UnixMilli(),
+	}}, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the Alert API
+// in the idle pool of the client.
+func (s *OpsgenieService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeOpsgenie) {
+		return nil
+	}
+
+	config, err := s.extractOpsgenieConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract Opsgenie config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, config.BaseURL)
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *OpsgenieService) CloseIdle(before time.Time) int {
+	return 0
+}
+
+// GetChannelType returns the supported channel type
+func (s *OpsgenieService) GetChannelType() string {
+	return shared.ChannelTypeOpsgenie.String()
+}
+
+// ValidateConfig validates Opsgenie channel configuration
+func (s *OpsgenieService) ValidateConfig(config *channel.ChannelConfig) error {
+	_, err := s.extractOpsgenieConfig(config)
+	return err
+}
+
+// extractOpsgenieConfig extracts Opsgenie configuration from channel config
+func (s *OpsgenieService) extractOpsgenieConfig(config *channel.ChannelConfig) (*OpsgenieConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+
+	opsgenieConfig := &OpsgenieConfig{
+		APIKey:  str("api_key"),
+		BaseURL: strings.TrimSuffix(str("base_url"), "/"),
+		Source:  str("source"),
+	}
+	if opsgenieConfig.APIKey == "" {
+		return nil, errors.New("missing required field: api_key")
+	}
+	if opsgenieConfig.Source == "" {
+		opsgenieConfig.Source = defaultOpsgenieSource
+	}
+	if opsgenieConfig.BaseURL == "" {
+		switch region := str("region"); region {
+		case "", "us":
+			opsgenieConfig.BaseURL = opsgenieURL
+		case "eu":
+			opsgenieConfig.BaseURL = opsgenieEUURL
+		default:
+			return nil, fmt.Errorf("unsupported region: %s", region)
+		}
+	}
+
+	responders, err := services.OpsgenieResponders(config)
+	if err != nil {
+		return nil, err
+	}
+	opsgenieConfig.Responders = responders
+
+	return opsgenieConfig, nil
+}
+
+// createAlert creates an alert routed to the responders of the channel.
+// Opsgenie deduplicates the alerts by alias, counting the repeats of an open
+// alert instead of creating another.
+func (s *OpsgenieService) createAlert(ctx context.Context, config *OpsgenieConfig, alert *services.OpsgenieAlert) error {
+	request := OpsgenieCreateAlertRequest{
+		Message:     alert.Message,
+		Alias:       alert.Alias,
+		Description: alert.Description,
+		Tags:        alert.Tags,
+		Source:      config.Source,
+		Priority:    alert.Priority,
+	}
+	for _, responder := range config.Responders {
+		field := OpsgenieResponderField{Type: responder.Type, ID: responder.ID}
+		if field.ID == "" {
+			if responder.Type == "user" {
+				field.Username = responder.Name
+			} else {
+				field.Name = responder.Name
+			}
+		}
+		request.Responders = append(request.Responders, field)
+	}
+
+	return s.post(ctx, config, config.BaseURL+"/v2/alerts", request)
+}
+
+// closeAlert closes the open alert with the alias of the message
+func (s *OpsgenieService) closeAlert(ctx context.Context, config *OpsgenieConfig, alert *services.OpsgenieAlert) error {
+	endpoint := fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", config.BaseURL, url.PathEscape(alert.Alias))
+	request := OpsgenieCloseAlertRequest{
+		Source: config.Source,
+		Note:   "Closed by a resolving notification",
+	}
+
+	return s.post(ctx, config, endpoint, request)
+}
+
+// post sends a request to the Alert API
+func (s *OpsgenieService) post(ctx context.Context, config *OpsgenieConfig, endpoint string, request interface{}) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Opsgenie request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+config.APIKey)
+	setCorrelationHeader(ctx, req.Header)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Opsgenie request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var opsgenieResp OpsgenieResponse
+	_ = json.NewDecoder(resp.Body).Decode(&opsgenieResp)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		statusErr := &StatusError{Provider: "Opsgenie", StatusCode: resp.StatusCode}
+		if opsgenieResp.Message != "" {
+			return fmt.Errorf("%s: %w", opsgenieResp.Message, statusErr)
+		}
+		return statusErr
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// pagerDutyEventsURL is the endpoint of the PagerDuty Events API v2
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// defaultPagerDutySource is the source of the incidents of channels setting none
+const defaultPagerDutySource = "notification"
+
+// pagerDutyTarget names the PagerDuty service of a channel in the results,
+// the routing key being a secret
+const pagerDutyTarget = "pagerduty"
+
+// PagerDutyService implements MessageSender for PagerDuty channels. Every
+// message sends one event to the service of the routing key of the channel,
+// triggering, acknowledging or resolving an incident.
+type PagerDutyService struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewPagerDutyService creates a new PagerDuty service
+func NewPagerDutyService(timeout time.Duration) *PagerDutyService {
+	return &PagerDutyService{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		timeout: timeout,
+	}
+}
+
+// PagerDutyConfig holds PagerDuty configuration
+type PagerDutyConfig struct {
+	RoutingKey string
+	Source     string
+	EventsURL  string
+}
+
+// PagerDutyEventRequest represents an event of the Events API v2
+type PagerDutyEventRequest struct {
+	RoutingKey  string                 `json:"routing_key"`
+	EventAction string                 `json:"event_action"`
+	DedupKey    string                 `json:"dedup_key,omitempty"`
+	Payload     *PagerDutyEventPayload `json:"payload,omitempty"`
+}
+
+// PagerDutyEventPayload describes the incident of a trigger event
+type PagerDutyEventPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Component     string                 `json:"component,omitempty"`
+	Group         string                 `json:"group,omitempty"`
+	Class         string                 `json:"class,omitempty"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// PagerDutyResponse represents the response of the Events API v2
+type PagerDutyResponse struct {
+	Status   string   `json:"status"`
+	Message  string   `json:"message"`
+	DedupKey string   `json:"dedup_key"`
+	Errors   []string `json:"errors"`
+}
+
+// Send sends an event to PagerDuty
+func (s *PagerDutyService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to send PagerDuty event: %w", result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients sends the event of the message and reports its outcome.
+// The dedup key PagerDuty answers with is the provider message ID, which
+// later messages set as incident_dedup_key to update the incident.
+func (s *PagerDutyService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypePagerDuty) {
+		return nil, fmt.Errorf("invalid channel type for PagerDuty service: %s", ch.ChannelType().String())
+	}
+
+	// Extract PagerDuty configuration
+	config, err := s.extractPagerDutyConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract PagerDuty config: %w", err)
+	}
+
+	event := content.PagerDutyEvent
+	if event == nil {
+		return nil, errors.New("message has no PagerDuty event")
+	}
+
+	dedupKey, err := s.sendEvent(ctx, config, event)
+	return []*RecipientResult{{
+		Target:            pagerDutyTarget,
+		Success:           err == nil,
+		ProviderMessageID: dedupKey,
+		Error:             err,
+		SentAt:            time.Now().UnixMilli(),
+	}}, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the Events
+// API in the idle pool of the client.
+func (s *PagerDutyService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypePagerDuty) {
+		return nil
+	}
+
+	config, err := s.extractPagerDutyConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract PagerDuty config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, config.EventsURL)
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *PagerDutyService) CloseIdle(before time.Time) int {
+	return 0
+}
+
+// GetChannelType returns the supported channel type
+func (s *PagerDutyService) GetChannelType() string {
+	return shared.ChannelTypePagerDuty.String()
+}
+
+// ValidateConfig validates PagerDuty channel configuration
+func (s *PagerDutyService) ValidateConfig(config *channel.ChannelConfig) error {
+	_, err := s.extractPagerDutyConfig(config)
+	return err
+}
+
+// extractPagerDutyConfig extracts PagerDuty configuration from channel config
+func (s *PagerDutyService) extractPagerDutyConfig(config *channel.ChannelConfig) (*PagerDutyConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+
+	pagerDutyConfig := &PagerDutyConfig{
+		RoutingKey: str("routing_key"),
+		Source:     str("source"),
+		EventsURL:  str("base_url"),
+	}
+	if pagerDutyConfig.RoutingKey == "" {
+		return nil, errors.New("missing required field: routing_key (Events API v2 integration key)")
+	}
+	if pagerDutyConfig.Source == "" {
+		pagerDutyConfig.Source = defaultPagerDutySource
+	}
+	if pagerDutyConfig.EventsURL == "" {
+		pagerDutyConfig.EventsURL = pagerDutyEventsURL
+	}
+
+	return pagerDutyConfig, nil
+}
+
+// sendEvent sends an event and returns the dedup key of its incident
+func (s *PagerDutyService) sendEvent(ctx context.Context, config *PagerDutyConfig, event *services.PagerDutyEvent) (string, error) {
+	request := PagerDutyEventRequest{
+		RoutingKey:  config.RoutingKey,
+		EventAction: event.Action,
+		DedupKey:    event.DedupKey,
+	}
+	if event.Action == services.PagerDutyActionTrigger {
+		request.Payload = &PagerDutyEventPayload{
+			Summary:   event.Summary,
+			Source:    config.Source,
+			Severity:  event.Severity,
+			Component: event.Component,
+			Group:     event.Group,
+			Class:     event.Class,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		}
+		if event.Details != "" {
+			request.Payload.CustomDetails = map[string]interface{}{"details": event.Details}
+		}
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal PagerDuty event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.EventsURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	setCorrelationHeader(ctx, req.Header)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send PagerDuty request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var pagerDutyResp PagerDutyResponse
+	_ = json.NewDecoder(resp.Body).Decode(&pagerDutyResp)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		statusErr := &StatusError{Provider: "PagerDuty", StatusCode: resp.StatusCode}
+		if len(pagerDutyResp.Errors) > 0 {
+			return "", fmt.Errorf("%s: %w", strings.Join(pagerDutyResp.Errors, "; "), statusErr)
+		}
+		return "", statusErr
+	}
+
+	if pagerDutyResp.DedupKey == "" {
+		return event.DedupKey, nil
+	}
+	return pagerDutyResp.DedupKey, nil
+}
@@ -0,0 +1,116 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// DefaultRetentionInterval is how often the retention windows are enforced
+// when no interval is configured
+const DefaultRetentionInterval = time.Hour
+
+// RetentionJob enforces the retention windows of the tenants every interval
+// in the background. Purging and deleting being repeatable, a run cut short
+// is completed by the next one.
+type RetentionJob struct {
+	enforcer *services.RetentionEnforcer
+	interval time.Duration
+	logger   *logger.Logger
+	readOnly *shared.ReadOnlyMode
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRetentionJob creates a job enforcing the retention windows every
+// interval; zero uses DefaultRetentionInterval
+func NewRetentionJob(enforcer *services.RetentionEnforcer, interval time.Duration, log *logger.Logger) *RetentionJob {
+	if interval <= 0 {
+		interval = DefaultRetentionInterval
+	}
+	return &RetentionJob{
+		enforcer: enforcer,
+		interval: interval,
+		logger:   log.WithComponent("retention"),
+	}
+}
+
+// SetReadOnly skips the runs while the instance is read-only, leaving them
+// to the primary.
+func (j *RetentionJob) SetReadOnly(mode *shared.ReadOnlyMode) {
+	j.readOnly = mode
+}
+
+// Start enforces the windows after one interval and then every interval
+func (j *RetentionJob) Start() {
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-j.stop
+		cancel()
+	}()
+
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-j.stop:
+				return
+			}
+			if j.readOnly.Enabled() {
+				continue
+			}
+			j.Run(ctx)
+		}
+	}()
+
+	j.logger.Info("Retention job started", zap.Duration("interval", j.interval))
+}
+
+// Stop ends the runs, cancelling the running one and waiting for it up to
+// the context deadline
+func (j *RetentionJob) Stop(ctx context.Context) error {
+	if j.stop == nil {
+		return nil
+	}
+	close(j.stop)
+
+	select {
+	case <-j.done:
+		j.logger.Info("Retention job stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("retention job stopped while running: %w", ctx.Err())
+	}
+}
+
+// Run enforces the windows once
+func (j *RetentionJob) Run(ctx context.Context) {
+	reports, err := j.enforcer.Enforce(ctx)
+	if err != nil {
+		j.logger.Error("Failed to enforce retention", zap.Error(err))
+		return
+	}
+	purged, deleted := 0, 0
+	for _, report := range reports {
+		purged += report.ContentPurged
+		deleted += report.MessagesDeleted
+	}
+	j.logger.Info("Retention enforced",
+		zap.Int("tenants", len(reports)),
+		zap.Int("content_purged", purged),
+		zap.Int("messages_deleted", deleted))
+}
@@ -0,0 +1,124 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// DefaultScheduledSendPollInterval is how often the due scheduled sends are
+// looked for when no interval is configured
+const DefaultScheduledSendPollInterval = 30 * time.Second
+
+// DefaultScheduledSendBatchSize is how many due scheduled sends a poll sends
+// at most when no batch size is configured
+const DefaultScheduledSendBatchSize = 100
+
+// ScheduledSendJob sends the scheduled sends whose time has come, polling
+// for them every interval in the background. A full batch is followed by
+// the next one right away, so that a backlog drains before the next tick.
+type ScheduledSendJob struct {
+	runner    *services.ScheduledSendRunner
+	interval  time.Duration
+	batchSize int
+	logger    *logger.Logger
+	now       func() time.Time
+	readOnly  *shared.ReadOnlyMode
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewScheduledSendJob creates a job polling every interval for up to batchSize
+// due sends; zero values use the defaults
+func NewScheduledSendJob(runner *services.ScheduledSendRunner, interval time.Duration, batchSize int, log *logger.Logger) *ScheduledSendJob {
+	if interval <= 0 {
+		interval = DefaultScheduledSendPollInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultScheduledSendBatchSize
+	}
+	return &ScheduledSendJob{
+		runner:    runner,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.WithComponent("scheduled_sends"),
+		now:       time.Now,
+	}
+}
+
+// SetReadOnly skips the polls while the instance is read-only, leaving the
+// sends to the primary.
+func (j *ScheduledSendJob) SetReadOnly(mode *shared.ReadOnlyMode) {
+	j.readOnly = mode
+}
+
+// Start polls for the due sends every interval
+func (j *ScheduledSendJob) Start() {
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-j.stop:
+				return
+			}
+			if j.readOnly.Enabled() {
+				continue
+			}
+			j.Run(context.Background())
+		}
+	}()
+
+	j.logger.Info("Scheduled sends started", zap.Duration("poll_interval", j.interval))
+}
+
+// Stop ends the polls, waiting for the running one up to the context deadline
+func (j *ScheduledSendJob) Stop(ctx context.Context) error {
+	if j.stop == nil {
+		return nil
+	}
+	close(j.stop)
+
+	select {
+	case <-j.done:
+		j.logger.Info("Scheduled sends stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduled sends stopped while sending: %w", ctx.Err())
+	}
+}
+
+// Run sends the sends due now, batch after batch until one is not full or
+// the job is stopped, and returns how many it sent
+func (j *ScheduledSendJob) Run(ctx context.Context) int {
+	total := 0
+	for {
+		sent, err := j.runner.RunDue(ctx, j.now(), j.batchSize)
+		total += sent
+		if err != nil {
+			j.logger.Error("Failed to run scheduled sends", zap.Error(err))
+			return total
+		}
+		if sent < j.batchSize {
+			return total
+		}
+		select {
+		case <-j.stop:
+			return total
+		default:
+		}
+	}
+}
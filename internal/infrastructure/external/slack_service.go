@@ -14,6 +14,12 @@ import (
 	"notification/internal/domain/shared"
 )
 
+// slackAPIURL is the Web API method the token channels post to
+const slackAPIURL = "https://slack.com/api/chat.postMessage"
+
+// slackAuthTestURL is the Web API method checking a token
+const slackAuthTestURL = "https://slack.com/api/auth.test"
+
 // SlackService implements MessageSender for Slack channel
 type SlackService struct {
 	httpClient *http.Client
@@ -32,31 +38,118 @@ func NewSlackService(timeout time.Duration) *SlackService {
 
 // Send sends a message to Slack
 func (s *SlackService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to send to target %s: %w", result.Target, result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients sends a message to every Slack target and reports the outcome of each
+func (s *SlackService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
 	// Validate channel type
 	if !ch.ChannelType().Equals(shared.ChannelTypeSlack) {
-		return fmt.Errorf("invalid channel type for Slack service: %s", ch.ChannelType().String())
+		return nil, fmt.Errorf("invalid channel type for Slack service: %s", ch.ChannelType().String())
 	}
 
 	// Extract Slack configuration
 	config, err := s.extractSlackConfig(ch.Config())
 	if err != nil {
-		return fmt.Errorf("failed to extract Slack config: %w", err)
+		return nil, fmt.Errorf("failed to extract Slack config: %w", err)
 	}
 
 	// Prepare recipients
 	targets := s.prepareTargets(ch.Recipients())
 	if len(targets) == 0 {
-		return fmt.Errorf("no valid Slack targets found")
+		return nil, fmt.Errorf("no valid Slack targets found")
 	}
+	ackLinks := s.prepareAcknowledgmentLinks(ch.Recipients(), content)
 
 	// Send to all targets
+	results := make([]*RecipientResult, 0, len(targets))
 	for _, target := range targets {
-		if err := s.sendToTarget(ctx, config, target, content); err != nil {
-			return fmt.Errorf("failed to send to target %s: %w", target, err)
-		}
+		messageID, err := s.sendToTarget(ctx, config, target, content, ackLinks[target])
+		results = append(results, &RecipientResult{
+			Target:            target,
+			Success:           err == nil,
+			ProviderMessageID: messageID,
+			Error:             err,
+			SentAt:            time.Now().UnixMilli(),
+		})
 	}
 
-	return nil
+	return results, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the webhook,
+// or to the Web API for token channels, in the idle pool of the client.
+func (s *SlackService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeSlack) {
+		return nil
+	}
+
+	config, err := s.extractSlackConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract Slack config: %w", err)
+	}
+	url := slackAPIURL
+	if config.WebhookURL != "" {
+		url = config.WebhookURL
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, url)
+}
+
+// VerifyCredentials implements CredentialVerifier. It checks the token of a
+// token channel with auth.test; a webhook has no credentials to check.
+func (s *SlackService) VerifyCredentials(ctx context.Context, ch *channel.Channel) (bool, error) {
+	config, err := s.extractSlackConfig(ch.Config())
+	if err != nil {
+		return false, fmt.Errorf("failed to extract Slack config: %w", err)
+	}
+	if config.WebhookURL != "" || config.Token == "" {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackAuthTestURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+config.Token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to reach provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var slackResp SlackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&slackResp); err != nil {
+		return false, fmt.Errorf("failed to decode Slack response: %w", err)
+	}
+	if !slackResp.OK {
+		return true, fmt.Errorf("%w: %w", ErrCredentialsRejected, &SlackAPIError{Code: slackResp.Error})
+	}
+	return true, nil
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *SlackService) CloseIdle(before time.Time) int {
+	return 0
 }
 
 // GetChannelType returns the supported channel type
@@ -110,11 +203,32 @@ type SlackMessage struct {
 
 // SlackAttachment represents a Slack message attachment
 type SlackAttachment struct {
-	Color     string `json:"color,omitempty"`
-	Title     string `json:"title,omitempty"`
-	Text      string `json:"text,omitempty"`
-	Footer    string `json:"footer,omitempty"`
-	Timestamp int64  `json:"ts,omitempty"`
+	Color     string        `json:"color,omitempty"`
+	Title     string        `json:"title,omitempty"`
+	Text      string        `json:"text,omitempty"`
+	Footer    string        `json:"footer,omitempty"`
+	Timestamp int64         `json:"ts,omitempty"`
+	Fallback  string        `json:"fallback,omitempty"`
+	Actions   []SlackAction `json:"actions,omitempty"`
+}
+
+// SlackAction represents a link button of a Slack message attachment
+type SlackAction struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	URL   string `json:"url"`
+	Style string `json:"style,omitempty"`
+}
+
+// acknowledgmentAttachment returns the attachment holding the button that
+// acknowledges a message
+func acknowledgmentAttachment(ackURL string) SlackAttachment {
+	return SlackAttachment{
+		Fallback: "Acknowledge: " + ackURL,
+		Actions: []SlackAction{
+			{Type: "button", Text: "Acknowledge", URL: ackURL, Style: "primary"},
+		},
+	}
 }
 
 // SlackResponse represents the response from Slack API
@@ -150,44 +264,64 @@ func (s *SlackService) prepareTargets(recipients *channel.Recipients) []string {
 		if recipient.Target == "" {
 			continue
 		}
+		targets = append(targets, slackTarget(recipient))
+	}
 
-		// Support different target types: channel, user, DM
-		target := recipient.Target
-		switch strings.ToLower(recipient.Type) {
-		case "channel":
-			// Ensure channel starts with #
-			if !strings.HasPrefix(target, "#") {
-				target = "#" + target
-			}
-		case "user", "dm":
-			// Ensure user starts with @
-			if !strings.HasPrefix(target, "@") {
-				target = "@" + target
-			}
-		default:
-			// Default to channel if type is not specified
-			if !strings.HasPrefix(target, "#") && !strings.HasPrefix(target, "@") {
-				target = "#" + target
-			}
-		}
+	return targets
+}
 
-		targets = append(targets, target)
+// prepareAcknowledgmentLinks maps the Slack targets to the acknowledgment
+// links of their recipients, nil when the message carries none
+func (s *SlackService) prepareAcknowledgmentLinks(recipients *channel.Recipients, content *services.RenderedContent) map[string]string {
+	if len(content.AcknowledgmentLinks) == 0 {
+		return nil
 	}
+	links := make(map[string]string, len(content.AcknowledgmentLinks))
+	for _, recipient := range recipients.ToSlice() {
+		if link, ok := content.AcknowledgmentLinks[recipient.Target]; ok {
+			links[slackTarget(recipient)] = link
+		}
+	}
+	return links
+}
 
-	return targets
+// slackTarget returns the Slack target of a recipient, a channel or a user
+func slackTarget(recipient *channel.Recipient) string {
+	// Support different target types: channel, user, DM
+	target := recipient.Target
+	switch strings.ToLower(recipient.Type) {
+	case "channel":
+		// Ensure channel starts with #
+		if !strings.HasPrefix(target, "#") {
+			target = "#" + target
+		}
+	case "user", "dm":
+		// Ensure user starts with @
+		if !strings.HasPrefix(target, "@") {
+			target = "@" + target
+		}
+	default:
+		// Default to channel if type is not specified
+		if !strings.HasPrefix(target, "#") && !strings.HasPrefix(target, "@") {
+			target = "#" + target
+		}
+	}
+	return target
 }
 
-// sendToTarget sends message to a specific Slack target
-func (s *SlackService) sendToTarget(ctx context.Context, config *SlackConfig, target string, content *services.RenderedContent) error {
+// sendToTarget sends message to a specific Slack target, with the button
+// acknowledging it when there is a link, and returns the message timestamp,
+// which webhooks do not report
+func (s *SlackService) sendToTarget(ctx context.Context, config *SlackConfig, target string, content *services.RenderedContent, ackURL string) (string, error) {
 	// Use webhook if available, otherwise use API
 	if config.WebhookURL != "" {
-		return s.sendViaWebhook(ctx, config.WebhookURL, target, content)
+		return "", s.sendViaWebhook(ctx, config.WebhookURL, target, content, ackURL)
 	}
-	return s.sendViaAPI(ctx, config.Token, target, content)
+	return s.sendViaAPI(ctx, config.Token, target, content, ackURL)
 }
 
 // sendViaWebhook sends message via Slack webhook
-func (s *SlackService) sendViaWebhook(ctx context.Context, webhookURL, target string, content *services.RenderedContent) error {
+func (s *SlackService) sendViaWebhook(ctx context.Context, webhookURL, target string, content *services.RenderedContent, ackURL string) error {
 	message := SlackMessage{
 		Channel: target,
 		Text:    content.Content,
@@ -204,6 +338,9 @@ func (s *SlackService) sendViaWebhook(ctx context.Context, webhookURL, target st
 		}
 		message.Text = content.Subject
 	}
+	if ackURL != "" {
+		message.Attachments = append(message.Attachments, acknowledgmentAttachment(ackURL))
+	}
 
 	payload, err := json.Marshal(message)
 	if err != nil {
@@ -216,6 +353,7 @@ func (s *SlackService) sendViaWebhook(ctx context.Context, webhookURL, target st
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	setCorrelationHeader(ctx, req.Header)
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -224,14 +362,14 @@ func (s *SlackService) sendViaWebhook(ctx context.Context, webhookURL, target st
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("webhook request failed with status: %d", resp.StatusCode)
+		return &StatusError{Provider: "webhook", StatusCode: resp.StatusCode}
 	}
 
 	return nil
 }
 
 // sendViaAPI sends message via Slack Web API
-func (s *SlackService) sendViaAPI(ctx context.Context, token, target string, content *services.RenderedContent) error {
+func (s *SlackService) sendViaAPI(ctx context.Context, token, target string, content *services.RenderedContent, ackURL string) (string, error) {
 	message := SlackMessage{
 		Channel: target,
 		Text:    content.Content,
@@ -249,34 +387,38 @@ func (s *SlackService) sendViaAPI(ctx context.Context, token, target string, con
 		}
 		message.Text = content.Subject
 	}
+	if ackURL != "" {
+		message.Attachments = append(message.Attachments, acknowledgmentAttachment(ackURL))
+	}
 
 	payload, err := json.Marshal(message)
 	if err != nil {
-		return fmt.Errorf("failed to marshal Slack message: %w", err)
+		return "", fmt.Errorf("failed to marshal Slack message: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://slack.com/api/chat.postMessage", bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, "POST", slackAPIURL, bytes.NewBuffer(payload))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	setCorrelationHeader(ctx, req.Header)
 	req.Header.Set("Authorization", "Bearer "+token)
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send API request: %w", err)
+		return "", fmt.Errorf("failed to send API request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var slackResp SlackResponse
 	if err := json.NewDecoder(resp.Body).Decode(&slackResp); err != nil {
-		return fmt.Errorf("failed to decode Slack response: %w", err)
+		return "", fmt.Errorf("failed to decode Slack response: %w", err)
 	}
 
 	if !slackResp.OK {
-		return fmt.Errorf("Slack API error: %s", slackResp.Error)
+		return "", &SlackAPIError{Code: slackResp.Error}
 	}
 
-	return nil
+	return slackResp.TS, nil
 }
\ No newline at end of file
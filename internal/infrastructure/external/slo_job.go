@@ -0,0 +1,168 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/slo"
+	"notification/pkg/logger"
+)
+
+// DefaultSLOInterval is how often the objectives are evaluated when no
+// interval is configured
+const DefaultSLOInterval = time.Minute
+
+var (
+	// sloTarget is the share of the messages each objective dispatches in time
+	sloTarget = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dntf_slo_target_ratio",
+		Help: "Target share of the messages dispatched within the threshold by objective.",
+	}, []string{"objective"})
+
+	// sloCompliance is the share of the messages of the window dispatched in time
+	sloCompliance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dntf_slo_compliance_ratio",
+		Help: "Share of the messages of the window dispatched within the threshold by objective.",
+	}, []string{"objective"})
+
+	// sloMessages counts the messages of the window
+	sloMessages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dntf_slo_window_messages",
+		Help: "Messages of the window by objective.",
+	}, []string{"objective"})
+
+	// sloBudgetRemaining is the share of the error budget left over the window
+	sloBudgetRemaining = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dntf_slo_error_budget_remaining_ratio",
+		Help: "Share of the error budget left over the window by objective, negative once exceeded.",
+	}, []string{"objective"})
+
+	// sloBurnRate is how fast the error budget burns over the alert windows
+	sloBurnRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dntf_slo_burn_rate",
+		Help: "Error budget burn rate by objective, alert and alert window (long or short).",
+	}, []string{"objective", "alert", "window"})
+
+	// sloAlertFiring is 1 while a burn-rate alert fires
+	sloAlertFiring = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dntf_slo_alert_firing",
+		Help: "Whether a burn-rate alert fires by objective and alert.",
+	}, []string{"objective", "alert"})
+)
+
+// SLOJob evaluates the delivery latency objectives every interval in the
+// background, exposing them on the metrics endpoint and sending their
+// burn-rate alerts.
+type SLOJob struct {
+	tracker  *services.SLOTracker
+	interval time.Duration
+	logger   *logger.Logger
+	readOnly *shared.ReadOnlyMode
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSLOJob creates a job evaluating the objectives every interval; zero
+// uses DefaultSLOInterval
+func NewSLOJob(tracker *services.SLOTracker, interval time.Duration, log *logger.Logger) *SLOJob {
+	if interval <= 0 {
+		interval = DefaultSLOInterval
+	}
+	return &SLOJob{
+		tracker:  tracker,
+		interval: interval,
+		logger:   log.WithComponent("slo"),
+	}
+}
+
+// SetReadOnly only updates the metrics while the instance is read-only,
+// leaving the alerts to the primary.
+func (j *SLOJob) SetReadOnly(mode *shared.ReadOnlyMode) {
+	j.readOnly = mode
+}
+
+// Start evaluates the objectives at once and then every interval
+func (j *SLOJob) Start() {
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-j.stop
+		cancel()
+	}()
+
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.Run(ctx)
+			select {
+			case <-ticker.C:
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+
+	j.logger.Info("SLO job started", zap.Duration("interval", j.interval))
+}
+
+// Stop ends the evaluations, cancelling the running one and waiting for it
+// up to the context deadline
+func (j *SLOJob) Stop(ctx context.Context) error {
+	if j.stop == nil {
+		return nil
+	}
+	close(j.stop)
+
+	select {
+	case <-j.done:
+		j.logger.Info("SLO job stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("slo job stopped while running: %w", ctx.Err())
+	}
+}
+
+// Run evaluates the objectives once and updates their metrics
+func (j *SLOJob) Run(ctx context.Context) {
+	var statuses []*slo.Status
+	var err error
+	if j.readOnly.Enabled() {
+		statuses, err = j.tracker.Status(ctx)
+	} else {
+		statuses, err = j.tracker.Evaluate(ctx)
+	}
+	if err != nil {
+		j.logger.Error("Failed to evaluate SLOs", zap.Error(err))
+		return
+	}
+
+	for _, status := range statuses {
+		name := status.Objective.Name
+		sloTarget.WithLabelValues(name).Set(status.Objective.Target)
+		sloCompliance.WithLabelValues(name).Set(status.Compliance())
+		sloMessages.WithLabelValues(name).Set(float64(status.Measurement.Total))
+		sloBudgetRemaining.WithLabelValues(name).Set(status.BudgetRemaining())
+		for _, burnRate := range status.BurnRates {
+			sloBurnRate.WithLabelValues(name, burnRate.Alert.Name, "long").Set(burnRate.Long)
+			sloBurnRate.WithLabelValues(name, burnRate.Alert.Name, "short").Set(burnRate.Short)
+			firing := 0.0
+			if burnRate.Firing() {
+				firing = 1
+			}
+			sloAlertFiring.WithLabelValues(name, burnRate.Alert.Name).Set(firing)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -14,6 +15,12 @@ import (
 	"notification/internal/domain/shared"
 )
 
+// SMSInboundPath returns the path, under the callback URL of an SMS channel,
+// where Twilio posts the replies to its messages
+func SMSInboundPath(channelID string) string {
+	return "/api/v1/public/sms/channels/" + url.PathEscape(channelID) + "/inbound"
+}
+
 // SMSService implements MessageSender for SMS channel
 type SMSService struct {
 	httpClient *http.Client
@@ -32,31 +39,119 @@ func NewSMSService(timeout time.Duration) *SMSService {
 
 // Send sends an SMS message
 func (s *SMSService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to send to phone number %s: %w", result.Target, result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients sends an SMS message to every phone number and reports the outcome of each
+func (s *SMSService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
 	// Validate channel type
 	if !ch.ChannelType().Equals(shared.ChannelTypeSMS) {
-		return fmt.Errorf("invalid channel type for SMS service: %s", ch.ChannelType().String())
+		return nil, fmt.Errorf("invalid channel type for SMS service: %s", ch.ChannelType().String())
 	}
 
 	// Extract SMS configuration
 	config, err := s.extractSMSConfig(ch.Config())
 	if err != nil {
-		return fmt.Errorf("failed to extract SMS config: %w", err)
+		return nil, fmt.Errorf("failed to extract SMS config: %w", err)
 	}
 
 	// Prepare phone numbers
 	phoneNumbers := s.preparePhoneNumbers(ch.Recipients())
 	if len(phoneNumbers) == 0 {
-		return fmt.Errorf("no valid phone numbers found")
+		return nil, fmt.Errorf("no valid phone numbers found")
 	}
 
 	// Send to all phone numbers
+	results := make([]*RecipientResult, 0, len(phoneNumbers))
 	for _, phoneNumber := range phoneNumbers {
-		if err := s.sendToPhoneNumber(ctx, config, phoneNumber, content); err != nil {
-			return fmt.Errorf("failed to send to phone number %s: %w", phoneNumber, err)
-		}
+		messageID, err := s.sendToPhoneNumber(ctx, config, phoneNumber, content)
+		results = append(results, &RecipientResult{
+			Target:            phoneNumber,
+			Success:           err == nil,
+			ProviderMessageID: messageID,
+			Error:             err,
+			SentAt:            time.Now().UnixMilli(),
+		})
 	}
 
-	return nil
+	return results, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the SMS
+// provider in the idle pool of the client.
+func (s *SMSService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeSMS) {
+		return nil
+	}
+
+	config, err := s.extractSMSConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract SMS config: %w", err)
+	}
+	if config.BaseURL == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, config.BaseURL)
+}
+
+// VerifyCredentials implements CredentialVerifier. It reads the account of
+// the channel from the providers that have an endpoint for it; AWS SNS
+// requests are signed and are not checked.
+func (s *SMSService) VerifyCredentials(ctx context.Context, ch *channel.Channel) (bool, error) {
+	config, err := s.extractSMSConfig(ch.Config())
+	if err != nil {
+		return false, fmt.Errorf("failed to extract SMS config: %w", err)
+	}
+
+	var endpoint string
+	switch config.Provider {
+	case "twilio":
+		endpoint = "/Accounts/" + url.PathEscape(config.APIKey) + ".json"
+	case "messagebird":
+		endpoint = "/balance"
+	case "nexmo":
+		endpoint = "/account/get-balance?" + url.Values{
+			"api_key":    {config.APIKey},
+			"api_secret": {config.APISecret},
+		}.Encode()
+	default:
+		return false, nil
+	}
+	if config.BaseURL == "" {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.BaseURL+endpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	setSMSAuthorization(req, config)
+
+	return true, checkCredentials(s.httpClient, req, "SMS")
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *SMSService) CloseIdle(before time.Time) int {
+	return 0
 }
 
 // GetChannelType returns the supported channel type
@@ -110,6 +205,9 @@ type SMSConfig struct {
 	APISecret string
 	From      string
 	BaseURL   string
+	// CallbackURL is the public address of the service Twilio posts the
+	// replies to
+	CallbackURL string
 }
 
 // SMSMessage represents an SMS message payload
@@ -133,6 +231,7 @@ func (s *SMSService) extractSMSConfig(config *channel.ChannelConfig) (*SMSConfig
 	apiSecret, _ := config.Get("api_secret")
 	from, _ := config.Get("from")
 	baseURL, _ := config.Get("base_url")
+	callbackURL, _ := config.Get("callback_url")
 
 	smsConfig := &SMSConfig{
 		Provider:  strings.ToLower(fmt.Sprintf("%v", provider)),
@@ -144,6 +243,10 @@ func (s *SMSService) extractSMSConfig(config *channel.ChannelConfig) (*SMSConfig
 		smsConfig.From = fmt.Sprintf("%v", from)
 	}
 
+	if callbackURL != nil {
+		smsConfig.CallbackURL = fmt.Sprintf("%v", callbackURL)
+	}
+
 	if baseURL != nil {
 		smsConfig.BaseURL = fmt.Sprintf("%v", baseURL)
 	} else {
@@ -154,6 +257,24 @@ func (s *SMSService) extractSMSConfig(config *channel.ChannelConfig) (*SMSConfig
 	return smsConfig, nil
 }
 
+// VerifyCallback checks that a reply posted to the callback URL of a Twilio
+// SMS channel comes from Twilio, signed with the auth token of the account
+func (s *SMSService) VerifyCallback(ch *channel.Channel, params url.Values, signature string) error {
+	config, err := s.extractSMSConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract SMS config: %w", err)
+	}
+	if config.Provider != "twilio" {
+		return fmt.Errorf("SMS provider %s does not post replies", config.Provider)
+	}
+	if config.CallbackURL == "" {
+		return fmt.Errorf("SMS channel has no callback URL")
+	}
+
+	callbackURL := strings.TrimRight(config.CallbackURL, "/") + SMSInboundPath(ch.ID().String())
+	return verifyTwilioSignature(callbackURL, params, config.APISecret, signature)
+}
+
 // getDefaultBaseURL returns default base URL for SMS providers
 func (s *SMSService) getDefaultBaseURL(provider string) string {
 	switch provider {
@@ -226,7 +347,7 @@ func (s *SMSService) isValidPhoneNumber(phoneNumber string) bool {
 }
 
 // sendToPhoneNumber sends SMS to a specific phone number
-func (s *SMSService) sendToPhoneNumber(ctx context.Context, config *SMSConfig, phoneNumber string, content *services.RenderedContent) error {
+func (s *SMSService) sendToPhoneNumber(ctx context.Context, config *SMSConfig, phoneNumber string, content *services.RenderedContent) (string, error) {
 	// Combine subject and content for SMS
 	messageBody := content.Content
 	if content.Subject != "" {
@@ -248,12 +369,12 @@ func (s *SMSService) sendToPhoneNumber(ctx context.Context, config *SMSConfig, p
 	case "messagebird":
 		return s.sendViaMessageBird(ctx, config, phoneNumber, messageBody)
 	default:
-		return fmt.Errorf("unsupported SMS provider: %s", config.Provider)
+		return "", fmt.Errorf("unsupported SMS provider: %s", config.Provider)
 	}
 }
 
 // sendViaTwilio sends SMS via Twilio API
-func (s *SMSService) sendViaTwilio(ctx context.Context, config *SMSConfig, phoneNumber, message string) error {
+func (s *SMSService) sendViaTwilio(ctx context.Context, config *SMSConfig, phoneNumber, message string) (string, error) {
 	// This is a simplified implementation
 	// In production, you would use the official Twilio SDK
 
@@ -267,7 +388,7 @@ func (s *SMSService) sendViaTwilio(ctx context.Context, config *SMSConfig, phone
 }
 
 // sendViaAWSSNS sends SMS via AWS SNS
-func (s *SMSService) sendViaAWSSNS(ctx context.Context, config *SMSConfig, phoneNumber, message string) error {
+func (s *SMSService) sendViaAWSSNS(ctx context.Context, config *SMSConfig, phoneNumber, message string) (string, error) {
 	// This is a simplified implementation
 	// In production, you would use the AWS SDK
 
@@ -280,7 +401,7 @@ func (s *SMSService) sendViaAWSSNS(ctx context.Context, config *SMSConfig, phone
 }
 
 // sendViaNexmo sends SMS via Nexmo API
-func (s *SMSService) sendViaNexmo(ctx context.Context, config *SMSConfig, phoneNumber, message string) error {
+func (s *SMSService) sendViaNexmo(ctx context.Context, config *SMSConfig, phoneNumber, message string) (string, error) {
 	payload := map[string]interface{}{
 		"from": config.From,
 		"to":   phoneNumber,
@@ -291,7 +412,7 @@ func (s *SMSService) sendViaNexmo(ctx context.Context, config *SMSConfig, phoneN
 }
 
 // sendViaMessageBird sends SMS via MessageBird API
-func (s *SMSService) sendViaMessageBird(ctx context.Context, config *SMSConfig, phoneNumber, message string) error {
+func (s *SMSService) sendViaMessageBird(ctx context.Context, config *SMSConfig, phoneNumber, message string) (string, error) {
 	payload := map[string]interface{}{
 		"originator": config.From,
 		"recipients": []string{phoneNumber},
@@ -301,40 +422,59 @@ func (s *SMSService) sendViaMessageBird(ctx context.Context, config *SMSConfig,
 	return s.sendHTTPRequest(ctx, config, payload, "/messages")
 }
 
-// sendHTTPRequest sends HTTP request to SMS provider
-func (s *SMSService) sendHTTPRequest(ctx context.Context, config *SMSConfig, payload interface{}, endpoint string) error {
+// sendHTTPRequest sends HTTP request to SMS provider and returns the provider message ID when the response has one
+func (s *SMSService) sendHTTPRequest(ctx context.Context, config *SMSConfig, payload interface{}, endpoint string) (string, error) {
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal SMS payload: %w", err)
+		return "", fmt.Errorf("failed to marshal SMS payload: %w", err)
 	}
 
 	url := config.BaseURL + endpoint
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	setCorrelationHeader(ctx, req.Header)
 
-	// Set authentication based on provider
-	switch config.Provider {
-	case "twilio":
-		req.SetBasicAuth(config.APIKey, config.APISecret)
-	case "messagebird":
-		req.Header.Set("Authorization", "AccessKey "+config.APIKey)
-	default:
-		req.Header.Set("Authorization", "Bearer "+config.APIKey)
-	}
+	setSMSAuthorization(req, config)
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send SMS request: %w", err)
+		return "", fmt.Errorf("failed to send SMS request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("SMS request failed with status: %d", resp.StatusCode)
+		return "", &StatusError{Provider: "SMS", StatusCode: resp.StatusCode}
 	}
 
-	return nil
+	// The providers name the message ID differently, a body without one is not an error
+	var body struct {
+		MessageID    string `json:"message_id"`
+		SID          string `json:"sid"`
+		ID           string `json:"id"`
+		SNSMessageID string `json:"MessageId"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	for _, id := range []string{body.MessageID, body.SID, body.ID, body.SNSMessageID} {
+		if id != "" {
+			return id, nil
+		}
+	}
+
+	return "", nil
+}
+
+// setSMSAuthorization authenticates a request to the provider of a channel
+func setSMSAuthorization(req *http.Request, config *SMSConfig) {
+	switch config.Provider {
+	case "twilio":
+		req.SetBasicAuth(config.APIKey, config.APISecret)
+	case "messagebird":
+		req.Header.Set("Authorization", "AccessKey "+config.APIKey)
+	default:
+		req.Header.Set("Authorization", "Bearer "+config.APIKey)
+	}
 }
@@ -0,0 +1,229 @@
+package external
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sync"
+	"time"
+)
+
+// smtpMaxIdle is how long an SMTP connection may go unused before it is
+// considered closed by the server. RFC 5321 asks servers to wait at least
+// five minutes for the next command.
+const smtpMaxIdle = 5 * time.Minute
+
+// smtpConn is an authenticated SMTP connection ready for the next mail
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+// smtpPool keeps one idle SMTP connection per server and account, so that a
+// mail does not pay for the connection, the TLS handshake and the
+// authentication of the one before it
+type smtpPool struct {
+	mu   sync.Mutex
+	idle map[string]*smtpConn
+	now  func() time.Time
+}
+
+// newSMTPPool creates an empty SMTP connection pool
+func newSMTPPool() *smtpPool {
+	return &smtpPool{
+		idle: make(map[string]*smtpConn),
+		now:  time.Now,
+	}
+}
+
+// smtpPoolKey identifies the connections that may be shared
+func smtpPoolKey(config *SMTPConfig) string {
+	return fmt.Sprintf("%s:%d|%s|%t", config.Host, config.Port, config.Username, config.UseTLS)
+}
+
+// send delivers a mail on the idle connection of its server, or on a new
+// one. The idle connection is checked with a RSET first, so that a
+// connection the server dropped is replaced before any of the mail is sent.
+func (p *smtpPool) send(ctx context.Context, config *SMTPConfig, recipients []string, message string) error {
+	key := smtpPoolKey(config)
+	c := p.take(key)
+	if c != nil {
+		setSMTPDeadline(ctx, c.conn)
+		if err := c.client.Reset(); err != nil {
+			c.close()
+			c = nil
+		}
+	}
+	if c == nil {
+		var err error
+		if c, err = dialSMTP(ctx, config); err != nil {
+			return err
+		}
+	}
+
+	err := p.deliver(ctx, c, config.From, recipients, message)
+	p.release(key, c, err)
+	return err
+}
+
+// warm checks the idle connection of a server with a NOOP, replacing it
+// when the server dropped it, or opens one when there is none
+func (p *smtpPool) warm(ctx context.Context, config *SMTPConfig) error {
+	key := smtpPoolKey(config)
+	if c := p.take(key); c != nil {
+		setSMTPDeadline(ctx, c.conn)
+		err := c.client.Noop()
+		if err == nil {
+			p.release(key, c, nil)
+			return nil
+		}
+		c.close()
+	}
+
+	c, err := dialSMTP(ctx, config)
+	if err != nil {
+		return err
+	}
+	p.release(key, c, nil)
+	return nil
+}
+
+// closeIdle closes the idle connections last used before the given time
+// and returns their number
+func (p *smtpPool) closeIdle(before time.Time) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	closed := 0
+	for key, c := range p.idle {
+		if c.lastUsed.Before(before) {
+			delete(p.idle, key)
+			c.close()
+			closed++
+		}
+	}
+	return closed
+}
+
+// take removes the idle connection of a server from the pool. A connection
+// idle for longer than smtpMaxIdle is closed instead.
+func (p *smtpPool) take(key string) *smtpConn {
+	p.mu.Lock()
+	c := p.idle[key]
+	delete(p.idle, key)
+	p.mu.Unlock()
+
+	if c != nil && p.now().Sub(c.lastUsed) > smtpMaxIdle {
+		c.close()
+		return nil
+	}
+	return c
+}
+
+// release returns a connection to the pool unless its last use failed, or
+// another connection to the server was returned in the meantime
+func (p *smtpPool) release(key string, c *smtpConn, err error) {
+	if err != nil {
+		c.close()
+		return
+	}
+	c.lastUsed = p.now()
+	_ = c.conn.SetDeadline(time.Time{})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.idle[key]; ok {
+		c.close()
+		return
+	}
+	p.idle[key] = c
+}
+
+// deliver sends one mail on a connection
+func (p *smtpPool) deliver(ctx context.Context, c *smtpConn, from string, recipients []string, message string) error {
+	setSMTPDeadline(ctx, c.conn)
+
+	if err := c.client.Mail(from); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := c.client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write([]byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// close ends a connection, politely when the server still listens
+func (c *smtpConn) close() {
+	_ = c.conn.SetDeadline(time.Now().Add(time.Second))
+	if err := c.client.Quit(); err != nil {
+		_ = c.client.Close()
+	}
+}
+
+// dialSMTP connects to the SMTP server of a configuration, starts TLS when
+// the server offers it and authenticates with the configured account
+func dialSMTP(ctx context.Context, config *SMTPConfig) (*smtpConn, error) {
+	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
+	dialer := &net.Dialer{}
+	var conn net.Conn
+	var err error
+	if config.UseTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: config.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	setSMTPDeadline(ctx, conn)
+
+	client, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to greet SMTP server: %w", err)
+	}
+	c := &smtpConn{conn: conn, client: client}
+
+	if !config.UseTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: config.Host}); err != nil {
+				c.close()
+				return nil, fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+
+	if config.Username != "" && config.Username != "<nil>" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", config.Username, config.Password, config.Host)
+			if err := client.Auth(auth); err != nil {
+				c.close()
+				return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// setSMTPDeadline bounds the commands on a connection by the context deadline
+func setSMTPDeadline(ctx context.Context, conn net.Conn) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+}
@@ -0,0 +1,276 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// statuspageURL is the endpoint of the Statuspage REST API
+const statuspageURL = "https://api.statuspage.io/v1"
+
+// StatuspageService implements MessageSender for Statuspage channels. Every
+// message opens an incident on the page of the channel, or updates the
+// incident it names, and sets the status of the affected components.
+type StatuspageService struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewStatuspageService creates a new Statuspage service
+func NewStatuspageService(timeout time.Duration) *StatuspageService {
+	return &StatuspageService{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		timeout: timeout,
+	}
+}
+
+// StatuspageConfig holds Statuspage configuration
+type StatuspageConfig struct {
+	APIKey               string
+	PageID               string
+	BaseURL              string
+	DeliverNotifications bool
+}
+
+// StatuspageIncidentRequest represents a request opening or updating an incident
+type StatuspageIncidentRequest struct {
+	Incident StatuspageIncidentFields `json:"incident"`
+}
+
+// StatuspageIncidentFields represents the fields of an incident
+type StatuspageIncidentFields struct {
+	Name                 string            `json:"name,omitempty"`
+	Status               string            `json:"status"`
+	Body                 string            `json:"body,omitempty"`
+	ComponentIDs         []string          `json:"component_ids,omitempty"`
+	Components           map[string]string `json:"components,omitempty"`
+	DeliverNotifications bool              `json:"deliver_notifications"`
+}
+
+// StatuspageIncidentResponse represents the incident the API answers with
+type StatuspageIncidentResponse struct {
+	ID        string `json:"id"`
+	Shortlink string `json:"shortlink"`
+	Status    string `json:"status"`
+}
+
+// StatuspageErrorResponse represents the error of a refused request, a
+// message or a list of messages
+type StatuspageErrorResponse struct {
+	Error interface{} `json:"error"`
+}
+
+// reason joins the messages of a refused request
+func (r *StatuspageErrorResponse) reason() string {
+	switch v := r.Error.(type) {
+	case string:
+		return v
+	case []interface{}:
+		reasons := make([]string, 0, len(v))
+		for _, item := range v {
+			reasons = append(reasons, fmt.Sprintf("%v", item))
+		}
+		return strings.Join(reasons, "; ")
+	}
+	return ""
+}
+
+// Send sends an incident to Statuspage
+func (s *StatuspageService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to post Statuspage incident: %w", result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients opens or updates the incident of the message and reports
+// its outcome. The ID of the incident is the provider message ID, which later
+// messages set as statuspage_incident_id to update the incident.
+func (s *StatuspageService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeStatuspage) {
+		return nil, fmt.Errorf("invalid channel type for Statuspage service: %s", ch.ChannelType().String())
+	}
+
+	// Extract Statuspage configuration
+	config, err := s.extractStatuspageConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Statuspage config: %w", err)
+	}
+
+	incident := content.StatuspageIncident
+	if incident == nil {
+		return nil, errors.New("message has no Statuspage incident")
+	}
+
+	result := &RecipientResult{Target: config.PageID}
+	response, err := s.postIncident(ctx, config, incident)
+	if err != nil {
+		result.Error = err
+		return []*RecipientResult{result}, nil
+	}
+
+	result.Success = true
+	result.ProviderMessageID = response.ID
+	result.SentAt = time.Now().UnixMilli()
+	result.Details = map[string]string{
+		"incident_id":     response.ID,
+		"incident_status": response.Status,
+	}
+	if response.Shortlink != "" {
+		result.Details["incident_url"] = response.Shortlink
+	}
+	return []*RecipientResult{result}, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the
+// Statuspage API in the idle pool of the client.
+func (s *StatuspageService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeStatuspage) {
+		return nil
+	}
+
+	config, err := s.extractStatuspageConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract Statuspage config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, config.BaseURL)
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *StatuspageService) CloseIdle(before time.Time) int {
+	return 0
+}
+
+// GetChannelType returns the supported channel type
+func (s *StatuspageService) GetChannelType() string {
+	return shared.ChannelTypeStatuspage.String()
+}
+
+// ValidateConfig validates Statuspage channel configuration
+func (s *StatuspageService) ValidateConfig(config *channel.ChannelConfig) error {
+	_, err := s.extractStatuspageConfig(config)
+	return err
+}
+
+// extractStatuspageConfig extracts Statuspage configuration from channel config
+func (s *StatuspageService) extractStatuspageConfig(config *channel.ChannelConfig) (*StatuspageConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+
+	statuspageConfig := &StatuspageConfig{
+		APIKey:               str("api_key"),
+		PageID:               str("page_id"),
+		BaseURL:              strings.TrimSuffix(str("base_url"), "/"),
+		DeliverNotifications: str("deliver_notifications") != "false",
+	}
+	if statuspageConfig.APIKey == "" {
+		return nil, errors.New("missing required field: api_key")
+	}
+	if statuspageConfig.PageID == "" {
+		return nil, errors.New("missing required field: page_id")
+	}
+	if statuspageConfig.BaseURL == "" {
+		statuspageConfig.BaseURL = statuspageURL
+	}
+
+	return statuspageConfig, nil
+}
+
+// postIncident opens an incident, or updates the incident with the ID of the
+// message
+func (s *StatuspageService) postIncident(ctx context.Context, config *StatuspageConfig, incident *services.StatuspageIncident) (*StatuspageIncidentResponse, error) {
+	request := StatuspageIncidentRequest{
+		Incident: StatuspageIncidentFields{
+			Name:                 incident.Name,
+			Status:               incident.Status,
+			Body:                 incident.Body,
+			ComponentIDs:         incident.ComponentIDs,
+			DeliverNotifications: config.DeliverNotifications,
+		},
+	}
+	if incident.ComponentStatus != "" {
+		request.Incident.Components = make(map[string]string, len(incident.ComponentIDs))
+		for _, id := range incident.ComponentIDs {
+			request.Incident.Components[id] = incident.ComponentStatus
+		}
+	}
+
+	method := http.MethodPost
+	endpoint := fmt.Sprintf("%s/pages/%s/incidents", config.BaseURL, url.PathEscape(config.PageID))
+	if incident.ID != "" {
+		method = http.MethodPatch
+		endpoint += "/" + url.PathEscape(incident.ID)
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Statuspage incident: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "OAuth "+config.APIKey)
+	setCorrelationHeader(ctx, req.Header)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Statuspage request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		statusErr := &StatusError{Provider: "Statuspage", StatusCode: resp.StatusCode}
+		var statuspageErr StatuspageErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&statuspageErr); err == nil {
+			if reason := statuspageErr.reason(); reason != "" {
+				return nil, fmt.Errorf("%s: %w", reason, statusErr)
+			}
+		}
+		return nil, statusErr
+	}
+
+	var response StatuspageIncidentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode Statuspage response: %w", err)
+	}
+	if response.ID == "" {
+		response.ID = incident.ID
+	}
+	return &response, nil
+}
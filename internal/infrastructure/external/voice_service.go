@@ -0,0 +1,533 @@
+package external
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+const (
+	// twilioAPIURL is the base URL of the Twilio REST API
+	twilioAPIURL = "https://api.twilio.com/2010-04-01"
+
+	// Defaults of the retry policy of voice channels
+	defaultVoiceMaxAttempts   = 3
+	defaultVoiceRetryInterval = time.Minute
+	defaultVoiceRingTimeout   = 30 * time.Second
+
+	// voiceTimeLimit bounds the length of a call
+	voiceTimeLimit = 5 * time.Minute
+	// voicePollInterval is how often the status of a call is checked
+	voicePollInterval = 2 * time.Second
+	// voiceGatherTimeout is how long a call waits for a key press after the text
+	voiceGatherTimeout = 10
+
+	defaultVoiceAckPrompt = "Press any key to acknowledge."
+)
+
+// Statuses of a Twilio call
+const (
+	callStatusInProgress = "in-progress"
+	callStatusCompleted  = "completed"
+	callStatusBusy       = "busy"
+	callStatusNoAnswer   = "no-answer"
+	callStatusFailed     = "failed"
+	callStatusCanceled   = "canceled"
+)
+
+// errCallNotAcknowledged is the failure of a call that ended without a key press
+var errCallNotAcknowledged = errors.New("call was not acknowledged")
+
+// VoiceAckPath returns the path, under the callback URL of a voice channel,
+// where the keys pressed during its calls are posted
+func VoiceAckPath(channelID string) string {
+	return "/api/v1/public/voice/channels/" + url.PathEscape(channelID) + "/ack"
+}
+
+// VoiceService implements MessageSender for voice channels. It calls every
+// recipient through Twilio Voice and reads the content out. A recipient who
+// does not answer is called again, up to the attempts of the channel. When
+// the channel has a callback URL, the recipients acknowledge the call with a
+// key press, which is reported in their result.
+type VoiceService struct {
+	httpClient   *http.Client
+	timeout      time.Duration
+	pollInterval time.Duration
+	acks         message.CallAcknowledgmentRepository
+}
+
+// NewVoiceService creates a new voice service
+func NewVoiceService(timeout time.Duration) *VoiceService {
+	return &VoiceService{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		timeout:      timeout,
+		pollInterval: voicePollInterval,
+	}
+}
+
+// SetAcknowledgments sets where the acknowledgments of the calls are read.
+// Without it calls are not acknowledged.
+func (s *VoiceService) SetAcknowledgments(acks message.CallAcknowledgmentRepository) {
+	s.acks = acks
+}
+
+// VoiceConfig holds voice configuration
+type VoiceConfig struct {
+	AccountSID  string
+	AuthToken   string
+	From        string
+	BaseURL     string
+	CallbackURL string
+	Voice       string
+	Language    string
+	AckPrompt   string
+	// RequireAck calls again when a call ends without a key press
+	RequireAck    bool
+	MaxAttempts   int
+	RetryInterval time.Duration
+	RingTimeout   time.Duration
+}
+
+// Send calls the recipients of a voice channel
+func (s *VoiceService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to call phone number %s: %w", result.Target, result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients calls every phone number at once and reports the outcome of each
+func (s *VoiceService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeVoice) {
+		return nil, fmt.Errorf("invalid channel type for voice service: %s", ch.ChannelType().String())
+	}
+
+	// Extract voice configuration
+	config, err := s.extractVoiceConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract voice config: %w", err)
+	}
+
+	// Prepare phone numbers
+	phoneNumbers := s.preparePhoneNumbers(ch.Recipients())
+	if len(phoneNumbers) == 0 {
+		return nil, fmt.Errorf("no valid phone numbers found")
+	}
+
+	twiml := s.buildTwiML(config, ch.ID().String(), content.Content)
+	results := make([]*RecipientResult, len(phoneNumbers))
+	var wg sync.WaitGroup
+	for i, phoneNumber := range phoneNumbers {
+		wg.Add(1)
+		go func(i int, phoneNumber string) {
+			defer wg.Done()
+			results[i] = s.callRecipient(ctx, config, phoneNumber, twiml)
+		}(i, phoneNumber)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// callRecipient calls a phone number until the call is answered, and
+// acknowledged when the channel requires it, or the attempts run out
+func (s *VoiceService) callRecipient(ctx context.Context, config *VoiceConfig, phoneNumber, twiml string) *RecipientResult {
+	result := &RecipientResult{Target: phoneNumber}
+
+	for attempt := 1; attempt <= config.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				result.Error = ctx.Err()
+				return result
+			case <-time.After(config.RetryInterval):
+			}
+		}
+
+		callSID, err := s.placeCall(ctx, config, phoneNumber, twiml)
+		if err != nil {
+			// The provider refused the call, calling again is left to the send retries
+			result.Error = err
+			return result
+		}
+		result.ProviderMessageID = callSID
+
+		ack, err := s.waitForCall(ctx, config, callSID)
+		if err != nil {
+			result.Error = err
+			// The retry policy of the channel is spent, sending again would
+			// call the recipients who answered too
+			result.ErrorCategory = message.ErrorCategoryPermanent
+			continue
+		}
+
+		result.Success = true
+		result.Error = nil
+		result.ErrorCategory = ""
+		result.SentAt = time.Now().UnixMilli()
+		if ack != nil {
+			result.Acknowledgment = ack.Digits
+			result.AcknowledgedAt = ack.AcknowledgedAt
+		}
+		return result
+	}
+
+	return result
+}
+
+// waitForCall follows a call until it is answered. Calls that can be
+// acknowledged are followed until the key press or their end, and fail when
+// they end without one while the channel requires it.
+func (s *VoiceService) waitForCall(ctx context.Context, config *VoiceConfig, callSID string) (*message.CallAcknowledgment, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.RingTimeout+voiceTimeLimit+time.Minute)
+	defer cancel()
+
+	acknowledgeable := s.acknowledgeable(config)
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("call %s did not end in time: %w", callSID, ctx.Err())
+		case <-ticker.C:
+		}
+
+		status, err := s.callStatus(ctx, config, callSID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status {
+		case callStatusBusy, callStatusNoAnswer, callStatusFailed, callStatusCanceled:
+			return nil, fmt.Errorf("call %s ended as %s", callSID, status)
+		case callStatusInProgress, callStatusCompleted:
+			if !acknowledgeable {
+				return nil, nil
+			}
+			ack, err := s.acks.FindByCallSID(ctx, callSID)
+			if err != nil {
+				return nil, err
+			}
+			if ack != nil {
+				return ack, nil
+			}
+			if status == callStatusCompleted {
+				if config.RequireAck {
+					return nil, errCallNotAcknowledged
+				}
+				return nil, nil
+			}
+		}
+	}
+}
+
+// acknowledgeable checks if the calls of a channel ask for a key press
+func (s *VoiceService) acknowledgeable(config *VoiceConfig) bool {
+	return s.acks != nil && config.CallbackURL != ""
+}
+
+// twilioCall is the part of a Twilio call resource the service reads
+type twilioCall struct {
+	SID    string `json:"sid"`
+	Status string `json:"status"`
+}
+
+// placeCall starts a call reading out the TwiML
+func (s *VoiceService) placeCall(ctx context.Context, config *VoiceConfig, phoneNumber, twiml string) (string, error) {
+	form := url.Values{
+		"To":        {phoneNumber},
+		"From":      {config.From},
+		"Twiml":     {twiml},
+		"Timeout":   {strconv.Itoa(int(config.RingTimeout.Seconds()))},
+		"TimeLimit": {strconv.Itoa(int(voiceTimeLimit.Seconds()))},
+	}
+
+	var call twilioCall
+	path := "/Accounts/" + url.PathEscape(config.AccountSID) + "/Calls.json"
+	if err := s.twilioRequest(ctx, config, http.MethodPost, path, form, &call); err != nil {
+		return "", err
+	}
+	if call.SID == "" {
+		return "", fmt.Errorf("voice provider returned no call SID")
+	}
+	return call.SID, nil
+}
+
+// callStatus returns the status of a call
+func (s *VoiceService) callStatus(ctx context.Context, config *VoiceConfig, callSID string) (string, error) {
+	var call twilioCall
+	path := "/Accounts/" + url.PathEscape(config.AccountSID) + "/Calls/" + url.PathEscape(callSID) + ".json"
+	if err := s.twilioRequest(ctx, config, http.MethodGet, path, nil, &call); err != nil {
+		return "", err
+	}
+	return call.Status, nil
+}
+
+// twilioRequest sends a request to the Twilio REST API and decodes its answer
+func (s *VoiceService) twilioRequest(ctx context.Context, config *VoiceConfig, method, path string, form url.Values, out interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(config.BaseURL, "/")+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(config.AccountSID, config.AuthToken)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{Provider: "voice", StatusCode: resp.StatusCode}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode voice provider response: %w", err)
+	}
+	return nil
+}
+
+// buildTwiML builds the instructions of a call. Calls that can be
+// acknowledged read the text inside a Gather, which posts the key pressed to
+// the callback URL of the channel.
+func (s *VoiceService) buildTwiML(config *VoiceConfig, channelID, text string) string {
+	say := func(text string) string {
+		var b strings.Builder
+		b.WriteString("<Say")
+		if config.Voice != "" {
+			b.WriteString(` voice="` + escapeXML(config.Voice) + `"`)
+		}
+		if config.Language != "" {
+			b.WriteString(` language="` + escapeXML(config.Language) + `"`)
+		}
+		b.WriteString(">" + escapeXML(text) + "</Say>")
+		return b.String()
+	}
+
+	if !s.acknowledgeable(config) {
+		return "<Response>" + say(text) + "</Response>"
+	}
+
+	action := strings.TrimRight(config.CallbackURL, "/") + VoiceAckPath(channelID)
+	return fmt.Sprintf(`<Response><Gather input="dtmf" numDigits="1" timeout="%d" action="%s" method="POST">%s%s</Gather></Response>`,
+		voiceGatherTimeout, escapeXML(action), say(text), say(config.AckPrompt))
+}
+
+// escapeXML escapes text for an XML element or attribute
+func escapeXML(text string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
+
+// VerifyCallback checks that a request to the callback URL of a voice
+// channel comes from Twilio
+func (s *VoiceService) VerifyCallback(ch *channel.Channel, params url.Values, signature string) error {
+	config, err := s.extractVoiceConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract voice config: %w", err)
+	}
+	if config.CallbackURL == "" {
+		return errors.New("voice channel has no callback URL")
+	}
+
+	callbackURL := strings.TrimRight(config.CallbackURL, "/") + VoiceAckPath(ch.ID().String())
+	return verifyTwilioSignature(callbackURL, params, config.AuthToken, signature)
+}
+
+// verifyTwilioSignature checks that a request to a callback URL comes from
+// Twilio: its signature is the HMAC-SHA1, keyed with the auth token, of the
+// URL followed by the sorted names and values of the posted parameters
+func verifyTwilioSignature(callbackURL string, params url.Values, authToken, signature string) error {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var data strings.Builder
+	data.WriteString(callbackURL)
+	for _, key := range keys {
+		for _, value := range params[key] {
+			data.WriteString(key)
+			data.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data.String()))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("invalid callback signature")
+	}
+	return nil
+}
+
+// GetChannelType returns the supported channel type
+func (s *VoiceService) GetChannelType() string {
+	return shared.ChannelTypeVoice.String()
+}
+
+// ValidateConfig validates voice channel configuration
+func (s *VoiceService) ValidateConfig(config *channel.ChannelConfig) error {
+	requiredFields := map[string]string{
+		"provider":    "voice provider (twilio)",
+		"account_sid": "account SID",
+		"auth_token":  "auth token",
+		"from_number": "caller phone number",
+	}
+
+	for field, description := range requiredFields {
+		value, exists := config.Get(field)
+		if !exists {
+			return fmt.Errorf("missing required field: %s (%s)", field, description)
+		}
+		if value == nil || value == "" {
+			return fmt.Errorf("empty required field: %s (%s)", field, description)
+		}
+	}
+
+	if provider, _ := config.Get("provider"); strings.ToLower(fmt.Sprintf("%v", provider)) != "twilio" {
+		return fmt.Errorf("unsupported voice provider: %v. Supported providers: [twilio]", provider)
+	}
+
+	voiceConfig, err := s.extractVoiceConfig(config)
+	if err != nil {
+		return err
+	}
+	if voiceConfig.RequireAck && voiceConfig.CallbackURL == "" {
+		return errors.New("require_ack needs a callback_url where the calls are acknowledged")
+	}
+
+	return nil
+}
+
+// extractVoiceConfig extracts voice configuration from channel config
+func (s *VoiceService) extractVoiceConfig(config *channel.ChannelConfig) (*VoiceConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+	number := func(key string, fallback int) (int, error) {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return fallback, nil
+		}
+		var n int
+		switch v := value.(type) {
+		case float64:
+			n = int(v)
+		case int:
+			n = v
+		case string:
+			var err error
+			if n, err = strconv.Atoi(v); err != nil {
+				return 0, fmt.Errorf("invalid %s: %s", key, v)
+			}
+		default:
+			return 0, fmt.Errorf("unsupported %s type: %T", key, v)
+		}
+		if n < 1 {
+			return 0, fmt.Errorf("%s must be positive", key)
+		}
+		return n, nil
+	}
+
+	voiceConfig := &VoiceConfig{
+		AccountSID:  str("account_sid"),
+		AuthToken:   str("auth_token"),
+		From:        str("from_number"),
+		BaseURL:     str("base_url"),
+		CallbackURL: str("callback_url"),
+		Voice:       str("voice"),
+		Language:    str("language"),
+		AckPrompt:   str("ack_prompt"),
+	}
+	if voiceConfig.BaseURL == "" {
+		voiceConfig.BaseURL = twilioAPIURL
+	}
+	if voiceConfig.AckPrompt == "" {
+		voiceConfig.AckPrompt = defaultVoiceAckPrompt
+	}
+	if requireAck, ok := config.Get("require_ack"); ok {
+		switch v := requireAck.(type) {
+		case bool:
+			voiceConfig.RequireAck = v
+		case string:
+			voiceConfig.RequireAck = strings.ToLower(v) == "true"
+		}
+	}
+
+	attempts, err := number("max_attempts", defaultVoiceMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	retryInterval, err := number("retry_interval", int(defaultVoiceRetryInterval.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	ringTimeout, err := number("ring_timeout", int(defaultVoiceRingTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	voiceConfig.MaxAttempts = attempts
+	voiceConfig.RetryInterval = time.Duration(retryInterval) * time.Second
+	voiceConfig.RingTimeout = time.Duration(ringTimeout) * time.Second
+
+	return voiceConfig, nil
+}
+
+// preparePhoneNumbers prepares phone numbers from channel recipients
+func (s *VoiceService) preparePhoneNumbers(recipients *channel.Recipients) []string {
+	phoneNumbers := make([]string, 0)
+	for _, recipient := range recipients.ToSlice() {
+		var cleaned strings.Builder
+		for _, char := range recipient.Target {
+			if char >= '0' && char <= '9' || char == '+' {
+				cleaned.WriteRune(char)
+			}
+		}
+		if number := cleaned.String(); len(number) >= 10 && len(number) <= 16 {
+			phoneNumbers = append(phoneNumbers, number)
+		}
+	}
+	return phoneNumbers
+}
@@ -0,0 +1,164 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+const (
+	// changeWebhookQueueSize is the number of changes waiting for delivery
+	// beyond which new changes are dropped
+	changeWebhookQueueSize = 1000
+	// changeWebhookAttempts is the number of times a change is posted to a
+	// webhook before it is given up
+	changeWebhookAttempts = 3
+	// ChangeSignatureHeader carries the hex HMAC-SHA256 of the body, keyed
+	// with the webhook secret, when a secret is configured
+	ChangeSignatureHeader = "X-Signature-SHA256"
+	// ChangeTypeHeader carries the type of the posted change
+	ChangeTypeHeader = "X-Change-Type"
+)
+
+// WebhookChangeNotifier posts the channel and template changes to webhooks.
+// The changes are queued and posted in order in the background, so that a
+// slow webhook does not hold up the change; the queue drops changes when full.
+type WebhookChangeNotifier struct {
+	urls       []string
+	secret     string
+	httpClient *http.Client
+	logger     *logger.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *shared.Change
+	done   chan struct{}
+}
+
+// NewWebhookChangeNotifier creates a notifier posting to the given URLs. A
+// non-empty secret signs the posted bodies.
+func NewWebhookChangeNotifier(urls []string, secret string, timeout time.Duration, log *logger.Logger) *WebhookChangeNotifier {
+	return &WebhookChangeNotifier{
+		urls:       urls,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     log.WithComponent("change_webhooks"),
+		queue:      make(chan *shared.Change, changeWebhookQueueSize),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start posts the queued changes in the background
+func (n *WebhookChangeNotifier) Start() {
+	go func() {
+		defer close(n.done)
+		for change := range n.queue {
+			n.deliver(change)
+		}
+	}()
+	n.logger.Info("Change webhooks started", zap.Int("webhooks", len(n.urls)))
+}
+
+// Stop stops queueing changes and waits for the queued ones to be posted,
+// up to the context deadline
+func (n *WebhookChangeNotifier) Stop(ctx context.Context) error {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+
+	select {
+	case <-n.done:
+		n.logger.Info("Change webhooks stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("change webhooks stopped with changes left: %w", ctx.Err())
+	}
+}
+
+// Changed implements shared.ChangeNotifier. Changes made after Stop are dropped.
+func (n *WebhookChangeNotifier) Changed(ctx context.Context, change *shared.Change) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.closed {
+		return
+	}
+
+	select {
+	case n.queue <- change:
+	default:
+		n.logger.WithContext(ctx).Warn("Change webhook queue is full, dropping change",
+			zap.String("type", change.Type),
+			zap.String("resource_id", change.ResourceID))
+	}
+}
+
+// deliver posts a change to every webhook
+func (n *WebhookChangeNotifier) deliver(change *shared.Change) {
+	body, err := json.Marshal(change)
+	if err != nil {
+		n.logger.Error("Failed to marshal change", zap.String("type", change.Type), zap.Error(err))
+		return
+	}
+
+	for _, url := range n.urls {
+		var err error
+		for attempt := 1; attempt <= changeWebhookAttempts; attempt++ {
+			if err = n.post(url, change.Type, body); err == nil {
+				break
+			}
+			if attempt < changeWebhookAttempts {
+				time.Sleep(time.Duration(attempt) * time.Second)
+			}
+		}
+		if err != nil {
+			n.logger.Warn("Failed to post change to webhook",
+				zap.String("url", url),
+				zap.String("type", change.Type),
+				zap.String("resource_id", change.ResourceID),
+				zap.Error(err))
+		}
+	}
+}
+
+// post posts a change body to a webhook
+func (n *WebhookChangeNotifier) post(url, changeType string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ChangeTypeHeader, changeType)
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		req.Header.Set(ChangeSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post change: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{Provider: "change webhook", StatusCode: resp.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"notification/internal/domain/message"
+	"notification/internal/domain/services"
+)
+
+// PolicyHookWebhook is the name of the policy hook asking a webhook
+const PolicyHookWebhook = "webhook"
+
+// WebhookPolicyHook is the policy hook posting each send to an external
+// moderation service, which answers with its decision and, to modify the
+// send, the subject and content to send instead.
+type WebhookPolicyHook struct {
+	url        string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewWebhookPolicyHook creates a policy hook posting to url. A non-empty
+// secret signs the posted bodies in X-Signature-SHA256.
+func NewWebhookPolicyHook(url, secret string, timeout time.Duration) *WebhookPolicyHook {
+	return &WebhookPolicyHook{
+		url:        url,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// PolicyWebhookRequest is the send posted to the moderation service
+type PolicyWebhookRequest struct {
+	MessageID   string `json:"messageId"`
+	TenantID    string `json:"tenantId"`
+	ChannelID   string `json:"channelId"`
+	ChannelType string `json:"channelType"`
+	Subject     string `json:"subject"`
+	Content     string `json:"content"`
+}
+
+// PolicyWebhookResponse is the decision of the moderation service. Subject
+// and Content replace those of the send when the action is modify.
+type PolicyWebhookResponse struct {
+	Action      message.PolicyAction `json:"action"`
+	Reason      string               `json:"reason"`
+	Annotations map[string]string    `json:"annotations"`
+	Subject     *string              `json:"subject"`
+	Content     *string              `json:"content"`
+}
+
+// Name implements services.PolicyHook
+func (h *WebhookPolicyHook) Name() string {
+	return PolicyHookWebhook
+}
+
+// Check implements services.PolicyHook
+func (h *WebhookPolicyHook) Check(ctx context.Context, send *services.PolicySend) (*services.PolicyVerdict, error) {
+	body, err := json.Marshal(&PolicyWebhookRequest{
+		MessageID:   send.MessageID,
+		TenantID:    send.TenantID,
+		ChannelID:   send.Channel.ID().String(),
+		ChannelType: send.Channel.ChannelType().String(),
+		Subject:     send.Content.Subject,
+		Content:     send.Content.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.secret != "" {
+		mac := hmac.New(sha256.New, []byte(h.secret))
+		mac.Write(body)
+		req.Header.Set(ChangeSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach policy webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{Provider: "policy webhook", StatusCode: resp.StatusCode}
+	}
+
+	var decision PolicyWebhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return nil, fmt.Errorf("failed to decode policy response: %w", err)
+	}
+
+	switch decision.Action {
+	case message.PolicyActionAllow, message.PolicyActionAnnotate, message.PolicyActionBlock:
+	case message.PolicyActionModify:
+		if decision.Subject != nil {
+			send.Content.Subject = *decision.Subject
+		}
+		if decision.Content != nil {
+			send.Content.Content = *decision.Content
+		}
+	default:
+		return nil, fmt.Errorf("policy webhook answered an unknown action %q", decision.Action)
+	}
+	return &services.PolicyVerdict{
+		Action:      decision.Action,
+		Reason:      decision.Reason,
+		Annotations: decision.Annotations,
+	}, nil
+}
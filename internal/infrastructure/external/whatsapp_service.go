@@ -0,0 +1,294 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// whatsAppAPIURL is the base URL of the WhatsApp Business Cloud API
+const whatsAppAPIURL = "https://graph.facebook.com/v21.0"
+
+// whatsAppReengagementCode is the error of a session message sent more than
+// 24 hours after the last message of the recipient
+const whatsAppReengagementCode = 131047
+
+// WhatsAppService implements MessageSender for WhatsApp channels. A message
+// whose template is mapped to a pre-approved WhatsApp template is sent as
+// that template, filled with the variables of the message; other messages
+// are sent as session messages carrying the rendered content, which WhatsApp
+// only delivers within 24 hours of the last message of the recipient.
+type WhatsAppService struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewWhatsAppService creates a new WhatsApp service
+func NewWhatsAppService(timeout time.Duration) *WhatsAppService {
+	return &WhatsAppService{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		timeout: timeout,
+	}
+}
+
+// WhatsAppConfig holds WhatsApp configuration
+type WhatsAppConfig struct {
+	AccessToken   string
+	PhoneNumberID string
+	BaseURL       string
+}
+
+// Send sends a WhatsApp message
+func (s *WhatsAppService) Send(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) error {
+	results, err := s.SendToRecipients(ctx, ch, content)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to send to WhatsApp number %s: %w", result.Target, result.Error)
+		}
+	}
+
+	return nil
+}
+
+// SendToRecipients sends a WhatsApp message to every phone number and reports the outcome of each
+func (s *WhatsAppService) SendToRecipients(ctx context.Context, ch *channel.Channel, content *services.RenderedContent) ([]*RecipientResult, error) {
+	// Validate channel type
+	if !ch.ChannelType().Equals(shared.ChannelTypeWhatsApp) {
+		return nil, fmt.Errorf("invalid channel type for WhatsApp service: %s", ch.ChannelType().String())
+	}
+
+	// Extract WhatsApp configuration
+	config, err := s.extractWhatsAppConfig(ch.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract WhatsApp config: %w", err)
+	}
+
+	// Prepare phone numbers
+	phoneNumbers := s.preparePhoneNumbers(ch.Recipients())
+	if len(phoneNumbers) == 0 {
+		return nil, fmt.Errorf("no valid phone numbers found")
+	}
+
+	// Send to all phone numbers
+	results := make([]*RecipientResult, 0, len(phoneNumbers))
+	for _, phoneNumber := range phoneNumbers {
+		messageID, err := s.sendToPhoneNumber(ctx, config, phoneNumber, content)
+		results = append(results, &RecipientResult{
+			Target:            phoneNumber,
+			Success:           err == nil,
+			ProviderMessageID: messageID,
+			Error:             err,
+			SentAt:            time.Now().UnixMilli(),
+		})
+	}
+
+	return results, nil
+}
+
+// WarmUp implements ProviderWarmer. It keeps the connection to the WhatsApp
+// API in the idle pool of the client.
+func (s *WhatsAppService) WarmUp(ctx context.Context, ch *channel.Channel) error {
+	if !ch.ChannelType().Equals(shared.ChannelTypeWhatsApp) {
+		return nil
+	}
+
+	config, err := s.extractWhatsAppConfig(ch.Config())
+	if err != nil {
+		return fmt.Errorf("failed to extract WhatsApp config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return warmHTTP(ctx, s.httpClient, config.BaseURL)
+}
+
+// CloseIdle implements ProviderWarmer. The HTTP client closes its own idle
+// connections.
+func (s *WhatsAppService) CloseIdle(before time.Time) int {
+	return 0
+}
+
+// GetChannelType returns the supported channel type
+func (s *WhatsAppService) GetChannelType() string {
+	return shared.ChannelTypeWhatsApp.String()
+}
+
+// ValidateConfig validates WhatsApp channel configuration
+func (s *WhatsAppService) ValidateConfig(config *channel.ChannelConfig) error {
+	requiredFields := map[string]string{
+		"access_token":    "access token",
+		"phone_number_id": "business phone number ID",
+	}
+
+	for field, description := range requiredFields {
+		value, exists := config.Get(field)
+		if !exists {
+			return fmt.Errorf("missing required field: %s (%s)", field, description)
+		}
+		if value == nil || value == "" {
+			return fmt.Errorf("empty required field: %s (%s)", field, description)
+		}
+	}
+
+	if _, err := services.WhatsAppTemplateMappings(config); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// extractWhatsAppConfig extracts WhatsApp configuration from channel config
+func (s *WhatsAppService) extractWhatsAppConfig(config *channel.ChannelConfig) (*WhatsAppConfig, error) {
+	str := func(key string) string {
+		value, ok := config.Get(key)
+		if !ok || value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+
+	whatsAppConfig := &WhatsAppConfig{
+		AccessToken:   str("access_token"),
+		PhoneNumberID: str("phone_number_id"),
+		BaseURL:       str("base_url"),
+	}
+	if whatsAppConfig.AccessToken == "" || whatsAppConfig.PhoneNumberID == "" {
+		return nil, fmt.Errorf("access_token and phone_number_id are required")
+	}
+	if whatsAppConfig.BaseURL == "" {
+		whatsAppConfig.BaseURL = whatsAppAPIURL
+	}
+
+	return whatsAppConfig, nil
+}
+
+// preparePhoneNumbers prepares phone numbers from channel recipients. The
+// API takes them in international format, without the leading '+'.
+func (s *WhatsAppService) preparePhoneNumbers(recipients *channel.Recipients) []string {
+	phoneNumbers := make([]string, 0)
+	for _, recipient := range recipients.ToSlice() {
+		var cleaned strings.Builder
+		for _, char := range recipient.Target {
+			if char >= '0' && char <= '9' {
+				cleaned.WriteRune(char)
+			}
+		}
+		if number := cleaned.String(); len(number) >= 8 && len(number) <= 15 {
+			phoneNumbers = append(phoneNumbers, number)
+		}
+	}
+	return phoneNumbers
+}
+
+// whatsAppParameter is a parameter of a component of a WhatsApp template
+type whatsAppParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// whatsAppComponent is a component of a WhatsApp template
+type whatsAppComponent struct {
+	Type       string              `json:"type"`
+	Parameters []whatsAppParameter `json:"parameters"`
+}
+
+// sendToPhoneNumber sends the mapped template, or a session message when
+// there is none, and returns the WhatsApp message ID
+func (s *WhatsAppService) sendToPhoneNumber(ctx context.Context, config *WhatsAppConfig, phoneNumber string, content *services.RenderedContent) (string, error) {
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                phoneNumber,
+	}
+
+	if tmpl := content.WhatsAppTemplate; tmpl != nil {
+		template := map[string]interface{}{
+			"name":     tmpl.Name,
+			"language": map[string]string{"code": tmpl.Language},
+		}
+		if len(tmpl.Parameters) > 0 {
+			body := whatsAppComponent{Type: "body"}
+			for _, parameter := range tmpl.Parameters {
+				body.Parameters = append(body.Parameters, whatsAppParameter{Type: "text", Text: parameter})
+			}
+			template["components"] = []whatsAppComponent{body}
+		}
+		payload["type"] = "template"
+		payload["template"] = template
+	} else {
+		payload["type"] = "text"
+		payload["text"] = map[string]interface{}{
+			"preview_url": false,
+			"body":        content.Content,
+		}
+	}
+
+	return s.sendHTTPRequest(ctx, config, payload)
+}
+
+// sendHTTPRequest posts a message to the WhatsApp API and returns its message ID
+func (s *WhatsAppService) sendHTTPRequest(ctx context.Context, config *WhatsAppConfig, payload interface{}) (string, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal WhatsApp payload: %w", err)
+	}
+
+	endpoint := strings.TrimRight(config.BaseURL, "/") + "/" + url.PathEscape(config.PhoneNumberID) + "/messages"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+config.AccessToken)
+	setCorrelationHeader(ctx, req.Header)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send WhatsApp request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Messages []struct {
+			ID string `json:"id"`
+		} `json:"messages"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if body.Error == nil {
+			return "", &StatusError{Provider: "WhatsApp", StatusCode: resp.StatusCode}
+		}
+		apiErr := &WhatsAppAPIError{StatusCode: resp.StatusCode, Code: body.Error.Code, Message: body.Error.Message}
+		if apiErr.Code == whatsAppReengagementCode {
+			return "", fmt.Errorf("session message outside the 24 hour customer service window, map the template to a WhatsApp template: %w", apiErr)
+		}
+		return "", apiErr
+	}
+
+	if len(body.Messages) == 0 {
+		return "", nil
+	}
+	return body.Messages[0].ID, nil
+}
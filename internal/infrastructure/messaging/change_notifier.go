@@ -0,0 +1,41 @@
+package messaging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// ChangeSubjectPrefix prefixes the subjects carrying the channel and template
+// changes, one per change type, e.g. changes.channel.updated. Subscribers
+// take every change with changes.> or those of one resource with changes.channel.*
+const ChangeSubjectPrefix = "changes"
+
+// NATSChangeNotifier publishes the channel and template changes over NATS so
+// that dependent services and caches can react to them
+type NATSChangeNotifier struct {
+	client *NATSClient
+	logger *logger.Logger
+}
+
+// NewNATSChangeNotifier creates a change notifier
+func NewNATSChangeNotifier(client *NATSClient, logger *logger.Logger) *NATSChangeNotifier {
+	return &NATSChangeNotifier{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Changed implements shared.ChangeNotifier. A failed publish is logged, it
+// does not affect the change.
+func (n *NATSChangeNotifier) Changed(ctx context.Context, change *shared.Change) {
+	if err := n.client.Publish(ChangeSubjectPrefix+"."+change.Type, change); err != nil {
+		n.logger.WithContext(ctx).Warn("Failed to publish change event",
+			zap.String("type", change.Type),
+			zap.String("resource_id", change.ResourceID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,68 @@
+package messaging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/channel"
+	"notification/pkg/logger"
+)
+
+// ChannelHealthSubject carries the channel health status changes
+const ChannelHealthSubject = "channel.health"
+
+// ChannelHealthEvent is the payload published on ChannelHealthSubject
+type ChannelHealthEvent struct {
+	ChannelID      string  `json:"channelId"`
+	ChannelName    string  `json:"channelName"`
+	Owner          string  `json:"owner,omitempty"`
+	Team           string  `json:"team,omitempty"`
+	Status         string  `json:"status"`
+	PreviousStatus string  `json:"previousStatus"`
+	Enabled        bool    `json:"enabled"`
+	SuccessCount   int     `json:"successCount"`
+	FailureCount   int     `json:"failureCount"`
+	FailureRate    float64 `json:"failureRate"`
+	CheckedAt      int64   `json:"checkedAt"`
+}
+
+// NATSChannelHealthNotifier publishes channel health changes over NATS so that
+// alerting can subscribe to them
+type NATSChannelHealthNotifier struct {
+	client *NATSClient
+	logger *logger.Logger
+}
+
+// NewNATSChannelHealthNotifier creates a channel health notifier
+func NewNATSChannelHealthNotifier(client *NATSClient, logger *logger.Logger) *NATSChannelHealthNotifier {
+	return &NATSChannelHealthNotifier{
+		client: client,
+		logger: logger,
+	}
+}
+
+// ChannelHealthChanged implements services.ChannelHealthNotifier. A failed
+// publish is logged, it does not affect the delivery.
+func (n *NATSChannelHealthNotifier) ChannelHealthChanged(ctx context.Context, ch *channel.Channel, previous channel.HealthStatus) {
+	health := ch.Health()
+	event := ChannelHealthEvent{
+		ChannelID:      ch.ID().String(),
+		ChannelName:    ch.Name().String(),
+		Owner:          ch.Ownership().Owner,
+		Team:           ch.Ownership().Team,
+		Status:         string(health.Status),
+		PreviousStatus: string(previous),
+		Enabled:        ch.IsEnabled(),
+		SuccessCount:   health.Successes,
+		FailureCount:   health.Failures,
+		FailureRate:    health.FailureRate(),
+		CheckedAt:      health.CheckedAt,
+	}
+
+	if err := n.client.Publish(ChannelHealthSubject, event); err != nil {
+		n.logger.WithContext(ctx).Warn("Failed to publish channel health event",
+			zap.String("channel_id", event.ChannelID),
+			zap.Error(err))
+	}
+}
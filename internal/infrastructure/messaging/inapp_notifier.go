@@ -0,0 +1,79 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	"notification/internal/domain/inapp"
+	"notification/pkg/logger"
+)
+
+// InAppNotificationSubject carries the notifications the in-app channels store
+const InAppNotificationSubject = "inapp.notification"
+
+// InAppNotificationEvent is the payload published on InAppNotificationSubject.
+// Workers store the notifications, every API instance receives the event and
+// pushes it to the clients of the user connected to it.
+type InAppNotificationEvent struct {
+	ID            string `json:"id"`
+	UserID        string `json:"userId"`
+	ChannelID     string `json:"channelId"`
+	Category      string `json:"category,omitempty"`
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	CorrelationID string `json:"correlationId,omitempty"`
+	CreatedAt     int64  `json:"createdAt"`
+	// UnreadCount is the unread count of the user once the notification is stored
+	UnreadCount int `json:"unreadCount"`
+}
+
+// NATSInAppNotifier publishes the new in-app notifications over NATS
+type NATSInAppNotifier struct {
+	client *NATSClient
+	logger *logger.Logger
+}
+
+// NewNATSInAppNotifier creates an in-app notifier
+func NewNATSInAppNotifier(client *NATSClient, logger *logger.Logger) *NATSInAppNotifier {
+	return &NATSInAppNotifier{
+		client: client,
+		logger: logger,
+	}
+}
+
+// NotificationCreated implements inapp.Notifier. A failed publish is logged,
+// the notification stays stored for the clients to list.
+func (n *NATSInAppNotifier) NotificationCreated(ctx context.Context, notification *inapp.Notification, unreadCount int) {
+	event := InAppNotificationEvent{
+		ID:            notification.ID,
+		UserID:        notification.UserID,
+		ChannelID:     notification.ChannelID,
+		Category:      notification.Category,
+		Title:         notification.Title,
+		Content:       notification.Content,
+		CorrelationID: notification.CorrelationID,
+		CreatedAt:     notification.CreatedAt.UnixMilli(),
+		UnreadCount:   unreadCount,
+	}
+	if err := n.client.Publish(InAppNotificationSubject, event); err != nil {
+		n.logger.WithContext(ctx).Warn("Failed to publish in-app notification event",
+			zap.String("notification_id", event.ID),
+			zap.Error(err))
+	}
+}
+
+// SubscribeInAppNotifications hands every in-app notification event to handle.
+// Each instance subscribes on its own, the events are not load balanced.
+func SubscribeInAppNotifications(client *NATSClient, logger *logger.Logger, handle func(event *InAppNotificationEvent)) (*nats.Subscription, error) {
+	return client.Subscribe(InAppNotificationSubject, func(msg *nats.Msg) {
+		var event InAppNotificationEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			logger.Warn("Failed to decode in-app notification event", zap.Error(err))
+			return
+		}
+		handle(&event)
+	})
+}
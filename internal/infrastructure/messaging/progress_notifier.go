@@ -0,0 +1,125 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/message"
+	"notification/pkg/logger"
+)
+
+// MessageProgressSubject carries the progress of the message deliveries
+const MessageProgressSubject = "message.progress"
+
+// Kinds of MessageProgressEvent
+const (
+	// MessageProgressChannel reports the delivery through one channel
+	MessageProgressChannel = "channel"
+	// MessageProgressCompleted reports the delivery through every channel
+	MessageProgressCompleted = "completed"
+)
+
+// MessageProgressEvent is the payload published on MessageProgressSubject.
+// A message sent with async set is answered before it is delivered; its
+// sender follows the delivery with these events, matching the messageId.
+type MessageProgressEvent struct {
+	MessageID     string `json:"messageId"`
+	CorrelationID string `json:"correlationId,omitempty"`
+	TenantID      string `json:"tenantId,omitempty"`
+	// Kind is MessageProgressChannel or MessageProgressCompleted
+	Kind string `json:"kind"`
+	// ChannelID, Status and Error describe the channel of a channel event
+	ChannelID string `json:"channelId,omitempty"`
+	// Status is the result status of a channel event and the message status
+	// of the completed event
+	Status            string `json:"status"`
+	Error             string `json:"error,omitempty"`
+	CompletedChannels int    `json:"completedChannels"`
+	TotalChannels     int    `json:"totalChannels"`
+	SuccessCount      int    `json:"successCount"`
+	FailureCount      int    `json:"failureCount"`
+	Timestamp         int64  `json:"timestamp"`
+}
+
+// NATSMessageProgressNotifier publishes the progress of the message
+// deliveries over NATS
+type NATSMessageProgressNotifier struct {
+	client *NATSClient
+	logger *logger.Logger
+}
+
+// NewNATSMessageProgressNotifier creates a message progress notifier
+func NewNATSMessageProgressNotifier(client *NATSClient, logger *logger.Logger) *NATSMessageProgressNotifier {
+	return &NATSMessageProgressNotifier{
+		client: client,
+		logger: logger,
+	}
+}
+
+// ChannelDelivered implements services.MessageProgressNotifier
+func (n *NATSMessageProgressNotifier) ChannelDelivered(ctx context.Context, msg *message.Message, result *message.MessageResult, completed int) {
+	event := newMessageProgressEvent(msg, MessageProgressChannel, completed)
+	event.ChannelID = result.ChannelID().String()
+	event.Status = string(result.Status())
+	if result.Error() != nil {
+		event.Error = result.Error().Code
+	}
+	n.publish(ctx, event)
+}
+
+// MessageDelivered implements services.MessageProgressNotifier
+func (n *NATSMessageProgressNotifier) MessageDelivered(ctx context.Context, msg *message.Message) {
+	event := newMessageProgressEvent(msg, MessageProgressCompleted, msg.ChannelIDs().Count())
+	event.Status = string(msg.Status())
+	n.publish(ctx, event)
+}
+
+// publish sends an event. A failed publish is logged, it does not affect the
+// delivery.
+func (n *NATSMessageProgressNotifier) publish(ctx context.Context, event MessageProgressEvent) {
+	if err := n.client.Publish(MessageProgressSubject, event); err != nil {
+		n.logger.WithContext(ctx).Warn("Failed to publish message progress event",
+			zap.String("message_id", event.MessageID),
+			zap.String("kind", event.Kind),
+			zap.Error(err))
+	}
+}
+
+// newMessageProgressEvent creates an event counting the results of the
+// message so far. A channel whose send fell back counts once, by the last
+// result of its fallback chain.
+func newMessageProgressEvent(msg *message.Message, kind string, completed int) MessageProgressEvent {
+	event := MessageProgressEvent{
+		MessageID:         msg.ID().String(),
+		CorrelationID:     msg.CorrelationID(),
+		TenantID:          msg.TenantID(),
+		Kind:              kind,
+		CompletedChannels: completed,
+		TotalChannels:     msg.ChannelIDs().Count(),
+		Timestamp:         time.Now().UnixMilli(),
+	}
+
+	origins := make(map[string]string)
+	last := make(map[string]*message.MessageResult)
+	for _, result := range msg.Results() {
+		origin := result.ChannelID().String()
+		if result.IsFallback() {
+			if from, ok := origins[result.FallbackFrom().String()]; ok {
+				origin = from
+			}
+		}
+		origins[result.ChannelID().String()] = origin
+		last[origin] = result
+	}
+	for _, result := range last {
+		switch {
+		case result.IsSuccess():
+			event.SuccessCount++
+		case result.IsFailed():
+			event.FailureCount++
+		}
+	}
+	return event
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/message"
+	"notification/pkg/logger"
+)
+
+// MessageReplySubject carries the replies received to the messages
+const MessageReplySubject = "message.reply"
+
+// MessageReplyEvent is the payload published on MessageReplySubject. The
+// messageId is empty for a reply no reply token matched.
+type MessageReplyEvent struct {
+	ID          string `json:"id"`
+	MessageID   string `json:"messageId,omitempty"`
+	ChannelID   string `json:"channelId,omitempty"`
+	ChannelType string `json:"channelType"`
+	From        string `json:"from"`
+	Subject     string `json:"subject,omitempty"`
+	Body        string `json:"body"`
+	ReceivedAt  int64  `json:"receivedAt"`
+}
+
+// NATSReplyNotifier publishes the replies received over NATS
+type NATSReplyNotifier struct {
+	client *NATSClient
+	logger *logger.Logger
+}
+
+// NewNATSReplyNotifier creates a reply notifier
+func NewNATSReplyNotifier(client *NATSClient, logger *logger.Logger) *NATSReplyNotifier {
+	return &NATSReplyNotifier{
+		client: client,
+		logger: logger,
+	}
+}
+
+// ReplyReceived implements message.ReplyNotifier. A failed publish is logged,
+// the reply stays stored for the API to list.
+func (n *NATSReplyNotifier) ReplyReceived(ctx context.Context, reply *message.Reply) {
+	event := MessageReplyEvent{
+		ID:          reply.ID,
+		MessageID:   reply.MessageID,
+		ChannelID:   reply.ChannelID,
+		ChannelType: reply.ChannelType,
+		From:        reply.From,
+		Subject:     reply.Subject,
+		Body:        reply.Body,
+		ReceivedAt:  reply.ReceivedAt,
+	}
+	if err := n.client.Publish(MessageReplySubject, event); err != nil {
+		n.logger.WithContext(ctx).Warn("Failed to publish message reply event",
+			zap.String("reply_id", event.ID),
+			zap.String("message_id", event.MessageID),
+			zap.Error(err))
+	}
+}
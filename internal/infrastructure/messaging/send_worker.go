@@ -0,0 +1,284 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	"notification/internal/domain/message"
+	"notification/internal/domain/services"
+	"notification/pkg/logger"
+)
+
+const (
+	// DispatchSubject carries accepted messages from the API tier to the send workers
+	DispatchSubject = "message.dispatch"
+	// SendWorkerQueue is the queue group shared by all send workers, so that
+	// every dispatched message is delivered by exactly one of them
+	SendWorkerQueue = "notification-send-workers"
+)
+
+// dispatchRequest is the payload published on DispatchSubject
+type dispatchRequest struct {
+	MessageID string `json:"messageId"`
+}
+
+// dispatchReply acknowledges that a worker took a dispatched message
+type dispatchReply struct {
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
+
+// NATSMessageDispatcher dispatches messages to the send workers over NATS
+type NATSMessageDispatcher struct {
+	client  *NATSClient
+	timeout time.Duration
+}
+
+// NewNATSMessageDispatcher creates a dispatcher that waits up to timeout for a worker to take a message
+func NewNATSMessageDispatcher(client *NATSClient, timeout time.Duration) *NATSMessageDispatcher {
+	return &NATSMessageDispatcher{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// Dispatch implements services.MessageDispatcher. It fails when no worker
+// takes the message in time, leaving it pending.
+func (d *NATSMessageDispatcher) Dispatch(ctx context.Context, msg *message.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	reply, err := d.client.RequestWithContext(ctx, DispatchSubject, dispatchRequest{MessageID: msg.ID().String()})
+	if err != nil {
+		return fmt.Errorf("no send worker took message %s: %w", msg.ID().String(), err)
+	}
+
+	var ack dispatchReply
+	if err := json.Unmarshal(reply.Data, &ack); err != nil {
+		return fmt.Errorf("invalid send worker reply: %w", err)
+	}
+	if !ack.Accepted {
+		return fmt.Errorf("send worker rejected message %s: %s", msg.ID().String(), ack.Error)
+	}
+	return nil
+}
+
+// SendWorker delivers the messages dispatched by the API tier
+type SendWorker struct {
+	client      *NATSClient
+	messageRepo message.MessageRepository
+	sender      *services.EnhancedMessageSender
+	timeout     time.Duration
+	logger      *logger.Logger
+
+	slots    chan struct{}
+	inFlight sync.WaitGroup
+
+	// mu guards the subscription, which is held while the worker runs and is not paused
+	mu      sync.Mutex
+	sub     *nats.Subscription
+	running bool
+	paused  bool
+}
+
+// NewSendWorker creates a send worker delivering up to concurrency messages
+// at a time, each within timeout
+func NewSendWorker(
+	client *NATSClient,
+	messageRepo message.MessageRepository,
+	sender *services.EnhancedMessageSender,
+	concurrency int,
+	timeout time.Duration,
+	log *logger.Logger,
+) *SendWorker {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &SendWorker{
+		client:      client,
+		messageRepo: messageRepo,
+		sender:      sender,
+		timeout:     timeout,
+		logger:      log.WithComponent("send_worker"),
+		slots:       make(chan struct{}, concurrency),
+	}
+}
+
+// Start joins the send worker queue group, unless the worker is paused
+func (w *SendWorker) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.running = true
+	if !w.paused {
+		if err := w.subscribe(); err != nil {
+			return err
+		}
+	}
+
+	w.logger.Info("Send worker started",
+		zap.Int("concurrency", cap(w.slots)),
+		zap.Bool("paused", w.paused))
+	return nil
+}
+
+// Pause leaves the queue group, so that the other workers take the
+// dispatched messages, while the messages in flight are still delivered
+func (w *SendWorker) Pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.paused {
+		return
+	}
+	w.paused = true
+	w.unsubscribe()
+	w.logger.Info("Send worker paused")
+}
+
+// Resume joins the queue group again after Pause
+func (w *SendWorker) Resume() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.paused {
+		return nil
+	}
+	w.paused = false
+	if w.running {
+		if err := w.subscribe(); err != nil {
+			return err
+		}
+	}
+	w.logger.Info("Send worker resumed")
+	return nil
+}
+
+// Stop leaves the queue group and waits for the messages in flight, up to the context deadline
+func (w *SendWorker) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	w.running = false
+	w.unsubscribe()
+	w.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		w.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.logger.Info("Send worker stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("send worker stopped with messages in flight: %w", ctx.Err())
+	}
+}
+
+// subscribe joins the queue group; the caller holds mu
+func (w *SendWorker) subscribe() error {
+	if w.sub != nil {
+		return nil
+	}
+	sub, err := w.client.QueueSubscribe(DispatchSubject, SendWorkerQueue, w.handleDispatch)
+	if err != nil {
+		return err
+	}
+	w.sub = sub
+	return nil
+}
+
+// unsubscribe leaves the queue group; the caller holds mu
+func (w *SendWorker) unsubscribe() {
+	if w.sub == nil {
+		return
+	}
+	if err := w.sub.Unsubscribe(); err != nil {
+		w.logger.Warn("Failed to unsubscribe send worker", zap.Error(err))
+	}
+	w.sub = nil
+}
+
+// handleDispatch takes a dispatched message once a slot is free, acknowledges
+// it and delivers it in the background
+func (w *SendWorker) handleDispatch(msg *nats.Msg) {
+	var req dispatchRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		w.reply(msg, dispatchReply{Error: "invalid dispatch request"})
+		return
+	}
+	messageID, err := message.NewMessageIDFromString(req.MessageID)
+	if err != nil {
+		w.reply(msg, dispatchReply{Error: err.Error()})
+		return
+	}
+
+	w.slots <- struct{}{}
+	w.inFlight.Add(1)
+	w.reply(msg, dispatchReply{Accepted: true})
+
+	go func() {
+		defer func() {
+			<-w.slots
+			w.inFlight.Done()
+		}()
+		w.deliver(messageID)
+	}()
+}
+
+// deliver loads a message and sends it, skipping messages that are no longer pending
+func (w *SendWorker) deliver(messageID *message.MessageID) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	msgLogger := w.logger.WithFields(zap.String("message_id", messageID.String()))
+
+	defer func() {
+		if r := recover(); r != nil {
+			msgLogger.Error("Send worker recovered from panic", zap.Any("panic", r))
+		}
+	}()
+
+	entity, err := w.messageRepo.FindByID(ctx, messageID)
+	if err != nil {
+		msgLogger.Error("Failed to load dispatched message", zap.Error(err))
+		return
+	}
+	if entity.CorrelationID() != "" {
+		msgLogger = msgLogger.WithCorrelationID(entity.CorrelationID())
+	}
+	if entity.Status() != message.MessageStatusPending {
+		msgLogger.Info("Skipping dispatched message that is no longer pending",
+			zap.String("status", string(entity.Status())))
+		return
+	}
+
+	if err := w.sender.Deliver(ctx, entity); err != nil {
+		if errors.Is(err, services.ErrDeliveryInProgress) {
+			msgLogger.Info("Skipping dispatched message delivered by another worker")
+			return
+		}
+		msgLogger.Error("Failed to deliver dispatched message", zap.Error(err))
+	}
+}
+
+func (w *SendWorker) reply(msg *nats.Msg, reply dispatchReply) {
+	if msg.Reply == "" {
+		return
+	}
+	data, err := json.Marshal(reply)
+	if err != nil {
+		return
+	}
+	if err := msg.Respond(data); err != nil {
+		w.logger.Warn("Failed to acknowledge dispatched message", zap.Error(err))
+	}
+}
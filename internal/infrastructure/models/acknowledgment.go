@@ -0,0 +1,17 @@
+package models
+
+// AcknowledgmentModel represents the acknowledgments table structure for GORM
+type AcknowledgmentModel struct {
+	Token          string `gorm:"primaryKey;type:varchar(64)" json:"token"`
+	MessageID      string `gorm:"type:varchar(255);not null;index:idx_acknowledgments_message_id" json:"message_id"`
+	ChannelID      string `gorm:"type:varchar(255);not null;default:''" json:"channel_id"`
+	Recipient      string `gorm:"type:varchar(255);not null" json:"recipient"`
+	CreatedAt      int64  `gorm:"not null;index:idx_acknowledgments_pending,priority:2" json:"created_at"`
+	AcknowledgedAt int64  `gorm:"not null;default:0;index:idx_acknowledgments_pending,priority:1" json:"acknowledged_at"`
+	AcknowledgedBy string `gorm:"type:varchar(255);not null;default:''" json:"acknowledged_by"`
+}
+
+// TableName returns the table name for GORM
+func (AcknowledgmentModel) TableName() string {
+	return "acknowledgments"
+}
@@ -0,0 +1,17 @@
+package models
+
+// BlackoutModel represents the blackouts table structure for GORM
+type BlackoutModel struct {
+	ID          string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Name        string `gorm:"type:varchar(255);not null" json:"name"`
+	Description string `gorm:"type:text;not null;default:''" json:"description"`
+	StartsAt    int64  `gorm:"not null;index:idx_blackouts_period,priority:1" json:"starts_at"`
+	EndsAt      int64  `gorm:"not null;index:idx_blackouts_period,priority:2" json:"ends_at"`
+	CreatedAt   int64  `gorm:"not null" json:"created_at"`
+	UpdatedAt   int64  `gorm:"not null" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (BlackoutModel) TableName() string {
+	return "blackouts"
+}
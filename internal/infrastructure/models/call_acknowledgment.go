@@ -0,0 +1,14 @@
+package models
+
+// CallAcknowledgmentModel represents the call_acknowledgments table structure for GORM
+type CallAcknowledgmentModel struct {
+	CallSID        string `gorm:"primaryKey;type:varchar(64)" json:"call_sid"`
+	ChannelID      string `gorm:"type:varchar(255);not null" json:"channel_id"`
+	Digits         string `gorm:"type:varchar(32);not null" json:"digits"`
+	AcknowledgedAt int64  `gorm:"not null;index:idx_call_acknowledgments_acknowledged_at" json:"acknowledged_at"`
+}
+
+// TableName returns the table name for GORM
+func (CallAcknowledgmentModel) TableName() string {
+	return "call_acknowledgments"
+}
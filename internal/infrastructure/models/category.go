@@ -0,0 +1,28 @@
+package models
+
+// CategoryModel represents the categories table structure for GORM
+type CategoryModel struct {
+	Name              string `gorm:"primaryKey;type:varchar(100)" json:"name"`
+	Description       string `gorm:"type:varchar(500);not null;default:''" json:"description"`
+	DefaultSubscribed bool   `gorm:"not null;default:false" json:"default_subscribed"`
+	CreatedAt         int64  `gorm:"not null" json:"created_at"`
+	UpdatedAt         int64  `gorm:"not null" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (CategoryModel) TableName() string {
+	return "categories"
+}
+
+// CategorySubscriptionModel represents the category_subscriptions table structure for GORM
+type CategorySubscriptionModel struct {
+	UserID     string `gorm:"primaryKey;type:varchar(255)" json:"user_id"`
+	Category   string `gorm:"primaryKey;type:varchar(100);index:idx_category_subscriptions_category" json:"category"`
+	Subscribed bool   `gorm:"not null" json:"subscribed"`
+	UpdatedAt  int64  `gorm:"not null" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (CategorySubscriptionModel) TableName() string {
+	return "category_subscriptions"
+}
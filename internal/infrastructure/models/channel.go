@@ -12,22 +12,34 @@ import (
 
 // ChannelModel represents the channel table structure for GORM
 type ChannelModel struct {
-	ID            string         `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	Name          string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_channels_name_unique,where:deleted_at IS NULL" json:"name"`
-	Description   string         `gorm:"type:varchar(500);default:''" json:"description"`
-	Enabled       bool           `gorm:"not null;default:true;index:idx_channels_enabled,where:deleted_at IS NULL" json:"enabled"`
-	ChannelType   string         `gorm:"type:varchar(50);not null;index:idx_channels_type,where:deleted_at IS NULL;check:channel_type IN ('email','slack','sms')" json:"channel_type"`
-	TemplateID    *string        `gorm:"type:varchar(255);index:idx_channels_template_id,where:deleted_at IS NULL" json:"template_id"`
-	Timeout       int            `gorm:"not null;check:timeout > 0" json:"timeout"`
-	RetryAttempts int            `gorm:"not null;default:0;check:retry_attempts >= 0" json:"retry_attempts"`
-	RetryDelay    int            `gorm:"not null;default:0;check:retry_delay >= 0" json:"retry_delay"`
-	Config        JSON           `gorm:"type:jsonb;not null" json:"config"`
-	Recipients    JSONArray      `gorm:"type:jsonb;not null" json:"recipients"`
-	Tags          pq.StringArray `gorm:"type:text[];default:'{}'" json:"tags"`
-	CreatedAt     int64          `gorm:"not null;index:idx_channels_created_at,where:deleted_at IS NULL" json:"created_at"`
-	UpdatedAt     int64          `gorm:"not null" json:"updated_at"`
-	DeletedAt     *int64         `gorm:"index" json:"deleted_at"`
-	LastUsed      *int64         `json:"last_used"`
+	ID                string         `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Name              string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_channels_name_unique,where:deleted_at IS NULL" json:"name"`
+	Description       string         `gorm:"type:varchar(500);default:''" json:"description"`
+	Enabled           bool           `gorm:"not null;default:true;index:idx_channels_enabled,where:deleted_at IS NULL" json:"enabled"`
+	Maintenance       bool           `gorm:"not null;default:false" json:"maintenance"`
+	ChannelType       string         `gorm:"type:varchar(50);not null;index:idx_channels_type,where:deleted_at IS NULL;check:channel_type IN ('email','slack','sms','voice','whatsapp','discord','pagerduty','opsgenie','jira','mqtt','statuspage','inapp')" json:"channel_type"`
+	TemplateID        *string        `gorm:"type:varchar(255);index:idx_channels_template_id,where:deleted_at IS NULL" json:"template_id"`
+	Timeout           int            `gorm:"not null;check:timeout > 0" json:"timeout"`
+	RetryAttempts     int            `gorm:"not null;default:0;check:retry_attempts >= 0" json:"retry_attempts"`
+	RetryDelay        int            `gorm:"not null;default:0;check:retry_delay >= 0" json:"retry_delay"`
+	Config            JSON           `gorm:"type:jsonb;not null" json:"config"`
+	Recipients        JSONArray      `gorm:"type:jsonb;not null" json:"recipients"`
+	Tags              pq.StringArray `gorm:"type:text[];default:'{}'" json:"tags"`
+	VariableDefaults  JSON           `gorm:"type:jsonb;not null;default:'{}'" json:"variable_defaults"`
+	Owner             string         `gorm:"type:varchar(255);not null;default:'';index:idx_channels_owner,where:deleted_at IS NULL" json:"owner"`
+	Team              string         `gorm:"type:varchar(255);not null;default:'';index:idx_channels_team,where:deleted_at IS NULL" json:"team"`
+	Environment       string         `gorm:"type:varchar(20);not null;default:'';index:idx_channels_environment,where:deleted_at IS NULL" json:"environment"`
+	FallbackChannelID *string        `gorm:"type:varchar(255)" json:"fallback_channel_id"`
+	HealthStatus      string         `gorm:"type:varchar(20);not null;default:'healthy';check:health_status IN ('healthy','degraded','disabled')" json:"health_status"`
+	HealthSuccesses   int            `gorm:"not null;default:0" json:"health_successes"`
+	HealthFailures    int            `gorm:"not null;default:0" json:"health_failures"`
+	HealthSince       int64          `gorm:"not null;default:0" json:"health_since"`
+	HealthCheckedAt   int64          `gorm:"not null;default:0" json:"health_checked_at"`
+	CreatedAt         int64          `gorm:"not null;index:idx_channels_created_at,where:deleted_at IS NULL" json:"created_at"`
+	UpdatedAt         int64          `gorm:"not null" json:"updated_at"`
+	DeletedAt         *int64         `gorm:"index" json:"deleted_at"`
+	LastUsed          *int64         `json:"last_used"`
+	Version           int64          `gorm:"not null;default:1;check:version > 0" json:"version"`
 }
 
 // TableName returns the table name for GORM
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/lib/pq"
+)
+
+// ChannelGroupModel represents the channel_groups table structure for GORM
+type ChannelGroupModel struct {
+	ID          string         `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Name        string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_channel_groups_name_unique,where:deleted_at IS NULL" json:"name"`
+	Description string         `gorm:"type:varchar(500);default:''" json:"description"`
+	ChannelIDs  pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"channel_ids"`
+	CreatedAt   int64          `gorm:"not null" json:"created_at"`
+	UpdatedAt   int64          `gorm:"not null" json:"updated_at"`
+	DeletedAt   *int64         `gorm:"index" json:"deleted_at"`
+}
+
+// TableName returns the table name for GORM
+func (ChannelGroupModel) TableName() string {
+	return "channel_groups"
+}
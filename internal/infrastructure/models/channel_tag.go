@@ -0,0 +1,14 @@
+package models
+
+// ChannelTagModel represents the channel_tags table structure for GORM. It
+// holds one row per tag of a channel, mirroring channels.tags, so that tag
+// filters can use an index on every database.
+type ChannelTagModel struct {
+	Tag       string `gorm:"primaryKey;type:varchar(255)" json:"tag"`
+	ChannelID string `gorm:"primaryKey;type:varchar(255);index:idx_channel_tags_channel_id" json:"channel_id"`
+}
+
+// TableName returns the table name for GORM
+func (ChannelTagModel) TableName() string {
+	return "channel_tags"
+}
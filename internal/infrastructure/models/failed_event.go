@@ -0,0 +1,25 @@
+package models
+
+// FailedEventModel represents the failed_events table structure for GORM
+type FailedEventModel struct {
+	ID            string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	EventID       string `gorm:"type:varchar(255);not null" json:"event_id"`
+	EventType     string `gorm:"type:varchar(100);not null" json:"event_type"`
+	AggregateID   string `gorm:"type:varchar(255);not null;default:''" json:"aggregate_id"`
+	AggregateType string `gorm:"type:varchar(100);not null;default:''" json:"aggregate_type"`
+	Version       int64  `gorm:"not null;default:0" json:"version"`
+	// Payload is the JSON encoded event data
+	Payload     string `gorm:"type:jsonb;not null;default:'null'" json:"payload"`
+	Handler     string `gorm:"type:varchar(255);not null" json:"handler"`
+	Attempts    int    `gorm:"not null;default:0" json:"attempts"`
+	Error       string `gorm:"type:text;not null;default:''" json:"error"`
+	Status      string `gorm:"type:varchar(20);not null;index:idx_failed_events_status_failed_at" json:"status"`
+	ReplayCount int    `gorm:"not null;default:0" json:"replay_count"`
+	FailedAt    int64  `gorm:"not null;index:idx_failed_events_status_failed_at" json:"failed_at"`
+	ReplayedAt  *int64 `json:"replayed_at"`
+}
+
+// TableName returns the table name for GORM
+func (FailedEventModel) TableName() string {
+	return "failed_events"
+}
@@ -0,0 +1,19 @@
+package models
+
+// InAppNotificationModel represents the inapp_notifications table structure for GORM
+type InAppNotificationModel struct {
+	ID            string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	UserID        string `gorm:"type:varchar(255);not null;index:idx_inapp_notifications_user_created_at;index:idx_inapp_notifications_user_unread,where:read_at IS NULL" json:"user_id"`
+	ChannelID     string `gorm:"type:varchar(255);not null" json:"channel_id"`
+	Category      string `gorm:"type:varchar(100);not null;default:''" json:"category"`
+	Title         string `gorm:"type:varchar(255);not null;default:''" json:"title"`
+	Content       string `gorm:"type:text;not null;default:''" json:"content"`
+	CorrelationID string `gorm:"type:varchar(255);not null;default:''" json:"correlation_id"`
+	CreatedAt     int64  `gorm:"not null;index:idx_inapp_notifications_user_created_at" json:"created_at"`
+	ReadAt        *int64 `json:"read_at"`
+}
+
+// TableName returns the table name for GORM
+func (InAppNotificationModel) TableName() string {
+	return "inapp_notifications"
+}
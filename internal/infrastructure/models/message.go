@@ -10,8 +10,14 @@ type MessageModel struct {
 	ChannelIDs       JSONArray          `gorm:"type:jsonb;not null" json:"channel_ids"`
 	Variables        JSON               `gorm:"type:jsonb;not null" json:"variables"`
 	ChannelOverrides JSON               `gorm:"type:jsonb;not null;default:'{}'" json:"channel_overrides"`
-	Status           string             `gorm:"type:varchar(50);not null;default:'pending';index:idx_messages_status;check:status IN ('pending','success','failed','partial_success')" json:"status"`
+	CorrelationID    string             `gorm:"type:varchar(255);not null;default:'';index:idx_messages_correlation_id" json:"correlation_id"`
+	TenantID         string             `gorm:"type:varchar(255);not null;default:'default';index:idx_messages_tenant_id" json:"tenant_id"`
+	MessageClass     string             `gorm:"type:varchar(20);not null;default:'marketing';index:idx_messages_message_class" json:"message_class"`
+	StrictRender     bool               `gorm:"not null;default:false" json:"strict_render"`
+	Status           string             `gorm:"type:varchar(50);not null;default:'pending';index:idx_messages_status;check:status IN ('pending','success','failed','partial_success','held')" json:"status"`
 	CreatedAt        int64              `gorm:"not null;index:idx_messages_created_at" json:"created_at"`
+	// ContentPurgedAt is when the retention job purged the content of the message
+	ContentPurgedAt  *int64             `json:"content_purged_at"`
 	Results          []MessageResultModel `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE" json:"results,omitempty"`
 }
 
@@ -37,11 +43,17 @@ type MessageResultModel struct {
 	ID           uint   `gorm:"primaryKey;autoIncrement" json:"id"`
 	MessageID    string `gorm:"type:varchar(255);not null;index:idx_message_results_message_id;uniqueIndex:idx_message_results_unique,priority:1" json:"message_id"`
 	ChannelID    string `gorm:"type:varchar(255);not null;index:idx_message_results_channel_id;uniqueIndex:idx_message_results_unique,priority:2" json:"channel_id"`
-	Status       string `gorm:"type:varchar(50);not null;index:idx_message_results_status;check:status IN ('success','failed')" json:"status"`
+	Status       string `gorm:"type:varchar(50);not null;index:idx_message_results_status;check:status IN ('success','failed','held')" json:"status"`
 	Message      string `gorm:"type:text;not null" json:"message"`
 	ErrorCode    *string `gorm:"type:varchar(100)" json:"error_code"`
 	ErrorDetails *string `gorm:"type:text" json:"error_details"`
+	ErrorCategory *string `gorm:"type:varchar(30)" json:"error_category"`
 	SentAt       *int64  `json:"sent_at"`
+	Recipients   JSONArray `gorm:"type:jsonb;not null;default:'[]'" json:"recipients"`
+	Cost         float64 `gorm:"type:numeric(14,6);not null;default:0" json:"cost"`
+	FallbackFrom *string `gorm:"type:varchar(255)" json:"fallback_from"`
+	PolicyDecisions JSONArray `gorm:"type:jsonb;not null;default:'[]'" json:"policy_decisions"`
+	AttachmentScans JSONArray `gorm:"type:jsonb;not null;default:'[]'" json:"attachment_scans"`
 	
 	// Foreign key relationship
 	MessageModel MessageModel `gorm:"foreignKey:MessageID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
@@ -8,9 +8,32 @@ import (
 func AllModels() []interface{} {
 	return []interface{}{
 		&ChannelModel{},
+		&ChannelTagModel{},
 		&TemplateModel{},
 		&MessageModel{},
 		&MessageResultModel{},
+		&QuotaUsageModel{},
+		&ChannelGroupModel{},
+		&ProvisioningSagaModel{},
+		&FailedEventModel{},
+		&NATSInboxModel{},
+		&CallAcknowledgmentModel{},
+		&InAppNotificationModel{},
+		&UserPreferenceModel{},
+		&CategoryModel{},
+		&CategorySubscriptionModel{},
+		&ShortLinkModel{},
+		&QuarantinedAttachmentModel{},
+		&SenderIdentityModel{},
+		&ScheduledSendModel{},
+		&BlackoutModel{},
+		&AcknowledgmentModel{},
+		&ReplyTokenModel{},
+		&ReplyModel{},
+		&OTPCodeModel{},
+		&PrivacyRequestModel{},
+		&PrivacyAuditEntryModel{},
+		&RetentionReportModel{},
 	}
 }
 
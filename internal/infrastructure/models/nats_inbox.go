@@ -0,0 +1,19 @@
+package models
+
+// NATSInboxModel represents the nats_inbox table structure for GORM
+type NATSInboxModel struct {
+	Subject  string `gorm:"primaryKey;type:varchar(255)" json:"subject"`
+	ClientID string `gorm:"primaryKey;type:varchar(255)" json:"client_id"`
+	ReqSeqID string `gorm:"primaryKey;type:varchar(255)" json:"req_seq_id"`
+	Status   string `gorm:"type:varchar(20);not null" json:"status"`
+	// Response is the JSON encoded response data of a completed command
+	Response    string `gorm:"type:jsonb;not null;default:'null'" json:"response"`
+	ReceivedAt  int64  `gorm:"not null" json:"received_at"`
+	CompletedAt *int64 `json:"completed_at"`
+	ExpiresAt   int64  `gorm:"not null;index:idx_nats_inbox_expires_at" json:"expires_at"`
+}
+
+// TableName returns the table name for GORM
+func (NATSInboxModel) TableName() string {
+	return "nats_inbox"
+}
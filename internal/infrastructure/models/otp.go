@@ -0,0 +1,20 @@
+package models
+
+// OTPCodeModel represents the otp_codes table structure for GORM
+type OTPCodeModel struct {
+	ID          string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Purpose     string `gorm:"type:varchar(100);not null;index:idx_otp_codes_recipient,priority:1" json:"purpose"`
+	Recipient   string `gorm:"type:varchar(255);not null;index:idx_otp_codes_recipient,priority:2" json:"recipient"`
+	ChannelID   string `gorm:"type:varchar(255);not null" json:"channel_id"`
+	CodeHash    string `gorm:"type:varchar(64);not null" json:"-"`
+	Attempts    int    `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int    `gorm:"not null" json:"max_attempts"`
+	CreatedAt   int64  `gorm:"not null;index:idx_otp_codes_recipient,priority:3" json:"created_at"`
+	ExpiresAt   int64  `gorm:"not null;index:idx_otp_codes_expires_at" json:"expires_at"`
+	VerifiedAt  *int64 `json:"verified_at"`
+}
+
+// TableName returns the table name for GORM
+func (OTPCodeModel) TableName() string {
+	return "otp_codes"
+}
@@ -0,0 +1,40 @@
+package models
+
+// PrivacyRequestModel represents the privacy_requests table structure for GORM
+type PrivacyRequestModel struct {
+	ID          string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Kind        string `gorm:"type:varchar(20);not null" json:"kind"`
+	Subject     string `gorm:"type:varchar(255);not null;default:''" json:"subject"`
+	SubjectHash string `gorm:"type:varchar(64);not null;index:idx_privacy_requests_subject_hash" json:"subject_hash"`
+	Status      string `gorm:"type:varchar(20);not null;index:idx_privacy_requests_status" json:"status"`
+	Report      JSON   `gorm:"type:jsonb;not null;default:'{}'" json:"report"`
+	// Export is the JSON of the rows exported, empty until an export completes
+	Export      string `gorm:"type:text;not null;default:''" json:"export"`
+	Error       string `gorm:"type:text;not null;default:''" json:"error"`
+	RequestedBy string `gorm:"type:varchar(255);not null;default:''" json:"requested_by"`
+	CreatedAt   int64  `gorm:"not null" json:"created_at"`
+	CompletedAt *int64 `json:"completed_at"`
+}
+
+// TableName returns the table name for GORM
+func (PrivacyRequestModel) TableName() string {
+	return "privacy_requests"
+}
+
+// PrivacyAuditEntryModel represents the privacy_audit_entries table structure for GORM
+type PrivacyAuditEntryModel struct {
+	ID          string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	RequestID   string `gorm:"type:varchar(255);not null;index:idx_privacy_audit_entries_request_id" json:"request_id"`
+	Kind        string `gorm:"type:varchar(20);not null" json:"kind"`
+	Action      string `gorm:"type:varchar(20);not null" json:"action"`
+	SubjectHash string `gorm:"type:varchar(64);not null" json:"subject_hash"`
+	Actor       string `gorm:"type:varchar(255);not null;default:''" json:"actor"`
+	Report      JSON   `gorm:"type:jsonb;not null;default:'{}'" json:"report"`
+	Error       string `gorm:"type:text;not null;default:''" json:"error"`
+	OccurredAt  int64  `gorm:"not null;index:idx_privacy_audit_entries_occurred_at" json:"occurred_at"`
+}
+
+// TableName returns the table name for GORM
+func (PrivacyAuditEntryModel) TableName() string {
+	return "privacy_audit_entries"
+}
@@ -0,0 +1,19 @@
+package models
+
+// ProvisioningSagaModel represents the provisioning_sagas table structure for GORM
+type ProvisioningSagaModel struct {
+	ID        string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Kind      string    `gorm:"type:varchar(50);not null;index:idx_provisioning_sagas_kind_status" json:"kind"`
+	Status    string    `gorm:"type:varchar(20);not null;index:idx_provisioning_sagas_kind_status" json:"status"`
+	Payload   JSON      `gorm:"type:jsonb;not null;default:'{}'" json:"payload"`
+	Data      JSON      `gorm:"type:jsonb;not null;default:'{}'" json:"data"`
+	Steps     JSONArray `gorm:"type:jsonb;not null;default:'[]'" json:"steps"`
+	Error     string    `gorm:"type:text;not null;default:''" json:"error"`
+	CreatedAt int64     `gorm:"not null" json:"created_at"`
+	UpdatedAt int64     `gorm:"not null" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (ProvisioningSagaModel) TableName() string {
+	return "provisioning_sagas"
+}
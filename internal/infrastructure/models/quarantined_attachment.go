@@ -0,0 +1,18 @@
+package models
+
+// QuarantinedAttachmentModel represents the quarantined_attachments table structure for GORM
+type QuarantinedAttachmentModel struct {
+	ID          string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	MessageID   string `gorm:"type:varchar(255);not null;index:idx_quarantined_attachments_message_id" json:"message_id"`
+	ChannelID   string `gorm:"type:varchar(255);not null" json:"channel_id"`
+	Filename    string `gorm:"type:varchar(255);not null" json:"filename"`
+	ContentType string `gorm:"type:varchar(255);not null;default:''" json:"content_type"`
+	Content     []byte `gorm:"type:bytea;not null" json:"-"`
+	Signature   string `gorm:"type:text;not null;default:''" json:"signature"`
+	CreatedAt   int64  `gorm:"not null" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (QuarantinedAttachmentModel) TableName() string {
+	return "quarantined_attachments"
+}
@@ -0,0 +1,16 @@
+package models
+
+// QuotaUsageModel represents the quota_usage table structure for GORM
+type QuotaUsageModel struct {
+	Scope     string `gorm:"primaryKey;type:varchar(20);check:scope IN ('tenant','channel')" json:"scope"`
+	SubjectID string `gorm:"primaryKey;type:varchar(255)" json:"subject_id"`
+	Period    string `gorm:"primaryKey;type:varchar(10);check:period IN ('daily','monthly')" json:"period"`
+	PeriodKey string `gorm:"primaryKey;type:varchar(10)" json:"period_key"`
+	Used      int    `gorm:"not null;default:0" json:"used"`
+	UpdatedAt int64  `gorm:"not null" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (QuotaUsageModel) TableName() string {
+	return "quota_usage"
+}
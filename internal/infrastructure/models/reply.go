@@ -0,0 +1,33 @@
+package models
+
+// ReplyTokenModel represents the reply_tokens table structure for GORM
+type ReplyTokenModel struct {
+	Token     string `gorm:"primaryKey;type:varchar(64)" json:"token"`
+	MessageID string `gorm:"type:varchar(255);not null" json:"message_id"`
+	ChannelID string `gorm:"type:varchar(255);not null;index:idx_reply_tokens_recipient,priority:1" json:"channel_id"`
+	Recipient string `gorm:"type:varchar(255);not null;default:'';index:idx_reply_tokens_recipient,priority:2" json:"recipient"`
+	CreatedAt int64  `gorm:"not null;index:idx_reply_tokens_recipient,priority:3" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (ReplyTokenModel) TableName() string {
+	return "reply_tokens"
+}
+
+// ReplyModel represents the replies table structure for GORM
+type ReplyModel struct {
+	ID          string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	MessageID   string `gorm:"type:varchar(255);not null;default:'';index:idx_replies_message_id" json:"message_id"`
+	ChannelID   string `gorm:"type:varchar(255);not null;default:''" json:"channel_id"`
+	ChannelType string `gorm:"type:varchar(50);not null" json:"channel_type"`
+	Sender      string `gorm:"type:varchar(255);not null" json:"sender"`
+	Subject     string `gorm:"type:text;not null;default:''" json:"subject"`
+	Body        string `gorm:"type:text;not null;default:''" json:"body"`
+	ProviderID  string `gorm:"type:varchar(255);not null;default:''" json:"provider_id"`
+	ReceivedAt  int64  `gorm:"not null;index:idx_replies_received_at" json:"received_at"`
+}
+
+// TableName returns the table name for GORM
+func (ReplyModel) TableName() string {
+	return "replies"
+}
@@ -0,0 +1,20 @@
+package models
+
+// RetentionReportModel represents the retention_reports table structure for GORM
+type RetentionReportModel struct {
+	ID              string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	RunID           string `gorm:"type:varchar(255);not null" json:"run_id"`
+	TenantID        string `gorm:"type:varchar(255);not null;index:idx_retention_reports_tenant_id" json:"tenant_id"`
+	ContentBefore   *int64 `json:"content_before"`
+	MetadataBefore  *int64 `json:"metadata_before"`
+	ContentPurged   int    `gorm:"not null;default:0" json:"content_purged"`
+	MessagesDeleted int    `gorm:"not null;default:0" json:"messages_deleted"`
+	Error           string `gorm:"type:text;not null;default:''" json:"error"`
+	StartedAt       int64  `gorm:"not null;index:idx_retention_reports_started_at" json:"started_at"`
+	CompletedAt     int64  `gorm:"not null" json:"completed_at"`
+}
+
+// TableName returns the table name for GORM
+func (RetentionReportModel) TableName() string {
+	return "retention_reports"
+}
@@ -0,0 +1,26 @@
+package models
+
+// ScheduledSendModel represents the scheduled_sends table structure for GORM
+type ScheduledSendModel struct {
+	ID       string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	BatchID  string `gorm:"type:varchar(255);not null;index:idx_scheduled_sends_batch_id" json:"batch_id"`
+	TenantID string `gorm:"type:varchar(255);not null;default:''" json:"tenant_id"`
+	Timezone string `gorm:"type:varchar(64);not null;default:''" json:"timezone"`
+	SendAt   int64  `gorm:"not null;index:idx_scheduled_sends_status_send_at,priority:2" json:"send_at"`
+	Request  string `gorm:"type:text;not null" json:"request"`
+	Critical bool   `gorm:"not null;default:false" json:"critical"`
+	Status   string `gorm:"type:varchar(20);not null;index:idx_scheduled_sends_status_send_at,priority:1" json:"status"`
+	// DeferredFrom is the send time before a blackout deferred the send, and
+	// DeferredBy that blackout
+	DeferredFrom *int64 `json:"deferred_from"`
+	DeferredBy   string `gorm:"type:varchar(255);not null;default:''" json:"deferred_by"`
+	MessageID    string `gorm:"type:varchar(255);not null;default:''" json:"message_id"`
+	Error        string `gorm:"type:text;not null;default:''" json:"error"`
+	CreatedAt    int64  `gorm:"not null" json:"created_at"`
+	SentAt       *int64 `json:"sent_at"`
+}
+
+// TableName returns the table name for GORM
+func (ScheduledSendModel) TableName() string {
+	return "scheduled_sends"
+}
@@ -0,0 +1,18 @@
+package models
+
+// SenderIdentityModel represents the sender_identities table structure for GORM
+type SenderIdentityModel struct {
+	ID         string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	TenantID   string `gorm:"type:varchar(255);not null;uniqueIndex:idx_sender_identities_tenant_value" json:"tenant_id"`
+	Kind       string `gorm:"type:varchar(20);not null" json:"kind"`
+	Value      string `gorm:"type:varchar(255);not null;uniqueIndex:idx_sender_identities_tenant_value" json:"value"`
+	Status     string `gorm:"type:varchar(20);not null" json:"status"`
+	Token      string `gorm:"type:varchar(64);not null" json:"-"`
+	CreatedAt  int64  `gorm:"not null" json:"created_at"`
+	VerifiedAt *int64 `json:"verified_at"`
+}
+
+// TableName returns the table name for GORM
+func (SenderIdentityModel) TableName() string {
+	return "sender_identities"
+}
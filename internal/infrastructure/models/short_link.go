@@ -0,0 +1,17 @@
+package models
+
+// ShortLinkModel represents the short_links table structure for GORM
+type ShortLinkModel struct {
+	Code          string `gorm:"primaryKey;type:varchar(16)" json:"code"`
+	TargetURL     string `gorm:"type:text;not null" json:"target_url"`
+	MessageID     string `gorm:"type:varchar(255);not null;index:idx_short_links_message_id" json:"message_id"`
+	ChannelID     string `gorm:"type:varchar(255);not null;default:''" json:"channel_id"`
+	Clicks        int64  `gorm:"not null;default:0" json:"clicks"`
+	CreatedAt     int64  `gorm:"not null" json:"created_at"`
+	LastClickedAt int64  `gorm:"not null;default:0" json:"last_clicked_at"`
+}
+
+// TableName returns the table name for GORM
+func (ShortLinkModel) TableName() string {
+	return "short_links"
+}
@@ -10,10 +10,18 @@ type TemplateModel struct {
 	ID          string         `gorm:"primaryKey;type:varchar(255)" json:"id"`
 	Name        string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_templates_name_unique,where:deleted_at IS NULL" json:"name"`
 	Description string         `gorm:"type:varchar(500);default:''" json:"description"`
-	ChannelType string         `gorm:"type:varchar(50);not null;index:idx_templates_type,where:deleted_at IS NULL;check:channel_type IN ('email','slack','sms')" json:"channel_type"`
+	ChannelType string         `gorm:"type:varchar(50);not null;index:idx_templates_type,where:deleted_at IS NULL;check:channel_type IN ('email','slack','sms','voice','whatsapp','discord','pagerduty','opsgenie','jira','mqtt','statuspage','inapp')" json:"channel_type"`
 	Subject     string         `gorm:"type:varchar(200);default:''" json:"subject"`
 	Content     string         `gorm:"type:text;not null" json:"content"`
 	Tags        pq.StringArray `gorm:"type:text[];default:'{}'" json:"tags"`
+	Strict      bool           `gorm:"not null;default:false" json:"strict"`
+	Attachments JSONArray      `gorm:"type:jsonb;not null;default:'[]'" json:"attachments"`
+	Headers     JSON           `gorm:"type:jsonb;not null;default:'{}'" json:"headers"`
+	Owner       string         `gorm:"type:varchar(255);not null;default:'';index:idx_templates_owner,where:deleted_at IS NULL" json:"owner"`
+	Team        string         `gorm:"type:varchar(255);not null;default:'';index:idx_templates_team,where:deleted_at IS NULL" json:"team"`
+	Environment string         `gorm:"type:varchar(20);not null;default:'';index:idx_templates_environment,where:deleted_at IS NULL" json:"environment"`
+	Variables   pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"variables"`
+	Settings    JSON           `gorm:"type:jsonb" json:"settings"`
 	CreatedAt   int64          `gorm:"not null;index:idx_templates_created_at,where:deleted_at IS NULL" json:"created_at"`
 	UpdatedAt   int64          `gorm:"not null" json:"updated_at"`
 	DeletedAt   *int64         `gorm:"index" json:"deleted_at"`
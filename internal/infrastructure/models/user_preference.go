@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/lib/pq"
+)
+
+// UserPreferenceModel represents the user_preferences table structure for GORM
+type UserPreferenceModel struct {
+	UserID             string         `gorm:"primaryKey;type:varchar(255)" json:"user_id"`
+	PreferredChannels  pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"preferred_channels"`
+	OptOutCategories   pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"opt_out_categories"`
+	QuietHoursStart    string         `gorm:"type:varchar(5);not null;default:''" json:"quiet_hours_start"`
+	QuietHoursEnd      string         `gorm:"type:varchar(5);not null;default:''" json:"quiet_hours_end"`
+	QuietHoursTimezone string         `gorm:"type:varchar(64);not null;default:''" json:"quiet_hours_timezone"`
+	Timezone           string         `gorm:"type:varchar(64);not null;default:''" json:"timezone"`
+	UpdatedAt          int64          `gorm:"not null" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (UserPreferenceModel) TableName() string {
+	return "user_preferences"
+}
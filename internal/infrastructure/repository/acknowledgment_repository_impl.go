@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/message"
+	"notification/internal/infrastructure/models"
+)
+
+// AcknowledgmentRepositoryImpl implements message.AcknowledgmentRepository interface using GORM
+type AcknowledgmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAcknowledgmentRepositoryImpl creates a new acknowledgment repository implementation
+func NewAcknowledgmentRepositoryImpl(db *gorm.DB) *AcknowledgmentRepositoryImpl {
+	return &AcknowledgmentRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save records the acknowledgments of a send in one insert
+func (r *AcknowledgmentRepositoryImpl) Save(ctx context.Context, acks []*message.Acknowledgment) error {
+	if len(acks) == 0 {
+		return nil
+	}
+	rows := make([]*models.AcknowledgmentModel, 0, len(acks))
+	for _, ack := range acks {
+		rows = append(rows, &models.AcknowledgmentModel{
+			Token:          ack.Token,
+			MessageID:      ack.MessageID,
+			ChannelID:      ack.ChannelID,
+			Recipient:      ack.Recipient,
+			CreatedAt:      ack.CreatedAt,
+			AcknowledgedAt: ack.AcknowledgedAt,
+			AcknowledgedBy: ack.AcknowledgedBy,
+		})
+	}
+	if err := r.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to save acknowledgments: %w", err)
+	}
+	return nil
+}
+
+// FindByToken finds an acknowledgment by its token
+func (r *AcknowledgmentRepositoryImpl) FindByToken(ctx context.Context, token string) (*message.Acknowledgment, error) {
+	var model models.AcknowledgmentModel
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find acknowledgment: %w", err)
+	}
+	return toAcknowledgment(&model), nil
+}
+
+// FindByMessageID lists the acknowledgments of a message
+func (r *AcknowledgmentRepositoryImpl) FindByMessageID(ctx context.Context, messageID string) ([]*message.Acknowledgment, error) {
+	var rows []models.AcknowledgmentModel
+	err := r.db.WithContext(ctx).
+		Where("message_id = ?", messageID).
+		Order("created_at ASC, recipient ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list acknowledgments: %w", err)
+	}
+	return toAcknowledgments(rows), nil
+}
+
+// FindUnacknowledged lists the pending acknowledgments created before a time
+func (r *AcknowledgmentRepositoryImpl) FindUnacknowledged(ctx context.Context, createdBefore int64, limit int) ([]*message.Acknowledgment, error) {
+	var rows []models.AcknowledgmentModel
+	err := r.db.WithContext(ctx).
+		Where("acknowledged_at = 0 AND created_at < ?", createdBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unacknowledged messages: %w", err)
+	}
+	return toAcknowledgments(rows), nil
+}
+
+// Acknowledge records an acknowledgment in a single conditional update, so
+// that the first of concurrent acknowledgments is kept
+func (r *AcknowledgmentRepositoryImpl) Acknowledge(ctx context.Context, token, by string, acknowledgedAt int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.AcknowledgmentModel{}).
+		Where("token = ? AND acknowledged_at = 0", token).
+		Updates(map[string]interface{}{
+			"acknowledged_at": acknowledgedAt,
+			"acknowledged_by": by,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record acknowledgment: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// toAcknowledgments converts acknowledgment models to their domain form
+func toAcknowledgments(rows []models.AcknowledgmentModel) []*message.Acknowledgment {
+	acks := make([]*message.Acknowledgment, 0, len(rows))
+	for i := range rows {
+		acks = append(acks, toAcknowledgment(&rows[i]))
+	}
+	return acks
+}
+
+// toAcknowledgment converts an acknowledgment model to its domain form
+func toAcknowledgment(model *models.AcknowledgmentModel) *message.Acknowledgment {
+	return &message.Acknowledgment{
+		Token:          model.Token,
+		MessageID:      model.MessageID,
+		ChannelID:      model.ChannelID,
+		Recipient:      model.Recipient,
+		CreatedAt:      model.CreatedAt,
+		AcknowledgedAt: model.AcknowledgedAt,
+		AcknowledgedBy: model.AcknowledgedBy,
+	}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/blackout"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// BlackoutRepositoryImpl implements blackout.BlackoutRepository interface using GORM
+type BlackoutRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBlackoutRepositoryImpl creates a new blackout repository implementation
+func NewBlackoutRepositoryImpl(db *gorm.DB) *BlackoutRepositoryImpl {
+	return &BlackoutRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a new blackout to the database
+func (r *BlackoutRepositoryImpl) Save(ctx context.Context, b *blackout.Blackout) error {
+	if err := r.db.WithContext(ctx).Create(toBlackoutModel(b)).Error; err != nil {
+		return fmt.Errorf("failed to save blackout: %w", err)
+	}
+	return nil
+}
+
+// Update stores the changes of a blackout
+func (r *BlackoutRepositoryImpl) Update(ctx context.Context, b *blackout.Blackout) error {
+	model := toBlackoutModel(b)
+	result := r.db.WithContext(ctx).
+		Model(&models.BlackoutModel{}).
+		Where("id = ?", b.ID).
+		Updates(map[string]interface{}{
+			"name":        model.Name,
+			"description": model.Description,
+			"starts_at":   model.StartsAt,
+			"ends_at":     model.EndsAt,
+			"updated_at":  model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update blackout: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("BLACKOUT_NOT_FOUND", "blackout not found")
+	}
+	return nil
+}
+
+// FindByID finds a blackout by its ID
+func (r *BlackoutRepositoryImpl) FindByID(ctx context.Context, id string) (*blackout.Blackout, error) {
+	var model models.BlackoutModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.NewNotFoundError("BLACKOUT_NOT_FOUND", "blackout not found")
+		}
+		return nil, fmt.Errorf("failed to find blackout: %w", err)
+	}
+	return fromBlackoutModel(&model), nil
+}
+
+// FindEndingAfter lists the blackouts ending after a time, by start
+func (r *BlackoutRepositoryImpl) FindEndingAfter(ctx context.Context, at time.Time) ([]*blackout.Blackout, error) {
+	return r.find(r.db.WithContext(ctx).Where("ends_at > ?", at.UnixMilli()))
+}
+
+// List lists the blackouts, by start
+func (r *BlackoutRepositoryImpl) List(ctx context.Context) ([]*blackout.Blackout, error) {
+	return r.find(r.db.WithContext(ctx))
+}
+
+// find lists the blackouts a query matches, by start
+func (r *BlackoutRepositoryImpl) find(query *gorm.DB) ([]*blackout.Blackout, error) {
+	var rows []models.BlackoutModel
+	if err := query.Order("starts_at ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query blackouts: %w", err)
+	}
+
+	blackouts := make([]*blackout.Blackout, 0, len(rows))
+	for i := range rows {
+		blackouts = append(blackouts, fromBlackoutModel(&rows[i]))
+	}
+	return blackouts, nil
+}
+
+// Delete deletes a blackout
+func (r *BlackoutRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.BlackoutModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete blackout: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("BLACKOUT_NOT_FOUND", "blackout not found")
+	}
+	return nil
+}
+
+// toBlackoutModel converts a blackout to GORM model
+func toBlackoutModel(b *blackout.Blackout) *models.BlackoutModel {
+	return &models.BlackoutModel{
+		ID:          b.ID,
+		Name:        b.Name,
+		Description: b.Description,
+		StartsAt:    b.StartsAt.UnixMilli(),
+		EndsAt:      b.EndsAt.UnixMilli(),
+		CreatedAt:   b.CreatedAt.UnixMilli(),
+		UpdatedAt:   b.UpdatedAt.UnixMilli(),
+	}
+}
+
+// fromBlackoutModel converts GORM model to a blackout
+func fromBlackoutModel(model *models.BlackoutModel) *blackout.Blackout {
+	return &blackout.Blackout{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		StartsAt:    time.UnixMilli(model.StartsAt),
+		EndsAt:      time.UnixMilli(model.EndsAt),
+		CreatedAt:   time.UnixMilli(model.CreatedAt),
+		UpdatedAt:   time.UnixMilli(model.UpdatedAt),
+	}
+}
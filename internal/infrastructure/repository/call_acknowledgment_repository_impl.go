@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"notification/internal/domain/message"
+	"notification/internal/infrastructure/models"
+)
+
+// CallAcknowledgmentRepositoryImpl implements message.CallAcknowledgmentRepository interface using GORM
+type CallAcknowledgmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCallAcknowledgmentRepositoryImpl creates a new call acknowledgment repository implementation
+func NewCallAcknowledgmentRepositoryImpl(db *gorm.DB) *CallAcknowledgmentRepositoryImpl {
+	return &CallAcknowledgmentRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save records an acknowledgment, replacing an earlier one of the same call
+func (r *CallAcknowledgmentRepositoryImpl) Save(ctx context.Context, ack *message.CallAcknowledgment) error {
+	model := &models.CallAcknowledgmentModel{
+		CallSID:        ack.CallSID,
+		ChannelID:      ack.ChannelID,
+		Digits:         ack.Digits,
+		AcknowledgedAt: ack.AcknowledgedAt,
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "call_sid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"digits", "acknowledged_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("failed to save call acknowledgment: %w", err)
+	}
+
+	return nil
+}
+
+// FindByCallSID finds the acknowledgment of a call
+func (r *CallAcknowledgmentRepositoryImpl) FindByCallSID(ctx context.Context, callSID string) (*message.CallAcknowledgment, error) {
+	var model models.CallAcknowledgmentModel
+	err := r.db.WithContext(ctx).Where("call_sid = ?", callSID).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find call acknowledgment: %w", err)
+	}
+
+	return &message.CallAcknowledgment{
+		CallSID:        model.CallSID,
+		ChannelID:      model.ChannelID,
+		Digits:         model.Digits,
+		AcknowledgedAt: model.AcknowledgedAt,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"notification/internal/domain/category"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// CategoryRepositoryImpl implements category.CategoryRepository interface using GORM
+type CategoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepositoryImpl creates a new category repository implementation
+func NewCategoryRepositoryImpl(db *gorm.DB) *CategoryRepositoryImpl {
+	return &CategoryRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a new category to the database
+func (r *CategoryRepositoryImpl) Save(ctx context.Context, c *category.Category) error {
+	if err := r.db.WithContext(ctx).Create(r.toCategoryModel(c)).Error; err != nil {
+		return fmt.Errorf("failed to save category: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates a category in the database
+func (r *CategoryRepositoryImpl) Update(ctx context.Context, c *category.Category) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.CategoryModel{}).
+		Where("name = ?", c.Name).
+		Updates(map[string]interface{}{
+			"description":        c.Description,
+			"default_subscribed": c.DefaultSubscribed,
+			"updated_at":         c.UpdatedAt.UnixMilli(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update category: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("CATEGORY_NOT_FOUND", "category not found")
+	}
+
+	return nil
+}
+
+// FindByName finds a category by its name
+func (r *CategoryRepositoryImpl) FindByName(ctx context.Context, name string) (*category.Category, error) {
+	var model models.CategoryModel
+
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, shared.NewNotFoundError("CATEGORY_NOT_FOUND", "category not found")
+		}
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	return r.fromCategoryModel(&model), nil
+}
+
+// FindAll finds every category, ordered by name
+func (r *CategoryRepositoryImpl) FindAll(ctx context.Context) ([]*category.Category, error) {
+	var categoryModels []models.CategoryModel
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&categoryModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+
+	categories := make([]*category.Category, 0, len(categoryModels))
+	for _, model := range categoryModels {
+		categories = append(categories, r.fromCategoryModel(&model))
+	}
+
+	return categories, nil
+}
+
+// Delete deletes a category and its subscriptions
+func (r *CategoryRepositoryImpl) Delete(ctx context.Context, name string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category = ?", name).Delete(&models.CategorySubscriptionModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete category subscriptions: %w", err)
+		}
+
+		result := tx.Where("name = ?", name).Delete(&models.CategoryModel{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete category: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return shared.NewNotFoundError("CATEGORY_NOT_FOUND", "category not found")
+		}
+		return nil
+	})
+}
+
+// toCategoryModel converts a category to GORM model
+func (r *CategoryRepositoryImpl) toCategoryModel(c *category.Category) *models.CategoryModel {
+	return &models.CategoryModel{
+		Name:              c.Name,
+		Description:       c.Description,
+		DefaultSubscribed: c.DefaultSubscribed,
+		CreatedAt:         c.CreatedAt.UnixMilli(),
+		UpdatedAt:         c.UpdatedAt.UnixMilli(),
+	}
+}
+
+// fromCategoryModel converts GORM model to a category
+func (r *CategoryRepositoryImpl) fromCategoryModel(model *models.CategoryModel) *category.Category {
+	return &category.Category{
+		Name:              model.Name,
+		Description:       model.Description,
+		DefaultSubscribed: model.DefaultSubscribed,
+		CreatedAt:         time.UnixMilli(model.CreatedAt),
+		UpdatedAt:         time.UnixMilli(model.UpdatedAt),
+	}
+}
+
+// CategorySubscriptionRepositoryImpl implements category.SubscriptionRepository interface using GORM
+type CategorySubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCategorySubscriptionRepositoryImpl creates a new category subscription repository implementation
+func NewCategorySubscriptionRepositoryImpl(db *gorm.DB) *CategorySubscriptionRepositoryImpl {
+	return &CategorySubscriptionRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves the subscription of a user to a category, replacing the earlier one
+func (r *CategorySubscriptionRepositoryImpl) Save(ctx context.Context, subscription *category.Subscription) error {
+	model := &models.CategorySubscriptionModel{
+		UserID:     subscription.UserID,
+		Category:   subscription.Category,
+		Subscribed: subscription.Subscribed,
+		UpdatedAt:  subscription.UpdatedAt.UnixMilli(),
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "category"}},
+		DoUpdates: clause.AssignmentColumns([]string{"subscribed", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("failed to save category subscription: %w", err)
+	}
+
+	return nil
+}
+
+// FindByUserID finds the subscriptions of a user, ordered by category
+func (r *CategorySubscriptionRepositoryImpl) FindByUserID(ctx context.Context, userID string) ([]*category.Subscription, error) {
+	var subscriptionModels []models.CategorySubscriptionModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("category ASC").
+		Find(&subscriptionModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category subscriptions: %w", err)
+	}
+
+	subscriptions := make([]*category.Subscription, 0, len(subscriptionModels))
+	for _, model := range subscriptionModels {
+		subscriptions = append(subscriptions, r.fromSubscriptionModel(&model))
+	}
+
+	return subscriptions, nil
+}
+
+// FindByCategory finds the subscriptions of the given users to a category
+func (r *CategorySubscriptionRepositoryImpl) FindByCategory(ctx context.Context, categoryName string, userIDs []string) (map[string]*category.Subscription, error) {
+	subscriptions := make(map[string]*category.Subscription)
+	if len(userIDs) == 0 {
+		return subscriptions, nil
+	}
+
+	var subscriptionModels []models.CategorySubscriptionModel
+	err := r.db.WithContext(ctx).
+		Where("category = ? AND user_id IN ?", categoryName, userIDs).
+		Find(&subscriptionModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category subscriptions: %w", err)
+	}
+
+	for _, model := range subscriptionModels {
+		subscriptions[model.UserID] = r.fromSubscriptionModel(&model)
+	}
+
+	return subscriptions, nil
+}
+
+// fromSubscriptionModel converts GORM model to a subscription
+func (r *CategorySubscriptionRepositoryImpl) fromSubscriptionModel(model *models.CategorySubscriptionModel) *category.Subscription {
+	return &category.Subscription{
+		UserID:     model.UserID,
+		Category:   model.Category,
+		Subscribed: model.Subscribed,
+		UpdatedAt:  time.UnixMilli(model.UpdatedAt),
+	}
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/channelgroup"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// ChannelGroupRepositoryImpl implements channelgroup.ChannelGroupRepository interface using GORM
+type ChannelGroupRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewChannelGroupRepositoryImpl creates a new channel group repository implementation
+func NewChannelGroupRepositoryImpl(db *gorm.DB) *ChannelGroupRepositoryImpl {
+	return &ChannelGroupRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a channel group to the database
+func (r *ChannelGroupRepositoryImpl) Save(ctx context.Context, group *channelgroup.ChannelGroup) error {
+	if err := r.db.WithContext(ctx).Create(r.toChannelGroupModel(group)).Error; err != nil {
+		return fmt.Errorf("failed to save channel group: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a channel group by its ID
+func (r *ChannelGroupRepositoryImpl) FindByID(ctx context.Context, id *channelgroup.ChannelGroupID) (*channelgroup.ChannelGroup, error) {
+	var model models.ChannelGroupModel
+
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", id.String()).
+		First(&model).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, shared.NewNotFoundError("CHANNEL_GROUP_NOT_FOUND", "channel group not found")
+		}
+		return nil, fmt.Errorf("failed to find channel group: %w", err)
+	}
+
+	return r.fromChannelGroupModel(&model)
+}
+
+// FindAll finds all channel groups with pagination, ordered by name
+func (r *ChannelGroupRepositoryImpl) FindAll(ctx context.Context, pagination *shared.Pagination) (*shared.PaginatedResult[*channelgroup.ChannelGroup], error) {
+	query := r.db.WithContext(ctx).Model(&models.ChannelGroupModel{}).Where("deleted_at IS NULL")
+
+	// Count total records
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count channel groups: %w", err)
+	}
+
+	// Query channel groups with pagination
+	var groupModels []models.ChannelGroupModel
+	err := query.
+		Order("name ASC").
+		Limit(pagination.MaxResultCount).
+		Offset(pagination.SkipCount).
+		Find(&groupModels).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channel groups: %w", err)
+	}
+
+	// Convert to domain objects
+	groups := make([]*channelgroup.ChannelGroup, 0, len(groupModels))
+	for _, model := range groupModels {
+		group, err := r.fromChannelGroupModel(&model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to channel group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return &shared.PaginatedResult[*channelgroup.ChannelGroup]{
+		Items:          groups,
+		SkipCount:      pagination.SkipCount,
+		MaxResultCount: pagination.MaxResultCount,
+		TotalCount:     int(totalCount),
+		HasMore:        pagination.SkipCount+len(groups) < int(totalCount),
+	}, nil
+}
+
+// Update updates a channel group in the database
+func (r *ChannelGroupRepositoryImpl) Update(ctx context.Context, group *channelgroup.ChannelGroup) error {
+	if err := r.db.WithContext(ctx).Save(r.toChannelGroupModel(group)).Error; err != nil {
+		return fmt.Errorf("failed to update channel group: %w", err)
+	}
+
+	return nil
+}
+
+// ExistsByName checks if a channel group with the specified name exists
+func (r *ChannelGroupRepositoryImpl) ExistsByName(ctx context.Context, name *channelgroup.GroupName) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.ChannelGroupModel{}).
+		Where("name = ? AND deleted_at IS NULL", name.String()).
+		Count(&count).Error
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check channel group name existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// toChannelGroupModel converts domain channel group to GORM model
+func (r *ChannelGroupRepositoryImpl) toChannelGroupModel(group *channelgroup.ChannelGroup) *models.ChannelGroupModel {
+	return &models.ChannelGroupModel{
+		ID:          group.ID().String(),
+		Name:        group.Name().String(),
+		Description: group.Description().String(),
+		ChannelIDs:  pq.StringArray(group.Members().Strings()),
+		CreatedAt:   group.Timestamps().CreatedAt,
+		UpdatedAt:   group.Timestamps().UpdatedAt,
+		DeletedAt:   group.Timestamps().DeletedAt,
+	}
+}
+
+// fromChannelGroupModel converts GORM model to domain channel group
+func (r *ChannelGroupRepositoryImpl) fromChannelGroupModel(model *models.ChannelGroupModel) (*channelgroup.ChannelGroup, error) {
+	id, err := channelgroup.NewChannelGroupIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel group ID: %w", err)
+	}
+
+	name, err := channelgroup.NewGroupName(model.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group name: %w", err)
+	}
+
+	description, err := channel.NewDescription(model.Description)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+
+	channelIDs := make([]*channel.ChannelID, 0, len(model.ChannelIDs))
+	for _, value := range model.ChannelIDs {
+		channelID, err := channel.NewChannelIDFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid member channel ID: %w", err)
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+	members, err := channelgroup.NewMembers(channelIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid members: %w", err)
+	}
+
+	return channelgroup.ReconstructChannelGroup(
+		id,
+		name,
+		description,
+		members,
+		&shared.Timestamps{
+			CreatedAt: model.CreatedAt,
+			UpdatedAt: model.UpdatedAt,
+			DeletedAt: model.DeletedAt,
+		},
+	), nil
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 	"gorm.io/gorm"
@@ -12,11 +14,21 @@ import (
 	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 	"notification/internal/infrastructure/models"
+	"notification/pkg/pii"
 )
 
+// errMissingTemplate is returned when a channel references a template that does not exist
+var errMissingTemplate = shared.NewValidationError("TEMPLATE_NOT_FOUND", errors.New("referenced template does not exist"))
+
+// errChannelModified is returned when a conditional write finds the channel
+// at another version than the one the request was checked against
+var errChannelModified = shared.NewPreconditionFailedError("PRECONDITION_FAILED", "channel has been modified since it was last retrieved")
+
 // ChannelRepositoryImpl implements channel.ChannelRepository interface using GORM
 type ChannelRepositoryImpl struct {
 	db *gorm.DB
+	// piiCipher encrypts the recipient targets, nil storing them in plain text
+	piiCipher *pii.Cipher
 }
 
 // NewChannelRepositoryImpl creates a new channel repository implementation
@@ -26,6 +38,12 @@ func NewChannelRepositoryImpl(db *gorm.DB) *ChannelRepositoryImpl {
 	}
 }
 
+// SetPIICipher encrypts the recipient targets of the channels saved from now
+// on and decrypts the ones read.
+func (r *ChannelRepositoryImpl) SetPIICipher(cipher *pii.Cipher) {
+	r.piiCipher = cipher
+}
+
 // Save saves a channel to the database
 func (r *ChannelRepositoryImpl) Save(ctx context.Context, ch *channel.Channel) error {
 	model, err := r.toChannelModel(ch)
@@ -33,7 +51,16 @@ func (r *ChannelRepositoryImpl) Save(ctx context.Context, ch *channel.Channel) e
 		return fmt.Errorf("failed to convert channel to model: %w", err)
 	}
 
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return saveChannelTags(tx, model.ID, model.Tags)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return errMissingTemplate
+		}
 		return fmt.Errorf("failed to save channel: %w", err)
 	}
 
@@ -50,7 +77,7 @@ func (r *ChannelRepositoryImpl) FindByID(ctx context.Context, id *channel.Channe
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("channel not found")
+			return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")
 		}
 		return nil, fmt.Errorf("failed to find channel: %w", err)
 	}
@@ -68,7 +95,7 @@ func (r *ChannelRepositoryImpl) FindByName(ctx context.Context, name *channel.Ch
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("channel not found")
+			return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")
 		}
 		return nil, fmt.Errorf("failed to find channel: %w", err)
 	}
@@ -85,22 +112,45 @@ func (r *ChannelRepositoryImpl) FindAll(ctx context.Context, filter *channel.Cha
 		query = query.Where("channel_type = ?", filter.ChannelType.String())
 	}
 
+	// Tag filters look the channels up in channel_tags on every database
 	if filter.HasTagsFilter() {
-		// For PostgreSQL, use array overlap operator
-		if r.db.Dialector.Name() == "postgres" {
-			query = query.Where("tags && ?", pq.StringArray(filter.Tags))
-		} else {
-			// For other databases, use JSON contains logic
-			for _, tag := range filter.Tags {
-				query = query.Where("JSON_EXTRACT(tags, '$') LIKE ?", "%"+tag+"%")
-			}
-		}
+		query = query.Where("id IN (?)", channelsByTag(r.db.WithContext(ctx), filter.Tags, false))
+	}
+
+	if filter.HasAllTagsFilter() {
+		query = query.Where("id IN (?)", channelsByTag(r.db.WithContext(ctx), filter.AllTags, true))
 	}
 
 	if filter.HasEnabledFilter() {
 		query = query.Where("enabled = ?", *filter.Enabled)
 	}
 
+	if filter.HasTemplateFilter() {
+		query = query.Where("template_id = ?", filter.TemplateID.String())
+	}
+
+	if filter.HasOwnerFilter() {
+		query = query.Where("owner = ?", filter.Owner)
+	}
+
+	if filter.HasTeamFilter() {
+		query = query.Where("team = ?", filter.Team)
+	}
+
+	if filter.HasEnvironmentFilter() {
+		// Unscoped channels serve every environment
+		query = query.Where("environment IN (?, '')", filter.Environment.String())
+	}
+
+	if filter.LastUsedBefore != nil {
+		// Channels that have never been used count as unused since before any timestamp
+		query = query.Where("(last_used IS NULL OR last_used < ?)", *filter.LastUsedBefore)
+	}
+
+	if filter.LastUsedAfter != nil {
+		query = query.Where("last_used >= ?", *filter.LastUsedAfter)
+	}
+
 	// Count total records
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
@@ -110,7 +160,7 @@ func (r *ChannelRepositoryImpl) FindAll(ctx context.Context, filter *channel.Cha
 	// Query channels with pagination
 	var channelModels []models.ChannelModel
 	err := query.
-		Order("created_at DESC").
+		Order(channelOrderClause(filter)).
 		Limit(pagination.MaxResultCount).
 		Offset(pagination.SkipCount).
 		Find(&channelModels).Error
@@ -148,16 +198,152 @@ func (r *ChannelRepositoryImpl) Update(ctx context.Context, ch *channel.Channel)
 		return fmt.Errorf("failed to convert channel to model: %w", err)
 	}
 
-	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A conditional request only writes the version it was checked against
+		if expected, ok := shared.ExpectedVersionFromContext(ctx, model.ID); ok {
+			result := tx.Model(&models.ChannelModel{}).
+				Where("id = ? AND version = ?", model.ID, expected).
+				UpdateColumn("version", model.Version)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errChannelModified
+			}
+		}
+		// The version is only ever raised, so that saving a channel read
+		// before a concurrent command does not roll it back
+		if err := tx.Omit("version").Save(model).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ChannelModel{}).
+			Where("id = ? AND version < ?", model.ID, model.Version).
+			UpdateColumn("version", model.Version).Error; err != nil {
+			return err
+		}
+		return saveChannelTags(tx, model.ID, model.Tags)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return errMissingTemplate
+		}
+		if errors.Is(err, errChannelModified) {
+			return err
+		}
 		return fmt.Errorf("failed to update channel: %w", err)
 	}
 
 	return nil
 }
 
+// channelSortColumns maps sortable channel fields to their database columns
+var channelSortColumns = map[string]string{
+	"channelName": "name",
+	"channelType": "channel_type",
+	"enabled":     "enabled",
+	"createdAt":   "created_at",
+	"updatedAt":   "updated_at",
+	"lastUsed":    "last_used",
+}
+
+// channelOrderClause builds the ORDER BY clause for a channel filter.
+// Unknown fields fall back to the default newest-first ordering; the ID is
+// always appended so that pages are stable when sort values tie.
+func channelOrderClause(filter *channel.ChannelFilter) string {
+	column, ok := channelSortColumns[filter.SortField]
+	if !filter.HasSorting() || !ok {
+		return "created_at DESC, id ASC"
+	}
+
+	direction := "ASC"
+	if filter.SortOrder == channel.SortOrderDesc {
+		direction = "DESC"
+	}
+	return column + " " + direction + ", id ASC"
+}
+
+// UpdateEnabled updates the enabled flag and the health of a channel in a
+// single statement, leaving the rest of the row untouched. The version only
+// moves forward, with a CASE rather than GREATEST, which SQLite lacks.
+func (r *ChannelRepositoryImpl) UpdateEnabled(ctx context.Context, ch *channel.Channel) error {
+	query := r.db.WithContext(ctx).
+		Model(&models.ChannelModel{}).
+		Where("id = ? AND deleted_at IS NULL", ch.ID().String())
+	expected, conditional := shared.ExpectedVersionFromContext(ctx, ch.ID().String())
+	if conditional {
+		query = query.Where("version = ?", expected)
+	}
+	result := query.
+		Updates(map[string]interface{}{
+			"enabled":           ch.IsEnabled(),
+			"health_status":     string(ch.Health().Status),
+			"health_successes":  ch.Health().Successes,
+			"health_failures":   ch.Health().Failures,
+			"health_since":      ch.Health().Since,
+			"health_checked_at": ch.Health().CheckedAt,
+			"updated_at":        ch.Timestamps().UpdatedAt,
+			"version":           gorm.Expr("CASE WHEN version < ? THEN ? ELSE version END", ch.Version(), ch.Version()),
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update channel enabled flag: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if conditional {
+			return errChannelModified
+		}
+		return shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")
+	}
+
+	return nil
+}
+
+// ReassignTemplate moves all channel references from one template to another in a single statement
+func (r *ChannelRepositoryImpl) ReassignTemplate(ctx context.Context, from *template.TemplateID, to *template.TemplateID) error {
+	var target *string
+	if to != nil {
+		id := to.String()
+		target = &id
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&models.ChannelModel{}).
+		Where("template_id = ?", from.String()).
+		Updates(map[string]interface{}{
+			"template_id": target,
+			"updated_at":  time.Now().UnixMilli(),
+			"version":     gorm.Expr("version + 1"),
+		}).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return errMissingTemplate
+		}
+		return fmt.Errorf("failed to reassign channel template: %w", err)
+	}
+
+	return nil
+}
+
+// CountTags returns the number of channels carrying each tag
+func (r *ChannelRepositoryImpl) CountTags(ctx context.Context) (map[string]int, error) {
+	return countTags(r.db.WithContext(ctx), &models.ChannelModel{})
+}
+
+// ReplaceTags replaces the given tags with another tag on every channel carrying one of them
+func (r *ChannelRepositoryImpl) ReplaceTags(ctx context.Context, from []string, to string) (int, error) {
+	return replaceTags(r.db.WithContext(ctx), &models.ChannelModel{}, from, to, saveChannelTags)
+}
+
 // Delete deletes a channel from the database (hard delete)
 func (r *ChannelRepositoryImpl) Delete(ctx context.Context, id *channel.ChannelID) error {
-	if err := r.db.WithContext(ctx).Delete(&models.ChannelModel{}, "id = ?", id.String()).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ChannelTagModel{}, "channel_id = ?", id.String()).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ChannelModel{}, "id = ?", id.String()).Error
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete channel: %w", err)
 	}
 
@@ -209,6 +395,9 @@ func (r *ChannelRepositoryImpl) toChannelModel(ch *channel.Channel) (*models.Cha
 	if err := json.Unmarshal(recipientData, &recipients); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal recipients to JSONArray type: %w", err)
 	}
+	if err := sealTargets(r.piiCipher, recipients); err != nil {
+		return nil, err
+	}
 
 	// Handle template ID
 	var templateID *string
@@ -217,6 +406,13 @@ func (r *ChannelRepositoryImpl) toChannelModel(ch *channel.Channel) (*models.Cha
 		templateID = &id
 	}
 
+	// Handle fallback channel ID
+	var fallbackChannelID *string
+	if ch.FallbackChannelID() != nil {
+		id := ch.FallbackChannelID().String()
+		fallbackChannelID = &id
+	}
+
 	// Handle deleted_at
 	var deletedAt *int64
 	if ch.Timestamps().DeletedAt != nil {
@@ -224,22 +420,34 @@ func (r *ChannelRepositoryImpl) toChannelModel(ch *channel.Channel) (*models.Cha
 	}
 
 	return &models.ChannelModel{
-		ID:            ch.ID().String(),
-		Name:          ch.Name().String(),
-		Description:   ch.Description().String(),
-		Enabled:       ch.IsEnabled(),
-		ChannelType:   ch.ChannelType().String(),
-		TemplateID:    templateID,
-		Timeout:       ch.CommonSettings().Timeout,
-		RetryAttempts: ch.CommonSettings().RetryAttempts,
-		RetryDelay:    ch.CommonSettings().RetryDelay,
-		Config:        config,
-		Recipients:    recipients,
-		Tags:          pq.StringArray(ch.Tags().ToSlice()),
-		CreatedAt:     ch.Timestamps().CreatedAt,
-		UpdatedAt:     ch.Timestamps().UpdatedAt,
-		DeletedAt:     deletedAt,
-		LastUsed:      ch.LastUsed(),
+		ID:                ch.ID().String(),
+		Name:              ch.Name().String(),
+		Description:       ch.Description().String(),
+		Enabled:           ch.IsEnabled(),
+		Maintenance:       ch.InMaintenance(),
+		ChannelType:       ch.ChannelType().String(),
+		TemplateID:        templateID,
+		Timeout:           ch.CommonSettings().Timeout,
+		RetryAttempts:     ch.CommonSettings().RetryAttempts,
+		RetryDelay:        ch.CommonSettings().RetryDelay,
+		Config:            config,
+		Recipients:        recipients,
+		Tags:              pq.StringArray(ch.Tags().ToSlice()),
+		VariableDefaults:  models.JSON(ch.VariableDefaults().ToMap()),
+		Owner:             ch.Ownership().Owner,
+		Team:              ch.Ownership().Team,
+		Environment:       ch.Environment().String(),
+		FallbackChannelID: fallbackChannelID,
+		HealthStatus:      string(ch.Health().Status),
+		HealthSuccesses:   ch.Health().Successes,
+		HealthFailures:    ch.Health().Failures,
+		HealthSince:       ch.Health().Since,
+		HealthCheckedAt:   ch.Health().CheckedAt,
+		CreatedAt:         ch.Timestamps().CreatedAt,
+		UpdatedAt:         ch.Timestamps().UpdatedAt,
+		DeletedAt:         deletedAt,
+		LastUsed:          ch.LastUsed(),
+		Version:           ch.Version(),
 	}, nil
 }
 
@@ -289,6 +497,9 @@ func (r *ChannelRepositoryImpl) fromChannelModel(model *models.ChannelModel) (*c
 	config := channel.NewChannelConfig(configMap)
 
 	// Convert recipients
+	if err := openTargets(r.piiCipher, model.Recipients); err != nil {
+		return nil, fmt.Errorf("failed to decrypt recipients: %w", err)
+	}
 	var recipientSlice []*channel.Recipient
 	recipientData, err := json.Marshal(model.Recipients)
 	if err != nil {
@@ -302,6 +513,33 @@ func (r *ChannelRepositoryImpl) fromChannelModel(model *models.ChannelModel) (*c
 	// Convert tags
 	tags := channel.NewTags(model.Tags)
 
+	// Convert variable defaults
+	variableDefaults := channel.NewVariableDefaults(map[string]interface{}(model.VariableDefaults))
+
+	// Convert ownership
+	ownership := &shared.Ownership{Owner: model.Owner, Team: model.Team}
+
+	// Convert fallback channel ID
+	var fallbackChannelID *channel.ChannelID
+	if model.FallbackChannelID != nil {
+		fallbackChannelID, err = channel.NewChannelIDFromString(*model.FallbackChannelID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback channel ID: %w", err)
+		}
+	}
+
+	// Convert health
+	health := &channel.ChannelHealth{
+		Status:    channel.HealthStatus(model.HealthStatus),
+		Successes: model.HealthSuccesses,
+		Failures:  model.HealthFailures,
+		Since:     model.HealthSince,
+		CheckedAt: model.HealthCheckedAt,
+	}
+	if health.Status == "" {
+		health.Status = channel.HealthStatusHealthy
+	}
+
 	// Convert timestamps
 	timestamps := &shared.Timestamps{
 		CreatedAt: model.CreatedAt,
@@ -315,13 +553,20 @@ func (r *ChannelRepositoryImpl) fromChannelModel(model *models.ChannelModel) (*c
 		name,
 		description,
 		model.Enabled,
+		model.Maintenance,
 		channelType,
 		templateID,
 		commonSettings,
 		config,
 		recipients,
 		tags,
+		variableDefaults,
+		ownership,
+		shared.Environment(model.Environment),
+		fallbackChannelID,
+		health,
 		timestamps,
 		model.LastUsed,
+		model.Version,
 	), nil
 }
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"notification/internal/domain/template"
+	"notification/internal/infrastructure/models"
+)
+
+func newChannelRepository(t *testing.T) (*ChannelRepositoryImpl, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "channels.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.ChannelModel{}, &models.ChannelTagModel{}))
+	return NewChannelRepositoryImpl(db), db
+}
+
+// insertChannel stores a channel row at version 1
+func insertChannel(t *testing.T, db *gorm.DB, id string, templateID *string, tags ...string) {
+	t.Helper()
+	require.NoError(t, db.Create(&models.ChannelModel{
+		ID:               id,
+		Name:             id,
+		Enabled:          true,
+		ChannelType:      "email",
+		TemplateID:       templateID,
+		Timeout:          10,
+		Config:           models.JSON{},
+		Recipients:       models.JSONArray{},
+		Tags:             append(pq.StringArray{}, tags...),
+		VariableDefaults: models.JSON{},
+		CreatedAt:        1,
+		UpdatedAt:        1,
+		Version:          1,
+	}).Error)
+}
+
+func channelVersion(t *testing.T, db *gorm.DB, id string) int64 {
+	t.Helper()
+	var row models.ChannelModel
+	require.NoError(t, db.Select("version").Where("id = ?", id).First(&row).Error)
+	return row.Version
+}
+
+func TestChannelRepository_ReassignTemplateRaisesTheVersion(t *testing.T) {
+	repo, db := newChannelRepository(t)
+	from, err := template.NewTemplateIDFromString("11111111-1111-1111-1111-111111111111")
+	require.NoError(t, err)
+	to, err := template.NewTemplateIDFromString("22222222-2222-2222-2222-222222222222")
+	require.NoError(t, err)
+	fromID := from.String()
+	insertChannel(t, db, "reassigned", &fromID)
+	insertChannel(t, db, "untouched", nil)
+
+	require.NoError(t, repo.ReassignTemplate(context.Background(), from, to))
+	assert.Equal(t, int64(2), channelVersion(t, db, "reassigned"))
+	assert.Equal(t, int64(1), channelVersion(t, db, "untouched"))
+
+	require.NoError(t, repo.ReassignTemplate(context.Background(), to, nil))
+	assert.Equal(t, int64(3), channelVersion(t, db, "reassigned"))
+}
+
+func TestChannelRepository_ReplaceTagsRaisesTheVersion(t *testing.T) {
+	repo, db := newChannelRepository(t)
+	insertChannel(t, db, "renamed", nil, "ops", "critical")
+	insertChannel(t, db, "untouched", nil, "critical")
+
+	changed, err := repo.ReplaceTags(context.Background(), []string{"ops"}, "operations")
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, int64(2), channelVersion(t, db, "renamed"))
+	assert.Equal(t, int64(1), channelVersion(t, db, "untouched"))
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"notification/internal/infrastructure/models"
+)
+
+// channelsByTag returns the subquery selecting the IDs of the channels
+// carrying any of the tags, or every one of them when all is set. It reads
+// the channel_tags lookup, whose primary key leads with the tag.
+func channelsByTag(db *gorm.DB, tags []string, all bool) *gorm.DB {
+	unique := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+
+	query := db.Model(&models.ChannelTagModel{}).Select("channel_id").Where("tag IN ?", unique)
+	if all {
+		query = query.Group("channel_id").Having("COUNT(*) = ?", len(unique))
+	}
+	return query
+}
+
+// saveChannelTags replaces the rows of the channel in the channel_tags lookup
+func saveChannelTags(tx *gorm.DB, channelID string, tags []string) error {
+	if err := tx.Where("channel_id = ?", channelID).Delete(&models.ChannelTagModel{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	rows := make([]models.ChannelTagModel, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, models.ChannelTagModel{Tag: tag, ChannelID: channelID})
+	}
+	return tx.Create(&rows).Error
+}
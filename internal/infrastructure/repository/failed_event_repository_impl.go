@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/failedevent"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// FailedEventRepositoryImpl implements failedevent.FailedEventRepository interface using GORM
+type FailedEventRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFailedEventRepositoryImpl creates a new failed event repository implementation
+func NewFailedEventRepositoryImpl(db *gorm.DB) *FailedEventRepositoryImpl {
+	return &FailedEventRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a failed event to the database
+func (r *FailedEventRepositoryImpl) Save(ctx context.Context, failed *failedevent.FailedEvent) error {
+	if err := r.db.WithContext(ctx).Create(r.toFailedEventModel(failed)).Error; err != nil {
+		return fmt.Errorf("failed to save failed event: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates a failed event in the database
+func (r *FailedEventRepositoryImpl) Update(ctx context.Context, failed *failedevent.FailedEvent) error {
+	if err := r.db.WithContext(ctx).Save(r.toFailedEventModel(failed)).Error; err != nil {
+		return fmt.Errorf("failed to update failed event: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a failed event by its ID
+func (r *FailedEventRepositoryImpl) FindByID(ctx context.Context, id string) (*failedevent.FailedEvent, error) {
+	var model models.FailedEventModel
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, shared.NewNotFoundError("FAILED_EVENT_NOT_FOUND", "failed event not found")
+		}
+		return nil, fmt.Errorf("failed to find failed event: %w", err)
+	}
+
+	return r.fromFailedEventModel(&model), nil
+}
+
+// FindAll finds failed events with filtering and pagination, most recent first
+func (r *FailedEventRepositoryImpl) FindAll(ctx context.Context, filter failedevent.Filter, pagination *shared.Pagination) (*shared.PaginatedResult[*failedevent.FailedEvent], error) {
+	query := r.db.WithContext(ctx).Model(&models.FailedEventModel{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+
+	// Count total records
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count failed events: %w", err)
+	}
+
+	// Query failed events with pagination
+	var eventModels []models.FailedEventModel
+	err := query.
+		Order("failed_at DESC").
+		Limit(pagination.MaxResultCount).
+		Offset(pagination.SkipCount).
+		Find(&eventModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failed events: %w", err)
+	}
+
+	events := make([]*failedevent.FailedEvent, 0, len(eventModels))
+	for _, model := range eventModels {
+		events = append(events, r.fromFailedEventModel(&model))
+	}
+
+	return &shared.PaginatedResult[*failedevent.FailedEvent]{
+		Items:          events,
+		SkipCount:      pagination.SkipCount,
+		MaxResultCount: pagination.MaxResultCount,
+		TotalCount:     int(totalCount),
+		HasMore:        pagination.SkipCount+len(events) < int(totalCount),
+	}, nil
+}
+
+// toFailedEventModel converts a failed event to GORM model
+func (r *FailedEventRepositoryImpl) toFailedEventModel(failed *failedevent.FailedEvent) *models.FailedEventModel {
+	payload := string(failed.Payload)
+	if !json.Valid(failed.Payload) {
+		payload = "null"
+	}
+
+	model := &models.FailedEventModel{
+		ID:            failed.ID,
+		EventID:       failed.EventID,
+		EventType:     failed.EventType,
+		AggregateID:   failed.AggregateID,
+		AggregateType: failed.AggregateType,
+		Version:       failed.Version,
+		Payload:       payload,
+		Handler:       failed.Handler,
+		Attempts:      failed.Attempts,
+		Error:         failed.Error,
+		Status:        string(failed.Status),
+		ReplayCount:   failed.ReplayCount,
+		FailedAt:      failed.FailedAt.UnixMilli(),
+	}
+	if failed.ReplayedAt != nil {
+		replayedAt := failed.ReplayedAt.UnixMilli()
+		model.ReplayedAt = &replayedAt
+	}
+	return model
+}
+
+// fromFailedEventModel converts GORM model to a failed event
+func (r *FailedEventRepositoryImpl) fromFailedEventModel(model *models.FailedEventModel) *failedevent.FailedEvent {
+	failed := &failedevent.FailedEvent{
+		ID:            model.ID,
+		EventID:       model.EventID,
+		EventType:     model.EventType,
+		AggregateID:   model.AggregateID,
+		AggregateType: model.AggregateType,
+		Version:       model.Version,
+		Payload:       json.RawMessage(model.Payload),
+		Handler:       model.Handler,
+		Attempts:      model.Attempts,
+		Error:         model.Error,
+		Status:        failedevent.Status(model.Status),
+		ReplayCount:   model.ReplayCount,
+		FailedAt:      time.UnixMilli(model.FailedAt),
+	}
+	if model.ReplayedAt != nil {
+		replayedAt := time.UnixMilli(*model.ReplayedAt)
+		failed.ReplayedAt = &replayedAt
+	}
+	return failed
+}
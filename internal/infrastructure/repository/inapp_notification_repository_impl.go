@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/inapp"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// InAppNotificationRepositoryImpl implements inapp.NotificationRepository interface using GORM
+type InAppNotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewInAppNotificationRepositoryImpl creates a new in-app notification repository implementation
+func NewInAppNotificationRepositoryImpl(db *gorm.DB) *InAppNotificationRepositoryImpl {
+	return &InAppNotificationRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a notification to the database
+func (r *InAppNotificationRepositoryImpl) Save(ctx context.Context, notification *inapp.Notification) error {
+	if err := r.db.WithContext(ctx).Create(r.toNotificationModel(notification)).Error; err != nil {
+		return fmt.Errorf("failed to save in-app notification: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a notification of a user by its ID
+func (r *InAppNotificationRepositoryImpl) FindByID(ctx context.Context, userID, id string) (*inapp.Notification, error) {
+	var model models.InAppNotificationModel
+
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, shared.NewNotFoundError("NOTIFICATION_NOT_FOUND", "notification not found")
+		}
+		return nil, fmt.Errorf("failed to find in-app notification: %w", err)
+	}
+
+	return r.fromNotificationModel(&model), nil
+}
+
+// FindAll finds the notifications of a user with filtering and pagination, most recent first
+func (r *InAppNotificationRepositoryImpl) FindAll(ctx context.Context, filter inapp.Filter, pagination *shared.Pagination) (*shared.PaginatedResult[*inapp.Notification], error) {
+	query := r.db.WithContext(ctx).Model(&models.InAppNotificationModel{}).Where("user_id = ?", filter.UserID)
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+
+	// Count total records
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count in-app notifications: %w", err)
+	}
+
+	// Query notifications with pagination
+	var notificationModels []models.InAppNotificationModel
+	err := query.
+		Order("created_at DESC").
+		Limit(pagination.MaxResultCount).
+		Offset(pagination.SkipCount).
+		Find(&notificationModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query in-app notifications: %w", err)
+	}
+
+	notifications := make([]*inapp.Notification, 0, len(notificationModels))
+	for _, model := range notificationModels {
+		notifications = append(notifications, r.fromNotificationModel(&model))
+	}
+
+	return &shared.PaginatedResult[*inapp.Notification]{
+		Items:          notifications,
+		SkipCount:      pagination.SkipCount,
+		MaxResultCount: pagination.MaxResultCount,
+		TotalCount:     int(totalCount),
+		HasMore:        pagination.SkipCount+len(notifications) < int(totalCount),
+	}, nil
+}
+
+// CountUnread counts the unread notifications of a user
+func (r *InAppNotificationRepositoryImpl) CountUnread(ctx context.Context, userID string) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.InAppNotificationModel{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread in-app notifications: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// MarkRead marks the unread notifications of a user read, the given ones or
+// every one when ids is empty
+func (r *InAppNotificationRepositoryImpl) MarkRead(ctx context.Context, userID string, ids []string, readAt time.Time) (int, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.InAppNotificationModel{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	result := query.Update("read_at", readAt.UnixMilli())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark in-app notifications read: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// Delete deletes a notification of a user
+func (r *InAppNotificationRepositoryImpl) Delete(ctx context.Context, userID, id string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.InAppNotificationModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete in-app notification: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("NOTIFICATION_NOT_FOUND", "notification not found")
+	}
+
+	return nil
+}
+
+// toNotificationModel converts a notification to GORM model
+func (r *InAppNotificationRepositoryImpl) toNotificationModel(notification *inapp.Notification) *models.InAppNotificationModel {
+	model := &models.InAppNotificationModel{
+		ID:            notification.ID,
+		UserID:        notification.UserID,
+		ChannelID:     notification.ChannelID,
+		Category:      notification.Category,
+		Title:         notification.Title,
+		Content:       notification.Content,
+		CorrelationID: notification.CorrelationID,
+		CreatedAt:     notification.CreatedAt.UnixMilli(),
+	}
+	if notification.ReadAt != nil {
+		readAt := notification.ReadAt.UnixMilli()
+		model.ReadAt = &readAt
+	}
+	return model
+}
+
+// fromNotificationModel converts GORM model to a notification
+func (r *InAppNotificationRepositoryImpl) fromNotificationModel(model *models.InAppNotificationModel) *inapp.Notification {
+	notification := &inapp.Notification{
+		ID:            model.ID,
+		UserID:        model.UserID,
+		ChannelID:     model.ChannelID,
+		Category:      model.Category,
+		Title:         model.Title,
+		Content:       model.Content,
+		CorrelationID: model.CorrelationID,
+		CreatedAt:     time.UnixMilli(model.CreatedAt),
+	}
+	if model.ReadAt != nil {
+		readAt := time.UnixMilli(*model.ReadAt)
+		notification.ReadAt = &readAt
+	}
+	return notification
+}
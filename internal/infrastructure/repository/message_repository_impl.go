@@ -6,15 +6,31 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"notification/internal/domain/channel"
 	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
 	"notification/internal/infrastructure/models"
+	"notification/pkg/pii"
 )
 
+// messageResultBatchSize bounds the rows of one INSERT of message results
+const messageResultBatchSize = 100
+
+// messageResultColumns are the columns of a message result row rewritten when
+// the result of its channel is saved again
+var messageResultColumns = []string{
+	"status", "message", "error_code", "error_details", "error_category",
+	"sent_at", "recipients", "cost", "fallback_from", "policy_decisions",
+	"attachment_scans",
+}
+
 // MessageRepositoryImpl implements message.MessageRepository interface using GORM
 type MessageRepositoryImpl struct {
 	db *gorm.DB
+	// piiCipher encrypts the recipient targets, nil storing them in plain text
+	piiCipher *pii.Cipher
 }
 
 // NewMessageRepositoryImpl creates a new message repository implementation
@@ -24,6 +40,12 @@ func NewMessageRepositoryImpl(db *gorm.DB) *MessageRepositoryImpl {
 	}
 }
 
+// SetPIICipher encrypts the recipient targets of the message overrides and
+// results saved from now on and decrypts the ones read.
+func (r *MessageRepositoryImpl) SetPIICipher(cipher *pii.Cipher) {
+	r.piiCipher = cipher
+}
+
 // Save saves a message to the database
 func (r *MessageRepositoryImpl) Save(ctx context.Context, msg *message.Message) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -39,18 +61,7 @@ func (r *MessageRepositoryImpl) Save(ctx context.Context, msg *message.Message)
 		}
 
 		// Save message results
-		for _, result := range msg.Results() {
-			resultModel, err := r.toMessageResultModel(msg.ID(), result)
-			if err != nil {
-				return fmt.Errorf("failed to convert message result to model: %w", err)
-			}
-
-			if err := tx.Create(resultModel).Error; err != nil {
-				return fmt.Errorf("failed to save message result: %w", err)
-			}
-		}
-
-		return nil
+		return r.saveResults(tx, msg)
 	})
 }
 
@@ -65,7 +76,7 @@ func (r *MessageRepositoryImpl) FindByID(ctx context.Context, id *message.Messag
 	
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("message not found")
+			return nil, shared.NewNotFoundError("MESSAGE_NOT_FOUND", "message not found")
 		}
 		return nil, fmt.Errorf("failed to find message: %w", err)
 	}
@@ -82,29 +93,26 @@ func (r *MessageRepositoryImpl) Update(ctx context.Context, msg *message.Message
 			return fmt.Errorf("failed to convert message to model: %w", err)
 		}
 
-		// Update message
-		if err := tx.Save(messageModel).Error; err != nil {
+		// Update message, keeping the mark of the retention job
+		if err := tx.Omit("content_purged_at").Save(messageModel).Error; err != nil {
 			return fmt.Errorf("failed to update message: %w", err)
 		}
 
-		// Delete existing results
-		if err := tx.Where("message_id = ?", msg.ID().String()).Delete(&models.MessageResultModel{}).Error; err != nil {
-			return fmt.Errorf("failed to delete existing message results: %w", err)
-		}
-
-		// Save updated results
+		// Delete the results of channels the message no longer has a result for
+		channelIDs := make([]string, 0, len(msg.Results()))
 		for _, result := range msg.Results() {
-			resultModel, err := r.toMessageResultModel(msg.ID(), result)
-			if err != nil {
-				return fmt.Errorf("failed to convert message result to model: %w", err)
-			}
-
-			if err := tx.Create(resultModel).Error; err != nil {
-				return fmt.Errorf("failed to save message result: %w", err)
-			}
+			channelIDs = append(channelIDs, result.ChannelID().String())
+		}
+		stale := tx.Where("message_id = ?", msg.ID().String())
+		if len(channelIDs) > 0 {
+			stale = stale.Where("channel_id NOT IN ?", channelIDs)
+		}
+		if err := stale.Delete(&models.MessageResultModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete stale message results: %w", err)
 		}
 
-		return nil
+		// Insert the new results and update the existing ones in place
+		return r.saveResults(tx, msg)
 	})
 }
 
@@ -123,6 +131,287 @@ func (r *MessageRepositoryImpl) Exists(ctx context.Context, id *message.MessageI
 	return count > 0, nil
 }
 
+// CountByChannelsSince counts messages sent to each channel since the given timestamp
+func (r *MessageRepositoryImpl) CountByChannelsSince(ctx context.Context, channelIDs []string, since int64) (map[string]int, error) {
+	counts := make(map[string]int, len(channelIDs))
+	if len(channelIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ChannelID string
+		Count     int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.MessageResultModel{}).
+		Select("message_results.channel_id AS channel_id, COUNT(*) AS count").
+		Joins("JOIN messages ON messages.id = message_results.message_id").
+		Where("message_results.channel_id IN ? AND messages.created_at >= ?", channelIDs, since).
+		Group("message_results.channel_id").
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count messages by channel: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.ChannelID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// FindHeldByChannel finds the messages with a result parked for the channel, oldest first
+func (r *MessageRepositoryImpl) FindHeldByChannel(ctx context.Context, channelID string) ([]*message.Message, error) {
+	var messageModels []models.MessageModel
+	err := r.db.WithContext(ctx).
+		Preload("Results").
+		Where("id IN (?)", r.db.Model(&models.MessageResultModel{}).
+			Select("message_id").
+			Where("channel_id = ? AND status = ?", channelID, string(message.MessageResultStatusHeld))).
+		Order("created_at ASC").
+		Find(&messageModels).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find held messages: %w", err)
+	}
+
+	messages := make([]*message.Message, 0, len(messageModels))
+	for i := range messageModels {
+		msg, err := r.fromMessageModel(&messageModels[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// CountRecentResults counts the successful and failed results of the last sends to a channel
+func (r *MessageRepositoryImpl) CountRecentResults(ctx context.Context, channelID string, since int64, limit int) (int, int, error) {
+	var statuses []string
+	err := r.db.WithContext(ctx).
+		Model(&models.MessageResultModel{}).
+		Joins("JOIN messages ON messages.id = message_results.message_id").
+		Where("message_results.channel_id = ? AND messages.created_at >= ?", channelID, since).
+		Order("messages.created_at DESC").
+		Limit(limit).
+		Pluck("message_results.status", &statuses).Error
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count recent results: %w", err)
+	}
+
+	successes, failures := 0, 0
+	for _, status := range statuses {
+		switch message.MessageResultStatus(status) {
+		case message.MessageResultStatusSuccess:
+			successes++
+		case message.MessageResultStatusFailed:
+			failures++
+		}
+	}
+
+	return successes, failures, nil
+}
+
+// SumCosts sums the estimated cost of the sends per group, most expensive first
+func (r *MessageRepositoryImpl) SumCosts(ctx context.Context, filter *message.CostFilter) ([]*message.CostTotal, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.MessageResultModel{}).
+		Joins("JOIN messages ON messages.id = message_results.message_id").
+		Where("message_results.status <> ?", string(message.MessageResultStatusHeld))
+
+	var groupKey string
+	switch filter.GroupBy {
+	case message.CostGroupByChannel:
+		groupKey = "message_results.channel_id"
+	case message.CostGroupByTenant:
+		groupKey = "messages.tenant_id"
+	case message.CostGroupByClass:
+		groupKey = "messages.message_class"
+	case message.CostGroupByTag:
+		query = query.
+			Joins("JOIN channels ON channels.id = message_results.channel_id").
+			Joins("CROSS JOIN unnest(channels.tags) AS channel_tag")
+		groupKey = "channel_tag"
+	default:
+		return nil, fmt.Errorf("unsupported cost grouping: %s", filter.GroupBy)
+	}
+
+	if filter.From > 0 {
+		query = query.Where("messages.created_at >= ?", filter.From)
+	}
+	if filter.To > 0 {
+		query = query.Where("messages.created_at < ?", filter.To)
+	}
+
+	var rows []struct {
+		GroupKey string
+		Sends    int
+		Cost     float64
+	}
+	err := query.
+		Select(groupKey + " AS group_key, COUNT(*) AS sends, COALESCE(SUM(message_results.cost), 0) AS cost").
+		Group(groupKey).
+		Order("cost DESC, group_key ASC").
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum costs: %w", err)
+	}
+
+	totals := make([]*message.CostTotal, 0, len(rows))
+	for _, row := range rows {
+		totals = append(totals, &message.CostTotal{
+			Key:   row.GroupKey,
+			Sends: row.Sends,
+			Cost:  row.Cost,
+		})
+	}
+
+	return totals, nil
+}
+
+// CountDispatched counts the messages created in a period and those of them
+// dispatched within a delay of their creation
+func (r *MessageRepositoryImpl) CountDispatched(ctx context.Context, from, to, within int64) (int, int, error) {
+	var row struct {
+		Total      int
+		Dispatched int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.MessageModel{}).
+		Select(`COUNT(*) AS total, COALESCE(SUM(CASE WHEN EXISTS (
+			SELECT 1 FROM message_results
+			WHERE message_results.message_id = messages.id
+			AND message_results.status = ?
+			AND message_results.sent_at <= messages.created_at + ?
+		) THEN 1 ELSE 0 END), 0) AS dispatched`, string(message.MessageResultStatusSuccess), within).
+		Where("messages.created_at >= ? AND messages.created_at < ?", from, to).
+		Where("messages.status <> ?", string(message.MessageStatusHeld)).
+		Scan(&row).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count dispatched messages: %w", err)
+	}
+	return row.Total, row.Dispatched, nil
+}
+
+// CountFailures counts the failed and held sends of the messages created in a period
+func (r *MessageRepositoryImpl) CountFailures(ctx context.Context, from, to int64) ([]*message.FailureCount, error) {
+	var rows []struct {
+		ChannelID string
+		Status    string
+		ErrorCode string
+		Count     int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.MessageResultModel{}).
+		Joins("JOIN messages ON messages.id = message_results.message_id").
+		Where("message_results.status IN ?", []string{
+			string(message.MessageResultStatusFailed),
+			string(message.MessageResultStatusHeld),
+		}).
+		Where("messages.created_at >= ? AND messages.created_at < ?", from, to).
+		Select("message_results.channel_id, message_results.status, COALESCE(message_results.error_code, '') AS error_code, COUNT(*) AS count").
+		Group("message_results.channel_id, message_results.status, COALESCE(message_results.error_code, '')").
+		Order("count DESC, message_results.channel_id ASC").
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failures: %w", err)
+	}
+
+	counts := make([]*message.FailureCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, &message.FailureCount{
+			ChannelID: row.ChannelID,
+			Status:    message.MessageResultStatus(row.Status),
+			ErrorCode: row.ErrorCode,
+			Count:     row.Count,
+		})
+	}
+
+	return counts, nil
+}
+
+// FindForExport finds a page of the messages matching the filter, oldest first, after the cursor
+func (r *MessageRepositoryImpl) FindForExport(ctx context.Context, filter *message.ExportFilter, after *message.ExportCursor, limit int) ([]*message.Message, error) {
+	query := r.db.WithContext(ctx).Preload("Results")
+
+	if filter.ChannelID != "" {
+		query = query.Where("id IN (?)", r.db.Model(&models.MessageResultModel{}).
+			Select("message_id").
+			Where("channel_id = ?", filter.ChannelID))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.TenantID != "" {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.From > 0 {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if filter.To > 0 {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if after != nil {
+		query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+
+	var messageModels []models.MessageModel
+	err := query.
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&messageModels).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find messages for export: %w", err)
+	}
+
+	messages := make([]*message.Message, 0, len(messageModels))
+	for i := range messageModels {
+		msg, err := r.fromMessageModel(&messageModels[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// saveResults writes the results of a message in batched INSERTs. A result is
+// unique per message and channel, so the row of a channel that already has
+// one is updated instead, keeping its ID.
+func (r *MessageRepositoryImpl) saveResults(tx *gorm.DB, msg *message.Message) error {
+	results := msg.Results()
+	if len(results) == 0 {
+		return nil
+	}
+
+	resultModels := make([]*models.MessageResultModel, 0, len(results))
+	for _, result := range results {
+		resultModel, err := r.toMessageResultModel(msg.ID(), result)
+		if err != nil {
+			return fmt.Errorf("failed to convert message result to model: %w", err)
+		}
+		resultModels = append(resultModels, resultModel)
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "message_id"}, {Name: "channel_id"}},
+		DoUpdates: clause.AssignmentColumns(messageResultColumns),
+	}).CreateInBatches(resultModels, messageResultBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("failed to save message results: %w", err)
+	}
+
+	return nil
+}
+
 // toMessageModel converts domain message to GORM model
 func (r *MessageRepositoryImpl) toMessageModel(msg *message.Message) (*models.MessageModel, error) {
 	// Convert channel IDs to JSONArray
@@ -148,12 +437,19 @@ func (r *MessageRepositoryImpl) toMessageModel(msg *message.Message) (*models.Me
 	if err := json.Unmarshal(overrideData, &channelOverrides); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal channel overrides to JSON type: %w", err)
 	}
+	if err := sealTargets(r.piiCipher, overrideRecipients(channelOverrides)); err != nil {
+		return nil, err
+	}
 
 	return &models.MessageModel{
 		ID:               msg.ID().String(),
 		ChannelIDs:       channelIDs,
 		Variables:        variables,
 		ChannelOverrides: channelOverrides,
+		CorrelationID:    msg.CorrelationID(),
+		TenantID:         msg.TenantID(),
+		MessageClass:     string(msg.Class()),
+		StrictRender:     msg.StrictRender(),
 		Status:           string(msg.Status()),
 		CreatedAt:        msg.CreatedAt(),
 	}, nil
@@ -167,6 +463,7 @@ func (r *MessageRepositoryImpl) toMessageResultModel(messageID *message.MessageI
 		Status:    string(result.Status()),
 		Message:   result.Message(),
 		SentAt:    result.SentAt(),
+		Cost:      result.Cost(),
 	}
 
 	// Handle error
@@ -175,6 +472,55 @@ func (r *MessageRepositoryImpl) toMessageResultModel(messageID *message.MessageI
 		errorDetails := result.Error().Details
 		model.ErrorCode = &errorCode
 		model.ErrorDetails = &errorDetails
+		if result.Error().Category != "" {
+			errorCategory := string(result.Error().Category)
+			model.ErrorCategory = &errorCategory
+		}
+	}
+
+	// Record the channel the send fell back from
+	if result.FallbackFrom() != nil {
+		fallbackFrom := result.FallbackFrom().String()
+		model.FallbackFrom = &fallbackFrom
+	}
+
+	// Convert recipient results to JSONArray
+	model.Recipients = models.JSONArray{}
+	if len(result.Recipients()) > 0 {
+		recipientData, err := json.Marshal(result.Recipients())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal recipient results: %w", err)
+		}
+		if err := json.Unmarshal(recipientData, &model.Recipients); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal recipient results: %w", err)
+		}
+		if err := sealTargets(r.piiCipher, model.Recipients); err != nil {
+			return nil, err
+		}
+	}
+
+	// Convert the policy decisions to JSONArray
+	model.PolicyDecisions = models.JSONArray{}
+	if len(result.PolicyDecisions()) > 0 {
+		decisionData, err := json.Marshal(result.PolicyDecisions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal policy decisions: %w", err)
+		}
+		if err := json.Unmarshal(decisionData, &model.PolicyDecisions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal policy decisions: %w", err)
+		}
+	}
+
+	// Convert the attachment scans to JSONArray
+	model.AttachmentScans = models.JSONArray{}
+	if len(result.AttachmentScans()) > 0 {
+		scanData, err := json.Marshal(result.AttachmentScans())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attachment scans: %w", err)
+		}
+		if err := json.Unmarshal(scanData, &model.AttachmentScans); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attachment scans: %w", err)
+		}
 	}
 
 	return model, nil
@@ -217,6 +563,9 @@ func (r *MessageRepositoryImpl) fromMessageModel(model *models.MessageModel) (*m
 	variables := message.NewVariables(variablesMap)
 
 	// Convert channel overrides
+	if err := openTargets(r.piiCipher, overrideRecipients(model.ChannelOverrides)); err != nil {
+		return nil, fmt.Errorf("failed to decrypt channel overrides: %w", err)
+	}
 	var channelOverridesMap map[string]*message.ChannelOverride
 	overrideData, err := json.Marshal(model.ChannelOverrides)
 	if err != nil {
@@ -246,6 +595,9 @@ func (r *MessageRepositoryImpl) fromMessageModel(model *models.MessageModel) (*m
 		channelIDsVO,
 		variables,
 		channelOverrides,
+		model.CorrelationID,
+		model.TenantID,
+		model.StrictRender,
 		status,
 		results,
 		model.CreatedAt,
@@ -260,10 +612,71 @@ func (r *MessageRepositoryImpl) fromMessageResultModel(model *models.MessageResu
 		return nil, fmt.Errorf("invalid channel ID: %w", err)
 	}
 
+	// Convert recipient results
+	if err := openTargets(r.piiCipher, model.Recipients); err != nil {
+		return nil, fmt.Errorf("failed to decrypt recipient results: %w", err)
+	}
+	var recipients []*message.RecipientResult
+	recipientData, err := json.Marshal(model.Recipients)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recipient results: %w", err)
+	}
+	if err := json.Unmarshal(recipientData, &recipients); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recipient results: %w", err)
+	}
+
+	// Convert the policy decisions
+	var decisions []*message.PolicyDecision
+	if len(model.PolicyDecisions) > 0 {
+		decisionData, err := json.Marshal(model.PolicyDecisions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal policy decisions: %w", err)
+		}
+		if err := json.Unmarshal(decisionData, &decisions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal policy decisions: %w", err)
+		}
+	}
+
+	// Convert the attachment scans
+	var scans []*message.AttachmentScan
+	if len(model.AttachmentScans) > 0 {
+		scanData, err := json.Marshal(model.AttachmentScans)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attachment scans: %w", err)
+		}
+		if err := json.Unmarshal(scanData, &scans); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attachment scans: %w", err)
+		}
+	}
+
+	result, err := r.newMessageResult(channelID, model)
+	if err != nil {
+		return nil, err
+	}
+	result.SetRecipients(recipients)
+	result.SetPolicyDecisions(decisions)
+	result.SetAttachmentScans(scans)
+	result.SetCost(model.Cost)
+
+	if model.FallbackFrom != nil {
+		fallbackFrom, err := channel.NewChannelIDFromString(*model.FallbackFrom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback channel ID: %w", err)
+		}
+		result.SetFallbackFrom(fallbackFrom)
+	}
+
+	return result, nil
+}
+
+// newMessageResult creates the domain message result for the status of a GORM model
+func (r *MessageRepositoryImpl) newMessageResult(channelID *channel.ChannelID, model *models.MessageResultModel) (*message.MessageResult, error) {
 	// Convert status and create result
 	status := message.MessageResultStatus(model.Status)
 	if status == message.MessageResultStatusSuccess {
 		return message.NewSuccessfulMessageResult(channelID, model.Message)
+	} else if status == message.MessageResultStatusHeld {
+		return message.NewHeldMessageResult(channelID, model.Message)
 	} else {
 		// Handle error
 		var msgError *message.MessageError
@@ -273,6 +686,9 @@ func (r *MessageRepositoryImpl) fromMessageResultModel(model *models.MessageResu
 				errorDetails = *model.ErrorDetails
 			}
 			msgError = message.NewMessageError(*model.ErrorCode, errorDetails)
+			if model.ErrorCategory != nil {
+				msgError.Category = message.ErrorCategory(*model.ErrorCategory)
+			}
 		} else {
 			msgError = message.NewMessageError("UNKNOWN_ERROR", "Unknown error occurred")
 		}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"notification/internal/domain/inbox"
+	"notification/internal/infrastructure/models"
+)
+
+// NATSInboxRepositoryImpl implements inbox.InboxRepository interface using GORM
+type NATSInboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewNATSInboxRepositoryImpl creates a new NATS inbox repository implementation
+func NewNATSInboxRepositoryImpl(db *gorm.DB) *NATSInboxRepositoryImpl {
+	return &NATSInboxRepositoryImpl{
+		db: db,
+	}
+}
+
+// Reserve inserts the entry as processing in a single statement, taking over
+// an expired entry of the same request, and returns the entry of the request
+// when one is still live
+func (r *NATSInboxRepositoryImpl) Reserve(ctx context.Context, entry *inbox.Entry) (*inbox.Entry, error) {
+	model := r.toInboxModel(entry)
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subject"}, {Name: "client_id"}, {Name: "req_seq_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "response", "received_at", "completed_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "nats_inbox.expires_at <= ?", Vars: []interface{}{model.ReceivedAt}},
+		}},
+	}).Create(model)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to reserve inbox entry: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing models.NATSInboxModel
+	err := r.db.WithContext(ctx).
+		Where("subject = ? AND client_id = ? AND req_seq_id = ?", model.Subject, model.ClientID, model.ReqSeqID).
+		First(&existing).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find inbox entry: %w", err)
+	}
+
+	return r.fromInboxModel(&existing), nil
+}
+
+// Complete stores the response and expiry of a reserved request, matched by
+// the time it was received so that an entry a retry took over is left alone
+func (r *NATSInboxRepositoryImpl) Complete(ctx context.Context, entry *inbox.Entry) error {
+	model := r.toInboxModel(entry)
+
+	err := r.db.WithContext(ctx).
+		Model(&models.NATSInboxModel{}).
+		Where("subject = ? AND client_id = ? AND req_seq_id = ? AND received_at = ?",
+			model.Subject, model.ClientID, model.ReqSeqID, model.ReceivedAt).
+		Updates(map[string]interface{}{
+			"status":       model.Status,
+			"response":     model.Response,
+			"completed_at": model.CompletedAt,
+			"expires_at":   model.ExpiresAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to complete inbox entry: %w", err)
+	}
+
+	return nil
+}
+
+// Release removes a reserved request that is still processing, unless a
+// retry took it over
+func (r *NATSInboxRepositoryImpl) Release(ctx context.Context, entry *inbox.Entry) error {
+	err := r.db.WithContext(ctx).
+		Where("subject = ? AND client_id = ? AND req_seq_id = ? AND status = ? AND received_at = ?",
+			entry.Subject, entry.ClientID, entry.ReqSeqID, string(inbox.StatusProcessing), entry.ReceivedAt.UnixMilli()).
+		Delete(&models.NATSInboxModel{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to release inbox entry: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the entries expired at now
+func (r *NATSInboxRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at <= ?", now.UnixMilli()).
+		Delete(&models.NATSInboxModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired inbox entries: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// toInboxModel converts an inbox entry to GORM model
+func (r *NATSInboxRepositoryImpl) toInboxModel(entry *inbox.Entry) *models.NATSInboxModel {
+	response := string(entry.Response)
+	if !json.Valid(entry.Response) {
+		response = "null"
+	}
+
+	model := &models.NATSInboxModel{
+		Subject:    entry.Subject,
+		ClientID:   entry.ClientID,
+		ReqSeqID:   entry.ReqSeqID,
+		Status:     string(entry.Status),
+		Response:   response,
+		ReceivedAt: entry.ReceivedAt.UnixMilli(),
+		ExpiresAt:  entry.ExpiresAt.UnixMilli(),
+	}
+	if entry.CompletedAt != nil {
+		completedAt := entry.CompletedAt.UnixMilli()
+		model.CompletedAt = &completedAt
+	}
+	return model
+}
+
+// fromInboxModel converts GORM model to an inbox entry
+func (r *NATSInboxRepositoryImpl) fromInboxModel(model *models.NATSInboxModel) *inbox.Entry {
+	entry := &inbox.Entry{
+		Subject:    model.Subject,
+		ClientID:   model.ClientID,
+		ReqSeqID:   model.ReqSeqID,
+		Status:     inbox.Status(model.Status),
+		Response:   json.RawMessage(model.Response),
+		ReceivedAt: time.UnixMilli(model.ReceivedAt),
+		ExpiresAt:  time.UnixMilli(model.ExpiresAt),
+	}
+	if model.CompletedAt != nil {
+		completedAt := time.UnixMilli(*model.CompletedAt)
+		entry.CompletedAt = &completedAt
+	}
+	return entry
+}
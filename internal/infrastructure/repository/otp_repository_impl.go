@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/otp"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// OTPRepositoryImpl implements otp.CodeRepository interface using GORM
+type OTPRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewOTPRepositoryImpl creates a new OTP repository implementation
+func NewOTPRepositoryImpl(db *gorm.DB) *OTPRepositoryImpl {
+	return &OTPRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a new code to the database
+func (r *OTPRepositoryImpl) Save(ctx context.Context, code *otp.Code) error {
+	if err := r.db.WithContext(ctx).Create(toOTPCodeModel(code)).Error; err != nil {
+		return fmt.Errorf("failed to save verification code: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a code by its ID
+func (r *OTPRepositoryImpl) FindByID(ctx context.Context, id string) (*otp.Code, error) {
+	var model models.OTPCodeModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.NewNotFoundError("OTP_NOT_FOUND", "verification code not found")
+		}
+		return nil, fmt.Errorf("failed to find verification code: %w", err)
+	}
+	return fromOTPCodeModel(&model), nil
+}
+
+// FindLatest finds the latest code sent to a recipient for a purpose
+func (r *OTPRepositoryImpl) FindLatest(ctx context.Context, purpose, recipient string) (*otp.Code, error) {
+	var model models.OTPCodeModel
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND recipient = ?", purpose, recipient).
+		Order("created_at DESC").
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find verification code: %w", err)
+	}
+	return fromOTPCodeModel(&model), nil
+}
+
+// Update stores the attempts and the verification of a code, guarded by the
+// attempts it had so that concurrent verifications cannot exceed its limit
+func (r *OTPRepositoryImpl) Update(ctx context.Context, code *otp.Code, attempts int) (bool, error) {
+	model := toOTPCodeModel(code)
+	result := r.db.WithContext(ctx).
+		Model(&models.OTPCodeModel{}).
+		Where("id = ? AND attempts = ?", code.ID, attempts).
+		Updates(map[string]interface{}{
+			"attempts":    model.Attempts,
+			"verified_at": model.VerifiedAt,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update verification code: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Delete deletes a code
+func (r *OTPRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.OTPCodeModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete verification code: %w", err)
+	}
+	return nil
+}
+
+// toOTPCodeModel converts a code to GORM model
+func toOTPCodeModel(code *otp.Code) *models.OTPCodeModel {
+	model := &models.OTPCodeModel{
+		ID:          code.ID,
+		Purpose:     code.Purpose,
+		Recipient:   code.Recipient,
+		ChannelID:   code.ChannelID,
+		CodeHash:    code.CodeHash,
+		Attempts:    code.Attempts,
+		MaxAttempts: code.MaxAttempts,
+		CreatedAt:   code.CreatedAt.UnixMilli(),
+		ExpiresAt:   code.ExpiresAt.UnixMilli(),
+	}
+	if code.VerifiedAt != nil {
+		verifiedAt := code.VerifiedAt.UnixMilli()
+		model.VerifiedAt = &verifiedAt
+	}
+	return model
+}
+
+// fromOTPCodeModel converts GORM model to a code
+func fromOTPCodeModel(model *models.OTPCodeModel) *otp.Code {
+	code := &otp.Code{
+		ID:          model.ID,
+		Purpose:     model.Purpose,
+		Recipient:   model.Recipient,
+		ChannelID:   model.ChannelID,
+		CodeHash:    model.CodeHash,
+		Attempts:    model.Attempts,
+		MaxAttempts: model.MaxAttempts,
+		CreatedAt:   time.UnixMilli(model.CreatedAt),
+		ExpiresAt:   time.UnixMilli(model.ExpiresAt),
+	}
+	if model.VerifiedAt != nil {
+		verifiedAt := time.UnixMilli(*model.VerifiedAt)
+		code.VerifiedAt = &verifiedAt
+	}
+	return code
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"notification/internal/infrastructure/models"
+	"notification/pkg/pii"
+)
+
+// piiRotationBatchSize is the number of rows loaded at once by RotatePIIKeys
+const piiRotationBatchSize = 500
+
+// RotatePIIKeys encrypts with the active key of a cipher the recipient targets
+// stored in plain text or with a former key: in the channels, deleted ones
+// included, the recipient overrides of the messages and the message results.
+// Rows are rewritten one at a time, so the rotation can be run again after an
+// interruption. It returns the number of rows rewritten by table.
+func RotatePIIKeys(ctx context.Context, db *gorm.DB, cipher *pii.Cipher) (map[string]int, error) {
+	db = db.WithContext(ctx)
+	counts := make(map[string]int, 3)
+
+	var err error
+	if counts["channels"], err = rotateChannels(db, cipher); err != nil {
+		return counts, err
+	}
+	if counts["messages"], err = rotateMessages(db, cipher); err != nil {
+		return counts, err
+	}
+	counts["message_results"], err = rotateMessageResults(db, cipher)
+	return counts, err
+}
+
+// rotateChannels rotates the recipients of the channels
+func rotateChannels(db *gorm.DB, cipher *pii.Cipher) (int, error) {
+	rotated, lastID := 0, ""
+	for {
+		var batch []models.ChannelModel
+		err := db.Select("id", "recipients").Where("id > ?", lastID).
+			Order("id").Limit(piiRotationBatchSize).Find(&batch).Error
+		if err != nil {
+			return rotated, fmt.Errorf("failed to load channels: %w", err)
+		}
+		for i := range batch {
+			model := &batch[i]
+			lastID = model.ID
+			if !targetsNeedRotation(cipher, model.Recipients) {
+				continue
+			}
+			if err := rotateTargets(cipher, model.Recipients); err != nil {
+				return rotated, fmt.Errorf("channel %s: %w", model.ID, err)
+			}
+			err := db.Model(&models.ChannelModel{}).Where("id = ?", model.ID).
+				UpdateColumn("recipients", model.Recipients).Error
+			if err != nil {
+				return rotated, fmt.Errorf("failed to update channel %s: %w", model.ID, err)
+			}
+			rotated++
+		}
+		if len(batch) < piiRotationBatchSize {
+			return rotated, nil
+		}
+	}
+}
+
+// rotateMessages rotates the recipients overridden per channel in the messages
+func rotateMessages(db *gorm.DB, cipher *pii.Cipher) (int, error) {
+	rotated, lastID := 0, ""
+	for {
+		var batch []models.MessageModel
+		err := db.Select("id", "channel_overrides").Where("id > ?", lastID).
+			Order("id").Limit(piiRotationBatchSize).Find(&batch).Error
+		if err != nil {
+			return rotated, fmt.Errorf("failed to load messages: %w", err)
+		}
+		for i := range batch {
+			model := &batch[i]
+			lastID = model.ID
+			recipients := overrideRecipients(model.ChannelOverrides)
+			if !targetsNeedRotation(cipher, recipients) {
+				continue
+			}
+			if err := rotateTargets(cipher, recipients); err != nil {
+				return rotated, fmt.Errorf("message %s: %w", model.ID, err)
+			}
+			err := db.Model(&models.MessageModel{}).Where("id = ?", model.ID).
+				UpdateColumn("channel_overrides", model.ChannelOverrides).Error
+			if err != nil {
+				return rotated, fmt.Errorf("failed to update message %s: %w", model.ID, err)
+			}
+			rotated++
+		}
+		if len(batch) < piiRotationBatchSize {
+			return rotated, nil
+		}
+	}
+}
+
+// rotateMessageResults rotates the recipients of the message results
+func rotateMessageResults(db *gorm.DB, cipher *pii.Cipher) (int, error) {
+	rotated, lastID := 0, uint(0)
+	for {
+		var batch []models.MessageResultModel
+		err := db.Select("id", "recipients").Where("id > ?", lastID).
+			Order("id").Limit(piiRotationBatchSize).Find(&batch).Error
+		if err != nil {
+			return rotated, fmt.Errorf("failed to load message results: %w", err)
+		}
+		for i := range batch {
+			model := &batch[i]
+			lastID = model.ID
+			if !targetsNeedRotation(cipher, model.Recipients) {
+				continue
+			}
+			if err := rotateTargets(cipher, model.Recipients); err != nil {
+				return rotated, fmt.Errorf("message result %d: %w", model.ID, err)
+			}
+			err := db.Model(&models.MessageResultModel{}).Where("id = ?", model.ID).
+				UpdateColumn("recipients", model.Recipients).Error
+			if err != nil {
+				return rotated, fmt.Errorf("failed to update message result %d: %w", model.ID, err)
+			}
+			rotated++
+		}
+		if len(batch) < piiRotationBatchSize {
+			return rotated, nil
+		}
+	}
+}
+
+// rotateTargets encrypts again with the active key the targets of decoded
+// recipients in place
+func rotateTargets(cipher *pii.Cipher, recipients []map[string]interface{}) error {
+	if err := openTargets(cipher, recipients); err != nil {
+		return err
+	}
+	return sealTargets(cipher, recipients)
+}
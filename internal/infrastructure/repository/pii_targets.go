@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"notification/internal/infrastructure/models"
+	"notification/pkg/pii"
+)
+
+// errNoPIICipher is returned when reading an encrypted target without keys
+var errNoPIICipher = errors.New("recipient target is encrypted but no PII encryption keys are configured")
+
+// sealTargets encrypts the targets of decoded recipients in place; a nil
+// cipher leaves them in plain text
+func sealTargets(cipher *pii.Cipher, recipients []map[string]interface{}) error {
+	if cipher == nil {
+		return nil
+	}
+	for _, recipient := range recipients {
+		target, _ := recipient["target"].(string)
+		encrypted, err := cipher.Encrypt(target)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt recipient target: %w", err)
+		}
+		if encrypted != "" {
+			recipient["target"] = encrypted
+		}
+	}
+	return nil
+}
+
+// openTargets decrypts the targets of decoded recipients in place, the
+// targets stored in plain text being kept as they are
+func openTargets(cipher *pii.Cipher, recipients []map[string]interface{}) error {
+	for _, recipient := range recipients {
+		target, _ := recipient["target"].(string)
+		if !pii.IsEncrypted(target) {
+			continue
+		}
+		if cipher == nil {
+			return errNoPIICipher
+		}
+		plain, err := cipher.Decrypt(target)
+		if err != nil {
+			return err
+		}
+		recipient["target"] = plain
+	}
+	return nil
+}
+
+// overrideRecipients returns the recipients overridden per channel in the
+// channel overrides column of a message
+func overrideRecipients(overrides models.JSON) []map[string]interface{} {
+	var recipients []map[string]interface{}
+	for _, value := range overrides {
+		override, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		list, _ := override["recipients"].([]interface{})
+		for _, item := range list {
+			if recipient, ok := item.(map[string]interface{}); ok {
+				recipients = append(recipients, recipient)
+			}
+		}
+	}
+	return recipients
+}
+
+// targetsNeedRotation tells whether a target of decoded recipients is in
+// plain text or encrypted with a former key
+func targetsNeedRotation(cipher *pii.Cipher, recipients []map[string]interface{}) bool {
+	for _, recipient := range recipients {
+		if target, _ := recipient["target"].(string); cipher.NeedsRotation(target) {
+			return true
+		}
+	}
+	return false
+}
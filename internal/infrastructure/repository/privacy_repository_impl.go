@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/privacy"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// PrivacyRequestRepositoryImpl implements privacy.RequestRepository interface using GORM
+type PrivacyRequestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPrivacyRequestRepositoryImpl creates a new privacy request repository implementation
+func NewPrivacyRequestRepositoryImpl(db *gorm.DB) *PrivacyRequestRepositoryImpl {
+	return &PrivacyRequestRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a new request to the database
+func (r *PrivacyRequestRepositoryImpl) Save(ctx context.Context, request *privacy.Request) error {
+	model, err := toPrivacyRequestModel(request)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save privacy request: %w", err)
+	}
+	return nil
+}
+
+// Update updates the status, report, export and subject of a request
+func (r *PrivacyRequestRepositoryImpl) Update(ctx context.Context, request *privacy.Request) error {
+	model, err := toPrivacyRequestModel(request)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).
+		Model(&models.PrivacyRequestModel{}).
+		Where("id = ?", request.ID).
+		Updates(map[string]interface{}{
+			"subject":      model.Subject,
+			"status":       model.Status,
+			"report":       model.Report,
+			"export":       model.Export,
+			"error":        model.Error,
+			"completed_at": model.CompletedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update privacy request: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a request by its ID
+func (r *PrivacyRequestRepositoryImpl) FindByID(ctx context.Context, id string) (*privacy.Request, error) {
+	var model models.PrivacyRequestModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.NewNotFoundError("PRIVACY_REQUEST_NOT_FOUND", "privacy request not found")
+		}
+		return nil, fmt.Errorf("failed to find privacy request: %w", err)
+	}
+	return fromPrivacyRequestModel(&model)
+}
+
+// FindUnfinished finds the pending and running requests, oldest first
+func (r *PrivacyRequestRepositoryImpl) FindUnfinished(ctx context.Context) ([]*privacy.Request, error) {
+	var requestModels []models.PrivacyRequestModel
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{
+			string(privacy.RequestStatusPending),
+			string(privacy.RequestStatusRunning),
+		}).
+		Order("created_at ASC").
+		Find(&requestModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unfinished privacy requests: %w", err)
+	}
+
+	requests := make([]*privacy.Request, 0, len(requestModels))
+	for i := range requestModels {
+		request, err := fromPrivacyRequestModel(&requestModels[i])
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// toPrivacyRequestModel converts a request to GORM model
+func toPrivacyRequestModel(request *privacy.Request) (*models.PrivacyRequestModel, error) {
+	model := &models.PrivacyRequestModel{
+		ID:          request.ID,
+		Kind:        string(request.Kind),
+		Subject:     request.Subject,
+		SubjectHash: request.SubjectHash,
+		Status:      string(request.Status),
+		Report:      reportToJSON(request.Report),
+		Error:       request.Error,
+		RequestedBy: request.RequestedBy,
+		CreatedAt:   request.CreatedAt.UnixMilli(),
+	}
+	if request.Export != nil {
+		data, err := json.Marshal(request.Export)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal privacy export: %w", err)
+		}
+		model.Export = string(data)
+	}
+	if request.CompletedAt != nil {
+		completedAt := request.CompletedAt.UnixMilli()
+		model.CompletedAt = &completedAt
+	}
+	return model, nil
+}
+
+// fromPrivacyRequestModel converts GORM model to a request
+func fromPrivacyRequestModel(model *models.PrivacyRequestModel) (*privacy.Request, error) {
+	request := &privacy.Request{
+		ID:          model.ID,
+		Kind:        privacy.RequestKind(model.Kind),
+		Subject:     model.Subject,
+		SubjectHash: model.SubjectHash,
+		Status:      privacy.RequestStatus(model.Status),
+		Report:      reportFromJSON(model.Report),
+		Error:       model.Error,
+		RequestedBy: model.RequestedBy,
+		CreatedAt:   time.UnixMilli(model.CreatedAt),
+	}
+	if model.Export != "" {
+		if err := json.Unmarshal([]byte(model.Export), &request.Export); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal privacy export: %w", err)
+		}
+	}
+	if model.CompletedAt != nil {
+		completedAt := time.UnixMilli(*model.CompletedAt)
+		request.CompletedAt = &completedAt
+	}
+	return request, nil
+}
+
+// PrivacyAuditLogImpl implements privacy.AuditLog interface using GORM
+type PrivacyAuditLogImpl struct {
+	db *gorm.DB
+}
+
+// NewPrivacyAuditLogImpl creates a new privacy audit log implementation
+func NewPrivacyAuditLogImpl(db *gorm.DB) *PrivacyAuditLogImpl {
+	return &PrivacyAuditLogImpl{
+		db: db,
+	}
+}
+
+// Append records an audit entry
+func (l *PrivacyAuditLogImpl) Append(ctx context.Context, entry *privacy.AuditEntry) error {
+	model := &models.PrivacyAuditEntryModel{
+		ID:          entry.ID,
+		RequestID:   entry.RequestID,
+		Kind:        string(entry.Kind),
+		Action:      string(entry.Action),
+		SubjectHash: entry.SubjectHash,
+		Actor:       entry.Actor,
+		Report:      reportToJSON(entry.Report),
+		Error:       entry.Error,
+		OccurredAt:  entry.OccurredAt.UnixMilli(),
+	}
+	if err := l.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to append privacy audit entry: %w", err)
+	}
+	return nil
+}
+
+// List lists up to limit audit entries, newest first
+func (l *PrivacyAuditLogImpl) List(ctx context.Context, limit int) ([]*privacy.AuditEntry, error) {
+	var entryModels []models.PrivacyAuditEntryModel
+	err := l.db.WithContext(ctx).
+		Order("occurred_at DESC").
+		Limit(limit).
+		Find(&entryModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list privacy audit entries: %w", err)
+	}
+
+	entries := make([]*privacy.AuditEntry, 0, len(entryModels))
+	for _, model := range entryModels {
+		entries = append(entries, &privacy.AuditEntry{
+			ID:          model.ID,
+			RequestID:   model.RequestID,
+			Kind:        privacy.RequestKind(model.Kind),
+			Action:      privacy.AuditAction(model.Action),
+			SubjectHash: model.SubjectHash,
+			Actor:       model.Actor,
+			Report:      reportFromJSON(model.Report),
+			Error:       model.Error,
+			OccurredAt:  time.UnixMilli(model.OccurredAt),
+		})
+	}
+	return entries, nil
+}
+
+// reportToJSON converts a report to its JSON column
+func reportToJSON(report privacy.Report) models.JSON {
+	data := make(models.JSON, len(report))
+	for kind, count := range report {
+		data[kind] = count
+	}
+	return data
+}
+
+// reportFromJSON converts a JSON column to a report, the counts being read
+// back as JSON numbers
+func reportFromJSON(data models.JSON) privacy.Report {
+	report := make(privacy.Report, len(data))
+	for kind, value := range data {
+		if count, ok := value.(float64); ok {
+			report[kind] = int(count)
+		}
+	}
+	return report
+}
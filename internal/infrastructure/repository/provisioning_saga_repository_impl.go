@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/provisioning"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// ProvisioningSagaRepositoryImpl implements provisioning.SagaRepository interface using GORM
+type ProvisioningSagaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewProvisioningSagaRepositoryImpl creates a new saga repository implementation
+func NewProvisioningSagaRepositoryImpl(db *gorm.DB) *ProvisioningSagaRepositoryImpl {
+	return &ProvisioningSagaRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a saga to the database
+func (r *ProvisioningSagaRepositoryImpl) Save(ctx context.Context, saga *provisioning.Saga) error {
+	if err := r.db.WithContext(ctx).Create(r.toSagaModel(saga)).Error; err != nil {
+		return fmt.Errorf("failed to save saga: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates a saga in the database
+func (r *ProvisioningSagaRepositoryImpl) Update(ctx context.Context, saga *provisioning.Saga) error {
+	if err := r.db.WithContext(ctx).Save(r.toSagaModel(saga)).Error; err != nil {
+		return fmt.Errorf("failed to update saga: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a saga by its ID
+func (r *ProvisioningSagaRepositoryImpl) FindByID(ctx context.Context, id *provisioning.SagaID) (*provisioning.Saga, error) {
+	var model models.ProvisioningSagaModel
+
+	err := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, shared.NewNotFoundError("SAGA_NOT_FOUND", "saga not found")
+		}
+		return nil, fmt.Errorf("failed to find saga: %w", err)
+	}
+
+	return r.fromSagaModel(&model)
+}
+
+// FindUnfinished finds the running and compensating sagas of a kind, oldest first
+func (r *ProvisioningSagaRepositoryImpl) FindUnfinished(ctx context.Context, kind string) ([]*provisioning.Saga, error) {
+	var sagaModels []models.ProvisioningSagaModel
+
+	err := r.db.WithContext(ctx).
+		Where("kind = ? AND status IN ?", kind, []string{
+			string(provisioning.SagaStatusRunning),
+			string(provisioning.SagaStatusCompensating),
+		}).
+		Order("created_at ASC").
+		Find(&sagaModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unfinished sagas: %w", err)
+	}
+
+	sagas := make([]*provisioning.Saga, 0, len(sagaModels))
+	for _, model := range sagaModels {
+		saga, err := r.fromSagaModel(&model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to saga: %w", err)
+		}
+		sagas = append(sagas, saga)
+	}
+	return sagas, nil
+}
+
+// toSagaModel converts domain saga to GORM model
+func (r *ProvisioningSagaRepositoryImpl) toSagaModel(saga *provisioning.Saga) *models.ProvisioningSagaModel {
+	data := make(models.JSON, len(saga.Data()))
+	for key, value := range saga.Data() {
+		data[key] = value
+	}
+
+	steps := make(models.JSONArray, 0, len(saga.Steps()))
+	for _, step := range saga.Steps() {
+		steps = append(steps, map[string]interface{}{
+			"name":       step.Name,
+			"status":     string(step.Status),
+			"error":      step.Error,
+			"startedAt":  step.StartedAt,
+			"finishedAt": step.FinishedAt,
+		})
+	}
+
+	payload := models.JSON(saga.Payload())
+	if payload == nil {
+		payload = models.JSON{}
+	}
+
+	return &models.ProvisioningSagaModel{
+		ID:        saga.ID().String(),
+		Kind:      saga.Kind(),
+		Status:    string(saga.Status()),
+		Payload:   payload,
+		Data:      data,
+		Steps:     steps,
+		Error:     saga.Error(),
+		CreatedAt: saga.Timestamps().CreatedAt,
+		UpdatedAt: saga.Timestamps().UpdatedAt,
+	}
+}
+
+// fromSagaModel converts GORM model to domain saga
+func (r *ProvisioningSagaRepositoryImpl) fromSagaModel(model *models.ProvisioningSagaModel) (*provisioning.Saga, error) {
+	id, err := provisioning.NewSagaIDFromString(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid saga ID: %w", err)
+	}
+
+	data := make(map[string]string, len(model.Data))
+	for key, value := range model.Data {
+		data[key] = fmt.Sprint(value)
+	}
+
+	steps := make([]*provisioning.Step, 0, len(model.Steps))
+	for _, fields := range model.Steps {
+		step := &provisioning.Step{}
+		step.Name, _ = fields["name"].(string)
+		status, _ := fields["status"].(string)
+		step.Status = provisioning.StepStatus(status)
+		step.Error, _ = fields["error"].(string)
+		if startedAt, ok := fields["startedAt"].(float64); ok {
+			step.StartedAt = int64(startedAt)
+		}
+		if finishedAt, ok := fields["finishedAt"].(float64); ok {
+			step.FinishedAt = int64(finishedAt)
+		}
+		steps = append(steps, step)
+	}
+
+	return provisioning.ReconstructSaga(
+		id,
+		model.Kind,
+		provisioning.SagaStatus(model.Status),
+		map[string]interface{}(model.Payload),
+		data,
+		steps,
+		model.Error,
+		&shared.Timestamps{
+			CreatedAt: model.CreatedAt,
+			UpdatedAt: model.UpdatedAt,
+		},
+	), nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/message"
+	"notification/internal/infrastructure/models"
+)
+
+// QuarantineRepositoryImpl implements message.QuarantineRepository interface using GORM
+type QuarantineRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewQuarantineRepositoryImpl creates a new quarantine repository implementation
+func NewQuarantineRepositoryImpl(db *gorm.DB) *QuarantineRepositoryImpl {
+	return &QuarantineRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save records a quarantined attachment
+func (r *QuarantineRepositoryImpl) Save(ctx context.Context, attachment *message.QuarantinedAttachment) error {
+	model := &models.QuarantinedAttachmentModel{
+		ID:          attachment.ID,
+		MessageID:   attachment.MessageID,
+		ChannelID:   attachment.ChannelID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Content:     attachment.Content,
+		Signature:   attachment.Signature,
+		CreatedAt:   attachment.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save quarantined attachment: %w", err)
+	}
+	return nil
+}
+
+// FindByMessageID lists the quarantined attachments of a message, leaving
+// their content out
+func (r *QuarantineRepositoryImpl) FindByMessageID(ctx context.Context, messageID string) ([]*message.QuarantinedAttachment, error) {
+	var rows []models.QuarantinedAttachmentModel
+	err := r.db.WithContext(ctx).
+		Select("id", "message_id", "channel_id", "filename", "content_type", "signature", "created_at").
+		Where("message_id = ?", messageID).
+		Order("created_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined attachments: %w", err)
+	}
+
+	attachments := make([]*message.QuarantinedAttachment, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, &message.QuarantinedAttachment{
+			ID:          row.ID,
+			MessageID:   row.MessageID,
+			ChannelID:   row.ChannelID,
+			Filename:    row.Filename,
+			ContentType: row.ContentType,
+			Signature:   row.Signature,
+			CreatedAt:   row.CreatedAt,
+		})
+	}
+	return attachments, nil
+}
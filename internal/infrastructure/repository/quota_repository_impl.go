@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"notification/internal/domain/quota"
+	"notification/internal/infrastructure/models"
+)
+
+// QuotaUsageRepositoryImpl implements quota.UsageRepository interface using GORM
+type QuotaUsageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewQuotaUsageRepositoryImpl creates a new quota usage repository implementation
+func NewQuotaUsageRepositoryImpl(db *gorm.DB) *QuotaUsageRepositoryImpl {
+	return &QuotaUsageRepositoryImpl{
+		db: db,
+	}
+}
+
+// Consume adds the charges to the usage in one transaction. The usage rows
+// stay locked by the upsert until the transaction ends, so concurrent
+// messages cannot both pass the last remaining sends.
+func (r *QuotaUsageRepositoryImpl) Consume(ctx context.Context, charges []*quota.Charge) error {
+	now := time.Now().UnixMilli()
+
+	// The upserts lock the usage rows; taking them in one order keeps
+	// concurrent messages charging the same subjects from deadlocking
+	charges = slices.Clone(charges)
+	quota.SortCharges(charges)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, charge := range charges {
+			model := &models.QuotaUsageModel{
+				Scope:     string(charge.Scope),
+				SubjectID: charge.SubjectID,
+				Period:    string(charge.Period),
+				PeriodKey: charge.PeriodKey,
+				Used:      charge.Amount,
+				UpdatedAt: now,
+			}
+
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "scope"}, {Name: "subject_id"}, {Name: "period"}, {Name: "period_key"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"used":       gorm.Expr("quota_usage.used + ?", charge.Amount),
+					"updated_at": now,
+				}),
+			}).Create(model).Error
+			if err != nil {
+				return fmt.Errorf("failed to consume quota: %w", err)
+			}
+
+			if charge.Limit <= 0 {
+				continue
+			}
+
+			used, err := r.findUsed(tx, charge.Scope, charge.SubjectID, charge.Period, charge.PeriodKey)
+			if err != nil {
+				return err
+			}
+			if used > charge.Limit {
+				return &quota.ExceededError{Charge: charge, Used: used - charge.Amount}
+			}
+		}
+
+		return nil
+	})
+}
+
+// FindUsed returns the number of sends a subject used in a period
+func (r *QuotaUsageRepositoryImpl) FindUsed(ctx context.Context, scope quota.Scope, subjectID string, period quota.Period, periodKey string) (int, error) {
+	return r.findUsed(r.db.WithContext(ctx), scope, subjectID, period, periodKey)
+}
+
+// findUsed reads the usage of a subject in a period, 0 when nothing was sent
+func (r *QuotaUsageRepositoryImpl) findUsed(db *gorm.DB, scope quota.Scope, subjectID string, period quota.Period, periodKey string) (int, error) {
+	var model models.QuotaUsageModel
+	err := db.
+		Where("scope = ? AND subject_id = ? AND period = ? AND period_key = ?", string(scope), subjectID, string(period), periodKey).
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+
+	return model.Used, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/message"
+	"notification/internal/infrastructure/models"
+)
+
+// ReplyRepositoryImpl implements message.ReplyRepository interface using GORM
+type ReplyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReplyRepositoryImpl creates a new reply repository implementation
+func NewReplyRepositoryImpl(db *gorm.DB) *ReplyRepositoryImpl {
+	return &ReplyRepositoryImpl{
+		db: db,
+	}
+}
+
+// SaveTokens records the reply tokens of a send in one insert
+func (r *ReplyRepositoryImpl) SaveTokens(ctx context.Context, tokens []*message.ReplyToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	rows := make([]*models.ReplyTokenModel, 0, len(tokens))
+	for _, token := range tokens {
+		rows = append(rows, &models.ReplyTokenModel{
+			Token:     token.Token,
+			MessageID: token.MessageID,
+			ChannelID: token.ChannelID,
+			Recipient: token.Recipient,
+			CreatedAt: token.CreatedAt,
+		})
+	}
+	if err := r.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to save reply tokens: %w", err)
+	}
+	return nil
+}
+
+// FindToken finds a reply token by its value
+func (r *ReplyRepositoryImpl) FindToken(ctx context.Context, token string) (*message.ReplyToken, error) {
+	return r.findToken(ctx, r.db.WithContext(ctx).Where("token = ?", token))
+}
+
+// FindLatestToken finds the latest reply token of a recipient on a channel
+func (r *ReplyRepositoryImpl) FindLatestToken(ctx context.Context, channelID, recipient string) (*message.ReplyToken, error) {
+	return r.findToken(ctx, r.db.WithContext(ctx).
+		Where("channel_id = ? AND recipient = ?", channelID, recipient).
+		Order("created_at DESC"))
+}
+
+// findToken finds the first reply token of a query, nil when there is none
+func (r *ReplyRepositoryImpl) findToken(ctx context.Context, query *gorm.DB) (*message.ReplyToken, error) {
+	var model models.ReplyTokenModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find reply token: %w", err)
+	}
+	return &message.ReplyToken{
+		Token:     model.Token,
+		MessageID: model.MessageID,
+		ChannelID: model.ChannelID,
+		Recipient: model.Recipient,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+// Save records a reply
+func (r *ReplyRepositoryImpl) Save(ctx context.Context, reply *message.Reply) error {
+	model := &models.ReplyModel{
+		ID:          reply.ID,
+		MessageID:   reply.MessageID,
+		ChannelID:   reply.ChannelID,
+		ChannelType: reply.ChannelType,
+		Sender:      reply.From,
+		Subject:     reply.Subject,
+		Body:        reply.Body,
+		ProviderID:  reply.ProviderID,
+		ReceivedAt:  reply.ReceivedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save reply: %w", err)
+	}
+	return nil
+}
+
+// FindByMessageID lists the replies to a message
+func (r *ReplyRepositoryImpl) FindByMessageID(ctx context.Context, messageID string) ([]*message.Reply, error) {
+	var rows []models.ReplyModel
+	err := r.db.WithContext(ctx).Where("message_id = ?", messageID).Order("received_at ASC").Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replies: %w", err)
+	}
+	return toReplies(rows), nil
+}
+
+// List lists the replies received after a time
+func (r *ReplyRepositoryImpl) List(ctx context.Context, since int64, limit int) ([]*message.Reply, error) {
+	var rows []models.ReplyModel
+	err := r.db.WithContext(ctx).
+		Where("received_at > ?", since).
+		Order("received_at DESC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replies: %w", err)
+	}
+	return toReplies(rows), nil
+}
+
+// toReplies converts reply models to their domain form
+func toReplies(rows []models.ReplyModel) []*message.Reply {
+	replies := make([]*message.Reply, 0, len(rows))
+	for i := range rows {
+		model := &rows[i]
+		replies = append(replies, &message.Reply{
+			ID:          model.ID,
+			MessageID:   model.MessageID,
+			ChannelID:   model.ChannelID,
+			ChannelType: model.ChannelType,
+			From:        model.Sender,
+			Subject:     model.Subject,
+			Body:        model.Body,
+			ProviderID:  model.ProviderID,
+			ReceivedAt:  model.ReceivedAt,
+		})
+	}
+	return replies
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/retention"
+	"notification/internal/infrastructure/models"
+)
+
+// RetentionReportRepositoryImpl implements retention.ReportRepository interface using GORM
+type RetentionReportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRetentionReportRepositoryImpl creates a new retention report repository implementation
+func NewRetentionReportRepositoryImpl(db *gorm.DB) *RetentionReportRepositoryImpl {
+	return &RetentionReportRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves the reports of a run
+func (r *RetentionReportRepositoryImpl) Save(ctx context.Context, reports []*retention.Report) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	reportModels := make([]*models.RetentionReportModel, 0, len(reports))
+	for _, report := range reports {
+		reportModels = append(reportModels, toRetentionReportModel(report))
+	}
+	if err := r.db.WithContext(ctx).Create(&reportModels).Error; err != nil {
+		return fmt.Errorf("failed to save retention reports: %w", err)
+	}
+	return nil
+}
+
+// List lists the latest reports, newest first, of a tenant or of every tenant
+func (r *RetentionReportRepositoryImpl) List(ctx context.Context, tenantID string, limit int) ([]*retention.Report, error) {
+	query := r.db.WithContext(ctx).Order("started_at DESC, tenant_id").Limit(limit)
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+
+	var reportModels []models.RetentionReportModel
+	if err := query.Find(&reportModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list retention reports: %w", err)
+	}
+	reports := make([]*retention.Report, 0, len(reportModels))
+	for i := range reportModels {
+		reports = append(reports, fromRetentionReportModel(&reportModels[i]))
+	}
+	return reports, nil
+}
+
+// toRetentionReportModel converts a report to its model
+func toRetentionReportModel(report *retention.Report) *models.RetentionReportModel {
+	model := &models.RetentionReportModel{
+		ID:              report.ID,
+		RunID:           report.RunID,
+		TenantID:        report.TenantID,
+		ContentPurged:   report.ContentPurged,
+		MessagesDeleted: report.MessagesDeleted,
+		Error:           report.Error,
+		StartedAt:       report.StartedAt.UnixMilli(),
+		CompletedAt:     report.CompletedAt.UnixMilli(),
+	}
+	if report.ContentBefore != nil {
+		before := report.ContentBefore.UnixMilli()
+		model.ContentBefore = &before
+	}
+	if report.MetadataBefore != nil {
+		before := report.MetadataBefore.UnixMilli()
+		model.MetadataBefore = &before
+	}
+	return model
+}
+
+// fromRetentionReportModel converts a model to its report
+func fromRetentionReportModel(model *models.RetentionReportModel) *retention.Report {
+	report := &retention.Report{
+		ID:              model.ID,
+		RunID:           model.RunID,
+		TenantID:        model.TenantID,
+		ContentPurged:   model.ContentPurged,
+		MessagesDeleted: model.MessagesDeleted,
+		Error:           model.Error,
+		StartedAt:       time.UnixMilli(model.StartedAt),
+		CompletedAt:     time.UnixMilli(model.CompletedAt),
+	}
+	if model.ContentBefore != nil {
+		before := time.UnixMilli(*model.ContentBefore)
+		report.ContentBefore = &before
+	}
+	if model.MetadataBefore != nil {
+		before := time.UnixMilli(*model.MetadataBefore)
+		report.MetadataBefore = &before
+	}
+	return report
+}
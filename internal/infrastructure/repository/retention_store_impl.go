@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/infrastructure/models"
+)
+
+// retentionBatchSize is the number of messages purged or deleted per
+// transaction, keeping each transaction short
+const retentionBatchSize = 500
+
+// RetentionStoreImpl implements retention.Store interface using GORM
+type RetentionStoreImpl struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewRetentionStoreImpl creates a new retention store implementation
+func NewRetentionStoreImpl(db *gorm.DB) *RetentionStoreImpl {
+	return &RetentionStoreImpl{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// Tenants lists the IDs of the tenants having messages
+func (s *RetentionStoreImpl) Tenants(ctx context.Context) ([]string, error) {
+	var tenants []string
+	err := s.db.WithContext(ctx).
+		Model(&models.MessageModel{}).
+		Distinct("tenant_id").
+		Order("tenant_id").
+		Pluck("tenant_id", &tenants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// PurgeContent empties the variables and template overrides of the messages
+// of a tenant created before a time, empties the replies to them and deletes
+// their quarantined attachments. The recipients, statuses and results are
+// kept; the messages are marked so that a later run skips them.
+func (s *RetentionStoreImpl) PurgeContent(ctx context.Context, tenantID string, before time.Time) (int, error) {
+	db := s.db.WithContext(ctx)
+	purged := 0
+	for {
+		var batch []models.MessageModel
+		err := db.Select("id", "channel_overrides").
+			Where("tenant_id = ? AND created_at < ? AND content_purged_at IS NULL", tenantID, before.UnixMilli()).
+			Order("created_at").
+			Limit(retentionBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			return purged, fmt.Errorf("failed to find messages to purge: %w", err)
+		}
+		if len(batch) == 0 {
+			return purged, nil
+		}
+
+		now := s.now().UnixMilli()
+		ids := make([]string, 0, len(batch))
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for i := range batch {
+				model := &batch[i]
+				ids = append(ids, model.ID)
+				err := tx.Model(&models.MessageModel{}).
+					Where("id = ?", model.ID).
+					UpdateColumns(map[string]interface{}{
+						"variables":         models.JSON{},
+						"channel_overrides": withoutTemplateOverrides(model.ChannelOverrides),
+						"content_purged_at": now,
+					}).Error
+				if err != nil {
+					return fmt.Errorf("failed to purge message %s: %w", model.ID, err)
+				}
+			}
+			err := tx.Model(&models.ReplyModel{}).
+				Where("message_id IN ?", ids).
+				UpdateColumns(map[string]interface{}{"subject": "", "body": ""}).Error
+			if err != nil {
+				return fmt.Errorf("failed to purge replies: %w", err)
+			}
+			if err := tx.Where("message_id IN ?", ids).Delete(&models.QuarantinedAttachmentModel{}).Error; err != nil {
+				return fmt.Errorf("failed to purge quarantined attachments: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+
+		purged += len(batch)
+		if len(batch) < retentionBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// DeleteMessages deletes the messages of a tenant created before a time,
+// with the rows belonging to them
+func (s *RetentionStoreImpl) DeleteMessages(ctx context.Context, tenantID string, before time.Time) (int, error) {
+	db := s.db.WithContext(ctx)
+	deleted := 0
+	for {
+		var ids []string
+		err := db.Model(&models.MessageModel{}).
+			Where("tenant_id = ? AND created_at < ?", tenantID, before.UnixMilli()).
+			Order("created_at").
+			Limit(retentionBatchSize).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return deleted, fmt.Errorf("failed to find messages to delete: %w", err)
+		}
+		if len(ids) == 0 {
+			return deleted, nil
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, model := range messageTables {
+				if err := tx.Where("message_id IN ?", ids).Delete(model).Error; err != nil {
+					return fmt.Errorf("failed to delete messages: %w", err)
+				}
+			}
+			if err := tx.Where("id IN ?", ids).Delete(&models.MessageModel{}).Error; err != nil {
+				return fmt.Errorf("failed to delete messages: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += len(ids)
+		if len(ids) < retentionBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+// withoutTemplateOverrides drops the template overrides, which hold content,
+// from the channel overrides column of a message, keeping the recipients and
+// settings overridden
+func withoutTemplateOverrides(overrides models.JSON) models.JSON {
+	for _, value := range overrides {
+		if override, ok := value.(map[string]interface{}); ok {
+			delete(override, "templateOverride")
+		}
+	}
+	if overrides == nil {
+		return models.JSON{}
+	}
+	return overrides
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/schedule"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// ScheduledSendRepositoryImpl implements schedule.ScheduledSendRepository interface using GORM
+type ScheduledSendRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewScheduledSendRepositoryImpl creates a new scheduled send repository implementation
+func NewScheduledSendRepositoryImpl(db *gorm.DB) *ScheduledSendRepositoryImpl {
+	return &ScheduledSendRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves the sends of a batch in one transaction
+func (r *ScheduledSendRepositoryImpl) Save(ctx context.Context, sends []*schedule.ScheduledSend) error {
+	if len(sends) == 0 {
+		return nil
+	}
+	rows := make([]*models.ScheduledSendModel, 0, len(sends))
+	for _, send := range sends {
+		rows = append(rows, toScheduledSendModel(send))
+	}
+	if err := r.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to save scheduled sends: %w", err)
+	}
+	return nil
+}
+
+// FindByBatch lists the sends of a batch by send time
+func (r *ScheduledSendRepositoryImpl) FindByBatch(ctx context.Context, batchID string) ([]*schedule.ScheduledSend, error) {
+	var rows []models.ScheduledSendModel
+	err := r.db.WithContext(ctx).
+		Where("batch_id = ?", batchID).
+		Order("send_at ASC, timezone ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled sends: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, shared.NewNotFoundError("SCHEDULE_NOT_FOUND", "scheduled send batch not found")
+	}
+	return fromScheduledSendModels(rows), nil
+}
+
+// pendingStatuses are the statuses of the sends waiting for their time
+var pendingStatuses = []string{string(schedule.StatusScheduled), string(schedule.StatusDeferred)}
+
+// FindDue lists the pending sends whose time has come, the earliest first
+func (r *ScheduledSendRepositoryImpl) FindDue(ctx context.Context, now time.Time, limit int) ([]*schedule.ScheduledSend, error) {
+	var rows []models.ScheduledSendModel
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND send_at <= ?", pendingStatuses, now.UnixMilli()).
+		Order("send_at ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due scheduled sends: %w", err)
+	}
+	return fromScheduledSendModels(rows), nil
+}
+
+// Claim moves a scheduled send to sending when it is still pending
+func (r *ScheduledSendRepositoryImpl) Claim(ctx context.Context, id string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ScheduledSendModel{}).
+		Where("id = ? AND status IN ?", id, pendingStatuses).
+		Update("status", string(schedule.StatusSending))
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim scheduled send: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Update stores the outcome or the deferral of a scheduled send
+func (r *ScheduledSendRepositoryImpl) Update(ctx context.Context, send *schedule.ScheduledSend) error {
+	model := toScheduledSendModel(send)
+	result := r.db.WithContext(ctx).
+		Model(&models.ScheduledSendModel{}).
+		Where("id = ?", send.ID).
+		Updates(map[string]interface{}{
+			"status":        model.Status,
+			"send_at":       model.SendAt,
+			"deferred_from": model.DeferredFrom,
+			"deferred_by":   model.DeferredBy,
+			"message_id":    model.MessageID,
+			"error":         model.Error,
+			"sent_at":       model.SentAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update scheduled send: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("SCHEDULE_NOT_FOUND", "scheduled send not found")
+	}
+	return nil
+}
+
+// CancelBatch cancels the sends of a batch still pending
+func (r *ScheduledSendRepositoryImpl) CancelBatch(ctx context.Context, batchID string) (int, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ScheduledSendModel{}).
+		Where("batch_id = ? AND status IN ?", batchID, pendingStatuses).
+		Update("status", string(schedule.StatusCancelled))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to cancel scheduled sends: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// toScheduledSendModel converts a scheduled send to GORM model
+func toScheduledSendModel(send *schedule.ScheduledSend) *models.ScheduledSendModel {
+	model := &models.ScheduledSendModel{
+		ID:         send.ID,
+		BatchID:    send.BatchID,
+		TenantID:   send.TenantID,
+		Timezone:   send.Timezone,
+		SendAt:     send.SendAt.UnixMilli(),
+		Request:    send.Request,
+		Critical:   send.Critical,
+		Status:     string(send.Status),
+		DeferredBy: send.DeferredBy,
+		MessageID:  send.MessageID,
+		Error:      send.Error,
+		CreatedAt:  send.CreatedAt.UnixMilli(),
+	}
+	if send.DeferredFrom != nil {
+		deferredFrom := send.DeferredFrom.UnixMilli()
+		model.DeferredFrom = &deferredFrom
+	}
+	if send.SentAt != nil {
+		sentAt := send.SentAt.UnixMilli()
+		model.SentAt = &sentAt
+	}
+	return model
+}
+
+// fromScheduledSendModels converts GORM models to scheduled sends
+func fromScheduledSendModels(rows []models.ScheduledSendModel) []*schedule.ScheduledSend {
+	sends := make([]*schedule.ScheduledSend, 0, len(rows))
+	for i := range rows {
+		model := &rows[i]
+		send := &schedule.ScheduledSend{
+			ID:         model.ID,
+			BatchID:    model.BatchID,
+			TenantID:   model.TenantID,
+			Timezone:   model.Timezone,
+			SendAt:     time.UnixMilli(model.SendAt),
+			Request:    model.Request,
+			Critical:   model.Critical,
+			Status:     schedule.Status(model.Status),
+			DeferredBy: model.DeferredBy,
+			MessageID:  model.MessageID,
+			Error:      model.Error,
+			CreatedAt:  time.UnixMilli(model.CreatedAt),
+		}
+		if model.DeferredFrom != nil {
+			deferredFrom := time.UnixMilli(*model.DeferredFrom)
+			send.DeferredFrom = &deferredFrom
+		}
+		if model.SentAt != nil {
+			sentAt := time.UnixMilli(*model.SentAt)
+			send.SentAt = &sentAt
+		}
+		sends = append(sends, send)
+	}
+	return sends
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/identity"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// SenderIdentityRepositoryImpl implements identity.SenderIdentityRepository interface using GORM
+type SenderIdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSenderIdentityRepositoryImpl creates a new sender identity repository implementation
+func NewSenderIdentityRepositoryImpl(db *gorm.DB) *SenderIdentityRepositoryImpl {
+	return &SenderIdentityRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves a new sender identity to the database
+func (r *SenderIdentityRepositoryImpl) Save(ctx context.Context, senderIdentity *identity.SenderIdentity) error {
+	if err := r.db.WithContext(ctx).Create(toSenderIdentityModel(senderIdentity)).Error; err != nil {
+		return fmt.Errorf("failed to save sender identity: %w", err)
+	}
+	return nil
+}
+
+// Update stores the verification of a sender identity
+func (r *SenderIdentityRepositoryImpl) Update(ctx context.Context, senderIdentity *identity.SenderIdentity) error {
+	model := toSenderIdentityModel(senderIdentity)
+	result := r.db.WithContext(ctx).
+		Model(&models.SenderIdentityModel{}).
+		Where("id = ?", senderIdentity.ID).
+		Updates(map[string]interface{}{
+			"status":      model.Status,
+			"verified_at": model.VerifiedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update sender identity: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("SENDER_IDENTITY_NOT_FOUND", "sender identity not found")
+	}
+	return nil
+}
+
+// FindByID finds a sender identity by its ID
+func (r *SenderIdentityRepositoryImpl) FindByID(ctx context.Context, id string) (*identity.SenderIdentity, error) {
+	var model models.SenderIdentityModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.NewNotFoundError("SENDER_IDENTITY_NOT_FOUND", "sender identity not found")
+		}
+		return nil, fmt.Errorf("failed to find sender identity: %w", err)
+	}
+	return fromSenderIdentityModel(&model), nil
+}
+
+// FindByTenant lists the sender identities of a tenant, or of every tenant
+func (r *SenderIdentityRepositoryImpl) FindByTenant(ctx context.Context, tenantID string) ([]*identity.SenderIdentity, error) {
+	query := r.db.WithContext(ctx).Order("tenant_id ASC, value ASC")
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+
+	var rows []models.SenderIdentityModel
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query sender identities: %w", err)
+	}
+
+	identities := make([]*identity.SenderIdentity, 0, len(rows))
+	for i := range rows {
+		identities = append(identities, fromSenderIdentityModel(&rows[i]))
+	}
+	return identities, nil
+}
+
+// Delete deletes a sender identity
+func (r *SenderIdentityRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.SenderIdentityModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete sender identity: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("SENDER_IDENTITY_NOT_FOUND", "sender identity not found")
+	}
+	return nil
+}
+
+// toSenderIdentityModel converts a sender identity to GORM model
+func toSenderIdentityModel(senderIdentity *identity.SenderIdentity) *models.SenderIdentityModel {
+	model := &models.SenderIdentityModel{
+		ID:        senderIdentity.ID,
+		TenantID:  senderIdentity.TenantID,
+		Kind:      string(senderIdentity.Kind),
+		Value:     senderIdentity.Value,
+		Status:    string(senderIdentity.Status),
+		Token:     senderIdentity.Token,
+		CreatedAt: senderIdentity.CreatedAt.UnixMilli(),
+	}
+	if senderIdentity.VerifiedAt != nil {
+		verifiedAt := senderIdentity.VerifiedAt.UnixMilli()
+		model.VerifiedAt = &verifiedAt
+	}
+	return model
+}
+
+// fromSenderIdentityModel converts GORM model to a sender identity
+func fromSenderIdentityModel(model *models.SenderIdentityModel) *identity.SenderIdentity {
+	senderIdentity := &identity.SenderIdentity{
+		ID:        model.ID,
+		TenantID:  model.TenantID,
+		Kind:      identity.Kind(model.Kind),
+		Value:     model.Value,
+		Status:    identity.Status(model.Status),
+		Token:     model.Token,
+		CreatedAt: time.UnixMilli(model.CreatedAt),
+	}
+	if model.VerifiedAt != nil {
+		verifiedAt := time.UnixMilli(*model.VerifiedAt)
+		senderIdentity.VerifiedAt = &verifiedAt
+	}
+	return senderIdentity
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/message"
+	"notification/internal/infrastructure/models"
+)
+
+// ShortLinkRepositoryImpl implements message.ShortLinkRepository interface using GORM
+type ShortLinkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewShortLinkRepositoryImpl creates a new short link repository implementation
+func NewShortLinkRepositoryImpl(db *gorm.DB) *ShortLinkRepositoryImpl {
+	return &ShortLinkRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save records a new short link
+func (r *ShortLinkRepositoryImpl) Save(ctx context.Context, link *message.ShortLink) error {
+	model := &models.ShortLinkModel{
+		Code:          link.Code,
+		TargetURL:     link.TargetURL,
+		MessageID:     link.MessageID,
+		ChannelID:     link.ChannelID,
+		Clicks:        link.Clicks,
+		CreatedAt:     link.CreatedAt,
+		LastClickedAt: link.LastClickedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save short link: %w", err)
+	}
+	return nil
+}
+
+// FindByCode finds a short link by its code
+func (r *ShortLinkRepositoryImpl) FindByCode(ctx context.Context, code string) (*message.ShortLink, error) {
+	var model models.ShortLinkModel
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find short link: %w", err)
+	}
+	return toShortLink(&model), nil
+}
+
+// FindByMessageID lists the short links of a message
+func (r *ShortLinkRepositoryImpl) FindByMessageID(ctx context.Context, messageID string) ([]*message.ShortLink, error) {
+	var rows []models.ShortLinkModel
+	err := r.db.WithContext(ctx).Where("message_id = ?", messageID).Order("created_at ASC").Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list short links: %w", err)
+	}
+
+	links := make([]*message.ShortLink, 0, len(rows))
+	for i := range rows {
+		links = append(links, toShortLink(&rows[i]))
+	}
+	return links, nil
+}
+
+// RecordClick counts a click on a short link in a single update, so that
+// concurrent clicks are all counted
+func (r *ShortLinkRepositoryImpl) RecordClick(ctx context.Context, code string, clickedAt int64) error {
+	err := r.db.WithContext(ctx).Model(&models.ShortLinkModel{}).
+		Where("code = ?", code).
+		Updates(map[string]interface{}{
+			"clicks":          gorm.Expr("clicks + 1"),
+			"last_clicked_at": clickedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record short link click: %w", err)
+	}
+	return nil
+}
+
+// toShortLink converts a short link model to its domain form
+func toShortLink(model *models.ShortLinkModel) *message.ShortLink {
+	return &message.ShortLink{
+		Code:          model.Code,
+		TargetURL:     model.TargetURL,
+		MessageID:     model.MessageID,
+		ChannelID:     model.ChannelID,
+		Clicks:        model.Clicks,
+		CreatedAt:     model.CreatedAt,
+		LastClickedAt: model.LastClickedAt,
+	}
+}
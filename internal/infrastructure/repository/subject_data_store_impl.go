@@ -0,0 +1,400 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"notification/internal/domain/privacy"
+	"notification/internal/infrastructure/models"
+	"notification/pkg/pii"
+)
+
+// subjectTable is a table whose rows belong to the subject in a column
+type subjectTable struct {
+	kind   string
+	model  interface{}
+	rows   interface{}
+	column string
+}
+
+// subjectTables returns the tables keyed by the address of a subject, with
+// fresh rows to export them into
+func subjectTables() []subjectTable {
+	return []subjectTable{
+		{privacy.DataAcknowledgments, &models.AcknowledgmentModel{}, &[]models.AcknowledgmentModel{}, "recipient"},
+		{privacy.DataReplyTokens, &models.ReplyTokenModel{}, &[]models.ReplyTokenModel{}, "recipient"},
+		{privacy.DataReplies, &models.ReplyModel{}, &[]models.ReplyModel{}, "sender"},
+		{privacy.DataOTPCodes, &models.OTPCodeModel{}, &[]models.OTPCodeModel{}, "recipient"},
+		{privacy.DataInAppNotifications, &models.InAppNotificationModel{}, &[]models.InAppNotificationModel{}, "user_id"},
+		{privacy.DataPreferences, &models.UserPreferenceModel{}, &[]models.UserPreferenceModel{}, "user_id"},
+		{privacy.DataSubscriptions, &models.CategorySubscriptionModel{}, &[]models.CategorySubscriptionModel{}, "user_id"},
+	}
+}
+
+// messageTables are the tables whose rows belong to a message, deleted with
+// the messages sent to the erased subject alone
+var messageTables = []interface{}{
+	&models.MessageResultModel{},
+	&models.AcknowledgmentModel{},
+	&models.ReplyTokenModel{},
+	&models.ReplyModel{},
+	&models.ShortLinkModel{},
+	&models.QuarantinedAttachmentModel{},
+}
+
+// SubjectDataStoreImpl implements privacy.SubjectDataStore interface using GORM
+type SubjectDataStoreImpl struct {
+	db *gorm.DB
+	// piiCipher decrypts the recipient targets, and finds the encrypted ones
+	piiCipher *pii.Cipher
+}
+
+// NewSubjectDataStoreImpl creates a new subject data store implementation
+func NewSubjectDataStoreImpl(db *gorm.DB) *SubjectDataStoreImpl {
+	return &SubjectDataStoreImpl{
+		db: db,
+	}
+}
+
+// SetPIICipher finds and decrypts the recipient targets encrypted by the
+// message repository.
+func (s *SubjectDataStoreImpl) SetPIICipher(cipher *pii.Cipher) {
+	s.piiCipher = cipher
+}
+
+// subjectMessage is a message sent to a subject; alone tells whether the
+// subject was its only recipient
+type subjectMessage struct {
+	model *models.MessageModel
+	alone bool
+}
+
+// Export collects the rows stored about a subject. The messages shared with
+// other recipients are exported with the results of the subject only.
+func (s *SubjectDataStoreImpl) Export(ctx context.Context, subject string) (privacy.Export, error) {
+	db := s.db.WithContext(ctx)
+	export := make(privacy.Export)
+
+	messages, err := findSubjectMessages(db, s.piiCipher, subject)
+	if err != nil {
+		return nil, err
+	}
+	export[privacy.DataMessages] = make([]map[string]interface{}, 0, len(messages))
+	for _, message := range messages {
+		export[privacy.DataMessages] = append(export[privacy.DataMessages], exportMessage(message.model, subject))
+	}
+
+	for _, table := range subjectTables() {
+		if err := db.Where("LOWER("+table.column+") = ?", subject).Find(table.rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", table.kind, err)
+		}
+		rows, err := toExportRows(table.rows)
+		if err != nil {
+			return nil, err
+		}
+		export[table.kind] = rows
+	}
+
+	var sends []models.ScheduledSendModel
+	if err := whereScheduledFor(db, subject).Find(&sends).Error; err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", privacy.DataScheduledSends, err)
+	}
+	rows, err := toExportRows(&sends)
+	if err != nil {
+		return nil, err
+	}
+	export[privacy.DataScheduledSends] = rows
+
+	return export, nil
+}
+
+// Erase deletes the rows stored about a subject in one transaction: the
+// messages sent to it alone with the rows belonging to them, its rows in the
+// tables keyed by address, its scheduled sends and the exports made of them.
+// The messages shared with other recipients keep their results, the subject
+// replaced by privacy.ErasedTarget.
+func (s *SubjectDataStoreImpl) Erase(ctx context.Context, subject string) (privacy.Report, error) {
+	report := privacy.Report{privacy.DataMessages: 0, privacy.DataRedactedMessages: 0}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		messages, err := findSubjectMessages(tx, s.piiCipher, subject)
+		if err != nil {
+			return err
+		}
+
+		// 1. The rows keyed by the subject, counted before the messages
+		// they belong to take the rest with them
+		for _, table := range subjectTables() {
+			result := tx.Where("LOWER("+table.column+") = ?", subject).Delete(table.model)
+			if result.Error != nil {
+				return fmt.Errorf("failed to erase %s: %w", table.kind, result.Error)
+			}
+			report[table.kind] = int(result.RowsAffected)
+		}
+
+		// 2. The messages, deleted or redacted
+		var deleted []string
+		for _, message := range messages {
+			if message.alone {
+				deleted = append(deleted, message.model.ID)
+				continue
+			}
+			if err := redactMessage(tx, s.piiCipher, message.model, subject); err != nil {
+				return err
+			}
+			report[privacy.DataRedactedMessages]++
+		}
+		if len(deleted) > 0 {
+			for _, model := range messageTables {
+				if err := tx.Where("message_id IN ?", deleted).Delete(model).Error; err != nil {
+					return fmt.Errorf("failed to erase messages: %w", err)
+				}
+			}
+			result := tx.Where("id IN ?", deleted).Delete(&models.MessageModel{})
+			if result.Error != nil {
+				return fmt.Errorf("failed to erase messages: %w", result.Error)
+			}
+			report[privacy.DataMessages] = int(result.RowsAffected)
+		}
+
+		// 3. The scheduled sends
+		result := whereScheduledFor(tx, subject).Delete(&models.ScheduledSendModel{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to erase %s: %w", privacy.DataScheduledSends, result.Error)
+		}
+		report[privacy.DataScheduledSends] = int(result.RowsAffected)
+
+		// 4. The exports made of the subject
+		result = tx.Model(&models.PrivacyRequestModel{}).
+			Where("subject_hash = ? AND kind = ? AND export <> ''", privacy.HashSubject(subject), string(privacy.RequestKindExport)).
+			Updates(map[string]interface{}{
+				"subject": "",
+				"export":  "",
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to erase %s: %w", privacy.DataExports, result.Error)
+		}
+		report[privacy.DataExports] = int(result.RowsAffected)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// findSubjectMessages finds the messages whose results or recipient overrides
+// hold a subject, their targets decrypted. The JSON columns are matched as
+// text first, on the subject or the lookup prefixes of its encrypted forms,
+// then decoded to drop the addresses merely containing the subject.
+func findSubjectMessages(db *gorm.DB, cipher *pii.Cipher, subject string) ([]*subjectMessage, error) {
+	patterns := []string{"%" + escapeLike(subject) + "%"}
+	if cipher != nil {
+		for _, prefix := range cipher.LookupPrefixes(subject) {
+			patterns = append(patterns, "%"+escapeLike(strings.ToLower(prefix))+"%")
+		}
+	}
+
+	var ids []string
+	err := whereAnyLike(db.Model(&models.MessageResultModel{}), "recipients", patterns).
+		Distinct("message_id").
+		Pluck("message_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the messages of the subject: %w", err)
+	}
+	var overridden []string
+	err = whereAnyLike(db.Model(&models.MessageModel{}), "channel_overrides", patterns).
+		Pluck("id", &overridden).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the messages of the subject: %w", err)
+	}
+	ids = append(ids, overridden...)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var messageModels []models.MessageModel
+	if err := db.Preload("Results").Where("id IN ?", ids).Order("created_at ASC").Find(&messageModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find the messages of the subject: %w", err)
+	}
+	messages := make([]*subjectMessage, 0, len(messageModels))
+	for i := range messageModels {
+		if err := openMessageTargets(cipher, &messageModels[i]); err != nil {
+			return nil, err
+		}
+		targets := messageTargets(&messageModels[i])
+		matched := 0
+		for _, target := range targets {
+			if target == subject {
+				matched++
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+		messages = append(messages, &subjectMessage{model: &messageModels[i], alone: matched == len(targets)})
+	}
+	return messages, nil
+}
+
+// whereAnyLike selects the rows whose JSON column, as lower case text, is
+// like one of the patterns
+func whereAnyLike(db *gorm.DB, column string, patterns []string) *gorm.DB {
+	conditions := make([]string, 0, len(patterns))
+	args := make([]interface{}, 0, len(patterns))
+	for _, pattern := range patterns {
+		conditions = append(conditions, "LOWER(CAST("+column+" AS TEXT)) LIKE ? ESCAPE '\\'")
+		args = append(args, pattern)
+	}
+	return db.Where(strings.Join(conditions, " OR "), args...)
+}
+
+// openMessageTargets decrypts the targets of the results and recipient
+// overrides of a message in place
+func openMessageTargets(cipher *pii.Cipher, model *models.MessageModel) error {
+	for i := range model.Results {
+		if err := openTargets(cipher, model.Results[i].Recipients); err != nil {
+			return fmt.Errorf("failed to decrypt message result: %w", err)
+		}
+	}
+	if err := openTargets(cipher, overrideRecipients(model.ChannelOverrides)); err != nil {
+		return fmt.Errorf("failed to decrypt message: %w", err)
+	}
+	return nil
+}
+
+// messageTargets returns the normalized targets of the results and recipient
+// overrides of a message
+func messageTargets(model *models.MessageModel) []string {
+	var targets []string
+	for _, result := range model.Results {
+		for _, recipient := range result.Recipients {
+			if target := recipientTarget(recipient); target != "" {
+				targets = append(targets, target)
+			}
+		}
+	}
+	for _, recipient := range overrideRecipients(model.ChannelOverrides) {
+		if target := recipientTarget(recipient); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// recipientTarget returns the normalized target of a decoded recipient
+func recipientTarget(recipient map[string]interface{}) string {
+	target, _ := recipient["target"].(string)
+	return privacy.NormalizeSubject(target)
+}
+
+// exportMessage converts a message to an export row holding the results of
+// the subject only
+func exportMessage(model *models.MessageModel, subject string) map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(model.Results))
+	for _, result := range model.Results {
+		var recipients []map[string]interface{}
+		for _, recipient := range result.Recipients {
+			if recipientTarget(recipient) == subject {
+				recipients = append(recipients, recipient)
+			}
+		}
+		if len(recipients) == 0 {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"channel_id": result.ChannelID,
+			"status":     result.Status,
+			"sent_at":    result.SentAt,
+			"recipients": recipients,
+		})
+	}
+	return map[string]interface{}{
+		"id":             model.ID,
+		"correlation_id": model.CorrelationID,
+		"tenant_id":      model.TenantID,
+		"message_class":  model.MessageClass,
+		"status":         model.Status,
+		"variables":      model.Variables,
+		"created_at":     model.CreatedAt,
+		"results":        results,
+	}
+}
+
+// redactMessage replaces a subject with privacy.ErasedTarget in the
+// decrypted results and recipient overrides of a message, encrypting the
+// targets again
+func redactMessage(tx *gorm.DB, cipher *pii.Cipher, model *models.MessageModel, subject string) error {
+	for i := range model.Results {
+		result := &model.Results[i]
+		if !redactRecipients(result.Recipients, subject) {
+			continue
+		}
+		if err := sealTargets(cipher, result.Recipients); err != nil {
+			return err
+		}
+		err := tx.Model(&models.MessageResultModel{}).
+			Where("id = ?", result.ID).
+			Update("recipients", result.Recipients).Error
+		if err != nil {
+			return fmt.Errorf("failed to redact message result: %w", err)
+		}
+	}
+
+	if overridden := overrideRecipients(model.ChannelOverrides); redactRecipients(overridden, subject) {
+		if err := sealTargets(cipher, overridden); err != nil {
+			return err
+		}
+		err := tx.Model(&models.MessageModel{}).
+			Where("id = ?", model.ID).
+			Update("channel_overrides", model.ChannelOverrides).Error
+		if err != nil {
+			return fmt.Errorf("failed to redact message: %w", err)
+		}
+	}
+	return nil
+}
+
+// redactRecipients replaces a subject in decoded recipients, in place, telling
+// whether any was; overridden recipients may be named after their address
+func redactRecipients(recipients []map[string]interface{}, subject string) bool {
+	redacted := false
+	for _, recipient := range recipients {
+		if recipientTarget(recipient) != subject {
+			continue
+		}
+		recipient["target"] = privacy.ErasedTarget
+		if name, ok := recipient["name"].(string); ok && privacy.NormalizeSubject(name) == subject {
+			recipient["name"] = privacy.ErasedTarget
+		}
+		redacted = true
+	}
+	return redacted
+}
+
+// whereScheduledFor selects the scheduled sends whose request holds a subject
+// as a JSON string
+func whereScheduledFor(db *gorm.DB, subject string) *gorm.DB {
+	return db.Where("LOWER(request) LIKE ? ESCAPE '\\'", `%"`+escapeLike(subject)+`"%`)
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// toExportRows converts the models found to export rows, in their JSON form
+func toExportRows(modelRows interface{}) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(modelRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export rows: %w", err)
+	}
+	rows := make([]map[string]interface{}, 0)
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to export rows: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// taggedRow is the ID and tags of a channel or template row. The column type
+// is declared because GORM cannot infer one from a nil pq.StringArray and
+// would otherwise leave the tags unread.
+type taggedRow struct {
+	ID   string
+	Tags pq.StringArray `gorm:"type:text[]"`
+}
+
+// countTags counts the rows of the model's table carrying each tag, leaving
+// out soft-deleted rows
+func countTags(db *gorm.DB, model interface{}) (map[string]int, error) {
+	var rows []taggedRow
+	if err := db.Model(model).Select("id, tags").Where("deleted_at IS NULL").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			counts[tag]++
+		}
+	}
+	return counts, nil
+}
+
+// replaceTags replaces the from tags with the to tag on the rows of the
+// model's table carrying one of them, in one transaction, and returns the
+// number of rows changed. onReplaced, when set, is called in the transaction
+// with the new tags of every changed row.
+func replaceTags(db *gorm.DB, model interface{}, from []string, to string, onReplaced func(tx *gorm.DB, id string, tags []string) error) (int, error) {
+	replaced := make(map[string]bool, len(from))
+	for _, tag := range from {
+		replaced[tag] = true
+	}
+
+	changed := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(model).Select("id, tags").Where("deleted_at IS NULL")
+		if tx.Dialector.Name() == "postgres" {
+			query = query.Where("tags && ?", pq.StringArray(from))
+		}
+
+		var rows []taggedRow
+		if err := query.Find(&rows).Error; err != nil {
+			return err
+		}
+
+		now := time.Now().UnixMilli()
+		for _, row := range rows {
+			tags, ok := withTagsReplaced(row.Tags, replaced, to)
+			if !ok {
+				continue
+			}
+
+			err := tx.Model(model).Where("id = ?", row.ID).Updates(map[string]interface{}{
+				"tags":       pq.StringArray(tags),
+				"updated_at": now,
+				"version":    gorm.Expr("version + 1"),
+			}).Error
+			if err != nil {
+				return err
+			}
+			if onReplaced != nil {
+				if err := onReplaced(tx, row.ID, tags); err != nil {
+					return err
+				}
+			}
+			changed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace tags: %w", err)
+	}
+
+	return changed, nil
+}
+
+// withTagsReplaced returns the tags with the replaced ones swapped for the
+// target, keeping their order and dropping duplicates. It reports false when
+// none of the tags is replaced.
+func withTagsReplaced(tags []string, replaced map[string]bool, target string) ([]string, bool) {
+	found := false
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if replaced[tag] {
+			tag = target
+			found = true
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result, found
+}
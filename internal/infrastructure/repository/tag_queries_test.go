@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+// saveTaggedTemplate stores an email template carrying tags
+func saveTaggedTemplate(t *testing.T, repo *TemplateRepositoryImpl, name string, tags ...string) *template.Template {
+	t.Helper()
+	templateName, err := template.NewTemplateName(name)
+	require.NoError(t, err)
+	content, err := template.NewTemplateContent("Hello")
+	require.NoError(t, err)
+	tmpl, err := template.NewTemplate(templateName, nil, shared.ChannelTypeEmail, nil, content, template.NewTags(tags))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), tmpl))
+	return tmpl
+}
+
+func TestTemplateRepository_CountsAndReplacesTags(t *testing.T) {
+	repo, _ := newTemplateRepository(t)
+	renamed := saveTaggedTemplate(t, repo, "alerts", "ops", "critical")
+	saveTaggedTemplate(t, repo, "reports", "critical")
+
+	counts, err := repo.CountTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"ops": 1, "critical": 2}, counts)
+
+	changed, err := repo.ReplaceTags(context.Background(), []string{"ops", "critical"}, "operations")
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+
+	stored, err := repo.FindByID(context.Background(), renamed.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"operations"}, stored.Tags().ToSlice())
+	assert.Equal(t, renamed.Version().Int()+1, stored.Version().Int())
+}
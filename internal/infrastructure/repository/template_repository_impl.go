@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"gorm.io/gorm"
@@ -12,6 +14,10 @@ import (
 	"notification/internal/infrastructure/models"
 )
 
+// errTemplateModified is returned when a conditional write finds the template
+// at another version than the one the request was checked against
+var errTemplateModified = shared.NewPreconditionFailedError("PRECONDITION_FAILED", "template has been modified since it was last retrieved")
+
 // TemplateRepositoryImpl implements template.TemplateRepository interface using GORM
 type TemplateRepositoryImpl struct {
 	db *gorm.DB
@@ -48,7 +54,7 @@ func (r *TemplateRepositoryImpl) FindByID(ctx context.Context, id *template.Temp
 	
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("template not found")
+			return nil, shared.NewNotFoundError("TEMPLATE_NOT_FOUND", "template not found")
 		}
 		return nil, fmt.Errorf("failed to find template: %w", err)
 	}
@@ -66,7 +72,7 @@ func (r *TemplateRepositoryImpl) FindByName(ctx context.Context, name *template.
 	
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("template not found")
+			return nil, shared.NewNotFoundError("TEMPLATE_NOT_FOUND", "template not found")
 		}
 		return nil, fmt.Errorf("failed to find template: %w", err)
 	}
@@ -95,6 +101,19 @@ func (r *TemplateRepositoryImpl) FindAll(ctx context.Context, filter *template.T
 		}
 	}
 
+	if filter.HasOwnerFilter() {
+		query = query.Where("owner = ?", filter.Owner)
+	}
+
+	if filter.HasTeamFilter() {
+		query = query.Where("team = ?", filter.Team)
+	}
+
+	if filter.HasEnvironmentFilter() {
+		// Unscoped templates serve every environment
+		query = query.Where("environment IN (?, '')", filter.Environment.String())
+	}
+
 	// Count total records
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
@@ -142,18 +161,58 @@ func (r *TemplateRepositoryImpl) Update(ctx context.Context, tmpl *template.Temp
 		return fmt.Errorf("failed to convert template to model: %w", err)
 	}
 
-	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A conditional request only writes the version it was checked against
+		if expected, ok := shared.ExpectedVersionFromContext(ctx, model.ID); ok {
+			result := tx.Model(&models.TemplateModel{}).
+				Where("id = ? AND version = ?", model.ID, expected).
+				UpdateColumn("version", model.Version)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errTemplateModified
+			}
+		}
+		return tx.Save(model).Error
+	})
+	if err != nil {
+		if errors.Is(err, errTemplateModified) {
+			return err
+		}
 		return fmt.Errorf("failed to update template: %w", err)
 	}
 
 	return nil
 }
 
+// CountTags returns the number of templates carrying each tag
+func (r *TemplateRepositoryImpl) CountTags(ctx context.Context) (map[string]int, error) {
+	return countTags(r.db.WithContext(ctx), &models.TemplateModel{})
+}
+
+// ReplaceTags replaces the given tags with another tag on every template carrying one of them
+func (r *TemplateRepositoryImpl) ReplaceTags(ctx context.Context, from []string, to string) (int, error) {
+	return replaceTags(r.db.WithContext(ctx), &models.TemplateModel{}, from, to, nil)
+}
+
 // Delete deletes a template from the database (hard delete)
 func (r *TemplateRepositoryImpl) Delete(ctx context.Context, id *template.TemplateID) error {
-	if err := r.db.WithContext(ctx).Delete(&models.TemplateModel{}, "id = ?", id.String()).Error; err != nil {
+	query := r.db.WithContext(ctx).Where("id = ?", id.String())
+	expected, conditional := shared.ExpectedVersionFromContext(ctx, id.String())
+	if conditional {
+		query = query.Where("version = ?", expected)
+	}
+	result := query.Delete(&models.TemplateModel{})
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return shared.NewConflictError("TEMPLATE_IN_USE", "template is still referenced by channels")
+		}
 		return fmt.Errorf("failed to delete template: %w", err)
 	}
+	if conditional && result.RowsAffected == 0 {
+		return errTemplateModified
+	}
 
 	return nil
 }
@@ -196,6 +255,12 @@ func (r *TemplateRepositoryImpl) toTemplateModel(tmpl *template.Template) (*mode
 		deletedAt = tmpl.Timestamps().DeletedAt
 	}
 
+	// Convert attachments to JSONArray
+	attachments, err := toAttachmentsJSON(tmpl.Attachments())
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.TemplateModel{
 		ID:          tmpl.ID().String(),
 		Name:        tmpl.Name().String(),
@@ -204,6 +269,14 @@ func (r *TemplateRepositoryImpl) toTemplateModel(tmpl *template.Template) (*mode
 		Subject:     tmpl.Subject().String(),
 		Content:     tmpl.Content().String(),
 		Tags:        pq.StringArray(tmpl.Tags().ToSlice()),
+		Strict:      tmpl.IsStrict(),
+		Attachments: attachments,
+		Headers:     toHeadersJSON(tmpl.Headers()),
+		Owner:       tmpl.Ownership().Owner,
+		Team:        tmpl.Ownership().Team,
+		Environment: tmpl.Environment().String(),
+		Variables:   pq.StringArray(tmpl.DeclaredVariables()),
+		Settings:    toSettingsJSON(tmpl.Settings()),
 		CreatedAt:   tmpl.Timestamps().CreatedAt,
 		UpdatedAt:   tmpl.Timestamps().UpdatedAt,
 		DeletedAt:   deletedAt,
@@ -255,6 +328,18 @@ func (r *TemplateRepositoryImpl) fromTemplateModel(model *models.TemplateModel)
 	// Convert tags
 	tags := template.NewTags(model.Tags)
 
+	// Convert attachments
+	attachments, err := fromAttachmentsJSON(model.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert settings
+	settings, err := fromSettingsJSON(model.Settings)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert version
 	version, err := template.NewVersionFromInt(model.Version)
 	if err != nil {
@@ -277,7 +362,120 @@ func (r *TemplateRepositoryImpl) fromTemplateModel(model *models.TemplateModel)
 		subject,
 		content,
 		tags,
+		model.Strict,
+		attachments,
+		fromHeadersJSON(model.Headers),
+		&shared.Ownership{Owner: model.Owner, Team: model.Team},
+		shared.Environment(model.Environment),
+		[]string(model.Variables),
+		settings,
 		timestamps,
 		version,
 	), nil
-}
\ No newline at end of file
+}
+// toSettingsJSON converts the delivery settings of a template to JSON, nil
+// when it has none
+func toSettingsJSON(settings *shared.CommonSettings) models.JSON {
+	if settings == nil {
+		return nil
+	}
+	return models.JSON{
+		"timeout":       settings.Timeout,
+		"retryAttempts": settings.RetryAttempts,
+		"retryDelay":    settings.RetryDelay,
+	}
+}
+
+// fromSettingsJSON converts the stored delivery settings of a template
+func fromSettingsJSON(record models.JSON) (*shared.CommonSettings, error) {
+	if len(record) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	var settings shared.CommonSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// toHeadersJSON converts the email headers of a template to JSON
+func toHeadersJSON(headers map[string]string) models.JSON {
+	record := make(models.JSON, len(headers))
+	for name, value := range headers {
+		record[name] = value
+	}
+	return record
+}
+
+// fromHeadersJSON converts the stored email headers of a template
+func fromHeadersJSON(record models.JSON) map[string]string {
+	headers := make(map[string]string, len(record))
+	for name, value := range record {
+		headers[name] = fmt.Sprintf("%v", value)
+	}
+	return headers
+}
+
+// attachmentRecord is the stored form of a template attachment; the content
+// is base64 encoded by encoding/json
+type attachmentRecord struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+	Inline      bool   `json:"inline,omitempty"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+// toAttachmentsJSON converts the attachments of a template to their column
+func toAttachmentsJSON(attachments *template.Attachments) (models.JSONArray, error) {
+	records := make([]attachmentRecord, 0, attachments.Count())
+	for _, a := range attachments.ToSlice() {
+		records = append(records, attachmentRecord{
+			Filename:    a.Filename(),
+			ContentType: a.ContentType(),
+			Content:     a.Content(),
+			Inline:      a.IsInline(),
+			ContentID:   a.ContentID(),
+		})
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal attachments: %w", err)
+	}
+	result := models.JSONArray{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachments to JSONArray type: %w", err)
+	}
+	return result, nil
+}
+
+// fromAttachmentsJSON converts the attachments column of a template
+func fromAttachmentsJSON(column models.JSONArray) (*template.Attachments, error) {
+	data, err := json.Marshal(column)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal attachments: %w", err)
+	}
+	var records []attachmentRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachments: %w", err)
+	}
+
+	items := make([]*template.Attachment, 0, len(records))
+	for _, record := range records {
+		item, err := template.NewAttachment(record.Filename, record.ContentType, record.Content, record.Inline, record.ContentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attachment: %w", err)
+		}
+		items = append(items, item)
+	}
+	attachments, err := template.NewAttachments(items)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachments: %w", err)
+	}
+	return attachments, nil
+}
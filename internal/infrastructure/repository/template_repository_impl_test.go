@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+	"notification/internal/infrastructure/models"
+)
+
+func newTemplateRepository(t *testing.T) (*TemplateRepositoryImpl, *template.Template) {
+	t.Helper()
+	shared.InitializeChannelTypes()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "templates.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.TemplateModel{}))
+
+	name, err := template.NewTemplateName("welcome")
+	require.NoError(t, err)
+	content, err := template.NewTemplateContent("Welcome {name}")
+	require.NoError(t, err)
+	tmpl, err := template.NewTemplate(name, nil, shared.ChannelTypeEmail, nil, content, nil)
+	require.NoError(t, err)
+
+	repo := NewTemplateRepositoryImpl(db)
+	require.NoError(t, repo.Save(context.Background(), tmpl))
+	return repo, tmpl
+}
+
+func updateContent(t *testing.T, tmpl *template.Template, content string) {
+	t.Helper()
+	templateContent, err := template.NewTemplateContent(content)
+	require.NoError(t, err)
+	require.NoError(t, tmpl.Update(tmpl.Name(), tmpl.Description(), tmpl.ChannelType(), tmpl.Subject(), templateContent, tmpl.Tags()))
+}
+
+func TestTemplateRepository_UpdateChecksTheExpectedVersion(t *testing.T) {
+	repo, tmpl := newTemplateRepository(t)
+	id := tmpl.ID().String()
+	checked := int64(tmpl.Version().Int())
+
+	// Another request changes the template after the precondition was checked
+	updateContent(t, tmpl, "Welcome back {name}")
+	require.NoError(t, repo.Update(context.Background(), tmpl))
+
+	stale, err := repo.FindByID(context.Background(), tmpl.ID())
+	require.NoError(t, err)
+	updateContent(t, stale, "Hello {name}")
+	err = repo.Update(shared.WithExpectedVersion(context.Background(), id, checked), stale)
+	assert.Equal(t, shared.ErrorKindPreconditionFailed, shared.ErrorKindOf(err))
+
+	stored, err := repo.FindByID(context.Background(), tmpl.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome back {name}", stored.Content().String())
+
+	// The version the template is at lets the write through
+	current := int64(stored.Version().Int())
+	updateContent(t, stored, "Hello {name}")
+	require.NoError(t, repo.Update(shared.WithExpectedVersion(context.Background(), id, current), stored))
+}
+
+func TestTemplateRepository_DeleteChecksTheExpectedVersion(t *testing.T) {
+	repo, tmpl := newTemplateRepository(t)
+	id := tmpl.ID().String()
+
+	err := repo.Delete(shared.WithExpectedVersion(context.Background(), id, int64(tmpl.Version().Int())+1), tmpl.ID())
+	assert.Equal(t, shared.ErrorKindPreconditionFailed, shared.ErrorKindOf(err))
+	exists, err := repo.Exists(context.Background(), tmpl.ID())
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, repo.Delete(shared.WithExpectedVersion(context.Background(), id, int64(tmpl.Version().Int())), tmpl.ID()))
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"notification/internal/domain/preference"
+	"notification/internal/domain/shared"
+	"notification/internal/infrastructure/models"
+)
+
+// UserPreferenceRepositoryImpl implements preference.PreferenceRepository interface using GORM
+type UserPreferenceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUserPreferenceRepositoryImpl creates a new user preference repository implementation
+func NewUserPreferenceRepositoryImpl(db *gorm.DB) *UserPreferenceRepositoryImpl {
+	return &UserPreferenceRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save saves the preferences of a user, replacing the earlier ones
+func (r *UserPreferenceRepositoryImpl) Save(ctx context.Context, userPreference *preference.UserPreference) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"preferred_channels", "opt_out_categories",
+			"quiet_hours_start", "quiet_hours_end", "quiet_hours_timezone", "timezone", "updated_at",
+		}),
+	}).Create(r.toPreferenceModel(userPreference)).Error
+	if err != nil {
+		return fmt.Errorf("failed to save user preference: %w", err)
+	}
+
+	return nil
+}
+
+// FindByUserID finds the preferences of a user
+func (r *UserPreferenceRepositoryImpl) FindByUserID(ctx context.Context, userID string) (*preference.UserPreference, error) {
+	var model models.UserPreferenceModel
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, shared.NewNotFoundError("PREFERENCE_NOT_FOUND", "user preference not found")
+		}
+		return nil, fmt.Errorf("failed to find user preference: %w", err)
+	}
+
+	return r.fromPreferenceModel(&model)
+}
+
+// FindByUserIDs finds the preferences of the users having some
+func (r *UserPreferenceRepositoryImpl) FindByUserIDs(ctx context.Context, userIDs []string) (map[string]*preference.UserPreference, error) {
+	preferences := make(map[string]*preference.UserPreference)
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+
+	var preferenceModels []models.UserPreferenceModel
+	if err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&preferenceModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to query user preferences: %w", err)
+	}
+
+	for _, model := range preferenceModels {
+		userPreference, err := r.fromPreferenceModel(&model)
+		if err != nil {
+			return nil, err
+		}
+		preferences[userPreference.UserID] = userPreference
+	}
+
+	return preferences, nil
+}
+
+// Delete deletes the preferences of a user
+func (r *UserPreferenceRepositoryImpl) Delete(ctx context.Context, userID string) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&models.UserPreferenceModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete user preference: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewNotFoundError("PREFERENCE_NOT_FOUND", "user preference not found")
+	}
+
+	return nil
+}
+
+// toPreferenceModel converts user preferences to GORM model
+func (r *UserPreferenceRepositoryImpl) toPreferenceModel(userPreference *preference.UserPreference) *models.UserPreferenceModel {
+	model := &models.UserPreferenceModel{
+		UserID:            userPreference.UserID,
+		PreferredChannels: userPreference.PreferredChannels,
+		OptOutCategories:  userPreference.OptOutCategories,
+		Timezone:          userPreference.Timezone,
+		UpdatedAt:         userPreference.UpdatedAt.UnixMilli(),
+	}
+	if model.PreferredChannels == nil {
+		model.PreferredChannels = []string{}
+	}
+	if model.OptOutCategories == nil {
+		model.OptOutCategories = []string{}
+	}
+	if quietHours := userPreference.QuietHours; quietHours != nil {
+		model.QuietHoursStart = quietHours.Start
+		model.QuietHoursEnd = quietHours.End
+		model.QuietHoursTimezone = quietHours.Timezone
+	}
+	return model
+}
+
+// fromPreferenceModel converts GORM model to user preferences
+func (r *UserPreferenceRepositoryImpl) fromPreferenceModel(model *models.UserPreferenceModel) (*preference.UserPreference, error) {
+	userPreference := &preference.UserPreference{
+		UserID:            model.UserID,
+		PreferredChannels: model.PreferredChannels,
+		OptOutCategories:  model.OptOutCategories,
+		Timezone:          model.Timezone,
+		UpdatedAt:         time.UnixMilli(model.UpdatedAt),
+	}
+	if model.QuietHoursStart != "" {
+		quietHours, err := preference.NewQuietHours(model.QuietHoursStart, model.QuietHoursEnd, model.QuietHoursTimezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quiet hours of user %s: %w", model.UserID, err)
+		}
+		userPreference.QuietHours = quietHours
+	}
+	return userPreference, nil
+}
@@ -0,0 +1,137 @@
+package executor
+
+import (
+	"context"
+
+	"notification/internal/application/channel/dtos"
+	"notification/internal/application/channel/usecases"
+	"notification/internal/application/cqrs"
+	channelcqrs "notification/internal/application/cqrs/channel"
+)
+
+// ChannelExecutor runs the channel operations behind the channel handlers
+type ChannelExecutor interface {
+	Create(ctx context.Context, request *dtos.CreateChannelRequest) (*dtos.ChannelResponse, error)
+	Get(ctx context.Context, channelID string) (*dtos.ChannelResponse, error)
+	List(ctx context.Context, request *dtos.ListChannelsRequest) (*dtos.ListChannelsResponse, error)
+	Update(ctx context.Context, channelID string, request *dtos.UpdateChannelRequest) (*dtos.ChannelResponse, error)
+	Delete(ctx context.Context, channelID string) (*dtos.DeleteChannelResponse, error)
+	SetEnabled(ctx context.Context, channelID string, enabled bool) (*dtos.ChannelResponse, error)
+}
+
+// useCaseChannelExecutor runs the channel operations directly on the use cases
+type useCaseChannelExecutor struct {
+	createUseCase *usecases.CreateChannelUseCase
+	getUseCase    *usecases.GetChannelUseCase
+	listUseCase   *usecases.ListChannelsUseCase
+	updateUseCase *usecases.UpdateChannelUseCase
+	deleteUseCase *usecases.DeleteChannelUseCase
+	enableUseCase *usecases.SetChannelEnabledUseCase
+}
+
+// NewUseCaseChannelExecutor creates a channel executor that calls the use cases directly
+func NewUseCaseChannelExecutor(
+	createUseCase *usecases.CreateChannelUseCase,
+	getUseCase *usecases.GetChannelUseCase,
+	listUseCase *usecases.ListChannelsUseCase,
+	updateUseCase *usecases.UpdateChannelUseCase,
+	deleteUseCase *usecases.DeleteChannelUseCase,
+	enableUseCase *usecases.SetChannelEnabledUseCase,
+) ChannelExecutor {
+	return &useCaseChannelExecutor{
+		createUseCase: createUseCase,
+		getUseCase:    getUseCase,
+		listUseCase:   listUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		enableUseCase: enableUseCase,
+	}
+}
+
+func (e *useCaseChannelExecutor) Create(ctx context.Context, request *dtos.CreateChannelRequest) (*dtos.ChannelResponse, error) {
+	return e.createUseCase.Execute(ctx, request)
+}
+
+func (e *useCaseChannelExecutor) Get(ctx context.Context, channelID string) (*dtos.ChannelResponse, error) {
+	return e.getUseCase.Execute(ctx, channelID)
+}
+
+func (e *useCaseChannelExecutor) List(ctx context.Context, request *dtos.ListChannelsRequest) (*dtos.ListChannelsResponse, error) {
+	return e.listUseCase.Execute(ctx, request)
+}
+
+func (e *useCaseChannelExecutor) Update(ctx context.Context, channelID string, request *dtos.UpdateChannelRequest) (*dtos.ChannelResponse, error) {
+	return e.updateUseCase.Execute(ctx, channelID, request)
+}
+
+func (e *useCaseChannelExecutor) Delete(ctx context.Context, channelID string) (*dtos.DeleteChannelResponse, error) {
+	return e.deleteUseCase.Execute(ctx, channelID)
+}
+
+func (e *useCaseChannelExecutor) SetEnabled(ctx context.Context, channelID string, enabled bool) (*dtos.ChannelResponse, error) {
+	return e.enableUseCase.Execute(ctx, channelID, enabled)
+}
+
+// cqrsChannelExecutor runs the channel operations as CQRS commands and
+// queries. Enabling and disabling have no command yet and use the use case
+// directly.
+type cqrsChannelExecutor struct {
+	cqrsFacade    *cqrs.CQRSFacade
+	enableUseCase *usecases.SetChannelEnabledUseCase
+}
+
+// NewCQRSChannelExecutor creates a channel executor that goes through the CQRS facade
+func NewCQRSChannelExecutor(cqrsFacade *cqrs.CQRSFacade, enableUseCase *usecases.SetChannelEnabledUseCase) ChannelExecutor {
+	return &cqrsChannelExecutor{
+		cqrsFacade:    cqrsFacade,
+		enableUseCase: enableUseCase,
+	}
+}
+
+func (e *cqrsChannelExecutor) Create(ctx context.Context, request *dtos.CreateChannelRequest) (*dtos.ChannelResponse, error) {
+	command := channelcqrs.NewCreateChannelCommand(request)
+	stampCommand(ctx, command.BaseCommand)
+	return send[*dtos.ChannelResponse](ctx, e.cqrsFacade, command)
+}
+
+func (e *cqrsChannelExecutor) Get(ctx context.Context, channelID string) (*dtos.ChannelResponse, error) {
+	query := channelcqrs.NewGetChannelQuery(channelID)
+	stampQuery(ctx, query.BaseQuery)
+	return ask[*dtos.ChannelResponse](ctx, e.cqrsFacade, query)
+}
+
+func (e *cqrsChannelExecutor) List(ctx context.Context, request *dtos.ListChannelsRequest) (*dtos.ListChannelsResponse, error) {
+	query := channelcqrs.NewListChannelsQuery()
+	stampQuery(ctx, query.BaseQuery)
+	query.WithChannelType(request.ChannelType).
+		WithTags(request.Tags).
+		WithTemplateID(request.TemplateID).
+		WithOwnership(request.Owner, request.Team, request.Environment).
+		WithLastUsedRange(request.LastUsedAfter, request.LastUsedBefore).
+		WithUnusedForDays(request.UnusedForDays).
+		WithPagination(request.SkipCount, request.MaxResultCount)
+	if request.SortField != "" {
+		sortOrder := request.SortOrder
+		if sortOrder == "" {
+			sortOrder = "asc"
+		}
+		query.WithSorting(request.SortField, sortOrder)
+	}
+	return ask[*dtos.ListChannelsResponse](ctx, e.cqrsFacade, query)
+}
+
+func (e *cqrsChannelExecutor) Update(ctx context.Context, channelID string, request *dtos.UpdateChannelRequest) (*dtos.ChannelResponse, error) {
+	command := channelcqrs.NewUpdateChannelCommand(channelID, request)
+	stampCommand(ctx, command.BaseCommand)
+	return send[*dtos.ChannelResponse](ctx, e.cqrsFacade, command)
+}
+
+func (e *cqrsChannelExecutor) Delete(ctx context.Context, channelID string) (*dtos.DeleteChannelResponse, error) {
+	command := channelcqrs.NewDeleteChannelCommand(channelID)
+	stampCommand(ctx, command.BaseCommand)
+	return send[*dtos.DeleteChannelResponse](ctx, e.cqrsFacade, command)
+}
+
+func (e *cqrsChannelExecutor) SetEnabled(ctx context.Context, channelID string, enabled bool) (*dtos.ChannelResponse, error) {
+	return e.enableUseCase.Execute(ctx, channelID, enabled)
+}
@@ -0,0 +1,61 @@
+// Package executor runs the channel, template and message operations behind
+// the HTTP and NATS handlers. An executor runs them either directly on the use
+// cases or as CQRS commands and queries, so that both transports share one
+// handler core whichever way the operations go.
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"notification/internal/application/cqrs"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// stampCommand records the actor and trace of the request on a command
+func stampCommand(ctx context.Context, base *cqrs.BaseCommand) {
+	base.UserID = shared.ActorFromContext(ctx)
+	base.TraceID = logger.RequestIDFromContext(ctx)
+}
+
+// stampQuery records the actor and trace of the request on a query
+func stampQuery(ctx context.Context, base *cqrs.BaseQuery) {
+	base.UserID = shared.ActorFromContext(ctx)
+	base.TraceID = logger.RequestIDFromContext(ctx)
+}
+
+// send executes a command and unwraps the data of its result
+func send[T any](ctx context.Context, facade *cqrs.CQRSFacade, cmd cqrs.Command) (T, error) {
+	var data T
+	result, err := facade.Send(ctx, cmd)
+	if err != nil {
+		return data, err
+	}
+	if !result.Success {
+		return data, result.Error
+	}
+	return unwrap[T](result.Data, cmd.GetCommandType())
+}
+
+// ask executes a query and unwraps the data of its result
+func ask[T any](ctx context.Context, facade *cqrs.CQRSFacade, q cqrs.Query) (T, error) {
+	var data T
+	result, err := facade.Query(ctx, q)
+	if err != nil {
+		return data, err
+	}
+	if !result.Success {
+		return data, result.Error
+	}
+	return unwrap[T](result.Data, q.GetQueryType())
+}
+
+// unwrap asserts the data of a result to the type the handlers expect
+func unwrap[T any](data interface{}, name string) (T, error) {
+	typed, ok := data.(T)
+	if !ok {
+		return typed, fmt.Errorf("unexpected result %T of %s", data, name)
+	}
+	return typed, nil
+}
@@ -0,0 +1,112 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	channeldtos "notification/internal/application/channel/dtos"
+	"notification/internal/application/cqrs"
+	channelcqrs "notification/internal/application/cqrs/channel"
+	messagecqrs "notification/internal/application/cqrs/message"
+	messagedtos "notification/internal/application/message/dtos"
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// queryHandlerFunc answers the queries of a type with a function
+type queryHandlerFunc struct {
+	queryType string
+	handle    func(query cqrs.Query) (*cqrs.QueryResult, error)
+}
+
+func (h *queryHandlerFunc) GetQueryType() string {
+	return h.queryType
+}
+
+func (h *queryHandlerFunc) Handle(ctx context.Context, query cqrs.Query) (*cqrs.QueryResult, error) {
+	return h.handle(query)
+}
+
+func newFacade(t *testing.T, handlers ...*queryHandlerFunc) *cqrs.CQRSFacade {
+	facade := cqrs.NewCQRSFacadeBuilder(nil).Build()
+	for _, handler := range handlers {
+		require.NoError(t, facade.Manager().RegisterQueryHandler(handler))
+	}
+	return facade
+}
+
+func TestCQRSExecutor_StampsAndUnwrapsQueries(t *testing.T) {
+	var seen *messagecqrs.GetMessageQuery
+	facade := newFacade(t, &queryHandlerFunc{
+		queryType: messagecqrs.GetMessageQueryType,
+		handle: func(query cqrs.Query) (*cqrs.QueryResult, error) {
+			seen = query.(*messagecqrs.GetMessageQuery)
+			return &cqrs.QueryResult{Success: true, Data: &messagedtos.MessageResponse{ID: seen.MessageID}}, nil
+		},
+	})
+
+	ctx := shared.WithActor(logger.ContextWithRequestID(context.Background(), "req-1"), "alice")
+	response, err := NewCQRSMessageExecutor(facade).Get(ctx, "msg-1")
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", response.ID)
+	assert.Equal(t, "alice", seen.UserID)
+	assert.Equal(t, "req-1", seen.TraceID)
+}
+
+func TestCQRSExecutor_KeepsTheDomainErrors(t *testing.T) {
+	facade := newFacade(t, &queryHandlerFunc{
+		queryType: messagecqrs.GetMessageQueryType,
+		handle: func(query cqrs.Query) (*cqrs.QueryResult, error) {
+			err := shared.NewNotFoundError("MESSAGE_NOT_FOUND", "message not found")
+			return &cqrs.QueryResult{Success: false, Error: err}, err
+		},
+	})
+
+	_, err := NewCQRSMessageExecutor(facade).Get(context.Background(), "msg-1")
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, shared.ErrorKindNotFound, domainErr.Kind())
+}
+
+func TestCQRSExecutor_RejectsUnexpectedResults(t *testing.T) {
+	facade := newFacade(t, &queryHandlerFunc{
+		queryType: messagecqrs.GetMessageQueryType,
+		handle: func(query cqrs.Query) (*cqrs.QueryResult, error) {
+			return &cqrs.QueryResult{Success: true, Data: "ok"}, nil
+		},
+	})
+
+	_, err := NewCQRSMessageExecutor(facade).Get(context.Background(), "msg-1")
+	assert.ErrorContains(t, err, "unexpected result string of message.get")
+}
+
+func TestCQRSChannelExecutor_PassesTheListFilters(t *testing.T) {
+	var seen *channelcqrs.ListChannelsQuery
+	facade := newFacade(t, &queryHandlerFunc{
+		queryType: channelcqrs.ListChannelsQueryType,
+		handle: func(query cqrs.Query) (*cqrs.QueryResult, error) {
+			seen = query.(*channelcqrs.ListChannelsQuery)
+			return &cqrs.QueryResult{Success: true, Data: &channeldtos.ListChannelsResponse{}}, nil
+		},
+	})
+
+	_, err := NewCQRSChannelExecutor(facade, nil).List(context.Background(), &channeldtos.ListChannelsRequest{
+		ChannelType:    "email",
+		Owner:          "alice",
+		Team:           "payments",
+		Environment:    "prod",
+		SkipCount:      20,
+		MaxResultCount: 10,
+		SortField:      "channelName",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "email", seen.ChannelType)
+	assert.Equal(t, "alice", seen.Owner)
+	assert.Equal(t, "payments", seen.Team)
+	assert.Equal(t, "prod", seen.Environment)
+	assert.Equal(t, &cqrs.Pagination{Offset: 20, Limit: 10}, seen.Options.Pagination)
+	assert.Equal(t, []cqrs.Sorting{{Field: "channelName", Order: "asc"}}, seen.Options.Sorting)
+}
@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"context"
+
+	"notification/internal/application/cqrs"
+	messagecqrs "notification/internal/application/cqrs/message"
+	"notification/internal/application/message/dtos"
+	"notification/internal/application/message/usecases"
+)
+
+// MessageExecutor runs the message operations behind the message handlers
+type MessageExecutor interface {
+	Send(ctx context.Context, request *dtos.SendMessageRequest) (*dtos.MessageResponse, error)
+	Get(ctx context.Context, messageID string) (*dtos.MessageResponse, error)
+	List(ctx context.Context, request *dtos.ListMessagesRequest) (*dtos.ListMessagesResponse, error)
+}
+
+// useCaseMessageExecutor runs the message operations directly on the use cases
+type useCaseMessageExecutor struct {
+	sendUseCase *usecases.SendMessageUseCase
+	getUseCase  *usecases.GetMessageUseCase
+	listUseCase *usecases.ListMessagesUseCase
+}
+
+// NewUseCaseMessageExecutor creates a message executor that calls the use cases directly
+func NewUseCaseMessageExecutor(
+	sendUseCase *usecases.SendMessageUseCase,
+	getUseCase *usecases.GetMessageUseCase,
+	listUseCase *usecases.ListMessagesUseCase,
+) MessageExecutor {
+	return &useCaseMessageExecutor{
+		sendUseCase: sendUseCase,
+		getUseCase:  getUseCase,
+		listUseCase: listUseCase,
+	}
+}
+
+func (e *useCaseMessageExecutor) Send(ctx context.Context, request *dtos.SendMessageRequest) (*dtos.MessageResponse, error) {
+	return e.sendUseCase.Execute(ctx, request)
+}
+
+func (e *useCaseMessageExecutor) Get(ctx context.Context, messageID string) (*dtos.MessageResponse, error) {
+	return e.getUseCase.Execute(ctx, messageID)
+}
+
+func (e *useCaseMessageExecutor) List(ctx context.Context, request *dtos.ListMessagesRequest) (*dtos.ListMessagesResponse, error) {
+	return e.listUseCase.Execute(ctx, request)
+}
+
+// cqrsMessageExecutor runs the message operations as CQRS commands and queries
+type cqrsMessageExecutor struct {
+	cqrsFacade *cqrs.CQRSFacade
+}
+
+// NewCQRSMessageExecutor creates a message executor that goes through the CQRS facade
+func NewCQRSMessageExecutor(cqrsFacade *cqrs.CQRSFacade) MessageExecutor {
+	return &cqrsMessageExecutor{cqrsFacade: cqrsFacade}
+}
+
+func (e *cqrsMessageExecutor) Send(ctx context.Context, request *dtos.SendMessageRequest) (*dtos.MessageResponse, error) {
+	command := messagecqrs.NewSendMessageCommand(request)
+	stampCommand(ctx, command.BaseCommand)
+	return send[*dtos.MessageResponse](ctx, e.cqrsFacade, command)
+}
+
+func (e *cqrsMessageExecutor) Get(ctx context.Context, messageID string) (*dtos.MessageResponse, error) {
+	query := messagecqrs.NewGetMessageQuery(messageID)
+	stampQuery(ctx, query.BaseQuery)
+	return ask[*dtos.MessageResponse](ctx, e.cqrsFacade, query)
+}
+
+func (e *cqrsMessageExecutor) List(ctx context.Context, request *dtos.ListMessagesRequest) (*dtos.ListMessagesResponse, error) {
+	query := messagecqrs.NewListMessagesQuery()
+	stampQuery(ctx, query.BaseQuery)
+	query.WithChannelID(request.ChannelID).
+		WithStatus(request.Status).
+		WithPagination(request.SkipCount, request.MaxResultCount)
+	return ask[*dtos.ListMessagesResponse](ctx, e.cqrsFacade, query)
+}
@@ -0,0 +1,120 @@
+package executor
+
+import (
+	"context"
+
+	"notification/internal/application/cqrs"
+	templatecqrs "notification/internal/application/cqrs/template"
+	"notification/internal/application/template/dtos"
+	"notification/internal/application/template/usecases"
+)
+
+// TemplateExecutor runs the template operations behind the template handlers
+type TemplateExecutor interface {
+	Create(ctx context.Context, request *dtos.CreateTemplateRequest) (*dtos.TemplateResponse, error)
+	Get(ctx context.Context, templateID string) (*dtos.TemplateResponse, error)
+	List(ctx context.Context, request *dtos.ListTemplatesRequest) (*dtos.ListTemplatesResponse, error)
+	Update(ctx context.Context, templateID string, request *dtos.UpdateTemplateRequest) (*dtos.TemplateResponse, error)
+	Delete(ctx context.Context, templateID string, request *dtos.DeleteTemplateRequest) error
+}
+
+// useCaseTemplateExecutor runs the template operations directly on the use cases
+type useCaseTemplateExecutor struct {
+	createUseCase *usecases.CreateTemplateUseCase
+	getUseCase    *usecases.GetTemplateUseCase
+	listUseCase   *usecases.ListTemplatesUseCase
+	updateUseCase *usecases.UpdateTemplateUseCase
+	deleteUseCase *usecases.DeleteTemplateUseCase
+}
+
+// NewUseCaseTemplateExecutor creates a template executor that calls the use cases directly
+func NewUseCaseTemplateExecutor(
+	createUseCase *usecases.CreateTemplateUseCase,
+	getUseCase *usecases.GetTemplateUseCase,
+	listUseCase *usecases.ListTemplatesUseCase,
+	updateUseCase *usecases.UpdateTemplateUseCase,
+	deleteUseCase *usecases.DeleteTemplateUseCase,
+) TemplateExecutor {
+	return &useCaseTemplateExecutor{
+		createUseCase: createUseCase,
+		getUseCase:    getUseCase,
+		listUseCase:   listUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+	}
+}
+
+func (e *useCaseTemplateExecutor) Create(ctx context.Context, request *dtos.CreateTemplateRequest) (*dtos.TemplateResponse, error) {
+	return e.createUseCase.Execute(ctx, request)
+}
+
+func (e *useCaseTemplateExecutor) Get(ctx context.Context, templateID string) (*dtos.TemplateResponse, error) {
+	return e.getUseCase.Execute(ctx, templateID)
+}
+
+func (e *useCaseTemplateExecutor) List(ctx context.Context, request *dtos.ListTemplatesRequest) (*dtos.ListTemplatesResponse, error) {
+	return e.listUseCase.Execute(ctx, request)
+}
+
+func (e *useCaseTemplateExecutor) Update(ctx context.Context, templateID string, request *dtos.UpdateTemplateRequest) (*dtos.TemplateResponse, error) {
+	return e.updateUseCase.Execute(ctx, templateID, request)
+}
+
+func (e *useCaseTemplateExecutor) Delete(ctx context.Context, templateID string, request *dtos.DeleteTemplateRequest) error {
+	return e.deleteUseCase.Execute(ctx, templateID, request)
+}
+
+// cqrsTemplateExecutor runs the template operations as CQRS commands and queries
+type cqrsTemplateExecutor struct {
+	cqrsFacade *cqrs.CQRSFacade
+}
+
+// NewCQRSTemplateExecutor creates a template executor that goes through the CQRS facade
+func NewCQRSTemplateExecutor(cqrsFacade *cqrs.CQRSFacade) TemplateExecutor {
+	return &cqrsTemplateExecutor{cqrsFacade: cqrsFacade}
+}
+
+func (e *cqrsTemplateExecutor) Create(ctx context.Context, request *dtos.CreateTemplateRequest) (*dtos.TemplateResponse, error) {
+	command := templatecqrs.NewCreateTemplateCommand(request)
+	stampCommand(ctx, command.BaseCommand)
+	return send[*dtos.TemplateResponse](ctx, e.cqrsFacade, command)
+}
+
+func (e *cqrsTemplateExecutor) Get(ctx context.Context, templateID string) (*dtos.TemplateResponse, error) {
+	query := templatecqrs.NewGetTemplateQuery(templateID)
+	stampQuery(ctx, query.BaseQuery)
+	return ask[*dtos.TemplateResponse](ctx, e.cqrsFacade, query)
+}
+
+func (e *cqrsTemplateExecutor) List(ctx context.Context, request *dtos.ListTemplatesRequest) (*dtos.ListTemplatesResponse, error) {
+	query := templatecqrs.NewListTemplatesQuery()
+	stampQuery(ctx, query.BaseQuery)
+	if request.ChannelType != nil {
+		query.WithChannelType(request.ChannelType.String())
+	}
+	query.WithTags(request.Tags).
+		WithOwnership(request.Owner, request.Team, request.Environment).
+		WithPagination(request.SkipCount, request.MaxResultCount)
+	return ask[*dtos.ListTemplatesResponse](ctx, e.cqrsFacade, query)
+}
+
+func (e *cqrsTemplateExecutor) Update(ctx context.Context, templateID string, request *dtos.UpdateTemplateRequest) (*dtos.TemplateResponse, error) {
+	command := templatecqrs.NewUpdateTemplateCommand(templateID, request)
+	stampCommand(ctx, command.BaseCommand)
+	return send[*dtos.TemplateResponse](ctx, e.cqrsFacade, command)
+}
+
+func (e *cqrsTemplateExecutor) Delete(ctx context.Context, templateID string, request *dtos.DeleteTemplateRequest) error {
+	command := templatecqrs.NewDeleteTemplateCommand(templateID).
+		WithForce(request.Force).
+		WithReassignTo(request.ReassignTo)
+	stampCommand(ctx, command.BaseCommand)
+	result, err := e.cqrsFacade.Send(ctx, command)
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// AcknowledgmentHandler handles the HTTP requests for the acknowledgments of
+// the messages by their recipients
+type AcknowledgmentHandler struct {
+	acknowledgeUC      *usecases.AcknowledgeMessageUseCase
+	getAcknowledgments *usecases.GetAcknowledgmentsUseCase
+}
+
+// NewAcknowledgmentHandler creates a new acknowledgment handler
+func NewAcknowledgmentHandler(acknowledgeUC *usecases.AcknowledgeMessageUseCase, getAcknowledgments *usecases.GetAcknowledgmentsUseCase) *AcknowledgmentHandler {
+	return &AcknowledgmentHandler{
+		acknowledgeUC:      acknowledgeUC,
+		getAcknowledgments: getAcknowledgments,
+	}
+}
+
+// Acknowledge handles GET /ack/{token}
+// @Summary Acknowledge a message
+// @Description Record the acknowledgment of a message by the recipient its link was sent to, or by someone else when by is set; a message already acknowledged keeps its first acknowledgment
+// @Tags messages
+// @Produce json
+// @Param token path string true "Acknowledgment token"
+// @Param by query string false "Who acknowledges the message, the recipient by default"
+// @Success 200 {object} map[string]interface{} "Success response with the acknowledgment"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Acknowledgment not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Router /ack/{token} [get]
+func (h *AcknowledgmentHandler) Acknowledge(c *gin.Context) {
+	response, err := h.acknowledgeUC.Execute(c.Request.Context(), c.Param("token"), c.Query("by"))
+	if err != nil {
+		httputil.RespondError(c, err, "ACKNOWLEDGE_MESSAGE_FAILED", "Failed to acknowledge message")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetMessageAcknowledgments handles GET /api/v1/messages/{id}/acknowledgments
+// @Summary List the acknowledgments of a message
+// @Description List the acknowledgments of a message by its recipients, who acknowledged it and when
+// @Tags messages
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} map[string]interface{} "Success response with the acknowledgments of the message"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Message not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/messages/{id}/acknowledgments [get]
+func (h *AcknowledgmentHandler) GetMessageAcknowledgments(c *gin.Context) {
+	response, err := h.getAcknowledgments.ForMessage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_MESSAGE_ACKNOWLEDGMENTS_FAILED", "Failed to get message acknowledgments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ListUnacknowledged handles GET /api/v1/acknowledgments/pending
+// @Summary List the unacknowledged messages
+// @Description List the acknowledgments still pending for longer than a duration, oldest first, e.g. to escalate the messages unacknowledged after 15 minutes
+// @Tags messages
+// @Produce json
+// @Param olderThan query string false "Pending for at least this duration, such as 15m"
+// @Param limit query int false "Number of acknowledgments listed, 100 by default" maximum(1000)
+// @Success 200 {object} map[string]interface{} "Success response with the pending acknowledgments"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/acknowledgments/pending [get]
+func (h *AcknowledgmentHandler) ListUnacknowledged(c *gin.Context) {
+	var req dtos.ListUnacknowledgedRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.getAcknowledgments.Unacknowledged(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_UNACKNOWLEDGED_FAILED", "Failed to list unacknowledged messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/analytics/dtos"
+	"notification/internal/application/analytics/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// AnalyticsHandler handles HTTP requests for delivery analytics.
+type AnalyticsHandler struct {
+	getCostReportUC *usecases.GetCostReportUseCase
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler.
+func NewAnalyticsHandler(getCostReportUC *usecases.GetCostReportUseCase) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		getCostReportUC: getCostReportUC,
+	}
+}
+
+// GetCostReport handles GET /api/v1/analytics/costs
+// @Summary Get estimated send costs
+// @Description Sum the estimated cost of the sends per channel, tag, tenant or message class, from the channel prices configured when they were sent
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param groupBy query string false "Group by channel, tag, tenant or class" default(channel)
+// @Param from query int false "Messages created at or after, Unix milliseconds"
+// @Param to query int false "Messages created before, Unix milliseconds"
+// @Success 200 {object} map[string]interface{} "Success response with the cost report"
+// @Failure 422 {object} httputil.Problem "Invalid grouping or time range"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/analytics/costs [get]
+func (h *AnalyticsHandler) GetCostReport(c *gin.Context) {
+	var req dtos.GetCostReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.getCostReportUC.Execute(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "GET_COST_REPORT_FAILED", "Failed to get cost report")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
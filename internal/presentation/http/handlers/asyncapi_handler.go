@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/nats/asyncapi"
+	natshandlers "notification/internal/presentation/nats/handlers"
+)
+
+// AsyncAPIHandler serves the AsyncAPI document of the NATS API
+type AsyncAPIHandler struct {
+	doc *asyncapi.Document
+}
+
+// NewAsyncAPIHandler creates a new AsyncAPI handler. The document is generated
+// from the subject registry of the NATS handlers and the events published
+// under eventPrefix.
+func NewAsyncAPIHandler(eventPrefix string, events []asyncapi.Event) *AsyncAPIHandler {
+	return &AsyncAPIHandler{
+		doc: asyncapi.Build(natshandlers.Subjects, eventPrefix, events),
+	}
+}
+
+// GetAsyncAPISpec handles GET /api/asyncapi.json
+func (h *AsyncAPIHandler) GetAsyncAPISpec(c *gin.Context) {
+	c.JSON(http.StatusOK, h.doc)
+}
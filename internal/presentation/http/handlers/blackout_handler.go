@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/blackout/dtos"
+	"notification/internal/application/blackout/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// BlackoutHandler handles the HTTP requests of the blackout calendar, the
+// periods during which the non-critical scheduled messages are deferred.
+type BlackoutHandler struct {
+	getUseCase    *usecases.GetBlackoutUseCase
+	updateUseCase *usecases.UpdateBlackoutUseCase
+}
+
+// NewBlackoutHandler creates a new BlackoutHandler.
+func NewBlackoutHandler(getUseCase *usecases.GetBlackoutUseCase, updateUseCase *usecases.UpdateBlackoutUseCase) *BlackoutHandler {
+	return &BlackoutHandler{
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+	}
+}
+
+// CreateBlackout handles POST /api/v1/blackouts
+// @Summary Create a blackout
+// @Description Add a period such as a change freeze or a holiday to the organization calendar; the non-critical scheduled messages falling within it are deferred to its end
+// @Tags blackouts
+// @Accept json
+// @Produce json
+// @Param request body dtos.BlackoutRequest true "Blackout request"
+// @Success 201 {object} map[string]interface{} "Success response with the blackout"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/blackouts [post]
+func (h *BlackoutHandler) CreateBlackout(c *gin.Context) {
+	var req dtos.BlackoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.Create(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "CREATE_BLACKOUT_FAILED", "Failed to create blackout")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ListBlackouts handles GET /api/v1/blackouts
+// @Summary List the blackouts
+// @Description List the blackouts of the organization calendar by start, only those not over yet with upcoming=true
+// @Tags blackouts
+// @Produce json
+// @Param upcoming query bool false "Only the blackouts not over yet"
+// @Success 200 {object} map[string]interface{} "Success response with the blackouts"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/blackouts [get]
+func (h *BlackoutHandler) ListBlackouts(c *gin.Context) {
+	response, err := h.getUseCase.List(c.Request.Context(), c.Query("upcoming") == "true")
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_BLACKOUTS_FAILED", "Failed to list blackouts")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetBlackout handles GET /api/v1/blackouts/{id}
+// @Summary Get a blackout
+// @Description Get a blackout of the organization calendar by ID
+// @Tags blackouts
+// @Produce json
+// @Param id path string true "Blackout ID"
+// @Success 200 {object} map[string]interface{} "Success response with the blackout"
+// @Failure 404 {object} httputil.Problem "Blackout not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/blackouts/{id} [get]
+func (h *BlackoutHandler) GetBlackout(c *gin.Context) {
+	response, err := h.getUseCase.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_BLACKOUT_FAILED", "Failed to get blackout")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// UpdateBlackout handles PUT /api/v1/blackouts/{id}
+// @Summary Replace a blackout
+// @Description Replace the name, description and period of a blackout; the scheduled messages are deferred by the new period when they come due
+// @Tags blackouts
+// @Accept json
+// @Produce json
+// @Param id path string true "Blackout ID"
+// @Param request body dtos.BlackoutRequest true "Blackout request"
+// @Success 200 {object} map[string]interface{} "Success response with the blackout"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Blackout not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/blackouts/{id} [put]
+func (h *BlackoutHandler) UpdateBlackout(c *gin.Context) {
+	var req dtos.BlackoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.Update(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "UPDATE_BLACKOUT_FAILED", "Failed to update blackout")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// DeleteBlackout handles DELETE /api/v1/blackouts/{id}
+// @Summary Delete a blackout
+// @Description Remove a blackout from the organization calendar; the messages it deferred go out at the time they were deferred to
+// @Tags blackouts
+// @Produce json
+// @Param id path string true "Blackout ID"
+// @Success 200 {object} map[string]interface{} "Success response"
+// @Failure 404 {object} httputil.Problem "Blackout not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/blackouts/{id} [delete]
+func (h *BlackoutHandler) DeleteBlackout(c *gin.Context) {
+	if err := h.updateUseCase.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		httputil.RespondError(c, err, "DELETE_BLACKOUT_FAILED", "Failed to delete blackout")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  map[string]interface{}{"deleted": true},
+		"error": nil,
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/category/dtos"
+	"notification/internal/application/category/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// CategoryHandler handles the HTTP requests of the message categories and the
+// subscriptions of the users to them.
+type CategoryHandler struct {
+	getUseCase                *usecases.GetCategoryUseCase
+	updateUseCase             *usecases.UpdateCategoryUseCase
+	getSubscriptionUseCase    *usecases.GetSubscriptionUseCase
+	updateSubscriptionUseCase *usecases.UpdateSubscriptionUseCase
+}
+
+// NewCategoryHandler creates a new CategoryHandler.
+func NewCategoryHandler(
+	getUseCase *usecases.GetCategoryUseCase,
+	updateUseCase *usecases.UpdateCategoryUseCase,
+	getSubscriptionUseCase *usecases.GetSubscriptionUseCase,
+	updateSubscriptionUseCase *usecases.UpdateSubscriptionUseCase,
+) *CategoryHandler {
+	return &CategoryHandler{
+		getUseCase:                getUseCase,
+		updateUseCase:             updateUseCase,
+		getSubscriptionUseCase:    getSubscriptionUseCase,
+		updateSubscriptionUseCase: updateSubscriptionUseCase,
+	}
+}
+
+// CreateCategory handles POST /api/v1/categories
+// @Summary Create a category
+// @Description Create a category messages can be sent in, received by its subscribers or, when subscribed by default, by every recipient not unsubscribing
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateCategoryRequest true "Create category request"
+// @Success 201 {object} map[string]interface{} "Success response with the category"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 409 {object} httputil.Problem "A category with the same name already exists"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req dtos.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.Create(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "CREATE_CATEGORY_FAILED", "Failed to create category")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ListCategories handles GET /api/v1/categories
+// @Summary List the categories
+// @Description List every category by name
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Success response with the categories"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories [get]
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	response, err := h.getUseCase.List(c.Request.Context())
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_CATEGORIES_FAILED", "Failed to list categories")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetCategory handles GET /api/v1/categories/:name
+// @Summary Get a category
+// @Description Get a category by name
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param name path string true "Category name"
+// @Success 200 {object} map[string]interface{} "Success response with the category"
+// @Failure 404 {object} httputil.Problem "Category not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/{name} [get]
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	response, err := h.getUseCase.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_CATEGORY_FAILED", "Failed to get category")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// UpdateCategory handles PUT /api/v1/categories/:name
+// @Summary Update a category
+// @Description Update the description of a category and whether recipients are subscribed to it by default; the choices of the recipients are kept
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param name path string true "Category name"
+// @Param request body dtos.UpdateCategoryRequest true "Update category request"
+// @Success 200 {object} map[string]interface{} "Success response with the category"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Category not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/{name} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	var req dtos.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.Update(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "UPDATE_CATEGORY_FAILED", "Failed to update category")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// DeleteCategory handles DELETE /api/v1/categories/:name
+// @Summary Delete a category
+// @Description Delete a category and the subscriptions to it
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param name path string true "Category name"
+// @Success 200 {object} map[string]interface{} "Success response"
+// @Failure 404 {object} httputil.Problem "Category not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/{name} [delete]
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	if err := h.updateUseCase.Delete(c.Request.Context(), c.Param("name")); err != nil {
+		httputil.RespondError(c, err, "DELETE_CATEGORY_FAILED", "Failed to delete category")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  map[string]interface{}{"deleted": true},
+		"error": nil,
+	})
+}
+
+// ListSubscriptions handles GET /api/v1/users/:userId/subscriptions
+// @Summary List the subscriptions of a user
+// @Description List whether a user, identified by a user ID or the target address the channels send to, receives the messages of every category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID or target address"
+// @Success 200 {object} map[string]interface{} "Success response with the subscriptions"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/subscriptions [get]
+func (h *CategoryHandler) ListSubscriptions(c *gin.Context) {
+	response, err := h.getSubscriptionUseCase.List(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_SUBSCRIPTIONS_FAILED", "Failed to list subscriptions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// Subscribe handles PUT /api/v1/users/:userId/subscriptions/:category
+// @Summary Subscribe a user to a category
+// @Description Make a user receive the messages of a category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID or target address"
+// @Param category path string true "Category name"
+// @Success 200 {object} map[string]interface{} "Success response with the subscription"
+// @Failure 404 {object} httputil.Problem "Category not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/subscriptions/{category} [put]
+func (h *CategoryHandler) Subscribe(c *gin.Context) {
+	response, err := h.updateSubscriptionUseCase.Subscribe(c.Request.Context(), c.Param("userId"), c.Param("category"))
+	if err != nil {
+		httputil.RespondError(c, err, "SUBSCRIBE_FAILED", "Failed to subscribe")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// Unsubscribe handles DELETE /api/v1/users/:userId/subscriptions/:category
+// @Summary Unsubscribe a user from a category
+// @Description Stop a user receiving the messages of a category, including a category subscribed by default
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID or target address"
+// @Param category path string true "Category name"
+// @Success 200 {object} map[string]interface{} "Success response with the subscription"
+// @Failure 404 {object} httputil.Problem "Category not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/subscriptions/{category} [delete]
+func (h *CategoryHandler) Unsubscribe(c *gin.Context) {
+	response, err := h.updateSubscriptionUseCase.Unsubscribe(c.Request.Context(), c.Param("userId"), c.Param("category"))
+	if err != nil {
+		httputil.RespondError(c, err, "UNSUBSCRIBE_FAILED", "Failed to unsubscribe")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/channelgroup/dtos"
+	"notification/internal/application/channelgroup/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// ChannelGroupHandler handles HTTP requests for channel groups.
+type ChannelGroupHandler struct {
+	createUseCase *usecases.CreateChannelGroupUseCase
+	getUseCase    *usecases.GetChannelGroupUseCase
+	updateUseCase *usecases.UpdateChannelGroupUseCase
+	deleteUseCase *usecases.DeleteChannelGroupUseCase
+}
+
+// NewChannelGroupHandler creates a new ChannelGroupHandler.
+func NewChannelGroupHandler(
+	createUseCase *usecases.CreateChannelGroupUseCase,
+	getUseCase *usecases.GetChannelGroupUseCase,
+	updateUseCase *usecases.UpdateChannelGroupUseCase,
+	deleteUseCase *usecases.DeleteChannelGroupUseCase,
+) *ChannelGroupHandler {
+	return &ChannelGroupHandler{
+		createUseCase: createUseCase,
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+	}
+}
+
+// CreateChannelGroup handles POST /api/v1/channel-groups
+// @Summary Create a channel group
+// @Description Create a named group of channels that send requests can target with one ID
+// @Tags channel-groups
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateChannelGroupRequest true "Create channel group request"
+// @Success 201 {object} map[string]interface{} "Success response with the channel group"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 409 {object} httputil.Problem "A channel group with the same name already exists"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/channel-groups [post]
+func (h *ChannelGroupHandler) CreateChannelGroup(c *gin.Context) {
+	var req dtos.CreateChannelGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.createUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "CREATE_CHANNEL_GROUP_FAILED", "Failed to create channel group")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetChannelGroup handles GET /api/v1/channel-groups/:id
+// @Summary Get a channel group
+// @Description Get a channel group and its member channel IDs
+// @Tags channel-groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel group ID"
+// @Success 200 {object} map[string]interface{} "Success response with the channel group"
+// @Failure 404 {object} httputil.Problem "Channel group not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/channel-groups/{id} [get]
+func (h *ChannelGroupHandler) GetChannelGroup(c *gin.Context) {
+	response, err := h.getUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "CHANNEL_GROUP_NOT_FOUND", "Channel group not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ListChannelGroups handles GET /api/v1/channel-groups
+// @Summary List channel groups
+// @Description List channel groups ordered by name
+// @Tags channel-groups
+// @Accept json
+// @Produce json
+// @Param skipCount query int false "Number of groups to skip"
+// @Param maxResultCount query int false "Maximum number of groups to return"
+// @Success 200 {object} map[string]interface{} "Success response with the channel groups"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/channel-groups [get]
+func (h *ChannelGroupHandler) ListChannelGroups(c *gin.Context) {
+	var req dtos.ListChannelGroupsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.getUseCase.List(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_CHANNEL_GROUPS_FAILED", "Failed to list channel groups")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// UpdateChannelGroup handles PUT /api/v1/channel-groups/:id
+// @Summary Update a channel group
+// @Description Update the name and description of a channel group
+// @Tags channel-groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel group ID"
+// @Param request body dtos.UpdateChannelGroupRequest true "Update channel group request"
+// @Success 200 {object} map[string]interface{} "Success response with the channel group"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Channel group not found"
+// @Failure 409 {object} httputil.Problem "A channel group with the same name already exists"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/channel-groups/{id} [put]
+func (h *ChannelGroupHandler) UpdateChannelGroup(c *gin.Context) {
+	var req dtos.UpdateChannelGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.Execute(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "UPDATE_CHANNEL_GROUP_FAILED", "Failed to update channel group")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// DeleteChannelGroup handles DELETE /api/v1/channel-groups/:id
+// @Summary Delete a channel group
+// @Description Delete a channel group; its member channels are left untouched
+// @Tags channel-groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel group ID"
+// @Success 200 {object} map[string]interface{} "Success response"
+// @Failure 404 {object} httputil.Problem "Channel group not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/channel-groups/{id} [delete]
+func (h *ChannelGroupHandler) DeleteChannelGroup(c *gin.Context) {
+	response, err := h.deleteUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "DELETE_CHANNEL_GROUP_FAILED", "Failed to delete channel group")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// AddChannelGroupMembers handles POST /api/v1/channel-groups/:id/members
+// @Summary Add channels to a channel group
+// @Description Add channels to a channel group; channels already in the group are ignored
+// @Tags channel-groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel group ID"
+// @Param request body dtos.ChannelGroupMembersRequest true "Channel IDs to add"
+// @Success 200 {object} map[string]interface{} "Success response with the channel group"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Channel group not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/channel-groups/{id}/members [post]
+func (h *ChannelGroupHandler) AddChannelGroupMembers(c *gin.Context) {
+	var req dtos.ChannelGroupMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.AddMembers(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "ADD_CHANNEL_GROUP_MEMBERS_FAILED", "Failed to add channels to channel group")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// RemoveChannelGroupMembers handles POST /api/v1/channel-groups/:id/members/remove
+// @Summary Remove channels from a channel group
+// @Description Remove channels from a channel group; channels not in the group are ignored
+// @Tags channel-groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel group ID"
+// @Param request body dtos.ChannelGroupMembersRequest true "Channel IDs to remove"
+// @Success 200 {object} map[string]interface{} "Success response with the channel group"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Channel group not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/channel-groups/{id}/members/remove [post]
+func (h *ChannelGroupHandler) RemoveChannelGroupMembers(c *gin.Context) {
+	var req dtos.ChannelGroupMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.RemoveMembers(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "REMOVE_CHANNEL_GROUP_MEMBERS_FAILED", "Failed to remove channels from channel group")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
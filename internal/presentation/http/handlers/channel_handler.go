@@ -3,20 +3,27 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"notification/internal/application/channel/dtos"
 	"notification/internal/application/channel/usecases"
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/executor"
+	"notification/internal/presentation/http/httputil"
 )
 
-// ChannelHandler handles HTTP requests for channel operations
+// ChannelHandler handles HTTP requests for channel operations. The channel
+// CRUD runs on its executor, the other operations on the use cases.
 type ChannelHandler struct {
-	createUseCase *usecases.CreateChannelUseCase
+	executor      executor.ChannelExecutor
 	getUseCase    *usecases.GetChannelUseCase
-	listUseCase   *usecases.ListChannelsUseCase
 	updateUseCase *usecases.UpdateChannelUseCase
-	deleteUseCase *usecases.DeleteChannelUseCase
+	bulkUseCase   *usecases.BulkChannelUseCase
+
+	maintenanceUseCase    *usecases.ChannelMaintenanceUseCase
+	deliverabilityUseCase *usecases.CheckDeliverabilityUseCase
 }
 
 // NewChannelHandler creates a new channel handler
@@ -26,16 +33,32 @@ func NewChannelHandler(
 	listUseCase *usecases.ListChannelsUseCase,
 	updateUseCase *usecases.UpdateChannelUseCase,
 	deleteUseCase *usecases.DeleteChannelUseCase,
+	enableUseCase *usecases.SetChannelEnabledUseCase,
+	bulkUseCase *usecases.BulkChannelUseCase,
+	maintenanceUseCase *usecases.ChannelMaintenanceUseCase,
+	deliverabilityUseCase *usecases.CheckDeliverabilityUseCase,
 ) *ChannelHandler {
 	return &ChannelHandler{
-		createUseCase: createUseCase,
+		executor: executor.NewUseCaseChannelExecutor(
+			createUseCase, getUseCase, listUseCase, updateUseCase, deleteUseCase, enableUseCase,
+		),
 		getUseCase:    getUseCase,
-		listUseCase:   listUseCase,
 		updateUseCase: updateUseCase,
-		deleteUseCase: deleteUseCase,
+		bulkUseCase:   bulkUseCase,
+
+		maintenanceUseCase:    maintenanceUseCase,
+		deliverabilityUseCase: deliverabilityUseCase,
 	}
 }
 
+// WithExecutor returns a copy of the handler running the channel CRUD on the
+// given executor, e.g. through CQRS for the v2 routes
+func (h *ChannelHandler) WithExecutor(channelExecutor executor.ChannelExecutor) *ChannelHandler {
+	handler := *h
+	handler.executor = channelExecutor
+	return &handler
+}
+
 // CreateChannel handles the creation of a new channel.
 // @Summary      Create a new channel
 // @Description  Creates a new channel with the provided details.
@@ -44,36 +67,31 @@ func NewChannelHandler(
 // @Produce      json
 // @Param        request body dtos.CreateChannelRequest true "Create Channel Request"
 // @Success      201  {object}  map[string]interface{} "Success response with channel data"
-// @Failure      400  {object}  map[string]interface{} "Bad Request - Invalid input or validation error"
-// @Failure      409  {object}  map[string]interface{} "Conflict - Channel with the same name already exists"
-// @Failure      500  {object}  map[string]interface{} "Internal Server Error"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input or validation error"
+// @Failure      409  {object}  httputil.Problem "Conflict - Channel with the same name already exists"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Validation failed"
+// @Failure      503  {object}  httputil.Problem "Service Unavailable - Legacy system unavailable"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /api/v1/channels [post]
+// @Router       /api/v2/channels [post]
 func (h *ChannelHandler) CreateChannel(c *gin.Context) {
 	var request dtos.CreateChannelRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid request format: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid request format")
 		return
 	}
+	if request.Owner == "" {
+		request.Owner = authenticatedUser(c)
+	}
 
-	response, err := h.createUseCase.Execute(c.Request.Context(), &request)
+	response, err := h.executor.Create(c.Request.Context(), &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "CREATE_CHANNEL_FAILED",
-				"message": "Failed to create channel: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "CREATE_CHANNEL_FAILED", "Failed to create channel")
 		return
 	}
 
+	httputil.SetETag(c, channelETag(response, nil))
 	c.JSON(http.StatusCreated, gin.H{
 		"data":  response,
 		"error": nil,
@@ -87,38 +105,85 @@ func (h *ChannelHandler) CreateChannel(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Channel ID"
+// @Param        fields query     string  false  "Comma-separated fields to return, e.g. channelId,channelName,enabled"
+// @Param        If-None-Match header  string  false  "Entity tag of the channel as last seen by the client"
 // @Success      200  {object}  map[string]interface{} "Success response with channel data"
-// @Failure      400  {object}  map[string]interface{} "Bad Request - Invalid channel ID format"
-// @Failure      404  {object}  map[string]interface{} "Not Found - Channel with specified ID does not exist"
-// @Failure      500  {object}  map[string]interface{} "Internal Server Error"
+// @Success      304  "Not Modified - Channel unchanged since the given entity tag"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID format"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Validation failed"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
 // @Router       /api/v1/channels/{id} [get]
+// @Router       /api/v2/channels/{id} [get]
 func (h *ChannelHandler) GetChannel(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.ChannelResponse{})
+	if !ok {
+		return
+	}
+
 	channelID := c.Param("id")
 	if channelID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Channel ID is required",
-			},
-		})
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Channel ID is required")
 		return
 	}
 
-	response, err := h.getUseCase.Execute(c.Request.Context(), channelID)
+	response, err := h.executor.Get(c.Request.Context(), channelID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "CHANNEL_NOT_FOUND",
-				"message": "Channel not found: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "CHANNEL_NOT_FOUND", "Channel not found")
+		return
+	}
+
+	if httputil.NotModified(c, channelETag(response, fields)) {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":  response,
+		"data":  fields.Select(response),
+		"error": nil,
+	})
+}
+
+// GetChannelByName handles GET /api/v1/channels/by-name/:name
+// @Summary      Get a channel by name
+// @Description  Retrieves a single channel's details using its unique name, e.g. to import existing resources.
+// @Tags         channels
+// @Accept       json
+// @Produce      json
+// @Param        name   path      string  true  "Channel name"
+// @Param        fields query     string  false  "Comma-separated fields to return, e.g. channelId,channelName,enabled"
+// @Param        If-None-Match header  string  false  "Entity tag of the channel as last seen by the client"
+// @Success      200  {object}  map[string]interface{} "Success response with channel data"
+// @Success      304  "Not Modified - Channel unchanged since the given entity tag"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel name"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified name does not exist"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/by-name/{name} [get]
+func (h *ChannelHandler) GetChannelByName(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.ChannelResponse{})
+	if !ok {
+		return
+	}
+
+	channelName := c.Param("name")
+	if channelName == "" {
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Channel name is required")
+		return
+	}
+
+	response, err := h.getUseCase.ExecuteByName(c.Request.Context(), channelName)
+	if err != nil {
+		httputil.RespondError(c, err, "CHANNEL_NOT_FOUND", "Channel not found")
+		return
+	}
+
+	if httputil.NotModified(c, channelETag(response, fields)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  fields.Select(response),
 		"error": nil,
 	})
 }
@@ -133,11 +198,29 @@ func (h *ChannelHandler) GetChannel(c *gin.Context) {
 // @Param        tags          query      []string  false  "Filter by tags (comma-separated)"  collectionFormat(csv)
 // @Param        skipCount     query      int     false  "Number of records to skip for pagination"  default(0)
 // @Param        maxResultCount query      int     false  "Maximum number of records to return per page (1-100)"  default(10)
+// @Param        templateId    query      string  false  "Filter by referenced template ID"
+// @Param        owner         query      string  false  "Filter by owner"
+// @Param        team          query      string  false  "Filter by team"
+// @Param        environment   query      string  false  "Filter by environment (dev, staging or prod), including unscoped channels; all lists every environment. Defaults to the server environment"
+// @Param        lastUsedBefore query     int     false  "Only channels last used before this Unix millisecond timestamp, including never-used channels"
+// @Param        lastUsedAfter query      int     false  "Only channels last used at or after this Unix millisecond timestamp"
+// @Param        unusedForDays query      int     false  "Only channels not used within this many days"
+// @Param        sortField     query      string  false  "Field to sort by"  Enums(channelName, channelType, enabled, createdAt, updatedAt, lastUsed)
+// @Param        sortOrder     query      string  false  "Sort order (asc or desc)"  default(asc)
+// @Param        fields        query      string  false  "Comma-separated fields to return, e.g. channelId,channelName,enabled"
 // @Success      200  {object}  map[string]interface{} "Success response with channels list"
-// @Failure      400  {object}  map[string]interface{} "Bad Request - Invalid query parameters"
-// @Failure      500  {object}  map[string]interface{} "Internal Server Error"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid query parameters"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Unsupported sort field or order, or invalid filter"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
 // @Router       /api/v1/channels [get]
+// @Router       /api/v2/channels [get]
 func (h *ChannelHandler) ListChannels(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.ChannelSummaryResponse{})
+	if !ok {
+		return
+	}
+
 	var request dtos.ListChannelsRequest
 
 	// Parse query parameters
@@ -161,25 +244,42 @@ func (h *ChannelHandler) ListChannels(c *gin.Context) {
 		}
 	}
 
+	request.TemplateID = c.Query("templateId")
+	request.Owner = c.Query("owner")
+	request.Team = c.Query("team")
+	request.Environment = c.Query("environment")
+
+	if request.LastUsedBefore, ok = queryInt64(c, "lastUsedBefore"); !ok {
+		return
+	}
+	if request.LastUsedAfter, ok = queryInt64(c, "lastUsedAfter"); !ok {
+		return
+	}
+
+	unusedForDays, ok := queryInt64(c, "unusedForDays")
+	if !ok {
+		return
+	}
+	if unusedForDays != nil {
+		request.UnusedForDays = int(*unusedForDays)
+	}
+
+	request.SortField = c.Query("sortField")
+	request.SortOrder = c.Query("sortOrder")
+
 	// Set default values
 	if request.MaxResultCount <= 0 {
 		request.MaxResultCount = 20
 	}
 
-	response, err := h.listUseCase.Execute(c.Request.Context(), &request)
+	response, err := h.executor.List(c.Request.Context(), &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "LIST_CHANNELS_FAILED",
-				"message": "Failed to list channels: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "LIST_CHANNELS_FAILED", "Failed to list channels")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":  response,
+		"data":  fields.SelectItems(response),
 		"error": nil,
 	})
 }
@@ -191,52 +291,95 @@ func (h *ChannelHandler) ListChannels(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Channel ID"
+// @Param        If-Match header  string  false  "Entity tag of the channel as last seen by the client"
 // @Param        request body dtos.UpdateChannelRequest true "Update Channel Request"
 // @Success      200  {object}  map[string]interface{}
-// @Failure      400  {object}  map[string]interface{} "Bad Request - Invalid input or validation error"
-// @Failure      404  {object}  map[string]interface{} "Not Found - Channel with specified ID does not exist"
-// @Failure      500  {object}  map[string]interface{} "Internal Server Error"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input or validation error"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      412  {object}  httputil.Problem "Precondition Failed - Channel was modified"
+// @Failure      409  {object}  httputil.Problem "Conflict - Channel with the same name already exists"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Validation failed"
+// @Failure      503  {object}  httputil.Problem "Service Unavailable - Legacy system unavailable"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
 // @Router       /api/v1/channels/{id} [put]
+// @Router       /api/v2/channels/{id} [put]
 func (h *ChannelHandler) UpdateChannel(c *gin.Context) {
 	channelID := c.Param("id")
 	if channelID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Channel ID is required",
-			},
-		})
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Channel ID is required")
 		return
 	}
 
 	var request dtos.UpdateChannelRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid request format: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid request format")
 		return
 	}
 
 	// Set the channel ID from URL parameter
 	request.ChannelID = channelID
 
-	response, err := h.updateUseCase.Execute(c.Request.Context(), channelID, &request)
+	if !h.checkIfMatch(c, channelID) {
+		return
+	}
+
+	response, err := h.executor.Update(c.Request.Context(), channelID, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "UPDATE_CHANNEL_FAILED",
-				"message": "Failed to update channel: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "UPDATE_CHANNEL_FAILED", "Failed to update channel")
 		return
 	}
 
+	httputil.SetETag(c, channelETag(response, nil))
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// PatchChannel handles PATCH /api/v1/channels/:id
+// @Summary      Partially update a channel
+// @Description  Updates only the provided fields of a channel. Config keys are merged into the existing configuration; a key set to null removes it.
+// @Tags         channels
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Channel ID"
+// @Param        If-Match header  string  false  "Entity tag of the channel as last seen by the client"
+// @Param        request body dtos.PatchChannelRequest true "Patch Channel Request"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      409  {object}  httputil.Problem "Conflict - Channel with the same name already exists"
+// @Failure      412  {object}  httputil.Problem "Precondition Failed - Channel was modified"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Validation failed"
+// @Failure      503  {object}  httputil.Problem "Service Unavailable - Legacy system unavailable"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/{id} [patch]
+func (h *ChannelHandler) PatchChannel(c *gin.Context) {
+	channelID := c.Param("id")
+	if channelID == "" {
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Channel ID is required")
+		return
+	}
+
+	var request dtos.PatchChannelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request format")
+		return
+	}
+
+	if !h.checkIfMatch(c, channelID) {
+		return
+	}
+
+	response, err := h.updateUseCase.Patch(c.Request.Context(), channelID, &request)
+	if err != nil {
+		httputil.RespondError(c, err, "UPDATE_CHANNEL_FAILED", "Failed to update channel")
+		return
+	}
+
+	httputil.SetETag(c, channelETag(response, nil))
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
@@ -250,33 +393,170 @@ func (h *ChannelHandler) UpdateChannel(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Channel ID"
+// @Param        If-Match header  string  false  "Entity tag of the channel as last seen by the client"
 // @Success      200  {object}  map[string]interface{}
-// @Failure      400  {object}  map[string]interface{} "Bad Request - Invalid channel ID format"
-// @Failure      404  {object}  map[string]interface{} "Not Found - Channel with specified ID does not exist"
-// @Failure      500  {object}  map[string]interface{} "Internal Server Error"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID format"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      412  {object}  httputil.Problem "Precondition Failed - Channel was modified"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Validation failed"
+// @Failure      503  {object}  httputil.Problem "Service Unavailable - Legacy system unavailable"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
 // @Router       /api/v1/channels/{id} [delete]
+// @Router       /api/v2/channels/{id} [delete]
 func (h *ChannelHandler) DeleteChannel(c *gin.Context) {
 	channelID := c.Param("id")
 	if channelID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Channel ID is required",
-			},
-		})
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Channel ID is required")
+		return
+	}
+
+	if !h.checkIfMatch(c, channelID) {
+		return
+	}
+
+	response, err := h.executor.Delete(c.Request.Context(), channelID)
+	if err != nil {
+		httputil.RespondError(c, err, "DELETE_CHANNEL_FAILED", "Failed to delete channel")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// EnableChannel handles POST /api/v1/channels/:id/enable
+// @Summary      Enable a channel
+// @Description  Enables a channel without resubmitting its configuration.
+// @Tags         channels
+// @Produce      json
+// @Param        id   path      string  true  "Channel ID"
+// @Param        If-Match header  string  false  "Entity tag of the channel as last seen by the client"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID format"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      412  {object}  httputil.Problem "Precondition Failed - Channel was modified"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Validation failed"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/{id}/enable [post]
+func (h *ChannelHandler) EnableChannel(c *gin.Context) {
+	h.setChannelEnabled(c, true)
+}
+
+// DisableChannel handles POST /api/v1/channels/:id/disable
+// @Summary      Disable a channel
+// @Description  Disables a channel without resubmitting its configuration.
+// @Tags         channels
+// @Produce      json
+// @Param        id   path      string  true  "Channel ID"
+// @Param        If-Match header  string  false  "Entity tag of the channel as last seen by the client"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID format"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      412  {object}  httputil.Problem "Precondition Failed - Channel was modified"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Validation failed"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/{id}/disable [post]
+func (h *ChannelHandler) DisableChannel(c *gin.Context) {
+	h.setChannelEnabled(c, false)
+}
+
+// setChannelEnabled handles the shared part of the enable and disable endpoints.
+func (h *ChannelHandler) setChannelEnabled(c *gin.Context, enabled bool) {
+	channelID := c.Param("id")
+	if channelID == "" {
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Channel ID is required")
+		return
+	}
+
+	if !h.checkIfMatch(c, channelID) {
+		return
+	}
+
+	response, err := h.executor.SetEnabled(c.Request.Context(), channelID, enabled)
+	if err != nil {
+		httputil.RespondError(c, err, "UPDATE_CHANNEL_FAILED", "Failed to update channel")
+		return
+	}
+
+	httputil.SetETag(c, channelETag(response, nil))
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// StartChannelMaintenance handles POST /api/v1/channels/:id/maintenance
+// @Summary      Put a channel in maintenance
+// @Description  Parks the messages for the channel with status held instead of sending them, until maintenance ends.
+// @Tags         channels
+// @Produce      json
+// @Param        id   path      string  true  "Channel ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID format"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/{id}/maintenance [post]
+func (h *ChannelHandler) StartChannelMaintenance(c *gin.Context) {
+	response, err := h.maintenanceUseCase.Start(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "START_MAINTENANCE_FAILED", "Failed to start channel maintenance")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// EndChannelMaintenance handles DELETE /api/v1/channels/:id/maintenance
+// @Summary      Take a channel out of maintenance
+// @Description  Ends maintenance and sends the messages parked for the channel, oldest first.
+// @Tags         channels
+// @Produce      json
+// @Param        id   path      string  true  "Channel ID"
+// @Success      200  {object}  map[string]interface{} "Success response with the number of released messages"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID format"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/{id}/maintenance [delete]
+func (h *ChannelHandler) EndChannelMaintenance(c *gin.Context) {
+	response, err := h.maintenanceUseCase.End(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "END_MAINTENANCE_FAILED", "Failed to end channel maintenance")
 		return
 	}
 
-	response, err := h.deleteUseCase.Execute(c.Request.Context(), channelID)
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ReleaseHeldMessages handles POST /api/v1/channels/:id/maintenance/release
+// @Summary      Release held messages
+// @Description  Sends the messages still parked for a channel that is out of maintenance.
+// @Tags         channels
+// @Produce      json
+// @Param        id   path      string  true  "Channel ID"
+// @Success      200  {object}  map[string]interface{} "Success response with the number of released messages"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID format"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      409  {object}  httputil.Problem "Conflict - Channel is in maintenance"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/{id}/maintenance/release [post]
+func (h *ChannelHandler) ReleaseHeldMessages(c *gin.Context) {
+	response, err := h.maintenanceUseCase.Release(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "DELETE_CHANNEL_FAILED",
-				"message": "Failed to delete channel: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "RELEASE_HELD_MESSAGES_FAILED", "Failed to release held messages")
 		return
 	}
 
@@ -284,4 +564,250 @@ func (h *ChannelHandler) DeleteChannel(c *gin.Context) {
 		"data":  response,
 		"error": nil,
 	})
-}
\ No newline at end of file
+}
+
+// CheckChannelDeliverability handles GET /api/v1/channels/:id/deliverability
+// @Summary      Check the email deliverability of a channel
+// @Description  Checks the SPF, DKIM and DMARC DNS records of the sender domain of an email channel and reports their problems. The DKIM keys are looked up at the given selectors, else at the dkim_selector of the channel config, else at the common ones.
+// @Tags         channels
+// @Produce      json
+// @Param        id        path      string  true   "Channel ID"
+// @Param        selector  query     string  false  "DKIM selectors, comma separated"
+// @Success      200  {object}  map[string]interface{} "Success response with the check of each record"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid channel ID or not an email channel"
+// @Failure      404  {object}  httputil.Problem "Not Found - Channel with specified ID does not exist"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/{id}/deliverability [get]
+func (h *ChannelHandler) CheckChannelDeliverability(c *gin.Context) {
+	var selectors []string
+	for _, selector := range strings.Split(c.Query("selector"), ",") {
+		if selector = strings.TrimSpace(selector); selector != "" {
+			selectors = append(selectors, selector)
+		}
+	}
+
+	response, err := h.deliverabilityUseCase.Execute(c.Request.Context(), c.Param("id"), selectors)
+	if err != nil {
+		httputil.RespondError(c, err, "CHECK_DELIVERABILITY_FAILED", "Failed to check channel deliverability")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// BulkCreateChannels handles POST /api/v1/channels/bulk
+// @Summary      Create channels in bulk
+// @Description  Creates every channel in the array. Each item succeeds or fails on its own.
+// @Tags         channels
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.BulkCreateChannelsRequest true "Create channels in bulk Request"
+// @Success      200  {object}  map[string]interface{} "Per-item results"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Empty or oversized batch"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/bulk [post]
+func (h *ChannelHandler) BulkCreateChannels(c *gin.Context) {
+	var request dtos.BulkCreateChannelsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request format")
+		return
+	}
+	owner := authenticatedUser(c)
+	for i := range request.Channels {
+		if request.Channels[i].Owner == "" {
+			request.Channels[i].Owner = owner
+		}
+	}
+
+	response, err := h.bulkUseCase.Create(c.Request.Context(), &request)
+	if err != nil {
+		httputil.RespondError(c, err, "BULK_CREATE_CHANNELS_FAILED", "Failed to create channels")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// BulkEnableChannels handles POST /api/v1/channels/bulk/enable
+// @Summary      Enable channels in bulk
+// @Description  Enables every listed channel.
+// @Tags         channels
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.BulkChannelIDsRequest true "Enable channels in bulk Request"
+// @Success      200  {object}  map[string]interface{} "Per-item results"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Empty or oversized batch"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/bulk/enable [post]
+func (h *ChannelHandler) BulkEnableChannels(c *gin.Context) {
+	h.bulkSetChannelsEnabled(c, true)
+}
+
+// BulkDisableChannels handles POST /api/v1/channels/bulk/disable
+// @Summary      Disable channels in bulk
+// @Description  Disables every listed channel.
+// @Tags         channels
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.BulkChannelIDsRequest true "Disable channels in bulk Request"
+// @Success      200  {object}  map[string]interface{} "Per-item results"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Empty or oversized batch"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/bulk/disable [post]
+func (h *ChannelHandler) BulkDisableChannels(c *gin.Context) {
+	h.bulkSetChannelsEnabled(c, false)
+}
+
+// bulkSetChannelsEnabled handles the shared part of the bulk enable and disable endpoints.
+func (h *ChannelHandler) bulkSetChannelsEnabled(c *gin.Context, enabled bool) {
+	var request dtos.BulkChannelIDsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request format")
+		return
+	}
+
+	response, err := h.bulkUseCase.SetEnabled(c.Request.Context(), &request, enabled)
+	if err != nil {
+		httputil.RespondError(c, err, "BULK_UPDATE_CHANNELS_FAILED", "Failed to update channels")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// BulkDeleteChannels handles POST /api/v1/channels/bulk/delete
+// @Summary      Delete channels in bulk
+// @Description  Deletes every listed channel.
+// @Tags         channels
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.BulkChannelIDsRequest true "Delete channels in bulk Request"
+// @Success      200  {object}  map[string]interface{} "Per-item results"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Empty or oversized batch"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/bulk/delete [post]
+func (h *ChannelHandler) BulkDeleteChannels(c *gin.Context) {
+	var request dtos.BulkChannelIDsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request format")
+		return
+	}
+
+	response, err := h.bulkUseCase.Delete(c.Request.Context(), &request)
+	if err != nil {
+		httputil.RespondError(c, err, "BULK_DELETE_CHANNELS_FAILED", "Failed to delete channels")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// BulkTagChannels handles POST /api/v1/channels/bulk/tag
+// @Summary      Tag channels in bulk
+// @Description  Adds and removes tags on every listed channel.
+// @Tags         channels
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.BulkTagChannelsRequest true "Tag channels in bulk Request"
+// @Success      200  {object}  map[string]interface{} "Per-item results"
+// @Failure      400  {object}  httputil.Problem "Bad Request - Invalid input"
+// @Failure      422  {object}  httputil.Problem "Unprocessable Entity - Empty or oversized batch"
+// @Failure      500  {object}  httputil.Problem "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/channels/bulk/tag [post]
+func (h *ChannelHandler) BulkTagChannels(c *gin.Context) {
+	var request dtos.BulkTagChannelsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request format")
+		return
+	}
+
+	response, err := h.bulkUseCase.Tag(c.Request.Context(), &request)
+	if err != nil {
+		httputil.RespondError(c, err, "BULK_UPDATE_CHANNELS_FAILED", "Failed to tag channels")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// queryInt64 parses an optional integer query parameter. An unparsable value is
+// rejected rather than ignored, since dropping a filter would widen the result set.
+// It writes the error response and returns false when the request must not proceed.
+func queryInt64(c *gin.Context, name string) (*int64, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid "+name+": must be an integer")
+		return nil, false
+	}
+	return &value, true
+}
+
+// authenticatedUser returns the identity the request was authenticated as, or
+// an empty string for anonymous requests. It is the default owner of the
+// channels and templates the request creates.
+func authenticatedUser(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return c.GetString("auth_user")
+}
+
+// checkIfMatch evaluates the If-Match precondition against the current channel.
+// It writes the error response and returns false when the request must not proceed.
+// When the precondition holds, the request context makes the write conditional
+// on the version it was checked against.
+func (h *ChannelHandler) checkIfMatch(c *gin.Context, channelID string) bool {
+	if !httputil.HasIfMatch(c) {
+		return true
+	}
+
+	current, err := h.executor.Get(c.Request.Context(), channelID)
+	if err != nil {
+		httputil.RespondError(c, err, "CHANNEL_NOT_FOUND", "Channel not found")
+		return false
+	}
+
+	if !httputil.IfMatch(c, channelETag(current, nil)) {
+		httputil.RespondProblem(c, http.StatusPreconditionFailed, "PRECONDITION_FAILED", "Channel has been modified since it was last retrieved")
+		return false
+	}
+
+	c.Request = c.Request.WithContext(shared.WithExpectedVersion(c.Request.Context(), current.ChannelID, current.Version))
+	return true
+}
+
+// channelETag derives the entity tag of a channel representation from its ID,
+// version and selected fields; writes compare the full representation's.
+func channelETag(response *dtos.ChannelResponse, fields httputil.FieldSet) string {
+	return httputil.ComputeETag(response.ChannelID, response.Version, fields)
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/channel/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// ChannelReadinessHandler handles the admin HTTP requests checking the channels at their providers
+type ChannelReadinessHandler struct {
+	checkUseCase *usecases.CheckChannelReadinessUseCase
+}
+
+// NewChannelReadinessHandler creates a new channel readiness handler
+func NewChannelReadinessHandler(checkUseCase *usecases.CheckChannelReadinessUseCase) *ChannelReadinessHandler {
+	return &ChannelReadinessHandler{checkUseCase: checkUseCase}
+}
+
+// CheckChannelReadiness handles GET /api/v1/admin/channels/readiness
+// @Summary Check the channel credentials
+// @Description Check the credentials of every enabled channel at its provider without sending anything: SMTP authentication, SMS API keys, Slack tokens, and the reachability of webhooks and other providers
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Success response with the readiness of each channel and the count by status"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security BasicAuth
+// @Router /api/v1/admin/channels/readiness [get]
+func (h *ChannelReadinessHandler) CheckChannelReadiness(c *gin.Context) {
+	response, err := h.checkUseCase.Execute(c.Request.Context())
+	if err != nil {
+		httputil.RespondError(c, err, "CHECK_CHANNEL_READINESS_FAILED", "Failed to check channel readiness")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
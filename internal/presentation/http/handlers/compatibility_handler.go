@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/template/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// CompatibilityHandler handles the HTTP requests for the content the channel types can send
+type CompatibilityHandler struct {
+	getCompatibilityUseCase *usecases.GetCompatibilityUseCase
+}
+
+// NewCompatibilityHandler creates a new compatibility handler
+func NewCompatibilityHandler(getCompatibilityUseCase *usecases.GetCompatibilityUseCase) *CompatibilityHandler {
+	return &CompatibilityHandler{getCompatibilityUseCase: getCompatibilityUseCase}
+}
+
+// GetCompatibility handles GET /api/v1/compatibility
+// @Summary List the template content each channel type supports
+// @Description List, for each channel type, whether it sends HTML, attachments and subjects and the longest content and subject it accepts, so that templates are only paired with channels able to send them
+// @Tags templates
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Success response with the content capabilities of each channel type"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/compatibility [get]
+func (h *CompatibilityHandler) GetCompatibility(c *gin.Context) {
+	response, err := h.getCompatibilityUseCase.Execute(c.Request.Context())
+	if err != nil {
+		httputil.RespondError(c, err, "GET_COMPATIBILITY_FAILED", "Failed to get channel type compatibility")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
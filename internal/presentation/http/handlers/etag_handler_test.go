@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	channeldtos "notification/internal/application/channel/dtos"
+	templatedtos "notification/internal/application/template/dtos"
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/executor"
+	"notification/internal/presentation/http/handlers"
+	"notification/internal/presentation/http/httputil"
+)
+
+const resourceID = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+// fakeChannelExecutor serves a single channel, recording the version the
+// writes were made conditional on
+type fakeChannelExecutor struct {
+	executor.ChannelExecutor
+	channel         channeldtos.ChannelResponse
+	writes          int
+	expectedVersion int64
+}
+
+func (e *fakeChannelExecutor) Get(ctx context.Context, channelID string) (*channeldtos.ChannelResponse, error) {
+	current := e.channel
+	return &current, nil
+}
+
+func (e *fakeChannelExecutor) Update(ctx context.Context, channelID string, request *channeldtos.UpdateChannelRequest) (*channeldtos.ChannelResponse, error) {
+	e.writes++
+	e.expectedVersion, _ = shared.ExpectedVersionFromContext(ctx, channelID)
+	e.channel.Version++
+	return e.Get(ctx, channelID)
+}
+
+func (e *fakeChannelExecutor) Delete(ctx context.Context, channelID string) (*channeldtos.DeleteChannelResponse, error) {
+	e.writes++
+	return &channeldtos.DeleteChannelResponse{}, nil
+}
+
+// fakeTemplateExecutor serves a single template, recording the version the
+// writes were made conditional on
+type fakeTemplateExecutor struct {
+	executor.TemplateExecutor
+	template        templatedtos.TemplateResponse
+	writes          int
+	expectedVersion int64
+}
+
+func (e *fakeTemplateExecutor) Get(ctx context.Context, templateID string) (*templatedtos.TemplateResponse, error) {
+	current := e.template
+	return &current, nil
+}
+
+func (e *fakeTemplateExecutor) Update(ctx context.Context, templateID string, request *templatedtos.UpdateTemplateRequest) (*templatedtos.TemplateResponse, error) {
+	e.writes++
+	e.expectedVersion, _ = shared.ExpectedVersionFromContext(ctx, templateID)
+	e.template.Version++
+	return e.Get(ctx, templateID)
+}
+
+func (e *fakeTemplateExecutor) Delete(ctx context.Context, templateID string, request *templatedtos.DeleteTemplateRequest) error {
+	e.writes++
+	return nil
+}
+
+func serve(router *gin.Engine, method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestChannelHandler_ConditionalRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	channels := &fakeChannelExecutor{channel: channeldtos.ChannelResponse{ChannelID: resourceID, ChannelName: "alerts", Version: 3}}
+	handler := handlers.NewChannelHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil).WithExecutor(channels)
+	router := gin.New()
+	router.GET("/api/v1/channels/:id", handler.GetChannel)
+	router.PUT("/api/v1/channels/:id", handler.UpdateChannel)
+	router.PATCH("/api/v1/channels/:id", handler.PatchChannel)
+	router.DELETE("/api/v1/channels/:id", handler.DeleteChannel)
+	path := "/api/v1/channels/" + resourceID
+	update := `{"channelName":"alerts","channelType":"email","commonSettings":{"timeout":10},"config":{}}`
+
+	rec := serve(router, http.MethodGet, path, "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, httputil.CacheControl, rec.Header().Get("Cache-Control"))
+
+	rec = serve(router, http.MethodGet, path, "", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+	assert.Equal(t, httputil.CacheControl, rec.Header().Get("Cache-Control"))
+	assert.Empty(t, rec.Body.String())
+
+	rec = serve(router, http.MethodPut, path, update, http.Header{"If-Match": {etag}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(3), channels.expectedVersion)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	// The channel changed since etag was read
+	for _, tc := range []struct{ method, body string }{
+		{http.MethodPut, update},
+		{http.MethodPatch, `{"enabled":false}`},
+		{http.MethodDelete, ""},
+	} {
+		rec = serve(router, tc.method, path, tc.body, http.Header{"If-Match": {etag}})
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code, tc.method)
+		assert.Contains(t, rec.Body.String(), "PRECONDITION_FAILED", tc.method)
+	}
+	assert.Equal(t, 1, channels.writes)
+
+	rec = serve(router, http.MethodGet, path, "", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestTemplateHandler_ConditionalRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	templates := &fakeTemplateExecutor{template: templatedtos.TemplateResponse{ID: resourceID, Name: "welcome", Version: 3}}
+	handler := handlers.NewTemplateHandler(nil, nil, nil, nil, nil, nil, nil, nil).WithExecutor(templates)
+	router := gin.New()
+	router.GET("/api/v1/templates/:id", handler.GetTemplate)
+	router.PUT("/api/v1/templates/:id", handler.ReplaceTemplate)
+	router.PATCH("/api/v1/templates/:id", handler.UpdateTemplate)
+	router.DELETE("/api/v1/templates/:id", handler.DeleteTemplate)
+	path := "/api/v1/templates/" + resourceID
+
+	rec := serve(router, http.MethodGet, path, "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, httputil.CacheControl, rec.Header().Get("Cache-Control"))
+
+	rec = serve(router, http.MethodGet, path, "", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+	assert.Equal(t, httputil.CacheControl, rec.Header().Get("Cache-Control"))
+	assert.Empty(t, rec.Body.String())
+
+	rec = serve(router, http.MethodPatch, path, `{"subject":"Welcome"}`, http.Header{"If-Match": {etag}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(3), templates.expectedVersion)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	// The template changed since etag was read
+	for _, tc := range []struct{ method, body string }{
+		{http.MethodPut, `{"name":"welcome","content":"Hello"}`},
+		{http.MethodPatch, `{"subject":"Hello"}`},
+		{http.MethodDelete, ""},
+	} {
+		rec = serve(router, tc.method, path, tc.body, http.Header{"If-Match": {etag}})
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code, tc.method)
+		assert.Contains(t, rec.Body.String(), "PRECONDITION_FAILED", tc.method)
+	}
+	assert.Equal(t, 1, templates.writes)
+
+	rec = serve(router, http.MethodGet, path, "", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
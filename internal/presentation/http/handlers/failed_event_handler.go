@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/failedevent/dtos"
+	"notification/internal/application/failedevent/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// FailedEventHandler handles the admin HTTP requests for failed events.
+type FailedEventHandler struct {
+	getUseCase    *usecases.GetFailedEventUseCase
+	replayUseCase *usecases.ReplayFailedEventUseCase
+}
+
+// NewFailedEventHandler creates a new FailedEventHandler.
+func NewFailedEventHandler(
+	getUseCase *usecases.GetFailedEventUseCase,
+	replayUseCase *usecases.ReplayFailedEventUseCase,
+) *FailedEventHandler {
+	return &FailedEventHandler{
+		getUseCase:    getUseCase,
+		replayUseCase: replayUseCase,
+	}
+}
+
+// ListFailedEvents handles GET /api/v1/admin/failed-events
+// @Summary List failed events
+// @Description List the event handler executions that failed on every attempt, most recent first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "failed or replayed"
+// @Param eventType query string false "Event type"
+// @Param skipCount query int false "Number of failed events to skip"
+// @Param maxResultCount query int false "Maximum number of failed events to return"
+// @Success 200 {object} map[string]interface{} "Success response with the failed events"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security BasicAuth
+// @Router /api/v1/admin/failed-events [get]
+func (h *FailedEventHandler) ListFailedEvents(c *gin.Context) {
+	var req dtos.ListFailedEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.getUseCase.List(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_FAILED_EVENTS_FAILED", "Failed to list failed events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetFailedEvent handles GET /api/v1/admin/failed-events/:id
+// @Summary Get a failed event
+// @Description Get a failed event handler execution with the event payload
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Failed event ID"
+// @Success 200 {object} map[string]interface{} "Success response with the failed event"
+// @Failure 404 {object} httputil.Problem "Failed event not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security BasicAuth
+// @Router /api/v1/admin/failed-events/{id} [get]
+func (h *FailedEventHandler) GetFailedEvent(c *gin.Context) {
+	response, err := h.getUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "FAILED_EVENT_NOT_FOUND", "Failed event not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ReplayFailedEvent handles POST /api/v1/admin/failed-events/:id/replay
+// @Summary Replay a failed event
+// @Description Hand a failed event to the handler that failed on it again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Failed event ID"
+// @Success 200 {object} map[string]interface{} "Success response with the replayed event"
+// @Failure 404 {object} httputil.Problem "Failed event not found"
+// @Failure 409 {object} httputil.Problem "Already replayed or the handler is no longer subscribed"
+// @Failure 503 {object} httputil.Problem "The handler failed again"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security BasicAuth
+// @Router /api/v1/admin/failed-events/{id}/replay [post]
+func (h *FailedEventHandler) ReplayFailedEvent(c *gin.Context) {
+	response, err := h.replayUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "REPLAY_FAILED_EVENT_FAILED", "Failed to replay failed event")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ReplayFailedEvents handles POST /api/v1/admin/failed-events/replay
+// @Summary Replay pending failed events
+// @Description Replay the failed events not replayed yet, oldest first, once the downstream recovered
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body dtos.ReplayFailedEventsRequest false "Replay failed events request"
+// @Success 200 {object} map[string]interface{} "Success response with the replay outcome"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security BasicAuth
+// @Router /api/v1/admin/failed-events/replay [post]
+func (h *FailedEventHandler) ReplayFailedEvents(c *gin.Context) {
+	var req dtos.ReplayFailedEventsRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httputil.RespondBindError(c, err, "Invalid request body")
+			return
+		}
+	}
+
+	response, err := h.replayUseCase.ReplayPending(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "REPLAY_FAILED_EVENTS_FAILED", "Failed to replay failed events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
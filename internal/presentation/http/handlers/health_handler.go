@@ -7,6 +7,7 @@ import (
 
 	_ "notification/internal/application/health/dtos" // Required for Swagger documentation
 	"notification/internal/application/health/usecases"
+	"notification/internal/presentation/http/httputil"
 )
 
 // HealthHandler handles health check endpoints following Clean Architecture
@@ -35,14 +36,12 @@ func NewHealthHandler(
 // @Tags health
 // @Produce json
 // @Success 200 {object} dtos.LivenessResponse
-// @Failure 500 {object} map[string]string
+// @Failure 500 {object} httputil.Problem
 // @Router /healthz [get]
 func (h *HealthHandler) Healthz(c *gin.Context) {
 	response, err := h.getLivenessUseCase.Execute(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to perform liveness check",
-		})
+		httputil.RespondProblem(c, http.StatusInternalServerError, "HEALTH_CHECK_FAILED", "Failed to perform liveness check")
 		return
 	}
 
@@ -60,9 +59,7 @@ func (h *HealthHandler) Healthz(c *gin.Context) {
 func (h *HealthHandler) HealthStatus(c *gin.Context) {
 	response, err := h.getSystemHealthUseCase.Execute(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to perform system health check",
-		})
+		httputil.RespondProblem(c, http.StatusInternalServerError, "HEALTH_CHECK_FAILED", "Failed to perform system health check")
 		return
 	}
 
@@ -84,9 +81,7 @@ func (h *HealthHandler) HealthStatus(c *gin.Context) {
 func (h *HealthHandler) Health(c *gin.Context) {
 	response, err := h.getLegacyHealthUseCase.Execute(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to get health status",
-		})
+		httputil.RespondProblem(c, http.StatusInternalServerError, "HEALTH_CHECK_FAILED", "Failed to get health status")
 		return
 	}
 
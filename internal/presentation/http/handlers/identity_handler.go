@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/identity/dtos"
+	"notification/internal/application/identity/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// IdentityHandler handles the HTTP requests of the sender identities, the
+// addresses and domains the tenants may send email from.
+type IdentityHandler struct {
+	getUseCase    *usecases.GetSenderIdentityUseCase
+	updateUseCase *usecases.UpdateSenderIdentityUseCase
+}
+
+// NewIdentityHandler creates a new IdentityHandler.
+func NewIdentityHandler(getUseCase *usecases.GetSenderIdentityUseCase, updateUseCase *usecases.UpdateSenderIdentityUseCase) *IdentityHandler {
+	return &IdentityHandler{
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+	}
+}
+
+// CreateIdentity handles POST /api/v1/identities
+// @Summary Create a sender identity
+// @Description Create a pending sender identity of a tenant: an address, emailed a confirmation link, or a domain, verified by publishing the DNS TXT record of the response
+// @Tags identities
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateSenderIdentityRequest true "Create sender identity request"
+// @Success 201 {object} map[string]interface{} "Success response with the sender identity"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 409 {object} httputil.Problem "The tenant already has the sender identity"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/identities [post]
+func (h *IdentityHandler) CreateIdentity(c *gin.Context) {
+	var req dtos.CreateSenderIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.Create(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "CREATE_SENDER_IDENTITY_FAILED", "Failed to create sender identity")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ListIdentities handles GET /api/v1/identities
+// @Summary List the sender identities
+// @Description List the sender identities of a tenant, or of every tenant
+// @Tags identities
+// @Accept json
+// @Produce json
+// @Param tenantId query string false "Tenant ID"
+// @Success 200 {object} map[string]interface{} "Success response with the sender identities"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/identities [get]
+func (h *IdentityHandler) ListIdentities(c *gin.Context) {
+	response, err := h.getUseCase.List(c.Request.Context(), c.Query("tenantId"))
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_SENDER_IDENTITIES_FAILED", "Failed to list sender identities")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetIdentity handles GET /api/v1/identities/:id
+// @Summary Get a sender identity
+// @Description Get a sender identity by ID
+// @Tags identities
+// @Accept json
+// @Produce json
+// @Param id path string true "Sender identity ID"
+// @Success 200 {object} map[string]interface{} "Success response with the sender identity"
+// @Failure 404 {object} httputil.Problem "Sender identity not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/identities/{id} [get]
+func (h *IdentityHandler) GetIdentity(c *gin.Context) {
+	response, err := h.getUseCase.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_SENDER_IDENTITY_FAILED", "Failed to get sender identity")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// VerifyIdentity handles POST /api/v1/identities/:id/verify
+// @Summary Verify a sender identity
+// @Description Verify a domain by looking up its DNS TXT record, or email the confirmation link of an address again
+// @Tags identities
+// @Accept json
+// @Produce json
+// @Param id path string true "Sender identity ID"
+// @Success 200 {object} map[string]interface{} "Success response with the sender identity"
+// @Failure 404 {object} httputil.Problem "Sender identity not found"
+// @Failure 422 {object} httputil.Problem "Verification record not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/identities/{id}/verify [post]
+func (h *IdentityHandler) VerifyIdentity(c *gin.Context) {
+	response, err := h.updateUseCase.Verify(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "VERIFY_SENDER_IDENTITY_FAILED", "Failed to verify sender identity")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// DeleteIdentity handles DELETE /api/v1/identities/:id
+// @Summary Delete a sender identity
+// @Description Delete a sender identity; email channels sending from it are refused afterwards when identities are enforced
+// @Tags identities
+// @Accept json
+// @Produce json
+// @Param id path string true "Sender identity ID"
+// @Success 200 {object} map[string]interface{} "Success response"
+// @Failure 404 {object} httputil.Problem "Sender identity not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/identities/{id} [delete]
+func (h *IdentityHandler) DeleteIdentity(c *gin.Context) {
+	if err := h.updateUseCase.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		httputil.RespondError(c, err, "DELETE_SENDER_IDENTITY_FAILED", "Failed to delete sender identity")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  map[string]interface{}{"deleted": true},
+		"error": nil,
+	})
+}
+
+// ConfirmIdentity handles GET /identities/:id/confirm
+// @Summary Confirm a sender address
+// @Description Verify an address identity through the link of its confirmation email
+// @Tags identities
+// @Produce json
+// @Param id path string true "Sender identity ID"
+// @Param token query string true "Verification token"
+// @Success 200 {object} map[string]interface{} "Success response with the sender identity"
+// @Failure 404 {object} httputil.Problem "Sender identity not found"
+// @Failure 422 {object} httputil.Problem "Invalid token"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Router /identities/{id}/confirm [get]
+func (h *IdentityHandler) ConfirmIdentity(c *gin.Context) {
+	response, err := h.updateUseCase.Confirm(c.Request.Context(), c.Param("id"), c.Query("token"))
+	if err != nil {
+		httputil.RespondError(c, err, "CONFIRM_SENDER_IDENTITY_FAILED", "Failed to confirm sender identity")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"notification/internal/application/inapp/dtos"
+	"notification/internal/application/inapp/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// Timing of the in-app notification streams
+const (
+	inAppWriteTimeout = 10 * time.Second
+	// inAppPingInterval keeps the streams open through idle proxies
+	inAppPingInterval = 30 * time.Second
+	inAppPongTimeout  = 2 * inAppPingInterval
+	// inAppSendBuffer is the number of pushes a slow client may lag behind
+	// before its stream is closed
+	inAppSendBuffer = 16
+)
+
+// inAppUpgrader upgrades the stream requests. They are authenticated like the
+// rest of the API by headers, not cookies, so any origin is accepted.
+var inAppUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// InAppHub pushes the new in-app notifications to the streams of their users
+// connected to this instance.
+type InAppHub struct {
+	mu      sync.Mutex
+	streams map[string]map[*inAppStream]struct{}
+}
+
+// inAppStream is the WebSocket of a connected client
+type inAppStream struct {
+	conn *websocket.Conn
+	send chan []byte
+	once sync.Once
+}
+
+// close stops the writer of the stream, which closes the connection
+func (s *inAppStream) close() {
+	s.once.Do(func() { close(s.send) })
+}
+
+// NewInAppHub creates an in-app notification hub.
+func NewInAppHub() *InAppHub {
+	return &InAppHub{
+		streams: make(map[string]map[*inAppStream]struct{}),
+	}
+}
+
+// Push sends a payload to every stream of a user. A client too slow to keep up
+// is disconnected and lists the notifications it missed when it reconnects.
+func (h *InAppHub) Push(userID string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for stream := range h.streams[userID] {
+		select {
+		case stream.send <- data:
+		default:
+			delete(h.streams[userID], stream)
+			stream.close()
+		}
+	}
+	if len(h.streams[userID]) == 0 {
+		delete(h.streams, userID)
+	}
+}
+
+// register adds a stream of a user
+func (h *InAppHub) register(userID string, stream *inAppStream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.streams[userID] == nil {
+		h.streams[userID] = make(map[*inAppStream]struct{})
+	}
+	h.streams[userID][stream] = struct{}{}
+}
+
+// unregister removes a stream of a user
+func (h *InAppHub) unregister(userID string, stream *inAppStream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.streams[userID], stream)
+	if len(h.streams[userID]) == 0 {
+		delete(h.streams, userID)
+	}
+	stream.close()
+}
+
+// InAppHandler handles the HTTP requests of the notification centers of the users.
+type InAppHandler struct {
+	getUseCase    *usecases.GetInAppNotificationUseCase
+	updateUseCase *usecases.UpdateInAppNotificationUseCase
+	hub           *InAppHub
+}
+
+// NewInAppHandler creates a new InAppHandler.
+func NewInAppHandler(
+	getUseCase *usecases.GetInAppNotificationUseCase,
+	updateUseCase *usecases.UpdateInAppNotificationUseCase,
+	hub *InAppHub,
+) *InAppHandler {
+	return &InAppHandler{
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+		hub:           hub,
+	}
+}
+
+// ListNotifications handles GET /api/v1/users/:userId/notifications
+// @Summary List the notifications of a user
+// @Description List the in-app notifications of a user, most recent first, with the unread count
+// @Tags inapp
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param unreadOnly query bool false "List the unread notifications only"
+// @Param category query string false "Category of the notifications"
+// @Param skipCount query int false "Number of notifications to skip"
+// @Param maxResultCount query int false "Maximum number of notifications to return"
+// @Success 200 {object} map[string]interface{} "Success response with the notifications"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/notifications [get]
+func (h *InAppHandler) ListNotifications(c *gin.Context) {
+	var req dtos.ListInAppNotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.getUseCase.List(c.Request.Context(), c.Param("userId"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_NOTIFICATIONS_FAILED", "Failed to list notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetUnreadCount handles GET /api/v1/users/:userId/notifications/unread-count
+// @Summary Count the unread notifications of a user
+// @Description Count the in-app notifications a user has not read
+// @Tags inapp
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} map[string]interface{} "Success response with the unread count"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/notifications/unread-count [get]
+func (h *InAppHandler) GetUnreadCount(c *gin.Context) {
+	response, err := h.getUseCase.CountUnread(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		httputil.RespondError(c, err, "COUNT_NOTIFICATIONS_FAILED", "Failed to count unread notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// MarkNotificationsRead handles POST /api/v1/users/:userId/notifications/read
+// @Summary Mark notifications of a user read
+// @Description Mark the given notifications of a user read, or every unread one when the request names none
+// @Tags inapp
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dtos.MarkInAppNotificationsReadRequest false "Notifications to mark read"
+// @Success 200 {object} map[string]interface{} "Success response with the marked and unread counts"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/notifications/read [post]
+func (h *InAppHandler) MarkNotificationsRead(c *gin.Context) {
+	var req dtos.MarkInAppNotificationsReadRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httputil.RespondBindError(c, err, "Invalid request body")
+			return
+		}
+	}
+
+	response, err := h.updateUseCase.MarkAllRead(c.Request.Context(), c.Param("userId"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "MARK_NOTIFICATIONS_READ_FAILED", "Failed to mark notifications read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// MarkNotificationRead handles POST /api/v1/users/:userId/notifications/:id/read
+// @Summary Mark a notification read
+// @Description Mark a notification of a user read
+// @Tags inapp
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param id path string true "Notification ID"
+// @Success 200 {object} map[string]interface{} "Success response with the notification"
+// @Failure 404 {object} httputil.Problem "Notification not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/notifications/{id}/read [post]
+func (h *InAppHandler) MarkNotificationRead(c *gin.Context) {
+	response, err := h.updateUseCase.MarkRead(c.Request.Context(), c.Param("userId"), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "MARK_NOTIFICATION_READ_FAILED", "Failed to mark notification read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// DeleteNotification handles DELETE /api/v1/users/:userId/notifications/:id
+// @Summary Delete a notification
+// @Description Delete a notification of a user
+// @Tags inapp
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param id path string true "Notification ID"
+// @Success 200 {object} map[string]interface{} "Success response with the unread count left"
+// @Failure 404 {object} httputil.Problem "Notification not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/notifications/{id} [delete]
+func (h *InAppHandler) DeleteNotification(c *gin.Context) {
+	response, err := h.updateUseCase.Delete(c.Request.Context(), c.Param("userId"), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "DELETE_NOTIFICATION_FAILED", "Failed to delete notification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// StreamNotifications handles GET /api/v1/users/:userId/notifications/stream
+// @Summary Stream the new notifications of a user
+// @Description Upgrade to a WebSocket receiving every new in-app notification of the user with the unread count
+// @Tags inapp
+// @Param userId path string true "User ID"
+// @Success 101 "Switching to the WebSocket protocol"
+// @Failure 400 {object} httputil.Problem "Not a WebSocket request"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/notifications/stream [get]
+func (h *InAppHandler) StreamNotifications(c *gin.Context) {
+	userID := c.Param("userId")
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		httputil.RespondProblem(c, http.StatusBadRequest, "WEBSOCKET_REQUIRED", "Notifications are streamed over a WebSocket")
+		return
+	}
+
+	conn, err := inAppUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader answered the request
+		return
+	}
+
+	stream := &inAppStream{conn: conn, send: make(chan []byte, inAppSendBuffer)}
+	h.hub.register(userID, stream)
+	go h.writeStream(stream)
+
+	// Clients only send control frames; reading detects the closed streams
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(inAppPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(inAppPongTimeout))
+	})
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+	h.hub.unregister(userID, stream)
+}
+
+// writeStream writes the pushes of a stream and pings the client until the
+// stream is closed
+func (h *InAppHandler) writeStream(stream *inAppStream) {
+	ticker := time.NewTicker(inAppPingInterval)
+	defer func() {
+		ticker.Stop()
+		stream.conn.Close()
+	}()
+
+	for {
+		select {
+		case data, ok := <-stream.send:
+			stream.conn.SetWriteDeadline(time.Now().Add(inAppWriteTimeout))
+			if !ok {
+				stream.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := stream.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			stream.conn.SetWriteDeadline(time.Now().Add(inAppWriteTimeout))
+			if err := stream.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/http/httputil"
+	"notification/pkg/logger"
+)
+
+// LinkHandler handles the HTTP requests for the short links of the messages
+type LinkHandler struct {
+	followLinkUC      *usecases.FollowShortLinkUseCase
+	getMessageLinksUC *usecases.GetMessageLinksUseCase
+}
+
+// NewLinkHandler creates a new short link handler
+func NewLinkHandler(followLinkUC *usecases.FollowShortLinkUseCase, getMessageLinksUC *usecases.GetMessageLinksUseCase) *LinkHandler {
+	return &LinkHandler{
+		followLinkUC:      followLinkUC,
+		getMessageLinksUC: getMessageLinksUC,
+	}
+}
+
+// FollowLink handles GET /l/{code}
+// @Summary Follow a short link
+// @Description Redirect to the target of a short link sent in a message, counting the click against the message
+// @Tags messages
+// @Param code path string true "Short link code"
+// @Success 302 {string} string "Redirect to the target of the link"
+// @Failure 404 {object} httputil.Problem "Short link not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Router /l/{code} [get]
+func (h *LinkHandler) FollowLink(c *gin.Context) {
+	targetURL, err := h.followLinkUC.Execute(c.Request.Context(), c.Param("code"))
+	if err != nil && targetURL == "" {
+		httputil.RespondError(c, err, "FOLLOW_LINK_FAILED", "Failed to follow short link")
+		return
+	}
+	if err != nil {
+		logger.Warn("Failed to count short link click", zap.String("code", c.Param("code")), zap.Error(err))
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, targetURL)
+}
+
+// GetMessageLinks handles GET /api/v1/messages/{id}/links
+// @Summary List the short links of a message
+// @Description List the links of a message sent in short form, with the clicks counted on each
+// @Tags messages
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} map[string]interface{} "Success response with the short links and their clicks"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Message not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/messages/{id}/links [get]
+func (h *LinkHandler) GetMessageLinks(c *gin.Context) {
+	response, err := h.getMessageLinksUC.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_MESSAGE_LINKS_FAILED", "Failed to get message links")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/http/httputil"
+	"notification/pkg/logger"
+)
+
+// LogLevelHandler handles the admin HTTP requests changing the log levels of
+// the instance at runtime
+type LogLevelHandler struct {
+	levels *logger.Levels
+}
+
+// NewLogLevelHandler creates a new log level handler
+func NewLogLevelHandler(levels *logger.Levels) *LogLevelHandler {
+	return &LogLevelHandler{levels: levels}
+}
+
+// SetLogLevelRequest represents the request to change a log level
+type SetLogLevelRequest struct {
+	// Module is the component whose level is set; the default level when empty
+	Module string `json:"module" binding:"max=100"`
+	Level  string `json:"level" binding:"required"`
+}
+
+// GetLogLevels handles GET /api/v1/admin/log-levels
+// @Summary Get the log levels
+// @Description Report the default log level of the instance and the levels of the modules overriding it
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Success response with the log levels"
+// @Security BasicAuth
+// @Router /api/v1/admin/log-levels [get]
+func (h *LogLevelHandler) GetLogLevels(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data":  h.response(),
+		"error": nil,
+	})
+}
+
+// SetLogLevel handles PUT /api/v1/admin/log-levels
+// @Summary Set a log level
+// @Description Set the default log level of the instance, or the level of one module, e.g. send_worker, until the instance restarts
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SetLogLevelRequest true "Log level request"
+// @Success 200 {object} map[string]interface{} "Success response with the log levels"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Security BasicAuth
+// @Router /api/v1/admin/log-levels [put]
+func (h *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	var req SetLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil {
+		httputil.RespondError(c, shared.NewValidationError("INVALID_LOG_LEVEL",
+			fmt.Errorf("level must be one of debug, info, warn, error, dpanic, panic, fatal, got %q", req.Level)),
+			"INVALID_LOG_LEVEL", "Invalid log level")
+		return
+	}
+
+	if req.Module == "" {
+		h.levels.SetLevel(level)
+	} else {
+		h.levels.SetModule(req.Module, level)
+	}
+	logger.Info("Log level changed",
+		zap.String("module", req.Module),
+		zap.String("level", level.String()),
+		zap.String("user", c.GetString("auth_user")))
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  h.response(),
+		"error": nil,
+	})
+}
+
+// ResetLogLevel handles DELETE /api/v1/admin/log-levels/:module
+// @Summary Reset the log level of a module
+// @Description Make a module log from the default level of the instance again
+// @Tags admin
+// @Produce json
+// @Param module path string true "Module"
+// @Success 200 {object} map[string]interface{} "Success response with the log levels"
+// @Security BasicAuth
+// @Router /api/v1/admin/log-levels/{module} [delete]
+func (h *LogLevelHandler) ResetLogLevel(c *gin.Context) {
+	module := c.Param("module")
+	h.levels.ResetModule(module)
+	logger.Info("Log level reset",
+		zap.String("module", module),
+		zap.String("user", c.GetString("auth_user")))
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  h.response(),
+		"error": nil,
+	})
+}
+
+// response returns the default level and the module levels
+func (h *LogLevelHandler) response() gin.H {
+	modules := make(map[string]string)
+	for module, level := range h.levels.Modules() {
+		modules[module] = level.String()
+	}
+	return gin.H{
+		"level":   h.levels.Level().String(),
+		"modules": modules,
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"notification/internal/application/message/dtos"
+)
+
+// messageExportFlushEvery is the number of exported messages buffered before
+// they are flushed to the client as a chunk
+const messageExportFlushEvery = 100
+
+// messageExportContentTypes maps the export formats to their content types
+var messageExportContentTypes = map[string]string{
+	"ndjson": "application/x-ndjson",
+	"csv":    "text/csv; charset=utf-8",
+}
+
+// messageExportCSVHeader names the columns of a CSV export, which has one
+// row per message result and one row for a message without results
+var messageExportCSVHeader = []string{
+	"messageId", "createdAt", "status", "tenantId", "correlationId",
+	"channelId", "resultStatus", "error", "errorCategory", "sentAt", "cost", "fallbackFrom",
+}
+
+// messageExportWriter writes exported messages as NDJSON or CSV
+type messageExportWriter struct {
+	format  string
+	out     http.ResponseWriter
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	pending int
+}
+
+// newMessageExportWriter creates a writer of the format to the response
+func newMessageExportWriter(format string, out http.ResponseWriter) (*messageExportWriter, error) {
+	if _, ok := messageExportContentTypes[format]; !ok {
+		return nil, fmt.Errorf("format must be ndjson or csv, got %q", format)
+	}
+
+	w := &messageExportWriter{format: format, out: out, buf: bufio.NewWriter(out)}
+	if format == "csv" {
+		w.csv = csv.NewWriter(w.buf)
+	}
+	return w, nil
+}
+
+// start writes the response headers and, for CSV, the header row
+func (w *messageExportWriter) start() error {
+	header := w.out.Header()
+	header.Set("Content-Type", messageExportContentTypes[w.format])
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="messages.%s"`, w.format))
+	header.Set("Trailer", messageExportErrorTrailer)
+	w.out.WriteHeader(http.StatusOK)
+
+	if w.csv != nil {
+		return w.csv.Write(messageExportCSVHeader)
+	}
+	return nil
+}
+
+// write writes one message, flushing every messageExportFlushEvery messages
+func (w *messageExportWriter) write(msg *dtos.MessageResponse) error {
+	var err error
+	if w.csv != nil {
+		err = w.writeCSV(msg)
+	} else {
+		err = json.NewEncoder(w.buf).Encode(msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.pending++
+	if w.pending >= messageExportFlushEvery {
+		return w.flush()
+	}
+	return nil
+}
+
+// writeCSV writes the rows of one message
+func (w *messageExportWriter) writeCSV(msg *dtos.MessageResponse) error {
+	row := []string{msg.ID, strconv.FormatInt(msg.CreatedAt, 10), string(msg.Status), msg.TenantID, msg.CorrelationID}
+	if len(msg.Results) == 0 {
+		return w.csv.Write(append(row, make([]string, len(messageExportCSVHeader)-len(row))...))
+	}
+
+	for _, result := range msg.Results {
+		sentAt := ""
+		if result.SentAt != nil {
+			sentAt = strconv.FormatInt(*result.SentAt, 10)
+		}
+		resultRow := append(append([]string{}, row...),
+			result.ChannelID,
+			string(result.Status),
+			result.Error,
+			string(result.ErrorCategory),
+			sentAt,
+			strconv.FormatFloat(result.Cost, 'f', -1, 64),
+			result.FallbackFrom,
+		)
+		if err := w.csv.Write(resultRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush sends the buffered messages to the client
+func (w *messageExportWriter) flush() error {
+	w.pending = 0
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	return http.NewResponseController(w.out).Flush()
+}
+
+// messageExportErrorTrailer is the trailer carrying the error code of an
+// export that failed after the response started
+const messageExportErrorTrailer = "X-Export-Error"
@@ -2,18 +2,25 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 
 	"notification/internal/application/message/dtos"
 	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/executor"
+	"notification/internal/presentation/http/httputil"
+	"notification/pkg/logger"
 )
 
-// MessageHandler handles HTTP requests for messages.
+// MessageHandler handles HTTP requests for messages. Sending, getting and
+// listing run on its executor, the other operations on the use cases.
 type MessageHandler struct {
+	executor      executor.MessageExecutor
 	sendMessageUC *usecases.SendMessageUseCase
-	getMessageUC  *usecases.GetMessageUseCase
-	listMessagesUC *usecases.ListMessagesUseCase
+	getQuotaUsageUC *usecases.GetQuotaUsageUseCase
+	exportMessagesUC *usecases.ExportMessagesUseCase
 }
 
 // NewMessageHandler creates a new MessageHandler.
@@ -21,15 +28,26 @@ func NewMessageHandler(
 	sendMessageUC *usecases.SendMessageUseCase,
 	getMessageUC *usecases.GetMessageUseCase,
 	listMessagesUC *usecases.ListMessagesUseCase,
+	getQuotaUsageUC *usecases.GetQuotaUsageUseCase,
+	exportMessagesUC *usecases.ExportMessagesUseCase,
 ) *MessageHandler {
 	return &MessageHandler{
+		executor:      executor.NewUseCaseMessageExecutor(sendMessageUC, getMessageUC, listMessagesUC),
 		sendMessageUC: sendMessageUC,
-		getMessageUC:  getMessageUC,
-		listMessagesUC: listMessagesUC,
+		getQuotaUsageUC: getQuotaUsageUC,
+		exportMessagesUC: exportMessagesUC,
 	}
 }
 
-// SendMessage handles POST /api/v1/messages
+// WithExecutor returns a copy of the handler sending, getting and listing the
+// messages on the given executor, e.g. through CQRS for the v2 routes
+func (h *MessageHandler) WithExecutor(messageExecutor executor.MessageExecutor) *MessageHandler {
+	handler := *h
+	handler.executor = messageExecutor
+	return &handler
+}
+
+// SendMessage handles POST /api/v1/messages and POST /api/v2/messages/send
 // @Summary Send a message
 // @Description Send a message to multiple channels using a template
 // @Tags messages
@@ -37,32 +55,58 @@ func NewMessageHandler(
 // @Produce json
 // @Param request body dtos.SendMessageRequest true "Send message request"
 // @Success 200 {object} map[string]interface{} "Success response with message data"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 403 {object} httputil.Problem "Prod channel or template refused to a deployment below prod"
+// @Failure 429 {object} httputil.Problem "Send quota exceeded"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Failure 503 {object} httputil.Problem "Send queue full, retry after the Retry-After seconds"
 // @Security ApiKeyAuth
-// @Router /messages [post]
+// @Router /api/v1/messages [post]
+// @Router /api/v2/messages/send [post]
 func (h *MessageHandler) SendMessage(c *gin.Context) {
 	var req dtos.SendMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid request body: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid request body")
 		return
 	}
 
-	response, err := h.sendMessageUC.Execute(c.Request.Context(), &req)
+	response, err := h.executor.Send(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "SEND_MESSAGE_FAILED",
-				"message": "Failed to send message: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "SEND_MESSAGE_FAILED", "Failed to send message")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// SimulateRouting handles POST /api/v1/routing/simulate
+// @Summary Simulate the routing of a message
+// @Description Work out which channels a send request selects and why, and for each channel the template, recipients and rendered preview of the send or how it would end, without sending, saving or counting the message
+// @Tags routing
+// @Accept json
+// @Produce json
+// @Param request body dtos.SendMessageRequest true "Hypothetical send message request"
+// @Success 200 {object} map[string]interface{} "Success response with the simulated routing"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 403 {object} httputil.Problem "Prod channel or template refused to a deployment below prod"
+// @Failure 404 {object} httputil.Problem "Template or channel not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/routing/simulate [post]
+func (h *MessageHandler) SimulateRouting(c *gin.Context) {
+	var req dtos.SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.sendMessageUC.Simulate(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "SIMULATE_ROUTING_FAILED", "Failed to simulate routing")
 		return
 	}
 
@@ -79,28 +123,29 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Message ID"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,status,createdAt"
 // @Success 200 {object} map[string]interface{} "Success response with message data"
-// @Failure 404 {object} map[string]interface{} "Message not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 404 {object} httputil.Problem "Message not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /messages/{id} [get]
+// @Router /api/v1/messages/{id} [get]
+// @Router /api/v2/messages/{id} [get]
 func (h *MessageHandler) GetMessage(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.MessageResponse{})
+	if !ok {
+		return
+	}
+
 	id := c.Param("id")
 
-	response, err := h.getMessageUC.Execute(c.Request.Context(), id)
+	response, err := h.executor.Get(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "MESSAGE_NOT_FOUND",
-				"message": "Message not found: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "MESSAGE_NOT_FOUND", "Message not found")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":  response,
+		"data":  fields.Select(response),
 		"error": nil,
 	})
 }
@@ -115,35 +160,62 @@ func (h *MessageHandler) GetMessage(c *gin.Context) {
 // @Param status query string false "Filter by message status"
 // @Param skipCount query int false "Number of items to skip" default(0)
 // @Param maxResultCount query int false "Maximum number of items to return" default(20)
+// @Param fields query string false "Comma-separated fields to return, e.g. id,status,createdAt"
 // @Success 200 {object} map[string]interface{} "Success response with messages list"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /messages [get]
+// @Router /api/v1/messages [get]
+// @Router /api/v2/messages [get]
 func (h *MessageHandler) ListMessages(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.MessageResponse{})
+	if !ok {
+		return
+	}
+
 	var req dtos.ListMessagesRequest
 	
 	// Parse query parameters
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid query parameters: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.executor.List(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_MESSAGES_FAILED", "Failed to list messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  fields.SelectItems(response),
+		"error": nil,
+	})
+}
+
+// GetQuotaUsage handles GET /api/v1/messages/quota
+// @Summary Get send quota usage
+// @Description Retrieve the daily and monthly send quota usage of a tenant and/or a channel; the default tenant when neither is given
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param tenantId query string false "Tenant ID"
+// @Param channelId query string false "Channel ID"
+// @Success 200 {object} map[string]interface{} "Success response with quota usage"
+// @Failure 422 {object} httputil.Problem "Invalid tenant or channel ID"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/messages/quota [get]
+func (h *MessageHandler) GetQuotaUsage(c *gin.Context) {
+	var req dtos.GetQuotaUsageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
 		return
 	}
 
-	response, err := h.listMessagesUC.Execute(c.Request.Context(), &req)
+	response, err := h.getQuotaUsageUC.Execute(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "LIST_MESSAGES_FAILED",
-				"message": "Failed to list messages: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "GET_QUOTA_USAGE_FAILED", "Failed to get quota usage")
 		return
 	}
 
@@ -151,4 +223,65 @@ func (h *MessageHandler) ListMessages(c *gin.Context) {
 		"data":  response,
 		"error": nil,
 	})
-}
\ No newline at end of file
+}
+
+// ExportMessages handles GET /api/v1/messages/export
+// @Summary Export messages
+// @Description Stream the messages matching the filter, oldest first, as NDJSON (one message per line) or CSV (one row per message result). The export is sent in chunks while it is read, so it is not paginated. An export failing after the response started ends early with the error code in the X-Export-Error trailer.
+// @Tags messages
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Param format query string false "Export format: ndjson or csv" default(ndjson)
+// @Param channelId query string false "Filter by channel ID"
+// @Param status query string false "Filter by message status"
+// @Param tenantId query string false "Filter by tenant ID"
+// @Param from query int false "Earliest creation time in Unix milliseconds, inclusive"
+// @Param to query int false "Latest creation time in Unix milliseconds, exclusive"
+// @Success 200 {string} string "Exported messages"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/messages/export [get]
+func (h *MessageHandler) ExportMessages(c *gin.Context) {
+	var req dtos.ExportMessagesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	export, err := newMessageExportWriter(c.DefaultQuery("format", "ndjson"), c.Writer)
+	if err != nil {
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	// An export may outlast the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	started := false
+	err = h.exportMessagesUC.Execute(c.Request.Context(), &req, func(msg *dtos.MessageResponse) error {
+		if !started {
+			started = true
+			if err := export.start(); err != nil {
+				return err
+			}
+		}
+		return export.write(msg)
+	})
+	if err == nil && !started {
+		started = true
+		err = export.start()
+	}
+	if err == nil {
+		err = export.flush()
+	}
+
+	if err != nil {
+		if !started {
+			httputil.RespondError(c, err, "EXPORT_MESSAGES_FAILED", "Failed to export messages")
+			return
+		}
+		logger.FromContext(c.Request.Context()).Error("Message export failed after the response started", zap.Error(err))
+		c.Writer.Header().Set(messageExportErrorTrailer, httputil.ErrorCode(err, "EXPORT_MESSAGES_FAILED"))
+	}
+}
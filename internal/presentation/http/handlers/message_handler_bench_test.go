@@ -0,0 +1,65 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+	"notification/internal/sendbench"
+)
+
+// BenchmarkMessageHandler_SendMessage sends messages through the HTTP handler,
+// from the request body to the response, against the in-memory pipeline
+func BenchmarkMessageHandler_SendMessage(b *testing.B) {
+	gin.SetMode(gin.ReleaseMode)
+
+	configs := []struct {
+		name   string
+		config sendbench.Config
+	}{
+		{"instant", sendbench.Config{}},
+		{"contended", sendbench.Config{PoolSize: 2, QueryLatency: 200 * time.Microsecond, ProviderLatency: 2 * time.Millisecond}},
+	}
+	for _, bc := range configs {
+		b.Run(bc.name, func(b *testing.B) {
+			p, err := sendbench.NewPipeline(bc.config)
+			if err != nil {
+				b.Fatal(err)
+			}
+			handler := handlers.NewMessageHandler(p.SendUseCase, nil, nil, nil, nil)
+			router := gin.New()
+			router.POST("/api/v1/messages", handler.SendMessage)
+
+			body, err := json.Marshal(p.Request())
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.SetParallelism(8)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					start := time.Now()
+					req := httptest.NewRequest(http.MethodPost, "/api/v1/messages", bytes.NewReader(body))
+					req.Header.Set("Content-Type", "application/json")
+					rec := httptest.NewRecorder()
+					router.ServeHTTP(rec, req)
+					if rec.Code != http.StatusOK {
+						b.Errorf("status %d: %s", rec.Code, rec.Body.String())
+						return
+					}
+					p.Latencies.Record(time.Since(start))
+				}
+			})
+			b.StopTimer()
+			p.ReportMetrics(b)
+		})
+	}
+}
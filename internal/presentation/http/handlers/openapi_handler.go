@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+
+	"notification/internal/presentation/http/httputil"
+	"notification/internal/presentation/http/openapi"
+)
+
+// OpenAPIHandler serves the OpenAPI 3 document of the HTTP API
+type OpenAPIHandler struct {
+	once sync.Once
+	doc  *openapi.Document
+	err  error
+}
+
+// NewOpenAPIHandler creates a new OpenAPI handler
+func NewOpenAPIHandler() *OpenAPIHandler {
+	return &OpenAPIHandler{}
+}
+
+// GetOpenAPISpec handles GET /api/openapi.json
+// The document is converted once from the Swagger docs generated from the
+// handler annotations and registered by the docs package.
+func (h *OpenAPIHandler) GetOpenAPISpec(c *gin.Context) {
+	h.once.Do(func() {
+		var swagger string
+		swagger, h.err = swag.ReadDoc()
+		if h.err != nil {
+			return
+		}
+		h.doc, h.err = openapi.FromSwagger([]byte(swagger))
+	})
+
+	if h.err != nil {
+		httputil.RespondProblem(c, http.StatusInternalServerError, "OPENAPI_UNAVAILABLE", "The API document is not available")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.doc)
+}
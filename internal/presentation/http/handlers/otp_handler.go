@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/otp/dtos"
+	"notification/internal/application/otp/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// OTPHandler handles the HTTP requests of the one-time verification codes.
+type OTPHandler struct {
+	otpUseCase *usecases.OTPUseCase
+}
+
+// NewOTPHandler creates a new OTPHandler.
+func NewOTPHandler(otpUseCase *usecases.OTPUseCase) *OTPHandler {
+	return &OTPHandler{
+		otpUseCase: otpUseCase,
+	}
+}
+
+// SendOTP handles POST /api/v1/otp/send
+// @Summary Send a verification code
+// @Description Generate a one-time code and send it to the recipient, rendered by the template of the request or the configured one in its {code} variable. Only the hash of the code is stored; verify it with the ID of the response.
+// @Tags otp
+// @Accept json
+// @Produce json
+// @Param request body dtos.SendOTPRequest true "Send OTP request"
+// @Success 201 {object} map[string]interface{} "Success response with the code sent"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Channel or template not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 429 {object} httputil.Problem "A code was sent to the recipient too recently"
+// @Failure 503 {object} httputil.Problem "The code could not be sent"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/otp/send [post]
+func (h *OTPHandler) SendOTP(c *gin.Context) {
+	var req dtos.SendOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.otpUseCase.Send(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "SEND_OTP_FAILED", "Failed to send verification code")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// VerifyOTP handles POST /api/v1/otp/verify
+// @Summary Verify a verification code
+// @Description Check a one-time code. A wrong code uses up one of its attempts; a code is verified once, before it expires.
+// @Tags otp
+// @Accept json
+// @Produce json
+// @Param request body dtos.VerifyOTPRequest true "Verify OTP request"
+// @Success 200 {object} map[string]interface{} "Success response with the verified code"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Verification code not found"
+// @Failure 409 {object} httputil.Problem "The code was already verified"
+// @Failure 422 {object} httputil.Problem "The code is wrong, expired or out of attempts"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/otp/verify [post]
+func (h *OTPHandler) VerifyOTP(c *gin.Context) {
+	var req dtos.VerifyOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.otpUseCase.Verify(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "VERIFY_OTP_FAILED", "Failed to verify code")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
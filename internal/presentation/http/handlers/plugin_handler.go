@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"notification/internal/infrastructure/plugins"
+	"notification/internal/presentation/http/httputil"
 )
 
 // PluginHandler handles HTTP requests for plugin management
@@ -34,45 +35,27 @@ type LoadPluginRequest struct {
 // @Produce json
 // @Param request body LoadPluginRequest true "Load plugin request"
 // @Success 200 {object} map[string]interface{} "Plugin loaded successfully"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /plugins/load [post]
+// @Router /api/v1/plugins/load [post]
 func (h *PluginHandler) LoadPlugin(c *gin.Context) {
 	var req LoadPluginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid request body: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid request body")
 		return
 	}
 
 	err := h.pluginLoader.LoadPluginFromSource(req.Name, req.Source)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "LOAD_PLUGIN_FAILED",
-				"message": "Failed to load plugin: " + err.Error(),
-			},
-		})
+		httputil.RespondProblem(c, http.StatusBadRequest, "LOAD_PLUGIN_FAILED", "Failed to load plugin: " + err.Error())
 		return
 	}
 
 	// Get plugin status after loading
 	status, err := h.pluginLoader.GetPluginStatus(req.Name)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "GET_STATUS_FAILED",
-				"message": "Plugin loaded but failed to get status: " + err.Error(),
-			},
-		})
+		httputil.RespondProblem(c, http.StatusInternalServerError, "GET_STATUS_FAILED", "Plugin loaded but failed to get status: " + err.Error())
 		return
 	}
 
@@ -89,9 +72,9 @@ func (h *PluginHandler) LoadPlugin(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Success response with plugins list"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /plugins [get]
+// @Router /api/v1/plugins [get]
 func (h *PluginHandler) ListPlugins(c *gin.Context) {
 	statuses := h.pluginLoader.GetAllPluginStatuses()
 
@@ -109,22 +92,16 @@ func (h *PluginHandler) ListPlugins(c *gin.Context) {
 // @Produce json
 // @Param name path string true "Plugin name"
 // @Success 200 {object} map[string]interface{} "Success response with plugin status"
-// @Failure 404 {object} map[string]interface{} "Plugin not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 404 {object} httputil.Problem "Plugin not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /plugins/{name} [get]
+// @Router /api/v1/plugins/{name} [get]
 func (h *PluginHandler) GetPlugin(c *gin.Context) {
 	name := c.Param("name")
 
 	status, err := h.pluginLoader.GetPluginStatus(name)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "PLUGIN_NOT_FOUND",
-				"message": "Plugin not found: " + err.Error(),
-			},
-		})
+		httputil.RespondProblem(c, http.StatusNotFound, "PLUGIN_NOT_FOUND", "Plugin not found: " + err.Error())
 		return
 	}
 
@@ -142,22 +119,16 @@ func (h *PluginHandler) GetPlugin(c *gin.Context) {
 // @Produce json
 // @Param name path string true "Plugin name"
 // @Success 200 {object} map[string]interface{} "Plugin unloaded successfully"
-// @Failure 404 {object} map[string]interface{} "Plugin not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 404 {object} httputil.Problem "Plugin not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /plugins/{name} [delete]
+// @Router /api/v1/plugins/{name} [delete]
 func (h *PluginHandler) UnloadPlugin(c *gin.Context) {
 	name := c.Param("name")
 
 	err := h.pluginLoader.UnloadPlugin(name)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "UNLOAD_PLUGIN_FAILED",
-				"message": "Failed to unload plugin: " + err.Error(),
-			},
-		})
+		httputil.RespondProblem(c, http.StatusBadRequest, "UNLOAD_PLUGIN_FAILED", "Failed to unload plugin: " + err.Error())
 		return
 	}
 
@@ -175,44 +146,26 @@ func (h *PluginHandler) UnloadPlugin(c *gin.Context) {
 // @Produce json
 // @Param request body map[string]string true "Load plugin from file request"
 // @Success 200 {object} map[string]interface{} "Plugin loaded successfully"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /plugins/load-file [post]
+// @Router /api/v1/plugins/load-file [post]
 func (h *PluginHandler) LoadPluginFromFile(c *gin.Context) {
 	var req map[string]string
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid request body: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid request body")
 		return
 	}
 
 	filePath, exists := req["file_path"]
 	if !exists || filePath == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "file_path is required",
-			},
-		})
+		httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "file_path is required")
 		return
 	}
 
 	err := h.pluginLoader.LoadPlugin(filePath)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "LOAD_PLUGIN_FAILED",
-				"message": "Failed to load plugin from file: " + err.Error(),
-			},
-		})
+		httputil.RespondProblem(c, http.StatusBadRequest, "LOAD_PLUGIN_FAILED", "Failed to load plugin from file: " + err.Error())
 		return
 	}
 
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/preference/dtos"
+	"notification/internal/application/preference/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// PreferenceHandler handles the HTTP requests of the notification preferences of the users.
+type PreferenceHandler struct {
+	getUseCase    *usecases.GetUserPreferenceUseCase
+	updateUseCase *usecases.UpdateUserPreferenceUseCase
+}
+
+// NewPreferenceHandler creates a new PreferenceHandler.
+func NewPreferenceHandler(
+	getUseCase *usecases.GetUserPreferenceUseCase,
+	updateUseCase *usecases.UpdateUserPreferenceUseCase,
+) *PreferenceHandler {
+	return &PreferenceHandler{
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+	}
+}
+
+// GetPreferences handles GET /api/v1/users/:userId/preferences
+// @Summary Get the notification preferences of a user
+// @Description Get the preferred channels, quiet hours and category opt-outs of a user, identified by a user ID or the target address the channels send to
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID or target address"
+// @Success 200 {object} map[string]interface{} "Success response with the preferences"
+// @Failure 404 {object} httputil.Problem "User has no preferences"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/preferences [get]
+func (h *PreferenceHandler) GetPreferences(c *gin.Context) {
+	response, err := h.getUseCase.Execute(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_PREFERENCES_FAILED", "Failed to get preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// SetPreferences handles PUT /api/v1/users/:userId/preferences
+// @Summary Set the notification preferences of a user
+// @Description Replace the preferences of a user. Messages to the user through channel types it does not prefer, in categories it opted out of or during its quiet hours are suppressed.
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID or target address"
+// @Param request body dtos.SetUserPreferenceRequest true "Preferences of the user"
+// @Success 200 {object} map[string]interface{} "Success response with the preferences"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/preferences [put]
+func (h *PreferenceHandler) SetPreferences(c *gin.Context) {
+	var req dtos.SetUserPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.updateUseCase.Set(c.Request.Context(), c.Param("userId"), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "SET_PREFERENCES_FAILED", "Failed to set preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// DeletePreferences handles DELETE /api/v1/users/:userId/preferences
+// @Summary Delete the notification preferences of a user
+// @Description Delete the preferences of a user, who receives every message again
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID or target address"
+// @Success 200 {object} map[string]interface{} "Success response"
+// @Failure 404 {object} httputil.Problem "User has no preferences"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{userId}/preferences [delete]
+func (h *PreferenceHandler) DeletePreferences(c *gin.Context) {
+	if err := h.updateUseCase.Delete(c.Request.Context(), c.Param("userId")); err != nil {
+		httputil.RespondError(c, err, "DELETE_PREFERENCES_FAILED", "Failed to delete preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  map[string]interface{}{"deleted": true},
+		"error": nil,
+	})
+}
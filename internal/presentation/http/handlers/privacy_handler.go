@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/privacy/dtos"
+	"notification/internal/application/privacy/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// PrivacyHandler handles the HTTP requests of the data subject requests,
+// exporting and erasing the data stored about a recipient.
+type PrivacyHandler struct {
+	privacyUseCase *usecases.PrivacyRequestUseCase
+}
+
+// NewPrivacyHandler creates a new PrivacyHandler.
+func NewPrivacyHandler(privacyUseCase *usecases.PrivacyRequestUseCase) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyUseCase: privacyUseCase,
+	}
+}
+
+// ExportSubject handles POST /api/v1/privacy/exports
+// @Summary Export the data of a recipient
+// @Description Start collecting every row stored about a recipient address: the messages sent to it with its results, its acknowledgments, reply tokens, replies, verification codes, in-app notifications, scheduled sends, preferences and subscriptions. The request runs in the background; follow it at the Location header and get the data once it completed.
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Param request body dtos.PrivacyRequest true "Privacy request"
+// @Success 202 {object} map[string]interface{} "Success response with the started request"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/privacy/exports [post]
+func (h *PrivacyHandler) ExportSubject(c *gin.Context) {
+	h.start(c, h.privacyUseCase.Export, "EXPORT_SUBJECT_FAILED", "Failed to start privacy export")
+}
+
+// EraseSubject handles POST /api/v1/privacy/erasures
+// @Summary Erase the data of a recipient
+// @Description Start deleting every row stored about a recipient address, in one transaction. The messages sent to the recipient alone are deleted with their results and engagement; the messages shared with other recipients keep their results, the address replaced by "[erased]". The exports made of the recipient are dropped, and the request and its audit trail keep the hash of the address only.
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Param request body dtos.PrivacyRequest true "Privacy request"
+// @Success 202 {object} map[string]interface{} "Success response with the started request"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/privacy/erasures [post]
+func (h *PrivacyHandler) EraseSubject(c *gin.Context) {
+	h.start(c, h.privacyUseCase.Erase, "ERASE_SUBJECT_FAILED", "Failed to start privacy erasure")
+}
+
+// start binds a privacy request and starts it with the use case method
+func (h *PrivacyHandler) start(
+	c *gin.Context,
+	startRequest func(ctx context.Context, req *dtos.PrivacyRequest) (*dtos.PrivacyRequestResponse, error),
+	code, summary string,
+) {
+	var req dtos.PrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := startRequest(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, code, summary)
+		return
+	}
+
+	c.Header("Location", "/api/v1/privacy/requests/"+response.ID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetPrivacyRequest handles GET /api/v1/privacy/requests/:id
+// @Summary Get a privacy request
+// @Description Get the status of an export or erasure request and, once completed, its report counting the rows exported or erased per kind of data
+// @Tags privacy
+// @Produce json
+// @Param id path string true "Privacy request ID"
+// @Success 200 {object} map[string]interface{} "Success response with the request"
+// @Failure 404 {object} httputil.Problem "Privacy request not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/privacy/requests/{id} [get]
+func (h *PrivacyHandler) GetPrivacyRequest(c *gin.Context) {
+	response, err := h.privacyUseCase.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_PRIVACY_REQUEST_FAILED", "Failed to get privacy request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetPrivacyExport handles GET /api/v1/privacy/requests/:id/export
+// @Summary Get the data of a privacy export
+// @Description Get the rows a completed export request collected about its recipient, per kind of data. The data is gone once the recipient is erased.
+// @Tags privacy
+// @Produce json
+// @Param id path string true "Privacy request ID"
+// @Success 200 {object} map[string]interface{} "Success response with the exported data"
+// @Failure 404 {object} httputil.Problem "Privacy export not found or erased"
+// @Failure 409 {object} httputil.Problem "The export has not completed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/privacy/requests/{id}/export [get]
+func (h *PrivacyHandler) GetPrivacyExport(c *gin.Context) {
+	response, err := h.privacyUseCase.GetExport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_PRIVACY_EXPORT_FAILED", "Failed to get privacy export")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ListPrivacyAudit handles GET /api/v1/privacy/audit
+// @Summary List the privacy audit trail
+// @Description List the audit entries of the privacy requests, newest first: who requested an export or erasure, and how it completed. Entries identify the recipients by the hash of their address.
+// @Tags privacy
+// @Produce json
+// @Param limit query int false "Number of entries listed, 100 by default" maximum(1000)
+// @Success 200 {object} map[string]interface{} "Success response with the audit entries"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/privacy/audit [get]
+func (h *PrivacyHandler) ListPrivacyAudit(c *gin.Context) {
+	var req dtos.ListPrivacyAuditRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.privacyUseCase.ListAudit(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_PRIVACY_AUDIT_FAILED", "Failed to list privacy audit trail")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
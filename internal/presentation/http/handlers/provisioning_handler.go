@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/provisioning/dtos"
+	"notification/internal/application/provisioning/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// ProvisioningHandler handles HTTP requests for provisioning sagas.
+type ProvisioningHandler struct {
+	provisionUseCase *usecases.ProvisionChannelUseCase
+	getUseCase       *usecases.GetProvisioningSagaUseCase
+}
+
+// NewProvisioningHandler creates a new ProvisioningHandler.
+func NewProvisioningHandler(
+	provisionUseCase *usecases.ProvisionChannelUseCase,
+	getUseCase *usecases.GetProvisioningSagaUseCase,
+) *ProvisioningHandler {
+	return &ProvisioningHandler{
+		provisionUseCase: provisionUseCase,
+		getUseCase:       getUseCase,
+	}
+}
+
+// ProvisionChannel handles POST /api/v1/provisioning/channels
+// @Summary Provision a channel
+// @Description Start provisioning a channel in steps: create it disabled, verify the provider connection, optionally send a test message and enable it. Completed steps are undone when a later one fails. Follow the saga at the Location header.
+// @Tags provisioning
+// @Accept json
+// @Produce json
+// @Param request body dtos.ProvisionChannelRequest true "Provision channel request"
+// @Success 202 {object} map[string]interface{} "Success response with the started saga"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/provisioning/channels [post]
+func (h *ProvisioningHandler) ProvisionChannel(c *gin.Context) {
+	var req dtos.ProvisionChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.provisionUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "PROVISION_CHANNEL_FAILED", "Failed to provision channel")
+		return
+	}
+
+	c.Header("Location", "/api/v1/provisioning/"+response.SagaID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetProvisioningSaga handles GET /api/v1/provisioning/:id
+// @Summary Get a provisioning saga
+// @Description Get the status of a provisioning saga and each of its steps
+// @Tags provisioning
+// @Accept json
+// @Produce json
+// @Param id path string true "Saga ID"
+// @Success 200 {object} map[string]interface{} "Success response with the saga"
+// @Failure 400 {object} httputil.Problem "Invalid saga ID"
+// @Failure 404 {object} httputil.Problem "Saga not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/provisioning/{id} [get]
+func (h *ProvisioningHandler) GetProvisioningSaga(c *gin.Context) {
+	response, err := h.getUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "SAGA_NOT_FOUND", "Saga not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
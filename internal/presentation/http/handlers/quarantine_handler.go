@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// QuarantineHandler handles the admin HTTP requests for the quarantined attachments
+type QuarantineHandler struct {
+	getQuarantineUC *usecases.GetMessageQuarantineUseCase
+}
+
+// NewQuarantineHandler creates a new quarantine handler
+func NewQuarantineHandler(getQuarantineUC *usecases.GetMessageQuarantineUseCase) *QuarantineHandler {
+	return &QuarantineHandler{getQuarantineUC: getQuarantineUC}
+}
+
+// GetMessageQuarantine handles GET /api/v1/admin/messages/{id}/quarantine
+// @Summary List the quarantined attachments of a message
+// @Description List the infected attachments the virus scan kept back from the sends of a message, with the virus found in each
+// @Tags admin
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} map[string]interface{} "Success response with the quarantined attachments"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Message not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security BasicAuth
+// @Router /api/v1/admin/messages/{id}/quarantine [get]
+func (h *QuarantineHandler) GetMessageQuarantine(c *gin.Context) {
+	response, err := h.getQuarantineUC.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_MESSAGE_QUARANTINE_FAILED", "Failed to get message quarantine")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/http/httputil"
+	"notification/pkg/logger"
+)
+
+// ReadOnlyHandler handles the admin HTTP requests for the read-only mode
+type ReadOnlyHandler struct {
+	mode *shared.ReadOnlyMode
+}
+
+// NewReadOnlyHandler creates a new read-only mode handler
+func NewReadOnlyHandler(mode *shared.ReadOnlyMode) *ReadOnlyHandler {
+	return &ReadOnlyHandler{mode: mode}
+}
+
+// SetReadOnlyRequest represents the request to switch the read-only mode
+type SetReadOnlyRequest struct {
+	ReadOnly *bool `json:"readOnly" binding:"required"`
+}
+
+// GetReadOnly handles GET /api/v1/admin/read-only
+// @Summary Get the read-only mode
+// @Description Report whether the instance serves queries only, rejecting commands and pausing its workers
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Success response with the read-only mode"
+// @Security BasicAuth
+// @Router /api/v1/admin/read-only [get]
+func (h *ReadOnlyHandler) GetReadOnly(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data":  gin.H{"readOnly": h.mode.Enabled()},
+		"error": nil,
+	})
+}
+
+// SetReadOnly handles PUT /api/v1/admin/read-only
+// @Summary Switch the read-only mode
+// @Description Enter read-only mode to serve queries only, as a warm standby or during a maintenance window, or leave it to accept commands and resume the workers
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SetReadOnlyRequest true "Read-only mode request"
+// @Success 200 {object} map[string]interface{} "Success response with the read-only mode"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Security BasicAuth
+// @Router /api/v1/admin/read-only [put]
+func (h *ReadOnlyHandler) SetReadOnly(c *gin.Context) {
+	var req SetReadOnlyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	if h.mode.Set(*req.ReadOnly) {
+		logger.Info("Read-only mode switched",
+			zap.Bool("readOnly", *req.ReadOnly),
+			zap.String("user", c.GetString("auth_user")))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  gin.H{"readOnly": h.mode.Enabled()},
+		"error": nil,
+	})
+}
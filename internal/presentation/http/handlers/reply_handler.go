@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// emptyTwiML is the answer to an SMS reply, which sends nothing back
+const emptyTwiML = `<?xml version="1.0" encoding="UTF-8"?><Response></Response>`
+
+// InboundSecretHeader is the header the mail provider posts the email
+// replies with, holding the inbound secret
+const InboundSecretHeader = "X-Inbound-Secret"
+
+// ReplyHandler handles the replies received back from the recipients
+type ReplyHandler struct {
+	receiveReplyUC *usecases.ReceiveReplyUseCase
+	getRepliesUC   *usecases.GetRepliesUseCase
+}
+
+// NewReplyHandler creates a new reply handler
+func NewReplyHandler(receiveReplyUC *usecases.ReceiveReplyUseCase, getRepliesUC *usecases.GetRepliesUseCase) *ReplyHandler {
+	return &ReplyHandler{
+		receiveReplyUC: receiveReplyUC,
+		getRepliesUC:   getRepliesUC,
+	}
+}
+
+// ReceiveSMS handles POST /api/v1/public/sms/channels/{id}/inbound
+// @Summary Receive an SMS reply
+// @Description Record an SMS sent back to an SMS channel, correlated to the latest message the channel sent to its sender. Called by Twilio, which signs the request with the auth token of the channel.
+// @Tags replies
+// @Accept x-www-form-urlencoded
+// @Produce xml
+// @Param id path string true "Channel ID"
+// @Param X-Twilio-Signature header string true "Twilio request signature"
+// @Param From formData string true "Phone number of the sender"
+// @Param Body formData string false "Text of the reply"
+// @Param MessageSid formData string false "Message SID"
+// @Success 200 {string} string "Empty TwiML"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 403 {object} httputil.Problem "Invalid signature"
+// @Failure 404 {object} httputil.Problem "SMS channel not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Router /api/v1/public/sms/channels/{id}/inbound [post]
+func (h *ReplyHandler) ReceiveSMS(c *gin.Context) {
+	if err := c.Request.ParseForm(); err != nil {
+		httputil.RespondBindError(c, err, "Invalid form body")
+		return
+	}
+
+	_, err := h.receiveReplyUC.ReceiveSMS(c.Request.Context(), c.Param("id"), c.Request.PostForm, c.GetHeader("X-Twilio-Signature"))
+	if err != nil {
+		httputil.RespondError(c, err, "RECEIVE_SMS_REPLY_FAILED", "Failed to receive SMS reply")
+		return
+	}
+
+	c.Data(http.StatusOK, "text/xml; charset=utf-8", []byte(emptyTwiML))
+}
+
+// ReceiveEmail handles POST /api/v1/public/email/inbound
+// @Summary Receive an email reply
+// @Description Record an email reply posted in raw RFC 5322 form by the mail provider, correlated to the message whose reply token tags the address it was sent to
+// @Tags replies
+// @Accept plain
+// @Produce json
+// @Param X-Inbound-Secret header string true "Inbound secret"
+// @Param email body string true "Raw email"
+// @Success 201 {object} map[string]interface{} "Success response with the reply"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 403 {object} httputil.Problem "Invalid secret"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Router /api/v1/public/email/inbound [post]
+func (h *ReplyHandler) ReceiveEmail(c *gin.Context) {
+	raw, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		httputil.RespondBindError(c, err, "Invalid email body")
+		return
+	}
+
+	response, err := h.receiveReplyUC.ReceiveEmail(c.Request.Context(), raw, c.GetHeader(InboundSecretHeader))
+	if err != nil {
+		httputil.RespondError(c, err, "RECEIVE_EMAIL_REPLY_FAILED", "Failed to receive email reply")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetMessageReplies handles GET /api/v1/messages/{id}/replies
+// @Summary List the replies to a message
+// @Description List the SMS and emails sent back by the recipients of a message
+// @Tags replies
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} map[string]interface{} "Success response with the replies"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Message not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/messages/{id}/replies [get]
+func (h *ReplyHandler) GetMessageReplies(c *gin.Context) {
+	response, err := h.getRepliesUC.ForMessage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_MESSAGE_REPLIES_FAILED", "Failed to get message replies")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ListReplies handles GET /api/v1/replies
+// @Summary List the replies received
+// @Description List the replies received after a time, newest first, the uncorrelated ones included
+// @Tags replies
+// @Produce json
+// @Param since query int false "Received after, in Unix milliseconds"
+// @Param limit query int false "Number of replies listed, 100 by default" maximum(1000)
+// @Success 200 {object} map[string]interface{} "Success response with the replies"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/replies [get]
+func (h *ReplyHandler) ListReplies(c *gin.Context) {
+	var req dtos.ListRepliesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.getRepliesUC.List(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_REPLIES_FAILED", "Failed to list replies")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/retention/dtos"
+	"notification/internal/application/retention/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// RetentionHandler handles the HTTP requests of the retention job reports.
+type RetentionHandler struct {
+	listReportsUseCase *usecases.ListRetentionReportsUseCase
+}
+
+// NewRetentionHandler creates a new RetentionHandler.
+func NewRetentionHandler(listReportsUseCase *usecases.ListRetentionReportsUseCase) *RetentionHandler {
+	return &RetentionHandler{
+		listReportsUseCase: listReportsUseCase,
+	}
+}
+
+// ListRetentionReports handles GET /api/v1/retention/reports
+// @Summary List the retention reports
+// @Description List what the retention job did per tenant, newest first: the number of messages whose content (variables, template overrides, reply bodies, quarantined attachments) was purged and of messages deleted with their delivery metadata, and the creation times before which it did so.
+// @Tags retention
+// @Produce json
+// @Param tenantId query string false "Tenant ID, every tenant by default"
+// @Param limit query int false "Number of reports listed, 100 by default" maximum(1000)
+// @Success 200 {object} map[string]interface{} "Success response with the reports"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/retention/reports [get]
+func (h *RetentionHandler) ListRetentionReports(c *gin.Context) {
+	var req dtos.ListRetentionReportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid query parameters")
+		return
+	}
+
+	response, err := h.listReportsUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_RETENTION_REPORTS_FAILED", "Failed to list retention reports")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// ScheduleHandler handles the HTTP requests of the scheduled messages.
+type ScheduleHandler struct {
+	scheduleUseCase *usecases.ScheduleMessageUseCase
+}
+
+// NewScheduleHandler creates a new ScheduleHandler.
+func NewScheduleHandler(scheduleUseCase *usecases.ScheduleMessageUseCase) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleUseCase: scheduleUseCase,
+	}
+}
+
+// ScheduleMessage handles POST /api/v1/schedules
+// @Summary Schedule a message
+// @Description Send a message later, at an instant (sendAt) or at a local time of each recipient (localTime). A local time fans out into one send per time zone of the recipients, read from the recipients, else their user preferences, else defaultTimezone. Non-critical sends falling within a blackout of the calendar are deferred to its end
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param request body dtos.ScheduleMessageRequest true "Schedule message request"
+// @Success 201 {object} map[string]interface{} "Success response with the sends of the schedule"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 403 {object} httputil.Problem "Prod channel or template refused to a deployment below prod"
+// @Failure 404 {object} httputil.Problem "Template or channel not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/schedules [post]
+func (h *ScheduleHandler) ScheduleMessage(c *gin.Context) {
+	var req dtos.ScheduleMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.scheduleUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "SCHEDULE_MESSAGE_FAILED", "Failed to schedule message")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetSchedule handles GET /api/v1/schedules/{id}
+// @Summary Get a schedule
+// @Description Get the sends of a schedule with their time zone, time and status
+// @Tags schedules
+// @Produce json
+// @Param id path string true "Schedule batch ID"
+// @Success 200 {object} map[string]interface{} "Success response with the sends of the schedule"
+// @Failure 404 {object} httputil.Problem "Schedule not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/schedules/{id} [get]
+func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
+	response, err := h.scheduleUseCase.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_SCHEDULE_FAILED", "Failed to get schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// CancelSchedule handles DELETE /api/v1/schedules/{id}
+// @Summary Cancel a schedule
+// @Description Cancel the sends of a schedule not sent yet, keeping those already sent
+// @Tags schedules
+// @Produce json
+// @Param id path string true "Schedule batch ID"
+// @Success 200 {object} map[string]interface{} "Success response with the sends of the schedule"
+// @Failure 404 {object} httputil.Problem "Schedule not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/schedules/{id} [delete]
+func (h *ScheduleHandler) CancelSchedule(c *gin.Context) {
+	response, err := h.scheduleUseCase.Cancel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, err, "CANCEL_SCHEDULE_FAILED", "Failed to cancel schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
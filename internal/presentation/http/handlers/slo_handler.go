@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/slo/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// SLOHandler handles the HTTP requests of the delivery latency objectives.
+type SLOHandler struct {
+	getStatusUseCase *usecases.GetSLOStatusUseCase
+}
+
+// NewSLOHandler creates a new SLOHandler.
+func NewSLOHandler(getStatusUseCase *usecases.GetSLOStatusUseCase) *SLOHandler {
+	return &SLOHandler{
+		getStatusUseCase: getStatusUseCase,
+	}
+}
+
+// ListSLOs handles GET /api/v1/slo
+// @Summary List the SLOs
+// @Description Measure the delivery latency objectives over their windows from the creation and first successful send times of the messages: the share of the messages dispatched within the threshold, the error budget left, and the burn rates of the alerts. Percents are out of 100 and durations in seconds.
+// @Tags slo
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Success response with the objectives"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/slo [get]
+func (h *SLOHandler) ListSLOs(c *gin.Context) {
+	response, err := h.getStatusUseCase.Execute(c.Request.Context())
+	if err != nil {
+		httputil.RespondError(c, err, "GET_SLO_FAILED", "Failed to measure SLOs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// GetSLO handles GET /api/v1/slo/:name
+// @Summary Get an SLO
+// @Description Measure one delivery latency objective over its window.
+// @Tags slo
+// @Produce json
+// @Param name path string true "Objective name"
+// @Success 200 {object} map[string]interface{} "Success response with the objective"
+// @Failure 404 {object} httputil.Problem "Objective not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/slo/{name} [get]
+func (h *SLOHandler) GetSLO(c *gin.Context) {
+	response, err := h.getStatusUseCase.ExecuteObjective(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		httputil.RespondError(c, err, "GET_SLO_FAILED", "Failed to measure SLO")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/tag/dtos"
+	"notification/internal/application/tag/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// TagHandler handles HTTP requests for the tags of channels and templates.
+type TagHandler struct {
+	listTagsUC  *usecases.ListTagsUseCase
+	mergeTagsUC *usecases.MergeTagsUseCase
+}
+
+// NewTagHandler creates a new TagHandler.
+func NewTagHandler(listTagsUC *usecases.ListTagsUseCase, mergeTagsUC *usecases.MergeTagsUseCase) *TagHandler {
+	return &TagHandler{
+		listTagsUC:  listTagsUC,
+		mergeTagsUC: mergeTagsUC,
+	}
+}
+
+// ListTags handles GET /api/v1/tags
+// @Summary List tags
+// @Description List every tag carried by a channel or template with its usage counts, most used first
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Success response with the tags"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	response, err := h.listTagsUC.Execute(c.Request.Context())
+	if err != nil {
+		httputil.RespondError(c, err, "LIST_TAGS_FAILED", "Failed to list tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// RenameTag handles POST /api/v1/tags/rename
+// @Summary Rename a tag
+// @Description Rename a tag on every channel and template carrying it
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param request body dtos.RenameTagRequest true "Rename tag request"
+// @Success 200 {object} map[string]interface{} "Success response with the number of channels and templates updated"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/tags/rename [post]
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	var req dtos.RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.mergeTagsUC.Rename(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "RENAME_TAG_FAILED", "Failed to rename tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// MergeTags handles POST /api/v1/tags/merge
+// @Summary Merge tags
+// @Description Replace the source tags with the target tag on every channel and template carrying one of them
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param request body dtos.MergeTagsRequest true "Merge tags request"
+// @Success 200 {object} map[string]interface{} "Success response with the number of channels and templates updated"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/tags/merge [post]
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	var req dtos.MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.mergeTagsUC.Merge(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "MERGE_TAGS_FAILED", "Failed to merge tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
@@ -9,15 +9,19 @@ import (
 	"notification/internal/application/template/dtos"
 	"notification/internal/application/template/usecases"
 	"notification/internal/domain/shared"
+	"notification/internal/presentation/executor"
+	"notification/internal/presentation/http/httputil"
 )
 
-// TemplateHandler handles HTTP requests for templates.
+// TemplateHandler handles HTTP requests for templates. The template CRUD runs
+// on its executor, the other operations on the use cases.
 type TemplateHandler struct {
-	createTemplateUC *usecases.CreateTemplateUseCase
-	getTemplateUC    *usecases.GetTemplateUseCase
-	listTemplatesUC  *usecases.ListTemplatesUseCase
-	updateTemplateUC *usecases.UpdateTemplateUseCase
-	deleteTemplateUC *usecases.DeleteTemplateUseCase
+	executor           executor.TemplateExecutor
+	getTemplateUC      *usecases.GetTemplateUseCase
+	updateTemplateUC   *usecases.UpdateTemplateUseCase
+	templateUsageUC    *usecases.GetTemplateUsageUseCase
+	lintTemplateUC     *usecases.LintTemplateUseCase
+	validateTemplateUC *usecases.ValidateTemplateUseCase
 }
 
 // NewTemplateHandler creates a new TemplateHandler.
@@ -27,16 +31,30 @@ func NewTemplateHandler(
 	listTemplatesUC *usecases.ListTemplatesUseCase,
 	updateTemplateUC *usecases.UpdateTemplateUseCase,
 	deleteTemplateUC *usecases.DeleteTemplateUseCase,
+	templateUsageUC *usecases.GetTemplateUsageUseCase,
+	lintTemplateUC *usecases.LintTemplateUseCase,
+	validateTemplateUC *usecases.ValidateTemplateUseCase,
 ) *TemplateHandler {
 	return &TemplateHandler{
-		createTemplateUC: createTemplateUC,
-		getTemplateUC:    getTemplateUC,
-		listTemplatesUC:  listTemplatesUC,
-		updateTemplateUC: updateTemplateUC,
-		deleteTemplateUC: deleteTemplateUC,
+		executor: executor.NewUseCaseTemplateExecutor(
+			createTemplateUC, getTemplateUC, listTemplatesUC, updateTemplateUC, deleteTemplateUC,
+		),
+		getTemplateUC:      getTemplateUC,
+		updateTemplateUC:   updateTemplateUC,
+		templateUsageUC:    templateUsageUC,
+		lintTemplateUC:     lintTemplateUC,
+		validateTemplateUC: validateTemplateUC,
 	}
 }
 
+// WithExecutor returns a copy of the handler running the template CRUD on the
+// given executor, e.g. through CQRS for the v2 routes
+func (h *TemplateHandler) WithExecutor(templateExecutor executor.TemplateExecutor) *TemplateHandler {
+	handler := *h
+	handler.executor = templateExecutor
+	return &handler
+}
+
 // CreateTemplate handles POST /api/v1/templates
 // @Summary Create a new template
 // @Description Create a new message template for a specific channel type
@@ -45,35 +63,30 @@ func NewTemplateHandler(
 // @Produce json
 // @Param request body dtos.CreateTemplateRequest true "Create template request"
 // @Success 201 {object} map[string]interface{} "Template created successfully"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 409 {object} httputil.Problem "Template with the same name already exists"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /templates [post]
+// @Router /api/v1/templates [post]
+// @Router /api/v2/templates [post]
 func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
 	var req dtos.CreateTemplateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid request body: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid request body")
 		return
 	}
+	if req.Owner == "" {
+		req.Owner = authenticatedUser(c)
+	}
 
-	response, err := h.createTemplateUC.Execute(c.Request.Context(), &req)
+	response, err := h.executor.Create(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "CREATE_TEMPLATE_FAILED",
-				"message": "Failed to create template: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "CREATE_TEMPLATE_FAILED", "Failed to create template")
 		return
 	}
 
+	httputil.SetETag(c, templateETag(response, nil))
 	c.JSON(http.StatusCreated, gin.H{
 		"data":  response,
 		"error": nil,
@@ -87,28 +100,75 @@ func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Template ID"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,channelType"
+// @Param If-None-Match header string false "Entity tag of the template as last seen by the client"
 // @Success 200 {object} map[string]interface{} "Success response with template data"
-// @Failure 404 {object} map[string]interface{} "Template not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Success 304 "Not Modified - Template unchanged since the given entity tag"
+// @Failure 404 {object} httputil.Problem "Template not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /templates/{id} [get]
+// @Router /api/v1/templates/{id} [get]
+// @Router /api/v2/templates/{id} [get]
 func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.TemplateResponse{})
+	if !ok {
+		return
+	}
+
 	id := c.Param("id")
 
-	response, err := h.getTemplateUC.Execute(c.Request.Context(), id)
+	response, err := h.executor.Get(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "TEMPLATE_NOT_FOUND",
-				"message": "Template not found: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "TEMPLATE_NOT_FOUND", "Template not found")
+		return
+	}
+
+	if httputil.NotModified(c, templateETag(response, fields)) {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":  response,
+		"data":  fields.Select(response),
+		"error": nil,
+	})
+}
+
+// GetTemplateByName handles GET /api/v1/templates/by-name/{name}
+// @Summary Get a template by name
+// @Description Retrieve a specific template by its unique name, e.g. to import existing resources
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param name path string true "Template name"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,channelType"
+// @Param If-None-Match header string false "Entity tag of the template as last seen by the client"
+// @Success 200 {object} map[string]interface{} "Success response with template data"
+// @Success 304 "Not Modified - Template unchanged since the given entity tag"
+// @Failure 404 {object} httputil.Problem "Template not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/templates/by-name/{name} [get]
+func (h *TemplateHandler) GetTemplateByName(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.TemplateResponse{})
+	if !ok {
+		return
+	}
+
+	name := c.Param("name")
+
+	response, err := h.getTemplateUC.ExecuteByName(c.Request.Context(), name)
+	if err != nil {
+		httputil.RespondError(c, err, "TEMPLATE_NOT_FOUND", "Template not found")
+		return
+	}
+
+	if httputil.NotModified(c, templateETag(response, fields)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  fields.Select(response),
 		"error": nil,
 	})
 }
@@ -121,14 +181,24 @@ func (h *TemplateHandler) GetTemplate(c *gin.Context) {
 // @Produce json
 // @Param channelType query string false "Filter by channel type"
 // @Param tags query []string false "Filter by tags"
+// @Param owner query string false "Filter by owner"
+// @Param team query string false "Filter by team"
+// @Param environment query string false "Filter by environment (dev, staging or prod), including unscoped templates; all lists every environment. Defaults to the server environment"
 // @Param skipCount query int false "Number of records to skip for pagination" default(0)
 // @Param maxResultCount query int false "Maximum number of records to return per page (1-100)" default(20)
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,channelType"
 // @Success 200 {object} map[string]interface{} "Success response with templates list"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /templates [get]
+// @Router /api/v1/templates [get]
+// @Router /api/v2/templates [get]
 func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	fields, ok := httputil.ParseFields(c, dtos.TemplateResponse{})
+	if !ok {
+		return
+	}
+
 	var req dtos.ListTemplatesRequest
 
 	// Parse query parameters
@@ -143,6 +213,10 @@ func (h *TemplateHandler) ListTemplates(c *gin.Context) {
 		req.Tags = tags
 	}
 
+	req.Owner = c.Query("owner")
+	req.Team = c.Query("team")
+	req.Environment = c.Query("environment")
+
 	// Parse pagination
 	if skipCount := c.Query("skipCount"); skipCount != "" {
 		if sc, err := strconv.Atoi(skipCount); err == nil && sc >= 0 {
@@ -156,65 +230,101 @@ func (h *TemplateHandler) ListTemplates(c *gin.Context) {
 		}
 	}
 
-	response, err := h.listTemplatesUC.Execute(c.Request.Context(), &req)
+	response, err := h.executor.List(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "LIST_TEMPLATES_FAILED",
-				"message": "Failed to list templates: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "LIST_TEMPLATES_FAILED", "Failed to list templates")
 		return
 	}
 
+	c.JSON(http.StatusOK, gin.H{
+		"data":  fields.SelectItems(response),
+		"error": nil,
+	})
+}
+
+// ReplaceTemplate handles PUT /api/v1/templates/{id}
+// @Summary Replace a template
+// @Description Fully replace an existing template by its ID. Omitted optional fields are cleared. Supports If-Match for optimistic concurrency.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param If-Match header string false "Entity tag of the template as last seen by the client"
+// @Param request body dtos.ReplaceTemplateRequest true "Replace template request"
+// @Success 200 {object} map[string]interface{} "Template replaced successfully"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Template not found"
+// @Failure 412 {object} httputil.Problem "Precondition failed - template was modified"
+// @Failure 409 {object} httputil.Problem "Template with the same name already exists"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/templates/{id} [put]
+func (h *TemplateHandler) ReplaceTemplate(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dtos.ReplaceTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	if !h.checkIfMatch(c, id) {
+		return
+	}
+
+	response, err := h.updateTemplateUC.Replace(c.Request.Context(), id, &req)
+	if err != nil {
+		httputil.RespondError(c, err, "UPDATE_TEMPLATE_FAILED", "Failed to replace template")
+		return
+	}
+
+	httputil.SetETag(c, templateETag(response, nil))
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
 	})
 }
 
-// UpdateTemplate handles PUT /api/v1/templates/{id}
-// @Summary Update a template
-// @Description Update an existing template by its ID
+// UpdateTemplate handles PATCH /api/v1/templates/{id} and PUT /api/v2/templates/{id}
+// @Summary Partially update a template
+// @Description Update only the provided fields of an existing template. Supports If-Match for optimistic concurrency.
 // @Tags templates
 // @Accept json
 // @Produce json
 // @Param id path string true "Template ID"
+// @Param If-Match header string false "Entity tag of the template as last seen by the client"
 // @Param request body dtos.UpdateTemplateRequest true "Update template request"
 // @Success 200 {object} map[string]interface{} "Template updated successfully"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 404 {object} map[string]interface{} "Template not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Template not found"
+// @Failure 412 {object} httputil.Problem "Precondition failed - template was modified"
+// @Failure 409 {object} httputil.Problem "Template with the same name already exists"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /templates/{id} [put]
+// @Router /api/v1/templates/{id} [patch]
+// @Router /api/v2/templates/{id} [put]
 func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 	id := c.Param("id")
 
 	var req dtos.UpdateTemplateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "INVALID_REQUEST",
-				"message": "Invalid request body: " + err.Error(),
-			},
-		})
+		httputil.RespondBindError(c, err, "Invalid request body")
 		return
 	}
 
-	response, err := h.updateTemplateUC.Execute(c.Request.Context(), id, &req)
+	if !h.checkIfMatch(c, id) {
+		return
+	}
+
+	response, err := h.executor.Update(c.Request.Context(), id, &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "UPDATE_TEMPLATE_FAILED",
-				"message": "Failed to update template: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "UPDATE_TEMPLATE_FAILED", "Failed to update template")
 		return
 	}
 
+	httputil.SetETag(c, templateETag(response, nil))
 	c.JSON(http.StatusOK, gin.H{
 		"data":  response,
 		"error": nil,
@@ -223,28 +333,39 @@ func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 
 // DeleteTemplate handles DELETE /api/v1/templates/{id}
 // @Summary Delete a template
-// @Description Delete an existing template by its ID
+// @Description Delete an existing template by its ID. Templates still referenced by channels are only deleted with force=true, which detaches the channels, or with reassignTo, which moves them to another template of the same channel type.
 // @Tags templates
 // @Accept json
 // @Produce json
 // @Param id path string true "Template ID"
+// @Param force query bool false "Detach referencing channels from the template and delete it" default(false)
+// @Param reassignTo query string false "Move referencing channels to this template before deleting"
+// @Param If-Match header string false "Entity tag of the template as last seen by the client"
 // @Success 200 {object} map[string]interface{} "Template deleted successfully"
-// @Failure 404 {object} map[string]interface{} "Template not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 404 {object} httputil.Problem "Template not found"
+// @Failure 409 {object} httputil.Problem "Template is still referenced by channels"
+// @Failure 412 {object} httputil.Problem "Precondition failed - template was modified"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
 // @Security ApiKeyAuth
-// @Router /templates/{id} [delete]
+// @Router /api/v1/templates/{id} [delete]
+// @Router /api/v2/templates/{id} [delete]
 func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.deleteTemplateUC.Execute(c.Request.Context(), id)
+	if !h.checkIfMatch(c, id) {
+		return
+	}
+
+	force, _ := strconv.ParseBool(c.Query("force"))
+	req := &dtos.DeleteTemplateRequest{
+		Force:      force,
+		ReassignTo: c.Query("reassignTo"),
+	}
+
+	err := h.executor.Delete(c.Request.Context(), id, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"data":  nil,
-			"error": map[string]interface{}{
-				"code":    "DELETE_TEMPLATE_FAILED",
-				"message": "Failed to delete template: " + err.Error(),
-			},
-		})
+		httputil.RespondError(c, err, "DELETE_TEMPLATE_FAILED", "Failed to delete template")
 		return
 	}
 
@@ -252,4 +373,134 @@ func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
 		"data":  map[string]interface{}{"deleted": true},
 		"error": nil,
 	})
-}
\ No newline at end of file
+}
+// GetTemplateUsage handles GET /api/v1/templates/{id}/usage
+// @Summary Get template usage
+// @Description List the channels referencing a template with their message counts over a recent window
+// @Tags templates
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param days query int false "Window for recent message counts in days (1-365)" default(30)
+// @Success 200 {object} map[string]interface{} "Template usage"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 404 {object} httputil.Problem "Template not found"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/templates/{id}/usage [get]
+func (h *TemplateHandler) GetTemplateUsage(c *gin.Context) {
+	id := c.Param("id")
+
+	days := 0
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil {
+			httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid days: must be an integer")
+			return
+		}
+		days = parsed
+	}
+
+	response, err := h.templateUsageUC.Execute(c.Request.Context(), id, days)
+	if err != nil {
+		httputil.RespondError(c, err, "GET_TEMPLATE_USAGE_FAILED", "Failed to get template usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// LintTemplate handles POST /api/v1/templates/lint
+// @Summary Lint template variables
+// @Description Report the variables used in a template's subject or content that are not declared in its variables
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param request body dtos.LintTemplateRequest true "Lint template request"
+// @Success 200 {object} map[string]interface{} "Success response with lint results"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Security ApiKeyAuth
+// @Router /api/v1/templates/lint [post]
+func (h *TemplateHandler) LintTemplate(c *gin.Context) {
+	var req dtos.LintTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.lintTemplateUC.Execute(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "LINT_TEMPLATE_FAILED", "Failed to lint template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// ValidateTemplate handles POST /api/v1/templates/validate
+// @Summary Validate a template
+// @Description Check a template before saving it: placeholder syntax errors with line and column, undeclared variables and channel type constraints
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param request body dtos.ValidateTemplateRequest true "Validate template request"
+// @Success 200 {object} map[string]interface{} "Success response with validation results"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 422 {object} httputil.Problem "Validation failed"
+// @Security ApiKeyAuth
+// @Router /api/v1/templates/validate [post]
+func (h *TemplateHandler) ValidateTemplate(c *gin.Context) {
+	var req dtos.ValidateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondBindError(c, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.validateTemplateUC.Execute(c.Request.Context(), &req)
+	if err != nil {
+		httputil.RespondError(c, err, "VALIDATE_TEMPLATE_FAILED", "Failed to validate template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  response,
+		"error": nil,
+	})
+}
+
+// checkIfMatch evaluates the If-Match precondition against the current template.
+// It writes the error response and returns false when the request must not proceed.
+// When the precondition holds, the request context makes the write conditional
+// on the version it was checked against.
+func (h *TemplateHandler) checkIfMatch(c *gin.Context, id string) bool {
+	if !httputil.HasIfMatch(c) {
+		return true
+	}
+
+	current, err := h.executor.Get(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, err, "TEMPLATE_NOT_FOUND", "Template not found")
+		return false
+	}
+
+	if !httputil.IfMatch(c, templateETag(current, nil)) {
+		httputil.RespondProblem(c, http.StatusPreconditionFailed, "PRECONDITION_FAILED", "Template has been modified since it was last retrieved")
+		return false
+	}
+
+	c.Request = c.Request.WithContext(shared.WithExpectedVersion(c.Request.Context(), current.ID, int64(current.Version)))
+	return true
+}
+
+// templateETag derives the entity tag of a template representation from its
+// ID, version and selected fields; writes compare the full representation's.
+func templateETag(response *dtos.TemplateResponse, fields httputil.FieldSet) string {
+	return httputil.ComputeETag(response.ID, response.Version, fields)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/http/httputil"
+)
+
+// acknowledgedTwiML is the answer to a key press, read out before the call ends
+const acknowledgedTwiML = `<?xml version="1.0" encoding="UTF-8"?><Response><Say>Thank you, the notification has been acknowledged.</Say></Response>`
+
+// VoiceHandler handles the callbacks of the voice provider.
+type VoiceHandler struct {
+	acknowledgeCallUC *usecases.AcknowledgeCallUseCase
+}
+
+// NewVoiceHandler creates a new VoiceHandler.
+func NewVoiceHandler(acknowledgeCallUC *usecases.AcknowledgeCallUseCase) *VoiceHandler {
+	return &VoiceHandler{
+		acknowledgeCallUC: acknowledgeCallUC,
+	}
+}
+
+// AcknowledgeCall handles POST /api/v1/public/voice/channels/{id}/ack
+// @Summary Acknowledge a voice call
+// @Description Record the key a recipient pressed during a call of a voice channel. Called by Twilio, which signs the request with the auth token of the channel.
+// @Tags voice
+// @Accept x-www-form-urlencoded
+// @Produce xml
+// @Param id path string true "Channel ID"
+// @Param X-Twilio-Signature header string true "Twilio request signature"
+// @Param CallSid formData string true "Call SID"
+// @Param Digits formData string true "Keys pressed"
+// @Success 200 {string} string "TwiML thanking the recipient"
+// @Failure 400 {object} httputil.Problem "Bad request"
+// @Failure 403 {object} httputil.Problem "Invalid signature"
+// @Failure 404 {object} httputil.Problem "Voice channel not found"
+// @Failure 500 {object} httputil.Problem "Internal server error"
+// @Router /api/v1/public/voice/channels/{id}/ack [post]
+func (h *VoiceHandler) AcknowledgeCall(c *gin.Context) {
+	if err := c.Request.ParseForm(); err != nil {
+		httputil.RespondBindError(c, err, "Invalid form body")
+		return
+	}
+
+	err := h.acknowledgeCallUC.Execute(c.Request.Context(), c.Param("id"), c.Request.PostForm, c.GetHeader("X-Twilio-Signature"))
+	if err != nil {
+		httputil.RespondError(c, err, "ACKNOWLEDGE_CALL_FAILED", "Failed to acknowledge call")
+		return
+	}
+
+	c.Data(http.StatusOK, "text/xml; charset=utf-8", []byte(acknowledgedTwiML))
+}
@@ -0,0 +1,87 @@
+package httputil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl is sent with conditional GET responses. Clients may keep
+// the response but must revalidate it with If-None-Match before reuse, and
+// shared caches must not store it since it is specific to the caller.
+const CacheControl = "private, no-cache"
+
+// ComputeETag builds a strong entity tag from the given resource identity parts.
+// Callers pass values that change whenever the representation changes
+// (e.g. resource ID and last update timestamp or version number).
+func ComputeETag(parts ...interface{}) string {
+	h := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%v|", part)
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`
+}
+
+// SetETag writes the ETag header to the response.
+func SetETag(c *gin.Context, etag string) {
+	c.Header("ETag", etag)
+}
+
+// IfMatch reports whether the request's If-Match precondition is satisfied by
+// the current entity tag. A missing header always satisfies the precondition.
+func IfMatch(c *gin.Context, currentETag string) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return true
+	}
+	return etagListContains(header, currentETag, false)
+}
+
+// NotModified sets the ETag and Cache-Control headers of a GET response and
+// answers 304 Not Modified when the request's If-None-Match header lists the
+// current entity tag. It returns true when the response was written.
+func NotModified(c *gin.Context, currentETag string) bool {
+	SetETag(c, currentETag)
+	c.Header("Cache-Control", CacheControl)
+
+	header := strings.TrimSpace(c.GetHeader("If-None-Match"))
+	if header == "" {
+		return false
+	}
+	if header != "*" && !etagListContains(header, currentETag, true) {
+		return false
+	}
+
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// HasIfMatch reports whether the request carries an If-Match header.
+func HasIfMatch(c *gin.Context) bool {
+	return strings.TrimSpace(c.GetHeader("If-Match")) != ""
+}
+
+// etagListContains checks a comma separated list of entity tags for the given tag.
+// The weak comparison of If-None-Match compares weak validators (W/"...") by
+// their opaque value; the strong comparison of If-Match never matches one.
+func etagListContains(header, etag string, weak bool) bool {
+	if weak {
+		etag = strings.TrimPrefix(etag, "W/")
+	} else if strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testETag = `"abc"`
+
+func newConditionalContext(header, value string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest("GET", "/resources/1", nil)
+	if value != "" {
+		c.Request.Header.Set(header, value)
+	}
+	return c, recorder
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		current string
+		want    bool
+	}{
+		{"missing header", "", testETag, true},
+		{"any", "*", testETag, true},
+		{"same tag", `"abc"`, testETag, true},
+		{"other tag", `"def"`, testETag, false},
+		{"tag in a list", `"def", "abc"`, testETag, true},
+		{"tag not in a list", `"def","ghi"`, testETag, false},
+		{"weak request tag", `W/"abc"`, testETag, false},
+		{"weak current tag", `"abc"`, `W/"abc"`, false},
+		{"both weak", `W/"abc"`, `W/"abc"`, false},
+		{"unquoted tag", `abc`, testETag, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newConditionalContext("If-Match", tt.header)
+			assert.Equal(t, tt.want, IfMatch(c, tt.current))
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		current string
+		want    bool
+	}{
+		{"missing header", "", testETag, false},
+		{"any", "*", testETag, true},
+		{"same tag", `"abc"`, testETag, true},
+		{"other tag", `"def"`, testETag, false},
+		{"tag in a list", `"def", "abc"`, testETag, true},
+		{"weak request tag", `W/"abc"`, testETag, true},
+		{"weak current tag", `"abc"`, `W/"abc"`, true},
+		{"other weak tag", `W/"def"`, testETag, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, recorder := newConditionalContext("If-None-Match", tt.header)
+			assert.Equal(t, tt.want, NotModified(c, tt.current))
+			assert.Equal(t, tt.current, recorder.Header().Get("ETag"))
+			assert.Equal(t, CacheControl, recorder.Header().Get("Cache-Control"))
+			if tt.want {
+				assert.Equal(t, http.StatusNotModified, c.Writer.Status())
+			}
+		})
+	}
+}
+
+func TestHasIfMatch(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"  ", false},
+		{"*", true},
+		{`"abc"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			c, _ := newConditionalContext("If-Match", tt.header)
+			assert.Equal(t, tt.want, HasIfMatch(c))
+		})
+	}
+}
+
+func TestComputeETag_IsAQuotedStrongTag(t *testing.T) {
+	etag := ComputeETag("1", int64(3))
+	assert.Len(t, etag, 34)
+	assert.Equal(t, byte('"'), etag[0])
+	assert.Equal(t, byte('"'), etag[len(etag)-1])
+	assert.Equal(t, etag, ComputeETag("1", int64(3)))
+	assert.NotEqual(t, etag, ComputeETag("13"))
+}
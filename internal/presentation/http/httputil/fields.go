@@ -0,0 +1,150 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldSet is the sparse fieldset a client selected with the fields query
+// parameter. An empty set selects every field.
+type FieldSet map[string]bool
+
+// ParseFields reads the comma separated fields query parameter. The names
+// must be JSON fields of resource, a value of the returned representation;
+// an unknown name is answered with a 400 problem and ok is false.
+func ParseFields(c *gin.Context, resource interface{}) (fields FieldSet, ok bool) {
+	known := jsonFieldNames(reflect.TypeOf(resource))
+
+	fields = FieldSet{}
+	for _, value := range c.QueryArray("fields") {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !known[name] {
+				RespondProblem(c, http.StatusBadRequest, "INVALID_FIELDS",
+					"Unknown field "+name+" in fields, expected one of "+strings.Join(sortedNames(known), ", "))
+				return nil, false
+			}
+			fields[name] = true
+		}
+	}
+	return fields, true
+}
+
+// String returns the selected names sorted and comma separated, so that the
+// sets selecting the same fields read the same, e.g. in an entity tag
+func (f FieldSet) String() string {
+	return strings.Join(sortedNames(f), ",")
+}
+
+// Select returns the resource with only the selected fields, or the
+// resource itself when the set is empty
+func (f FieldSet) Select(resource interface{}) interface{} {
+	if len(f) == 0 {
+		return resource
+	}
+
+	var object map[string]json.RawMessage
+	if !decodeAs(resource, &object) {
+		return resource
+	}
+	return f.filter(object)
+}
+
+// SelectItems returns a list response whose items carry only the selected
+// fields, keeping the paging fields, or the response itself when the set is
+// empty
+func (f FieldSet) SelectItems(list interface{}) interface{} {
+	if len(f) == 0 {
+		return list
+	}
+
+	var object map[string]json.RawMessage
+	if !decodeAs(list, &object) {
+		return list
+	}
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(object["items"], &items); err != nil {
+		return list
+	}
+
+	selected := make([]map[string]json.RawMessage, len(items))
+	for i, item := range items {
+		selected[i] = f.filter(item)
+	}
+	result := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		result[key] = value
+	}
+	result["items"] = selected
+	return result
+}
+
+// filter drops the fields of an object that are not selected
+func (f FieldSet) filter(object map[string]json.RawMessage) map[string]json.RawMessage {
+	selected := make(map[string]json.RawMessage, len(f))
+	for name := range f {
+		if value, ok := object[name]; ok {
+			selected[name] = value
+		}
+	}
+	return selected
+}
+
+// decodeAs round-trips v through JSON into out, so that the selection sees
+// the fields as they are serialized
+func decodeAs(v interface{}, out interface{}) bool {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, out) == nil
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type,
+// including the fields of embedded structs
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	names := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// sortedNames returns the names of a set in order
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,56 @@
+package httputil
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsResource struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+func parseFields(t *testing.T, query string) FieldSet {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/resources/1"+query, nil)
+
+	fields, ok := ParseFields(c, fieldsResource{})
+	require.True(t, ok)
+	return fields
+}
+
+func TestFieldSet_StringIsNormalized(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"?fields=name", "name"},
+		{"?fields=name,id", "id,name"},
+		{"?fields=id,name", "id,name"},
+		{"?fields=+name+,,id,name", "id,name"},
+		{"?fields=name&fields=id", "id,name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseFields(t, tt.query).String())
+		})
+	}
+}
+
+func TestComputeETag_DependsOnTheSelectedFields(t *testing.T) {
+	full := ComputeETag("1", int64(3), FieldSet(nil))
+	assert.Equal(t, full, ComputeETag("1", int64(3), parseFields(t, "")))
+	assert.NotEqual(t, full, ComputeETag("1", int64(3), parseFields(t, "?fields=name")))
+	assert.Equal(t,
+		ComputeETag("1", int64(3), parseFields(t, "?fields=name,id")),
+		ComputeETag("1", int64(3), parseFields(t, "?fields=id,name")))
+	assert.NotEqual(t, full, ComputeETag("1", int64(4), FieldSet(nil)))
+}
@@ -0,0 +1,147 @@
+package httputil
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+)
+
+// ProblemContentType is the media type for RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes problem type URIs, which are derived from error codes.
+const problemTypeBase = "urn:notification:problem:"
+
+// FieldError describes a problem with a single request field.
+type FieldError struct {
+	Field   string `json:"field" example:"channelName"`
+	Message string `json:"message" example:"channel name is required"`
+}
+
+// Problem is an RFC 7807 problem details object.
+// Code and Errors are extension members carrying the machine-readable
+// error code and per-field validation failures.
+type Problem struct {
+	Type     string       `json:"type" example:"urn:notification:problem:channel-not-found"`
+	Title    string       `json:"title" example:"Not Found"`
+	Status   int          `json:"status" example:"404"`
+	Detail   string       `json:"detail,omitempty" example:"channel not found"`
+	Instance string       `json:"instance,omitempty" example:"/api/v1/channels/123"`
+	Code     string       `json:"code,omitempty" example:"CHANNEL_NOT_FOUND"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem creates a problem for the given status and machine-readable code.
+func NewProblem(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   ProblemType(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// ProblemType derives the problem type URI from an error code.
+func ProblemType(code string) string {
+	if code == "" {
+		return "about:blank"
+	}
+	return problemTypeBase + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
+
+// WithFieldErrors attaches per-field validation failures to the problem.
+func (p *Problem) WithFieldErrors(fieldErrors []FieldError) *Problem {
+	p.Errors = fieldErrors
+	return p
+}
+
+// WriteProblem writes the problem as an application/problem+json response and aborts the chain.
+func WriteProblem(c *gin.Context, problem *Problem) {
+	if problem.Instance == "" && c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// RespondProblem writes a problem response built from a status, code and detail.
+func RespondProblem(c *gin.Context, status int, code, detail string) {
+	WriteProblem(c, NewProblem(status, code, detail))
+}
+
+// RespondError writes a problem response for an error returned by a use case or the CQRS bus.
+// The status and code come from the error classification; fallbackCode is used for
+// unclassified errors. The summary, when given, prefixes the detail. An error telling
+// when to retry sets the Retry-After header.
+func RespondError(c *gin.Context, err error, fallbackCode, summary string) {
+	detail := err.Error()
+	if summary != "" {
+		detail = summary + ": " + detail
+	}
+
+	if retryAfter := shared.RetryAfterOf(err); retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+
+	problem := NewProblem(StatusFromError(err), ErrorCode(err, fallbackCode), detail)
+	problem.WithFieldErrors(fieldErrorsFrom(err))
+	WriteProblem(c, problem)
+}
+
+// RespondBindError writes a 400 problem response for a request that could not be bound.
+func RespondBindError(c *gin.Context, err error, summary string) {
+	detail := err.Error()
+	if summary != "" {
+		detail = summary + ": " + detail
+	}
+
+	problem := NewProblem(http.StatusBadRequest, "INVALID_REQUEST", detail)
+	problem.WithFieldErrors(fieldErrorsFrom(err))
+	WriteProblem(c, problem)
+}
+
+// fieldErrorsFrom extracts per-field failures from binding and domain validation errors.
+func fieldErrorsFrom(err error) []FieldError {
+	var bindErrs validator.ValidationErrors
+	if errors.As(err, &bindErrs) {
+		fieldErrors := make([]FieldError, 0, len(bindErrs))
+		for _, fe := range bindErrs {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   lowerFirst(fe.Field()),
+				Message: "failed on the '" + fe.Tag() + "' rule",
+			})
+		}
+		return fieldErrors
+	}
+
+	var domainErrs services.ValidationErrors
+	if errors.As(err, &domainErrs) {
+		fieldErrors := make([]FieldError, 0, len(domainErrs))
+		for _, ve := range domainErrs {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   ve.Field,
+				Message: ve.Message,
+			})
+		}
+		return fieldErrors
+	}
+
+	return nil
+}
+
+// lowerFirst converts a Go field name to its JSON-style counterpart.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,36 @@
+package httputil
+
+import (
+	"net/http"
+
+	"notification/internal/domain/shared"
+)
+
+// StatusFromError maps a classified domain error to its HTTP status code.
+// Unclassified errors are reported as 500 Internal Server Error.
+func StatusFromError(err error) int {
+	switch shared.ErrorKindOf(err) {
+	case shared.ErrorKindNotFound:
+		return http.StatusNotFound
+	case shared.ErrorKindConflict:
+		return http.StatusConflict
+	case shared.ErrorKindValidation:
+		return http.StatusUnprocessableEntity
+	case shared.ErrorKindUnavailable:
+		return http.StatusServiceUnavailable
+	case shared.ErrorKindQuotaExceeded:
+		return http.StatusTooManyRequests
+	case shared.ErrorKindForbidden:
+		return http.StatusForbidden
+	case shared.ErrorKindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ErrorCode returns the machine-readable code for an error, falling back to
+// the given operation-specific code for unclassified errors.
+func ErrorCode(err error, fallback string) string {
+	return shared.ErrorCodeOf(err, fallback)
+}
@@ -0,0 +1,39 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"notification/internal/domain/shared"
+)
+
+func TestStatusFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		want     int
+		wantCode string
+	}{
+		{"not found", shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found"), http.StatusNotFound, "CHANNEL_NOT_FOUND"},
+		{"conflict", shared.NewConflictError("NAME_CONFLICT", "name taken"), http.StatusConflict, "NAME_CONFLICT"},
+		{"validation", shared.NewValidationError("INVALID_REQUEST", errors.New("name is required")), http.StatusUnprocessableEntity, "INVALID_REQUEST"},
+		{"unavailable", shared.NewUnavailableError("LEGACY_SYSTEM_UNAVAILABLE", "legacy system down", nil), http.StatusServiceUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+		{"quota exceeded", shared.NewDomainError(shared.ErrorKindQuotaExceeded, "", "over quota", nil), http.StatusTooManyRequests, "QUOTA_EXCEEDED"},
+		{"forbidden", shared.NewForbiddenError("READ_ONLY", "read-only"), http.StatusForbidden, "READ_ONLY"},
+		{"precondition failed", shared.NewPreconditionFailedError("PRECONDITION_FAILED", "modified"), http.StatusPreconditionFailed, "PRECONDITION_FAILED"},
+		{"legacy rejection", shared.WrapLegacyError("failed to send", shared.NewLegacyStatusError(400, "")), http.StatusUnprocessableEntity, "LEGACY_REQUEST_REJECTED"},
+		{"legacy outage", shared.WrapLegacyError("failed to send", shared.NewLegacyStatusError(502, "")), http.StatusServiceUnavailable, "LEGACY_SYSTEM_UNAVAILABLE"},
+		{"wrapped", fmt.Errorf("failed to update: %w", shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")), http.StatusNotFound, "CHANNEL_NOT_FOUND"},
+		{"unclassified", errors.New("boom"), http.StatusInternalServerError, "FALLBACK"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StatusFromError(tt.err))
+			assert.Equal(t, tt.wantCode, ErrorCode(tt.err, "FALLBACK"))
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/http/httputil"
 	"notification/pkg/logger"
 )
 
@@ -70,12 +72,7 @@ func (a *AuthMiddleware) Handler() gin.HandlerFunc {
 				zap.String("client_ip", c.ClientIP()),
 				zap.Error(err))
 
-			c.JSON(http.StatusUnauthorized, ErrorResponse{
-				Error:   "Authentication failed",
-				Details: err.Error(),
-				Code:    "AUTH_FAILED",
-			})
-			c.Abort()
+			httputil.RespondProblem(c, http.StatusUnauthorized, "AUTH_FAILED", "Authentication failed: " + err.Error())
 			return
 		}
 
@@ -85,17 +82,14 @@ func (a *AuthMiddleware) Handler() gin.HandlerFunc {
 				zap.String("method", c.Request.Method),
 				zap.String("client_ip", c.ClientIP()))
 
-			c.JSON(http.StatusUnauthorized, ErrorResponse{
-				Error: "Invalid credentials",
-				Code:  "INVALID_CREDENTIALS",
-			})
-			c.Abort()
+			httputil.RespondProblem(c, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid credentials")
 			return
 		}
 
 		// Set user context
 		c.Set("user_id", userID)
 		c.Set("authenticated", true)
+		c.Request = c.Request.WithContext(shared.WithActor(c.Request.Context(), userID))
 
 		logger.Debug("Authentication successful",
 			zap.String("user_id", userID),
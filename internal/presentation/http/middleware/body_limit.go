@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/httputil"
+	"notification/pkg/jsonutil"
+)
+
+// BodyLimitConfig holds configuration for request body limits
+type BodyLimitConfig struct {
+	// MaxBodyBytes is the largest accepted request body
+	MaxBodyBytes int64
+	// MaxJSONDepth is the deepest accepted nesting of JSON objects and arrays
+	MaxJSONDepth int
+	// DisallowUnknownFields rejects JSON bodies with fields the target DTO does not declare
+	DisallowUnknownFields bool
+}
+
+// DefaultBodyLimitConfig returns the default body limit configuration
+func DefaultBodyLimitConfig() *BodyLimitConfig {
+	return &BodyLimitConfig{
+		MaxBodyBytes: 1 << 20, // 1 MiB
+		MaxJSONDepth: 32,
+	}
+}
+
+// BodyLimit is a middleware that rejects oversized request bodies and JSON
+// bodies that nest too deeply, before any handler binds them.
+func BodyLimit(config *BodyLimitConfig) gin.HandlerFunc {
+	if config == nil {
+		config = DefaultBodyLimitConfig()
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > config.MaxBodyBytes {
+			rejectOversizedBody(c, config.MaxBodyBytes)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, config.MaxBodyBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				rejectOversizedBody(c, config.MaxBodyBytes)
+				return
+			}
+			httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "Failed to read request body")
+			return
+		}
+
+		if isJSONRequest(c) {
+			if err := jsonutil.CheckDepth(body, config.MaxJSONDepth); err != nil {
+				httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+				return
+			}
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Next()
+	}
+}
+
+// rejectOversizedBody writes a 413 problem response
+func rejectOversizedBody(c *gin.Context, maxBodyBytes int64) {
+	httputil.RespondProblem(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE",
+		fmt.Sprintf("request body exceeds the limit of %d bytes", maxBodyBytes))
+}
+
+// isJSONRequest reports whether the request body is declared as JSON
+func isJSONRequest(c *gin.Context) bool {
+	contentType := c.ContentType()
+	return contentType == gin.MIMEJSON || strings.HasSuffix(contentType, "+json")
+}
@@ -250,8 +250,11 @@ func DefaultCORSConfig() *CORSConfig {
 			"X-CSRF-Token",
 			"X-Request-ID",
 			"X-API-Key",
+			"If-Match",
+			"If-None-Match",
 		},
 		ExposedHeaders: []string{
+			"ETag",
 			"X-Request-ID",
 			"X-RateLimit-Limit",
 			"X-RateLimit-Remaining",
@@ -272,6 +275,7 @@ func RestrictiveCORSConfig(allowedOrigins []string) *CORSConfig {
 			"GET",
 			"POST",
 			"PUT",
+			"PATCH",
 			"DELETE",
 			"OPTIONS",
 		},
@@ -333,6 +337,7 @@ func ProductionCORSConfig(allowedOrigins []string) *CORSConfig {
 			"GET",
 			"POST",
 			"PUT",
+			"PATCH",
 			"DELETE",
 			"OPTIONS",
 		},
@@ -342,8 +347,11 @@ func ProductionCORSConfig(allowedOrigins []string) *CORSConfig {
 			"Content-Type",
 			"X-Request-ID",
 			"X-API-Key",
+			"If-Match",
+			"If-None-Match",
 		},
 		ExposedHeaders: []string{
+			"ETag",
 			"X-Request-ID",
 			"X-RateLimit-Limit",
 			"X-RateLimit-Remaining",
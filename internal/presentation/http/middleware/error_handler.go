@@ -1,53 +1,63 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
-)
+	"go.uber.org/zap"
 
-// ErrorResponse represents a standardized error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
-	Code    string `json:"code,omitempty"`
-}
+	"notification/internal/presentation/http/httputil"
+	"notification/pkg/logger"
+)
 
-// ErrorHandler is a middleware that handles panics and errors
+// ErrorHandler is a middleware that recovers from panics in later handlers.
+// The panic is logged with its stack trace and the client receives a generic
+// problem+json 500 that does not leak the panic value.
 func ErrorHandler() gin.HandlerFunc {
-	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		if err, ok := recovered.(string); ok {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "Internal server error",
-				Details: err,
-				Code:    "INTERNAL_ERROR",
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error: "Internal server error",
-				Code:  "INTERNAL_ERROR",
-			})
-		}
-		c.Abort()
-	})
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// http.ErrAbortHandler is the sanctioned way to abort a response; let net/http handle it
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+
+			logger.Error("Recovered from panic in HTTP handler",
+				zap.String("request_id", c.GetString(RequestIDKey)),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("panic", fmt.Sprint(recovered)),
+				zap.ByteString("stack", debug.Stack()),
+			)
+
+			if c.Writer.Written() {
+				// The response is already on its way; all we can do is stop the chain
+				c.Abort()
+				return
+			}
+			httputil.RespondProblem(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		}()
+
+		c.Next()
+	}
 }
 
 // NotFoundHandler handles 404 errors
 func NotFoundHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error: "Resource not found",
-			Code:  "NOT_FOUND",
-		})
+		httputil.RespondProblem(c, http.StatusNotFound, "NOT_FOUND", "Resource not found")
 	}
 }
 
 // MethodNotAllowedHandler handles 405 errors
 func MethodNotAllowedHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.JSON(http.StatusMethodNotAllowed, ErrorResponse{
-			Error: "Method not allowed",
-			Code:  "METHOD_NOT_ALLOWED",
-		})
+		httputil.RespondProblem(c, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
 	}
-}
\ No newline at end of file
+}
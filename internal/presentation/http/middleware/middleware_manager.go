@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 // MiddlewareConfig holds configuration for all middleware
@@ -26,6 +27,9 @@ type MiddlewareConfig struct {
 	
 	// Basic auth configuration (for admin endpoints)
 	BasicAuth *BasicAuthConfig
+
+	// Request body limits (defaults apply when nil)
+	BodyLimit *BodyLimitConfig
 	
 	// Enable/disable specific middleware
 	EnableAuth      bool
@@ -52,10 +56,18 @@ func NewMiddlewareManager(config *MiddlewareConfig) *MiddlewareManager {
 // SetupMiddleware sets up all middleware for the given router
 func (mm *MiddlewareManager) SetupMiddleware(router *gin.Engine) {
 	// Core middleware (always enabled)
-	router.Use(RequestLogger())
 	router.Use(RequestID())
+	router.Use(RequestLogger())
 	router.Use(ErrorHandler())
 
+	// Payload limits (always enabled)
+	bodyLimit := mm.config.BodyLimit
+	if bodyLimit == nil {
+		bodyLimit = DefaultBodyLimitConfig()
+	}
+	binding.EnableDecoderDisallowUnknownFields = bodyLimit.DisallowUnknownFields
+	router.Use(BodyLimit(bodyLimit))
+
 	// Security middleware
 	if mm.config.EnableSecurity {
 		if mm.config.Security != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"notification/internal/presentation/http/httputil"
 	"notification/pkg/logger"
 )
 
@@ -102,12 +103,7 @@ func (rl *RateLimiter) Handler() gin.HandlerFunc {
 				zap.String("client_ip", c.ClientIP()),
 				zap.Int("remaining", remaining))
 
-			c.JSON(http.StatusTooManyRequests, ErrorResponse{
-				Error:   "Rate limit exceeded",
-				Details: fmt.Sprintf("Too many requests. Try again in %v", time.Until(resetTime)),
-				Code:    "RATE_LIMIT_EXCEEDED",
-			})
-			c.Abort()
+			httputil.RespondProblem(c, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "Rate limit exceeded: " + fmt.Sprintf("Too many requests. Try again in %v", time.Until(resetTime)))
 			return
 		}
 
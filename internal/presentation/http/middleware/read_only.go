@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/http/httputil"
+)
+
+// ReadOnly is a middleware that rejects commands with 503 READ_ONLY while the
+// instance is in read-only mode. GET, HEAD and OPTIONS requests always pass,
+// as do the routes in queryRoutes: POST routes that only compute a result,
+// matched against the route pattern.
+func ReadOnly(mode *shared.ReadOnlyMode, queryRoutes ...string) gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(queryRoutes))
+	for _, route := range queryRoutes {
+		allowed[route] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		err := mode.Check()
+		if err == nil || isQueryMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if _, ok := allowed[c.FullPath()]; ok {
+			c.Next()
+			return
+		}
+
+		httputil.RespondError(c, err, shared.ErrCodeReadOnly, "")
+	}
+}
+
+// isQueryMethod reports whether the HTTP method cannot change state
+func isQueryMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
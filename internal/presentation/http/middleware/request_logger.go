@@ -4,45 +4,94 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"notification/pkg/logger"
 )
 
+const (
+	// RequestIDHeader is the header used to pass the request ID in and out
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey is the gin context key holding the request ID
+	RequestIDKey = "request_id"
+
+	// maxRequestIDLength limits client supplied request IDs
+	maxRequestIDLength = 128
+)
+
 // RequestLogger is a middleware that logs HTTP requests
 func RequestLogger() gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		// Use structured logging with zap
-		logger.Info("HTTP Request",
-			zap.String("method", param.Method),
-			zap.String("path", param.Path),
-			zap.Int("status", param.StatusCode),
-			zap.Duration("latency", param.Latency),
-			zap.String("client_ip", param.ClientIP),
-			zap.String("user_agent", param.Request.UserAgent()),
-			zap.Int("body_size", param.BodySize),
-		)
-		return ""
-	})
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []zap.Field{
+			zap.String("request_id", c.GetString(RequestIDKey)),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("query", c.Request.URL.RawQuery),
+			zap.String("route", c.FullPath()),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.Int64("request_size", c.Request.ContentLength),
+			zap.Int("body_size", max(c.Writer.Size(), 0)),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+
+		switch {
+		case status >= 500:
+			logger.Error("HTTP Request", fields...)
+		case status >= 400:
+			logger.Warn("HTTP Request", fields...)
+		default:
+			logger.Info("HTTP Request", fields...)
+		}
+	}
 }
 
-// RequestID is a middleware that adds a unique request ID to each request
+// RequestID is a middleware that adds a unique request ID to each request.
+// A well-formed X-Request-ID sent by the client is kept so that calls can be
+// traced across services; otherwise a new ID is generated. The ID is echoed
+// in the response header, stored in the gin context and in the request context.
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
 			requestID = generateRequestID()
 		}
-		
-		c.Header("X-Request-ID", requestID)
-		c.Set("request_id", requestID)
+
+		c.Header(RequestIDHeader, requestID)
+		c.Set(RequestIDKey, requestID)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
 		c.Next()
 	}
 }
 
 // generateRequestID generates a unique request ID
 func generateRequestID() string {
-	// Simple implementation using timestamp
-	// In production, consider using UUID or other unique ID generators
-	return time.Now().Format("20060102150405.000000")
-}
\ No newline at end of file
+	return uuid.NewString()
+}
+
+// isValidRequestID reports whether a client supplied request ID is safe to
+// propagate into logs and headers.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/http/httputil"
 	"notification/pkg/logger"
 )
 
@@ -57,11 +59,7 @@ func (sm *SecurityMiddleware) Handler() gin.HandlerFunc {
 				zap.String("host", c.Request.Host),
 				zap.String("client_ip", c.ClientIP()))
 			
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error: "Invalid host",
-				Code:  "INVALID_HOST",
-			})
-			c.Abort()
+			httputil.RespondProblem(c, http.StatusBadRequest, "INVALID_HOST", "Invalid host")
 			return
 		}
 
@@ -235,11 +233,7 @@ func IPWhitelistMiddleware(config *IPWhitelistConfig) gin.HandlerFunc {
 				zap.String("client_ip", clientIP),
 				zap.String("path", c.Request.URL.Path))
 			
-			c.JSON(http.StatusForbidden, ErrorResponse{
-				Error: "Access denied",
-				Code:  "IP_NOT_ALLOWED",
-			})
-			c.Abort()
+			httputil.RespondProblem(c, http.StatusForbidden, "IP_NOT_ALLOWED", "Access denied")
 			return
 		}
 
@@ -269,11 +263,7 @@ func BasicAuthMiddleware(config *BasicAuthConfig) gin.HandlerFunc {
 		
 		if !hasAuth {
 			c.Header("WWW-Authenticate", `Basic realm="`+config.Realm+`"`)
-			c.JSON(http.StatusUnauthorized, ErrorResponse{
-				Error: "Authentication required",
-				Code:  "AUTH_REQUIRED",
-			})
-			c.Abort()
+			httputil.RespondProblem(c, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required")
 			return
 		}
 
@@ -282,6 +272,7 @@ func BasicAuthMiddleware(config *BasicAuthConfig) gin.HandlerFunc {
 			// Use constant-time comparison to prevent timing attacks
 			if subtle.ConstantTimeCompare([]byte(password), []byte(expectedPassword)) == 1 {
 				c.Set("auth_user", username)
+				c.Request = c.Request.WithContext(shared.WithActor(c.Request.Context(), username))
 				c.Next()
 				return
 			}
@@ -292,11 +283,7 @@ func BasicAuthMiddleware(config *BasicAuthConfig) gin.HandlerFunc {
 			zap.String("client_ip", c.ClientIP()))
 
 		c.Header("WWW-Authenticate", `Basic realm="`+config.Realm+`"`)
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error: "Invalid credentials",
-			Code:  "INVALID_CREDENTIALS",
-		})
-		c.Abort()
+		httputil.RespondProblem(c, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid credentials")
 	}
 }
 
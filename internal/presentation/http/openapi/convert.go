@@ -0,0 +1,414 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	definitionsPrefix = "#/definitions/"
+	schemasPrefix     = "#/components/schemas/"
+
+	defaultMediaType = "application/json"
+)
+
+// FromSwagger converts a Swagger 2.0 document, as generated by swag from the
+// handler annotations, into an OpenAPI 3 document
+func FromSwagger(data []byte) (*Document, error) {
+	var swagger spec.Swagger
+	if err := json.Unmarshal(data, &swagger); err != nil {
+		return nil, fmt.Errorf("invalid swagger document: %w", err)
+	}
+	if swagger.Swagger != "2.0" {
+		return nil, fmt.Errorf("unsupported swagger version %q", swagger.Swagger)
+	}
+
+	doc := &Document{
+		OpenAPI:  Version,
+		Servers:  convertServers(&swagger),
+		Paths:    make(map[string]PathItem),
+		Security: swagger.Security,
+		Tags:     swagger.Tags,
+	}
+	if swagger.Info != nil {
+		doc.Info = Info{
+			Title:          swagger.Info.Title,
+			Description:    swagger.Info.Description,
+			TermsOfService: swagger.Info.TermsOfService,
+			Contact:        swagger.Info.Contact,
+			License:        swagger.Info.License,
+			Version:        swagger.Info.Version,
+		}
+	}
+
+	if len(swagger.Definitions) > 0 {
+		doc.Components.Schemas = make(map[string]spec.Schema, len(swagger.Definitions))
+		for name, schema := range swagger.Definitions {
+			schema := schema
+			rewriteRefs(&schema)
+			doc.Components.Schemas[name] = schema
+		}
+	}
+
+	if len(swagger.SecurityDefinitions) > 0 {
+		doc.Components.SecuritySchemes = make(map[string]SecurityScheme, len(swagger.SecurityDefinitions))
+		for name, definition := range swagger.SecurityDefinitions {
+			if scheme, ok := convertSecurityScheme(definition); ok {
+				doc.Components.SecuritySchemes[name] = scheme
+			}
+		}
+	}
+
+	if swagger.Paths != nil {
+		for path, item := range swagger.Paths.Paths {
+			converted := make(PathItem)
+			for method, op := range operations(item) {
+				converted[method] = convertOperation(op, item.Parameters, &swagger)
+				if converted[method].OperationID == "" {
+					converted[method].OperationID = operationID(method, path)
+				}
+			}
+			if len(converted) > 0 {
+				doc.Paths[path] = converted
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// operations returns the operations of a path item keyed by lower-case method
+func operations(item spec.PathItem) map[string]*spec.Operation {
+	ops := map[string]*spec.Operation{
+		"get":     item.Get,
+		"put":     item.Put,
+		"post":    item.Post,
+		"delete":  item.Delete,
+		"options": item.Options,
+		"head":    item.Head,
+		"patch":   item.Patch,
+	}
+	for method, op := range ops {
+		if op == nil {
+			delete(ops, method)
+		}
+	}
+	return ops
+}
+
+// operationID derives a stable operation ID from the method and path for
+// operations without an @ID annotation, e.g. "getApiV1ChannelsById" for
+// GET /api/v1/channels/{id}
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(method)
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			b.WriteString("By")
+			segment = strings.Trim(segment, "{}")
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// convertServers derives the server list from the host, schemes and base path
+func convertServers(swagger *spec.Swagger) []Server {
+	basePath := strings.TrimSuffix(swagger.BasePath, "/")
+	if swagger.Host == "" {
+		if basePath == "" {
+			return nil
+		}
+		return []Server{{URL: basePath}}
+	}
+
+	schemes := swagger.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	servers := make([]Server, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, Server{URL: scheme + "://" + swagger.Host + basePath})
+	}
+	return servers
+}
+
+// convertSecurityScheme maps a Swagger 2.0 security definition to OpenAPI 3
+func convertSecurityScheme(definition *spec.SecurityScheme) (SecurityScheme, bool) {
+	if definition == nil {
+		return SecurityScheme{}, false
+	}
+	switch definition.Type {
+	case "apiKey":
+		return SecurityScheme{
+			Type:        "apiKey",
+			Description: definition.Description,
+			Name:        definition.Name,
+			In:          definition.In,
+		}, true
+	case "basic":
+		return SecurityScheme{
+			Type:        "http",
+			Description: definition.Description,
+			Scheme:      "basic",
+		}, true
+	default:
+		return SecurityScheme{}, false
+	}
+}
+
+// convertOperation converts a single operation, moving body and form
+// parameters into the request body
+func convertOperation(op *spec.Operation, pathParams []spec.Parameter, swagger *spec.Swagger) *Operation {
+	converted := &Operation{
+		Tags:        op.Tags,
+		Summary:     op.Summary,
+		Description: op.Description,
+		OperationID: op.ID,
+		Deprecated:  op.Deprecated,
+		Security:    op.Security,
+		Responses:   make(map[string]Response),
+	}
+
+	consumes := op.Consumes
+	if len(consumes) == 0 {
+		consumes = swagger.Consumes
+	}
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = swagger.Produces
+	}
+	if len(produces) == 0 {
+		produces = []string{defaultMediaType}
+	}
+
+	params := append(append([]spec.Parameter{}, pathParams...), op.Parameters...)
+	var formParams []spec.Parameter
+	for _, param := range params {
+		switch param.In {
+		case "body":
+			converted.RequestBody = convertBody(param, consumes)
+		case "formData":
+			formParams = append(formParams, param)
+		default:
+			converted.Parameters = append(converted.Parameters, convertParameter(param))
+		}
+	}
+	if len(formParams) > 0 {
+		converted.RequestBody = convertForm(formParams, consumes)
+	}
+
+	if op.Responses != nil {
+		for code, response := range op.Responses.StatusCodeResponses {
+			converted.Responses[strconv.Itoa(code)] = convertResponse(code, response, produces)
+		}
+		if op.Responses.Default != nil {
+			converted.Responses["default"] = convertResponse(0, *op.Responses.Default, produces)
+		}
+	}
+	if len(converted.Responses) == 0 {
+		converted.Responses["default"] = Response{Description: "Default response"}
+	}
+
+	return converted
+}
+
+// convertBody converts a body parameter into a request body
+func convertBody(param spec.Parameter, consumes []string) *RequestBody {
+	if len(consumes) == 0 {
+		consumes = []string{defaultMediaType}
+	}
+
+	schema := param.Schema
+	if schema == nil {
+		schema = &spec.Schema{}
+	}
+	rewriteRefs(schema)
+
+	content := make(map[string]MediaType, len(consumes))
+	for _, mediaType := range consumes {
+		content[mediaType] = MediaType{Schema: schema}
+	}
+
+	return &RequestBody{
+		Description: param.Description,
+		Required:    param.Required,
+		Content:     content,
+	}
+}
+
+// convertForm combines form parameters into an object request body
+func convertForm(params []spec.Parameter, consumes []string) *RequestBody {
+	schema := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       spec.StringOrArray{"object"},
+		Properties: make(spec.SchemaProperties, len(params)),
+	}}
+
+	mediaType := "application/x-www-form-urlencoded"
+	required := false
+	for _, param := range params {
+		property := simpleSchema(param.SimpleSchema, param.CommonValidations)
+		property.Description = param.Description
+		if param.Type == "file" {
+			property.Type = spec.StringOrArray{"string"}
+			property.Format = "binary"
+			mediaType = "multipart/form-data"
+		}
+		schema.Properties[param.Name] = *property
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+			required = true
+		}
+	}
+	for _, consumed := range consumes {
+		if consumed == "multipart/form-data" {
+			mediaType = consumed
+		}
+	}
+	sort.Strings(schema.Required)
+
+	return &RequestBody{
+		Required: required,
+		Content:  map[string]MediaType{mediaType: {Schema: schema}},
+	}
+}
+
+// convertParameter converts a path, query or header parameter
+func convertParameter(param spec.Parameter) Parameter {
+	converted := Parameter{
+		Name:        param.Name,
+		In:          param.In,
+		Description: param.Description,
+		Required:    param.Required || param.In == "path",
+		Schema:      simpleSchema(param.SimpleSchema, param.CommonValidations),
+	}
+
+	if param.Type == "array" {
+		explode := false
+		switch param.CollectionFormat {
+		case "multi":
+			converted.Style = "form"
+			explode = true
+		case "ssv":
+			converted.Style = "spaceDelimited"
+		case "pipes":
+			converted.Style = "pipeDelimited"
+		default:
+			if param.In == "query" {
+				converted.Style = "form"
+			} else {
+				converted.Style = "simple"
+			}
+		}
+		converted.Explode = &explode
+	}
+
+	return converted
+}
+
+// convertResponse converts a response, exposing its schema for every
+// produced media type
+func convertResponse(code int, response spec.Response, produces []string) Response {
+	converted := Response{Description: response.Description}
+	if converted.Description == "" {
+		converted.Description = http.StatusText(code)
+	}
+	if converted.Description == "" {
+		converted.Description = "Default response"
+	}
+
+	if response.Schema != nil {
+		rewriteRefs(response.Schema)
+		converted.Content = make(map[string]MediaType, len(produces))
+		for _, mediaType := range produces {
+			converted.Content[mediaType] = MediaType{Schema: response.Schema}
+		}
+	}
+
+	if len(response.Headers) > 0 {
+		converted.Headers = make(map[string]Header, len(response.Headers))
+		for name, header := range response.Headers {
+			converted.Headers[name] = Header{
+				Description: header.Description,
+				Schema:      simpleSchema(header.SimpleSchema, header.CommonValidations),
+			}
+		}
+	}
+
+	return converted
+}
+
+// simpleSchema builds a schema from the simple schema of a non-body parameter,
+// item or header
+func simpleSchema(simple spec.SimpleSchema, validations spec.CommonValidations) *spec.Schema {
+	schema := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Format:           simple.Format,
+		Nullable:         simple.Nullable,
+		Default:          simple.Default,
+		Maximum:          validations.Maximum,
+		ExclusiveMaximum: validations.ExclusiveMaximum,
+		Minimum:          validations.Minimum,
+		ExclusiveMinimum: validations.ExclusiveMinimum,
+		MaxLength:        validations.MaxLength,
+		MinLength:        validations.MinLength,
+		Pattern:          validations.Pattern,
+		MaxItems:         validations.MaxItems,
+		MinItems:         validations.MinItems,
+		UniqueItems:      validations.UniqueItems,
+		MultipleOf:       validations.MultipleOf,
+		Enum:             validations.Enum,
+	}}
+	if simple.Type != "" {
+		schema.Type = spec.StringOrArray{simple.Type}
+	}
+	if simple.Example != nil {
+		schema.Example = simple.Example
+	}
+	if simple.Items != nil {
+		schema.Items = &spec.SchemaOrArray{
+			Schema: simpleSchema(simple.Items.SimpleSchema, simple.Items.CommonValidations),
+		}
+	}
+	return schema
+}
+
+// rewriteRefs points the definition references of a schema at the OpenAPI 3
+// component schemas
+func rewriteRefs(schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+
+	if ref := schema.Ref.String(); strings.HasPrefix(ref, definitionsPrefix) {
+		schema.Ref = spec.MustCreateRef(schemasPrefix + strings.TrimPrefix(ref, definitionsPrefix))
+	}
+
+	if schema.Items != nil {
+		rewriteRefs(schema.Items.Schema)
+		for i := range schema.Items.Schemas {
+			rewriteRefs(&schema.Items.Schemas[i])
+		}
+	}
+	for _, schemas := range [][]spec.Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for i := range schemas {
+			rewriteRefs(&schemas[i])
+		}
+	}
+	rewriteRefs(schema.Not)
+	if schema.AdditionalProperties != nil {
+		rewriteRefs(schema.AdditionalProperties.Schema)
+	}
+	for name, property := range schema.Properties {
+		property := property
+		rewriteRefs(&property)
+		schema.Properties[name] = property
+	}
+}
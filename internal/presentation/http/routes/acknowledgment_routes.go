@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupAcknowledgmentRoutes sets up the routes listing the acknowledgments of the messages
+func SetupAcknowledgmentRoutes(router *gin.RouterGroup, acknowledgmentHandler *handlers.AcknowledgmentHandler) {
+	router.GET("/messages/:id/acknowledgments", acknowledgmentHandler.GetMessageAcknowledgments) // GET /api/v1/messages/{id}/acknowledgments for who acknowledged it
+	router.GET("/acknowledgments/pending", acknowledgmentHandler.ListUnacknowledged)             // GET /api/v1/acknowledgments/pending for the escalations
+}
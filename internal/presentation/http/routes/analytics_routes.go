@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupAnalyticsRoutes sets up the analytics routes.
+func SetupAnalyticsRoutes(router *gin.RouterGroup, analyticsHandler *handlers.AnalyticsHandler) {
+	analyticsRouter := router.Group("/analytics")
+
+	analyticsRouter.GET("/costs", analyticsHandler.GetCostReport) // GET /api/v1/analytics/costs for the estimated send costs
+}
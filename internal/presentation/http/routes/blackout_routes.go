@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupBlackoutRoutes sets up the routes of the blackout calendar
+func SetupBlackoutRoutes(router *gin.RouterGroup, blackoutHandler *handlers.BlackoutHandler) {
+	blackouts := router.Group("/blackouts")
+	{
+		blackouts.POST("", blackoutHandler.CreateBlackout)
+		blackouts.GET("", blackoutHandler.ListBlackouts)
+		blackouts.GET("/:id", blackoutHandler.GetBlackout)
+		blackouts.PUT("/:id", blackoutHandler.UpdateBlackout)
+		blackouts.DELETE("/:id", blackoutHandler.DeleteBlackout)
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupCategoryRoutes sets up the routes of the message categories and the
+// subscriptions of the users to them
+func SetupCategoryRoutes(router *gin.RouterGroup, categoryHandler *handlers.CategoryHandler) {
+	categories := router.Group("/categories")
+	{
+		categories.POST("", categoryHandler.CreateCategory)
+		categories.GET("", categoryHandler.ListCategories)
+		categories.GET("/:name", categoryHandler.GetCategory)
+		categories.PUT("/:name", categoryHandler.UpdateCategory)
+		categories.DELETE("/:name", categoryHandler.DeleteCategory)
+	}
+
+	subscriptions := router.Group("/users/:userId/subscriptions")
+	{
+		subscriptions.GET("", categoryHandler.ListSubscriptions)
+		subscriptions.PUT("/:category", categoryHandler.Subscribe)
+		subscriptions.DELETE("/:category", categoryHandler.Unsubscribe)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupChannelGroupRoutes sets up the routes for channel group operations
+func SetupChannelGroupRoutes(router *gin.RouterGroup, channelGroupHandler *handlers.ChannelGroupHandler) {
+	groups := router.Group("/channel-groups")
+	{
+		groups.POST("", channelGroupHandler.CreateChannelGroup)
+		groups.GET("", channelGroupHandler.ListChannelGroups)
+		groups.GET("/:id", channelGroupHandler.GetChannelGroup)
+		groups.PUT("/:id", channelGroupHandler.UpdateChannelGroup)
+		groups.DELETE("/:id", channelGroupHandler.DeleteChannelGroup)
+		groups.POST("/:id/members", channelGroupHandler.AddChannelGroupMembers)
+		groups.POST("/:id/members/remove", channelGroupHandler.RemoveChannelGroupMembers)
+	}
+}
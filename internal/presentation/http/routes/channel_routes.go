@@ -14,6 +14,23 @@ func SetupChannelRoutes(router *gin.RouterGroup, channelHandler *handlers.Channe
 		channels.GET("", channelHandler.ListChannels)
 		channels.GET("/:id", channelHandler.GetChannel)
 		channels.PUT("/:id", channelHandler.UpdateChannel)
+		channels.PATCH("/:id", channelHandler.PatchChannel)
 		channels.DELETE("/:id", channelHandler.DeleteChannel)
+		channels.POST("/:id/enable", channelHandler.EnableChannel)
+		channels.POST("/:id/disable", channelHandler.DisableChannel)
+		channels.POST("/:id/maintenance", channelHandler.StartChannelMaintenance)
+		channels.DELETE("/:id/maintenance", channelHandler.EndChannelMaintenance)
+		channels.POST("/:id/maintenance/release", channelHandler.ReleaseHeldMessages)
+		channels.GET("/:id/deliverability", channelHandler.CheckChannelDeliverability)
+		channels.GET("/by-name/:name", channelHandler.GetChannelByName)
+
+		bulk := channels.Group("/bulk")
+		{
+			bulk.POST("", channelHandler.BulkCreateChannels)
+			bulk.POST("/enable", channelHandler.BulkEnableChannels)
+			bulk.POST("/disable", channelHandler.BulkDisableChannels)
+			bulk.POST("/delete", channelHandler.BulkDeleteChannels)
+			bulk.POST("/tag", channelHandler.BulkTagChannels)
+		}
 	}
 }
\ No newline at end of file
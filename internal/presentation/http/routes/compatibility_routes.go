@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupCompatibilityRoutes sets up the route listing the content the channel types can send
+func SetupCompatibilityRoutes(router *gin.RouterGroup, compatibilityHandler *handlers.CompatibilityHandler) {
+	router.GET("/compatibility", compatibilityHandler.GetCompatibility)
+}
@@ -6,14 +6,15 @@ import (
 	"notification/internal/presentation/http/handlers"
 )
 
-// SetupCQRSChannelRoutes sets up the CQRS routes for channel operations
-func SetupCQRSChannelRoutes(router *gin.RouterGroup, cqrsChannelHandler *handlers.CQRSChannelHandler) {
+// SetupCQRSChannelRoutes sets up the v2 channel routes, served by the channel
+// handler running on the CQRS executor
+func SetupCQRSChannelRoutes(router *gin.RouterGroup, channelHandler *handlers.ChannelHandler) {
 	channels := router.Group("/channels")
 	{
-		channels.POST("", cqrsChannelHandler.CreateChannel)
-		channels.GET("", cqrsChannelHandler.ListChannels)
-		channels.GET("/:id", cqrsChannelHandler.GetChannel)
-		channels.PUT("/:id", cqrsChannelHandler.UpdateChannel)
-		channels.DELETE("/:id", cqrsChannelHandler.DeleteChannel)
+		channels.POST("", channelHandler.CreateChannel)
+		channels.GET("", channelHandler.ListChannels)
+		channels.GET("/:id", channelHandler.GetChannel)
+		channels.PUT("/:id", channelHandler.UpdateChannel)
+		channels.DELETE("/:id", channelHandler.DeleteChannel)
 	}
-}
\ No newline at end of file
+}
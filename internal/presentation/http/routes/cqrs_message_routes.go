@@ -6,8 +6,9 @@ import (
 	"notification/internal/presentation/http/handlers"
 )
 
-// SetupCQRSMessageRoutes sets up the CQRS message routes
-func SetupCQRSMessageRoutes(router *gin.RouterGroup, messageHandler *handlers.CQRSMessageHandler) {
+// SetupCQRSMessageRoutes sets up the v2 message routes, served by the message
+// handler running on the CQRS executor
+func SetupCQRSMessageRoutes(router *gin.RouterGroup, messageHandler *handlers.MessageHandler) {
 	// Message routes using CQRS pattern
 	messageRouter := router.Group("/messages")
 
@@ -15,4 +16,4 @@ func SetupCQRSMessageRoutes(router *gin.RouterGroup, messageHandler *handlers.CQ
 	messageRouter.POST("/send", messageHandler.SendMessage)
 	messageRouter.GET("", messageHandler.ListMessages)
 	messageRouter.GET("/:id", messageHandler.GetMessage)
-}
\ No newline at end of file
+}
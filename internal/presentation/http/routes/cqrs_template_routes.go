@@ -6,8 +6,9 @@ import (
 	"notification/internal/presentation/http/handlers"
 )
 
-// SetupCQRSTemplateRoutes sets up the CQRS template routes
-func SetupCQRSTemplateRoutes(router *gin.RouterGroup, templateHandler *handlers.CQRSTemplateHandler) {
+// SetupCQRSTemplateRoutes sets up the v2 template routes, served by the
+// template handler running on the CQRS executor
+func SetupCQRSTemplateRoutes(router *gin.RouterGroup, templateHandler *handlers.TemplateHandler) {
 	// Template routes using CQRS pattern
 	templateRouter := router.Group("/templates")
 
@@ -17,4 +18,4 @@ func SetupCQRSTemplateRoutes(router *gin.RouterGroup, templateHandler *handlers.
 	templateRouter.GET("/:id", templateHandler.GetTemplate)
 	templateRouter.PUT("/:id", templateHandler.UpdateTemplate)
 	templateRouter.DELETE("/:id", templateHandler.DeleteTemplate)
-}
\ No newline at end of file
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupFailedEventRoutes sets up the admin routes for failed events
+func SetupFailedEventRoutes(router *gin.RouterGroup, failedEventHandler *handlers.FailedEventHandler) {
+	failedEvents := router.Group("/failed-events")
+	{
+		failedEvents.GET("", failedEventHandler.ListFailedEvents)
+		failedEvents.POST("/replay", failedEventHandler.ReplayFailedEvents)
+		failedEvents.GET("/:id", failedEventHandler.GetFailedEvent)
+		failedEvents.POST("/:id/replay", failedEventHandler.ReplayFailedEvent)
+	}
+}
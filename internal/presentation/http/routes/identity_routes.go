@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupIdentityRoutes sets up the routes of the sender identities
+func SetupIdentityRoutes(router *gin.RouterGroup, identityHandler *handlers.IdentityHandler) {
+	identities := router.Group("/identities")
+	{
+		identities.POST("", identityHandler.CreateIdentity)
+		identities.GET("", identityHandler.ListIdentities)
+		identities.GET("/:id", identityHandler.GetIdentity)
+		identities.DELETE("/:id", identityHandler.DeleteIdentity)
+		identities.POST("/:id/verify", identityHandler.VerifyIdentity)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupInAppRoutes sets up the routes of the notification centers of the users
+func SetupInAppRoutes(router *gin.RouterGroup, inAppHandler *handlers.InAppHandler) {
+	notifications := router.Group("/users/:userId/notifications")
+	{
+		notifications.GET("", inAppHandler.ListNotifications)
+		notifications.GET("/unread-count", inAppHandler.GetUnreadCount)
+		notifications.GET("/stream", inAppHandler.StreamNotifications)
+		notifications.POST("/read", inAppHandler.MarkNotificationsRead)
+		notifications.POST("/:id/read", inAppHandler.MarkNotificationRead)
+		notifications.DELETE("/:id", inAppHandler.DeleteNotification)
+	}
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupLinkRoutes sets up the route listing the short links of a message
+func SetupLinkRoutes(router *gin.RouterGroup, linkHandler *handlers.LinkHandler) {
+	router.GET("/messages/:id/links", linkHandler.GetMessageLinks) // GET /api/v1/messages/{id}/links for the clicks on its links
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupLogLevelRoutes sets up the admin routes changing the log levels at runtime
+func SetupLogLevelRoutes(router *gin.RouterGroup, logLevelHandler *handlers.LogLevelHandler) {
+	router.GET("/log-levels", logLevelHandler.GetLogLevels)
+	router.PUT("/log-levels", logLevelHandler.SetLogLevel)
+	router.DELETE("/log-levels/:module", logLevelHandler.ResetLogLevel)
+}
@@ -14,5 +14,7 @@ func SetupMessageRoutes(router *gin.RouterGroup, messageHandler *handlers.Messag
 	// Message operations
 	messageRouter.POST("", messageHandler.SendMessage)  // POST /api/v1/messages for sending messages
 	messageRouter.GET("", messageHandler.ListMessages)  // GET /api/v1/messages for listing messages
+	messageRouter.GET("/quota", messageHandler.GetQuotaUsage) // GET /api/v1/messages/quota for the send quota usage
+	messageRouter.GET("/export", messageHandler.ExportMessages) // GET /api/v1/messages/export for streaming an export
 	messageRouter.GET("/:id", messageHandler.GetMessage) // GET /api/v1/messages/{id} for getting specific message
 }
\ No newline at end of file
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupOTPRoutes sets up the routes of the one-time verification codes
+func SetupOTPRoutes(router *gin.RouterGroup, otpHandler *handlers.OTPHandler) {
+	otp := router.Group("/otp")
+	{
+		otp.POST("/send", otpHandler.SendOTP)
+		otp.POST("/verify", otpHandler.VerifyOTP)
+	}
+}
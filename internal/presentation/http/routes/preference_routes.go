@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupPreferenceRoutes sets up the routes of the notification preferences of the users
+func SetupPreferenceRoutes(router *gin.RouterGroup, preferenceHandler *handlers.PreferenceHandler) {
+	preferences := router.Group("/users/:userId/preferences")
+	{
+		preferences.GET("", preferenceHandler.GetPreferences)
+		preferences.PUT("", preferenceHandler.SetPreferences)
+		preferences.DELETE("", preferenceHandler.DeletePreferences)
+	}
+}
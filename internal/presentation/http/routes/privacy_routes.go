@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupPrivacyRoutes sets up the routes of the data subject requests
+func SetupPrivacyRoutes(router *gin.RouterGroup, privacyHandler *handlers.PrivacyHandler) {
+	privacy := router.Group("/privacy")
+	{
+		privacy.POST("/exports", privacyHandler.ExportSubject)
+		privacy.POST("/erasures", privacyHandler.EraseSubject)
+		privacy.GET("/requests/:id", privacyHandler.GetPrivacyRequest)
+		privacy.GET("/requests/:id/export", privacyHandler.GetPrivacyExport)
+		privacy.GET("/audit", privacyHandler.ListPrivacyAudit)
+	}
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupProvisioningRoutes sets up the routes for provisioning sagas
+func SetupProvisioningRoutes(router *gin.RouterGroup, provisioningHandler *handlers.ProvisioningHandler) {
+	provisioning := router.Group("/provisioning")
+	{
+		provisioning.POST("/channels", provisioningHandler.ProvisionChannel)
+		provisioning.GET("/:id", provisioningHandler.GetProvisioningSaga)
+	}
+}
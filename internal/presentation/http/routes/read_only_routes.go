@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupReadOnlyRoutes sets up the admin routes for the read-only mode
+func SetupReadOnlyRoutes(router *gin.RouterGroup, readOnlyHandler *handlers.ReadOnlyHandler) {
+	router.GET("/read-only", readOnlyHandler.GetReadOnly)
+	router.PUT("/read-only", readOnlyHandler.SetReadOnly)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupReplyRoutes sets up the routes listing the replies to the messages
+func SetupReplyRoutes(router *gin.RouterGroup, replyHandler *handlers.ReplyHandler) {
+	router.GET("/messages/:id/replies", replyHandler.GetMessageReplies) // GET /api/v1/messages/{id}/replies for the replies to a message
+	router.GET("/replies", replyHandler.ListReplies)                    // GET /api/v1/replies for the replies received lately
+}
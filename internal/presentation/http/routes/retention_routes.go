@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupRetentionRoutes sets up the routes of the retention job reports
+func SetupRetentionRoutes(router *gin.RouterGroup, retentionHandler *handlers.RetentionHandler) {
+	retention := router.Group("/retention")
+	{
+		retention.GET("/reports", retentionHandler.ListRetentionReports)
+	}
+}
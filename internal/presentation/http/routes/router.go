@@ -2,9 +2,12 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"notification/internal/domain/shared"
 	"notification/internal/presentation/http/handlers"
 	"notification/internal/presentation/http/middleware"
+	_ "notification/internal/presentation/http/models" // Required for Swagger documentation
 
 	swaggerFiles "github.com/swaggo/files"     // swagger embed files
 	ginSwagger "github.com/swaggo/gin-swagger" // gin-swagger middleware
@@ -12,19 +15,98 @@ import (
 
 // RouterConfig holds the configuration for setting up routes
 type RouterConfig struct {
-	ChannelHandler     *handlers.ChannelHandler
-	CQRSChannelHandler *handlers.CQRSChannelHandler
-	TemplateHandler    *handlers.TemplateHandler
-	MessageHandler     *handlers.MessageHandler
+	ChannelHandler      *handlers.ChannelHandler
+	CQRSChannelHandler  *handlers.ChannelHandler
+	TemplateHandler     *handlers.TemplateHandler
+	MessageHandler      *handlers.MessageHandler
+	AnalyticsHandler    *handlers.AnalyticsHandler
+	TagHandler          *handlers.TagHandler
+	ChannelGroupHandler *handlers.ChannelGroupHandler
+	ProvisioningHandler *handlers.ProvisioningHandler
+	FailedEventHandler  *handlers.FailedEventHandler
 
-	// CQRS handlers
-	CQRSTemplateHandler *handlers.CQRSTemplateHandler
-	CQRSMessageHandler  *handlers.CQRSMessageHandler
+	// Handlers of the v2 routes, running on the CQRS executors
+	CQRSTemplateHandler *handlers.TemplateHandler
+	CQRSMessageHandler  *handlers.MessageHandler
 
 	// Middleware configuration
 	MiddlewareConfig *middleware.MiddlewareConfig
 
 	HealthHandler *handlers.HealthHandler
+
+	// AsyncAPIHandler serves the AsyncAPI document of the NATS API
+	AsyncAPIHandler *handlers.AsyncAPIHandler
+
+	// VoiceHandler receives the callbacks of the voice provider
+	VoiceHandler *handlers.VoiceHandler
+
+	// InAppHandler serves the notification centers of the users
+	InAppHandler *handlers.InAppHandler
+
+	// PreferenceHandler serves the notification preferences of the users
+	PreferenceHandler *handlers.PreferenceHandler
+
+	// CategoryHandler serves the message categories and the subscriptions to them
+	CategoryHandler *handlers.CategoryHandler
+
+	// ReadOnly rejects commands while the instance is read-only, and
+	// ReadOnlyHandler lets admins toggle it
+	ReadOnly        *shared.ReadOnlyMode
+	ReadOnlyHandler *handlers.ReadOnlyHandler
+
+	// LogLevelHandler changes the log levels of the instance at runtime
+	LogLevelHandler *handlers.LogLevelHandler
+
+	// ChannelReadinessHandler checks the channel credentials on demand
+	ChannelReadinessHandler *handlers.ChannelReadinessHandler
+
+	// CompatibilityHandler lists the template content each channel type can send
+	CompatibilityHandler *handlers.CompatibilityHandler
+
+	// LinkHandler redirects the short links of the messages and lists their clicks
+	LinkHandler *handlers.LinkHandler
+
+	// QuarantineHandler lists the infected attachments kept back from sends
+	QuarantineHandler *handlers.QuarantineHandler
+
+	// IdentityHandler manages the sender identities and confirms their addresses
+	IdentityHandler *handlers.IdentityHandler
+
+	// ScheduleHandler schedules messages at an instant or at recipient-local times
+	ScheduleHandler *handlers.ScheduleHandler
+
+	// BlackoutHandler manages the blackout calendar deferring the scheduled messages
+	BlackoutHandler *handlers.BlackoutHandler
+
+	// AcknowledgmentHandler records and lists the acknowledgments of the messages
+	AcknowledgmentHandler *handlers.AcknowledgmentHandler
+
+	// ReplyHandler receives and lists the SMS and email replies to the messages
+	ReplyHandler *handlers.ReplyHandler
+
+	// OTPHandler sends and verifies the one-time verification codes
+	OTPHandler *handlers.OTPHandler
+
+	// PrivacyHandler exports and erases the data stored about the recipients
+	PrivacyHandler *handlers.PrivacyHandler
+
+	// RetentionHandler lists what the retention job purged and deleted per tenant
+	RetentionHandler *handlers.RetentionHandler
+
+	// SLOHandler measures the delivery latency objectives
+	SLOHandler *handlers.SLOHandler
+}
+
+// readOnlyQueryRoutes are the POST routes served in read-only mode, as they
+// only compute a result from their request, and the admin routes changing the
+// instance rather than its data
+var readOnlyQueryRoutes = []string{
+	"/api/v1/routing/simulate",
+	"/api/v1/templates/lint",
+	"/api/v1/templates/validate",
+	"/api/v1/admin/read-only",
+	"/api/v1/admin/log-levels",
+	"/api/v1/admin/log-levels/:module",
 }
 
 // SetupRouter sets up the main router with all routes and middleware
@@ -42,14 +124,8 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 
 	middlewareManager := middleware.NewMiddlewareManager(middlewareConfig)
 	middlewareManager.SetupMiddleware(router)
+	readOnly := middleware.ReadOnly(config.ReadOnly, readOnlyQueryRoutes...)
 
-	// Health check endpoint (public)
-	// @Summary Health check
-	// @Description Check if the API is running and healthy
-	// @Tags system
-	// @Produce json
-	// @Success 200 {object} models.HealthResponse "API is healthy"
-	// @Router /healthz [get]
 	// Health check endpoints (public)
 	if config.HealthHandler != nil {
 		router.GET("/healthz", config.HealthHandler.Healthz)
@@ -57,45 +133,47 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 		router.GET("/health", config.HealthHandler.Health)
 	}
 
-	// Metrics endpoint (public, but could be protected)
-	router.GET("/metrics", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "ok",
-			"metrics": gin.H{
-				"uptime": "placeholder", // TODO: Implement actual metrics
-			},
-		})
-	})
+	// Prometheus metrics endpoint (public, but could be protected)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Short links of the messages (public, followed by their recipients)
+	if config.LinkHandler != nil {
+		router.GET("/l/:code", config.LinkHandler.FollowLink)
+	}
+
+	// Acknowledgment links of the messages (public, followed by their recipients)
+	if config.AcknowledgmentHandler != nil {
+		router.GET("/ack/:token", config.AcknowledgmentHandler.Acknowledge)
+	}
+
+	// Confirmation links of the sender addresses (public, followed by their owners)
+	if config.IdentityHandler != nil {
+		router.GET("/identities/:id/confirm", config.IdentityHandler.ConfirmIdentity)
+	}
 
 	// Public API v1 routes (no authentication required)
 	publicV1 := router.Group("/api/v1/public")
+	publicV1.Use(readOnly)
 	{
 		// Add public endpoints here if needed
-		// @Summary API information
-		// @Description Get information about the API and available endpoints
-		// @Tags system
-		// @Produce json
-		// @Success 200 {object} models.InfoResponse "API information"
-		// @Router /api/v1/public/info [get]
-		publicV1.GET("/info", func(c *gin.Context) {
-			c.JSON(200, gin.H{
-				"service": "notification-api",
-				"version": "1.0.0",
-				"endpoints": []string{
-					"/api/v1/channels",
-					"/api/v1/templates",
-					"/api/v1/messages",
-					"/api/v2/channels (CQRS)",
-					"/api/v2/templates (CQRS)",
-					"/api/v2/messages (CQRS)",
-				},
-			})
-		})
+		publicV1.GET("/info", apiInfo)
+
+		// Voice calls are acknowledged by the provider, which signs its requests
+		if config.VoiceHandler != nil {
+			publicV1.POST("/voice/channels/:id/ack", config.VoiceHandler.AcknowledgeCall)
+		}
+
+		// Replies are posted by the providers, which sign them or hold a secret
+		if config.ReplyHandler != nil {
+			publicV1.POST("/sms/channels/:id/inbound", config.ReplyHandler.ReceiveSMS)
+			publicV1.POST("/email/inbound", config.ReplyHandler.ReceiveEmail)
+		}
 	}
 
 	// Protected API v1 routes (authentication required)
 	protectedV1 := router.Group("/api/v1")
 	middlewareManager.SetupProtectedRoutes(protectedV1)
+	protectedV1.Use(readOnly)
 	{
 		// Traditional Channel routes
 		if config.ChannelHandler != nil {
@@ -107,9 +185,100 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 			SetupTemplateRoutes(protectedV1, config.TemplateHandler)
 		}
 
+		// Channel type compatibility routes
+		if config.CompatibilityHandler != nil {
+			SetupCompatibilityRoutes(protectedV1, config.CompatibilityHandler)
+		}
+
 		// Message routes
 		if config.MessageHandler != nil {
 			SetupMessageRoutes(protectedV1, config.MessageHandler)
+			SetupRoutingRoutes(protectedV1, config.MessageHandler)
+		}
+
+		// Message short link routes
+		if config.LinkHandler != nil {
+			SetupLinkRoutes(protectedV1, config.LinkHandler)
+		}
+
+		// Analytics routes
+		if config.AnalyticsHandler != nil {
+			SetupAnalyticsRoutes(protectedV1, config.AnalyticsHandler)
+		}
+
+		// Tag routes
+		if config.TagHandler != nil {
+			SetupTagRoutes(protectedV1, config.TagHandler)
+		}
+
+		// Channel group routes
+		if config.ChannelGroupHandler != nil {
+			SetupChannelGroupRoutes(protectedV1, config.ChannelGroupHandler)
+		}
+
+		// Provisioning routes
+		if config.ProvisioningHandler != nil {
+			SetupProvisioningRoutes(protectedV1, config.ProvisioningHandler)
+		}
+
+		// In-app notification center routes
+		if config.InAppHandler != nil {
+			SetupInAppRoutes(protectedV1, config.InAppHandler)
+		}
+
+		// User notification preference routes
+		if config.PreferenceHandler != nil {
+			SetupPreferenceRoutes(protectedV1, config.PreferenceHandler)
+		}
+
+		// Category and subscription routes
+		if config.CategoryHandler != nil {
+			SetupCategoryRoutes(protectedV1, config.CategoryHandler)
+		}
+
+		// Sender identity routes
+		if config.IdentityHandler != nil {
+			SetupIdentityRoutes(protectedV1, config.IdentityHandler)
+		}
+
+		// Scheduled message routes
+		if config.ScheduleHandler != nil {
+			SetupScheduleRoutes(protectedV1, config.ScheduleHandler)
+		}
+
+		// Blackout calendar routes
+		if config.BlackoutHandler != nil {
+			SetupBlackoutRoutes(protectedV1, config.BlackoutHandler)
+		}
+
+		// Message acknowledgment routes
+		if config.AcknowledgmentHandler != nil {
+			SetupAcknowledgmentRoutes(protectedV1, config.AcknowledgmentHandler)
+		}
+
+		// Message reply routes
+		if config.ReplyHandler != nil {
+			SetupReplyRoutes(protectedV1, config.ReplyHandler)
+		}
+
+		// One-time verification code routes
+		if config.OTPHandler != nil {
+			SetupOTPRoutes(protectedV1, config.OTPHandler)
+		}
+
+		// Data subject request routes
+		if config.PrivacyHandler != nil {
+			SetupPrivacyRoutes(protectedV1, config.PrivacyHandler)
+		}
+
+		// Retention report routes
+		if config.RetentionHandler != nil {
+			SetupRetentionRoutes(protectedV1, config.RetentionHandler)
+		}
+
+		// SLO routes
+		if config.SLOHandler != nil {
+			SetupSLORoutes(protectedV1, config.SLOHandler)
 		}
 
 		// Plugin management routes
@@ -119,6 +288,7 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 	// CQRS API v2 routes (using CQRS pattern)
 	cqrsV2 := router.Group("/api/v2")
 	middlewareManager.SetupProtectedRoutes(cqrsV2)
+	cqrsV2.Use(readOnly)
 	{
 		// CQRS Channel routes
 		if config.CQRSChannelHandler != nil {
@@ -139,6 +309,7 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 	// Admin routes (additional authentication/authorization)
 	adminV1 := router.Group("/api/v1/admin")
 	middlewareManager.SetupAdminRoutes(adminV1)
+	adminV1.Use(readOnly)
 	{
 		// Admin endpoints
 		adminV1.GET("/stats", func(c *gin.Context) {
@@ -154,10 +325,42 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 				"user":    c.GetString("auth_user"),
 			})
 		})
+
+		// Failed event inspection and replay
+		if config.FailedEventHandler != nil {
+			SetupFailedEventRoutes(adminV1, config.FailedEventHandler)
+		}
+
+		// Read-only mode toggle for standby replicas and maintenance windows
+		if config.ReadOnlyHandler != nil {
+			SetupReadOnlyRoutes(adminV1, config.ReadOnlyHandler)
+		}
+
+		// Log levels changed at runtime, e.g. to debug one module
+		if config.LogLevelHandler != nil {
+			SetupLogLevelRoutes(adminV1, config.LogLevelHandler)
+		}
+
+		// Channel credential checks
+		if config.ChannelReadinessHandler != nil {
+			adminV1.GET("/channels/readiness", config.ChannelReadinessHandler.CheckChannelReadiness)
+		}
+
+		// Attachments quarantined by the virus scan
+		if config.QuarantineHandler != nil {
+			adminV1.GET("/messages/:id/quarantine", config.QuarantineHandler.GetMessageQuarantine)
+		}
 	}
 
-	// Swagger UI
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// OpenAPI 3 document and the Swagger UI rendering it
+	openAPIHandler := handlers.NewOpenAPIHandler()
+	router.GET("/api/openapi.json", openAPIHandler.GetOpenAPISpec)
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/api/openapi.json")))
+
+	// AsyncAPI document of the NATS API
+	if config.AsyncAPIHandler != nil {
+		router.GET("/api/asyncapi.json", config.AsyncAPIHandler.GetAsyncAPISpec)
+	}
 
 	// Handle 404
 	router.NoRoute(middleware.NotFoundHandler())
@@ -165,3 +368,35 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 
 	return router
 }
+
+// apiInfo handles GET /api/v1/public/info
+// @Summary API information
+// @Description Get information about the API and available endpoints
+// @Tags system
+// @Produce json
+// @Success 200 {object} models.InfoResponse "API information"
+// @Router /api/v1/public/info [get]
+func apiInfo(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"service": "notification-api",
+		"version": "1.0.0",
+		"endpoints": []string{
+			"/api/v1/channels",
+			"/api/v1/templates",
+			"/api/v1/messages",
+			"/api/v1/routing",
+			"/api/v1/analytics",
+			"/api/v1/tags",
+			"/api/v1/channel-groups",
+			"/api/v1/users/{userId}/notifications",
+			"/api/v1/users/{userId}/preferences",
+			"/api/v1/categories",
+			"/api/v1/users/{userId}/subscriptions",
+			"/api/v2/channels (CQRS)",
+			"/api/v2/templates (CQRS)",
+			"/api/v2/messages (CQRS)",
+			"/api/openapi.json",
+			"/api/asyncapi.json",
+		},
+	})
+}
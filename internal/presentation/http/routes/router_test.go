@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "notification/docs"
+	"notification/internal/presentation/http/handlers"
+)
+
+var pathParamPattern = regexp.MustCompile(`:([^/]+)`)
+
+func TestOpenAPISpec_CoversAPIRoutes(t *testing.T) {
+	router := SetupRouter(&RouterConfig{
+		ChannelHandler:      &handlers.ChannelHandler{},
+		CQRSChannelHandler:  &handlers.ChannelHandler{},
+		TemplateHandler:     &handlers.TemplateHandler{},
+		MessageHandler:      &handlers.MessageHandler{},
+		AnalyticsHandler:    &handlers.AnalyticsHandler{},
+		TagHandler:          &handlers.TagHandler{},
+		ChannelGroupHandler: &handlers.ChannelGroupHandler{},
+		CQRSTemplateHandler: &handlers.TemplateHandler{},
+		CQRSMessageHandler:  &handlers.MessageHandler{},
+		HealthHandler:       &handlers.HealthHandler{},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/v1/") && !strings.HasPrefix(route.Path, "/api/v2/") {
+			continue
+		}
+		if strings.HasPrefix(route.Path, "/api/v1/admin/") {
+			continue
+		}
+
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		_, ok := doc.Paths[path][strings.ToLower(route.Method)]
+		assert.True(t, ok, "%s %s is not documented", route.Method, path)
+	}
+}
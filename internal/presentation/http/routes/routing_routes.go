@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupRoutingRoutes sets up the routing routes.
+func SetupRoutingRoutes(router *gin.RouterGroup, messageHandler *handlers.MessageHandler) {
+	routingRouter := router.Group("/routing")
+
+	routingRouter.POST("/simulate", messageHandler.SimulateRouting) // POST /api/v1/routing/simulate for previewing where a message would go
+}
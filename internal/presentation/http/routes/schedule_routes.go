@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupScheduleRoutes sets up the routes of the scheduled messages
+func SetupScheduleRoutes(router *gin.RouterGroup, scheduleHandler *handlers.ScheduleHandler) {
+	schedules := router.Group("/schedules")
+	{
+		schedules.POST("", scheduleHandler.ScheduleMessage)
+		schedules.GET("/:id", scheduleHandler.GetSchedule)
+		schedules.DELETE("/:id", scheduleHandler.CancelSchedule)
+	}
+}
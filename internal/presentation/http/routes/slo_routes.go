@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupSLORoutes sets up the routes of the delivery latency objectives
+func SetupSLORoutes(router *gin.RouterGroup, sloHandler *handlers.SLOHandler) {
+	slo := router.Group("/slo")
+	{
+		slo.GET("", sloHandler.ListSLOs)
+		slo.GET("/:name", sloHandler.GetSLO)
+	}
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"notification/internal/presentation/http/handlers"
+)
+
+// SetupTagRoutes sets up the tag routes.
+func SetupTagRoutes(router *gin.RouterGroup, tagHandler *handlers.TagHandler) {
+	tagRouter := router.Group("/tags")
+
+	tagRouter.GET("", tagHandler.ListTags)          // GET /api/v1/tags for the tags in use and their counts
+	tagRouter.POST("/rename", tagHandler.RenameTag) // POST /api/v1/tags/rename
+	tagRouter.POST("/merge", tagHandler.MergeTags)  // POST /api/v1/tags/merge
+}
@@ -15,6 +15,15 @@ func SetupTemplateRoutes(router *gin.RouterGroup, templateHandler *handlers.Temp
 	templateRouter.POST("", templateHandler.CreateTemplate)
 	templateRouter.GET("", templateHandler.ListTemplates)
 	templateRouter.GET("/:id", templateHandler.GetTemplate)
-	templateRouter.PUT("/:id", templateHandler.UpdateTemplate)
+	templateRouter.PUT("/:id", templateHandler.ReplaceTemplate)
+	templateRouter.PATCH("/:id", templateHandler.UpdateTemplate)
 	templateRouter.DELETE("/:id", templateHandler.DeleteTemplate)
+	templateRouter.GET("/:id/usage", templateHandler.GetTemplateUsage)
+
+	// Check templates before saving them
+	templateRouter.POST("/lint", templateHandler.LintTemplate)
+	templateRouter.POST("/validate", templateHandler.ValidateTemplate)
+
+	// Lookup by unique name (used when importing existing resources)
+	templateRouter.GET("/by-name/:name", templateHandler.GetTemplateByName)
 }
@@ -0,0 +1,159 @@
+package asyncapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+
+	"notification/internal/presentation/nats/handlers"
+	"notification/internal/presentation/nats/schema"
+)
+
+// Event describes a subject the service publishes events on
+type Event struct {
+	// Subject is relative to the prefix of the published subjects
+	Subject string
+	Summary string
+	// Payload is a value of the published type
+	Payload interface{}
+}
+
+// Build generates the AsyncAPI document of the request/reply subjects and of
+// the events published under eventPrefix
+func Build(subjects []handlers.SubjectSpec, eventPrefix string, events []Event) *Document {
+	g := schema.NewGenerator("#/components/schemas/")
+	doc := &Document{
+		AsyncAPI: Version,
+		Info: Info{
+			Title:   "Notification NATS API",
+			Version: "1.0",
+			Description: "Request/reply API of the Notification service over NATS. " +
+				"Requests carry their data in a request envelope and are answered with a response envelope " +
+				"holding either the data or the error.",
+		},
+		DefaultContentType: "application/json",
+		Channels:           make(map[string]*Channel),
+		Operations:         make(map[string]*Operation),
+		Components: Components{
+			Messages: make(map[string]*Message),
+			Schemas:  g.Definitions(),
+		},
+	}
+
+	headers := requestHeaders()
+	for _, subject := range subjects {
+		id := operationID(subject.Subject)
+		address := handlers.SubjectPrefix + "." + subject.Subject
+		requestName := id + "Request"
+		replyName := id + "Reply"
+
+		doc.Components.Messages[requestName] = &Message{
+			Name:    requestName,
+			Summary: subject.Summary,
+			Headers: headers,
+			Payload: envelope(g, handlers.NATSRequest{}, subject.Request),
+		}
+		doc.Components.Messages[replyName] = &Message{
+			Name:    replyName,
+			Payload: envelope(g, handlers.NATSResponse{}, subject.Response),
+		}
+
+		doc.Channels[id] = &Channel{
+			Address:  &address,
+			Messages: map[string]Reference{"request": messageRef(requestName)},
+		}
+		doc.Channels[replyName] = &Channel{
+			Description: "The reply subject of the request",
+			Messages:    map[string]Reference{"reply": messageRef(replyName)},
+		}
+		doc.Operations[id] = &Operation{
+			Action:   "receive",
+			Channel:  channelRef(id),
+			Summary:  subject.Summary,
+			Messages: []Reference{channelMessageRef(id, "request")},
+			Reply: &OperationReply{
+				Channel:  channelRef(replyName),
+				Messages: []Reference{channelMessageRef(replyName, "reply")},
+			},
+		}
+	}
+
+	for _, event := range events {
+		id := operationID(event.Subject)
+		address := event.Subject
+		if eventPrefix != "" {
+			address = eventPrefix + "." + event.Subject
+		}
+		payload := g.SchemaOf(reflect.TypeOf(event.Payload))
+
+		doc.Components.Messages[id] = &Message{
+			Name:    id,
+			Summary: event.Summary,
+			Payload: &payload,
+		}
+		doc.Channels[id] = &Channel{
+			Address:  &address,
+			Messages: map[string]Reference{"event": messageRef(id)},
+		}
+		doc.Operations[id] = &Operation{
+			Action:   "send",
+			Channel:  channelRef(id),
+			Summary:  event.Summary,
+			Messages: []Reference{channelMessageRef(id, "event")},
+		}
+	}
+
+	return doc
+}
+
+// envelope returns the schema of the envelope with the data field holding data
+func envelope(g *schema.Generator, envelope, data interface{}) *spec.Schema {
+	payload := g.StructSchema(reflect.TypeOf(envelope))
+	if data != nil {
+		payload.Properties["data"] = g.SchemaOf(reflect.TypeOf(data))
+	}
+	return &payload
+}
+
+// requestHeaders returns the schema of the headers accepted on requests
+func requestHeaders() *spec.Schema {
+	return new(spec.Schema).
+		Typed("object", "").
+		SetProperty("X-API-Key", *spec.StringProperty().
+			WithDescription("API key, required when authentication is enabled")).
+		SetProperty("Authorization", *spec.StringProperty().
+			WithDescription("API key as a Bearer token, instead of X-API-Key")).
+		SetProperty(handlers.RequestIDHeader, *spec.StringProperty().
+			WithDescription("Request ID, used when the envelope has no reqSeqId")).
+		SetProperty(handlers.DeadlineHeader, *spec.StringProperty().
+			WithDescription("Client deadline in Unix milliseconds, overriding the envelope deadline")).
+		SetProperty(handlers.TimeoutHeader, *spec.StringProperty().
+			WithDescription("Client timeout in milliseconds, overriding the envelope timeout"))
+}
+
+// operationID returns the identifier of a subject, e.g. "channelCreate" for "channel.create"
+func operationID(subject string) string {
+	parts := strings.Split(subject, ".")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// messageRef references a message component
+func messageRef(name string) Reference {
+	return Reference{Ref: "#/components/messages/" + name}
+}
+
+// channelRef references a channel
+func channelRef(id string) Reference {
+	return Reference{Ref: "#/channels/" + id}
+}
+
+// channelMessageRef references a message of a channel
+func channelMessageRef(id, message string) Reference {
+	return Reference{Ref: "#/channels/" + id + "/messages/" + message}
+}
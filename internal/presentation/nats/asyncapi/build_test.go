@@ -0,0 +1,95 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/presentation/nats/handlers"
+)
+
+func TestBuild(t *testing.T) {
+	doc := Build(handlers.Subjects, "eco1j.infra.eventcenter", []Event{
+		{Subject: "channel.health", Summary: "Channel health changed", Payload: struct {
+			ChannelID string `json:"channelId"`
+		}{}},
+	})
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	// Every reference resolves within the document
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	for _, ref := range collectRefs(raw) {
+		assert.NotNil(t, resolve(raw, ref), "unresolved reference %s", ref)
+	}
+
+	for _, subject := range handlers.Subjects {
+		id := operationID(subject.Subject)
+		require.Contains(t, doc.Operations, id)
+		assert.Equal(t, "eco1j.infra.eventcenter."+subject.Subject, *doc.Channels[id].Address)
+
+		request := doc.Components.Messages[id+"Request"]
+		require.NotNil(t, request)
+		assert.NotEmpty(t, refOf(request.Payload.Properties["data"]), "%s request data", subject.Subject)
+		reply := doc.Components.Messages[id+"Reply"]
+		require.NotNil(t, reply)
+		assert.NotEmpty(t, refOf(reply.Payload.Properties["data"]), "%s reply data", subject.Subject)
+	}
+
+	assert.Equal(t, "send", doc.Operations["channelHealth"].Action)
+	assert.Equal(t, "eco1j.infra.eventcenter.channel.health", *doc.Channels["channelHealth"].Address)
+
+	// Domain types with their own encoding are described by their JSON form
+	overrides := doc.Components.Schemas["ChannelOverride"]
+	assert.Equal(t, "#/components/schemas/TemplateOverride", refOf(overrides.Properties["templateOverride"]))
+	assert.True(t, overrides.Properties["recipients"].Type.Contains("array"))
+}
+
+func TestOperationID(t *testing.T) {
+	assert.Equal(t, "channelCreate", operationID("channel.create"))
+	assert.Equal(t, "health", operationID("health"))
+}
+
+// collectRefs returns the $ref values found in a decoded JSON document
+func collectRefs(value interface{}) []string {
+	var refs []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+				continue
+			}
+			refs = append(refs, collectRefs(child)...)
+		}
+	case []interface{}:
+		for _, child := range v {
+			refs = append(refs, collectRefs(child)...)
+		}
+	}
+	return refs
+}
+
+// resolve returns the value a local reference points to, or nil
+func resolve(doc map[string]interface{}, ref string) interface{} {
+	var current interface{} = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+// refOf returns the reference of a schema
+func refOf(schema spec.Schema) string {
+	return schema.Ref.String()
+}
@@ -0,0 +1,65 @@
+package asyncapi
+
+import "github.com/go-openapi/spec"
+
+// Version is the AsyncAPI version of the generated documents
+const Version = "3.0.0"
+
+// Document represents an AsyncAPI 3 document
+type Document struct {
+	AsyncAPI           string                `json:"asyncapi"`
+	Info               Info                  `json:"info"`
+	DefaultContentType string                `json:"defaultContentType,omitempty"`
+	Channels           map[string]*Channel   `json:"channels"`
+	Operations         map[string]*Operation `json:"operations"`
+	Components         Components            `json:"components"`
+}
+
+// Info holds the API metadata
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Channel is a NATS subject. A nil address is a subject only known at
+// runtime, like the inbox a reply is sent to.
+type Channel struct {
+	Address     *string              `json:"address"`
+	Description string               `json:"description,omitempty"`
+	Messages    map[string]Reference `json:"messages"`
+}
+
+// Operation is a message the service receives or sends on a channel
+type Operation struct {
+	Action   string          `json:"action"`
+	Channel  Reference       `json:"channel"`
+	Summary  string          `json:"summary,omitempty"`
+	Messages []Reference     `json:"messages"`
+	Reply    *OperationReply `json:"reply,omitempty"`
+}
+
+// OperationReply is the reply the service sends to a received request
+type OperationReply struct {
+	Channel  Reference   `json:"channel"`
+	Messages []Reference `json:"messages"`
+}
+
+// Reference points to an object elsewhere in the document
+type Reference struct {
+	Ref string `json:"$ref"`
+}
+
+// Components holds the messages and schemas referenced by the document
+type Components struct {
+	Messages map[string]*Message    `json:"messages"`
+	Schemas  map[string]spec.Schema `json:"schemas"`
+}
+
+// Message describes the headers and payload of a message
+type Message struct {
+	Name    string       `json:"name"`
+	Summary string       `json:"summary,omitempty"`
+	Headers *spec.Schema `json:"headers,omitempty"`
+	Payload *spec.Schema `json:"payload"`
+}
@@ -2,40 +2,38 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
-	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
-	"go.uber.org/zap"
 
 	"notification/internal/application/channel/dtos"
 	"notification/internal/application/channel/usecases"
+	"notification/internal/presentation/executor"
 	"notification/pkg/logger"
 )
 
 // ChannelNATSHandler handles NATS messages for channel operations
 type ChannelNATSHandler struct {
-	createUseCase *usecases.CreateChannelUseCase
-	getUseCase    *usecases.GetChannelUseCase
-	listUseCase   *usecases.ListChannelsUseCase
-	updateUseCase *usecases.UpdateChannelUseCase
-	deleteUseCase *usecases.DeleteChannelUseCase
-	natsConn      *nats.Conn
+	executor executor.ChannelExecutor
+	natsConn *nats.Conn
+	pipeline *Pipeline
 }
 
 // NATSRequest represents a generic NATS request message
 type NATSRequest struct {
 	ReqSeqId  string      `json:"reqSeqId"`
+	Version   int         `json:"version,omitempty"` // envelope version, omitted in the former format
 	Data      interface{} `json:"data"`
 	Timestamp int64       `json:"timestamp"`
+	Deadline  int64       `json:"deadline,omitempty"` // optional client deadline in Unix milliseconds
+	Timeout   int64       `json:"timeout,omitempty"`  // optional client timeout in milliseconds
 }
 
 // NATSResponse represents a generic NATS response message
 type NATSResponse struct {
 	ReqSeqId  string      `json:"reqSeqId"`
 	RspSeqId  string      `json:"rspSeqId"`
+	Version   int         `json:"version"`
 	Success   bool        `json:"success"`
 	Data      interface{} `json:"data,omitempty"`
 	Error     *NATSError  `json:"error,omitempty"`
@@ -47,6 +45,8 @@ type NATSError struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	// RetryAfter is the number of seconds to wait before retrying a RETRY_LATER request
+	RetryAfter int `json:"retryAfter,omitempty"`
 }
 
 // NewChannelNATSHandler creates a new NATS handler for channel operations
@@ -56,156 +56,108 @@ func NewChannelNATSHandler(
 	listUseCase *usecases.ListChannelsUseCase,
 	updateUseCase *usecases.UpdateChannelUseCase,
 	deleteUseCase *usecases.DeleteChannelUseCase,
+	enableUseCase *usecases.SetChannelEnabledUseCase,
 	natsConn *nats.Conn,
 ) *ChannelNATSHandler {
+	return NewChannelNATSHandlerWithExecutor(
+		executor.NewUseCaseChannelExecutor(createUseCase, getUseCase, listUseCase, updateUseCase, deleteUseCase, enableUseCase),
+		natsConn,
+	)
+}
+
+// NewChannelNATSHandlerWithExecutor creates a new NATS handler for channel
+// operations that runs them on the given executor
+func NewChannelNATSHandlerWithExecutor(channelExecutor executor.ChannelExecutor, natsConn *nats.Conn) *ChannelNATSHandler {
 	return &ChannelNATSHandler{
-		createUseCase: createUseCase,
-		getUseCase:    getUseCase,
-		listUseCase:   listUseCase,
-		updateUseCase: updateUseCase,
-		deleteUseCase: deleteUseCase,
-		natsConn:      natsConn,
+		executor: channelExecutor,
+		natsConn: natsConn,
+		pipeline: DefaultPipeline(),
 	}
 }
 
+// SetPipeline sets the middleware pipeline the handlers run behind
+func (h *ChannelNATSHandler) SetPipeline(pipeline *Pipeline) {
+	h.pipeline = pipeline
+}
+
 // RegisterHandlers registers all NATS message handlers for channel operations
 func (h *ChannelNATSHandler) RegisterHandlers() error {
 	// Register create channel handler
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.channel.create", h.handleCreateChannel); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectChannelCreate), h.pipeline.Handle(h.handleCreateChannel)); err != nil {
 		return fmt.Errorf("failed to subscribe to create channel topic: %w", err)
 	}
 
 	// Register get channel handler
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.channel.get", h.handleGetChannel); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectChannelGet), h.pipeline.Handle(h.handleGetChannel)); err != nil {
 		return fmt.Errorf("failed to subscribe to get channel topic: %w", err)
 	}
 
 	// Register list channels handler
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.channel.list", h.handleListChannels); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectChannelList), h.pipeline.Handle(h.handleListChannels)); err != nil {
 		return fmt.Errorf("failed to subscribe to list channels topic: %w", err)
 	}
 
 	// Register update channel handler
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.channel.update", h.handleUpdateChannel); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectChannelUpdate), h.pipeline.Handle(h.handleUpdateChannel)); err != nil {
 		return fmt.Errorf("failed to subscribe to update channel topic: %w", err)
 	}
 
 	// Register delete channel handler
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.channel.delete", h.handleDeleteChannel); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectChannelDelete), h.pipeline.Handle(h.handleDeleteChannel)); err != nil {
 		return fmt.Errorf("failed to subscribe to delete channel topic: %w", err)
 	}
 
+	// Register enable channel handler
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectChannelEnable), h.pipeline.Handle(h.handleEnableChannel)); err != nil {
+		return fmt.Errorf("failed to subscribe to enable channel topic: %w", err)
+	}
+
+	// Register disable channel handler
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectChannelDisable), h.pipeline.Handle(h.handleDisableChannel)); err != nil {
+		return fmt.Errorf("failed to subscribe to disable channel topic: %w", err)
+	}
+
 	logger.Info("Channel NATS handlers registered successfully")
 	return nil
 }
 
 // handleCreateChannel handles create channel NATS messages
-func (h *ChannelNATSHandler) handleCreateChannel(msg *nats.Msg) {
-	ctx := context.Background()
-
-	logger.Info("Received create channel NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	// Convert data to CreateChannelRequest
-	dataBytes, err := json.Marshal(natsReq.Data)
-	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-		return
-	}
-
+func (h *ChannelNATSHandler) handleCreateChannel(ctx context.Context, req *Request) (interface{}, error) {
 	var request dtos.CreateChannelRequest
-	if err := json.Unmarshal(dataBytes, &request); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse create channel request", err.Error())
-		return
+	if err := req.Bind(&request); err != nil {
+		return nil, err
 	}
 
-	// Execute use case
-	response, err := h.createUseCase.Execute(ctx, &request)
+	response, err := h.executor.Create(ctx, &request)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to create channel", err.Error())
-		return
+		return nil, executionError("Failed to create channel", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleGetChannel handles get channel NATS messages
-func (h *ChannelNATSHandler) handleGetChannel(msg *nats.Msg) {
-	ctx := context.Background()
-
-	logger.Info("Received get channel NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	// Extract channel ID from data
-	channelID, ok := natsReq.Data.(string)
-	if !ok {
-		// Try to extract from a map structure
-		if dataMap, ok := natsReq.Data.(map[string]interface{}); ok {
-			if id, exists := dataMap["channelId"]; exists {
-				channelID, _ = id.(string)
-			}
-		}
-	}
-
+func (h *ChannelNATSHandler) handleGetChannel(ctx context.Context, req *Request) (interface{}, error) {
+	channelID := req.ID("channelId")
 	if channelID == "" {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Channel ID is required", "")
-		return
+		return nil, invalidRequest("Channel ID is required")
 	}
 
-	// Execute use case
-	response, err := h.getUseCase.Execute(ctx, channelID)
+	response, err := h.executor.Get(ctx, channelID)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to get channel", err.Error())
-		return
+		return nil, executionError("Failed to get channel", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleListChannels handles list channels NATS messages
-func (h *ChannelNATSHandler) handleListChannels(msg *nats.Msg) {
-	ctx := context.Background()
-
-	logger.Info("Received list channels NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
+func (h *ChannelNATSHandler) handleListChannels(ctx context.Context, req *Request) (interface{}, error) {
+	var request ListChannelsRequest
+	if err := req.Bind(&request); err != nil {
+		return nil, err
 	}
-
-	// Convert data to ListChannelsRequest
-	var request dtos.ListChannelsRequest
-	if natsReq.Data != nil {
-		dataBytes, err := json.Marshal(natsReq.Data)
-		if err != nil {
-			h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-			return
-		}
-
-		if err := json.Unmarshal(dataBytes, &request); err != nil {
-			h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse list channels request", err.Error())
-			return
-		}
+	if request.Pagination != nil {
+		request.SkipCount = request.Pagination.Offset
+		request.MaxResultCount = request.Pagination.Limit
 	}
 
 	// Set default values
@@ -213,139 +165,64 @@ func (h *ChannelNATSHandler) handleListChannels(msg *nats.Msg) {
 		request.MaxResultCount = 20
 	}
 
-	// Execute use case
-	response, err := h.listUseCase.Execute(ctx, &request)
+	response, err := h.executor.List(ctx, &request.ListChannelsRequest)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to list channels", err.Error())
-		return
+		return nil, executionError("Failed to list channels", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleUpdateChannel handles update channel NATS messages
-func (h *ChannelNATSHandler) handleUpdateChannel(msg *nats.Msg) {
-	ctx := context.Background()
-
-	logger.Info("Received update channel NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	// Convert data to UpdateChannelRequest
-	dataBytes, err := json.Marshal(natsReq.Data)
-	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-		return
-	}
-
+func (h *ChannelNATSHandler) handleUpdateChannel(ctx context.Context, req *Request) (interface{}, error) {
 	var request dtos.UpdateChannelRequest
-	if err := json.Unmarshal(dataBytes, &request); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse update channel request", err.Error())
-		return
+	if err := req.Bind(&request); err != nil {
+		return nil, err
+	}
+	if request.ChannelID == "" {
+		return nil, invalidRequest("Channel ID is required")
 	}
 
-	// Execute use case
-	response, err := h.updateUseCase.Execute(ctx, request.ChannelID, &request)
+	response, err := h.executor.Update(ctx, request.ChannelID, &request)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to update channel", err.Error())
-		return
+		return nil, executionError("Failed to update channel", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleDeleteChannel handles delete channel NATS messages
-func (h *ChannelNATSHandler) handleDeleteChannel(msg *nats.Msg) {
-	ctx := context.Background()
-
-	logger.Info("Received delete channel NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	// Extract channel ID from data
-	channelID, ok := natsReq.Data.(string)
-	if !ok {
-		// Try to extract from a map structure
-		if dataMap, ok := natsReq.Data.(map[string]interface{}); ok {
-			if id, exists := dataMap["channelId"]; exists {
-				channelID, _ = id.(string)
-			}
-		}
-	}
-
+func (h *ChannelNATSHandler) handleDeleteChannel(ctx context.Context, req *Request) (interface{}, error) {
+	channelID := req.ID("channelId")
 	if channelID == "" {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Channel ID is required", "")
-		return
+		return nil, invalidRequest("Channel ID is required")
 	}
 
-	// Execute use case
-	response, err := h.deleteUseCase.Execute(ctx, channelID)
+	response, err := h.executor.Delete(ctx, channelID)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to delete channel", err.Error())
-		return
+		return nil, executionError("Failed to delete channel", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
-// sendSuccessResponse sends a success response via NATS
-func (h *ChannelNATSHandler) sendSuccessResponse(msg *nats.Msg, reqSeqId string, data interface{}) {
-	rspId, _ := uuid.NewRandom()
-	response := NATSResponse{
-		ReqSeqId:  reqSeqId,
-		RspSeqId:  rspId.String(),
-		Success:   true,
-		Data:      data,
-		Timestamp: time.Now().UnixMilli(),
-	}
-
-	responseBytes, err := json.Marshal(response)
-	if err != nil {
-		logger.Error("Failed to marshal success response", zap.Error(err))
-		return
-	}
+// handleEnableChannel handles enable channel NATS messages
+func (h *ChannelNATSHandler) handleEnableChannel(ctx context.Context, req *Request) (interface{}, error) {
+	return h.setChannelEnabled(ctx, req, true)
+}
 
-	if err := msg.Respond(responseBytes); err != nil {
-		logger.Error("Failed to send success response", zap.Error(err))
-	}
+// handleDisableChannel handles disable channel NATS messages
+func (h *ChannelNATSHandler) handleDisableChannel(ctx context.Context, req *Request) (interface{}, error) {
+	return h.setChannelEnabled(ctx, req, false)
 }
 
-// sendErrorResponse sends an error response via NATS
-func (h *ChannelNATSHandler) sendErrorResponse(msg *nats.Msg, requestID, code, message, details string) {
-	rspId, _ := uuid.NewRandom()
-	response := NATSResponse{
-		ReqSeqId: requestID,
-		RspSeqId: rspId.String(),
-		Success:  false,
-		Error: &NATSError{
-			Code:    code,
-			Message: message,
-			Details: details,
-		},
-		Timestamp: time.Now().UnixMilli(),
+// setChannelEnabled handles the shared part of the enable and disable channel messages
+func (h *ChannelNATSHandler) setChannelEnabled(ctx context.Context, req *Request, enabled bool) (interface{}, error) {
+	channelID := req.ID("channelId")
+	if channelID == "" {
+		return nil, invalidRequest("Channel ID is required")
 	}
 
-	responseBytes, err := json.Marshal(response)
+	response, err := h.executor.SetEnabled(ctx, channelID, enabled)
 	if err != nil {
-		logger.Error("Failed to marshal error response", zap.Error(err))
-		return
+		return nil, executionError("Failed to update channel", err)
 	}
-
-	if err := msg.Respond(responseBytes); err != nil {
-		logger.Error("Failed to send error response", zap.Error(err))
-	}
-}
\ No newline at end of file
+	return response, nil
+}
@@ -152,6 +152,7 @@ func (suite *ChannelHandlerTestSuite) SetupSuite() {
 	listUseCase := usecases.NewListChannelsUseCase(suite.channelRepo)
 	updateUseCase := usecases.NewUpdateChannelUseCase(suite.channelRepo, templateRepo, validator, suite.appConfig)
 	deleteUseCase := usecases.NewDeleteChannelUseCase(suite.channelRepo, validator, suite.appConfig)
+	enableUseCase := usecases.NewSetChannelEnabledUseCase(suite.channelRepo)
 
 	// 5. Instantiate Handler
 	suite.handler = NewChannelNATSHandler(
@@ -160,6 +161,7 @@ func (suite *ChannelHandlerTestSuite) SetupSuite() {
 		listUseCase,
 		updateUseCase,
 		deleteUseCase,
+		enableUseCase,
 		suite.natsConn,
 	)
 	err = suite.handler.RegisterHandlers()
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Headers negotiating the compression of request and response payloads
+const (
+	// HeaderContentEncoding marks a gzip compressed payload
+	HeaderContentEncoding = "Content-Encoding"
+	// HeaderAcceptEncoding asks for a gzip compressed response
+	HeaderAcceptEncoding = "Accept-Encoding"
+
+	encodingGzip = "gzip"
+)
+
+// DefaultMaxResponseBytes is the largest response sent when the limit of the
+// connection is unknown, the default max_payload of a NATS server
+const DefaultMaxResponseBytes = 1 << 20 // 1 MiB
+
+// minCompressBytes is the size below which responses are not worth compressing
+const minCompressBytes = 1 << 10 // 1 KiB
+
+// decodePayload returns the payload of a message, decompressing it when it
+// carries a gzip Content-Encoding header. A decompressed payload larger than
+// maxBytes is rejected with PAYLOAD_TOO_LARGE.
+func decodePayload(msg *nats.Msg, maxBytes int) ([]byte, error) {
+	encoding := ""
+	if msg.Header != nil {
+		encoding = strings.TrimSpace(msg.Header.Get(HeaderContentEncoding))
+	}
+
+	switch strings.ToLower(encoding) {
+	case "", "identity":
+		return msg.Data, nil
+	case encodingGzip:
+	default:
+		return nil, NewRequestError("UNSUPPORTED_ENCODING", "Unsupported payload encoding",
+			fmt.Sprintf("%s %q is not supported, use %s", HeaderContentEncoding, encoding, encodingGzip))
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(msg.Data))
+	if err != nil {
+		return nil, NewRequestError("INVALID_REQUEST", "Failed to decompress payload", err.Error())
+	}
+	defer reader.Close()
+
+	// Read one byte past the limit to tell a payload at the limit from a larger one
+	data, err := io.ReadAll(io.LimitReader(reader, int64(maxBytes)+1))
+	if err != nil {
+		return nil, NewRequestError("INVALID_REQUEST", "Failed to decompress payload", err.Error())
+	}
+	if len(data) > maxBytes {
+		return nil, NewRequestError("PAYLOAD_TOO_LARGE",
+			fmt.Sprintf("decompressed payload exceeds the limit of %d bytes", maxBytes), "")
+	}
+	return data, nil
+}
+
+// acceptsGzip reports whether the requester accepts a gzip compressed response
+func acceptsGzip(msg *nats.Msg) bool {
+	if msg.Header == nil {
+		return false
+	}
+	for _, encoding := range strings.Split(msg.Header.Get(HeaderAcceptEncoding), ",") {
+		if strings.EqualFold(strings.TrimSpace(encoding), encodingGzip) {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipBytes compresses data with gzip
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContentChunk is the data of the template content upload request. Content
+// too large for one NATS message is uploaded in chunks; the first chunk has
+// no upload ID and sequence 0, later chunks pass the returned upload ID and
+// the next sequence. The upload is referenced by contentUploadId in place of
+// content on template.create and template.update once the final chunk is in.
+type ContentChunk struct {
+	UploadID string `json:"uploadId,omitempty"`
+	Sequence int    `json:"sequence"`
+	Content  string `json:"content"`
+	Final    bool   `json:"final"`
+}
+
+// ContentUploadResponse is the data of the template content upload response
+type ContentUploadResponse struct {
+	UploadID  string    `json:"uploadId"`
+	Size      int       `json:"size"`
+	Complete  bool      `json:"complete"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// contentUpload is the content received so far of one upload
+type contentUpload struct {
+	clientID  string
+	content   strings.Builder
+	next      int
+	complete  bool
+	expiresAt time.Time
+}
+
+// ContentUploads holds the chunked template content uploads in memory until
+// they are referenced or expire
+type ContentUploads struct {
+	mu       sync.Mutex
+	uploads  map[string]*contentUpload
+	maxBytes int
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// Default limits of the template content uploads
+const (
+	DefaultMaxUploadBytes = 16 << 20 // 16 MiB
+	DefaultUploadTTL      = 10 * time.Minute
+)
+
+// NewContentUploads creates an upload store. Zero limits use the defaults.
+func NewContentUploads(maxBytes int, ttl time.Duration) *ContentUploads {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxUploadBytes
+	}
+	if ttl <= 0 {
+		ttl = DefaultUploadTTL
+	}
+	return &ContentUploads{
+		uploads:  make(map[string]*contentUpload),
+		maxBytes: maxBytes,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Append adds a chunk to its upload, starting a new upload for a chunk
+// without an upload ID. Chunks must arrive in sequence.
+func (u *ContentUploads) Append(clientID string, chunk *ContentChunk) (*ContentUploadResponse, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := u.now()
+	u.expire(now)
+
+	var upload *contentUpload
+	uploadID := chunk.UploadID
+	if uploadID == "" {
+		if chunk.Sequence != 0 {
+			return nil, invalidRequest("The first chunk of an upload must have sequence 0")
+		}
+		uploadID = uuid.NewString()
+		upload = &contentUpload{clientID: clientID}
+		u.uploads[uploadID] = upload
+	} else {
+		var err error
+		if upload, err = u.lookup(uploadID, clientID); err != nil {
+			return nil, err
+		}
+		if upload.complete {
+			return nil, NewRequestError("INVALID_REQUEST", "Upload is already complete", uploadID)
+		}
+		if chunk.Sequence != upload.next {
+			return nil, NewRequestError("INVALID_REQUEST", "Chunk out of sequence",
+				fmt.Sprintf("expected sequence %d, got %d", upload.next, chunk.Sequence))
+		}
+	}
+
+	if upload.content.Len()+len(chunk.Content) > u.maxBytes {
+		delete(u.uploads, uploadID)
+		return nil, NewRequestError("PAYLOAD_TOO_LARGE",
+			fmt.Sprintf("uploaded content exceeds the limit of %d bytes", u.maxBytes), "")
+	}
+
+	upload.content.WriteString(chunk.Content)
+	upload.next++
+	upload.complete = chunk.Final
+	upload.expiresAt = now.Add(u.ttl)
+
+	return &ContentUploadResponse{
+		UploadID:  uploadID,
+		Size:      upload.content.Len(),
+		Complete:  upload.complete,
+		ExpiresAt: upload.expiresAt,
+	}, nil
+}
+
+// Take returns the content of a complete upload and removes the upload, so
+// that an upload is consumed by the request referencing it
+func (u *ContentUploads) Take(clientID, uploadID string) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.expire(u.now())
+
+	upload, err := u.lookup(uploadID, clientID)
+	if err != nil {
+		return "", err
+	}
+	if !upload.complete {
+		return "", NewRequestError("INVALID_REQUEST", "Upload is not complete", "send the last chunk with final set")
+	}
+
+	delete(u.uploads, uploadID)
+	return upload.content.String(), nil
+}
+
+// lookup returns an upload of the client. Uploads of other clients are
+// reported as missing.
+func (u *ContentUploads) lookup(uploadID, clientID string) (*contentUpload, error) {
+	upload, ok := u.uploads[uploadID]
+	if !ok || upload.clientID != clientID {
+		return nil, NewRequestError("UPLOAD_NOT_FOUND", "Upload not found or expired", uploadID)
+	}
+	return upload, nil
+}
+
+// expire drops the uploads past their expiry
+func (u *ContentUploads) expire(now time.Time) {
+	for id, upload := range u.uploads {
+		if now.After(upload.expiresAt) {
+			delete(u.uploads, id)
+		}
+	}
+}
+
+// ContentUploadMiddleware replaces the contentUploadId of template create
+// and update requests with the content of the referenced upload, so that
+// the contract and the handlers see the request as if the content had been
+// sent inline
+func ContentUploadMiddleware(uploads *ContentUploads) Middleware {
+	subjects := map[string]bool{
+		fullSubject(SubjectTemplateCreate): true,
+		fullSubject(SubjectTemplateUpdate): true,
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			if !subjects[req.Msg.Subject] {
+				return next(ctx, req)
+			}
+
+			var fields map[string]json.RawMessage
+			if json.Unmarshal(req.Data, &fields) != nil || fields["contentUploadId"] == nil {
+				return next(ctx, req)
+			}
+
+			var uploadID string
+			if err := json.Unmarshal(fields["contentUploadId"], &uploadID); err != nil || uploadID == "" {
+				return nil, invalidRequest("contentUploadId must be a non-empty string")
+			}
+			if _, ok := fields["content"]; ok {
+				return nil, invalidRequest("content and contentUploadId are mutually exclusive")
+			}
+
+			content, err := uploads.Take(req.ClientID, uploadID)
+			if err != nil {
+				return nil, err
+			}
+
+			contentJSON, err := json.Marshal(content)
+			if err != nil {
+				return nil, NewRequestError("INVALID_REQUEST", "Failed to encode uploaded content", err.Error())
+			}
+			fields["content"] = contentJSON
+			delete(fields, "contentUploadId")
+
+			if req.Data, err = json.Marshal(fields); err != nil {
+				return nil, NewRequestError("INVALID_REQUEST", "Failed to encode request data", err.Error())
+			}
+			return next(ctx, req)
+		}
+	}
+}
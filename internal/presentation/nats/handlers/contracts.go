@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/go-openapi/spec"
+
+	"notification/internal/presentation/nats/schema"
+)
+
+// EnvelopeVersion is the version of the NATSRequest and NATSResponse format.
+// Requests without a version use the former format, whose data is only
+// checked when it is decoded.
+const EnvelopeVersion = 1
+
+// Contract is the JSON schema of the request and response data of a subject.
+// Versioned requests are validated against it on ingest.
+type Contract struct {
+	Subject     string                 `json:"subject"`
+	Version     int                    `json:"version"`
+	Request     spec.Schema            `json:"request"`
+	Response    spec.Schema            `json:"response"`
+	Definitions map[string]spec.Schema `json:"definitions,omitempty"`
+}
+
+// NewContract generates the contract of a subject from its request and response types
+func NewContract(subject SubjectSpec) *Contract {
+	g := schema.NewGenerator("#/definitions/")
+	return &Contract{
+		Subject:     subject.Subject,
+		Version:     EnvelopeVersion,
+		Request:     g.SchemaOf(reflect.TypeOf(subject.Request)),
+		Response:    g.SchemaOf(reflect.TypeOf(subject.Response)),
+		Definitions: g.Definitions(),
+	}
+}
+
+// ValidateRequest checks request data against the contract. Missing data is
+// validated as an empty object.
+func (c *Contract) ValidateRequest(data []byte) error {
+	if len(data) == 0 || string(data) == "null" {
+		data = []byte("{}")
+	}
+	return schema.Validate(c.Request, c.Definitions, data)
+}
+
+var (
+	contractsOnce sync.Once
+	contracts     map[string]*Contract
+)
+
+// subjectContracts returns the contracts of the subjects in the registry,
+// keyed by their full subject
+func subjectContracts() map[string]*Contract {
+	contractsOnce.Do(func() {
+		contracts = make(map[string]*Contract, len(Subjects))
+		for _, subject := range Subjects {
+			contracts[fullSubject(subject.Subject)] = NewContract(subject)
+		}
+	})
+	return contracts
+}
+
+// ContractMiddleware checks the envelope version and validates the data of
+// versioned requests against the contract of their subject. Unversioned
+// requests are accepted for compatibility unless requireVersion is set.
+func ContractMiddleware(contracts map[string]*Contract, requireVersion bool) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			switch {
+			case req.Version == 0:
+				if requireVersion {
+					return nil, NewRequestError("UNSUPPORTED_VERSION", "The request envelope has no version",
+						fmt.Sprintf("set version to %d", EnvelopeVersion))
+				}
+				return next(ctx, req)
+			case req.Version < 0 || req.Version > EnvelopeVersion:
+				return nil, NewRequestError("UNSUPPORTED_VERSION", "Unsupported request envelope version",
+					fmt.Sprintf("version %d is not supported, the latest is %d", req.Version, EnvelopeVersion))
+			}
+
+			if contract, ok := contracts[req.Msg.Subject]; ok {
+				if err := contract.ValidateRequest(req.Data); err != nil {
+					return nil, NewRequestError("INVALID_REQUEST", "Request data does not match the contract of "+contract.Subject, err.Error())
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
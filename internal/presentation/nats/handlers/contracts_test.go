@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateContracts = flag.Bool("update-contracts", false, "rewrite the golden contract files")
+
+// TestContracts compares the contract of every subject with its golden file,
+// so that a change of the request or response data of a subject is a visible
+// change of testdata/contracts. Both the classic and the CQRS handlers answer
+// with the data types of the registry. Run with -update-contracts after an
+// intended change.
+func TestContracts(t *testing.T) {
+	for _, subject := range Subjects {
+		t.Run(subject.Subject, func(t *testing.T) {
+			generated, err := json.MarshalIndent(NewContract(subject), "", "  ")
+			require.NoError(t, err)
+			generated = append(generated, '\n')
+
+			path := filepath.Join("testdata", "contracts", subject.Subject+".json")
+			if *updateContracts {
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, generated, 0o644))
+			}
+
+			golden, err := os.ReadFile(path)
+			require.NoError(t, err, "missing golden contract, run the test with -update-contracts")
+			assert.JSONEq(t, string(golden), string(generated), "contract of %s changed", subject.Subject)
+		})
+	}
+}
+
+func TestContract_ValidateRequest(t *testing.T) {
+	contracts := subjectContracts()
+
+	get := contracts[fullSubject(SubjectChannelGet)]
+	assert.NoError(t, get.ValidateRequest([]byte(`{"channelId":"ch-1"}`)))
+	assert.EqualError(t, get.ValidateRequest(nil), "channelId: is required")
+	assert.EqualError(t, get.ValidateRequest([]byte(`"ch-1"`)), "expected an object, got a string")
+
+	send := contracts[fullSubject(SubjectMessageSend)]
+	assert.EqualError(t, send.ValidateRequest([]byte(`{"templateId":"t","recipients":[1]}`)),
+		"recipients[0]: expected an object, got a number")
+	assert.EqualError(t, send.ValidateRequest([]byte(`{"templateId":"t","recipients":[],"channelOverrides":{"ch-1":{"templateOverride":{"subject":1}}}}`)),
+		"channelOverrides.ch-1.templateOverride.subject: expected a string, got a number")
+
+	list := contracts[fullSubject(SubjectChannelList)]
+	assert.NoError(t, list.ValidateRequest([]byte(`{"pagination":{"offset":0,"limit":10}}`)))
+	assert.EqualError(t, list.ValidateRequest([]byte(`{"skipCount":1.5}`)), "skipCount: expected an integer, got 1.5")
+}
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/nats-io/nats.go"
 
 	channel_uc "notification/internal/application/channel/usecases"
+	"notification/internal/application/cqrs"
+	"notification/internal/domain/inbox"
+	"notification/internal/domain/shared"
+	"notification/internal/presentation/executor"
 	message_uc "notification/internal/application/message/usecases"
 	template_uc "notification/internal/application/template/usecases"
 	"notification/pkg/logger"
@@ -14,6 +19,8 @@ import (
 // HandlerManager manages all NATS message handlers
 type HandlerManager struct {
 	natsConn        *nats.Conn
+	pipeline        *Pipeline
+	metrics         *MessageMetrics
 	channelHandler  *ChannelNATSHandler
 	templateHandler *TemplateNATSHandler
 	messageHandler  *MessageNATSHandler
@@ -23,12 +30,45 @@ type HandlerManager struct {
 type HandlerConfig struct {
 	NATSConn *nats.Conn
 
+	// MessageTimeout bounds the handling of one message; zero uses DefaultMessageTimeout
+	MessageTimeout time.Duration
+
+	// PayloadLimits bounds the accepted message payloads; zero fields use the defaults
+	PayloadLimits PayloadLimits
+
+	// MaxUploadBytes and UploadTTL bound the chunked template content
+	// uploads; zero uses DefaultMaxUploadBytes and DefaultUploadTTL
+	MaxUploadBytes int
+	UploadTTL      time.Duration
+
+	// APIKeys maps accepted API keys to client IDs; empty disables authentication
+	APIKeys map[string]string
+
+	// RequireVersion rejects requests in the former unversioned envelope format
+	RequireVersion bool
+
+	// Inbox keeps the responses of the command requests for InboxTTL, so that
+	// retried requests are not run twice; nil disables it. Zero InboxTTL uses
+	// DefaultInboxTTL. A request in progress holds its reqSeqId for twice
+	// MessageTimeout.
+	Inbox    inbox.InboxRepository
+	InboxTTL time.Duration
+
+	// ReadOnly rejects command requests while the instance is read-only; nil never rejects them
+	ReadOnly *shared.ReadOnlyMode
+
+	// UseCQRS runs the channel, template and message requests through
+	// CQRSFacade instead of the use cases
+	UseCQRS    bool
+	CQRSFacade *cqrs.CQRSFacade
+
 	// Channel use cases
-	CreateChannelUseCase *channel_uc.CreateChannelUseCase
-	GetChannelUseCase    *channel_uc.GetChannelUseCase
-	ListChannelsUseCase  *channel_uc.ListChannelsUseCase
-	UpdateChannelUseCase *channel_uc.UpdateChannelUseCase
-	DeleteChannelUseCase *channel_uc.DeleteChannelUseCase
+	CreateChannelUseCase     *channel_uc.CreateChannelUseCase
+	GetChannelUseCase        *channel_uc.GetChannelUseCase
+	ListChannelsUseCase      *channel_uc.ListChannelsUseCase
+	UpdateChannelUseCase     *channel_uc.UpdateChannelUseCase
+	DeleteChannelUseCase     *channel_uc.DeleteChannelUseCase
+	SetChannelEnabledUseCase *channel_uc.SetChannelEnabledUseCase
 
 	// Template use cases
 	CreateTemplateUseCase *template_uc.CreateTemplateUseCase
@@ -43,10 +83,39 @@ type HandlerConfig struct {
 	ListMessagesUseCase *message_uc.ListMessagesUseCase
 }
 
+// useCQRS tells whether the requests run through the CQRS facade
+func (c *HandlerConfig) useCQRS() bool {
+	return c.UseCQRS && c.CQRSFacade != nil
+}
+
 // NewHandlerManager creates a new NATS handler manager
 func NewHandlerManager(config *HandlerConfig) *HandlerManager {
+	// Responses are bounded by the max payload the server announced
+	var maxResponseBytes int
+	if config.NATSConn != nil {
+		maxResponseBytes = int(config.NATSConn.MaxPayload())
+	}
+
+	var commandInbox *Inbox
+	if config.Inbox != nil {
+		commandInbox = NewInbox(config.Inbox, config.InboxTTL, 2*config.MessageTimeout)
+	}
+
+	metrics := NewMessageMetrics()
 	manager := &HandlerManager{
 		natsConn: config.NATSConn,
+		metrics:  metrics,
+		pipeline: NewPipeline(PipelineConfig{
+			MessageTimeout:   config.MessageTimeout,
+			PayloadLimits:    config.PayloadLimits,
+			MaxResponseBytes: maxResponseBytes,
+			Uploads:          NewContentUploads(config.MaxUploadBytes, config.UploadTTL),
+			APIKeys:          config.APIKeys,
+			RequireVersion:   config.RequireVersion,
+			Metrics:          metrics,
+			Inbox:            commandInbox,
+			ReadOnly:         config.ReadOnly,
+		}),
 	}
 
 	// Initialize channel handler
@@ -54,16 +123,23 @@ func NewHandlerManager(config *HandlerConfig) *HandlerManager {
 		config.GetChannelUseCase != nil &&
 		config.ListChannelsUseCase != nil &&
 		config.UpdateChannelUseCase != nil &&
-		config.DeleteChannelUseCase != nil {
+		config.DeleteChannelUseCase != nil &&
+		config.SetChannelEnabledUseCase != nil {
 
-		manager.channelHandler = NewChannelNATSHandler(
+		channelExecutor := executor.NewUseCaseChannelExecutor(
 			config.CreateChannelUseCase,
 			config.GetChannelUseCase,
 			config.ListChannelsUseCase,
 			config.UpdateChannelUseCase,
 			config.DeleteChannelUseCase,
-			config.NATSConn,
+			config.SetChannelEnabledUseCase,
 		)
+		if config.useCQRS() {
+			channelExecutor = executor.NewCQRSChannelExecutor(config.CQRSFacade, config.SetChannelEnabledUseCase)
+		}
+
+		manager.channelHandler = NewChannelNATSHandlerWithExecutor(channelExecutor, config.NATSConn)
+		manager.channelHandler.SetPipeline(manager.pipeline)
 	}
 
 	// Initialize template handler
@@ -72,31 +148,51 @@ func NewHandlerManager(config *HandlerConfig) *HandlerManager {
 		config.ListTemplatesUseCase != nil &&
 		config.UpdateTemplateUseCase != nil &&
 		config.DeleteTemplateUseCase != nil {
-		manager.templateHandler = NewTemplateNATSHandler(
+		templateExecutor := executor.NewUseCaseTemplateExecutor(
 			config.CreateTemplateUseCase,
 			config.GetTemplateUseCase,
 			config.ListTemplatesUseCase,
 			config.UpdateTemplateUseCase,
 			config.DeleteTemplateUseCase,
-			config.NATSConn,
 		)
+		if config.useCQRS() {
+			templateExecutor = executor.NewCQRSTemplateExecutor(config.CQRSFacade)
+		}
+
+		manager.templateHandler = NewTemplateNATSHandlerWithExecutor(templateExecutor, config.NATSConn)
+		manager.templateHandler.SetPipeline(manager.pipeline)
 	}
 
 	// Initialize message handler
 	if config.SendMessageUseCase != nil &&
 		config.GetMessageUseCase != nil &&
 		config.ListMessagesUseCase != nil {
-		manager.messageHandler = NewMessageNATSHandler(
+		messageExecutor := executor.NewUseCaseMessageExecutor(
 			config.SendMessageUseCase,
 			config.GetMessageUseCase,
 			config.ListMessagesUseCase,
-			config.NATSConn,
 		)
+		if config.useCQRS() {
+			messageExecutor = executor.NewCQRSMessageExecutor(config.CQRSFacade)
+		}
+
+		manager.messageHandler = NewMessageNATSHandlerWithExecutor(messageExecutor, config.NATSConn)
+		manager.messageHandler.SetPipeline(manager.pipeline)
 	}
 
 	return manager
 }
 
+// Pipeline returns the middleware pipeline shared by the handlers
+func (m *HandlerManager) Pipeline() *Pipeline {
+	return m.pipeline
+}
+
+// Metrics returns the per-subject counters of the handled messages
+func (m *HandlerManager) Metrics() *MessageMetrics {
+	return m.metrics
+}
+
 // RegisterAllHandlers registers all NATS message handlers
 func (m *HandlerManager) RegisterAllHandlers() error {
 	logger.Info("Registering NATS message handlers")
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/inbox"
+	"notification/pkg/logger"
+)
+
+// DefaultInboxTTL is how long the response of a command request is kept
+const DefaultInboxTTL = 24 * time.Hour
+
+// DefaultInboxLease is how long a command request in progress holds its
+// reqSeqId when no lease is configured, twice DefaultMessageTimeout
+const DefaultInboxLease = 2 * DefaultMessageTimeout
+
+const (
+	// inboxWriteTimeout bounds storing the outcome of a command, which is
+	// detached from the message so that it outlives a timed out handler
+	inboxWriteTimeout = 5 * time.Second
+	// inboxPurgeInterval is the least time between two purges of the expired entries
+	inboxPurgeInterval = 10 * time.Minute
+)
+
+// commandSubjects are the subjects whose requests change state. Queries are
+// safe to run again and are not kept in the inbox.
+var commandSubjects = map[string]bool{
+	fullSubject(SubjectChannelCreate):  true,
+	fullSubject(SubjectChannelUpdate):  true,
+	fullSubject(SubjectChannelDelete):  true,
+	fullSubject(SubjectChannelEnable):  true,
+	fullSubject(SubjectChannelDisable): true,
+	fullSubject(SubjectTemplateCreate): true,
+	fullSubject(SubjectTemplateUpdate): true,
+	fullSubject(SubjectTemplateDelete): true,
+	fullSubject(SubjectMessageSend):    true,
+}
+
+// Inbox keeps the response of every command request by its reqSeqId, so that
+// a request the client retries because the response was lost, or because the
+// process stopped before sending it, is answered with the stored response
+// instead of running the command twice. Requests without a reqSeqId are not
+// kept.
+type Inbox struct {
+	repo inbox.InboxRepository
+	ttl  time.Duration
+	// lease is how long a request in progress holds its reqSeqId
+	lease     time.Duration
+	now       func() time.Time
+	lastPurge atomic.Int64
+}
+
+// NewInbox creates an inbox keeping responses for ttl and holding requests
+// in progress for lease; zero uses DefaultInboxTTL and DefaultInboxLease
+func NewInbox(repo inbox.InboxRepository, ttl, lease time.Duration) *Inbox {
+	if ttl <= 0 {
+		ttl = DefaultInboxTTL
+	}
+	if lease <= 0 {
+		lease = DefaultInboxLease
+	}
+	return &Inbox{repo: repo, ttl: ttl, lease: lease, now: time.Now}
+}
+
+// Middleware answers retried command requests from the inbox. A request
+// still in progress is answered with REQUEST_IN_PROGRESS until its lease
+// runs out; a retry of a request whose process stopped before storing the
+// response then takes the entry over and runs the command again. A failed
+// command is released, so that its retry runs it again.
+func (i *Inbox) Middleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			if req.ReqSeqId == "" || !commandSubjects[req.Msg.Subject] {
+				return next(ctx, req)
+			}
+			i.purgeExpired(ctx)
+
+			now := i.now()
+			entry := &inbox.Entry{
+				Subject:    req.Msg.Subject,
+				ClientID:   req.ClientID,
+				ReqSeqID:   req.ReqSeqId,
+				Status:     inbox.StatusProcessing,
+				ReceivedAt: now,
+				ExpiresAt:  now.Add(i.lease),
+			}
+			existing, err := i.repo.Reserve(ctx, entry)
+			if err != nil {
+				return nil, executionError("Failed to record the request", err)
+			}
+			if existing != nil {
+				if existing.Status == inbox.StatusCompleted {
+					logger.FromContext(ctx).Info("Answered retried request from the inbox",
+						zap.String("subject", req.Msg.Subject),
+						zap.String("request_id", req.ReqSeqId))
+					return existing.Response, nil
+				}
+				return nil, NewRequestError("REQUEST_IN_PROGRESS", "A request with this reqSeqId is still being handled", "retry later for its response")
+			}
+
+			data, err := next(ctx, req)
+			writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), inboxWriteTimeout)
+			defer cancel()
+
+			// A handler answering the message itself leaves no response to keep
+			if err != nil || req.answered {
+				if releaseErr := i.repo.Release(writeCtx, entry); releaseErr != nil {
+					logger.FromContext(ctx).Error("Failed to release inbox entry",
+						zap.String("subject", req.Msg.Subject),
+						zap.String("request_id", req.ReqSeqId),
+						zap.Error(releaseErr))
+				}
+				return data, err
+			}
+
+			i.complete(writeCtx, entry, data)
+			return data, nil
+		}
+	}
+}
+
+// complete stores the response of a command, kept for the TTL. The command
+// ran whether or not this succeeds; an entry left processing is run again by
+// a retry once its lease runs out.
+func (i *Inbox) complete(ctx context.Context, entry *inbox.Entry, data interface{}) {
+	response, err := json.Marshal(data)
+	if err == nil {
+		completedAt := i.now()
+		entry.Status = inbox.StatusCompleted
+		entry.Response = response
+		entry.CompletedAt = &completedAt
+		entry.ExpiresAt = completedAt.Add(i.ttl)
+		err = i.repo.Complete(ctx, entry)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to store response in the inbox",
+			zap.String("subject", entry.Subject),
+			zap.String("request_id", entry.ReqSeqID),
+			zap.Error(err))
+	}
+}
+
+// purgeExpired deletes the expired entries in the background, at most once
+// per inboxPurgeInterval
+func (i *Inbox) purgeExpired(ctx context.Context) {
+	now := i.now()
+	last := i.lastPurge.Load()
+	if now.Sub(time.UnixMilli(last)) < inboxPurgeInterval || !i.lastPurge.CompareAndSwap(last, now.UnixMilli()) {
+		return
+	}
+
+	go func() {
+		purgeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), inboxWriteTimeout)
+		defer cancel()
+		if _, err := i.repo.DeleteExpired(purgeCtx, now); err != nil {
+			logger.FromContext(ctx).Warn("Failed to purge expired inbox entries", zap.Error(err))
+		}
+	}()
+}
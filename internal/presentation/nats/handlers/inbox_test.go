@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/inbox"
+)
+
+type memoryInbox struct {
+	mu      sync.Mutex
+	entries map[string]inbox.Entry
+}
+
+func (m *memoryInbox) key(entry *inbox.Entry) string {
+	return entry.Subject + "|" + entry.ClientID + "|" + entry.ReqSeqID
+}
+
+func (m *memoryInbox) Reserve(ctx context.Context, entry *inbox.Entry) (*inbox.Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.entries[m.key(entry)]; ok && !existing.IsExpired(entry.ReceivedAt) {
+		return &existing, nil
+	}
+	m.entries[m.key(entry)] = *entry
+	return nil, nil
+}
+
+func (m *memoryInbox) Complete(ctx context.Context, entry *inbox.Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[m.key(entry)] = *entry
+	return nil
+}
+
+func (m *memoryInbox) Release(ctx context.Context, entry *inbox.Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, m.key(entry))
+	return nil
+}
+
+func (m *memoryInbox) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestInbox_RunsCommandsOnce(t *testing.T) {
+	repo := &memoryInbox{entries: make(map[string]inbox.Entry)}
+	runs := 0
+	handler := NewInbox(repo, time.Hour, time.Minute).Middleware()(func(ctx context.Context, req *Request) (interface{}, error) {
+		runs++
+		if runs == 1 {
+			return nil, errors.New("legacy system unavailable")
+		}
+		return map[string]int{"run": runs}, nil
+	})
+	request := func(subject, reqSeqId string) (interface{}, error) {
+		return handler(context.Background(), &Request{Msg: &nats.Msg{Subject: fullSubject(subject)}, ReqSeqId: reqSeqId})
+	}
+
+	_, err := request(SubjectChannelCreate, "req-1")
+	require.Error(t, err, "a failed command is released")
+
+	data, err := request(SubjectChannelCreate, "req-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"run": 2}, data)
+
+	data, err = request(SubjectChannelCreate, "req-1")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"run":2}`, string(data.(json.RawMessage)))
+	assert.Equal(t, 2, runs, "the retry is answered from the inbox")
+
+	_, err = request(SubjectChannelGet, "req-1")
+	require.NoError(t, err)
+	_, err = request(SubjectChannelCreate, "")
+	require.NoError(t, err)
+	assert.Equal(t, 4, runs, "queries and requests without reqSeqId are not kept")
+}
+
+func TestInbox_RejectsRequestsInProgress(t *testing.T) {
+	repo := &memoryInbox{entries: make(map[string]inbox.Entry)}
+	now := time.Now()
+	require.NoError(t, repo.Complete(context.Background(), &inbox.Entry{
+		Subject:    fullSubject(SubjectMessageSend),
+		ReqSeqID:   "req-1",
+		Status:     inbox.StatusProcessing,
+		ReceivedAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}))
+
+	handler := NewInbox(repo, time.Hour, time.Minute).Middleware()(func(ctx context.Context, req *Request) (interface{}, error) {
+		t.Fatal("a request in progress must not run again")
+		return nil, nil
+	})
+	_, err := handler(context.Background(), &Request{Msg: &nats.Msg{Subject: fullSubject(SubjectMessageSend)}, ReqSeqId: "req-1"})
+
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, "REQUEST_IN_PROGRESS", requestErr.Code)
+}
+
+func TestInbox_LeasesRequestsInProgress(t *testing.T) {
+	repo := &memoryInbox{entries: make(map[string]inbox.Entry)}
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	commandInbox := NewInbox(repo, time.Hour, time.Minute)
+	commandInbox.now = func() time.Time { return now }
+
+	key := fullSubject(SubjectMessageSend) + "||req-1"
+	runs := 0
+	handler := commandInbox.Middleware()(func(ctx context.Context, req *Request) (interface{}, error) {
+		runs++
+		// The request in progress holds its reqSeqId for the lease only
+		assert.Equal(t, now.Add(time.Minute), repo.entries[key].ExpiresAt)
+		return map[string]int{"run": runs}, nil
+	})
+	request := func() (interface{}, error) {
+		return handler(context.Background(), &Request{Msg: &nats.Msg{Subject: fullSubject(SubjectMessageSend)}, ReqSeqId: "req-1"})
+	}
+
+	// A process stopped before storing the response of the first request
+	require.NoError(t, repo.Complete(context.Background(), &inbox.Entry{
+		Subject:    fullSubject(SubjectMessageSend),
+		ReqSeqID:   "req-1",
+		Status:     inbox.StatusProcessing,
+		ReceivedAt: now,
+		ExpiresAt:  now.Add(time.Minute),
+	}))
+	_, err := request()
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, "REQUEST_IN_PROGRESS", requestErr.Code)
+
+	// Its retry takes the entry over once the lease ran out
+	now = now.Add(time.Minute)
+	data, err := request()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"run": 1}, data)
+
+	// The response is kept for the TTL
+	assert.Equal(t, inbox.StatusCompleted, repo.entries[key].Status)
+	assert.Equal(t, now.Add(time.Hour), repo.entries[key].ExpiresAt)
+	now = now.Add(30 * time.Minute)
+	_, err = request()
+	require.NoError(t, err)
+	assert.Equal(t, 1, runs)
+}
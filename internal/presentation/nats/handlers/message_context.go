@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+
+	"notification/pkg/logger"
+)
+
+const (
+	// DefaultMessageTimeout bounds the work done for one NATS message when no timeout is configured
+	DefaultMessageTimeout = 30 * time.Second
+
+	// DeadlineHeader carries the client deadline as Unix milliseconds
+	DeadlineHeader = "X-Request-Deadline"
+	// TimeoutHeader carries the client timeout in milliseconds
+	TimeoutHeader = "X-Request-Timeout"
+	// RequestIDHeader carries the request ID when the payload has no reqSeqId
+	RequestIDHeader = "X-Request-ID"
+)
+
+// messageEnvelope holds the NATS request payload fields read before dispatching
+type messageEnvelope struct {
+	ReqSeqId string `json:"reqSeqId"`
+	Deadline int64  `json:"deadline"`
+	Timeout  int64  `json:"timeout"`
+}
+
+// readEnvelope reads the envelope fields of a NATS request payload.
+// Payloads that are not JSON objects simply yield an empty envelope.
+func readEnvelope(msg *nats.Msg) messageEnvelope {
+	var envelope messageEnvelope
+	_ = json.Unmarshal(msg.Data, &envelope)
+	return envelope
+}
+
+// messageRequestID returns the request ID of a NATS message: the payload
+// reqSeqId, else the X-Request-ID header, else a generated ID.
+func messageRequestID(msg *nats.Msg, envelope messageEnvelope) string {
+	if envelope.ReqSeqId != "" {
+		return envelope.ReqSeqId
+	}
+	if msg.Header != nil {
+		if requestID := msg.Header.Get(RequestIDHeader); requestID != "" {
+			return requestID
+		}
+	}
+	return uuid.NewString()
+}
+
+// newMessageContext derives the context for handling one NATS message.
+// The work is bounded by the handler timeout, shortened to the client
+// deadline when the request carries one, so that downstream HTTP and
+// database calls are cancelled once nobody waits for the reply anymore.
+// The context also carries the request ID of the message.
+func newMessageContext(msg *nats.Msg, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = DefaultMessageTimeout
+	}
+
+	envelope := readEnvelope(msg)
+	now := time.Now()
+	deadline := now.Add(timeout)
+	if clientDeadline, ok := clientDeadline(msg, envelope, now); ok && clientDeadline.Before(deadline) {
+		deadline = clientDeadline
+	}
+
+	ctx := logger.ContextWithRequestID(context.Background(), messageRequestID(msg, envelope))
+	return context.WithDeadline(ctx, deadline)
+}
+
+// clientDeadline returns the earliest deadline requested by the client.
+// Headers take precedence over the payload fields.
+func clientDeadline(msg *nats.Msg, envelope messageEnvelope, now time.Time) (time.Time, bool) {
+	fields := envelope
+	if msg.Header != nil {
+		headerDeadline, _ := strconv.ParseInt(msg.Header.Get(DeadlineHeader), 10, 64)
+		headerTimeout, _ := strconv.ParseInt(msg.Header.Get(TimeoutHeader), 10, 64)
+		if headerDeadline > 0 || headerTimeout > 0 {
+			fields.Deadline, fields.Timeout = headerDeadline, headerTimeout
+		}
+	}
+
+	var deadline time.Time
+	if fields.Deadline > 0 {
+		deadline = time.UnixMilli(fields.Deadline)
+	}
+	if fields.Timeout > 0 {
+		if byTimeout := now.Add(time.Duration(fields.Timeout) * time.Millisecond); deadline.IsZero() || byTimeout.Before(deadline) {
+			deadline = byTimeout
+		}
+	}
+
+	return deadline, !deadline.IsZero()
+}
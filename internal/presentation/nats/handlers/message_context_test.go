@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessageContext(t *testing.T) {
+	t.Run("uses handler timeout without client deadline", func(t *testing.T) {
+		ctx, cancel := newMessageContext(&nats.Msg{Data: []byte(`{"reqSeqId":"1"}`)}, time.Minute)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	})
+
+	t.Run("honors shorter payload timeout", func(t *testing.T) {
+		ctx, cancel := newMessageContext(&nats.Msg{Data: []byte(`{"reqSeqId":"1","timeout":500}`)}, time.Minute)
+		defer cancel()
+
+		deadline, _ := ctx.Deadline()
+		require.WithinDuration(t, time.Now().Add(500*time.Millisecond), deadline, 100*time.Millisecond)
+	})
+
+	t.Run("honors header deadline over payload", func(t *testing.T) {
+		clientDeadline := time.Now().Add(2 * time.Second)
+		msg := &nats.Msg{Header: nats.Header{}, Data: []byte(`{"timeout":500}`)}
+		msg.Header.Set(DeadlineHeader, strconv.FormatInt(clientDeadline.UnixMilli(), 10))
+
+		ctx, cancel := newMessageContext(msg, time.Minute)
+		defer cancel()
+
+		deadline, _ := ctx.Deadline()
+		require.WithinDuration(t, clientDeadline, deadline, 10*time.Millisecond)
+	})
+
+	t.Run("never extends past handler timeout", func(t *testing.T) {
+		msg := &nats.Msg{Header: nats.Header{}}
+		msg.Header.Set(TimeoutHeader, strconv.FormatInt(time.Hour.Milliseconds(), 10))
+
+		ctx, cancel := newMessageContext(msg, time.Second)
+		defer cancel()
+
+		deadline, _ := ctx.Deadline()
+		require.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+	})
+}
@@ -2,25 +2,21 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
-	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
-	"go.uber.org/zap"
 
 	"notification/internal/application/message/dtos"
 	"notification/internal/application/message/usecases"
+	"notification/internal/presentation/executor"
 	"notification/pkg/logger"
 )
 
 // MessageNATSHandler handles NATS messages for message operations
 type MessageNATSHandler struct {
-	sendUseCase *usecases.SendMessageUseCase
-	getUseCase  *usecases.GetMessageUseCase
-	listUseCase *usecases.ListMessagesUseCase
-	natsConn    *nats.Conn
+	executor executor.MessageExecutor
+	natsConn *nats.Conn
+	pipeline *Pipeline
 }
 
 // NewMessageNATSHandler creates a new NATS handler for message operations
@@ -30,23 +26,36 @@ func NewMessageNATSHandler(
 	listUseCase *usecases.ListMessagesUseCase,
 	natsConn *nats.Conn,
 ) *MessageNATSHandler {
+	return NewMessageNATSHandlerWithExecutor(
+		executor.NewUseCaseMessageExecutor(sendUseCase, getUseCase, listUseCase),
+		natsConn,
+	)
+}
+
+// NewMessageNATSHandlerWithExecutor creates a new NATS handler for message
+// operations that runs them on the given executor
+func NewMessageNATSHandlerWithExecutor(messageExecutor executor.MessageExecutor, natsConn *nats.Conn) *MessageNATSHandler {
 	return &MessageNATSHandler{
-		sendUseCase: sendUseCase,
-		getUseCase:  getUseCase,
-		listUseCase: listUseCase,
-		natsConn:    natsConn,
+		executor: messageExecutor,
+		natsConn: natsConn,
+		pipeline: DefaultPipeline(),
 	}
 }
 
+// SetPipeline sets the middleware pipeline the handlers run behind
+func (h *MessageNATSHandler) SetPipeline(pipeline *Pipeline) {
+	h.pipeline = pipeline
+}
+
 // RegisterHandlers registers all NATS message handlers for message operations
 func (h *MessageNATSHandler) RegisterHandlers() error {
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.message.send", h.handleSendMessage); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectMessageSend), h.pipeline.Handle(h.handleSendMessage)); err != nil {
 		return fmt.Errorf("failed to subscribe to send message topic: %w", err)
 	}
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.message.get", h.handleGetMessage); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectMessageGet), h.pipeline.Handle(h.handleGetMessage)); err != nil {
 		return fmt.Errorf("failed to subscribe to get message topic: %w", err)
 	}
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.message.list", h.handleListMessages); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectMessageList), h.pipeline.Handle(h.handleListMessages)); err != nil {
 		return fmt.Errorf("failed to subscribe to list messages topic: %w", err)
 	}
 	logger.Info("Message NATS handlers registered successfully")
@@ -54,159 +63,43 @@ func (h *MessageNATSHandler) RegisterHandlers() error {
 }
 
 // handleSendMessage handles send message NATS messages
-func (h *MessageNATSHandler) handleSendMessage(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received send message NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	dataBytes, err := json.Marshal(natsReq.Data)
-	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-		return
-	}
-
+func (h *MessageNATSHandler) handleSendMessage(ctx context.Context, req *Request) (interface{}, error) {
 	var request dtos.SendMessageRequest
-	if err := json.Unmarshal(dataBytes, &request); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse send message request", err.Error())
-		return
+	if err := req.Bind(&request); err != nil {
+		return nil, err
 	}
 
-	// Execute the send message use case
-	response, err := h.sendUseCase.Execute(ctx, &request)
+	response, err := h.executor.Send(ctx, &request)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to send message", err.Error())
-		return
+		return nil, executionError("Failed to send message", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleGetMessage handles get message NATS messages
-func (h *MessageNATSHandler) handleGetMessage(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received get message NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	messageID, ok := natsReq.Data.(string)
-	if !ok {
-		if dataMap, ok := natsReq.Data.(map[string]interface{}); ok {
-			if id, exists := dataMap["messageId"]; exists {
-				messageID, _ = id.(string)
-			}
-		}
-	}
-
+func (h *MessageNATSHandler) handleGetMessage(ctx context.Context, req *Request) (interface{}, error) {
+	messageID := req.ID("messageId")
 	if messageID == "" {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Message ID is required", "")
-		return
+		return nil, invalidRequest("Message ID is required")
 	}
 
-	response, err := h.getUseCase.Execute(ctx, messageID)
+	response, err := h.executor.Get(ctx, messageID)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to get message", err.Error())
-		return
+		return nil, executionError("Failed to get message", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleListMessages handles list messages NATS messages
-func (h *MessageNATSHandler) handleListMessages(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received list messages NATS message",
-		zap.String("subject", msg.Subject),
-		zap.String("reply", msg.Reply),
-	)
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
+func (h *MessageNATSHandler) handleListMessages(ctx context.Context, req *Request) (interface{}, error) {
 	var request dtos.ListMessagesRequest
-	if natsReq.Data != nil {
-		dataBytes, err := json.Marshal(natsReq.Data)
-		if err != nil {
-			h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-			return
-		}
-
-		if err := json.Unmarshal(dataBytes, &request); err != nil {
-			h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse list messages request", err.Error())
-			return
-		}
-	}
-
-	response, err := h.listUseCase.Execute(ctx, &request)
-	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to list messages", err.Error())
-		return
-	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
-}
-
-// sendSuccessResponse sends a success response via NATS
-func (h *MessageNATSHandler) sendSuccessResponse(msg *nats.Msg, reqSeqId string, data interface{}) {
-	rspId, _ := uuid.NewRandom()
-	response := NATSResponse{
-		ReqSeqId:  reqSeqId,
-		RspSeqId:  rspId.String(),
-		Success:   true,
-		Data:      data,
-		Timestamp: time.Now().UnixMilli(),
+	if err := req.Bind(&request); err != nil {
+		return nil, err
 	}
 
-	responseBytes, err := json.Marshal(response)
+	response, err := h.executor.List(ctx, &request)
 	if err != nil {
-		logger.Error("Failed to marshal success response", zap.Error(err))
-		return
-	}
-
-	if err := msg.Respond(responseBytes); err != nil {
-		logger.Error("Failed to send success response", zap.Error(err))
-	}
-}
-
-// sendErrorResponse sends an error response via NATS
-func (h *MessageNATSHandler) sendErrorResponse(msg *nats.Msg, reqSeqId, code, message, details string) {
-	rspId, _ := uuid.NewRandom()
-	response := NATSResponse{
-		ReqSeqId: reqSeqId,
-		RspSeqId: rspId.String(),
-		Success:  false,
-		Error: &NATSError{
-			Code:    code,
-			Message: message,
-			Details: details,
-		},
-		Timestamp: time.Now().UnixMilli(),
-	}
-
-	responseBytes, err := json.Marshal(response)
-	if err != nil {
-		logger.Error("Failed to marshal error response", zap.Error(err))
-		return
-	}
-
-	if err := msg.Respond(responseBytes); err != nil {
-		logger.Error("Failed to send error response", zap.Error(err))
+		return nil, executionError("Failed to list messages", err)
 	}
+	return response, nil
 }
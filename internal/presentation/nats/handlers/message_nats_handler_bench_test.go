@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+
+	"notification/internal/sendbench"
+	"notification/pkg/logger"
+)
+
+// BenchmarkMessageNATSHandler_SendMessage sends messages over an in-process
+// NATS server through the pipeline and handler, against the in-memory send
+// pipeline. The subscription handles one message at a time, as in production.
+func BenchmarkMessageNATSHandler_SendMessage(b *testing.B) {
+	if err := logger.InitGlobalLogger(sendbench.LoggerConfig()); err != nil {
+		b.Fatal(err)
+	}
+
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1})
+	if err != nil {
+		b.Fatal(err)
+	}
+	go ns.Start()
+	if !ns.ReadyForConnections(5 * time.Second) {
+		b.Fatal("NATS server not ready")
+	}
+	defer ns.Shutdown()
+
+	configs := []struct {
+		name   string
+		config sendbench.Config
+	}{
+		{"instant", sendbench.Config{}},
+		{"latency", sendbench.Config{QueryLatency: 200 * time.Microsecond, ProviderLatency: 2 * time.Millisecond}},
+	}
+	for _, bc := range configs {
+		b.Run(bc.name, func(b *testing.B) {
+			p, err := sendbench.NewPipeline(bc.config)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			nc, err := nats.Connect(ns.ClientURL())
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer nc.Close()
+
+			handler := NewMessageNATSHandler(p.SendUseCase, nil, nil, nc)
+			if err := handler.RegisterHandlers(); err != nil {
+				b.Fatal(err)
+			}
+
+			data, err := json.Marshal(NATSRequest{Version: EnvelopeVersion, Data: p.Request()})
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.SetParallelism(8)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					start := time.Now()
+					reply, err := nc.Request(fullSubject(SubjectMessageSend), data, 10*time.Second)
+					if err != nil {
+						b.Error(err)
+						return
+					}
+					var response NATSResponse
+					if err := json.Unmarshal(reply.Data, &response); err != nil || !response.Success {
+						b.Errorf("send failed: %s", reply.Data)
+						return
+					}
+					p.Latencies.Record(time.Since(start))
+				}
+			})
+			b.StopTimer()
+			p.ReportMetrics(b)
+		})
+	}
+}
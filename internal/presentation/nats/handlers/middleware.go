@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification/internal/domain/shared"
+	"notification/pkg/jsonutil"
+	"notification/pkg/logger"
+)
+
+// RecoveryMiddleware turns a panic in a later handler into an INTERNAL_ERROR
+// response, logging it with its stack trace, instead of killing the
+// subscription goroutine and leaving the requester waiting
+func RecoveryMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (data interface{}, err error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+
+				logger.Error("Recovered from panic in NATS handler",
+					zap.String("request_id", logger.RequestIDFromContext(ctx)),
+					zap.String("subject", req.Msg.Subject),
+					zap.String("panic", fmt.Sprint(recovered)),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				data, err = nil, NewRequestError("INTERNAL_ERROR", "Internal server error", "")
+			}()
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// LoggingMiddleware logs every handled message with its subject, request ID
+// and latency, like HTTP requests are
+func LoggingMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			start := time.Now()
+
+			data, err := next(ctx, req)
+
+			fields := []zap.Field{
+				zap.String("request_id", logger.RequestIDFromContext(ctx)),
+				zap.String("subject", req.Msg.Subject),
+				zap.Duration("latency", time.Since(start)),
+				zap.Int("request_size", len(req.Msg.Data)),
+			}
+			if err != nil {
+				fields = append(fields, zap.String("code", errorCode(err)), zap.Error(err))
+				logger.Warn("NATS Request", fields...)
+			} else {
+				logger.Info("NATS Request", fields...)
+			}
+
+			return data, err
+		}
+	}
+}
+
+// errorCode returns the response code for a handler error
+func errorCode(err error) string {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.Code
+	}
+	return "EXECUTION_ERROR"
+}
+
+// SubjectMetrics holds the counters of one subject
+type SubjectMetrics struct {
+	Requests     int64         `json:"requests"`
+	Failures     int64         `json:"failures"`
+	TotalLatency time.Duration `json:"totalLatency"`
+}
+
+// MessageMetrics collects per-subject NATS request counters
+type MessageMetrics struct {
+	mu       sync.Mutex
+	subjects map[string]*SubjectMetrics
+}
+
+// NewMessageMetrics creates an empty metrics collector
+func NewMessageMetrics() *MessageMetrics {
+	return &MessageMetrics{
+		subjects: make(map[string]*SubjectMetrics),
+	}
+}
+
+// Snapshot returns a copy of the counters per subject
+func (m *MessageMetrics) Snapshot() map[string]SubjectMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]SubjectMetrics, len(m.subjects))
+	for subject, metrics := range m.subjects {
+		snapshot[subject] = *metrics
+	}
+	return snapshot
+}
+
+// record adds one handled message to the counters
+func (m *MessageMetrics) record(subject string, latency time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics, ok := m.subjects[subject]
+	if !ok {
+		metrics = &SubjectMetrics{}
+		m.subjects[subject] = metrics
+	}
+	metrics.Requests++
+	metrics.TotalLatency += latency
+	if failed {
+		metrics.Failures++
+	}
+}
+
+// MetricsMiddleware records every handled message in the metrics collector
+func MetricsMiddleware(metrics *MessageMetrics) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			start := time.Now()
+
+			data, err := next(ctx, req)
+
+			metrics.record(req.Msg.Subject, time.Since(start), err != nil)
+			return data, err
+		}
+	}
+}
+
+// PayloadLimits bounds the NATS request payloads accepted by the handlers
+type PayloadLimits struct {
+	// MaxBytes is the largest accepted payload
+	MaxBytes int
+	// MaxJSONDepth is the deepest accepted nesting of JSON objects and arrays
+	MaxJSONDepth int
+}
+
+// DefaultPayloadLimits returns the default payload limits
+func DefaultPayloadLimits() PayloadLimits {
+	return PayloadLimits{
+		MaxBytes:     1 << 20, // 1 MiB
+		MaxJSONDepth: 32,
+	}
+}
+
+// PayloadLimitMiddleware rejects oversized payloads and JSON payloads that
+// nest too deeply before they are decoded. A gzip compressed payload is
+// decompressed in place and its decompressed size is held to the same limit.
+// Zero limits use the defaults.
+func PayloadLimitMiddleware(limits PayloadLimits) Middleware {
+	defaults := DefaultPayloadLimits()
+	if limits.MaxBytes <= 0 {
+		limits.MaxBytes = defaults.MaxBytes
+	}
+	if limits.MaxJSONDepth <= 0 {
+		limits.MaxJSONDepth = defaults.MaxJSONDepth
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			if len(req.Msg.Data) > limits.MaxBytes {
+				return nil, NewRequestError("PAYLOAD_TOO_LARGE",
+					fmt.Sprintf("payload exceeds the limit of %d bytes", limits.MaxBytes), "")
+			}
+
+			data, err := decodePayload(req.Msg, limits.MaxBytes)
+			if err != nil {
+				return nil, err
+			}
+			req.Msg.Data = data
+			req.Msg.Header.Del(HeaderContentEncoding)
+
+			if err := jsonutil.CheckDepth(req.Msg.Data, limits.MaxJSONDepth); err != nil {
+				return nil, NewRequestError("INVALID_REQUEST", err.Error(), "")
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// DecodeMiddleware decodes the NATSRequest envelope into the request
+func DecodeMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			var envelope struct {
+				ReqSeqId string          `json:"reqSeqId"`
+				Version  int             `json:"version"`
+				Data     json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(req.Msg.Data, &envelope); err != nil {
+				return nil, NewRequestError("INVALID_REQUEST", "Failed to parse request", err.Error())
+			}
+
+			req.ReqSeqId = envelope.ReqSeqId
+			req.Version = envelope.Version
+			req.Data = envelope.Data
+			return next(ctx, req)
+		}
+	}
+}
+
+// AuthMiddleware accepts only messages carrying a known API key in the
+// X-API-Key header or as an Authorization bearer token
+func AuthMiddleware(apiKeys map[string]string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			var apiKey string
+			if req.Msg.Header != nil {
+				apiKey = req.Msg.Header.Get("X-API-Key")
+				if apiKey == "" {
+					apiKey = strings.TrimPrefix(req.Msg.Header.Get("Authorization"), "Bearer ")
+				}
+			}
+
+			clientID, ok := apiKeys[apiKey]
+			if apiKey == "" || !ok {
+				return nil, NewRequestError("UNAUTHORIZED", "Invalid or missing API key", "")
+			}
+
+			req.ClientID = clientID
+			return next(shared.WithActor(ctx, clientID), req)
+		}
+	}
+}
+
+// ReadOnlyMiddleware rejects command requests with READ_ONLY while the
+// instance is in read-only mode; queries are still answered
+func ReadOnlyMiddleware(mode *shared.ReadOnlyMode) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *Request) (interface{}, error) {
+			if commandSubjects[req.Msg.Subject] && mode.Enabled() {
+				return nil, NewRequestError(shared.ErrCodeReadOnly, "The instance is in read-only mode", "commands are rejected until it leaves read-only mode")
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	"notification/internal/domain/shared"
+	"notification/pkg/logger"
+)
+
+// HandlerFunc handles a decoded NATS request and returns the response data
+type HandlerFunc func(ctx context.Context, req *Request) (interface{}, error)
+
+// Middleware wraps a HandlerFunc with cross-cutting behaviour
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Chain composes middlewares so that the first one runs outermost
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// Request is a NATS request flowing through the pipeline
+type Request struct {
+	Msg      *nats.Msg
+	ReqSeqId string
+	// Version is the envelope version, zero for the former unversioned format
+	Version int
+	Data    json.RawMessage
+	// ClientID identifies the authenticated client when authentication is enabled
+	ClientID string
+
+	// answered is set when the handler replied to the message itself
+	answered bool
+}
+
+// Bind decodes the request data into v and validates it against its binding
+// tags, like the HTTP handlers do. Missing data leaves v unchanged.
+func (r *Request) Bind(v interface{}) error {
+	if len(r.Data) == 0 || string(r.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return NewRequestError("INVALID_REQUEST", "Failed to parse request data", err.Error())
+	}
+	if err := binding.Validator.ValidateStruct(v); err != nil {
+		return NewRequestError("INVALID_REQUEST", "Request validation failed", err.Error())
+	}
+	return nil
+}
+
+// ID returns the request data when it is a JSON string, or the named field when it is an object
+func (r *Request) ID(field string) string {
+	var id string
+	if err := json.Unmarshal(r.Data, &id); err == nil {
+		return id
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(r.Data, &fields); err == nil {
+		id, _ = fields[field].(string)
+	}
+	return id
+}
+
+// RequestError is a handler error answered with its own code, message and details
+type RequestError struct {
+	Code    string
+	Message string
+	Details string
+	cause   error
+}
+
+// NewRequestError creates a request error
+func NewRequestError(code, message, details string) *RequestError {
+	return &RequestError{Code: code, Message: message, Details: details}
+}
+
+// invalidRequest creates an INVALID_REQUEST error for a malformed request
+func invalidRequest(message string) *RequestError {
+	return NewRequestError("INVALID_REQUEST", message, "")
+}
+
+// executionError creates an EXECUTION_ERROR error for a failed use case, or a
+// RETRY_LATER error when the use case turned the request away until later
+func executionError(message string, err error) *RequestError {
+	code := "EXECUTION_ERROR"
+	if shared.RetryAfterOf(err) > 0 {
+		code = "RETRY_LATER"
+	}
+	return &RequestError{Code: code, Message: message, Details: err.Error(), cause: err}
+}
+
+// Error implements error
+func (e *RequestError) Error() string {
+	if e.Details == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Message, e.Details)
+}
+
+// Unwrap returns the underlying error
+func (e *RequestError) Unwrap() error {
+	return e.cause
+}
+
+// PipelineConfig configures the middleware pipeline shared by the NATS handlers
+type PipelineConfig struct {
+	// MessageTimeout bounds the handling of one message; zero uses DefaultMessageTimeout
+	MessageTimeout time.Duration
+	// PayloadLimits bounds the accepted payloads; zero fields use the defaults
+	PayloadLimits PayloadLimits
+	// MaxResponseBytes bounds the sent responses; zero uses DefaultMaxResponseBytes
+	MaxResponseBytes int
+	// Uploads holds the chunked template content uploads; nil creates a store with the default limits
+	Uploads *ContentUploads
+	// APIKeys maps accepted API keys to client IDs; empty disables authentication
+	APIKeys map[string]string
+	// RequireVersion rejects requests in the former unversioned envelope format
+	RequireVersion bool
+	// Metrics collects per-subject counters when set
+	Metrics *MessageMetrics
+	// Inbox answers retried command requests with their stored response when set
+	Inbox *Inbox
+	// ReadOnly rejects command requests while the instance is read-only when set
+	ReadOnly *shared.ReadOnlyMode
+}
+
+// Pipeline runs NATS messages through the middleware chain and answers them
+type Pipeline struct {
+	messageTimeout   time.Duration
+	maxResponseBytes int
+	uploads          *ContentUploads
+	middleware       Middleware
+}
+
+// NewPipeline creates a pipeline with recovery, logging, metrics, payload
+// limits, envelope decoding, authentication, the read-only check, the inbox,
+// content upload resolution and contract validation, in that order
+func NewPipeline(config PipelineConfig) *Pipeline {
+	if config.MaxResponseBytes <= 0 {
+		config.MaxResponseBytes = DefaultMaxResponseBytes
+	}
+	if config.Uploads == nil {
+		config.Uploads = NewContentUploads(0, 0)
+	}
+
+	middlewares := []Middleware{
+		RecoveryMiddleware(),
+		LoggingMiddleware(),
+	}
+	if config.Metrics != nil {
+		middlewares = append(middlewares, MetricsMiddleware(config.Metrics))
+	}
+	middlewares = append(middlewares,
+		PayloadLimitMiddleware(config.PayloadLimits),
+		DecodeMiddleware(),
+	)
+	if len(config.APIKeys) > 0 {
+		middlewares = append(middlewares, AuthMiddleware(config.APIKeys))
+	}
+	if config.ReadOnly != nil {
+		middlewares = append(middlewares, ReadOnlyMiddleware(config.ReadOnly))
+	}
+	// The inbox runs before the content upload resolution, which consumes
+	// the upload a retried request still references
+	if config.Inbox != nil {
+		middlewares = append(middlewares, config.Inbox.Middleware())
+	}
+	middlewares = append(middlewares,
+		ContentUploadMiddleware(config.Uploads),
+		ContractMiddleware(subjectContracts(), config.RequireVersion),
+	)
+
+	return &Pipeline{
+		messageTimeout:   config.MessageTimeout,
+		maxResponseBytes: config.MaxResponseBytes,
+		uploads:          config.Uploads,
+		middleware:       Chain(middlewares...),
+	}
+}
+
+// DefaultPipeline creates a pipeline with the default configuration
+func DefaultPipeline() *Pipeline {
+	return NewPipeline(PipelineConfig{})
+}
+
+// Uploads returns the store of the chunked template content uploads
+func (p *Pipeline) Uploads() *ContentUploads {
+	return p.uploads
+}
+
+// Handle adapts a HandlerFunc into a NATS message handler. The returned data
+// is sent as a success response; an error is sent as an error response.
+func (p *Pipeline) Handle(handler HandlerFunc) nats.MsgHandler {
+	wrapped := p.middleware(handler)
+
+	return func(msg *nats.Msg) {
+		ctx, cancel := newMessageContext(msg, p.messageTimeout)
+		defer cancel()
+
+		req := &Request{Msg: msg}
+		data, err := wrapped(ctx, req)
+		if req.answered {
+			return
+		}
+		if err != nil {
+			respondError(msg, req.ReqSeqId, err, p.maxResponseBytes)
+			return
+		}
+		respondSuccess(msg, req.ReqSeqId, data, p.maxResponseBytes)
+	}
+}
+
+// HandleRaw adapts a handler that decodes and answers messages itself, so
+// that it still runs behind the pipeline middleware
+func (p *Pipeline) HandleRaw(handler func(ctx context.Context, msg *nats.Msg)) nats.MsgHandler {
+	return p.Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+		handler(ctx, req.Msg)
+		req.answered = true
+		return nil, nil
+	})
+}
+
+// respondSuccess sends a success response via NATS. Zero maxBytes uses DefaultMaxResponseBytes.
+func respondSuccess(msg *nats.Msg, reqSeqId string, data interface{}, maxBytes int) {
+	rspId, _ := uuid.NewRandom()
+	respond(msg, NATSResponse{
+		ReqSeqId:  reqSeqId,
+		RspSeqId:  rspId.String(),
+		Version:   EnvelopeVersion,
+		Success:   true,
+		Data:      data,
+		Timestamp: time.Now().UnixMilli(),
+	}, maxBytes)
+}
+
+// respondError sends an error response via NATS. Zero maxBytes uses DefaultMaxResponseBytes.
+func respondError(msg *nats.Msg, reqSeqId string, err error, maxBytes int) {
+	var requestErr *RequestError
+	if !errors.As(err, &requestErr) {
+		requestErr = executionError("Failed to handle request", err)
+	}
+
+	rspId, _ := uuid.NewRandom()
+	respond(msg, NATSResponse{
+		ReqSeqId: reqSeqId,
+		RspSeqId: rspId.String(),
+		Version:  EnvelopeVersion,
+		Success:  false,
+		Error: &NATSError{
+			Code:       requestErr.Code,
+			Message:    requestErr.Message,
+			Details:    requestErr.Details,
+			RetryAfter: int(math.Ceil(shared.RetryAfterOf(requestErr).Seconds())),
+		},
+		Timestamp: time.Now().UnixMilli(),
+	}, maxBytes)
+}
+
+// respond marshals and sends a response. Messages without a reply subject
+// are not answered. The response is gzip compressed when the requester
+// accepts it, and a response that still exceeds maxBytes is replaced by a
+// RESPONSE_TOO_LARGE error instead of being dropped by the server.
+func respond(msg *nats.Msg, response NATSResponse, maxBytes int) {
+	if msg.Reply == "" {
+		return
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxResponseBytes
+	}
+
+	reply, err := encodeResponse(msg, response)
+	if err != nil {
+		logger.Error("Failed to marshal response", zap.Error(err))
+		return
+	}
+
+	if len(reply.Data) > maxBytes {
+		logger.Warn("NATS response exceeds the payload limit",
+			zap.String("subject", msg.Subject),
+			zap.Int("size", len(reply.Data)),
+			zap.Int("limit", maxBytes),
+		)
+		response.Success = false
+		response.Data = nil
+		response.Error = &NATSError{
+			Code:    "RESPONSE_TOO_LARGE",
+			Message: fmt.Sprintf("response exceeds the limit of %d bytes", maxBytes),
+			Details: fmt.Sprintf("the response has %d bytes; page the request or send %s: %s", len(reply.Data), HeaderAcceptEncoding, encodingGzip),
+		}
+		if reply, err = encodeResponse(msg, response); err != nil {
+			logger.Error("Failed to marshal response", zap.Error(err))
+			return
+		}
+	}
+
+	if err := msg.RespondMsg(reply); err != nil {
+		logger.Error("Failed to send response", zap.Error(err))
+	}
+}
+
+// encodeResponse marshals a response into a reply message, compressing
+// responses worth compressing when the requester accepts gzip
+func encodeResponse(msg *nats.Msg, response NATSResponse) (*nats.Msg, error) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &nats.Msg{Data: data}
+	if len(data) >= minCompressBytes && acceptsGzip(msg) {
+		if reply.Data, err = gzipBytes(data); err != nil {
+			return nil, err
+		}
+		reply.Header = nats.Header{}
+		reply.Header.Set(HeaderContentEncoding, encodingGzip)
+	}
+	return reply, nil
+}
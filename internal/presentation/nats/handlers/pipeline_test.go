@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"notification/internal/domain/shared"
+)
+
+func TestPipeline(t *testing.T) {
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1})
+	require.NoError(t, err)
+	go ns.Start()
+	require.True(t, ns.ReadyForConnections(5*time.Second))
+	defer ns.Shutdown()
+
+	nc, err := nats.Connect(ns.ClientURL())
+	require.NoError(t, err)
+	defer nc.Close()
+
+	request := func(t *testing.T, msg *nats.Msg) NATSResponse {
+		reply, err := nc.RequestMsg(msg, 2*time.Second)
+		require.NoError(t, err)
+
+		var response NATSResponse
+		require.NoError(t, json.Unmarshal(reply.Data, &response))
+		return response
+	}
+
+	t.Run("recovers from panics", func(t *testing.T) {
+		_, err := nc.Subscribe("test.panic", DefaultPipeline().Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			panic("boom")
+		}))
+		require.NoError(t, err)
+
+		response := request(t, &nats.Msg{Subject: "test.panic", Data: []byte(`{"reqSeqId":"req-1"}`)})
+		require.False(t, response.Success)
+		require.Equal(t, "req-1", response.ReqSeqId)
+		require.Equal(t, "INTERNAL_ERROR", response.Error.Code)
+		require.NotContains(t, response.Error.Message, "boom")
+	})
+
+	t.Run("authenticates API keys", func(t *testing.T) {
+		pipeline := NewPipeline(PipelineConfig{APIKeys: map[string]string{"secret": "client-1"}})
+		_, err := nc.Subscribe("test.auth", pipeline.Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			return req.ClientID, nil
+		}))
+		require.NoError(t, err)
+
+		response := request(t, &nats.Msg{Subject: "test.auth", Data: []byte(`{"reqSeqId":"req-2"}`)})
+		require.False(t, response.Success)
+		require.Equal(t, "UNAUTHORIZED", response.Error.Code)
+
+		msg := &nats.Msg{Subject: "test.auth", Header: nats.Header{}, Data: []byte(`{"reqSeqId":"req-3"}`)}
+		msg.Header.Set("X-API-Key", "secret")
+		response = request(t, msg)
+		require.True(t, response.Success)
+		require.Equal(t, "client-1", response.Data)
+	})
+
+	t.Run("validates versioned requests against the contract", func(t *testing.T) {
+		subject := fullSubject(SubjectChannelGet)
+		_, err := nc.Subscribe(subject, DefaultPipeline().Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			return req.ID("channelId"), nil
+		}))
+		require.NoError(t, err)
+
+		// The former unversioned format is accepted as before
+		response := request(t, &nats.Msg{Subject: subject, Data: []byte(`{"data":"ch-1"}`)})
+		require.True(t, response.Success)
+		require.Equal(t, EnvelopeVersion, response.Version)
+
+		response = request(t, &nats.Msg{Subject: subject, Data: []byte(`{"version":1,"data":"ch-1"}`)})
+		require.False(t, response.Success)
+		require.Equal(t, "INVALID_REQUEST", response.Error.Code)
+
+		response = request(t, &nats.Msg{Subject: subject, Data: []byte(`{"version":1,"data":{"channelId":"ch-1"}}`)})
+		require.True(t, response.Success)
+		require.Equal(t, "ch-1", response.Data)
+
+		response = request(t, &nats.Msg{Subject: subject, Data: []byte(`{"version":2,"data":{"channelId":"ch-1"}}`)})
+		require.False(t, response.Success)
+		require.Equal(t, "UNSUPPORTED_VERSION", response.Error.Code)
+	})
+
+	t.Run("requires a version when configured", func(t *testing.T) {
+		pipeline := NewPipeline(PipelineConfig{RequireVersion: true})
+		_, err := nc.Subscribe("test.version", pipeline.Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			return nil, nil
+		}))
+		require.NoError(t, err)
+
+		response := request(t, &nats.Msg{Subject: "test.version", Data: []byte(`{"reqSeqId":"req-4"}`)})
+		require.False(t, response.Success)
+		require.Equal(t, "UNSUPPORTED_VERSION", response.Error.Code)
+
+		response = request(t, &nats.Msg{Subject: "test.version", Data: []byte(`{"reqSeqId":"req-5","version":1}`)})
+		require.True(t, response.Success)
+	})
+
+	t.Run("negotiates gzip compression", func(t *testing.T) {
+		pipeline := NewPipeline(PipelineConfig{PayloadLimits: PayloadLimits{MaxBytes: 4 << 10}})
+		_, err := nc.Subscribe("test.gzip", pipeline.Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			var data string
+			return data, json.Unmarshal(req.Data, &data)
+		}))
+		require.NoError(t, err)
+
+		content := strings.Repeat("a", 2<<10)
+		payload, err := gzipBytes([]byte(`{"reqSeqId":"req-6","data":"` + content + `"}`))
+		require.NoError(t, err)
+		msg := &nats.Msg{Subject: "test.gzip", Header: nats.Header{}, Data: payload}
+		msg.Header.Set(HeaderContentEncoding, "gzip")
+		msg.Header.Set(HeaderAcceptEncoding, "gzip")
+
+		reply, err := nc.RequestMsg(msg, 2*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, "gzip", reply.Header.Get(HeaderContentEncoding))
+		reader, err := gzip.NewReader(bytes.NewReader(reply.Data))
+		require.NoError(t, err)
+		var response NATSResponse
+		require.NoError(t, json.NewDecoder(reader).Decode(&response))
+		require.True(t, response.Success)
+		require.Equal(t, content, response.Data)
+
+		// The decompressed size is held to the payload limit
+		payload, err = gzipBytes([]byte(`{"data":"` + strings.Repeat("a", 8<<10) + `"}`))
+		require.NoError(t, err)
+		msg = &nats.Msg{Subject: "test.gzip", Header: nats.Header{}, Data: payload}
+		msg.Header.Set(HeaderContentEncoding, "gzip")
+		response = request(t, msg)
+		require.False(t, response.Success)
+		require.Equal(t, "PAYLOAD_TOO_LARGE", response.Error.Code)
+	})
+
+	t.Run("rejects responses over the limit", func(t *testing.T) {
+		pipeline := NewPipeline(PipelineConfig{MaxResponseBytes: 1 << 10})
+		_, err := nc.Subscribe("test.large", pipeline.Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			return strings.Repeat("a", 2<<10), nil
+		}))
+		require.NoError(t, err)
+
+		response := request(t, &nats.Msg{Subject: "test.large", Data: []byte(`{"reqSeqId":"req-7"}`)})
+		require.False(t, response.Success)
+		require.Equal(t, "req-7", response.ReqSeqId)
+		require.Equal(t, "RESPONSE_TOO_LARGE", response.Error.Code)
+	})
+
+	t.Run("tells when to retry saturated requests", func(t *testing.T) {
+		_, err := nc.Subscribe("test.saturated", DefaultPipeline().Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			return nil, executionError("Failed to send message", shared.NewRetryLaterError("the send queue is full", 1500*time.Millisecond))
+		}))
+		require.NoError(t, err)
+
+		response := request(t, &nats.Msg{Subject: "test.saturated", Data: []byte(`{"reqSeqId":"req-8"}`)})
+		require.False(t, response.Success)
+		require.Equal(t, "RETRY_LATER", response.Error.Code)
+		require.Equal(t, 2, response.Error.RetryAfter)
+	})
+
+	t.Run("rejects commands in read-only mode", func(t *testing.T) {
+		mode := shared.NewReadOnlyMode(true)
+		pipeline := NewPipeline(PipelineConfig{ReadOnly: mode})
+		for _, subject := range []string{SubjectChannelDelete, SubjectChannelList} {
+			_, err := nc.Subscribe(fullSubject(subject), pipeline.Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+				return "done", nil
+			}))
+			require.NoError(t, err)
+		}
+
+		response := request(t, &nats.Msg{Subject: fullSubject(SubjectChannelDelete), Data: []byte(`{"reqSeqId":"req-9","data":"ch-1"}`)})
+		require.False(t, response.Success)
+		require.Equal(t, shared.ErrCodeReadOnly, response.Error.Code)
+
+		response = request(t, &nats.Msg{Subject: fullSubject(SubjectChannelList), Data: []byte(`{"reqSeqId":"req-10"}`)})
+		require.True(t, response.Success)
+
+		mode.Set(false)
+		response = request(t, &nats.Msg{Subject: fullSubject(SubjectChannelDelete), Data: []byte(`{"reqSeqId":"req-11","data":"ch-1"}`)})
+		require.True(t, response.Success)
+	})
+
+	t.Run("resolves chunked content uploads", func(t *testing.T) {
+		pipeline := NewPipeline(PipelineConfig{})
+		_, err := nc.Subscribe(fullSubject(SubjectTemplateCreate), pipeline.Handle(func(ctx context.Context, req *Request) (interface{}, error) {
+			var fields map[string]interface{}
+			return fields["content"], json.Unmarshal(req.Data, &fields)
+		}))
+		require.NoError(t, err)
+
+		uploads := pipeline.Uploads()
+		first, err := uploads.Append("", &ContentChunk{Content: "Hello, "})
+		require.NoError(t, err)
+		_, err = uploads.Append("", &ContentChunk{UploadID: first.UploadID, Sequence: 2, Content: "{{name}}"})
+		require.Error(t, err)
+		last, err := uploads.Append("", &ContentChunk{UploadID: first.UploadID, Sequence: 1, Content: "{{name}}", Final: true})
+		require.NoError(t, err)
+		require.True(t, last.Complete)
+		require.Equal(t, len("Hello, {{name}}"), last.Size)
+
+		data := `{"version":1,"data":{"name":"welcome","channelType":"email","contentUploadId":"` + first.UploadID + `"}}`
+		response := request(t, &nats.Msg{Subject: fullSubject(SubjectTemplateCreate), Data: []byte(data)})
+		require.True(t, response.Success, "%+v", response.Error)
+		require.Equal(t, "Hello, {{name}}", response.Data)
+
+		// An upload is consumed by the request referencing it
+		response = request(t, &nats.Msg{Subject: fullSubject(SubjectTemplateCreate), Data: []byte(data)})
+		require.False(t, response.Success)
+		require.Equal(t, "UPLOAD_NOT_FOUND", response.Error.Code)
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	channel_dtos "notification/internal/application/channel/dtos"
+	message_dtos "notification/internal/application/message/dtos"
+	template_dtos "notification/internal/application/template/dtos"
+)
+
+// SubjectPrefix prefixes the subjects the handlers subscribe to
+const SubjectPrefix = "eco1j.infra.eventcenter"
+
+// Request/reply subjects of the NATS API, relative to SubjectPrefix
+const (
+	SubjectChannelCreate  = "channel.create"
+	SubjectChannelGet     = "channel.get"
+	SubjectChannelList    = "channel.list"
+	SubjectChannelUpdate  = "channel.update"
+	SubjectChannelDelete  = "channel.delete"
+	SubjectChannelEnable  = "channel.enable"
+	SubjectChannelDisable = "channel.disable"
+
+	SubjectTemplateCreate = "template.create"
+	SubjectTemplateGet    = "template.get"
+	SubjectTemplateList   = "template.list"
+	SubjectTemplateUpdate = "template.update"
+	SubjectTemplateDelete = "template.delete"
+
+	SubjectTemplateContentUpload = "template.content.upload"
+
+	SubjectMessageSend = "message.send"
+	SubjectMessageGet  = "message.get"
+	SubjectMessageList = "message.list"
+)
+
+// SubjectSpec describes a request/reply subject. Request and Response are
+// values of the types carried in the data field of the request and response
+// envelopes.
+type SubjectSpec struct {
+	Subject  string
+	Summary  string
+	Request  interface{}
+	Response interface{}
+}
+
+// Subjects is the registry of the request/reply subjects served by the
+// handlers. The AsyncAPI document is generated from it, so a new subject
+// must be added here as well.
+var Subjects = []SubjectSpec{
+	{SubjectChannelCreate, "Create a channel", channel_dtos.CreateChannelRequest{}, channel_dtos.ChannelResponse{}},
+	{SubjectChannelGet, "Get a channel", ChannelIDRequest{}, channel_dtos.ChannelResponse{}},
+	{SubjectChannelList, "List channels", ListChannelsRequest{}, channel_dtos.ListChannelsResponse{}},
+	{SubjectChannelUpdate, "Update a channel", channel_dtos.UpdateChannelRequest{}, channel_dtos.ChannelResponse{}},
+	{SubjectChannelDelete, "Delete a channel", ChannelIDRequest{}, channel_dtos.DeleteChannelResponse{}},
+	{SubjectChannelEnable, "Enable a channel", ChannelIDRequest{}, channel_dtos.ChannelResponse{}},
+	{SubjectChannelDisable, "Disable a channel", ChannelIDRequest{}, channel_dtos.ChannelResponse{}},
+
+	{SubjectTemplateCreate, "Create a template", template_dtos.CreateTemplateRequest{}, template_dtos.TemplateResponse{}},
+	{SubjectTemplateGet, "Get a template", TemplateIDRequest{}, template_dtos.TemplateResponse{}},
+	{SubjectTemplateList, "List templates", template_dtos.ListTemplatesRequest{}, template_dtos.ListTemplatesResponse{}},
+	{SubjectTemplateUpdate, "Update the given fields of a template", UpdateTemplateRequest{}, template_dtos.TemplateResponse{}},
+	{SubjectTemplateDelete, "Delete a template", DeleteTemplateRequest{}, DeleteTemplateResponse{}},
+	{SubjectTemplateContentUpload, "Upload a chunk of template content too large for one message", ContentChunk{}, ContentUploadResponse{}},
+
+	{SubjectMessageSend, "Send a message", message_dtos.SendMessageRequest{}, message_dtos.MessageResponse{}},
+	{SubjectMessageGet, "Get a message", MessageIDRequest{}, message_dtos.MessageResponse{}},
+	{SubjectMessageList, "List messages", message_dtos.ListMessagesRequest{}, message_dtos.ListMessagesResponse{}},
+}
+
+// fullSubject returns the subject prefixed with SubjectPrefix
+func fullSubject(subject string) string {
+	return SubjectPrefix + "." + subject
+}
+
+// ChannelIDRequest is the data of the requests on a single channel. The
+// data may also be the channel ID as a plain string.
+type ChannelIDRequest struct {
+	ChannelID string `json:"channelId" binding:"required"`
+}
+
+// TemplateIDRequest is the data of the requests on a single template. The
+// data may also be the template ID as a plain string.
+type TemplateIDRequest struct {
+	TemplateID string `json:"templateId" binding:"required"`
+}
+
+// MessageIDRequest is the data of the requests on a single message. The
+// data may also be the message ID as a plain string.
+type MessageIDRequest struct {
+	MessageID string `json:"messageId" binding:"required"`
+}
+
+// ListChannelsRequest is the data of the list channels request
+type ListChannelsRequest struct {
+	channel_dtos.ListChannelsRequest
+	// Pagination is the paging object accepted by the former CQRS handler
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination pages a list by offset, overriding skipCount and maxResultCount
+type Pagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// UpdateTemplateRequest is the data of the update template request
+type UpdateTemplateRequest struct {
+	TemplateID string `json:"templateId" binding:"required"`
+	template_dtos.UpdateTemplateRequest
+}
+
+// DeleteTemplateRequest is the data of the delete template request
+type DeleteTemplateRequest struct {
+	TemplateID string `json:"templateId" binding:"required"`
+	template_dtos.DeleteTemplateRequest
+}
+
+// DeleteTemplateResponse is the data of the delete template response
+type DeleteTemplateResponse struct {
+	Deleted bool `json:"deleted"`
+}
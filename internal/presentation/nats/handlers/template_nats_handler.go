@@ -4,25 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
-	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
-	"go.uber.org/zap"
 
 	"notification/internal/application/template/dtos"
 	"notification/internal/application/template/usecases"
+	"notification/internal/presentation/executor"
 	"notification/pkg/logger"
 )
 
 // TemplateNATSHandler handles NATS messages for template operations
 type TemplateNATSHandler struct {
-	createUseCase *usecases.CreateTemplateUseCase
-	getUseCase    *usecases.GetTemplateUseCase
-	listUseCase   *usecases.ListTemplatesUseCase
-	updateUseCase *usecases.UpdateTemplateUseCase
-	deleteUseCase *usecases.DeleteTemplateUseCase
-	natsConn      *nats.Conn
+	executor executor.TemplateExecutor
+	natsConn *nats.Conn
+	pipeline *Pipeline
 }
 
 // NewTemplateNATSHandler creates a new NATS handler for template operations
@@ -34,264 +29,149 @@ func NewTemplateNATSHandler(
 	deleteUseCase *usecases.DeleteTemplateUseCase,
 	natsConn *nats.Conn,
 ) *TemplateNATSHandler {
+	return NewTemplateNATSHandlerWithExecutor(
+		executor.NewUseCaseTemplateExecutor(createUseCase, getUseCase, listUseCase, updateUseCase, deleteUseCase),
+		natsConn,
+	)
+}
+
+// NewTemplateNATSHandlerWithExecutor creates a new NATS handler for template
+// operations that runs them on the given executor
+func NewTemplateNATSHandlerWithExecutor(templateExecutor executor.TemplateExecutor, natsConn *nats.Conn) *TemplateNATSHandler {
 	return &TemplateNATSHandler{
-		createUseCase: createUseCase,
-		getUseCase:    getUseCase,
-		listUseCase:   listUseCase,
-		updateUseCase: updateUseCase,
-		deleteUseCase: deleteUseCase,
-		natsConn:      natsConn,
+		executor: templateExecutor,
+		natsConn: natsConn,
+		pipeline: DefaultPipeline(),
 	}
 }
 
+// SetPipeline sets the middleware pipeline the handlers run behind
+func (h *TemplateNATSHandler) SetPipeline(pipeline *Pipeline) {
+	h.pipeline = pipeline
+}
+
 // RegisterHandlers registers all NATS message handlers for template operations
 func (h *TemplateNATSHandler) RegisterHandlers() error {
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.template.create", h.handleCreateTemplate); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectTemplateCreate), h.pipeline.Handle(h.handleCreateTemplate)); err != nil {
 		return fmt.Errorf("failed to subscribe to create template topic: %w", err)
 	}
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.template.get", h.handleGetTemplate); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectTemplateGet), h.pipeline.Handle(h.handleGetTemplate)); err != nil {
 		return fmt.Errorf("failed to subscribe to get template topic: %w", err)
 	}
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.template.list", h.handleListTemplates); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectTemplateList), h.pipeline.Handle(h.handleListTemplates)); err != nil {
 		return fmt.Errorf("failed to subscribe to list templates topic: %w", err)
 	}
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.template.update", h.handleUpdateTemplate); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectTemplateUpdate), h.pipeline.Handle(h.handleUpdateTemplate)); err != nil {
 		return fmt.Errorf("failed to subscribe to update template topic: %w", err)
 	}
-	if _, err := h.natsConn.Subscribe("eco1j.infra.eventcenter.template.delete", h.handleDeleteTemplate); err != nil {
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectTemplateDelete), h.pipeline.Handle(h.handleDeleteTemplate)); err != nil {
 		return fmt.Errorf("failed to subscribe to delete template topic: %w", err)
 	}
+	if _, err := h.natsConn.Subscribe(fullSubject(SubjectTemplateContentUpload), h.pipeline.Handle(h.handleUploadContent)); err != nil {
+		return fmt.Errorf("failed to subscribe to upload template content topic: %w", err)
+	}
 	logger.Info("Template NATS handlers registered successfully")
 	return nil
 }
 
 // handleCreateTemplate handles create template NATS messages
-func (h *TemplateNATSHandler) handleCreateTemplate(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received create template NATS message", zap.String("subject", msg.Subject), zap.String("reply", msg.Reply))
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	dataBytes, err := json.Marshal(natsReq.Data)
-	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-		return
-	}
-
+func (h *TemplateNATSHandler) handleCreateTemplate(ctx context.Context, req *Request) (interface{}, error) {
 	var request dtos.CreateTemplateRequest
-	if err := json.Unmarshal(dataBytes, &request); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse create template request", err.Error())
-		return
+	if err := req.Bind(&request); err != nil {
+		return nil, err
 	}
 
-	response, err := h.createUseCase.Execute(ctx, &request)
+	response, err := h.executor.Create(ctx, &request)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to create template", err.Error())
-		return
+		return nil, executionError("Failed to create template", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleGetTemplate handles get template NATS messages
-func (h *TemplateNATSHandler) handleGetTemplate(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received get template NATS message", zap.String("subject", msg.Subject), zap.String("reply", msg.Reply))
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	templateID, ok := natsReq.Data.(string)
-	if !ok {
-		if dataMap, ok := natsReq.Data.(map[string]interface{}); ok {
-			if id, exists := dataMap["templateId"]; exists {
-				templateID, _ = id.(string)
-			}
-		}
-	}
-
+func (h *TemplateNATSHandler) handleGetTemplate(ctx context.Context, req *Request) (interface{}, error) {
+	templateID := req.ID("templateId")
 	if templateID == "" {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Template ID is required", "")
-		return
+		return nil, invalidRequest("Template ID is required")
 	}
 
-	response, err := h.getUseCase.Execute(ctx, templateID)
+	response, err := h.executor.Get(ctx, templateID)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to get template", err.Error())
-		return
+		return nil, executionError("Failed to get template", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleListTemplates handles list templates NATS messages
-func (h *TemplateNATSHandler) handleListTemplates(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received list templates NATS message", zap.String("subject", msg.Subject), zap.String("reply", msg.Reply))
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
+func (h *TemplateNATSHandler) handleListTemplates(ctx context.Context, req *Request) (interface{}, error) {
 	var request dtos.ListTemplatesRequest
-	if natsReq.Data != nil {
-		dataBytes, err := json.Marshal(natsReq.Data)
-		if err != nil {
-			h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-			return
-		}
-
-		if err := json.Unmarshal(dataBytes, &request); err != nil {
-			h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse list templates request", err.Error())
-			return
-		}
+	if err := req.Bind(&request); err != nil {
+		return nil, err
 	}
 
-	response, err := h.listUseCase.Execute(ctx, &request)
+	response, err := h.executor.List(ctx, &request)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to list templates", err.Error())
-		return
+		return nil, executionError("Failed to list templates", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleUpdateTemplate handles update template NATS messages
-func (h *TemplateNATSHandler) handleUpdateTemplate(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received update template NATS message", zap.String("subject", msg.Subject), zap.String("reply", msg.Reply))
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
-
-	dataBytes, err := json.Marshal(natsReq.Data)
-	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal request data", err.Error())
-		return
-	}
-
+func (h *TemplateNATSHandler) handleUpdateTemplate(ctx context.Context, req *Request) (interface{}, error) {
 	var payload map[string]interface{}
-	if err := json.Unmarshal(dataBytes, &payload); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse update template payload", err.Error())
-		return
+	if err := req.Bind(&payload); err != nil {
+		return nil, err
 	}
 
 	templateID, ok := payload["templateId"].(string)
 	if !ok || templateID == "" {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "templateId is required in payload", "")
-		return
+		return nil, invalidRequest("templateId is required in payload")
 	}
 	delete(payload, "templateId")
 
 	updateDtoBytes, err := json.Marshal(payload)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to marshal update DTO from payload", err.Error())
-		return
+		return nil, NewRequestError("INVALID_REQUEST", "Failed to marshal update DTO from payload", err.Error())
 	}
 	var updateDto dtos.UpdateTemplateRequest
 	if err := json.Unmarshal(updateDtoBytes, &updateDto); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to unmarshal update DTO", err.Error())
-		return
+		return nil, NewRequestError("INVALID_REQUEST", "Failed to unmarshal update DTO", err.Error())
 	}
 
-	response, err := h.updateUseCase.Execute(ctx, templateID, &updateDto)
+	response, err := h.executor.Update(ctx, templateID, &updateDto)
 	if err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to update template", err.Error())
-		return
+		return nil, executionError("Failed to update template", err)
 	}
-
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, response)
+	return response, nil
 }
 
 // handleDeleteTemplate handles delete template NATS messages
-func (h *TemplateNATSHandler) handleDeleteTemplate(msg *nats.Msg) {
-	ctx := context.Background()
-	logger.Info("Received delete template NATS message", zap.String("subject", msg.Subject), zap.String("reply", msg.Reply))
-
-	var natsReq NATSRequest
-	if err := json.Unmarshal(msg.Data, &natsReq); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Failed to parse request", err.Error())
-		return
-	}
+func (h *TemplateNATSHandler) handleDeleteTemplate(ctx context.Context, req *Request) (interface{}, error) {
+	templateID := req.ID("templateId")
 
-	templateID, ok := natsReq.Data.(string)
-	if !ok {
-		if dataMap, ok := natsReq.Data.(map[string]interface{}); ok {
-			if id, exists := dataMap["templateId"]; exists {
-				templateID, _ = id.(string)
-			}
-		}
+	var deleteReq dtos.DeleteTemplateRequest
+	var dataMap map[string]interface{}
+	if json.Unmarshal(req.Data, &dataMap) == nil {
+		deleteReq.Force, _ = dataMap["force"].(bool)
+		deleteReq.ReassignTo, _ = dataMap["reassignTo"].(string)
 	}
 
 	if templateID == "" {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "INVALID_REQUEST", "Template ID is required", "")
-		return
-	}
-
-	if err := h.deleteUseCase.Execute(ctx, templateID); err != nil {
-		h.sendErrorResponse(msg, natsReq.ReqSeqId, "EXECUTION_ERROR", "Failed to delete template", err.Error())
-		return
+		return nil, invalidRequest("Template ID is required")
 	}
 
-	h.sendSuccessResponse(msg, natsReq.ReqSeqId, map[string]interface{}{"deleted": true})
-}
-
-// sendSuccessResponse sends a success response via NATS
-func (h *TemplateNATSHandler) sendSuccessResponse(msg *nats.Msg, reqSeqId string, data interface{}) {
-	rspSeqId, _ := uuid.NewRandom()
-	response := NATSResponse{
-		ReqSeqId:  reqSeqId,
-		RspSeqId:  rspSeqId.String(),
-		Success:   true,
-		Data:      data,
-		Timestamp: time.Now().UnixMilli(),
-	}
-
-	responseBytes, err := json.Marshal(response)
-	if err != nil {
-		logger.Error("Failed to marshal success response", zap.Error(err))
-		return
-	}
-
-	if err := msg.Respond(responseBytes); err != nil {
-		logger.Error("Failed to send success response", zap.Error(err))
+	if err := h.executor.Delete(ctx, templateID, &deleteReq); err != nil {
+		return nil, executionError("Failed to delete template", err)
 	}
+	return &DeleteTemplateResponse{Deleted: true}, nil
 }
 
-// sendErrorResponse sends an error response via NATS
-func (h *TemplateNATSHandler) sendErrorResponse(msg *nats.Msg, reqSeqId, code, message, details string) {
-	rspSeqId, _ := uuid.NewRandom()
-	response := NATSResponse{
-		ReqSeqId: reqSeqId,
-		RspSeqId: rspSeqId.String(),
-		Success:  false,
-		Error: &NATSError{
-			Code:    code,
-			Message: message,
-			Details: details,
-		},
-		Timestamp: time.Now().UnixMilli(),
+// handleUploadContent handles template content upload NATS messages
+func (h *TemplateNATSHandler) handleUploadContent(ctx context.Context, req *Request) (interface{}, error) {
+	var chunk ContentChunk
+	if err := req.Bind(&chunk); err != nil {
+		return nil, err
 	}
 
-	responseBytes, err := json.Marshal(response)
-	if err != nil {
-		logger.Error("Failed to marshal error response", zap.Error(err))
-		return
-	}
-
-	if err := msg.Respond(responseBytes); err != nil {
-		logger.Error("Failed to send error response", zap.Error(err))
-	}
+	return h.pipeline.Uploads().Append(req.ClientID, &chunk)
 }
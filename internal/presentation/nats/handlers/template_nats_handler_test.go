@@ -8,6 +8,7 @@ import (
 	"notification/internal/application/template/dtos"
 	"notification/internal/application/template/usecases"
 	"notification/internal/domain/channel"
+	"notification/internal/domain/services"
 	"notification/internal/domain/shared"
 	"notification/internal/domain/template"
 	"notification/internal/infrastructure/repository"
@@ -41,7 +42,7 @@ func (suite *TemplateHandlerTestSuite) SetupSuite() {
 	getUseCase := usecases.NewGetTemplateUseCase(suite.templateRepo)
 	listUseCase := usecases.NewListTemplatesUseCase(suite.templateRepo)
 	updateUseCase := usecases.NewUpdateTemplateUseCase(suite.templateRepo, suite.channelRepo, suite.appConfig)
-	deleteUseCase := usecases.NewDeleteTemplateUseCase(suite.templateRepo, suite.channelRepo, suite.appConfig)
+	deleteUseCase := usecases.NewDeleteTemplateUseCase(suite.templateRepo, suite.channelRepo, services.NewTemplateIntegrityService(suite.channelRepo), suite.appConfig)
 
 	handler := NewTemplateNATSHandler(
 		createUseCase,
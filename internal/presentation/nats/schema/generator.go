@@ -0,0 +1,182 @@
+package schema
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/spec"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawJSONType   = reflect.TypeOf(json.RawMessage{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Generator builds JSON schemas from Go types the way encoding/json encodes
+// them. Named structs are collected as definitions and referenced.
+type Generator struct {
+	refPrefix   string
+	definitions map[string]spec.Schema
+	names       map[reflect.Type]string
+	// overrides gives the schemas of types with their own JSON encoding
+	overrides map[reflect.Type]func() spec.Schema
+}
+
+// NewGenerator creates a schema generator whose references to definitions
+// start with refPrefix, e.g. "#/definitions/"
+func NewGenerator(refPrefix string) *Generator {
+	g := &Generator{
+		refPrefix:   refPrefix,
+		definitions: make(map[string]spec.Schema),
+		names:       make(map[reflect.Type]string),
+	}
+	g.overrides = domainOverrides(g)
+	return g
+}
+
+// Definitions returns the schemas of the named structs generated so far. The
+// map keeps growing with the generated schemas.
+func (g *Generator) Definitions() map[string]spec.Schema {
+	return g.definitions
+}
+
+// SchemaOf returns the schema of the values of type t
+func (g *Generator) SchemaOf(t reflect.Type) spec.Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if override, ok := g.overrides[t]; ok {
+		return override()
+	}
+
+	switch {
+	case t == timeType:
+		return *spec.DateTimeProperty()
+	case t == rawJSONType:
+		return spec.Schema{}
+	case t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType):
+		// Encoded by its own marshaler, the shape is unknown
+		return spec.Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return *spec.BoolProperty()
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return *spec.Int32Property()
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return *spec.Int64Property()
+	case reflect.Float32:
+		return *spec.Float32Property()
+	case reflect.Float64:
+		return *spec.Float64Property()
+	case reflect.String:
+		return *spec.StringProperty()
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return *spec.StrFmtProperty("byte")
+		}
+		items := g.SchemaOf(t.Elem())
+		return *spec.ArrayProperty(&items)
+	case reflect.Map:
+		values := g.SchemaOf(t.Elem())
+		return *spec.MapProperty(&values)
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.StructSchema(t)
+		}
+		return *spec.RefSchema(g.refPrefix + g.define(t))
+	default:
+		// Interfaces hold any value
+		return spec.Schema{}
+	}
+}
+
+// StructSchema returns the object schema of the struct t, without adding it
+// to the definitions. Embedded structs without a JSON name have their fields
+// promoted, like encoding/json does.
+func (g *Generator) StructSchema(t reflect.Type) spec.Schema {
+	schema := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       spec.StringOrArray{"object"},
+		Properties: make(map[string]spec.Schema),
+	}}
+	g.addFields(&schema, t)
+	return schema
+}
+
+// define adds the schema of the named struct t to the definitions and
+// returns its name. Names taken by a struct of another package are
+// qualified with the package.
+func (g *Generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.definitions[name]; taken {
+		name = packageName(t.PkgPath()) + "." + name
+	}
+	g.names[t] = name
+	// Reserve the name before generating, so that recursive types end in a reference
+	g.definitions[name] = spec.Schema{}
+	g.definitions[name] = g.StructSchema(t)
+	return name
+}
+
+// addFields adds the encoded fields of the struct t to the object schema
+func (g *Generator) addFields(schema *spec.Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			g.addFields(schema, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = g.SchemaOf(field.Type)
+		if isRequired(field) && !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// isRequired reports whether the binding or validation tags require the field
+func isRequired(field reflect.StructField) bool {
+	for _, key := range []string{"binding", "validate"} {
+		for _, rule := range strings.Split(field.Tag.Get(key), ",") {
+			if rule == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// packageName returns the name qualifying the types of a package. DTO
+// packages are named after their parent, e.g. "template" for template/dtos.
+func packageName(pkgPath string) string {
+	if path.Base(pkgPath) == "dtos" {
+		return path.Base(path.Dir(pkgPath))
+	}
+	return path.Base(pkgPath)
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"reflect"
+
+	"github.com/go-openapi/spec"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+)
+
+// domainOverrides returns the schemas of the domain types carried in the
+// DTOs that encode themselves with their own JSON marshaler
+func domainOverrides(g *Generator) map[reflect.Type]func() spec.Schema {
+	return map[reflect.Type]func() spec.Schema{
+		reflect.TypeOf(shared.ChannelType{}): func() spec.Schema {
+			return *spec.StringProperty()
+		},
+		reflect.TypeOf(channel.Recipients{}): func() spec.Schema {
+			return g.SchemaOf(reflect.TypeOf([]channel.Recipient{}))
+		},
+		reflect.TypeOf(message.TemplateOverride{}): func() spec.Schema {
+			g.definitions["TemplateOverride"] = *new(spec.Schema).
+				Typed("object", "").
+				SetProperty("subject", *spec.StringProperty()).
+				SetProperty("template", *spec.StringProperty())
+			return *spec.RefSchema(g.refPrefix + "TemplateOverride")
+		},
+		reflect.TypeOf(message.ChannelOverrides{}): func() spec.Schema {
+			return g.SchemaOf(reflect.TypeOf(map[string]message.ChannelOverride{}))
+		},
+	}
+}
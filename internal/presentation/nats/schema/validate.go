@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// ValidationError reports where a JSON value does not match its schema
+type ValidationError struct {
+	// Path locates the value, e.g. "recipients[0].type"; empty for the root
+	Path    string
+	Message string
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks JSON data against the schema, resolving references among
+// the definitions. It supports what the Generator produces: types,
+// properties, required properties, array items and additional properties.
+// Null is accepted for every type, as encoding/json decodes it into any.
+func Validate(schema spec.Schema, definitions map[string]spec.Schema, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return &ValidationError{Message: "invalid JSON: " + err.Error()}
+	}
+
+	v := &validator{definitions: definitions}
+	return v.validate("", schema, value)
+}
+
+// validator validates decoded JSON values
+type validator struct {
+	definitions map[string]spec.Schema
+}
+
+// validate checks the value found at path against the schema
+func (v *validator) validate(path string, schema spec.Schema, value interface{}) error {
+	if ref := schema.Ref.String(); ref != "" {
+		definition, ok := v.definitions[ref[strings.LastIndex(ref, "/")+1:]]
+		if !ok {
+			return &ValidationError{Path: path, Message: "unknown schema " + ref}
+		}
+		return v.validate(path, definition, value)
+	}
+	if value == nil || len(schema.Type) == 0 {
+		return nil
+	}
+
+	switch schema.Type[0] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return typeError(path, "an object", value)
+		}
+		return v.validateObject(path, schema, object)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return typeError(path, "an array", value)
+		}
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return nil
+		}
+		for i, item := range items {
+			if err := v.validate(fmt.Sprintf("%s[%d]", path, i), *schema.Items.Schema, item); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return typeError(path, "a string", value)
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return typeError(path, "an integer", value)
+		}
+		if _, err := number.Int64(); err != nil {
+			return &ValidationError{Path: path, Message: "expected an integer, got " + number.String()}
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return typeError(path, "a number", value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return typeError(path, "a boolean", value)
+		}
+	}
+	return nil
+}
+
+// validateObject checks the required and the present properties of an
+// object. Properties the schema does not know are accepted.
+func (v *validator) validateObject(path string, schema spec.Schema, object map[string]interface{}) error {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			return &ValidationError{Path: join(path, name), Message: "is required"}
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			if schema.AdditionalProperties == nil || schema.AdditionalProperties.Schema == nil {
+				continue
+			}
+			property = *schema.AdditionalProperties.Schema
+		}
+		if err := v.validate(join(path, name), property, object[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeError reports a value of the wrong type
+func typeError(path, expected string, value interface{}) error {
+	var actual string
+	switch value.(type) {
+	case map[string]interface{}:
+		actual = "an object"
+	case []interface{}:
+		actual = "an array"
+	case string:
+		actual = "a string"
+	case json.Number:
+		actual = "a number"
+	case bool:
+		actual = "a boolean"
+	}
+	return &ValidationError{Path: path, Message: fmt.Sprintf("expected %s, got %s", expected, actual)}
+}
+
+// join appends a property name to a path
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"notification/internal/domain/shared"
 	"notification/internal/presentation/http/handlers"
 	"notification/internal/presentation/http/middleware"
 	"notification/internal/presentation/http/routes"
@@ -30,22 +31,50 @@ type ServerConfig struct {
 	HTTPTimeout time.Duration
 
 	// HTTP handlers
-	ChannelHandler     *handlers.ChannelHandler
-	CQRSChannelHandler *handlers.CQRSChannelHandler
-	TemplateHandler    *handlers.TemplateHandler
-	MessageHandler     *handlers.MessageHandler
-	HealthHandler      *handlers.HealthHandler
-
-	// CQRS handlers
-	CQRSTemplateHandler *handlers.CQRSTemplateHandler
-	CQRSMessageHandler  *handlers.CQRSMessageHandler
+	ChannelHandler      *handlers.ChannelHandler
+	CQRSChannelHandler  *handlers.ChannelHandler
+	TemplateHandler     *handlers.TemplateHandler
+	MessageHandler      *handlers.MessageHandler
+	AnalyticsHandler    *handlers.AnalyticsHandler
+	TagHandler          *handlers.TagHandler
+	ChannelGroupHandler *handlers.ChannelGroupHandler
+	ProvisioningHandler *handlers.ProvisioningHandler
+	FailedEventHandler  *handlers.FailedEventHandler
+	HealthHandler       *handlers.HealthHandler
+	AsyncAPIHandler     *handlers.AsyncAPIHandler
+	VoiceHandler        *handlers.VoiceHandler
+	InAppHandler        *handlers.InAppHandler
+	PreferenceHandler   *handlers.PreferenceHandler
+	CategoryHandler     *handlers.CategoryHandler
+	ReadOnlyHandler     *handlers.ReadOnlyHandler
+	LogLevelHandler     *handlers.LogLevelHandler
+
+	ChannelReadinessHandler *handlers.ChannelReadinessHandler
+	CompatibilityHandler    *handlers.CompatibilityHandler
+	LinkHandler             *handlers.LinkHandler
+	QuarantineHandler       *handlers.QuarantineHandler
+	IdentityHandler         *handlers.IdentityHandler
+	ScheduleHandler         *handlers.ScheduleHandler
+	BlackoutHandler         *handlers.BlackoutHandler
+	AcknowledgmentHandler   *handlers.AcknowledgmentHandler
+	ReplyHandler            *handlers.ReplyHandler
+	OTPHandler              *handlers.OTPHandler
+	PrivacyHandler          *handlers.PrivacyHandler
+	RetentionHandler        *handlers.RetentionHandler
+	SLOHandler              *handlers.SLOHandler
+
+	// Handlers of the v2 routes, running on the CQRS executors
+	CQRSTemplateHandler *handlers.TemplateHandler
+	CQRSMessageHandler  *handlers.MessageHandler
 
 	// NATS handler manager
-	NATSManager     *natshandlers.HandlerManager
-	CQRSNATSHandler *natshandlers.CQRSChannelNATSHandler
+	NATSManager *natshandlers.HandlerManager
 
 	// Middleware configuration
 	MiddlewareConfig *middleware.MiddlewareConfig
+
+	// ReadOnly rejects the HTTP commands while the instance is read-only
+	ReadOnly *shared.ReadOnlyMode
 }
 
 // NewServer creates a new presentation layer server
@@ -56,10 +85,37 @@ func NewServer(config *ServerConfig) *Server {
 		CQRSChannelHandler:  config.CQRSChannelHandler,
 		TemplateHandler:     config.TemplateHandler,
 		MessageHandler:      config.MessageHandler,
+		AnalyticsHandler:    config.AnalyticsHandler,
+		TagHandler:          config.TagHandler,
+		ChannelGroupHandler: config.ChannelGroupHandler,
+		ProvisioningHandler: config.ProvisioningHandler,
+		FailedEventHandler:  config.FailedEventHandler,
 		CQRSTemplateHandler: config.CQRSTemplateHandler,
 		CQRSMessageHandler:  config.CQRSMessageHandler,
 		MiddlewareConfig:    config.MiddlewareConfig,
 		HealthHandler:       config.HealthHandler,
+		AsyncAPIHandler:     config.AsyncAPIHandler,
+		VoiceHandler:        config.VoiceHandler,
+		InAppHandler:        config.InAppHandler,
+		PreferenceHandler:   config.PreferenceHandler,
+		CategoryHandler:     config.CategoryHandler,
+		ReadOnly:            config.ReadOnly,
+		ReadOnlyHandler:     config.ReadOnlyHandler,
+		LogLevelHandler:     config.LogLevelHandler,
+
+		ChannelReadinessHandler: config.ChannelReadinessHandler,
+		CompatibilityHandler:    config.CompatibilityHandler,
+		LinkHandler:             config.LinkHandler,
+		QuarantineHandler:       config.QuarantineHandler,
+		IdentityHandler:         config.IdentityHandler,
+		ScheduleHandler:         config.ScheduleHandler,
+		BlackoutHandler:         config.BlackoutHandler,
+		AcknowledgmentHandler:   config.AcknowledgmentHandler,
+		ReplyHandler:            config.ReplyHandler,
+		OTPHandler:              config.OTPHandler,
+		PrivacyHandler:          config.PrivacyHandler,
+		RetentionHandler:        config.RetentionHandler,
+		SLOHandler:              config.SLOHandler,
 	}
 	router := routes.SetupRouter(routerConfig)
 
@@ -89,17 +145,7 @@ func (s *Server) Start(ctx context.Context) error {
 		if err := s.natsManager.RegisterAllHandlers(); err != nil {
 			return fmt.Errorf("failed to register NATS handlers: %w", err)
 		}
-		logger.Info("Traditional NATS handlers registered successfully")
-	}
-
-	// Register CQRS NATS handlers
-	if s.config.CQRSNATSHandler != nil {
-		/*
-			if err := s.config.CQRSNATSHandler.RegisterHandlers(); err != nil {
-				return fmt.Errorf("failed to register CQRS NATS handlers: %w", err)
-			}
-			logger.Info("CQRS NATS handlers registered successfully")
-		*/
+		logger.Info("NATS handlers registered successfully")
 	}
 
 	// Start HTTP server in a goroutine
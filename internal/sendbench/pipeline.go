@@ -0,0 +1,277 @@
+// Package sendbench builds the message send pipeline on an in-memory store
+// and a mock provider, for the benchmarks of the HTTP, NATS and direct send
+// paths. It reports the throughput, the latency percentiles and the
+// contention on the store, so that regressions of the EnhancedMessageSender
+// show in the benchmark results.
+package sendbench
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"notification/internal/application/message/dtos"
+	"notification/internal/application/message/usecases"
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/services"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+	"notification/pkg/config"
+	"notification/pkg/logger"
+)
+
+// Config shapes the pipeline and its simulated latencies
+type Config struct {
+	// Channels is the number of channels each message is sent through; zero uses 1
+	Channels int
+	// Recipients is the number of recipients of each channel; zero uses 1
+	Recipients int
+	// PoolSize is the number of store connections; zero uses 10
+	PoolSize int
+	// QueryLatency is the time each store query holds a connection
+	QueryLatency time.Duration
+	// ProviderLatency is the time the provider takes for each send
+	ProviderLatency time.Duration
+	// FailureRate is the share of the sends the provider fails, from 0 to 1
+	FailureRate float64
+	// Environment is the deployment stage the messages are sent from
+	Environment shared.Environment
+}
+
+// Pipeline is the send pipeline with its fixtures
+type Pipeline struct {
+	Store       *Store
+	Provider    *Provider
+	Sender      *services.EnhancedMessageSender
+	SendUseCase *usecases.SendMessageUseCase
+	// Latencies collects the latencies of the sends the benchmark records
+	Latencies *Latencies
+
+	templateID string
+	channelIDs []*channel.ChannelID
+}
+
+// LoggerConfig returns the logger configuration of the pipeline. The
+// messages are encoded and discarded, so that logging counts in the results.
+func LoggerConfig() *config.LoggerConfig {
+	return &config.LoggerConfig{Level: "info", Format: "json", OutputPath: os.DevNull}
+}
+
+// NewPipeline creates the pipeline and saves a template and its channels
+func NewPipeline(cfg Config) (*Pipeline, error) {
+	shared.InitializeChannelTypes()
+
+	if cfg.Channels <= 0 {
+		cfg.Channels = 1
+	}
+	if cfg.Recipients <= 0 {
+		cfg.Recipients = 1
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 10
+	}
+
+	log, err := logger.NewLogger(LoggerConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	store := NewStore(cfg.PoolSize, cfg.QueryLatency)
+	provider := NewProvider(cfg.ProviderLatency, cfg.FailureRate)
+	sender := services.NewEnhancedMessageSender(
+		store.ChannelRepository(),
+		store.TemplateRepository(),
+		store.MessageRepository(),
+		services.NewDefaultTemplateRenderer(),
+		services.NewEnvironmentGuard(provider, cfg.Environment),
+		log,
+	)
+	sendUseCase := usecases.NewSendMessageUseCase(
+		store.MessageRepository(),
+		store.ChannelRepository(),
+		store.TemplateRepository(),
+		sender,
+		&config.Config{},
+	)
+	sendUseCase.SetEnvironment(cfg.Environment)
+
+	p := &Pipeline{
+		Store:       store,
+		Provider:    provider,
+		Sender:      sender,
+		SendUseCase: sendUseCase,
+		Latencies:   &Latencies{},
+	}
+	if err := p.saveFixtures(cfg); err != nil {
+		return nil, err
+	}
+	p.ResetStats()
+	return p, nil
+}
+
+// saveFixtures saves the template and the channels the messages are sent through
+func (p *Pipeline) saveFixtures(cfg Config) error {
+	ctx := context.Background()
+
+	name, _ := template.NewTemplateName("benchmark")
+	subject, _ := template.NewSubject("Build {build} {status}")
+	content, err := template.NewTemplateContent("Hello {name}, build {build} of {project} finished with status {status}.")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.NewTemplate(name, nil, shared.ChannelTypeEmail, subject, content, nil)
+	if err != nil {
+		return err
+	}
+	if err := p.Store.TemplateRepository().Save(ctx, tmpl); err != nil {
+		return err
+	}
+	p.templateID = tmpl.ID().String()
+
+	settings, err := shared.NewCommonSettings(30000, 0, 0)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < cfg.Channels; i++ {
+		channelName, _ := channel.NewChannelName(fmt.Sprintf("benchmark-%d", i))
+		recipients := channel.NewRecipients(nil)
+		for j := 0; j < cfg.Recipients; j++ {
+			recipient, err := channel.NewRecipient(fmt.Sprintf("user-%d", j), fmt.Sprintf("user-%d@example.com", j), "to")
+			if err != nil {
+				return err
+			}
+			_ = recipients.Add(recipient)
+		}
+
+		ch, err := channel.NewChannel(channelName, nil, true, shared.ChannelTypeEmail, tmpl.ID(), settings,
+			channel.NewChannelConfig(map[string]interface{}{"host": "smtp.example.com", "port": 587}), recipients, nil)
+		if err != nil {
+			return err
+		}
+		if err := p.Store.ChannelRepository().Save(ctx, ch); err != nil {
+			return err
+		}
+		p.channelIDs = append(p.channelIDs, ch.ID())
+	}
+	return nil
+}
+
+// Request returns a send request for the fixtures
+func (p *Pipeline) Request() *dtos.SendMessageRequest {
+	channelIDs := make([]string, 0, len(p.channelIDs))
+	for _, id := range p.channelIDs {
+		channelIDs = append(channelIDs, id.String())
+	}
+
+	return &dtos.SendMessageRequest{
+		ChannelIDs: channelIDs,
+		TemplateID: p.templateID,
+		Recipients: []map[string]interface{}{{"name": "user-0", "target": "user-0@example.com", "type": "to"}},
+		Variables:  Variables(),
+	}
+}
+
+// ChannelIDs returns the channels of the fixtures
+func (p *Pipeline) ChannelIDs() *message.ChannelIDs {
+	channelIDs, _ := message.NewChannelIDs(p.channelIDs)
+	return channelIDs
+}
+
+// Variables returns the variables of the template of the fixtures
+func Variables() map[string]interface{} {
+	return map[string]interface{}{
+		"name":    "Ada",
+		"build":   1234,
+		"project": "notification",
+		"status":  "success",
+	}
+}
+
+// ResetStats clears the store, provider and latency counters, e.g. after b.ResetTimer
+func (p *Pipeline) ResetStats() {
+	p.Store.ResetStats()
+	p.Provider.ResetCalls()
+	p.Latencies.Reset()
+}
+
+// ReportMetrics reports the store contention, the provider failures and the
+// latency percentiles of the recorded sends per operation of the benchmark
+func (p *Pipeline) ReportMetrics(b *testing.B) {
+	if b.N == 0 {
+		return
+	}
+	n := float64(b.N)
+
+	stats := p.Store.Stats()
+	b.ReportMetric(float64(stats.Queries)/n, "db-queries/op")
+	b.ReportMetric(float64(stats.WaitCount)/n, "db-waits/op")
+	b.ReportMetric(float64(stats.WaitDuration.Nanoseconds())/n, "db-wait-ns/op")
+
+	if _, failures := p.Provider.Calls(); failures > 0 {
+		b.ReportMetric(float64(failures)/n, "provider-failures/op")
+	}
+
+	if p.Latencies.Count() > 0 {
+		b.ReportMetric(float64(p.Latencies.Percentile(50).Nanoseconds()), "p50-ns")
+		b.ReportMetric(float64(p.Latencies.Percentile(99).Nanoseconds()), "p99-ns")
+	}
+}
+
+// Latencies collects latency samples and computes their percentiles
+type Latencies struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	sorted  bool
+}
+
+// Record adds a sample
+func (l *Latencies) Record(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.samples = append(l.samples, d)
+	l.sorted = false
+}
+
+// Count returns the number of samples
+func (l *Latencies) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.samples)
+}
+
+// Percentile returns the sample below which p percent of the samples fall,
+// 0 without samples
+func (l *Latencies) Percentile(p float64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples) == 0 {
+		return 0
+	}
+	if !l.sorted {
+		sort.Slice(l.samples, func(i, j int) bool { return l.samples[i] < l.samples[j] })
+		l.sorted = true
+	}
+
+	index := int(p / 100 * float64(len(l.samples)-1))
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(l.samples) {
+		index = len(l.samples) - 1
+	}
+	return l.samples[index]
+}
+
+// Reset removes the samples
+func (l *Latencies) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.samples = l.samples[:0]
+	l.sorted = true
+}
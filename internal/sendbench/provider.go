@@ -0,0 +1,96 @@
+package sendbench
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/services"
+)
+
+// errProviderUnavailable is the failure of the sends the provider drops
+var errProviderUnavailable = errors.New("mock provider unavailable")
+
+// Provider is an ExternalNotificationService that answers every send after
+// the latency, failing the given share of the sends with a temporary error
+type Provider struct {
+	latency     time.Duration
+	failureRate float64
+
+	calls    atomic.Int64
+	failures atomic.Int64
+}
+
+// NewProvider creates a mock provider. A failure rate of 0.1 fails one send in ten.
+func NewProvider(latency time.Duration, failureRate float64) *Provider {
+	return &Provider{latency: latency, failureRate: failureRate}
+}
+
+// SendSingleNotification answers a send after the latency
+func (p *Provider) SendSingleNotification(ctx context.Context, request *services.SendRequest) *services.SendResult {
+	p.calls.Add(1)
+
+	if p.latency > 0 {
+		timer := time.NewTimer(p.latency)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return p.failed(ctx.Err())
+		}
+	}
+
+	if p.failureRate > 0 && rand.Float64() < p.failureRate {
+		return p.failed(errProviderUnavailable)
+	}
+
+	sentAt := time.Now().UnixMilli()
+	recipients := request.Channel.Recipients().ToSlice()
+	results := make([]*services.RecipientSendResult, 0, len(recipients))
+	for _, recipient := range recipients {
+		results = append(results, &services.RecipientSendResult{
+			Target:  recipient.Target,
+			Success: true,
+			SentAt:  sentAt,
+		})
+	}
+
+	return &services.SendResult{
+		Success:    true,
+		Message:    "sent by the mock provider",
+		SentAt:     sentAt,
+		Recipients: results,
+	}
+}
+
+// ValidateChannel accepts every channel
+func (p *Provider) ValidateChannel(ch *channel.Channel) error {
+	return nil
+}
+
+// Calls returns the number of sends and of failed sends
+func (p *Provider) Calls() (calls, failures int64) {
+	return p.calls.Load(), p.failures.Load()
+}
+
+// ResetCalls clears the send counters
+func (p *Provider) ResetCalls() {
+	p.calls.Store(0)
+	p.failures.Store(0)
+}
+
+// failed counts and returns a failed send
+func (p *Provider) failed(err error) *services.SendResult {
+	p.failures.Add(1)
+	return &services.SendResult{
+		Success:       false,
+		Message:       "Failed to send message",
+		Error:         err,
+		SentAt:        time.Now().UnixMilli(),
+		ErrorCategory: message.ErrorCategoryTemporary,
+	}
+}
@@ -0,0 +1,285 @@
+package sendbench
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"notification/internal/domain/channel"
+	"notification/internal/domain/message"
+	"notification/internal/domain/shared"
+	"notification/internal/domain/template"
+)
+
+// Store keeps channels, templates and messages in memory behind a pool of
+// connections, the way database/sql limits the open connections. Every query
+// holds a connection for the query latency; waiting for a free connection is
+// counted as contention.
+type Store struct {
+	conns        chan struct{}
+	queryLatency time.Duration
+
+	mu        sync.RWMutex
+	channels  map[string]*channel.Channel
+	templates map[string]*template.Template
+	messages  map[string]*message.Message
+
+	queries   atomic.Int64
+	waitCount atomic.Int64
+	waitTime  atomic.Int64
+}
+
+// StoreStats counts the queries of a store and the waits for a connection,
+// like the WaitCount and WaitDuration of sql.DBStats
+type StoreStats struct {
+	Queries      int64
+	WaitCount    int64
+	WaitDuration time.Duration
+}
+
+// NewStore creates a store with poolSize connections, each query taking queryLatency
+func NewStore(poolSize int, queryLatency time.Duration) *Store {
+	if poolSize <= 0 {
+		poolSize = 1
+	}
+	return &Store{
+		conns:        make(chan struct{}, poolSize),
+		queryLatency: queryLatency,
+		channels:     make(map[string]*channel.Channel),
+		templates:    make(map[string]*template.Template),
+		messages:     make(map[string]*message.Message),
+	}
+}
+
+// Stats returns the query and contention counters
+func (s *Store) Stats() StoreStats {
+	return StoreStats{
+		Queries:      s.queries.Load(),
+		WaitCount:    s.waitCount.Load(),
+		WaitDuration: time.Duration(s.waitTime.Load()),
+	}
+}
+
+// ResetStats clears the query and contention counters
+func (s *Store) ResetStats() {
+	s.queries.Store(0)
+	s.waitCount.Store(0)
+	s.waitTime.Store(0)
+}
+
+// ChannelRepository returns the channel repository of the store
+func (s *Store) ChannelRepository() channel.ChannelRepository {
+	return &channelRepository{store: s}
+}
+
+// TemplateRepository returns the template repository of the store
+func (s *Store) TemplateRepository() template.TemplateRepository {
+	return &templateRepository{store: s}
+}
+
+// MessageRepository returns the message repository of the store
+func (s *Store) MessageRepository() message.MessageRepository {
+	return &messageRepository{store: s}
+}
+
+// query takes a connection for the duration of one query and returns the
+// function releasing it
+func (s *Store) query(ctx context.Context) (func(), error) {
+	s.queries.Add(1)
+
+	select {
+	case s.conns <- struct{}{}:
+	default:
+		start := time.Now()
+		select {
+		case s.conns <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		s.waitCount.Add(1)
+		s.waitTime.Add(int64(time.Since(start)))
+	}
+
+	if s.queryLatency > 0 {
+		time.Sleep(s.queryLatency)
+	}
+	return func() { <-s.conns }, nil
+}
+
+// copyChannel returns a channel that does not share mutable state with ch, as
+// a channel read from the database would not
+func copyChannel(ch *channel.Channel) *channel.Channel {
+	timestamps := *ch.Timestamps()
+	health := *ch.Health()
+	var lastUsed *int64
+	if ch.LastUsed() != nil {
+		value := *ch.LastUsed()
+		lastUsed = &value
+	}
+
+	return channel.ReconstructChannel(
+		ch.ID(),
+		ch.Name(),
+		ch.Description(),
+		ch.IsEnabled(),
+		ch.InMaintenance(),
+		ch.ChannelType(),
+		ch.TemplateID(),
+		ch.CommonSettings(),
+		ch.Config(),
+		ch.Recipients(),
+		ch.Tags(),
+		ch.VariableDefaults(),
+		ch.Ownership(),
+		ch.Environment(),
+		ch.FallbackChannelID(),
+		&health,
+		&timestamps,
+		lastUsed,
+		ch.Version(),
+	)
+}
+
+// channelRepository implements the channel queries of the send path. The
+// other methods are not supported.
+type channelRepository struct {
+	channel.ChannelRepository
+	store *Store
+}
+
+// Save saves a channel
+func (r *channelRepository) Save(ctx context.Context, ch *channel.Channel) error {
+	release, err := r.store.query(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.channels[ch.ID().String()] = copyChannel(ch)
+	return nil
+}
+
+// FindByID finds a channel by ID
+func (r *channelRepository) FindByID(ctx context.Context, id *channel.ChannelID) (*channel.Channel, error) {
+	release, err := r.store.query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	ch, ok := r.store.channels[id.String()]
+	if !ok {
+		return nil, shared.NewNotFoundError("CHANNEL_NOT_FOUND", "channel not found")
+	}
+	return copyChannel(ch), nil
+}
+
+// Update updates a channel
+func (r *channelRepository) Update(ctx context.Context, ch *channel.Channel) error {
+	return r.Save(ctx, ch)
+}
+
+// UpdateEnabled updates the enabled flag and health of a channel
+func (r *channelRepository) UpdateEnabled(ctx context.Context, ch *channel.Channel) error {
+	return r.Save(ctx, ch)
+}
+
+// templateRepository implements the template queries of the send path. The
+// other methods are not supported.
+type templateRepository struct {
+	template.TemplateRepository
+	store *Store
+}
+
+// Save saves a template
+func (r *templateRepository) Save(ctx context.Context, tmpl *template.Template) error {
+	release, err := r.store.query(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.templates[tmpl.ID().String()] = tmpl
+	return nil
+}
+
+// FindByID finds a template by ID
+func (r *templateRepository) FindByID(ctx context.Context, id *template.TemplateID) (*template.Template, error) {
+	release, err := r.store.query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	tmpl, ok := r.store.templates[id.String()]
+	if !ok {
+		return nil, shared.NewNotFoundError("TEMPLATE_NOT_FOUND", "template not found")
+	}
+	return tmpl, nil
+}
+
+// messageRepository implements the message queries of the send path. The
+// other methods are not supported.
+type messageRepository struct {
+	message.MessageRepository
+	store *Store
+}
+
+// Save saves a message
+func (r *messageRepository) Save(ctx context.Context, msg *message.Message) error {
+	release, err := r.store.query(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.messages[msg.ID().String()] = msg
+	return nil
+}
+
+// FindByID finds a message by ID
+func (r *messageRepository) FindByID(ctx context.Context, id *message.MessageID) (*message.Message, error) {
+	release, err := r.store.query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	msg, ok := r.store.messages[id.String()]
+	if !ok {
+		return nil, shared.NewNotFoundError("MESSAGE_NOT_FOUND", "message not found")
+	}
+	return msg, nil
+}
+
+// Update updates a message
+func (r *messageRepository) Update(ctx context.Context, msg *message.Message) error {
+	return r.Save(ctx, msg)
+}
+
+// Exists checks if a message exists
+func (r *messageRepository) Exists(ctx context.Context, id *message.MessageID) (bool, error) {
+	release, err := r.store.query(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	_, ok := r.store.messages[id.String()]
+	return ok, nil
+}
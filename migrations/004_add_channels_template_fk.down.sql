@@ -0,0 +1,2 @@
+-- Drop channel template foreign key
+ALTER TABLE channels DROP CONSTRAINT IF EXISTS fk_channels_template;
@@ -0,0 +1,9 @@
+-- Clear channel references to templates that no longer exist
+UPDATE channels SET template_id = NULL WHERE template_id = '';
+UPDATE channels SET template_id = NULL
+    WHERE template_id IS NOT NULL
+    AND template_id NOT IN (SELECT id FROM templates);
+
+-- Prevent deleting a template while channels still reference it
+ALTER TABLE channels ADD CONSTRAINT fk_channels_template
+    FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE RESTRICT;
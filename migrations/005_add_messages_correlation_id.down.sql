@@ -0,0 +1,3 @@
+-- Drop message correlation ID
+DROP INDEX IF EXISTS idx_messages_correlation_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS correlation_id;
@@ -0,0 +1,4 @@
+-- Add caller supplied correlation ID to messages
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS correlation_id VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_messages_correlation_id ON messages(correlation_id);
@@ -0,0 +1,2 @@
+-- Drop channel template variable defaults
+ALTER TABLE channels DROP COLUMN IF EXISTS variable_defaults;
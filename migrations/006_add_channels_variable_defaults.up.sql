@@ -0,0 +1,2 @@
+-- Add per-channel template variable defaults
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS variable_defaults JSONB NOT NULL DEFAULT '{}';
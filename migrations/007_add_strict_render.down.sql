@@ -0,0 +1,3 @@
+-- Drop strict rendering
+ALTER TABLE messages DROP COLUMN IF EXISTS strict_render;
+ALTER TABLE templates DROP COLUMN IF EXISTS strict;
@@ -0,0 +1,3 @@
+-- Add strict rendering to templates and messages
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS strict BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS strict_render BOOLEAN NOT NULL DEFAULT FALSE;
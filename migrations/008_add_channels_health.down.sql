@@ -0,0 +1,6 @@
+-- Drop the channel delivery health
+ALTER TABLE channels DROP COLUMN IF EXISTS health_checked_at;
+ALTER TABLE channels DROP COLUMN IF EXISTS health_since;
+ALTER TABLE channels DROP COLUMN IF EXISTS health_failures;
+ALTER TABLE channels DROP COLUMN IF EXISTS health_successes;
+ALTER TABLE channels DROP COLUMN IF EXISTS health_status;
@@ -0,0 +1,7 @@
+-- Add the rolling delivery health of channels
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS health_status VARCHAR(20) NOT NULL DEFAULT 'healthy'
+    CHECK (health_status IN ('healthy', 'degraded', 'disabled'));
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS health_successes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS health_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS health_since BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS health_checked_at BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+-- Drop channel maintenance mode
+ALTER TABLE channels DROP COLUMN IF EXISTS maintenance;
+
+-- Held messages cannot be represented any more, give them up as failed
+UPDATE message_results SET status = 'failed', error_code = 'CHANNEL_MAINTENANCE' WHERE status = 'held';
+UPDATE messages SET status = 'failed' WHERE status = 'held';
+
+ALTER TABLE message_results DROP CONSTRAINT IF EXISTS check_message_result_status;
+ALTER TABLE message_results ADD CONSTRAINT check_message_result_status
+    CHECK (status IN ('success', 'failed'));
+
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS check_message_status;
+ALTER TABLE messages ADD CONSTRAINT check_message_status
+    CHECK (status IN ('pending', 'success', 'failed', 'partial_success'));
@@ -0,0 +1,11 @@
+-- Allow messages and results parked for channels in maintenance
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS check_message_status;
+ALTER TABLE messages ADD CONSTRAINT check_message_status
+    CHECK (status IN ('pending', 'success', 'failed', 'partial_success', 'held'));
+
+ALTER TABLE message_results DROP CONSTRAINT IF EXISTS check_message_result_status;
+ALTER TABLE message_results ADD CONSTRAINT check_message_result_status
+    CHECK (status IN ('success', 'failed', 'held'));
+
+-- Add channel maintenance mode
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS maintenance BOOLEAN NOT NULL DEFAULT FALSE;
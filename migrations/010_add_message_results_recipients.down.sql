@@ -0,0 +1,2 @@
+-- Drop the delivery outcome per recipient
+ALTER TABLE message_results DROP COLUMN IF EXISTS recipients;
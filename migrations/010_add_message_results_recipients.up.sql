@@ -0,0 +1,2 @@
+-- Add the delivery outcome per recipient to message results
+ALTER TABLE message_results ADD COLUMN IF NOT EXISTS recipients JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+-- Drop the provider error classification
+ALTER TABLE message_results DROP COLUMN IF EXISTS error_category;
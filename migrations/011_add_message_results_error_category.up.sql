@@ -0,0 +1,2 @@
+-- Add the provider error classification to message results
+ALTER TABLE message_results ADD COLUMN IF NOT EXISTS error_category VARCHAR(30);
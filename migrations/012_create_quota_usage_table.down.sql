@@ -0,0 +1,2 @@
+-- Drop the send quota usage table
+DROP TABLE IF EXISTS quota_usage;
@@ -0,0 +1,10 @@
+-- Create the send quota usage table, one row per subject and period
+CREATE TABLE IF NOT EXISTS quota_usage (
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('tenant', 'channel')),
+    subject_id VARCHAR(255) NOT NULL,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('daily', 'monthly')),
+    period_key VARCHAR(10) NOT NULL,
+    used INTEGER NOT NULL DEFAULT 0 CHECK (used >= 0),
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (scope, subject_id, period, period_key)
+);
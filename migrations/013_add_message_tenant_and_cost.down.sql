@@ -0,0 +1,4 @@
+-- Drop the estimated send cost and message tenant
+ALTER TABLE message_results DROP COLUMN IF EXISTS cost;
+DROP INDEX IF EXISTS idx_messages_tenant_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS tenant_id;
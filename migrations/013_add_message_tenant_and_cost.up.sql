@@ -0,0 +1,4 @@
+-- Add the tenant of messages and the estimated cost of each send
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_messages_tenant_id ON messages(tenant_id);
+ALTER TABLE message_results ADD COLUMN IF NOT EXISTS cost NUMERIC(14, 6) NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- Drop the owner and team of channels and templates
+DROP INDEX IF EXISTS idx_templates_team;
+DROP INDEX IF EXISTS idx_templates_owner;
+ALTER TABLE templates DROP COLUMN IF EXISTS team;
+ALTER TABLE templates DROP COLUMN IF EXISTS owner;
+
+DROP INDEX IF EXISTS idx_channels_team;
+DROP INDEX IF EXISTS idx_channels_owner;
+ALTER TABLE channels DROP COLUMN IF EXISTS team;
+ALTER TABLE channels DROP COLUMN IF EXISTS owner;
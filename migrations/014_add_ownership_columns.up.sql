@@ -0,0 +1,10 @@
+-- Add the owner and team of channels and templates
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS owner VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS team VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_channels_owner ON channels(owner) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_channels_team ON channels(team) WHERE deleted_at IS NULL;
+
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS owner VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS team VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_templates_owner ON templates(owner) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_templates_team ON templates(team) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- Drop the channel groups table
+DROP TABLE IF EXISTS channel_groups;
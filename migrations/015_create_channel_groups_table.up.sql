@@ -0,0 +1,13 @@
+-- Create the channel groups table, bundling channels under one ID
+CREATE TABLE IF NOT EXISTS channel_groups (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) DEFAULT '',
+    channel_ids TEXT[] NOT NULL DEFAULT '{}',
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    deleted_at BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channel_groups_name_unique ON channel_groups(name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_channel_groups_deleted_at ON channel_groups(deleted_at);
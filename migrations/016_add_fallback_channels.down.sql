@@ -0,0 +1,4 @@
+-- Drop the fallback hop of message results and the fallback channel of channels
+ALTER TABLE message_results DROP COLUMN IF EXISTS fallback_from;
+
+ALTER TABLE channels DROP COLUMN IF EXISTS fallback_channel_id;
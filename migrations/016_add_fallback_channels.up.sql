@@ -0,0 +1,4 @@
+-- Add the fallback channel of channels and the fallback hop of message results
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS fallback_channel_id VARCHAR(255);
+
+ALTER TABLE message_results ADD COLUMN IF NOT EXISTS fallback_from VARCHAR(255);
@@ -0,0 +1,2 @@
+-- Drop the channel tag lookup
+DROP TABLE IF EXISTS channel_tags;
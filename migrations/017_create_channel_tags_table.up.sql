@@ -0,0 +1,14 @@
+-- Create the channel tag lookup, one row per tag of a channel, so that tag
+-- filters use the primary key instead of scanning channels.tags
+CREATE TABLE IF NOT EXISTS channel_tags (
+    tag VARCHAR(255) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    PRIMARY KEY (tag, channel_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_channel_tags_channel_id ON channel_tags(channel_id);
+
+-- Backfill the lookup from the tags of the existing channels
+INSERT INTO channel_tags (tag, channel_id)
+SELECT DISTINCT unnest(tags), id FROM channels WHERE deleted_at IS NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+-- Drop the provisioning sagas table
+DROP INDEX IF EXISTS idx_provisioning_sagas_kind_status;
+DROP TABLE IF EXISTS provisioning_sagas;
@@ -0,0 +1,16 @@
+-- Create the provisioning sagas table, persisting every step of a multi-step
+-- provisioning so that its status can be followed and an interrupted saga
+-- can be compensated after a restart
+CREATE TABLE IF NOT EXISTS provisioning_sagas (
+    id VARCHAR(255) PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    data JSONB NOT NULL DEFAULT '{}',
+    steps JSONB NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_provisioning_sagas_kind_status ON provisioning_sagas(kind, status);
@@ -0,0 +1,3 @@
+-- Drop the failed events table
+DROP INDEX IF EXISTS idx_failed_events_status_failed_at;
+DROP TABLE IF EXISTS failed_events;
@@ -0,0 +1,21 @@
+-- Create the failed events table, persisting the event handler executions
+-- that failed on every attempt with the event payload, so that they can be
+-- replayed once the downstream the handler depends on recovers
+CREATE TABLE IF NOT EXISTS failed_events (
+    id VARCHAR(255) PRIMARY KEY,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL DEFAULT '',
+    aggregate_type VARCHAR(100) NOT NULL DEFAULT '',
+    version BIGINT NOT NULL DEFAULT 0,
+    payload JSONB NOT NULL DEFAULT 'null',
+    handler VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    replay_count INTEGER NOT NULL DEFAULT 0,
+    failed_at BIGINT NOT NULL,
+    replayed_at BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_failed_events_status_failed_at ON failed_events(status, failed_at);
@@ -0,0 +1,2 @@
+-- Drop the aggregate version of channels
+ALTER TABLE channels DROP COLUMN IF EXISTS version;
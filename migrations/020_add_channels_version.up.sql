@@ -0,0 +1,2 @@
+-- Add the aggregate version of channels, carried by the channel events
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1 CHECK (version > 0);
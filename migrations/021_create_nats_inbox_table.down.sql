@@ -0,0 +1,3 @@
+-- Drop the NATS inbox table
+DROP INDEX IF EXISTS idx_nats_inbox_expires_at;
+DROP TABLE IF EXISTS nats_inbox;
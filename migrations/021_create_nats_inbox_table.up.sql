@@ -0,0 +1,16 @@
+-- Create the NATS inbox table, keeping the responses of the command requests
+-- by reqSeqId so that a retried request is answered without running the
+-- command twice
+CREATE TABLE IF NOT EXISTS nats_inbox (
+    subject VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    req_seq_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    response JSONB NOT NULL DEFAULT 'null',
+    received_at BIGINT NOT NULL,
+    completed_at BIGINT,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (subject, client_id, req_seq_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_nats_inbox_expires_at ON nats_inbox(expires_at);
@@ -0,0 +1,2 @@
+-- Drop the attachments of templates
+ALTER TABLE templates DROP COLUMN IF EXISTS attachments;
@@ -0,0 +1,2 @@
+-- Add the attachments of templates, sent with their messages
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS attachments JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,8 @@
+-- Drop the call acknowledgments table
+DROP INDEX IF EXISTS idx_call_acknowledgments_acknowledged_at;
+DROP TABLE IF EXISTS call_acknowledgments;
+
+-- Refuse voice templates again
+ALTER TABLE templates DROP CONSTRAINT IF EXISTS check_template_channel_type;
+ALTER TABLE templates ADD CONSTRAINT check_template_channel_type
+    CHECK (channel_type IN ('email', 'slack', 'sms'));
@@ -0,0 +1,15 @@
+-- Accept voice templates
+ALTER TABLE templates DROP CONSTRAINT IF EXISTS check_template_channel_type;
+ALTER TABLE templates ADD CONSTRAINT check_template_channel_type
+    CHECK (channel_type IN ('email', 'slack', 'sms', 'voice'));
+
+-- Create the call acknowledgments table, keeping the keys the recipients of
+-- voice calls pressed until the sender reads them into the message results
+CREATE TABLE IF NOT EXISTS call_acknowledgments (
+    call_sid VARCHAR(64) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL,
+    digits VARCHAR(32) NOT NULL,
+    acknowledged_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_call_acknowledgments_acknowledged_at ON call_acknowledgments(acknowledged_at);
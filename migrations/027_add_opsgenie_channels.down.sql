@@ -0,0 +1,4 @@
+-- Refuse Opsgenie templates again
+ALTER TABLE templates DROP CONSTRAINT IF EXISTS check_template_channel_type;
+ALTER TABLE templates ADD CONSTRAINT check_template_channel_type
+    CHECK (channel_type IN ('email', 'slack', 'sms', 'voice', 'whatsapp', 'discord', 'pagerduty'));
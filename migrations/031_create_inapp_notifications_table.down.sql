@@ -0,0 +1,9 @@
+-- Drop the in-app notifications table
+DROP INDEX IF EXISTS idx_inapp_notifications_user_unread;
+DROP INDEX IF EXISTS idx_inapp_notifications_user_created_at;
+DROP TABLE IF EXISTS inapp_notifications;
+
+-- Refuse in-app templates again
+ALTER TABLE templates DROP CONSTRAINT IF EXISTS check_template_channel_type;
+ALTER TABLE templates ADD CONSTRAINT check_template_channel_type
+    CHECK (channel_type IN ('email', 'slack', 'sms', 'voice', 'whatsapp', 'discord', 'pagerduty', 'opsgenie', 'jira', 'mqtt', 'statuspage'));
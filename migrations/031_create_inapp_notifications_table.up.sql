@@ -0,0 +1,21 @@
+-- Accept in-app templates
+ALTER TABLE templates DROP CONSTRAINT IF EXISTS check_template_channel_type;
+ALTER TABLE templates ADD CONSTRAINT check_template_channel_type
+    CHECK (channel_type IN ('email', 'slack', 'sms', 'voice', 'whatsapp', 'discord', 'pagerduty', 'opsgenie', 'jira', 'mqtt', 'statuspage', 'inapp'));
+
+-- Create the in-app notifications table, the notification center of the
+-- users the in-app channels deliver to
+CREATE TABLE IF NOT EXISTS inapp_notifications (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    correlation_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL,
+    read_at BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_inapp_notifications_user_created_at ON inapp_notifications(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_inapp_notifications_user_unread ON inapp_notifications(user_id) WHERE read_at IS NULL;
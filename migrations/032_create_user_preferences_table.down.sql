@@ -0,0 +1,2 @@
+-- Drop the user preferences table
+DROP TABLE IF EXISTS user_preferences;
@@ -0,0 +1,11 @@
+-- Create the user preferences table, holding the preferred channels, quiet
+-- hours and category opt-outs of the end users the channels send to
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    preferred_channels TEXT[] NOT NULL DEFAULT '{}',
+    opt_out_categories TEXT[] NOT NULL DEFAULT '{}',
+    quiet_hours_start VARCHAR(5) NOT NULL DEFAULT '',
+    quiet_hours_end VARCHAR(5) NOT NULL DEFAULT '',
+    quiet_hours_timezone VARCHAR(64) NOT NULL DEFAULT '',
+    updated_at BIGINT NOT NULL
+);
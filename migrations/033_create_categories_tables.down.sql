@@ -0,0 +1,4 @@
+-- Drop the categories and their subscriptions
+DROP INDEX IF EXISTS idx_category_subscriptions_category;
+DROP TABLE IF EXISTS category_subscriptions;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,20 @@
+-- Create the categories table, the topics messages are sent in
+CREATE TABLE IF NOT EXISTS categories (
+    name VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    default_subscribed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+-- Create the category subscriptions table, the choices of the recipients to
+-- receive the messages of a category or not
+CREATE TABLE IF NOT EXISTS category_subscriptions (
+    user_id VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL REFERENCES categories(name) ON DELETE CASCADE,
+    subscribed BOOLEAN NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (user_id, category)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_subscriptions_category ON category_subscriptions(category);
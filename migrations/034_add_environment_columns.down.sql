@@ -0,0 +1,6 @@
+-- Drop the deployment environment of channels and templates
+DROP INDEX IF EXISTS idx_templates_environment;
+ALTER TABLE templates DROP COLUMN IF EXISTS environment;
+
+DROP INDEX IF EXISTS idx_channels_environment;
+ALTER TABLE channels DROP COLUMN IF EXISTS environment;
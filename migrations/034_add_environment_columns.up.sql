@@ -0,0 +1,6 @@
+-- Add the deployment environment of channels and templates; empty leaves them unscoped
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS environment VARCHAR(20) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_channels_environment ON channels(environment) WHERE deleted_at IS NULL;
+
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS environment VARCHAR(20) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_templates_environment ON templates(environment) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- Drop the short links table
+DROP INDEX IF EXISTS idx_short_links_message_id;
+DROP TABLE IF EXISTS short_links;
@@ -0,0 +1,13 @@
+-- Create the short links table, the links of the SMS messages sent in short
+-- form and the clicks counted on them
+CREATE TABLE IF NOT EXISTS short_links (
+    code VARCHAR(16) PRIMARY KEY,
+    target_url TEXT NOT NULL,
+    message_id VARCHAR(255) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL DEFAULT '',
+    clicks BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    last_clicked_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_short_links_message_id ON short_links(message_id);
@@ -0,0 +1,2 @@
+-- Drop the policy decisions of the message results
+ALTER TABLE message_results DROP COLUMN IF EXISTS policy_decisions;
@@ -0,0 +1,3 @@
+-- Add the decisions of the pre-send policy hooks to the message results, the
+-- audit trail of each send
+ALTER TABLE message_results ADD COLUMN IF NOT EXISTS policy_decisions JSONB NOT NULL DEFAULT '[]';
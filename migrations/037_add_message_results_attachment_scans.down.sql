@@ -0,0 +1,2 @@
+-- Drop the attachment scans of the message results
+ALTER TABLE message_results DROP COLUMN IF EXISTS attachment_scans;
@@ -0,0 +1,2 @@
+-- Add the virus scans of the attachments sent to the message results
+ALTER TABLE message_results ADD COLUMN IF NOT EXISTS attachment_scans JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,3 @@
+-- Drop the quarantined attachments table
+DROP INDEX IF EXISTS idx_quarantined_attachments_message_id;
+DROP TABLE IF EXISTS quarantined_attachments;
@@ -0,0 +1,14 @@
+-- Create the quarantined attachments table, the infected attachments kept
+-- back from sends for review
+CREATE TABLE IF NOT EXISTS quarantined_attachments (
+    id VARCHAR(255) PRIMARY KEY,
+    message_id VARCHAR(255) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    content BYTEA NOT NULL,
+    signature TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_quarantined_attachments_message_id ON quarantined_attachments(message_id);
@@ -0,0 +1,3 @@
+-- Drop the sender identities table
+DROP INDEX IF EXISTS idx_sender_identities_tenant_value;
+DROP TABLE IF EXISTS sender_identities;
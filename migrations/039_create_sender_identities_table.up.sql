@@ -0,0 +1,14 @@
+-- Create the sender identities table, the addresses and domains each tenant
+-- may send email from once verified
+CREATE TABLE IF NOT EXISTS sender_identities (
+    id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    token VARCHAR(64) NOT NULL,
+    created_at BIGINT NOT NULL,
+    verified_at BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sender_identities_tenant_value ON sender_identities(tenant_id, value);
@@ -0,0 +1,2 @@
+-- Drop the extra email headers of templates
+ALTER TABLE templates DROP COLUMN IF EXISTS headers;
@@ -0,0 +1,2 @@
+-- Add the extra email headers of templates, rendered with the variables of their messages
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}';
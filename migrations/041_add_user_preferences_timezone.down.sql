@@ -0,0 +1,2 @@
+-- Drop the time zone of the users
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS timezone;
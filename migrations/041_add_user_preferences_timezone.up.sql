@@ -0,0 +1,2 @@
+-- Add the time zone of the users, which sends scheduled in local time reach them in
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Drop the scheduled sends table
+DROP TABLE IF EXISTS scheduled_sends;
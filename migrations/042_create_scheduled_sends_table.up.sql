@@ -0,0 +1,18 @@
+-- Create the scheduled sends table, the send requests held until their time;
+-- a send scheduled in recipient-local time has one row per time zone
+CREATE TABLE IF NOT EXISTS scheduled_sends (
+    id VARCHAR(255) PRIMARY KEY,
+    batch_id VARCHAR(255) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL DEFAULT '',
+    send_at BIGINT NOT NULL,
+    request TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL,
+    sent_at BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_sends_batch_id ON scheduled_sends(batch_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_sends_status_send_at ON scheduled_sends(status, send_at);
@@ -0,0 +1,5 @@
+-- Drop the blackout calendar and the deferrals of the scheduled sends
+ALTER TABLE scheduled_sends DROP COLUMN IF EXISTS deferred_by;
+ALTER TABLE scheduled_sends DROP COLUMN IF EXISTS deferred_from;
+ALTER TABLE scheduled_sends DROP COLUMN IF EXISTS critical;
+DROP TABLE IF EXISTS blackouts;
@@ -0,0 +1,20 @@
+-- Create the blackouts table, the periods of the organization calendar such
+-- as change freezes and holidays during which the non-critical scheduled
+-- messages are deferred
+CREATE TABLE IF NOT EXISTS blackouts (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    starts_at BIGINT NOT NULL,
+    ends_at BIGINT NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_blackouts_period ON blackouts(starts_at, ends_at);
+
+-- Record which scheduled sends are critical, and which blackout deferred
+-- the others from when
+ALTER TABLE scheduled_sends ADD COLUMN IF NOT EXISTS critical BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE scheduled_sends ADD COLUMN IF NOT EXISTS deferred_from BIGINT;
+ALTER TABLE scheduled_sends ADD COLUMN IF NOT EXISTS deferred_by VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- Drop the acknowledgments table
+DROP INDEX IF EXISTS idx_acknowledgments_pending;
+DROP INDEX IF EXISTS idx_acknowledgments_message_id;
+DROP TABLE IF EXISTS acknowledgments;
@@ -0,0 +1,14 @@
+-- Create the acknowledgments table, one row per recipient of the messages
+-- sent with acknowledgment links, recording who acknowledged them and when
+CREATE TABLE IF NOT EXISTS acknowledgments (
+    token VARCHAR(64) PRIMARY KEY,
+    message_id VARCHAR(255) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL DEFAULT '',
+    recipient VARCHAR(255) NOT NULL,
+    created_at BIGINT NOT NULL,
+    acknowledged_at BIGINT NOT NULL DEFAULT 0,
+    acknowledged_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_acknowledgments_message_id ON acknowledgments(message_id);
+CREATE INDEX IF NOT EXISTS idx_acknowledgments_pending ON acknowledgments(acknowledged_at, created_at);
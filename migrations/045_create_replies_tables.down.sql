@@ -0,0 +1,6 @@
+-- Drop the replies and reply tokens tables
+DROP INDEX IF EXISTS idx_replies_received_at;
+DROP INDEX IF EXISTS idx_replies_message_id;
+DROP TABLE IF EXISTS replies;
+DROP INDEX IF EXISTS idx_reply_tokens_recipient;
+DROP TABLE IF EXISTS reply_tokens;
@@ -0,0 +1,28 @@
+-- Create the reply tokens table, correlating the replies to the messages of
+-- the email and SMS channels tracking them
+CREATE TABLE IF NOT EXISTS reply_tokens (
+    token VARCHAR(64) PRIMARY KEY,
+    message_id VARCHAR(255) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL,
+    recipient VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reply_tokens_recipient ON reply_tokens(channel_id, recipient, created_at);
+
+-- Create the replies table, the SMS and emails received back from the
+-- recipients
+CREATE TABLE IF NOT EXISTS replies (
+    id VARCHAR(255) PRIMARY KEY,
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    channel_id VARCHAR(255) NOT NULL DEFAULT '',
+    channel_type VARCHAR(50) NOT NULL,
+    sender VARCHAR(255) NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    provider_id VARCHAR(255) NOT NULL DEFAULT '',
+    received_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_replies_message_id ON replies(message_id);
+CREATE INDEX IF NOT EXISTS idx_replies_received_at ON replies(received_at);
@@ -0,0 +1,4 @@
+-- Drop the OTP codes table
+DROP INDEX IF EXISTS idx_otp_codes_expires_at;
+DROP INDEX IF EXISTS idx_otp_codes_recipient;
+DROP TABLE IF EXISTS otp_codes;
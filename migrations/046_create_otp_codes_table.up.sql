@@ -0,0 +1,17 @@
+-- Create the OTP codes table, the one-time verification codes sent and the
+-- hash they are checked against
+CREATE TABLE IF NOT EXISTS otp_codes (
+    id VARCHAR(255) PRIMARY KEY,
+    purpose VARCHAR(100) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    created_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    verified_at BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_otp_codes_recipient ON otp_codes(purpose, recipient, created_at);
+CREATE INDEX IF NOT EXISTS idx_otp_codes_expires_at ON otp_codes(expires_at);
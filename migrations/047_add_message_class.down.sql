@@ -0,0 +1,3 @@
+-- Drop the class of messages
+DROP INDEX IF EXISTS idx_messages_message_class;
+ALTER TABLE messages DROP COLUMN IF EXISTS message_class;
@@ -0,0 +1,4 @@
+-- Add the class of messages, transactional or marketing, the messages sent
+-- before it being marketing messages
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS message_class VARCHAR(20) NOT NULL DEFAULT 'marketing';
+CREATE INDEX IF NOT EXISTS idx_messages_message_class ON messages(message_class);
@@ -0,0 +1,7 @@
+-- Drop the privacy requests and their audit trail
+DROP INDEX IF EXISTS idx_privacy_audit_entries_occurred_at;
+DROP INDEX IF EXISTS idx_privacy_audit_entries_request_id;
+DROP TABLE IF EXISTS privacy_audit_entries;
+DROP INDEX IF EXISTS idx_privacy_requests_status;
+DROP INDEX IF EXISTS idx_privacy_requests_subject_hash;
+DROP TABLE IF EXISTS privacy_requests;
@@ -0,0 +1,35 @@
+-- Create the privacy requests table, the data subject requests exporting or
+-- erasing the data stored about a recipient address
+CREATE TABLE IF NOT EXISTS privacy_requests (
+    id VARCHAR(255) PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    subject_hash VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    report JSONB NOT NULL DEFAULT '{}',
+    export TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL,
+    completed_at BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_privacy_requests_subject_hash ON privacy_requests(subject_hash);
+CREATE INDEX IF NOT EXISTS idx_privacy_requests_status ON privacy_requests(status);
+
+-- Create the audit trail of the privacy requests, which identifies the
+-- subjects by their hash only
+CREATE TABLE IF NOT EXISTS privacy_audit_entries (
+    id VARCHAR(255) PRIMARY KEY,
+    request_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    subject_hash VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    report JSONB NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL DEFAULT '',
+    occurred_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_privacy_audit_entries_request_id ON privacy_audit_entries(request_id);
+CREATE INDEX IF NOT EXISTS idx_privacy_audit_entries_occurred_at ON privacy_audit_entries(occurred_at);
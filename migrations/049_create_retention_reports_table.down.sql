@@ -0,0 +1,5 @@
+-- Drop the retention reports and the purge mark of the messages
+DROP INDEX IF EXISTS idx_retention_reports_started_at;
+DROP INDEX IF EXISTS idx_retention_reports_tenant_id;
+DROP TABLE IF EXISTS retention_reports;
+ALTER TABLE messages DROP COLUMN IF EXISTS content_purged_at;
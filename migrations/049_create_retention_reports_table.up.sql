@@ -0,0 +1,20 @@
+-- Mark the messages whose content the retention job purged
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS content_purged_at BIGINT;
+
+-- Create the retention reports table, what each run of the retention job did
+-- for each tenant
+CREATE TABLE IF NOT EXISTS retention_reports (
+    id VARCHAR(255) PRIMARY KEY,
+    run_id VARCHAR(255) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    content_before BIGINT,
+    metadata_before BIGINT,
+    content_purged INTEGER NOT NULL DEFAULT 0,
+    messages_deleted INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    started_at BIGINT NOT NULL,
+    completed_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_reports_tenant_id ON retention_reports(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_retention_reports_started_at ON retention_reports(started_at);
@@ -0,0 +1,3 @@
+-- Drop the lock leases
+DROP INDEX IF EXISTS idx_lock_leases_expires_at;
+DROP TABLE IF EXISTS lock_leases;
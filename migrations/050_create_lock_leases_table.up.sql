@@ -0,0 +1,9 @@
+-- Create the lock leases table, the locks shared by the replicas such as the
+-- send lock of each message being delivered
+CREATE TABLE IF NOT EXISTS lock_leases (
+    lock_key VARCHAR(255) PRIMARY KEY,
+    owner VARCHAR(255) NOT NULL,
+    expires_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_lock_leases_expires_at ON lock_leases(expires_at);
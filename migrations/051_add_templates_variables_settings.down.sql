@@ -0,0 +1,3 @@
+-- Drop the declared variables and delivery settings of templates
+ALTER TABLE templates DROP COLUMN IF EXISTS settings;
+ALTER TABLE templates DROP COLUMN IF EXISTS variables;
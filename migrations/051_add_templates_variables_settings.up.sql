@@ -0,0 +1,4 @@
+-- Add the variables declared by the author of a template and its delivery
+-- settings, NULL when it has none
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS variables TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS settings JSONB;
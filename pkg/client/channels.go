@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// channelPath returns the HTTP path of a channel
+func channelPath(channelID string, rest ...string) string {
+	path := "/api/v1/channels/" + url.PathEscape(channelID)
+	for _, segment := range rest {
+		path += "/" + segment
+	}
+	return path
+}
+
+// CreateChannel creates a channel
+func (c *Client) CreateChannel(ctx context.Context, req *CreateChannelRequest) (*Channel, error) {
+	var channel Channel
+	err := c.do(ctx, &call{
+		method:  http.MethodPost,
+		path:    "/api/v1/channels",
+		subject: "channel.create",
+		body:    req,
+		data:    req,
+	}, &channel)
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// GetChannel returns a channel by ID
+func (c *Client) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
+	var channel Channel
+	err := c.do(ctx, &call{
+		method:     http.MethodGet,
+		path:       channelPath(channelID),
+		subject:    "channel.get",
+		data:       map[string]string{"channelId": channelID},
+		idempotent: true,
+	}, &channel)
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// ListChannels returns a page of channels
+func (c *Client) ListChannels(ctx context.Context, req *ListChannelsRequest) (*ChannelList, error) {
+	if req == nil {
+		req = &ListChannelsRequest{}
+	}
+
+	query := url.Values{}
+	setQuery(query, "channelType", req.ChannelType)
+	for _, tag := range req.Tags {
+		query.Add("tags", tag)
+	}
+	setQuery(query, "templateId", req.TemplateID)
+	setQuery(query, "owner", req.Owner)
+	setQuery(query, "team", req.Team)
+	setQuery(query, "environment", req.Environment)
+	setQuery(query, "sortField", req.SortField)
+	setQuery(query, "sortOrder", req.SortOrder)
+	setQueryInt(query, "skipCount", req.SkipCount)
+	setQueryInt(query, "maxResultCount", req.MaxResultCount)
+
+	var list ChannelList
+	err := c.do(ctx, &call{
+		method:     http.MethodGet,
+		path:       "/api/v1/channels",
+		query:      query,
+		subject:    "channel.list",
+		data:       req,
+		idempotent: true,
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// UpdateChannel replaces the settings of a channel
+func (c *Client) UpdateChannel(ctx context.Context, channelID string, req *UpdateChannelRequest) (*Channel, error) {
+	var channel Channel
+	err := c.do(ctx, &call{
+		method:  http.MethodPut,
+		path:    channelPath(channelID),
+		subject: "channel.update",
+		body:    req,
+		data: struct {
+			ChannelID string `json:"channelId"`
+			*UpdateChannelRequest
+		}{channelID, req},
+		idempotent: true,
+	}, &channel)
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// DeleteChannel deletes a channel
+func (c *Client) DeleteChannel(ctx context.Context, channelID string) error {
+	return c.do(ctx, &call{
+		method:     http.MethodDelete,
+		path:       channelPath(channelID),
+		subject:    "channel.delete",
+		data:       map[string]string{"channelId": channelID},
+		idempotent: true,
+	}, nil)
+}
+
+// EnableChannel enables a channel
+func (c *Client) EnableChannel(ctx context.Context, channelID string) (*Channel, error) {
+	return c.setChannelEnabled(ctx, channelID, "enable")
+}
+
+// DisableChannel disables a channel
+func (c *Client) DisableChannel(ctx context.Context, channelID string) (*Channel, error) {
+	return c.setChannelEnabled(ctx, channelID, "disable")
+}
+
+// setChannelEnabled handles the shared part of EnableChannel and DisableChannel
+func (c *Client) setChannelEnabled(ctx context.Context, channelID, action string) (*Channel, error) {
+	var channel Channel
+	err := c.do(ctx, &call{
+		method:     http.MethodPost,
+		path:       channelPath(channelID, action),
+		subject:    "channel." + action,
+		data:       map[string]string{"channelId": channelID},
+		idempotent: true,
+	}, &channel)
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// setQuery sets a query parameter when the value is not empty
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// setQueryInt sets a query parameter when the value is positive
+func setQueryInt(query url.Values, key string, value int) {
+	if value > 0 {
+		query.Set(key, strconv.Itoa(value))
+	}
+}
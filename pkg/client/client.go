@@ -0,0 +1,132 @@
+// Package client is the Go SDK for the notification service. It calls the
+// HTTP API or the NATS request/reply API with typed requests and responses,
+// retries transient failures and honours context cancellation.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// DefaultSubjectPrefix is the subject prefix the NATS API listens on
+	DefaultSubjectPrefix = "eco1j.infra.eventcenter"
+	// DefaultTimeout bounds one attempt of a call
+	DefaultTimeout = 30 * time.Second
+)
+
+// Config configures a Client
+type Config struct {
+	// BaseURL is the address of the HTTP API, e.g. http://localhost:8080
+	BaseURL string
+	// NATSConn sends the calls over NATS instead of HTTP when set. The
+	// connection is owned by the caller and not closed by the client.
+	NATSConn *nats.Conn
+	// SubjectPrefix prefixes the NATS subjects; empty uses DefaultSubjectPrefix
+	SubjectPrefix string
+	// APIKey is sent in the X-API-Key header when set
+	APIKey string
+	// Timeout bounds one attempt of a call; zero uses DefaultTimeout
+	Timeout time.Duration
+	// Retry controls the retries of failed calls; the zero value uses DefaultRetryPolicy
+	Retry RetryPolicy
+	// HTTPClient sends the HTTP requests; nil uses a client with Timeout
+	HTTPClient *http.Client
+}
+
+// Client calls the notification service API
+type Client struct {
+	transport transport
+	retry     RetryPolicy
+}
+
+// call describes one API call in both transports
+type call struct {
+	// method, path and query address the HTTP API
+	method string
+	path   string
+	query  url.Values
+	// subject addresses the NATS API, relative to the subject prefix
+	subject string
+	// body is the HTTP request body and data is the NATS request data
+	body interface{}
+	data interface{}
+	// idempotent calls are retried after failures that may have reached the server
+	idempotent bool
+}
+
+// transport sends a call and returns the response data
+type transport interface {
+	do(ctx context.Context, c *call) (json.RawMessage, error)
+}
+
+// New creates a client for the HTTP API, or for the NATS API when a NATS
+// connection is configured
+func New(config Config) (*Client, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.Retry == (RetryPolicy{}) {
+		config.Retry = DefaultRetryPolicy()
+	}
+
+	var t transport
+	switch {
+	case config.NATSConn != nil:
+		prefix := config.SubjectPrefix
+		if prefix == "" {
+			prefix = DefaultSubjectPrefix
+		}
+		t = &natsTransport{
+			conn:          config.NATSConn,
+			subjectPrefix: prefix,
+			apiKey:        config.APIKey,
+			timeout:       config.Timeout,
+		}
+	case config.BaseURL != "":
+		baseURL, err := url.Parse(config.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		httpClient := config.HTTPClient
+		if httpClient == nil {
+			httpClient = &http.Client{Timeout: config.Timeout}
+		}
+		t = &httpTransport{
+			baseURL: baseURL,
+			apiKey:  config.APIKey,
+			http:    httpClient,
+		}
+	default:
+		return nil, errors.New("client: either BaseURL or NATSConn is required")
+	}
+
+	return &Client{transport: t, retry: config.Retry}, nil
+}
+
+// do sends a call with retries and decodes the response data into out
+func (c *Client) do(ctx context.Context, req *call, out interface{}) error {
+	var data json.RawMessage
+	err := c.retry.run(ctx, req.idempotent, func() error {
+		var err error
+		data, err = c.transport.do(ctx, req)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return &Error{Code: "INVALID_RESPONSE", Message: "failed to decode response", Details: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := New(Config{
+		BaseURL: server.URL,
+		APIKey:  "test-key",
+		Retry:   RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_HTTP(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-key", r.Header.Get("X-API-Key"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/messages":
+			var req SendMessageRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, []string{"ch-1"}, req.ChannelIDs)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data":  Message{ID: "msg-1", Status: MessageStatusPending},
+				"error": nil,
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/channels":
+			assert.Equal(t, []string{"a", "b"}, r.URL.Query()["tags"])
+			assert.Equal(t, "10", r.URL.Query().Get("maxResultCount"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": ChannelList{Items: []ChannelSummary{{ChannelID: "ch-1"}}, TotalCount: 1},
+			})
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"title":"Not Found","status":404,"detail":"message not found","code":"MESSAGE_NOT_FOUND"}`))
+		}
+	})
+	ctx := context.Background()
+
+	msg, err := c.SendMessage(ctx, &SendMessageRequest{
+		ChannelIDs: []string{"ch-1"},
+		TemplateID: "tpl-1",
+		Recipients: []Recipient{{Target: "ops@example.com", Type: "email"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", msg.ID)
+	assert.False(t, msg.Status.IsFinal())
+
+	list, err := c.ListChannels(ctx, &ListChannelsRequest{Tags: []string{"a", "b"}, MaxResultCount: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.TotalCount)
+
+	_, err = c.GetMessageStatus(ctx, "missing")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, "message not found (MESSAGE_NOT_FOUND)", err.Error())
+}
+
+func TestClient_Retry(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": Channel{ChannelID: "ch-1"}})
+	})
+	ctx := context.Background()
+
+	// Reads are retried on transient failures
+	channel, err := c.GetChannel(ctx, "ch-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ch-1", channel.ChannelID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	// Sends are not retried once the request reached the service
+	atomic.StoreInt32(&attempts, 0)
+	_, err = c.SendMessage(ctx, &SendMessageRequest{TemplateID: "tpl-1"})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestNew_RequiresTransport(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// FieldError describes a problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error answered by the notification service
+type Error struct {
+	// StatusCode is the HTTP status code; zero for NATS responses
+	StatusCode int
+	// Code is the machine-readable error code, e.g. CHANNEL_NOT_FOUND
+	Code    string
+	Message string
+	Details string
+	// FieldErrors lists the per-field validation failures
+	FieldErrors []FieldError
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	message := e.Message
+	if e.Details != "" {
+		message = e.Details
+	}
+	if message == "" && e.StatusCode != 0 {
+		message = http.StatusText(e.StatusCode)
+	}
+	if e.Code == "" {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, e.Code)
+}
+
+// IsNotFound reports whether err is an error for a resource that does not exist
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || strings.HasSuffix(apiErr.Code, "NOT_FOUND")
+}
+
+// IsValidation reports whether err is an error for an invalid request
+func IsValidation(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return true
+	}
+	return apiErr.Code == "INVALID_REQUEST" || apiErr.Code == "VALIDATION_ERROR"
+}
+
+// IsConflict reports whether err is an error for a conflicting change
+func IsConflict(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusPreconditionFailed)
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// httpTransport calls the HTTP API
+type httpTransport struct {
+	baseURL *url.URL
+	apiKey  string
+	http    *http.Client
+}
+
+// do sends the call as an HTTP request and unwraps the data of the response
+// envelope
+func (t *httpTransport) do(ctx context.Context, c *call) (json.RawMessage, error) {
+	target := t.baseURL.JoinPath(c.path)
+	if len(c.query) > 0 {
+		target.RawQuery = c.query.Encode()
+	}
+
+	var body io.Reader
+	if c.body != nil {
+		data, err := json.Marshal(c.body)
+		if err != nil {
+			return nil, &Error{Code: "INVALID_REQUEST", Message: "failed to encode request", Details: err.Error()}
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if t.apiKey != "" {
+		req.Header.Set("X-API-Key", t.apiKey)
+	}
+
+	resp, err := t.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, problemError(resp.StatusCode, data)
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, &Error{StatusCode: resp.StatusCode, Code: "INVALID_RESPONSE", Message: "failed to decode response", Details: err.Error()}
+	}
+	return envelope.Data, nil
+}
+
+// problemError converts a problem details response into an Error
+func problemError(status int, data []byte) *Error {
+	var problem struct {
+		Title  string       `json:"title"`
+		Detail string       `json:"detail"`
+		Code   string       `json:"code"`
+		Errors []FieldError `json:"errors"`
+	}
+	_ = json.Unmarshal(data, &problem)
+
+	return &Error{
+		StatusCode:  status,
+		Code:        problem.Code,
+		Message:     problem.Title,
+		Details:     problem.Detail,
+		FieldErrors: problem.Errors,
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SendMessage sends a message and returns it with its delivery results. It is
+// only retried when the request cannot have reached the service.
+func (c *Client) SendMessage(ctx context.Context, req *SendMessageRequest) (*Message, error) {
+	var msg Message
+	err := c.do(ctx, &call{
+		method:  http.MethodPost,
+		path:    "/api/v1/messages",
+		subject: "message.send",
+		body:    req,
+		data:    req,
+	}, &msg)
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// GetMessage returns a message by ID
+func (c *Client) GetMessage(ctx context.Context, messageID string) (*Message, error) {
+	var msg Message
+	err := c.do(ctx, &call{
+		method:     http.MethodGet,
+		path:       "/api/v1/messages/" + url.PathEscape(messageID),
+		subject:    "message.get",
+		data:       map[string]string{"messageId": messageID},
+		idempotent: true,
+	}, &msg)
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// GetMessageStatus returns the delivery status of a message
+func (c *Client) GetMessageStatus(ctx context.Context, messageID string) (*DeliveryStatus, error) {
+	msg, err := c.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	return &DeliveryStatus{
+		MessageID: msg.ID,
+		Status:    msg.Status,
+		Results:   msg.Results,
+	}, nil
+}
+
+// ListMessages returns a page of messages
+func (c *Client) ListMessages(ctx context.Context, req *ListMessagesRequest) (*MessageList, error) {
+	if req == nil {
+		req = &ListMessagesRequest{}
+	}
+
+	query := url.Values{}
+	setQuery(query, "channelId", req.ChannelID)
+	setQuery(query, "status", string(req.Status))
+	setQueryInt(query, "skipCount", req.SkipCount)
+	setQueryInt(query, "maxResultCount", req.MaxResultCount)
+
+	var list MessageList
+	err := c.do(ctx, &call{
+		method:     http.MethodGet,
+		path:       "/api/v1/messages",
+		query:      query,
+		subject:    "message.list",
+		data:       req,
+		idempotent: true,
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+// natsTransport calls the NATS request/reply API
+type natsTransport struct {
+	conn          *nats.Conn
+	subjectPrefix string
+	apiKey        string
+	timeout       time.Duration
+}
+
+// envelopeVersion is the version of the NATS envelope format the client speaks.
+// Versioned requests are validated against the contract of their subject.
+const envelopeVersion = 1
+
+// compressThreshold is the size from which request payloads are sent gzip
+// compressed; responses are always accepted compressed
+const compressThreshold = 64 << 10 // 64 KiB
+
+// natsRequest is the request envelope of the NATS API
+type natsRequest struct {
+	ReqSeqId  string      `json:"reqSeqId"`
+	Version   int         `json:"version"`
+	Data      interface{} `json:"data"`
+	Timestamp int64       `json:"timestamp"`
+	Timeout   int64       `json:"timeout,omitempty"`
+}
+
+// natsResponse is the response envelope of the NATS API
+type natsResponse struct {
+	ReqSeqId string          `json:"reqSeqId"`
+	Success  bool            `json:"success"`
+	Data     json.RawMessage `json:"data"`
+	Error    *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details string `json:"details"`
+	} `json:"error"`
+}
+
+// do sends the call as a NATS request and unwraps the data of the reply
+func (t *natsTransport) do(ctx context.Context, c *call) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	timeout := t.timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	data, err := json.Marshal(natsRequest{
+		ReqSeqId:  uuid.NewString(),
+		Version:   envelopeVersion,
+		Data:      c.data,
+		Timestamp: time.Now().UnixMilli(),
+		Timeout:   timeout.Milliseconds(),
+	})
+	if err != nil {
+		return nil, &Error{Code: "INVALID_REQUEST", Message: "failed to encode request", Details: err.Error()}
+	}
+
+	msg := nats.NewMsg(t.subjectPrefix + "." + c.subject)
+	msg.Data = data
+	msg.Header.Set("Accept-Encoding", "gzip")
+	if len(data) >= compressThreshold {
+		if msg.Data, err = gzipBytes(data); err != nil {
+			return nil, &Error{Code: "INVALID_REQUEST", Message: "failed to compress request", Details: err.Error()}
+		}
+		msg.Header.Set("Content-Encoding", "gzip")
+	}
+	if t.apiKey != "" {
+		msg.Header.Set("X-API-Key", t.apiKey)
+	}
+
+	reply, err := t.conn.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", msg.Subject, err)
+	}
+
+	payload := reply.Data
+	if reply.Header.Get("Content-Encoding") == "gzip" {
+		if payload, err = gunzipBytes(payload); err != nil {
+			return nil, &Error{Code: "INVALID_RESPONSE", Message: "failed to decompress response", Details: err.Error()}
+		}
+	}
+
+	var response natsResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, &Error{Code: "INVALID_RESPONSE", Message: "failed to decode response", Details: err.Error()}
+	}
+	if !response.Success {
+		if response.Error == nil {
+			return nil, &Error{Code: "EXECUTION_ERROR", Message: "request failed"}
+		}
+		return nil, &Error{
+			Code:    response.Error.Code,
+			Message: response.Error.Message,
+			Details: response.Error.Details,
+		}
+	}
+	return response.Data, nil
+}
+
+// gzipBytes compresses data with gzip
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gunzipBytes decompresses gzip data
+func gunzipBytes(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
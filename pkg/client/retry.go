@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// RetryPolicy controls the retries of failed calls. Calls that create or send
+// are only retried when the request cannot have reached the service, so that
+// a retry never sends a message twice.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; 1 disables retries
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled for each further retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+	}
+}
+
+// NoRetry returns a retry policy that makes a single attempt
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// run calls attempt until it succeeds, fails permanently, the attempts are
+// exhausted or the context is done
+func (p RetryPolicy) run(ctx context.Context, idempotent bool, attempt func() error) error {
+	backoff := p.InitialBackoff
+	for i := 1; ; i++ {
+		err := attempt()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || i >= p.MaxAttempts || !retryable(err, idempotent) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// retryable reports whether a failed call may succeed when it is retried
+func retryable(err error, idempotent bool) bool {
+	// Nobody received the request: safe to retry any call
+	if errors.Is(err, nats.ErrNoResponders) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	if !idempotent {
+		return false
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	// Timeouts and broken connections
+	return true
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// templatePath returns the HTTP path of a template
+func templatePath(templateID string) string {
+	return "/api/v1/templates/" + url.PathEscape(templateID)
+}
+
+// CreateTemplate creates a template
+func (c *Client) CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*Template, error) {
+	var template Template
+	err := c.do(ctx, &call{
+		method:  http.MethodPost,
+		path:    "/api/v1/templates",
+		subject: "template.create",
+		body:    req,
+		data:    req,
+	}, &template)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplate returns a template by ID
+func (c *Client) GetTemplate(ctx context.Context, templateID string) (*Template, error) {
+	var template Template
+	err := c.do(ctx, &call{
+		method:     http.MethodGet,
+		path:       templatePath(templateID),
+		subject:    "template.get",
+		data:       map[string]string{"templateId": templateID},
+		idempotent: true,
+	}, &template)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ListTemplates returns a page of templates
+func (c *Client) ListTemplates(ctx context.Context, req *ListTemplatesRequest) (*TemplateList, error) {
+	if req == nil {
+		req = &ListTemplatesRequest{}
+	}
+
+	query := url.Values{}
+	setQuery(query, "channelType", req.ChannelType)
+	for _, tag := range req.Tags {
+		query.Add("tags", tag)
+	}
+	setQuery(query, "owner", req.Owner)
+	setQuery(query, "team", req.Team)
+	setQuery(query, "environment", req.Environment)
+	setQueryInt(query, "skipCount", req.SkipCount)
+	setQueryInt(query, "maxResultCount", req.MaxResultCount)
+
+	var list TemplateList
+	err := c.do(ctx, &call{
+		method:     http.MethodGet,
+		path:       "/api/v1/templates",
+		query:      query,
+		subject:    "template.list",
+		data:       req,
+		idempotent: true,
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// UpdateTemplate updates the given fields of a template
+func (c *Client) UpdateTemplate(ctx context.Context, templateID string, req *UpdateTemplateRequest) (*Template, error) {
+	var template Template
+	err := c.do(ctx, &call{
+		method:  http.MethodPatch,
+		path:    templatePath(templateID),
+		subject: "template.update",
+		body:    req,
+		data: struct {
+			TemplateID string `json:"templateId"`
+			*UpdateTemplateRequest
+		}{templateID, req},
+		idempotent: true,
+	}, &template)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// DeleteTemplate deletes a template. Templates still referenced by channels
+// are only deleted with the Force or ReassignTo option.
+func (c *Client) DeleteTemplate(ctx context.Context, templateID string, opts *DeleteTemplateOptions) error {
+	if opts == nil {
+		opts = &DeleteTemplateOptions{}
+	}
+
+	query := url.Values{}
+	if opts.Force {
+		query.Set("force", strconv.FormatBool(opts.Force))
+	}
+	setQuery(query, "reassignTo", opts.ReassignTo)
+
+	return c.do(ctx, &call{
+		method:  http.MethodDelete,
+		path:    templatePath(templateID),
+		query:   query,
+		subject: "template.delete",
+		data: struct {
+			TemplateID string `json:"templateId"`
+			*DeleteTemplateOptions
+		}{templateID, opts},
+		idempotent: true,
+	}, nil)
+}
@@ -0,0 +1,311 @@
+package client
+
+import "time"
+
+// Recipient is a recipient of a channel or a message
+type Recipient struct {
+	Name   string `json:"name,omitempty"`
+	Target string `json:"target,omitempty"`
+	Type   string `json:"type"`
+}
+
+// CommonSettings holds the timeout and retry settings, in milliseconds
+type CommonSettings struct {
+	Timeout       int `json:"timeout"`
+	RetryAttempts int `json:"retryAttempts"`
+	RetryDelay    int `json:"retryDelay"`
+}
+
+// CreateChannelRequest is the request to create a channel
+type CreateChannelRequest struct {
+	ChannelName       string                 `json:"channelName"`
+	Description       string                 `json:"description,omitempty"`
+	Enabled           bool                   `json:"enabled"`
+	ChannelType       string                 `json:"channelType"`
+	TemplateID        string                 `json:"templateId,omitempty"`
+	CommonSettings    CommonSettings         `json:"commonSettings"`
+	Config            map[string]interface{} `json:"config"`
+	Recipients        []Recipient            `json:"recipients,omitempty"`
+	Tags              []string               `json:"tags,omitempty"`
+	VariableDefaults  map[string]interface{} `json:"variableDefaults,omitempty"`
+	Owner             string                 `json:"owner,omitempty"`
+	Team              string                 `json:"team,omitempty"`
+	Environment       string                 `json:"environment,omitempty"`
+	FallbackChannelID string                 `json:"fallbackChannelId,omitempty"`
+}
+
+// UpdateChannelRequest is the request to replace the settings of a channel
+type UpdateChannelRequest CreateChannelRequest
+
+// ListChannelsRequest filters, sorts and pages the listed channels
+type ListChannelsRequest struct {
+	ChannelType    string   `json:"channelType,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	TemplateID     string   `json:"templateId,omitempty"`
+	Owner          string   `json:"owner,omitempty"`
+	Team           string   `json:"team,omitempty"`
+	Environment    string   `json:"environment,omitempty"`
+	SortField      string   `json:"sortField,omitempty"`
+	SortOrder      string   `json:"sortOrder,omitempty"`
+	SkipCount      int      `json:"skipCount,omitempty"`
+	MaxResultCount int      `json:"maxResultCount,omitempty"`
+}
+
+// ChannelHealth is the delivery health of a channel
+type ChannelHealth struct {
+	Status       string  `json:"status"`
+	SuccessCount int     `json:"successCount"`
+	FailureCount int     `json:"failureCount"`
+	FailureRate  float64 `json:"failureRate"`
+	CheckedAt    int64   `json:"checkedAt,omitempty"`
+}
+
+// Channel is a notification channel. Times are Unix milliseconds.
+type Channel struct {
+	ChannelID         string                 `json:"channelId"`
+	ChannelName       string                 `json:"channelName"`
+	Description       string                 `json:"description"`
+	Enabled           bool                   `json:"enabled"`
+	Maintenance       bool                   `json:"maintenance"`
+	ChannelType       string                 `json:"channelType"`
+	TemplateID        string                 `json:"templateId,omitempty"`
+	CommonSettings    CommonSettings         `json:"commonSettings"`
+	Config            map[string]interface{} `json:"config"`
+	Recipients        []Recipient            `json:"recipients"`
+	Tags              []string               `json:"tags"`
+	VariableDefaults  map[string]interface{} `json:"variableDefaults"`
+	Owner             string                 `json:"owner,omitempty"`
+	Team              string                 `json:"team,omitempty"`
+	Environment       string                 `json:"environment,omitempty"`
+	FallbackChannelID string                 `json:"fallbackChannelId,omitempty"`
+	Health            *ChannelHealth         `json:"health,omitempty"`
+	CreatedAt         int64                  `json:"createdAt"`
+	UpdatedAt         int64                  `json:"updatedAt"`
+	LastUsed          *int64                 `json:"lastUsed,omitempty"`
+}
+
+// ChannelSummary is a channel as listed
+type ChannelSummary struct {
+	ChannelID   string         `json:"channelId"`
+	ChannelName string         `json:"channelName"`
+	ChannelType string         `json:"channelType"`
+	TemplateID  string         `json:"templateId,omitempty"`
+	Tags        []string       `json:"tags"`
+	Enabled     bool           `json:"enabled"`
+	Maintenance bool           `json:"maintenance"`
+	Owner       string         `json:"owner,omitempty"`
+	Team        string         `json:"team,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	Health      *ChannelHealth `json:"health,omitempty"`
+	CreatedAt   int64          `json:"createdAt"`
+	UpdatedAt   int64          `json:"updatedAt"`
+	LastUsed    *int64         `json:"lastUsed,omitempty"`
+}
+
+// ChannelList is a page of channels
+type ChannelList struct {
+	Items          []ChannelSummary `json:"items"`
+	SkipCount      int              `json:"skipCount"`
+	MaxResultCount int              `json:"maxResultCount"`
+	TotalCount     int              `json:"totalCount"`
+	HasMore        bool             `json:"hasMore"`
+}
+
+// CreateTemplateRequest is the request to create a template
+type CreateTemplateRequest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	ChannelType string          `json:"channelType"`
+	Subject     string          `json:"subject,omitempty"`
+	Content     string          `json:"content"`
+	Variables   []string        `json:"variables,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Strict      bool            `json:"strict,omitempty"`
+	Owner       string          `json:"owner,omitempty"`
+	Team        string          `json:"team,omitempty"`
+	Environment string          `json:"environment,omitempty"`
+	Settings    *CommonSettings `json:"settings,omitempty"`
+}
+
+// UpdateTemplateRequest is the request to update the given fields of a template
+type UpdateTemplateRequest struct {
+	Name        *string         `json:"name,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Subject     *string         `json:"subject,omitempty"`
+	Content     *string         `json:"content,omitempty"`
+	Variables   []string        `json:"variables,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+	Owner       *string         `json:"owner,omitempty"`
+	Team        *string         `json:"team,omitempty"`
+	Environment *string         `json:"environment,omitempty"`
+	Settings    *CommonSettings `json:"settings,omitempty"`
+}
+
+// DeleteTemplateOptions controls the deletion of a template still referenced by channels
+type DeleteTemplateOptions struct {
+	// Force detaches the referencing channels from the template
+	Force bool `json:"force,omitempty"`
+	// ReassignTo moves the referencing channels to another template
+	ReassignTo string `json:"reassignTo,omitempty"`
+}
+
+// ListTemplatesRequest filters and pages the listed templates
+type ListTemplatesRequest struct {
+	ChannelType    string   `json:"channelType,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Owner          string   `json:"owner,omitempty"`
+	Team           string   `json:"team,omitempty"`
+	Environment    string   `json:"environment,omitempty"`
+	SkipCount      int      `json:"skipCount,omitempty"`
+	MaxResultCount int      `json:"maxResultCount,omitempty"`
+}
+
+// Template is a message template
+type Template struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	ChannelType string          `json:"channelType"`
+	Subject     string          `json:"subject,omitempty"`
+	Content     string          `json:"content"`
+	Variables   []string        `json:"variables,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Strict      bool            `json:"strict"`
+	Owner       string          `json:"owner,omitempty"`
+	Team        string          `json:"team,omitempty"`
+	Environment string          `json:"environment,omitempty"`
+	Version     int             `json:"version"`
+	Settings    *CommonSettings `json:"settings,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// TemplateList is a page of templates
+type TemplateList struct {
+	Items          []Template `json:"items"`
+	SkipCount      int        `json:"skipCount"`
+	MaxResultCount int        `json:"maxResultCount"`
+	TotalCount     int        `json:"totalCount"`
+	HasMore        bool       `json:"hasMore"`
+}
+
+// TemplateOverride replaces the subject and/or content of the template for one channel
+type TemplateOverride struct {
+	Subject  *string `json:"subject,omitempty"`
+	Template *string `json:"template,omitempty"`
+}
+
+// ChannelOverride adjusts a send for one channel
+type ChannelOverride struct {
+	Recipients       []Recipient       `json:"recipients,omitempty"`
+	TemplateOverride *TemplateOverride `json:"templateOverride,omitempty"`
+	// Config overrides the non-secret config keys of the channel, e.g. the email sender
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// SendMessageRequest is the request to send a message. The channels are
+// given by ID, by tag and/or by channel group.
+type SendMessageRequest struct {
+	ChannelIDs       []string                   `json:"channelIds,omitempty"`
+	ChannelTags      []string                   `json:"channelTags,omitempty"`
+	ChannelGroupIDs  []string                   `json:"channelGroupIds,omitempty"`
+	TemplateID       string                     `json:"templateId"`
+	Recipients       []Recipient                `json:"recipients"`
+	Variables        map[string]interface{}     `json:"variables,omitempty"`
+	ChannelOverrides map[string]ChannelOverride `json:"channelOverrides,omitempty"`
+	Settings         *CommonSettings            `json:"settings,omitempty"`
+	CorrelationID    string                     `json:"correlationId,omitempty"`
+	TenantID         string                     `json:"tenantId,omitempty"`
+	Category         string                     `json:"category,omitempty"`
+	Strict           bool                       `json:"strict,omitempty"`
+	// AllowProd lets a deployment below prod send through prod channels and templates
+	AllowProd bool `json:"allowProd,omitempty"`
+}
+
+// ListMessagesRequest filters and pages the listed messages
+type ListMessagesRequest struct {
+	ChannelID      string        `json:"channelId,omitempty"`
+	Status         MessageStatus `json:"status,omitempty"`
+	SkipCount      int           `json:"skipCount,omitempty"`
+	MaxResultCount int           `json:"maxResultCount,omitempty"`
+}
+
+// MessageStatus is the delivery status of a message
+type MessageStatus string
+
+const (
+	MessageStatusPending        MessageStatus = "pending"
+	MessageStatusSuccess        MessageStatus = "success"
+	MessageStatusPartialSuccess MessageStatus = "partial_success"
+	MessageStatusFailed         MessageStatus = "failed"
+	MessageStatusHeld           MessageStatus = "held"
+)
+
+// IsFinal reports whether the status no longer changes. Held messages are
+// sent when their channels leave maintenance.
+func (s MessageStatus) IsFinal() bool {
+	switch s {
+	case MessageStatusSuccess, MessageStatusPartialSuccess, MessageStatusFailed:
+		return true
+	}
+	return false
+}
+
+// RecipientResult is the delivery result for one recipient on a channel
+type RecipientResult struct {
+	Target            string `json:"target"`
+	Status            string `json:"status"`
+	ProviderMessageID string `json:"providerMessageId,omitempty"`
+	Error             string `json:"error,omitempty"`
+	ErrorCategory     string `json:"errorCategory,omitempty"`
+	SentAt            *int64 `json:"sentAt,omitempty"`
+	// SuppressionReason tells why the preferences or subscriptions of the recipient stopped the send
+	SuppressionReason string `json:"suppressionReason,omitempty"`
+}
+
+// MessageResult is the delivery result on one channel
+type MessageResult struct {
+	ChannelID     string            `json:"channelId"`
+	Recipient     string            `json:"recipient"`
+	Status        string            `json:"status"`
+	Error         string            `json:"error,omitempty"`
+	ErrorCategory string            `json:"errorCategory,omitempty"`
+	SentAt        *int64            `json:"sentAt,omitempty"`
+	Cost          float64           `json:"cost,omitempty"`
+	Recipients    []RecipientResult `json:"recipients,omitempty"`
+	// FallbackFrom is the channel this channel was used as the fallback for
+	FallbackFrom string `json:"fallbackFrom,omitempty"`
+}
+
+// Message is a sent message. Times are Unix milliseconds.
+type Message struct {
+	ID               string                     `json:"id"`
+	ChannelID        string                     `json:"channelId"`
+	TemplateID       string                     `json:"templateId"`
+	Recipients       []Recipient                `json:"recipients"`
+	Variables        map[string]interface{}     `json:"variables,omitempty"`
+	ChannelOverrides map[string]ChannelOverride `json:"channelOverrides,omitempty"`
+	CorrelationID    string                     `json:"correlationId,omitempty"`
+	TenantID         string                     `json:"tenantId,omitempty"`
+	Status           MessageStatus              `json:"status"`
+	Results          []MessageResult            `json:"results,omitempty"`
+	Settings         *CommonSettings            `json:"settings,omitempty"`
+	CreatedAt        int64                      `json:"createdAt"`
+}
+
+// MessageList is a page of messages
+type MessageList struct {
+	Items          []Message `json:"items"`
+	SkipCount      int       `json:"skipCount"`
+	MaxResultCount int       `json:"maxResultCount"`
+	TotalCount     int       `json:"totalCount"`
+	HasMore        bool      `json:"hasMore"`
+}
+
+// DeliveryStatus is the delivery status of a message and its results per channel
+type DeliveryStatus struct {
+	MessageID string          `json:"messageId"`
+	Status    MessageStatus   `json:"status"`
+	Results   []MessageResult `json:"results,omitempty"`
+}
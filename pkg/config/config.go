@@ -2,111 +2,471 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"strconv"
 
 	"github.com/joho/godotenv"
+
+	"notification/pkg/pii"
 )
 
 // LegacySystemConfig holds configuration for the legacy system
 type LegacySystemConfig struct {
-	URL   string `json:"url"`
-	Token string `json:"token"`
+	URL   string `json:"url" yaml:"url"`
+	Token string `json:"token" yaml:"token"`
 }
 
 // Config holds all application configuration
 type Config struct {
-	Server       ServerConfig
-	Database     DatabaseConfig
-	NATS         NATSConfig
-	Logger       LoggerConfig
-	LegacySystem LegacySystemConfig
+	Server        ServerConfig        `json:"server" yaml:"server"`
+	Database      DatabaseConfig      `json:"database" yaml:"database"`
+	NATS          NATSConfig          `json:"nats" yaml:"nats"`
+	Logger        LoggerConfig        `json:"logger" yaml:"logger"`
+	LegacySystem  LegacySystemConfig  `json:"legacySystem" yaml:"legacySystem"`
+	ChannelHealth ChannelHealthConfig `json:"channelHealth" yaml:"channelHealth"`
+	Quota         QuotaConfig         `json:"quota" yaml:"quota"`
+	Pricing       PricingConfig       `json:"pricing" yaml:"pricing"`
+	Mirror        MirrorConfig        `json:"mirror" yaml:"mirror"`
+	Ownership     OwnershipConfig     `json:"ownership" yaml:"ownership"`
+
+	ProviderKeepAlive ProviderKeepAliveConfig `json:"providerKeepAlive" yaml:"providerKeepAlive"`
+	FailureDigest     FailureDigestConfig     `json:"failureDigest" yaml:"failureDigest"`
+	SendLock          SendLockConfig          `json:"sendLock" yaml:"sendLock"`
+	SelfTest          SelfTestConfig          `json:"selfTest" yaml:"selfTest"`
+	ChangeEvents      ChangeEventsConfig      `json:"changeEvents" yaml:"changeEvents"`
+	ContentAdaptation ContentAdaptationConfig `json:"contentAdaptation" yaml:"contentAdaptation"`
+	LinkShortening    LinkShorteningConfig    `json:"linkShortening" yaml:"linkShortening"`
+	SendPolicy        SendPolicyConfig        `json:"sendPolicy" yaml:"sendPolicy"`
+	AttachmentScan    AttachmentScanConfig    `json:"attachmentScan" yaml:"attachmentScan"`
+	SenderIdentity    SenderIdentityConfig    `json:"senderIdentity" yaml:"senderIdentity"`
+	ScheduledSend     ScheduledSendConfig     `json:"scheduledSend" yaml:"scheduledSend"`
+	Acknowledgment    AcknowledgmentConfig    `json:"acknowledgment" yaml:"acknowledgment"`
+	Replies           RepliesConfig           `json:"replies" yaml:"replies"`
+	OTP               OTPConfig               `json:"otp" yaml:"otp"`
+	PII               PIIConfig               `json:"pii" yaml:"pii"`
+	Retention         RetentionConfig         `json:"retention" yaml:"retention"`
+	SLO               SLOConfig               `json:"slo" yaml:"slo"`
 }
 
+// Run modes select which parts of the service a process runs
+const (
+	// ModeAPI serves the HTTP and NATS APIs and hands messages to the workers
+	ModeAPI = "api"
+	// ModeWorker runs the send workers only
+	ModeWorker = "worker"
+	// ModeAll serves the APIs, sends messages inline and runs the workers
+	ModeAll = "all"
+)
+
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port         int    `json:"port"`
-	Host         string `json:"host"`
-	ReadTimeout  int    `json:"readTimeout"`
-	WriteTimeout int    `json:"writeTimeout"`
+	Mode         string `json:"mode" yaml:"mode"` // api, worker or all
+	Port         int    `json:"port" yaml:"port"`
+	Host         string `json:"host" yaml:"host"`
+	ReadTimeout  int    `json:"readTimeout" yaml:"readTimeout"`
+	WriteTimeout int    `json:"writeTimeout" yaml:"writeTimeout"`
+
+	// Payload limits, applied to HTTP request bodies and NATS messages
+	MaxBodyBytes int  `json:"maxBodyBytes" yaml:"maxBodyBytes"`
+	MaxJSONDepth int  `json:"maxJsonDepth" yaml:"maxJsonDepth"`
+	StrictJSON   bool `json:"strictJson" yaml:"strictJson"` // reject unknown JSON fields in HTTP request bodies
+
+	// WorkerConcurrency is the number of messages a send worker delivers at a time
+	WorkerConcurrency int `json:"workerConcurrency" yaml:"workerConcurrency"`
+
+	// Send intake: at most SendConcurrency send requests are handled at a time
+	// and up to SendQueueDepth more wait for their turn; the rest are answered
+	// with RETRY_LATER and a delay of SendRetryAfter seconds. A zero
+	// SendConcurrency disables the intake.
+	SendConcurrency int `json:"sendConcurrency" yaml:"sendConcurrency"`
+	SendQueueDepth  int `json:"sendQueueDepth" yaml:"sendQueueDepth"`
+	SendRetryAfter  int `json:"sendRetryAfter" yaml:"sendRetryAfter"` // in seconds
+
+	// Environment is the deployment stage, dev, staging or prod. It is the
+	// default environment filter of the channel and template lists, and a
+	// deployment below prod may only send through prod channels and templates
+	// when a request allows it. Empty leaves the deployment unscoped.
+	Environment string `json:"environment" yaml:"environment"`
+
+	// ReadOnly starts the instance in read-only mode: it serves queries but
+	// rejects commands with 503 READ_ONLY and pauses its workers, as a warm
+	// standby or during a maintenance window. Admins can toggle it at runtime.
+	ReadOnly bool `json:"readOnly" yaml:"readOnly"`
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
-	Type           string `json:"type"` // postgres, sqlite, sqlserver
-	Host           string `json:"host"`
-	Port           int    `json:"port"`
-	User           string `json:"user"`
-	Password       string `json:"password"`
-	DBName         string `json:"dbName"`
-	Schema         string `json:"schema"`
-	SSLMode        string `json:"sslMode"`
-	MaxOpenConns   int    `json:"maxOpenConns"`
-	MaxIdleConns   int    `json:"maxIdleConns"`
-	MaxLifetime    int    `json:"maxLifetime"` // in minutes
-	MigrationsPath string `json:"migrationsPath"`
+	Type           string `json:"type" yaml:"type"` // postgres, sqlite, sqlserver
+	Host           string `json:"host" yaml:"host"`
+	Port           int    `json:"port" yaml:"port"`
+	User           string `json:"user" yaml:"user"`
+	Password       string `json:"password" yaml:"password"`
+	DBName         string `json:"dbName" yaml:"dbName"`
+	Schema         string `json:"schema" yaml:"schema"`
+	SSLMode        string `json:"sslMode" yaml:"sslMode"`
+	MaxOpenConns   int    `json:"maxOpenConns" yaml:"maxOpenConns"`
+	MaxIdleConns   int    `json:"maxIdleConns" yaml:"maxIdleConns"`
+	MaxLifetime    int    `json:"maxLifetime" yaml:"maxLifetime"` // in minutes
+	MaxIdleTime    int    `json:"maxIdleTime" yaml:"maxIdleTime"` // in minutes, 0 keeps idle connections
+	MigrationsPath string `json:"migrationsPath" yaml:"migrationsPath"`
+	// SlowQueryThreshold is the duration in milliseconds above which a query
+	// is logged as slow; 0 disables slow query logging
+	SlowQueryThreshold int `json:"slowQueryThreshold" yaml:"slowQueryThreshold"`
+	// ReplicaDSNs are driver DSNs of read replicas. Get and list queries are
+	// served by a replica and may lag behind the primary; commands and the
+	// reads they make use the primary.
+	ReplicaDSNs []string `json:"replicaDsns,omitempty" yaml:"replicaDsns"`
 }
 
 // NATSConfig holds NATS configuration
 type NATSConfig struct {
-	URL            string `json:"url"`
-	CredsPath      string `json:"credsPath"`
-	MaxReconnects  int    `json:"maxReconnects"`
-	ReconnectWait  int    `json:"reconnectWait"`  // in seconds
-	RequestTimeout int    `json:"requestTimeout"` // in seconds
-	SubjectPrefix  string `json:"subjectPrefix"`
+	URL            string `json:"url" yaml:"url"`
+	CredsPath      string `json:"credsPath" yaml:"credsPath"`
+	MaxReconnects  int    `json:"maxReconnects" yaml:"maxReconnects"`
+	ReconnectWait  int    `json:"reconnectWait" yaml:"reconnectWait"`   // in seconds
+	RequestTimeout int    `json:"requestTimeout" yaml:"requestTimeout"` // in seconds
+	HandlerTimeout int    `json:"handlerTimeout" yaml:"handlerTimeout"` // in seconds, per handled message
+	SubjectPrefix  string `json:"subjectPrefix" yaml:"subjectPrefix"`
+	UseCQRS        bool   `json:"useCqrs" yaml:"useCqrs"`               // run channel, template and message requests through the CQRS facade
+	RequireVersion bool   `json:"requireVersion" yaml:"requireVersion"` // reject requests in the former unversioned envelope format
+	MaxUploadBytes int    `json:"maxUploadBytes" yaml:"maxUploadBytes"` // largest template content uploaded in chunks
+	UploadTTL      int    `json:"uploadTtl" yaml:"uploadTtl"`           // in seconds, until an unreferenced upload is dropped
+	InboxTTL       int    `json:"inboxTtl" yaml:"inboxTtl"`             // in seconds, how long command responses are kept for retried requests; 0 disables the inbox
+
+	// APIKeys maps the API keys accepted on NATS requests to client IDs; empty disables authentication
+	APIKeys map[string]string `json:"apiKeys" yaml:"apiKeys"`
+}
+
+// ChannelHealthConfig holds the thresholds of channel health monitoring
+type ChannelHealthConfig struct {
+	Enabled    bool `json:"enabled" yaml:"enabled"`
+	Window     int  `json:"window" yaml:"window"`         // number of most recent results a channel is judged on
+	MinSamples int  `json:"minSamples" yaml:"minSamples"` // results needed before a channel can be degraded
+
+	// Failure percentages of the window at which a channel is marked degraded
+	// and at which it is disabled; a zero disable percentage never disables
+	DegradedFailurePercent int `json:"degradedFailurePercent" yaml:"degradedFailurePercent"`
+	DisableFailurePercent  int `json:"disableFailurePercent" yaml:"disableFailurePercent"`
+}
+
+// QuotaConfig holds the daily and monthly send quotas; a zero limit is unlimited
+type QuotaConfig struct {
+	Enabled        bool `json:"enabled" yaml:"enabled"`
+	TenantDaily    int  `json:"tenantDaily" yaml:"tenantDaily"`       // default limit of a tenant
+	TenantMonthly  int  `json:"tenantMonthly" yaml:"tenantMonthly"`   // default limit of a tenant
+	ChannelDaily   int  `json:"channelDaily" yaml:"channelDaily"`     // default limit of a channel
+	ChannelMonthly int  `json:"channelMonthly" yaml:"channelMonthly"` // default limit of a channel
+
+	// Tenants and Channels override the default limits per tenant ID and channel ID
+	Tenants  map[string]QuotaLimit `json:"tenants" yaml:"tenants"`
+	Channels map[string]QuotaLimit `json:"channels" yaml:"channels"`
+}
+
+// QuotaLimit holds the send limits of one tenant or channel
+type QuotaLimit struct {
+	Daily   int `json:"daily" yaml:"daily"`
+	Monthly int `json:"monthly" yaml:"monthly"`
+}
+
+// PricingConfig holds the estimated price of one send, keyed by channel type
+// (sms) or by channel type and provider (sms/twilio); no prices records no cost
+type PricingConfig struct {
+	Currency string             `json:"currency" yaml:"currency"`
+	Prices   map[string]float64 `json:"prices" yaml:"prices"`
+}
+
+// MirrorConfig holds the mirroring of a share of the sends to a shadow channel,
+// or to the log when no shadow channel is set
+type MirrorConfig struct {
+	Enabled         bool   `json:"enabled" yaml:"enabled"`
+	Percent         int    `json:"percent" yaml:"percent"`                 // share of the sends mirrored
+	Mode            string `json:"mode" yaml:"mode"`                       // metadata or full
+	ShadowChannelID string `json:"shadowChannelId" yaml:"shadowChannelId"` // empty logs the mirrored sends
+}
+
+// OwnershipConfig holds the owner and team requirements of channels and templates
+type OwnershipConfig struct {
+	// Required rejects channels and templates created with neither an owner nor a team
+	Required bool `json:"required" yaml:"required"`
+}
+
+// ProviderKeepAliveConfig holds the warm up of the provider connections: the
+// SMTP connections of email channels are kept open with a NOOP and the
+// webhooks of Slack and SMS channels with a HEAD request
+type ProviderKeepAliveConfig struct {
+	Enabled  bool `json:"enabled" yaml:"enabled"`
+	Interval int  `json:"interval" yaml:"interval"` // in seconds, below the idle timeouts of the providers
+}
+
+// FailureDigestConfig holds the digests of the failed and held sends sent to
+// the operators. Every worker process sends its own, so enable it on one.
+type FailureDigestConfig struct {
+	Enabled   bool   `json:"enabled" yaml:"enabled"`
+	ChannelID string `json:"channelId" yaml:"channelId"` // operations channel the digests are sent through
+	Interval  int    `json:"interval" yaml:"interval"`   // in seconds, the period each digest covers
+	// MinFailed and MinHeld are the numbers of failed and held sends in a
+	// period from which a digest is sent, 0 to ignore them
+	MinFailed int `json:"minFailed" yaml:"minFailed"`
+	MinHeld   int `json:"minHeld" yaml:"minHeld"`
+}
+
+// SendLockConfig holds the locks taken by the senders, so that a message
+// dispatched twice is sent by one worker replica only. The locks are
+// leases in the PostgreSQL lock_leases table, or held in the process with
+// other databases.
+type SendLockConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// TTL releases a lock its holder did not release, in seconds. It must
+	// exceed the longest delivery, past which a second worker may send again.
+	TTL int `json:"ttl" yaml:"ttl"`
+}
+
+// SelfTestConfig holds the check of the enabled channels at startup: the
+// credentials of each channel are checked at its provider and a readiness
+// summary is logged. Admins can run the same check on demand.
+type SelfTestConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// FailOnError stops the startup when a channel fails its check
+	FailOnError bool `json:"failOnError" yaml:"failOnError"`
+	Timeout     int  `json:"timeout" yaml:"timeout"` // in seconds, for the whole check
+}
+
+// ChangeEventsConfig holds the publication of the channel and template
+// changes: each change is published over NATS on changes.<type> and, when
+// webhooks are configured, posted to each of them.
+type ChangeEventsConfig struct {
+	Enabled     bool     `json:"enabled" yaml:"enabled"`
+	WebhookURLs []string `json:"webhookUrls,omitempty" yaml:"webhookUrls"`
+	// WebhookSecret signs the posted bodies with HMAC-SHA256, empty to not sign them
+	WebhookSecret  string `json:"webhookSecret" yaml:"webhookSecret"`
+	WebhookTimeout int    `json:"webhookTimeout" yaml:"webhookTimeout"` // in seconds
+}
+
+// ContentAdaptationConfig holds the adaptation of the rendered content to the
+// type of each channel: HTML is converted to text, or to mrkdwn for Slack, and
+// content longer than the channel type sends is truncated.
+type ContentAdaptationConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// LinkVariable is the message variable whose value, a link to the full
+	// content, ends truncated content
+	LinkVariable string `json:"linkVariable" yaml:"linkVariable"`
+}
+
+// Link shortener providers
+const (
+	// LinkShortenerInternal shortens the links to the redirect endpoint of the service
+	LinkShortenerInternal = "internal"
+	// LinkShortenerBitly shortens the internal short links again with Bitly
+	LinkShortenerBitly = "bitly"
+)
+
+// LinkShorteningConfig holds the shortening of the links sent through SMS
+// channels. The short links point to BaseURL/l/<code>, which counts each
+// click against the message before redirecting.
+type LinkShorteningConfig struct {
+	Enabled  bool   `json:"enabled" yaml:"enabled"`
+	Provider string `json:"provider" yaml:"provider"` // internal or bitly
+	// BaseURL is the public address of the service the short links point to
+	BaseURL string `json:"baseUrl" yaml:"baseUrl"`
+	// APIToken and Domain are the Bitly access token and branded domain
+	APIToken string `json:"apiToken" yaml:"apiToken"`
+	Domain   string `json:"domain" yaml:"domain"`
+	Timeout  int    `json:"timeout" yaml:"timeout"` // in seconds, per provider request
+}
+
+// SendPolicyHooks are the names of the pre-send policy hooks
+var SendPolicyHooks = []string{"pii", "profanity", "size", "webhook"}
+
+// SendPolicyConfig holds the pre-send policy hooks, which may change, annotate
+// or block each send; their decisions are recorded on the message results.
+// Hooks run by default, and Tenants and Channels override them per tenant ID
+// and channel ID, the channel first.
+type SendPolicyConfig struct {
+	Enabled  bool                `json:"enabled" yaml:"enabled"`
+	Hooks    []string            `json:"hooks" yaml:"hooks"`
+	Tenants  map[string][]string `json:"tenants" yaml:"tenants"`
+	Channels map[string][]string `json:"channels" yaml:"channels"`
+
+	// PIIBlock blocks the sends with personal data rather than masking it
+	PIIBlock bool `json:"piiBlock" yaml:"piiBlock"`
+	// ProfanityWords are blocked, or only annotated with ProfanityAnnotateOnly
+	ProfanityWords        []string `json:"profanityWords" yaml:"profanityWords"`
+	ProfanityAnnotateOnly bool     `json:"profanityAnnotateOnly" yaml:"profanityAnnotateOnly"`
+	// MaxBytes is the largest subject, content and attachments of a send
+	MaxBytes int `json:"maxBytes" yaml:"maxBytes"`
+
+	// WebhookURL is the moderation service the webhook hook posts each send to
+	WebhookURL     string `json:"webhookUrl" yaml:"webhookUrl"`
+	WebhookSecret  string `json:"webhookSecret" yaml:"webhookSecret"`
+	WebhookTimeout int    `json:"webhookTimeout" yaml:"webhookTimeout"` // in seconds
+}
+
+// AttachmentScanActions are what may happen to the infected attachments of a send
+var AttachmentScanActions = []string{"off", "reject", "quarantine"}
+
+// AttachmentScanConfig holds the virus scan of the attachments with ClamAV
+// before delivery. The attachment_scan key of a channel config overrides
+// DefaultAction for its sends.
+type AttachmentScanConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Address is the clamd host:port, or the path of its unix socket
+	Address string `json:"address" yaml:"address"`
+	Timeout int    `json:"timeout" yaml:"timeout"` // in seconds, per attachment
+	// DefaultAction rejects the sends with an infected attachment, quarantines
+	// the attachment and sends without it, or turns the scan off
+	DefaultAction string `json:"defaultAction" yaml:"defaultAction"`
+	// FailOpen sends the attachments that could not be scanned rather than
+	// failing the send for a retry
+	FailOpen bool `json:"failOpen" yaml:"failOpen"`
+}
+
+// SenderIdentityConfig holds the verified addresses and domains the tenants
+// send email from
+type SenderIdentityConfig struct {
+	// Enforced fails the email sends from an address no verified identity of
+	// the tenant of the message covers
+	Enforced bool `json:"enforced" yaml:"enforced"`
+	// ConfirmationChannelID is the email channel the confirmation links of the
+	// addresses are sent through; empty verifies addresses by the DNS record
+	// of their domain
+	ConfirmationChannelID string `json:"confirmationChannelId" yaml:"confirmationChannelId"`
+	// BaseURL is the public address of the service the confirmation links point to
+	BaseURL string `json:"baseUrl" yaml:"baseUrl"`
+}
+
+// ScheduledSendConfig holds how the worker processes send the scheduled
+// messages whose time has come. Each send is claimed by one worker.
+type ScheduledSendConfig struct {
+	PollInterval int `json:"pollInterval" yaml:"pollInterval"` // in seconds, how late a scheduled send may go out
+	BatchSize    int `json:"batchSize" yaml:"batchSize"`       // due sends handled per query
+}
+
+// AcknowledgmentConfig holds the acknowledgment links added to the messages
+// of the email and Slack channels asking for them. Each recipient gets a link
+// to BaseURL/ack/<token>, which records who acknowledged the message and when.
+type AcknowledgmentConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// BaseURL is the public address of the service the links point to
+	BaseURL string `json:"baseUrl" yaml:"baseUrl"`
+}
+
+// RepliesConfig holds the replies received to the messages of the email and
+// SMS channels with track_replies set. SMS replies are posted by Twilio to
+// the callback URL of their channel. Emails are answered to InboundAddress,
+// tagged with the reply token of the message, and the mail provider posts
+// them in raw form to the inbound email endpoint with InboundSecret.
+type RepliesConfig struct {
+	Enabled        bool   `json:"enabled" yaml:"enabled"`
+	InboundAddress string `json:"inboundAddress" yaml:"inboundAddress"`
+	InboundSecret  string `json:"inboundSecret" yaml:"inboundSecret"`
+}
+
+// OTPConfig holds the one-time verification codes sent by the OTP endpoints.
+// ChannelID and TemplateID designate where the codes go and how they read
+// when a send request names neither; the template gets the code in the
+// {code} variable. A zero CodeLength, TTL or MaxAttempts uses the default.
+type OTPConfig struct {
+	ChannelID  string `json:"channelId" yaml:"channelId"`
+	TemplateID string `json:"templateId" yaml:"templateId"`
+	CodeLength int    `json:"codeLength" yaml:"codeLength"` // digits of a code
+	TTL        int    `json:"ttl" yaml:"ttl"`               // in seconds, how long a code can be verified
+	// MaxAttempts is how many times a code may be tried before it is spent
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts"`
+	// ResendInterval is how long, in seconds, a recipient waits before being
+	// sent another code for the same purpose, zero not waiting
+	ResendInterval int `json:"resendInterval" yaml:"resendInterval"`
+}
+
+// PIIConfig holds the encryption of the recipient targets stored in the
+// channels, messages and results. Keys maps key IDs to base64 encoded 32-byte
+// keys: ActiveKey encrypts, the others still decrypt and look up the rows
+// not rotated yet. Without keys the targets are stored in plain text.
+type PIIConfig struct {
+	Keys      map[string]string `json:"keys" yaml:"keys"`
+	ActiveKey string            `json:"activeKey" yaml:"activeKey"`
+}
+
+// Cipher creates the cipher of the configured keys, nil without keys
+func (c PIIConfig) Cipher() (*pii.Cipher, error) {
+	if len(c.Keys) == 0 {
+		return nil, nil
+	}
+	keys, err := pii.ParseKeys(c.Keys)
+	if err != nil {
+		return nil, err
+	}
+	return pii.NewCipher(keys, c.ActiveKey)
+}
+
+// RetentionConfig holds how long the messages are kept, enforced by the
+// retention job of the worker processes. The content of a message (its
+// variables, template overrides, reply bodies and quarantined attachments) is
+// purged after ContentDays, the message and its delivery metadata are deleted
+// after MetadataDays; 0 keeps them forever.
+type RetentionConfig struct {
+	Enabled      bool `json:"enabled" yaml:"enabled"`
+	Interval     int  `json:"interval" yaml:"interval"`         // in seconds, between two runs of the job
+	ContentDays  int  `json:"contentDays" yaml:"contentDays"`   // default window of a tenant
+	MetadataDays int  `json:"metadataDays" yaml:"metadataDays"` // default window of a tenant
+
+	// Tenants override the default windows per tenant ID
+	Tenants map[string]RetentionWindow `json:"tenants" yaml:"tenants"`
+}
+
+// RetentionWindow holds the retention windows of one tenant
+type RetentionWindow struct {
+	ContentDays  int `json:"contentDays" yaml:"contentDays"`
+	MetadataDays int `json:"metadataDays" yaml:"metadataDays"`
+}
+
+// SLOConfig holds the delivery latency objectives, measured by the API
+// processes for the metrics endpoint and the SLO API, and their burn-rate
+// alerts sent through an operations channel.
+type SLOConfig struct {
+	Enabled  bool `json:"enabled" yaml:"enabled"`
+	Interval int  `json:"interval" yaml:"interval"` // in seconds, between two evaluations
+	// AlertChannelID is the operations channel the alerts are sent through;
+	// empty only logs them
+	AlertChannelID string         `json:"alertChannelId" yaml:"alertChannelId"`
+	Objectives     []SLOObjective `json:"objectives" yaml:"objectives"`
+}
+
+// SLOObjective holds one delivery latency objective
+type SLOObjective struct {
+	Name       string  `json:"name" yaml:"name"`
+	Threshold  int     `json:"threshold" yaml:"threshold"`   // in seconds from the creation of a message to its dispatch
+	Target     float64 `json:"target" yaml:"target"`         // percent of the messages dispatched within the threshold
+	WindowDays int     `json:"windowDays" yaml:"windowDays"` // rolling window the objective is measured over
 }
 
 // LoggerConfig holds logger configuration
 type LoggerConfig struct {
-	Level      string `json:"level"`
-	Format     string `json:"format"` // json or console
-	OutputPath string `json:"outputPath"`
+	Level      string `json:"level" yaml:"level"`
+	Format     string `json:"format" yaml:"format"` // json or console
+	OutputPath string `json:"outputPath" yaml:"outputPath"`
+
+	// Redact replaces the secrets and masks the recipient addresses in the
+	// fields of the entries
+	Redact bool `json:"redact" yaml:"redact"`
+	// Modules overrides the level per module, the component of a logger;
+	// the levels can be changed at runtime through the admin API
+	Modules map[string]string `json:"modules" yaml:"modules"`
+	// Sampling limits the debug and info entries of the high-volume modules
+	Sampling LogSamplingConfig `json:"sampling" yaml:"sampling"`
 }
 
-// Load loads configuration from environment variables
-func Load() (*Config, error) {
-	// Load .env file if exists
-	_ = godotenv.Load()
+// LogSamplingConfig holds the sampling of the entries of the modules: per
+// second, the first Initial entries with the same message are logged, then
+// one in every Thereafter
+type LogSamplingConfig struct {
+	Modules    []string `json:"modules" yaml:"modules"`
+	Initial    int      `json:"initial" yaml:"initial"`
+	Thereafter int      `json:"thereafter" yaml:"thereafter"`
+}
 
-	config := &Config{
-		Server: ServerConfig{
-			Port:         getEnvAsInt("SERVER_PORT", 8080),
-			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
-			ReadTimeout:  getEnvAsInt("SERVER_READ_TIMEOUT", 30),
-			WriteTimeout: getEnvAsInt("SERVER_WRITE_TIMEOUT", 30),
-		},
-		Database: DatabaseConfig{
-			Type:           getEnv("DB_TYPE", "postgres"),
-			Host:           getEnv("DB_HOST", "localhost"),
-			Port:           getEnvAsInt("DB_PORT", 5432),
-			User:           getEnv("DB_USER", "postgres"),
-			Password:       getEnv("DB_PASSWORD", ""),
-			DBName:         getEnv("DB_NAME", "channel_api"),
-			Schema:         getEnv("DB_SCHEMA", "public"),
-			SSLMode:        getEnv("DB_SSL_MODE", "disable"),
-			MaxOpenConns:   getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns:   getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
-			MaxLifetime:    getEnvAsInt("DB_MAX_LIFETIME", 5),
-			MigrationsPath: getEnv("DB_MIGRATIONS_PATH", "migrations"),
-		},
-		NATS: NATSConfig{
-			URL:            getEnv("NATS_URL", "nats://localhost:4222"),
-			CredsPath:      getEnv("NATS_CREDS_PATH", ""),
-			MaxReconnects:  getEnvAsInt("NATS_MAX_RECONNECTS", 10),
-			ReconnectWait:  getEnvAsInt("NATS_RECONNECT_WAIT", 2),
-			RequestTimeout: getEnvAsInt("NATS_REQUEST_TIMEOUT", 30),
-			SubjectPrefix:  getEnv("NATS_SUBJECT_PREFIX", "eco1j.infra.eventcenter"),
-		},
-		Logger: LoggerConfig{
-			Level:      getEnv("LOG_LEVEL", "info"),
-			Format:     getEnv("LOG_FORMAT", "json"),
-			OutputPath: getEnv("LOG_OUTPUT_PATH", "stdout"),
-		},
-		LegacySystem: LegacySystemConfig{
-			URL:   getEnv("LEGACY_SYSTEM_URL", ""),
-			Token: getEnv("LEGACY_SYSTEM_TOKEN", ""),
-		},
+// Load loads configuration from the default sources and validates it
+func Load() (*Config, error) {
+	config, err := LoadUnvalidated()
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate required fields
@@ -117,35 +477,139 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
-// Validate validates the configuration
-func (c *Config) Validate() error {
-	// Validate database type
-	validDBTypes := map[string]bool{
-		"postgres":   true,
-		"postgresql": true,
-		"sqlite":     true,
-		"sqlserver":  true,
-		"mssql":      true,
-	}
-	if !validDBTypes[c.Database.Type] {
-		return fmt.Errorf("unsupported database type: %s", c.Database.Type)
-	}
+// LoadUnvalidated loads configuration from the default sources without validating it
+func LoadUnvalidated() (*Config, error) {
+	// Load .env file if exists
+	_ = godotenv.Load()
 
-	// For non-SQLite databases, password is required
-	if c.Database.Type != "sqlite" && c.Database.Password == "" {
-		return fmt.Errorf("database password is required for %s", c.Database.Type)
-	}
+	return LoadFrom(DefaultSources()...)
+}
 
-	if c.Server.Port <= 0 || c.Server.Port > 65535 {
-		return fmt.Errorf("invalid server port: %d", c.Server.Port)
-	}
+// Defaults returns the built-in configuration every source is applied on
+func Defaults() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Mode:         ModeAll,
+			Port:         8080,
+			Host:         "0.0.0.0",
+			ReadTimeout:  30,
+			WriteTimeout: 30,
+			MaxBodyBytes: 1 << 20,
+			MaxJSONDepth: 32,
 
-	// For non-SQLite databases, validate port
-	if c.Database.Type != "sqlite" && (c.Database.Port <= 0 || c.Database.Port > 65535) {
-		return fmt.Errorf("invalid database port: %d", c.Database.Port)
-	}
+			WorkerConcurrency: 4,
 
-	return nil
+			SendConcurrency: 64,
+			SendQueueDepth:  256,
+			SendRetryAfter:  5,
+		},
+		Database: DatabaseConfig{
+			Type:           "postgres",
+			Host:           "localhost",
+			Port:           5432,
+			User:           "postgres",
+			DBName:         "channel_api",
+			Schema:         "public",
+			SSLMode:        "disable",
+			MaxOpenConns:   25,
+			MaxIdleConns:   5,
+			MaxLifetime:    5,
+			MigrationsPath: "migrations",
+
+			SlowQueryThreshold: 200,
+		},
+		NATS: NATSConfig{
+			URL:            "nats://localhost:4222",
+			MaxReconnects:  10,
+			ReconnectWait:  2,
+			RequestTimeout: 30,
+			HandlerTimeout: 30,
+			SubjectPrefix:  "eco1j.infra.eventcenter",
+			MaxUploadBytes: 16 << 20, // 16 MiB
+			UploadTTL:      600,
+			InboxTTL:       86400,
+		},
+		Logger: LoggerConfig{
+			Level:      "info",
+			Format:     "json",
+			OutputPath: "stdout",
+			Redact:     true,
+			Sampling: LogSamplingConfig{
+				Modules:    []string{"send_worker", "message_sender"},
+				Initial:    100,
+				Thereafter: 100,
+			},
+		},
+		ChannelHealth: ChannelHealthConfig{
+			Enabled:                true,
+			Window:                 20,
+			MinSamples:             10,
+			DegradedFailurePercent: 50,
+		},
+		Pricing: PricingConfig{
+			Currency: "USD",
+		},
+		Mirror: MirrorConfig{
+			Percent: 10,
+			Mode:    "metadata",
+		},
+		ProviderKeepAlive: ProviderKeepAliveConfig{
+			Enabled:  true,
+			Interval: 60,
+		},
+		FailureDigest: FailureDigestConfig{
+			Interval:  3600,
+			MinFailed: 1,
+		},
+		ScheduledSend: ScheduledSendConfig{
+			PollInterval: 30,
+			BatchSize:    100,
+		},
+		Retention: RetentionConfig{
+			Interval: 3600,
+		},
+		SLO: SLOConfig{
+			Interval: 60,
+			Objectives: []SLOObjective{
+				{Name: "dispatch-30s", Threshold: 30, Target: 99, WindowDays: 30},
+			},
+		},
+		OTP: OTPConfig{
+			CodeLength:     6,
+			TTL:            300,
+			MaxAttempts:    5,
+			ResendInterval: 30,
+		},
+		SendLock: SendLockConfig{
+			Enabled: true,
+			TTL:     300,
+		},
+		SelfTest: SelfTestConfig{
+			Timeout: 60,
+		},
+		ChangeEvents: ChangeEventsConfig{
+			Enabled:        true,
+			WebhookTimeout: 10,
+		},
+		ContentAdaptation: ContentAdaptationConfig{
+			Enabled:      true,
+			LinkVariable: "link",
+		},
+		LinkShortening: LinkShorteningConfig{
+			Provider: LinkShortenerInternal,
+			Timeout:  5,
+		},
+		SendPolicy: SendPolicyConfig{
+			Hooks:          []string{"pii", "size"},
+			MaxBytes:       10 * 1024 * 1024,
+			WebhookTimeout: 5,
+		},
+		AttachmentScan: AttachmentScanConfig{
+			Address:       "localhost:3310",
+			Timeout:       30,
+			DefaultAction: "reject",
+		},
+	}
 }
 
 // GetDatabaseConnectionString returns the database connection string
@@ -160,25 +624,17 @@ func (c *Config) GetDatabaseConnectionString() string {
 	)
 }
 
-// GetServerAddress returns the server address
-func (c *Config) GetServerAddress() string {
-	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
+// RunsAPI reports whether the run mode serves the HTTP and NATS APIs
+func (s ServerConfig) RunsAPI() bool {
+	return s.Mode == ModeAPI || s.Mode == ModeAll
 }
 
-// getEnv gets an environment variable with a default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+// RunsWorkers reports whether the run mode runs the send workers
+func (s ServerConfig) RunsWorkers() bool {
+	return s.Mode == ModeWorker || s.Mode == ModeAll
 }
 
-// getEnvAsInt gets an environment variable as integer with a default value
-func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
+// GetServerAddress returns the server address
+func (c *Config) GetServerAddress() string {
+	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
 }
@@ -0,0 +1,369 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv lists the YAML configuration files to load, separated by commas
+const ConfigFileEnv = "CONFIG_FILE"
+
+// fileSuffix marks an environment variable holding the path of a file with
+// the value, as used for Docker and Kubernetes secrets
+const fileSuffix = "_FILE"
+
+// Source applies one layer of configuration on top of the layers applied before it
+type Source interface {
+	Apply(config *Config) error
+}
+
+// SourceFunc adapts a function into a Source
+type SourceFunc func(config *Config) error
+
+// Apply implements Source
+func (f SourceFunc) Apply(config *Config) error {
+	return f(config)
+}
+
+// LoadFrom applies the sources in order on top of the defaults, so that
+// later sources take precedence over earlier ones
+func LoadFrom(sources ...Source) (*Config, error) {
+	config := Defaults()
+	for _, source := range sources {
+		if err := source.Apply(config); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// DefaultSources returns the YAML files listed in CONFIG_FILE, in order,
+// followed by the environment variables, which override every file
+func DefaultSources() []Source {
+	var sources []Source
+	for _, path := range strings.Split(os.Getenv(ConfigFileEnv), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			sources = append(sources, FileSource(path))
+		}
+	}
+	return append(sources, EnvSource())
+}
+
+// FileSource reads a YAML configuration file. Keys left out of the file keep
+// the value of the layers below it.
+func FileSource(path string) Source {
+	return SourceFunc(func(config *Config) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// EnvSource reads the environment variables. Every variable overrides one
+// configuration key and can also be given as <NAME>_FILE, naming a file
+// that holds the value.
+func EnvSource() Source {
+	return SourceFunc(func(config *Config) error {
+		env := &envReader{}
+
+		env.string("SERVER_MODE", &config.Server.Mode)
+		env.int("SERVER_PORT", &config.Server.Port)
+		env.string("SERVER_HOST", &config.Server.Host)
+		env.int("SERVER_READ_TIMEOUT", &config.Server.ReadTimeout)
+		env.int("SERVER_WRITE_TIMEOUT", &config.Server.WriteTimeout)
+		env.int("SERVER_MAX_BODY_BYTES", &config.Server.MaxBodyBytes)
+		env.int("SERVER_MAX_JSON_DEPTH", &config.Server.MaxJSONDepth)
+		env.bool("SERVER_STRICT_JSON", &config.Server.StrictJSON)
+		env.int("SERVER_WORKER_CONCURRENCY", &config.Server.WorkerConcurrency)
+		env.int("SERVER_SEND_CONCURRENCY", &config.Server.SendConcurrency)
+		env.int("SERVER_SEND_QUEUE_DEPTH", &config.Server.SendQueueDepth)
+		env.int("SERVER_SEND_RETRY_AFTER", &config.Server.SendRetryAfter)
+		env.string("SERVER_ENVIRONMENT", &config.Server.Environment)
+		env.bool("SERVER_READ_ONLY", &config.Server.ReadOnly)
+
+		env.string("DB_TYPE", &config.Database.Type)
+		env.string("DB_HOST", &config.Database.Host)
+		env.int("DB_PORT", &config.Database.Port)
+		env.string("DB_USER", &config.Database.User)
+		env.string("DB_PASSWORD", &config.Database.Password)
+		env.string("DB_NAME", &config.Database.DBName)
+		env.string("DB_SCHEMA", &config.Database.Schema)
+		env.string("DB_SSL_MODE", &config.Database.SSLMode)
+		env.int("DB_MAX_OPEN_CONNS", &config.Database.MaxOpenConns)
+		env.int("DB_MAX_IDLE_CONNS", &config.Database.MaxIdleConns)
+		env.int("DB_MAX_LIFETIME", &config.Database.MaxLifetime)
+		env.int("DB_MAX_IDLE_TIME", &config.Database.MaxIdleTime)
+		env.int("DB_SLOW_QUERY_THRESHOLD", &config.Database.SlowQueryThreshold)
+		env.string("DB_MIGRATIONS_PATH", &config.Database.MigrationsPath)
+		env.stringList("DB_REPLICA_DSNS", &config.Database.ReplicaDSNs)
+
+		env.string("NATS_URL", &config.NATS.URL)
+		env.string("NATS_CREDS_PATH", &config.NATS.CredsPath)
+		env.int("NATS_MAX_RECONNECTS", &config.NATS.MaxReconnects)
+		env.int("NATS_RECONNECT_WAIT", &config.NATS.ReconnectWait)
+		env.int("NATS_REQUEST_TIMEOUT", &config.NATS.RequestTimeout)
+		env.int("NATS_HANDLER_TIMEOUT", &config.NATS.HandlerTimeout)
+		env.string("NATS_SUBJECT_PREFIX", &config.NATS.SubjectPrefix)
+		env.bool("NATS_USE_CQRS", &config.NATS.UseCQRS)
+		env.bool("NATS_REQUIRE_VERSION", &config.NATS.RequireVersion)
+		env.int("NATS_MAX_UPLOAD_BYTES", &config.NATS.MaxUploadBytes)
+		env.int("NATS_UPLOAD_TTL", &config.NATS.UploadTTL)
+		env.int("NATS_INBOX_TTL", &config.NATS.InboxTTL)
+		env.stringMap("NATS_API_KEYS", &config.NATS.APIKeys)
+
+		env.string("LOG_LEVEL", &config.Logger.Level)
+		env.string("LOG_FORMAT", &config.Logger.Format)
+		env.string("LOG_OUTPUT_PATH", &config.Logger.OutputPath)
+		env.bool("LOG_REDACT", &config.Logger.Redact)
+		env.stringMap("LOG_MODULE_LEVELS", &config.Logger.Modules)
+		env.stringList("LOG_SAMPLING_MODULES", &config.Logger.Sampling.Modules)
+		env.int("LOG_SAMPLING_INITIAL", &config.Logger.Sampling.Initial)
+		env.int("LOG_SAMPLING_THEREAFTER", &config.Logger.Sampling.Thereafter)
+
+		env.string("LEGACY_SYSTEM_URL", &config.LegacySystem.URL)
+		env.string("LEGACY_SYSTEM_TOKEN", &config.LegacySystem.Token)
+
+		env.bool("CHANNEL_HEALTH_ENABLED", &config.ChannelHealth.Enabled)
+		env.int("CHANNEL_HEALTH_WINDOW", &config.ChannelHealth.Window)
+		env.int("CHANNEL_HEALTH_MIN_SAMPLES", &config.ChannelHealth.MinSamples)
+		env.int("CHANNEL_HEALTH_DEGRADED_PERCENT", &config.ChannelHealth.DegradedFailurePercent)
+		env.int("CHANNEL_HEALTH_DISABLE_PERCENT", &config.ChannelHealth.DisableFailurePercent)
+
+		env.bool("QUOTA_ENABLED", &config.Quota.Enabled)
+		env.int("QUOTA_TENANT_DAILY", &config.Quota.TenantDaily)
+		env.int("QUOTA_TENANT_MONTHLY", &config.Quota.TenantMonthly)
+		env.int("QUOTA_CHANNEL_DAILY", &config.Quota.ChannelDaily)
+		env.int("QUOTA_CHANNEL_MONTHLY", &config.Quota.ChannelMonthly)
+
+		env.string("PRICING_CURRENCY", &config.Pricing.Currency)
+		env.floatMap("PRICING_PRICES", &config.Pricing.Prices)
+
+		env.bool("MIRROR_ENABLED", &config.Mirror.Enabled)
+		env.int("MIRROR_PERCENT", &config.Mirror.Percent)
+		env.string("MIRROR_MODE", &config.Mirror.Mode)
+		env.string("MIRROR_SHADOW_CHANNEL_ID", &config.Mirror.ShadowChannelID)
+
+		env.bool("PROVIDER_KEEPALIVE_ENABLED", &config.ProviderKeepAlive.Enabled)
+		env.int("PROVIDER_KEEPALIVE_INTERVAL", &config.ProviderKeepAlive.Interval)
+
+		env.bool("FAILURE_DIGEST_ENABLED", &config.FailureDigest.Enabled)
+		env.string("FAILURE_DIGEST_CHANNEL_ID", &config.FailureDigest.ChannelID)
+		env.int("FAILURE_DIGEST_INTERVAL", &config.FailureDigest.Interval)
+		env.int("FAILURE_DIGEST_MIN_FAILED", &config.FailureDigest.MinFailed)
+		env.int("FAILURE_DIGEST_MIN_HELD", &config.FailureDigest.MinHeld)
+
+		env.bool("SEND_LOCK_ENABLED", &config.SendLock.Enabled)
+		env.int("SEND_LOCK_TTL", &config.SendLock.TTL)
+
+		env.bool("SELF_TEST_ENABLED", &config.SelfTest.Enabled)
+		env.bool("SELF_TEST_FAIL_ON_ERROR", &config.SelfTest.FailOnError)
+		env.int("SELF_TEST_TIMEOUT", &config.SelfTest.Timeout)
+
+		env.bool("CHANGE_EVENTS_ENABLED", &config.ChangeEvents.Enabled)
+		env.stringList("CHANGE_WEBHOOK_URLS", &config.ChangeEvents.WebhookURLs)
+		env.string("CHANGE_WEBHOOK_SECRET", &config.ChangeEvents.WebhookSecret)
+		env.int("CHANGE_WEBHOOK_TIMEOUT", &config.ChangeEvents.WebhookTimeout)
+
+		env.bool("CONTENT_ADAPTATION_ENABLED", &config.ContentAdaptation.Enabled)
+		env.string("CONTENT_ADAPTATION_LINK_VARIABLE", &config.ContentAdaptation.LinkVariable)
+
+		env.bool("LINK_SHORTENING_ENABLED", &config.LinkShortening.Enabled)
+		env.string("LINK_SHORTENER_PROVIDER", &config.LinkShortening.Provider)
+		env.string("LINK_SHORTENER_BASE_URL", &config.LinkShortening.BaseURL)
+		env.string("LINK_SHORTENER_API_TOKEN", &config.LinkShortening.APIToken)
+		env.string("LINK_SHORTENER_DOMAIN", &config.LinkShortening.Domain)
+		env.int("LINK_SHORTENER_TIMEOUT", &config.LinkShortening.Timeout)
+
+		env.bool("SEND_POLICY_ENABLED", &config.SendPolicy.Enabled)
+		env.stringList("SEND_POLICY_HOOKS", &config.SendPolicy.Hooks)
+		env.bool("SEND_POLICY_PII_BLOCK", &config.SendPolicy.PIIBlock)
+		env.stringList("SEND_POLICY_PROFANITY_WORDS", &config.SendPolicy.ProfanityWords)
+		env.bool("SEND_POLICY_PROFANITY_ANNOTATE_ONLY", &config.SendPolicy.ProfanityAnnotateOnly)
+		env.int("SEND_POLICY_MAX_BYTES", &config.SendPolicy.MaxBytes)
+		env.string("SEND_POLICY_WEBHOOK_URL", &config.SendPolicy.WebhookURL)
+		env.string("SEND_POLICY_WEBHOOK_SECRET", &config.SendPolicy.WebhookSecret)
+		env.int("SEND_POLICY_WEBHOOK_TIMEOUT", &config.SendPolicy.WebhookTimeout)
+
+		env.bool("ATTACHMENT_SCAN_ENABLED", &config.AttachmentScan.Enabled)
+		env.string("ATTACHMENT_SCAN_CLAMD_ADDRESS", &config.AttachmentScan.Address)
+		env.int("ATTACHMENT_SCAN_TIMEOUT", &config.AttachmentScan.Timeout)
+		env.string("ATTACHMENT_SCAN_DEFAULT_ACTION", &config.AttachmentScan.DefaultAction)
+		env.bool("ATTACHMENT_SCAN_FAIL_OPEN", &config.AttachmentScan.FailOpen)
+
+		env.bool("SENDER_IDENTITY_ENFORCED", &config.SenderIdentity.Enforced)
+		env.string("SENDER_IDENTITY_CONFIRMATION_CHANNEL_ID", &config.SenderIdentity.ConfirmationChannelID)
+		env.string("SENDER_IDENTITY_BASE_URL", &config.SenderIdentity.BaseURL)
+
+		env.int("SCHEDULED_SEND_POLL_INTERVAL", &config.ScheduledSend.PollInterval)
+		env.int("SCHEDULED_SEND_BATCH_SIZE", &config.ScheduledSend.BatchSize)
+
+		env.bool("ACKNOWLEDGMENT_ENABLED", &config.Acknowledgment.Enabled)
+		env.string("ACKNOWLEDGMENT_BASE_URL", &config.Acknowledgment.BaseURL)
+
+		env.bool("REPLIES_ENABLED", &config.Replies.Enabled)
+		env.string("REPLIES_INBOUND_ADDRESS", &config.Replies.InboundAddress)
+		env.string("REPLIES_INBOUND_SECRET", &config.Replies.InboundSecret)
+
+		env.string("OTP_CHANNEL_ID", &config.OTP.ChannelID)
+		env.string("OTP_TEMPLATE_ID", &config.OTP.TemplateID)
+		env.int("OTP_CODE_LENGTH", &config.OTP.CodeLength)
+		env.int("OTP_TTL", &config.OTP.TTL)
+		env.int("OTP_MAX_ATTEMPTS", &config.OTP.MaxAttempts)
+		env.int("OTP_RESEND_INTERVAL", &config.OTP.ResendInterval)
+
+		env.stringMap("PII_ENCRYPTION_KEYS", &config.PII.Keys)
+		env.string("PII_ACTIVE_KEY", &config.PII.ActiveKey)
+
+		env.bool("RETENTION_ENABLED", &config.Retention.Enabled)
+		env.int("RETENTION_INTERVAL", &config.Retention.Interval)
+		env.int("RETENTION_CONTENT_DAYS", &config.Retention.ContentDays)
+		env.int("RETENTION_METADATA_DAYS", &config.Retention.MetadataDays)
+
+		env.bool("SLO_ENABLED", &config.SLO.Enabled)
+		env.int("SLO_INTERVAL", &config.SLO.Interval)
+		env.string("SLO_ALERT_CHANNEL_ID", &config.SLO.AlertChannelID)
+
+		env.bool("OWNERSHIP_REQUIRED", &config.Ownership.Required)
+
+		if len(env.errors) > 0 {
+			return fmt.Errorf("invalid environment: %w", env.errors)
+		}
+		return nil
+	})
+}
+
+// envReader reads environment variables into configuration fields,
+// collecting the variables it could not read
+type envReader struct {
+	errors ValidationErrors
+}
+
+// lookup returns the value of an environment variable, read from the file
+// named by <key>_FILE when the variable itself is not set
+func (r *envReader) lookup(key string) (string, bool) {
+	value := os.Getenv(key)
+	path := os.Getenv(key + fileSuffix)
+
+	switch {
+	case value != "" && path != "":
+		r.addf(key, "is set both directly and through %s%s", key, fileSuffix)
+		return "", false
+	case value != "":
+		return value, true
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			r.addf(key+fileSuffix, "cannot read secret file: %v", err)
+			return "", false
+		}
+		return strings.TrimRight(string(data), "\r\n"), true
+	}
+	return "", false
+}
+
+// addf records a variable that could not be read
+func (r *envReader) addf(key, format string, args ...interface{}) {
+	r.errors = append(r.errors, FieldError{Env: key, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *envReader) string(key string, target *string) {
+	if value, ok := r.lookup(key); ok {
+		*target = value
+	}
+}
+
+func (r *envReader) int(key string, target *int) {
+	if value, ok := r.lookup(key); ok {
+		intValue, err := strconv.Atoi(value)
+		if err != nil {
+			r.addf(key, "must be an integer, got %q", value)
+			return
+		}
+		*target = intValue
+	}
+}
+
+func (r *envReader) bool(key string, target *bool) {
+	if value, ok := r.lookup(key); ok {
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			r.addf(key, "must be a boolean, got %q", value)
+			return
+		}
+		*target = boolValue
+	}
+}
+
+// stringList reads comma-separated values
+func (r *envReader) stringList(key string, target *[]string) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	result := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	*target = result
+}
+
+// floatMap reads comma-separated key:number pairs
+func (r *envReader) floatMap(key string, target *map[string]float64) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	result := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		name, mapValue, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || name == "" {
+			r.addf(key, "must be comma-separated key:number pairs, got %q", pair)
+			return
+		}
+		number, err := strconv.ParseFloat(mapValue, 64)
+		if err != nil {
+			r.addf(key, "must be comma-separated key:number pairs, got %q", pair)
+			return
+		}
+		result[name] = number
+	}
+	*target = result
+}
+
+// stringMap reads comma-separated key:value pairs
+func (r *envReader) stringMap(key string, target *map[string]string) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	result := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		name, mapValue, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || name == "" {
+			r.addf(key, "must be comma-separated key:value pairs, got %q", pair)
+			return
+		}
+		result[name] = mapValue
+	}
+	*target = result
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFromMergesSourcesByPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	override := filepath.Join(dir, "override.yaml")
+	secret := filepath.Join(dir, "db_password")
+	require.NoError(t, os.WriteFile(base, []byte("database:\n  host: base-db\n  user: base-user\nserver:\n  port: 9000\n"), 0o600))
+	require.NoError(t, os.WriteFile(override, []byte("database:\n  host: override-db\n"), 0o600))
+	require.NoError(t, os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+
+	t.Setenv("CONFIG_FILE", base+","+override)
+	t.Setenv("DB_USER", "env-user")
+	t.Setenv("DB_PASSWORD_FILE", secret)
+
+	cfg, err := LoadFrom(DefaultSources()...)
+	require.NoError(t, err)
+	require.Equal(t, "override-db", cfg.Database.Host)
+	require.Equal(t, "env-user", cfg.Database.User)
+	require.Equal(t, "s3cret", cfg.Database.Password)
+	require.Equal(t, 9000, cfg.Server.Port)
+	require.Equal(t, "channel_api", cfg.Database.DBName, "keys set nowhere keep their default")
+}
+
+func TestEnvSourceReportsInvalidVariables(t *testing.T) {
+	t.Setenv("SERVER_PORT", "eighty")
+	t.Setenv("DB_PASSWORD", "direct")
+	t.Setenv("DB_PASSWORD_FILE", "/run/secrets/db_password")
+
+	_, err := LoadFrom(EnvSource())
+	require.ErrorContains(t, err, "SERVER_PORT: must be an integer")
+	require.ErrorContains(t, err, "DB_PASSWORD: is set both directly and through DB_PASSWORD_FILE")
+}
+
+func TestFileSourceRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  hots: typo\n"), 0o600))
+
+	_, err := LoadFrom(FileSource(path))
+	require.Error(t, err)
+}
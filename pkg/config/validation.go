@@ -0,0 +1,524 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maskedValue replaces secrets in the printed configuration
+const maskedValue = "****"
+
+// FieldError describes one invalid configuration value
+type FieldError struct {
+	// Env is the environment variable the value is read from
+	Env     string
+	Message string
+}
+
+// Error implements error
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Env, e.Message)
+}
+
+// ValidationErrors holds every problem found while validating a configuration
+type ValidationErrors []FieldError
+
+// Error implements error, listing all problems
+func (e ValidationErrors) Error() string {
+	problems := make([]string, len(e))
+	for i, fieldErr := range e {
+		problems[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("%d configuration problem(s): %s", len(e), strings.Join(problems, "; "))
+}
+
+// validator collects the problems of a configuration
+type validator struct {
+	errors ValidationErrors
+}
+
+// addf records a problem with the value of an environment variable
+func (v *validator) addf(env, format string, args ...interface{}) {
+	v.errors = append(v.errors, FieldError{Env: env, Message: fmt.Sprintf(format, args...)})
+}
+
+// required records a problem when value is empty
+func (v *validator) required(env, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf(env, "is required")
+	}
+}
+
+// port records a problem when value is not a valid TCP port
+func (v *validator) port(env string, value int) {
+	if value <= 0 || value > 65535 {
+		v.addf(env, "must be between 1 and 65535, got %d", value)
+	}
+}
+
+// positive records a problem when value is not greater than zero
+func (v *validator) positive(env string, value int) {
+	if value <= 0 {
+		v.addf(env, "must be greater than 0, got %d", value)
+	}
+}
+
+// nonNegative records a problem when value is below zero
+func (v *validator) nonNegative(env string, value int) {
+	if value < 0 {
+		v.addf(env, "must be 0 or greater, got %d", value)
+	}
+}
+
+// retentionWindow records a problem when a retention window is negative or
+// keeps the content of the messages longer than the messages themselves
+func (v *validator) retentionWindow(contentEnv, metadataEnv string, content, metadata int) {
+	v.nonNegative(contentEnv, content)
+	v.nonNegative(metadataEnv, metadata)
+	if metadata > 0 && content > metadata {
+		v.addf(contentEnv, "must not exceed %s (%d days), got %d", metadataEnv, metadata, content)
+	}
+}
+
+// url records a problem when value is not an absolute URL with one of the schemes
+func (v *validator) url(env, value string, schemes ...string) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		v.addf(env, "is not a valid URL: %v", err)
+		return
+	}
+	if parsed.Host == "" {
+		v.addf(env, "must be an absolute URL with a host, got %q", value)
+		return
+	}
+	for _, scheme := range schemes {
+		if parsed.Scheme == scheme {
+			return
+		}
+	}
+	v.addf(env, "must use one of the schemes %s, got %q", strings.Join(schemes, ", "), parsed.Scheme)
+}
+
+// Validate validates the configuration. It reports all problems at once as
+// ValidationErrors rather than stopping at the first one.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// Server
+	switch c.Server.Mode {
+	case ModeAPI, ModeWorker, ModeAll:
+	default:
+		v.addf("SERVER_MODE", "must be one of api, worker, all, got %q", c.Server.Mode)
+	}
+	v.port("SERVER_PORT", c.Server.Port)
+	v.positive("SERVER_READ_TIMEOUT", c.Server.ReadTimeout)
+	v.positive("SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout)
+	v.positive("SERVER_MAX_BODY_BYTES", c.Server.MaxBodyBytes)
+	v.positive("SERVER_MAX_JSON_DEPTH", c.Server.MaxJSONDepth)
+	v.positive("SERVER_WORKER_CONCURRENCY", c.Server.WorkerConcurrency)
+	v.nonNegative("SERVER_SEND_CONCURRENCY", c.Server.SendConcurrency)
+	if c.Server.SendConcurrency > 0 {
+		v.nonNegative("SERVER_SEND_QUEUE_DEPTH", c.Server.SendQueueDepth)
+		v.positive("SERVER_SEND_RETRY_AFTER", c.Server.SendRetryAfter)
+	}
+	switch c.Server.Environment {
+	case "", "dev", "staging", "prod":
+	default:
+		v.addf("SERVER_ENVIRONMENT", "must be one of dev, staging, prod or empty, got %q", c.Server.Environment)
+	}
+
+	// Database
+	validDBTypes := map[string]bool{
+		"postgres":   true,
+		"postgresql": true,
+		"sqlite":     true,
+		"sqlserver":  true,
+		"mssql":      true,
+	}
+	if !validDBTypes[c.Database.Type] {
+		v.addf("DB_TYPE", "unsupported database type %q", c.Database.Type)
+	}
+	// SQLite only needs a database name; the other databases need a server and credentials
+	v.required("DB_NAME", c.Database.DBName)
+	if c.Database.Type != "sqlite" {
+		v.required("DB_HOST", c.Database.Host)
+		v.port("DB_PORT", c.Database.Port)
+		v.required("DB_USER", c.Database.User)
+		if c.Database.Password == "" {
+			v.addf("DB_PASSWORD", "is required for %s", c.Database.Type)
+		}
+	}
+	if len(c.Database.ReplicaDSNs) > 0 && c.Database.Type == "sqlite" {
+		v.addf("DB_REPLICA_DSNS", "read replicas are not supported for sqlite")
+	}
+	for _, dsn := range c.Database.ReplicaDSNs {
+		if strings.TrimSpace(dsn) == "" {
+			v.addf("DB_REPLICA_DSNS", "must not contain an empty DSN")
+			break
+		}
+	}
+	v.nonNegative("DB_MAX_OPEN_CONNS", c.Database.MaxOpenConns)
+	v.nonNegative("DB_MAX_IDLE_CONNS", c.Database.MaxIdleConns)
+	v.nonNegative("DB_MAX_LIFETIME", c.Database.MaxLifetime)
+	v.nonNegative("DB_MAX_IDLE_TIME", c.Database.MaxIdleTime)
+	v.nonNegative("DB_SLOW_QUERY_THRESHOLD", c.Database.SlowQueryThreshold)
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		v.addf("DB_MAX_IDLE_CONNS", "must not exceed DB_MAX_OPEN_CONNS (%d), got %d", c.Database.MaxOpenConns, c.Database.MaxIdleConns)
+	}
+
+	// NATS
+	if c.NATS.URL == "" {
+		v.addf("NATS_URL", "is required")
+	} else {
+		// NATS_URL may list several servers separated by commas
+		for _, server := range strings.Split(c.NATS.URL, ",") {
+			v.url("NATS_URL", strings.TrimSpace(server), "nats", "tls", "ws", "wss")
+		}
+	}
+	v.positive("NATS_REQUEST_TIMEOUT", c.NATS.RequestTimeout)
+	v.positive("NATS_HANDLER_TIMEOUT", c.NATS.HandlerTimeout)
+	v.positive("NATS_MAX_UPLOAD_BYTES", c.NATS.MaxUploadBytes)
+	v.positive("NATS_UPLOAD_TTL", c.NATS.UploadTTL)
+	v.nonNegative("NATS_INBOX_TTL", c.NATS.InboxTTL)
+	v.required("NATS_SUBJECT_PREFIX", c.NATS.SubjectPrefix)
+
+	// Legacy system, optional but must be a usable base URL when set
+	if c.LegacySystem.URL != "" {
+		v.url("LEGACY_SYSTEM_URL", c.LegacySystem.URL, "http", "https")
+		if strings.HasSuffix(c.LegacySystem.URL, "/") {
+			v.addf("LEGACY_SYSTEM_URL", "must not end with a slash, got %q", c.LegacySystem.URL)
+		}
+	}
+
+	// Channel health
+	if c.ChannelHealth.Enabled {
+		health := c.ChannelHealth
+		v.positive("CHANNEL_HEALTH_WINDOW", health.Window)
+		if health.MinSamples <= 0 || health.MinSamples > health.Window {
+			v.addf("CHANNEL_HEALTH_MIN_SAMPLES", "must be between 1 and CHANNEL_HEALTH_WINDOW (%d), got %d", health.Window, health.MinSamples)
+		}
+		if health.DegradedFailurePercent <= 0 || health.DegradedFailurePercent > 100 {
+			v.addf("CHANNEL_HEALTH_DEGRADED_PERCENT", "must be between 1 and 100, got %d", health.DegradedFailurePercent)
+		}
+		if health.DisableFailurePercent != 0 &&
+			(health.DisableFailurePercent < health.DegradedFailurePercent || health.DisableFailurePercent > 100) {
+			v.addf("CHANNEL_HEALTH_DISABLE_PERCENT", "must be 0 or between CHANNEL_HEALTH_DEGRADED_PERCENT (%d) and 100, got %d",
+				health.DegradedFailurePercent, health.DisableFailurePercent)
+		}
+	}
+
+	// Quotas
+	if c.Quota.Enabled {
+		v.nonNegative("QUOTA_TENANT_DAILY", c.Quota.TenantDaily)
+		v.nonNegative("QUOTA_TENANT_MONTHLY", c.Quota.TenantMonthly)
+		v.nonNegative("QUOTA_CHANNEL_DAILY", c.Quota.ChannelDaily)
+		v.nonNegative("QUOTA_CHANNEL_MONTHLY", c.Quota.ChannelMonthly)
+		for tenantID, limit := range c.Quota.Tenants {
+			v.nonNegative(fmt.Sprintf("quota.tenants.%s.daily", tenantID), limit.Daily)
+			v.nonNegative(fmt.Sprintf("quota.tenants.%s.monthly", tenantID), limit.Monthly)
+		}
+		for channelID, limit := range c.Quota.Channels {
+			v.nonNegative(fmt.Sprintf("quota.channels.%s.daily", channelID), limit.Daily)
+			v.nonNegative(fmt.Sprintf("quota.channels.%s.monthly", channelID), limit.Monthly)
+		}
+	}
+
+	// Pricing
+	if len(c.Pricing.Prices) > 0 {
+		v.required("PRICING_CURRENCY", c.Pricing.Currency)
+		for key, price := range c.Pricing.Prices {
+			if price < 0 {
+				v.addf("PRICING_PRICES", "price of %q must be 0 or greater, got %g", key, price)
+			}
+		}
+	}
+
+	// Traffic mirroring
+	if c.Mirror.Enabled {
+		if c.Mirror.Percent <= 0 || c.Mirror.Percent > 100 {
+			v.addf("MIRROR_PERCENT", "must be between 1 and 100, got %d", c.Mirror.Percent)
+		}
+		if c.Mirror.Mode != "metadata" && c.Mirror.Mode != "full" {
+			v.addf("MIRROR_MODE", "must be metadata or full, got %q", c.Mirror.Mode)
+		}
+	}
+
+	// Provider keepalive
+	if c.ProviderKeepAlive.Enabled {
+		v.positive("PROVIDER_KEEPALIVE_INTERVAL", c.ProviderKeepAlive.Interval)
+	}
+
+	// Failure digest
+	if c.FailureDigest.Enabled {
+		v.required("FAILURE_DIGEST_CHANNEL_ID", c.FailureDigest.ChannelID)
+		v.positive("FAILURE_DIGEST_INTERVAL", c.FailureDigest.Interval)
+		v.nonNegative("FAILURE_DIGEST_MIN_FAILED", c.FailureDigest.MinFailed)
+		v.nonNegative("FAILURE_DIGEST_MIN_HELD", c.FailureDigest.MinHeld)
+		if c.FailureDigest.MinFailed == 0 && c.FailureDigest.MinHeld == 0 {
+			v.addf("FAILURE_DIGEST_MIN_FAILED", "must be greater than 0 when FAILURE_DIGEST_MIN_HELD is 0")
+		}
+	}
+
+	// Send locks
+	if c.SendLock.Enabled {
+		v.positive("SEND_LOCK_TTL", c.SendLock.TTL)
+	}
+
+	// Startup self-test
+	if c.SelfTest.Enabled {
+		v.positive("SELF_TEST_TIMEOUT", c.SelfTest.Timeout)
+	}
+
+	// Change events
+	if c.ChangeEvents.Enabled {
+		for _, webhookURL := range c.ChangeEvents.WebhookURLs {
+			v.url("CHANGE_WEBHOOK_URLS", webhookURL, "http", "https")
+		}
+		if len(c.ChangeEvents.WebhookURLs) > 0 {
+			v.positive("CHANGE_WEBHOOK_TIMEOUT", c.ChangeEvents.WebhookTimeout)
+		}
+	}
+
+	// Content adaptation
+	if c.ContentAdaptation.Enabled {
+		v.required("CONTENT_ADAPTATION_LINK_VARIABLE", c.ContentAdaptation.LinkVariable)
+	}
+
+	// Link shortening
+	if c.LinkShortening.Enabled {
+		v.url("LINK_SHORTENER_BASE_URL", c.LinkShortening.BaseURL, "http", "https")
+		switch c.LinkShortening.Provider {
+		case LinkShortenerInternal:
+		case LinkShortenerBitly:
+			v.required("LINK_SHORTENER_API_TOKEN", c.LinkShortening.APIToken)
+			v.positive("LINK_SHORTENER_TIMEOUT", c.LinkShortening.Timeout)
+		default:
+			v.addf("LINK_SHORTENER_PROVIDER", "must be one of internal, bitly, got %q", c.LinkShortening.Provider)
+		}
+	}
+
+	// Send policy
+	if c.SendPolicy.Enabled {
+		lists := [][]string{c.SendPolicy.Hooks}
+		for _, hooks := range c.SendPolicy.Tenants {
+			lists = append(lists, hooks)
+		}
+		for _, hooks := range c.SendPolicy.Channels {
+			lists = append(lists, hooks)
+		}
+		usesWebhook := false
+		for _, hooks := range lists {
+			for _, hook := range hooks {
+				if !slices.Contains(SendPolicyHooks, hook) {
+					v.addf("SEND_POLICY_HOOKS", "must name hooks among %s, got %q", strings.Join(SendPolicyHooks, ", "), hook)
+				}
+				usesWebhook = usesWebhook || hook == "webhook"
+			}
+		}
+		v.positive("SEND_POLICY_MAX_BYTES", c.SendPolicy.MaxBytes)
+		if usesWebhook {
+			v.url("SEND_POLICY_WEBHOOK_URL", c.SendPolicy.WebhookURL, "http", "https")
+			v.positive("SEND_POLICY_WEBHOOK_TIMEOUT", c.SendPolicy.WebhookTimeout)
+		}
+	}
+
+	// Attachment scan
+	if c.AttachmentScan.Enabled {
+		v.required("ATTACHMENT_SCAN_CLAMD_ADDRESS", c.AttachmentScan.Address)
+		v.positive("ATTACHMENT_SCAN_TIMEOUT", c.AttachmentScan.Timeout)
+		if !slices.Contains(AttachmentScanActions, c.AttachmentScan.DefaultAction) {
+			v.addf("ATTACHMENT_SCAN_DEFAULT_ACTION", "must be one of %s, got %q",
+				strings.Join(AttachmentScanActions, ", "), c.AttachmentScan.DefaultAction)
+		}
+	}
+
+	// Sender identity
+	if c.SenderIdentity.ConfirmationChannelID != "" {
+		v.url("SENDER_IDENTITY_BASE_URL", c.SenderIdentity.BaseURL, "http", "https")
+	}
+
+	// Scheduled sends, zero using the defaults of the job
+	v.nonNegative("SCHEDULED_SEND_POLL_INTERVAL", c.ScheduledSend.PollInterval)
+	v.nonNegative("SCHEDULED_SEND_BATCH_SIZE", c.ScheduledSend.BatchSize)
+
+	// Acknowledgment links
+	if c.Acknowledgment.Enabled {
+		v.url("ACKNOWLEDGMENT_BASE_URL", c.Acknowledgment.BaseURL, "http", "https")
+	}
+
+	// Replies, email replies needing the address they are answered to and the
+	// secret their provider posts them with
+	if c.Replies.Enabled && c.Replies.InboundAddress != "" {
+		if _, err := mail.ParseAddress(c.Replies.InboundAddress); err != nil {
+			v.addf("REPLIES_INBOUND_ADDRESS", "must be an email address, got %q", c.Replies.InboundAddress)
+		}
+		v.required("REPLIES_INBOUND_SECRET", c.Replies.InboundSecret)
+	}
+
+	// One-time verification codes, a zero length, TTL or attempts using the
+	// defaults of the OTP module
+	if c.OTP.CodeLength != 0 && (c.OTP.CodeLength < 4 || c.OTP.CodeLength > 10) {
+		v.addf("OTP_CODE_LENGTH", "must be between 4 and 10, got %d", c.OTP.CodeLength)
+	}
+	v.nonNegative("OTP_TTL", c.OTP.TTL)
+	v.nonNegative("OTP_MAX_ATTEMPTS", c.OTP.MaxAttempts)
+	v.nonNegative("OTP_RESEND_INTERVAL", c.OTP.ResendInterval)
+
+	// Retention, the content of a message being purged before it is deleted
+	if c.Retention.Enabled {
+		v.positive("RETENTION_INTERVAL", c.Retention.Interval)
+		v.retentionWindow("RETENTION_CONTENT_DAYS", "RETENTION_METADATA_DAYS",
+			c.Retention.ContentDays, c.Retention.MetadataDays)
+		for tenantID, window := range c.Retention.Tenants {
+			prefix := fmt.Sprintf("retention.tenants.%s.", tenantID)
+			v.retentionWindow(prefix+"contentDays", prefix+"metadataDays", window.ContentDays, window.MetadataDays)
+		}
+	}
+
+	// SLOs, the names labelling their metrics
+	if c.SLO.Enabled {
+		v.positive("SLO_INTERVAL", c.SLO.Interval)
+		if len(c.SLO.Objectives) == 0 {
+			v.addf("slo.objectives", "is required when SLO_ENABLED is true")
+		}
+		names := make(map[string]bool, len(c.SLO.Objectives))
+		for i, objective := range c.SLO.Objectives {
+			prefix := fmt.Sprintf("slo.objectives[%d].", i)
+			v.required(prefix+"name", objective.Name)
+			if names[objective.Name] {
+				v.addf(prefix+"name", "duplicates objective %q", objective.Name)
+			}
+			names[objective.Name] = true
+			v.positive(prefix+"threshold", objective.Threshold)
+			v.positive(prefix+"windowDays", objective.WindowDays)
+			if objective.Target <= 0 || objective.Target >= 100 {
+				v.addf(prefix+"target", "must be between 0 and 100 exclusive, got %g", objective.Target)
+			}
+		}
+	}
+
+	// PII encryption, the active key being one of the keys
+	if len(c.PII.Keys) > 0 {
+		if _, err := c.PII.Cipher(); err != nil {
+			v.addf("PII_ENCRYPTION_KEYS", "%v", err)
+		}
+	} else if c.PII.ActiveKey != "" {
+		v.addf("PII_ACTIVE_KEY", "is set without PII_ENCRYPTION_KEYS")
+	}
+
+	// Logger
+	switch c.Logger.Level {
+	case "debug", "info", "warn", "error", "dpanic", "panic", "fatal":
+	default:
+		v.addf("LOG_LEVEL", "must be one of debug, info, warn, error, dpanic, panic, fatal, got %q", c.Logger.Level)
+	}
+	if c.Logger.Format != "json" && c.Logger.Format != "console" {
+		v.addf("LOG_FORMAT", "must be json or console, got %q", c.Logger.Format)
+	}
+	for module, level := range c.Logger.Modules {
+		switch level {
+		case "debug", "info", "warn", "error", "dpanic", "panic", "fatal":
+		default:
+			v.addf("LOG_MODULE_LEVELS", "level of module %q must be one of debug, info, warn, error, dpanic, panic, fatal, got %q", module, level)
+		}
+	}
+	if len(c.Logger.Sampling.Modules) > 0 {
+		v.positive("LOG_SAMPLING_INITIAL", c.Logger.Sampling.Initial)
+		v.positive("LOG_SAMPLING_THEREAFTER", c.Logger.Sampling.Thereafter)
+	}
+
+	if len(v.errors) > 0 {
+		return v.errors
+	}
+	return nil
+}
+
+// Masked returns a copy of the configuration with its secrets masked
+func (c *Config) Masked() *Config {
+	masked := *c
+
+	if masked.Database.Password != "" {
+		masked.Database.Password = maskedValue
+	}
+	if masked.LegacySystem.Token != "" {
+		masked.LegacySystem.Token = maskedValue
+	}
+	if masked.ChangeEvents.WebhookSecret != "" {
+		masked.ChangeEvents.WebhookSecret = maskedValue
+	}
+	if masked.Replies.InboundSecret != "" {
+		masked.Replies.InboundSecret = maskedValue
+	}
+	if masked.LinkShortening.APIToken != "" {
+		masked.LinkShortening.APIToken = maskedValue
+	}
+	if masked.SendPolicy.WebhookSecret != "" {
+		masked.SendPolicy.WebhookSecret = maskedValue
+	}
+	if len(c.Database.ReplicaDSNs) > 0 {
+		masked.Database.ReplicaDSNs = make([]string, len(c.Database.ReplicaDSNs))
+		for i, dsn := range c.Database.ReplicaDSNs {
+			masked.Database.ReplicaDSNs[i] = maskDSNPassword(dsn)
+		}
+	}
+	masked.NATS.URL = maskURLPasswords(masked.NATS.URL)
+	if len(c.PII.Keys) > 0 {
+		// Keep the key IDs, they tell which keys are configured
+		masked.PII.Keys = make(map[string]string, len(c.PII.Keys))
+		for id := range c.PII.Keys {
+			masked.PII.Keys[id] = maskedValue
+		}
+	}
+	if len(c.NATS.APIKeys) > 0 {
+		// Keep the client IDs, they tell which clients are configured
+		masked.NATS.APIKeys = make(map[string]string, len(c.NATS.APIKeys))
+		i := 0
+		for _, clientID := range c.NATS.APIKeys {
+			i++
+			masked.NATS.APIKeys[fmt.Sprintf("%s%d", maskedValue, i)] = clientID
+		}
+	}
+
+	return &masked
+}
+
+// maskURLPasswords masks the passwords in a comma-separated list of URLs
+func maskURLPasswords(urls string) string {
+	servers := strings.Split(urls, ",")
+	for i, server := range servers {
+		parsed, err := url.Parse(strings.TrimSpace(server))
+		if err != nil || parsed.User == nil {
+			continue
+		}
+		if _, hasPassword := parsed.User.Password(); !hasPassword {
+			// A user without password is a token, masked like Redacted masks passwords
+			parsed.User = url.UserPassword("", "xxxxx")
+		}
+		servers[i] = parsed.Redacted()
+	}
+	return strings.Join(servers, ",")
+}
+
+// dsnPassword matches the password of a key=value DSN, e.g. of PostgreSQL or SQL Server
+var dsnPassword = regexp.MustCompile(`(?i)(password=)[^;\s]*`)
+
+// maskDSNPassword masks the password of a URL or key=value DSN
+func maskDSNPassword(dsn string) string {
+	if strings.Contains(dsn, "://") {
+		return maskURLPasswords(dsn)
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+maskedValue)
+}
+
+// Print writes the configuration as indented JSON with its secrets masked
+func (c *Config) Print(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c.Masked())
+}
@@ -12,8 +12,9 @@ import (
 	gorm_sqlite "gorm.io/driver/sqlite"
 	gorm_sqlserver "gorm.io/driver/sqlserver"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 
+	"notification/internal/domain/shared"
 	"notification/internal/infrastructure/models"
 	"notification/pkg/config"
 )
@@ -31,7 +32,9 @@ func NewGormDB(cfg *config.DatabaseConfig) (*GormDB, error) {
 
 	// Configure GORM logger
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: newQueryLogger(time.Duration(cfg.SlowQueryThreshold) * time.Millisecond),
+		// Translate driver errors (e.g. foreign key violations) into gorm's sentinel errors
+		TranslateError: true,
 	}
 
 	// Set up dialector based on database type
@@ -75,18 +78,78 @@ func NewGormDB(cfg *config.DatabaseConfig) (*GormDB, error) {
 	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
 	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(time.Duration(cfg.MaxLifetime) * time.Minute)
+	sqlDB.SetConnMaxIdleTime(time.Duration(cfg.MaxIdleTime) * time.Minute)
+	if err := registerPoolMetrics(sqlDB, cfg.DBName); err != nil {
+		return nil, fmt.Errorf("failed to register connection pool metrics: %w", err)
+	}
 
 	// Test connection
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// Route stale-tolerant reads to the read replicas
+	if len(cfg.ReplicaDSNs) > 0 {
+		if err := registerReplicas(db, cfg); err != nil {
+			return nil, fmt.Errorf("failed to register read replicas: %w", err)
+		}
+	}
+
 	return &GormDB{
 		DB:     db,
 		config: cfg,
 	}, nil
 }
 
+// registerReplicas serves reads from the read replicas when their context
+// allows stale reads (see shared.WithStaleReads) and from the primary
+// otherwise. Writes and transactions always use the primary.
+func registerReplicas(db *gorm.DB, cfg *config.DatabaseConfig) error {
+	replicas := make([]gorm.Dialector, 0, len(cfg.ReplicaDSNs))
+	for _, dsn := range cfg.ReplicaDSNs {
+		switch cfg.Type {
+		case "postgres", "postgresql":
+			replicas = append(replicas, postgres.Open(dsn))
+		case "sqlserver", "mssql":
+			replicas = append(replicas, gorm_sqlserver.Open(dsn))
+		default:
+			return fmt.Errorf("read replicas are not supported for database type: %s", cfg.Type)
+		}
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(time.Duration(cfg.MaxLifetime) * time.Minute).
+		SetConnMaxIdleTime(time.Duration(cfg.MaxIdleTime) * time.Minute)
+	if err := db.Use(resolver); err != nil {
+		return err
+	}
+
+	// dbresolver sends every read to a replica; move reads back to the
+	// primary unless their context allows stale reads
+	const name = "database:primary_reads"
+	if err := db.Callback().Query().After("gorm:db_resolver").Before("gorm:query").Register(name, usePrimaryForFreshReads); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().After("gorm:db_resolver").Before("gorm:row").Register(name, usePrimaryForFreshReads); err != nil {
+		return err
+	}
+	return db.Callback().Raw().After("gorm:db_resolver").Before("gorm:raw").Register(name, usePrimaryForFreshReads)
+}
+
+// usePrimaryForFreshReads routes the statement to the primary unless its
+// context allows stale reads. Marking the statement as a write resolves its
+// connection again.
+func usePrimaryForFreshReads(db *gorm.DB) {
+	if !shared.StaleReadsAllowed(db.Statement.Context) {
+		dbresolver.Write.ModifyStatement(db.Statement)
+	}
+}
+
 // createPostgresDialector creates a PostgreSQL dialector
 func createPostgresDialector(cfg *config.DatabaseConfig) (gorm.Dialector, error) {
 	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// queryDuration observes the duration of every query by operation
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "dntf_db_query_duration_seconds",
+		Help:    "Duration of database queries by operation.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation"})
+
+	// slowQueries counts the queries slower than the slow query threshold
+	slowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dntf_db_slow_queries_total",
+		Help: "Database queries slower than the slow query threshold by operation.",
+	}, []string{"operation"})
+)
+
+// registerPoolMetrics exposes the connection pool statistics of the
+// database. A pool registered before under the same name keeps its metrics.
+func registerPoolMetrics(sqlDB *sql.DB, name string) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(sqlDB, name))
+	var registered prometheus.AlreadyRegisteredError
+	if err != nil && !errors.As(err, &registered) {
+		return err
+	}
+	return nil
+}
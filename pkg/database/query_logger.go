@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"notification/pkg/logger"
+)
+
+// queryLogger is the GORM logger. It records the duration of every query,
+// warns about queries slower than the threshold and logs the other queries
+// at debug level, through the application logger.
+type queryLogger struct {
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// newQueryLogger creates a GORM logger warning about queries slower than
+// slowThreshold; a zero threshold disables the warnings
+func newQueryLogger(slowThreshold time.Duration) *queryLogger {
+	return &queryLogger{level: gormlogger.Info, slowThreshold: slowThreshold}
+}
+
+// LogMode returns a copy of the logger logging at the level
+func (l *queryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+// Info logs a GORM info message
+func (l *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		logger.FromContext(ctx).Info(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Warn logs a GORM warning
+func (l *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		logger.FromContext(ctx).Warn(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Error logs a GORM error
+func (l *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		logger.FromContext(ctx).Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace records and logs a finished query. Missing records are not logged
+// as errors, as the repositories report them as not found.
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	operation := queryOperation(sql)
+	queryDuration.WithLabelValues(operation).Observe(elapsed.Seconds())
+
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+	if slow {
+		slowQueries.WithLabelValues(operation).Inc()
+	}
+
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", elapsed),
+	}
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		logger.FromContext(ctx).Error("Database query failed", append(fields, zap.Error(err))...)
+	case slow && l.level >= gormlogger.Warn:
+		logger.FromContext(ctx).Warn("Slow database query", append(fields, zap.Duration("threshold", l.slowThreshold))...)
+	case l.level >= gormlogger.Info:
+		logger.FromContext(ctx).Debug("Database query", fields...)
+	}
+}
+
+// queryOperation returns the lower-cased statement keyword of the query,
+// e.g. select or insert, or other for anything else
+func queryOperation(sql string) string {
+	keyword, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	switch keyword = strings.ToLower(keyword); keyword {
+	case "select", "insert", "update", "delete":
+		return keyword
+	default:
+		return "other"
+	}
+}
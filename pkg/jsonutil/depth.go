@@ -0,0 +1,41 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrTooDeep is returned when a JSON document nests deeper than allowed
+var ErrTooDeep = errors.New("JSON document is nested too deeply")
+
+// CheckDepth checks that the JSON document does not nest objects and arrays
+// deeper than maxDepth. Malformed JSON is not reported here; it is left to
+// the decoder that consumes the document.
+func CheckDepth(data []byte, maxDepth int) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// io.EOF at the end of the document, or malformed JSON
+			return nil
+		}
+
+		delim, ok := token.(json.Delim)
+		if !ok {
+			continue
+		}
+		switch delim {
+		case '{', '[':
+			depth++
+			if depth > maxDepth {
+				return fmt.Errorf("%w: maximum depth is %d", ErrTooDeep, maxDepth)
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+}
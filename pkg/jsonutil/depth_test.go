@@ -0,0 +1,34 @@
+package jsonutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckDepth(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "flat object", data: `{"a":1,"b":"x"}`},
+		{name: "at limit", data: `{"a":{"b":[1]}}`},
+		{name: "over limit", data: `{"a":{"b":[[1]]}}`, wantErr: true},
+		{name: "brackets in strings", data: `{"a":"[[[[{{{{"}`},
+		{name: "malformed", data: `{"a":`},
+		{name: "deeply nested array", data: strings.Repeat("[", 100) + strings.Repeat("]", 100), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckDepth([]byte(tt.data), 3)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("CheckDepth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrTooDeep) {
+				t.Fatalf("CheckDepth() error = %v, want ErrTooDeep", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package lock provides locks shared by the replicas of the service, so that
+// a piece of work such as the delivery of a message is done by one of them at
+// a time. Every lock expires after its TTL, so that a holder that hangs or
+// forgets to release it cannot block the others for good.
+package lock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrNotAcquired is returned when the lock is held by someone else
+var ErrNotAcquired = errors.New("lock is held by another owner")
+
+// Locker acquires named locks
+type Locker interface {
+	// TryAcquire takes the lock named key for at most ttl without waiting,
+	// returning ErrNotAcquired when it is held elsewhere
+	TryAcquire(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock is a held lock
+type Lock interface {
+	// Release gives the lock up; releasing it again, or after it expired, does nothing
+	Release(ctx context.Context) error
+}
+
+// Acquisition results recorded by the metrics
+const (
+	resultAcquired  = "acquired"
+	resultContended = "contended"
+	resultError     = "error"
+)
+
+var (
+	// acquisitions counts the lock acquisitions by result
+	acquisitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dntf_lock_acquisitions_total",
+		Help: "Lock acquisitions by result: acquired, contended or error.",
+	}, []string{"result"})
+
+	// heldDuration observes how long the locks were held until released or expired
+	heldDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "dntf_lock_held_seconds",
+		Help:    "Duration the locks were held until released or expired.",
+		Buckets: []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300},
+	})
+
+	// expirations counts the locks released by their TTL rather than by their holder
+	expirations = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dntf_lock_expirations_total",
+		Help: "Locks released by their TTL rather than by their holder.",
+	})
+)
+
+// recordAcquisition counts the outcome of an acquisition
+func recordAcquisition(err error) {
+	switch {
+	case err == nil:
+		acquisitions.WithLabelValues(resultAcquired).Inc()
+	case errors.Is(err, ErrNotAcquired):
+		acquisitions.WithLabelValues(resultContended).Inc()
+	default:
+		acquisitions.WithLabelValues(resultError).Inc()
+	}
+}
+
+// recordRelease observes how long a lock acquired at the given time was held
+func recordRelease(acquiredAt time.Time, expired bool) {
+	heldDuration.Observe(time.Since(acquiredAt).Seconds())
+	if expired {
+		expirations.Inc()
+	}
+}
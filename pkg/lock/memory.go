@@ -0,0 +1,76 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLocker holds the locks in the process. It only keeps the goroutines
+// of one replica apart, which suffices for a single instance or a database
+// without lock leases.
+type MemoryLocker struct {
+	mu    sync.Mutex
+	held  map[string]*memoryLock
+	now   func() time.Time
+	token uint64
+}
+
+// NewMemoryLocker creates an in-process locker
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{
+		held: make(map[string]*memoryLock),
+		now:  time.Now,
+	}
+}
+
+// TryAcquire implements Locker. A lock past its TTL is taken over.
+func (l *MemoryLocker) TryAcquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if current, ok := l.held[key]; ok {
+		if now.Before(current.expiresAt) {
+			recordAcquisition(ErrNotAcquired)
+			return nil, ErrNotAcquired
+		}
+		delete(l.held, key)
+		recordRelease(current.acquiredAt, true)
+	}
+
+	l.token++
+	lock := &memoryLock{
+		locker:     l,
+		key:        key,
+		token:      l.token,
+		acquiredAt: now,
+		expiresAt:  now.Add(ttl),
+	}
+	l.held[key] = lock
+	recordAcquisition(nil)
+	return lock, nil
+}
+
+// memoryLock is a lock held in a MemoryLocker
+type memoryLock struct {
+	locker     *MemoryLocker
+	key        string
+	token      uint64
+	acquiredAt time.Time
+	expiresAt  time.Time
+}
+
+// Release implements Lock. A lock taken over after it expired stays with its new holder.
+func (m *memoryLock) Release(ctx context.Context) error {
+	m.locker.mu.Lock()
+	defer m.locker.mu.Unlock()
+
+	current, ok := m.locker.held[m.key]
+	if !ok || current.token != m.token {
+		return nil
+	}
+	delete(m.locker.held, m.key)
+	recordRelease(m.acquiredAt, !m.locker.now().Before(m.expiresAt))
+	return nil
+}
@@ -0,0 +1,42 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLocker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	locker := NewMemoryLocker()
+	locker.now = func() time.Time { return now }
+
+	first, err := locker.TryAcquire(ctx, "message:1", time.Minute)
+	require.NoError(t, err)
+
+	_, err = locker.TryAcquire(ctx, "message:1", time.Minute)
+	assert.ErrorIs(t, err, ErrNotAcquired)
+
+	// Other keys are not affected
+	other, err := locker.TryAcquire(ctx, "message:2", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, other.Release(ctx))
+
+	require.NoError(t, first.Release(ctx))
+	second, err := locker.TryAcquire(ctx, "message:1", time.Minute)
+	require.NoError(t, err)
+
+	// An expired lock is taken over, and its former holder cannot release it
+	now = now.Add(2 * time.Minute)
+	third, err := locker.TryAcquire(ctx, "message:1", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, second.Release(ctx))
+	_, err = locker.TryAcquire(ctx, "message:1", time.Minute)
+	assert.ErrorIs(t, err, ErrNotAcquired)
+
+	require.NoError(t, third.Release(ctx))
+}
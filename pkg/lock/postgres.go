@@ -0,0 +1,100 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresLocker takes leases on the rows of the lock_leases table, shared by
+// every replica using the database. A lease holds no connection while it is
+// held: taking and giving it up are one statement each, so that the lock
+// holders never starve the pool of the work they guard. A lease whose replica
+// died is taken over once expired.
+type PostgresLocker struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// NewPostgresLocker creates a locker taking leases in the given database
+func NewPostgresLocker(db *sql.DB) *PostgresLocker {
+	return &PostgresLocker{db: db, now: time.Now}
+}
+
+// TryAcquire implements Locker. Past its TTL the lock is released even when
+// its holder is still working, so the TTL must exceed the work it guards.
+func (l *PostgresLocker) TryAcquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	lock, err := l.tryAcquire(ctx, key, ttl)
+	recordAcquisition(err)
+	return lock, err
+}
+
+// tryAcquire inserts the lease, or takes an expired one over
+func (l *PostgresLocker) tryAcquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	owner := uuid.NewString()
+	now := l.now()
+	result, err := l.db.ExecContext(ctx, `INSERT INTO lock_leases (lock_key, owner, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (lock_key) DO UPDATE SET owner = excluded.owner, expires_at = excluded.expires_at
+		WHERE lock_leases.expires_at <= $4`,
+		key, owner, now.Add(ttl).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to take lock %s: %w", key, err)
+	}
+	acquired, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take lock %s: %w", key, err)
+	}
+	if acquired == 0 {
+		return nil, ErrNotAcquired
+	}
+
+	lock := &postgresLock{locker: l, key: key, owner: owner, acquiredAt: now}
+	lock.mu.Lock()
+	lock.timer = time.AfterFunc(ttl, func() {
+		lock.release(context.Background(), true)
+	})
+	lock.mu.Unlock()
+	return lock, nil
+}
+
+// postgresLock is a held lease
+type postgresLock struct {
+	mu         sync.Mutex
+	locker     *PostgresLocker
+	key        string
+	owner      string
+	acquiredAt time.Time
+	timer      *time.Timer
+	released   bool
+}
+
+// Release implements Lock
+func (p *postgresLock) Release(ctx context.Context) error {
+	return p.release(ctx, false)
+}
+
+// release deletes the lease unless another owner took it over, along with
+// the expired leases left by the replicas that died holding them
+func (p *postgresLock) release(ctx context.Context, expired bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.released {
+		return nil
+	}
+	p.released = true
+	p.timer.Stop()
+	recordRelease(p.acquiredAt, expired)
+
+	_, err := p.locker.db.ExecContext(ctx,
+		"DELETE FROM lock_leases WHERE (lock_key = $1 AND owner = $2) OR expires_at <= $3",
+		p.key, p.owner, p.locker.now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	return nil
+}
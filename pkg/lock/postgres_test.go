@@ -0,0 +1,84 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPostgresLockerHoldsNoConnection(t *testing.T) {
+	ctx := context.Background()
+	gormDB, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "locks.db")+"?_busy_timeout=5000"), &gorm.Config{})
+	require.NoError(t, err)
+	db, err := gormDB.DB()
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(2)
+	_, err = db.Exec("CREATE TABLE lock_leases (lock_key VARCHAR(255) PRIMARY KEY, owner VARCHAR(255) NOT NULL, expires_at BIGINT NOT NULL)")
+	require.NoError(t, err)
+
+	now := time.Now()
+	var clock sync.Mutex
+	locker := NewPostgresLocker(db)
+	locker.now = func() time.Time {
+		clock.Lock()
+		defer clock.Unlock()
+		return now
+	}
+
+	// More deliveries than connections hold their lock while querying
+	deliveryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			held, err := locker.TryAcquire(deliveryCtx, fmt.Sprintf("message:%d", i), time.Minute)
+			if err != nil {
+				errs <- err
+				return
+			}
+			var one int
+			if err := db.QueryRowContext(deliveryCtx, "SELECT 1").Scan(&one); err != nil {
+				errs <- err
+				return
+			}
+			errs <- held.Release(deliveryCtx)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	first, err := locker.TryAcquire(ctx, "message:1", time.Minute)
+	require.NoError(t, err)
+	_, err = locker.TryAcquire(ctx, "message:1", time.Minute)
+	assert.ErrorIs(t, err, ErrNotAcquired)
+
+	// An expired lease is taken over, and its former holder cannot release it
+	clock.Lock()
+	now = now.Add(2 * time.Minute)
+	clock.Unlock()
+	second, err := locker.TryAcquire(ctx, "message:1", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, first.Release(ctx))
+	_, err = locker.TryAcquire(ctx, "message:1", time.Minute)
+	assert.ErrorIs(t, err, ErrNotAcquired)
+
+	require.NoError(t, second.Release(ctx))
+	var leases int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM lock_leases").Scan(&leases))
+	assert.Zero(t, leases)
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"context"
+)
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// correlationIDKey is the context key for the caller supplied correlation ID
+type correlationIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ContextWithCorrelationID returns a copy of ctx carrying the correlation ID
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by ctx, or an empty string
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
+// FromContext returns the global logger annotated with the request and
+// correlation IDs carried by ctx
+func FromContext(ctx context.Context) *Logger {
+	return GetGlobalLogger().WithContext(ctx)
+}
@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels holds the level of the entries logged, which can be changed at
+// runtime: a default level, and the levels of the modules overriding it. The
+// module of a logger is its component, see WithComponent.
+type Levels struct {
+	level zap.AtomicLevel
+	// modules is replaced as a whole on every change, so that reading it
+	// takes no lock on the logging path
+	modules atomic.Pointer[map[string]zapcore.Level]
+	mu      sync.Mutex
+}
+
+// NewLevels creates levels logging the entries from a default level.
+func NewLevels(level zapcore.Level) *Levels {
+	l := &Levels{level: zap.NewAtomicLevelAt(level)}
+	l.modules.Store(&map[string]zapcore.Level{})
+	return l
+}
+
+// Enabled tells whether an entry of a module at a level is logged.
+func (l *Levels) Enabled(module string, level zapcore.Level) bool {
+	if module != "" {
+		if moduleLevel, ok := (*l.modules.Load())[module]; ok {
+			return level >= moduleLevel
+		}
+	}
+	return l.level.Enabled(level)
+}
+
+// Level gets the default level.
+func (l *Levels) Level() zapcore.Level {
+	return l.level.Level()
+}
+
+// SetLevel sets the default level.
+func (l *Levels) SetLevel(level zapcore.Level) {
+	l.level.SetLevel(level)
+}
+
+// Modules gets the levels of the modules overriding the default one.
+func (l *Levels) Modules() map[string]zapcore.Level {
+	modules := *l.modules.Load()
+	copied := make(map[string]zapcore.Level, len(modules))
+	for module, level := range modules {
+		copied[module] = level
+	}
+	return copied
+}
+
+// SetModule sets the level of a module.
+func (l *Levels) SetModule(module string, level zapcore.Level) {
+	l.update(func(modules map[string]zapcore.Level) {
+		modules[module] = level
+	})
+}
+
+// ResetModule makes a module log from the default level again.
+func (l *Levels) ResetModule(module string) {
+	l.update(func(modules map[string]zapcore.Level) {
+		delete(modules, module)
+	})
+}
+
+// update replaces the module levels with a changed copy
+func (l *Levels) update(change func(map[string]zapcore.Level)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	modules := l.Modules()
+	change(modules)
+	l.modules.Store(&modules)
+}
+
+// moduleCore filters the entries by the level of the module of the logger,
+// sampling the debug and info entries of the high-volume modules
+type moduleCore struct {
+	inner zapcore.Core
+	// sampled is inner with sampling, nil when no module is sampled
+	sampled        zapcore.Core
+	sampledModules map[string]bool
+	levels         *Levels
+	module         string
+}
+
+// Enabled tells whether the module logs at a level
+func (c *moduleCore) Enabled(level zapcore.Level) bool {
+	return c.levels.Enabled(c.module, level)
+}
+
+// With adds fields, the component field setting the module
+func (c *moduleCore) With(fields []zapcore.Field) zapcore.Core {
+	module := c.module
+	for _, field := range fields {
+		if field.Key == "component" && field.Type == zapcore.StringType {
+			module = field.String
+		}
+	}
+	with := &moduleCore{
+		inner:          c.inner.With(fields),
+		sampledModules: c.sampledModules,
+		levels:         c.levels,
+		module:         module,
+	}
+	if c.sampled != nil {
+		with.sampled = c.sampled.With(fields)
+	}
+	return with
+}
+
+// Check adds the core writing the entry when its module logs at its level
+func (c *moduleCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	if c.sampled != nil && entry.Level < zapcore.WarnLevel && c.sampledModules[c.module] {
+		return c.sampled.Check(entry, checked)
+	}
+	return c.inner.Check(entry, checked)
+}
+
+// Write writes an entry
+func (c *moduleCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.inner.Write(entry, fields)
+}
+
+// Sync flushes the entries written
+func (c *moduleCore) Sync() error {
+	return c.inner.Sync()
+}
@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -12,7 +14,8 @@ import (
 // Logger wraps zap.Logger
 type Logger struct {
 	*zap.Logger
-	sugar *zap.SugaredLogger
+	sugar  *zap.SugaredLogger
+	levels *Levels
 }
 
 // NewLogger creates a new logger instance
@@ -28,11 +31,17 @@ func NewLogger(cfg *config.LoggerConfig) (*Logger, error) {
 		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
-	// Configure log level
+	// Configure log level, and the levels of the modules overriding it
 	level, err := zapcore.ParseLevel(cfg.Level)
 	if err != nil {
 		level = zapcore.InfoLevel
 	}
+	levels := NewLevels(level)
+	for module, moduleLevel := range cfg.Modules {
+		if parsed, err := zapcore.ParseLevel(moduleLevel); err == nil {
+			levels.SetModule(module, parsed)
+		}
+	}
 
 	// Configure output
 	var writer zapcore.WriteSyncer
@@ -54,15 +63,27 @@ func NewLogger(cfg *config.LoggerConfig) (*Logger, error) {
 		encoder = zapcore.NewJSONEncoder(encoderConfig)
 	}
 
-	// Create core
-	core := zapcore.NewCore(encoder, writer, level)
+	// Create core, writing every entry the levels let through
+	var core zapcore.Core = zapcore.NewCore(encoder, writer, zapcore.DebugLevel)
+	if cfg.Redact {
+		core = &redactCore{Core: core}
+	}
+	root := &moduleCore{inner: core, levels: levels}
+	if len(cfg.Sampling.Modules) > 0 {
+		root.sampled = zapcore.NewSamplerWithOptions(core, time.Second, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+		root.sampledModules = make(map[string]bool, len(cfg.Sampling.Modules))
+		for _, module := range cfg.Sampling.Modules {
+			root.sampledModules[module] = true
+		}
+	}
 
 	// Create logger
-	zapLogger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zapcore.ErrorLevel))
+	zapLogger := zap.New(root, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zapcore.ErrorLevel))
 
 	return &Logger{
 		Logger: zapLogger,
 		sugar:  zapLogger.Sugar(),
+		levels: levels,
 	}, nil
 }
 
@@ -71,15 +92,23 @@ func (l *Logger) Sugar() *zap.SugaredLogger {
 	return l.sugar
 }
 
+// Levels returns the levels of the logger, shared by the loggers derived
+// from it.
+func (l *Logger) Levels() *Levels {
+	return l.levels
+}
+
 // WithFields creates a new logger with additional fields
 func (l *Logger) WithFields(fields ...zap.Field) *Logger {
 	return &Logger{
 		Logger: l.Logger.With(fields...),
 		sugar:  l.Logger.With(fields...).Sugar(),
+		levels: l.levels,
 	}
 }
 
-// WithComponent creates a new logger with component field
+// WithComponent creates a new logger with component field, the component
+// being the module whose level and sampling apply to the logger
 func (l *Logger) WithComponent(component string) *Logger {
 	return l.WithFields(zap.String("component", component))
 }
@@ -89,6 +118,23 @@ func (l *Logger) WithRequestID(requestID string) *Logger {
 	return l.WithFields(zap.String("request_id", requestID))
 }
 
+// WithCorrelationID creates a new logger with correlation ID field
+func (l *Logger) WithCorrelationID(correlationID string) *Logger {
+	return l.WithFields(zap.String("correlation_id", correlationID))
+}
+
+// WithContext creates a new logger with the request and correlation IDs carried by ctx
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	log := l
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		log = log.WithRequestID(requestID)
+	}
+	if correlationID := CorrelationIDFromContext(ctx); correlationID != "" {
+		log = log.WithCorrelationID(correlationID)
+	}
+	return log
+}
+
 // LogError logs an error with additional context
 func (l *Logger) LogError(err error, msg string, fields ...zap.Field) {
 	allFields := append(fields, zap.Error(err))
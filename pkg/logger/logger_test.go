@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"notification/pkg/config"
+)
+
+func TestLoggerRedactsAndFiltersByModule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.json")
+	log, err := NewLogger(&config.LoggerConfig{
+		Level:      "info",
+		Format:     "json",
+		OutputPath: path,
+		Redact:     true,
+		Modules:    map[string]string{"retention": "warn"},
+		Sampling:   config.LogSamplingConfig{Modules: []string{"send_worker"}, Initial: 2, Thereafter: 100},
+	})
+	require.NoError(t, err)
+
+	log.Info("Message sent",
+		zap.String("recipient", "alice@example.com"),
+		zap.String("api_token", "s3cr3t"),
+		zap.Int("recipient_count", 2),
+		zap.Error(errors.New("bounced by bob@example.com")))
+
+	retention := log.WithComponent("retention")
+	retention.Info("Dropped below the module level")
+	log.Levels().SetModule("retention", zapcore.DebugLevel)
+	retention.Debug("Logged once the module level changed")
+
+	worker := log.WithComponent("send_worker")
+	for i := 0; i < 5; i++ {
+		worker.Info("Sampled entry")
+	}
+	worker.Warn("Warnings are not sampled")
+	require.NoError(t, log.Sync())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	output := string(data)
+
+	assert.NotContains(t, output, "alice@example.com")
+	assert.Contains(t, output, `"recipient":"a***@example.com"`)
+	assert.NotContains(t, output, "s3cr3t")
+	assert.Contains(t, output, `"recipient_count":2`)
+	assert.Contains(t, output, "bounced by b***@example.com")
+
+	assert.NotContains(t, output, "Dropped below the module level")
+	assert.Contains(t, output, "Logged once the module level changed")
+	assert.Equal(t, 2, strings.Count(output, "Sampled entry"))
+	assert.Contains(t, output, "Warnings are not sampled")
+}
@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// redacted replaces the values of the secret fields
+const redacted = "[REDACTED]"
+
+// secretKeys are the parts of the field keys whose values are secrets,
+// replaced as a whole
+var secretKeys = []string{"password", "passwd", "secret", "token", "apikey", "authorization", "credential", "privatekey", "cookie"}
+
+// recipientKeySuffixes end the field keys whose values are recipient
+// addresses, masked, e.g. user_email
+var recipientKeySuffixes = []string{"recipient", "recipients", "email", "emailaddress", "phone", "phonenumber"}
+
+// recipientKeys are the other field keys whose values are recipient addresses
+var recipientKeys = map[string]bool{"to": true, "from": true, "target": true, "address": true, "sender": true, "subject": true}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+[0-9][0-9 ()\-]{6,}[0-9]`)
+)
+
+// redactCore redacts the secrets and recipient addresses of the fields of
+// the entries before they are written
+type redactCore struct {
+	zapcore.Core
+}
+
+// With adds redacted fields
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(redactFields(fields))}
+}
+
+// Check adds this core, rather than the wrapped one, so that the entry is
+// written redacted
+func (c *redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write writes an entry with redacted fields
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+// redactFields returns the fields with their secrets replaced and their
+// recipient addresses masked, copied only when one is changed
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, field := range fields {
+		redactedField, changed := redactField(field)
+		if !changed {
+			if out != nil {
+				out = append(out, field)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, redactedField)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// redactField redacts a field by its key, or scrubs the addresses out of its
+// text otherwise
+func redactField(field zapcore.Field) (zapcore.Field, bool) {
+	if !holdsText(field.Type) {
+		return field, false
+	}
+	switch sensitivityOf(field.Key) {
+	case sensitiveSecret:
+		return stringField(field.Key, redacted), true
+	case sensitiveRecipient:
+		if field.Type == zapcore.StringType {
+			return stringField(field.Key, MaskTarget(field.String)), true
+		}
+		return stringField(field.Key, redacted), true
+	}
+
+	var text string
+	switch field.Type {
+	case zapcore.StringType:
+		text = field.String
+	case zapcore.ErrorType:
+		err, ok := field.Interface.(error)
+		if !ok || err == nil {
+			return field, false
+		}
+		text = err.Error()
+	default:
+		return field, false
+	}
+	scrubbed := Scrub(text)
+	if scrubbed == text {
+		return field, false
+	}
+	return stringField(field.Key, scrubbed), true
+}
+
+// holdsText tells whether a field type may hold a secret or an address,
+// numbers, booleans and times being left as they are
+func holdsText(fieldType zapcore.FieldType) bool {
+	switch fieldType {
+	case zapcore.StringType, zapcore.ByteStringType, zapcore.BinaryType, zapcore.ErrorType,
+		zapcore.StringerType, zapcore.ReflectType, zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType:
+		return true
+	}
+	return false
+}
+
+// stringField creates a string field
+func stringField(key, value string) zapcore.Field {
+	return zapcore.Field{Key: key, Type: zapcore.StringType, String: value}
+}
+
+// sensitivity is what a field key tells about its value
+type sensitivity int
+
+const (
+	sensitiveNone sensitivity = iota
+	sensitiveSecret
+	sensitiveRecipient
+)
+
+// sensitivityOf returns what a field key tells about its value, ignoring its
+// case and separators
+func sensitivityOf(key string) sensitivity {
+	normalized := strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(key))
+	for _, part := range secretKeys {
+		if strings.Contains(normalized, part) {
+			return sensitiveSecret
+		}
+	}
+	for _, suffix := range recipientKeySuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return sensitiveRecipient
+		}
+	}
+	if recipientKeys[normalized] {
+		return sensitiveRecipient
+	}
+	return sensitiveNone
+}
+
+// MaskTarget masks a recipient address, keeping the first character and the
+// domain of an email address or the last 4 characters of other addresses.
+func MaskTarget(target string) string {
+	if at := strings.LastIndex(target, "@"); at > 0 {
+		return target[:1] + "***" + target[at:]
+	}
+	if len(target) > 4 {
+		return "***" + target[len(target)-4:]
+	}
+	return "***"
+}
+
+// Scrub masks the email addresses and international phone numbers found in
+// a text.
+func Scrub(text string) string {
+	if strings.Contains(text, "@") {
+		text = emailPattern.ReplaceAllStringFunc(text, MaskTarget)
+	}
+	if strings.Contains(text, "+") {
+		text = phonePattern.ReplaceAllStringFunc(text, MaskTarget)
+	}
+	return text
+}
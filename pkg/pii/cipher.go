@@ -0,0 +1,195 @@
+// Package pii encrypts the personal data stored at rest, such as the
+// addresses and phone numbers of the recipients. Encryption is deterministic,
+// so that a value is stored the same way every time and can still be looked
+// up: an encrypted value carries a blind index of its normalized form, which
+// equal values share whatever their case.
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Prefix starts every encrypted value, followed by the key ID, the blind
+// index and the sealed value, separated by colons
+const Prefix = "pii:"
+
+// KeySize is the size in bytes of an encryption key
+const KeySize = 32
+
+// indexSize is the size in bytes of a blind index
+const indexSize = 16
+
+var encoding = base64.RawURLEncoding
+
+// key holds the keys derived from one encryption key
+type key struct {
+	aead cipher.AEAD
+	// nonceKey derives the nonce of a value, which makes the encryption
+	// deterministic without reusing a nonce for two values
+	nonceKey []byte
+	// indexKey derives the blind index of a value
+	indexKey []byte
+}
+
+// Cipher encrypts values with its active key and decrypts them with any of
+// its keys, the former keys decrypting the values not rotated yet.
+type Cipher struct {
+	activeKeyID string
+	keys        map[string]*key
+}
+
+// NewCipher creates a cipher from keys of KeySize bytes by key ID, encrypting
+// with the active one.
+func NewCipher(keys map[string][]byte, activeKeyID string) (*Cipher, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %q is not among the keys", activeKeyID)
+	}
+
+	c := &Cipher{
+		activeKeyID: activeKeyID,
+		keys:        make(map[string]*key, len(keys)),
+	}
+	for id, secret := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("key ID %q must be non-empty and hold no colon", id)
+		}
+		if len(secret) != KeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes, got %d", id, KeySize, len(secret))
+		}
+		block, err := aes.NewCipher(derive(secret, "encryption"))
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		c.keys[id] = &key{
+			aead:     aead,
+			nonceKey: derive(secret, "nonce"),
+			indexKey: derive(secret, "index"),
+		}
+	}
+	return c, nil
+}
+
+// ParseKeys decodes base64 encoded keys by key ID.
+func ParseKeys(encoded map[string]string) (map[string][]byte, error) {
+	keys := make(map[string][]byte, len(encoded))
+	for id, value := range encoded {
+		secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not base64: %w", id, err)
+		}
+		keys[id] = secret
+	}
+	return keys, nil
+}
+
+// derive returns the key for one use of a secret
+func derive(secret []byte, use string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("pii-" + use))
+	return mac.Sum(nil)
+}
+
+// ActiveKeyID gets the ID of the key values are encrypted with.
+func (c *Cipher) ActiveKeyID() string {
+	return c.activeKeyID
+}
+
+// IsEncrypted tells whether a value was encrypted by a cipher.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Encrypt encrypts a value with the active key. Empty and already encrypted
+// values are returned as they are.
+func (c *Cipher) Encrypt(value string) (string, error) {
+	if value == "" || IsEncrypted(value) {
+		return value, nil
+	}
+
+	k := c.keys[c.activeKeyID]
+	nonce := mac(k.nonceKey, value)[:k.aead.NonceSize()]
+	sealed := k.aead.Seal(nonce, nonce, []byte(value), []byte(c.activeKeyID))
+	return c.lookupPrefix(c.activeKeyID, k, value) + encoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value encrypted with any of the keys. A value that is
+// not encrypted, e.g. stored before encryption was enabled, is returned as it
+// is.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(value, Prefix), ":", 3)
+	if len(parts) != 3 {
+		return "", errors.New("malformed encrypted value")
+	}
+	k, ok := c.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("value is encrypted with unknown key %q", parts[0])
+	}
+	sealed, err := encoding.DecodeString(parts[2])
+	if err != nil || len(sealed) < k.aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	nonceSize := k.aead.NonceSize()
+	plain, err := k.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(parts[0]))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %q: %w", parts[0], err)
+	}
+	return string(plain), nil
+}
+
+// NeedsRotation tells whether a value is stored in plain text or encrypted
+// with another key than the active one.
+func (c *Cipher) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, Prefix+c.activeKeyID+":")
+}
+
+// LookupPrefixes returns the prefixes the encrypted forms of a value start
+// with under each key, the active one first, matching its values whatever
+// their case.
+func (c *Cipher) LookupPrefixes(value string) []string {
+	ids := make([]string, 0, len(c.keys))
+	for id := range c.keys {
+		if id != c.activeKeyID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	ids = append([]string{c.activeKeyID}, ids...)
+
+	prefixes := make([]string, 0, len(ids))
+	for _, id := range ids {
+		prefixes = append(prefixes, c.lookupPrefix(id, c.keys[id], value))
+	}
+	return prefixes
+}
+
+// lookupPrefix returns the key ID and blind index an encrypted value starts with
+func (c *Cipher) lookupPrefix(id string, k *key, value string) string {
+	index := mac(k.indexKey, strings.ToLower(strings.TrimSpace(value)))[:indexSize]
+	return Prefix + id + ":" + encoding.EncodeToString(index) + ":"
+}
+
+// mac returns the HMAC-SHA256 of a value
+func mac(secret []byte, value string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
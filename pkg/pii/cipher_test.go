@@ -0,0 +1,63 @@
+package pii
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipherEncryptDecrypt(t *testing.T) {
+	c, err := NewCipher(map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeySize)}, "k1")
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt("Alice@Example.com")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, strings.ToLower(encrypted), "alice")
+
+	again, err := c.Encrypt("Alice@Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, encrypted, again, "encryption is deterministic")
+
+	other, err := c.Encrypt("alice@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, other)
+	assert.True(t, strings.HasPrefix(encrypted, c.LookupPrefixes("alice@example.com")[0]))
+	assert.True(t, strings.HasPrefix(other, c.LookupPrefixes("ALICE@example.com")[0]))
+
+	plain, err := c.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice@Example.com", plain)
+	plain, err = c.Decrypt("+15551234567")
+	require.NoError(t, err)
+	assert.Equal(t, "+15551234567", plain, "plain text values are read as they are")
+}
+
+func TestCipherRotation(t *testing.T) {
+	old, err := NewCipher(map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeySize)}, "k1")
+	require.NoError(t, err)
+	encrypted, err := old.Encrypt("+15551234567")
+	require.NoError(t, err)
+
+	rotated, err := NewCipher(map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, KeySize),
+		"k2": bytes.Repeat([]byte{2}, KeySize),
+	}, "k2")
+	require.NoError(t, err)
+	assert.True(t, rotated.NeedsRotation(encrypted))
+	assert.Len(t, rotated.LookupPrefixes("+15551234567"), 2)
+
+	plain, err := rotated.Decrypt(encrypted)
+	require.NoError(t, err)
+	reencrypted, err := rotated.Encrypt(plain)
+	require.NoError(t, err)
+	assert.False(t, rotated.NeedsRotation(reencrypted))
+
+	_, err = old.Decrypt(reencrypted)
+	assert.Error(t, err)
+	_, err = NewCipher(map[string][]byte{"k1": []byte("short")}, "k1")
+	assert.Error(t, err)
+}
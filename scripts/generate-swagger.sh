@@ -23,6 +23,7 @@ swag init -g cmd/server/main.go -o docs --parseDependency --parseInternal
 echo "✅ Swagger documentation generated successfully!"
 echo "📁 Documentation files created in ./docs/"
 echo "🌐 Start the server and visit http://localhost:8080/swagger/index.html to view the API documentation"
+echo "📄 The OpenAPI 3 document is served at http://localhost:8080/api/openapi.json"
 
 # List generated files
 echo ""